@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// batchJobInfo is a batch job, as listed to admins.
+type batchJobInfo struct {
+	ID              string     `json:"id"`
+	Description     string     `json:"description,omitempty"`
+	Operation       string     `json:"operation"`
+	Status          string     `json:"status"`
+	Priority        int        `json:"priority"`
+	AccessKey       string     `json:"accessKey"`
+	CreationTime    time.Time  `json:"creationTime"`
+	TerminationDate *time.Time `json:"terminationDate,omitempty"`
+	TotalTasks      int64      `json:"totalTasks"`
+	SucceededTasks  int64      `json:"succeededTasks"`
+	FailedTasks     int64      `json:"failedTasks"`
+}
+
+// ListBatchJobsHandler - GET /minio/admin/v3/batch-jobs?status=<status>
+// ----------
+// Lists the batch jobs of all users, the newest first, optionally
+// only those with the given status.
+func (a adminAPIHandlers) ListBatchJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBatchJobs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	jobs, err := listBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status := r.Form.Get("status")
+	infos := []batchJobInfo{}
+	for _, j := range jobs {
+		d := j.Descriptor
+		if status != "" && d.Status != status {
+			continue
+		}
+		infos = append(infos, batchJobInfo{
+			ID:              d.JobID,
+			Description:     d.Description,
+			Operation:       d.Operation.Name(),
+			Status:          d.Status,
+			Priority:        d.Priority,
+			AccessKey:       j.AccessKey,
+			CreationTime:    d.CreationTime,
+			TerminationDate: d.TerminationDate,
+			TotalTasks:      d.ProgressSummary.TotalNumberOfTasks,
+			SucceededTasks:  d.ProgressSummary.NumberOfTasksSucceeded,
+			FailedTasks:     d.ProgressSummary.NumberOfTasksFailed,
+		})
+	}
+
+	data, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -157,6 +157,169 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 	writeSuccessResponseHeadersOnly(w)
 }
 
+// StageConfigKVHandler - PUT /minio/admin/v3/stage-config-kv
+// Stages set-config-kv input to be validated and applied later.
+func (a adminAPIHandlers) StageConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StageConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	stageConfigKV(ctx, w, r, false)
+}
+
+// StageDelConfigKVHandler - DELETE /minio/admin/v3/stage-config-kv
+// Stages del-config-kv input to be validated and applied later.
+func (a adminAPIHandlers) StageDelConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StageDelConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	stageConfigKV(ctx, w, r, true)
+}
+
+func stageConfigKV(ctx context.Context, w http.ResponseWriter, r *http.Request, del bool) {
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	password := cred.SecretKey
+	kvBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err, logger.Application)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	change := stagedConfigChange{
+		Delete: del,
+		KV:     string(kvBytes),
+		Time:   UTCNow(),
+	}
+	if err = stageConfigChange(ctx, objectAPI, change); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetStagedConfigKVHandler - GET /minio/admin/v3/staged-config-kv
+// Returns the staged config changes.
+func (a adminAPIHandlers) GetStagedConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetStagedConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	staged, err := readStagedConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(staged)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	password := cred.SecretKey
+	econfigData, err := madmin.EncryptData(password, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// DiscardStagedConfigKVHandler - DELETE /minio/admin/v3/staged-config-kv
+// Discards the staged config changes.
+func (a adminAPIHandlers) DiscardStagedConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DiscardStagedConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if err := deleteStagedConfig(ctx, objectAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// ValidateStagedConfigKVHandler - POST /minio/admin/v3/validate-staged-config-kv
+// Validates the server config with the staged changes on all nodes,
+// without applying it.
+func (a adminAPIHandlers) ValidateStagedConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ValidateStagedConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	result, err := validateStagedConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ApplyStagedConfigKVHandler - POST /minio/admin/v3/apply-staged-config-kv
+// Validates the staged changes on all nodes and applies them, rolling
+// back all nodes if any of them fails to apply.
+func (a adminAPIHandlers) ApplyStagedConfigKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ApplyStagedConfigKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	result, err := applyStagedConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if result.Applied && !result.RestartRequired {
+		// Tell the client that dynamic config was applied.
+		w.Header().Set(madmin.ConfigAppliedHeader, madmin.ConfigAppliedTrue)
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
 // GetConfigKVHandler - GET /minio/admin/v3/get-config-kv?key={key}
 func (a adminAPIHandlers) GetConfigKVHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetConfigKV")
@@ -309,6 +472,86 @@ func (a adminAPIHandlers) ListConfigHistoryKVHandler(w http.ResponseWriter, r *h
 	writeSuccessResponseJSON(w, econfigData)
 }
 
+// DiffConfigHistoryKVHandler - GET /minio/admin/v3/diff-config-history-kv?from={restoreId}&to={restoreId}
+// Lists the config keys changed between two config history entries, "current"
+// stands for the current server config.
+func (a adminAPIHandlers) DiffConfigHistoryKVHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DiffConfigHistoryKV")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	fromID, toID := vars["from"], vars["to"]
+	if fromID == "" || toID == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	readConfigAt := func(restoreID string) (config.Config, error) {
+		if restoreID == "current" {
+			return readServerConfig(ctx, objectAPI)
+		}
+		return readServerConfigAtHistory(ctx, objectAPI, restoreID)
+	}
+
+	fromCfg, err := readConfigAt(fromID)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	toCfg, err := readConfigAt(toID)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// The server credentials are not part of the config history.
+	delete(fromCfg, config.CredentialsSubSys)
+	delete(toCfg, config.CredentialsSubSys)
+
+	writeConfigDiff(ctx, w, r, cred.SecretKey, fromCfg.Diff(toCfg))
+}
+
+func writeConfigDiff(ctx context.Context, w http.ResponseWriter, r *http.Request, password string, diffs []config.Diff) {
+	if diffs == nil {
+		diffs = []config.Diff{}
+	}
+	data, err := json.Marshal(diffs)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	econfigData, err := madmin.EncryptData(password, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// restoreRedactedValues replaces the redacted values in cfg with the values
+// in oldCfg. Returns true if any value was redacted.
+func restoreRedactedValues(cfg, oldCfg config.Config) (redacted bool) {
+	for subSys, tgtKVS := range cfg {
+		for tgt, kvs := range tgtKVS {
+			for i := range kvs {
+				if kvs[i].Value == config.RedactedValue {
+					kvs[i].Value = oldCfg[subSys][tgt].Get(kvs[i].Key)
+					redacted = true
+				}
+			}
+		}
+	}
+	return redacted
+}
+
 // HelpConfigKVHandler - GET /minio/admin/v3/help-config-kv?subSys={subSys}&key={key}
 func (a adminAPIHandlers) HelpConfigKVHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HelpConfigKV")
@@ -367,11 +610,29 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	oldCfg, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Config exported with redacted secrets keeps the secrets of this
+	// server, the redacted values are not part of the history either.
+	if restoreRedactedValues(cfg, oldCfg) {
+		kvBytes = []byte(configKV(cfg))
+	}
+
 	if err = validateConfig(cfg); err != nil {
 		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
 		return
 	}
 
+	if _, dryRun := r.Form["dry-run"]; dryRun {
+		// Only report the changes the config would make.
+		writeConfigDiff(ctx, w, r, cred.SecretKey, oldCfg.Diff(cfg))
+		return
+	}
+
 	// Update the actual server config on disk.
 	if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -400,7 +661,23 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	cfg := globalServerConfig.Clone()
+	if _, redact := r.Form["redact"]; redact {
+		cfg = cfg.RedactSensitiveInfo()
+	}
 
+	password := cred.SecretKey
+	econfigData, err := madmin.EncryptData(password, []byte(configKV(cfg)))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// configKV returns cfg in the KV format accepted by SetConfigHandler,
+// disabled sub-systems are commented out.
+func configKV(cfg config.Config) string {
 	var s strings.Builder
 	hkvs := config.HelpSubSysMap[""]
 	var count int
@@ -445,12 +722,5 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	password := cred.SecretKey
-	econfigData, err := madmin.EncryptData(password, []byte(s.String()))
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	writeSuccessResponseJSON(w, econfigData)
+	return s.String()
 }
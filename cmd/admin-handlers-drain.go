@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// DrainHandler - POST /minio/admin/v3/drain?node={node}&cancel={bool}
+// ----------
+// Drains the node before it is shut down: it rejects new S3 requests
+// with 503 SlowDown and a Retry-After header, fails its readiness
+// probe, waits for the in-flight requests and the queued heal and
+// replication operations to complete, saves its stats and is then
+// reported as drained. With cancel the node accepts S3 requests again.
+func (a adminAPIHandlers) DrainHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Drain")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceStopAdminAction)
+	if objectAPI == nil || globalNotificationSys == nil {
+		return
+	}
+
+	node := r.Form.Get("node")
+	if node == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, errors.New("node must not be empty")), r.URL)
+		return
+	}
+	var cancel bool
+	if v := r.Form.Get("cancel"); v != "" {
+		var err error
+		if cancel, err = strconv.ParseBool(v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+			return
+		}
+	}
+
+	if err := globalNotificationSys.Drain(ctx, node, cancel); err != nil {
+		if errors.Is(err, errNodeNotFound) {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+			return
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// DrainStatusHandler - GET /minio/admin/v3/drain
+// ----------
+// Returns the drain state of all nodes, a node can be shut down once
+// it is drained.
+func (a adminAPIHandlers) DrainStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DrainStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil || globalNotificationSys == nil {
+		return
+	}
+
+	data, err := json.Marshal(globalNotificationSys.DrainStatus(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// InlineMigrationStartHandler - POST /minio/admin/v3/inline-migration/start?bucket={bucket}
+// ----------
+// Starts moving the data of small objects in or out of xl.meta to match
+// the current inline threshold, for all buckets when bucket is empty.
+// The migration runs on the node receiving the request.
+func (a adminAPIHandlers) InlineMigrationStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "InlineMigrationStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	status, err := z.startInlineMigration(ctx, r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeInlineMigrationStatus(ctx, w, r, status)
+}
+
+// InlineMigrationCancelHandler - POST /minio/admin/v3/inline-migration/cancel
+// ----------
+// Cancels the migration running on this node, objects already migrated
+// are kept.
+func (a adminAPIHandlers) InlineMigrationCancelHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "InlineMigrationCancel")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := globalInlineMigration.stop()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeInlineMigrationStatus(ctx, w, r, status)
+}
+
+// InlineMigrationStatusHandler - GET /minio/admin/v3/inline-migration/status
+// ----------
+// Returns the progress of the current or last migration started on this
+// node.
+func (a adminAPIHandlers) InlineMigrationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "InlineMigrationStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := globalInlineMigration.getStatus()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeInlineMigrationStatus(ctx, w, r, status)
+}
+
+func writeInlineMigrationStatus(ctx context.Context, w http.ResponseWriter, r *http.Request, status InlineMigrationStatus) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
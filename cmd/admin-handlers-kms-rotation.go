@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// KMSRotationStartHandler - POST /minio/admin/v3/kms/rotation/start?bucket={bucket}&rate={rate}
+// ----------
+// Starts re-wrapping the SSE-KMS object keys of a bucket with the latest
+// version of their KMS master key, at most rate objects per second.
+func (a adminAPIHandlers) KMSRotationStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRotationStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var rateLimit int
+	if v := r.Form.Get("rate"); v != "" {
+		var err error
+		if rateLimit, err = strconv.Atoi(v); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errInvalidArgument), r.URL)
+			return
+		}
+	}
+
+	meta, err := startKMSRotation(ctx, objectAPI, r.Form.Get("bucket"), rateLimit)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeKMSRotationMeta(ctx, w, r, meta)
+}
+
+// KMSRotationCancelHandler - POST /minio/admin/v3/kms/rotation/cancel?bucket={bucket}
+// ----------
+// Cancels the key rotation in progress, object keys already re-wrapped
+// are kept.
+func (a adminAPIHandlers) KMSRotationCancelHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRotationCancel")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := cancelKMSRotation(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeKMSRotationMeta(ctx, w, r, meta)
+}
+
+// KMSRotationStatusHandler - GET /minio/admin/v3/kms/rotation/status?bucket={bucket}
+// ----------
+// Returns the progress of the current or last key rotation of a bucket.
+func (a adminAPIHandlers) KMSRotationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRotationStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := loadKMSRotationMeta(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			err = errKMSRotationNotStarted
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeKMSRotationMeta(ctx, w, r, meta)
+}
+
+func writeKMSRotationMeta(ctx context.Context, w http.ResponseWriter, r *http.Request, meta *kmsRotationMeta) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// ObjectLockAuditStartHandler - POST /minio/admin/v3/object-lock-audit/start?bucket={bucket}&target={target}&prefix={prefix}
+// ----------
+// Starts an audit of the retention and legal hold of all object versions
+// of a bucket with object lock, the signed report is written under
+// prefix in the target bucket.
+func (a adminAPIHandlers) ObjectLockAuditStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ObjectLockAuditStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := startObjectLockAudit(ctx, objectAPI, r.Form.Get("bucket"), r.Form.Get("target"), r.Form.Get("prefix"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeObjectLockAuditMeta(ctx, w, r, meta)
+}
+
+// ObjectLockAuditStatusHandler - GET /minio/admin/v3/object-lock-audit/status?bucket={bucket}
+// ----------
+// Returns the progress of the current or last audit of a bucket.
+func (a adminAPIHandlers) ObjectLockAuditStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ObjectLockAuditStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := loadObjectLockAuditMeta(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			err = errObjectLockAuditNotStarted
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeObjectLockAuditMeta(ctx, w, r, meta)
+}
+
+func writeObjectLockAuditMeta(ctx context.Context, w http.ResponseWriter, r *http.Request, meta *objectLockAuditMeta) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
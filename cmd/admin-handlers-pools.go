@@ -0,0 +1,210 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// PoolStatus is the state of a server pool.
+type PoolStatus struct {
+	Index     int    `json:"index"`
+	CmdLine   string `json:"cmdLine"`
+	Suspended bool   `json:"suspended"`
+}
+
+// PoolDecommissionStatus is the state of the pools along with the
+// progress of the current or last decommission.
+type PoolDecommissionStatus struct {
+	Pools        []PoolStatus      `json:"pools"`
+	Decommission *decommissionMeta `json:"decommission,omitempty"`
+	// Removable is set once the decommissioned pool can be removed from
+	// the command line of all servers.
+	Removable bool `json:"removable"`
+}
+
+// PoolAddResult is the outcome of adding a pool to the running servers.
+type PoolAddResult struct {
+	Pool PoolStatus `json:"pool"`
+}
+
+// decommissionObjectLayer returns the object layer of a multi pool
+// erasure setup, writes an error response and returns nil otherwise.
+func decommissionObjectLayer(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) *erasureServerPools {
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok || z.SinglePool() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errDecommissionSinglePool), r.URL)
+		return nil
+	}
+	return z
+}
+
+// AddPoolHandler - POST /minio/admin/v3/pools/add?pool={pool}
+// ----------
+// Adds the pool, given as an ellipses pattern like on the command line, to
+// all running servers. The drives of the pool are formatted by this server
+// and new objects are placed on the pool once all servers added it, the
+// pool is removed again otherwise.
+func (a adminAPIHandlers) AddPoolHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddPool")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	ep, err := newPoolEndpoints(r.Form.Get("pool"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	idx, err := z.expand(ctx, ep)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	result := PoolAddResult{
+		Pool: PoolStatus{Index: idx, CmdLine: ep.CmdLine},
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// StartDecommissionHandler - POST /minio/admin/v3/pools/decommission?pool={pool}
+// ----------
+// Suspends the pool, given by its command line or index, and moves all its
+// objects to the other pools in the background.
+func (a adminAPIHandlers) StartDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := decommissionObjectLayer(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	idx, err := z.lookupPool(r.Form.Get("pool"))
+	if err == nil {
+		_, err = z.startDecommission(ctx, idx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	writeDecommissionStatus(ctx, w, r, z)
+}
+
+// CancelDecommissionHandler - POST /minio/admin/v3/pools/cancel?pool={pool}
+// ----------
+// Cancels the decommission of the pool, which accepts new objects again.
+// Objects already moved stay on the other pools.
+func (a adminAPIHandlers) CancelDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := decommissionObjectLayer(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	idx, err := z.lookupPool(r.Form.Get("pool"))
+	if err == nil {
+		_, err = z.cancelDecommission(ctx, idx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	writeDecommissionStatus(ctx, w, r, z)
+}
+
+// DecommissionStatusHandler - GET /minio/admin/v3/pools/status
+// ----------
+// Returns the state of the pools and the per bucket progress of the
+// current or last decommission.
+func (a adminAPIHandlers) DecommissionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DecommissionStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := decommissionObjectLayer(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	writeDecommissionStatus(ctx, w, r, z)
+}
+
+func writeDecommissionStatus(ctx context.Context, w http.ResponseWriter, r *http.Request, z *erasureServerPools) {
+	meta, err := loadDecommissionMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status := PoolDecommissionStatus{
+		Decommission: meta,
+		Removable:    meta != nil && meta.removable() && z.poolIndex(meta.CmdLine) >= 0,
+	}
+	for i, cmdLine := range z.getPoolCmdLines() {
+		status.Pools = append(status.Pools, PoolStatus{
+			Index:     i,
+			CmdLine:   cmdLine,
+			Suspended: z.IsSuspended(i),
+		})
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// rebalanceObjectLayer returns the object layer of a multi pool erasure
+// setup, writes an error response and returns nil otherwise.
+func rebalanceObjectLayer(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) *erasureServerPools {
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok || z.SinglePool() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errRebalanceSinglePool), r.URL)
+		return nil
+	}
+	return z
+}
+
+// RebalanceStartHandler - POST /minio/admin/v3/rebalance/start
+// ----------
+// Starts moving objects from the pools above the cluster wide average
+// usage to the other pools, returns the initial rebalance progress.
+func (a adminAPIHandlers) RebalanceStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := rebalanceObjectLayer(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	meta, err := z.startRebalance(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeRebalanceMeta(ctx, w, r, meta)
+}
+
+// RebalancePauseHandler - POST /minio/admin/v3/rebalance/pause
+// ----------
+// Pauses the rebalance in progress, objects being moved are completed.
+func (a adminAPIHandlers) RebalancePauseHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalancePause")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := rebalanceObjectLayer(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	meta, err := z.setRebalancePaused(ctx, true)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeRebalanceMeta(ctx, w, r, meta)
+}
+
+// RebalanceResumeHandler - POST /minio/admin/v3/rebalance/resume
+// ----------
+// Resumes a paused rebalance from the bucket it was stopped at.
+func (a adminAPIHandlers) RebalanceResumeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceResume")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := rebalanceObjectLayer(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if z == nil {
+		return
+	}
+
+	meta, err := z.setRebalancePaused(ctx, false)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeRebalanceMeta(ctx, w, r, meta)
+}
+
+// RebalanceStatusHandler - GET /minio/admin/v3/rebalance/status
+// ----------
+// Returns the per pool progress of the current or last rebalance.
+func (a adminAPIHandlers) RebalanceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := rebalanceObjectLayer(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	meta, err := loadRebalanceMeta(ctx, z)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			err = errRebalanceNotStarted
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeRebalanceMeta(ctx, w, r, meta)
+}
+
+func writeRebalanceMeta(ctx context.Context, w http.ResponseWriter, r *http.Request, meta *rebalanceMeta) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
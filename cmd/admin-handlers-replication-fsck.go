@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// ReplicationFsckStartHandler - POST /minio/admin/v3/replication-fsck/start?bucket={bucket}&target={target}&prefix={prefix}&sample={sample}&interval={interval}
+// ----------
+// Starts a consistency check of the object versions of a replicated
+// bucket against its remote targets, checking a sample of the versions
+// when sample is below 1 and repeating the check every interval if set.
+// The differences found are written under prefix in the target bucket.
+func (a adminAPIHandlers) ReplicationFsckStartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationFsckStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	sample := 1.0
+	if s := r.Form.Get("sample"); s != "" {
+		var err error
+		if sample, err = strconv.ParseFloat(s, 64); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errReplicationFsckInvalidSample), r.URL)
+			return
+		}
+	}
+	var interval time.Duration
+	if s := r.Form.Get("interval"); s != "" {
+		var err error
+		if interval, err = time.ParseDuration(s); err != nil || interval < 0 {
+			if err == nil {
+				err = errors.New("interval must not be negative")
+			}
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+			return
+		}
+	}
+
+	meta, err := startReplicationFsck(ctx, objectAPI, r.Form.Get("bucket"), r.Form.Get("target"), r.Form.Get("prefix"), sample, interval)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeReplicationFsckMeta(ctx, w, r, meta)
+}
+
+// ReplicationFsckStatusHandler - GET /minio/admin/v3/replication-fsck/status?bucket={bucket}
+// ----------
+// Returns the progress of the current or last replication consistency
+// check of a bucket.
+func (a adminAPIHandlers) ReplicationFsckStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationFsckStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := loadReplicationFsckMeta(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			err = errReplicationFsckNotStarted
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeReplicationFsckMeta(ctx, w, r, meta)
+}
+
+func writeReplicationFsckMeta(ctx context.Context, w http.ResponseWriter, r *http.Request, meta *replicationFsckMeta) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -199,7 +199,7 @@ func (a adminAPIHandlers) SRInternalReplicateBucketItem(w http.ResponseWriter, r
 		return
 	}
 
-	var item madmin.SRBucketMeta
+	var item srBucketMeta
 	errCode := readJSONBody(ctx, r.Body, &item, "")
 	if errCode != ErrNone {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
@@ -224,6 +224,10 @@ func (a adminAPIHandlers) SRInternalReplicateBucketItem(w http.ResponseWriter, r
 		err = globalSiteReplicationSys.PeerBucketObjectLockConfigHandler(ctx, item.Bucket, item.ObjectLockConfig)
 	case madmin.SRBucketMetaTypeSSEConfig:
 		err = globalSiteReplicationSys.PeerBucketSSEConfigHandler(ctx, item.Bucket, item.SSEConfig)
+	case srBucketMetaTypeNotificationConfig:
+		err = globalSiteReplicationSys.PeerBucketNotificationConfigHandler(ctx, item.Bucket, item.NotificationConfig)
+	case srBucketMetaTypeLifecycleConfig:
+		err = globalSiteReplicationSys.PeerBucketLifecycleConfigHandler(ctx, item.Bucket, item.LifecycleConfig)
 
 	default:
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
@@ -271,6 +275,54 @@ func (a adminAPIHandlers) SiteReplicationInfo(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// SiteReplicationStatus - GET /minio/admin/v3/site-replication/status?bucket=
+//
+// Compares the checksums of the replicated bucket configs of all sites.
+func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.GetStatus(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(info); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRInternalBucketChecksums - GET /minio/admin/v3/site-replication/peer/bucket-checksums?bucket=
+func (a adminAPIHandlers) SRInternalBucketChecksums(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRInternalBucketChecksums")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	checksums, err := getBucketConfigChecksums(ctx, objectAPI, r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(checksums); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
 func (a adminAPIHandlers) SRInternalGetIDPSettings(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SiteReplicationGetIDPSettings")
 
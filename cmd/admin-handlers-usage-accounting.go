@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// UsageAccountingHandler - GET /minio/admin/v3/usage-accounting?user={user}
+// ----------
+// Returns the number of requests and bytes transferred by access key and
+// by user for each S3 API, counted on all nodes since the accounting
+// started. With user only the usage of the user, its service accounts
+// and temporary credentials is returned.
+func (a adminAPIHandlers) UsageAccountingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UsageAccounting")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	s := globalNotificationSys.UsageAccounting(ctx)
+	if user := r.Form.Get("user"); user != "" {
+		for accessKey, u := range s.AccessKeys {
+			if accessKey != user && u.User != user {
+				delete(s.AccessKeys, accessKey)
+			}
+		}
+	}
+
+	data, err := json.Marshal(newUsageAccountingReport(s))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
@@ -454,6 +455,31 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// addServiceAccountReq - madmin.AddServiceAccountReq with the optional
+// expiration and source IP restrictions of the service account.
+type addServiceAccountReq struct {
+	madmin.AddServiceAccountReq
+	Expiration *time.Time `json:"expiration,omitempty"`
+	SourceIPs  []string   `json:"sourceIPs,omitempty"`
+}
+
+// updateServiceAccountReq - madmin.UpdateServiceAccountReq with the
+// expiration and source IP restrictions to replace, a zero expiration
+// or an empty list of source IPs removes the restriction.
+type updateServiceAccountReq struct {
+	madmin.UpdateServiceAccountReq
+	NewExpiration *time.Time `json:"newExpiration,omitempty"`
+	NewSourceIPs  []string   `json:"newSourceIPs"`
+}
+
+// infoServiceAccountResp - madmin.InfoServiceAccountResp with the
+// expiration and source IP restrictions of the service account.
+type infoServiceAccountResp struct {
+	madmin.InfoServiceAccountResp
+	Expiration *time.Time `json:"expiration,omitempty"`
+	SourceIPs  []string   `json:"sourceIPs,omitempty"`
+}
+
 // AddServiceAccount - PUT /minio/admin/v3/add-service-account
 func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddServiceAccount")
@@ -480,7 +506,7 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var createReq madmin.AddServiceAccountReq
+	var createReq addServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &createReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
@@ -501,8 +527,12 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 	opts := newServiceAccountOpts{
 		accessKey: createReq.AccessKey,
 		secretKey: createReq.SecretKey,
+		sourceIPs: createReq.SourceIPs,
 		claims:    make(map[string]interface{}),
 	}
+	if createReq.Expiration != nil {
+		opts.expiration = *createReq.Expiration
+	}
 
 	// Find the user for the request sender (as it may be sent via a service
 	// account or STS account):
@@ -555,7 +585,8 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		// In case of LDAP/OIDC we need to set `opts.claims` to ensure
 		// it is associated with the LDAP/OIDC user properly.
 		for k, v := range cred.Claims {
-			if k == expClaim {
+			switch k {
+			case expClaim, svcAccExpirationClaim, svcAccSourceIPsClaim:
 				continue
 			}
 			opts.claims[k] = v
@@ -716,7 +747,7 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var updateReq madmin.UpdateServiceAccountReq
+	var updateReq updateServiceAccountReq
 	if err = json.Unmarshal(reqBytes, &updateReq); err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
 		return
@@ -734,6 +765,8 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		secretKey:     updateReq.NewSecretKey,
 		status:        updateReq.NewStatus,
 		sessionPolicy: sp,
+		expiration:    updateReq.NewExpiration,
+		sourceIPs:     updateReq.NewSourceIPs,
 	}
 	err = globalIAMSys.UpdateServiceAccount(ctx, accessKey, opts)
 	if err != nil {
@@ -839,11 +872,25 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var infoResp = madmin.InfoServiceAccountResp{
-		ParentUser:    svcAccount.ParentUser,
-		AccountStatus: svcAccount.Status,
-		ImpliedPolicy: impliedPolicy,
-		Policy:        string(policyJSON),
+	var infoResp = infoServiceAccountResp{
+		InfoServiceAccountResp: madmin.InfoServiceAccountResp{
+			ParentUser:    svcAccount.ParentUser,
+			AccountStatus: svcAccount.Status,
+			ImpliedPolicy: impliedPolicy,
+			Policy:        string(policyJSON),
+		},
+	}
+
+	expiration, networks, err := globalIAMSys.GetServiceAccountRestrictions(ctx, accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if !expiration.IsZero() {
+		infoResp.Expiration = &expiration
+	}
+	for _, network := range networks {
+		infoResp.SourceIPs = append(infoResp.SourceIPs, network.String())
 	}
 
 	data, err := json.Marshal(infoResp)
@@ -1178,7 +1225,10 @@ func (a adminAPIHandlers) AccountInfoHandler(w http.ResponseWriter, r *http.Requ
 			}
 
 			lcfg, _ := globalBucketObjectLockSys.Get(bucket.Name)
-			quota, _ := globalBucketQuotaSys.Get(bucket.Name)
+			var quota *madmin.BucketQuota
+			if q, _ := globalBucketQuotaSys.Get(bucket.Name); q != nil {
+				quota = &q.BucketQuota
+			}
 			rcfg, _ := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket.Name)
 			tcfg, _ := globalBucketMetadataSys.GetTaggingConfig(bucket.Name)
 
@@ -1440,3 +1490,216 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 		return
 	}
 }
+
+// PermissionsBoundary - the permissions boundary attached to a user,
+// service account or role ARN.
+type PermissionsBoundary struct {
+	AccessKey  string `json:"accessKey"`
+	PolicyName string `json:"policyName"`
+}
+
+// SetPermissionsBoundary - PUT /minio/admin/v3/set-permissions-boundary?accessKey=<access_key>&policyName=<policy_name>
+func (a adminAPIHandlers) SetPermissionsBoundary(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetPermissionsBoundary")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AttachPolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	accessKey := vars["accessKey"]
+	policyName := vars["policyName"]
+
+	// Users may not lift the permissions boundary restricting
+	// their own credentials.
+	if accessKey == cred.AccessKey || (cred.ParentUser != "" && accessKey == cred.ParentUser) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	if err := globalIAMSys.SetPermissionsBoundary(ctx, accessKey, policyName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// GetPermissionsBoundary - GET /minio/admin/v3/permissions-boundary?accessKey=<access_key>
+func (a adminAPIHandlers) GetPermissionsBoundary(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetPermissionsBoundary")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetUserAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+	policyName, err := globalIAMSys.GetPermissionsBoundary(accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(PermissionsBoundary{
+		AccessKey:  accessKey,
+		PolicyName: policyName,
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// IAM import archives larger than this are rejected.
+const maxIAMImportSize = 100 << 20 // 100 MiB
+
+// ExportIAMHandler - GET /minio/admin/v3/export-iam
+// Returns all long-term IAM state as a zip archive, encrypted with the
+// secret key of the requester as it contains the secret keys of all
+// users and service accounts.
+func (a adminAPIHandlers) ExportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	// Exporting secrets requires all admin permissions.
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AllAdminActions)
+	if objectAPI == nil {
+		return
+	}
+
+	e, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = writeIAMExportArchive(&buf, e); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	econfigData, err := madmin.EncryptData(cred.SecretKey, buf.Bytes())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// ImportIAMHandler - PUT /minio/admin/v3/import-iam?conflict={skip|overwrite|fail}[&dry-run]
+// Imports an archive returned by ExportIAMHandler, encrypted with the
+// secret key of the requester. Entries that differ from existing ones are
+// kept (skip), replaced (overwrite) or abort the import before any change
+// is made (fail). With dry-run only the conflicts are reported.
+func (a adminAPIHandlers) ImportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AllAdminActions)
+	if objectAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxIAMImportSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	onConflict := r.Form.Get("conflict")
+	if onConflict == "" {
+		onConflict = iamImportSkip
+	}
+	_, dryRun := r.Form["dry-run"]
+
+	data, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	e, err := readIAMExportArchive(data)
+	if err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
+		return
+	}
+
+	result, importErr := globalIAMSys.ImportIAM(ctx, e, onConflict, dryRun)
+	if importErr != nil && !errors.Is(importErr, errIAMImportConflict) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, importErr), r.URL)
+		return
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if importErr != nil {
+		// Nothing was imported, report the conflicting entries.
+		writeResponse(w, http.StatusConflict, data, mimeJSON)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// SimulatePolicy - POST /minio/admin/v3/simulate-policy
+// ----------
+// Evaluates the request in the body against the IAM and bucket policies
+// and returns whether it is allowed along with the matched statements.
+func (a adminAPIHandlers) SimulatePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SimulatePolicy")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Error out if Content-Length is missing.
+	if r.ContentLength <= 0 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
+		return
+	}
+
+	// Error out if Content-Length is beyond allowed size.
+	if r.ContentLength > maxBucketPolicySize {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	var req policySimulationRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+	if err := req.validate(); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+
+	res, err := simulatePolicy(ctx, req)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -31,6 +31,7 @@ import (
 	cr "github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
+	iampolicy "github.com/minio/pkg/iam/policy"
 )
 
 const (
@@ -178,11 +179,14 @@ func TestIAMInternalIDPServerSuite(t *testing.T) {
 				suite.SetUpSuite(c)
 				suite.TestUserCreate(c)
 				suite.TestPolicyCreate(c)
+				suite.TestExistingObjectTagPolicy(c)
 				suite.TestCannedPolicies(c)
 				suite.TestGroupAddRemove(c)
 				suite.TestServiceAccountOpsByAdmin(c)
 				suite.TestServiceAccountOpsByUser(c)
 				suite.TestAddServiceAccountPerms(c)
+				suite.TestPermissionsBoundary(c)
+				suite.TestPolicySimulation(c)
 				suite.TearDownSuite(c)
 			},
 		)
@@ -462,6 +466,85 @@ func (s *TestSuiteIAM) TestPolicyCreate(c *check) {
 	}
 }
 
+func (s *TestSuiteIAM) TestExistingObjectTagPolicy(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket creat error: %v", err)
+	}
+	for object, security := range map[string]string{"public-object": "public", "private-object": "private"} {
+		_, err = s.client.PutObject(ctx, bucket, object, strings.NewReader("a"), 1, minio.PutObjectOptions{
+			UserTags: map[string]string{"security": security},
+		})
+		if err != nil {
+			c.Fatalf("Unable to put object: %v", err)
+		}
+	}
+
+	// 1. The tags of existing objects are not a condition of new objects.
+	policy := "mytagpolicy"
+	err = s.adm.AddCannedPolicy(ctx, policy, []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:PutObject"],
+   "Resource": ["arn:aws:s3:::%s/*"],
+   "Condition": {"StringEquals": {"s3:ExistingObjectTag/security": ["public"]}}
+  }
+ ]
+}`, bucket)))
+	if err == nil {
+		c.Fatalf("policy with an unsupported condition key for the action was added")
+	}
+
+	// 2. Allow reading objects tagged as public.
+	err = s.adm.AddCannedPolicy(ctx, policy, []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:GetObject", "s3:GetObjectTagging"],
+   "Resource": ["arn:aws:s3:::%s/*"],
+   "Condition": {"StringEquals": {"s3:ExistingObjectTag/security": ["public"]}}
+  }
+ ]
+}`, bucket)))
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	// 3. Only the object tagged as public can be read by the user.
+	uClient := s.getUserClient(c, accessKey, secretKey, "")
+	if _, err = uClient.StatObject(ctx, bucket, "public-object", minio.StatObjectOptions{}); err != nil {
+		c.Fatalf("Unable to read the public object: %v", err)
+	}
+	if _, err = uClient.GetObjectTagging(ctx, bucket, "public-object", minio.GetObjectTaggingOptions{}); err != nil {
+		c.Fatalf("Unable to read the tags of the public object: %v", err)
+	}
+	_, err = uClient.StatObject(ctx, bucket, "private-object", minio.StatObjectOptions{})
+	if minio.ToErrorResponse(err).Code != "AccessDenied" {
+		c.Fatalf("Expected the private object to be denied, got: %v", err)
+	}
+	_, err = uClient.GetObjectTagging(ctx, bucket, "private-object", minio.GetObjectTaggingOptions{})
+	if minio.ToErrorResponse(err).Code != "AccessDenied" {
+		c.Fatalf("Expected the tags of the private object to be denied, got: %v", err)
+	}
+}
+
 func (s *TestSuiteIAM) TestCannedPolicies(c *check) {
 	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
 	defer cancel()
@@ -831,6 +914,244 @@ func (s *TestSuiteIAM) TestServiceAccountOpsByAdmin(c *check) {
 	c.assertSvcAccDeletion(ctx, s, s.adm, accessKey, bucket)
 }
 
+func (s *TestSuiteIAM) TestPermissionsBoundary(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	// 1. Create a boundary policy that only allows reading the bucket.
+	boundary := "boundarypolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:GetObject",
+    "s3:ListBucket"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/*"
+   ]
+  }
+ ]
+}`, bucket))
+	err = s.adm.AddCannedPolicy(ctx, boundary, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	// 2. Create a user with full access to S3.
+	policy := "fullaccesspolicy"
+	err = s.adm.AddCannedPolicy(ctx, policy, []byte(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:*"
+   ],
+   "Resource": [
+    "arn:aws:s3:::*"
+   ]
+  }
+ ]
+}`))
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+	uClient := s.getUserClient(c, accessKey, secretKey, "")
+	err = uClient.MakeBucket(ctx, getRandomBucketName(), minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("user could not create bucket: %v", err)
+	}
+
+	// 3. Attach the boundary, the user may only read the bucket now.
+	if err = globalIAMSys.SetPermissionsBoundary(ctx, accessKey, "nosuchpolicy"); err == nil {
+		c.Fatalf("boundary with a missing policy was set")
+	}
+	if err = globalIAMSys.SetPermissionsBoundary(ctx, accessKey, boundary); err != nil {
+		c.Fatalf("Unable to set permissions boundary: %v", err)
+	}
+	c.mustListObjects(ctx, uClient, bucket)
+	err = uClient.MakeBucket(ctx, getRandomBucketName(), minio.MakeBucketOptions{})
+	if err == nil {
+		c.Fatalf("user could exceed the permissions boundary")
+	}
+
+	// 4. Service accounts of the user are restricted as well.
+	userAdmClient, err := madmin.NewWithOptions(s.endpoint, &madmin.Options{
+		Creds:  cr.NewStaticV4(accessKey, secretKey, ""),
+		Secure: s.secure,
+	})
+	if err != nil {
+		c.Fatalf("Err creating user admin client: %v", err)
+	}
+	userAdmClient.SetCustomTransport(s.TestSuiteCommon.client.Transport)
+	svcCred := c.mustCreateSvcAccount(ctx, accessKey, userAdmClient)
+	svcClient := s.getUserClient(c, svcCred.AccessKey, svcCred.SecretKey, "")
+	c.mustListObjects(ctx, svcClient, bucket)
+	err = svcClient.MakeBucket(ctx, getRandomBucketName(), minio.MakeBucketOptions{})
+	if err == nil {
+		c.Fatalf("service account could exceed the permissions boundary of its parent")
+	}
+
+	// 5. The boundary policy cannot be deleted while attached.
+	err = s.adm.RemoveCannedPolicy(ctx, boundary)
+	if err == nil {
+		c.Fatalf("policy could be unexpectedly deleted!")
+	}
+
+	// 6. Removing the boundary restores the permissions of the user.
+	if err = globalIAMSys.SetPermissionsBoundary(ctx, accessKey, ""); err != nil {
+		c.Fatalf("Unable to remove permissions boundary: %v", err)
+	}
+	err = uClient.MakeBucket(ctx, getRandomBucketName(), minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("user could not create bucket: %v", err)
+	}
+
+	err = s.adm.RemoveUser(ctx, accessKey)
+	if err != nil {
+		c.Fatalf("user could not be deleted: %v", err)
+	}
+	for _, p := range []string{boundary, policy} {
+		if err = s.adm.RemoveCannedPolicy(ctx, p); err != nil {
+			c.Fatalf("policy del err: %v", err)
+		}
+	}
+}
+
+func (s *TestSuiteIAM) TestPolicySimulation(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	policy := "readonlysimulated"
+	err = s.adm.AddCannedPolicy(ctx, policy, []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/*"]
+  }
+ ]
+}`, bucket)))
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	// 1. The user may read objects, the statement of its policy matches.
+	res, err := simulatePolicy(ctx, policySimulationRequest{
+		AccessKey: accessKey,
+		Action:    string(iampolicy.GetObjectAction),
+		Bucket:    bucket,
+		Object:    "object",
+	})
+	if err != nil {
+		c.Fatalf("Unable to simulate: %v", err)
+	}
+	if !res.Allowed || len(res.MatchedStatements) != 1 || res.MatchedStatements[0].Policy != policy {
+		c.Fatalf("Unexpected simulation result %#v", res)
+	}
+
+	// 2. The user may not write objects, no statement matches.
+	res, err = simulatePolicy(ctx, policySimulationRequest{
+		AccessKey: accessKey,
+		Action:    string(iampolicy.PutObjectAction),
+		Bucket:    bucket,
+		Object:    "object",
+	})
+	if err != nil {
+		c.Fatalf("Unable to simulate: %v", err)
+	}
+	if res.Allowed || len(res.MatchedStatements) != 0 {
+		c.Fatalf("Unexpected simulation result %#v", res)
+	}
+
+	// 3. Anonymous requests are evaluated against the bucket policy.
+	err = s.client.SetBucketPolicy(ctx, bucket, fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Principal": {"AWS": ["*"]},
+   "Action": ["s3:GetObject"],
+   "Resource": ["arn:aws:s3:::%s/public/*"]
+  }
+ ]
+}`, bucket))
+	if err != nil {
+		c.Fatalf("Unable to set bucket policy: %v", err)
+	}
+	for object, matched := range map[string]int{"public/object": 1, "private/object": 0} {
+		res, err = simulatePolicy(ctx, policySimulationRequest{
+			Action: string(iampolicy.GetObjectAction),
+			Bucket: bucket,
+			Object: object,
+		})
+		if err != nil {
+			c.Fatalf("Unable to simulate: %v", err)
+		}
+		if res.Allowed != (matched > 0) || len(res.MatchedStatements) != matched {
+			c.Fatalf("Unexpected simulation result for %s %#v", object, res)
+		}
+	}
+
+	// 4. Unknown users and actions are rejected.
+	if _, err = simulatePolicy(ctx, policySimulationRequest{
+		AccessKey: "nosuchuser",
+		Action:    string(iampolicy.GetObjectAction),
+		Bucket:    bucket,
+	}); err == nil {
+		c.Fatalf("Expected simulation of an unknown user to fail")
+	}
+	if _, err = simulatePolicy(ctx, policySimulationRequest{
+		AccessKey: accessKey,
+		Action:    "s3:NoSuchAction",
+		Bucket:    bucket,
+	}); err == nil {
+		c.Fatalf("Expected simulation of an unknown action to fail")
+	}
+
+	err = s.adm.RemoveUser(ctx, accessKey)
+	if err != nil {
+		c.Fatalf("user could not be deleted: %v", err)
+	}
+	if err = s.adm.RemoveCannedPolicy(ctx, policy); err != nil {
+		c.Fatalf("policy del err: %v", err)
+	}
+}
+
 func (c *check) mustCreateSvcAccount(ctx context.Context, tgtUser string, admClnt *madmin.AdminClient) madmin.Credentials {
 	cr, err := admClnt.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
 		TargetUser: tgtUser,
@@ -23,6 +23,7 @@ import (
 	"crypto/subtle"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -43,6 +44,7 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/dsync"
+	"github.com/minio/minio/internal/event/target"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
@@ -64,6 +66,9 @@ const (
 	mgmtClientToken = "clientToken"
 	mgmtForceStart  = "forceStart"
 	mgmtForceStop   = "forceStop"
+
+	mgmtModifiedAfter  = "modifiedAfter"
+	mgmtModifiedBefore = "modifiedBefore"
 )
 
 func updateServer(u *url.URL, sha256Sum []byte, lrTime time.Time, releaseInfo string, mode string) (us madmin.ServerUpdateStatus, err error) {
@@ -76,6 +81,40 @@ func updateServer(u *url.URL, sha256Sum []byte, lrTime time.Time, releaseInfo st
 	return us, nil
 }
 
+// getServerUpdateInfo downloads the release information from updateURL,
+// the official release URL if empty, and returns the binary to update to.
+func getServerUpdateInfo(updateURL, mode string) (info serverUpdateInfo, err error) {
+	if updateURL == "" {
+		updateURL = minioReleaseInfoURL
+		if runtime.GOOS == globalWindowsOSName {
+			updateURL = minioReleaseWindowsInfoURL
+		}
+	}
+
+	u, err := url.Parse(updateURL)
+	if err != nil {
+		return info, err
+	}
+
+	content, err := downloadReleaseURL(u, updateTimeout, mode)
+	if err != nil {
+		return info, err
+	}
+
+	sha256Sum, lrTime, releaseInfo, err := parseReleaseData(content)
+	if err != nil {
+		return info, err
+	}
+
+	u.Path = path.Dir(u.Path) + SlashSeparator + releaseInfo
+	return serverUpdateInfo{
+		URL:         u,
+		Sha256Sum:   sha256Sum,
+		Time:        lrTime,
+		ReleaseInfo: releaseInfo,
+	}, nil
+}
+
 // ServerUpdateHandler - POST /minio/admin/v3/update?updateURL={updateURL}
 // ----------
 // updates all minio servers and restarts them gracefully.
@@ -96,34 +135,14 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	vars := mux.Vars(r)
-	updateURL := vars["updateURL"]
 	mode := getMinioMode()
-	if updateURL == "" {
-		updateURL = minioReleaseInfoURL
-		if runtime.GOOS == globalWindowsOSName {
-			updateURL = minioReleaseWindowsInfoURL
-		}
-	}
-
-	u, err := url.Parse(updateURL)
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	content, err := downloadReleaseURL(u, updateTimeout, mode)
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	sha256Sum, lrTime, releaseInfo, err := parseReleaseData(content)
+	info, err := getServerUpdateInfo(vars["updateURL"], mode)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	u, sha256Sum, lrTime, releaseInfo := info.URL, info.Sha256Sum, info.Time, info.ReleaseInfo
 
-	u.Path = path.Dir(u.Path) + SlashSeparator + releaseInfo
 	crTime, err := GetCurrentReleaseTime()
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -188,6 +207,95 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 	globalServiceSignalCh <- serviceRestart
 }
 
+// ServerRollingUpdateHandler - POST /minio/admin/v3/rolling-update?updateURL={updateURL}
+// ----------
+// updates the minio servers one at a time, unlike ServerUpdateHandler
+// which restarts all of them at once. Each server is drained, updated
+// and restarted, then it must be back with the same internode API
+// versions and the cluster healthy before the next one is updated, the
+// server coordinating the update is updated last. Returns the progress
+// of the update, which runs in the background.
+func (a adminAPIHandlers) ServerRollingUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServerRollingUpdate")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerUpdateAdminAction)
+	if objectAPI == nil || globalNotificationSys == nil {
+		return
+	}
+
+	if globalInplaceUpdateDisabled {
+		// if MINIO_UPDATE=off - inplace update is disabled, mostly in containers.
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	mode := getMinioMode()
+	info, err := getServerUpdateInfo(vars["updateURL"], mode)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	crTime, err := GetCurrentReleaseTime()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status := RollingUpdateStatus{
+		State:          rollingUpdateCompleted,
+		CurrentVersion: Version,
+		UpdatedVersion: Version,
+	}
+	if info.Time.Sub(crTime) > 0 {
+		status, err = globalRollingUpdate.start(ctx, objectAPI, info, mode)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ServerRollingUpdateStatusHandler - GET /minio/admin/v3/rolling-update
+// ----------
+// returns the progress of the last rolling update.
+func (a adminAPIHandlers) ServerRollingUpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServerRollingUpdateStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := loadRollingUpdateStatus(ctx, objectAPI)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			err = errRollingUpdateNotStarted
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
 // ServiceHandler - POST /minio/admin/v3/service?action={action}
 // ----------
 // Supports following actions:
@@ -364,6 +472,40 @@ func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Re
 	writeSuccessResponseJSON(w, dataUsageInfoJSON)
 }
 
+// PrefixUsageHandler - GET /minio/admin/v3/prefix-usage/{bucket}?prefix={prefix}
+// ----------
+// Get the usage of a prefix broken down by the prefixes directly below it.
+func (a adminAPIHandlers) PrefixUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PrefixUsage")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	prefixUsage, err := loadPrefixDrilldownFromBackend(ctx, objectAPI, bucket, r.Form.Get("prefix"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	prefixUsageJSON, err := json.Marshal(prefixUsage)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, prefixUsageJSON)
+}
+
 func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -446,7 +588,7 @@ func (a adminAPIHandlers) ForceUnlockHandler(w http.ResponseWriter, r *http.Requ
 		args.Resources = append(args.Resources, path)
 	}
 
-	for _, lks := range z.serverPools[0].erasureLockers {
+	for _, lks := range z.getServerPools()[0].erasureLockers {
 		lockers = append(lockers, lks...)
 	}
 
@@ -636,9 +778,37 @@ func (a adminAPIHandlers) DownloadProfilingHandler(w http.ResponseWriter, r *htt
 	}
 }
 
+// DiagnosticsHandler - GET /minio/admin/v3/healthinfo/diagnostics
+// ----------
+// Download a snapshot of the heap and goroutine profiles, the garbage
+// collector statistics and the runtime metrics of all nodes in a zip format
+func (a adminAPIHandlers) DiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Diagnostics")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	// Validate request signature.
+	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.HealthInfoAdminAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
+		return
+	}
+
+	if globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, "application/zip")
+	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=\"diagnostics-%s.zip\"",
+		UTCNow().Format("20060102150405")))
+	globalNotificationSys.DownloadDiagnostics(ctx, w)
+}
+
 type healInitParams struct {
 	bucket, objPrefix     string
 	hs                    madmin.HealOpts
+	window                HealTimeWindow
 	clientToken           string
 	forceStart, forceStop bool
 }
@@ -675,6 +845,26 @@ func extractHealInitParams(vars map[string]string, qParms url.Values, r io.Reade
 		hip.forceStop = true
 	}
 
+	// Optionally only heal the object versions modified
+	// within a time window.
+	var terr error
+	if v := qParms.Get(mgmtModifiedAfter); v != "" {
+		if hip.window.After, terr = time.Parse(time.RFC3339, v); terr != nil {
+			err = ErrHealInvalidTimeWindow
+			return
+		}
+	}
+	if v := qParms.Get(mgmtModifiedBefore); v != "" {
+		if hip.window.Before, terr = time.Parse(time.RFC3339, v); terr != nil {
+			err = ErrHealInvalidTimeWindow
+			return
+		}
+	}
+	if !hip.window.After.IsZero() && !hip.window.Before.IsZero() && !hip.window.After.Before(hip.window.Before) {
+		err = ErrHealInvalidTimeWindow
+		return
+	}
+
 	// Invalid request conditions:
 	//
 	//   Cannot have both forceStart and forceStop in the same
@@ -865,6 +1055,7 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 		}()
 	case hip.clientToken == "":
 		nh := newHealSequence(GlobalContext, hip.bucket, hip.objPrefix, handlers.GetSourceIP(r), hip.hs, hip.forceStart)
+		nh.window = hip.window
 		go func() {
 			respBytes, apiErr, errMsg := globalAllHealState.LaunchNewHealSequence(nh, objectAPI)
 			hr := healResp{respBytes, apiErr, errMsg}
@@ -934,6 +1125,266 @@ func (a adminAPIHandlers) BackgroundHealStatusHandler(w http.ResponseWriter, r *
 	}
 }
 
+// QuorumStatusHandler - GET /minio/admin/v3/quorum-status
+// ----------
+// Returns the per erasure set counts of sampled objects that are one
+// disk away from losing read quorum, as found by the last quorum
+// monitor cycle.
+func (a adminAPIHandlers) QuorumStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "QuorumStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	status, err := loadQuorumMonitorStatus(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// DamagedObjectsHandler - GET /minio/admin/v3/damaged-objects/{bucket}?prefix={prefix}
+// ----------
+// Walks the bucket and streams the objects which have lost read quorum,
+// along with the per disk errors, as newline delimited JSON. Such objects
+// cannot be healed and need to be restored from a backup.
+func (a adminAPIHandlers) DamagedObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DamagedObjects")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok || !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	bucket := mux.Vars(r)["bucket"]
+	prefix := r.Form.Get("prefix")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	setEventStreamHeaders(w)
+
+	results := make(chan DamagedObject, 100)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- z.listDamagedObjects(ctx, bucket, prefix, results)
+	}()
+
+	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+	defer keepAliveTicker.Stop()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case damaged, ok := <-results:
+			if !ok {
+				if err := <-errCh; err != nil {
+					logger.LogIf(ctx, err)
+					enc.Encode(struct {
+						Error string `json:"error"`
+					}{Error: err.Error()})
+				}
+				return
+			}
+			if err := enc.Encode(damaged); err != nil {
+				return
+			}
+			if len(results) == 0 {
+				// Flush if nothing is queued
+				w.(http.Flusher).Flush()
+			}
+		case <-keepAliveTicker.C:
+			if len(results) > 0 {
+				continue
+			}
+			if _, err := w.Write([]byte(" ")); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ObjectIntegrityHandler - GET /minio/admin/v3/object-integrity?bucket={bucket}&object={object}&versionId={versionId}
+// ----------
+// Returns the state of an object version on every disk of its erasure
+// set: the metadata read from the disk, the erasure distribution and
+// the bitrot verification of each part.
+func (a adminAPIHandlers) ObjectIntegrityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ObjectIntegrity")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok || !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	object := r.Form.Get("object")
+	versionID := r.Form.Get("versionId")
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+
+	report, err := z.objectIntegrity(ctx, bucket, object, versionID)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListNotifyStoreHandler - GET /minio/admin/v3/notify-store/list?target={target}&from={from}&to={to}&max-events={max}
+// ----------
+// Lists the undelivered events held in the queue store of a notification
+// target on all servers. Events are selected by event time, from and to
+// are RFC3339 times, max-events limits the events returned per server.
+func (a adminAPIHandlers) ListNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListNotifyStore")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	targetID, filter, apiErr := parseNotifyStoreReq(r)
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	events, err := globalNotificationSys.ListNotifyStoreEvents(ctx, targetID, filter)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, eventsJSON)
+}
+
+// ReplayNotifyStoreHandler - POST /minio/admin/v3/notify-store/replay?target={target}&from={from}&to={to}&max-events={max}
+// ----------
+// Sends the selected undelivered events of a notification target right
+// away on all servers, instead of waiting for the next delivery retry.
+func (a adminAPIHandlers) ReplayNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplayNotifyStore")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	targetID, filter, apiErr := parseNotifyStoreReq(r)
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	resultsJSON, err := json.Marshal(globalNotificationSys.ReplayNotifyStoreEvents(ctx, targetID, filter))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, resultsJSON)
+}
+
+// PurgeNotifyStoreHandler - DELETE /minio/admin/v3/notify-store/purge?target={target}&from={from}&to={to}&max-events={max}
+// ----------
+// Removes the selected undelivered events of a notification target on
+// all servers, they are never delivered.
+func (a adminAPIHandlers) PurgeNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PurgeNotifyStore")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	targetID, filter, apiErr := parseNotifyStoreReq(r)
+	if apiErr != noError {
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	resultsJSON, err := json.Marshal(globalNotificationSys.PurgeNotifyStoreEvents(ctx, targetID, filter))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, resultsJSON)
+}
+
+// parseNotifyStoreReq validates the notification target and parses
+// the stored event filter of a notify store admin request.
+func parseNotifyStoreReq(r *http.Request) (string, target.StoreFilter, APIError) {
+	targetID := r.Form.Get("target")
+	if _, err := getNotifyStoreTarget(targetID); err != nil {
+		return "", target.StoreFilter{}, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err)
+	}
+	filter, err := notifyStoreFilterFromValues(r.Form)
+	if err != nil {
+		return "", filter, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err)
+	}
+	return targetID, filter, noError
+}
+
 // SpeedtestHandler - reports maximum speed of a cluster by performing PUT and
 // GET operations on the server, supports auto tuning by default by automatically
 // increasing concurrency and stopping when we have reached the limits on the
@@ -1032,17 +1483,22 @@ const (
 // - input entry is not of the type *madmin.TraceInfo*
 // - errOnly entries are to be traced, not status code 2xx, 3xx.
 // - madmin.TraceInfo type is asked by opts
-func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace bool) {
+// - HTTP entries of APIs not asked by opts or not sampled.
+func mustTrace(entry interface{}, opts traceOptions) (shouldTrace bool) {
 	trcInfo, ok := entry.(madmin.TraceInfo)
 	if !ok {
 		return false
 	}
 
 	// Override shouldTrace decision with errOnly filtering
+	// and sample the remaining HTTP entries.
 	defer func() {
 		if shouldTrace && opts.OnlyErrors {
 			shouldTrace = trcInfo.RespInfo.StatusCode >= http.StatusBadRequest
 		}
+		if shouldTrace && trcInfo.TraceType == madmin.TraceHTTP {
+			shouldTrace = globalTraceSampler.sampled(trcInfo.FuncName, opts.SampleRate)
+		}
 	}()
 
 	if opts.Threshold > 0 {
@@ -1060,6 +1516,10 @@ func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace boo
 		}
 	}
 
+	if trcInfo.TraceType == madmin.TraceHTTP && !opts.matchAPI(trcInfo.FuncName) {
+		return false
+	}
+
 	if opts.Internal && trcInfo.TraceType == madmin.TraceHTTP && HasPrefix(trcInfo.ReqInfo.Path, minioReservedBucketPath+SlashSeparator) {
 		return true
 	}
@@ -1075,7 +1535,28 @@ func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace boo
 	return opts.OS && trcInfo.TraceType == madmin.TraceOS
 }
 
-func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+// parseTraceSampling parses the sample rate and the
+// comma separated list of traced APIs.
+func parseTraceSampling(sample, apis string) (rate float64, names []string, err error) {
+	rate = 1
+	if sample != "" {
+		rate, err = strconv.ParseFloat(sample, 64)
+		if err != nil {
+			return 0, nil, err
+		}
+		if rate <= 0 || rate > 1 {
+			return 0, nil, fmt.Errorf("sample rate %v must be in (0, 1]", rate)
+		}
+	}
+	for _, api := range strings.Split(apis, ",") {
+		if api = strings.TrimSpace(api); api != "" {
+			names = append(names, api)
+		}
+	}
+	return rate, names, nil
+}
+
+func extractTraceOptions(r *http.Request) (opts traceOptions, err error) {
 	q := r.Form
 
 	opts.OnlyErrors = q.Get("err") == "true"
@@ -1099,7 +1580,9 @@ func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err err
 		}
 		opts.Threshold = d
 	}
-	return
+
+	opts.SampleRate, opts.APIs, err = parseTraceSampling(q.Get("sample"), q.Get("api"))
+	return opts, err
 }
 
 // TraceHandler - POST /minio/admin/v3/trace
@@ -1129,6 +1612,7 @@ func (a adminAPIHandlers) TraceHandler(w http.ResponseWriter, r *http.Request) {
 
 	peers, _ := newPeerRestClients(globalEndpoints)
 
+	globalTraceSampler.register(&traceOpts, ctx.Done())
 	globalTrace.Subscribe(traceCh, ctx.Done(), func(entry interface{}) bool {
 		return mustTrace(entry, traceOpts)
 	})
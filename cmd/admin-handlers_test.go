@@ -382,3 +382,37 @@ func TestExtractHealInitParams(t *testing.T) {
 	}
 
 }
+
+func TestExtractHealInitParamsTimeWindow(t *testing.T) {
+	body := `{"recursive": true}`
+	testCases := []struct {
+		after, before string
+		expectedErr   APIErrorCode
+	}{
+		{"", "", ErrNone},
+		{"2021-10-01T00:00:00Z", "", ErrNone},
+		{"", "2021-10-08T00:00:00Z", ErrNone},
+		{"2021-10-01T00:00:00Z", "2021-10-08T00:00:00Z", ErrNone},
+		{"2021-10-08T00:00:00Z", "2021-10-01T00:00:00Z", ErrHealInvalidTimeWindow},
+		{"2021-10-01T00:00:00Z", "2021-10-01T00:00:00Z", ErrHealInvalidTimeWindow},
+		{"7d", "", ErrHealInvalidTimeWindow},
+	}
+
+	for i, testCase := range testCases {
+		v := url.Values{}
+		if testCase.after != "" {
+			v.Set(mgmtModifiedAfter, testCase.after)
+		}
+		if testCase.before != "" {
+			v.Set(mgmtModifiedBefore, testCase.before)
+		}
+		hip, err := extractHealInitParams(map[string]string{mgmtBucket: "bucket"}, v, bytes.NewReader([]byte(body)))
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+			continue
+		}
+		if err == ErrNone && testCase.after != "" && hip.window.After.Format(time.RFC3339) != testCase.after {
+			t.Errorf("Test %d: expected window to start at %s, got %s", i+1, testCase.after, hip.window.After)
+		}
+	}
+}
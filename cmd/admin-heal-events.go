@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// HealEvent is published for every object that healObject
+// attempted to repair, it is streamed to admin clients
+// subscribed to live heal progress.
+type HealEvent struct {
+	NodeName  string    `json:"node"`
+	Time      time.Time `json:"time"`
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+
+	// DisksHealed is the number of disks the object was written to.
+	DisksHealed int `json:"disksHealed"`
+	// BytesReconstructed is the total number of shard bytes written.
+	BytesReconstructed int64 `json:"bytesReconstructed"`
+	// DataErrors holds the state of the data on each disk as found
+	// by disksWithAllParts, indexed by disk endpoint.
+	DataErrors map[string]string `json:"dataErrors,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// publishHealEvent publishes a heal event to all local subscribers.
+func publishHealEvent(bucket, object, versionID string, disksHealed int, bytesReconstructed int64,
+	endpoints []Endpoint, dataErrs []error, err error) {
+	ev := HealEvent{
+		NodeName:           globalLocalNodeName,
+		Time:               UTCNow(),
+		Bucket:             bucket,
+		Object:             object,
+		VersionID:          versionID,
+		DisksHealed:        disksHealed,
+		BytesReconstructed: bytesReconstructed,
+	}
+	for i, derr := range dataErrs {
+		if derr == nil || i >= len(endpoints) {
+			continue
+		}
+		if ev.DataErrors == nil {
+			ev.DataErrors = make(map[string]string, len(dataErrs))
+		}
+		ev.DataErrors[endpoints[i].String()] = derr.Error()
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	globalHealEvents.Publish(ev)
+}
+
+// healEventFilter returns the filter of heal events of bucket, all the
+// heal events if bucket is empty.
+func healEventFilter(bucket string) func(entry interface{}) bool {
+	return func(entry interface{}) bool {
+		ev, ok := entry.(HealEvent)
+		if !ok {
+			return false
+		}
+		return bucket == "" || ev.Bucket == bucket
+	}
+}
+
+// HealEventsHandler - GET /minio/admin/v3/heal-events
+// ----------
+// Streams per object heal events from all the nodes in the cluster as
+// newline delimited JSON until the client disconnects.
+func (a adminAPIHandlers) HealEventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "HealEvents")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+
+	setEventStreamHeaders(w)
+
+	// Heal events publisher and peer clients use nonblocking send and hence
+	// do not wait for slow receivers. Use buffered channel to take care of
+	// burst sends or slow w.Write()
+	healCh := make(chan interface{}, 4000)
+
+	peers, _ := newPeerRestClients(globalEndpoints)
+
+	globalHealEvents.Subscribe(healCh, ctx.Done(), healEventFilter(bucket))
+
+	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+		peer.HealEvents(healCh, ctx.Done(), bucket)
+	}
+
+	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+	defer keepAliveTicker.Stop()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case entry := <-healCh:
+			if err := enc.Encode(entry); err != nil {
+				return
+			}
+			if len(healCh) == 0 {
+				// Flush if nothing is queued
+				w.(http.Flusher).Flush()
+			}
+		case <-keepAliveTicker.C:
+			if len(healCh) > 0 {
+				continue
+			}
+			if _, err := w.Write([]byte(" ")); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealEventFilter(t *testing.T) {
+	testCases := []struct {
+		bucket string
+		entry  interface{}
+		match  bool
+	}{
+		// Only heal events are sent.
+		{"", "not a heal event", false},
+		{"bucket", &HealEvent{Bucket: "bucket"}, false},
+		// All the heal events are sent without a bucket.
+		{"", HealEvent{Bucket: "bucket"}, true},
+		{"bucket", HealEvent{Bucket: "bucket"}, true},
+		{"bucket", HealEvent{Bucket: "other"}, false},
+	}
+	for i, testCase := range testCases {
+		if match := healEventFilter(testCase.bucket)(testCase.entry); match != testCase.match {
+			t.Errorf("case %d: expected %t, got %t", i+1, testCase.match, match)
+		}
+	}
+}
+
+func TestHealEventsHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	ts := httptest.NewServer(adminTestBed.router)
+	defer ts.Close()
+
+	req, err := newTestRequest(http.MethodGet, ts.URL+adminPathPrefix+adminAPIVersionPrefix+"/heal-events?bucket=b1", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := globalActiveCred
+	if err = signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatal(err)
+	}
+	reqCtx, reqCancel := context.WithCancel(ctx)
+	defer reqCancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(reqCtx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	// Wait for the handler to subscribe before publishing.
+	for i := 0; globalHealEvents.NumSubscribers() == 0; i++ {
+		if i == 100 {
+			t.Fatal("handler did not subscribe to heal events")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	publishHealEvent("b2", "object", "", 1, 10, nil, nil, nil)
+	publishHealEvent("b1", "object1", "", 1, 10, nil, nil, nil)
+	publishHealEvent("b2", "object", "", 1, 10, nil, nil, nil)
+	publishHealEvent("b1", "object2", "", 2, 20, nil, nil, nil)
+
+	// Heal events are newline delimited JSON, separated by keep alive spaces.
+	reader := bufio.NewReader(resp.Body)
+	for _, object := range []string{"object1", "object2"} {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ev HealEvent
+		if err = json.Unmarshal([]byte(strings.TrimSpace(line)), &ev); err != nil {
+			t.Fatal(err)
+		}
+		if ev.Bucket != "b1" || ev.Object != object {
+			t.Fatalf("expected heal event of b1/%s, got %s/%s", object, ev.Bucket, ev.Object)
+		}
+	}
+}
@@ -418,6 +418,9 @@ type healSequence struct {
 	// heal settings applied to this heal sequence
 	settings madmin.HealOpts
 
+	// only the object versions modified within window are healed
+	window HealTimeWindow
+
 	// current accumulated status of the heal sequence
 	currentStatus healSequenceStatus
 
@@ -690,6 +693,11 @@ func (h *healSequence) logHeal(healType madmin.HealItemType) {
 }
 
 func (h *healSequence) queueHealTask(source healSource, healType madmin.HealItemType) error {
+	if globalBackgroundHealRoutine == nil {
+		// The heal workers are not started yet.
+		return errServerNotInitialized
+	}
+
 	// Send heal request
 	task := healTask{
 		bucket:    source.bucket,
@@ -789,7 +797,7 @@ func (h *healSequence) healMinioSysMeta(objAPI ObjectLayer, metaPrefix string) f
 		// NOTE: Healing on meta is run regardless
 		// of any bucket being selected, this is to ensure that
 		// meta are always upto date and correct.
-		return objAPI.HealObjects(h.ctx, minioMetaBucket, metaPrefix, h.settings, func(bucket, object, versionID string) error {
+		return objAPI.HealObjects(h.ctx, minioMetaBucket, metaPrefix, h.settings, HealTimeWindow{}, func(bucket, object, versionID string) error {
 			if h.isQuitting() {
 				return errHealStopSignalled
 			}
@@ -869,7 +877,7 @@ func (h *healSequence) healBucket(objAPI ObjectLayer, bucket string, bucketsOnly
 		return nil
 	}
 
-	if err := objAPI.HealObjects(h.ctx, bucket, h.object, h.settings, h.healObject); err != nil {
+	if err := objAPI.HealObjects(h.ctx, bucket, h.object, h.settings, h.window, h.healObject); err != nil {
 		return errFnHealFromAPIErr(h.ctx, err)
 	}
 	return nil
@@ -56,6 +56,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 	for _, adminVersion := range adminVersions {
 		// Restart and stop MinIO service.
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/service").HandlerFunc(gz(httpTraceAll(adminAPI.ServiceHandler))).Queries("action", "{action:.*}")
+		// Update MinIO servers one at a time.
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/rolling-update").HandlerFunc(gz(httpTraceAll(adminAPI.ServerRollingUpdateHandler))).Queries("updateURL", "{updateURL:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rolling-update").HandlerFunc(gz(httpTraceAll(adminAPI.ServerRollingUpdateStatusHandler)))
+		// Drain a node before shutting it down.
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drain").HandlerFunc(gz(httpTraceAll(adminAPI.DrainHandler))).Queries("node", "{node:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/drain").HandlerFunc(gz(httpTraceAll(adminAPI.DrainStatusHandler)))
 		// Update MinIO servers.
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/update").HandlerFunc(gz(httpTraceAll(adminAPI.ServerUpdateHandler))).Queries("updateURL", "{updateURL:.*}")
 
@@ -67,6 +73,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.StorageInfoHandler)))
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.DataUsageInfoHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/prefix-usage/{bucket}").HandlerFunc(gz(httpTraceAll(adminAPI.PrefixUsageHandler)))
+		// Usage of the S3 APIs by access key
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/usage-accounting").HandlerFunc(gz(httpTraceAll(adminAPI.UsageAccountingHandler)))
 
 		if globalIsDistErasure || globalIsErasure {
 			// Heal operations
@@ -77,6 +86,33 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}/{prefix:.*}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
 
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/background-heal/status").HandlerFunc(gz(httpTraceAll(adminAPI.BackgroundHealStatusHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/heal-events").HandlerFunc(gz(http.HandlerFunc(adminAPI.HealEventsHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/quorum-status").HandlerFunc(gz(httpTraceAll(adminAPI.QuorumStatusHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/damaged-objects/{bucket}").HandlerFunc(gz(httpTraceAll(adminAPI.DamagedObjectsHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/object-integrity").HandlerFunc(gz(httpTraceAll(adminAPI.ObjectIntegrityHandler))).Queries("bucket", "{bucket:.*}", "object", "{object:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/drives/latency").HandlerFunc(gz(httpTraceAll(adminAPI.DriveLatencyHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/replace").HandlerFunc(gz(httpTraceAll(adminAPI.DriveReplaceHandler))).Queries("endpoint", "{endpoint:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/drives/replace/status").HandlerFunc(gz(httpTraceAll(adminAPI.DriveReplaceStatusHandler))).Queries("endpoint", "{endpoint:.*}")
+
+			// Rebalance operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStartHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/pause").HandlerFunc(gz(httpTraceAll(adminAPI.RebalancePauseHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/resume").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceResumeHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(gz(httpTraceAll(adminAPI.RebalanceStatusHandler)))
+
+			// Inline data migration operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/inline-migration/start").HandlerFunc(gz(httpTraceAll(adminAPI.InlineMigrationStartHandler))).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/inline-migration/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.InlineMigrationCancelHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/inline-migration/status").HandlerFunc(gz(httpTraceAll(adminAPI.InlineMigrationStatusHandler)))
+
+			// Pool expansion and decommission operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/add").HandlerFunc(gz(httpTraceAll(adminAPI.AddPoolHandler))).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(gz(httpTraceAll(adminAPI.StartDecommissionHandler))).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelDecommissionHandler))).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/status").HandlerFunc(gz(httpTraceAll(adminAPI.DecommissionStatusHandler)))
+
+			// Batch jobs
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/batch-jobs").HandlerFunc(gz(httpTraceAll(adminAPI.ListBatchJobsHandler)))
 		}
 
 		// Profiling operations
@@ -84,11 +120,24 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			Queries("profilerType", "{profilerType:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/profiling/download").HandlerFunc(gz(httpTraceAll(adminAPI.DownloadProfilingHandler)))
 
+		// Notification target queue store operations.
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/notify-store/list").HandlerFunc(gz(httpTraceAll(adminAPI.ListNotifyStoreHandler))).Queries("target", "{target:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/notify-store/replay").HandlerFunc(gz(httpTraceAll(adminAPI.ReplayNotifyStoreHandler))).Queries("target", "{target:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/notify-store/purge").HandlerFunc(gz(httpTraceAll(adminAPI.PurgeNotifyStoreHandler))).Queries("target", "{target:.*}")
+
 		// Config KV operations.
 		if enableConfigOps {
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetConfigKVHandler))).Queries("key", "{key:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/set-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetConfigKVHandler)))
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/del-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.DelConfigKVHandler)))
+
+			// Staged config changes
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/stage-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.StageConfigKVHandler)))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/stage-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.StageDelConfigKVHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/staged-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetStagedConfigKVHandler)))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/staged-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.DiscardStagedConfigKVHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/validate-staged-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.ValidateStagedConfigKVHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/apply-staged-config-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.ApplyStagedConfigKVHandler)))
 		}
 
 		// Enable config help in all modes.
@@ -99,6 +148,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-config-history-kv").HandlerFunc(gz(httpTraceAll(adminAPI.ListConfigHistoryKVHandler))).Queries("count", "{count:[0-9]+}")
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/clear-config-history-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.ClearConfigHistoryKVHandler))).Queries("restoreId", "{restoreId:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/restore-config-history-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.RestoreConfigHistoryKVHandler))).Queries("restoreId", "{restoreId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/diff-config-history-kv").HandlerFunc(gz(httpTraceAll(adminAPI.DiffConfigHistoryKVHandler))).Queries("from", "{from:.*}", "to", "{to:.*}")
 		}
 
 		// Config import/export bulk operations
@@ -142,6 +192,17 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			HandlerFunc(gz(httpTraceHdrs(adminAPI.SetPolicyForUserOrGroup))).
 			Queries("policyName", "{policyName:.*}", "userOrGroup", "{userOrGroup:.*}", "isGroup", "{isGroup:true|false}")
 
+		// Set or remove the permissions boundary of a user, service account or role
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-permissions-boundary").
+			HandlerFunc(gz(httpTraceHdrs(adminAPI.SetPermissionsBoundary))).
+			Queries("accessKey", "{accessKey:.*}", "policyName", "{policyName:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/permissions-boundary").
+			HandlerFunc(gz(httpTraceHdrs(adminAPI.GetPermissionsBoundary))).
+			Queries("accessKey", "{accessKey:.*}")
+
+		// Simulate a request against the IAM and bucket policies
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/simulate-policy").HandlerFunc(gz(httpTraceHdrs(adminAPI.SimulatePolicy)))
+
 		// Remove user IAM
 		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-user").HandlerFunc(gz(httpTraceHdrs(adminAPI.RemoveUser))).Queries("accessKey", "{accessKey:.*}")
 
@@ -160,6 +221,10 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// List Groups
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/groups").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListGroups)))
 
+		// Export and import all IAM state
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/export-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ExportIAMHandler)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/import-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ImportIAMHandler)))
+
 		// Set Group Status
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-group-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetGroupStatus))).Queries("group", "{group:.*}").Queries("status", "{status:.*}")
 
@@ -170,6 +235,21 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			// PutBucketQuotaConfig
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 				gz(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))).Queries("bucket", "{bucket:.*}")
+			// GetBucketQoSConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-qos").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetBucketQoSConfigHandler))).Queries("bucket", "{bucket:.*}")
+			// PutBucketQoSConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-qos").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.PutBucketQoSConfigHandler))).Queries("bucket", "{bucket:.*}")
+			// GetReplicationBandwidth
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-replication-bandwidth").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetReplicationBandwidthHandler))).Queries("bucket", "{bucket:.*}")
+			// PutReplicationBandwidth
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-replication-bandwidth").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.PutReplicationBandwidthHandler))).Queries("bucket", "{bucket:.*}")
+			// GetReplicationMRF
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-mrf").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetReplicationMRFHandler))).Queries("bucket", "{bucket:.*}")
 
 			// Bucket replication operations
 			// GetBucketTargetHandler
@@ -189,18 +269,60 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 			// Tier stats
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-stats").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierStatsHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierStatusHandler)))
+
+			// Scanner control operations
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/scanner/status").HandlerFunc(gz(httpTraceHdrs(adminAPI.ScannerStatusHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/scanner/{op}").HandlerFunc(gz(httpTraceHdrs(adminAPI.ScannerControlHandler)))
 
 			// Cluster Replication APIs
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/add").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationAdd)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/disable").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationDisable)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/info").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationInfo)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationStatus)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/join").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalJoin)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/site-replication/peer/bucket-ops").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalBucketOps))).Queries("bucket", "{bucket:.*}").Queries("operation", "{operation:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/iam-item").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalReplicateIAMItem)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/bucket-meta").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalReplicateBucketItem)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/idp-settings").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalGetIDPSettings)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/bucket-checksums").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRInternalBucketChecksums)))
 		}
 
+		// GetBucketCompressionConfig
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-compression").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketCompressionConfigHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketCompressionConfig
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-compression").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketCompressionConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// GetBucketDefaultTags
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-default-tags").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketDefaultTagsHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketDefaultTags
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-default-tags").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketDefaultTagsHandler))).Queries("bucket", "{bucket:.*}")
+
+		// GetBucketStorageClasses
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-storage-classes").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketStorageClassesHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketStorageClasses
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-storage-classes").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketStorageClassesHandler))).Queries("bucket", "{bucket:.*}")
+
+		// GetBucketReplicationConflictConfig
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-replication-conflict").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketReplicationConflictConfigHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketReplicationConflictConfig
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-replication-conflict").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketReplicationConflictConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// GetBucketReplicationProxyConfig
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-replication-proxy").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketReplicationProxyConfigHandler))).Queries("bucket", "{bucket:.*}")
+		// PutBucketReplicationProxyConfig
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-replication-proxy").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketReplicationProxyConfigHandler))).Queries("bucket", "{bucket:.*}")
+
 		if globalIsDistErasure {
 			// Top locks
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top/locks").HandlerFunc(gz(httpTraceHdrs(adminAPI.TopLocksHandler)))
@@ -222,6 +344,17 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSStatusHandler)))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/key/create").HandlerFunc(gz(httpTraceAll(adminAPI.KMSCreateKeyHandler))).Queries("key-id", "{key-id:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSKeyStatusHandler)))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/rotation/start").HandlerFunc(gz(httpTraceAll(adminAPI.KMSRotationStartHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/rotation/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.KMSRotationCancelHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/kms/rotation/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSRotationStatusHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Object lock compliance audit
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/object-lock-audit/start").HandlerFunc(gz(httpTraceAll(adminAPI.ObjectLockAuditStartHandler))).Queries("bucket", "{bucket:.*}", "target", "{target:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/object-lock-audit/status").HandlerFunc(gz(httpTraceAll(adminAPI.ObjectLockAuditStatusHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Replication consistency check
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication-fsck/start").HandlerFunc(gz(httpTraceAll(adminAPI.ReplicationFsckStartHandler))).Queries("bucket", "{bucket:.*}", "target", "{target:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-fsck/status").HandlerFunc(gz(httpTraceAll(adminAPI.ReplicationFsckStatusHandler))).Queries("bucket", "{bucket:.*}")
 
 		if !globalIsGateway {
 			// Keep obdinfo for backward compatibility with mc
@@ -230,6 +363,8 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			// -- Health API --
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/healthinfo").
 				HandlerFunc(gz(httpTraceHdrs(adminAPI.HealthInfoHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/healthinfo/diagnostics").
+				HandlerFunc(httpTraceHdrs(adminAPI.DiagnosticsHandler))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/bandwidth").
 				HandlerFunc(gz(httpTraceHdrs(adminAPI.BandwidthMonitorHandler)))
 		}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"runtime/metrics"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const (
+	// The heap is sampled at most this often.
+	admissionHeapSampleInterval = 100 * time.Millisecond
+
+	// Queued requests check whether they can be admitted this often.
+	admissionRetryInterval = 20 * time.Millisecond
+)
+
+// admissionLimits are the memory limits above which large requests
+// are queued, and rejected with SlowDown after the deadline.
+type admissionLimits struct {
+	// heapSize is the largest heap in use, 0 disables the check.
+	heapSize uint64
+	// inflightSize is the most body bytes of uploads in flight,
+	// 0 disables the check.
+	inflightSize int64
+	// minSize is the smallest upload which is checked.
+	minSize int64
+	// deadline is how long requests wait to be admitted.
+	deadline time.Duration
+}
+
+func (l admissionLimits) enabled() bool {
+	return l.heapSize > 0 || l.inflightSize > 0
+}
+
+// admissionController keeps track of the memory used by large
+// requests and admits new ones while it is below the limits.
+type admissionController struct {
+	inflightBytes int64  // body bytes of admitted uploads
+	waiting       int64  // requests waiting to be admitted
+	rejected      uint64 // requests rejected with SlowDown
+
+	heapBytes   uint64 // heap in use when last sampled
+	heapSampled int64  // unix nano time of the last sample
+
+	readHeap func() uint64
+}
+
+var globalAdmission = &admissionController{readHeap: readHeapInUse}
+
+// readHeapInUse returns the bytes occupied by heap objects, reading it
+// does not stop the world unlike runtime.ReadMemStats.
+func readHeapInUse() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+func (a *admissionController) heapInUse() uint64 {
+	now := time.Now().UnixNano()
+	last := atomic.LoadInt64(&a.heapSampled)
+	if now-last >= int64(admissionHeapSampleInterval) && atomic.CompareAndSwapInt64(&a.heapSampled, last, now) {
+		atomic.StoreUint64(&a.heapBytes, a.readHeap())
+	}
+	return atomic.LoadUint64(&a.heapBytes)
+}
+
+// tryAdmit admits a request with size body bytes if the node is not under
+// memory pressure. An upload is always admitted when no other upload is in
+// flight, so that uploads larger than the in-flight limit can still succeed.
+func (a *admissionController) tryAdmit(limits admissionLimits, size int64) bool {
+	if limits.heapSize > 0 && a.heapInUse() > limits.heapSize {
+		return false
+	}
+	for {
+		inflight := atomic.LoadInt64(&a.inflightBytes)
+		if limits.inflightSize > 0 && inflight > 0 && inflight+size > limits.inflightSize {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&a.inflightBytes, inflight, inflight+size) {
+			return true
+		}
+	}
+}
+
+// admit waits until a request with size body bytes is admitted, it returns
+// false if the request was not admitted before the deadline or ctx is done.
+// Admitted requests must be released with the same size.
+func (a *admissionController) admit(ctx context.Context, limits admissionLimits, size int64) bool {
+	if a.tryAdmit(limits, size) {
+		return true
+	}
+
+	atomic.AddInt64(&a.waiting, 1)
+	defer atomic.AddInt64(&a.waiting, -1)
+
+	deadline := time.NewTimer(limits.deadline)
+	defer deadline.Stop()
+	retry := time.NewTicker(admissionRetryInterval)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			atomic.AddUint64(&a.rejected, 1)
+			return false
+		case <-retry.C:
+			if a.tryAdmit(limits, size) {
+				return true
+			}
+		}
+	}
+}
+
+func (a *admissionController) release(size int64) {
+	atomic.AddInt64(&a.inflightBytes, -size)
+}
+
+// admitRequest queues large uploads and GET requests while the node is
+// under memory pressure, they are rejected with SlowDown if the pressure
+// does not drop before the admission deadline. The size of GET responses
+// is not known up front, they are only admitted based on the heap in use.
+func admitRequest(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limits := globalAPIConfig.getAdmissionLimits()
+		if !limits.enabled() {
+			f.ServeHTTP(w, r)
+			return
+		}
+
+		var size int64
+		if r.Method != http.MethodGet {
+			size = r.ContentLength
+			if v := r.Header.Get(xhttp.AmzDecodedContentLength); v != "" {
+				if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+					size = n
+				}
+			}
+			if size >= 0 && size < limits.minSize {
+				f.ServeHTTP(w, r)
+				return
+			}
+			if size < 0 {
+				// Unknown length, charge the smallest large upload.
+				size = limits.minSize
+			}
+		}
+
+		if !globalAdmission.admit(r.Context(), limits, size) {
+			if r.Context().Err() == nil {
+				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSlowDown), r.URL)
+			}
+			return
+		}
+		defer globalAdmission.release(size)
+
+		f.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdmissionControllerInflight(t *testing.T) {
+	a := &admissionController{readHeap: func() uint64 { return 0 }}
+	limits := admissionLimits{inflightSize: 10, minSize: 1, deadline: 50 * time.Millisecond}
+
+	// The first upload is admitted even if it exceeds the limit on its own.
+	if !a.tryAdmit(limits, 20) {
+		t.Fatal("expected the first upload to be admitted")
+	}
+	if a.tryAdmit(limits, 1) {
+		t.Fatal("expected an upload over the in-flight limit to be refused")
+	}
+	if a.admit(context.Background(), limits, 1) {
+		t.Fatal("expected the upload to be rejected after the deadline")
+	}
+	if got := atomic.LoadUint64(&a.rejected); got != 1 {
+		t.Fatalf("expected 1 rejected request, got %d", got)
+	}
+
+	// Releasing the first upload lets a waiting one in.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		a.release(20)
+	}()
+	limits.deadline = time.Second
+	if !a.admit(context.Background(), limits, 5) {
+		t.Fatal("expected the upload to be admitted after a release")
+	}
+	a.release(5)
+	if got := atomic.LoadInt64(&a.inflightBytes); got != 0 {
+		t.Fatalf("expected no bytes in flight, got %d", got)
+	}
+	if got := atomic.LoadInt64(&a.waiting); got != 0 {
+		t.Fatalf("expected no waiting requests, got %d", got)
+	}
+}
+
+func TestAdmissionControllerHeap(t *testing.T) {
+	var heap uint64 = 200
+	a := &admissionController{readHeap: func() uint64 { return atomic.LoadUint64(&heap) }}
+	limits := admissionLimits{heapSize: 100, deadline: 20 * time.Millisecond}
+
+	if a.tryAdmit(limits, 0) {
+		t.Fatal("expected a request over the heap limit to be refused")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limits.deadline = time.Minute
+	if a.admit(ctx, limits, 0) {
+		t.Fatal("expected a canceled request not to be admitted")
+	}
+	if got := atomic.LoadUint64(&a.rejected); got != 0 {
+		t.Fatalf("expected canceled requests not to count as rejected, got %d", got)
+	}
+
+	atomic.StoreUint64(&heap, 50)
+	if !a.admit(context.Background(), limits, 0) {
+		t.Fatal("expected the request to be admitted once the heap shrinks")
+	}
+}
+
+func TestAdmitRequest(t *testing.T) {
+	saved := globalAdmission
+	defer func() {
+		globalAdmission = saved
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.admission = admissionLimits{}
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	globalAdmission = &admissionController{readHeap: func() uint64 { return 0 }}
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.admission = admissionLimits{inflightSize: 10, minSize: 4, deadline: 20 * time.Millisecond}
+	globalAPIConfig.mu.Unlock()
+
+	// Keep an upload in flight so that further large uploads are over the limit.
+	globalAdmission.inflightBytes = 10
+
+	handler := admitRequest(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		size   int64
+		status int
+	}{
+		// Small uploads bypass the check.
+		{size: 2, status: http.StatusOK},
+		// Large uploads are rejected with SlowDown.
+		{size: 8, status: http.StatusServiceUnavailable},
+		// Uploads of unknown length are charged the minimum size.
+		{size: -1, status: http.StatusServiceUnavailable},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/bucket/object", nil)
+		req.ContentLength = testCase.size
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		if rec.Code != testCase.status {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.status, rec.Code)
+		}
+	}
+	if got := atomic.LoadInt64(&globalAdmission.inflightBytes); got != 10 {
+		t.Fatalf("expected in-flight bytes to be restored, got %d", got)
+	}
+}
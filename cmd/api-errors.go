@@ -33,7 +33,9 @@ import (
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/crypto"
@@ -93,6 +95,7 @@ const (
 	ErrInvalidMaxUploads
 	ErrInvalidMaxParts
 	ErrInvalidPartNumberMarker
+	ErrInvalidVersionIDMarker
 	ErrInvalidPartNumber
 	ErrInvalidRequestBody
 	ErrInvalidCopySource
@@ -113,6 +116,9 @@ const (
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
 	ErrNoSuchWebsiteConfiguration
+	ErrNoSuchInventoryConfiguration
+	ErrNoSuchBatchJob
+	ErrBatchJobStatus
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -190,6 +196,7 @@ const (
 	ErrPastObjectLockRetainDate
 	ErrUnknownWORMModeDirective
 	ErrBucketTaggingNotFound
+	ErrNoSuchPublicAccessBlockConfiguration
 	ErrObjectLockInvalidHeaders
 	ErrInvalidTagDirective
 	// Add new error codes here.
@@ -228,6 +235,9 @@ const (
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
+	ErrContentChecksumMismatch
+	ErrInvalidChecksum
+	ErrInvalidObjectAttributes
 
 	// Add new extended error codes here.
 
@@ -280,7 +290,10 @@ const (
 
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
+	ErrAdminBucketVersionQuotaExceeded
+	ErrAdminBucketNoncurrentQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
+	ErrBucketQoSExceeded
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -288,6 +301,7 @@ const (
 	ErrHealMissingBucket
 	ErrHealAlreadyRunning
 	ErrHealOverlappingPaths
+	ErrHealInvalidTimeWindow
 	ErrIncorrectContinuationToken
 
 	// S3 Select Errors
@@ -384,6 +398,9 @@ const (
 	ErrAccountNotEligible
 	ErrAdminServiceAccountNotFound
 	ErrPostPolicyConditionInvalidFormat
+	ErrLambdaInvalidResponse
+	ErrServiceAccountExpired
+	ErrServiceAccountSourceIPDenied
 )
 
 type errorCodeMap map[APIErrorCode]APIError
@@ -443,6 +460,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Argument max-uploads must be an integer between 0 and 2147483647",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidVersionIDMarker: {
+		Code:           "InvalidArgument",
+		Description:    "A version-id marker cannot be specified without a key marker.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidMaxKeys: {
 		Code:           "InvalidArgument",
 		Description:    "Argument maxKeys must be an integer between 0 and 2147483647",
@@ -828,6 +850,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Bucket is missing ObjectLockConfiguration",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrNoSuchPublicAccessBlockConfiguration: {
+		Code:           "NoSuchPublicAccessBlockConfiguration",
+		Description:    "The public access block configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrBucketTaggingNotFound: {
 		Code:           "NoSuchTagSet",
 		Description:    "The TagSet does not exist",
@@ -853,6 +880,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrNoSuchInventoryConfiguration: {
+		Code:           "NoSuchConfiguration",
+		Description:    "The specified configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchBatchJob: {
+		Code:           "NotFoundException",
+		Description:    "The specified job does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrBatchJobStatus: {
+		Code:           "JobStatusException",
+		Description:    "The requested job status change is not allowed for the current job status.",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -1132,6 +1174,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The provided 'x-amz-content-sha256' header does not match what was computed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrContentChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The provided 'x-amz-checksum' header does not match what was computed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "Invalid checksum provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidObjectAttributes: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	// MinIO extensions.
 	ErrStorageFull: {
@@ -1250,11 +1307,26 @@ var errorCodes = errorCodeMap{
 		Description:    "Bucket quota exceeded",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminBucketVersionQuotaExceeded: {
+		Code:           "XMinioAdminBucketVersionQuotaExceeded",
+		Description:    "Bucket version count quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminBucketNoncurrentQuotaExceeded: {
+		Code:           "XMinioAdminBucketNoncurrentQuotaExceeded",
+		Description:    "Bucket noncurrent versions quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAdminNoSuchQuotaConfiguration: {
 		Code:           "XMinioAdminNoSuchQuotaConfiguration",
 		Description:    "The quota configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrBucketQoSExceeded: {
+		Code:           "SlowDown",
+		Description:    "Bucket request rate or bandwidth limit exceeded, please reduce your request rate",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1366,6 +1438,11 @@ var errorCodes = errorCodeMap{
 		Description:    "",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrHealInvalidTimeWindow: {
+		Code:           "XMinioHealInvalidTimeWindow",
+		Description:    "The modifiedAfter and modifiedBefore parameters of a heal request must be RFC3339 timestamps, modifiedAfter preceding modifiedBefore.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrBackendDown: {
 		Code:           "XMinioBackendDown",
 		Description:    "Remote backend is unreachable",
@@ -1827,6 +1904,21 @@ var errorCodes = errorCodeMap{
 		Description:    "Invalid according to Policy: Policy Condition failed",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrLambdaInvalidResponse: {
+		Code:           "XMinioLambdaInvalidResponse",
+		Description:    "The object transformation webhook did not return a valid response",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrServiceAccountExpired: {
+		Code:           "XMinioServiceAccountExpired",
+		Description:    "The service account used to sign the request has expired.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrServiceAccountSourceIPDenied: {
+		Code:           "AccessDenied",
+		Description:    "The service account is not allowed to be used from this source IP address.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	// Add your error structure here.
 }
 
@@ -1863,6 +1955,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case errInvalidRange:
 		apiErr = ErrInvalidRange
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	case errDataTooLarge:
 		apiErr = ErrEntityTooLarge
 	case errDataTooSmall:
@@ -1960,6 +2054,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrBucketAlreadyOwnedByYou
 	case ObjectNotFound:
 		apiErr = ErrNoSuchKey
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
 	case MethodNotAllowed:
 		apiErr = ErrMethodNotAllowed
 	case ObjectLocked:
@@ -1994,6 +2090,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
@@ -2012,6 +2110,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketPublicAccessBlockNotFound:
+		apiErr = ErrNoSuchPublicAccessBlockConfiguration
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
@@ -2045,6 +2145,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case BucketVersionQuotaExceeded:
+		apiErr = ErrAdminBucketVersionQuotaExceeded
+	case BucketNoncurrentQuotaExceeded:
+		apiErr = ErrAdminBucketNoncurrentQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -2061,6 +2165,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrFilterNameSuffix
 	case *event.ErrInvalidFilterValue:
 		apiErr = ErrFilterValueInvalid
+	case *event.ErrInvalidFilterKey:
+		apiErr = ErrFilterNameInvalid
 	case *event.ErrDuplicateEventName:
 		apiErr = ErrOverlappingConfigs
 	case *event.ErrDuplicateQueueConfiguration:
@@ -2173,6 +2279,18 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    fmt.Sprintf("Versioning configuration specified in the request is invalid. (%s)", e.Error()),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case inventory.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    fmt.Sprintf("Inventory configuration specified in the request is invalid. (%s)", e.Error()),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    fmt.Sprintf("Logging configuration specified in the request is invalid. (%s)", e.Error()),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case lifecycle.Error:
 			apiErr = APIError{
 				Code:           "InvalidRequest",
@@ -19,8 +19,13 @@ package cmd
 
 import (
 	"encoding/base64"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Parse bucket url queries
@@ -62,6 +67,18 @@ func getListBucketObjectVersionsArgs(values url.Values) (prefix, marker, delimit
 	delimiter = values.Get("delimiter")
 	encodingType = values.Get("encoding-type")
 	versionIDMarker = values.Get("version-id-marker")
+	if versionIDMarker != "" {
+		if marker == "" {
+			errCode = ErrInvalidVersionIDMarker
+			return
+		}
+		if versionIDMarker != nullVersionID {
+			if _, err := uuid.Parse(versionIDMarker); err != nil {
+				errCode = ErrInvalidVersionID
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -151,3 +168,57 @@ func getObjectResources(values url.Values) (uploadID string, partNumberMarker, m
 	encodingType = values.Get("encoding-type")
 	return
 }
+
+// objectAttributes holds the attributes requested by a GetObjectAttributes call.
+type objectAttributes struct {
+	ETag         bool
+	Checksum     bool
+	ObjectParts  bool
+	StorageClass bool
+	ObjectSize   bool
+
+	MaxParts         int
+	PartNumberMarker int
+}
+
+// Parse GetObjectAttributes request headers
+func getObjectAttributesResources(header http.Header) (attributes objectAttributes, errCode APIErrorCode) {
+	for _, values := range header.Values(xhttp.AmzObjectAttributes) {
+		for _, v := range strings.Split(values, ",") {
+			switch strings.TrimSpace(v) {
+			case "ETag":
+				attributes.ETag = true
+			case "Checksum":
+				attributes.Checksum = true
+			case "ObjectParts":
+				attributes.ObjectParts = true
+			case "StorageClass":
+				attributes.StorageClass = true
+			case "ObjectSize":
+				attributes.ObjectSize = true
+			default:
+				return attributes, ErrInvalidObjectAttributes
+			}
+		}
+	}
+	if !attributes.ETag && !attributes.Checksum && !attributes.ObjectParts && !attributes.StorageClass && !attributes.ObjectSize {
+		return attributes, ErrInvalidObjectAttributes
+	}
+
+	var err error
+	attributes.MaxParts = maxAttributeParts
+	if v := header.Get(xhttp.AmzMaxParts); v != "" {
+		if attributes.MaxParts, err = strconv.Atoi(v); err != nil || attributes.MaxParts < 0 {
+			return attributes, ErrInvalidMaxParts
+		}
+		if attributes.MaxParts > maxPartsList {
+			attributes.MaxParts = maxPartsList
+		}
+	}
+	if v := header.Get(xhttp.AmzPartNumberMarker); v != "" {
+		if attributes.PartNumberMarker, err = strconv.Atoi(v); err != nil || attributes.PartNumberMarker < 0 {
+			return attributes, ErrInvalidPartNumberMarker
+		}
+	}
+	return attributes, ErrNone
+}
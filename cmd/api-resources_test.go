@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 )
@@ -179,6 +180,55 @@ func TestListObjectsV1Resources(t *testing.T) {
 	}
 }
 
+func TestListObjectVersionsResources(t *testing.T) {
+	testCases := []struct {
+		values          url.Values
+		marker          string
+		versionIDMarker string
+		errCode         APIErrorCode
+	}{
+		{
+			values: url.Values{"key-marker": []string{"test"}},
+			marker: "test",
+		},
+		{
+			values:          url.Values{"key-marker": []string{"test"}, "version-id-marker": []string{"null"}},
+			marker:          "test",
+			versionIDMarker: "null",
+		},
+		{
+			values:          url.Values{"key-marker": []string{"test"}, "version-id-marker": []string{"3e0a3b5c-3c6b-4c43-9b2b-8f1a4a2f3d21"}},
+			marker:          "test",
+			versionIDMarker: "3e0a3b5c-3c6b-4c43-9b2b-8f1a4a2f3d21",
+		},
+		{
+			values:  url.Values{"version-id-marker": []string{"null"}},
+			errCode: ErrInvalidVersionIDMarker,
+		},
+		{
+			values:  url.Values{"key-marker": []string{"test"}, "version-id-marker": []string{"invalid"}},
+			errCode: ErrInvalidVersionID,
+		},
+	}
+
+	for i, testCase := range testCases {
+		_, marker, _, _, _, versionIDMarker, errCode := getListBucketObjectVersionsArgs(testCase.values)
+		if errCode != testCase.errCode {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.errCode, errCode)
+			continue
+		}
+		if errCode != ErrNone {
+			continue
+		}
+		if marker != testCase.marker {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, testCase.marker, marker)
+		}
+		if versionIDMarker != testCase.versionIDMarker {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, testCase.versionIDMarker, versionIDMarker)
+		}
+	}
+}
+
 // Validates extracting information for object resources.
 func TestGetObjectsResources(t *testing.T) {
 	testCases := []struct {
@@ -220,3 +270,49 @@ func TestGetObjectsResources(t *testing.T) {
 		}
 	}
 }
+
+// Test get object attributes resources.
+func TestGetObjectAttributesResources(t *testing.T) {
+	testCases := []struct {
+		header     http.Header
+		attributes objectAttributes
+		errCode    APIErrorCode
+	}{
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes":  []string{"ETag,ObjectSize", "ObjectParts"},
+				"X-Amz-Max-Parts":          []string{"10"},
+				"X-Amz-Part-Number-Marker": []string{"2"},
+			},
+			attributes: objectAttributes{ETag: true, ObjectSize: true, ObjectParts: true, MaxParts: 10, PartNumberMarker: 2},
+			errCode:    ErrNone,
+		},
+		{
+			header:     http.Header{"X-Amz-Object-Attributes": []string{"Checksum, StorageClass"}},
+			attributes: objectAttributes{Checksum: true, StorageClass: true, MaxParts: maxAttributeParts},
+			errCode:    ErrNone,
+		},
+		{
+			header:  http.Header{},
+			errCode: ErrInvalidObjectAttributes,
+		},
+		{
+			header:  http.Header{"X-Amz-Object-Attributes": []string{"ETag,Owner"}},
+			errCode: ErrInvalidObjectAttributes,
+		},
+		{
+			header:  http.Header{"X-Amz-Object-Attributes": []string{"ETag"}, "X-Amz-Max-Parts": []string{"-1"}},
+			errCode: ErrInvalidMaxParts,
+		},
+	}
+
+	for i, testCase := range testCases {
+		attributes, errCode := getObjectAttributesResources(testCase.header)
+		if errCode != testCase.errCode {
+			t.Fatalf("Test %d: Expected %v, got %v", i+1, testCase.errCode, errCode)
+		}
+		if errCode == ErrNone && attributes != testCase.attributes {
+			t.Errorf("Test %d: Expected %+v, got %+v", i+1, testCase.attributes, attributes)
+		}
+	}
+}
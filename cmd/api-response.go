@@ -22,15 +22,18 @@ import (
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/handlers"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 )
@@ -42,6 +45,7 @@ const (
 	maxDeleteList     = 1000                       // Limit number of objects deleted in a delete call.
 	maxUploadsList    = 10000                      // Limit number of uploads in a listUploadsResponse.
 	maxPartsList      = 10000                      // Limit number of parts in a listPartsResponse.
+	maxAttributeParts = 1000                       // Default number of parts in a getObjectAttributesResponse.
 )
 
 // LocationResponse - format for location response.
@@ -311,6 +315,39 @@ type Object struct {
 	UserMetadata StringMap `xml:"UserMetadata,omitempty"`
 }
 
+// ObjectAttributesChecksum container for the additional checksum of an object.
+type ObjectAttributesChecksum struct {
+	ChecksumCRC32C string `xml:",omitempty"`
+	ChecksumSHA256 string `xml:",omitempty"`
+}
+
+// ObjectAttributesPart container for a part of an object in GetObjectAttributesResponse.
+type ObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+}
+
+// ObjectAttributesParts container for the parts of an object in GetObjectAttributesResponse.
+type ObjectAttributesParts struct {
+	IsTruncated          bool
+	MaxParts             int
+	NextPartNumberMarker int
+	PartNumberMarker     int
+	Parts                []ObjectAttributesPart `xml:"Part"`
+	PartsCount           int
+}
+
+// GetObjectAttributesResponse container for the response of GetObjectAttributes.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                    `xml:",omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:",omitempty"`
+	StorageClass string                    `xml:",omitempty"`
+	ObjectSize   *int64                    `xml:",omitempty"`
+}
+
 // CopyObjectResponse container returns ETag and LastModified of the successfully copied object
 type CopyObjectResponse struct {
 	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult" json:"-"`
@@ -351,6 +388,9 @@ type CompleteMultipartUploadResponse struct {
 	Bucket   string
 	Key      string
 	ETag     string
+
+	ChecksumCRC32C string `xml:",omitempty"`
+	ChecksumSHA256 string `xml:",omitempty"`
 }
 
 // DeleteError structure.
@@ -653,6 +693,63 @@ func generateCompleteMultpartUploadResponse(bucket, key, location, etag string)
 	}
 }
 
+// generates GetObjectAttributesResponse from ObjectInfo.
+func generateGetObjectAttributesResponse(objInfo ObjectInfo, attributes objectAttributes) (GetObjectAttributesResponse, error) {
+	var response GetObjectAttributesResponse
+	if attributes.ETag {
+		response.ETag = objInfo.ETag
+	}
+	if attributes.Checksum {
+		if c := getObjectChecksum(objInfo.UserDefined); c != nil {
+			response.Checksum = &ObjectAttributesChecksum{}
+			switch c.Type {
+			case hash.ChecksumCRC32C:
+				response.Checksum.ChecksumCRC32C = c.Encoded
+			case hash.ChecksumSHA256:
+				response.Checksum.ChecksumSHA256 = c.Encoded
+			}
+		}
+	}
+	if attributes.StorageClass {
+		response.StorageClass = objInfo.StorageClass
+		if response.StorageClass == "" {
+			response.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if attributes.ObjectSize {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			return response, err
+		}
+		response.ObjectSize = &size
+	}
+	// Parts are only returned for objects uploaded using multipart upload.
+	if attributes.ObjectParts && strings.Contains(objInfo.ETag, "-") {
+		parts := &ObjectAttributesParts{
+			MaxParts:         attributes.MaxParts,
+			PartNumberMarker: attributes.PartNumberMarker,
+			PartsCount:       len(objInfo.Parts),
+		}
+		for _, part := range objInfo.Parts {
+			if part.Number <= attributes.PartNumberMarker {
+				continue
+			}
+			if len(parts.Parts) == attributes.MaxParts {
+				parts.IsTruncated = true
+				break
+			}
+			size := part.ActualSize
+			if size <= 0 {
+				size = part.Size
+			}
+			parts.Parts = append(parts.Parts, ObjectAttributesPart{PartNumber: part.Number, Size: size})
+			parts.NextPartNumberMarker = part.Number
+		}
+		response.ObjectParts = parts
+	}
+	return response, nil
+}
+
 // generates ListPartsResponse from ListPartsInfo.
 func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) ListPartsResponse {
 	listPartsResponse := ListPartsResponse{}
@@ -728,6 +825,55 @@ func generateMultiDeleteResponse(quiet bool, deletedObjects []DeletedObject, err
 	return deleteResp
 }
 
+// multiDeleteResponseWriter streams a multi-object delete response, the
+// entries are written and flushed to the client as the deletes complete,
+// the length of the response is not known upfront.
+type multiDeleteResponseWriter struct {
+	mu    sync.Mutex
+	w     http.ResponseWriter
+	enc   *xml.Encoder
+	quiet bool
+}
+
+var deleteResultName = xml.Name{Space: "http://s3.amazonaws.com/doc/2006-03-01/", Local: "DeleteResult"}
+
+// newMultiDeleteResponseWriter writes the headers and the start of the
+// response, no error response can be written afterwards.
+func newMultiDeleteResponseWriter(w http.ResponseWriter, quiet bool) *multiDeleteResponseWriter {
+	setCommonHeaders(w)
+	w.Header().Set(xhttp.ContentType, string(mimeXML))
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, xml.Header)
+	enc := xml.NewEncoder(w)
+	enc.EncodeToken(xml.StartElement{Name: deleteResultName})
+	return &multiDeleteResponseWriter{w: w, enc: enc, quiet: quiet}
+}
+
+// write writes the entries of deleted objects, unless the response is
+// quiet, and of errors, then flushes them to the client.
+func (d *multiDeleteResponseWriter) write(deletedObjects []DeletedObject, errs []DeleteError) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.quiet {
+		for _, dobj := range deletedObjects {
+			d.enc.EncodeElement(dobj, xml.StartElement{Name: xml.Name{Local: "Deleted"}})
+		}
+	}
+	for _, derr := range errs {
+		d.enc.EncodeElement(derr, xml.StartElement{Name: xml.Name{Local: "Error"}})
+	}
+	d.enc.Flush()
+	d.w.(http.Flusher).Flush()
+}
+
+// close writes the end of the response.
+func (d *multiDeleteResponseWriter) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enc.EncodeToken(xml.EndElement{Name: deleteResultName})
+	d.enc.Flush()
+}
+
 func writeResponse(w http.ResponseWriter, statusCode int, response []byte, mType mimeType) {
 	setCommonHeaders(w)
 	if mType != mimeNone {
@@ -783,9 +929,12 @@ func writeSuccessResponseHeadersOnly(w http.ResponseWriter) {
 func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 	switch err.Code {
 	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
-		// Set retry-after header to indicate user-agents to retry request after 120secs.
+		// Set retry-after header to indicate user-agents to retry request after 120secs,
+		// unless the caller already knows when the request may be retried.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
-		w.Header().Set(xhttp.RetryAfter, "120")
+		if w.Header().Get(xhttp.RetryAfter) == "" {
+			w.Header().Set(xhttp.RetryAfter, "120")
+		}
 	case "InvalidRegion":
 		err.Description = fmt.Sprintf("Region does not match; expecting '%s'.", globalSite.Region)
 	case "AuthorizationHeaderMalformed":
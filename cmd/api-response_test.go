@@ -123,3 +123,36 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests generating GetObjectAttributes responses.
+func TestGenerateGetObjectAttributesResponse(t *testing.T) {
+	objInfo := ObjectInfo{
+		ETag: "d41d8cd98f00b204e9800998ecf8427e-3",
+		Size: 12,
+		Parts: []ObjectPartInfo{
+			{Number: 1, Size: 5, ActualSize: 5},
+			{Number: 2, Size: 5, ActualSize: 5},
+			{Number: 3, Size: 2, ActualSize: 2},
+		},
+		UserDefined: map[string]string{objectChecksumKey: "CRC32C:ksgKMQ==-3"},
+	}
+	attributes := objectAttributes{ETag: true, Checksum: true, ObjectParts: true, StorageClass: true, ObjectSize: true, MaxParts: 1, PartNumberMarker: 1}
+	response, err := generateGetObjectAttributesResponse(objInfo, attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.ETag != objInfo.ETag || response.StorageClass != globalMinioDefaultStorageClass {
+		t.Errorf("unexpected etag %s or storage class %s", response.ETag, response.StorageClass)
+	}
+	if response.ObjectSize == nil || *response.ObjectSize != 12 {
+		t.Errorf("unexpected object size %v", response.ObjectSize)
+	}
+	if response.Checksum == nil || response.Checksum.ChecksumCRC32C != "ksgKMQ==-3" {
+		t.Errorf("unexpected checksum %v", response.Checksum)
+	}
+	parts := response.ObjectParts
+	if parts == nil || parts.PartsCount != 3 || !parts.IsTruncated || parts.NextPartNumberMarker != 2 ||
+		len(parts.Parts) != 1 || parts.Parts[0].PartNumber != 2 {
+		t.Errorf("unexpected object parts %+v", parts)
+	}
+}
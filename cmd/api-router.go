@@ -122,11 +122,6 @@ var rejectedObjAPIs = []rejectedAPI{
 }
 
 var rejectedBucketAPIs = []rejectedAPI{
-	{
-		api:     "inventory",
-		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
-		queries: []string{"inventory", ""},
-	},
 	{
 		api:     "cors",
 		methods: []string{http.MethodPut, http.MethodDelete},
@@ -195,6 +190,25 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
+	// S3 batch operations, the S3 control API is only told apart
+	// from bucket requests by its account ID header.
+	jobsRouter := apiRouter.PathPrefix("/v20180820/jobs").HeadersRegexp(xhttp.AmzAccountID, ".*").Subrouter()
+	// CreateJob
+	jobsRouter.Methods(http.MethodPost).Path("").HandlerFunc(
+		collectAPIStats("createjob", maxClients(httpTraceAll(api.CreateJobHandler))))
+	// ListJobs
+	jobsRouter.Methods(http.MethodGet).Path("").HandlerFunc(
+		collectAPIStats("listjobs", maxClients(httpTraceAll(api.ListJobsHandler))))
+	// DescribeJob
+	jobsRouter.Methods(http.MethodGet).Path("/{id:[0-9a-f-]+}").HandlerFunc(
+		collectAPIStats("describejob", maxClients(httpTraceAll(api.DescribeJobHandler))))
+	// UpdateJobPriority
+	jobsRouter.Methods(http.MethodPost).Path("/{id:[0-9a-f-]+}/priority").HandlerFunc(
+		collectAPIStats("updatejobpriority", maxClients(httpTraceAll(api.UpdateJobPriorityHandler)))).Queries("priority", "{priority:.*}")
+	// UpdateJobStatus
+	jobsRouter.Methods(http.MethodPost).Path("/{id:[0-9a-f-]+}/status").HandlerFunc(
+		collectAPIStats("updatejobstatus", maxClients(httpTraceAll(api.UpdateJobStatusHandler)))).Queries("requestedJobStatus", "{requestedJobStatus:.*}")
+
 	var routers []*mux.Router
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
@@ -248,7 +262,7 @@ func registerAPIRouter(router *mux.Router) {
 			Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 		// PutObjectPart
 		router.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(
-			collectAPIStats("putobjectpart", maxClients(gz(httpTraceHdrs(api.PutObjectPartHandler))))).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+			collectAPIStats("putobjectpart", maxClients(admitRequest(gz(httpTraceHdrs(api.PutObjectPartHandler)))))).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
 		// ListObjectParts
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("listobjectparts", maxClients(gz(httpTraceAll(api.ListObjectPartsHandler))))).Queries("uploadId", "{uploadId:.*}")
@@ -282,12 +296,15 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectRetention
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectretention", maxClients(gz(httpTraceAll(api.GetObjectRetentionHandler))))).Queries("retention", "")
+		// GetObjectAttributes
+		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(gz(httpTraceHdrs(api.GetObjectAttributesHandler))))).Queries("attributes", "")
 		// GetObjectLegalHold
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(gz(httpTraceAll(api.GetObjectLegalHoldHandler))))).Queries("legal-hold", "")
 		// GetObject - note gzip compression is *not* added due to Range requests.
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
-			collectAPIStats("getobject", maxClients(httpTraceHdrs(api.GetObjectHandler))))
+			collectAPIStats("getobject", maxClients(admitRequest(httpTraceHdrs(api.GetObjectHandler)))))
 		// CopyObject
 		router.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.AmzCopySource, ".*?(\\/|%2F).*?").HandlerFunc(
 			collectAPIStats("copyobject", maxClients(gz(httpTraceAll(api.CopyObjectHandler)))))
@@ -300,11 +317,11 @@ func registerAPIRouter(router *mux.Router) {
 
 		// PutObject with auto-extract support for zip
 		router.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.AmzSnowballExtract, "true").HandlerFunc(
-			collectAPIStats("putobject", maxClients(gz(httpTraceHdrs(api.PutObjectExtractHandler)))))
+			collectAPIStats("putobject", maxClients(admitRequest(gz(httpTraceHdrs(api.PutObjectExtractHandler))))))
 
 		// PutObject
 		router.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(
-			collectAPIStats("putobject", maxClients(gz(httpTraceHdrs(api.PutObjectHandler)))))
+			collectAPIStats("putobject", maxClients(admitRequest(gz(httpTraceHdrs(api.PutObjectHandler))))))
 
 		// DeleteObject
 		router.Methods(http.MethodDelete).Path("/{object:.+}").HandlerFunc(
@@ -328,6 +345,12 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketEncryption
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketencryption", maxClients(gz(httpTraceAll(api.GetBucketEncryptionHandler))))).Queries("encryption", "")
+		// GetBucketInventoryConfiguration
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.GetBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
+		// ListBucketInventoryConfigurations
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("listbucketinventoryconfigurations", maxClients(gz(httpTraceAll(api.ListBucketInventoryConfigurationsHandler))))).Queries("inventory", "")
 		// GetBucketObjectLockConfig
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketobjectlockconfiguration", maxClients(gz(httpTraceAll(api.GetBucketObjectLockConfigHandler))))).Queries("object-lock", "")
@@ -363,7 +386,7 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketRequestPaymentHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(gz(httpTraceAll(api.GetBucketRequestPaymentHandler))))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
+		// GetBucketLogging
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlogging", maxClients(gz(httpTraceAll(api.GetBucketLoggingHandler))))).Queries("logging", "")
 		// GetBucketTaggingHandler
@@ -391,6 +414,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketPolicyStatus
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getpolicystatus", maxClients(gz(httpTraceAll(api.GetBucketPolicyStatusHandler))))).Queries("policyStatus", "")
+		// GetPublicAccessBlock
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getpublicaccessblock", maxClients(gz(httpTraceAll(api.GetPublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
 		// PutBucketLifecycle
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketlifecycle", maxClients(gz(httpTraceAll(api.PutBucketLifecycleHandler))))).Queries("lifecycle", "")
@@ -405,6 +431,17 @@ func registerAPIRouter(router *mux.Router) {
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketpolicy", maxClients(gz(httpTraceAll(api.PutBucketPolicyHandler))))).Queries("policy", "")
 
+		// PutBucketLogging
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(gz(httpTraceAll(api.PutBucketLoggingHandler))))).Queries("logging", "")
+
+		// PutPublicAccessBlock
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putpublicaccessblock", maxClients(gz(httpTraceAll(api.PutPublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
+
+		// PutBucketInventoryConfiguration
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.PutBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
 		// PutBucketObjectLockConfig
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketobjectlockconfig", maxClients(gz(httpTraceAll(api.PutBucketObjectLockConfigHandler))))).Queries("object-lock", "")
@@ -435,6 +472,9 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketPolicy
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketpolicy", maxClients(gz(httpTraceAll(api.DeleteBucketPolicyHandler))))).Queries("policy", "")
+		// DeletePublicAccessBlock
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletepublicaccessblock", maxClients(gz(httpTraceAll(api.DeletePublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
 		// DeleteBucketReplication
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketreplicationconfiguration", maxClients(gz(httpTraceAll(api.DeleteBucketReplicationConfigHandler))))).Queries("replication", "")
@@ -444,6 +484,9 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketEncryption
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketencryption", maxClients(gz(httpTraceAll(api.DeleteBucketEncryptionHandler))))).Queries("encryption", "")
+		// DeleteBucketInventoryConfiguration
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketinventoryconfiguration", maxClients(gz(httpTraceAll(api.DeleteBucketInventoryConfigurationHandler))))).Queries("inventory", "", "id", "{id:.*}")
 		// DeleteBucket
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucket", maxClients(gz(httpTraceAll(api.DeleteBucketHandler)))))
@@ -453,6 +496,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketReplicationMetrics
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketreplicationmetrics", maxClients(gz(httpTraceAll(api.GetBucketReplicationMetricsHandler))))).Queries("replication-metrics", "")
+		// GetBucketReplicationLockDrift
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketreplicationlockdrift", maxClients(httpTraceAll(api.GetBucketReplicationLockDriftHandler)))).Queries("replication-lock-drift", "")
 
 		// Register rejected bucket APIs
 		for _, r := range rejectedBucketAPIs {
@@ -28,275 +28,290 @@ func _() {
 	_ = x[ErrInvalidMaxUploads-17]
 	_ = x[ErrInvalidMaxParts-18]
 	_ = x[ErrInvalidPartNumberMarker-19]
-	_ = x[ErrInvalidPartNumber-20]
-	_ = x[ErrInvalidRequestBody-21]
-	_ = x[ErrInvalidCopySource-22]
-	_ = x[ErrInvalidMetadataDirective-23]
-	_ = x[ErrInvalidCopyDest-24]
-	_ = x[ErrInvalidPolicyDocument-25]
-	_ = x[ErrInvalidObjectState-26]
-	_ = x[ErrMalformedXML-27]
-	_ = x[ErrMissingContentLength-28]
-	_ = x[ErrMissingContentMD5-29]
-	_ = x[ErrMissingRequestBodyError-30]
-	_ = x[ErrMissingSecurityHeader-31]
-	_ = x[ErrNoSuchBucket-32]
-	_ = x[ErrNoSuchBucketPolicy-33]
-	_ = x[ErrNoSuchBucketLifecycle-34]
-	_ = x[ErrNoSuchLifecycleConfiguration-35]
-	_ = x[ErrInvalidLifecycleWithObjectLock-36]
-	_ = x[ErrNoSuchBucketSSEConfig-37]
-	_ = x[ErrNoSuchCORSConfiguration-38]
-	_ = x[ErrNoSuchWebsiteConfiguration-39]
-	_ = x[ErrReplicationConfigurationNotFoundError-40]
-	_ = x[ErrRemoteDestinationNotFoundError-41]
-	_ = x[ErrReplicationDestinationMissingLock-42]
-	_ = x[ErrRemoteTargetNotFoundError-43]
-	_ = x[ErrReplicationRemoteConnectionError-44]
-	_ = x[ErrReplicationBandwidthLimitError-45]
-	_ = x[ErrBucketRemoteIdenticalToSource-46]
-	_ = x[ErrBucketRemoteAlreadyExists-47]
-	_ = x[ErrBucketRemoteLabelInUse-48]
-	_ = x[ErrBucketRemoteArnTypeInvalid-49]
-	_ = x[ErrBucketRemoteArnInvalid-50]
-	_ = x[ErrBucketRemoteRemoveDisallowed-51]
-	_ = x[ErrRemoteTargetNotVersionedError-52]
-	_ = x[ErrReplicationSourceNotVersionedError-53]
-	_ = x[ErrReplicationNeedsVersioningError-54]
-	_ = x[ErrReplicationBucketNeedsVersioningError-55]
-	_ = x[ErrReplicationNoMatchingRuleError-56]
-	_ = x[ErrObjectRestoreAlreadyInProgress-57]
-	_ = x[ErrNoSuchKey-58]
-	_ = x[ErrNoSuchUpload-59]
-	_ = x[ErrInvalidVersionID-60]
-	_ = x[ErrNoSuchVersion-61]
-	_ = x[ErrNotImplemented-62]
-	_ = x[ErrPreconditionFailed-63]
-	_ = x[ErrRequestTimeTooSkewed-64]
-	_ = x[ErrSignatureDoesNotMatch-65]
-	_ = x[ErrMethodNotAllowed-66]
-	_ = x[ErrInvalidPart-67]
-	_ = x[ErrInvalidPartOrder-68]
-	_ = x[ErrAuthorizationHeaderMalformed-69]
-	_ = x[ErrMalformedPOSTRequest-70]
-	_ = x[ErrPOSTFileRequired-71]
-	_ = x[ErrSignatureVersionNotSupported-72]
-	_ = x[ErrBucketNotEmpty-73]
-	_ = x[ErrAllAccessDisabled-74]
-	_ = x[ErrMalformedPolicy-75]
-	_ = x[ErrMissingFields-76]
-	_ = x[ErrMissingCredTag-77]
-	_ = x[ErrCredMalformed-78]
-	_ = x[ErrInvalidRegion-79]
-	_ = x[ErrInvalidServiceS3-80]
-	_ = x[ErrInvalidServiceSTS-81]
-	_ = x[ErrInvalidRequestVersion-82]
-	_ = x[ErrMissingSignTag-83]
-	_ = x[ErrMissingSignHeadersTag-84]
-	_ = x[ErrMalformedDate-85]
-	_ = x[ErrMalformedPresignedDate-86]
-	_ = x[ErrMalformedCredentialDate-87]
-	_ = x[ErrMalformedCredentialRegion-88]
-	_ = x[ErrMalformedExpires-89]
-	_ = x[ErrNegativeExpires-90]
-	_ = x[ErrAuthHeaderEmpty-91]
-	_ = x[ErrExpiredPresignRequest-92]
-	_ = x[ErrRequestNotReadyYet-93]
-	_ = x[ErrUnsignedHeaders-94]
-	_ = x[ErrMissingDateHeader-95]
-	_ = x[ErrInvalidQuerySignatureAlgo-96]
-	_ = x[ErrInvalidQueryParams-97]
-	_ = x[ErrBucketAlreadyOwnedByYou-98]
-	_ = x[ErrInvalidDuration-99]
-	_ = x[ErrBucketAlreadyExists-100]
-	_ = x[ErrMetadataTooLarge-101]
-	_ = x[ErrUnsupportedMetadata-102]
-	_ = x[ErrMaximumExpires-103]
-	_ = x[ErrSlowDown-104]
-	_ = x[ErrInvalidPrefixMarker-105]
-	_ = x[ErrBadRequest-106]
-	_ = x[ErrKeyTooLongError-107]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-108]
-	_ = x[ErrObjectLockConfigurationNotFound-109]
-	_ = x[ErrObjectLockConfigurationNotAllowed-110]
-	_ = x[ErrNoSuchObjectLockConfiguration-111]
-	_ = x[ErrObjectLocked-112]
-	_ = x[ErrInvalidRetentionDate-113]
-	_ = x[ErrPastObjectLockRetainDate-114]
-	_ = x[ErrUnknownWORMModeDirective-115]
-	_ = x[ErrBucketTaggingNotFound-116]
-	_ = x[ErrObjectLockInvalidHeaders-117]
-	_ = x[ErrInvalidTagDirective-118]
-	_ = x[ErrInvalidEncryptionMethod-119]
-	_ = x[ErrInsecureSSECustomerRequest-120]
-	_ = x[ErrSSEMultipartEncrypted-121]
-	_ = x[ErrSSEEncryptedObject-122]
-	_ = x[ErrInvalidEncryptionParameters-123]
-	_ = x[ErrInvalidSSECustomerAlgorithm-124]
-	_ = x[ErrInvalidSSECustomerKey-125]
-	_ = x[ErrMissingSSECustomerKey-126]
-	_ = x[ErrMissingSSECustomerKeyMD5-127]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-128]
-	_ = x[ErrInvalidSSECustomerParameters-129]
-	_ = x[ErrIncompatibleEncryptionMethod-130]
-	_ = x[ErrKMSNotConfigured-131]
-	_ = x[ErrNoAccessKey-132]
-	_ = x[ErrInvalidToken-133]
-	_ = x[ErrEventNotification-134]
-	_ = x[ErrARNNotification-135]
-	_ = x[ErrRegionNotification-136]
-	_ = x[ErrOverlappingFilterNotification-137]
-	_ = x[ErrFilterNameInvalid-138]
-	_ = x[ErrFilterNamePrefix-139]
-	_ = x[ErrFilterNameSuffix-140]
-	_ = x[ErrFilterValueInvalid-141]
-	_ = x[ErrOverlappingConfigs-142]
-	_ = x[ErrUnsupportedNotification-143]
-	_ = x[ErrContentSHA256Mismatch-144]
-	_ = x[ErrReadQuorum-145]
-	_ = x[ErrWriteQuorum-146]
-	_ = x[ErrStorageFull-147]
-	_ = x[ErrRequestBodyParse-148]
-	_ = x[ErrObjectExistsAsDirectory-149]
-	_ = x[ErrInvalidObjectName-150]
-	_ = x[ErrInvalidObjectNamePrefixSlash-151]
-	_ = x[ErrInvalidResourceName-152]
-	_ = x[ErrServerNotInitialized-153]
-	_ = x[ErrOperationTimedOut-154]
-	_ = x[ErrClientDisconnected-155]
-	_ = x[ErrOperationMaxedOut-156]
-	_ = x[ErrInvalidRequest-157]
-	_ = x[ErrTransitionStorageClassNotFoundError-158]
-	_ = x[ErrInvalidStorageClass-159]
-	_ = x[ErrBackendDown-160]
-	_ = x[ErrMalformedJSON-161]
-	_ = x[ErrAdminNoSuchUser-162]
-	_ = x[ErrAdminNoSuchGroup-163]
-	_ = x[ErrAdminGroupNotEmpty-164]
-	_ = x[ErrAdminNoSuchPolicy-165]
-	_ = x[ErrAdminInvalidArgument-166]
-	_ = x[ErrAdminInvalidAccessKey-167]
-	_ = x[ErrAdminInvalidSecretKey-168]
-	_ = x[ErrAdminConfigNoQuorum-169]
-	_ = x[ErrAdminConfigTooLarge-170]
-	_ = x[ErrAdminConfigBadJSON-171]
-	_ = x[ErrAdminConfigDuplicateKeys-172]
-	_ = x[ErrAdminCredentialsMismatch-173]
-	_ = x[ErrInsecureClientRequest-174]
-	_ = x[ErrObjectTampered-175]
-	_ = x[ErrSiteReplicationInvalidRequest-176]
-	_ = x[ErrSiteReplicationPeerResp-177]
-	_ = x[ErrSiteReplicationBackendIssue-178]
-	_ = x[ErrSiteReplicationServiceAccountError-179]
-	_ = x[ErrSiteReplicationBucketConfigError-180]
-	_ = x[ErrSiteReplicationBucketMetaError-181]
-	_ = x[ErrSiteReplicationIAMError-182]
-	_ = x[ErrAdminBucketQuotaExceeded-183]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-184]
-	_ = x[ErrHealNotImplemented-185]
-	_ = x[ErrHealNoSuchProcess-186]
-	_ = x[ErrHealInvalidClientToken-187]
-	_ = x[ErrHealMissingBucket-188]
-	_ = x[ErrHealAlreadyRunning-189]
-	_ = x[ErrHealOverlappingPaths-190]
-	_ = x[ErrIncorrectContinuationToken-191]
-	_ = x[ErrEmptyRequestBody-192]
-	_ = x[ErrUnsupportedFunction-193]
-	_ = x[ErrInvalidExpressionType-194]
-	_ = x[ErrBusy-195]
-	_ = x[ErrUnauthorizedAccess-196]
-	_ = x[ErrExpressionTooLong-197]
-	_ = x[ErrIllegalSQLFunctionArgument-198]
-	_ = x[ErrInvalidKeyPath-199]
-	_ = x[ErrInvalidCompressionFormat-200]
-	_ = x[ErrInvalidFileHeaderInfo-201]
-	_ = x[ErrInvalidJSONType-202]
-	_ = x[ErrInvalidQuoteFields-203]
-	_ = x[ErrInvalidRequestParameter-204]
-	_ = x[ErrInvalidDataType-205]
-	_ = x[ErrInvalidTextEncoding-206]
-	_ = x[ErrInvalidDataSource-207]
-	_ = x[ErrInvalidTableAlias-208]
-	_ = x[ErrMissingRequiredParameter-209]
-	_ = x[ErrObjectSerializationConflict-210]
-	_ = x[ErrUnsupportedSQLOperation-211]
-	_ = x[ErrUnsupportedSQLStructure-212]
-	_ = x[ErrUnsupportedSyntax-213]
-	_ = x[ErrUnsupportedRangeHeader-214]
-	_ = x[ErrLexerInvalidChar-215]
-	_ = x[ErrLexerInvalidOperator-216]
-	_ = x[ErrLexerInvalidLiteral-217]
-	_ = x[ErrLexerInvalidIONLiteral-218]
-	_ = x[ErrParseExpectedDatePart-219]
-	_ = x[ErrParseExpectedKeyword-220]
-	_ = x[ErrParseExpectedTokenType-221]
-	_ = x[ErrParseExpected2TokenTypes-222]
-	_ = x[ErrParseExpectedNumber-223]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-224]
-	_ = x[ErrParseExpectedTypeName-225]
-	_ = x[ErrParseExpectedWhenClause-226]
-	_ = x[ErrParseUnsupportedToken-227]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-228]
-	_ = x[ErrParseExpectedMember-229]
-	_ = x[ErrParseUnsupportedSelect-230]
-	_ = x[ErrParseUnsupportedCase-231]
-	_ = x[ErrParseUnsupportedCaseClause-232]
-	_ = x[ErrParseUnsupportedAlias-233]
-	_ = x[ErrParseUnsupportedSyntax-234]
-	_ = x[ErrParseUnknownOperator-235]
-	_ = x[ErrParseMissingIdentAfterAt-236]
-	_ = x[ErrParseUnexpectedOperator-237]
-	_ = x[ErrParseUnexpectedTerm-238]
-	_ = x[ErrParseUnexpectedToken-239]
-	_ = x[ErrParseUnexpectedKeyword-240]
-	_ = x[ErrParseExpectedExpression-241]
-	_ = x[ErrParseExpectedLeftParenAfterCast-242]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-243]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-244]
-	_ = x[ErrParseExpectedArgumentDelimiter-245]
-	_ = x[ErrParseCastArity-246]
-	_ = x[ErrParseInvalidTypeParam-247]
-	_ = x[ErrParseEmptySelect-248]
-	_ = x[ErrParseSelectMissingFrom-249]
-	_ = x[ErrParseExpectedIdentForGroupName-250]
-	_ = x[ErrParseExpectedIdentForAlias-251]
-	_ = x[ErrParseUnsupportedCallWithStar-252]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-253]
-	_ = x[ErrParseMalformedJoin-254]
-	_ = x[ErrParseExpectedIdentForAt-255]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-256]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-257]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-258]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-259]
-	_ = x[ErrValueParseFailure-260]
-	_ = x[ErrEvaluatorInvalidArguments-261]
-	_ = x[ErrIntegerOverflow-262]
-	_ = x[ErrLikeInvalidInputs-263]
-	_ = x[ErrCastFailed-264]
-	_ = x[ErrInvalidCast-265]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-266]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-267]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-268]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-269]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-270]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-271]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-272]
-	_ = x[ErrEvaluatorBindingDoesNotExist-273]
-	_ = x[ErrMissingHeaders-274]
-	_ = x[ErrInvalidColumnIndex-275]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-276]
-	_ = x[ErrAdminProfilerNotEnabled-277]
-	_ = x[ErrInvalidDecompressedSize-278]
-	_ = x[ErrAddUserInvalidArgument-279]
-	_ = x[ErrAdminAccountNotEligible-280]
-	_ = x[ErrAccountNotEligible-281]
-	_ = x[ErrAdminServiceAccountNotFound-282]
-	_ = x[ErrPostPolicyConditionInvalidFormat-283]
+	_ = x[ErrInvalidVersionIDMarker-20]
+	_ = x[ErrInvalidPartNumber-21]
+	_ = x[ErrInvalidRequestBody-22]
+	_ = x[ErrInvalidCopySource-23]
+	_ = x[ErrInvalidMetadataDirective-24]
+	_ = x[ErrInvalidCopyDest-25]
+	_ = x[ErrInvalidPolicyDocument-26]
+	_ = x[ErrInvalidObjectState-27]
+	_ = x[ErrMalformedXML-28]
+	_ = x[ErrMissingContentLength-29]
+	_ = x[ErrMissingContentMD5-30]
+	_ = x[ErrMissingRequestBodyError-31]
+	_ = x[ErrMissingSecurityHeader-32]
+	_ = x[ErrNoSuchBucket-33]
+	_ = x[ErrNoSuchBucketPolicy-34]
+	_ = x[ErrNoSuchBucketLifecycle-35]
+	_ = x[ErrNoSuchLifecycleConfiguration-36]
+	_ = x[ErrInvalidLifecycleWithObjectLock-37]
+	_ = x[ErrNoSuchBucketSSEConfig-38]
+	_ = x[ErrNoSuchCORSConfiguration-39]
+	_ = x[ErrNoSuchWebsiteConfiguration-40]
+	_ = x[ErrNoSuchInventoryConfiguration-41]
+	_ = x[ErrNoSuchBatchJob-42]
+	_ = x[ErrBatchJobStatus-43]
+	_ = x[ErrReplicationConfigurationNotFoundError-44]
+	_ = x[ErrRemoteDestinationNotFoundError-45]
+	_ = x[ErrReplicationDestinationMissingLock-46]
+	_ = x[ErrRemoteTargetNotFoundError-47]
+	_ = x[ErrReplicationRemoteConnectionError-48]
+	_ = x[ErrReplicationBandwidthLimitError-49]
+	_ = x[ErrBucketRemoteIdenticalToSource-50]
+	_ = x[ErrBucketRemoteAlreadyExists-51]
+	_ = x[ErrBucketRemoteLabelInUse-52]
+	_ = x[ErrBucketRemoteArnTypeInvalid-53]
+	_ = x[ErrBucketRemoteArnInvalid-54]
+	_ = x[ErrBucketRemoteRemoveDisallowed-55]
+	_ = x[ErrRemoteTargetNotVersionedError-56]
+	_ = x[ErrReplicationSourceNotVersionedError-57]
+	_ = x[ErrReplicationNeedsVersioningError-58]
+	_ = x[ErrReplicationBucketNeedsVersioningError-59]
+	_ = x[ErrReplicationNoMatchingRuleError-60]
+	_ = x[ErrObjectRestoreAlreadyInProgress-61]
+	_ = x[ErrNoSuchKey-62]
+	_ = x[ErrNoSuchUpload-63]
+	_ = x[ErrInvalidVersionID-64]
+	_ = x[ErrNoSuchVersion-65]
+	_ = x[ErrNotImplemented-66]
+	_ = x[ErrPreconditionFailed-67]
+	_ = x[ErrRequestTimeTooSkewed-68]
+	_ = x[ErrSignatureDoesNotMatch-69]
+	_ = x[ErrMethodNotAllowed-70]
+	_ = x[ErrInvalidPart-71]
+	_ = x[ErrInvalidPartOrder-72]
+	_ = x[ErrAuthorizationHeaderMalformed-73]
+	_ = x[ErrMalformedPOSTRequest-74]
+	_ = x[ErrPOSTFileRequired-75]
+	_ = x[ErrSignatureVersionNotSupported-76]
+	_ = x[ErrBucketNotEmpty-77]
+	_ = x[ErrAllAccessDisabled-78]
+	_ = x[ErrMalformedPolicy-79]
+	_ = x[ErrMissingFields-80]
+	_ = x[ErrMissingCredTag-81]
+	_ = x[ErrCredMalformed-82]
+	_ = x[ErrInvalidRegion-83]
+	_ = x[ErrInvalidServiceS3-84]
+	_ = x[ErrInvalidServiceSTS-85]
+	_ = x[ErrInvalidRequestVersion-86]
+	_ = x[ErrMissingSignTag-87]
+	_ = x[ErrMissingSignHeadersTag-88]
+	_ = x[ErrMalformedDate-89]
+	_ = x[ErrMalformedPresignedDate-90]
+	_ = x[ErrMalformedCredentialDate-91]
+	_ = x[ErrMalformedCredentialRegion-92]
+	_ = x[ErrMalformedExpires-93]
+	_ = x[ErrNegativeExpires-94]
+	_ = x[ErrAuthHeaderEmpty-95]
+	_ = x[ErrExpiredPresignRequest-96]
+	_ = x[ErrRequestNotReadyYet-97]
+	_ = x[ErrUnsignedHeaders-98]
+	_ = x[ErrMissingDateHeader-99]
+	_ = x[ErrInvalidQuerySignatureAlgo-100]
+	_ = x[ErrInvalidQueryParams-101]
+	_ = x[ErrBucketAlreadyOwnedByYou-102]
+	_ = x[ErrInvalidDuration-103]
+	_ = x[ErrBucketAlreadyExists-104]
+	_ = x[ErrMetadataTooLarge-105]
+	_ = x[ErrUnsupportedMetadata-106]
+	_ = x[ErrMaximumExpires-107]
+	_ = x[ErrSlowDown-108]
+	_ = x[ErrInvalidPrefixMarker-109]
+	_ = x[ErrBadRequest-110]
+	_ = x[ErrKeyTooLongError-111]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-112]
+	_ = x[ErrObjectLockConfigurationNotFound-113]
+	_ = x[ErrObjectLockConfigurationNotAllowed-114]
+	_ = x[ErrNoSuchObjectLockConfiguration-115]
+	_ = x[ErrObjectLocked-116]
+	_ = x[ErrInvalidRetentionDate-117]
+	_ = x[ErrPastObjectLockRetainDate-118]
+	_ = x[ErrUnknownWORMModeDirective-119]
+	_ = x[ErrBucketTaggingNotFound-120]
+	_ = x[ErrNoSuchPublicAccessBlockConfiguration-121]
+	_ = x[ErrObjectLockInvalidHeaders-122]
+	_ = x[ErrInvalidTagDirective-123]
+	_ = x[ErrInvalidEncryptionMethod-124]
+	_ = x[ErrInsecureSSECustomerRequest-125]
+	_ = x[ErrSSEMultipartEncrypted-126]
+	_ = x[ErrSSEEncryptedObject-127]
+	_ = x[ErrInvalidEncryptionParameters-128]
+	_ = x[ErrInvalidSSECustomerAlgorithm-129]
+	_ = x[ErrInvalidSSECustomerKey-130]
+	_ = x[ErrMissingSSECustomerKey-131]
+	_ = x[ErrMissingSSECustomerKeyMD5-132]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-133]
+	_ = x[ErrInvalidSSECustomerParameters-134]
+	_ = x[ErrIncompatibleEncryptionMethod-135]
+	_ = x[ErrKMSNotConfigured-136]
+	_ = x[ErrNoAccessKey-137]
+	_ = x[ErrInvalidToken-138]
+	_ = x[ErrEventNotification-139]
+	_ = x[ErrARNNotification-140]
+	_ = x[ErrRegionNotification-141]
+	_ = x[ErrOverlappingFilterNotification-142]
+	_ = x[ErrFilterNameInvalid-143]
+	_ = x[ErrFilterNamePrefix-144]
+	_ = x[ErrFilterNameSuffix-145]
+	_ = x[ErrFilterValueInvalid-146]
+	_ = x[ErrOverlappingConfigs-147]
+	_ = x[ErrUnsupportedNotification-148]
+	_ = x[ErrContentSHA256Mismatch-149]
+	_ = x[ErrContentChecksumMismatch-150]
+	_ = x[ErrInvalidChecksum-151]
+	_ = x[ErrInvalidObjectAttributes-152]
+	_ = x[ErrReadQuorum-153]
+	_ = x[ErrWriteQuorum-154]
+	_ = x[ErrStorageFull-155]
+	_ = x[ErrRequestBodyParse-156]
+	_ = x[ErrObjectExistsAsDirectory-157]
+	_ = x[ErrInvalidObjectName-158]
+	_ = x[ErrInvalidObjectNamePrefixSlash-159]
+	_ = x[ErrInvalidResourceName-160]
+	_ = x[ErrServerNotInitialized-161]
+	_ = x[ErrOperationTimedOut-162]
+	_ = x[ErrClientDisconnected-163]
+	_ = x[ErrOperationMaxedOut-164]
+	_ = x[ErrInvalidRequest-165]
+	_ = x[ErrTransitionStorageClassNotFoundError-166]
+	_ = x[ErrInvalidStorageClass-167]
+	_ = x[ErrBackendDown-168]
+	_ = x[ErrMalformedJSON-169]
+	_ = x[ErrAdminNoSuchUser-170]
+	_ = x[ErrAdminNoSuchGroup-171]
+	_ = x[ErrAdminGroupNotEmpty-172]
+	_ = x[ErrAdminNoSuchPolicy-173]
+	_ = x[ErrAdminInvalidArgument-174]
+	_ = x[ErrAdminInvalidAccessKey-175]
+	_ = x[ErrAdminInvalidSecretKey-176]
+	_ = x[ErrAdminConfigNoQuorum-177]
+	_ = x[ErrAdminConfigTooLarge-178]
+	_ = x[ErrAdminConfigBadJSON-179]
+	_ = x[ErrAdminConfigDuplicateKeys-180]
+	_ = x[ErrAdminCredentialsMismatch-181]
+	_ = x[ErrInsecureClientRequest-182]
+	_ = x[ErrObjectTampered-183]
+	_ = x[ErrSiteReplicationInvalidRequest-184]
+	_ = x[ErrSiteReplicationPeerResp-185]
+	_ = x[ErrSiteReplicationBackendIssue-186]
+	_ = x[ErrSiteReplicationServiceAccountError-187]
+	_ = x[ErrSiteReplicationBucketConfigError-188]
+	_ = x[ErrSiteReplicationBucketMetaError-189]
+	_ = x[ErrSiteReplicationIAMError-190]
+	_ = x[ErrAdminBucketQuotaExceeded-191]
+	_ = x[ErrAdminBucketVersionQuotaExceeded-192]
+	_ = x[ErrAdminBucketNoncurrentQuotaExceeded-193]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-194]
+	_ = x[ErrBucketQoSExceeded-195]
+	_ = x[ErrHealNotImplemented-196]
+	_ = x[ErrHealNoSuchProcess-197]
+	_ = x[ErrHealInvalidClientToken-198]
+	_ = x[ErrHealMissingBucket-199]
+	_ = x[ErrHealAlreadyRunning-200]
+	_ = x[ErrHealOverlappingPaths-201]
+	_ = x[ErrHealInvalidTimeWindow-202]
+	_ = x[ErrIncorrectContinuationToken-203]
+	_ = x[ErrEmptyRequestBody-204]
+	_ = x[ErrUnsupportedFunction-205]
+	_ = x[ErrInvalidExpressionType-206]
+	_ = x[ErrBusy-207]
+	_ = x[ErrUnauthorizedAccess-208]
+	_ = x[ErrExpressionTooLong-209]
+	_ = x[ErrIllegalSQLFunctionArgument-210]
+	_ = x[ErrInvalidKeyPath-211]
+	_ = x[ErrInvalidCompressionFormat-212]
+	_ = x[ErrInvalidFileHeaderInfo-213]
+	_ = x[ErrInvalidJSONType-214]
+	_ = x[ErrInvalidQuoteFields-215]
+	_ = x[ErrInvalidRequestParameter-216]
+	_ = x[ErrInvalidDataType-217]
+	_ = x[ErrInvalidTextEncoding-218]
+	_ = x[ErrInvalidDataSource-219]
+	_ = x[ErrInvalidTableAlias-220]
+	_ = x[ErrMissingRequiredParameter-221]
+	_ = x[ErrObjectSerializationConflict-222]
+	_ = x[ErrUnsupportedSQLOperation-223]
+	_ = x[ErrUnsupportedSQLStructure-224]
+	_ = x[ErrUnsupportedSyntax-225]
+	_ = x[ErrUnsupportedRangeHeader-226]
+	_ = x[ErrLexerInvalidChar-227]
+	_ = x[ErrLexerInvalidOperator-228]
+	_ = x[ErrLexerInvalidLiteral-229]
+	_ = x[ErrLexerInvalidIONLiteral-230]
+	_ = x[ErrParseExpectedDatePart-231]
+	_ = x[ErrParseExpectedKeyword-232]
+	_ = x[ErrParseExpectedTokenType-233]
+	_ = x[ErrParseExpected2TokenTypes-234]
+	_ = x[ErrParseExpectedNumber-235]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-236]
+	_ = x[ErrParseExpectedTypeName-237]
+	_ = x[ErrParseExpectedWhenClause-238]
+	_ = x[ErrParseUnsupportedToken-239]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-240]
+	_ = x[ErrParseExpectedMember-241]
+	_ = x[ErrParseUnsupportedSelect-242]
+	_ = x[ErrParseUnsupportedCase-243]
+	_ = x[ErrParseUnsupportedCaseClause-244]
+	_ = x[ErrParseUnsupportedAlias-245]
+	_ = x[ErrParseUnsupportedSyntax-246]
+	_ = x[ErrParseUnknownOperator-247]
+	_ = x[ErrParseMissingIdentAfterAt-248]
+	_ = x[ErrParseUnexpectedOperator-249]
+	_ = x[ErrParseUnexpectedTerm-250]
+	_ = x[ErrParseUnexpectedToken-251]
+	_ = x[ErrParseUnexpectedKeyword-252]
+	_ = x[ErrParseExpectedExpression-253]
+	_ = x[ErrParseExpectedLeftParenAfterCast-254]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-255]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-256]
+	_ = x[ErrParseExpectedArgumentDelimiter-257]
+	_ = x[ErrParseCastArity-258]
+	_ = x[ErrParseInvalidTypeParam-259]
+	_ = x[ErrParseEmptySelect-260]
+	_ = x[ErrParseSelectMissingFrom-261]
+	_ = x[ErrParseExpectedIdentForGroupName-262]
+	_ = x[ErrParseExpectedIdentForAlias-263]
+	_ = x[ErrParseUnsupportedCallWithStar-264]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-265]
+	_ = x[ErrParseMalformedJoin-266]
+	_ = x[ErrParseExpectedIdentForAt-267]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-268]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-269]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-270]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-271]
+	_ = x[ErrValueParseFailure-272]
+	_ = x[ErrEvaluatorInvalidArguments-273]
+	_ = x[ErrIntegerOverflow-274]
+	_ = x[ErrLikeInvalidInputs-275]
+	_ = x[ErrCastFailed-276]
+	_ = x[ErrInvalidCast-277]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-278]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-279]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-280]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-281]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-282]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-283]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-284]
+	_ = x[ErrEvaluatorBindingDoesNotExist-285]
+	_ = x[ErrMissingHeaders-286]
+	_ = x[ErrInvalidColumnIndex-287]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-288]
+	_ = x[ErrAdminProfilerNotEnabled-289]
+	_ = x[ErrInvalidDecompressedSize-290]
+	_ = x[ErrAddUserInvalidArgument-291]
+	_ = x[ErrAdminAccountNotEligible-292]
+	_ = x[ErrAccountNotEligible-293]
+	_ = x[ErrAdminServiceAccountNotFound-294]
+	_ = x[ErrPostPolicyConditionInvalidFormat-295]
+	_ = x[ErrLambdaInvalidResponse-296]
+	_ = x[ErrServiceAccountExpired-297]
+	_ = x[ErrServiceAccountSourceIPDenied-298]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidVersionIDMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationNoSuchInventoryConfigurationNoSuchBatchJobBatchJobStatusReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundNoSuchPublicAccessBlockConfigurationObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchContentChecksumMismatchInvalidChecksumInvalidObjectAttributesReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminBucketVersionQuotaExceededAdminBucketNoncurrentQuotaExceededAdminNoSuchQuotaConfigurationBucketQoSExceededHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsHealInvalidTimeWindowIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormatLambdaInvalidResponseServiceAccountExpiredServiceAccountSourceIPDenied"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 329, 347, 364, 388, 403, 424, 442, 454, 474, 491, 514, 535, 547, 565, 586, 614, 644, 665, 688, 714, 751, 781, 814, 839, 871, 901, 930, 955, 977, 1003, 1025, 1053, 1082, 1116, 1147, 1184, 1214, 1244, 1253, 1265, 1281, 1294, 1308, 1326, 1346, 1367, 1383, 1394, 1410, 1438, 1458, 1474, 1502, 1516, 1533, 1548, 1561, 1575, 1588, 1601, 1617, 1634, 1655, 1669, 1690, 1703, 1725, 1748, 1773, 1789, 1804, 1819, 1840, 1858, 1873, 1890, 1915, 1933, 1956, 1971, 1990, 2006, 2025, 2039, 2047, 2066, 2076, 2091, 2127, 2158, 2191, 2220, 2232, 2252, 2276, 2300, 2321, 2345, 2364, 2387, 2413, 2434, 2452, 2479, 2506, 2527, 2548, 2572, 2597, 2625, 2653, 2669, 2680, 2692, 2709, 2724, 2742, 2771, 2788, 2804, 2820, 2838, 2856, 2879, 2900, 2910, 2921, 2932, 2948, 2971, 2988, 3016, 3035, 3055, 3072, 3090, 3107, 3121, 3156, 3175, 3186, 3199, 3214, 3230, 3248, 3265, 3285, 3306, 3327, 3346, 3365, 3383, 3407, 3431, 3452, 3466, 3495, 3518, 3545, 3579, 3611, 3641, 3664, 3688, 3717, 3735, 3752, 3774, 3791, 3809, 3829, 3855, 3871, 3890, 3911, 3915, 3933, 3950, 3976, 3990, 4014, 4035, 4050, 4068, 4091, 4106, 4125, 4142, 4159, 4183, 4210, 4233, 4256, 4273, 4295, 4311, 4331, 4350, 4372, 4393, 4413, 4435, 4459, 4478, 4520, 4541, 4564, 4585, 4616, 4635, 4657, 4677, 4703, 4724, 4746, 4766, 4790, 4813, 4832, 4852, 4874, 4897, 4928, 4966, 5007, 5037, 5051, 5072, 5088, 5110, 5140, 5166, 5194, 5227, 5245, 5268, 5303, 5343, 5385, 5417, 5434, 5459, 5474, 5491, 5501, 5512, 5550, 5604, 5650, 5702, 5750, 5793, 5837, 5865, 5879, 5897, 5933, 5956, 5979, 6001, 6024, 6042, 6069, 6101}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 334, 351, 369, 386, 410, 425, 446, 464, 476, 496, 513, 536, 557, 569, 587, 608, 636, 666, 687, 710, 736, 764, 778, 792, 829, 859, 892, 917, 949, 979, 1008, 1033, 1055, 1081, 1103, 1131, 1160, 1194, 1225, 1262, 1292, 1322, 1331, 1343, 1359, 1372, 1386, 1404, 1424, 1445, 1461, 1472, 1488, 1516, 1536, 1552, 1580, 1594, 1611, 1626, 1639, 1653, 1666, 1679, 1695, 1712, 1733, 1747, 1768, 1781, 1803, 1826, 1851, 1867, 1882, 1897, 1918, 1936, 1951, 1968, 1993, 2011, 2034, 2049, 2068, 2084, 2103, 2117, 2125, 2144, 2154, 2169, 2205, 2236, 2269, 2298, 2310, 2330, 2354, 2378, 2399, 2435, 2459, 2478, 2501, 2527, 2548, 2566, 2593, 2620, 2641, 2662, 2686, 2711, 2739, 2767, 2783, 2794, 2806, 2823, 2838, 2856, 2885, 2902, 2918, 2934, 2952, 2970, 2993, 3014, 3037, 3052, 3075, 3085, 3096, 3107, 3123, 3146, 3163, 3191, 3210, 3230, 3247, 3265, 3282, 3296, 3331, 3350, 3361, 3374, 3389, 3405, 3423, 3440, 3460, 3481, 3502, 3521, 3540, 3558, 3582, 3606, 3627, 3641, 3670, 3693, 3720, 3754, 3786, 3816, 3839, 3863, 3894, 3928, 3957, 3974, 3992, 4009, 4031, 4048, 4066, 4086, 4107, 4133, 4149, 4168, 4189, 4193, 4211, 4228, 4254, 4268, 4292, 4313, 4328, 4346, 4369, 4384, 4403, 4420, 4437, 4461, 4488, 4511, 4534, 4551, 4573, 4589, 4609, 4628, 4650, 4671, 4691, 4713, 4737, 4756, 4798, 4819, 4842, 4863, 4894, 4913, 4935, 4955, 4981, 5002, 5024, 5044, 5068, 5091, 5110, 5130, 5152, 5175, 5206, 5244, 5285, 5315, 5329, 5350, 5366, 5388, 5418, 5444, 5472, 5505, 5523, 5546, 5581, 5621, 5663, 5695, 5712, 5737, 5752, 5769, 5779, 5790, 5828, 5882, 5928, 5980, 6028, 6071, 6115, 6143, 6157, 6175, 6211, 6234, 6257, 6279, 6302, 6320, 6347, 6379, 6400, 6421, 6449}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -80,10 +81,18 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256 &&
+	payload := r.Header.Get(xhttp.AmzContentSha256)
+	return (payload == streamingContentSHA256 || payload == streamingContentSHA256Trailer) &&
 		r.Method == http.MethodPut
 }
 
+// Verify if the request has an unsigned streaming payload followed by a trailer, the
+// request itself is signed with AWS Signature Version '4'. This is only valid for 'PUT' operation.
+func isRequestUnsignedTrailerV4(r *http.Request) bool {
+	return r.Header.Get(xhttp.AmzContentSha256) == unsignedPayloadTrailer &&
+		r.Method == http.MethodPut && isRequestSignatureV4(r)
+}
+
 // Authorization type.
 type authType int
 
@@ -95,6 +104,7 @@ const (
 	authTypePresignedV2
 	authTypePostPolicy
 	authTypeStreamingSigned
+	authTypeStreamingUnsignedTrailer
 	authTypeSigned
 	authTypeSignedV2
 	authTypeJWT
@@ -117,6 +127,8 @@ func getRequestAuthType(r *http.Request) authType {
 		return authTypePresignedV2
 	} else if isRequestSignStreamingV4(r) {
 		return authTypeStreamingSigned
+	} else if isRequestUnsignedTrailerV4(r) {
+		return authTypeStreamingUnsignedTrailer
 	} else if isRequestSignatureV4(r) {
 		return authTypeSigned
 	} else if isRequestPresignedSignatureV4(r) {
@@ -245,7 +257,7 @@ func getClaimsFromToken(token string) (map[string]interface{}, error) {
 
 	// Session token must have a policy, reject requests without policy
 	// claim.
-	_, pokOpenIDClaimName := claims.MapClaims[iamPolicyClaimNameOpenID()]
+	_, pokOpenIDClaimName := claims.MapClaims[iamPolicyClaimNameOpenIDFor(claims.MapClaims)]
 	_, pokOpenIDRoleArn := claims.MapClaims[roleArnClaim]
 	_, pokSA := claims.MapClaims[iamPolicyClaimNameSA()]
 	if !pokOpenIDClaimName && !pokOpenIDRoleArn && !pokSA {
@@ -271,9 +283,42 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 	if err != nil {
 		return nil, toAPIErrorCode(r.Context(), err)
 	}
+	if cred.IsServiceAccount() {
+		if s3Err := checkSvcAccRestrictions(r, claims); s3Err != ErrNone {
+			return nil, s3Err
+		}
+	}
 	return claims, ErrNone
 }
 
+// checkSvcAccRestrictions rejects requests signed by an expired service
+// account or sent from outside the networks it is restricted to.
+func checkSvcAccRestrictions(r *http.Request, claims map[string]interface{}) APIErrorCode {
+	expiration, networks, err := getSvcAccRestrictions(claims)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if !expiration.IsZero() && !UTCNow().Before(expiration) {
+		return ErrServiceAccountExpired
+	}
+	if len(networks) == 0 {
+		return ErrNone
+	}
+	// The forwarding headers are set by the client and can not be
+	// trusted to restrict access, use the address of the connection.
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	sourceIP := net.ParseIP(host)
+	for _, network := range networks {
+		if sourceIP != nil && network.Contains(sourceIP) {
+			return ErrNone
+		}
+	}
+	return ErrServiceAccountSourceIPDenied
+}
+
 // Check request auth type verifies the incoming http request
 // - validates the request signature
 // - validates the policy action if anonymous tests bucket policies if any,
@@ -292,7 +337,7 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 // Additionally returns the accessKey used in the request, and if this request is by an admin.
 func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (cred auth.Credentials, owner bool, s3Err APIErrorCode) {
 	switch getRequestAuthType(r) {
-	case authTypeUnknown, authTypeStreamingSigned:
+	case authTypeUnknown, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
 		return cred, owner, ErrSignatureVersionNotSupported
 	case authTypePresignedV2, authTypeSignedV2:
 		if s3Err = isReqAuthenticatedV2(r); s3Err != ErrNone {
@@ -342,11 +387,13 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 
 	if action != policy.ListAllMyBucketsAction && cred.AccessKey == "" {
 		// Anonymous checks are not meant for ListBuckets action
+		conditionValues := getConditionValues(r, locationConstraint, "", nil)
+		setExistingObjectTagValues(ctx, r, conditionValues, action, bucketName, objectName)
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, locationConstraint, "", nil),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -373,12 +420,17 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 		return cred, owner, ErrAccessDenied
 	}
 
+	conditionValues := getConditionValues(r, "", cred.AccessKey, cred.Claims)
+	if !owner {
+		// The owner is allowed any action without evaluating policies.
+		setExistingObjectTagValues(ctx, r, conditionValues, action, bucketName, objectName)
+	}
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Groups:          cred.Groups,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey, cred.Claims),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          cred.Claims,
@@ -468,13 +520,14 @@ func isReqAuthenticated(ctx context.Context, r *http.Request, region string, sty
 
 // List of all support S3 auth types.
 var supportedS3AuthTypes = map[authType]struct{}{
-	authTypeAnonymous:       {},
-	authTypePresigned:       {},
-	authTypePresignedV2:     {},
-	authTypeSigned:          {},
-	authTypeSignedV2:        {},
-	authTypePostPolicy:      {},
-	authTypeStreamingSigned: {},
+	authTypeAnonymous:                {},
+	authTypePresigned:                {},
+	authTypePresignedV2:              {},
+	authTypeSigned:                   {},
+	authTypeSignedV2:                 {},
+	authTypePostPolicy:               {},
+	authTypeStreamingSigned:          {},
+	authTypeStreamingUnsignedTrailer: {},
 }
 
 // Validate if the authType is valid and supported.
@@ -488,7 +541,7 @@ func setAuthHandler(h http.Handler) http.Handler {
 	// handler for validating incoming authorization headers.
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		aType := getRequestAuthType(r)
-		if aType == authTypeSigned || aType == authTypeSignedV2 || aType == authTypeStreamingSigned {
+		if aType == authTypeSigned || aType == authTypeSignedV2 || aType == authTypeStreamingSigned || aType == authTypeStreamingUnsignedTrailer {
 			// Verify if date headers are set, if not reject the request
 			amzDate, errCode := parseAmzDateHeader(r)
 			if errCode != ErrNone {
@@ -522,7 +575,7 @@ func validateSignature(atype authType, r *http.Request) (auth.Credentials, bool,
 	var owner bool
 	var s3Err APIErrorCode
 	switch atype {
-	case authTypeUnknown, authTypeStreamingSigned:
+	case authTypeUnknown, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
 		return cred, owner, ErrSignatureVersionNotSupported
 	case authTypeSignedV2, authTypePresignedV2:
 		if s3Err = isReqAuthenticatedV2(r); s3Err != ErrNone {
@@ -596,7 +649,7 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 		return ErrSignatureVersionNotSupported
 	case authTypeSignedV2, authTypePresignedV2:
 		cred, owner, s3Err = getReqAccessKeyV2(r)
-	case authTypeStreamingSigned, authTypePresigned, authTypeSigned:
+	case authTypeStreamingSigned, authTypeStreamingUnsignedTrailer, authTypePresigned, authTypeSigned:
 		region := globalSite.Region
 		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 	}
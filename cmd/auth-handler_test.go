@@ -491,3 +491,67 @@ func TestValidateAdminSignature(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckSvcAccRestrictions(t *testing.T) {
+	testCases := []struct {
+		expiration time.Time
+		sourceIPs  []string
+		remoteAddr string
+		errCode    APIErrorCode
+	}{
+		{time.Time{}, nil, "10.0.0.1:9000", ErrNone},
+		{UTCNow().Add(time.Hour), nil, "10.0.0.1:9000", ErrNone},
+		{UTCNow().Add(time.Hour), []string{"10.0.0.0/8"}, "10.0.0.1:9000", ErrNone},
+		{time.Time{}, []string{"192.168.1.0/24", "10.0.0.1"}, "10.0.0.1:9000", ErrNone},
+		{time.Time{}, []string{"10.0.0.2"}, "10.0.0.1:9000", ErrServiceAccountSourceIPDenied},
+		{time.Time{}, []string{"fd00::/8"}, "[fd00::1]:9000", ErrNone},
+		{time.Time{}, []string{"fd00::/8"}, "10.0.0.1:9000", ErrServiceAccountSourceIPDenied},
+	}
+
+	for i, testCase := range testCases {
+		claims := make(map[string]interface{})
+		if err := setSvcAccRestrictions(claims, testCase.expiration, testCase.sourceIPs); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		req := mustNewRequest(http.MethodGet, "http://localhost:9000/", 0, nil, t)
+		req.RemoteAddr = testCase.remoteAddr
+		if errCode := checkSvcAccRestrictions(req, claims); errCode != testCase.errCode {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.errCode, errCode)
+		}
+	}
+
+	// Forwarding headers set by the client do not bypass the source
+	// networks of the account.
+	claims := make(map[string]interface{})
+	if err := setSvcAccRestrictions(claims, time.Time{}, []string{"10.0.0.0/8"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, header := range []string{"X-Forwarded-For", "X-Real-Ip", "Forwarded"} {
+		req := mustNewRequest(http.MethodGet, "http://localhost:9000/", 0, nil, t)
+		req.RemoteAddr = "192.168.1.1:9000"
+		value := "10.0.0.1"
+		if header == "Forwarded" {
+			value = "for=10.0.0.1"
+		}
+		req.Header.Set(header, value)
+		if errCode := checkSvcAccRestrictions(req, claims); errCode != ErrServiceAccountSourceIPDenied {
+			t.Errorf("%s: expected %v, got %v", header, ErrServiceAccountSourceIPDenied, errCode)
+		}
+	}
+
+	// Restrictions can not be set to an expiration in the past or
+	// to malformed networks.
+	if err := setSvcAccRestrictions(map[string]interface{}{}, UTCNow().Add(-time.Hour), nil); err != errInvalidArgument {
+		t.Errorf("expected %v, got %v", errInvalidArgument, err)
+	}
+	if err := setSvcAccRestrictions(map[string]interface{}{}, time.Time{}, []string{"10.0.0.0/33"}); err != errInvalidArgument {
+		t.Errorf("expected %v, got %v", errInvalidArgument, err)
+	}
+
+	// An account that has expired since it was created is rejected.
+	claims = map[string]interface{}{svcAccExpirationClaim: float64(UTCNow().Add(-time.Minute).Unix())}
+	req := mustNewRequest(http.MethodGet, "http://localhost:9000/", 0, nil, t)
+	if errCode := checkSvcAccRestrictions(req, claims); errCode != ErrServiceAccountExpired {
+		t.Errorf("expected %v, got %v", ErrServiceAccountExpired, errCode)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/color"
+	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/console"
 )
@@ -337,8 +338,8 @@ func monitorLocalDisksAndHeal(ctx context.Context, z *erasureServerPools, bgSeq
 				logger.Info(fmt.Sprintf("Found drives to heal %d, proceeding to heal content...",
 					len(healDisks)))
 
-				erasureSetInPoolDisksToHeal = make([]map[int][]StorageAPI, len(z.serverPools))
-				for i := range z.serverPools {
+				erasureSetInPoolDisksToHeal = make([]map[int][]StorageAPI, len(z.getServerPools()))
+				for i := range erasureSetInPoolDisksToHeal {
 					erasureSetInPoolDisksToHeal[i] = map[int][]StorageAPI{}
 				}
 			}
@@ -361,10 +362,11 @@ func monitorLocalDisksAndHeal(ctx context.Context, z *erasureServerPools, bgSeq
 				}
 
 				// Calculate the set index where the current endpoint belongs
-				z.serverPools[poolIdx].erasureDisksMu.RLock()
+				pool := z.getServerPools()[poolIdx]
+				pool.erasureDisksMu.RLock()
 				// Protect reading reference format.
-				setIndex, _, err := findDiskIndex(z.serverPools[poolIdx].format, format)
-				z.serverPools[poolIdx].erasureDisksMu.RUnlock()
+				setIndex, _, err := findDiskIndex(pool.format, format)
+				pool.erasureDisksMu.RUnlock()
 				if err != nil {
 					printEndpointError(endpoint, err, false)
 					continue
@@ -419,7 +421,7 @@ func monitorLocalDisksAndHeal(ctx context.Context, z *erasureServerPools, bgSeq
 
 							// Load bucket totals
 							cache := dataUsageCache{}
-							if err := cache.load(ctx, z.serverPools[i].sets[setIndex], dataUsageCacheName); err == nil {
+							if err := cache.load(ctx, z.getServerPools()[i].sets[setIndex], dataUsageCacheName); err == nil {
 								dataUsageInfo := cache.dui(dataUsageRoot, nil)
 								tracker.ObjectsTotalCount = dataUsageInfo.ObjectsTotalCount
 								tracker.ObjectsTotalSize = dataUsageInfo.ObjectsTotalSize
@@ -434,12 +436,14 @@ func monitorLocalDisksAndHeal(ctx context.Context, z *erasureServerPools, bgSeq
 								// disks to be healed again, we cannot proceed further.
 								return
 							}
+							sendDriveHealEvent(ctx, event.DriveHealStarted, tracker)
 
-							err = z.serverPools[i].sets[setIndex].healErasureSet(ctx, tracker.QueuedBuckets, tracker)
+							err = z.getServerPools()[i].sets[setIndex].healErasureSet(ctx, tracker.QueuedBuckets, tracker)
 							if err != nil {
 								logger.LogIf(ctx, err)
 								continue
 							}
+							sendDriveHealEvent(ctx, event.DriveHealCompleted, tracker)
 
 							logger.Info("Healing disk '%s' on %s pool complete", disk, humanize.Ordinal(i+1))
 							logger.Info("Summary:\n")
@@ -0,0 +1,418 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v7/pkg/set"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	// Maximum 1MiB size per job request.
+	maxBatchJobRequestSize = 1 << 20
+
+	// Maximum number of jobs returned by ListJobs.
+	maxBatchJobsList = 1000
+
+	// S3 batch operations actions, matched by the s3:* policy actions.
+	createJobAction         policy.Action = "s3:CreateJob"
+	describeJobAction       policy.Action = "s3:DescribeJob"
+	listJobsAction          policy.Action = "s3:ListJobs"
+	updateJobPriorityAction policy.Action = "s3:UpdateJobPriority"
+	updateJobStatusAction   policy.Action = "s3:UpdateJobStatus"
+)
+
+// CreateJobRequest - the request of CreateJob.
+type CreateJobRequest struct {
+	XMLName              xml.Name          `xml:"CreateJobRequest"`
+	ConfirmationRequired bool              `xml:"ConfirmationRequired"`
+	Operation            BatchJobOperation `xml:"Operation"`
+	Report               BatchJobReport    `xml:"Report"`
+	ClientRequestToken   string            `xml:"ClientRequestToken"`
+	Manifest             BatchJobManifest  `xml:"Manifest"`
+	Description          string            `xml:"Description"`
+	Priority             int               `xml:"Priority"`
+	RoleArn              string            `xml:"RoleArn"`
+}
+
+// CreateJobResult - the response of CreateJob.
+type CreateJobResult struct {
+	XMLName xml.Name `xml:"http://awss3control.amazonaws.com/doc/2018-08-20/ CreateJobResult"`
+	JobID   string   `xml:"JobId"`
+}
+
+// DescribeJobResult - the response of DescribeJob.
+type DescribeJobResult struct {
+	XMLName xml.Name           `xml:"http://awss3control.amazonaws.com/doc/2018-08-20/ DescribeJobResult"`
+	Job     BatchJobDescriptor `xml:"Job"`
+}
+
+// BatchJobListDescriptor - a job, as returned by ListJobs.
+type BatchJobListDescriptor struct {
+	JobID           string           `xml:"JobId"`
+	Description     string           `xml:"Description,omitempty"`
+	Operation       string           `xml:"Operation"`
+	Priority        int              `xml:"Priority"`
+	Status          string           `xml:"Status"`
+	CreationTime    time.Time        `xml:"CreationTime"`
+	TerminationDate *time.Time       `xml:"TerminationDate,omitempty"`
+	ProgressSummary BatchJobProgress `xml:"ProgressSummary"`
+}
+
+// ListJobsResult - the response of ListJobs.
+type ListJobsResult struct {
+	XMLName   xml.Name                 `xml:"http://awss3control.amazonaws.com/doc/2018-08-20/ ListJobsResult"`
+	Jobs      []BatchJobListDescriptor `xml:"Jobs>member"`
+	NextToken string                   `xml:"NextToken,omitempty"`
+}
+
+// UpdateJobPriorityResult - the response of UpdateJobPriority.
+type UpdateJobPriorityResult struct {
+	XMLName  xml.Name `xml:"http://awss3control.amazonaws.com/doc/2018-08-20/ UpdateJobPriorityResult"`
+	JobID    string   `xml:"JobId"`
+	Priority int      `xml:"Priority"`
+}
+
+// UpdateJobStatusResult - the response of UpdateJobStatus.
+type UpdateJobStatusResult struct {
+	XMLName            xml.Name `xml:"http://awss3control.amazonaws.com/doc/2018-08-20/ UpdateJobStatusResult"`
+	JobID              string   `xml:"JobId"`
+	Status             string   `xml:"Status"`
+	StatusUpdateReason string   `xml:"StatusUpdateReason,omitempty"`
+}
+
+// batchJobsObjectLayer returns the object layer if batch jobs are supported,
+// writes an error response and returns nil otherwise.
+func batchJobsObjectLayer(ctx context.Context, w http.ResponseWriter, r *http.Request, objAPI ObjectLayer) ObjectLayer {
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return nil
+	}
+	// Jobs are only run on erasure coded setups.
+	if !globalIsErasure {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+	return objAPI
+}
+
+// errBatchJobStatus is returned for a status change not allowed
+// for the current status of a job.
+var errBatchJobStatus = errors.New("batch job status change not allowed")
+
+func toBatchJobAPIError(ctx context.Context, err error) APIError {
+	switch {
+	case errors.Is(err, errConfigNotFound):
+		return errorCodes.ToAPIErr(ErrNoSuchBatchJob)
+	case err == errBatchJobStatus:
+		return errorCodes.ToAPIErr(ErrBatchJobStatus)
+	case err == errAuthentication:
+		return errorCodes.ToAPIErr(ErrAccessDenied)
+	}
+	return toAPIError(ctx, err)
+}
+
+func invalidBatchJobRequest(description string) APIError {
+	apiErr := errorCodes.ToAPIErr(ErrInvalidRequest)
+	apiErr.Description = description
+	return apiErr
+}
+
+// CreateJobHandler - creates a batch job.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_control_CreateJob.html
+func (api objectAPIHandlers) CreateJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CreateJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := batchJobsObjectLayer(ctx, w, r, api.ObjectAPI())
+	if objAPI == nil {
+		return
+	}
+
+	cred, owner, s3Error := checkRequestAuthTypeCredential(ctx, r, createJobAction, "", "")
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	var req CreateJobRequest
+	if err := xmlDecoder(r.Body, &req, maxBatchJobRequestSize); err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL)
+		return
+	}
+	if req.ClientRequestToken == "" {
+		writeErrorResponse(ctx, w, invalidBatchJobRequest("ClientRequestToken is required"), r.URL)
+		return
+	}
+
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	// A retried request returns the job created by the first one.
+	for _, j := range jobs {
+		if j.ClientRequestToken == req.ClientRequestToken && j.AccessKey == cred.AccessKey {
+			writeSuccessResponseXML(w, encodeResponse(CreateJobResult{JobID: j.Descriptor.JobID}))
+			return
+		}
+	}
+
+	id := mustGetUUID()
+	status := batchJobReady
+	if req.ConfirmationRequired {
+		status = batchJobSuspended
+	}
+	j := &batchJob{
+		Descriptor: BatchJobDescriptor{
+			JobID:                id,
+			JobArn:               fmt.Sprintf("arn:aws:s3:%s:%s:job/%s", globalSite.Region, r.Header.Get(xhttp.AmzAccountID), id),
+			ConfirmationRequired: req.ConfirmationRequired,
+			Description:          req.Description,
+			Status:               status,
+			Priority:             req.Priority,
+			Operation:            req.Operation,
+			Manifest:             req.Manifest,
+			Report:               req.Report,
+			RoleArn:              req.RoleArn,
+			CreationTime:         UTCNow(),
+		},
+		Owner:              owner,
+		AccessKey:          cred.AccessKey,
+		Groups:             cred.Groups,
+		Claims:             cred.Claims,
+		ClientRequestToken: req.ClientRequestToken,
+	}
+	if err = j.validate(); err != nil {
+		if err == errAuthentication {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+		writeErrorResponse(ctx, w, invalidBatchJobRequest(err.Error()), r.URL)
+		return
+	}
+
+	if err = saveBatchJob(ctx, objAPI, j); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(CreateJobResult{JobID: id}))
+}
+
+// DescribeJobHandler - returns the configuration and status of a batch job.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_control_DescribeJob.html
+func (api objectAPIHandlers) DescribeJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DescribeJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := batchJobsObjectLayer(ctx, w, r, api.ObjectAPI())
+	if objAPI == nil {
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, describeJobAction, "", ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	j, err := loadBatchJob(ctx, objAPI, mux.Vars(r)["id"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toBatchJobAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(DescribeJobResult{Job: j.Descriptor}))
+}
+
+// ListJobsHandler - lists the batch jobs, the newest first.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_control_ListJobs.html
+func (api objectAPIHandlers) ListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListJobs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := batchJobsObjectLayer(ctx, w, r, api.ObjectAPI())
+	if objAPI == nil {
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, listJobsAction, "", ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	maxResults := maxBatchJobsList
+	if v := r.Form.Get("maxResults"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponse(ctx, w, invalidBatchJobRequest("maxResults must be a positive integer"), r.URL)
+			return
+		}
+		if n < maxResults {
+			maxResults = n
+		}
+	}
+	statuses := set.CreateStringSet(r.Form["jobStatuses"]...)
+
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// The token is the ID of the last job of the previous page.
+	if token := r.Form.Get("nextToken"); token != "" {
+		for i, j := range jobs {
+			if j.Descriptor.JobID == token {
+				jobs = jobs[i+1:]
+				break
+			}
+		}
+	}
+
+	var res ListJobsResult
+	for _, j := range jobs {
+		d := j.Descriptor
+		if !statuses.IsEmpty() && !statuses.Contains(d.Status) {
+			continue
+		}
+		if len(res.Jobs) == maxResults {
+			res.NextToken = res.Jobs[len(res.Jobs)-1].JobID
+			break
+		}
+		res.Jobs = append(res.Jobs, BatchJobListDescriptor{
+			JobID:           d.JobID,
+			Description:     d.Description,
+			Operation:       d.Operation.Name(),
+			Priority:        d.Priority,
+			Status:          d.Status,
+			CreationTime:    d.CreationTime,
+			TerminationDate: d.TerminationDate,
+			ProgressSummary: d.ProgressSummary,
+		})
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(res))
+}
+
+// UpdateJobPriorityHandler - changes the priority of a batch job.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_control_UpdateJobPriority.html
+func (api objectAPIHandlers) UpdateJobPriorityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UpdateJobPriority")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := batchJobsObjectLayer(ctx, w, r, api.ObjectAPI())
+	if objAPI == nil {
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, updateJobPriorityAction, "", ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	priority, err := strconv.Atoi(r.Form.Get("priority"))
+	if err != nil || priority < 0 || priority > batchJobMaxPriority {
+		writeErrorResponse(ctx, w, invalidBatchJobRequest(fmt.Sprintf("priority must be between 0 and %d", batchJobMaxPriority)), r.URL)
+		return
+	}
+
+	j, err := updateBatchJob(ctx, objAPI, mux.Vars(r)["id"], func(j *batchJob) error {
+		if j.terminated() {
+			return errBatchJobStatus
+		}
+		j.Descriptor.Priority = priority
+		return nil
+	})
+	if err != nil {
+		writeErrorResponse(ctx, w, toBatchJobAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(UpdateJobPriorityResult{
+		JobID:    j.Descriptor.JobID,
+		Priority: j.Descriptor.Priority,
+	}))
+}
+
+// UpdateJobStatusHandler - confirms a suspended batch job, or cancels a job.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_control_UpdateJobStatus.html
+func (api objectAPIHandlers) UpdateJobStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UpdateJobStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := batchJobsObjectLayer(ctx, w, r, api.ObjectAPI())
+	if objAPI == nil {
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, updateJobStatusAction, "", ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	status := r.Form.Get("requestedJobStatus")
+	if status != batchJobReady && status != batchJobCancelled {
+		writeErrorResponse(ctx, w, invalidBatchJobRequest("requestedJobStatus must be Ready or Cancelled"), r.URL)
+		return
+	}
+	reason := r.Form.Get("statusUpdateReason")
+
+	j, err := updateBatchJob(ctx, objAPI, mux.Vars(r)["id"], func(j *batchJob) error {
+		switch {
+		case status == batchJobReady && j.Descriptor.Status != batchJobSuspended:
+			return errBatchJobStatus
+		case status == batchJobCancelled && j.terminated():
+			return errBatchJobStatus
+		}
+		j.Descriptor.Status = status
+		j.Descriptor.StatusUpdateReason = reason
+		if status == batchJobCancelled {
+			// An active job is stopped by its runner shortly after.
+			now := UTCNow()
+			j.Descriptor.TerminationDate = &now
+		}
+		return nil
+	})
+	if err != nil {
+		writeErrorResponse(ctx, w, toBatchJobAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(UpdateJobStatusResult{
+		JobID:              j.Descriptor.JobID,
+		Status:             j.Descriptor.Status,
+		StatusUpdateReason: j.Descriptor.StatusUpdateReason,
+	}))
+}
@@ -0,0 +1,1202 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/etag"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	batchJobsPrefix = "batch-jobs"
+
+	// Time between two checks for a batch job to run.
+	batchJobCheckInterval = 30 * time.Second
+	// Time between two saves of the progress of a running job.
+	batchJobSaveInterval = 10 * time.Second
+	// Number of tasks of a job run concurrently.
+	batchJobWorkers = 16
+	// Finished jobs are removed after this duration, as S3 does.
+	batchJobExpiry = 90 * 24 * time.Hour
+
+	// Supported manifest formats.
+	batchJobManifestCSV       = "S3BatchOperations_CSV_20180820"
+	batchJobManifestInventory = "S3InventoryReport_CSV_20161130"
+
+	batchJobReportFormat = "Report_CSV_20180820"
+	batchJobReportSchema = "Bucket, Key, VersionId, TaskStatus, ErrorCode, HTTPStatusCode, ResultMessage"
+
+	batchJobMaxPriority = 1<<31 - 1
+
+	// Buckets and objects are referenced by ARN in jobs.
+	batchJobARNPrefix = "arn:aws:s3:::"
+)
+
+// Batch job statuses.
+const (
+	batchJobSuspended = "Suspended" // Waiting for confirmation.
+	batchJobReady     = "Ready"
+	batchJobActive    = "Active"
+	batchJobComplete  = "Complete"
+	batchJobCancelled = "Cancelled"
+	batchJobFailed    = "Failed"
+)
+
+// Report scopes.
+const (
+	batchJobReportAllTasks    = "AllTasks"
+	batchJobReportFailedTasks = "FailedTasksOnly"
+)
+
+var batchJobsLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// BatchJobTag is a tag set by a batch job.
+type BatchJobTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// BatchJobCopy copies the objects to the target bucket.
+type BatchJobCopy struct {
+	TargetResource  string `xml:"TargetResource"`
+	TargetKeyPrefix string `xml:"TargetKeyPrefix,omitempty"`
+	StorageClass    string `xml:"StorageClass,omitempty"`
+}
+
+// BatchJobTagging replaces the tags of the objects.
+type BatchJobTagging struct {
+	TagSet []BatchJobTag `xml:"TagSet>S3Tag"`
+}
+
+// BatchJobRetentionConfig is the retention set by a batch job.
+type BatchJobRetentionConfig struct {
+	Mode            objectlock.RetMode `xml:"Mode,omitempty"`
+	RetainUntilDate time.Time          `xml:"RetainUntilDate,omitempty"`
+}
+
+// BatchJobRetention sets the retention of the objects.
+type BatchJobRetention struct {
+	BypassGovernanceRetention bool                    `xml:"BypassGovernanceRetention,omitempty"`
+	Retention                 BatchJobRetentionConfig `xml:"Retention"`
+}
+
+// BatchJobRestore restores the transitioned objects.
+type BatchJobRestore struct {
+	ExpirationInDays int    `xml:"ExpirationInDays"`
+	GlacierJobTier   string `xml:"GlacierJobTier,omitempty"`
+}
+
+// BatchJobOperation is the operation applied to every object of a job.
+type BatchJobOperation struct {
+	S3PutObjectCopy         *BatchJobCopy      `xml:"S3PutObjectCopy,omitempty"`
+	S3PutObjectTagging      *BatchJobTagging   `xml:"S3PutObjectTagging,omitempty"`
+	S3PutObjectRetention    *BatchJobRetention `xml:"S3PutObjectRetention,omitempty"`
+	S3InitiateRestoreObject *BatchJobRestore   `xml:"S3InitiateRestoreObject,omitempty"`
+}
+
+// Name returns the name of the operation.
+func (o BatchJobOperation) Name() string {
+	switch {
+	case o.S3PutObjectCopy != nil:
+		return "S3PutObjectCopy"
+	case o.S3PutObjectTagging != nil:
+		return "S3PutObjectTagging"
+	case o.S3PutObjectRetention != nil:
+		return "S3PutObjectRetention"
+	case o.S3InitiateRestoreObject != nil:
+		return "S3InitiateRestoreObject"
+	}
+	return ""
+}
+
+// action returns the permission required on every object of the job.
+func (o BatchJobOperation) action() iampolicy.Action {
+	switch {
+	case o.S3PutObjectCopy != nil:
+		return iampolicy.GetObjectAction
+	case o.S3PutObjectTagging != nil:
+		return iampolicy.PutObjectTaggingAction
+	case o.S3PutObjectRetention != nil:
+		return iampolicy.PutObjectRetentionAction
+	}
+	return iampolicy.Action(policy.RestoreObjectAction)
+}
+
+// BatchJobManifestSpec describes the format of a manifest.
+type BatchJobManifestSpec struct {
+	Format string   `xml:"Format"`
+	Fields []string `xml:"Fields>member,omitempty"`
+}
+
+// BatchJobManifestLocation is the object holding the manifest.
+type BatchJobManifestLocation struct {
+	ObjectArn       string `xml:"ObjectArn"`
+	ObjectVersionID string `xml:"ObjectVersionId,omitempty"`
+	ETag            string `xml:"ETag,omitempty"`
+}
+
+// BatchJobManifest lists the objects of a job.
+type BatchJobManifest struct {
+	Spec     BatchJobManifestSpec     `xml:"Spec"`
+	Location BatchJobManifestLocation `xml:"Location"`
+}
+
+// BatchJobReport configures the completion report of a job.
+type BatchJobReport struct {
+	Bucket      string `xml:"Bucket,omitempty"`
+	Enabled     bool   `xml:"Enabled"`
+	Format      string `xml:"Format,omitempty"`
+	Prefix      string `xml:"Prefix,omitempty"`
+	ReportScope string `xml:"ReportScope,omitempty"`
+}
+
+// BatchJobProgress is the number of tasks of a job.
+type BatchJobProgress struct {
+	TotalNumberOfTasks     int64 `xml:"TotalNumberOfTasks"`
+	NumberOfTasksSucceeded int64 `xml:"NumberOfTasksSucceeded"`
+	NumberOfTasksFailed    int64 `xml:"NumberOfTasksFailed"`
+}
+
+// BatchJobFailure is the reason of a job failure.
+type BatchJobFailure struct {
+	FailureCode   string `xml:"FailureCode"`
+	FailureReason string `xml:"FailureReason"`
+}
+
+// BatchJobDescriptor describes a batch job, as returned by DescribeJob.
+type BatchJobDescriptor struct {
+	JobID                string            `xml:"JobId"`
+	JobArn               string            `xml:"JobArn"`
+	ConfirmationRequired bool              `xml:"ConfirmationRequired"`
+	Description          string            `xml:"Description,omitempty"`
+	Status               string            `xml:"Status"`
+	StatusUpdateReason   string            `xml:"StatusUpdateReason,omitempty"`
+	Priority             int               `xml:"Priority"`
+	Operation            BatchJobOperation `xml:"Operation"`
+	Manifest             BatchJobManifest  `xml:"Manifest"`
+	Report               BatchJobReport    `xml:"Report"`
+	ProgressSummary      BatchJobProgress  `xml:"ProgressSummary"`
+	FailureReasons       []BatchJobFailure `xml:"FailureReasons>member,omitempty"`
+	RoleArn              string            `xml:"RoleArn,omitempty"`
+	CreationTime         time.Time         `xml:"CreationTime"`
+	TerminationDate      *time.Time        `xml:"TerminationDate,omitempty"`
+}
+
+// batchJob is the persisted state of a batch job.
+type batchJob struct {
+	Descriptor BatchJobDescriptor `json:"descriptor"`
+
+	// Credentials of the job creator, every task is
+	// authorized as if done by the creator.
+	Owner     bool                   `json:"owner,omitempty"`
+	AccessKey string                 `json:"accessKey"`
+	Groups    []string               `json:"groups,omitempty"`
+	Claims    map[string]interface{} `json:"claims,omitempty"`
+
+	ClientRequestToken string `json:"clientRequestToken,omitempty"`
+
+	// Processed is the number of manifest records done, a resumed
+	// job starts after them.
+	Processed int64 `json:"processed"`
+	// Results are the report files written so far.
+	Results []batchJobReportResult `json:"results,omitempty"`
+}
+
+func (j *batchJob) terminated() bool {
+	switch j.Descriptor.Status {
+	case batchJobComplete, batchJobCancelled, batchJobFailed:
+		return true
+	}
+	return false
+}
+
+// isAllowed returns true if the creator of the job is allowed the action.
+func (j *batchJob) isAllowed(action iampolicy.Action, bucket, object string) bool {
+	if j.Owner {
+		return true
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     j.AccessKey,
+		Groups:          j.Groups,
+		Action:          action,
+		BucketName:      bucket,
+		ObjectName:      object,
+		ConditionValues: map[string][]string{},
+		Claims:          j.Claims,
+	})
+}
+
+// validate checks the job request, with the permissions of the creator.
+func (j *batchJob) validate() error {
+	d := j.Descriptor
+	if d.Priority < 0 || d.Priority > batchJobMaxPriority {
+		return fmt.Errorf("job priority must be between 0 and %d", batchJobMaxPriority)
+	}
+	if len(d.Description) > 256 {
+		return errors.New("job description must be at most 256 characters")
+	}
+
+	ops := 0
+	op := d.Operation
+	if op.S3PutObjectCopy != nil {
+		ops++
+		bucket := strings.TrimPrefix(op.S3PutObjectCopy.TargetResource, batchJobARNPrefix)
+		if !strings.HasPrefix(op.S3PutObjectCopy.TargetResource, batchJobARNPrefix) || bucket == "" {
+			return fmt.Errorf("invalid copy target %q", op.S3PutObjectCopy.TargetResource)
+		}
+		if !j.isAllowed(iampolicy.PutObjectAction, bucket, op.S3PutObjectCopy.TargetKeyPrefix) {
+			return errAuthentication
+		}
+	}
+	if op.S3PutObjectTagging != nil {
+		ops++
+		if _, err := op.S3PutObjectTagging.tags(); err != nil {
+			return err
+		}
+	}
+	if op.S3PutObjectRetention != nil {
+		ops++
+		ret := op.S3PutObjectRetention.Retention
+		if ret.Mode != "" && !ret.Mode.Valid() {
+			return fmt.Errorf("invalid retention mode %q", ret.Mode)
+		}
+		if ret.Mode.Valid() && ret.RetainUntilDate.Before(UTCNow()) {
+			return errors.New("retain until date must be in the future")
+		}
+	}
+	if op.S3InitiateRestoreObject != nil {
+		ops++
+		if op.S3InitiateRestoreObject.ExpirationInDays <= 0 {
+			return errors.New("restore expiration must be at least one day")
+		}
+	}
+	if ops != 1 {
+		return errors.New("job must have exactly one operation")
+	}
+
+	switch d.Manifest.Spec.Format {
+	case batchJobManifestCSV:
+		fields := strings.Join(d.Manifest.Spec.Fields, ",")
+		if fields != "Bucket,Key" && fields != "Bucket,Key,VersionId" {
+			return fmt.Errorf("unsupported manifest fields %q", fields)
+		}
+	case batchJobManifestInventory:
+	default:
+		return fmt.Errorf("unsupported manifest format %q", d.Manifest.Spec.Format)
+	}
+	bucket, object, ok := parseBatchJobObjectArn(d.Manifest.Location.ObjectArn)
+	if !ok {
+		return fmt.Errorf("invalid manifest location %q", d.Manifest.Location.ObjectArn)
+	}
+	if !j.isAllowed(iampolicy.GetObjectAction, bucket, object) {
+		return errAuthentication
+	}
+
+	if r := d.Report; r.Enabled {
+		bucket := strings.TrimPrefix(r.Bucket, batchJobARNPrefix)
+		if !strings.HasPrefix(r.Bucket, batchJobARNPrefix) || bucket == "" {
+			return fmt.Errorf("invalid report bucket %q", r.Bucket)
+		}
+		if r.Format != batchJobReportFormat {
+			return fmt.Errorf("unsupported report format %q", r.Format)
+		}
+		if r.ReportScope != batchJobReportAllTasks && r.ReportScope != batchJobReportFailedTasks {
+			return fmt.Errorf("invalid report scope %q", r.ReportScope)
+		}
+		if !j.isAllowed(iampolicy.PutObjectAction, bucket, r.Prefix) {
+			return errAuthentication
+		}
+	}
+	return nil
+}
+
+func (t *BatchJobTagging) tags() (*tags.Tags, error) {
+	m := make(map[string]string, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		m[tag.Key] = tag.Value
+	}
+	return tags.NewTags(m, true)
+}
+
+// parseBatchJobObjectArn returns the bucket and object of an object ARN.
+func parseBatchJobObjectArn(arn string) (bucket, object string, ok bool) {
+	if !strings.HasPrefix(arn, batchJobARNPrefix) {
+		return "", "", false
+	}
+	bucket, object = path2BucketObjectWithBasePath("", strings.TrimPrefix(arn, batchJobARNPrefix))
+	return bucket, object, bucket != "" && object != ""
+}
+
+func batchJobPath(id string) string {
+	return pathJoin(batchJobsPrefix, id+".json")
+}
+
+func loadBatchJob(ctx context.Context, objAPI ObjectLayer, id string) (*batchJob, error) {
+	data, err := readConfig(ctx, objAPI, batchJobPath(id))
+	if err != nil {
+		return nil, err
+	}
+	j := &batchJob{}
+	if err = json.Unmarshal(data, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func saveBatchJob(ctx context.Context, objAPI ObjectLayer, j *batchJob) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, batchJobPath(j.Descriptor.JobID), data)
+}
+
+// listBatchJobs returns all the batch jobs, the newest first.
+func listBatchJobs(ctx context.Context, objAPI ObjectLayer) ([]*batchJob, error) {
+	var jobs []*batchJob
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, batchJobsPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			j, err := loadBatchJob(ctx, objAPI, strings.TrimSuffix(path.Base(obj.Name), ".json"))
+			if err != nil {
+				if errors.Is(err, errConfigNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			jobs = append(jobs, j)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].Descriptor.CreationTime.After(jobs[k].Descriptor.CreationTime)
+	})
+	return jobs, nil
+}
+
+// updateBatchJob applies fn to the persisted job under a lock, so
+// requests and the job runner do not overwrite each other.
+func updateBatchJob(ctx context.Context, objAPI ObjectLayer, id string, fn func(j *batchJob) error) (*batchJob, error) {
+	// Not the job object itself, which is locked when read and written.
+	lk := objAPI.NewNSLock(minioMetaBucket, pathJoin(batchJobsPrefix, id+".lock"))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	j, err := loadBatchJob(ctx, objAPI, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = fn(j); err != nil {
+		return nil, err
+	}
+	return j, saveBatchJob(ctx, objAPI, j)
+}
+
+// initBatchJobs will start the batch job runner in the background.
+func initBatchJobs(ctx context.Context, objAPI ObjectLayer) {
+	go runBatchJobs(ctx, objAPI)
+}
+
+// runBatchJobs runs the ready batch jobs one at a time, highest priority
+// first. There should only ever be one batch job runner per cluster.
+func runBatchJobs(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 batch job runner is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runBatchJobs.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, batchJobsLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(batchJobCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	jobTimer := time.NewTimer(batchJobCheckInterval)
+	defer jobTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-jobTimer.C:
+			for {
+				j, err := nextBatchJob(ctx, objAPI)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					break
+				}
+				if j == nil {
+					break
+				}
+				newBatchJobRunner(objAPI, j).run(ctx)
+			}
+			jobTimer.Reset(batchJobCheckInterval)
+		}
+	}
+}
+
+// nextBatchJob returns the job to run next, nil if there is none.
+// Expired finished jobs are removed on the way.
+func nextBatchJob(ctx context.Context, objAPI ObjectLayer) (*batchJob, error) {
+	jobs, err := listBatchJobs(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+	var next *batchJob
+	for _, j := range jobs {
+		if j.terminated() {
+			if t := j.Descriptor.TerminationDate; t != nil && UTCNow().Sub(*t) > batchJobExpiry {
+				logger.LogIf(ctx, deleteConfig(ctx, objAPI, batchJobPath(j.Descriptor.JobID)))
+			}
+			continue
+		}
+		if j.Descriptor.Status != batchJobReady && j.Descriptor.Status != batchJobActive {
+			continue
+		}
+		// Jobs are listed newest first, the oldest wins on equal priority.
+		if next == nil || j.Descriptor.Priority >= next.Descriptor.Priority {
+			next = j
+		}
+	}
+	return next, nil
+}
+
+// batchJobTask is an object of a job manifest.
+type batchJobTask struct {
+	Bucket    string
+	Key       string
+	VersionID string
+}
+
+// batchJobManifestReader reads the tasks of a job manifest, either a
+// CSV file or the CSV data files of an inventory report.
+type batchJobManifestReader struct {
+	ctx    context.Context
+	objAPI ObjectLayer
+	bucket string
+	files  []string
+	gzip   bool
+
+	// Columns of the task fields.
+	bucketCol, keyCol, versionCol int
+
+	gr  *GetObjectReader
+	csv *csv.Reader
+}
+
+func openBatchJobManifest(ctx context.Context, objAPI ObjectLayer, m BatchJobManifest) (*batchJobManifestReader, error) {
+	bucket, object, ok := parseBatchJobObjectArn(m.Location.ObjectArn)
+	if !ok {
+		return nil, fmt.Errorf("invalid manifest location %q", m.Location.ObjectArn)
+	}
+	r := &batchJobManifestReader{ctx: ctx, objAPI: objAPI, bucket: bucket, versionCol: -1}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{VersionID: m.Location.ObjectVersionID})
+	if err != nil {
+		return nil, err
+	}
+	if want := canonicalizeETag(m.Location.ETag); want != "" && want != gr.ObjInfo.ETag {
+		gr.Close()
+		return nil, fmt.Errorf("manifest ETag %s does not match %s", gr.ObjInfo.ETag, want)
+	}
+
+	if m.Spec.Format == batchJobManifestCSV {
+		r.bucketCol, r.keyCol = 0, 1
+		if len(m.Spec.Fields) == 3 {
+			r.versionCol = 2
+		}
+		r.gr = gr
+		r.csv = newBatchJobCSVReader(gr)
+		return r, nil
+	}
+
+	// Inventory report manifest.
+	defer gr.Close()
+	var manifest inventoryManifest
+	if err = json.NewDecoder(gr).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	if manifest.FileFormat != "CSV" {
+		return nil, fmt.Errorf("unsupported inventory report format %q", manifest.FileFormat)
+	}
+	r.bucketCol, r.keyCol = -1, -1
+	for i, column := range strings.Split(manifest.FileSchema, ",") {
+		switch strings.TrimSpace(column) {
+		case "Bucket":
+			r.bucketCol = i
+		case "Key":
+			r.keyCol = i
+		case "VersionId":
+			r.versionCol = i
+		}
+	}
+	if r.bucketCol < 0 || r.keyCol < 0 {
+		return nil, fmt.Errorf("invalid inventory report schema %q", manifest.FileSchema)
+	}
+	for _, file := range manifest.Files {
+		r.files = append(r.files, file.Key)
+	}
+	r.gzip = true
+	return r, nil
+}
+
+func newBatchJobCSVReader(r io.Reader) *csv.Reader {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	c.ReuseRecord = true
+	return c
+}
+
+// Read returns the next task of the manifest, io.EOF at its end.
+func (r *batchJobManifestReader) Read() (batchJobTask, error) {
+	for {
+		if r.csv == nil {
+			if len(r.files) == 0 {
+				return batchJobTask{}, io.EOF
+			}
+			gr, err := r.objAPI.GetObjectNInfo(r.ctx, r.bucket, r.files[0], nil, http.Header{}, readLock, ObjectOptions{})
+			if err != nil {
+				return batchJobTask{}, err
+			}
+			r.files = r.files[1:]
+			r.gr = gr
+			var rd io.Reader = gr
+			if r.gzip {
+				if rd, err = gzip.NewReader(gr); err != nil {
+					return batchJobTask{}, err
+				}
+			}
+			r.csv = newBatchJobCSVReader(rd)
+		}
+
+		record, err := r.csv.Read()
+		if err == io.EOF {
+			r.gr.Close()
+			r.gr, r.csv = nil, nil
+			continue
+		}
+		if err != nil {
+			return batchJobTask{}, err
+		}
+		if len(record) <= r.bucketCol || len(record) <= r.keyCol || len(record) <= r.versionCol {
+			return batchJobTask{}, fmt.Errorf("invalid manifest record %q", record)
+		}
+		// Keys are URL encoded in manifests.
+		key, err := url.QueryUnescape(record[r.keyCol])
+		if err != nil {
+			return batchJobTask{}, err
+		}
+		t := batchJobTask{Bucket: record[r.bucketCol], Key: key}
+		if r.versionCol >= 0 {
+			t.VersionID = record[r.versionCol]
+		}
+		return t, nil
+	}
+}
+
+// Close releases the manifest file being read.
+func (r *batchJobManifestReader) Close() {
+	if r.gr != nil {
+		r.gr.Close()
+	}
+}
+
+// batchJobRunner runs the tasks of a job and writes its report.
+type batchJobRunner struct {
+	objAPI ObjectLayer
+	job    *batchJob
+
+	mu       sync.Mutex
+	progress BatchJobProgress
+	results  map[string]*bytes.Buffer // report rows by task status
+	lastSave time.Time
+}
+
+func newBatchJobRunner(objAPI ObjectLayer, j *batchJob) *batchJobRunner {
+	return &batchJobRunner{
+		objAPI:   objAPI,
+		job:      j,
+		progress: j.Descriptor.ProgressSummary,
+		results:  make(map[string]*bytes.Buffer),
+		lastSave: UTCNow(),
+	}
+}
+
+// errBatchJobPreempted stops a job for a job of higher priority.
+var errBatchJobPreempted = errors.New("batch job preempted")
+
+// run runs the job until it is done, cancelled, preempted or fails.
+func (b *batchJobRunner) run(ctx context.Context) {
+	id := b.job.Descriptor.JobID
+	if _, err := updateBatchJob(ctx, b.objAPI, id, func(j *batchJob) error {
+		if j.Descriptor.Status != batchJobReady && j.Descriptor.Status != batchJobActive {
+			return errBatchJobPreempted
+		}
+		j.Descriptor.Status = batchJobActive
+		return nil
+	}); err != nil {
+		if err != errBatchJobPreempted {
+			logger.LogIf(ctx, err)
+		}
+		return
+	}
+
+	err := b.runTasks(ctx)
+	if err == errBatchJobPreempted {
+		return
+	}
+
+	status := batchJobComplete
+	var failure *BatchJobFailure
+	switch {
+	case err == errBatchJobCancelled:
+		status = batchJobCancelled
+	case err != nil:
+		status = batchJobFailed
+		failure = &BatchJobFailure{FailureCode: "JobFailed", FailureReason: err.Error()}
+	}
+	if rerr := b.writeReport(ctx); rerr != nil && failure == nil {
+		status = batchJobFailed
+		failure = &BatchJobFailure{FailureCode: "ReportFailed", FailureReason: rerr.Error()}
+	}
+	if _, err = updateBatchJob(ctx, b.objAPI, id, func(j *batchJob) error {
+		now := UTCNow()
+		if j.Descriptor.Status == batchJobCancelled {
+			// Cancelled while the last tasks were running.
+			status = batchJobCancelled
+		}
+		j.Descriptor.Status = status
+		j.Descriptor.TerminationDate = &now
+		j.Descriptor.ProgressSummary = b.progress
+		j.Results = b.job.Results
+		if failure != nil {
+			j.Descriptor.FailureReasons = append(j.Descriptor.FailureReasons, *failure)
+		}
+		return nil
+	}); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// errBatchJobCancelled stops a job cancelled by a request.
+var errBatchJobCancelled = errors.New("batch job cancelled")
+
+// runTasks runs the tasks of the manifest not processed yet, batchJobWorkers
+// at a time, saving the progress every batchJobSaveInterval.
+func (b *batchJobRunner) runTasks(ctx context.Context) error {
+	r, err := openBatchJobManifest(ctx, b.objAPI, b.job.Descriptor.Manifest)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// Skip the tasks done before the job was interrupted.
+	for i := int64(0); i < b.job.Processed; i++ {
+		if _, err = r.Read(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		tasks := make([]batchJobTask, 0, batchJobWorkers)
+		for len(tasks) < batchJobWorkers {
+			t, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, t)
+		}
+
+		var wg sync.WaitGroup
+		for _, t := range tasks {
+			wg.Add(1)
+			go func(t batchJobTask) {
+				defer wg.Done()
+				b.recordTask(ctx, t, b.runTask(ctx, t))
+			}(t)
+		}
+		wg.Wait()
+		b.job.Processed += int64(len(tasks))
+
+		if len(tasks) < batchJobWorkers {
+			return b.save(ctx)
+		}
+		if UTCNow().Sub(b.lastSave) >= batchJobSaveInterval {
+			if err = b.save(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// save writes the pending report rows and the progress of the job, and
+// checks whether the job was cancelled or a job of higher priority is ready.
+func (b *batchJobRunner) save(ctx context.Context) error {
+	if err := b.flushResults(ctx); err != nil {
+		return err
+	}
+	b.lastSave = UTCNow()
+	var stop error
+	_, err := updateBatchJob(ctx, b.objAPI, b.job.Descriptor.JobID, func(j *batchJob) error {
+		j.Processed = b.job.Processed
+		j.Results = b.job.Results
+		j.Descriptor.ProgressSummary = b.progress
+		b.job.Descriptor.Priority = j.Descriptor.Priority
+		if j.Descriptor.Status == batchJobCancelled {
+			stop = errBatchJobCancelled
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if stop != nil {
+		return stop
+	}
+	next, err := nextBatchJob(ctx, b.objAPI)
+	if err == nil && next != nil && next.Descriptor.Priority > b.job.Descriptor.Priority {
+		// Resumed once the higher priority job is done.
+		return errBatchJobPreempted
+	}
+	return nil
+}
+
+// recordTask counts the task and adds it to the report.
+func (b *batchJobRunner) recordTask(ctx context.Context, t batchJobTask, err error) {
+	taskStatus, errorCode, httpStatus, message := "succeeded", "", http.StatusOK, "Successful"
+	if err != nil {
+		apiErr := toAPIError(ctx, err)
+		taskStatus, errorCode, httpStatus, message = "failed", apiErr.Code, apiErr.HTTPStatusCode, apiErr.Description
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.progress.NumberOfTasksFailed++
+	} else {
+		b.progress.NumberOfTasksSucceeded++
+	}
+	b.progress.TotalNumberOfTasks++
+
+	report := b.job.Descriptor.Report
+	if !report.Enabled || (err == nil && report.ReportScope == batchJobReportFailedTasks) {
+		return
+	}
+	buf, ok := b.results[taskStatus]
+	if !ok {
+		buf = &bytes.Buffer{}
+		b.results[taskStatus] = buf
+	}
+	w := csv.NewWriter(buf)
+	w.Write([]string{t.Bucket, url.QueryEscape(t.Key), t.VersionID, taskStatus, errorCode, strconv.Itoa(httpStatus), message})
+	w.Flush()
+}
+
+// batchJobReportResult is a result file of a job report.
+type batchJobReportResult struct {
+	TaskExecutionStatus string `json:"TaskExecutionStatus"`
+	Bucket              string `json:"Bucket"`
+	MD5Checksum         string `json:"MD5Checksum"`
+	Key                 string `json:"Key"`
+}
+
+// batchJobReportManifest lists the result files of a job report.
+type batchJobReportManifest struct {
+	Format             string                 `json:"Format"`
+	ReportCreationDate time.Time              `json:"ReportCreationDate"`
+	Results            []batchJobReportResult `json:"Results"`
+	ReportSchema       string                 `json:"ReportSchema"`
+}
+
+// reportPrefix returns the prefix of the report files of the job.
+func (b *batchJobRunner) reportPrefix() string {
+	return pathJoin(b.job.Descriptor.Report.Prefix, "job-"+b.job.Descriptor.JobID)
+}
+
+// flushResults writes the pending report rows as result files.
+func (b *batchJobRunner) flushResults(ctx context.Context) error {
+	report := b.job.Descriptor.Report
+	if !report.Enabled {
+		return nil
+	}
+	bucket := strings.TrimPrefix(report.Bucket, batchJobARNPrefix)
+	for taskStatus, buf := range b.results {
+		if buf.Len() == 0 {
+			continue
+		}
+		object := pathJoin(b.reportPrefix(), "results", mustGetUUID()+".csv")
+		sum := md5.Sum(buf.Bytes())
+		if err := putBatchJobObject(ctx, b.objAPI, bucket, object, "text/csv", buf.Bytes()); err != nil {
+			return err
+		}
+		b.job.Results = append(b.job.Results, batchJobReportResult{
+			TaskExecutionStatus: taskStatus,
+			Bucket:              bucket,
+			MD5Checksum:         hex.EncodeToString(sum[:]),
+			Key:                 object,
+		})
+		buf.Reset()
+	}
+	return nil
+}
+
+// writeReport writes the manifest of the job report.
+func (b *batchJobRunner) writeReport(ctx context.Context) error {
+	report := b.job.Descriptor.Report
+	if !report.Enabled {
+		return nil
+	}
+	if err := b.flushResults(ctx); err != nil {
+		return err
+	}
+	data, err := json.Marshal(batchJobReportManifest{
+		Format:             batchJobReportFormat,
+		ReportCreationDate: UTCNow(),
+		Results:            b.job.Results,
+		ReportSchema:       batchJobReportSchema,
+	})
+	if err != nil {
+		return err
+	}
+	bucket := strings.TrimPrefix(report.Bucket, batchJobARNPrefix)
+	return putBatchJobObject(ctx, b.objAPI, bucket, pathJoin(b.reportPrefix(), "manifest.json"), "application/json", data)
+}
+
+func putBatchJobObject(ctx context.Context, objAPI ObjectLayer, bucket, object, contentType string, data []byte) error {
+	hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr), ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	})
+	return err
+}
+
+// runTask applies the operation of the job to an object.
+func (b *batchJobRunner) runTask(ctx context.Context, t batchJobTask) error {
+	op := b.job.Descriptor.Operation
+	action := op.action()
+	if t.VersionID != "" && action == iampolicy.GetObjectAction {
+		action = iampolicy.GetObjectVersionAction
+	}
+	if !b.job.isAllowed(action, t.Bucket, t.Key) {
+		return errAuthentication
+	}
+	switch {
+	case op.S3PutObjectCopy != nil:
+		return b.copyObject(ctx, t, op.S3PutObjectCopy)
+	case op.S3PutObjectTagging != nil:
+		return b.putObjectTags(ctx, t, op.S3PutObjectTagging)
+	case op.S3PutObjectRetention != nil:
+		return b.putObjectRetention(ctx, t, op.S3PutObjectRetention)
+	case op.S3InitiateRestoreObject != nil:
+		return b.restoreObject(ctx, t, op.S3InitiateRestoreObject)
+	}
+	return nil
+}
+
+// batchJobEventHost is the host of the events of batch jobs.
+const batchJobEventHost = "Internal: [Batch]"
+
+// copyObject copies the object to the target bucket, encrypted
+// as configured on the target bucket.
+func (b *batchJobRunner) copyObject(ctx context.Context, t batchJobTask, op *BatchJobCopy) error {
+	bucket := strings.TrimPrefix(op.TargetResource, batchJobARNPrefix)
+	object := op.TargetKeyPrefix + t.Key
+	if !b.job.isAllowed(iampolicy.PutObjectAction, bucket, object) {
+		return errAuthentication
+	}
+
+	gr, err := b.objAPI.GetObjectNInfo(ctx, t.Bucket, t.Key, nil, http.Header{}, readLock, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	srcInfo := gr.ObjInfo
+	if crypto.SSEC.IsEncrypted(srcInfo.UserDefined) {
+		// The customer key is not known to the job.
+		return errInvalidEncryptionParameters
+	}
+
+	metadata := make(map[string]string, len(srcInfo.UserDefined))
+	for k, v := range srcInfo.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) || strings.HasPrefix(strings.ToLower(k), "x-amz-object-lock-") {
+			continue
+		}
+		metadata[k] = v
+	}
+	metadata = cleanMetadata(metadata)
+	if srcInfo.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = srcInfo.UserTags
+	}
+	if op.StorageClass != "" {
+		metadata[xhttp.AmzStorageClass] = op.StorageClass
+	}
+
+	size, err := srcInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hashReader, err := hash.NewReader(gr, size, "", "", size)
+	if err != nil {
+		return err
+	}
+	pReader := NewPutObjReader(hashReader)
+
+	// Apply the default encryption of the target bucket.
+	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
+	headers := http.Header{}
+	sseConfig.Apply(headers, sse.ApplyOptions{AutoEncrypt: globalAutoEncryption})
+	if kind, ok := crypto.IsRequested(headers); ok {
+		var keyID string
+		if kind == crypto.S3KMS {
+			if keyID, _, err = crypto.S3KMS.ParseHTTP(headers); err != nil {
+				return err
+			}
+		}
+		reader, objectEncryptionKey, err := newEncryptReader(hashReader, kind, keyID, nil, bucket, object, metadata, nil)
+		if err != nil {
+			return err
+		}
+		info := ObjectInfo{Size: size}
+		encReader, err := hash.NewReader(etag.Wrap(reader, hashReader), info.EncryptedSize(), "", "", size)
+		if err != nil {
+			return err
+		}
+		if pReader, err = pReader.WithEncryption(encReader, &objectEncryptionKey); err != nil {
+			return err
+		}
+	}
+
+	opts := ObjectOptions{
+		UserDefined:      metadata,
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	}
+	dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(ObjectInfo{UserDefined: metadata}, replication.ObjectReplicationType, opts))
+	if dsc.ReplicateAny() {
+		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+	}
+	objInfo, err := b.objAPI.PutObject(ctx, bucket, object, pReader, opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), b.objAPI, dsc, replication.ObjectReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedCopy,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       batchJobEventHost,
+	})
+	return nil
+}
+
+// putObjectTags replaces the tags of the object.
+func (b *batchJobRunner) putObjectTags(ctx context.Context, t batchJobTask, op *BatchJobTagging) error {
+	tags, err := op.tags()
+	if err != nil {
+		return err
+	}
+	opts := ObjectOptions{VersionID: t.VersionID}
+	oi, err := b.objAPI.GetObjectInfo(ctx, t.Bucket, t.Key, opts)
+	if err != nil {
+		return err
+	}
+	tagsStr := tags.String()
+	oi.UserTags = tagsStr
+	dsc := mustReplicate(ctx, t.Bucket, t.Key, getMustReplicateOptions(oi, replication.MetadataReplicationType, opts))
+	if dsc.ReplicateAny() {
+		opts.UserDefined = make(map[string]string)
+		opts.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		opts.UserDefined[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+		opts.UserDefined[xhttp.AmzTagDirective] = replaceDirective
+	}
+	objInfo, err := b.objAPI.PutObjectTags(ctx, t.Bucket, t.Key, tagsStr, opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), b.objAPI, dsc, replication.MetadataReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPutTagging,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       batchJobEventHost,
+	})
+	return nil
+}
+
+// putObjectRetention sets the retention of the object. Retention may only be
+// shortened or removed in governance mode, with the bypass permission.
+func (b *batchJobRunner) putObjectRetention(ctx context.Context, t batchJobTask, op *BatchJobRetention) error {
+	if rcfg, _ := globalBucketObjectLockSys.Get(t.Bucket); !rcfg.LockEnabled {
+		return errInvalidArgument
+	}
+	ret := op.Retention
+	opts := ObjectOptions{
+		VersionID: t.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			cur := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+			if cur.Mode.Valid() && cur.RetainUntilDate.After(UTCNow()) {
+				shortened := ret.Mode != cur.Mode || ret.RetainUntilDate.Before(cur.RetainUntilDate.Time)
+				if shortened {
+					bypass := op.BypassGovernanceRetention && b.job.isAllowed(iampolicy.BypassGovernanceRetentionAction, t.Bucket, t.Key)
+					if cur.Mode == objectlock.RetCompliance || !bypass {
+						return ObjectLocked{Bucket: oi.Bucket, Object: oi.Name, VersionID: oi.VersionID}
+					}
+				}
+			}
+			if ret.Mode.Valid() {
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = string(ret.Mode)
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = ret.RetainUntilDate.UTC().Format(time.RFC3339)
+			} else {
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = ""
+				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = ""
+			}
+			oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = UTCNow().Format(time.RFC3339Nano)
+			dsc := mustReplicate(ctx, t.Bucket, t.Key, getMustReplicateOptions(oi, replication.MetadataReplicationType, ObjectOptions{}))
+			if dsc.ReplicateAny() {
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+			}
+			return nil
+		},
+	}
+	objInfo, err := b.objAPI.PutObjectMetadata(ctx, t.Bucket, t.Key, opts)
+	if err != nil {
+		return err
+	}
+	dsc := mustReplicate(ctx, t.Bucket, t.Key, getMustReplicateOptions(objInfo, replication.MetadataReplicationType, ObjectOptions{}))
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), b.objAPI, dsc, replication.MetadataReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPutRetention,
+		BucketName: t.Bucket,
+		Object:     objInfo,
+		Host:       batchJobEventHost,
+	})
+	return nil
+}
+
+// restoreObject restores a transitioned object, as a RestoreObject
+// request would, waiting for the restore to finish or to be resumed
+// by the scanner.
+func (b *batchJobRunner) restoreObject(ctx context.Context, t batchJobTask, op *BatchJobRestore) error {
+	objInfo, err := b.objAPI.GetObjectInfo(ctx, t.Bucket, t.Key, ObjectOptions{VersionID: t.VersionID})
+	if err != nil {
+		return err
+	}
+	if objInfo.TransitionedObject.Status != lifecycle.TransitionComplete {
+		return InvalidObjectState{Bucket: t.Bucket, Object: t.Key}
+	}
+	if objInfo.RestoreOngoing {
+		return errors.New("object restore is already in progress")
+	}
+	alreadyRestored := !objInfo.RestoreExpires.IsZero()
+	restoreExpiry := lifecycle.ExpectedExpiryTime(time.Now(), op.ExpirationInDays)
+
+	metadata := cloneMSS(objInfo.UserDefined)
+	metadata[xhttp.AmzRestoreExpiryDays] = strconv.Itoa(op.ExpirationInDays)
+	metadata[xhttp.AmzRestoreRequestDate] = time.Now().UTC().Format(http.TimeFormat)
+	if alreadyRestored {
+		metadata[xhttp.AmzRestore] = completedRestoreObj(restoreExpiry).String()
+	} else {
+		metadata[xhttp.AmzRestore] = ongoingRestoreObj().String()
+	}
+	objInfo.metadataOnly = true
+	objInfo.UserDefined = metadata
+	if _, err = b.objAPI.CopyObject(ctx, t.Bucket, t.Key, t.Bucket, t.Key, objInfo, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		return err
+	}
+	if alreadyRestored {
+		return nil
+	}
+
+	err = b.objAPI.RestoreTransitionedObject(ctx, t.Bucket, t.Key, ObjectOptions{
+		Transition: TransitionOptions{
+			RestoreRequest: &RestoreObjectRequest{Days: op.ExpirationInDays},
+			RestoreExpiry:  restoreExpiry,
+		},
+		VersionID: objInfo.VersionID,
+	})
+	if err != nil && !errors.Is(err, errRestoreRehydrating) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestBatchJobValidate(t *testing.T) {
+	valid := func() *batchJob {
+		return &batchJob{
+			Owner: true,
+			Descriptor: BatchJobDescriptor{
+				Operation: BatchJobOperation{
+					S3PutObjectTagging: &BatchJobTagging{TagSet: []BatchJobTag{{Key: "k", Value: "v"}}},
+				},
+				Manifest: BatchJobManifest{
+					Spec:     BatchJobManifestSpec{Format: batchJobManifestCSV, Fields: []string{"Bucket", "Key"}},
+					Location: BatchJobManifestLocation{ObjectArn: "arn:aws:s3:::bucket/manifest.csv"},
+				},
+			},
+		}
+	}
+	testCases := []struct {
+		modify func(j *batchJob)
+		valid  bool
+	}{
+		{func(j *batchJob) {}, true},
+		{func(j *batchJob) { j.Descriptor.Priority = -1 }, false},
+		{func(j *batchJob) {
+			j.Descriptor.Operation.S3InitiateRestoreObject = &BatchJobRestore{ExpirationInDays: 1}
+		}, false},
+		{func(j *batchJob) { j.Descriptor.Operation = BatchJobOperation{} }, false},
+		{func(j *batchJob) { j.Descriptor.Manifest.Spec.Fields = []string{"Key"} }, false},
+		{func(j *batchJob) { j.Descriptor.Manifest.Spec.Format = "S3InventoryReport_ORC" }, false},
+		{func(j *batchJob) { j.Descriptor.Manifest.Location.ObjectArn = "arn:aws:s3:::bucket" }, false},
+		{func(j *batchJob) {
+			j.Descriptor.Report = BatchJobReport{Enabled: true, Bucket: "arn:aws:s3:::reports", Format: batchJobReportFormat, ReportScope: batchJobReportAllTasks}
+		}, true},
+		{func(j *batchJob) {
+			j.Descriptor.Report = BatchJobReport{Enabled: true, Bucket: "reports", Format: batchJobReportFormat, ReportScope: batchJobReportAllTasks}
+		}, false},
+	}
+	for i, tc := range testCases {
+		j := valid()
+		tc.modify(j)
+		if err := j.validate(); (err == nil) != tc.valid {
+			t.Errorf("test %d: expected valid %v, got %v", i+1, tc.valid, err)
+		}
+	}
+}
+
+func TestBatchJobRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket, reportBucket = "bucket", "reports"
+	for _, b := range []string{bucket, reportBucket} {
+		if err = obj.MakeBucketWithLocation(ctx, b, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The manifest has 20 objects and a missing one, more than a batch of tasks.
+	var manifest bytes.Buffer
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("dir/obj %02d", i)
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&manifest, "%s,%s\n", bucket, strings.ReplaceAll(object, " ", "+"))
+	}
+	fmt.Fprintf(&manifest, "%s,missing\n", bucket)
+	if _, err = obj.PutObject(ctx, bucket, "manifest.csv", mustGetPutObjReader(t, bytes.NewReader(manifest.Bytes()), int64(manifest.Len()), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	j := &batchJob{
+		Owner: true,
+		Descriptor: BatchJobDescriptor{
+			JobID:  mustGetUUID(),
+			Status: batchJobReady,
+			Operation: BatchJobOperation{
+				S3PutObjectTagging: &BatchJobTagging{TagSet: []BatchJobTag{{Key: "project", Value: "batch"}}},
+			},
+			Manifest: BatchJobManifest{
+				Spec:     BatchJobManifestSpec{Format: batchJobManifestCSV, Fields: []string{"Bucket", "Key"}},
+				Location: BatchJobManifestLocation{ObjectArn: "arn:aws:s3:::" + bucket + "/manifest.csv"},
+			},
+			Report: BatchJobReport{
+				Enabled:     true,
+				Bucket:      "arn:aws:s3:::" + reportBucket,
+				Format:      batchJobReportFormat,
+				Prefix:      "reports",
+				ReportScope: batchJobReportFailedTasks,
+			},
+			CreationTime: UTCNow(),
+		},
+	}
+	if err = j.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveBatchJob(ctx, obj, j); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := nextBatchJob(ctx, obj)
+	if err != nil || next == nil || next.Descriptor.JobID != j.Descriptor.JobID {
+		t.Fatalf("expected job %s to run next, got %v, %v", j.Descriptor.JobID, next, err)
+	}
+	newBatchJobRunner(obj, next).run(ctx)
+
+	j, err = loadBatchJob(ctx, obj, j.Descriptor.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BatchJobProgress{TotalNumberOfTasks: 21, NumberOfTasksSucceeded: 20, NumberOfTasksFailed: 1}
+	if j.Descriptor.Status != batchJobComplete || j.Descriptor.ProgressSummary != want || j.Descriptor.TerminationDate == nil {
+		t.Fatalf("unexpected job state %+v", j.Descriptor)
+	}
+	if j.Processed != 21 {
+		t.Fatalf("expected 21 processed records, got %d", j.Processed)
+	}
+	oi, err := obj.GetObjectInfo(ctx, bucket, "dir/obj 07", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.UserTags != "project=batch" {
+		t.Fatalf("unexpected tags %q", oi.UserTags)
+	}
+
+	readObject := func(object string) []byte {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, reportBucket, object, nil, nil, noLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	var report batchJobReportManifest
+	if err = json.Unmarshal(readObject("reports/job-"+j.Descriptor.JobID+"/manifest.json"), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 1 || report.Results[0].TaskExecutionStatus != "failed" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	records, err := csv.NewReader(bytes.NewReader(readObject(report.Results[0].Key))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0][1] != "missing" || records[0][4] != "NoSuchKey" || records[0][5] != "404" {
+		t.Fatalf("unexpected report records %v", records)
+	}
+
+	// A finished job is not run again.
+	if next, err = nextBatchJob(ctx, obj); err != nil || next != nil {
+		t.Fatalf("expected no job to run, got %v, %v", next, err)
+	}
+}
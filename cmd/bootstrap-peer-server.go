@@ -64,12 +64,19 @@ func (s1 ServerSystemConfig) Diff(s2 ServerSystemConfig) error {
 		return fmt.Errorf("Expected platform '%s', found to be running '%s'",
 			s1.MinioPlatform, s2.MinioPlatform)
 	}
-	if s1.MinioEndpoints.NEndpoints() != s2.MinioEndpoints.NEndpoints() {
-		return fmt.Errorf("Expected number of endpoints %d, seen %d", s1.MinioEndpoints.NEndpoints(),
-			s2.MinioEndpoints.NEndpoints())
+	// Pools added while running are appended to the pools of the command
+	// line, this server loads the ones it is missing from the pool layout
+	// once started. It must not know of pools the other server does not.
+	if len(s1.MinioEndpoints) > len(s2.MinioEndpoints) {
+		return fmt.Errorf("Expected number of pools %d, seen %d", len(s1.MinioEndpoints),
+			len(s2.MinioEndpoints))
 	}
 
 	for i, ep := range s1.MinioEndpoints {
+		if len(ep.Endpoints) != len(s2.MinioEndpoints[i].Endpoints) {
+			return fmt.Errorf("Expected number of endpoints %d, seen %d", len(ep.Endpoints),
+				len(s2.MinioEndpoints[i].Endpoints))
+		}
 		if ep.SetCount != s2.MinioEndpoints[i].SetCount {
 			return fmt.Errorf("Expected set count %d, seen %d", ep.SetCount,
 				s2.MinioEndpoints[i].SetCount)
@@ -111,7 +118,7 @@ func getServerSystemCfg() ServerSystemConfig {
 	}
 	return ServerSystemConfig{
 		MinioPlatform:  fmt.Sprintf("OS: %s | Arch: %s", runtime.GOOS, runtime.GOARCH),
-		MinioEndpoints: globalEndpoints,
+		MinioEndpoints: currentEndpoints(),
 		MinioEnv:       envValues,
 	}
 }
@@ -247,7 +254,7 @@ func newBootstrapRESTClient(endpoint Endpoint) *bootstrapRESTClient {
 		Path:   bootstrapRESTPath,
 	}
 
-	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient := newInternodeRESTClient(serverURL)
 	restClient.HealthCheckFn = nil
 
 	return &bootstrapRESTClient{endpoint: endpoint, restClient: restClient}
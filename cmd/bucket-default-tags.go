@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const (
+	bucketDefaultTagsConfigFile = "default-tags.json"
+
+	// Maximum number of tags of an object.
+	maxObjectTags = 10
+
+	// Ten tags of at most 128 byte keys and 256 byte values fit easily.
+	maxBucketDefaultTagsConfigSize = 64 << 10
+)
+
+// BucketDefaultTags - tags added to the objects uploaded to a bucket
+// without them, e.g. to select the objects of tag based lifecycle rules
+// without changing the clients.
+type BucketDefaultTags struct {
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// parseBucketDefaultTags parses BucketDefaultTags from json
+func parseBucketDefaultTags(bucket string, data []byte) (*BucketDefaultTags, error) {
+	d := &BucketDefaultTags{}
+	if err := json.Unmarshal(data, d); err != nil {
+		return d, err
+	}
+	if _, err := tags.MapToObjectTags(d.Tags); err != nil {
+		return d, fmt.Errorf("Invalid default tags for bucket %s: %w", bucket, err)
+	}
+	return d, nil
+}
+
+// apply adds the default tags to the object tags in metadata, a tag set
+// by the client is never replaced. Default tags are added in the order of
+// their keys as long as the object has less than 10 tags.
+func (d *BucketDefaultTags) apply(metadata map[string]string) error {
+	if d == nil || len(d.Tags) == 0 {
+		return nil
+	}
+	objTags, err := tags.ParseObjectTags(metadata[xhttp.AmzObjectTagging])
+	if err != nil {
+		return err
+	}
+	tagMap := objTags.ToMap()
+
+	keys := make([]string, 0, len(d.Tags))
+	for k := range d.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var added bool
+	for _, k := range keys {
+		if len(tagMap) >= maxObjectTags {
+			break
+		}
+		if _, ok := tagMap[k]; !ok {
+			tagMap[k] = d.Tags[k]
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	objTags, err = tags.MapToObjectTags(tagMap)
+	if err != nil {
+		return err
+	}
+	metadata[xhttp.AmzObjectTagging] = objTags.String()
+	return nil
+}
+
+// applyBucketDefaultTags adds the default tags of the bucket to the
+// metadata of an object uploaded by r. Objects replicated from another
+// site keep the tags of the source.
+func applyBucketDefaultTags(r *http.Request, bucket string, metadata map[string]string) error {
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; ok {
+		return nil
+	}
+	d, err := globalBucketMetadataSys.GetDefaultTagsConfig(bucket)
+	if err != nil {
+		// No default tags, e.g. in gateway mode.
+		return nil
+	}
+	return d.apply(metadata)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestParseBucketDefaultTags(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"tags":{"team":"analytics"}}`, false},
+		{`{}`, false},
+		{`{"tags":{"":"value"}}`, true},
+		{`{"tags":{"a":"1","b":"2","c":"3","d":"4","e":"5","f":"6","g":"7","h":"8","i":"9","j":"10","k":"11"}}`, true},
+		{`{"tags":`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseBucketDefaultTags("bucket", []byte(testCase.data))
+		if testCase.expectErr && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+	}
+}
+
+func TestBucketDefaultTagsApply(t *testing.T) {
+	defaults := &BucketDefaultTags{Tags: map[string]string{"team": "analytics", "tier": "cold"}}
+
+	testCases := []struct {
+		defaults *BucketDefaultTags
+		objTags  string
+		expected string
+	}{
+		{defaults, "", "team=analytics&tier=cold"},
+		{defaults, "team=web", "team=web&tier=cold"},
+		{defaults, "team=web&tier=hot", "team=web&tier=hot"},
+		{&BucketDefaultTags{}, "team=web", "team=web"},
+		{nil, "", ""},
+		{defaults, "a=1&b=2&c=3&d=4&e=5&f=6&g=7&h=8&i=9", "a=1&b=2&c=3&d=4&e=5&f=6&g=7&h=8&i=9&team=analytics"},
+	}
+
+	for i, testCase := range testCases {
+		metadata := map[string]string{}
+		if testCase.objTags != "" {
+			metadata[xhttp.AmzObjectTagging] = testCase.objTags
+		}
+		if err := testCase.defaults.apply(metadata); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if got := metadata[xhttp.AmzObjectTagging]; got != testCase.expected {
+			t.Errorf("Test %d: expected tags %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestBucketDefaultTagsHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketDefaultTagsHandlers, []string{"CopyObject", "PutObjectExtract", "PutObject"})
+}
+
+func testBucketDefaultTagsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	meta := newBucketMetadata(bucketName)
+	meta.defaultTagsConfig = &BucketDefaultTags{Tags: map[string]string{"team": "analytics"}}
+	globalBucketMetadataSys.Set(bucketName, meta)
+	defer globalBucketMetadataSys.Set(bucketName, newBucketMetadata(bucketName))
+
+	putObject := func(object string, data []byte, headers map[string]string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, object),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, headers)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be %d, but instead found %d: %s", instanceType, http.StatusOK, rec.Code, rec.Body)
+		}
+	}
+	objectTags := func(object string) string {
+		t.Helper()
+		oi, err := obj.GetObjectInfo(context.Background(), bucketName, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		return oi.UserTags
+	}
+
+	putObject("source", []byte("data"), map[string]string{xhttp.AmzObjectTagging: "team=web"})
+	copySource := "/" + bucketName + "/source"
+
+	// Copied tags are kept as they are.
+	putObject("copy", nil, map[string]string{xhttp.AmzCopySource: copySource})
+	if tags := objectTags("copy"); tags != "team=web" {
+		t.Errorf("%s: Expected the copied tags team=web, got %q", instanceType, tags)
+	}
+
+	// Replaced tags get the default tags.
+	putObject("replace", nil, map[string]string{
+		xhttp.AmzCopySource:    copySource,
+		xhttp.AmzTagDirective:  replaceDirective,
+		xhttp.AmzObjectTagging: "env=prod",
+	})
+	if tags := objectTags("replace"); tags != "env=prod&team=analytics" {
+		t.Errorf("%s: Expected the tags env=prod&team=analytics, got %q", instanceType, tags)
+	}
+
+	// Objects extracted from an archive get the default tags.
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := tw.WriteHeader(&tar.Header{Name: "extracted", Mode: 0o600, Size: 4, ModTime: time.Now(), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	putObject("archive.tar", archive.Bytes(), map[string]string{xhttp.AmzSnowballExtract: "true"})
+	if tags := objectTags("extracted"); tags != "team=analytics" {
+		t.Errorf("%s: Expected the tags team=analytics, got %q", instanceType, tags)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/logger"
+)
+
+// bucketErrorTopCodes is the number of most frequent error codes of
+// an API reported in the bucket metrics.
+const bucketErrorTopCodes = 5
+
+// bucketAPIErrors - failed requests of an API on a bucket.
+type bucketAPIErrors struct {
+	ClientErrors uint64
+	ServerErrors uint64
+	Codes        map[string]uint64
+}
+
+// topCodes returns the n most frequent error codes, most frequent first.
+func (e bucketAPIErrors) topCodes(n int) []string {
+	codes := make([]string, 0, len(e.Codes))
+	for code := range e.Codes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if e.Codes[codes[i]] != e.Codes[codes[j]] {
+			return e.Codes[codes[i]] > e.Codes[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	if len(codes) > n {
+		codes = codes[:n]
+	}
+	return codes
+}
+
+// bucketErrorStats - counts the failed S3 requests by bucket and API.
+type bucketErrorStats struct {
+	sync.Mutex
+	buckets map[string]map[string]*bucketAPIErrors
+}
+
+func newBucketErrorStats() *bucketErrorStats {
+	return &bucketErrorStats{buckets: make(map[string]map[string]*bucketAPIErrors)}
+}
+
+var globalBucketErrorStats = newBucketErrorStats()
+
+// record counts a failed request by its status class and error code.
+func (s *bucketErrorStats) record(bucket, api string, statusCode int, code string) {
+	if bucket == "" || statusCode < http.StatusBadRequest {
+		return
+	}
+	// Canceled requests are not errors of the server nor of the client.
+	if statusCode == 499 {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	apis, ok := s.buckets[bucket]
+	if !ok {
+		apis = make(map[string]*bucketAPIErrors)
+		s.buckets[bucket] = apis
+	}
+	e, ok := apis[api]
+	if !ok {
+		e = &bucketAPIErrors{Codes: make(map[string]uint64)}
+		apis[api] = e
+	}
+	if statusCode >= http.StatusInternalServerError {
+		e.ServerErrors++
+	} else {
+		e.ClientErrors++
+	}
+	if code != "" {
+		e.Codes[code]++
+	}
+}
+
+// recordResponse counts the response if it is an error.
+func (s *bucketErrorStats) recordResponse(api string, r *http.Request, w *logger.ResponseWriter) {
+	if w.StatusCode < http.StatusBadRequest {
+		return
+	}
+	s.record(mux.Vars(r)["bucket"], api, w.StatusCode, getS3ErrorCode(w))
+}
+
+// remove drops the counters of a deleted bucket.
+func (s *bucketErrorStats) remove(bucket string) {
+	s.Lock()
+	delete(s.buckets, bucket)
+	s.Unlock()
+}
+
+// stats returns a copy of the counters by bucket and API.
+func (s *bucketErrorStats) stats() map[string]map[string]bucketAPIErrors {
+	s.Lock()
+	defer s.Unlock()
+	m := make(map[string]map[string]bucketAPIErrors, len(s.buckets))
+	for bucket, apis := range s.buckets {
+		bm := make(map[string]bucketAPIErrors, len(apis))
+		for api, e := range apis {
+			codes := make(map[string]uint64, len(e.Codes))
+			for code, n := range e.Codes {
+				codes[code] = n
+			}
+			bm[api] = bucketAPIErrors{
+				ClientErrors: e.ClientErrors,
+				ServerErrors: e.ServerErrors,
+				Codes:        codes,
+			}
+		}
+		m[bucket] = bm
+	}
+	return m
+}
+
+// getS3ErrorCode returns the code of the S3 error in the body of a failed
+// response, responses to HEAD requests have no body and no code.
+func getS3ErrorCode(w *logger.ResponseWriter) string {
+	if w.StatusCode < http.StatusBadRequest {
+		return ""
+	}
+	var errResp struct {
+		Code string
+	}
+	if xml.Unmarshal(w.Body(), &errResp) != nil {
+		return ""
+	}
+	return errResp.Code
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+func TestBucketErrorStats(t *testing.T) {
+	s := newBucketErrorStats()
+	s.record("bucket", "GetObject", http.StatusNotFound, "NoSuchKey")
+	s.record("bucket", "GetObject", http.StatusNotFound, "NoSuchKey")
+	s.record("bucket", "GetObject", http.StatusForbidden, "AccessDenied")
+	s.record("bucket", "GetObject", http.StatusServiceUnavailable, "SlowDown")
+	// Successful, canceled and bucket-less requests are not counted.
+	s.record("bucket", "GetObject", http.StatusOK, "")
+	s.record("bucket", "GetObject", 499, "")
+	s.record("", "ListBuckets", http.StatusForbidden, "AccessDenied")
+
+	stats := s.stats()
+	if len(stats) != 1 {
+		t.Fatalf("Expected the errors of one bucket, got %v", stats)
+	}
+	e := stats["bucket"]["GetObject"]
+	if e.ClientErrors != 3 || e.ServerErrors != 1 {
+		t.Errorf("Unexpected errors by status class %v", e)
+	}
+	if codes := e.topCodes(2); !reflect.DeepEqual(codes, []string{"NoSuchKey", "AccessDenied"}) {
+		t.Errorf("Unexpected top error codes %v", codes)
+	}
+
+	s.remove("bucket")
+	if stats = s.stats(); len(stats) != 0 {
+		t.Errorf("Expected no errors of a deleted bucket, got %v", stats)
+	}
+}
+
+func TestGetS3ErrorCode(t *testing.T) {
+	w := logger.NewResponseWriter(httptest.NewRecorder())
+	w.LogErrBody = true
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(ErrNoSuchKey), r.URL)
+	if code := getS3ErrorCode(w); code != "NoSuchKey" {
+		t.Errorf("Expected NoSuchKey, got %q", code)
+	}
+
+	w = logger.NewResponseWriter(httptest.NewRecorder())
+	w.LogErrBody = true
+	writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(ErrNoSuchKey))
+	if code := getS3ErrorCode(w); code != "" {
+		t.Errorf("Expected no code without a body, got %q", code)
+	}
+}
@@ -382,6 +382,16 @@ func (api objectAPIHandlers) ListBucketsHandler(w http.ResponseWriter, r *http.R
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
+const (
+	// Number of objects of a multi-object delete request looked up
+	// concurrently, on backends without batched lookups.
+	deleteObjectsLookupConcurrency = 100
+
+	// Number of objects of a multi-object delete request deleted per
+	// batch, the result of each batch is sent once it completes.
+	deleteObjectsBatchSize = 100
+)
+
 // DeleteMultipleObjectsHandler - deletes multiple objects.
 func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DeleteMultipleObjects")
@@ -449,7 +459,11 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		return
 	}
 
-	var objectsToDelete = map[ObjectToDelete]int{}
+	var (
+		objectsToDelete = map[ObjectToDelete]struct{}{}
+		deleteList      []ObjectToDelete
+		deleteIndexes   []int
+	)
 	getObjectInfoFn := objectAPI.GetObjectInfo
 	if api.CacheAPI() != nil {
 		getObjectInfoFn = api.CacheAPI().GetObjectInfo
@@ -458,8 +472,6 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	var (
 		hasLockEnabled bool
 		dsc            ReplicateDecision
-		goi            ObjectInfo
-		gerr           error
 	)
 	replicateDeletes := hasReplicationRules(ctx, bucket, deleteObjectsReq.Objects)
 	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
@@ -478,6 +490,10 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 	oss := make([]*objSweeper, len(deleteObjectsReq.Objects))
 
+	objectsOpts := make([]ObjectOptions, len(deleteObjectsReq.Objects))
+	gois := make([]ObjectInfo, len(deleteObjectsReq.Objects))
+	gerrs := make([]error, len(deleteObjectsReq.Objects))
+
 	for index, object := range deleteObjectsReq.Objects {
 		if apiErrCode := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object.ObjectName); apiErrCode != ErrNone {
 			if apiErrCode == ErrSignatureDoesNotMatch || apiErrCode == ErrInvalidAccessKeyID {
@@ -509,19 +525,58 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 		opts := ObjectOptions{
 			VersionID:        object.VersionID,
-			Versioned:        versioned,
-			VersionSuspended: suspended,
+			Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object.ObjectName),
+			VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object.ObjectName),
+		}
+		if !globalTierConfigMgr.Empty() && object.VersionID == "" && opts.VersionSuspended {
+			opts.VersionID = nullVersionID
 		}
+		objectsOpts[index] = opts
+	}
 
-		if replicateDeletes || object.VersionID != "" && hasLockEnabled || !globalTierConfigMgr.Empty() {
-			if !globalTierConfigMgr.Empty() && object.VersionID == "" && opts.VersionSuspended {
-				opts.VersionID = nullVersionID
-			}
-			goi, gerr = getObjectInfoFn(ctx, bucket, object.ObjectName, opts)
+	// Look up the objects when needed to replicate the deletes, enforce
+	// retention or clean up remote tiers, erasure backends read their
+	// metadata in batches, others look them up concurrently.
+	var lookups []int
+	for index, object := range deleteObjectsReq.Objects {
+		if deleteResults[index].errInfo.Code != "" {
+			continue
+		}
+		if !replicateDeletes && (object.VersionID == "" || !hasLockEnabled) && globalTierConfigMgr.Empty() {
+			continue
+		}
+		lookups = append(lookups, index)
+	}
+	if z, ok := objectAPI.(*erasureServerPools); ok && api.CacheAPI() == nil {
+		names := make([]string, len(lookups))
+		opts := make([]ObjectOptions, len(lookups))
+		for i, index := range lookups {
+			names[i], opts[i] = deleteObjectsReq.Objects[index].ObjectName, objectsOpts[index]
+		}
+		objInfos, errs := z.getObjectInfos(ctx, bucket, names, opts)
+		for i, index := range lookups {
+			gois[index], gerrs[index] = objInfos[i], errs[i]
+		}
+	} else {
+		g := errgroup.WithNErrs(len(lookups)).WithConcurrency(deleteObjectsLookupConcurrency)
+		for i, index := range lookups {
+			index := index
+			g.Go(func() error {
+				gois[index], gerrs[index] = getObjectInfoFn(ctx, bucket, deleteObjectsReq.Objects[index].ObjectName, objectsOpts[index])
+				return nil
+			}, i)
+		}
+		g.Wait()
+	}
+
+	for index, object := range deleteObjectsReq.Objects {
+		if deleteResults[index].errInfo.Code != "" {
+			continue
 		}
+		opts, goi, gerr := objectsOpts[index], gois[index], gerrs[index]
 
 		if !globalTierConfigMgr.Empty() {
-			oss[index] = newObjSweeper(bucket, object.ObjectName).WithVersion(opts.VersionID).WithVersioning(versioned, suspended)
+			oss[index] = newObjSweeper(bucket, object.ObjectName).WithVersion(opts.VersionID).WithVersioning(opts.Versioned, opts.VersionSuspended)
 			oss[index].SetTransitionState(goi.TransitionedObject)
 		}
 
@@ -555,72 +610,82 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 		// Avoid duplicate objects, we use map to filter them out.
 		if _, ok := objectsToDelete[object]; !ok {
-			objectsToDelete[object] = index
+			objectsToDelete[object] = struct{}{}
+			deleteList = append(deleteList, object)
+			deleteIndexes = append(deleteIndexes, index)
 		}
 	}
 
-	toNames := func(input map[ObjectToDelete]int) (output []ObjectToDelete) {
-		output = make([]ObjectToDelete, len(input))
-		idx := 0
-		for obj := range input {
-			output[idx] = obj
-			idx++
+	var rejected []int
+	for index := range deleteResults {
+		if deleteResults[index].errInfo.Code != "" {
+			rejected = append(rejected, index)
 		}
-		return
 	}
 
-	deleteList := toNames(objectsToDelete)
-	dObjects, errs := deleteObjectsFn(ctx, bucket, deleteList, ObjectOptions{
-		Versioned:        versioned,
-		VersionSuspended: suspended,
-	})
-
-	for i := range errs {
-		// DeleteMarkerVersionID is not used specifically to avoid
-		// lookup errors, since DeleteMarkerVersionID is only
-		// created during DeleteMarker creation when client didn't
-		// specify a versionID.
-		objToDel := ObjectToDelete{
-			ObjectName:                    dObjects[i].ObjectName,
-			VersionID:                     dObjects[i].VersionID,
-			VersionPurgeStatus:            dObjects[i].VersionPurgeStatus(),
-			VersionPurgeStatuses:          dObjects[i].ReplicationState.VersionPurgeStatusInternal,
-			DeleteMarkerReplicationStatus: dObjects[i].ReplicationState.ReplicationStatusInternal,
-			ReplicateDecisionStr:          dObjects[i].ReplicationState.ReplicateDecisionStr,
+	// Delete the objects in batches concurrently, the results of each
+	// batch are streamed to the client as soon as it completes.
+	resp := newMultiDeleteResponseWriter(w, deleteObjectsReq.Quiet)
+	var wg sync.WaitGroup
+	for start := 0; start < len(deleteList); start += deleteObjectsBatchSize {
+		end := start + deleteObjectsBatchSize
+		if end > len(deleteList) {
+			end = len(deleteList)
 		}
-		dindex := objectsToDelete[objToDel]
-		if errs[i] == nil || isErrObjectNotFound(errs[i]) || isErrVersionNotFound(errs[i]) {
-			if replicateDeletes {
-				dObjects[i].ReplicationState = deleteList[i].ReplicationState()
+		wg.Add(1)
+		go func(batch []ObjectToDelete, indexes []int) {
+			defer wg.Done()
+			dObjects, errs := deleteObjectsFn(ctx, bucket, batch, ObjectOptions{
+				PrefixEnabledFn: func(prefix string) bool {
+					return globalBucketVersioningSys.PrefixEnabled(bucket, prefix)
+				},
+				Versioned:        versioned,
+				VersionSuspended: suspended,
+			})
+
+			var (
+				deletedObjects []DeletedObject
+				deleteErrors   []DeleteError
+			)
+			for i := range errs {
+				dindex := indexes[i]
+				if errs[i] == nil || isErrObjectNotFound(errs[i]) || isErrVersionNotFound(errs[i]) {
+					if replicateDeletes {
+						dObjects[i].ReplicationState = batch[i].ReplicationState()
+					}
+					deleteResults[dindex].delInfo = dObjects[i]
+					deletedObjects = append(deletedObjects, dObjects[i])
+					continue
+				}
+				apiErr := toAPIError(ctx, errs[i])
+				deleteResults[dindex].errInfo = DeleteError{
+					Code:      apiErr.Code,
+					Message:   apiErr.Description,
+					Key:       deleteObjectsReq.Objects[dindex].ObjectName,
+					VersionID: deleteObjectsReq.Objects[dindex].VersionID,
+				}
+				deleteErrors = append(deleteErrors, deleteResults[dindex].errInfo)
 			}
-			deleteResults[dindex].delInfo = dObjects[i]
-			continue
-		}
-		apiErr := toAPIError(ctx, errs[i])
-		deleteResults[dindex].errInfo = DeleteError{
-			Code:      apiErr.Code,
-			Message:   apiErr.Description,
-			Key:       deleteList[i].ObjectName,
-			VersionID: deleteList[i].VersionID,
-		}
+			resp.write(deletedObjects, deleteErrors)
+		}(deleteList[start:end], deleteIndexes[start:end])
 	}
+	wg.Wait()
+
+	// Send the errors of the objects which were not deleted.
+	var deleteErrors []DeleteError
+	for _, index := range rejected {
+		deleteErrors = append(deleteErrors, deleteResults[index].errInfo)
+	}
+	resp.write(nil, deleteErrors)
+	resp.close()
 
-	// Generate response
-	var deleteErrors = make([]DeleteError, 0, len(deleteObjectsReq.Objects))
-	var deletedObjects = make([]DeletedObject, 0, len(deleteObjectsReq.Objects))
+	var deletedObjects = make([]DeletedObject, 0, len(deleteList))
 	for _, deleteResult := range deleteResults {
-		if deleteResult.errInfo.Code != "" {
-			deleteErrors = append(deleteErrors, deleteResult.errInfo)
-		} else {
+		if deleteResult.errInfo.Code == "" {
 			deletedObjects = append(deletedObjects, deleteResult.delInfo)
 		}
 	}
 
-	response := generateMultiDeleteResponse(deleteObjectsReq.Quiet, deletedObjects, deleteErrors)
-	encodedSuccessResponse := encodeResponse(response)
-
-	// Write success response.
-	writeSuccessResponseXML(w, encodedSuccessResponse)
 	for _, dobj := range deletedObjects {
 		if dobj.ObjectName == "" {
 			continue
@@ -996,6 +1061,11 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	if err = applyBucketDefaultTags(r, bucket, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -1740,6 +1810,66 @@ func (api objectAPIHandlers) GetBucketReplicationMetricsHandler(w http.ResponseW
 	}
 }
 
+// GetBucketReplicationLockDriftHandler - compares the object lock metadata
+// of replicated object versions with their replicas and streams the
+// versions that differ as JSON, one per line. With `repair=true` such
+// versions are queued to replicate their metadata again. This API is a
+// MinIO only extension.
+func (api objectAPIHandlers) GetBucketReplicationLockDriftHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketReplicationLockDrift")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	prefix := r.URL.Query().Get("prefix")
+	repair := r.URL.Query().Get("repair") == "true"
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	// check if user has permissions to perform this operation
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetReplicationConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if repair {
+		if s3Error := checkRequestAuthType(ctx, r, policy.ResetBucketReplicationStateAction, bucket, ""); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return
+		}
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if _, err := getReplicationConfig(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, string(mimeJSON))
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	err := checkReplicationLockDrift(ctx, objectAPI, bucket, prefix, repair, func(d ReplicationLockDrift) {
+		if err := enc.Encode(d); err != nil {
+			return
+		}
+		w.(http.Flusher).Flush()
+	})
+	if err != nil {
+		// The status is already sent, report the failed check in the stream.
+		logger.LogIf(ctx, err)
+		enc.Encode(ReplicationLockDrift{Error: err.Error()})
+	}
+}
+
 // ResetBucketReplicationStateHandler - starts a replication reset for all objects in a bucket which
 // qualify for replication and re-sync the object(s) to target, provided ExistingObjectReplication is
 // enabled for the qualifying rule. This API is a MinIO only extension provided for situations where
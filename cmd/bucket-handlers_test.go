@@ -908,3 +908,72 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 	// `ExecObjectLayerAPINilTest` manages the operation.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling DeleteMultipleObjects HTTP handler tests with more objects than
+// are deleted per batch, for both Erasure multiple disks and single node setup.
+func TestAPIDeleteMultipleObjectsBatchesHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIDeleteMultipleObjectsBatchesHandler, []string{"DeleteMultipleObjects"})
+}
+
+func testAPIDeleteMultipleObjectsBatchesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	contentBytes := []byte("hello")
+	request := DeleteObjectsRequest{}
+	for i := 0; i < 2*deleteObjectsBatchSize+10; i++ {
+		objectName := "test-object-" + strconv.Itoa(i)
+		_, err := obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(contentBytes), int64(len(contentBytes)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Put Object %d:  Error uploading object: <ERROR> %v", i, err)
+		}
+		request.Objects = append(request.Objects, ObjectToDelete{ObjectName: objectName})
+	}
+	// Objects rejected before deleting are reported along with the deleted ones.
+	for i := 0; i < 5; i++ {
+		request.Objects = append(request.Objects, ObjectToDelete{ObjectName: "invalid-version-" + strconv.Itoa(i), VersionID: "invalid"})
+	}
+
+	requestBytes := encodeResponse(request)
+	req, err := newTestSignedRequestV4(http.MethodPost, getDeleteMultipleObjectsURL("", bucketName),
+		int64(len(requestBytes)), bytes.NewReader(requestBytes), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for DeleteMultipleObjects: <ERROR> %v", err)
+	}
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if !rec.Flushed || rec.Header().Get("Content-Length") != "" {
+		t.Fatalf("MinIO %s: Expected the response to be streamed", instanceType)
+	}
+
+	var response DeleteObjectsResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("MinIO %s: Failed parsing response body: <ERROR> %v", instanceType, err)
+	}
+	deleted := make(map[string]bool)
+	for _, dobj := range response.DeletedObjects {
+		deleted[dobj.ObjectName] = true
+	}
+	for _, object := range request.Objects {
+		if object.VersionID != "" {
+			continue
+		}
+		if !deleted[object.ObjectName] {
+			t.Fatalf("MinIO %s: Expected %s to be reported deleted", instanceType, object.ObjectName)
+		}
+		if _, err = obj.GetObjectInfo(GlobalContext, bucketName, object.ObjectName, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("MinIO %s: Expected %s to be deleted, got %v", instanceType, object.ObjectName, err)
+		}
+	}
+	if len(response.DeletedObjects) != 2*deleteObjectsBatchSize+10 || len(response.Errors) != 5 {
+		t.Fatalf("MinIO %s: Expected %d deleted objects and 5 errors, got %d and %d", instanceType,
+			2*deleteObjectsBatchSize+10, len(response.DeletedObjects), len(response.Errors))
+	}
+	for _, derr := range response.Errors {
+		if derr.Code != errorCodes[ErrNoSuchVersion].Code {
+			t.Fatalf("MinIO %s: Expected %s error for %s, got %s", instanceType, errorCodes[ErrNoSuchVersion].Code, derr.Key, derr.Code)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/inventory"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	// Maximum 64KiB size per inventory configuration.
+	maxInventoryConfigSize = 1 << 16
+
+	// S3 inventory actions, matched by the s3:* policy actions.
+	putInventoryConfigurationAction policy.Action = "s3:PutInventoryConfiguration"
+	getInventoryConfigurationAction policy.Action = "s3:GetInventoryConfiguration"
+)
+
+// ListInventoryConfigurationsResult - the response of ListBucketInventoryConfigurations.
+type ListInventoryConfigurationsResult struct {
+	XMLName                 xml.Name           `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListInventoryConfigurationsResult"`
+	InventoryConfigurations []inventory.Config `xml:"InventoryConfiguration"`
+	IsTruncated             bool               `xml:"IsTruncated"`
+}
+
+// updateInventoryConfig applies fn to a copy of the inventory
+// configurations of the bucket and stores the result.
+func updateInventoryConfig(bucket string, fn func(configs *inventory.Configs) error) error {
+	current, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		return err
+	}
+	configs := &inventory.Configs{
+		Configs: append([]inventory.Config{}, current.Configs...),
+	}
+	if err = fn(configs); err != nil {
+		return err
+	}
+	var configData []byte
+	if len(configs.Configs) > 0 {
+		if configData, err = xml.Marshal(configs); err != nil {
+			return err
+		}
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketInventoryConfig, configData)
+}
+
+// PutBucketInventoryConfigurationHandler - adds or replaces an inventory configuration.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketInventoryConfiguration.html
+func (api objectAPIHandlers) PutBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	id := r.Form.Get("id")
+
+	if s3Error := checkRequestAuthType(ctx, r, putInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := inventory.ParseConfig(io.LimitReader(r.Body, maxInventoryConfigSize))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if cfg.ID != id {
+		writeErrorResponse(ctx, w, toAPIError(ctx, inventory.Errorf("inventory configuration ID %q does not match the id parameter %q", cfg.ID, id)), r.URL)
+		return
+	}
+
+	// The reports are written with the permissions of the requester.
+	dstBucket := cfg.DestinationBucket()
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, dstBucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if _, err = objAPI.GetBucketInfo(ctx, dstBucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if cfg.Destination.S3BucketDestination.Encryption != nil && GlobalKMS == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	if err = updateInventoryConfig(bucket, func(configs *inventory.Configs) error {
+		return configs.Set(*cfg)
+	}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInventoryConfigurationHandler - returns an inventory configuration.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketInventoryConfiguration.html
+func (api objectAPIHandlers) GetBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, getInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	cfg := configs.Get(r.Form.Get("id"))
+	if cfg == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(cfg)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, configData)
+}
+
+// ListBucketInventoryConfigurationsHandler - returns all inventory configurations.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBucketInventoryConfigurations.html
+func (api objectAPIHandlers) ListBucketInventoryConfigurationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketInventoryConfigurations")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, getInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// A bucket has at most 1000 configurations, all returned at once.
+	writeSuccessResponseXML(w, encodeResponse(ListInventoryConfigurationsResult{
+		InventoryConfigurations: configs.Configs,
+	}))
+}
+
+// DeleteBucketInventoryConfigurationHandler - removes an inventory configuration.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketInventoryConfiguration.html
+func (api objectAPIHandlers) DeleteBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketInventoryConfiguration")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	id := r.Form.Get("id")
+
+	if s3Error := checkRequestAuthType(ctx, r, putInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if configs.Get(id) == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchInventoryConfiguration), r.URL)
+		return
+	}
+
+	if err = updateInventoryConfig(bucket, func(configs *inventory.Configs) error {
+		configs.Delete(id)
+		return nil
+	}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
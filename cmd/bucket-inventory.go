@@ -0,0 +1,505 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/inventory"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/etag"
+	xhash "github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	bucketInventoryConfig = "inventory.xml"
+
+	inventoryCheckInterval   = time.Hour // Time between two checks for due reports.
+	inventoryMaxRowsPerFile  = 1000000   // Maximum rows per report data file.
+	inventoryManifestVersion = "2016-11-30"
+
+	// inventoryDateFormat is the format of the dates in the reports.
+	inventoryDateFormat = "2006-01-02T15:04:05.000Z"
+	// inventoryFolderFormat is the format of the folder of a report manifest.
+	inventoryFolderFormat = "2006-01-02T15-04Z"
+)
+
+// Encryption status of the objects listed in the reports.
+const (
+	inventoryNotSSE = "NOT-SSE"
+	inventorySSES3  = "SSE-S3"
+	inventorySSEC   = "SSE-C"
+	inventorySSEKMS = "SSE-KMS"
+)
+
+var inventoryLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// inventoryStatus is the result of the last report of an inventory configuration.
+type inventoryStatus struct {
+	LastRun  time.Time `json:"lastRun"`
+	Manifest string    `json:"manifest,omitempty"`
+	Objects  uint64    `json:"objects"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// inventoryManifest lists the data files of a report, as S3 inventory does.
+type inventoryManifest struct {
+	SourceBucket      string                  `json:"sourceBucket"`
+	DestinationBucket string                  `json:"destinationBucket"`
+	Version           string                  `json:"version"`
+	CreationTimestamp string                  `json:"creationTimestamp"`
+	FileFormat        inventory.Format        `json:"fileFormat"`
+	FileSchema        string                  `json:"fileSchema"`
+	Files             []inventoryManifestFile `json:"files"`
+}
+
+// inventoryManifestFile is a data file of a report.
+type inventoryManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+func inventoryStatusPath(bucket, id string) string {
+	return pathJoin(bucketMetaPrefix, bucket, "inventory", id+".json")
+}
+
+func loadInventoryStatus(ctx context.Context, objAPI ObjectLayer, bucket, id string) (status inventoryStatus, err error) {
+	data, err := readConfig(ctx, objAPI, inventoryStatusPath(bucket, id))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return status, nil
+		}
+		return status, err
+	}
+	err = json.Unmarshal(data, &status)
+	return status, err
+}
+
+func saveInventoryStatus(ctx context.Context, objAPI ObjectLayer, bucket, id string, status inventoryStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, inventoryStatusPath(bucket, id), data)
+}
+
+// initBucketInventory will start the inventory reports in the background.
+func initBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	go runBucketInventory(ctx, objAPI)
+}
+
+// runBucketInventory periodically writes the reports of the enabled
+// inventory configurations which are due. There should only ever be
+// one inventory runner per cluster.
+func runBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 inventory runner is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runBucketInventory.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, inventoryLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(inventoryCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	inventoryTimer := time.NewTimer(inventoryCheckInterval)
+	defer inventoryTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-inventoryTimer.C:
+			runDueInventories(ctx, objAPI, UTCNow())
+			inventoryTimer.Reset(inventoryCheckInterval)
+		}
+	}
+}
+
+// runDueInventories writes the reports of all enabled inventory
+// configurations whose last report is older than their frequency.
+func runDueInventories(ctx context.Context, objAPI ObjectLayer, now time.Time) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bucket := range buckets {
+		configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket.Name)
+		if err != nil {
+			continue
+		}
+		for _, cfg := range configs.Configs {
+			if !cfg.IsEnabled {
+				continue
+			}
+			status, err := loadInventoryStatus(ctx, objAPI, bucket.Name, cfg.ID)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if now.Sub(status.LastRun) < cfg.Schedule.Frequency.Interval() {
+				continue
+			}
+			status, err = generateInventory(ctx, objAPI, bucket.Name, cfg, now)
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("inventory %s of bucket %s failed: %w", cfg.ID, bucket.Name, err))
+				status.Error = err.Error()
+			}
+			if err = saveInventoryStatus(ctx, objAPI, bucket.Name, cfg.ID, status); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// generateInventory lists the objects of the bucket matching the inventory
+// configuration and writes the report data files and manifest to its
+// destination bucket.
+func generateInventory(ctx context.Context, objAPI ObjectLayer, bucket string, cfg inventory.Config, now time.Time) (status inventoryStatus, err error) {
+	status.LastRun = now
+	dst := cfg.Destination.S3BucketDestination
+	if _, err = objAPI.GetBucketInfo(ctx, cfg.DestinationBucket()); err != nil {
+		return status, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	columns := cfg.Columns()
+	prefix := pathJoin(dst.Prefix, bucket, cfg.ID)
+	files := &inventoryFileWriter{
+		ctx:     ctx,
+		objAPI:  objAPI,
+		cfg:     cfg,
+		prefix:  prefix,
+		columns: columns,
+	}
+
+	results := make(chan ObjectInfo, 100)
+	if err = objAPI.Walk(ctx, bucket, cfg.Prefix(), results, ObjectOptions{}); err != nil {
+		return status, err
+	}
+	for oi := range results {
+		if !strings.HasPrefix(oi.Name, cfg.Prefix()) {
+			continue
+		}
+		if !cfg.IncludesAllVersions() && (!oi.IsLatest || oi.DeleteMarker) {
+			continue
+		}
+		if err = files.write(inventoryRow(bucket, oi, dst.Format, columns)); err != nil {
+			cancel()
+			for range results {
+				// Drain the walk so it can exit.
+			}
+			files.abort(err)
+			return status, err
+		}
+		status.Objects++
+	}
+	if err = files.close(); err != nil {
+		return status, err
+	}
+
+	manifest := inventoryManifest{
+		SourceBucket:      bucket,
+		DestinationBucket: dst.Bucket,
+		Version:           inventoryManifestVersion,
+		CreationTimestamp: strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10),
+		FileFormat:        dst.Format,
+		FileSchema:        inventoryFileSchema(dst.Format, columns),
+		Files:             files.files,
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return status, err
+	}
+	folder := pathJoin(prefix, now.UTC().Format(inventoryFolderFormat))
+	status.Manifest = pathJoin(folder, "manifest.json")
+	if _, err = putInventoryObject(ctx, objAPI, cfg, status.Manifest, "application/json", bytes.NewReader(data)); err != nil {
+		return status, err
+	}
+	sum := md5.Sum(data)
+	checksum := []byte(hex.EncodeToString(sum[:]))
+	_, err = putInventoryObject(ctx, objAPI, cfg, pathJoin(folder, "manifest.checksum"), "text/plain", bytes.NewReader(checksum))
+	return status, err
+}
+
+// inventoryRow returns the values of the report columns for the object,
+// keys are URL encoded in CSV reports as S3 inventory does.
+func inventoryRow(bucket string, oi ObjectInfo, format inventory.Format, columns []inventory.Column) []interface{} {
+	values := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		switch column.Name {
+		case "Bucket":
+			values = append(values, bucket)
+		case "Key":
+			if format == inventory.CSV {
+				values = append(values, url.QueryEscape(oi.Name))
+			} else {
+				values = append(values, oi.Name)
+			}
+		case "VersionId":
+			versionID := oi.VersionID
+			if versionID == "" {
+				versionID = nullVersionID
+			}
+			values = append(values, versionID)
+		case "IsLatest":
+			values = append(values, oi.IsLatest)
+		case "IsDeleteMarker":
+			values = append(values, oi.DeleteMarker)
+		case inventory.FieldSize:
+			values = append(values, oi.Size)
+		case inventory.FieldLastModifiedDate:
+			values = append(values, oi.ModTime.UTC().Format(inventoryDateFormat))
+		case inventory.FieldStorageClass:
+			storageClass := oi.StorageClass
+			if storageClass == "" {
+				storageClass = globalMinioDefaultStorageClass
+			}
+			values = append(values, storageClass)
+		case inventory.FieldETag:
+			values = append(values, oi.GetActualETag(nil))
+		case inventory.FieldIsMultipartUploaded:
+			values = append(values, strings.Contains(oi.ETag, "-"))
+		case inventory.FieldReplicationStatus:
+			values = append(values, oi.ReplicationStatus.String())
+		case inventory.FieldEncryptionStatus:
+			values = append(values, inventoryEncryptionStatus(oi.UserDefined))
+		case inventory.FieldObjectLockRetainUntilDate:
+			var retainUntil string
+			if ret := objectlock.GetObjectRetentionMeta(oi.UserDefined); !ret.RetainUntilDate.IsZero() {
+				retainUntil = ret.RetainUntilDate.UTC().Format(inventoryDateFormat)
+			}
+			values = append(values, retainUntil)
+		case inventory.FieldObjectLockMode:
+			values = append(values, string(objectlock.GetObjectRetentionMeta(oi.UserDefined).Mode))
+		case inventory.FieldObjectLockLegalHoldStatus:
+			values = append(values, string(objectlock.GetObjectLegalHoldMeta(oi.UserDefined).Status))
+		}
+	}
+	return values
+}
+
+func inventoryEncryptionStatus(metadata map[string]string) string {
+	switch {
+	case crypto.S3KMS.IsEncrypted(metadata):
+		return inventorySSEKMS
+	case crypto.S3.IsEncrypted(metadata):
+		return inventorySSES3
+	case crypto.SSEC.IsEncrypted(metadata):
+		return inventorySSEC
+	}
+	return inventoryNotSSE
+}
+
+// inventoryFileSchema returns the schema of the report data files.
+func inventoryFileSchema(format inventory.Format, columns []inventory.Column) string {
+	if format != inventory.Parquet {
+		names := make([]string, 0, len(columns))
+		for _, column := range columns {
+			names = append(names, column.Name)
+		}
+		return strings.Join(names, ", ")
+	}
+	var b strings.Builder
+	b.WriteString("message s3.inventory { ")
+	for _, column := range columns {
+		switch column.Type {
+		case inventory.Int64Column:
+			fmt.Fprintf(&b, "required int64 %s; ", column.Name)
+		case inventory.BoolColumn:
+			fmt.Fprintf(&b, "required boolean %s; ", column.Name)
+		default:
+			fmt.Fprintf(&b, "required binary %s (UTF8); ", column.Name)
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// inventoryFileWriter writes the rows of a report to data files in
+// the destination bucket, starting a new file every inventoryMaxRowsPerFile rows.
+type inventoryFileWriter struct {
+	ctx     context.Context
+	objAPI  ObjectLayer
+	cfg     inventory.Config
+	prefix  string
+	columns []inventory.Column
+	files   []inventoryManifestFile
+
+	// Current data file.
+	object string
+	w      inventory.Writer
+	pw     *io.PipeWriter
+	md5    hash.Hash
+	size   int64
+	rows   int
+	done   chan error
+}
+
+// Write counts and hashes the encoded data of the current file.
+func (f *inventoryFileWriter) Write(p []byte) (int, error) {
+	n, err := f.pw.Write(p)
+	f.md5.Write(p[:n])
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *inventoryFileWriter) write(values []interface{}) error {
+	if f.w == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if err := f.w.Write(values); err != nil {
+		return err
+	}
+	f.rows++
+	if f.rows >= inventoryMaxRowsPerFile {
+		return f.closeFile()
+	}
+	return nil
+}
+
+// open starts a new data file, uploaded while it is written.
+func (f *inventoryFileWriter) open() error {
+	format := f.cfg.Destination.S3BucketDestination.Format
+	f.object = pathJoin(f.prefix, "data", mustGetUUID()+format.FileExtension())
+	f.md5 = md5.New()
+	f.size = 0
+	f.rows = 0
+
+	pr, pw := io.Pipe()
+	f.pw = pw
+	f.done = make(chan error, 1)
+	go func(object string) {
+		_, err := putInventoryObject(f.ctx, f.objAPI, f.cfg, object, "application/octet-stream", pr)
+		pr.CloseWithError(err)
+		f.done <- err
+	}(f.object)
+
+	w, err := inventory.NewWriter(f, format, f.columns)
+	if err != nil {
+		pw.CloseWithError(err)
+		<-f.done
+		return err
+	}
+	f.w = w
+	return nil
+}
+
+// closeFile finishes the current data file and waits for its upload.
+func (f *inventoryFileWriter) closeFile() error {
+	err := f.w.Close()
+	f.pw.CloseWithError(err)
+	if perr := <-f.done; err == nil {
+		err = perr
+	}
+	f.w = nil
+	if err != nil {
+		return err
+	}
+	f.files = append(f.files, inventoryManifestFile{
+		Key:         f.object,
+		Size:        f.size,
+		MD5Checksum: hex.EncodeToString(f.md5.Sum(nil)),
+	})
+	return nil
+}
+
+// close finishes the last data file, a report always has at least one.
+func (f *inventoryFileWriter) close() error {
+	if f.w == nil && len(f.files) == 0 {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.w == nil {
+		return nil
+	}
+	return f.closeFile()
+}
+
+// abort cancels the upload of the current data file.
+func (f *inventoryFileWriter) abort(err error) {
+	if f.w == nil {
+		return
+	}
+	f.pw.CloseWithError(err)
+	<-f.done
+	f.w = nil
+}
+
+// putInventoryObject uploads a report file to the destination bucket,
+// encrypted as requested by the inventory configuration.
+func putInventoryObject(ctx context.Context, objAPI ObjectLayer, cfg inventory.Config, object, contentType string, r io.Reader) (ObjectInfo, error) {
+	bucket := cfg.DestinationBucket()
+	hashReader, err := xhash.NewReader(r, -1, "", "", -1)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	pReader := NewPutObjReader(hashReader)
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	}
+	if enc := cfg.Destination.S3BucketDestination.Encryption; enc != nil {
+		var kind crypto.Type = crypto.S3
+		var keyID string
+		if enc.SSEKMS != nil {
+			kind, keyID = crypto.S3KMS, enc.SSEKMS.KeyID
+		}
+		reader, objectEncryptionKey, err := newEncryptReader(hashReader, kind, keyID, nil, bucket, object, opts.UserDefined, nil)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		encReader, err := xhash.NewReader(etag.Wrap(reader, hashReader), -1, "", "", -1)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		if pReader, err = pReader.WithEncryption(encReader, &objectEncryptionKey); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
+	return objAPI.PutObject(ctx, bucket, object, pReader, opts)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/bucket/inventory"
+	parquetgo "github.com/minio/parquet-go"
+)
+
+func TestGenerateInventory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket, dstBucket = "bucket", "reports"
+	for _, b := range []string{bucket, dstBucket} {
+		if err = obj.MakeBucketWithLocation(ctx, b, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var want []string
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("dir%d/obj%02d", i%2, i)
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(object, "dir1/") {
+			want = append(want, object)
+		}
+	}
+
+	readObject := func(object string) []byte {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, dstBucket, object, nil, nil, noLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	for _, format := range []inventory.Format{inventory.CSV, inventory.Parquet} {
+		cfg, err := inventory.ParseConfig(strings.NewReader(fmt.Sprintf(`<InventoryConfiguration>
+<Id>report</Id><IsEnabled>true</IsEnabled><Filter><Prefix>dir1/</Prefix></Filter>
+<Destination><S3BucketDestination><Bucket>arn:aws:s3:::%s</Bucket><Format>%s</Format><Prefix>inventory</Prefix></S3BucketDestination></Destination>
+<Schedule><Frequency>Daily</Frequency></Schedule>
+<IncludedObjectVersions>Current</IncludedObjectVersions>
+<OptionalFields><Field>Size</Field><Field>EncryptionStatus</Field></OptionalFields>
+</InventoryConfiguration>`, dstBucket, format)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		now := time.Date(2021, 11, 5, 10, 30, 0, 0, time.UTC)
+		status, err := generateInventory(ctx, obj, bucket, *cfg, now)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if status.Objects != uint64(len(want)) {
+			t.Fatalf("%s: expected %d objects, got %d", format, len(want), status.Objects)
+		}
+		if status.Manifest != "inventory/bucket/report/2021-11-05T10-30Z/manifest.json" {
+			t.Fatalf("%s: unexpected manifest %s", format, status.Manifest)
+		}
+
+		data := readObject(status.Manifest)
+		sum := md5.Sum(data)
+		if checksum := readObject(strings.TrimSuffix(status.Manifest, ".json") + ".checksum"); string(checksum) != hex.EncodeToString(sum[:]) {
+			t.Fatalf("%s: unexpected manifest checksum %s", format, checksum)
+		}
+		var manifest inventoryManifest
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			t.Fatal(err)
+		}
+		if manifest.SourceBucket != bucket || manifest.FileFormat != format || len(manifest.Files) != 1 {
+			t.Fatalf("%s: unexpected manifest %s", format, data)
+		}
+		file := manifest.Files[0]
+		data = readObject(file.Key)
+		sum = md5.Sum(data)
+		if int64(len(data)) != file.Size || hex.EncodeToString(sum[:]) != file.MD5Checksum {
+			t.Fatalf("%s: data file does not match the manifest %+v", format, file)
+		}
+
+		var got []string
+		switch format {
+		case inventory.CSV:
+			if manifest.FileSchema != "Bucket, Key, Size, EncryptionStatus" {
+				t.Fatalf("unexpected schema %s", manifest.FileSchema)
+			}
+			gz, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			records, err := csv.NewReader(gz).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, record := range records {
+				if record[0] != bucket || record[2] != "4" || record[3] != inventoryNotSSE {
+					t.Fatalf("unexpected record %v", record)
+				}
+				key, err := url.QueryUnescape(record[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, key)
+			}
+		case inventory.Parquet:
+			r, err := parquetgo.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+				if offset < 0 {
+					offset += int64(len(data))
+				}
+				if length < 0 {
+					length = int64(len(data)) - offset
+				}
+				return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+			}, set.CreateStringSet("Key", "Size"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for {
+				record, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				key, _ := record.Get("Key")
+				size, _ := record.Get("Size")
+				if size.Value != int64(4) {
+					t.Fatalf("unexpected size %v", size.Value)
+				}
+				got = append(got, string(key.Value.([]byte)))
+			}
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("%s: expected %v, got %v", format, want, got)
+		}
+	}
+}
@@ -18,11 +18,13 @@
 package cmd
 
 import (
+	"encoding/base64"
 	"encoding/xml"
 	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/bucket/object/lock"
 	xhttp "github.com/minio/minio/internal/http"
@@ -108,6 +110,22 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Call site replication hook.
+	//
+	// We encode the xml bytes as base64 to ensure there are no encoding
+	// errors.
+	cfgStr := base64.StdEncoding.EncodeToString(configData)
+	if err = globalSiteReplicationSys.BucketConfigHook(ctx, srBucketMeta{
+		SRBucketMeta: madmin.SRBucketMeta{
+			Type:   srBucketMetaTypeLifecycleConfig,
+			Bucket: bucket,
+		},
+		LifecycleConfig: &cfgStr,
+	}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Success.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -185,6 +203,17 @@ func (api objectAPIHandlers) DeleteBucketLifecycleHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Call site replication hook.
+	if err := globalSiteReplicationSys.BucketConfigHook(ctx, srBucketMeta{
+		SRBucketMeta: madmin.SRBucketMeta{
+			Type:   srBucketMetaTypeLifecycleConfig,
+			Bucket: bucket,
+		},
+	}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Success.
 	writeSuccessNoContent(w)
 }
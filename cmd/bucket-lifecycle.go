@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -50,6 +51,8 @@ const (
 	TransitionedVersionID = "transitioned-versionID"
 	// TransitionTier name of transition storage class
 	TransitionTier = "transition-tier"
+	// RestoreRehydrating marks a restore waiting on the remote tier to rehydrate the object
+	RestoreRehydrating = "restore-rehydrating"
 )
 
 // LifecycleSys - Bucket lifecycle subsystem.
@@ -86,6 +89,12 @@ type expiryState struct {
 	byMaxNoncurrentCh chan maxNoncurrentTask
 }
 
+// TierStatus returns the transition statistics of this node per remote
+// tier.
+func (t *transitionState) TierStatus() []TierTransitionStatus {
+	return t.stats.status()
+}
+
 // PendingTasks returns the number of pending ILM expiry tasks.
 func (es *expiryState) PendingTasks() int {
 	return len(es.byDaysCh) + len(es.byMaxNoncurrentCh)
@@ -122,6 +131,20 @@ func (es *expiryState) enqueueByMaxNoncurrent(bucket string, versions []ObjectTo
 
 var globalExpiryState *expiryState
 
+// lifecycleMultipartStats counts the incomplete multipart uploads
+// aborted by the AbortIncompleteMultipartUpload lifecycle action.
+type lifecycleMultipartStats struct {
+	abortedUploads uint64
+	abortedBytes   uint64
+}
+
+var globalLifecycleMultipartStats = &lifecycleMultipartStats{}
+
+func (s *lifecycleMultipartStats) aborted(size int64) {
+	atomic.AddUint64(&s.abortedUploads, 1)
+	atomic.AddUint64(&s.abortedBytes, uint64(size))
+}
+
 func newExpiryState() *expiryState {
 	return &expiryState{
 		byDaysCh:          make(chan expiryTask, 10000),
@@ -154,9 +177,16 @@ type maxNoncurrentTask struct {
 	versions []ObjectToDelete
 }
 
+// transitionTask is an object version to be transitioned to tier.
+type transitionTask struct {
+	oi   ObjectInfo
+	tier string
+}
+
 type transitionState struct {
 	once         sync.Once
-	transitionCh chan ObjectInfo
+	transitionCh chan transitionTask
+	stats        *transitionStats
 
 	ctx        context.Context
 	objAPI     ObjectLayer
@@ -167,15 +197,24 @@ type transitionState struct {
 	activeTasks int32
 }
 
-func (t *transitionState) queueTransitionTask(oi ObjectInfo) {
+// queueTransitionTask queues oi to be transitioned, it returns false if
+// the queue is full.
+func (t *transitionState) queueTransitionTask(oi ObjectInfo) bool {
+	var tier string
+	if lc, err := globalLifecycleSys.Get(oi.Bucket); err == nil {
+		tier = lc.TransitionTier(oi.ToLifecycleOpts())
+	}
 	select {
 	case <-GlobalContext.Done():
 		t.once.Do(func() {
 			close(t.transitionCh)
 		})
-	case t.transitionCh <- oi:
+	case t.transitionCh <- transitionTask{oi: oi, tier: tier}:
+		t.stats.queued(tier, oi)
+		return true
 	default:
 	}
+	return false
 }
 
 var (
@@ -184,7 +223,8 @@ var (
 
 func newTransitionState(ctx context.Context, objAPI ObjectLayer) *transitionState {
 	return &transitionState{
-		transitionCh: make(chan ObjectInfo, 10000),
+		transitionCh: make(chan transitionTask, 10000),
+		stats:        newTransitionStats(),
 		ctx:          ctx,
 		objAPI:       objAPI,
 		killCh:       make(chan struct{}),
@@ -210,14 +250,17 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 			return
 		case <-ctx.Done():
 			return
-		case oi, ok := <-t.transitionCh:
+		case task, ok := <-t.transitionCh:
 			if !ok {
 				return
 			}
 			atomic.AddInt32(&t.activeTasks, 1)
-			if err := transitionObject(ctx, objectAPI, oi); err != nil {
+			oi := task.oi
+			err := transitionObject(ctx, objectAPI, oi, task.tier)
+			if err != nil {
 				logger.LogIf(ctx, fmt.Errorf("Transition failed for %s/%s version:%s with %w", oi.Bucket, oi.Name, oi.VersionID, err))
 			}
+			t.stats.done(task.tier, oi, err)
 			atomic.AddInt32(&t.activeTasks, -1)
 		}
 	}
@@ -244,6 +287,72 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 	globalTransitionState = newTransitionState(ctx, objectAPI)
 	n := globalAPIConfig.getTransitionWorkers()
 	globalTransitionState.UpdateWorkers(n)
+	go globalTransitionState.stats.retry(ctx, globalTransitionState)
+
+	globalRehydrationState = newRehydrationState()
+	go globalRehydrationState.worker(ctx, objectAPI)
+}
+
+// rehydrationState resumes restores of objects which were waiting on their
+// remote tier to rehydrate them from an offline archive tier. Such objects
+// are queued by the scanner.
+type rehydrationState struct {
+	rehydrateCh chan ObjectInfo
+}
+
+var globalRehydrationState *rehydrationState
+
+func newRehydrationState() *rehydrationState {
+	return &rehydrationState{
+		rehydrateCh: make(chan ObjectInfo, 1000),
+	}
+}
+
+// queue enqueues oi to check whether it has been rehydrated, if the queue is
+// full oi is checked again in the next scanner cycle.
+func (r *rehydrationState) queue(oi ObjectInfo) {
+	if r == nil {
+		return
+	}
+	select {
+	case <-GlobalContext.Done():
+	case r.rehydrateCh <- oi:
+	default:
+	}
+}
+
+func (r *rehydrationState) worker(ctx context.Context, objectAPI ObjectLayer) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case oi := <-r.rehydrateCh:
+			days, err := strconv.Atoi(oi.UserDefined[xhttp.AmzRestoreExpiryDays])
+			if err != nil {
+				days = 1
+			}
+			opts := ObjectOptions{
+				Transition: TransitionOptions{
+					RestoreRequest: &RestoreObjectRequest{Days: days},
+					RestoreExpiry:  lifecycle.ExpectedExpiryTime(time.Now(), days),
+				},
+				VersionID: oi.VersionID,
+			}
+			err = objectAPI.RestoreTransitionedObject(ctx, oi.Bucket, oi.Name, opts)
+			if err != nil && !errors.Is(err, errRestoreRehydrating) {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if err == nil {
+				sendEvent(eventArgs{
+					EventName:  event.ObjectRestorePostCompleted,
+					BucketName: oi.Bucket,
+					Object:     oi,
+					Host:       "Internal: [ILM-Restore]",
+				})
+			}
+		}
+	}
 }
 
 var errInvalidStorageClass = errors.New("invalid storage class")
@@ -295,7 +404,7 @@ const (
 // 2. when a transitioned object expires (based on an ILM rule).
 func expireTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi *ObjectInfo, lcOpts lifecycle.ObjectOpts, action expireAction) error {
 	var opts ObjectOptions
-	opts.Versioned = globalBucketVersioningSys.Enabled(oi.Bucket)
+	opts.Versioned = globalBucketVersioningSys.PrefixEnabled(oi.Bucket, oi.Name)
 	opts.VersionID = lcOpts.VersionID
 	opts.Expiration = ExpirationOptions{Expire: true}
 	switch action {
@@ -343,8 +452,14 @@ func expireTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi *Ob
 		// from the source, while leaving metadata behind. The data on
 		// transitioned tier lies untouched and still accessible
 		opts.Transition.ExpireRestored = true
-		_, err := objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts)
-		return err
+		if _, err := objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, opts); err != nil {
+			return err
+		}
+		// The copy rehydrated by an archive tier to restore the object
+		// is not needed anymore.
+		if err := removeRehydratedObject(ctx, *oi); err != nil {
+			logger.LogIf(ctx, err)
+		}
 	default:
 		return fmt.Errorf("Unknown expire action %v", action)
 	}
@@ -367,20 +482,16 @@ func genTransitionObjName(bucket string) (string, error) {
 // storage specified by the transition ARN, the metadata is left behind on source cluster and original content
 // is moved to the transition tier. Note that in the case of encrypted objects, entire encrypted stream is moved
 // to the transition tier without decrypting or re-encrypting.
-func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo) error {
-	lc, err := globalLifecycleSys.Get(oi.Bucket)
-	if err != nil {
-		return err
-	}
+func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo, tier string) error {
 	opts := ObjectOptions{
 		Transition: TransitionOptions{
 			Status: lifecycle.TransitionPending,
-			Tier:   lc.TransitionTier(oi.ToLifecycleOpts()),
+			Tier:   tier,
 			ETag:   oi.ETag,
 		},
 		VersionID:        oi.VersionID,
-		Versioned:        globalBucketVersioningSys.Enabled(oi.Bucket),
-		VersionSuspended: globalBucketVersioningSys.Suspended(oi.Bucket),
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(oi.Bucket, oi.Name),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(oi.Bucket, oi.Name),
 		MTime:            oi.ModTime,
 	}
 	return objectAPI.TransitionObject(ctx, oi.Bucket, oi.Name, opts)
@@ -541,8 +652,8 @@ func (r *RestoreObjectRequest) validate(ctx context.Context, objAPI ObjectLayer)
 
 // postRestoreOpts returns ObjectOptions with version-id from the POST restore object request for a given bucket and object.
 func postRestoreOpts(ctx context.Context, r *http.Request, bucket, object string) (opts ObjectOptions, err error) {
-	versioned := globalBucketVersioningSys.Enabled(bucket)
-	versionSuspended := globalBucketVersioningSys.Suspended(bucket)
+	versioned := globalBucketVersioningSys.PrefixEnabled(bucket, object)
+	versionSuspended := globalBucketVersioningSys.PrefixSuspended(bucket, object)
 	vid := strings.TrimSpace(r.Form.Get(xhttp.VersionID))
 	if vid != "" && vid != nullVersionID {
 		_, err := uuid.Parse(vid)
@@ -606,8 +717,8 @@ func putRestoreOpts(bucket, object string, rreq *RestoreObjectRequest, objInfo O
 	}
 
 	return ObjectOptions{
-		Versioned:        globalBucketVersioningSys.Enabled(bucket),
-		VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
 		UserDefined:      meta,
 		VersionID:        objInfo.VersionID,
 		MTime:            objInfo.ModTime,
@@ -635,6 +746,50 @@ func (oi ObjectInfo) IsRemote() bool {
 	return !isRestoredObjectOnDisk(oi.UserDefined)
 }
 
+// errRestoreRehydrating is returned when an object can't be restored yet
+// because its remote tier is rehydrating it from an offline archive tier.
+var errRestoreRehydrating = errors.New("remote object is being rehydrated")
+
+// rehydrateTransitionedObject requests the remote tier of oi to rehydrate
+// the transitioned object, if the tier keeps it in an offline archive tier.
+// It returns true once the transitioned object can be read.
+func rehydrateTransitionedObject(ctx context.Context, oi ObjectInfo) (bool, error) {
+	w, err := globalTierConfigMgr.getDriver(oi.TransitionedObject.Tier)
+	if err != nil {
+		return false, err
+	}
+	a, ok := w.(warmBackendArchive)
+	if !ok {
+		return true, nil
+	}
+	return a.Rehydrate(ctx, oi.TransitionedObject.Name, remoteVersionID(oi.TransitionedObject.VersionID))
+}
+
+// removeRehydratedObject removes the online copy the remote tier of oi
+// rehydrated the transitioned object to, if the tier keeps it in an
+// offline archive tier.
+func removeRehydratedObject(ctx context.Context, oi ObjectInfo) error {
+	w, err := globalTierConfigMgr.getDriver(oi.TransitionedObject.Tier)
+	if err != nil {
+		return err
+	}
+	a, ok := w.(warmBackendArchive)
+	if !ok {
+		return nil
+	}
+	return a.RemoveRehydrated(ctx, oi.TransitionedObject.Name, remoteVersionID(oi.TransitionedObject.VersionID))
+}
+
+// isRestoreRehydrating returns true if the restore of an object is waiting
+// on its remote tier to rehydrate it.
+func isRestoreRehydrating(oi ObjectInfo) bool {
+	if !oi.RestoreOngoing || oi.TransitionedObject.Status != lifecycle.TransitionComplete {
+		return false
+	}
+	_, ok := oi.UserDefined[ReservedMetadataPrefixLower+RestoreRehydrating]
+	return ok
+}
+
 // restoreObjStatus represents a restore-object's status. It can be either
 // ongoing or completed.
 type restoreObjStatus struct {
@@ -746,11 +901,13 @@ func isRestoredObjectOnDisk(meta map[string]string) (onDisk bool) {
 
 // ToLifecycleOpts returns lifecycle.ObjectOpts value for oi.
 func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
+	size, _ := oi.GetActualSize()
 	return lifecycle.ObjectOpts{
 		Name:             oi.Name,
 		UserTags:         oi.UserTags,
 		VersionID:        oi.VersionID,
 		ModTime:          oi.ModTime,
+		Size:             size,
 		IsLatest:         oi.IsLatest,
 		NumVersions:      oi.NumVersions,
 		DeleteMarker:     oi.DeleteMarker,
@@ -19,7 +19,9 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -245,3 +247,103 @@ func TestValidateTransitionTier(t *testing.T) {
 		}
 	}
 }
+
+// memArchiveWarmBackend is a remote tier keeping transitioned objects in
+// memory as if they were archived, recording the rehydrated copies.
+type memArchiveWarmBackend struct {
+	*memWarmBackend
+	mu         sync.Mutex
+	rehydrated map[string]bool
+}
+
+func (m *memArchiveWarmBackend) Archived() bool {
+	return true
+}
+
+func (m *memArchiveWarmBackend) Rehydrate(ctx context.Context, object string, rv remoteVersionID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rehydrated[object] = true
+	return true, nil
+}
+
+func (m *memArchiveWarmBackend) RemoveRehydrated(ctx context.Context, object string, rv remoteVersionID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.rehydrated, object)
+	return nil
+}
+
+func TestExpireRestoredObjectRemovesRehydrated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(tiers *TierConfigMgr) {
+		globalTierConfigMgr = tiers
+	}(globalTierConfigMgr)
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	tier := &memArchiveWarmBackend{
+		memWarmBackend: &memWarmBackend{objects: make(map[string][]byte)},
+		rehydrated:     make(map[string]bool),
+	}
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierConfigMgr.drivercache["ARCHIVE"] = tier
+
+	const bucket, object = "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Restored objects are only expired in buckets with a lifecycle.
+	meta := newBucketMetadata(bucket)
+	meta.lifecycleConfig, err = lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>365</Days></Expiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+	defer globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.TransitionObject(ctx, bucket, object, ObjectOptions{
+		Transition: TransitionOptions{Status: lifecycle.TransitionComplete, Tier: "ARCHIVE", ETag: oi.ETag},
+		MTime:      oi.ModTime,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.RestoreTransitionedObject(ctx, bucket, object, ObjectOptions{
+		Transition: TransitionOptions{RestoreRequest: &RestoreObjectRequest{Days: 1}, RestoreExpiry: UTCNow().Add(-time.Minute)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if oi, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !tier.rehydrated[oi.TransitionedObject.Name] {
+		t.Fatalf("expected the object to be rehydrated to restore it")
+	}
+
+	if err = expireTransitionedObject(ctx, obj, &oi, oi.ToLifecycleOpts(), expireRestoredObj); err != nil {
+		t.Fatal(err)
+	}
+	if tier.rehydrated[oi.TransitionedObject.Name] {
+		t.Fatalf("expected the rehydrated copy to be removed with the restored object")
+	}
+	// The archived object is kept.
+	if _, ok := tier.objects[oi.TransitionedObject.Name]; !ok {
+		t.Fatalf("expected the transitioned object to be kept")
+	}
+	if oi, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil || !oi.IsRemote() {
+		t.Fatalf("expected the object to be remote again, got %+v, %v", oi, err)
+	}
+}
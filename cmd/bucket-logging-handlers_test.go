@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/logging"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Wrapper for calling bucket logging HTTP handler tests for both Erasure multiple disks and single node setup.
+func TestBucketLoggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingHandlers, []string{"PutBucketLogging", "GetBucketLogging"})
+}
+
+func testBucketLoggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	targetBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(GlobalContext, targetBucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	loggingURL := func() string {
+		queryValue := url.Values{}
+		queryValue.Set("logging", "")
+		return makeTestTargetURL("", bucketName, "", queryValue)
+	}
+	do := func(method string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := newTestSignedRequestV4(method, loggingURL(), int64(len(body)), bytes.NewReader(body),
+			credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	expect := func(rec *httptest.ResponseRecorder, status int, what string) {
+		t.Helper()
+		if rec.Code != status {
+			t.Fatalf("%s: %s: expected the response status to be `%d`, but instead found `%d`: %s",
+				instanceType, what, status, rec.Code, rec.Body.String())
+		}
+	}
+	getStatus := func() logging.Config {
+		t.Helper()
+		rec := do(http.MethodGet, nil)
+		expect(rec, http.StatusOK, "get bucket logging")
+		var status logging.Config
+		if err := xml.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		}
+		return status
+	}
+
+	if status := getStatus(); status.Enabled() {
+		t.Fatalf("%s: expected logging to be disabled, got %+v", instanceType, status.LoggingEnabled)
+	}
+
+	enabled := func(target string) []byte {
+		return []byte(fmt.Sprintf(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>%s</TargetBucket><TargetPrefix>access/</TargetPrefix><TargetObjectKeyFormat><PartitionedPrefix><PartitionDateSource>EventTime</PartitionDateSource></PartitionedPrefix></TargetObjectKeyFormat></LoggingEnabled></BucketLoggingStatus>`, target))
+	}
+	expect(do(http.MethodPut, []byte("<invalid")), http.StatusBadRequest, "put malformed bucket logging")
+	expect(do(http.MethodPut, enabled("")), http.StatusBadRequest, "put bucket logging without target bucket")
+	expect(do(http.MethodPut, enabled("missing-logging-target")), http.StatusNotFound, "put bucket logging with missing target bucket")
+	expect(do(http.MethodPut, enabled(targetBucket)), http.StatusOK, "put bucket logging")
+
+	status := getStatus()
+	if status.TargetBucket() != targetBucket || !status.PartitionByEventTime() {
+		t.Fatalf("%s: unexpected bucket logging status %+v", instanceType, status.LoggingEnabled)
+	}
+
+	// The buffered records are delivered to the target bucket.
+	cfg, err := globalBucketMetadataSys.GetLoggingConfig(bucketName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketAccessLogs.add(cfg, logging.Record{
+		Bucket:     bucketName,
+		Time:       UTCNow(),
+		Operation:  "REST.GET.OBJECT",
+		Key:        "object",
+		HTTPStatus: http.StatusOK,
+	})
+	globalBucketAccessLogs.flush(GlobalContext)
+
+	region := globalSite.Region
+	if region == "" {
+		region = accessLogDefaultRegion
+	}
+	prefix := "access/" + globalDeploymentID + "/" + region + "/" + bucketName + "/" + UTCNow().Format("2006/01/02") + "/"
+	result, err := obj.ListObjects(GlobalContext, targetBucket, "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || !strings.HasPrefix(result.Objects[0].Name, prefix) {
+		t.Fatalf("%s: expected one log object under %s, got %+v", instanceType, prefix, result.Objects)
+	}
+	gr, err := obj.GetObjectNInfo(GlobalContext, targetBucket, result.Objects[0].Name, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), " "+bucketName+" ") || !strings.Contains(string(data), " REST.GET.OBJECT object ") {
+		t.Fatalf("%s: unexpected access log %q", instanceType, data)
+	}
+
+	expect(do(http.MethodPut, []byte(`<BucketLoggingStatus/>`)), http.StatusOK, "disable bucket logging")
+	if status := getStatus(); status.Enabled() {
+		t.Fatalf("%s: expected logging to be disabled, got %+v", instanceType, status.LoggingEnabled)
+	}
+}
+
+func TestLogBucketAccess(t *testing.T) {
+	cfg := &logging.Config{LoggingEnabled: &logging.LoggingEnabled{TargetBucket: "logs"}}
+	defer func(l *bucketAccessLogs) { globalBucketAccessLogs = l }(globalBucketAccessLogs)
+	globalBucketAccessLogs = newBucketAccessLogs()
+
+	req := httptest.NewRequest(http.MethodGet, "/photos/2019/puppy.jpg?versionId=v1", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	req = mux.SetURLVars(req, map[string]string{"bucket": "photos", "object": "2019/puppy.jpg"})
+
+	w := logger.NewResponseWriter(httptest.NewRecorder())
+	w.LogErrBody = true
+	w.Header().Set(xhttp.AmzRequestID, "REQUESTID")
+	writeErrorResponse(GlobalContext, w, errorCodes.ToAPIErr(ErrNoSuchKey), req.URL)
+
+	logBucketAccess(cfg, req, w)
+
+	b := globalBucketAccessLogs.batches["photos"]
+	if b == nil {
+		t.Fatal("expected a buffered access log record")
+	}
+	fields := strings.Fields(b.data.String())
+	for i, expected := range map[int]string{
+		0:  globalMinioDefaultOwnerID,
+		1:  "photos",
+		6:  "REQUESTID",
+		7:  "REST.GET.OBJECT",
+		8:  "2019/puppy.jpg",
+		9:  `"GET`,
+		12: "404",
+		13: "NoSuchKey",
+		15: "-",
+		19: `"test-agent"`,
+		20: "v1",
+	} {
+		if fields[i] != expected {
+			t.Errorf("field %d: expected %s, got %s (%s)", i, expected, fields[i], b.data.String())
+		}
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/logging"
+	"github.com/minio/minio/internal/handlers"
+	xhash "github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Bucket access logging configuration file name.
+	bucketLoggingConfig = "logging.xml"
+
+	// The access logs of a bucket are delivered once they reach
+	// accessLogMaxSize, or at the latest after accessLogFlushInterval.
+	accessLogMaxSize       = 4 << 20
+	accessLogFlushInterval = 5 * time.Minute
+
+	// accessLogDefaultRegion is the region of the partitioned log object
+	// keys when no region is configured, as in S3.
+	accessLogDefaultRegion = "us-east-1"
+)
+
+// accessLogBatch - access log records of a source bucket, delivered
+// together as a single log object.
+type accessLogBatch struct {
+	bucket    string
+	config    *logging.Config
+	eventTime time.Time
+	data      bytes.Buffer
+}
+
+// bucketAccessLogs buffers the access log records of the buckets with
+// logging enabled and delivers them to the target buckets. Every node
+// delivers the records of the requests it served.
+type bucketAccessLogs struct {
+	mu      sync.Mutex
+	batches map[string]*accessLogBatch
+}
+
+func newBucketAccessLogs() *bucketAccessLogs {
+	return &bucketAccessLogs{
+		batches: make(map[string]*accessLogBatch),
+	}
+}
+
+// add appends the record to the batch of its bucket. The current batch is
+// delivered first if the logging configuration was updated, or if the record
+// belongs to another partition.
+func (l *bucketAccessLogs) add(cfg *logging.Config, rec logging.Record) {
+	var deliver []*accessLogBatch
+
+	l.mu.Lock()
+	b := l.batches[rec.Bucket]
+	if b != nil && (b.config != cfg ||
+		(cfg.PartitionByEventTime() && b.eventTime.Format("2006-01-02") != rec.Time.UTC().Format("2006-01-02"))) {
+		deliver = append(deliver, b)
+		b = nil
+	}
+	if b == nil {
+		b = &accessLogBatch{bucket: rec.Bucket, config: cfg, eventTime: rec.Time.UTC()}
+		l.batches[rec.Bucket] = b
+	}
+	b.data.WriteString(rec.String())
+	b.data.WriteByte('\n')
+	if b.data.Len() >= accessLogMaxSize {
+		deliver = append(deliver, b)
+		delete(l.batches, rec.Bucket)
+	}
+	l.mu.Unlock()
+
+	for _, b := range deliver {
+		go b.deliver(GlobalContext)
+	}
+}
+
+// flush delivers all buffered records.
+func (l *bucketAccessLogs) flush(ctx context.Context) {
+	l.mu.Lock()
+	batches := l.batches
+	l.batches = make(map[string]*accessLogBatch)
+	l.mu.Unlock()
+
+	for _, b := range batches {
+		b.deliver(ctx)
+	}
+}
+
+// deliver writes the records of the batch to a new log object in the
+// target bucket.
+func (b *accessLogBatch) deliver(ctx context.Context) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+
+	var unique [8]byte
+	if _, err := rand.Read(unique[:]); err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	region := globalSite.Region
+	if region == "" {
+		region = accessLogDefaultRegion
+	}
+	targetBucket := b.config.TargetBucket()
+	object := b.config.ObjectKey(globalDeploymentID, region, b.bucket, b.eventTime, UTCNow(),
+		strings.ToUpper(hex.EncodeToString(unique[:])))
+
+	size := int64(b.data.Len())
+	hashReader, err := xhash.NewReader(bytes.NewReader(b.data.Bytes()), size, "", "", size)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	opts := ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: "text/plain"},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(targetBucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(targetBucket, object),
+	}
+	if _, err = objAPI.PutObject(ctx, targetBucket, object, NewPutObjReader(hashReader), opts); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to deliver the access logs of bucket %s to %s: %w", b.bucket, targetBucket, err))
+	}
+}
+
+// initBucketAccessLogs will start delivering the buffered access logs
+// in the background.
+func initBucketAccessLogs(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(accessLogFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				globalBucketAccessLogs.flush(ctx)
+			}
+		}
+	}()
+}
+
+// getBucketLoggingConfig returns the logging configuration of the bucket
+// of the request, nil if access logging is not enabled.
+func getBucketLoggingConfig(r *http.Request) *logging.Config {
+	bucket := mux.Vars(r)["bucket"]
+	if bucket == "" || globalBucketMetadataSys == nil || isMinioMetaBucketName(bucket) {
+		return nil
+	}
+	cfg, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	if err != nil || !cfg.Enabled() {
+		return nil
+	}
+	return cfg
+}
+
+var tlsVersionNames = map[uint16]string{
+	tls.VersionTLS10: "TLSv1",
+	tls.VersionTLS11: "TLSv1.1",
+	tls.VersionTLS12: "TLSv1.2",
+	tls.VersionTLS13: "TLSv1.3",
+}
+
+// logBucketAccess adds the access log record of a served request, w must
+// have been set to log error bodies to record the S3 error codes.
+func logBucketAccess(cfg *logging.Config, r *http.Request, w *logger.ResponseWriter) {
+	vars := mux.Vars(r)
+	object := likelyUnescapeGeneric(vars["object"], url.PathUnescape)
+	query := r.URL.Query()
+
+	rec := logging.Record{
+		BucketOwner:    globalMinioDefaultOwnerID,
+		Bucket:         vars["bucket"],
+		Time:           w.StartTime,
+		RemoteIP:       handlers.GetSourceIP(r),
+		Requester:      getReqAccessCred(r, globalSite.Region).AccessKey,
+		RequestID:      w.Header().Get(xhttp.AmzRequestID),
+		Operation:      logging.Operation(r.Method, query, object, r.Header.Get(xhttp.AmzCopySource) != ""),
+		Key:            object,
+		RequestURI:     r.Method + " " + r.URL.RequestURI() + " " + r.Proto,
+		HTTPStatus:     w.StatusCode,
+		BytesSent:      int64(w.BodySize()),
+		TotalTime:      time.Since(w.StartTime),
+		TurnAroundTime: w.TimeToFirstByte,
+		Referer:        r.Referer(),
+		UserAgent:      r.UserAgent(),
+		VersionID:      query.Get(xhttp.VersionID),
+		HostHeader:     r.Host,
+	}
+	if rec.VersionID == "" {
+		rec.VersionID = w.Header().Get(xhttp.AmzVersionID)
+	}
+
+	rec.ErrorCode = getS3ErrorCode(w)
+
+	isObject := strings.HasSuffix(rec.Operation, ".OBJECT") || strings.HasSuffix(rec.Operation, ".PART")
+	if isObject && w.StatusCode < http.StatusBadRequest {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			size := w.Header().Get(xhttp.ContentLength)
+			if cr := w.Header().Get(xhttp.ContentRange); cr != "" {
+				size = cr[strings.LastIndex(cr, "/")+1:]
+			}
+			rec.ObjectSize, _ = strconv.ParseInt(size, 10, 64)
+		case http.MethodPut:
+			rec.ObjectSize = r.ContentLength
+			if size, err := strconv.ParseInt(r.Header.Get(xhttp.AmzDecodedContentLength), 10, 64); err == nil {
+				rec.ObjectSize = size
+			}
+		}
+	}
+
+	switch getRequestAuthType(r) {
+	case authTypeSignedV2:
+		rec.SignatureVersion, rec.AuthType = "SigV2", logging.AuthHeader
+	case authTypePresignedV2:
+		rec.SignatureVersion, rec.AuthType = "SigV2", logging.QueryString
+	case authTypeSigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
+		rec.SignatureVersion, rec.AuthType = "SigV4", logging.AuthHeader
+	case authTypePresigned:
+		rec.SignatureVersion, rec.AuthType = "SigV4", logging.QueryString
+	case authTypePostPolicy:
+		rec.SignatureVersion = "SigV4"
+	}
+
+	if r.TLS != nil {
+		rec.CipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		rec.TLSVersion = tlsVersionNames[r.TLS.Version]
+	}
+
+	globalBucketAccessLogs.add(cfg, rec)
+}
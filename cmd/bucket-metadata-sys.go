@@ -26,9 +26,14 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/bucket/compression"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/event"
@@ -52,6 +57,8 @@ func (sys *BucketMetadataSys) Remove(bucket string) {
 	sys.Lock()
 	delete(sys.metadataMap, bucket)
 	globalBucketMonitor.DeleteBucket(bucket)
+	globalBucketQoSSys.remove(bucket)
+	globalBucketErrorStats.remove(bucket)
 	sys.Unlock()
 }
 
@@ -121,6 +128,26 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.TaggingConfigXML = configData
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
+	case bucketQoSConfigFile:
+		meta.QoSConfigJSON = configData
+	case bucketReplicationBandwidthConfigFile:
+		meta.ReplicationBandwidthConfigJSON = configData
+	case bucketCompressionConfigFile:
+		meta.CompressionConfigJSON = configData
+	case bucketReplicationConflictConfigFile:
+		meta.ReplicationConflictConfigJSON = configData
+	case bucketInventoryConfig:
+		meta.InventoryConfigXML = configData
+	case bucketPublicAccessBlockConfig:
+		meta.PublicAccessBlockConfigXML = configData
+	case bucketDefaultTagsConfigFile:
+		meta.DefaultTagsConfigJSON = configData
+	case bucketStorageClassesConfigFile:
+		meta.StorageClassesConfigJSON = configData
+	case bucketReplicationProxyConfigFile:
+		meta.ReplicationProxyConfigJSON = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
 	case objectLockConfig:
 		if !globalIsErasure && !globalIsDistErasure {
 			return NotImplemented{}
@@ -194,6 +221,22 @@ func (sys *BucketMetadataSys) GetVersioningConfig(bucket string) (*versioning.Ve
 	return meta.versioningConfig, nil
 }
 
+// GetPublicAccessBlockConfig returns the public access block config of
+// the bucket. The returned object may not be modified.
+func (sys *BucketMetadataSys) GetPublicAccessBlockConfig(bucket string) (*publicaccess.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketPublicAccessBlockNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.publicAccessBlockConfig == nil {
+		return nil, BucketPublicAccessBlockNotFound{Bucket: bucket}
+	}
+	return meta.publicAccessBlockConfig, nil
+}
+
 // GetTaggingConfig returns configured tagging config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetTaggingConfig(bucket string) (*tags.Tags, error) {
@@ -323,7 +366,7 @@ func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, er
 
 // GetQuotaConfig returns configured bucket quota
 // The returned object may not be modified.
-func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota, error) {
+func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*BucketQuota, error) {
 	meta, err := sys.GetConfig(bucket)
 	if err != nil {
 		return nil, err
@@ -331,6 +374,100 @@ func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota
 	return meta.quotaConfig, nil
 }
 
+// GetQoSConfig returns configured bucket QoS limits
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetQoSConfig(bucket string) (*BucketQoS, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.qosConfig, nil
+}
+
+// GetReplicationBandwidthConfig returns the configured bucket level
+// replication bandwidth limit.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicationBandwidthConfig(bucket string) (*bandwidth.Limit, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.replicationBandwidthConfig, nil
+}
+
+// GetCompressionConfig returns the configured bucket compression rules.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCompressionConfig(bucket string) (*compression.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.compressionConfig, nil
+}
+
+// GetReplicationConflictConfig returns the configured conflict resolution
+// of the bucket replication.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicationConflictConfig(bucket string) (*replication.ConflictConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.replicationConflictConfig, nil
+}
+
+// GetReplicationProxyConfig returns the proxying configuration of the
+// bucket replication.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicationProxyConfig(bucket string) (*replication.ProxyConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.replicationProxyConfig, nil
+}
+
+// GetLoggingConfig returns the access logging configuration of the
+// bucket, logging is disabled when it is not configured.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.loggingConfig, nil
+}
+
+// GetDefaultTagsConfig returns the default object tags of the bucket.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetDefaultTagsConfig(bucket string) (*BucketDefaultTags, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.defaultTagsConfig, nil
+}
+
+// GetStorageClassesConfig returns the custom storage classes of the bucket.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetStorageClassesConfig(bucket string) (*BucketStorageClasses, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.storageClassesConfig, nil
+}
+
+// GetInventoryConfig returns the configured bucket inventory reports.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetInventoryConfig(bucket string) (*inventory.Configs, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.inventoryConfig, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
@@ -451,6 +588,8 @@ func (sys *BucketMetadataSys) concurrentLoad(ctx context.Context, buckets []Buck
 
 			globalBucketTargetSys.set(buckets[index], meta) // set remote replication targets
 
+			setReplicationBandwidthLimit(buckets[index].Name, meta) // set replication bandwidth limit
+
 			return nil
 		}, index)
 	}
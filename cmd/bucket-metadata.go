@@ -31,9 +31,14 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/bucket/compression"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/inventory"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/crypto"
@@ -67,20 +72,30 @@ var (
 // bucketMetadataFormat refers to the format.
 // bucketMetadataVersion can be used to track a rolling upgrade of a field.
 type BucketMetadata struct {
-	Name                        string
-	Created                     time.Time
-	LockEnabled                 bool // legacy not used anymore.
-	PolicyConfigJSON            []byte
-	NotificationConfigXML       []byte
-	LifecycleConfigXML          []byte
-	ObjectLockConfigXML         []byte
-	VersioningConfigXML         []byte
-	EncryptionConfigXML         []byte
-	TaggingConfigXML            []byte
-	QuotaConfigJSON             []byte
-	ReplicationConfigXML        []byte
-	BucketTargetsConfigJSON     []byte
-	BucketTargetsConfigMetaJSON []byte
+	Name                           string
+	Created                        time.Time
+	LockEnabled                    bool // legacy not used anymore.
+	PolicyConfigJSON               []byte
+	NotificationConfigXML          []byte
+	LifecycleConfigXML             []byte
+	ObjectLockConfigXML            []byte
+	VersioningConfigXML            []byte
+	EncryptionConfigXML            []byte
+	TaggingConfigXML               []byte
+	QuotaConfigJSON                []byte
+	ReplicationConfigXML           []byte
+	BucketTargetsConfigJSON        []byte
+	BucketTargetsConfigMetaJSON    []byte
+	QoSConfigJSON                  []byte
+	ReplicationBandwidthConfigJSON []byte
+	CompressionConfigJSON          []byte
+	InventoryConfigXML             []byte
+	ReplicationConflictConfigJSON  []byte
+	PublicAccessBlockConfigXML     []byte
+	DefaultTagsConfigJSON          []byte
+	StorageClassesConfigJSON       []byte
+	ReplicationProxyConfigJSON     []byte
+	LoggingConfigXML               []byte
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -90,10 +105,21 @@ type BucketMetadata struct {
 	versioningConfig       *versioning.Versioning
 	sseConfig              *bucketsse.BucketSSEConfig
 	taggingConfig          *tags.Tags
-	quotaConfig            *madmin.BucketQuota
+	quotaConfig            *BucketQuota
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	qosConfig              *BucketQoS
+	// Bucket level replication bandwidth limit
+	replicationBandwidthConfig *bandwidth.Limit
+	compressionConfig          *compression.Config
+	inventoryConfig            *inventory.Configs
+	replicationConflictConfig  *replication.ConflictConfig
+	publicAccessBlockConfig    *publicaccess.Config
+	defaultTagsConfig          *BucketDefaultTags
+	storageClassesConfig       *BucketStorageClasses
+	replicationProxyConfig     *replication.ProxyConfig
+	loggingConfig              *logging.Config
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -104,7 +130,16 @@ func newBucketMetadata(name string) BucketMetadata {
 		notificationConfig: &event.Config{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
-		quotaConfig: &madmin.BucketQuota{},
+		quotaConfig:                &BucketQuota{},
+		qosConfig:                  &BucketQoS{},
+		replicationBandwidthConfig: &bandwidth.Limit{},
+		compressionConfig:          &compression.Config{},
+		inventoryConfig:            &inventory.Configs{},
+		replicationConflictConfig:  &replication.ConflictConfig{},
+		defaultTagsConfig:          &BucketDefaultTags{},
+		storageClassesConfig:       &BucketStorageClasses{},
+		replicationProxyConfig:     &replication.ProxyConfig{},
+		loggingConfig:              &logging.Config{},
 		versioningConfig: &versioning.Versioning{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
@@ -233,6 +268,94 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		}
 	}
 
+	if len(b.QoSConfigJSON) != 0 {
+		b.qosConfig, err = parseBucketQoS(b.Name, b.QoSConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.ReplicationBandwidthConfigJSON) != 0 {
+		b.replicationBandwidthConfig, err = parseReplicationBandwidthLimit(b.Name, b.ReplicationBandwidthConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicationBandwidthConfig = &bandwidth.Limit{}
+	}
+
+	if len(b.CompressionConfigJSON) != 0 {
+		b.compressionConfig, err = compression.ParseConfig(bytes.NewReader(b.CompressionConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.compressionConfig = &compression.Config{}
+	}
+
+	if len(b.InventoryConfigXML) != 0 {
+		b.inventoryConfig, err = inventory.ParseConfigs(bytes.NewReader(b.InventoryConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.inventoryConfig = &inventory.Configs{}
+	}
+
+	if len(b.ReplicationConflictConfigJSON) != 0 {
+		b.replicationConflictConfig, err = replication.ParseConflictConfig(bytes.NewReader(b.ReplicationConflictConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicationConflictConfig = &replication.ConflictConfig{}
+	}
+
+	if len(b.DefaultTagsConfigJSON) != 0 {
+		b.defaultTagsConfig, err = parseBucketDefaultTags(b.Name, b.DefaultTagsConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.defaultTagsConfig = &BucketDefaultTags{}
+	}
+
+	if len(b.StorageClassesConfigJSON) != 0 {
+		b.storageClassesConfig, err = parseBucketStorageClasses(b.StorageClassesConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.storageClassesConfig = &BucketStorageClasses{}
+	}
+
+	if len(b.ReplicationProxyConfigJSON) != 0 {
+		b.replicationProxyConfig, err = replication.ParseProxyConfig(bytes.NewReader(b.ReplicationProxyConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicationProxyConfig = &replication.ProxyConfig{}
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = &logging.Config{}
+	}
+
+	if len(b.PublicAccessBlockConfigXML) != 0 {
+		b.publicAccessBlockConfig, err = publicaccess.ParseConfig(bytes.NewReader(b.PublicAccessBlockConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.publicAccessBlockConfig = nil
+	}
+
 	if len(b.ReplicationConfigXML) != 0 {
 		b.replicationConfig, err = replication.ParseConfig(bytes.NewReader(b.ReplicationConfigXML))
 		if err != nil {
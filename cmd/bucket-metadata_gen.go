@@ -108,6 +108,66 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "QoSConfigJSON":
+			z.QoSConfigJSON, err = dc.ReadBytes(z.QoSConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "QoSConfigJSON")
+				return
+			}
+		case "ReplicationBandwidthConfigJSON":
+			z.ReplicationBandwidthConfigJSON, err = dc.ReadBytes(z.ReplicationBandwidthConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationBandwidthConfigJSON")
+				return
+			}
+		case "CompressionConfigJSON":
+			z.CompressionConfigJSON, err = dc.ReadBytes(z.CompressionConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "CompressionConfigJSON")
+				return
+			}
+		case "InventoryConfigXML":
+			z.InventoryConfigXML, err = dc.ReadBytes(z.InventoryConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "InventoryConfigXML")
+				return
+			}
+		case "ReplicationConflictConfigJSON":
+			z.ReplicationConflictConfigJSON, err = dc.ReadBytes(z.ReplicationConflictConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictConfigJSON")
+				return
+			}
+		case "PublicAccessBlockConfigXML":
+			z.PublicAccessBlockConfigXML, err = dc.ReadBytes(z.PublicAccessBlockConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+				return
+			}
+		case "DefaultTagsConfigJSON":
+			z.DefaultTagsConfigJSON, err = dc.ReadBytes(z.DefaultTagsConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "DefaultTagsConfigJSON")
+				return
+			}
+		case "StorageClassesConfigJSON":
+			z.StorageClassesConfigJSON, err = dc.ReadBytes(z.StorageClassesConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClassesConfigJSON")
+				return
+			}
+		case "ReplicationProxyConfigJSON":
+			z.ReplicationProxyConfigJSON, err = dc.ReadBytes(z.ReplicationProxyConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationProxyConfigJSON")
+				return
+			}
+		case "LoggingConfigXML":
+			z.LoggingConfigXML, err = dc.ReadBytes(z.LoggingConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigXML")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -121,9 +181,9 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 14
+	// map header, size 24
 	// write "Name"
-	err = en.Append(0x8e, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	err = en.Append(0xde, 0x0, 0x18, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
@@ -262,15 +322,115 @@ func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 		return
 	}
+	// write "QoSConfigJSON"
+	err = en.Append(0xad, 0x51, 0x6f, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.QoSConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "QoSConfigJSON")
+		return
+	}
+	// write "ReplicationBandwidthConfigJSON"
+	err = en.Append(0xbe, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ReplicationBandwidthConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicationBandwidthConfigJSON")
+		return
+	}
+	// write "CompressionConfigJSON"
+	err = en.Append(0xb5, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.CompressionConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "CompressionConfigJSON")
+		return
+	}
+	// write "InventoryConfigXML"
+	err = en.Append(0xb2, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.InventoryConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "InventoryConfigXML")
+		return
+	}
+	// write "ReplicationConflictConfigJSON"
+	err = en.Append(0xbd, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ReplicationConflictConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicationConflictConfigJSON")
+		return
+	}
+	// write "PublicAccessBlockConfigXML"
+	err = en.Append(0xba, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.PublicAccessBlockConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+		return
+	}
+	// write "DefaultTagsConfigJSON"
+	err = en.Append(0xb5, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.DefaultTagsConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "DefaultTagsConfigJSON")
+		return
+	}
+	// write "StorageClassesConfigJSON"
+	err = en.Append(0xb8, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.StorageClassesConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "StorageClassesConfigJSON")
+		return
+	}
+	// write "ReplicationProxyConfigJSON"
+	err = en.Append(0xba, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ReplicationProxyConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicationProxyConfigJSON")
+		return
+	}
+	// write "LoggingConfigXML"
+	err = en.Append(0xb0, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.LoggingConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "LoggingConfigXML")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 14
+	// map header, size 24
 	// string "Name"
-	o = append(o, 0x8e, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = append(o, 0xde, 0x0, 0x18, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.Name)
 	// string "Created"
 	o = append(o, 0xa7, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64)
@@ -311,6 +471,36 @@ func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "BucketTargetsConfigMetaJSON"
 	o = append(o, 0xbb, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x4a, 0x53, 0x4f, 0x4e)
 	o = msgp.AppendBytes(o, z.BucketTargetsConfigMetaJSON)
+	// string "QoSConfigJSON"
+	o = append(o, 0xad, 0x51, 0x6f, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.QoSConfigJSON)
+	// string "ReplicationBandwidthConfigJSON"
+	o = append(o, 0xbe, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ReplicationBandwidthConfigJSON)
+	// string "CompressionConfigJSON"
+	o = append(o, 0xb5, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.CompressionConfigJSON)
+	// string "InventoryConfigXML"
+	o = append(o, 0xb2, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.InventoryConfigXML)
+	// string "ReplicationConflictConfigJSON"
+	o = append(o, 0xbd, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ReplicationConflictConfigJSON)
+	// string "PublicAccessBlockConfigXML"
+	o = append(o, 0xba, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.PublicAccessBlockConfigXML)
+	// string "DefaultTagsConfigJSON"
+	o = append(o, 0xb5, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x67, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.DefaultTagsConfigJSON)
+	// string "StorageClassesConfigJSON"
+	o = append(o, 0xb8, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.StorageClassesConfigJSON)
+	// string "ReplicationProxyConfigJSON"
+	o = append(o, 0xba, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ReplicationProxyConfigJSON)
+	// string "LoggingConfigXML"
+	o = append(o, 0xb0, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.LoggingConfigXML)
 	return
 }
 
@@ -416,6 +606,66 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "QoSConfigJSON":
+			z.QoSConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.QoSConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "QoSConfigJSON")
+				return
+			}
+		case "ReplicationBandwidthConfigJSON":
+			z.ReplicationBandwidthConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.ReplicationBandwidthConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationBandwidthConfigJSON")
+				return
+			}
+		case "CompressionConfigJSON":
+			z.CompressionConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.CompressionConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "CompressionConfigJSON")
+				return
+			}
+		case "InventoryConfigXML":
+			z.InventoryConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.InventoryConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "InventoryConfigXML")
+				return
+			}
+		case "ReplicationConflictConfigJSON":
+			z.ReplicationConflictConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.ReplicationConflictConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationConflictConfigJSON")
+				return
+			}
+		case "PublicAccessBlockConfigXML":
+			z.PublicAccessBlockConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.PublicAccessBlockConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+				return
+			}
+		case "DefaultTagsConfigJSON":
+			z.DefaultTagsConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.DefaultTagsConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "DefaultTagsConfigJSON")
+				return
+			}
+		case "StorageClassesConfigJSON":
+			z.StorageClassesConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.StorageClassesConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClassesConfigJSON")
+				return
+			}
+		case "ReplicationProxyConfigJSON":
+			z.ReplicationProxyConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.ReplicationProxyConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicationProxyConfigJSON")
+				return
+			}
+		case "LoggingConfigXML":
+			z.LoggingConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.LoggingConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigXML")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -430,6 +680,6 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketMetadata) Msgsize() (s int) {
-	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON)
+	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 14 + msgp.BytesPrefixSize + len(z.QoSConfigJSON) + 31 + msgp.BytesPrefixSize + len(z.ReplicationBandwidthConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.CompressionConfigJSON) + 19 + msgp.BytesPrefixSize + len(z.InventoryConfigXML) + 30 + msgp.BytesPrefixSize + len(z.ReplicationConflictConfigJSON) + 27 + msgp.BytesPrefixSize + len(z.PublicAccessBlockConfigXML) + 22 + msgp.BytesPrefixSize + len(z.DefaultTagsConfigJSON) + 25 + msgp.BytesPrefixSize + len(z.StorageClassesConfigJSON) + 27 + msgp.BytesPrefixSize + len(z.ReplicationProxyConfigJSON) + 17 + msgp.BytesPrefixSize + len(z.LoggingConfigXML)
 	return
 }
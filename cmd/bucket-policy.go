@@ -27,6 +27,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -43,6 +44,11 @@ func (sys *PolicySys) Get(bucket string) (*policy.Policy, error) {
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *PolicySys) IsAllowed(args policy.Args) bool {
+	if !args.IsOwner && getPublicAccessBlock(args.BucketName).RestrictsPublicAccess() {
+		// Anonymous requests are denied whatever the bucket policy.
+		return false
+	}
+
 	p, err := sys.Get(args.BucketName)
 	if err == nil {
 		return p.IsAllowed(args)
@@ -58,6 +64,36 @@ func (sys *PolicySys) IsAllowed(args policy.Args) bool {
 	return args.IsOwner
 }
 
+// getPublicAccessBlock returns the public access block applying to the
+// bucket, the "block_public_access" API setting blocks all public access
+// of every bucket.
+func getPublicAccessBlock(bucket string) *publicaccess.Config {
+	if globalAPIConfig.isPublicAccessBlocked() {
+		return &publicaccess.Config{
+			BlockPublicAcls:       true,
+			IgnorePublicAcls:      true,
+			BlockPublicPolicy:     true,
+			RestrictPublicBuckets: true,
+		}
+	}
+	config, err := globalBucketMetadataSys.GetPublicAccessBlockConfig(bucket)
+	if err != nil {
+		return nil
+	}
+	return config
+}
+
+// isPublicPolicy returns true if a statement of the bucket policy allows
+// anonymous requests without any condition.
+func isPublicPolicy(p *policy.Policy) bool {
+	for _, st := range p.Statements {
+		if st.Effect == policy.Allow && st.Principal.Match("") && len(st.Conditions) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // NewPolicySys - creates new policy system.
 func NewPolicySys() *PolicySys {
 	return &PolicySys{}
@@ -89,7 +125,7 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 	switch authType {
 	case authTypeSignedV2, authTypePresignedV2:
 		signatureVersion = signV2Algorithm
-	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypePostPolicy:
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer, authTypePostPolicy:
 		signatureVersion = signV4Algorithm
 	}
 
@@ -97,7 +133,7 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 	switch authType {
 	case authTypePresignedV2, authTypePresigned:
 		authtype = "REST-QUERY-STRING"
-	case authTypeSignedV2, authTypeSigned, authTypeStreamingSigned:
+	case authTypeSignedV2, authTypeSigned, authTypeStreamingSigned, authTypeStreamingUnsignedTrailer:
 		authtype = "REST-HEADER"
 	case authTypePostPolicy:
 		authtype = "POST"
@@ -183,6 +219,11 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		}
 	}
 
+	// Session tags of temporary credentials.
+	setPrincipalTagValues(args, claims)
+
+	setRequestConditionValues(r, args)
+
 	return args
 }
 
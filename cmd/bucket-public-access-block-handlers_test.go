@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/publicaccess"
+)
+
+// Wrapper for calling public access block HTTP handler tests for both Erasure multiple disks and single node setup.
+func TestPublicAccessBlockHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testPublicAccessBlockHandlers, []string{
+		"PutPublicAccessBlock", "GetPublicAccessBlock", "DeletePublicAccessBlock",
+		"PutBucketPolicy", "GetObject",
+	})
+}
+
+func testPublicAccessBlockHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	const object = "public-object"
+	data := []byte("hello")
+	if _, err := obj.PutObject(GlobalContext, bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	accessBlockURL := func() string {
+		queryValue := url.Values{}
+		queryValue.Set("publicAccessBlock", "")
+		return makeTestTargetURL("", bucketName, "", queryValue)
+	}
+	do := func(method, urlStr string, body []byte, anonymous bool) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		var err error
+		if anonymous {
+			req, err = newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		} else {
+			req, err = newTestSignedRequestV4(method, urlStr, int64(len(body)), bytes.NewReader(body),
+				credentials.AccessKey, credentials.SecretKey, nil)
+		}
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	expect := func(rec *httptest.ResponseRecorder, status int, what string) {
+		t.Helper()
+		if rec.Code != status {
+			t.Fatalf("%s: %s: expected the response status to be `%d`, but instead found `%d`: %s",
+				instanceType, what, status, rec.Code, rec.Body.String())
+		}
+	}
+
+	publicPolicy := []byte(fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName))
+	getObjectURL := getGetObjectURL("", bucketName, object)
+
+	expect(do(http.MethodGet, accessBlockURL(), nil, false), http.StatusNotFound, "get missing public access block")
+	expect(do(http.MethodPut, getPutPolicyURL("", bucketName), publicPolicy, false), http.StatusNoContent, "put public policy")
+	expect(do(http.MethodGet, getObjectURL, nil, true), http.StatusOK, "anonymous get with public policy")
+
+	config := []byte(`<PublicAccessBlockConfiguration><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>`)
+	expect(do(http.MethodPut, accessBlockURL(), []byte("<invalid"), false), http.StatusBadRequest, "put malformed public access block")
+	expect(do(http.MethodPut, accessBlockURL(), config, true), http.StatusForbidden, "anonymous put public access block")
+	expect(do(http.MethodPut, accessBlockURL(), config, false), http.StatusOK, "put public access block")
+
+	rec := do(http.MethodGet, accessBlockURL(), nil, false)
+	expect(rec, http.StatusOK, "get public access block")
+	var got publicaccess.Config
+	if err := xml.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.BlockPublicPolicy || !got.RestrictPublicBuckets || got.BlockPublicAcls || got.IgnorePublicAcls {
+		t.Fatalf("%s: unexpected public access block %+v", instanceType, got)
+	}
+
+	// The existing public policy is ignored, new ones are rejected.
+	expect(do(http.MethodGet, getObjectURL, nil, true), http.StatusForbidden, "anonymous get with restricted public buckets")
+	expect(do(http.MethodGet, getObjectURL, nil, false), http.StatusOK, "signed get with restricted public buckets")
+	expect(do(http.MethodPut, getPutPolicyURL("", bucketName), publicPolicy, false), http.StatusForbidden, "put public policy with blocked public policies")
+
+	expect(do(http.MethodDelete, accessBlockURL(), nil, false), http.StatusNoContent, "delete public access block")
+	expect(do(http.MethodGet, accessBlockURL(), nil, false), http.StatusNotFound, "get deleted public access block")
+	expect(do(http.MethodGet, getObjectURL, nil, true), http.StatusOK, "anonymous get after deleting public access block")
+
+	// The API setting blocks public access of all buckets.
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.blockPublicAccess = true
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.blockPublicAccess = false
+		globalAPIConfig.mu.Unlock()
+	}()
+	expect(do(http.MethodGet, getObjectURL, nil, true), http.StatusForbidden, "anonymous get with public access blocked")
+	expect(do(http.MethodPut, getPutPolicyURL("", bucketName), publicPolicy, false), http.StatusForbidden, "put public policy with public access blocked")
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/http/stats"
+	"golang.org/x/time/rate"
+)
+
+// BucketQoS - per bucket read/write limits, enforced by each
+// server independently. A zero value means no limit.
+type BucketQoS struct {
+	ReadRequestsPerSec  float64 `json:"readRequestsPerSec,omitempty"`
+	WriteRequestsPerSec float64 `json:"writeRequestsPerSec,omitempty"`
+	// Bandwidth limits in bytes per second.
+	ReadBandwidth  int64 `json:"readBandwidth,omitempty"`
+	WriteBandwidth int64 `json:"writeBandwidth,omitempty"`
+}
+
+// IsValid returns false if any of the limits is negative.
+func (q BucketQoS) IsValid() bool {
+	return q.ReadRequestsPerSec >= 0 && q.WriteRequestsPerSec >= 0 &&
+		q.ReadBandwidth >= 0 && q.WriteBandwidth >= 0
+}
+
+// IsEmpty returns true if no limits are configured.
+func (q BucketQoS) IsEmpty() bool {
+	return q == BucketQoS{}
+}
+
+// parseBucketQoS parses BucketQoS from json
+func parseBucketQoS(bucket string, data []byte) (*BucketQoS, error) {
+	qos := &BucketQoS{}
+	if err := json.Unmarshal(data, qos); err != nil {
+		return qos, err
+	}
+	if !qos.IsValid() {
+		return qos, fmt.Errorf("Invalid QoS config for bucket %s: %#v", bucket, qos)
+	}
+	return qos, nil
+}
+
+// qosType is the kind of bucket API call a QoS limit applies to.
+type qosType int
+
+const (
+	qosRead qosType = iota
+	qosWrite
+	qosTypes
+)
+
+func (t qosType) String() string {
+	if t == qosRead {
+		return "read"
+	}
+	return "write"
+}
+
+// qosTypeFromMethod - GET and HEAD requests are reads, everything else writes.
+func qosTypeFromMethod(method string) qosType {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return qosRead
+	}
+	return qosWrite
+}
+
+// bucketQoSLimiter enforces the QoS limits of a single bucket.
+type bucketQoSLimiter struct {
+	// Number of requests rejected by the request rate and the
+	// bandwidth limits, must be accessed atomically.
+	throttledRequests  [qosTypes]uint64
+	throttledBandwidth [qosTypes]uint64
+
+	cfg       BucketQoS
+	requests  [qosTypes]*rate.Limiter
+	bandwidth [qosTypes]*rate.Limiter
+}
+
+func newRequestsLimiter(perSec float64) *rate.Limiter {
+	if perSec <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(perSec), int(math.Max(1, math.Ceil(perSec))))
+}
+
+func newBandwidthLimiter(bytesPerSec int64) *rate.Limiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSec), int(bytesPerSec))
+}
+
+func newBucketQoSLimiter(cfg BucketQoS) *bucketQoSLimiter {
+	l := &bucketQoSLimiter{cfg: cfg}
+	l.requests[qosRead] = newRequestsLimiter(cfg.ReadRequestsPerSec)
+	l.requests[qosWrite] = newRequestsLimiter(cfg.WriteRequestsPerSec)
+	l.bandwidth[qosRead] = newBandwidthLimiter(cfg.ReadBandwidth)
+	l.bandwidth[qosWrite] = newBandwidthLimiter(cfg.WriteBandwidth)
+	return l
+}
+
+// admit returns zero if a request of type t may proceed, otherwise
+// the duration after which the request may be retried.
+func (l *bucketQoSLimiter) admit(t qosType, now time.Time) time.Duration {
+	// Bandwidth is charged once a request has completed, reject
+	// new requests as long as previous ones have not been paid for.
+	if lim := l.bandwidth[t]; lim != nil {
+		if delay := lim.ReserveN(now, 0).DelayFrom(now); delay > 0 {
+			atomic.AddUint64(&l.throttledBandwidth[t], 1)
+			return delay
+		}
+	}
+	if lim := l.requests[t]; lim != nil {
+		r := lim.ReserveN(now, 1)
+		if delay := r.DelayFrom(now); delay > 0 {
+			r.CancelAt(now)
+			atomic.AddUint64(&l.throttledRequests[t], 1)
+			return delay
+		}
+	}
+	return 0
+}
+
+// consume charges n transferred bytes to the bandwidth limit of type t.
+func (l *bucketQoSLimiter) consume(t qosType, n int64, now time.Time) {
+	lim := l.bandwidth[t]
+	if lim == nil {
+		return
+	}
+	// Reservations may not exceed the burst, charge in chunks.
+	burst := int64(lim.Burst())
+	for n > 0 {
+		c := n
+		if c > burst {
+			c = burst
+		}
+		lim.ReserveN(now, int(c))
+		n -= c
+	}
+}
+
+// BucketQoSSys - map of bucket and its QoS limiters.
+type BucketQoSSys struct {
+	sync.RWMutex
+	limiters map[string]*bucketQoSLimiter
+}
+
+// NewBucketQoSSys returns initialized BucketQoSSys
+func NewBucketQoSSys() *BucketQoSSys {
+	return &BucketQoSSys{
+		limiters: make(map[string]*bucketQoSLimiter),
+	}
+}
+
+// get returns the limiter of bucket, nil is returned if the bucket
+// has no QoS limits configured.
+func (sys *BucketQoSSys) get(bucket string) *bucketQoSLimiter {
+	if sys == nil || bucket == "" || bucket == minioMetaBucket || globalBucketMetadataSys == nil {
+		return nil
+	}
+
+	// Only look at metadata already loaded, requests are
+	// not authenticated yet and must not trigger any I/O.
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err != nil || meta.qosConfig == nil || meta.qosConfig.IsEmpty() {
+		return nil
+	}
+	cfg := *meta.qosConfig
+
+	sys.RLock()
+	l := sys.limiters[bucket]
+	sys.RUnlock()
+	if l != nil && l.cfg == cfg {
+		return l
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	if l = sys.limiters[bucket]; l != nil && l.cfg == cfg {
+		return l
+	}
+	nl := newBucketQoSLimiter(cfg)
+	if l != nil {
+		// Limits have changed, preserve the counters.
+		for t := qosRead; t < qosTypes; t++ {
+			nl.throttledRequests[t] = atomic.LoadUint64(&l.throttledRequests[t])
+			nl.throttledBandwidth[t] = atomic.LoadUint64(&l.throttledBandwidth[t])
+		}
+	}
+	sys.limiters[bucket] = nl
+	return nl
+}
+
+func (sys *BucketQoSSys) remove(bucket string) {
+	if sys == nil {
+		return
+	}
+	sys.Lock()
+	delete(sys.limiters, bucket)
+	sys.Unlock()
+}
+
+// bucketQoSStats - throttled request counters of a bucket.
+type bucketQoSStats struct {
+	ThrottledRequests  [qosTypes]uint64
+	ThrottledBandwidth [qosTypes]uint64
+}
+
+// stats returns the throttled request counters of all buckets with QoS limits.
+func (sys *BucketQoSSys) stats() map[string]bucketQoSStats {
+	if sys == nil {
+		return nil
+	}
+	sys.RLock()
+	defer sys.RUnlock()
+	m := make(map[string]bucketQoSStats, len(sys.limiters))
+	for bucket, l := range sys.limiters {
+		var s bucketQoSStats
+		for t := qosRead; t < qosTypes; t++ {
+			s.ThrottledRequests[t] = atomic.LoadUint64(&l.throttledRequests[t])
+			s.ThrottledBandwidth[t] = atomic.LoadUint64(&l.throttledBandwidth[t])
+		}
+		m[bucket] = s
+	}
+	return m
+}
+
+// setBucketQoSHandler enforces the per bucket QoS limits, requests
+// exceeding the limits are rejected with 503 SlowDown.
+func setBucketQoSHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guessIsHealthCheckReq(r) || guessIsMetricsReq(r) ||
+			guessIsRPCReq(r) || guessIsLoginSTSReq(r) || isAdminReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		bucket, _ := request2BucketObjectName(r)
+		l := globalBucketQoSSys.get(bucket)
+		if l == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		t := qosTypeFromMethod(r.Method)
+		if delay := l.admit(t, time.Now()); delay > 0 {
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set(xhttp.RetryAfter, strconv.Itoa(retryAfter))
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrBucketQoSExceeded), r.URL)
+			return
+		}
+
+		if l.bandwidth[t] == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if t == qosRead {
+			meteredResponse := &stats.OutgoingTrafficMeter{ResponseWriter: w}
+			h.ServeHTTP(meteredResponse, r)
+			l.consume(t, meteredResponse.BytesWritten(), time.Now())
+			return
+		}
+		meteredRequest := &stats.IncomingTrafficMeter{ReadCloser: r.Body}
+		r.Body = meteredRequest
+		h.ServeHTTP(w, r)
+		l.consume(t, meteredRequest.BytesRead(), time.Now())
+	})
+}
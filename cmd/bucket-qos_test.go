@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBucketQoS(t *testing.T) {
+	testCases := []struct {
+		data    string
+		want    BucketQoS
+		wantErr bool
+	}{
+		{`{}`, BucketQoS{}, false},
+		{`{"readRequestsPerSec":10.5,"writeBandwidth":1048576}`, BucketQoS{ReadRequestsPerSec: 10.5, WriteBandwidth: 1048576}, false},
+		{`{"writeRequestsPerSec":-1}`, BucketQoS{}, true},
+		{`{"readBandwidth":"1MiB"}`, BucketQoS{}, true},
+	}
+	for i, testCase := range testCases {
+		qos, err := parseBucketQoS("bucket", []byte(testCase.data))
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if err == nil && *qos != testCase.want {
+			t.Errorf("Test %d: expected %#v, got %#v", i+1, testCase.want, *qos)
+		}
+	}
+}
+
+func TestBucketQoSLimiter(t *testing.T) {
+	l := newBucketQoSLimiter(BucketQoS{ReadRequestsPerSec: 2, WriteBandwidth: 1000})
+	now := time.Now()
+
+	// Two read requests fit into the burst, the third has to wait.
+	for i := 0; i < 2; i++ {
+		if delay := l.admit(qosRead, now); delay != 0 {
+			t.Fatalf("read %d: unexpected delay %v", i+1, delay)
+		}
+	}
+	if delay := l.admit(qosRead, now); delay <= 0 || delay > time.Second {
+		t.Fatalf("expected read to be throttled for at most a second, got %v", delay)
+	}
+	if delay := l.admit(qosRead, now.Add(time.Second)); delay != 0 {
+		t.Fatalf("unexpected delay %v after refill", delay)
+	}
+
+	// Writes are only limited by bandwidth, once 3s worth of
+	// bytes have been written further writes are rejected.
+	if delay := l.admit(qosWrite, now); delay != 0 {
+		t.Fatalf("unexpected write delay %v", delay)
+	}
+	l.consume(qosWrite, 4000, now)
+	delay := l.admit(qosWrite, now)
+	if delay != 3*time.Second {
+		t.Fatalf("expected write to be throttled for 3s, got %v", delay)
+	}
+	if delay = l.admit(qosWrite, now.Add(3*time.Second)); delay != 0 {
+		t.Fatalf("unexpected delay %v after refill", delay)
+	}
+
+	if l.throttledRequests[qosRead] != 1 || l.throttledBandwidth[qosWrite] != 1 {
+		t.Errorf("unexpected throttled counters %v %v", l.throttledRequests, l.throttledBandwidth)
+	}
+}
@@ -28,19 +28,31 @@ import (
 	"github.com/minio/minio/internal/logger"
 )
 
+// BucketQuota holds the quota configuration of a bucket, it extends
+// madmin.BucketQuota with limits on the versions held by the bucket.
+type BucketQuota struct {
+	madmin.BucketQuota
+	// MaxVersions limits the number of object versions, delete markers
+	// excluded.
+	MaxVersions uint64 `json:"maxversions,omitempty"`
+	// MaxNoncurrentSize limits the total size in bytes of noncurrent
+	// object versions.
+	MaxNoncurrentSize uint64 `json:"maxnoncurrentsize,omitempty"`
+}
+
 // BucketQuotaSys - map of bucket and quota configuration.
 type BucketQuotaSys struct {
 	bucketStorageCache timedValue
 }
 
 // Get - Get quota configuration.
-func (sys *BucketQuotaSys) Get(bucketName string) (*madmin.BucketQuota, error) {
+func (sys *BucketQuotaSys) Get(bucketName string) (*BucketQuota, error) {
 	if globalIsGateway {
 		objAPI := newObjectLayerFn()
 		if objAPI == nil {
 			return nil, errServerNotInitialized
 		}
-		return &madmin.BucketQuota{}, nil
+		return &BucketQuota{}, nil
 	}
 
 	return globalBucketMetadataSys.GetQuotaConfig(bucketName)
@@ -52,8 +64,8 @@ func NewBucketQuotaSys() *BucketQuotaSys {
 }
 
 // parseBucketQuota parses BucketQuota from json
-func parseBucketQuota(bucket string, data []byte) (quotaCfg *madmin.BucketQuota, err error) {
-	quotaCfg = &madmin.BucketQuota{}
+func parseBucketQuota(bucket string, data []byte) (quotaCfg *BucketQuota, err error) {
+	quotaCfg = &BucketQuota{}
 	if err = json.Unmarshal(data, quotaCfg); err != nil {
 		return quotaCfg, err
 	}
@@ -63,10 +75,12 @@ func parseBucketQuota(bucket string, data []byte) (quotaCfg *madmin.BucketQuota,
 	return
 }
 
-func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64) error {
+// bucketUsage returns the last known usage of bucket, ok is false if the
+// bucket has not been scanned yet.
+func (sys *BucketQuotaSys) bucketUsage(bucket string) (bui BucketUsageInfo, ok bool, err error) {
 	objAPI := newObjectLayerFn()
 	if objAPI == nil {
-		return errServerNotInitialized
+		return bui, false, errServerNotInitialized
 	}
 
 	sys.bucketStorageCache.Once.Do(func() {
@@ -78,23 +92,35 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 		}
 	})
 
+	v, err := sys.bucketStorageCache.Get()
+	if err != nil {
+		return bui, false, err
+	}
+
+	dui, ok := v.(DataUsageInfo)
+	if !ok {
+		return bui, false, fmt.Errorf("internal error: Unexpected DUI data type: %T", v)
+	}
+
+	bui, ok = dui.BucketsUsage[bucket]
+	return bui, ok, nil
+}
+
+func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64) error {
+	if newObjectLayerFn() == nil {
+		return errServerNotInitialized
+	}
+
 	q, err := sys.Get(bucket)
 	if err != nil {
 		return err
 	}
 
 	if q != nil && q.Type == madmin.HardQuota && q.Quota > 0 {
-		v, err := sys.bucketStorageCache.Get()
+		bui, ok, err := sys.bucketUsage(bucket)
 		if err != nil {
 			return err
 		}
-
-		dui, ok := v.(DataUsageInfo)
-		if !ok {
-			return fmt.Errorf("internal error: Unexpected DUI data type: %T", v)
-		}
-
-		bui, ok := dui.BucketsUsage[bucket]
 		if !ok {
 			// bucket not found, cannot enforce quota
 			// call will fail anyways later.
@@ -109,6 +135,55 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 	return nil
 }
 
+// checkVersions returns an error if writing object adds a version to
+// bucket beyond its version count quota, or if the write turns a version
+// noncurrent while the noncurrent versions of bucket already reached their
+// size quota. Without versioning only a new object adds a version, an
+// overwrite replaces the existing null version.
+func (sys *BucketQuotaSys) checkVersions(ctx context.Context, bucket, object string, getObjectInfo GetObjectInfoFn) error {
+	if newObjectLayerFn() == nil {
+		return errServerNotInitialized
+	}
+
+	q, err := sys.Get(bucket)
+	if err != nil {
+		return err
+	}
+	if q == nil || (q.MaxVersions == 0 && q.MaxNoncurrentSize == 0) {
+		return nil
+	}
+
+	versioned := globalBucketVersioningSys.PrefixEnabled(bucket, object)
+	if !versioned {
+		var opts ObjectOptions
+		if globalBucketVersioningSys.PrefixSuspended(bucket, object) {
+			opts.VersionID = nullVersionID
+		}
+		if _, err = getObjectInfo(ctx, bucket, object, opts); err == nil {
+			// overwrite of the null version, no version is added.
+			return nil
+		}
+	}
+
+	bui, ok, err := sys.bucketUsage(bucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// bucket not found, cannot enforce quota
+		// call will fail anyways later.
+		return nil
+	}
+
+	if q.MaxVersions > 0 && bui.VersionsCount+1 > q.MaxVersions {
+		return BucketVersionQuotaExceeded{Bucket: bucket}
+	}
+	if versioned && q.MaxNoncurrentSize > 0 && bui.NoncurrentSize >= q.MaxNoncurrentSize {
+		return BucketNoncurrentQuotaExceeded{Bucket: bucket}
+	}
+	return nil
+}
+
 func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
 	if size < 0 {
 		return nil
@@ -117,6 +192,12 @@ func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
 	return globalBucketQuotaSys.check(ctx, bucket, size)
 }
 
+// enforceBucketVersionQuota enforces the version quotas of bucket on
+// writes of object which add a new object version.
+func enforceBucketVersionQuota(ctx context.Context, bucket, object string, getObjectInfo GetObjectInfoFn) error {
+	return globalBucketQuotaSys.checkVersions(ctx, bucket, object, getObjectInfo)
+}
+
 // enforceFIFOQuota deletes objects in FIFO order until sufficient objects
 // have been deleted so as to bring bucket usage within quota.
 func enforceFIFOQuotaBucket(ctx context.Context, objectAPI ObjectLayer, bucket string, bui BucketUsageInfo) {
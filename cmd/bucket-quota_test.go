@@ -0,0 +1,152 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/versioning"
+)
+
+func TestParseBucketQuota(t *testing.T) {
+	testCases := []struct {
+		data    string
+		want    BucketQuota
+		wantErr bool
+	}{
+		{
+			data: `{"quota":1073741824,"quotatype":"hard"}`,
+			want: BucketQuota{BucketQuota: madmin.BucketQuota{Quota: 1073741824, Type: madmin.HardQuota}},
+		},
+		{
+			data: `{"quota":0,"maxversions":100,"maxnoncurrentsize":1024}`,
+			want: BucketQuota{MaxVersions: 100, MaxNoncurrentSize: 1024},
+		},
+		{
+			data: `{"quota":1024,"quotatype":"fifo","maxversions":100}`,
+			want: BucketQuota{BucketQuota: madmin.BucketQuota{Quota: 1024, Type: madmin.FIFOQuota}, MaxVersions: 100},
+		},
+		{
+			data:    `{"quota":1024,"quotatype":"soft"}`,
+			wantErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		q, err := parseBucketQuota("bucket", []byte(testCase.data))
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("case %d: unexpected error %v", i+1, err)
+		}
+		if testCase.wantErr {
+			continue
+		}
+		if *q != testCase.want {
+			t.Fatalf("case %d: expected %#v, got %#v", i+1, testCase.want, *q)
+		}
+		data, err := json.Marshal(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mq madmin.BucketQuota
+		if err = json.Unmarshal(data, &mq); err != nil {
+			t.Fatal(err)
+		}
+		if mq != testCase.want.BucketQuota {
+			t.Fatalf("case %d: expected %#v, got %#v", i+1, testCase.want.BucketQuota, mq)
+		}
+	}
+}
+
+func TestBucketVersionQuota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader([]byte("a")), 1, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bucket already holds as many versions as allowed.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.bucketStorageCache.Once.Do(func() {
+		globalBucketQuotaSys.bucketStorageCache.TTL = time.Hour
+		globalBucketQuotaSys.bucketStorageCache.Update = func() (interface{}, error) {
+			return DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{
+				bucket: {VersionsCount: 1, NoncurrentSize: 1},
+			}}, nil
+		}
+	})
+
+	testCases := []struct {
+		versioning string
+		quota      string
+		object     string
+		want       error
+	}{
+		// Unversioned overwrite replaces the existing version.
+		{"", `{"maxversions":1}`, "object", nil},
+		{"", `{"maxversions":1}`, "new", BucketVersionQuotaExceeded{Bucket: bucket}},
+		{"", `{"maxnoncurrentsize":1}`, "new", nil},
+		// Suspended overwrite replaces the null version.
+		{"Suspended", `{"maxversions":1}`, "object", nil},
+		{"Suspended", `{"maxversions":1}`, "new", BucketVersionQuotaExceeded{Bucket: bucket}},
+		// Versioned overwrite adds a version and makes one noncurrent.
+		{"Enabled", `{"maxversions":1}`, "object", BucketVersionQuotaExceeded{Bucket: bucket}},
+		{"Enabled", `{"maxversions":2,"maxnoncurrentsize":1}`, "object", BucketNoncurrentQuotaExceeded{Bucket: bucket}},
+		{"Enabled", `{"maxversions":2,"maxnoncurrentsize":2}`, "object", nil},
+	}
+
+	for i, testCase := range testCases {
+		meta := newBucketMetadata(bucket)
+		if testCase.versioning != "" {
+			cfg := `<VersioningConfiguration><Status>` + testCase.versioning + `</Status></VersioningConfiguration>`
+			if meta.versioningConfig, err = versioning.ParseConfig(strings.NewReader(cfg)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if meta.quotaConfig, err = parseBucketQuota(bucket, []byte(testCase.quota)); err != nil {
+			t.Fatal(err)
+		}
+		globalBucketMetadataSys.Set(bucket, meta)
+
+		err = enforceBucketVersionQuota(ctx, bucket, testCase.object, obj.GetObjectInfo)
+		if err != testCase.want {
+			t.Errorf("case %d: expected %v, got %v", i+1, testCase.want, err)
+		}
+	}
+}
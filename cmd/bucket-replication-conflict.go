@@ -0,0 +1,239 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/etag"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// replicationConflictEventHost is the host of the events sent while
+// resolving replication conflicts.
+const replicationConflictEventHost = "Internal: [Replication]"
+
+// replicaConflict is a replica received while the latest version of
+// the object, written on this cluster, was not yet known to the source
+// of the replica.
+type replicaConflict struct {
+	cfg *replication.ConflictConfig
+	// peer is the latest version written on this cluster.
+	peer ObjectInfo
+	// origin is the deployment ID of the cluster the replica was
+	// written on.
+	origin string
+}
+
+// getReplicaOrigin returns the deployment ID of the cluster the replica
+// sent by r was written on. It is only accepted from replication requests
+// allowed to replicate objects, empty for any other request.
+func getReplicaOrigin(ctx context.Context, r *http.Request, bucket, object string) string {
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; !ok {
+		return ""
+	}
+	origin := r.Header.Get(xhttp.MinioDeploymentID)
+	if origin == "" {
+		return ""
+	}
+	if isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction) != ErrNone {
+		return ""
+	}
+	return origin
+}
+
+// getReplicaConflict returns the conflict caused by a replica with the
+// modification time mtime, nil if the bucket has no conflict policy, r
+// is not an allowed replication request or the replica does not
+// conflict with the latest version.
+func getReplicaConflict(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket, object string, mtime time.Time) *replicaConflict {
+	origin := getReplicaOrigin(ctx, r, bucket, object)
+	if origin == "" {
+		return nil
+	}
+	cfg, err := globalBucketMetadataSys.GetReplicationConflictConfig(bucket)
+	if err != nil || cfg == nil || cfg.IsEmpty() {
+		return nil
+	}
+	peer, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil || peer.DeleteMarker || peer.ReplicationStatus == replication.Replica {
+		return nil
+	}
+	// The peer was known to the source of the replica if it was
+	// replicated before the replica was written.
+	if peer.ReplicationStatus == replication.Completed && !getReplicationTimestamp(peer, ReplicationTimestamp).After(mtime) {
+		return nil
+	}
+	return &replicaConflict{
+		cfg:    cfg,
+		peer:   peer,
+		origin: origin,
+	}
+}
+
+// resolve applies the conflict policy once the replica is written. All
+// clusters pick the same winner, the cluster the winner was written on
+// makes it the latest version again if the replica superseded it.
+func (c *replicaConflict) resolve(ctx context.Context, objAPI ObjectLayer, replica ObjectInfo) {
+	if c.peer.VersionID == replica.VersionID {
+		return
+	}
+	peerSize, _ := c.peer.GetActualSize()
+	replicaSize, _ := replica.GetActualSize()
+	local := replication.ConflictVersion{
+		VersionID: c.peer.VersionID,
+		ModTime:   c.peer.ModTime,
+		Size:      peerSize,
+		Site:      globalDeploymentID,
+	}
+	remote := replication.ConflictVersion{
+		VersionID: replica.VersionID,
+		ModTime:   replica.ModTime,
+		Size:      replicaSize,
+		Site:      c.origin,
+	}
+	winner, loser := remote, local
+	if c.cfg.Wins(local, remote) {
+		winner, loser = local, remote
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectReplicationConflict,
+		BucketName: replica.Bucket,
+		Object:     replica,
+		ReqParams: map[string]string{
+			"conflictPolicy":  string(c.cfg.Policy),
+			"winnerVersionId": winner.VersionID,
+			"winnerSite":      winner.Site,
+			"loserVersionId":  loser.VersionID,
+			"loserSite":       loser.Site,
+		},
+		Host: replicationConflictEventHost,
+	})
+	if winner.VersionID != local.VersionID || !replica.ModTime.After(c.peer.ModTime) {
+		return
+	}
+	if err := c.restorePeer(ctx, objAPI, replica); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// restorePeer writes the peer as a new version, unless a version newer
+// than the replica exists already.
+func (c *replicaConflict) restorePeer(ctx context.Context, objAPI ObjectLayer, replica ObjectInfo) error {
+	bucket, object := c.peer.Bucket, c.peer.Name
+	latest, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil || latest.VersionID != replica.VersionID {
+		return nil
+	}
+
+	// The peer version is read without a lock, the object is locked to
+	// write the new version while it is being read.
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, noLock, ObjectOptions{VersionID: c.peer.VersionID})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	defer gr.Close()
+	srcInfo := gr.ObjInfo
+	if crypto.SSEC.IsEncrypted(srcInfo.UserDefined) {
+		// The customer key is not known to the server.
+		return nil
+	}
+
+	metadata := make(map[string]string, len(srcInfo.UserDefined))
+	for k, v := range srcInfo.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			continue
+		}
+		metadata[k] = v
+	}
+	metadata = cleanMetadata(metadata)
+	if srcInfo.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = srcInfo.UserTags
+	}
+
+	size, err := srcInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hashReader, err := hash.NewReader(gr, size, "", "", size)
+	if err != nil {
+		return err
+	}
+	pReader := NewPutObjReader(hashReader)
+	if kind, ok := crypto.IsEncrypted(srcInfo.UserDefined); ok {
+		// Encrypt the new version as the peer, with the same KMS key.
+		var keyID string
+		if kind == crypto.S3KMS {
+			keyID = srcInfo.UserDefined[crypto.MetaKeyID]
+		}
+		reader, objectEncryptionKey, err := newEncryptReader(hashReader, kind, keyID, nil, bucket, object, metadata, nil)
+		if err != nil {
+			return err
+		}
+		info := ObjectInfo{Size: size}
+		encReader, err := hash.NewReader(etag.Wrap(reader, hashReader), info.EncryptedSize(), "", "", size)
+		if err != nil {
+			return err
+		}
+		if pReader, err = pReader.WithEncryption(encReader, &objectEncryptionKey); err != nil {
+			return err
+		}
+	}
+
+	opts := ObjectOptions{
+		UserDefined:      metadata,
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	}
+	// The new version must supersede the replica, even if the clock of
+	// the site the replica was written on is ahead.
+	if now := UTCNow(); !now.After(replica.ModTime) {
+		opts.MTime = replica.ModTime.Add(time.Millisecond)
+	}
+	dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(ObjectInfo{UserDefined: metadata}, replication.ObjectReplicationType, opts))
+	if dsc.ReplicateAny() {
+		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+	}
+	objInfo, err := objAPI.PutObject(ctx, bucket, object, pReader, opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.ObjectReplicationType)
+	}
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       replicationConflictEventHost,
+	})
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/bucket/versioning"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func newReplicaTestRequest(t *testing.T, accessKey, secretKey string, headers map[string]string) *http.Request {
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", "bucket", "object"), 0, nil, accessKey, secretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Headers are set after signing, only the access key of the
+	// request is looked up.
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req
+}
+
+func TestGetReplicaOrigin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	initConfigSubsystem(ctx, obj)
+	globalIAMSys.Init(ctx, obj, globalEtcdClient, globalNotificationSys, 2*time.Second)
+
+	cred := globalActiveCred
+	replicationHeaders := map[string]string{
+		xhttp.MinIOSourceReplicationRequest: "true",
+		xhttp.MinioDeploymentID:             "site-b",
+	}
+	testCases := []struct {
+		accessKey, secretKey string
+		headers              map[string]string
+		origin               string
+	}{
+		// Replication request allowed to replicate objects.
+		{cred.AccessKey, cred.SecretKey, replicationHeaders, "site-b"},
+		// Anonymous requests are not allowed to replicate objects.
+		{"", "", replicationHeaders, ""},
+		// Not a replication request.
+		{cred.AccessKey, cred.SecretKey, map[string]string{xhttp.MinioDeploymentID: "site-b"}, ""},
+		// No deployment ID sent.
+		{cred.AccessKey, cred.SecretKey, map[string]string{xhttp.MinIOSourceReplicationRequest: "true"}, ""},
+	}
+	for i, testCase := range testCases {
+		r := newReplicaTestRequest(t, testCase.accessKey, testCase.secretKey, testCase.headers)
+		if origin := getReplicaOrigin(ctx, r, "bucket", "object"); origin != testCase.origin {
+			t.Errorf("case %d: expected origin %q, got %q", i+1, testCase.origin, origin)
+		}
+	}
+}
+
+func TestReplicaConflictResolve(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	initConfigSubsystem(ctx, obj)
+	globalIAMSys.Init(ctx, obj, globalEtcdClient, globalNotificationSys, 2*time.Second)
+
+	deploymentID := globalDeploymentID
+	globalDeploymentID = "site-a"
+	defer func() { globalDeploymentID = deploymentID }()
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	setConflictConfig := func(cfg string) {
+		meta := newBucketMetadata(bucket)
+		if meta.versioningConfig, err = versioning.ParseConfig(strings.NewReader(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`)); err != nil {
+			t.Fatal(err)
+		}
+		if cfg != "" {
+			if meta.replicationConflictConfig, err = replication.ParseConflictConfig(strings.NewReader(cfg)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		globalBucketMetadataSys.Set(bucket, meta)
+	}
+	readLatest := func(object string) string {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	cred := globalActiveCred
+	replicationHeaders := map[string]string{
+		xhttp.MinIOSourceReplicationRequest: "true",
+		xhttp.MinioDeploymentID:             "site-b",
+	}
+	now := UTCNow()
+	testCases := []struct {
+		config string
+		// peerMeta is the metadata of the latest version written locally.
+		peerMeta map[string]string
+		// replicaDelay is the modification time of the replica relative
+		// to the local version.
+		replicaDelay time.Duration
+		anonymous    bool
+		conflict     bool
+		latest       string
+	}{
+		// No conflict policy.
+		{config: "", replicaDelay: time.Second, latest: "remote"},
+		// Replicas are only accepted from allowed replication requests.
+		{config: `{"policy":"last-writer-wins"}`, replicaDelay: time.Second, anonymous: true, latest: "remote"},
+		// The local version was replicated before the replica was written.
+		{
+			config: `{"policy":"source-priority","sites":["site-a","site-b"]}`,
+			peerMeta: map[string]string{
+				ReservedMetadataPrefixLower + ReplicationStatus:    replication.Completed.String(),
+				ReservedMetadataPrefixLower + ReplicationTimestamp: now.Format(time.RFC3339Nano),
+			},
+			replicaDelay: time.Second,
+			latest:       "remote",
+		},
+		// The local version is a replica itself.
+		{
+			config:       `{"policy":"source-priority","sites":["site-a","site-b"]}`,
+			peerMeta:     map[string]string{ReservedMetadataPrefixLower + ReplicaStatus: replication.Replica.String()},
+			replicaDelay: time.Second,
+			latest:       "remote",
+		},
+		// The newer replica wins.
+		{config: `{"policy":"last-writer-wins"}`, replicaDelay: time.Second, conflict: true, latest: "remote"},
+		// The local version wins and is written again as the latest version.
+		{config: `{"policy":"source-priority","sites":["site-a","site-b"]}`, replicaDelay: time.Second, conflict: true, latest: "local"},
+		{config: `{"policy":"largest-object-wins"}`, replicaDelay: time.Second, conflict: true, latest: "local-larger"},
+		// The local version wins and is still the latest version.
+		{config: `{"policy":"last-writer-wins"}`, replicaDelay: -time.Second, conflict: true, latest: "local"},
+		// The remote version wins and is not the latest version, which
+		// the site it was written on restores.
+		{config: `{"policy":"source-priority","sites":["site-b","site-a"]}`, replicaDelay: -time.Second, conflict: true, latest: "local"},
+	}
+	for i, testCase := range testCases {
+		setConflictConfig(testCase.config)
+		object := "object" + string(rune('a'+i))
+
+		local := "local"
+		if testCase.latest == "local-larger" {
+			local = "local-larger"
+		}
+		peerMeta := map[string]string{}
+		for k, v := range testCase.peerMeta {
+			peerMeta[k] = v
+		}
+		peer, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(local)), int64(len(local)), "", ""),
+			ObjectOptions{Versioned: true, MTime: now, UserDefined: peerMeta})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		accessKey, secretKey := cred.AccessKey, cred.SecretKey
+		if testCase.anonymous {
+			accessKey, secretKey = "", ""
+		}
+		r := newReplicaTestRequest(t, accessKey, secretKey, replicationHeaders)
+		mtime := now.Add(testCase.replicaDelay)
+		conflict := getReplicaConflict(ctx, obj, r, bucket, object, mtime)
+		if (conflict != nil) != testCase.conflict {
+			t.Fatalf("case %d: expected conflict %t, got %v", i+1, testCase.conflict, conflict)
+		}
+
+		replica, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("remote")), 6, "", ""),
+			ObjectOptions{Versioned: true, MTime: mtime, UserDefined: map[string]string{
+				ReservedMetadataPrefixLower + ReplicaStatus: replication.Replica.String(),
+			}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conflict != nil {
+			if conflict.origin != "site-b" || conflict.peer.VersionID != peer.VersionID {
+				t.Fatalf("case %d: unexpected conflict with %s from %q", i+1, conflict.peer.VersionID, conflict.origin)
+			}
+			conflict.resolve(ctx, obj, replica)
+		}
+
+		if latest := readLatest(object); latest != testCase.latest {
+			t.Errorf("case %d: expected latest version %q, got %q", i+1, testCase.latest, latest)
+		}
+	}
+}
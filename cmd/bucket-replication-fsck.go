@@ -0,0 +1,535 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	replicationFsckMetaName = "replication-fsck.json"
+
+	// Time between two checks for a replication fsck to run.
+	replicationFsckCheckInterval = time.Minute
+	// Time between two saves of the replication fsck progress.
+	replicationFsckSaveInterval = 30 * time.Second
+
+	replicationFsckSchema = "Bucket, Key, VersionId, TargetArn, Drift, ETag, TargetETag"
+)
+
+// replicationDrift is the kind of difference found between an object
+// version marked as replicated and its copy on a remote target.
+type replicationDrift string
+
+const (
+	// The object does not exist on the target.
+	replicationDriftMissing replicationDrift = "missing"
+	// The object exists on the target but not the version.
+	replicationDriftVersion replicationDrift = "version"
+	// The version exists on the target with another ETag.
+	replicationDriftETag replicationDrift = "etag"
+	// The version exists on the target with other tags.
+	replicationDriftTags replicationDrift = "tags"
+)
+
+var (
+	replicationFsckLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// error returned when a replication fsck is started while another one is running.
+	errReplicationFsckAlreadyRunning = AdminError{
+		Code:       "XMinioAdminReplicationFsckAlreadyRunning",
+		Message:    "A replication consistency check is already in progress for this bucket",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when reading the status of a bucket never checked.
+	errReplicationFsckNotStarted = AdminError{
+		Code:       "XMinioAdminReplicationFsckNotStarted",
+		Message:    "No replication consistency check was started for this bucket",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when the sample rate is not within (0, 1].
+	errReplicationFsckInvalidSample = AdminError{
+		Code:       "XMinioAdminReplicationFsckInvalidSample",
+		Message:    "The sample rate must be greater than 0 and at most 1",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// replicationFsckMeta is the persisted state of the last replication
+// consistency check of a bucket.
+type replicationFsckMeta struct {
+	ID           string    `json:"id"`
+	Bucket       string    `json:"bucket"`
+	TargetBucket string    `json:"targetBucket"`
+	TargetPrefix string    `json:"targetPrefix,omitempty"`
+	StartedAt    time.Time `json:"startedAt"`
+	CompletedAt  time.Time `json:"completedAt,omitempty"`
+	// Fraction of the replicated versions which are checked,
+	// all of them when 1.
+	SampleRate float64 `json:"sampleRate"`
+	// Time after the completion of a check when the next one
+	// starts, the check is not repeated when 0.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Number of version and target pairs checked.
+	Checked         uint64 `json:"checked"`
+	Missing         uint64 `json:"missing"`
+	VersionMismatch uint64 `json:"versionMismatch"`
+	ETagMismatch    uint64 `json:"etagMismatch"`
+	TagMismatch     uint64 `json:"tagMismatch"`
+
+	// Key of the report in the target bucket.
+	Report string `json:"report,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (m replicationFsckMeta) complete() bool {
+	return !m.CompletedAt.IsZero()
+}
+
+// due returns true when a check must run now.
+func (m replicationFsckMeta) due(now time.Time) bool {
+	if !m.complete() {
+		return true
+	}
+	return m.Interval > 0 && now.Sub(m.CompletedAt) >= m.Interval
+}
+
+// reportKey returns the key of the report of the check.
+func (m replicationFsckMeta) reportKey() string {
+	return pathJoin(m.TargetPrefix, m.Bucket, "replication-fsck-"+m.ID, "report.csv")
+}
+
+func replicationFsckMetaPath(bucket string) string {
+	return pathJoin(bucketMetaPrefix, bucket, replicationFsckMetaName)
+}
+
+func loadReplicationFsckMeta(ctx context.Context, objAPI ObjectLayer, bucket string) (*replicationFsckMeta, error) {
+	data, err := readConfig(ctx, objAPI, replicationFsckMetaPath(bucket))
+	if err != nil {
+		return nil, err
+	}
+	m := &replicationFsckMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func saveReplicationFsckMeta(ctx context.Context, objAPI ObjectLayer, m *replicationFsckMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, replicationFsckMetaPath(m.Bucket), data)
+}
+
+// startReplicationFsck initializes a new replication consistency check
+// of bucket checking a sample of its replicated versions, the report is
+// written under prefix in the target bucket. The check is repeated
+// every interval after its completion when interval is set.
+func startReplicationFsck(ctx context.Context, objAPI ObjectLayer, bucket, target, prefix string, sample float64, interval time.Duration) (*replicationFsckMeta, error) {
+	if sample <= 0 || sample > 1 {
+		return nil, errReplicationFsckInvalidSample
+	}
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, err
+	}
+	if _, err := objAPI.GetBucketInfo(ctx, target); err != nil {
+		return nil, err
+	}
+	if _, err := getReplicationConfig(ctx, bucket); err != nil {
+		return nil, err
+	}
+
+	m, err := loadReplicationFsckMeta(ctx, objAPI, bucket)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	if m != nil && !m.complete() {
+		return nil, errReplicationFsckAlreadyRunning
+	}
+
+	m = &replicationFsckMeta{
+		ID:           mustGetUUID(),
+		Bucket:       bucket,
+		TargetBucket: target,
+		TargetPrefix: prefix,
+		StartedAt:    UTCNow(),
+		SampleRate:   sample,
+		Interval:     interval,
+	}
+	if err = saveReplicationFsckMeta(ctx, objAPI, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// initReplicationFsck will start the replication fsck worker in the background.
+func initReplicationFsck(ctx context.Context, objAPI ObjectLayer) {
+	go runReplicationFsck(ctx, objAPI)
+}
+
+// runReplicationFsck waits for checks to be started or to be due again
+// and checks one bucket at a time. There should only ever be one
+// replication fsck worker running per cluster.
+func runReplicationFsck(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 replication fsck worker is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runReplicationFsck.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, replicationFsckLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(replicationFsckCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	checkTimer := time.NewTimer(replicationFsckCheckInterval)
+	defer checkTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkTimer.C:
+			buckets, err := objAPI.ListBuckets(ctx)
+			if err != nil {
+				logger.LogIf(ctx, err)
+			}
+			for _, bucket := range buckets {
+				m, err := loadReplicationFsckMeta(ctx, objAPI, bucket.Name)
+				if err != nil {
+					if !errors.Is(err, errConfigNotFound) {
+						logger.LogIf(ctx, err)
+					}
+					continue
+				}
+				if !m.due(UTCNow()) {
+					continue
+				}
+				if m.complete() {
+					// Scheduled again, start a new check with the same settings.
+					m = &replicationFsckMeta{
+						ID:           mustGetUUID(),
+						Bucket:       m.Bucket,
+						TargetBucket: m.TargetBucket,
+						TargetPrefix: m.TargetPrefix,
+						StartedAt:    UTCNow(),
+						SampleRate:   m.SampleRate,
+						Interval:     m.Interval,
+					}
+				}
+				fs := &replicationFsckState{meta: m}
+				if err = fsckBucketReplication(ctx, objAPI, fs); err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					logger.LogIf(ctx, err)
+				}
+			}
+			checkTimer.Reset(replicationFsckCheckInterval)
+		}
+	}
+}
+
+// replicationFsckState is the in-memory state of a running check.
+type replicationFsckState struct {
+	mu       sync.Mutex
+	meta     *replicationFsckMeta
+	lastSave time.Time
+}
+
+// sync persists the in-memory progress.
+func (fs *replicationFsckState) sync(ctx context.Context, objAPI ObjectLayer, force bool) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if !force && time.Since(fs.lastSave) < replicationFsckSaveInterval {
+		return nil
+	}
+	fs.lastSave = time.Now()
+	return saveReplicationFsckMeta(ctx, objAPI, fs.meta)
+}
+
+func (fs *replicationFsckState) update(fn func(m *replicationFsckMeta)) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fn(fs.meta)
+}
+
+// fsckBucketReplication walks the object versions of the bucket and
+// compares those replicated with their copies on the remote targets,
+// differences are written to a report in the target bucket and counted
+// in the replication stats. A check interrupted before completion
+// starts over.
+func fsckBucketReplication(ctx context.Context, objAPI ObjectLayer, fs *replicationFsckState) error {
+	fs.update(func(m *replicationFsckMeta) {
+		*m = replicationFsckMeta{
+			ID:           m.ID,
+			Bucket:       m.Bucket,
+			TargetBucket: m.TargetBucket,
+			TargetPrefix: m.TargetPrefix,
+			StartedAt:    m.StartedAt,
+			SampleRate:   m.SampleRate,
+			Interval:     m.Interval,
+		}
+	})
+	if err := fs.sync(ctx, objAPI, true); err != nil {
+		return err
+	}
+
+	err := writeReplicationFsckReport(ctx, objAPI, fs)
+	fs.update(func(m *replicationFsckMeta) {
+		if err != nil {
+			m.Error = err.Error()
+		} else {
+			m.Report = m.reportKey()
+		}
+		m.CompletedAt = UTCNow()
+	})
+	if ctx.Err() != nil {
+		// Interrupted, the next leader checks the bucket again.
+		return ctx.Err()
+	}
+	if serr := fs.sync(ctx, objAPI, true); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// writeReplicationFsckReport streams the differences found to the
+// report file.
+func writeReplicationFsckReport(ctx context.Context, objAPI ObjectLayer, fs *replicationFsckState) (err error) {
+	fs.mu.Lock()
+	m := *fs.meta
+	fs.mu.Unlock()
+
+	if _, err = getReplicationConfig(ctx, m.Bucket); err != nil {
+		return err
+	}
+	// Only the targets still configured on the bucket are checked.
+	targets := make(map[string]*TargetClient)
+	for _, t := range globalBucketTargetSys.ListTargets(ctx, m.Bucket, string(madmin.ReplicationService)) {
+		if tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, t.Arn); tgt != nil {
+			targets[t.Arn] = tgt
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := putObjectLockAuditObject(ctx, objAPI, m.TargetBucket, m.reportKey(), "text/csv", pr, -1)
+		pr.CloseWithError(err)
+		done <- err
+	}()
+
+	w := csv.NewWriter(pw)
+	if err = w.Write(strings.Split(replicationFsckSchema, ", ")); err != nil {
+		pw.CloseWithError(err)
+		<-done
+		return err
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	results := make(chan ObjectInfo, 100)
+	if err = objAPI.Walk(ctx, m.Bucket, "", results, ObjectOptions{WalkVersions: true}); err != nil {
+		pw.CloseWithError(err)
+		<-done
+		return err
+	}
+	for oi := range results {
+		// Keep draining the walker once stopped, it does
+		// not give up sending on context cancelation.
+		if err != nil {
+			continue
+		}
+		// Delete markers have no data, tags or ETag to compare.
+		if oi.DeleteMarker {
+			continue
+		}
+		arns := replicationFsckTargets(oi, targets)
+		if len(arns) == 0 || (m.SampleRate < 1 && r.Float64() >= m.SampleRate) {
+			continue
+		}
+		for _, arn := range arns {
+			var drift replicationDrift
+			var remoteETag string
+			drift, remoteETag, err = checkReplicationFsckTarget(ctx, targets[arn], oi)
+			if err != nil {
+				cancel()
+				break
+			}
+			if drift != "" {
+				if err = w.Write(replicationFsckRow(oi, arn, drift, remoteETag)); err != nil {
+					cancel()
+					break
+				}
+				globalReplicationStats.UpdateDriftStat(oi.Bucket, arn, drift)
+			}
+			fs.update(func(m *replicationFsckMeta) {
+				m.Checked++
+				switch drift {
+				case replicationDriftMissing:
+					m.Missing++
+				case replicationDriftVersion:
+					m.VersionMismatch++
+				case replicationDriftETag:
+					m.ETagMismatch++
+				case replicationDriftTags:
+					m.TagMismatch++
+				}
+			})
+		}
+		if err != nil {
+			continue
+		}
+		if err = fs.sync(ctx, objAPI, false); err != nil {
+			cancel()
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	pw.CloseWithError(err)
+	if perr := <-done; err == nil {
+		err = perr
+	}
+	return err
+}
+
+// replicationFsckTargets returns the sorted arns of the targets among
+// targets which oi was replicated to.
+func replicationFsckTargets(oi ObjectInfo, targets map[string]*TargetClient) []string {
+	var arns []string
+	for arn, status := range replicationStatusesMap(oi.ReplicationStatusInternal) {
+		if _, ok := targets[arn]; ok && status == replication.Completed {
+			arns = append(arns, arn)
+		}
+	}
+	sort.Strings(arns)
+	return arns
+}
+
+// checkReplicationFsckTarget looks up the copy of oi on the remote
+// target tgt and returns how it differs from oi, if it does.
+func checkReplicationFsckTarget(ctx context.Context, tgt *TargetClient, oi ObjectInfo) (drift replicationDrift, remoteETag string, err error) {
+	gopts := miniogo.StatObjectOptions{
+		VersionID: oi.VersionID,
+		Internal: miniogo.AdvancedGetOptions{
+			ReplicationProxyRequest: "false",
+		},
+	}
+	roi, err := tgt.StatObject(ctx, tgt.Bucket, oi.Name, gopts)
+	if err != nil {
+		if !isReplicationFsckNotFound(err) {
+			return "", "", err
+		}
+		// Find out whether any other version of the object exists.
+		gopts.VersionID = ""
+		if _, err = tgt.StatObject(ctx, tgt.Bucket, oi.Name, gopts); err == nil {
+			return replicationDriftVersion, "", nil
+		}
+		if isReplicationFsckNotFound(err) {
+			return replicationDriftMissing, "", nil
+		}
+		return "", "", err
+	}
+
+	var remoteTags map[string]string
+	if oi.UserTags != "" || roi.UserTagCount > 0 {
+		t, err := tgt.GetObjectTagging(ctx, tgt.Bucket, oi.Name, miniogo.GetObjectTaggingOptions{
+			VersionID: oi.VersionID,
+		})
+		if err != nil {
+			return "", "", err
+		}
+		remoteTags = t.ToMap()
+	}
+	return replicationFsckCompare(oi, roi, remoteTags), roi.ETag, nil
+}
+
+// replicationFsckCompare returns how the remote version roi with the
+// tags remoteTags differs from oi, if it does.
+func replicationFsckCompare(oi ObjectInfo, roi miniogo.ObjectInfo, remoteTags map[string]string) replicationDrift {
+	if oi.VersionID != "" && oi.VersionID != nullVersionID && roi.VersionID != oi.VersionID {
+		return replicationDriftVersion
+	}
+	if roi.ETag != oi.ETag {
+		return replicationDriftETag
+	}
+	t, _ := tags.ParseObjectTags(oi.UserTags)
+	localTags := t.ToMap()
+	if (len(localTags) > 0 || len(remoteTags) > 0) && !reflect.DeepEqual(localTags, remoteTags) {
+		return replicationDriftTags
+	}
+	return ""
+}
+
+func isReplicationFsckNotFound(err error) bool {
+	errResp := miniogo.ToErrorResponse(err)
+	return errResp.StatusCode == http.StatusNotFound ||
+		errResp.Code == "NoSuchKey" || errResp.Code == "NoSuchVersion"
+}
+
+// replicationFsckRow returns the report row of the version oi found
+// out of sync with the target arn.
+func replicationFsckRow(oi ObjectInfo, arn string, drift replicationDrift, remoteETag string) []string {
+	versionID := oi.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return []string{
+		oi.Bucket,
+		url.QueryEscape(oi.Name),
+		versionID,
+		arn,
+		string(drift),
+		oi.ETag,
+		remoteETag,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+)
+
+func TestReplicationFsckCompare(t *testing.T) {
+	oi := ObjectInfo{Name: "obj", VersionID: "v1", ETag: "etag1", UserTags: "k=v"}
+
+	testCases := []struct {
+		roi        miniogo.ObjectInfo
+		remoteTags map[string]string
+		drift      replicationDrift
+	}{
+		// Version in sync.
+		{roi: miniogo.ObjectInfo{VersionID: "v1", ETag: "etag1"}, remoteTags: map[string]string{"k": "v"}},
+		// Other version returned by the target.
+		{roi: miniogo.ObjectInfo{VersionID: "v2", ETag: "etag1"}, remoteTags: map[string]string{"k": "v"}, drift: replicationDriftVersion},
+		// Other ETag.
+		{roi: miniogo.ObjectInfo{VersionID: "v1", ETag: "etag2"}, remoteTags: map[string]string{"k": "v"}, drift: replicationDriftETag},
+		// Other tags.
+		{roi: miniogo.ObjectInfo{VersionID: "v1", ETag: "etag1"}, remoteTags: map[string]string{"k": "w"}, drift: replicationDriftTags},
+		// Tags missing on the target.
+		{roi: miniogo.ObjectInfo{VersionID: "v1", ETag: "etag1"}, drift: replicationDriftTags},
+	}
+	for i, testCase := range testCases {
+		if drift := replicationFsckCompare(oi, testCase.roi, testCase.remoteTags); drift != testCase.drift {
+			t.Errorf("Test %d: expected drift %q, got %q", i+1, testCase.drift, drift)
+		}
+	}
+
+	// No tags on either side.
+	if drift := replicationFsckCompare(ObjectInfo{VersionID: "v1", ETag: "etag1"}, miniogo.ObjectInfo{VersionID: "v1", ETag: "etag1"}, nil); drift != "" {
+		t.Errorf("expected no drift, got %q", drift)
+	}
+}
+
+func TestReplicationFsckTargets(t *testing.T) {
+	const arn1, arn2, arn3 = "arn:minio:replication::1:a", "arn:minio:replication::2:b", "arn:minio:replication::3:c"
+	targets := map[string]*TargetClient{arn1: {}, arn2: {}}
+	oi := ObjectInfo{ReplicationStatusInternal: arn2 + "=COMPLETED;" + arn1 + "=COMPLETED;" + arn3 + "=COMPLETED;"}
+	if arns := replicationFsckTargets(oi, targets); !reflect.DeepEqual(arns, []string{arn1, arn2}) {
+		t.Fatalf("unexpected targets %v", arns)
+	}
+	oi.ReplicationStatusInternal = arn1 + "=PENDING;" + arn2 + "=FAILED;"
+	if arns := replicationFsckTargets(oi, targets); len(arns) != 0 {
+		t.Fatalf("expected no targets, got %v", arns)
+	}
+}
+
+func TestReplicationFsckDue(t *testing.T) {
+	now := UTCNow()
+	if !(replicationFsckMeta{}).due(now) {
+		t.Fatal("expected a started check to be due")
+	}
+	m := replicationFsckMeta{CompletedAt: now.Add(-time.Hour)}
+	if m.due(now) {
+		t.Fatal("expected a completed check without interval not to be due")
+	}
+	m.Interval = 2 * time.Hour
+	if m.due(now) {
+		t.Fatal("expected a check completed within the interval not to be due")
+	}
+	m.Interval = time.Hour
+	if !m.due(now) {
+		t.Fatal("expected a check completed before the interval to be due")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/replication"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// applyReplicaLockMetadata sets the object lock metadata sent by the
+// replication source on the metadata of a replica. ondisk is the
+// metadata of the replica before this request, nil if the version is
+// new. Retention and legal hold on disk are only replaced when the
+// source changed them more recently than the replica.
+func applyReplicaLockMetadata(meta, ondisk map[string]string, mode objectlock.RetMode, date objectlock.RetentionDate, legalHold objectlock.ObjectLegalHold, opts ObjectOptions) map[string]string {
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	modeKey := strings.ToLower(xhttp.AmzObjectLockMode)
+	dateKey := strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)
+	holdKey := strings.ToLower(xhttp.AmzObjectLockLegalHold)
+
+	retTimestampKey := ReservedMetadataPrefixLower + ObjectLockRetentionTimestamp
+	delete(meta, xhttp.AmzObjectLockMode)
+	delete(meta, xhttp.AmzObjectLockRetainUntilDate)
+	delete(meta, modeKey)
+	delete(meta, dateKey)
+	if isNewerReplicaTimestamp(ondisk, retTimestampKey, opts.ReplicationSourceRetentionTimestamp) {
+		if mode.Valid() {
+			meta[modeKey] = string(mode)
+			meta[dateKey] = date.UTC().Format(iso8601TimeFormat)
+		}
+		if !opts.ReplicationSourceRetentionTimestamp.IsZero() {
+			meta[retTimestampKey] = opts.ReplicationSourceRetentionTimestamp.Format(time.RFC3339Nano)
+		}
+	} else {
+		if ret := objectlock.GetObjectRetentionMeta(ondisk); ret.Mode.Valid() {
+			meta[modeKey] = string(ret.Mode)
+			meta[dateKey] = ret.RetainUntilDate.UTC().Format(iso8601TimeFormat)
+		}
+		meta[retTimestampKey] = ondisk[retTimestampKey]
+	}
+
+	holdTimestampKey := ReservedMetadataPrefixLower + ObjectLockLegalHoldTimestamp
+	delete(meta, xhttp.AmzObjectLockLegalHold)
+	delete(meta, holdKey)
+	if isNewerReplicaTimestamp(ondisk, holdTimestampKey, opts.ReplicationSourceLegalholdTimestamp) {
+		if legalHold.Status.Valid() {
+			meta[holdKey] = string(legalHold.Status)
+		}
+		if !opts.ReplicationSourceLegalholdTimestamp.IsZero() {
+			meta[holdTimestampKey] = opts.ReplicationSourceLegalholdTimestamp.Format(time.RFC3339Nano)
+		}
+	} else {
+		if hold := objectlock.GetObjectLegalHoldMeta(ondisk); hold.Status.Valid() {
+			meta[holdKey] = string(hold.Status)
+		}
+		meta[holdTimestampKey] = ondisk[holdTimestampKey]
+	}
+	return meta
+}
+
+// isNewerReplicaTimestamp returns true if the source timestamp is newer
+// than the timestamp stored under key in the replica metadata ondisk.
+func isNewerReplicaTimestamp(ondisk map[string]string, key string, srcTimestamp time.Time) bool {
+	v, ok := ondisk[key]
+	if !ok {
+		return true
+	}
+	ondiskTimestamp, err := time.Parse(time.RFC3339Nano, v)
+	return err != nil || ondiskTimestamp.Before(srcTimestamp)
+}
+
+// lockMetadata is the normalized object lock metadata of an object
+// version, used to compare a source version with its replica.
+type lockMetadata struct {
+	Mode            string `json:"mode,omitempty"`
+	RetainUntilDate string `json:"retainUntilDate,omitempty"`
+	LegalHold       string `json:"legalHold,omitempty"`
+}
+
+// getLockMetadata returns the lock metadata in meta, the retention
+// date is truncated to seconds and a legal hold which is off is
+// treated as absent.
+func getLockMetadata(meta map[string]string) (l lockMetadata) {
+	if ret := objectlock.GetObjectRetentionMeta(meta); ret.Mode.Valid() {
+		l.Mode = string(ret.Mode)
+		if !ret.RetainUntilDate.IsZero() {
+			l.RetainUntilDate = ret.RetainUntilDate.UTC().Format(time.RFC3339)
+		}
+	}
+	if hold := objectlock.GetObjectLegalHoldMeta(meta); hold.Status == objectlock.LegalHoldOn {
+		l.LegalHold = string(hold.Status)
+	}
+	return l
+}
+
+// getTargetLockMetadata returns the lock metadata of a version on a
+// remote target.
+func getTargetLockMetadata(oi miniogo.ObjectInfo) lockMetadata {
+	meta := make(map[string]string, len(oi.Metadata))
+	for k, v := range oi.Metadata {
+		meta[k] = strings.Join(v, ",")
+	}
+	return getLockMetadata(meta)
+}
+
+// hasLockMetadata returns true if the version has, or ever had, object
+// lock metadata.
+func hasLockMetadata(oi ObjectInfo) bool {
+	if getLockMetadata(oi.UserDefined) != (lockMetadata{}) {
+		return true
+	}
+	for _, k := range []string{ObjectLockRetentionTimestamp, ObjectLockLegalHoldTimestamp} {
+		if _, ok := oi.UserDefined[ReservedMetadataPrefixLower+k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplicationLockDrift is an object version whose object lock metadata
+// on a replication target differs from the source.
+type ReplicationLockDrift struct {
+	Object    string       `json:"object"`
+	VersionID string       `json:"versionId,omitempty"`
+	Arn       string       `json:"arn"`
+	Source    lockMetadata `json:"source"`
+	Target    lockMetadata `json:"target"`
+	// Requeued is set if the version was queued to replicate its
+	// metadata again.
+	Requeued bool `json:"requeued,omitempty"`
+	// Error is set if the lock metadata on the target could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+var errReplicationTargetOffline = errors.New("remote target is offline")
+
+// checkReplicationLockDrift compares the object lock metadata of all
+// replicated versions under prefix with their replicas and calls fn for
+// each version that differs. With repair set such versions are queued
+// to replicate their metadata again, replicas keep lock metadata that
+// was changed on them more recently than on the source.
+func checkReplicationLockDrift(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, repair bool, fn func(ReplicationLockDrift)) error {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	objInfoCh := make(chan ObjectInfo)
+	if err = objAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+		return err
+	}
+	for oi := range objInfoCh {
+		if oi.DeleteMarker || !hasLockMetadata(oi) {
+			continue
+		}
+		statuses := replicationStatusesMap(oi.ReplicationStatusInternal)
+		if len(statuses) == 0 && cfg.RoleArn != "" {
+			// Objects replicated with a legacy single target
+			// configuration only carry the overall status.
+			statuses = map[string]replication.StatusType{cfg.RoleArn: oi.ReplicationStatus}
+		}
+		src := getLockMetadata(oi.UserDefined)
+		for arn, status := range statuses {
+			if status != replication.Completed {
+				continue
+			}
+			d := ReplicationLockDrift{
+				Object:    oi.Name,
+				VersionID: oi.VersionID,
+				Arn:       arn,
+				Source:    src,
+			}
+			tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+			if tgt == nil {
+				d.Error = BucketRemoteTargetNotFound{Bucket: bucket}.Error()
+				fn(d)
+				continue
+			}
+			if tgt.IsOffline() {
+				d.Error = errReplicationTargetOffline.Error()
+				fn(d)
+				continue
+			}
+			toi, err := tgt.StatObject(ctx, tgt.Bucket, oi.Name, miniogo.StatObjectOptions{
+				VersionID: oi.VersionID,
+				Internal: miniogo.AdvancedGetOptions{
+					ReplicationProxyRequest: "false",
+				}})
+			if err != nil {
+				d.Error = err.Error()
+				fn(d)
+				continue
+			}
+			d.Target = getTargetLockMetadata(toi)
+			if d.Target == src {
+				continue
+			}
+			if repair {
+				var dsc ReplicateDecision
+				dsc.Set(newReplicateTargetDecision(arn, true, false))
+				scheduleReplication(ctx, oi.Clone(), objAPI, dsc, replication.MetadataReplicationType)
+				d.Requeued = true
+			}
+			fn(d)
+		}
+	}
+	return nil
+}
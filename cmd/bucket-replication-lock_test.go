@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+)
+
+func TestApplyReplicaLockMetadata(t *testing.T) {
+	srcTime := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	retainUntil := objectlock.RetentionDate{Time: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hold := objectlock.ObjectLegalHold{Status: objectlock.LegalHoldOn}
+	opts := ObjectOptions{
+		ReplicationRequest:                  true,
+		ReplicationSourceRetentionTimestamp: srcTime,
+		ReplicationSourceLegalholdTimestamp: srcTime,
+	}
+	ondisk := func(ts time.Time) map[string]string {
+		return map[string]string{
+			"x-amz-object-lock-mode":                                   "GOVERNANCE",
+			"x-amz-object-lock-retain-until-date":                      "2025-01-01T00:00:00.000Z",
+			"x-amz-object-lock-legal-hold":                             "OFF",
+			ReservedMetadataPrefixLower + ObjectLockRetentionTimestamp: ts.Format(time.RFC3339Nano),
+			ReservedMetadataPrefixLower + ObjectLockLegalHoldTimestamp: ts.Format(time.RFC3339Nano),
+		}
+	}
+
+	testCases := []struct {
+		ondisk    map[string]string
+		mode      objectlock.RetMode
+		hold      objectlock.ObjectLegalHold
+		expected  lockMetadata
+		timestamp time.Time
+	}{
+		// New replica takes the lock metadata of the source.
+		{
+			mode:      objectlock.RetCompliance,
+			hold:      hold,
+			expected:  lockMetadata{Mode: "COMPLIANCE", RetainUntilDate: "2030-01-01T00:00:00Z", LegalHold: "ON"},
+			timestamp: srcTime,
+		},
+		// Source changed its lock metadata after the replica.
+		{
+			ondisk:    ondisk(srcTime.Add(-time.Hour)),
+			mode:      objectlock.RetCompliance,
+			hold:      hold,
+			expected:  lockMetadata{Mode: "COMPLIANCE", RetainUntilDate: "2030-01-01T00:00:00Z", LegalHold: "ON"},
+			timestamp: srcTime,
+		},
+		// Source removed its retention after the replica was changed.
+		{
+			ondisk:    ondisk(srcTime.Add(-time.Hour)),
+			expected:  lockMetadata{},
+			timestamp: srcTime,
+		},
+		// Replica changed its lock metadata after the source.
+		{
+			ondisk:    ondisk(srcTime.Add(time.Hour)),
+			mode:      objectlock.RetCompliance,
+			hold:      hold,
+			expected:  lockMetadata{Mode: "GOVERNANCE", RetainUntilDate: "2025-01-01T00:00:00Z"},
+			timestamp: srcTime.Add(time.Hour),
+		},
+	}
+	for i, tc := range testCases {
+		meta := map[string]string{
+			// Lock metadata copied from the version on disk
+			"x-amz-object-lock-mode": "GOVERNANCE",
+			"X-Amz-Meta-Key":         "value",
+		}
+		meta = applyReplicaLockMetadata(meta, tc.ondisk, tc.mode, retainUntil, tc.hold, opts)
+		if got := getLockMetadata(meta); got != tc.expected {
+			t.Errorf("case %d: expected %+v, got %+v", i+1, tc.expected, got)
+		}
+		if meta["X-Amz-Meta-Key"] != "value" {
+			t.Errorf("case %d: user metadata was not kept", i+1)
+		}
+		for _, k := range []string{ObjectLockRetentionTimestamp, ObjectLockLegalHoldTimestamp} {
+			if ts := meta[ReservedMetadataPrefixLower+k]; ts != tc.timestamp.Format(time.RFC3339Nano) {
+				t.Errorf("case %d: expected %s %s, got %s", i+1, k, tc.timestamp.Format(time.RFC3339Nano), ts)
+			}
+		}
+	}
+}
+
+func TestGetTargetLockMetadata(t *testing.T) {
+	src := getLockMetadata(map[string]string{
+		"x-amz-object-lock-mode":              "COMPLIANCE",
+		"x-amz-object-lock-retain-until-date": "2030-01-01T00:00:00.000Z",
+		"x-amz-object-lock-legal-hold":        "OFF",
+	})
+	testCases := []struct {
+		header http.Header
+		drift  bool
+	}{
+		{
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"COMPLIANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{"2030-01-01T00:00:00Z"},
+			},
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"GOVERNANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{"2030-01-01T00:00:00Z"},
+			},
+			drift: true,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"COMPLIANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{"2029-01-01T00:00:00Z"},
+			},
+			drift: true,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Lock-Mode":              []string{"COMPLIANCE"},
+				"X-Amz-Object-Lock-Retain-Until-Date": []string{"2030-01-01T00:00:00Z"},
+				"X-Amz-Object-Lock-Legal-Hold":        []string{"ON"},
+			},
+			drift: true,
+		},
+		{
+			header: http.Header{},
+			drift:  true,
+		},
+	}
+	for i, tc := range testCases {
+		tgt := getTargetLockMetadata(miniogo.ObjectInfo{Metadata: tc.header})
+		if drift := tgt != src; drift != tc.drift {
+			t.Errorf("case %d: expected drift %t, got %t (%+v)", i+1, tc.drift, drift, tgt)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/config/api"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file $GOFILE
+//msgp:ignore replicationMRF
+
+const (
+	replicationMRFDir           = ".replication-mrf"
+	replicationMRFRetryInterval = 5 * time.Second
+	replicationMRFSaveInterval  = time.Minute
+)
+
+// ReplicationMRFEntry is an object version whose replication failed
+// and is queued to be retried.
+type ReplicationMRFEntry struct {
+	Bucket     string    `json:"bucket"`
+	Object     string    `json:"object"`
+	VersionID  string    `json:"versionId,omitempty"`
+	RetryCount uint32    `json:"retryCount"`
+	FailedArns []string  `json:"failedArns"`
+	Queued     time.Time `json:"queued"`
+	NextRetry  time.Time `json:"nextRetry"`
+}
+
+func (e ReplicationMRFEntry) key() string {
+	return pathJoin(e.Bucket, e.Object) + "\x00" + e.VersionID
+}
+
+// ReplicationMRFStatus is the replication retry queue of a node,
+// persisted so that it survives restarts and returned by the admin API.
+type ReplicationMRFStatus struct {
+	Node string `json:"node"`
+	// Number of queued entries
+	Pending int `json:"pending"`
+	// Number of failures not queued because the queue was full
+	Dropped uint64 `json:"dropped"`
+	// Number of failures not queued again after exhausting their retries
+	Exhausted uint64 `json:"exhausted"`
+	// Queued entries, sorted by their next retry
+	Entries []ReplicationMRFEntry `json:"entries,omitempty"`
+}
+
+// replicationMRF queues the failed replications of a node, each entry is
+// retried after a delay that backs off per the policy of its failed targets.
+type replicationMRF struct {
+	sync.Mutex
+	entries   map[string]ReplicationMRFEntry
+	dropped   uint64
+	exhausted uint64
+	dirty     bool
+}
+
+func newReplicationMRF() *replicationMRF {
+	return &replicationMRF{entries: make(map[string]ReplicationMRFEntry)}
+}
+
+// add queues e for a retry after the delay of its retry count, the longest
+// delay of its failed targets applies. It returns false when e is not
+// queued because the queue holds size entries or its retries are exhausted,
+// such versions are left for the scanner to heal.
+func (m *replicationMRF) add(e ReplicationMRFEntry, size int, backoff func(arn string) api.ReplicationBackoff, now time.Time) bool {
+	var delay time.Duration
+	retry := false
+	for _, arn := range e.FailedArns {
+		b := backoff(arn)
+		if int(e.RetryCount) > b.Attempts {
+			continue
+		}
+		retry = true
+		if d := b.Delay(int(e.RetryCount)); d > delay {
+			delay = d
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if !retry {
+		m.exhausted++
+		return false
+	}
+	k := e.key()
+	if _, ok := m.entries[k]; !ok && len(m.entries) >= size {
+		m.dropped++
+		return false
+	}
+	if e.Queued.IsZero() {
+		e.Queued = now
+	}
+	e.NextRetry = now.Add(delay)
+	m.entries[k] = e
+	m.dirty = true
+	return true
+}
+
+// restore queues e again without changing its next retry, e.g. when
+// it could not be handed to the workers.
+func (m *replicationMRF) restore(e ReplicationMRFEntry) {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.entries[e.key()]; !ok {
+		m.entries[e.key()] = e
+		m.dirty = true
+	}
+}
+
+// due removes and returns the entries whose next retry is before now.
+func (m *replicationMRF) due(now time.Time) []ReplicationMRFEntry {
+	m.Lock()
+	defer m.Unlock()
+
+	var entries []ReplicationMRFEntry
+	for k, e := range m.entries {
+		if e.NextRetry.After(now) {
+			continue
+		}
+		entries = append(entries, e)
+		delete(m.entries, k)
+		m.dirty = true
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NextRetry.Before(entries[j].NextRetry)
+	})
+	return entries
+}
+
+// status returns the queued entries of bucket, or of all buckets
+// when bucket is empty.
+func (m *replicationMRF) status(bucket string) ReplicationMRFStatus {
+	m.Lock()
+	defer m.Unlock()
+
+	s := ReplicationMRFStatus{
+		Node:      globalLocalNodeName,
+		Dropped:   m.dropped,
+		Exhausted: m.exhausted,
+	}
+	for _, e := range m.entries {
+		if bucket != "" && e.Bucket != bucket {
+			continue
+		}
+		s.Entries = append(s.Entries, e)
+	}
+	s.Pending = len(s.Entries)
+	sort.Slice(s.Entries, func(i, j int) bool {
+		return s.Entries[i].NextRetry.Before(s.Entries[j].NextRetry)
+	})
+	return s
+}
+
+// load merges the entries of a persisted status, up to size entries.
+func (m *replicationMRF) load(s ReplicationMRFStatus, size int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.dropped += s.Dropped
+	m.exhausted += s.Exhausted
+	for _, e := range s.Entries {
+		if _, ok := m.entries[e.key()]; ok {
+			continue
+		}
+		if len(m.entries) >= size {
+			m.dropped++
+			continue
+		}
+		m.entries[e.key()] = e
+	}
+}
+
+// replicationMRFPath returns the path in the meta bucket where
+// this node persists its replication retry queue.
+func replicationMRFPath() string {
+	return path.Join(bucketMetaPrefix, replicationMRFDir, fmt.Sprintf("%x.bin", xxhash.Sum64String(globalLocalNodeName)))
+}
+
+// save persists the queue if it changed since it was last saved.
+func (m *replicationMRF) save(ctx context.Context, objAPI ObjectLayer) error {
+	m.Lock()
+	dirty := m.dirty
+	m.dirty = false
+	m.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	s := m.status("")
+	data, err := s.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, replicationMRFPath(), data); err != nil {
+		m.Lock()
+		m.dirty = true
+		m.Unlock()
+	}
+	return err
+}
+
+// loadMRF reads the retry queue persisted by this node before it was restarted.
+func (m *replicationMRF) loadMRF(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := readConfig(ctx, objAPI, replicationMRFPath())
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var s ReplicationMRFStatus
+	if _, err = s.UnmarshalMsg(data); err != nil {
+		return err
+	}
+	m.load(s, globalAPIConfig.getReplicationMRFSize())
+	return nil
+}
+
+// getMRFStatus returns the queued failed replications of bucket on this node.
+func (p *ReplicationPool) getMRFStatus(bucket string) ReplicationMRFStatus {
+	if p == nil {
+		return ReplicationMRFStatus{Node: globalLocalNodeName}
+	}
+	return p.mrf.status(bucket)
+}
+
+// queueMRF queues the failed replication of ri to its targets
+// failedArns for a retry, after its retry count is incremented.
+func (p *ReplicationPool) queueMRF(ri ReplicateObjectInfo, failedArns []string) {
+	if p == nil || len(failedArns) == 0 {
+		return
+	}
+	p.mrf.add(ReplicationMRFEntry{
+		Bucket:     ri.Bucket,
+		Object:     ri.Name,
+		VersionID:  ri.VersionID,
+		RetryCount: ri.RetryCount + 1,
+		FailedArns: failedArns,
+	}, globalAPIConfig.getReplicationMRFSize(), globalAPIConfig.getReplicationMRFBackoff, UTCNow())
+}
+
+// retryMRF hands the due entries of the retry queue to the failed
+// replication workers, with the current state of their object versions.
+func (p *ReplicationPool) retryMRF(ctx context.Context) {
+	for _, e := range p.mrf.due(UTCNow()) {
+		oi, err := p.objLayer.GetObjectInfo(ctx, e.Bucket, e.Object, ObjectOptions{VersionID: e.VersionID})
+		if err != nil {
+			if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				p.mrf.restore(e)
+			}
+			continue
+		}
+		if oi.DeleteMarker || oi.ReplicationStatus == replication.Completed {
+			continue
+		}
+		cfg, err := getReplicationConfig(ctx, e.Bucket)
+		if err != nil || cfg == nil {
+			continue
+		}
+		tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, e.Bucket)
+		if err != nil {
+			p.mrf.restore(e)
+			continue
+		}
+		roi := getHealReplicateObjectInfo(oi, replicationConfig{Config: cfg, remotes: tgts})
+		if !roi.Dsc.ReplicateAny() {
+			continue
+		}
+		roi.RetryCount = e.RetryCount
+		if !p.queueReplicaFailedTask(roi) {
+			// Workers are busy, retry the remaining entries later.
+			p.mrf.restore(e)
+		}
+	}
+}
+
+// processMRF loads the retry queue persisted before the last restart, then
+// retries its due entries and saves it periodically until ctx is canceled.
+func (p *ReplicationPool) processMRF(ctx context.Context) {
+	if p == nil {
+		return
+	}
+
+	logger.LogIf(ctx, p.mrf.loadMRF(ctx, p.objLayer))
+
+	rTimer := time.NewTimer(replicationMRFRetryInterval)
+	defer rTimer.Stop()
+	sTimer := time.NewTimer(replicationMRFSaveInterval)
+	defer sTimer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-rTimer.C:
+			p.retryMRF(ctx)
+			rTimer.Reset(replicationMRFRetryInterval)
+		case <-sTimer.C:
+			logger.LogIf(ctx, p.mrf.save(ctx, p.objLayer))
+			sTimer.Reset(replicationMRFSaveInterval)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/api"
+)
+
+func TestReplicationMRF(t *testing.T) {
+	backoff := func(arn string) api.ReplicationBackoff {
+		if arn == "arn2" {
+			return api.ReplicationBackoff{Initial: time.Minute, Max: time.Hour, Attempts: 5}
+		}
+		return api.ReplicationBackoff{Initial: 10 * time.Second, Max: time.Hour, Attempts: 2}
+	}
+	now := time.Now().UTC()
+	m := newReplicationMRF()
+
+	e := ReplicationMRFEntry{Bucket: "bucket", Object: "object", VersionID: "v1", RetryCount: 1, FailedArns: []string{"arn1"}}
+	if !m.add(e, 2, backoff, now) {
+		t.Fatal("expected entry to be queued")
+	}
+	// The longest delay of the failed targets applies.
+	e.FailedArns = []string{"arn1", "arn2"}
+	if !m.add(e, 2, backoff, now) {
+		t.Fatal("expected entry to be queued again")
+	}
+	if st := m.status("bucket"); st.Pending != 1 || !st.Entries[0].NextRetry.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected status %#v", st)
+	}
+
+	// The queue is capped.
+	e2 := ReplicationMRFEntry{Bucket: "bucket2", Object: "object", RetryCount: 1, FailedArns: []string{"arn1"}}
+	if !m.add(e2, 2, backoff, now) {
+		t.Fatal("expected entry to be queued")
+	}
+	e3 := ReplicationMRFEntry{Bucket: "bucket", Object: "object3", RetryCount: 1, FailedArns: []string{"arn1"}}
+	if m.add(e3, 2, backoff, now) {
+		t.Fatal("expected entry to be dropped from a full queue")
+	}
+	// Entries which exhausted their retries are not queued.
+	e3.RetryCount = 3
+	if m.add(e3, 3, backoff, now) {
+		t.Fatal("expected entry with exhausted retries to be dropped")
+	}
+	if st := m.status(""); st.Pending != 2 || st.Dropped != 1 || st.Exhausted != 1 {
+		t.Fatalf("unexpected status %#v", st)
+	}
+
+	// Due entries are removed in the order of their next retry.
+	if due := m.due(now.Add(time.Second)); len(due) != 0 {
+		t.Fatalf("expected no due entries, got %d", len(due))
+	}
+	due := m.due(now.Add(2 * time.Minute))
+	if len(due) != 2 || due[0].Bucket != "bucket2" || due[1].Bucket != "bucket" {
+		t.Fatalf("unexpected due entries %#v", due)
+	}
+	if st := m.status(""); st.Pending != 0 {
+		t.Fatalf("expected empty queue, got %d entries", st.Pending)
+	}
+	m.restore(due[1])
+
+	// Persisted queues are reloaded up to the size of the queue.
+	st := m.status("")
+	data, err := st.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s ReplicationMRFStatus
+	if _, err = s.UnmarshalMsg(data); err != nil {
+		t.Fatal(err)
+	}
+	m2 := newReplicationMRF()
+	m2.add(e2, 1, backoff, now)
+	m2.load(s, 1)
+	if st := m2.status(""); st.Pending != 1 || st.Entries[0].Bucket != "bucket2" || st.Dropped != 2 || st.Exhausted != 1 {
+		t.Fatalf("unexpected status after load %#v", st)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Remote targets offline for at least this long are resynced
+	// once they are back online, shorter outages are covered by
+	// retrying failed replication.
+	targetResyncMinDowntime = time.Minute
+
+	// Interval at which the health of remote targets is checked.
+	targetHealthWatchInterval = 10 * time.Second
+)
+
+// Only one server resyncs a recovered target, the others
+// give up waiting for the resync lock.
+var targetResyncLockTimeout = newDynamicTimeout(5*time.Second, time.Second)
+
+// targetHealth is the health of a replication target.
+type targetHealth struct {
+	bucket string
+	online bool
+}
+
+// targetsHealth returns the health of all replication targets by ARN.
+func (sys *BucketTargetSys) targetsHealth() map[string]targetHealth {
+	sys.RLock()
+	defer sys.RUnlock()
+	health := make(map[string]targetHealth)
+	for bucket, tgts := range sys.targetsMap {
+		for _, t := range tgts {
+			if t.Type != madmin.ReplicationService {
+				continue
+			}
+			tc, ok := sys.arnRemotesMap[t.Arn]
+			if !ok {
+				continue
+			}
+			health[t.Arn] = targetHealth{bucket: bucket, online: !tc.IsOffline()}
+		}
+	}
+	return health
+}
+
+// targetHealthWatcher tracks the downtime of replication targets.
+type targetHealthWatcher struct {
+	offlineSince map[string]time.Time
+}
+
+func newTargetHealthWatcher() *targetHealthWatcher {
+	return &targetHealthWatcher{offlineSince: make(map[string]time.Time)}
+}
+
+// update records the current health of the targets and returns the
+// ARNs of targets which are back online after a long enough outage.
+func (w *targetHealthWatcher) update(now time.Time, health map[string]targetHealth) (recovered []string) {
+	for arn, h := range health {
+		since, offline := w.offlineSince[arn]
+		switch {
+		case !h.online && !offline:
+			w.offlineSince[arn] = now
+		case h.online && offline:
+			delete(w.offlineSince, arn)
+			if now.Sub(since) >= targetResyncMinDowntime {
+				recovered = append(recovered, arn)
+			}
+		}
+	}
+	// Forget about targets which have been removed.
+	for arn := range w.offlineSince {
+		if _, ok := health[arn]; !ok {
+			delete(w.offlineSince, arn)
+		}
+	}
+	return recovered
+}
+
+// watchTargetsHealth schedules a resync of failed and pending
+// replication to targets recovering from an outage.
+func watchTargetsHealth(ctx context.Context, objAPI ObjectLayer) {
+	w := newTargetHealthWatcher()
+	t := time.NewTicker(targetHealthWatchInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			health := globalBucketTargetSys.targetsHealth()
+			for _, arn := range w.update(UTCNow(), health) {
+				go resyncTargetReplication(ctx, objAPI, health[arn].bucket, arn)
+			}
+		}
+	}
+}
+
+// resyncTargetReplication queues all object versions in bucket whose
+// replication to the target arn is pending or has failed.
+func resyncTargetReplication(ctx context.Context, objAPI ObjectLayer, bucket, arn string) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil || cfg == nil {
+		return
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return
+	}
+	rcfg := replicationConfig{Config: cfg, remotes: tgts}
+
+	lk := objAPI.NewNSLock(minioMetaBucket, path.Join(bucketMetaPrefix, bucket, "replication-resync", arn))
+	lkctx, err := lk.GetLock(ctx, targetResyncLockTimeout)
+	if err != nil {
+		// Resync is already running on another server.
+		return
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	objInfoCh := make(chan ObjectInfo)
+	if err := objAPI.Walk(ctx, bucket, "", objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	var queued int
+	for oi := range objInfoCh {
+		if !mustResyncTarget(oi, arn, rcfg) {
+			continue
+		}
+		queueReplicationHeal(oi, rcfg)
+		queued++
+	}
+	if queued > 0 {
+		logger.Info("Replication target %s of bucket %s is back online, resyncing %d object versions", arn, bucket, queued)
+	}
+}
+
+// mustResyncTarget returns true if replication of oi to the target arn
+// is pending or has failed.
+func mustResyncTarget(oi ObjectInfo, arn string, rcfg replicationConfig) bool {
+	status := oi.TargetReplicationStatus(arn)
+	purgeStatus := versionPurgeStatusesMap(oi.VersionPurgeStatusInternal)[arn]
+	if rcfg.Config != nil && rcfg.Config.RoleArn == arn {
+		// Objects replicated with a legacy single target
+		// configuration only carry the overall status.
+		if oi.ReplicationStatusInternal == "" {
+			status = oi.ReplicationStatus
+		}
+		if oi.VersionPurgeStatusInternal == "" {
+			purgeStatus = oi.VersionPurgeStatus
+		}
+	}
+	switch status {
+	case replication.Pending, replication.Failed:
+		return true
+	}
+	return purgeStatus == Pending || purgeStatus == Failed
+}
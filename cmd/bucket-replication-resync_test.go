@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestTargetHealthWatcher(t *testing.T) {
+	w := newTargetHealthWatcher()
+	now := time.Now()
+	health := func(a, b bool) map[string]targetHealth {
+		return map[string]targetHealth{
+			"arn1": {bucket: "bucket", online: a},
+			"arn2": {bucket: "bucket", online: b},
+		}
+	}
+
+	if recovered := w.update(now, health(true, true)); len(recovered) != 0 {
+		t.Fatalf("unexpected recovered targets %v", recovered)
+	}
+	w.update(now, health(false, false))
+	// arn2 recovers after a short outage, no resync.
+	if recovered := w.update(now.Add(targetResyncMinDowntime/2), health(false, true)); len(recovered) != 0 {
+		t.Fatalf("unexpected recovered targets %v", recovered)
+	}
+	if recovered := w.update(now.Add(targetResyncMinDowntime), health(true, true)); !reflect.DeepEqual(recovered, []string{"arn1"}) {
+		t.Fatalf("expected arn1 to be recovered, got %v", recovered)
+	}
+	if recovered := w.update(now.Add(2*targetResyncMinDowntime), health(true, true)); len(recovered) != 0 {
+		t.Fatalf("unexpected recovered targets %v", recovered)
+	}
+
+	// Removed targets are forgotten.
+	w.update(now, health(false, false))
+	w.update(now, map[string]targetHealth{})
+	if len(w.offlineSince) != 0 {
+		t.Fatalf("expected removed targets to be forgotten, got %v", w.offlineSince)
+	}
+}
+
+func TestMustResyncTarget(t *testing.T) {
+	rcfg := replicationConfig{Config: &replication.Config{RoleArn: "arn1"}}
+	testCases := []struct {
+		oi       ObjectInfo
+		arn      string
+		expected bool
+	}{
+		{ObjectInfo{ReplicationStatusInternal: "arn1=FAILED;arn2=COMPLETED;"}, "arn1", true},
+		{ObjectInfo{ReplicationStatusInternal: "arn1=FAILED;arn2=COMPLETED;"}, "arn2", false},
+		{ObjectInfo{ReplicationStatusInternal: "arn2=PENDING;"}, "arn2", true},
+		{ObjectInfo{VersionPurgeStatusInternal: "arn2=FAILED;", DeleteMarker: true}, "arn2", true},
+		// Legacy single target status.
+		{ObjectInfo{ReplicationStatus: replication.Failed}, "arn1", true},
+		{ObjectInfo{ReplicationStatus: replication.Failed}, "arn2", false},
+		{ObjectInfo{VersionPurgeStatus: Pending}, "arn1", true},
+	}
+	for i, testCase := range testCases {
+		if got := mustResyncTarget(testCase.oi, testCase.arn, rcfg); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
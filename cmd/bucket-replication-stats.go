@@ -19,12 +19,66 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
 	"sync"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
 )
 
+const (
+	replicationStatsDir          = ".replication-stats"
+	replicationStatsSaveInterval = 5 * time.Minute
+)
+
+// replicationPendingQueue tallies the replications queued to a target
+// by the second they were queued at. Replications are assumed to be
+// done in the order they were queued, so a replication leaving the
+// pending state is taken off the oldest second.
+type replicationPendingQueue struct {
+	entries []replicationPendingEntry
+}
+
+type replicationPendingEntry struct {
+	since time.Time
+	count int64
+}
+
+// add records a replication queued at t.
+func (q *replicationPendingQueue) add(t time.Time) {
+	t = t.Truncate(time.Second)
+	if n := len(q.entries); n > 0 && !q.entries[n-1].since.Before(t) {
+		q.entries[n-1].count++
+		return
+	}
+	q.entries = append(q.entries, replicationPendingEntry{since: t, count: 1})
+}
+
+// done records a replication which is not pending anymore, replications
+// which were queued before a restart are not tallied and are ignored.
+func (q *replicationPendingQueue) done() {
+	if len(q.entries) == 0 {
+		return
+	}
+	q.entries[0].count--
+	if q.entries[0].count == 0 {
+		q.entries = q.entries[1:]
+	}
+}
+
+// oldest returns the time the oldest pending replication was queued at,
+// zero when none is pending.
+func (q *replicationPendingQueue) oldest() time.Time {
+	if len(q.entries) == 0 {
+		return time.Time{}
+	}
+	return q.entries[0].since
+}
+
 func (b *BucketReplicationStats) hasReplicationUsage() bool {
 	for _, s := range b.Stats {
 		if s.hasReplicationUsage() {
@@ -40,6 +94,13 @@ type ReplicationStats struct {
 	UsageCache map[string]*BucketReplicationStats
 	sync.RWMutex
 	ulock sync.RWMutex
+
+	// Stats history persisted before the last restart, kept apart
+	// from Cache which only holds the stats since startup.
+	persisted map[string]BucketReplicationStats
+	// Set once the persisted stats are loaded, nothing is saved
+	// before that so that the history is not overwritten.
+	loaded bool
 }
 
 // Delete deletes in-memory replication statistics for a bucket.
@@ -52,6 +113,7 @@ func (r *ReplicationStats) Delete(bucket string) {
 	defer r.Unlock()
 	delete(r.Cache, bucket)
 	delete(r.UsageCache, bucket)
+	delete(r.persisted, bucket)
 
 }
 
@@ -71,6 +133,129 @@ func (r *ReplicationStats) UpdateReplicaStat(bucket string, n int64) {
 	r.Cache[bucket] = bs
 }
 
+// UpdateLockSyncFailed counts a failure to sync object lock
+// metadata of an object version to the remote target arn.
+func (r *ReplicationStats) UpdateLockSyncFailed(bucket, arn string) {
+	if r == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+	}
+	b.LockSyncFailedCount++
+	bs.LockSyncFailedCount++
+	bs.Stats[arn] = b
+	r.Cache[bucket] = bs
+}
+
+// UpdateProxyStat counts a read of an object not replicated locally
+// yet which was proxied to the remote target arn, or failed there.
+func (r *ReplicationStats) UpdateProxyStat(bucket, arn string, failed bool) {
+	if r == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+	}
+	if failed {
+		b.ProxyFailedCount++
+	} else {
+		b.ProxiedCount++
+	}
+	bs.Stats[arn] = b
+	r.Cache[bucket] = bs
+}
+
+// UpdateDriftStat counts an object version found out of sync with the
+// remote target arn by the replication consistency check.
+func (r *ReplicationStats) UpdateDriftStat(bucket, arn string, drift replicationDrift) {
+	if r == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+	}
+	switch drift {
+	case replicationDriftMissing:
+		b.DriftMissingCount++
+	case replicationDriftVersion:
+		b.DriftVersionMismatchCount++
+	case replicationDriftETag:
+		b.DriftETagMismatchCount++
+	case replicationDriftTags:
+		b.DriftTagMismatchCount++
+	default:
+		return
+	}
+	bs.DriftCount++
+	bs.Stats[arn] = b
+	r.Cache[bucket] = bs
+}
+
+// UpdateDeleteStat updates the delete replication counters of the target
+// arn, purge is set for permanent deletes of object versions and unset
+// for delete markers.
+func (r *ReplicationStats) UpdateDeleteStat(bucket, arn string, status, prevStatus replication.StatusType, purge bool) {
+	if r == nil || status == prevStatus {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+	}
+	replicated, pending, failed := &b.ReplicatedDeleteMarkers, &b.PendingDeleteMarkers, &b.FailedDeleteMarkers
+	if purge {
+		replicated, pending, failed = &b.ReplicatedVersionPurges, &b.PendingVersionPurges, &b.FailedVersionPurges
+	}
+	switch prevStatus { // adjust counters based on previous state
+	case replication.Pending:
+		*pending--
+	case replication.Failed:
+		*failed--
+	}
+	switch status {
+	case replication.Completed:
+		*replicated++
+	case replication.Pending:
+		*pending++
+	case replication.Failed:
+		*failed++
+	}
+	bs.Stats[arn] = b
+	r.Cache[bucket] = bs
+}
+
 // Update updates in-memory replication statistics with new values.
 func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration time.Duration, status, prevStatus replication.StatusType, opType replication.Type) {
 	if r == nil {
@@ -115,6 +300,25 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 			b.ReplicaSize += n
 		}
 	}
+	switch {
+	case status == replication.Pending && prevStatus == "":
+		b.pending.add(UTCNow())
+		if opType == replication.ObjectReplicationType {
+			b.PendingCount++
+			b.PendingSize += n
+		}
+	case prevStatus == replication.Pending && status != replication.Pending:
+		b.pending.done()
+		if opType == replication.ObjectReplicationType {
+			b.PendingCount--
+			b.PendingSize -= n
+		}
+	}
+	b.OldestPendingSince = b.pending.oldest()
+	// Permanent deletes report the completion as a version purge status.
+	if status == replication.Completed || status == replication.StatusType(Complete) {
+		b.LastReplicatedAt = UTCNow()
+	}
 
 	bs.Stats[arn] = b
 	r.Cache[bucket] = bs
@@ -134,7 +338,8 @@ func (r *ReplicationStats) GetInitialUsage(bucket string) BucketReplicationStats
 	return BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
 }
 
-// Get replication metrics for a bucket from this node since this node came up.
+// Get replication metrics for a bucket from this node since this node came up,
+// along with the stats history persisted before it was restarted.
 func (r *ReplicationStats) Get(bucket string) BucketReplicationStats {
 	if r == nil {
 		return BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
@@ -143,11 +348,14 @@ func (r *ReplicationStats) Get(bucket string) BucketReplicationStats {
 	r.RLock()
 	defer r.RUnlock()
 
-	st, ok := r.Cache[bucket]
-	if !ok {
-		return BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	s := BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+	if st, ok := r.Cache[bucket]; ok {
+		s = st.Clone()
 	}
-	return st.Clone()
+	if st, ok := r.persisted[bucket]; ok {
+		s.merge(st.history())
+	}
+	return s
 }
 
 // NewReplicationStats initialize in-memory replication statistics
@@ -180,12 +388,19 @@ func (r *ReplicationStats) loadInitialReplicationMetrics(ctx context.Context) {
 				b := &BucketReplicationStats{
 					Stats: make(map[string]*BucketReplicationStat, len(usage.ReplicationInfo)),
 				}
+				r.RLock()
+				_, persisted := r.persisted[bucket]
+				r.RUnlock()
 				for arn, uinfo := range usage.ReplicationInfo {
 					b.Stats[arn] = &BucketReplicationStat{
-						FailedSize:     int64(uinfo.ReplicationFailedSize),
 						ReplicatedSize: int64(uinfo.ReplicatedSize),
 						ReplicaSize:    int64(uinfo.ReplicaSize),
-						FailedCount:    int64(uinfo.ReplicationFailedCount),
+					}
+					// The failed replications are part of the persisted
+					// stats history when there is one.
+					if !persisted {
+						b.Stats[arn].FailedSize = int64(uinfo.ReplicationFailedSize)
+						b.Stats[arn].FailedCount = int64(uinfo.ReplicationFailedCount)
 					}
 				}
 				b.ReplicaSize += int64(usage.ReplicaSize)
@@ -200,3 +415,109 @@ func (r *ReplicationStats) loadInitialReplicationMetrics(ctx context.Context) {
 		}
 	}
 }
+
+// replicationStatsPath returns the path in the meta bucket where
+// this node persists its replication stats.
+func replicationStatsPath() string {
+	return path.Join(bucketMetaPrefix, replicationStatsDir, fmt.Sprintf("%x.bin", xxhash.Sum64String(globalLocalNodeName)))
+}
+
+// snapshot returns the replication stats history of this node, both
+// persisted before the last restart and accumulated since.
+func (r *ReplicationStats) snapshot() ReplicationStatsSnapshot {
+	r.RLock()
+	defer r.RUnlock()
+
+	s := ReplicationStatsSnapshot{
+		Buckets: make(map[string]BucketReplicationStats, len(r.Cache)),
+		Saved:   UTCNow(),
+	}
+	for bucket, st := range r.persisted {
+		s.Buckets[bucket] = st.history()
+	}
+	for bucket, st := range r.Cache {
+		bs := s.Buckets[bucket]
+		bs.merge(st.history())
+		s.Buckets[bucket] = bs
+	}
+	return s
+}
+
+// saveStats persists the replication stats history of this node.
+func (r *ReplicationStats) saveStats(ctx context.Context, objAPI ObjectLayer) error {
+	r.RLock()
+	loaded := r.loaded
+	r.RUnlock()
+	if !loaded {
+		return nil
+	}
+
+	s := r.snapshot()
+	data, err := s.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, replicationStatsPath(), data)
+}
+
+// loadStats reads the replication stats history persisted by this node
+// before it was restarted, it is loaded only once.
+func (r *ReplicationStats) loadStats(ctx context.Context, objAPI ObjectLayer) error {
+	r.RLock()
+	loaded := r.loaded
+	r.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	var s ReplicationStatsSnapshot
+	data, err := readConfig(ctx, objAPI, replicationStatsPath())
+	switch {
+	case errors.Is(err, errConfigNotFound):
+	case err != nil:
+		return err
+	default:
+		if _, err = s.UnmarshalMsg(data); err != nil {
+			return err
+		}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if r.loaded {
+		return nil
+	}
+	r.persisted = make(map[string]BucketReplicationStats, len(s.Buckets))
+	for bucket, st := range s.Buckets {
+		r.persisted[bucket] = st.history()
+	}
+	r.loaded = true
+	return nil
+}
+
+// persistStats loads the replication stats saved before the last restart
+// and then periodically saves the stats history until ctx is canceled.
+func (r *ReplicationStats) persistStats(ctx context.Context, objAPI ObjectLayer) {
+	if r == nil {
+		return
+	}
+
+	logger.LogIf(ctx, r.loadStats(ctx, objAPI))
+
+	sTimer := time.NewTimer(replicationStatsSaveInterval)
+	defer sTimer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sTimer.C:
+			// Retry loading the history if it failed at startup.
+			if err := r.loadStats(ctx, objAPI); err != nil {
+				logger.LogIf(ctx, err)
+			} else {
+				logger.LogIf(ctx, r.saveStats(ctx, objAPI))
+			}
+			sTimer.Reset(replicationStatsSaveInterval)
+		}
+	}
+}
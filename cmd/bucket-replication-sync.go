@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+// replicationAcks tracks the synchronous targets whose rules acknowledge
+// writes once they committed the object, done is closed once as many of
+// them as their policies require committed it, or too many of them failed
+// to for that to happen.
+type replicationAcks struct {
+	mu        sync.Mutex
+	pending   map[string]struct{} // targets yet to report
+	total     int                 // number of targets acknowledging writes
+	required  int                 // number of targets which must commit the object
+	committed int
+	failed    bool
+	done      chan struct{}
+	closed    bool
+
+	// how long writes wait for the targets, 0 waits without limit.
+	timeout time.Duration
+}
+
+func newReplicationAcks(dsc ReplicateDecision) *replicationAcks {
+	a := &replicationAcks{
+		pending: make(map[string]struct{}),
+		done:    make(chan struct{}),
+	}
+	unlimited := false
+	var sacks []replication.SyncAcknowledgment
+	for arn, t := range dsc.targetsMap {
+		if !t.Replicate || !t.Synchronous || !t.syncAck.Enabled() {
+			continue
+		}
+		a.pending[arn] = struct{}{}
+		sacks = append(sacks, t.syncAck)
+		switch timeout := t.syncAck.Timeout(); {
+		case timeout == 0:
+			unlimited = true
+		case timeout > a.timeout:
+			a.timeout = timeout
+		}
+	}
+	if unlimited {
+		a.timeout = 0
+	}
+	a.total = len(a.pending)
+	// The strictest policy of the rules applies.
+	for _, sack := range sacks {
+		if required := sack.Required(a.total); required > a.required {
+			a.required = required
+		}
+	}
+	if a.total == 0 {
+		a.close()
+	}
+	return a
+}
+
+// close must be called with mu held, or before a is shared.
+func (a *replicationAcks) close() {
+	if a.closed {
+		return
+	}
+	a.closed = true
+	close(a.done)
+}
+
+// update records the outcome of replicating to a target.
+func (a *replicationAcks) update(rinfo replicatedTargetInfo) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.pending[rinfo.Arn]; !ok {
+		return
+	}
+	delete(a.pending, rinfo.Arn)
+	if rinfo.ReplicationStatus == replication.Completed {
+		a.committed++
+	}
+	// Fewer targets are left to report than still need to commit the object.
+	if a.committed < a.required && len(a.pending) < a.required-a.committed {
+		a.failed = true
+	}
+	if a.failed || a.committed >= a.required {
+		a.close()
+	}
+}
+
+// finish is called once replication to all targets was attempted,
+// targets which never reported did not commit the object.
+func (a *replicationAcks) finish() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.committed < a.required {
+		a.failed = true
+	}
+	a.close()
+}
+
+// status returns the replication status of the object on the targets,
+// completed once as many of them as required committed it.
+func (a *replicationAcks) status() replication.StatusType {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case a.committed >= a.required:
+		return replication.Completed
+	case a.failed:
+		return replication.Failed
+	default:
+		return replication.Pending
+	}
+}
+
+// replicateObjectSync replicates an object to its synchronous targets. If
+// the rules of some of them acknowledge writes, it waits until as many of
+// these targets as the strictest policy of their rules requires commit the
+// object, too many of them fail to or the longest timeout of their rules
+// expires, and returns the replication status of the object on these
+// targets. The object is stored locally whatever the outcome, and
+// replication continues in the background like asynchronous replication.
+func replicateObjectSync(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer) replication.StatusType {
+	acks := newReplicationAcks(ri.Dsc)
+	if acks.total == 0 {
+		replicateObject(ctx, ri, objectAPI, ReplicateIncoming)
+		return ""
+	}
+
+	// Replication may outlive the request, do not cancel it along with the request.
+	rctx := logger.SetReqInfo(GlobalContext, logger.GetReqInfo(ctx))
+	go replicateObjectAck(rctx, ri, objectAPI, ReplicateIncoming, acks)
+
+	var expired <-chan time.Time
+	if acks.timeout > 0 {
+		timer := time.NewTimer(acks.timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-acks.done:
+	case <-expired:
+	case <-ctx.Done():
+	}
+	return acks.status()
+}
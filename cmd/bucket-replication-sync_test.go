@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestReplicationAcks(t *testing.T) {
+	ack := func(arn string, timeout int) replicateTargetDecision {
+		d := newReplicateTargetDecision(arn, true, true)
+		d.syncAck = replication.SyncAcknowledgment{Status: replication.Enabled, TimeoutSeconds: timeout}
+		return d
+	}
+	dsc := ReplicateDecision{targetsMap: map[string]replicateTargetDecision{
+		"arn1":  ack("arn1", 5),
+		"arn2":  ack("arn2", 10),
+		"sync":  newReplicateTargetDecision("sync", true, true),
+		"async": newReplicateTargetDecision("async", true, false),
+	}}
+	completed := func(arn string) replicatedTargetInfo {
+		return replicatedTargetInfo{Arn: arn, ReplicationStatus: replication.Completed}
+	}
+	failed := func(arn string) replicatedTargetInfo {
+		return replicatedTargetInfo{Arn: arn, ReplicationStatus: replication.Failed}
+	}
+
+	testCases := []struct {
+		updates []replicatedTargetInfo
+		// number of updates after which done must be closed, 0 if
+		// it is only closed by finish.
+		doneAfter int
+		status    replication.StatusType
+	}{
+		// Targets whose rules do not acknowledge writes are ignored.
+		{[]replicatedTargetInfo{failed("sync"), failed("async"), completed("arn1"), completed("arn2")}, 4, replication.Completed},
+		// A single failure is enough to report the object as failed.
+		{[]replicatedTargetInfo{failed("arn1")}, 1, replication.Failed},
+		// Targets which never report did not commit the object.
+		{[]replicatedTargetInfo{completed("arn1")}, 0, replication.Failed},
+		// Duplicate reports are ignored.
+		{[]replicatedTargetInfo{completed("arn1"), completed("arn1")}, 0, replication.Failed},
+	}
+	for i, testCase := range testCases {
+		acks := newReplicationAcks(dsc)
+		if acks.total != 2 || acks.timeout != 10*time.Second {
+			t.Fatalf("Test %d: expected 2 targets with a 10s timeout, got %d with %v", i+1, acks.total, acks.timeout)
+		}
+		for j, update := range testCase.updates {
+			acks.update(update)
+			closed := false
+			select {
+			case <-acks.done:
+				closed = true
+			default:
+			}
+			if want := testCase.doneAfter > 0 && j+1 >= testCase.doneAfter; closed != want {
+				t.Fatalf("Test %d: expected done %v after %d updates, got %v", i+1, want, j+1, closed)
+			}
+		}
+		acks.finish()
+		<-acks.done
+		if status := acks.status(); status != testCase.status {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.status, status)
+		}
+	}
+
+	// A write returning before the targets reported is still pending.
+	if status := newReplicationAcks(dsc).status(); status != replication.Pending {
+		t.Fatalf("expected %s, got %s", replication.Pending, status)
+	}
+
+	// A rule without a timeout waits without limit.
+	dsc.targetsMap["arn3"] = ack("arn3", 0)
+	if acks := newReplicationAcks(dsc); acks.total != 3 || acks.timeout != 0 {
+		t.Fatalf("expected 3 targets without timeout, got %d with %v", acks.total, acks.timeout)
+	}
+
+	// No rule acknowledges writes, there is nothing to wait for.
+	acks := newReplicationAcks(ReplicateDecision{targetsMap: map[string]replicateTargetDecision{
+		"sync": newReplicateTargetDecision("sync", true, true),
+	}})
+	<-acks.done
+	if acks.total != 0 {
+		t.Fatalf("expected no targets to wait for, got %d", acks.total)
+	}
+}
+
+func TestReplicationAcksPolicy(t *testing.T) {
+	decision := func(policies ...replication.AckPolicy) ReplicateDecision {
+		dsc := ReplicateDecision{targetsMap: make(map[string]replicateTargetDecision)}
+		for i, policy := range policies {
+			arn := "arn" + strconv.Itoa(i+1)
+			d := newReplicateTargetDecision(arn, true, true)
+			d.syncAck = replication.SyncAcknowledgment{Status: replication.Enabled, Policy: policy}
+			dsc.targetsMap[arn] = d
+		}
+		return dsc
+	}
+	completed := func(arn string) replicatedTargetInfo {
+		return replicatedTargetInfo{Arn: arn, ReplicationStatus: replication.Completed}
+	}
+	failed := func(arn string) replicatedTargetInfo {
+		return replicatedTargetInfo{Arn: arn, ReplicationStatus: replication.Failed}
+	}
+
+	testCases := []struct {
+		dsc      ReplicateDecision
+		required int
+		updates  []replicatedTargetInfo
+		// number of updates after which done must be closed, 0 if
+		// it is only closed by finish.
+		doneAfter int
+		status    replication.StatusType
+	}{
+		// A single target committing the object is enough.
+		{decision(replication.AckMin, replication.AckMin, replication.AckMin), 1, []replicatedTargetInfo{completed("arn2")}, 1, replication.Completed},
+		// Failures are tolerated as long as a target may still commit the object.
+		{decision(replication.AckMin, replication.AckMin, replication.AckMin), 1, []replicatedTargetInfo{failed("arn1"), failed("arn2"), completed("arn3")}, 3, replication.Completed},
+		{decision(replication.AckMin, replication.AckMin, replication.AckMin), 1, []replicatedTargetInfo{failed("arn1"), failed("arn2"), failed("arn3")}, 3, replication.Failed},
+		// A majority of the targets must commit the object.
+		{decision(replication.AckQuorum, replication.AckQuorum, replication.AckQuorum), 2, []replicatedTargetInfo{completed("arn1"), failed("arn2"), completed("arn3")}, 3, replication.Completed},
+		{decision(replication.AckQuorum, replication.AckQuorum, replication.AckQuorum), 2, []replicatedTargetInfo{completed("arn1"), completed("arn3")}, 2, replication.Completed},
+		{decision(replication.AckQuorum, replication.AckQuorum, replication.AckQuorum), 2, []replicatedTargetInfo{failed("arn1"), failed("arn2")}, 2, replication.Failed},
+		{decision(replication.AckQuorum, replication.AckQuorum, replication.AckQuorum), 2, []replicatedTargetInfo{completed("arn1")}, 0, replication.Failed},
+		// The strictest policy of the rules applies.
+		{decision(replication.AckMin, replication.AckQuorum, replication.AckMin), 2, []replicatedTargetInfo{completed("arn1"), completed("arn2")}, 2, replication.Completed},
+		{decision(replication.AckMin, replication.AckQuorum, ""), 3, []replicatedTargetInfo{completed("arn1"), completed("arn2")}, 0, replication.Failed},
+	}
+	for i, testCase := range testCases {
+		acks := newReplicationAcks(testCase.dsc)
+		if acks.total != 3 || acks.required != testCase.required {
+			t.Fatalf("Test %d: expected %d of 3 targets, got %d of %d", i+1, testCase.required, acks.required, acks.total)
+		}
+		for j, update := range testCase.updates {
+			acks.update(update)
+			closed := false
+			select {
+			case <-acks.done:
+				closed = true
+			default:
+			}
+			if want := testCase.doneAfter > 0 && j+1 >= testCase.doneAfter; closed != want {
+				t.Fatalf("Test %d: expected done %v after %d updates, got %v", i+1, want, j+1, closed)
+			}
+		}
+		acks.finish()
+		<-acks.done
+		if status := acks.status(); status != testCase.status {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.status, status)
+		}
+	}
+}
@@ -175,6 +175,10 @@ type replicateTargetDecision struct {
 	Synchronous bool   // Synchronous replication configured.
 	Arn         string // ARN of replication target
 	ID          string
+
+	// Acknowledgment setting of the rule of a synchronous target, only
+	// known while the object is written and not persisted.
+	syncAck replication.SyncAcknowledgment
 }
 
 func (t *replicateTargetDecision) String() string {
@@ -601,3 +605,16 @@ func (v VersionPurgeStatusType) Empty() bool {
 func (v VersionPurgeStatusType) Pending() bool {
 	return v == Pending || v == Failed
 }
+
+// replicationStatus returns the replication status matching v.
+func (v VersionPurgeStatusType) replicationStatus() replication.StatusType {
+	switch v {
+	case Pending:
+		return replication.Pending
+	case Complete:
+		return replication.Completed
+	case Failed:
+		return replication.Failed
+	}
+	return replication.StatusType(string(v))
+}
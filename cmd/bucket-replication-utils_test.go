@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/bucket/replication"
 )
@@ -244,3 +246,240 @@ func TestCompositeReplicationStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestReplicationDeleteStats(t *testing.T) {
+	const bucket, arn = "bucket", "arn:minio:replication::1:target"
+	stats := NewReplicationStats(context.Background(), nil)
+
+	// Two delete markers and a permanent delete are queued, one delete
+	// marker fails and is retried successfully.
+	stats.UpdateDeleteStat(bucket, arn, replication.Pending, "", false)
+	stats.UpdateDeleteStat(bucket, arn, replication.Pending, "", false)
+	stats.UpdateDeleteStat(bucket, arn, replication.Pending, "", true)
+	stats.UpdateDeleteStat(bucket, arn, replication.Completed, replication.Pending, false)
+	stats.UpdateDeleteStat(bucket, arn, replication.Failed, replication.Pending, false)
+	stats.UpdateDeleteStat(bucket, arn, Complete.replicationStatus(), Pending.replicationStatus(), true)
+
+	st := stats.Get(bucket).Stats[arn]
+	if st.ReplicatedDeleteMarkers != 1 || st.PendingDeleteMarkers != 0 || st.FailedDeleteMarkers != 1 {
+		t.Fatalf("unexpected delete marker stats %+v", st)
+	}
+	if st.ReplicatedVersionPurges != 1 || st.PendingVersionPurges != 0 || st.FailedVersionPurges != 0 {
+		t.Fatalf("unexpected version purge stats %+v", st)
+	}
+
+	stats.UpdateDeleteStat(bucket, arn, replication.Completed, replication.Failed, false)
+	st = stats.Get(bucket).Stats[arn]
+	if st.ReplicatedDeleteMarkers != 2 || st.FailedDeleteMarkers != 0 {
+		t.Fatalf("unexpected delete marker stats after retry %+v", st)
+	}
+}
+
+func TestReplicationProxyStats(t *testing.T) {
+	const bucket, arn = "bucket", "arn:minio:replication::1:target"
+	stats := NewReplicationStats(context.Background(), nil)
+
+	stats.UpdateProxyStat(bucket, arn, false)
+	stats.UpdateProxyStat(bucket, arn, false)
+	stats.UpdateProxyStat(bucket, arn, true)
+
+	st := stats.Get(bucket).Stats[arn]
+	if st.ProxiedCount != 2 || st.ProxyFailedCount != 1 {
+		t.Fatalf("unexpected proxy stats %+v", st)
+	}
+	if !st.hasReplicationUsage() {
+		t.Fatal("expected proxied reads to count as replication usage")
+	}
+
+	var merged BucketReplicationStats
+	merged.merge(stats.Get(bucket))
+	merged.merge(stats.Get(bucket))
+	if st := merged.Stats[arn]; st.ProxiedCount != 4 || st.ProxyFailedCount != 2 {
+		t.Fatalf("unexpected merged proxy stats %+v", st)
+	}
+}
+
+func TestReplicationDriftStats(t *testing.T) {
+	const bucket, arn = "bucket", "arn:minio:replication::1:target"
+	stats := NewReplicationStats(context.Background(), nil)
+
+	stats.UpdateDriftStat(bucket, arn, replicationDriftMissing)
+	stats.UpdateDriftStat(bucket, arn, replicationDriftMissing)
+	stats.UpdateDriftStat(bucket, arn, replicationDriftETag)
+	stats.UpdateDriftStat(bucket, arn, replicationDriftTags)
+
+	bs := stats.Get(bucket)
+	st := bs.Stats[arn]
+	if st.DriftMissingCount != 2 || st.DriftVersionMismatchCount != 0 || st.DriftETagMismatchCount != 1 || st.DriftTagMismatchCount != 1 {
+		t.Fatalf("unexpected drift stats %+v", st)
+	}
+	if bs.DriftCount != 4 {
+		t.Fatalf("expected 4 versions out of sync, got %d", bs.DriftCount)
+	}
+	if !st.hasReplicationUsage() {
+		t.Fatal("expected drift to count as replication usage")
+	}
+
+	var merged BucketReplicationStats
+	merged.merge(bs)
+	merged.merge(bs)
+	if st := merged.Stats[arn]; st.DriftMissingCount != 4 || st.DriftTagMismatchCount != 2 || merged.DriftCount != 8 {
+		t.Fatalf("unexpected merged drift stats %+v", st)
+	}
+
+	data, err := bs.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BucketReplicationStats
+	if _, err = decoded.UnmarshalMsg(data); err != nil {
+		t.Fatal(err)
+	}
+	if st := decoded.Stats[arn]; st.DriftMissingCount != 2 || st.DriftETagMismatchCount != 1 || decoded.DriftCount != 4 {
+		t.Fatalf("unexpected decoded drift stats %+v", st)
+	}
+}
+
+func TestReplicationPendingTimes(t *testing.T) {
+	const bucket, arn = "bucket", "arn:minio:replication::1:target"
+	stats := NewReplicationStats(context.Background(), nil)
+
+	before := UTCNow().Truncate(time.Second)
+	stats.Update(bucket, arn, 10, 0, replication.Pending, "", replication.ObjectReplicationType)
+	stats.Update(bucket, arn, 10, 0, replication.Pending, "", replication.ObjectReplicationType)
+	st := stats.Get(bucket).Stats[arn]
+	if st.OldestPendingSince.Before(before) || !st.LastReplicatedAt.IsZero() {
+		t.Fatalf("unexpected replication times %+v", st)
+	}
+
+	stats.Update(bucket, arn, 10, time.Millisecond, replication.Completed, replication.Pending, replication.ObjectReplicationType)
+	st = stats.Get(bucket).Stats[arn]
+	if st.OldestPendingSince.IsZero() || st.LastReplicatedAt.Before(before) {
+		t.Fatalf("expected one replication still pending %+v", st)
+	}
+
+	stats.Update(bucket, arn, 10, 0, replication.Failed, replication.Pending, replication.ObjectReplicationType)
+	// Not counted as pending, queued before a restart.
+	stats.Update(bucket, arn, 10, 0, replication.Failed, replication.Pending, replication.ObjectReplicationType)
+	st = stats.Get(bucket).Stats[arn]
+	if !st.OldestPendingSince.IsZero() {
+		t.Fatalf("expected no replication pending %+v", st)
+	}
+
+	// Oldest pending and latest replication across nodes.
+	old, recent := UTCNow().Add(-time.Hour), UTCNow()
+	agg := BucketReplicationStat{OldestPendingSince: recent, LastReplicatedAt: old}
+	agg.addReplicationTimes(BucketReplicationStat{OldestPendingSince: old, LastReplicatedAt: recent})
+	agg.addReplicationTimes(BucketReplicationStat{})
+	if !agg.OldestPendingSince.Equal(old) || !agg.LastReplicatedAt.Equal(recent) {
+		t.Fatalf("unexpected aggregated replication times %+v", agg)
+	}
+}
+
+func TestReplicationPendingQueue(t *testing.T) {
+	var q replicationPendingQueue
+	t0 := UTCNow().Truncate(time.Second)
+	q.add(t0)
+	q.add(t0.Add(500 * time.Millisecond))
+	q.add(t0.Add(2 * time.Second))
+	if len(q.entries) != 2 || !q.oldest().Equal(t0) {
+		t.Fatalf("unexpected pending queue %+v", q.entries)
+	}
+	q.done()
+	q.done()
+	if !q.oldest().Equal(t0.Add(2 * time.Second)) {
+		t.Fatalf("unexpected oldest pending %v", q.oldest())
+	}
+	q.done()
+	q.done()
+	if !q.oldest().IsZero() {
+		t.Fatalf("expected no pending replication, got %v", q.oldest())
+	}
+}
+
+func TestReplicationStatsRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket, arn = "bucket", "arn:minio:replication::1:target"
+	stats := NewReplicationStats(ctx, obj)
+	if err = stats.loadStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	stats.Update(bucket, arn, 100, 0, replication.Pending, "", replication.ObjectReplicationType)
+	stats.Update(bucket, arn, 100, time.Second, replication.Completed, replication.Pending, replication.ObjectReplicationType)
+	stats.UpdateDeleteStat(bucket, arn, replication.Completed, "", false)
+	stats.UpdateProxyStat(bucket, arn, false)
+	// One replication still pending and one failed at the restart.
+	stats.Update(bucket, arn, 10, 0, replication.Pending, "", replication.ObjectReplicationType)
+	stats.Update(bucket, arn, 20, 0, replication.Pending, "", replication.ObjectReplicationType)
+	stats.Update(bucket, arn, 20, 0, replication.Failed, replication.Pending, replication.ObjectReplicationType)
+	if err = stats.saveStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart, loading the history twice must not count it twice.
+	restarted := NewReplicationStats(ctx, obj)
+	for i := 0; i < 2; i++ {
+		if err = restarted.loadStats(ctx, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	st := restarted.Get(bucket).Stats[arn]
+	if st == nil || st.ReplicatedDeleteMarkers != 1 || st.ProxiedCount != 1 || st.LastReplicatedAt.IsZero() {
+		t.Fatalf("unexpected stats after restart %+v", st)
+	}
+	// Replicated sizes are accounted by the data usage scanner.
+	if st.ReplicatedSize != 0 {
+		t.Fatalf("expected replicated size to be left to the data usage, got %d", st.ReplicatedSize)
+	}
+	if st.PendingCount != 1 || st.PendingSize != 10 || st.FailedCount != 1 || st.FailedSize != 20 {
+		t.Fatalf("expected the pending and failed replications to be kept, got %+v", st)
+	}
+	// The latency windows would be stale after a restart.
+	if len(st.Latency.UploadWindows) != 0 {
+		t.Fatalf("expected no latency windows after restart, got %+v", st.Latency.UploadWindows)
+	}
+	// The pending and failed replications are done after the restart.
+	restarted.Update(bucket, arn, 10, 0, replication.Completed, replication.Pending, replication.ObjectReplicationType)
+	restarted.Update(bucket, arn, 20, 0, replication.Completed, replication.Failed, replication.ObjectReplicationType)
+	if st := restarted.Get(bucket).Stats[arn]; st.PendingCount != 0 || st.PendingSize != 0 || st.FailedCount != 0 || st.FailedSize != 0 {
+		t.Fatalf("expected no pending and failed replications, got %+v", st)
+	}
+
+	// Stats since the restart add to the history, each of them once.
+	restarted.UpdateProxyStat(bucket, arn, false)
+	if err = restarted.saveStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if err = restarted.saveStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	again := NewReplicationStats(ctx, obj)
+	if err = again.loadStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if st := again.Get(bucket).Stats[arn]; st.ReplicatedDeleteMarkers != 1 || st.ProxiedCount != 2 || st.PendingCount != 0 || st.FailedCount != 0 {
+		t.Fatalf("unexpected stats after second restart %+v", st)
+	}
+
+	// Nothing is saved before the history is loaded.
+	unloaded := NewReplicationStats(ctx, obj)
+	if err = unloaded.saveStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	again = NewReplicationStats(ctx, obj)
+	if err = again.loadStats(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if st := again.Get(bucket).Stats[arn]; st == nil || st.ProxiedCount != 2 {
+		t.Fatalf("history overwritten before it was loaded %+v", st)
+	}
+}
@@ -62,6 +62,8 @@ const (
 	ObjectLockRetentionTimestamp = "objectlock-retention-timestamp"
 	// ObjectLockLegalHoldTimestamp - the last time a legal hold metadata modification happened on this cluster for this object version
 	ObjectLockLegalHoldTimestamp = "objectlock-legalhold-timestamp"
+	// ReplicaOrigin - the deployment ID of the cluster a replica received by this cluster was written on
+	ReplicaOrigin = "replica-origin"
 )
 
 // gets replication config associated to a given bucket name.
@@ -179,6 +181,10 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 	if mopts.replicationRequest { // incoming replication request on target cluster
 		return
 	}
+	// Objects under prefixes excluded from versioning are not replicated.
+	if !globalBucketVersioningSys.PrefixEnabled(bucket, object) {
+		return
+	}
 	cfg, err := getReplicationConfig(ctx, bucket)
 	if err != nil {
 		return
@@ -204,7 +210,11 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 		if tgt != nil {
 			synchronous = tgt.replicateSync
 		}
-		dsc.Set(newReplicateTargetDecision(tgtArn, replicate, synchronous))
+		d := newReplicateTargetDecision(tgtArn, replicate, synchronous)
+		if synchronous {
+			d.syncAck = cfg.SyncAcknowledgment(opts)
+		}
+		dsc.Set(d)
 	}
 	return dsc
 }
@@ -255,6 +265,10 @@ func checkReplicateDelete(ctx context.Context, bucket string, dobj ObjectToDelet
 	if delOpts.ReplicationRequest {
 		return
 	}
+	// Objects under prefixes excluded from versioning are not replicated.
+	if !globalBucketVersioningSys.PrefixEnabled(bucket, dobj.ObjectName) {
+		return
+	}
 	opts := replication.ObjectOpts{
 		Name:         dobj.ObjectName,
 		SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
@@ -437,6 +451,15 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectReplicationInfo, obj
 			globalReplicationStats.Update(dobj.Bucket, rinfo.Arn, 0, 0, replicationStatus,
 				prevStatus, replication.DeleteReplicationType)
 		}
+		if rinfo.Empty() {
+			continue
+		}
+		if dobj.VersionID == "" {
+			globalReplicationStats.UpdateDeleteStat(dobj.Bucket, rinfo.Arn, rinfo.ReplicationStatus, rinfo.PrevReplicationStatus, false)
+		} else {
+			globalReplicationStats.UpdateDeleteStat(dobj.Bucket, rinfo.Arn, rinfo.VersionPurgeStatus.replicationStatus(),
+				dobj.ReplicationState.PurgeTargets[rinfo.Arn].replicationStatus(), true)
+		}
 	}
 
 	var eventName = event.ObjectReplicationComplete
@@ -448,8 +471,8 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectReplicationInfo, obj
 		VersionID:         versionID,
 		MTime:             dobj.DeleteMarkerMTime.Time,
 		DeleteReplication: drs,
-		Versioned:         globalBucketVersioningSys.Enabled(bucket),
-		VersionSuspended:  globalBucketVersioningSys.Suspended(bucket),
+		Versioned:         globalBucketVersioningSys.PrefixEnabled(bucket, dobj.ObjectName),
+		VersionSuspended:  globalBucketVersioningSys.PrefixSuspended(bucket, dobj.ObjectName),
 	})
 	if err != nil && !isErrVersionNotFound(err) { // VersionNotFound would be reported by pool that object version is missing on.
 		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %s", bucket, dobj.ObjectName, versionID, err))
@@ -568,6 +591,11 @@ func getCopyObjMetadata(oi ObjectInfo, sc string) map[string]string {
 			continue
 		}
 
+		// Skip retention removed from the source version.
+		if equals(k, xhttp.AmzObjectLockMode, xhttp.AmzObjectLockRetainUntilDate) && v == "" {
+			continue
+		}
+
 		// https://github.com/google/security-research/security/advisories/GHSA-76wf-9vgp-pj7w
 		if equals(k, xhttp.AmzMetaUnencryptedContentLength, xhttp.AmzMetaUnencryptedContentMD5) {
 			continue
@@ -622,6 +650,17 @@ func (m caseInsensitiveMap) Lookup(key string) (string, bool) {
 	return "", false
 }
 
+// getReplicationTimestamp returns the time the metadata tracked by the
+// reserved timestamp key was last changed, defaults to the version mtime.
+func getReplicationTimestamp(oi ObjectInfo, key string) time.Time {
+	if v, ok := oi.UserDefined[ReservedMetadataPrefixLower+key]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t
+		}
+	}
+	return oi.ModTime
+}
+
 func putReplicationOpts(ctx context.Context, sc string, objInfo ObjectInfo) (putOpts miniogo.PutObjectOptions, err error) {
 	meta := make(map[string]string)
 	for k, v := range objInfo.UserDefined {
@@ -676,11 +715,12 @@ func putReplicationOpts(ctx context.Context, sc string, objInfo ObjectInfo) (put
 	if cc, ok := lkMap.Lookup(xhttp.CacheControl); ok {
 		putOpts.CacheControl = cc
 	}
-	if mode, ok := lkMap.Lookup(xhttp.AmzObjectLockMode); ok {
+	// Retention removed from the source version is recorded with empty values.
+	if mode, ok := lkMap.Lookup(xhttp.AmzObjectLockMode); ok && mode != "" {
 		rmode := miniogo.RetentionMode(mode)
 		putOpts.Mode = rmode
 	}
-	if retainDateStr, ok := lkMap.Lookup(xhttp.AmzObjectLockRetainUntilDate); ok {
+	if retainDateStr, ok := lkMap.Lookup(xhttp.AmzObjectLockRetainUntilDate); ok && retainDateStr != "" {
 		rdate, err := time.Parse(time.RFC3339, retainDateStr)
 		if err != nil {
 			return putOpts, err
@@ -794,7 +834,7 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo, opType replicati
 			found = true
 			break
 		}
-		if found {
+		if found && v != "" {
 			compareMeta1[strings.ToLower(k)] = v
 		}
 	}
@@ -824,6 +864,16 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo, opType replicati
 // replicateObject replicates the specified version of the object to destination bucket
 // The source object is then updated to reflect the replication status.
 func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer, trigger string) {
+	replicateObjectAck(ctx, ri, objectAPI, trigger, nil)
+}
+
+// replicateObjectAck is replicateObject reporting the outcome of each
+// target to acks as soon as it is known, if acks is not nil.
+func replicateObjectAck(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer, trigger string, acks *replicationAcks) {
+	if acks != nil {
+		defer acks.finish()
+	}
+
 	var replicationStatus replication.StatusType
 	defer func() {
 		if replicationStatus.Empty() {
@@ -896,6 +946,9 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		go func(index int, tgt *TargetClient) {
 			defer wg.Done()
 			rinfos.Targets[index] = replicateObjectToTarget(ctx, ri, objectAPI, tgt)
+			if acks != nil {
+				acks.update(rinfos.Targets[index])
+			}
 		}(i, tgt)
 	}
 	wg.Wait()
@@ -951,13 +1004,16 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		Host:       "Internal: [Replication]",
 	})
 
-	// re-queue failures once more - keep a retry count to avoid flooding the queue if
-	// the target site is down. Leave it to scanner to catch up instead.
-	if rinfos.ReplicationStatus() != replication.Completed && ri.RetryCount < 1 {
-		ri.OpType = replication.HealReplicationType
-		ri.ReplicationStatusInternal = rinfos.ReplicationStatusInternal()
-		ri.RetryCount++
-		globalReplicationPool.queueReplicaFailedTask(ri)
+	// queue failures to be retried with backoff - once the retries to a target
+	// are exhausted, leave it to scanner to catch up instead.
+	if rinfos.ReplicationStatus() != replication.Completed {
+		var failedArns []string
+		for _, rinfo := range rinfos.Targets {
+			if rinfo.ReplicationStatus != replication.Completed {
+				failedArns = append(failedArns, rinfo.Arn)
+			}
+		}
+		globalReplicationPool.queueMRF(ri, failedArns)
 	}
 }
 
@@ -1117,10 +1173,17 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 			Internal: miniogo.AdvancedPutOptions{
 				SourceVersionID:    objInfo.VersionID,
 				ReplicationRequest: true, // always set this to distinguish between `mc mirror` replication and serverside
+				// let the target keep lock metadata and tags changed more recently on it
+				RetentionTimestamp: getReplicationTimestamp(objInfo, ObjectLockRetentionTimestamp),
+				LegalholdTimestamp: getReplicationTimestamp(objInfo, ObjectLockLegalHoldTimestamp),
+				TaggingTimestamp:   getReplicationTimestamp(objInfo, TaggingTimestamp),
 			}}
 		if _, err = c.CopyObject(ctx, tgt.Bucket, object, tgt.Bucket, object, getCopyObjMetadata(objInfo, tgt.StorageClass), srcOpts, dstOpts); err != nil {
 			rinfo.ReplicationStatus = replication.Failed
 			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
+			if cerr == nil && getLockMetadata(objInfo.UserDefined) != getTargetLockMetadata(oi) {
+				globalReplicationStats.UpdateLockSyncFailed(bucket, tgt.ARN)
+			}
 		}
 	} else {
 		var putOpts minio.PutObjectOptions
@@ -1163,6 +1226,9 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		}
+		if rinfo.ReplicationStatus == replication.Failed && getLockMetadata(objInfo.UserDefined) != (lockMetadata{}) {
+			globalReplicationStats.UpdateLockSyncFailed(bucket, tgt.ARN)
+		}
 	}
 	gr.Close()
 	closeOnDefer = false
@@ -1290,6 +1356,7 @@ type ReplicationPool struct {
 	mrfReplicaCh            chan ReplicateObjectInfo
 	existingReplicaCh       chan ReplicateObjectInfo
 	existingReplicaDeleteCh chan DeletedObjectReplicationInfo
+	mrf                     *replicationMRF
 	workerSize              int
 	mrfWorkerSize           int
 	workerWg                sync.WaitGroup
@@ -1308,6 +1375,7 @@ func NewReplicationPool(ctx context.Context, o ObjectLayer, opts replicationPool
 		mrfWorkerKillCh:         make(chan struct{}, opts.FailedWorkers),
 		existingReplicaCh:       make(chan ReplicateObjectInfo, 100000),
 		existingReplicaDeleteCh: make(chan DeletedObjectReplicationInfo, 100000),
+		mrf:                     newReplicationMRF(),
 		ctx:                     ctx,
 		objLayer:                o,
 	}
@@ -1318,6 +1386,29 @@ func NewReplicationPool(ctx context.Context, o ObjectLayer, opts replicationPool
 	return pool
 }
 
+// backlog returns the number of replications and replication retries
+// queued on this server.
+func (p *ReplicationPool) backlog() int {
+	if p == nil {
+		return 0
+	}
+	n := p.queued()
+	p.mrf.Lock()
+	n += len(p.mrf.entries)
+	p.mrf.Unlock()
+	return n
+}
+
+// queued returns the number of replication operations waiting for a
+// worker, the failed operations persisted for a retry are not counted.
+func (p *ReplicationPool) queued() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.replicaCh) + len(p.replicaDeleteCh) + len(p.mrfReplicaCh) +
+		len(p.existingReplicaCh) + len(p.existingReplicaDeleteCh)
+}
+
 // AddMRFWorker adds a pending/failed replication worker to handle requests that could not be queued
 // to the other workers
 func (p *ReplicationPool) AddMRFWorker() {
@@ -1412,9 +1503,11 @@ func (p *ReplicationPool) ResizeFailedWorkers(n int) {
 	}
 }
 
-func (p *ReplicationPool) queueReplicaFailedTask(ri ReplicateObjectInfo) {
+// queueReplicaFailedTask hands ri to the failed replication workers,
+// it returns false if they are busy.
+func (p *ReplicationPool) queueReplicaFailedTask(ri ReplicateObjectInfo) bool {
 	if p == nil {
-		return
+		return false
 	}
 	select {
 	case <-GlobalContext.Done():
@@ -1423,8 +1516,11 @@ func (p *ReplicationPool) queueReplicaFailedTask(ri ReplicateObjectInfo) {
 			close(p.mrfReplicaCh)
 			close(p.existingReplicaCh)
 		})
+		return false
 	case p.mrfReplicaCh <- ri:
+		return true
 	default:
+		return false
 	}
 }
 
@@ -1494,6 +1590,17 @@ type replicationPoolOpts struct {
 	FailedWorkers int
 }
 
+// queueReplicationHeal queues an object version whose replication
+// is pending or has failed to be replicated again.
+func queueReplicationHeal(oi ObjectInfo, rcfg replicationConfig) {
+	roi := getHealReplicateObjectInfo(oi, rcfg)
+	if oi.DeleteMarker || !oi.VersionPurgeStatus.Empty() {
+		healReplicationDeletes(roi)
+		return
+	}
+	globalReplicationPool.queueReplicaTask(roi)
+}
+
 func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 	globalReplicationPool = NewReplicationPool(ctx, objectAPI, replicationPoolOpts{
 		Workers:       globalAPIConfig.getReplicationWorkers(),
@@ -1501,6 +1608,9 @@ func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 	})
 	globalReplicationStats = NewReplicationStats(ctx, objectAPI)
 	go globalReplicationStats.loadInitialReplicationMetrics(ctx)
+	go globalReplicationStats.persistStats(ctx, objectAPI)
+	go globalReplicationPool.processMRF(ctx)
+	go watchTargetsHealth(ctx, objectAPI)
 }
 
 // get Reader from replication target if active-active replication is in place and
@@ -1532,8 +1642,9 @@ func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs
 		return nil, false
 	}
 	c := miniogo.Core{Client: tgt.Client}
-	obj, _, _, err := c.GetObject(ctx, bucket, object, gopts)
+	obj, _, _, err := c.GetObject(ctx, tgt.Bucket, object, gopts)
 	if err != nil {
+		globalReplicationStats.UpdateProxyStat(bucket, tgt.ARN, true)
 		return nil, false
 	}
 	closeReader := func() { obj.Close() }
@@ -1543,6 +1654,7 @@ func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs
 		return nil, false
 	}
 	reader.ObjInfo = oi.Clone()
+	globalReplicationStats.UpdateProxyStat(bucket, tgt.ARN, false)
 	return reader, true
 }
 
@@ -1551,6 +1663,10 @@ func getproxyTargets(ctx context.Context, bucket, object string, opts ObjectOpti
 	if err != nil || cfg == nil {
 		return &madmin.BucketTargets{}
 	}
+	if pcfg, err := globalBucketMetadataSys.GetReplicationProxyConfig(bucket); err == nil && !pcfg.Enabled() {
+		// proxying disabled on the bucket
+		return &madmin.BucketTargets{}
+	}
 	topts := replication.ObjectOpts{Name: object}
 	tgtArns := cfg.FilterTargetArns(topts)
 	tgts = &madmin.BucketTargets{Targets: make([]madmin.BucketTarget, len(tgtArns))}
@@ -1587,6 +1703,9 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 		}
 		objInfo, err := tgt.StatObject(ctx, t.TargetBucket, object, gopts)
 		if err != nil {
+			if miniogo.ToErrorResponse(err).StatusCode != http.StatusNotFound {
+				globalReplicationStats.UpdateProxyStat(bucket, t.Arn, true)
+			}
 			continue
 		}
 
@@ -1625,31 +1744,47 @@ func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts Objec
 // get object info from replication target if active-active replication is in place and
 // this node returns a 404
 func proxyHeadToReplicationTarget(ctx context.Context, bucket, object string, opts ObjectOptions, proxyTargets *madmin.BucketTargets) (oi ObjectInfo, proxy bool) {
-	_, oi, proxy = proxyHeadToRepTarget(ctx, bucket, object, opts, proxyTargets)
+	tgt, oi, proxy := proxyHeadToRepTarget(ctx, bucket, object, opts, proxyTargets)
+	if proxy {
+		globalReplicationStats.UpdateProxyStat(bucket, tgt.ARN, false)
+	}
 	return oi, proxy
 }
 
 func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer, dsc ReplicateDecision, opType replication.Type) {
-	if dsc.Synchronous() {
-		replicateObject(ctx, ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc}, o, ReplicateIncoming)
-	} else {
-		globalReplicationPool.queueReplicaTask(ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc})
-	}
+	scheduleReplicationSync(ctx, objInfo, o, dsc, opType)
+}
+
+// scheduleReplicationSync schedules the replication of an object, it
+// returns the replication status of the object on the synchronous targets
+// whose rules acknowledge writes, empty if there are none. The object is
+// still replicated asynchronously if they did not commit it.
+func scheduleReplicationSync(ctx context.Context, objInfo ObjectInfo, o ObjectLayer, dsc ReplicateDecision, opType replication.Type) (status replication.StatusType) {
+	// Count the replication as pending before it is queued, so that
+	// it cannot be done before being counted as pending.
 	if sz, err := objInfo.GetActualSize(); err == nil {
 		for arn := range dsc.targetsMap {
 			globalReplicationStats.Update(objInfo.Bucket, arn, sz, 0, objInfo.ReplicationStatus, replication.StatusType(""), opType)
 		}
 	}
+	if dsc.Synchronous() {
+		status = replicateObjectSync(ctx, ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc}, o)
+	} else {
+		globalReplicationPool.queueReplicaTask(ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc})
+	}
+	return status
 }
 
 func scheduleReplicationDelete(ctx context.Context, dv DeletedObjectReplicationInfo, o ObjectLayer) {
-	globalReplicationPool.queueReplicaDeleteTask(dv)
 	for arn := range dv.ReplicationState.Targets {
 		globalReplicationStats.Update(dv.Bucket, arn, 0, 0, replication.Pending, replication.StatusType(""), replication.DeleteReplicationType)
+		globalReplicationStats.UpdateDeleteStat(dv.Bucket, arn, replication.Pending, replication.StatusType(""), false)
 	}
 	for arn := range dv.ReplicationState.PurgeTargets {
 		globalReplicationStats.Update(dv.Bucket, arn, 0, 0, replication.Pending, replication.StatusType(""), replication.DeleteReplicationType)
+		globalReplicationStats.UpdateDeleteStat(dv.Bucket, arn, replication.Pending, replication.StatusType(""), true)
 	}
+	globalReplicationPool.queueReplicaDeleteTask(dv)
 }
 
 type replicationConfig struct {
@@ -1785,12 +1920,24 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 			if oldst == nil {
 				oldst = &BucketReplicationStat{}
 			}
-			stats[arn] = &BucketReplicationStat{
-				FailedCount:    stat.FailedCount + oldst.FailedCount,
-				FailedSize:     stat.FailedSize + oldst.FailedSize,
-				ReplicatedSize: stat.ReplicatedSize + oldst.ReplicatedSize,
-				Latency:        stat.Latency.merge(oldst.Latency),
+			st := &BucketReplicationStat{
+				PendingCount:        stat.PendingCount + oldst.PendingCount,
+				PendingSize:         stat.PendingSize + oldst.PendingSize,
+				FailedCount:         stat.FailedCount + oldst.FailedCount,
+				FailedSize:          stat.FailedSize + oldst.FailedSize,
+				ReplicatedSize:      stat.ReplicatedSize + oldst.ReplicatedSize,
+				Latency:             stat.Latency.merge(oldst.Latency),
+				LockSyncFailedCount: stat.LockSyncFailedCount + oldst.LockSyncFailedCount,
 			}
+			st.addDeleteStats(*oldst)
+			st.addDeleteStats(*stat)
+			st.addProxyStats(*oldst)
+			st.addProxyStats(*stat)
+			st.addDriftStats(*oldst)
+			st.addDriftStats(*stat)
+			st.addReplicationTimes(*oldst)
+			st.addReplicationTimes(*stat)
+			stats[arn] = st
 		}
 	}
 
@@ -1802,15 +1949,20 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 			st := stats[arn]
 			if st == nil {
 				st = &BucketReplicationStat{
-					ReplicatedSize: stat.ReplicatedSize,
-					FailedSize:     stat.FailedSize,
-					FailedCount:    stat.FailedCount,
+					ReplicatedSize:      stat.ReplicatedSize,
+					FailedSize:          stat.FailedSize,
+					FailedCount:         stat.FailedCount,
+					LockSyncFailedCount: stat.LockSyncFailedCount,
 				}
 			} else {
 				st.ReplicatedSize += stat.ReplicatedSize
 				st.FailedSize += stat.FailedSize
 				st.FailedCount += stat.FailedCount
+				st.LockSyncFailedCount += stat.LockSyncFailedCount
 			}
+			st.addDeleteStats(*stat)
+			st.addProxyStats(*stat)
+			st.addDriftStats(*stat)
 			stats[arn] = st
 		}
 	}
@@ -1838,11 +1990,42 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 		// happen since data usage picture can lag behind actual usage state at the time of cluster start
 		st.FailedSize = int64(math.Max(float64(tgtstat.FailedSize), 0))
 		st.FailedCount = int64(math.Max(float64(tgtstat.FailedCount), 0))
+		// Pending replications queued before the stats history was
+		// persisted are not counted but their completion is.
+		st.PendingSize = int64(math.Max(float64(tgtstat.PendingSize), 0))
+		st.PendingCount = int64(math.Max(float64(tgtstat.PendingCount), 0))
 		st.Latency = tgtstat.Latency
+		st.LockSyncFailedCount = tgtstat.LockSyncFailedCount
+		// Pending and failed deletes picked up again after a restart
+		// were not counted when first queued.
+		st.ReplicatedDeleteMarkers = tgtstat.ReplicatedDeleteMarkers
+		st.PendingDeleteMarkers = int64(math.Max(float64(tgtstat.PendingDeleteMarkers), 0))
+		st.FailedDeleteMarkers = int64(math.Max(float64(tgtstat.FailedDeleteMarkers), 0))
+		st.ReplicatedVersionPurges = tgtstat.ReplicatedVersionPurges
+		st.PendingVersionPurges = int64(math.Max(float64(tgtstat.PendingVersionPurges), 0))
+		st.FailedVersionPurges = int64(math.Max(float64(tgtstat.FailedVersionPurges), 0))
+		st.ProxiedCount = tgtstat.ProxiedCount
+		st.ProxyFailedCount = tgtstat.ProxyFailedCount
+		st.DriftMissingCount = tgtstat.DriftMissingCount
+		st.DriftVersionMismatchCount = tgtstat.DriftVersionMismatchCount
+		st.DriftETagMismatchCount = tgtstat.DriftETagMismatchCount
+		st.DriftTagMismatchCount = tgtstat.DriftTagMismatchCount
+		st.LastReplicatedAt = tgtstat.LastReplicatedAt
+		st.OldestPendingSince = tgtstat.OldestPendingSince
 
 		s.Stats[arn] = &st
 		s.FailedSize += st.FailedSize
 		s.FailedCount += st.FailedCount
+		s.PendingSize += st.PendingSize
+		s.PendingCount += st.PendingCount
+		s.LockSyncFailedCount += st.LockSyncFailedCount
+		s.ReplicatedDeleteMarkers += st.ReplicatedDeleteMarkers
+		s.PendingDeleteMarkers += st.PendingDeleteMarkers
+		s.FailedDeleteMarkers += st.FailedDeleteMarkers
+		s.ReplicatedVersionPurges += st.ReplicatedVersionPurges
+		s.PendingVersionPurges += st.PendingVersionPurges
+		s.FailedVersionPurges += st.FailedVersionPurges
+		s.DriftCount += st.DriftMissingCount + st.DriftVersionMismatchCount + st.DriftETagMismatchCount + st.DriftTagMismatchCount
 	}
 	// normalize overall stats
 	s.ReplicaSize = int64(math.Max(float64(totReplicaSize), float64(u.ReplicaSize)))
@@ -18,31 +18,46 @@
 package cmd
 
 import (
+	"strconv"
 	"sync/atomic"
 	"time"
 )
 
 //go:generate msgp -file $GOFILE
 
+// replicationLatencyWindows are the durations of the windows of
+// replication latencies kept in addition to the last minute.
+var replicationLatencyWindows = []time.Duration{5 * time.Minute, time.Hour}
+
 // ReplicationLatency holds information of bucket operations latency, such us uploads
 type ReplicationLatency struct {
 	// Single & Multipart PUTs latency
 	UploadHistogram LastMinuteLatencies
+	// Single & Multipart PUTs latency over each of the
+	// replicationLatencyWindows
+	UploadWindows []RollingLatencies
 }
 
 // Merge two replication latency into a new one
 func (rl ReplicationLatency) merge(other ReplicationLatency) (newReplLatency ReplicationLatency) {
 	newReplLatency.UploadHistogram = rl.UploadHistogram.Merge(other.UploadHistogram)
+	newReplLatency.UploadWindows = mergeLatencyWindows(rl.UploadWindows, other.UploadWindows)
 	return
 }
 
 // Get upload latency of each object size range
 func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
-	ret = make(map[string]uint64)
-	avg := rl.UploadHistogram.GetAvg()
-	for k, v := range avg {
-		// Convert nanoseconds to milliseconds
-		ret[sizeTagToString(k)] = v.avg() / uint64(time.Millisecond)
+	return latencyAvgToMillis(rl.UploadHistogram.GetAvg())
+}
+
+// Get upload latency of each object size range over each window,
+// including the last minute, by window duration.
+func (rl ReplicationLatency) getUploadLatencies() (ret map[string]map[string]uint64) {
+	ret = make(map[string]map[string]uint64, len(rl.UploadWindows)+1)
+	ret[latencyWindowLabel(time.Minute)] = rl.getUploadLatency()
+	for i := range rl.UploadWindows {
+		w := rl.UploadWindows[i].Clone()
+		ret[latencyWindowLabel(w.Duration())] = latencyAvgToMillis(w.GetAvg())
 	}
 	return
 }
@@ -50,13 +65,71 @@ func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
 // Update replication upload latency with a new value
 func (rl *ReplicationLatency) update(size int64, duration time.Duration) {
 	rl.UploadHistogram.Add(size, duration)
+	if len(rl.UploadWindows) == 0 {
+		for _, d := range replicationLatencyWindows {
+			rl.UploadWindows = append(rl.UploadWindows, newRollingLatencies(d))
+		}
+	}
+	for i := range rl.UploadWindows {
+		rl.UploadWindows[i].Add(size, duration)
+	}
 }
 
 // Clone replication latency
 func (rl ReplicationLatency) clone() ReplicationLatency {
-	return ReplicationLatency{
+	c := ReplicationLatency{
 		UploadHistogram: rl.UploadHistogram.Clone(),
 	}
+	for i := range rl.UploadWindows {
+		c.UploadWindows = append(c.UploadWindows, rl.UploadWindows[i].Clone())
+	}
+	return c
+}
+
+// mergeLatencyWindows merges the windows of the same duration of a and b,
+// the windows only found in one of them are copied.
+func mergeLatencyWindows(a, b []RollingLatencies) (merged []RollingLatencies) {
+	for i := range a {
+		merged = append(merged, a[i].Clone())
+	}
+	for i := range b {
+		found := false
+		for j := range merged {
+			if merged[j].Slot == b[i].Slot {
+				merged[j] = merged[j].Merge(b[i])
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, b[i].Clone())
+		}
+	}
+	return merged
+}
+
+// latencyAvgToMillis returns the average latency in milliseconds of
+// each object size range.
+func latencyAvgToMillis(avg [sizeLastElemMarker]AccElem) map[string]uint64 {
+	ret := make(map[string]uint64)
+	for k, v := range avg {
+		// Convert nanoseconds to milliseconds
+		ret[sizeTagToString(k)] = v.avg() / uint64(time.Millisecond)
+	}
+	return ret
+}
+
+// latencyWindowLabel returns the short form of the window duration d,
+// such as 5m or 1h.
+func latencyWindowLabel(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	default:
+		return d.String()
+	}
 }
 
 // BucketStats bucket statistics
@@ -80,6 +153,20 @@ type BucketReplicationStats struct {
 	PendingCount int64 `json:"pendingReplicationCount"`
 	// Total number of failed operations including metadata updates
 	FailedCount int64 `json:"failedReplicationCount"`
+	// Total number of failed object lock metadata syncs
+	LockSyncFailedCount int64 `json:"lockSyncFailedCount"`
+	// Delete marker replications completed, pending and failed
+	ReplicatedDeleteMarkers int64 `json:"replicatedDeleteMarkers"`
+	PendingDeleteMarkers    int64 `json:"pendingDeleteMarkers"`
+	FailedDeleteMarkers     int64 `json:"failedDeleteMarkers"`
+	// Permanent delete replications of object versions completed,
+	// pending and failed
+	ReplicatedVersionPurges int64 `json:"replicatedVersionPurges"`
+	PendingVersionPurges    int64 `json:"pendingVersionPurges"`
+	FailedVersionPurges     int64 `json:"failedVersionPurges"`
+	// Total number of object versions found out of sync with
+	// a remote target by the replication consistency check
+	DriftCount int64 `json:"driftCount"`
 }
 
 // Empty returns true if there are no target stats
@@ -95,13 +182,31 @@ func (brs BucketReplicationStats) Clone() BucketReplicationStats {
 	// This is called only by replicationStats cache and already holds a read lock before calling Clone()
 	for arn, st := range brs.Stats {
 		c.Stats[arn] = &BucketReplicationStat{
-			FailedSize:     atomic.LoadInt64(&st.FailedSize),
-			ReplicatedSize: atomic.LoadInt64(&st.ReplicatedSize),
-			ReplicaSize:    atomic.LoadInt64(&st.ReplicaSize),
-			FailedCount:    atomic.LoadInt64(&st.FailedCount),
-			PendingSize:    atomic.LoadInt64(&st.PendingSize),
-			PendingCount:   atomic.LoadInt64(&st.PendingCount),
-			Latency:        st.Latency.clone(),
+			FailedSize:          atomic.LoadInt64(&st.FailedSize),
+			ReplicatedSize:      atomic.LoadInt64(&st.ReplicatedSize),
+			ReplicaSize:         atomic.LoadInt64(&st.ReplicaSize),
+			FailedCount:         atomic.LoadInt64(&st.FailedCount),
+			PendingSize:         atomic.LoadInt64(&st.PendingSize),
+			PendingCount:        atomic.LoadInt64(&st.PendingCount),
+			Latency:             st.Latency.clone(),
+			LockSyncFailedCount: atomic.LoadInt64(&st.LockSyncFailedCount),
+
+			ReplicatedDeleteMarkers: atomic.LoadInt64(&st.ReplicatedDeleteMarkers),
+			PendingDeleteMarkers:    atomic.LoadInt64(&st.PendingDeleteMarkers),
+			FailedDeleteMarkers:     atomic.LoadInt64(&st.FailedDeleteMarkers),
+			ReplicatedVersionPurges: atomic.LoadInt64(&st.ReplicatedVersionPurges),
+			PendingVersionPurges:    atomic.LoadInt64(&st.PendingVersionPurges),
+			FailedVersionPurges:     atomic.LoadInt64(&st.FailedVersionPurges),
+			ProxiedCount:            atomic.LoadInt64(&st.ProxiedCount),
+			ProxyFailedCount:        atomic.LoadInt64(&st.ProxyFailedCount),
+
+			DriftMissingCount:         atomic.LoadInt64(&st.DriftMissingCount),
+			DriftVersionMismatchCount: atomic.LoadInt64(&st.DriftVersionMismatchCount),
+			DriftETagMismatchCount:    atomic.LoadInt64(&st.DriftETagMismatchCount),
+			DriftTagMismatchCount:     atomic.LoadInt64(&st.DriftTagMismatchCount),
+
+			LastReplicatedAt:   st.LastReplicatedAt,
+			OldestPendingSince: st.OldestPendingSince,
 		}
 	}
 	// update total counts across targets
@@ -111,6 +216,14 @@ func (brs BucketReplicationStats) Clone() BucketReplicationStats {
 	c.PendingSize = atomic.LoadInt64(&brs.PendingSize)
 	c.ReplicaSize = atomic.LoadInt64(&brs.ReplicaSize)
 	c.ReplicatedSize = atomic.LoadInt64(&brs.ReplicatedSize)
+	c.LockSyncFailedCount = atomic.LoadInt64(&brs.LockSyncFailedCount)
+	c.ReplicatedDeleteMarkers = atomic.LoadInt64(&brs.ReplicatedDeleteMarkers)
+	c.PendingDeleteMarkers = atomic.LoadInt64(&brs.PendingDeleteMarkers)
+	c.FailedDeleteMarkers = atomic.LoadInt64(&brs.FailedDeleteMarkers)
+	c.ReplicatedVersionPurges = atomic.LoadInt64(&brs.ReplicatedVersionPurges)
+	c.PendingVersionPurges = atomic.LoadInt64(&brs.PendingVersionPurges)
+	c.FailedVersionPurges = atomic.LoadInt64(&brs.FailedVersionPurges)
+	c.DriftCount = atomic.LoadInt64(&brs.DriftCount)
 	return c
 }
 
@@ -130,8 +243,167 @@ type BucketReplicationStat struct {
 	PendingCount int64 `json:"pendingReplicationCount"`
 	// Total number of failed operations including metadata updates
 	FailedCount int64 `json:"failedReplicationCount"`
+	// Total number of failed object lock metadata syncs
+	LockSyncFailedCount int64 `json:"lockSyncFailedCount"`
+	// Delete marker replications completed, pending and failed
+	ReplicatedDeleteMarkers int64 `json:"replicatedDeleteMarkers"`
+	PendingDeleteMarkers    int64 `json:"pendingDeleteMarkers"`
+	FailedDeleteMarkers     int64 `json:"failedDeleteMarkers"`
+	// Permanent delete replications of object versions completed,
+	// pending and failed
+	ReplicatedVersionPurges int64 `json:"replicatedVersionPurges"`
+	PendingVersionPurges    int64 `json:"pendingVersionPurges"`
+	FailedVersionPurges     int64 `json:"failedVersionPurges"`
+	// Reads of objects not replicated locally yet which were proxied
+	// to the target, and which failed on the target
+	ProxiedCount     int64 `json:"proxiedCount"`
+	ProxyFailedCount int64 `json:"proxyFailedCount"`
+	// Object versions found by the replication consistency check to
+	// be missing on the target, present with a different version id,
+	// or present with a different ETag or different tags
+	DriftMissingCount         int64 `json:"driftMissingCount"`
+	DriftVersionMismatchCount int64 `json:"driftVersionMismatchCount"`
+	DriftETagMismatchCount    int64 `json:"driftETagMismatchCount"`
+	DriftTagMismatchCount     int64 `json:"driftTagMismatchCount"`
+	// Time of the last successful replication to the target, and time
+	// since when the oldest replication still pending on this node was
+	// queued, zero when none is pending
+	LastReplicatedAt   time.Time `json:"lastReplicatedAt"`
+	OldestPendingSince time.Time `json:"oldestPendingSince"`
 	// Replication latency information
 	Latency ReplicationLatency `json:"replicationLatency"`
+
+	// Replications queued and not done yet, only tracked in memory
+	pending replicationPendingQueue
+}
+
+// merge adds the counters of o to brs, this is used to combine the
+// stats of several nodes, or the persisted history of a node with the
+// stats accumulated since startup.
+func (brs *BucketReplicationStats) merge(o BucketReplicationStats) {
+	if brs.Stats == nil {
+		brs.Stats = make(map[string]*BucketReplicationStat, len(o.Stats))
+	}
+	for arn, ost := range o.Stats {
+		st, ok := brs.Stats[arn]
+		if !ok {
+			st = &BucketReplicationStat{}
+			brs.Stats[arn] = st
+		}
+		st.PendingSize += ost.PendingSize
+		st.ReplicatedSize += ost.ReplicatedSize
+		st.ReplicaSize += ost.ReplicaSize
+		st.FailedSize += ost.FailedSize
+		st.PendingCount += ost.PendingCount
+		st.FailedCount += ost.FailedCount
+		st.LockSyncFailedCount += ost.LockSyncFailedCount
+		st.addDeleteStats(*ost)
+		st.addProxyStats(*ost)
+		st.addDriftStats(*ost)
+		// Replications pending before a restart are queued again,
+		// the persisted oldest pending time is stale.
+		if ost.LastReplicatedAt.After(st.LastReplicatedAt) {
+			st.LastReplicatedAt = ost.LastReplicatedAt
+		}
+		st.Latency = st.Latency.merge(ost.Latency)
+	}
+	brs.PendingSize += o.PendingSize
+	brs.ReplicatedSize += o.ReplicatedSize
+	brs.ReplicaSize += o.ReplicaSize
+	brs.FailedSize += o.FailedSize
+	brs.PendingCount += o.PendingCount
+	brs.FailedCount += o.FailedCount
+	brs.LockSyncFailedCount += o.LockSyncFailedCount
+	brs.ReplicatedDeleteMarkers += o.ReplicatedDeleteMarkers
+	brs.PendingDeleteMarkers += o.PendingDeleteMarkers
+	brs.FailedDeleteMarkers += o.FailedDeleteMarkers
+	brs.ReplicatedVersionPurges += o.ReplicatedVersionPurges
+	brs.PendingVersionPurges += o.PendingVersionPurges
+	brs.FailedVersionPurges += o.FailedVersionPurges
+	brs.DriftCount += o.DriftCount
+}
+
+// history returns a copy of the counters of brs which are not
+// recomputed after a restart. Replicated and replica sizes are accounted
+// by the data usage scanner, and the latency windows would be stale,
+// so they are left out. Pending replications are queued again after a
+// restart and their completion is deducted from the persisted counters.
+func (brs BucketReplicationStats) history() BucketReplicationStats {
+	c := brs.Clone()
+	h := BucketReplicationStats{
+		Stats:                   make(map[string]*BucketReplicationStat, len(c.Stats)),
+		PendingSize:             c.PendingSize,
+		PendingCount:            c.PendingCount,
+		FailedSize:              c.FailedSize,
+		FailedCount:             c.FailedCount,
+		LockSyncFailedCount:     c.LockSyncFailedCount,
+		ReplicatedDeleteMarkers: c.ReplicatedDeleteMarkers,
+		ReplicatedVersionPurges: c.ReplicatedVersionPurges,
+		DriftCount:              c.DriftCount,
+	}
+	for arn, st := range c.Stats {
+		h.Stats[arn] = &BucketReplicationStat{
+			PendingSize:               st.PendingSize,
+			PendingCount:              st.PendingCount,
+			FailedSize:                st.FailedSize,
+			FailedCount:               st.FailedCount,
+			LockSyncFailedCount:       st.LockSyncFailedCount,
+			ReplicatedDeleteMarkers:   st.ReplicatedDeleteMarkers,
+			ReplicatedVersionPurges:   st.ReplicatedVersionPurges,
+			ProxiedCount:              st.ProxiedCount,
+			ProxyFailedCount:          st.ProxyFailedCount,
+			DriftMissingCount:         st.DriftMissingCount,
+			DriftVersionMismatchCount: st.DriftVersionMismatchCount,
+			DriftETagMismatchCount:    st.DriftETagMismatchCount,
+			DriftTagMismatchCount:     st.DriftTagMismatchCount,
+			LastReplicatedAt:          st.LastReplicatedAt,
+		}
+	}
+	return h
+}
+
+// addDeleteStats adds the delete replication counters of o to bs.
+func (bs *BucketReplicationStat) addDeleteStats(o BucketReplicationStat) {
+	bs.ReplicatedDeleteMarkers += o.ReplicatedDeleteMarkers
+	bs.PendingDeleteMarkers += o.PendingDeleteMarkers
+	bs.FailedDeleteMarkers += o.FailedDeleteMarkers
+	bs.ReplicatedVersionPurges += o.ReplicatedVersionPurges
+	bs.PendingVersionPurges += o.PendingVersionPurges
+	bs.FailedVersionPurges += o.FailedVersionPurges
+}
+
+// addProxyStats adds the proxied read counters of o to bs.
+func (bs *BucketReplicationStat) addProxyStats(o BucketReplicationStat) {
+	bs.ProxiedCount += o.ProxiedCount
+	bs.ProxyFailedCount += o.ProxyFailedCount
+}
+
+// addDriftStats adds the replication consistency check counters of o to bs.
+func (bs *BucketReplicationStat) addDriftStats(o BucketReplicationStat) {
+	bs.DriftMissingCount += o.DriftMissingCount
+	bs.DriftVersionMismatchCount += o.DriftVersionMismatchCount
+	bs.DriftETagMismatchCount += o.DriftETagMismatchCount
+	bs.DriftTagMismatchCount += o.DriftTagMismatchCount
+}
+
+// addReplicationTimes combines the last replication and oldest pending
+// times of o with those of bs, keeping the latest and oldest of them.
+func (bs *BucketReplicationStat) addReplicationTimes(o BucketReplicationStat) {
+	if o.LastReplicatedAt.After(bs.LastReplicatedAt) {
+		bs.LastReplicatedAt = o.LastReplicatedAt
+	}
+	if !o.OldestPendingSince.IsZero() && (bs.OldestPendingSince.IsZero() || o.OldestPendingSince.Before(bs.OldestPendingSince)) {
+		bs.OldestPendingSince = o.OldestPendingSince
+	}
+}
+
+// ReplicationStatsSnapshot is a point in time copy of the replication
+// stats history of a node, persisted so that it survives restarts.
+type ReplicationStatsSnapshot struct {
+	// Stats of each bucket by bucket name
+	Buckets map[string]BucketReplicationStats
+	// Time when this snapshot was taken
+	Saved time.Time
 }
 
 func (bs *BucketReplicationStat) hasReplicationUsage() bool {
@@ -140,5 +412,20 @@ func (bs *BucketReplicationStat) hasReplicationUsage() bool {
 		bs.ReplicaSize > 0 ||
 		bs.FailedCount > 0 ||
 		bs.PendingCount > 0 ||
-		bs.PendingSize > 0
+		bs.PendingSize > 0 ||
+		bs.LockSyncFailedCount > 0 ||
+		bs.ReplicatedDeleteMarkers > 0 ||
+		bs.PendingDeleteMarkers > 0 ||
+		bs.FailedDeleteMarkers > 0 ||
+		bs.ReplicatedVersionPurges > 0 ||
+		bs.PendingVersionPurges > 0 ||
+		bs.FailedVersionPurges > 0 ||
+		bs.ProxiedCount > 0 ||
+		bs.ProxyFailedCount > 0 ||
+		bs.DriftMissingCount > 0 ||
+		bs.DriftVersionMismatchCount > 0 ||
+		bs.DriftETagMismatchCount > 0 ||
+		bs.DriftTagMismatchCount > 0 ||
+		!bs.LastReplicatedAt.IsZero() ||
+		!bs.OldestPendingSince.IsZero()
 }
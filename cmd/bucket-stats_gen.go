@@ -60,6 +60,96 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "FailedCount")
 				return
 			}
+		case "LockSyncFailedCount":
+			z.LockSyncFailedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "LockSyncFailedCount")
+				return
+			}
+		case "ReplicatedDeleteMarkers":
+			z.ReplicatedDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+				return
+			}
+		case "PendingDeleteMarkers":
+			z.PendingDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingDeleteMarkers")
+				return
+			}
+		case "FailedDeleteMarkers":
+			z.FailedDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "FailedDeleteMarkers")
+				return
+			}
+		case "ReplicatedVersionPurges":
+			z.ReplicatedVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedVersionPurges")
+				return
+			}
+		case "PendingVersionPurges":
+			z.PendingVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingVersionPurges")
+				return
+			}
+		case "FailedVersionPurges":
+			z.FailedVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "FailedVersionPurges")
+				return
+			}
+		case "ProxiedCount":
+			z.ProxiedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ProxiedCount")
+				return
+			}
+		case "ProxyFailedCount":
+			z.ProxyFailedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ProxyFailedCount")
+				return
+			}
+		case "DriftMissingCount":
+			z.DriftMissingCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "DriftMissingCount")
+				return
+			}
+		case "DriftVersionMismatchCount":
+			z.DriftVersionMismatchCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "DriftVersionMismatchCount")
+				return
+			}
+		case "DriftETagMismatchCount":
+			z.DriftETagMismatchCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "DriftETagMismatchCount")
+				return
+			}
+		case "DriftTagMismatchCount":
+			z.DriftTagMismatchCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "DriftTagMismatchCount")
+				return
+			}
+		case "LastReplicatedAt":
+			z.LastReplicatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicatedAt")
+				return
+			}
+		case "OldestPendingSince":
+			z.OldestPendingSince, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "OldestPendingSince")
+				return
+			}
 		case "Latency":
 			var zb0002 uint32
 			zb0002, err = dc.ReadMapHeader()
@@ -81,6 +171,25 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 						err = msgp.WrapError(err, "Latency", "UploadHistogram")
 						return
 					}
+				case "UploadWindows":
+					var zb0003 uint32
+					zb0003, err = dc.ReadArrayHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Latency", "UploadWindows")
+						return
+					}
+					if cap(z.Latency.UploadWindows) >= int(zb0003) {
+						z.Latency.UploadWindows = (z.Latency.UploadWindows)[:zb0003]
+					} else {
+						z.Latency.UploadWindows = make([]RollingLatencies, zb0003)
+					}
+					for za0001 := range z.Latency.UploadWindows {
+						err = z.Latency.UploadWindows[za0001].DecodeMsg(dc)
+						if err != nil {
+							err = msgp.WrapError(err, "Latency", "UploadWindows", za0001)
+							return
+						}
+					}
 				default:
 					err = dc.Skip()
 					if err != nil {
@@ -102,9 +211,9 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 22
 	// write "PendingSize"
-	err = en.Append(0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	err = en.Append(0xde, 0x0, 0x16, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	if err != nil {
 		return
 	}
@@ -163,14 +272,164 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "FailedCount")
 		return
 	}
+	// write "LockSyncFailedCount"
+	err = en.Append(0xb3, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.LockSyncFailedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "LockSyncFailedCount")
+		return
+	}
+	// write "ReplicatedDeleteMarkers"
+	err = en.Append(0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ReplicatedDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+		return
+	}
+	// write "PendingDeleteMarkers"
+	err = en.Append(0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.PendingDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "PendingDeleteMarkers")
+		return
+	}
+	// write "FailedDeleteMarkers"
+	err = en.Append(0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.FailedDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "FailedDeleteMarkers")
+		return
+	}
+	// write "ReplicatedVersionPurges"
+	err = en.Append(0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ReplicatedVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicatedVersionPurges")
+		return
+	}
+	// write "PendingVersionPurges"
+	err = en.Append(0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.PendingVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "PendingVersionPurges")
+		return
+	}
+	// write "FailedVersionPurges"
+	err = en.Append(0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.FailedVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "FailedVersionPurges")
+		return
+	}
+	// write "ProxiedCount"
+	err = en.Append(0xac, 0x50, 0x72, 0x6f, 0x78, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ProxiedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "ProxiedCount")
+		return
+	}
+	// write "ProxyFailedCount"
+	err = en.Append(0xb0, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ProxyFailedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "ProxyFailedCount")
+		return
+	}
+	// write "DriftMissingCount"
+	err = en.Append(0xb1, 0x44, 0x72, 0x69, 0x66, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.DriftMissingCount)
+	if err != nil {
+		err = msgp.WrapError(err, "DriftMissingCount")
+		return
+	}
+	// write "DriftVersionMismatchCount"
+	err = en.Append(0xb9, 0x44, 0x72, 0x69, 0x66, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.DriftVersionMismatchCount)
+	if err != nil {
+		err = msgp.WrapError(err, "DriftVersionMismatchCount")
+		return
+	}
+	// write "DriftETagMismatchCount"
+	err = en.Append(0xb6, 0x44, 0x72, 0x69, 0x66, 0x74, 0x45, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.DriftETagMismatchCount)
+	if err != nil {
+		err = msgp.WrapError(err, "DriftETagMismatchCount")
+		return
+	}
+	// write "DriftTagMismatchCount"
+	err = en.Append(0xb5, 0x44, 0x72, 0x69, 0x66, 0x74, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.DriftTagMismatchCount)
+	if err != nil {
+		err = msgp.WrapError(err, "DriftTagMismatchCount")
+		return
+	}
+	// write "LastReplicatedAt"
+	err = en.Append(0xb0, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.LastReplicatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "LastReplicatedAt")
+		return
+	}
+	// write "OldestPendingSince"
+	err = en.Append(0xb2, 0x4f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x6e, 0x63, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.OldestPendingSince)
+	if err != nil {
+		err = msgp.WrapError(err, "OldestPendingSince")
+		return
+	}
 	// write "Latency"
 	err = en.Append(0xa7, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
 	if err != nil {
 		return
 	}
-	// map header, size 1
+	// map header, size 2
 	// write "UploadHistogram"
-	err = en.Append(0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	err = en.Append(0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	if err != nil {
 		return
 	}
@@ -179,15 +438,32 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// write "UploadWindows"
+	err = en.Append(0xad, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Latency.UploadWindows)))
+	if err != nil {
+		err = msgp.WrapError(err, "Latency", "UploadWindows")
+		return
+	}
+	for za0001 := range z.Latency.UploadWindows {
+		err = z.Latency.UploadWindows[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", "UploadWindows", za0001)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 22
 	// string "PendingSize"
-	o = append(o, 0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	o = append(o, 0xde, 0x0, 0x16, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.PendingSize)
 	// string "ReplicatedSize"
 	o = append(o, 0xae, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65)
@@ -204,16 +480,71 @@ func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "FailedCount"
 	o = append(o, 0xab, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 	o = msgp.AppendInt64(o, z.FailedCount)
+	// string "LockSyncFailedCount"
+	o = append(o, 0xb3, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.LockSyncFailedCount)
+	// string "ReplicatedDeleteMarkers"
+	o = append(o, 0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.ReplicatedDeleteMarkers)
+	// string "PendingDeleteMarkers"
+	o = append(o, 0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.PendingDeleteMarkers)
+	// string "FailedDeleteMarkers"
+	o = append(o, 0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.FailedDeleteMarkers)
+	// string "ReplicatedVersionPurges"
+	o = append(o, 0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.ReplicatedVersionPurges)
+	// string "PendingVersionPurges"
+	o = append(o, 0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.PendingVersionPurges)
+	// string "FailedVersionPurges"
+	o = append(o, 0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.FailedVersionPurges)
+	// string "ProxiedCount"
+	o = append(o, 0xac, 0x50, 0x72, 0x6f, 0x78, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.ProxiedCount)
+	// string "ProxyFailedCount"
+	o = append(o, 0xb0, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.ProxyFailedCount)
+	// string "DriftMissingCount"
+	o = append(o, 0xb1, 0x44, 0x72, 0x69, 0x66, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.DriftMissingCount)
+	// string "DriftVersionMismatchCount"
+	o = append(o, 0xb9, 0x44, 0x72, 0x69, 0x66, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.DriftVersionMismatchCount)
+	// string "DriftETagMismatchCount"
+	o = append(o, 0xb6, 0x44, 0x72, 0x69, 0x66, 0x74, 0x45, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.DriftETagMismatchCount)
+	// string "DriftTagMismatchCount"
+	o = append(o, 0xb5, 0x44, 0x72, 0x69, 0x66, 0x74, 0x54, 0x61, 0x67, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.DriftTagMismatchCount)
+	// string "LastReplicatedAt"
+	o = append(o, 0xb0, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.LastReplicatedAt)
+	// string "OldestPendingSince"
+	o = append(o, 0xb2, 0x4f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x6e, 0x63, 0x65)
+	o = msgp.AppendTime(o, z.OldestPendingSince)
 	// string "Latency"
 	o = append(o, 0xa7, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
-	// map header, size 1
+	// map header, size 2
 	// string "UploadHistogram"
-	o = append(o, 0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = append(o, 0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	o, err = z.Latency.UploadHistogram.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// string "UploadWindows"
+	o = append(o, 0xad, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Latency.UploadWindows)))
+	for za0001 := range z.Latency.UploadWindows {
+		o, err = z.Latency.UploadWindows[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", "UploadWindows", za0001)
+			return
+		}
+	}
 	return
 }
 
@@ -271,6 +602,96 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "FailedCount")
 				return
 			}
+		case "LockSyncFailedCount":
+			z.LockSyncFailedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LockSyncFailedCount")
+				return
+			}
+		case "ReplicatedDeleteMarkers":
+			z.ReplicatedDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+				return
+			}
+		case "PendingDeleteMarkers":
+			z.PendingDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingDeleteMarkers")
+				return
+			}
+		case "FailedDeleteMarkers":
+			z.FailedDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FailedDeleteMarkers")
+				return
+			}
+		case "ReplicatedVersionPurges":
+			z.ReplicatedVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedVersionPurges")
+				return
+			}
+		case "PendingVersionPurges":
+			z.PendingVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingVersionPurges")
+				return
+			}
+		case "FailedVersionPurges":
+			z.FailedVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FailedVersionPurges")
+				return
+			}
+		case "ProxiedCount":
+			z.ProxiedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProxiedCount")
+				return
+			}
+		case "ProxyFailedCount":
+			z.ProxyFailedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProxyFailedCount")
+				return
+			}
+		case "DriftMissingCount":
+			z.DriftMissingCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DriftMissingCount")
+				return
+			}
+		case "DriftVersionMismatchCount":
+			z.DriftVersionMismatchCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DriftVersionMismatchCount")
+				return
+			}
+		case "DriftETagMismatchCount":
+			z.DriftETagMismatchCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DriftETagMismatchCount")
+				return
+			}
+		case "DriftTagMismatchCount":
+			z.DriftTagMismatchCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DriftTagMismatchCount")
+				return
+			}
+		case "LastReplicatedAt":
+			z.LastReplicatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicatedAt")
+				return
+			}
+		case "OldestPendingSince":
+			z.OldestPendingSince, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OldestPendingSince")
+				return
+			}
 		case "Latency":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
@@ -292,6 +713,25 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 						err = msgp.WrapError(err, "Latency", "UploadHistogram")
 						return
 					}
+				case "UploadWindows":
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Latency", "UploadWindows")
+						return
+					}
+					if cap(z.Latency.UploadWindows) >= int(zb0003) {
+						z.Latency.UploadWindows = (z.Latency.UploadWindows)[:zb0003]
+					} else {
+						z.Latency.UploadWindows = make([]RollingLatencies, zb0003)
+					}
+					for za0001 := range z.Latency.UploadWindows {
+						bts, err = z.Latency.UploadWindows[za0001].UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Latency", "UploadWindows", za0001)
+							return
+						}
+					}
 				default:
 					bts, err = msgp.Skip(bts)
 					if err != nil {
@@ -314,7 +754,10 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketReplicationStat) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize()
+	s = 3 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 20 + msgp.Int64Size + 24 + msgp.Int64Size + 21 + msgp.Int64Size + 20 + msgp.Int64Size + 24 + msgp.Int64Size + 21 + msgp.Int64Size + 20 + msgp.Int64Size + 13 + msgp.Int64Size + 17 + msgp.Int64Size + 18 + msgp.Int64Size + 26 + msgp.Int64Size + 23 + msgp.Int64Size + 22 + msgp.Int64Size + 17 + msgp.TimeSize + 19 + msgp.TimeSize + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize() + 14 + msgp.ArrayHeaderSize
+	for za0001 := range z.Latency.UploadWindows {
+		s += z.Latency.UploadWindows[za0001].Msgsize()
+	}
 	return
 }
 
@@ -414,6 +857,54 @@ func (z *BucketReplicationStats) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "FailedCount")
 				return
 			}
+		case "LockSyncFailedCount":
+			z.LockSyncFailedCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "LockSyncFailedCount")
+				return
+			}
+		case "ReplicatedDeleteMarkers":
+			z.ReplicatedDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+				return
+			}
+		case "PendingDeleteMarkers":
+			z.PendingDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingDeleteMarkers")
+				return
+			}
+		case "FailedDeleteMarkers":
+			z.FailedDeleteMarkers, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "FailedDeleteMarkers")
+				return
+			}
+		case "ReplicatedVersionPurges":
+			z.ReplicatedVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedVersionPurges")
+				return
+			}
+		case "PendingVersionPurges":
+			z.PendingVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "PendingVersionPurges")
+				return
+			}
+		case "FailedVersionPurges":
+			z.FailedVersionPurges, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "FailedVersionPurges")
+				return
+			}
+		case "DriftCount":
+			z.DriftCount, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "DriftCount")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -427,9 +918,9 @@ func (z *BucketReplicationStats) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketReplicationStats) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 15
 	// write "Stats"
-	err = en.Append(0x87, 0xa5, 0x53, 0x74, 0x61, 0x74, 0x73)
+	err = en.Append(0x8f, 0xa5, 0x53, 0x74, 0x61, 0x74, 0x73)
 	if err != nil {
 		return
 	}
@@ -517,15 +1008,95 @@ func (z *BucketReplicationStats) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "FailedCount")
 		return
 	}
+	// write "LockSyncFailedCount"
+	err = en.Append(0xb3, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.LockSyncFailedCount)
+	if err != nil {
+		err = msgp.WrapError(err, "LockSyncFailedCount")
+		return
+	}
+	// write "ReplicatedDeleteMarkers"
+	err = en.Append(0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ReplicatedDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+		return
+	}
+	// write "PendingDeleteMarkers"
+	err = en.Append(0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.PendingDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "PendingDeleteMarkers")
+		return
+	}
+	// write "FailedDeleteMarkers"
+	err = en.Append(0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.FailedDeleteMarkers)
+	if err != nil {
+		err = msgp.WrapError(err, "FailedDeleteMarkers")
+		return
+	}
+	// write "ReplicatedVersionPurges"
+	err = en.Append(0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ReplicatedVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplicatedVersionPurges")
+		return
+	}
+	// write "PendingVersionPurges"
+	err = en.Append(0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.PendingVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "PendingVersionPurges")
+		return
+	}
+	// write "FailedVersionPurges"
+	err = en.Append(0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.FailedVersionPurges)
+	if err != nil {
+		err = msgp.WrapError(err, "FailedVersionPurges")
+		return
+	}
+	// write "DriftCount"
+	err = en.Append(0xaa, 0x44, 0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.DriftCount)
+	if err != nil {
+		err = msgp.WrapError(err, "DriftCount")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketReplicationStats) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 15
 	// string "Stats"
-	o = append(o, 0x87, 0xa5, 0x53, 0x74, 0x61, 0x74, 0x73)
+	o = append(o, 0x8f, 0xa5, 0x53, 0x74, 0x61, 0x74, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Stats)))
 	for za0001, za0002 := range z.Stats {
 		o = msgp.AppendString(o, za0001)
@@ -557,6 +1128,30 @@ func (z *BucketReplicationStats) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "FailedCount"
 	o = append(o, 0xab, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
 	o = msgp.AppendInt64(o, z.FailedCount)
+	// string "LockSyncFailedCount"
+	o = append(o, 0xb3, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.LockSyncFailedCount)
+	// string "ReplicatedDeleteMarkers"
+	o = append(o, 0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.ReplicatedDeleteMarkers)
+	// string "PendingDeleteMarkers"
+	o = append(o, 0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.PendingDeleteMarkers)
+	// string "FailedDeleteMarkers"
+	o = append(o, 0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt64(o, z.FailedDeleteMarkers)
+	// string "ReplicatedVersionPurges"
+	o = append(o, 0xb7, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.ReplicatedVersionPurges)
+	// string "PendingVersionPurges"
+	o = append(o, 0xb4, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.PendingVersionPurges)
+	// string "FailedVersionPurges"
+	o = append(o, 0xb3, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x73)
+	o = msgp.AppendInt64(o, z.FailedVersionPurges)
+	// string "DriftCount"
+	o = append(o, 0xaa, 0x44, 0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74)
+	o = msgp.AppendInt64(o, z.DriftCount)
 	return
 }
 
@@ -655,6 +1250,54 @@ func (z *BucketReplicationStats) UnmarshalMsg(bts []byte) (o []byte, err error)
 				err = msgp.WrapError(err, "FailedCount")
 				return
 			}
+		case "LockSyncFailedCount":
+			z.LockSyncFailedCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LockSyncFailedCount")
+				return
+			}
+		case "ReplicatedDeleteMarkers":
+			z.ReplicatedDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedDeleteMarkers")
+				return
+			}
+		case "PendingDeleteMarkers":
+			z.PendingDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingDeleteMarkers")
+				return
+			}
+		case "FailedDeleteMarkers":
+			z.FailedDeleteMarkers, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FailedDeleteMarkers")
+				return
+			}
+		case "ReplicatedVersionPurges":
+			z.ReplicatedVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplicatedVersionPurges")
+				return
+			}
+		case "PendingVersionPurges":
+			z.PendingVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingVersionPurges")
+				return
+			}
+		case "FailedVersionPurges":
+			z.FailedVersionPurges, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FailedVersionPurges")
+				return
+			}
+		case "DriftCount":
+			z.DriftCount, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DriftCount")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -681,7 +1324,7 @@ func (z *BucketReplicationStats) Msgsize() (s int) {
 			}
 		}
 	}
-	s += 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size
+	s += 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 20 + msgp.Int64Size + 24 + msgp.Int64Size + 21 + msgp.Int64Size + 20 + msgp.Int64Size + 24 + msgp.Int64Size + 21 + msgp.Int64Size + 20 + msgp.Int64Size + 11 + msgp.Int64Size
 	return
 }
 
@@ -816,6 +1459,25 @@ func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "UploadHistogram")
 				return
 			}
+		case "UploadWindows":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UploadWindows")
+				return
+			}
+			if cap(z.UploadWindows) >= int(zb0002) {
+				z.UploadWindows = (z.UploadWindows)[:zb0002]
+			} else {
+				z.UploadWindows = make([]RollingLatencies, zb0002)
+			}
+			for za0001 := range z.UploadWindows {
+				err = z.UploadWindows[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "UploadWindows", za0001)
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -829,9 +1491,9 @@ func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *ReplicationLatency) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// map header, size 2
 	// write "UploadHistogram"
-	err = en.Append(0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	err = en.Append(0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	if err != nil {
 		return
 	}
@@ -840,20 +1502,47 @@ func (z *ReplicationLatency) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "UploadHistogram")
 		return
 	}
+	// write "UploadWindows"
+	err = en.Append(0xad, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.UploadWindows)))
+	if err != nil {
+		err = msgp.WrapError(err, "UploadWindows")
+		return
+	}
+	for za0001 := range z.UploadWindows {
+		err = z.UploadWindows[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "UploadWindows", za0001)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *ReplicationLatency) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 2
 	// string "UploadHistogram"
-	o = append(o, 0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = append(o, 0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	o, err = z.UploadHistogram.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "UploadHistogram")
 		return
 	}
+	// string "UploadWindows"
+	o = append(o, 0xad, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.UploadWindows)))
+	for za0001 := range z.UploadWindows {
+		o, err = z.UploadWindows[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "UploadWindows", za0001)
+			return
+		}
+	}
 	return
 }
 
@@ -881,6 +1570,25 @@ func (z *ReplicationLatency) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "UploadHistogram")
 				return
 			}
+		case "UploadWindows":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UploadWindows")
+				return
+			}
+			if cap(z.UploadWindows) >= int(zb0002) {
+				z.UploadWindows = (z.UploadWindows)[:zb0002]
+			} else {
+				z.UploadWindows = make([]RollingLatencies, zb0002)
+			}
+			for za0001 := range z.UploadWindows {
+				bts, err = z.UploadWindows[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "UploadWindows", za0001)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -895,6 +1603,212 @@ func (z *ReplicationLatency) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ReplicationLatency) Msgsize() (s int) {
-	s = 1 + 16 + z.UploadHistogram.Msgsize()
+	s = 1 + 16 + z.UploadHistogram.Msgsize() + 14 + msgp.ArrayHeaderSize
+	for za0001 := range z.UploadWindows {
+		s += z.UploadWindows[za0001].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationStatsSnapshot) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Buckets":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if z.Buckets == nil {
+				z.Buckets = make(map[string]BucketReplicationStats, zb0002)
+			} else if len(z.Buckets) > 0 {
+				for key := range z.Buckets {
+					delete(z.Buckets, key)
+				}
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 BucketReplicationStats
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets")
+					return
+				}
+				err = za0002.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+				z.Buckets[za0001] = za0002
+			}
+		case "Saved":
+			z.Saved, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Saved")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationStatsSnapshot) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "Buckets"
+	err = en.Append(0x82, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Buckets)))
+	if err != nil {
+		err = msgp.WrapError(err, "Buckets")
+		return
+	}
+	for za0001, za0002 := range z.Buckets {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Buckets")
+			return
+		}
+		err = za0002.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Buckets", za0001)
+			return
+		}
+	}
+	// write "Saved"
+	err = en.Append(0xa5, 0x53, 0x61, 0x76, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Saved)
+	if err != nil {
+		err = msgp.WrapError(err, "Saved")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationStatsSnapshot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Buckets"
+	o = append(o, 0x82, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Buckets)))
+	for za0001, za0002 := range z.Buckets {
+		o = msgp.AppendString(o, za0001)
+		o, err = za0002.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Buckets", za0001)
+			return
+		}
+	}
+	// string "Saved"
+	o = append(o, 0xa5, 0x53, 0x61, 0x76, 0x65, 0x64)
+	o = msgp.AppendTime(o, z.Saved)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationStatsSnapshot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Buckets":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if z.Buckets == nil {
+				z.Buckets = make(map[string]BucketReplicationStats, zb0002)
+			} else if len(z.Buckets) > 0 {
+				for key := range z.Buckets {
+					delete(z.Buckets, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 BucketReplicationStats
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets")
+					return
+				}
+				bts, err = za0002.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Buckets", za0001)
+					return
+				}
+				z.Buckets[za0001] = za0002
+			}
+		case "Saved":
+			z.Saved, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Saved")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationStatsSnapshot) Msgsize() (s int) {
+	s = 1 + 8 + msgp.MapHeaderSize
+	if z.Buckets != nil {
+		for za0001, za0002 := range z.Buckets {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
+		}
+	}
+	s += 6 + msgp.TimeSize
 	return
 }
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/minio/minio/internal/config/storageclass"
+)
+
+const (
+	bucketStorageClassesConfigFile = "storage-classes.json"
+
+	// Minimum parity of a custom storage class.
+	minStorageClassParity = 2
+
+	maxBucketStorageClassesConfigSize = 64 << 10
+)
+
+var validStorageClassName = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,62}$`)
+
+// BucketStorageClass - a storage class of a bucket with a custom parity.
+type BucketStorageClass struct {
+	Name   string `json:"name"`
+	Parity int    `json:"parity"`
+}
+
+// BucketStorageClasses - storage classes of a bucket in addition to
+// STANDARD and REDUCED_REDUNDANCY, objects uploaded with one of them in
+// x-amz-storage-class are erasure coded with its parity.
+type BucketStorageClasses struct {
+	Classes []BucketStorageClass `json:"classes,omitempty"`
+}
+
+// parseBucketStorageClasses parses BucketStorageClasses from json
+func parseBucketStorageClasses(data []byte) (*BucketStorageClasses, error) {
+	c := &BucketStorageClasses{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Validate checks the names of the storage classes and their parity
+// against the smallest erasure set of the deployment.
+func (c *BucketStorageClasses) Validate(setDriveCount int) error {
+	names := make(map[string]struct{}, len(c.Classes))
+	for _, class := range c.Classes {
+		if storageclass.IsValid(class.Name) {
+			return fmt.Errorf("storage class %s cannot be redefined", class.Name)
+		}
+		if !validStorageClassName.MatchString(class.Name) {
+			return fmt.Errorf("invalid storage class name %q, only upper case letters, digits and underscores are allowed", class.Name)
+		}
+		if _, ok := names[class.Name]; ok {
+			return fmt.Errorf("duplicate storage class %s", class.Name)
+		}
+		names[class.Name] = struct{}{}
+		if class.Parity < minStorageClassParity || class.Parity > setDriveCount/2 {
+			return fmt.Errorf("parity %d of storage class %s should be between %d and %d",
+				class.Parity, class.Name, minStorageClassParity, setDriveCount/2)
+		}
+	}
+	return nil
+}
+
+// parity returns the parity of the storage class sc.
+func (c *BucketStorageClasses) parity(sc string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	for _, class := range c.Classes {
+		if class.Name == sc {
+			return class.Parity, true
+		}
+	}
+	return 0, false
+}
+
+// isCustomStorageClass returns whether sc is neither empty nor one of
+// the server wide storage classes.
+func isCustomStorageClass(sc string) bool {
+	return sc != "" && !storageclass.IsValid(sc)
+}
+
+// isValidStorageClass returns whether objects of bucket can be
+// uploaded with the storage class sc.
+func isValidStorageClass(bucket, sc string) bool {
+	if !isCustomStorageClass(sc) {
+		return storageclass.IsValid(sc)
+	}
+	classes, err := globalBucketMetadataSys.GetStorageClassesConfig(bucket)
+	if err != nil {
+		return false
+	}
+	_, ok := classes.parity(sc)
+	return ok
+}
+
+// getParityForSC returns the parity of the objects of bucket uploaded
+// with the storage class sc, the storage classes of the bucket take
+// precedence over the server wide configuration.
+func getParityForSC(bucket, sc string) int {
+	if isCustomStorageClass(sc) {
+		if classes, err := globalBucketMetadataSys.GetStorageClassesConfig(bucket); err == nil {
+			if parity, ok := classes.parity(sc); ok {
+				return parity
+			}
+		}
+	}
+	return globalStorageClass.GetParityForSC(sc)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestBucketStorageClassesValidate(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"classes":[{"name":"WARM","parity":3},{"name":"COLD_2","parity":8}]}`, false},
+		{`{}`, false},
+		{`{"classes":[{"name":"STANDARD","parity":4}]}`, true},
+		{`{"classes":[{"name":"REDUCED_REDUNDANCY","parity":2}]}`, true},
+		{`{"classes":[{"name":"warm","parity":3}]}`, true},
+		{`{"classes":[{"name":"","parity":3}]}`, true},
+		{`{"classes":[{"name":"WARM","parity":3},{"name":"WARM","parity":4}]}`, true},
+		{`{"classes":[{"name":"WARM","parity":1}]}`, true},
+		{`{"classes":[{"name":"WARM","parity":9}]}`, true},
+	}
+
+	for i, testCase := range testCases {
+		classes, err := parseBucketStorageClasses([]byte(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		err = classes.Validate(16)
+		if testCase.expectErr && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+	}
+}
+
+func TestBucketStorageClassesPutObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketStorageClassesConfigFile, []byte(`{"classes":[{"name":"ARCHIVE","parity":7}]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !isValidStorageClass(bucket, "ARCHIVE") || !isValidStorageClass(bucket, "STANDARD") {
+		t.Fatal("Expected ARCHIVE and STANDARD storage classes to be valid")
+	}
+	if isValidStorageClass(bucket, "GLACIER") {
+		t.Fatal("Expected GLACIER storage class to be invalid")
+	}
+
+	data := []byte("hello")
+	testCases := []struct {
+		object, sc     string
+		expectedParity int
+	}{
+		{"archived", "ARCHIVE", 7},
+		{"standard", "", obj.(*erasureServerPools).getServerPools()[0].sets[0].defaultParityCount},
+	}
+	for i, testCase := range testCases {
+		opts := ObjectOptions{UserDefined: map[string]string{}}
+		if testCase.sc != "" {
+			opts.UserDefined[xhttp.AmzStorageClass] = testCase.sc
+		}
+		if _, err = obj.PutObject(ctx, bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		er := obj.(*erasureServerPools).getServerPools()[0].getHashedSet(testCase.object)
+		fi, err := er.getDisks()[0].ReadVersion(ctx, bucket, testCase.object, "", false)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if fi.Erasure.ParityBlocks != testCase.expectedParity {
+			t.Errorf("Test %d: expected parity %d, got %d", i+1, testCase.expectedParity, fi.Erasure.ParityBlocks)
+		}
+		oi, err := obj.GetObjectInfo(ctx, bucket, testCase.object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if testCase.sc != "" && oi.StorageClass != testCase.sc {
+			t.Errorf("Test %d: expected storage class %s, got %s", i+1, testCase.sc, oi.StorageClass)
+		}
+	}
+
+	// Objects keep their parity when the storage class is removed.
+	if err = globalBucketMetadataSys.Update(bucket, bucketStorageClassesConfigFile, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "archived", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -28,15 +30,20 @@ import (
 	minio "github.com/minio/minio-go/v7"
 	miniogo "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 )
 
 const (
 	defaultHealthCheckDuration = 30 * time.Second
+
+	// Minimum replication bandwidth limit in bytes per second.
+	minReplicationBandwidthLimit = 100 * 1000 * 1000
 )
 
 // BucketTargetSys represents bucket targets subsystem
@@ -170,13 +177,37 @@ func (sys *BucketTargetSys) updateBandwidthLimit(bucket string, limit int64) {
 	if globalIsGateway {
 		return
 	}
-	if limit == 0 {
-		globalBucketMonitor.DeleteBucket(bucket)
-		return
+	// Setup bandwidth throttling, a zero limit removes it. The bucket level
+	// limit, if any, is capped by the target limit.
+	globalBucketMonitor.SetBandwidthLimit(bucket, limit)
+}
+
+// parseReplicationBandwidthLimit parses the bucket level replication
+// bandwidth limit from json.
+func parseReplicationBandwidthLimit(bucket string, data []byte) (*bandwidth.Limit, error) {
+	limit := &bandwidth.Limit{}
+	if err := json.Unmarshal(data, limit); err != nil {
+		return limit, err
+	}
+	if limit.BytesPerSec < 0 || limit.Percent < 0 || limit.Percent > 100 || limit.LinkCapacity < 0 ||
+		(limit.BytesPerSec > 0 && limit.Percent > 0) || (limit.LinkCapacity > 0 && limit.Percent == 0) {
+		return limit, fmt.Errorf("Invalid replication bandwidth limit for bucket %s: %#v", bucket, limit)
 	}
-	// Setup bandwidth throttling
+	return limit, nil
+}
 
-	globalBucketMonitor.SetBandwidthLimit(bucket, limit)
+// setReplicationBandwidthLimit applies the bucket level replication
+// bandwidth limit of the bucket metadata, replication already in
+// progress picks up the new limit.
+func setReplicationBandwidthLimit(bucket string, meta BucketMetadata) {
+	if globalIsGateway || globalBucketMonitor == nil {
+		return
+	}
+	var limit bandwidth.Limit
+	if meta.replicationBandwidthConfig != nil {
+		limit = *meta.replicationBandwidthConfig
+	}
+	globalBucketMonitor.SetBucketLimit(bucket, limit)
 }
 
 // RemoveTarget - removes a remote bucket target for this source bucket.
@@ -347,7 +378,7 @@ func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*T
 		Creds:     creds,
 		Secure:    tcfg.Secure,
 		Region:    tcfg.Region,
-		Transport: getRemoteTargetInstanceTransport,
+		Transport: replicationOriginTransport{getRemoteTargetInstanceTransport},
 	})
 	if err != nil {
 		return nil, err
@@ -374,6 +405,19 @@ func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*T
 	return tc, nil
 }
 
+// replicationOriginTransport sends the deployment ID of this cluster
+// with every request to a remote target, so that replicas record the
+// site they were written on.
+type replicationOriginTransport struct {
+	http.RoundTripper
+}
+
+func (t replicationOriginTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(xhttp.MinioDeploymentID, globalDeploymentID)
+	return t.RoundTripper.RoundTrip(req)
+}
+
 // getRemoteARN gets existing ARN for an endpoint or generates a new one.
 func (sys *BucketTargetSys) getRemoteARN(bucket string, target *madmin.BucketTarget) string {
 	if target == nil {
@@ -71,6 +71,16 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		}, r.URL)
 		return
 	}
+	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled && (len(v.ExcludedPrefixes) > 0 || v.ExcludeFolders) {
+		// Objects under excluded prefixes are not versioned, so their
+		// retention and legal hold could not be enforced.
+		writeErrorResponse(ctx, w, APIError{
+			Code:           "InvalidBucketState",
+			Description:    "An Object Lock configuration is present on this bucket, so no prefixes can be excluded from versioning.",
+			HTTPStatusCode: http.StatusConflict,
+		}, r.URL)
+		return
+	}
 	if _, err := getReplicationConfig(ctx, bucket); err == nil && v.Suspended() {
 		writeErrorResponse(ctx, w, APIError{
 			Code:           "InvalidBucketState",
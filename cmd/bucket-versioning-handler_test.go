@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestPutBucketVersioningHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testPutBucketVersioningHandler, []string{"PutBucketVersioning"})
+}
+
+func testPutBucketVersioningHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	if instanceType != ErasureTestStr {
+		// Bucket versioning and object locking require erasure.
+		return
+	}
+	isErasure := globalIsErasure
+	globalIsErasure = true
+	defer func() { globalIsErasure = isErasure }()
+
+	ctx := context.Background()
+	lockedBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, lockedBucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	meta := newBucketMetadata(lockedBucket)
+	meta.ObjectLockConfigXML = enabledBucketObjectLockConfig
+	if err := meta.parseAllConfigs(ctx, obj); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	globalBucketMetadataSys.Set(lockedBucket, meta)
+	defer globalBucketMetadataSys.Set(lockedBucket, newBucketMetadata(lockedBucket))
+
+	const (
+		enabled          = `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`
+		excludedPrefixes = `<VersioningConfiguration><Status>Enabled</Status><ExcludedPrefixes><Prefix>spark/_temporary/</Prefix></ExcludedPrefixes></VersioningConfiguration>`
+		excludeFolders   = `<VersioningConfiguration><Status>Enabled</Status><ExcludeFolders>true</ExcludeFolders></VersioningConfiguration>`
+	)
+	testCases := []struct {
+		bucket             string
+		config             string
+		expectedRespStatus int
+	}{
+		{bucketName, excludedPrefixes, http.StatusOK},
+		{bucketName, excludeFolders, http.StatusOK},
+		// Objects under excluded prefixes would not be locked.
+		{lockedBucket, excludedPrefixes, http.StatusConflict},
+		{lockedBucket, excludeFolders, http.StatusConflict},
+		{lockedBucket, enabled, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getBucketVersioningURL("", testCase.bucket),
+			int64(len(testCase.config)), strings.NewReader(testCase.config), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Test %d: Failed to create HTTP request: <ERROR> %v", instanceType, i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("%s: Test %d: Expected the response status to be %d, but instead found %d: %s",
+				instanceType, i+1, testCase.expectedRespStatus, rec.Code, rec.Body)
+		}
+	}
+}
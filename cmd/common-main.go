@@ -478,6 +478,17 @@ func handleCommonEnvVars() {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
 	}
 
+	switch transport := env.Get(config.EnvInternodeTransport, internodeTransportHTTP); transport {
+	case internodeTransportHTTP:
+	case internodeTransportGRPC:
+		globalInternodeGRPCPort = env.Get(config.EnvInternodeGRPCPort, defaultInternodeGRPCPort)
+		if _, err = strconv.ParseUint(globalInternodeGRPCPort, 10, 16); err != nil {
+			logger.Fatal(config.ErrInvalidInternodeTransport(err), "Invalid MINIO_INTERNODE_GRPC_PORT value in environment variable")
+		}
+	default:
+		logger.Fatal(config.ErrInvalidInternodeTransport(nil).Msg("Unknown value `%s`", transport), "Invalid MINIO_INTERNODE_TRANSPORT value in environment variable")
+	}
+
 	domains := env.Get(config.EnvDomain, "")
 	if len(domains) != 0 {
 		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
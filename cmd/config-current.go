@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/config"
@@ -35,6 +36,7 @@ import (
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
 	xtls "github.com/minio/minio/internal/config/identity/tls"
+	"github.com/minio/minio/internal/config/lambda"
 	"github.com/minio/minio/internal/config/notify"
 	"github.com/minio/minio/internal/config/policy/opa"
 	"github.com/minio/minio/internal/config/scanner"
@@ -44,6 +46,7 @@ import (
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/target/file"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
 	"github.com/minio/pkg/env"
@@ -65,9 +68,11 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditWebhookKVS,
 		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
+		config.AuditFileSubSys:      logger.DefaultAuditFileKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.ScannerSubSys:        scanner.DefaultKVS,
 		config.SubnetSubSys:         subnet.DefaultKVS,
+		config.LambdaWebhookSubSys:  lambda.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -96,8 +101,9 @@ func initHelp() {
 			Description: "federate multiple clusters for IAM and Bucket DNS",
 		},
 		config.HelpKV{
-			Key:         config.IdentityOpenIDSubSys,
-			Description: "enable OpenID SSO support",
+			Key:             config.IdentityOpenIDSubSys,
+			Description:     "enable OpenID SSO support",
+			MultipleTargets: true,
 		},
 		config.HelpKV{
 			Key:         config.IdentityLDAPSubSys,
@@ -138,6 +144,11 @@ func initHelp() {
 			Description:     "send audit logs to kafka endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.AuditFileSubSys,
+			Description:     "write audit logs to local files",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -168,6 +179,11 @@ func initHelp() {
 			Description:     "publish bucket notifications to NSQ endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifyPulsarSubSys,
+			Description:     "publish bucket notifications to Pulsar endpoints",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyMySQLSubSys,
 			Description:     "publish bucket notifications to MySQL databases",
@@ -188,6 +204,16 @@ func initHelp() {
 			Description:     "publish bucket notifications to Redis datastores",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifySQSSubSys,
+			Description:     "publish bucket notifications to Amazon SQS queues",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.LambdaWebhookSubSys,
+			Description:     "transform objects read with GET through webhook endpoints",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:         config.SubnetSubSys,
 			Type:        "string",
@@ -223,17 +249,21 @@ func initHelp() {
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpWebhook,
 		config.AuditKafkaSubSys:     logger.HelpKafka,
+		config.AuditFileSubSys:      logger.HelpFile,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
 		config.NotifyNATSSubSys:     notify.HelpNATS,
 		config.NotifyNSQSubSys:      notify.HelpNSQ,
+		config.NotifyPulsarSubSys:   notify.HelpPulsar,
 		config.NotifyMySQLSubSys:    notify.HelpMySQL,
 		config.NotifyPostgresSubSys: notify.HelpPostgres,
 		config.NotifyRedisSubSys:    notify.HelpRedis,
+		config.NotifySQSSubSys:      notify.HelpSQS,
 		config.NotifyWebhookSubSys:  notify.HelpWebhook,
 		config.NotifyESSubSys:       notify.HelpES,
 		config.SubnetSubSys:         subnet.HelpSubnet,
+		config.LambdaWebhookSubSys:  lambda.Help,
 	}
 
 	config.RegisterHelpSubSys(helpMap)
@@ -326,7 +356,7 @@ func validateConfig(s config.Config) error {
 			etcdClnt.Close()
 		}
 	}
-	if _, err := openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	if _, err := openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody, globalSite.Region); err != nil {
 		return err
 	}
@@ -361,6 +391,10 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err := lambda.LookupConfig(s); err != nil {
+		return err
+	}
+
 	return notify.TestNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), globalNotificationSys.ConfiguredTargetIDs())
 }
 
@@ -512,11 +546,12 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.Info("CRITICAL: enabling %s is not recommended in a production environment", xtls.EnvIdentityTLSSkipVerify)
 	}
 
-	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
+	globalOpenIDProviders, err = openid.LookupConfigs(s[config.IdentityOpenIDSubSys],
 		NewGatewayHTTPTransport(), xhttp.DrainBody, globalSite.Region)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OpenID: %w", err))
 	}
+	globalOpenIDConfig = globalOpenIDProviders.Default
 
 	opaCfg, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody)
@@ -524,7 +559,7 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize OPA: %w", err))
 	}
 
-	globalOpenIDValidators = getOpenIDValidators(globalOpenIDConfig)
+	globalOpenIDValidators = getOpenIDValidators(globalOpenIDProviders)
 	globalPolicyOPA = opa.New(opaCfg)
 
 	globalLDAPConfig, err = xldap.Lookup(s[config.IdentityLDAPSubSys][config.Default],
@@ -580,6 +615,22 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		}
 	}
 
+	for _, l := range loggerCfg.AuditFile {
+		if l.Enabled {
+			l.LogOnce = logger.LogOnceIf
+			// Enable file audit logging
+			if err = logger.AddAuditTarget(file.New(l)); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize audit file target: %w", err))
+			}
+		}
+	}
+
+	lambdaTargets, err := lambda.LookupConfig(s)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize lambda webhook target(s): %w", err))
+	}
+	globalObjectLambda.Set(lambdaTargets)
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
@@ -652,6 +703,7 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 	// update dynamic scanner values.
 	scannerCycle.Update(scannerCfg.Cycle)
 	logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
+	atomic.StoreInt32(&scannerPrefixDepth, int32(scannerCfg.PrefixDepth))
 
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
@@ -785,11 +837,11 @@ func loadConfig(objAPI ObjectLayer) error {
 // enabled providers in server config.
 // A new authentication provider is added like below
 // * Add a new provider in pkg/iam/openid package.
-func getOpenIDValidators(cfg openid.Config) *openid.Validators {
+func getOpenIDValidators(providers openid.Providers) *openid.Validators {
 	validators := openid.NewValidators()
 
-	if cfg.Enabled {
-		validators.Add(&cfg)
+	if providers.Enabled() {
+		validators.Add(providers)
 	}
 
 	return validators
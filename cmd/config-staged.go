@@ -0,0 +1,308 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Config changes staged to be validated and applied together.
+	minioConfigStagedFile = minioConfigPrefix + "/staged.json"
+)
+
+var stagedConfigLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// errNoStagedConfig - apply or validate was requested without staged changes.
+var errNoStagedConfig = errors.New("There are no staged configuration changes")
+
+// stagedConfigChange - a set-config-kv or del-config-kv input staged
+// for later.
+type stagedConfigChange struct {
+	Delete bool      `json:"delete,omitempty"`
+	KV     string    `json:"kv"`
+	Time   time.Time `json:"time"`
+}
+
+// stagedConfig - the staged config changes, applied in order on top of
+// the current server config.
+type stagedConfig struct {
+	Changes []stagedConfigChange `json:"changes"`
+}
+
+// stagedConfigNodeResult - the outcome of validating or applying the
+// staged config on a node.
+type stagedConfigNodeResult struct {
+	Host  string `json:"host"`
+	Error string `json:"error,omitempty"`
+}
+
+// stagedConfigResult - the outcome of validating or applying the staged
+// config on all nodes.
+type stagedConfigResult struct {
+	Valid           bool                     `json:"valid"`
+	Applied         bool                     `json:"applied"`
+	RolledBack      bool                     `json:"rolledBack,omitempty"`
+	RestartRequired bool                     `json:"restartRequired,omitempty"`
+	Nodes           []stagedConfigNodeResult `json:"nodes"`
+}
+
+// failed returns true if the operation failed on any node.
+func (r stagedConfigResult) failed() bool {
+	for _, node := range r.Nodes {
+		if node.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTo applies the staged changes to cfg. Returns whether all changed
+// parameters are dynamic.
+func (s stagedConfig) applyTo(cfg config.Config) (dynamic bool, err error) {
+	dynamic = true
+	for _, change := range s.Changes {
+		if change.Delete {
+			if err = cfg.DelFrom(strings.NewReader(change.KV)); err != nil {
+				return false, err
+			}
+			dynamic = dynamic && config.SubSystemsDynamic.Contains(change.KV)
+			continue
+		}
+		dynOnly, err := cfg.ReadConfig(strings.NewReader(change.KV))
+		if err != nil {
+			return false, err
+		}
+		dynamic = dynamic && dynOnly
+	}
+	return dynamic, nil
+}
+
+// historyKV returns the staged set-config-kv inputs to be saved in the
+// config history.
+func (s stagedConfig) historyKV() []byte {
+	var kvs []string
+	for _, change := range s.Changes {
+		if !change.Delete {
+			kvs = append(kvs, change.KV)
+		}
+	}
+	return []byte(strings.Join(kvs, config.KvNewline))
+}
+
+func readStagedConfig(ctx context.Context, objAPI ObjectLayer) (staged stagedConfig, err error) {
+	data, err := readConfig(ctx, objAPI, minioConfigStagedFile)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return staged, nil
+		}
+		return staged, err
+	}
+
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, minioConfigStagedFile),
+		})
+		if err != nil {
+			return staged, err
+		}
+	}
+	err = json.Unmarshal(data, &staged)
+	return staged, err
+}
+
+func saveStagedConfig(ctx context.Context, objAPI ObjectLayer, staged stagedConfig) error {
+	data, err := json.Marshal(staged)
+	if err != nil {
+		return err
+	}
+
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, minioConfigStagedFile),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, minioConfigStagedFile, data)
+}
+
+func deleteStagedConfig(ctx context.Context, objAPI ObjectLayer) error {
+	err := deleteConfig(ctx, objAPI, minioConfigStagedFile)
+	if errors.Is(err, errConfigNotFound) {
+		return nil
+	}
+	return err
+}
+
+// stageConfigChange validates the change against the current server
+// config and appends it to the staged changes.
+func stageConfigChange(ctx context.Context, objAPI ObjectLayer, change stagedConfigChange) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, minioConfigStagedFile+".lock")
+	lkctx, err := lk.GetLock(ctx, stagedConfigLockTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	staged, err := readStagedConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	staged.Changes = append(staged.Changes, change)
+
+	cfg, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if _, err = staged.applyTo(cfg); err != nil {
+		return err
+	}
+	return saveStagedConfig(ctx, objAPI, staged)
+}
+
+// nodeResults returns the results of this node and of the peers.
+func nodeResults(localErr error, peerErrs []NotificationPeerErr) []stagedConfigNodeResult {
+	nodes := make([]stagedConfigNodeResult, 0, len(peerErrs)+1)
+	local := stagedConfigNodeResult{Host: globalLocalNodeName}
+	if localErr != nil {
+		local.Error = localErr.Error()
+	}
+	nodes = append(nodes, local)
+	for _, nErr := range peerErrs {
+		node := stagedConfigNodeResult{Host: nErr.Host.String()}
+		if nErr.Err != nil {
+			node.Error = nErr.Err.Error()
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// validateConfigClusterWide validates the config on all nodes, every node
+// loads the config without applying it.
+func validateConfigClusterWide(ctx context.Context, cfg config.Config) stagedConfigResult {
+	result := stagedConfigResult{
+		Nodes: nodeResults(validateConfig(cfg), globalNotificationSys.ValidateConfig(ctx, cfg)),
+	}
+	result.Valid = !result.failed()
+	return result
+}
+
+// validateStagedConfig validates the current server config with the
+// staged changes on all nodes.
+func validateStagedConfig(ctx context.Context, objAPI ObjectLayer) (stagedConfigResult, error) {
+	staged, err := readStagedConfig(ctx, objAPI)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+	if len(staged.Changes) == 0 {
+		return stagedConfigResult{}, errNoStagedConfig
+	}
+
+	cfg, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+	if _, err = staged.applyTo(cfg); err != nil {
+		return stagedConfigResult{}, err
+	}
+	return validateConfigClusterWide(ctx, cfg), nil
+}
+
+// applyStagedConfig validates the staged changes on all nodes, then saves
+// and applies them. When the changes are dynamic and any node fails to
+// apply them, the previous config is saved and applied again on all nodes.
+func applyStagedConfig(ctx context.Context, objAPI ObjectLayer) (stagedConfigResult, error) {
+	lk := objAPI.NewNSLock(minioMetaBucket, minioConfigStagedFile+".lock")
+	lkctx, err := lk.GetLock(ctx, stagedConfigLockTimeout)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	staged, err := readStagedConfig(ctx, objAPI)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+	if len(staged.Changes) == 0 {
+		return stagedConfigResult{}, errNoStagedConfig
+	}
+
+	oldCfg, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+	cfg := oldCfg.Clone()
+	dynamic, err := staged.applyTo(cfg)
+	if err != nil {
+		return stagedConfigResult{}, err
+	}
+
+	result := validateConfigClusterWide(ctx, cfg)
+	if !result.Valid {
+		return result, nil
+	}
+
+	if err = saveServerConfig(ctx, objAPI, cfg); err != nil {
+		return result, err
+	}
+
+	if dynamic {
+		applyErr := applyDynamicConfig(GlobalContext, objAPI, cfg)
+		result.Nodes = nodeResults(applyErr, globalNotificationSys.SignalService(serviceReloadDynamic))
+	} else {
+		// The changes are picked up by the nodes when restarted.
+		result.RestartRequired = true
+	}
+
+	if !result.failed() {
+		if kv := staged.historyKV(); len(kv) > 0 {
+			logger.LogIf(ctx, saveServerConfigHistory(ctx, objAPI, kv))
+		}
+		logger.LogIf(ctx, deleteStagedConfig(ctx, objAPI))
+		result.Applied = true
+		return result, nil
+	}
+
+	// Roll back all nodes to the previous config, the changes stay staged.
+	if err = saveServerConfig(ctx, objAPI, oldCfg); err != nil {
+		return result, err
+	}
+	logger.LogIf(ctx, applyDynamicConfig(GlobalContext, objAPI, oldCfg))
+	for _, nErr := range globalNotificationSys.SignalService(serviceReloadDynamic) {
+		if nErr.Err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+	result.RolledBack = true
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestStagedConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	objAPI := adminTestBed.objLayer
+
+	if _, err = validateStagedConfig(ctx, objAPI); !errors.Is(err, errNoStagedConfig) {
+		t.Fatalf("Expected %v, got %v", errNoStagedConfig, err)
+	}
+	if _, err = applyStagedConfig(ctx, objAPI); !errors.Is(err, errNoStagedConfig) {
+		t.Fatalf("Expected %v, got %v", errNoStagedConfig, err)
+	}
+
+	// Unknown sub-systems are rejected when staged.
+	if err = stageConfigChange(ctx, objAPI, stagedConfigChange{KV: "unknown foo=bar"}); err == nil {
+		t.Fatal("Expected staging an unknown sub-system to fail")
+	}
+
+	changes := []stagedConfigChange{
+		{KV: "api requests_max=1000", Time: UTCNow()},
+		{KV: "api requests_deadline=20s", Time: UTCNow()},
+	}
+	for _, change := range changes {
+		if err = stageConfigChange(ctx, objAPI, change); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	staged, err := readStagedConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(staged.Changes) != len(changes) {
+		t.Fatalf("Expected %d staged changes, got %d", len(changes), len(staged.Changes))
+	}
+
+	// Staged changes are not applied until requested.
+	cfg, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cfg[config.APISubSys][config.Default].Get("requests_max"); v == "1000" {
+		t.Fatal("Expected staged changes to not be saved before they are applied")
+	}
+
+	result, err := validateStagedConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Applied {
+		t.Fatalf("Unexpected validation result %#v", result)
+	}
+
+	result, err = applyStagedConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || !result.Applied || result.RolledBack || result.RestartRequired {
+		t.Fatalf("Unexpected apply result %#v", result)
+	}
+
+	cfg, err = readServerConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cfg[config.APISubSys][config.Default].Get("requests_max"); v != "1000" {
+		t.Fatalf("Expected requests_max=1000 to be applied, got %q", v)
+	}
+
+	staged, err = readStagedConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(staged.Changes) != 0 {
+		t.Fatalf("Expected the staged changes to be cleared, got %d", len(staged.Changes))
+	}
+
+	// Invalid values are reported by the validation and not applied.
+	if err = stageConfigChange(ctx, objAPI, stagedConfigChange{KV: "api requests_max=abc"}); err != nil {
+		t.Fatal(err)
+	}
+	result, err = applyStagedConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || result.Applied {
+		t.Fatalf("Unexpected apply result %#v", result)
+	}
+	cfg, err = readServerConfig(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := cfg[config.APISubSys][config.Default].Get("requests_max"); v != "1000" {
+		t.Fatalf("Expected requests_max=1000 to be kept, got %q", v)
+	}
+
+	if err = deleteStagedConfig(ctx, objAPI); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = validateStagedConfig(ctx, objAPI); !errors.Is(err, errNoStagedConfig) {
+		t.Fatalf("Expected %v, got %v", errNoStagedConfig, err)
+	}
+}
@@ -118,6 +118,28 @@ func readServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV str
 	return data, err
 }
 
+// readServerConfigAtHistory returns the server config as of the config
+// history entry restoreID, replaying the history up to it on top of the
+// default config. Deleted keys are not recorded in the history and keep
+// their last set value.
+func readServerConfigAtHistory(ctx context.Context, objAPI ObjectLayer, restoreID string) (config.Config, error) {
+	chEntries, err := listServerConfigHistory(ctx, objAPI, true, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := newServerConfig()
+	for _, chEntry := range chEntries {
+		if _, err = cfg.ReadConfig(strings.NewReader(chEntry.Data)); err != nil {
+			return nil, err
+		}
+		if chEntry.RestoreID == restoreID {
+			return cfg, nil
+		}
+	}
+	return nil, errConfigNotFound
+}
+
 func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, kv []byte) error {
 	uuidKV := mustGetUUID() + kvPrefix
 	historyFile := pathJoin(minioConfigHistoryPrefix, uuidKV)
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestReadServerConfigAtHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	objAPI := adminTestBed.objLayer
+	for _, kv := range []string{"api requests_max=100", "api requests_max=200 cors_allow_origin=https://example.com"} {
+		if err = saveServerConfigHistory(ctx, objAPI, []byte(kv)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chEntries, err := listServerConfigHistory(ctx, objAPI, false, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chEntries) != 2 {
+		t.Fatalf("Expected 2 config history entries, got %d", len(chEntries))
+	}
+
+	fromCfg, err := readServerConfigAtHistory(ctx, objAPI, chEntries[0].RestoreID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toCfg, err := readServerConfigAtHistory(ctx, objAPI, chEntries[1].RestoreID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffs := fromCfg.Diff(toCfg)
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 differences, got %#v", diffs)
+	}
+	for _, diff := range diffs {
+		if diff.SubSys != config.APISubSys {
+			t.Fatalf("Unexpected difference %#v", diff)
+		}
+		if diff.Key == "requests_max" && (diff.From != "100" || diff.To != "200") {
+			t.Fatalf("Unexpected difference %#v", diff)
+		}
+	}
+
+	if _, err = readServerConfigAtHistory(ctx, objAPI, mustGetUUID()); !errors.Is(err, errConfigNotFound) {
+		t.Fatalf("Expected %v, got %v", errConfigNotFound, err)
+	}
+}
+
+func TestRestoreRedactedValues(t *testing.T) {
+	oldCfg := newServerConfig()
+	if _, err := oldCfg.ReadConfig(strings.NewReader("notify_webhook:1 endpoint=http://localhost:8080 auth_token=secret")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newServerConfig()
+	if _, err := cfg.ReadConfig(strings.NewReader("notify_webhook:1 endpoint=http://localhost:9090 auth_token=" + config.RedactedValue)); err != nil {
+		t.Fatal(err)
+	}
+	if !restoreRedactedValues(cfg, oldCfg) {
+		t.Fatal("Expected redacted values to be found")
+	}
+
+	kvs := cfg[config.NotifyWebhookSubSys]["1"]
+	if v := kvs.Get("auth_token"); v != "secret" {
+		t.Fatalf("Expected the redacted auth_token to be restored, got %q", v)
+	}
+	if v := kvs.Get("endpoint"); v != "http://localhost:9090" {
+		t.Fatalf("Expected the imported endpoint to be kept, got %q", v)
+	}
+
+	if restoreRedactedValues(oldCfg, oldCfg) {
+		t.Fatal("Expected no redacted values")
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	mrand "math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+)
+
+const (
+	// EnvConsistencyProbeInterval sets the interval between two probe
+	// runs, 0 disables the probes.
+	EnvConsistencyProbeInterval = "MINIO_CONSISTENCY_PROBE_INTERVAL"
+
+	defaultConsistencyProbeInterval = 5 * time.Minute
+
+	// Canary objects are written under this prefix of minioMetaBucket.
+	consistencyProbePrefix = "consistency-probes"
+
+	// The canary is larger than the inline threshold, so that the
+	// probe exercises the part files on the drives.
+	consistencyProbeSize = 256 << 10
+
+	// Maximum duration of the probe of an erasure set.
+	consistencyProbeTimeout = time.Minute
+)
+
+// Steps of a consistency probe.
+const (
+	probeStepWrite  = "write"
+	probeStepRead   = "read"
+	probeStepList   = "list"
+	probeStepDelete = "delete"
+)
+
+var probeSteps = []string{probeStepWrite, probeStepRead, probeStepList, probeStepDelete}
+
+// ConsistencyProbeResult is the outcome of the last probe of an erasure
+// set by this node.
+type ConsistencyProbeResult struct {
+	Pool int       `json:"pool"`
+	Set  int       `json:"set"`
+	Time time.Time `json:"time"`
+	OK   bool      `json:"ok"`
+	// FailedStep is the step which failed, the steps after it were
+	// not run.
+	FailedStep string `json:"failedStep,omitempty"`
+	// Latencies of the steps which were run.
+	Latencies map[string]time.Duration `json:"latencies,omitempty"`
+	// Failures is the number of failed probes since the server started.
+	Failures uint64 `json:"failures"`
+}
+
+// ConsistencyProbeStatus holds the results of the last probe of all
+// erasure sets by this node.
+type ConsistencyProbeStatus struct {
+	Node     string                   `json:"node"`
+	Interval time.Duration            `json:"interval"`
+	Healthy  bool                     `json:"healthy"`
+	Sets     []ConsistencyProbeResult `json:"sets"`
+}
+
+type consistencyProbes struct {
+	mu       sync.Mutex
+	interval time.Duration
+	results  map[[2]int]ConsistencyProbeResult
+}
+
+var globalConsistencyProbes = &consistencyProbes{
+	results: make(map[[2]int]ConsistencyProbeResult),
+}
+
+// record stores the result of the probe of an erasure set.
+func (p *consistencyProbes) record(res ConsistencyProbeResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := [2]int{res.Pool, res.Set}
+	res.Failures = p.results[key].Failures
+	if !res.OK {
+		res.Failures++
+	}
+	p.results[key] = res
+}
+
+// status returns the results of the last probes, ordered by pool and set.
+func (p *consistencyProbes) status() ConsistencyProbeStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := ConsistencyProbeStatus{
+		Node:     globalLocalNodeName,
+		Interval: p.interval,
+		Healthy:  true,
+		Sets:     make([]ConsistencyProbeResult, 0, len(p.results)),
+	}
+	for _, res := range p.results {
+		st.Healthy = st.Healthy && res.OK
+		st.Sets = append(st.Sets, res)
+	}
+	sort.Slice(st.Sets, func(i, j int) bool {
+		if st.Sets[i].Pool != st.Sets[j].Pool {
+			return st.Sets[i].Pool < st.Sets[j].Pool
+		}
+		return st.Sets[i].Set < st.Sets[j].Set
+	})
+	return st
+}
+
+// initConsistencyProbes starts probing all erasure sets periodically.
+// Every node probes all sets, each with its own canary objects, so that
+// the results also reflect the connectivity of the node to the drives.
+func initConsistencyProbes(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	interval, err := time.ParseDuration(env.Get(EnvConsistencyProbeInterval, defaultConsistencyProbeInterval.String()))
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("invalid %s: %w", EnvConsistencyProbeInterval, err))
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+	globalConsistencyProbes.mu.Lock()
+	globalConsistencyProbes.interval = interval
+	globalConsistencyProbes.mu.Unlock()
+	go z.runConsistencyProbes(ctx, interval)
+}
+
+func (z *erasureServerPools) runConsistencyProbes(ctx context.Context, interval time.Duration) {
+	r := mrand.New(mrand.NewSource(time.Now().UnixNano()))
+	// Spread the probes of the nodes over the interval.
+	timer := time.NewTimer(time.Duration(r.Float64() * float64(interval)))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		z.probeAllSets(ctx)
+		timer.Reset(interval)
+	}
+}
+
+// probeAllSets probes all erasure sets of the pools accepting new
+// objects and records the results.
+func (z *erasureServerPools) probeAllSets(ctx context.Context) {
+	for poolIdx, pool := range z.getServerPools() {
+		if z.IsSuspended(poolIdx) {
+			// The canary would be written on a pool being drained.
+			continue
+		}
+		for setIdx, set := range pool.sets {
+			res := probeSet(ctx, pool, set, poolIdx, setIdx)
+			if ctx.Err() != nil {
+				return
+			}
+			globalConsistencyProbes.record(res)
+		}
+	}
+}
+
+// consistencyProbeObject returns the name of the canary object of this
+// node for the erasure set setIdx, it hashes to that set.
+func consistencyProbeObject(sets *erasureSets, setIdx int) string {
+	prefix := pathJoin(consistencyProbePrefix, getSHA256Hash([]byte(globalLocalNodeName))[:16])
+	for i := 0; ; i++ {
+		object := pathJoin(prefix, strconv.Itoa(i))
+		if sets.getHashedSetIndex(object) == setIdx {
+			return object
+		}
+	}
+}
+
+// probeSet writes a canary object to the erasure set, reads it back,
+// looks for it in a listing of the drives and deletes it, checking it is
+// gone afterwards.
+func probeSet(ctx context.Context, sets *erasureSets, set *erasureObjects, poolIdx, setIdx int) ConsistencyProbeResult {
+	res := ConsistencyProbeResult{
+		Pool:      poolIdx,
+		Set:       setIdx,
+		Time:      UTCNow(),
+		Latencies: make(map[string]time.Duration, len(probeSteps)),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, consistencyProbeTimeout)
+	defer cancel()
+
+	bucket, object := minioMetaBucket, consistencyProbeObject(sets, setIdx)
+	data := make([]byte, consistencyProbeSize)
+	if _, err := rand.Read(data); err != nil {
+		res.FailedStep = probeStepWrite
+		return res
+	}
+
+	var etag string
+	steps := map[string]func() error{
+		probeStepWrite: func() error {
+			hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+			if err != nil {
+				return err
+			}
+			oi, err := set.PutObject(ctx, bucket, object, NewPutObjReader(hr), ObjectOptions{})
+			etag = oi.ETag
+			return err
+		},
+		probeStepRead: func() error {
+			gr, err := set.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			defer gr.Close()
+			got, err := ioutil.ReadAll(gr)
+			if err != nil {
+				return err
+			}
+			if gr.ObjInfo.ETag != etag || !bytes.Equal(got, data) {
+				return errFileCorrupt
+			}
+			return nil
+		},
+		probeStepList: func() error {
+			return probeListSet(ctx, set, bucket, object)
+		},
+		probeStepDelete: func() error {
+			if _, err := set.DeleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+				return err
+			}
+			_, err := set.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+			if isErrObjectNotFound(err) {
+				return nil
+			}
+			if err == nil {
+				err = errors.New("canary object still readable after its deletion")
+			}
+			return err
+		},
+	}
+
+	for _, step := range probeSteps {
+		start := time.Now()
+		err := steps[step]()
+		res.Latencies[step] = time.Since(start)
+		if err != nil {
+			res.FailedStep = step
+			logger.LogIf(ctx, fmt.Errorf("consistency probe of pool %d set %d failed to %s %s: %w",
+				poolIdx+1, setIdx+1, step, object, err))
+			if step != probeStepWrite && step != probeStepDelete {
+				// Do not leave the canary behind.
+				set.DeleteObject(ctx, bucket, object, ObjectOptions{})
+			}
+			return res
+		}
+	}
+	res.OK = true
+	return res
+}
+
+// probeListSet lists the drives of the erasure set and returns an error
+// unless the object is listed by a read quorum of the drives.
+func probeListSet(ctx context.Context, set *erasureObjects, bucket, object string) error {
+	disks := set.getDisks()
+	readQuorum := len(disks) - set.defaultParityCount
+
+	found := false
+	err := listPathRaw(ctx, listPathRawOptions{
+		disks:     disks,
+		bucket:    bucket,
+		path:      pathJoin(consistencyProbePrefix, SlashSeparator),
+		recursive: true,
+		minDisks:  readQuorum,
+		agreed: func(entry metaCacheEntry) {
+			found = found || entry.name == object
+		},
+		partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+			var n int
+			for _, entry := range entries {
+				if entry.name == object {
+					n++
+				}
+			}
+			found = found || n >= readQuorum
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errFileNotFound
+	}
+	return nil
+}
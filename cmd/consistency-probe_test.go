@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/internal/config/storageclass"
+)
+
+func TestConsistencyProbes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(sc storageclass.Config) {
+		globalStorageClass = sc
+	}(globalStorageClass)
+	globalStorageClass = storageclass.Config{}
+
+	obj, fsDirs, err := prepareErasureSets32(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	probes := &consistencyProbes{results: make(map[[2]int]ConsistencyProbeResult)}
+	defer func(p *consistencyProbes) {
+		globalConsistencyProbes = p
+	}(globalConsistencyProbes)
+	globalConsistencyProbes = probes
+
+	z.probeAllSets(ctx)
+	status := probes.status()
+	if !status.Healthy || len(status.Sets) != len(z.getServerPools()[0].sets) {
+		t.Fatalf("expected all %d sets to be healthy, got %+v", len(z.getServerPools()[0].sets), status)
+	}
+	for _, res := range status.Sets {
+		if len(res.Latencies) != len(probeSteps) {
+			t.Fatalf("expected the latency of all steps, got %v", res.Latencies)
+		}
+	}
+
+	// The canaries are deleted after the probes.
+	for setIdx, set := range z.getServerPools()[0].sets {
+		object := consistencyProbeObject(z.getServerPools()[0], setIdx)
+		if _, err = set.GetObjectInfo(ctx, minioMetaBucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("expected the canary of set %d to be deleted, got %v", setIdx, err)
+		}
+	}
+
+	// Removing all drives of a set but its parity fails its probe.
+	set := z.getServerPools()[0].sets[0]
+	disks := set.getDisks()
+	for _, disk := range disks[:set.defaultParityCount+1] {
+		if err = os.RemoveAll(disk.String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	z.probeAllSets(ctx)
+	status = probes.status()
+	if status.Healthy {
+		t.Fatal("expected the probes to fail")
+	}
+	for _, res := range status.Sets {
+		if (res.Set == 0) == res.OK {
+			t.Fatalf("unexpected result of set %d: %+v", res.Set, res)
+		}
+		if res.Set == 0 && (res.FailedStep != probeStepWrite || res.Failures != 1) {
+			t.Fatalf("expected the write to fail once, got %+v", res)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// Operations of the scanner control API.
+const (
+	scannerOpPause     = "pause"
+	scannerOpResume    = "resume"
+	scannerOpFullCycle = "full-cycle"
+)
+
+// ScannerStatus is the state of the data scanner on a node.
+type ScannerStatus struct {
+	Node   string `json:"node"`
+	Paused bool   `json:"paused"`
+	// Leader is true on the node running the scanner cycles, the cycle
+	// fields below are only set on the leader.
+	Leader bool `json:"leader"`
+
+	// Buckets to scan at full depth in the next cycle.
+	FullCycleBuckets []string `json:"fullCycleBuckets,omitempty"`
+
+	Cycle          uint64    `json:"cycle,omitempty"`
+	CycleStarted   time.Time `json:"cycleStarted,omitempty"`
+	BucketsTotal   int       `json:"bucketsTotal,omitempty"`
+	BucketsScanned int       `json:"bucketsScanned,omitempty"`
+	// Scanning lists the buckets being scanned.
+	Scanning []string `json:"scanning,omitempty"`
+	// ETA is the estimated end of the current cycle.
+	ETA time.Time `json:"eta,omitempty"`
+
+	LastCycleDuration time.Duration `json:"lastCycleDuration,omitempty"`
+	LastCycleEnded    time.Time     `json:"lastCycleEnded,omitempty"`
+}
+
+// scannerControl pauses and resumes the data scanner, forces full depth
+// cycles on buckets and tracks the progress of the current cycle.
+type scannerControl struct {
+	mu sync.Mutex
+	// resumeCh is closed on resume, nil if the scanner is not paused.
+	resumeCh chan struct{}
+	// triggerCh starts a cycle immediately.
+	triggerCh chan struct{}
+	// fullCycle holds the buckets to scan at full depth in the next
+	// cycle, fullScan those of the current cycle.
+	fullCycle set.StringSet
+	fullScan  set.StringSet
+
+	leader         bool
+	cycle          uint64
+	cycleStarted   time.Time
+	bucketsTotal   int
+	bucketsScanned int
+	scanning       map[string]int
+
+	lastCycleDuration time.Duration
+	lastCycleEnded    time.Time
+}
+
+var globalScannerControl = newScannerControl()
+
+func newScannerControl() *scannerControl {
+	return &scannerControl{
+		triggerCh: make(chan struct{}, 1),
+		fullCycle: set.NewStringSet(),
+		fullScan:  set.NewStringSet(),
+		scanning:  make(map[string]int),
+	}
+}
+
+// apply applies a scanner control operation on this node.
+func (c *scannerControl) apply(op, bucket string) error {
+	switch op {
+	case scannerOpPause:
+		c.pause()
+	case scannerOpResume:
+		c.resume()
+	case scannerOpFullCycle:
+		if bucket == "" {
+			return errInvalidArgument
+		}
+		c.forceFullCycle(bucket)
+	default:
+		return errInvalidArgument
+	}
+	return nil
+}
+
+func (c *scannerControl) pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumeCh == nil {
+		c.resumeCh = make(chan struct{})
+	}
+}
+
+func (c *scannerControl) resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumeCh != nil {
+		close(c.resumeCh)
+		c.resumeCh = nil
+	}
+}
+
+// waitWhilePaused blocks while the scanner is paused.
+func (c *scannerControl) waitWhilePaused(ctx context.Context) {
+	c.mu.Lock()
+	ch := c.resumeCh
+	c.mu.Unlock()
+	if ch == nil {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-ch:
+	}
+}
+
+// forceFullCycle schedules a full depth scan of bucket and starts a
+// cycle immediately, unless one is running.
+func (c *scannerControl) forceFullCycle(bucket string) {
+	c.mu.Lock()
+	c.fullCycle.Add(bucket)
+	c.mu.Unlock()
+	select {
+	case c.triggerCh <- struct{}{}:
+	default:
+	}
+}
+
+// isFullScan returns true if bucket is scanned at full depth in the
+// current cycle.
+func (c *scannerControl) isFullScan(bucket string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fullScan.Contains(bucket)
+}
+
+// startCycle records the start of a cycle on the leader.
+func (c *scannerControl) startCycle(cycle uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.leader = true
+	c.cycle = cycle
+	c.cycleStarted = UTCNow()
+	c.bucketsTotal = 0
+	c.bucketsScanned = 0
+	c.scanning = make(map[string]int)
+	c.fullScan = c.fullCycle
+	c.fullCycle = set.NewStringSet()
+}
+
+// endCycle records the end of the current cycle.
+func (c *scannerControl) endCycle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastCycleEnded = UTCNow()
+	c.lastCycleDuration = c.lastCycleEnded.Sub(c.cycleStarted)
+	c.cycleStarted = time.Time{}
+	c.scanning = make(map[string]int)
+	c.fullScan = set.NewStringSet()
+}
+
+// addBuckets adds n bucket scans to the current cycle.
+func (c *scannerControl) addBuckets(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bucketsTotal += n
+}
+
+func (c *scannerControl) bucketStarted(bucket string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scanning[bucket]++
+}
+
+func (c *scannerControl) bucketFinished(bucket string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bucketsScanned++
+	if c.scanning[bucket]--; c.scanning[bucket] <= 0 {
+		delete(c.scanning, bucket)
+	}
+}
+
+// status returns the state of the scanner on this node.
+func (c *scannerControl) status() ScannerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := ScannerStatus{
+		Node:              globalLocalNodeName,
+		Paused:            c.resumeCh != nil,
+		Leader:            c.leader,
+		FullCycleBuckets:  c.fullCycle.ToSlice(),
+		LastCycleDuration: c.lastCycleDuration,
+		LastCycleEnded:    c.lastCycleEnded,
+	}
+	if c.cycleStarted.IsZero() {
+		return st
+	}
+	st.Cycle = c.cycle
+	st.CycleStarted = c.cycleStarted
+	st.BucketsTotal = c.bucketsTotal
+	st.BucketsScanned = c.bucketsScanned
+	for bucket := range c.scanning {
+		st.Scanning = append(st.Scanning, bucket)
+	}
+	sort.Strings(st.Scanning)
+	st.ETA = scannerCycleETA(c.cycleStarted, UTCNow(), c.bucketsScanned, c.bucketsTotal, c.lastCycleDuration)
+	return st
+}
+
+// scannerCycleETA estimates the end of a cycle started at start, from the
+// bucket scans done so far or else from the duration of the last cycle.
+func scannerCycleETA(start, now time.Time, scanned, total int, last time.Duration) time.Time {
+	elapsed := now.Sub(start)
+	switch {
+	case scanned > 0 && total >= scanned:
+		return start.Add(time.Duration(float64(elapsed) * float64(total) / float64(scanned)))
+	case last > 0:
+		return start.Add(last)
+	}
+	return time.Time{}
+}
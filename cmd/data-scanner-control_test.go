@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScannerControl(t *testing.T) {
+	c := newScannerControl()
+
+	if err := c.apply("stop", ""); err == nil {
+		t.Fatal("expected unknown operation to fail")
+	}
+	if err := c.apply(scannerOpFullCycle, ""); err == nil {
+		t.Fatal("expected full cycle without bucket to fail")
+	}
+
+	// Pause blocks until resumed.
+	if err := c.apply(scannerOpPause, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !c.status().Paused {
+		t.Fatal("expected scanner to be paused")
+	}
+	done := make(chan struct{})
+	go func() {
+		c.waitWhilePaused(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("expected scanner to wait while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := c.apply(scannerOpResume, ""); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected scanner to resume")
+	}
+
+	// A forced full cycle triggers a cycle and applies to it only.
+	if err := c.apply(scannerOpFullCycle, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.triggerCh:
+	default:
+		t.Fatal("expected a cycle to be triggered")
+	}
+	if c.isFullScan("bucket") {
+		t.Fatal("expected full scan to start with the next cycle")
+	}
+	c.startCycle(10)
+	if !c.isFullScan("bucket") || c.isFullScan("other") {
+		t.Fatal("expected only bucket to be scanned at full depth")
+	}
+	c.addBuckets(2)
+	c.bucketStarted("bucket")
+	st := c.status()
+	if !st.Leader || st.Cycle != 10 || st.BucketsTotal != 2 || len(st.Scanning) != 1 || st.Scanning[0] != "bucket" {
+		t.Fatalf("unexpected status %#v", st)
+	}
+	c.bucketFinished("bucket")
+	if st = c.status(); st.BucketsScanned != 1 || len(st.Scanning) != 0 {
+		t.Fatalf("unexpected status %#v", st)
+	}
+	c.endCycle()
+	if c.isFullScan("bucket") {
+		t.Fatal("expected full scan to end with the cycle")
+	}
+	if st = c.status(); st.Cycle != 0 || st.LastCycleEnded.IsZero() {
+		t.Fatalf("unexpected status %#v", st)
+	}
+}
+
+func TestScannerCycleETA(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour)
+	if eta := scannerCycleETA(start, now, 1, 4, 0); !eta.Equal(start.Add(4 * time.Hour)) {
+		t.Fatalf("unexpected eta %v", eta)
+	}
+	if eta := scannerCycleETA(start, now, 0, 4, 2*time.Hour); !eta.Equal(start.Add(2 * time.Hour)) {
+		t.Fatalf("unexpected eta %v", eta)
+	}
+	if eta := scannerCycleETA(start, now, 0, 4, 0); !eta.IsZero() {
+		t.Fatalf("expected no eta, got %v", eta)
+	}
+}
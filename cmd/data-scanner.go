@@ -67,6 +67,10 @@ var (
 	scannerCycle   = &safeDuration{
 		t: dataScannerStartDelay,
 	}
+	// Number of prefix levels below buckets which are never compacted
+	// for small size, updated when config is loaded.
+	// Must be accessed atomically.
+	scannerPrefixDepth int32
 )
 
 // initDataScanner will start the scanner in the background.
@@ -135,36 +139,44 @@ func runDataScanner(pctx context.Context, objAPI ObjectLayer) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-globalScannerControl.triggerCh:
+			// A full cycle was forced on a bucket, start a cycle now.
+			if !scannerTimer.Stop() {
+				<-scannerTimer.C
+			}
 		case <-scannerTimer.C:
-			// Reset the timer for next cycle.
-			scannerTimer.Reset(scannerCycle.Get())
+		}
+		// Reset the timer for next cycle.
+		scannerTimer.Reset(scannerCycle.Get())
 
-			if intDataUpdateTracker.debug {
-				console.Debugln("starting scanner cycle")
-			}
+		globalScannerControl.waitWhilePaused(ctx)
+		if intDataUpdateTracker.debug {
+			console.Debugln("starting scanner cycle")
+		}
+		globalScannerControl.startCycle(nextBloomCycle)
 
-			// Wait before starting next cycle and wait on startup.
-			results := make(chan DataUsageInfo, 1)
-			go storeDataUsageInBackend(ctx, objAPI, results)
-			bf, err := globalNotificationSys.updateBloomFilter(ctx, nextBloomCycle)
-			logger.LogIf(ctx, err)
-			err = objAPI.NSScanner(ctx, bf, results, uint32(nextBloomCycle))
-			logger.LogIf(ctx, err)
-			if err == nil {
-				// Store new cycle...
-				nextBloomCycle++
-				var tmp [8]byte
-				binary.LittleEndian.PutUint64(tmp[:], nextBloomCycle)
-				r, err := hash.NewReader(bytes.NewReader(tmp[:]), int64(len(tmp)), "", "", int64(len(tmp)))
-				if err != nil {
-					logger.LogIf(ctx, err)
-					continue
-				}
+		// Wait before starting next cycle and wait on startup.
+		results := make(chan DataUsageInfo, 1)
+		go storeDataUsageInBackend(ctx, objAPI, results)
+		bf, err := globalNotificationSys.updateBloomFilter(ctx, nextBloomCycle)
+		logger.LogIf(ctx, err)
+		err = objAPI.NSScanner(ctx, bf, results, uint32(nextBloomCycle))
+		logger.LogIf(ctx, err)
+		globalScannerControl.endCycle()
+		if err == nil {
+			// Store new cycle...
+			nextBloomCycle++
+			var tmp [8]byte
+			binary.LittleEndian.PutUint64(tmp[:], nextBloomCycle)
+			r, err := hash.NewReader(bytes.NewReader(tmp[:]), int64(len(tmp)), "", "", int64(len(tmp)))
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
 
-				_, err = objAPI.PutObject(ctx, dataUsageBucket, dataUsageBloomName, NewPutObjReader(r), ObjectOptions{})
-				if !isErrBucketNotFound(err) {
-					logger.LogIf(ctx, err)
-				}
+			_, err = objAPI.PutObject(ctx, dataUsageBucket, dataUsageBloomName, NewPutObjReader(r), ObjectOptions{})
+			if !isErrBucketNotFound(err) {
+				logger.LogIf(ctx, err)
 			}
 		}
 	}
@@ -187,6 +199,7 @@ type folderScanner struct {
 	dataUsageScannerDebug bool
 	healFolderInclude     uint32 // Include a clean folder one in n cycles.
 	healObjectSelect      uint32 // Do a heal check on an object once every n cycles. Must divide into healFolderInclude
+	prefixDepth           int    // Keep usage of prefixes up to this depth below the bucket.
 
 	disks []StorageAPI
 
@@ -284,6 +297,7 @@ func scanDataFolder(ctx context.Context, basePath string, cache dataUsageCache,
 		dataUsageScannerDebug: intDataUpdateTracker.debug,
 		healFolderInclude:     0,
 		healObjectSelect:      0,
+		prefixDepth:           int(atomic.LoadInt32(&scannerPrefixDepth)),
 		updates:               cache.Info.updates,
 	}
 
@@ -306,7 +320,7 @@ func scanDataFolder(ctx context.Context, basePath string, cache dataUsageCache,
 		// Do a heal check on an object once every n cycles. Must divide into healFolderInclude
 		s.healObjectSelect = healObjectSelectProb
 	}
-	if len(cache.Info.BloomFilter) > 0 {
+	if len(cache.Info.BloomFilter) > 0 && !cache.Info.FullScan {
 		s.withFilter = &bloomFilter{BloomFilter: &bloom.BloomFilter{}}
 		_, err := s.withFilter.ReadFrom(bytes.NewReader(cache.Info.BloomFilter))
 		if err != nil {
@@ -361,6 +375,13 @@ func (f *folderScanner) sendUpdate() {
 	}
 }
 
+// keepPrefix returns true if folder is within the configured prefix
+// depth below the bucket and must not be compacted for being small.
+func (f *folderScanner) keepPrefix(folder string) bool {
+	depth := strings.Count(strings.TrimPrefix(folder, f.newCache.Info.Name), slashSeparator)
+	return depth > 0 && depth <= f.prefixDepth
+}
+
 // scanFolder will scan the provided folder.
 // Files found in the folders will be added to f.newCache.
 // If final is provided folders will be put into f.newFolders or f.existingFolders.
@@ -421,6 +442,7 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 				folder.objectHealProbDiv = f.healFolderInclude
 			}
 		}
+		globalScannerControl.waitWhilePaused(ctx)
 		scannerSleeper.Sleep(ctx, dataScannerSleepPerFolder)
 
 		var existingFolders, newFolders []cachedFolder
@@ -610,7 +632,7 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 			// Check if we should skip scanning folder...
 			// We can only skip if we are not indexing into a compacted destination
 			// and the entry itself is compacted.
-			if !into.Compacted && f.oldCache.isCompacted(h) {
+			if !into.Compacted && !f.oldCache.Info.FullScan && f.oldCache.isCompacted(h) {
 				if !h.mod(f.oldCache.Info.NextCycle, dataUsageUpdateDirCycles) {
 					if f.healObjectSelect == 0 || !h.mod(f.oldCache.Info.NextCycle, f.healFolderInclude/folder.objectHealProbDiv) {
 						// Transfer and add as child...
@@ -769,7 +791,7 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 		f.newCache.replaceHashed(thisHash, folder.parent, *into)
 	}
 
-	if !into.Compacted && f.newCache.Info.Name != folder.name {
+	if !into.Compacted && f.newCache.Info.Name != folder.name && !f.keepPrefix(folder.name) {
 		flat := f.newCache.sizeRecursive(thisHash.Key())
 		flat.Compacted = true
 		var compact bool
@@ -832,6 +854,7 @@ type scannerItem struct {
 type sizeSummary struct {
 	totalSize       int64
 	versions        uint64
+	noncurrentSize  int64
 	replicatedSize  int64
 	pendingSize     int64
 	failedSize      int64
@@ -908,6 +931,7 @@ func (i *scannerItem) applyLifecycle(ctx context.Context, o ObjectLayer, oi Obje
 			Name:             i.objectPath(),
 			UserTags:         oi.UserTags,
 			ModTime:          oi.ModTime,
+			Size:             size,
 			VersionID:        oi.VersionID,
 			DeleteMarker:     oi.DeleteMarker,
 			IsLatest:         oi.IsLatest,
@@ -979,7 +1003,12 @@ func (i *scannerItem) applyMaxNoncurrentVersionLimit(ctx context.Context, o Obje
 		return fivs, nil
 	}
 
-	lim := i.lifeCycle.NoncurrentVersionsExpirationLimit(lifecycle.ObjectOpts{Name: i.objectPath()})
+	opts := lifecycle.ObjectOpts{Name: i.objectPath()}
+	if len(fivs) > 0 {
+		// Object size filters apply to the latest version.
+		opts.Size, _ = fivs[0].ToObjectInfo(i.bucket, i.objectPath()).GetActualSize()
+	}
+	lim := i.lifeCycle.NoncurrentVersionsExpirationLimit(opts)
 	if lim == 0 || len(fivs) <= lim+1 { // fewer than lim _noncurrent_ versions
 		return fivs, nil
 	}
@@ -1012,10 +1041,53 @@ func (i *scannerItem) applyMaxNoncurrentVersionLimit(ctx context.Context, o Obje
 	return fivs, nil
 }
 
+// applyNewerNoncurrentVersionActions expires or transitions noncurrent versions per lifecycle rules
+// limited by NewerNoncurrentVersions, which retain the given number of newer noncurrent versions.
+// Note: This function doesn't update sizeSummary since it always removes versions that it doesn't return.
+func (i *scannerItem) applyNewerNoncurrentVersionActions(ctx context.Context, o ObjectLayer, fivs []FileInfo) ([]FileInfo, error) {
+	if i.lifeCycle == nil || !i.lifeCycle.HasNewerNoncurrentVersions() || len(fivs) <= 1 {
+		return fivs, nil
+	}
+
+	rcfg, _ := globalBucketObjectLockSys.Get(i.bucket)
+	remaining := fivs[:1]
+	var toDel []ObjectToDelete
+	for n, fi := range fivs[1:] {
+		// n is the number of noncurrent versions newer than fi.
+		obj := fi.ToObjectInfo(i.bucket, i.objectPath())
+		switch i.lifeCycle.ComputeNewerNoncurrentAction(obj.ToLifecycleOpts(), n) {
+		case lifecycle.DeleteVersionAction:
+			if rcfg.LockEnabled && enforceRetentionForDeletion(ctx, obj) {
+				if i.debug {
+					console.Debugf(applyVersionActionsLogPrefix+" lifecycle: %s v(%s) is locked, not deleting\n", obj.Name, obj.VersionID)
+				}
+				break
+			}
+			toDel = append(toDel, ObjectToDelete{
+				ObjectName: fi.Name,
+				VersionID:  fi.VersionID,
+			})
+			continue
+		case lifecycle.TransitionVersionAction:
+			applyTransitionRule(obj)
+		}
+		remaining = append(remaining, fi)
+	}
+
+	if len(toDel) > 0 {
+		globalExpiryState.enqueueByMaxNoncurrent(i.bucket, toDel)
+	}
+	return remaining, nil
+}
+
 // applyVersionActions will apply lifecycle checks on all versions of a scanned item. Returns versions that remain
 // after applying lifecycle checks configured.
 func (i *scannerItem) applyVersionActions(ctx context.Context, o ObjectLayer, fivs []FileInfo) ([]FileInfo, error) {
-	return i.applyMaxNoncurrentVersionLimit(ctx, o, fivs)
+	fivs, err := i.applyMaxNoncurrentVersionLimit(ctx, o, fivs)
+	if err != nil {
+		return fivs, err
+	}
+	return i.applyNewerNoncurrentVersionActions(ctx, o, fivs)
 }
 
 // applyActions will apply lifecycle checks on to a scanned item.
@@ -1023,6 +1095,11 @@ func (i *scannerItem) applyVersionActions(ctx context.Context, o ObjectLayer, fi
 // The metadata will be compared to consensus on the object layer before any changes are applied.
 // If no metadata is supplied, -1 is returned if no action is taken.
 func (i *scannerItem) applyActions(ctx context.Context, o ObjectLayer, oi ObjectInfo, sizeS *sizeSummary) int64 {
+	// Resume restores waiting on the remote tier to rehydrate the object.
+	if isRestoreRehydrating(oi) {
+		globalRehydrationState.queue(oi)
+	}
+
 	applied, size := i.applyLifecycle(ctx, o, oi)
 	// For instance, an applied lifecycle means we remove/transitioned an object
 	// from the current deployment, which means we don't have to call healing
@@ -1105,7 +1182,7 @@ func applyExpiryOnNonTransitionedObjects(ctx context.Context, objLayer ObjectLay
 		opts.VersionID = obj.VersionID
 	}
 	if opts.VersionID == "" {
-		opts.Versioned = globalBucketVersioningSys.Enabled(obj.Bucket)
+		opts.Versioned = globalBucketVersioningSys.PrefixEnabled(obj.Bucket, obj.Name)
 	}
 
 	obj, err := objLayer.DeleteObject(ctx, obj.Bucket, obj.Name, opts)
@@ -1170,12 +1247,12 @@ func (i *scannerItem) healReplication(ctx context.Context, o ObjectLayer, oi Obj
 		if oi.ReplicationStatus == replication.Pending ||
 			oi.ReplicationStatus == replication.Failed ||
 			oi.VersionPurgeStatus == Failed || oi.VersionPurgeStatus == Pending {
-			i.healReplicationDeletes(ctx, o, roi)
+			healReplicationDeletes(roi)
 			return
 		}
 		// if replication status is Complete on DeleteMarker and existing object resync required
 		if roi.ExistingObjResync.mustResync() && (oi.ReplicationStatus == replication.Completed || oi.ReplicationStatus.Empty()) {
-			i.healReplicationDeletes(ctx, o, roi)
+			healReplicationDeletes(roi)
 			return
 		}
 		return
@@ -1225,7 +1302,7 @@ func (i *scannerItem) healReplication(ctx context.Context, o ObjectLayer, oi Obj
 }
 
 // healReplicationDeletes will heal a scanned deleted item that failed to replicate deletes.
-func (i *scannerItem) healReplicationDeletes(ctx context.Context, o ObjectLayer, roi ReplicateObjectInfo) {
+func healReplicationDeletes(roi ReplicateObjectInfo) {
 	// handle soft delete and permanent delete failures here.
 	if roi.DeleteMarker || !roi.VersionPurgeStatus.Empty() {
 		versionID := ""
@@ -1386,6 +1463,8 @@ const (
 	ILMFreeVersionDelete = "ilm:free-version-delete"
 	// ILMTransition - audit trail for ILM transitioning.
 	ILMTransition = " ilm:transition"
+	// ILMAbortMultipart - audit trail for ILM abort of incomplete multipart uploads
+	ILMAbortMultipart = "ilm:abort-multipart"
 )
 
 func auditLogLifecycle(ctx context.Context, oi ObjectInfo, trigger string) {
@@ -1397,6 +1476,8 @@ func auditLogLifecycle(ctx context.Context, oi ObjectInfo, trigger string) {
 		apiName = "ILMFreeVersionDelete"
 	case ILMTransition:
 		apiName = "ILMTransition"
+	case ILMAbortMultipart:
+		apiName = "ILMAbortMultipartUpload"
 	}
 	auditLogInternal(ctx, oi.Bucket, oi.Name, AuditLogOptions{
 		Trigger:   trigger,
@@ -52,6 +52,7 @@ type dataUsageEntry struct {
 	Size             int64                `msg:"sz"`
 	Objects          uint64               `msg:"os"`
 	Versions         uint64               `msg:"vs"` // Versions that are not delete markers.
+	NoncurrentSize   int64                `msg:"ncs,omitempty"`
 	ObjSizes         sizeHistogram        `msg:"szs"`
 	ReplicationStats *replicationAllStats `msg:"rs,omitempty"`
 	AllTierStats     *allTierStats        `msg:"ats,omitempty"`
@@ -282,6 +283,8 @@ type dataUsageCacheInfo struct {
 	// should skip healing the disk
 	SkipHealing bool
 	BloomFilter []byte `msg:"BloomFilter,omitempty"`
+	// FullScan scans all folders regardless of the bloom filter.
+	FullScan bool `msg:"FullScan,omitempty"`
 
 	// Active lifecycle, if any on the bucket
 	lifeCycle *lifecycle.Lifecycle `msg:"-"`
@@ -296,6 +299,7 @@ type dataUsageCacheInfo struct {
 func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 	e.Size += summary.totalSize
 	e.Versions += summary.versions
+	e.NoncurrentSize += summary.noncurrentSize
 	e.ObjSizes.add(summary.totalSize)
 
 	if e.ReplicationStats == nil {
@@ -333,6 +337,7 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 func (e *dataUsageEntry) merge(other dataUsageEntry) {
 	e.Objects += other.Objects
 	e.Versions += other.Versions
+	e.NoncurrentSize += other.NoncurrentSize
 	e.Size += other.Size
 	if other.ReplicationStats != nil {
 		if e.ReplicationStats == nil {
@@ -437,6 +442,22 @@ func (d *dataUsageCache) isCompacted(h dataUsageHash) bool {
 	return due.Compacted
 }
 
+// isParentCompacted returns whether the closest parent of prefix in
+// bucket found in the cache is compacted, and hence the usage of
+// prefix was merged into it.
+func (d *dataUsageCache) isParentCompacted(bucket, prefix string) bool {
+	for prefix != "" {
+		prefix = path.Dir(prefix)
+		if prefix == "." {
+			prefix = ""
+		}
+		if due := d.find(path.Join(bucket, prefix)); due != nil {
+			return due.Compacted
+		}
+	}
+	return false
+}
+
 // findChildrenCopy returns a copy of the children of the supplied hash.
 func (d *dataUsageCache) findChildrenCopy(h dataUsageHash) dataUsageHashMap {
 	ch := d.Cache[h.String()].Children
@@ -784,6 +805,8 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 		bui := BucketUsageInfo{
 			Size:                 uint64(flat.Size),
 			ObjectsCount:         flat.Objects,
+			VersionsCount:        flat.Versions,
+			NoncurrentSize:       uint64(flat.NoncurrentSize),
 			ObjectSizesHistogram: flat.ObjSizes.toMap(),
 		}
 		if flat.ReplicationStats != nil {
@@ -816,6 +839,8 @@ func (d *dataUsageCache) bucketUsageInfo(bucket string) BucketUsageInfo {
 	bui := BucketUsageInfo{
 		Size:                 uint64(flat.Size),
 		ObjectsCount:         flat.Objects,
+		VersionsCount:        flat.Versions,
+		NoncurrentSize:       uint64(flat.NoncurrentSize),
 		ObjectSizesHistogram: flat.ObjSizes.toMap(),
 	}
 	if flat.ReplicationStats != nil {
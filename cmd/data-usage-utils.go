@@ -59,6 +59,8 @@ type BucketUsageInfo struct {
 	ReplicationFailedCountV1 uint64 `json:"objectsFailedReplicationCount"`
 
 	ObjectsCount         uint64                           `json:"objectsCount"`
+	VersionsCount        uint64                           `json:"versionsCount"`
+	NoncurrentSize       uint64                           `json:"noncurrentSize"`
 	ObjectSizesHistogram map[string]uint64                `json:"objectsSizesHistogram"`
 	ReplicaSize          uint64                           `json:"objectReplicaTotalSize"`
 	ReplicationInfo      map[string]BucketTargetUsageInfo `json:"objectsReplicationInfo"`
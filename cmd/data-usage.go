@@ -21,7 +21,10 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"path"
+	"sort"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio/internal/hash"
@@ -71,7 +74,7 @@ func loadPrefixUsageFromBackend(ctx context.Context, objAPI ObjectLayer, bucket
 	cache := dataUsageCache{}
 
 	m := make(map[string]uint64)
-	for _, pool := range z.serverPools {
+	for _, pool := range z.getServerPools() {
 		for _, er := range pool.sets {
 			// Load bucket usage prefixes
 			if err := cache.load(ctx, er, bucket+slashSeparator+dataUsageCacheName); err == nil {
@@ -94,6 +97,102 @@ func loadPrefixUsageFromBackend(ctx context.Context, objAPI ObjectLayer, bucket
 	return m, nil
 }
 
+// PrefixUsage is the usage of a single prefix.
+type PrefixUsage struct {
+	Prefix   string `json:"prefix"`
+	Size     uint64 `json:"size"`
+	Objects  uint64 `json:"objects"`
+	Versions uint64 `json:"versions"`
+}
+
+// PrefixUsageInfo is the usage of a prefix in a bucket broken down
+// by the prefixes directly below it, as recorded by the data scanner.
+type PrefixUsageInfo struct {
+	LastUpdate time.Time `json:"lastUpdate"`
+	Bucket     string    `json:"bucket"`
+	PrefixUsage
+	// Compacted is true if the scanner did not keep the usage
+	// below the prefix separately in all erasure sets.
+	Compacted bool `json:"compacted,omitempty"`
+	// Prefixes directly below the prefix, largest first.
+	Prefixes []PrefixUsage `json:"prefixes"`
+}
+
+// loadPrefixDrilldownFromBackend returns the usage of prefix in bucket
+// and of the prefixes directly below it, merged from the usage caches
+// of all erasure sets.
+func loadPrefixDrilldownFromBackend(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) (PrefixUsageInfo, error) {
+	prefix = strings.Trim(prefix, slashSeparator)
+	info := PrefixUsageInfo{Bucket: bucket}
+	if prefix != "" {
+		info.Prefix = prefix + slashSeparator
+	}
+
+	var stores []objectIO
+	switch z := objAPI.(type) {
+	case *erasureServerPools:
+		for _, pool := range z.getServerPools() {
+			for _, er := range pool.sets {
+				stores = append(stores, er)
+			}
+		}
+	case *FSObjects:
+		stores = append(stores, z)
+	}
+
+	prefixes := make(map[string]*PrefixUsage)
+	for _, store := range stores {
+		var cache dataUsageCache
+		if err := cache.load(ctx, store, path.Join(bucket, dataUsageCacheName)); err != nil {
+			return info, err
+		}
+		if cache.Info.LastUpdate.After(info.LastUpdate) {
+			info.LastUpdate = cache.Info.LastUpdate
+		}
+		e := cache.find(path.Join(bucket, prefix))
+		if e == nil {
+			// Either no objects below the prefix in this set
+			// or the usage was compacted into a parent.
+			if cache.isParentCompacted(bucket, prefix) {
+				info.Compacted = true
+			}
+			continue
+		}
+		if e.Compacted {
+			info.Compacted = true
+		}
+
+		flat := cache.flatten(*e)
+		info.Size += uint64(flat.Size)
+		info.Objects += flat.Objects
+		info.Versions += flat.Versions
+
+		for id, child := range cache.flattenChildrens(*e) {
+			name := decodeDirObject(strings.TrimPrefix(id, bucket+slashSeparator)) + slashSeparator
+			pu, ok := prefixes[name]
+			if !ok {
+				pu = &PrefixUsage{Prefix: name}
+				prefixes[name] = pu
+			}
+			pu.Size += uint64(child.Size)
+			pu.Objects += child.Objects
+			pu.Versions += child.Versions
+		}
+	}
+
+	info.Prefixes = make([]PrefixUsage, 0, len(prefixes))
+	for _, pu := range prefixes {
+		info.Prefixes = append(info.Prefixes, *pu)
+	}
+	sort.Slice(info.Prefixes, func(i, j int) bool {
+		if info.Prefixes[i].Size != info.Prefixes[j].Size {
+			return info.Prefixes[i].Size > info.Prefixes[j].Size
+		}
+		return info.Prefixes[i].Prefix < info.Prefixes[j].Prefix
+	})
+	return info, nil
+}
+
 func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (DataUsageInfo, error) {
 	r, err := objAPI.GetObjectNInfo(ctx, dataUsageBucket, dataUsageObjName, nil, http.Header{}, readLock, ObjectOptions{})
 	if err != nil {
@@ -26,6 +26,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"sync/atomic"
 	"testing"
 )
 
@@ -536,6 +538,128 @@ func generateUsageTestFiles(t *testing.T, base, bucket string, nFolders, nFiles,
 	}
 }
 
+func TestDataUsageUpdatePrefixDepth(t *testing.T) {
+	base, err := ioutil.TempDir("", "TestDataUsageUpdatePrefixDepth")
+	if err != nil {
+		t.Skip(err)
+	}
+	const bucket = "bucket"
+	defer os.RemoveAll(base)
+	var files = []usageTestFile{
+		{name: "rootfile", size: 10000},
+		{name: "dir1/d1file", size: 2000},
+		{name: "dir1/dira/dafile", size: 100000},
+		{name: "dir1/dira/dirasub/dcfile", size: 1000000},
+		{name: "dir1/dira/dirasub/sublevel3/dccccfile", size: 10},
+	}
+	createUsageTestFiles(t, base, bucket, files)
+
+	getSize := func(item scannerItem) (sizeS sizeSummary, err error) {
+		if item.Typ&os.ModeDir == 0 {
+			var s os.FileInfo
+			s, err = os.Stat(item.Path)
+			if err != nil {
+				return
+			}
+			sizeS.totalSize = s.Size()
+			sizeS.versions++
+			return sizeS, nil
+		}
+		return
+	}
+
+	atomic.StoreInt32(&scannerPrefixDepth, 2)
+	defer atomic.StoreInt32(&scannerPrefixDepth, 0)
+
+	got, err := scanDataFolder(context.Background(), base, dataUsageCache{Info: dataUsageCacheInfo{Name: bucket}}, getSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = []struct {
+		path       string
+		isNil      bool
+		compacted  bool
+		size, objs int
+	}{
+		{path: "/dir1", size: 2000, objs: 1},
+		{path: "/dir1/dira", size: 100000, objs: 1},
+		{path: "/dir1/dira/dirasub", size: 1000010, objs: 2, compacted: true},
+		{path: "/dir1/dira/dirasub/sublevel3", isNil: true},
+	}
+
+	for _, w := range want {
+		p := path.Join(bucket, w.path)
+		t.Run(p, func(t *testing.T) {
+			e := got.find(p)
+			if w.isNil {
+				if e != nil {
+					t.Error("want nil, got", e)
+				}
+				return
+			}
+			if e == nil {
+				t.Fatal("got nil result")
+			}
+			if e.Compacted != w.compacted {
+				t.Error("got compacted", e.Compacted, "want", w.compacted)
+			}
+			if e.Size != int64(w.size) {
+				t.Error("got size", e.Size, "want", w.size)
+			}
+			if e.Objects != uint64(w.objs) {
+				t.Error("got objects", e.Objects, "want", w.objs)
+			}
+		})
+	}
+
+	// The drilldown reports the prefixes dropped by the compaction.
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	defer obj.Shutdown(context.Background())
+	newAllSubsystems()
+	if err = got.save(context.Background(), obj.(*FSObjects), path.Join(bucket, dataUsageCacheName)); err != nil {
+		t.Fatal(err)
+	}
+
+	var drilldown = []struct {
+		prefix    string
+		compacted bool
+		size      uint64
+		prefixes  []string
+	}{
+		{prefix: "", size: 1112010, prefixes: []string{"dir1/"}},
+		{prefix: "dir1/dira", size: 1100010, prefixes: []string{"dir1/dira/dirasub/"}},
+		{prefix: "dir1/dira/dirasub", size: 1000010, compacted: true},
+		{prefix: "dir1/dira/dirasub/sublevel3", compacted: true},
+		{prefix: "dir2"},
+	}
+	for _, w := range drilldown {
+		t.Run("drilldown/"+w.prefix, func(t *testing.T) {
+			info, err := loadPrefixDrilldownFromBackend(context.Background(), obj, bucket, w.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Compacted != w.compacted {
+				t.Error("got compacted", info.Compacted, "want", w.compacted)
+			}
+			if info.Size != w.size {
+				t.Error("got size", info.Size, "want", w.size)
+			}
+			var prefixes []string
+			for _, pu := range info.Prefixes {
+				prefixes = append(prefixes, pu.Prefix)
+			}
+			if !reflect.DeepEqual(prefixes, w.prefixes) {
+				t.Error("got prefixes", prefixes, "want", w.prefixes)
+			}
+		})
+	}
+}
+
 func TestDataUsageCacheSerialize(t *testing.T) {
 	base, err := ioutil.TempDir("", "TestDataUsageCacheSerialize")
 	if err != nil {
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Files of the diagnostics snapshot of a node.
+const (
+	diagHeapProfile      = "heap.pprof"
+	diagGoroutineProfile = "goroutine.pprof"
+	diagGoroutineDump    = "goroutines.txt"
+	diagGCStats          = "gc.json"
+	diagRuntimeMetrics   = "runtime-metrics.txt"
+	diagError            = "error.txt"
+)
+
+// diagnosticsGCStats holds the memory and garbage collector statistics of
+// a node.
+type diagnosticsGCStats struct {
+	Time       time.Time        `json:"time"`
+	Goroutines int              `json:"goroutines"`
+	NumCPU     int              `json:"numCPU"`
+	GOMAXPROCS int              `json:"gomaxprocs"`
+	GoVersion  string           `json:"goVersion"`
+	MemStats   runtime.MemStats `json:"memStats"`
+	// PauseQuantiles holds the minimum, 25%, 50%, 75% and maximum
+	// garbage collection pause times.
+	PauseQuantiles []time.Duration `json:"pauseQuantiles"`
+	LastGC         time.Time       `json:"lastGC"`
+	NumGC          int64           `json:"numGC"`
+	PauseTotal     time.Duration   `json:"pauseTotal"`
+}
+
+// getDiagnosticsData returns a snapshot of the heap and goroutine profiles,
+// the garbage collector statistics and the runtime metrics of this node,
+// by file name.
+func getDiagnosticsData() (map[string][]byte, error) {
+	data := make(map[string][]byte, 5)
+	for name, p := range map[string]struct {
+		profile string
+		debug   int
+	}{
+		diagHeapProfile:      {"heap", 0},
+		diagGoroutineProfile: {"goroutine", 0},
+		diagGoroutineDump:    {"goroutine", 2},
+	} {
+		var buf bytes.Buffer
+		if err := pprof.Lookup(p.profile).WriteTo(&buf, p.debug); err != nil {
+			return nil, err
+		}
+		data[name] = buf.Bytes()
+	}
+
+	st := diagnosticsGCStats{
+		Time:           UTCNow(),
+		Goroutines:     runtime.NumGoroutine(),
+		NumCPU:         runtime.NumCPU(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		GoVersion:      runtime.Version(),
+		PauseQuantiles: make([]time.Duration, 5),
+	}
+	runtime.ReadMemStats(&st.MemStats)
+	gcStats := debug.GCStats{PauseQuantiles: st.PauseQuantiles}
+	debug.ReadGCStats(&gcStats)
+	st.LastGC, st.NumGC, st.PauseTotal = gcStats.LastGC, gcStats.NumGC, gcStats.PauseTotal
+	buf, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	data[diagGCStats] = buf
+
+	data[diagRuntimeMetrics] = runtimeMetricsText()
+	return data, nil
+}
+
+// runtimeMetricsText returns all runtime metrics, one per line, followed
+// by the non empty buckets of the histograms.
+func runtimeMetricsText() []byte {
+	descs := metrics.All()
+	samples := make([]metrics.Sample, len(descs))
+	for i := range descs {
+		samples[i].Name = descs[i].Name
+	}
+	metrics.Read(samples)
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Name < samples[j].Name
+	})
+
+	var buf bytes.Buffer
+	for _, s := range samples {
+		switch s.Value.Kind() {
+		case metrics.KindUint64:
+			fmt.Fprintf(&buf, "%s %d\n", s.Name, s.Value.Uint64())
+		case metrics.KindFloat64:
+			fmt.Fprintf(&buf, "%s %g\n", s.Name, s.Value.Float64())
+		case metrics.KindFloat64Histogram:
+			h := s.Value.Float64Histogram()
+			var total uint64
+			for _, c := range h.Counts {
+				total += c
+			}
+			fmt.Fprintf(&buf, "%s count=%d\n", s.Name, total)
+			for i, c := range h.Counts {
+				if c == 0 {
+					continue
+				}
+				fmt.Fprintf(&buf, "%s [%g, %g) %d\n", s.Name, h.Buckets[i], h.Buckets[i+1], c)
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeDiagnosticsZip writes the diagnostics snapshot of node to zw, in a
+// directory named after the node. An error.txt file is written instead
+// if the snapshot could not be taken.
+func writeDiagnosticsZip(ctx context.Context, zw *zip.Writer, node string, data map[string][]byte, err error) {
+	if err != nil {
+		data = map[string][]byte{diagError: []byte(err.Error())}
+	}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w, zerr := zw.CreateHeader(&zip.FileHeader{
+			Name:     node + SlashSeparator + name,
+			Method:   zip.Deflate,
+			Modified: UTCNow(),
+		})
+		if zerr != nil {
+			logger.LogIf(ctx, zerr)
+			return
+		}
+		if _, zerr = io.Copy(w, bytes.NewReader(data[name])); zerr != nil {
+			logger.LogIf(ctx, zerr)
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sort"
+	"testing"
+
+	"github.com/klauspost/compress/zip"
+)
+
+func TestDiagnosticsZip(t *testing.T) {
+	data, err := getDiagnosticsData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var st diagnosticsGCStats
+	if err = json.Unmarshal(data[diagGCStats], &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.Goroutines == 0 || st.MemStats.HeapAlloc == 0 || len(st.PauseQuantiles) != 5 {
+		t.Fatalf("unexpected gc stats %+v", st)
+	}
+	if !bytes.Contains(data[diagRuntimeMetrics], []byte("/sched/goroutines:goroutines ")) {
+		t.Fatal("expected the goroutines count in the runtime metrics")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeDiagnosticsZip(context.Background(), zw, "node1:9000", data, nil)
+	writeDiagnosticsZip(context.Background(), zw, "node2:9000", nil, errors.New("node offline"))
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name != "node2:9000/"+diagError {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil || string(msg) != "node offline" {
+			t.Fatalf("unexpected error file content %q, %v", msg, err)
+		}
+	}
+	want := []string{
+		"node1:9000/" + diagGCStats,
+		"node1:9000/" + diagGoroutineProfile,
+		"node1:9000/" + diagGoroutineDump,
+		"node1:9000/" + diagHeapProfile,
+		"node1:9000/" + diagRuntimeMetrics,
+		"node2:9000/" + diagError,
+	}
+	sort.Strings(want)
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+}
@@ -357,6 +357,11 @@ func (c *cacheObjects) GetObjectNInfo(ctx context.Context, bucket, object string
 	}
 
 	if rs != nil && !dcache.enableRange {
+		if objInfo.IsRemote() {
+			// Don't fetch the entire object from the remote tier
+			// to serve a range GET.
+			return bkReader, bkErr
+		}
 		go func() {
 			// if range caching is disabled, download entire object.
 			rs = nil
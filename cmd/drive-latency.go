@@ -0,0 +1,348 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	driveLatencyInterval   = 10 * time.Second      // Time between two checks of the drive latencies.
+	driveLatencyWindowSize = 256                   // Number of most recent read calls kept per drive.
+	driveLatencyMinSamples = 32                    // Read calls needed before a percentile is reported.
+	driveLatencyFloor      = 20 * time.Millisecond // Drives faster than this are never suspect.
+	driveLatencyMinDrives  = 3                     // Drives reporting latencies needed to compare them.
+)
+
+// latencyWindow keeps the latencies of the most recent calls.
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples [driveLatencyWindowSize]time.Duration
+	next    int
+	full    bool
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next = 0
+		w.full = true
+	}
+}
+
+// percentile returns the p-th percentile of the recorded latencies,
+// 0 if too few calls were recorded so far.
+func (w *latencyWindow) percentile(p float64) time.Duration {
+	w.mu.Lock()
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	if n < driveLatencyMinSamples {
+		w.mu.Unlock()
+		return 0
+	}
+	sorted := make([]time.Duration, n)
+	copy(sorted, w.samples[:n])
+	w.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(p*float64(n-1))]
+}
+
+// DriveLatencyStatus is the read latency state of a single drive.
+type DriveLatencyStatus struct {
+	Endpoint string `json:"endpoint"`
+	Pool     int    `json:"pool"`
+	Set      int    `json:"set"`
+
+	// ReadLatencyP99 of the drive and the median
+	// of all drives in its erasure set.
+	ReadLatencyP99 time.Duration `json:"readLatencyP99"`
+	SetMedian      time.Duration `json:"setMedian"`
+
+	// Suspect drives are avoided by reads as long as enough
+	// other drives are available.
+	Suspect bool `json:"suspect"`
+	// Since is when the drive was last marked suspect or cleared.
+	Since time.Time `json:"since,omitempty"`
+
+	// When the drive started to be slow, or fast again if suspect.
+	changing time.Time
+}
+
+// driveLatency is the read latency reported by a drive.
+type driveLatency struct {
+	endpoint string
+	p99      time.Duration
+}
+
+// driveQuarantine keeps track of the drives which are suspect
+// of being slow compared to the other drives of their set.
+type driveQuarantine struct {
+	mu       sync.RWMutex
+	drives   map[string]*DriveLatencyStatus
+	suspects int32
+}
+
+var globalDriveQuarantine = newDriveQuarantine()
+
+func newDriveQuarantine() *driveQuarantine {
+	return &driveQuarantine{drives: make(map[string]*DriveLatencyStatus)}
+}
+
+func (q *driveQuarantine) hasSuspects() bool {
+	return atomic.LoadInt32(&q.suspects) > 0
+}
+
+func (q *driveQuarantine) isSuspect(endpoint string) bool {
+	if !q.hasSuspects() {
+		return false
+	}
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	st, ok := q.drives[endpoint]
+	return ok && st.Suspect
+}
+
+// update compares the read latencies of the drives of an erasure set
+// with their median. Drives slower than factor times the median for
+// window are marked suspect, suspect drives faster than that for window
+// are cleared. Returns the drives which were marked suspect or cleared.
+func (q *driveQuarantine) update(pool, set int, drives []driveLatency, factor float64, window time.Duration, now time.Time) (changed []DriveLatencyStatus) {
+	var latencies []time.Duration
+	for _, d := range drives {
+		if d.p99 > 0 {
+			latencies = append(latencies, d.p99)
+		}
+	}
+	var median time.Duration
+	if len(latencies) >= driveLatencyMinDrives {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		median = latencies[len(latencies)/2]
+	}
+	if factor <= 0 {
+		// Disabled, clear all suspect drives right away.
+		window = 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, d := range drives {
+		st, ok := q.drives[d.endpoint]
+		if !ok {
+			st = &DriveLatencyStatus{Endpoint: d.endpoint, Pool: pool, Set: set}
+			q.drives[d.endpoint] = st
+		}
+		st.ReadLatencyP99, st.SetMedian = d.p99, median
+
+		slow := factor > 0 && median > 0 && d.p99 >= driveLatencyFloor &&
+			float64(d.p99) > factor*float64(median)
+		if slow == st.Suspect {
+			st.changing = time.Time{}
+			continue
+		}
+		if st.changing.IsZero() {
+			st.changing = now
+		}
+		if now.Sub(st.changing) < window {
+			continue
+		}
+		st.Suspect, st.Since, st.changing = slow, now, time.Time{}
+		if slow {
+			atomic.AddInt32(&q.suspects, 1)
+		} else {
+			atomic.AddInt32(&q.suspects, -1)
+		}
+		changed = append(changed, *st)
+	}
+	return changed
+}
+
+// status returns the state of all drives ordered by erasure set.
+func (q *driveQuarantine) status() []DriveLatencyStatus {
+	q.mu.RLock()
+	status := make([]DriveLatencyStatus, 0, len(q.drives))
+	for _, st := range q.drives {
+		status = append(status, *st)
+	}
+	q.mu.RUnlock()
+
+	sort.Slice(status, func(i, j int) bool {
+		if status[i].Pool != status[j].Pool {
+			return status[i].Pool < status[j].Pool
+		}
+		if status[i].Set != status[j].Set {
+			return status[i].Set < status[j].Set
+		}
+		return status[i].Endpoint < status[j].Endpoint
+	})
+	return status
+}
+
+// avoidSuspectDrives updates prefer to read from the drives which are not
+// suspect of being slow first, provided enough of them are available to
+// decode the data.
+func avoidSuspectDrives(disks []StorageAPI, readers []io.ReaderAt, prefer []bool, dataBlocks int) {
+	if !globalDriveQuarantine.hasSuspects() {
+		return
+	}
+	suspect := make([]bool, len(disks))
+	var available int
+	for i, disk := range disks {
+		if readers[i] == nil {
+			continue
+		}
+		suspect[i] = globalDriveQuarantine.isSuspect(disk.Endpoint().String())
+		if !suspect[i] {
+			available++
+		}
+	}
+	if available < dataBlocks {
+		return
+	}
+	for i := range prefer {
+		prefer[i] = readers[i] != nil && !suspect[i]
+	}
+}
+
+// initDriveLatencyMonitor starts checking the drive latencies in the
+// background. Every node checks all drives, the drive metrics are
+// collected by the node owning the drive so all nodes agree.
+func initDriveLatencyMonitor(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	go z.monitorDriveLatency(ctx)
+}
+
+func (z *erasureServerPools) monitorDriveLatency(ctx context.Context) {
+	monitorTimer := time.NewTimer(driveLatencyInterval)
+	defer monitorTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-monitorTimer.C:
+			factor, window := globalHealConfig.DriveLatency()
+			for _, pool := range z.getServerPools() {
+				for _, set := range pool.sets {
+					latencies := set.readDriveLatencies(ctx)
+					changed := globalDriveQuarantine.update(set.poolIndex, set.setIndex, latencies, factor, window, UTCNow())
+					for _, st := range changed {
+						logDriveLatencyChange(ctx, st)
+					}
+				}
+			}
+			monitorTimer.Reset(driveLatencyInterval)
+		}
+	}
+}
+
+// readDriveLatencies returns the read latencies reported by
+// the online drives of this erasure set.
+func (er erasureObjects) readDriveLatencies(ctx context.Context) []driveLatency {
+	disks := er.getDisks()
+	latencies := make([]driveLatency, len(disks))
+
+	var wg sync.WaitGroup
+	for i, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, disk StorageAPI) {
+			defer wg.Done()
+			info, err := disk.DiskInfo(ctx)
+			if err != nil {
+				return
+			}
+			latencies[i] = driveLatency{
+				endpoint: disk.Endpoint().String(),
+				p99:      time.Duration(info.Metrics.ReadLatencyP99),
+			}
+		}(i, disk)
+	}
+	wg.Wait()
+
+	n := 0
+	for _, l := range latencies {
+		if l.endpoint != "" {
+			latencies[n] = l
+			n++
+		}
+	}
+	return latencies[:n]
+}
+
+func logDriveLatencyChange(ctx context.Context, st DriveLatencyStatus) {
+	if !st.Suspect {
+		logger.Info("Drive %s is no longer suspect, read latency p99 %s, erasure set median %s",
+			st.Endpoint, st.ReadLatencyP99, st.SetMedian)
+		return
+	}
+	reqInfo := (&logger.ReqInfo{}).AppendTags("drive", st.Endpoint)
+	logger.LogAlwaysIf(logger.SetReqInfo(ctx, reqInfo),
+		fmt.Errorf("Drive %s marked suspect: read latency p99 %s exceeds the median %s of pool %d, set %d",
+			st.Endpoint, st.ReadLatencyP99, st.SetMedian, st.Pool+1, st.Set+1))
+}
+
+// DriveLatencyHandler - GET /minio/admin/v3/drives/latency
+// ----------
+// Returns the read latency of every drive and whether it is suspect of
+// being slow compared to the other drives of its erasure set.
+func (a adminAPIHandlers) DriveLatencyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveLatency")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.StorageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalDriveQuarantine.status())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyWindowPercentile(t *testing.T) {
+	var w latencyWindow
+	for i := 1; i < driveLatencyMinSamples; i++ {
+		w.add(time.Duration(i) * time.Millisecond)
+	}
+	if p := w.percentile(0.99); p != 0 {
+		t.Fatalf("expected no percentile with too few samples, got %s", p)
+	}
+
+	// Fill the window twice, only the most recent samples count.
+	for i := 0; i < 2*driveLatencyWindowSize; i++ {
+		d := time.Second
+		if i >= driveLatencyWindowSize {
+			d = time.Duration(i-driveLatencyWindowSize+1) * time.Millisecond
+		}
+		w.add(d)
+	}
+	if p := w.percentile(0.99); p != 253*time.Millisecond {
+		t.Fatalf("expected p99 of 253ms, got %s", p)
+	}
+	if p := w.percentile(0.5); p != 128*time.Millisecond {
+		t.Fatalf("expected p50 of 128ms, got %s", p)
+	}
+}
+
+func TestDriveQuarantineUpdate(t *testing.T) {
+	q := newDriveQuarantine()
+	drives := func(slow time.Duration) []driveLatency {
+		return []driveLatency{
+			{endpoint: "d1", p99: 10 * time.Millisecond},
+			{endpoint: "d2", p99: 12 * time.Millisecond},
+			{endpoint: "d3", p99: 11 * time.Millisecond},
+			{endpoint: "d4", p99: slow},
+		}
+	}
+	now := time.Now()
+	window := time.Minute
+
+	// A slow drive is only marked suspect once it stays slow for the window.
+	if changed := q.update(0, 0, drives(200*time.Millisecond), 5, window, now); len(changed) != 0 {
+		t.Fatalf("expected no change, got %v", changed)
+	}
+	if q.isSuspect("d4") {
+		t.Fatal("expected d4 not to be suspect yet")
+	}
+	changed := q.update(0, 0, drives(200*time.Millisecond), 5, window, now.Add(window))
+	if len(changed) != 1 || changed[0].Endpoint != "d4" || !changed[0].Suspect {
+		t.Fatalf("expected d4 to be marked suspect, got %v", changed)
+	}
+	if !q.isSuspect("d4") || q.isSuspect("d1") {
+		t.Fatal("expected only d4 to be suspect")
+	}
+	if changed[0].SetMedian != 12*time.Millisecond {
+		t.Fatalf("expected set median of 12ms, got %s", changed[0].SetMedian)
+	}
+
+	// A short recovery does not clear the drive.
+	q.update(0, 0, drives(10*time.Millisecond), 5, window, now.Add(2*window))
+	q.update(0, 0, drives(200*time.Millisecond), 5, window, now.Add(3*window))
+	if changed = q.update(0, 0, drives(10*time.Millisecond), 5, window, now.Add(3*window+time.Second)); len(changed) != 0 {
+		t.Fatalf("expected no change, got %v", changed)
+	}
+	changed = q.update(0, 0, drives(10*time.Millisecond), 5, window, now.Add(4*window+time.Second))
+	if len(changed) != 1 || changed[0].Suspect {
+		t.Fatalf("expected d4 to be cleared, got %v", changed)
+	}
+	if q.hasSuspects() {
+		t.Fatal("expected no suspect drives")
+	}
+
+	// Fast drives are never suspect, regardless of the ratio.
+	for i := 0; i < 3; i++ {
+		q.update(0, 0, []driveLatency{
+			{endpoint: "d1", p99: time.Millisecond},
+			{endpoint: "d2", p99: time.Millisecond},
+			{endpoint: "d3", p99: 15 * time.Millisecond},
+		}, 5, 0, now)
+	}
+	if q.hasSuspects() {
+		t.Fatal("expected no suspect drives below the latency floor")
+	}
+}
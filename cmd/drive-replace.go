@@ -0,0 +1,332 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/env"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Replacement states of a drive.
+const (
+	driveReplaceOffline = "offline" // drive is not connected
+	driveReplaceHealing = "healing" // drive is formatted, objects are being healed onto it
+	driveReplaceOnline  = "online"  // drive is formatted and fully healed
+)
+
+var (
+	// error returned when the endpoint is not a drive of this deployment.
+	errDriveReplaceNotFound = AdminError{
+		Code:       "XMinioAdminDriveNotFound",
+		Message:    "Drive endpoint is not part of this deployment",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when the drive at the endpoint is already formatted.
+	errDriveReplaceFormatted = AdminError{
+		Code:       "XMinioAdminDriveAlreadyFormatted",
+		Message:    "Drive is already formatted, only a fresh drive can replace a failed one",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when the drive at the endpoint is on the root disk.
+	errDriveReplaceRootDisk = AdminError{
+		Code:       "XMinioAdminDriveRootDisk",
+		Message:    "Drive path is on the root disk, the new drive is likely not mounted",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the drive is smaller than the others of its set.
+	errDriveReplaceTooSmall = AdminError{
+		Code:       "XMinioAdminDriveTooSmall",
+		Message:    "Drive is smaller than the other drives of its erasure set",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// driveReplaceErrs are the errors returned by the node owning the
+// drive, they are matched by message when forwarded by a peer.
+var driveReplaceErrs = []AdminError{
+	errDriveReplaceNotFound,
+	errDriveReplaceFormatted,
+	errDriveReplaceRootDisk,
+	errDriveReplaceTooSmall,
+}
+
+func toDriveReplaceErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, e := range driveReplaceErrs {
+		if err.Error() == e.Message {
+			return e
+		}
+	}
+	return err
+}
+
+// DriveReplaceStatus is the state of a drive being replaced.
+type DriveReplaceStatus struct {
+	Endpoint string `json:"endpoint"`
+	Pool     int    `json:"pool"`
+	Set      int    `json:"set"`
+	Disk     int    `json:"disk"`
+	DiskID   string `json:"diskID"`
+	State    string `json:"state"`
+
+	// Healing progress of the drive, only set while healing.
+	Healing *madmin.HealingDisk `json:"healing,omitempty"`
+}
+
+// findDrive returns the endpoint and the location of a drive
+// of this deployment from its string representation.
+func (z *erasureServerPools) findDrive(endpoint string) (ep Endpoint, pool, set, disk int, err error) {
+	for pool, s := range z.getServerPools() {
+		for i, e := range s.endpoints {
+			if e.String() == endpoint {
+				return e, pool, i / s.setDriveCount, i % s.setDriveCount, nil
+			}
+		}
+	}
+	return Endpoint{}, -1, -1, -1, errDriveReplaceNotFound
+}
+
+// replaceDrive formats the fresh drive mounted at the local endpoint with
+// the disk UUID of the failed drive it replaces, puts it in place of the
+// failed drive and queues it for healing.
+func (z *erasureServerPools) replaceDrive(ctx context.Context, endpoint string) (DriveReplaceStatus, error) {
+	ep, pool, set, diskIdx, err := z.findDrive(endpoint)
+	if err != nil {
+		return DriveReplaceStatus{}, err
+	}
+	if !ep.IsLocal {
+		return DriveReplaceStatus{}, fmt.Errorf("drive %s is not local to this node", endpoint)
+	}
+	s := z.getServerPools()[pool]
+
+	disk, err := newStorageAPIWithoutHealthCheck(ep)
+	if err != nil {
+		return DriveReplaceStatus{}, err
+	}
+	if err = s.validateReplacementDrive(ctx, disk, set, diskIdx); err != nil {
+		disk.Close()
+		return DriveReplaceStatus{}, err
+	}
+
+	s.erasureDisksMu.RLock()
+	format := s.format.Clone()
+	s.erasureDisksMu.RUnlock()
+	format.Erasure.This = format.Erasure.Sets[set][diskIdx]
+
+	disk.SetDiskLoc(s.poolIndex, set, diskIdx)
+	if err = saveFormatErasure(disk, format, true); err != nil {
+		disk.Close()
+		return DriveReplaceStatus{}, fmt.Errorf("Drive %s failed to write 'format.json': %w", endpoint, err)
+	}
+
+	s.erasureDisksMu.Lock()
+	if s.erasureDisks[set][diskIdx] != nil {
+		s.erasureDisks[set][diskIdx].Close()
+	}
+	s.erasureDisks[set][diskIdx] = disk
+	s.erasureDisksMu.Unlock()
+
+	globalBackgroundHealState.pushHealLocalDisks(ep)
+	logger.Info("Drive %s replaced on %d pool, %d set, healing queued", endpoint, pool+1, set+1)
+
+	return z.driveReplaceStatus(ctx, endpoint)
+}
+
+// validateReplacementDrive checks that disk is a fresh mounted drive
+// which is at least as large as the other drives of the erasure set.
+func (s *erasureSets) validateReplacementDrive(ctx context.Context, disk StorageAPI, set, diskIdx int) error {
+	if _, err := loadFormatErasure(disk); err == nil {
+		return errDriveReplaceFormatted
+	} else if !errors.Is(err, errUnformattedDisk) {
+		return err
+	}
+
+	// DiskInfo of a fresh drive is returned along with errUnformattedDisk.
+	info, err := disk.DiskInfo(ctx)
+	if err != nil && !errors.Is(err, errUnformattedDisk) {
+		return err
+	}
+	// Root disk detection is disabled in CI/CD setups.
+	if info.RootDisk && env.Get("MINIO_CI_CD", "") == "" {
+		return errDriveReplaceRootDisk
+	}
+	if info.Total < s.minDriveCapacity(ctx, set, diskIdx) {
+		return errDriveReplaceTooSmall
+	}
+	return nil
+}
+
+// minDriveCapacity returns the total capacity of the smallest online
+// drive of the erasure set, skipping the drive at index skip.
+func (s *erasureSets) minDriveCapacity(ctx context.Context, set, skip int) (capacity uint64) {
+	for i, disk := range s.sets[set].getDisks() {
+		if i == skip || disk == nil {
+			continue
+		}
+		info, err := disk.DiskInfo(ctx)
+		if err != nil {
+			continue
+		}
+		if capacity == 0 || info.Total < capacity {
+			capacity = info.Total
+		}
+	}
+	return capacity
+}
+
+// driveReplaceStatus returns the replacement state of a drive,
+// drives of other nodes are queried over the network.
+func (z *erasureServerPools) driveReplaceStatus(ctx context.Context, endpoint string) (DriveReplaceStatus, error) {
+	_, pool, set, diskIdx, err := z.findDrive(endpoint)
+	if err != nil {
+		return DriveReplaceStatus{}, err
+	}
+	s := z.getServerPools()[pool]
+
+	s.erasureDisksMu.RLock()
+	disk := s.erasureDisks[set][diskIdx]
+	diskID := s.format.Erasure.Sets[set][diskIdx]
+	s.erasureDisksMu.RUnlock()
+
+	status := DriveReplaceStatus{
+		Endpoint: endpoint,
+		Pool:     pool,
+		Set:      set,
+		Disk:     diskIdx,
+		DiskID:   diskID,
+		State:    driveReplaceOffline,
+	}
+	if disk == nil || !disk.IsOnline() {
+		return status, nil
+	}
+	if id, err := disk.GetDiskID(); err != nil || id != diskID {
+		return status, nil
+	}
+	if tracker := disk.Healing(); tracker != nil {
+		healing := tracker.toHealingDisk()
+		status.State, status.Healing = driveReplaceHealing, &healing
+		return status, nil
+	}
+	status.State = driveReplaceOnline
+	return status, nil
+}
+
+// ReplaceDrive replaces the failed drive at endpoint with the fresh drive
+// mounted in its place, on the node owning the drive.
+func (sys *NotificationSys) ReplaceDrive(ctx context.Context, z *erasureServerPools, endpoint string) (DriveReplaceStatus, error) {
+	ep, _, _, _, err := z.findDrive(endpoint)
+	if err != nil {
+		return DriveReplaceStatus{}, err
+	}
+	if ep.IsLocal {
+		return z.replaceDrive(ctx, endpoint)
+	}
+	for _, client := range sys.peerClients {
+		if client != nil && client.host.String() == ep.Host {
+			status, err := client.ReplaceDrive(ctx, endpoint)
+			return status, toDriveReplaceErr(err)
+		}
+	}
+	return DriveReplaceStatus{}, errDriveReplaceNotFound
+}
+
+// driveReplaceObjectLayer returns the object layer of an erasure setup
+// and the drive endpoint of the request, writes an error response and
+// returns nil otherwise.
+func driveReplaceObjectLayer(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (*erasureServerPools, string) {
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil, ""
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, ""
+	}
+
+	endpoint := r.URL.Query().Get("endpoint")
+	if endpoint == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return nil, ""
+	}
+	return z, endpoint
+}
+
+func writeDriveReplaceStatus(ctx context.Context, w http.ResponseWriter, r *http.Request, status DriveReplaceStatus) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// DriveReplaceHandler - POST /minio/admin/v3/drives/replace?endpoint={endpoint}
+// ----------
+// Replaces a failed drive with the fresh drive mounted at the same path:
+// the new drive is validated, formatted with the disk UUID of the failed
+// drive and healed in the background. Returns the replacement state.
+func (a adminAPIHandlers) DriveReplaceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveReplace")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, endpoint := driveReplaceObjectLayer(ctx, w, r, iampolicy.HealAdminAction)
+	if z == nil {
+		return
+	}
+
+	status, err := globalNotificationSys.ReplaceDrive(ctx, z, endpoint)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeDriveReplaceStatus(ctx, w, r, status)
+}
+
+// DriveReplaceStatusHandler - GET /minio/admin/v3/drives/replace/status?endpoint={endpoint}
+// ----------
+// Returns the replacement state of a drive and its healing progress.
+func (a adminAPIHandlers) DriveReplaceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveReplaceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, endpoint := driveReplaceObjectLayer(ctx, w, r, iampolicy.StorageInfoAdminAction)
+	if z == nil {
+		return
+	}
+
+	status, err := z.driveReplaceStatus(ctx, endpoint)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeDriveReplaceStatus(ctx, w, r, status)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestReplaceDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetGlobalHealState()
+	defer resetGlobalHealState()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	s := z.getServerPools()[0]
+	ep := s.endpoints[2]
+	defer globalBackgroundHealState.popHealLocalDisks(ep)
+
+	if _, err = z.replaceDrive(ctx, "/no/such/drive"); !errors.Is(err, errDriveReplaceNotFound) {
+		t.Fatalf("expected %v, got %v", errDriveReplaceNotFound, err)
+	}
+	if _, err = z.replaceDrive(ctx, ep.String()); !errors.Is(err, errDriveReplaceFormatted) {
+		t.Fatalf("expected %v, got %v", errDriveReplaceFormatted, err)
+	}
+
+	// Swap the drive with a fresh one.
+	if err = os.RemoveAll(ep.Path); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(ep.Path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := z.replaceDrive(ctx, ep.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Pool != 0 || status.Set != 0 || status.Disk != 2 {
+		t.Fatalf("unexpected drive location %+v", status)
+	}
+	if status.DiskID != s.format.Erasure.Sets[0][2] {
+		t.Fatalf("expected disk ID %s, got %s", s.format.Erasure.Sets[0][2], status.DiskID)
+	}
+	if status.State != driveReplaceHealing || status.Healing == nil {
+		t.Fatalf("expected drive to be healing, got %+v", status)
+	}
+
+	disk, format, err := connectEndpoint(ep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer disk.Close()
+	if format.Erasure.This != status.DiskID {
+		t.Fatalf("expected drive formatted with %s, got %s", status.DiskID, format.Erasure.This)
+	}
+	var queued bool
+	for _, e := range globalBackgroundHealState.getHealLocalDiskEndpoints() {
+		queued = queued || e.String() == ep.String()
+	}
+	if !queued {
+		t.Fatal("expected drive to be queued for healing")
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/minio/minio/internal/config/api"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/fips"
 	xhttp "github.com/minio/minio/internal/http"
@@ -668,6 +669,21 @@ func DecryptETag(key crypto.ObjectKey, object ObjectInfo) (string, error) {
 	return hex.EncodeToString(etag), nil
 }
 
+// clientETagKey holds the content MD5 of single part objects encrypted
+// with SSE-S3, stored when the "aws" ETag mode is enabled.
+const clientETagKey = ReservedMetadataPrefix + "client-etag"
+
+// setClientETag stores the content MD5 read by r as client visible ETag
+// if the object is encrypted with SSE-S3 and the "aws" ETag mode is
+// enabled, it removes any stale client ETag otherwise.
+func setClientETag(metadata map[string]string, r *PutObjReader) {
+	if globalAPIConfig.getETagMode() == api.ETagModeAWS && crypto.S3.IsEncrypted(metadata) {
+		metadata[clientETagKey] = hex.EncodeToString(r.rawReader.MD5Current())
+		return
+	}
+	delete(metadata, clientETagKey)
+}
+
 // For encrypted objects, the ETag sent by client if available
 // is stored in encrypted form in the backend. Decrypt the ETag
 // if ETag was previously encrypted.
@@ -681,6 +697,11 @@ func getDecryptedETag(headers http.Header, objInfo ObjectInfo, copySource bool)
 		return objInfo.ETag
 	}
 
+	// The content MD5 was stored at upload, no need to unseal the ETag.
+	if etag, ok := objInfo.UserDefined[clientETagKey]; ok && crypto.S3.IsEncrypted(objInfo.UserDefined) {
+		return etag
+	}
+
 	if crypto.IsMultiPart(objInfo.UserDefined) {
 		return objInfo.ETag
 	}
@@ -728,6 +749,47 @@ func tryDecryptETag(key []byte, encryptedETag string, ssec bool) string {
 	return hex.EncodeToString(etagBytes)
 }
 
+// decryptPartETag returns the ETag of a part of an encrypted multipart
+// upload as sent to the client, which must send it back unchanged to
+// complete the upload.
+func decryptPartETag(kind crypto.Type, objectKey crypto.ObjectKey, etag string) string {
+	switch kind {
+	case crypto.S3:
+		return tryDecryptETag(objectKey[:], etag, false)
+	case crypto.SSEC:
+		if len(etag) >= 32 && strings.Count(etag, "-") != 1 {
+			return etag[len(etag)-32:]
+		}
+	}
+	return etag
+}
+
+// setPartEncryptionHeaders sets the encryption response headers of a
+// part uploaded to a multipart upload encrypted with kind.
+func setPartEncryptionHeaders(w http.ResponseWriter, r *http.Request, kind crypto.Type) {
+	switch kind {
+	case crypto.S3:
+		w.Header().Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+	case crypto.SSEC:
+		w.Header().Set(xhttp.AmzServerSideEncryptionCustomerAlgorithm, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerAlgorithm))
+		w.Header().Set(xhttp.AmzServerSideEncryptionCustomerKeyMD5, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerKeyMD5))
+	}
+}
+
+// checkCopyPartSourceEncryption validates the copy source SSE-C headers
+// of an UploadPartCopy request against the source object. The source
+// of a part copy is only ever decrypted with the copy source key, never
+// with the key of the destination upload.
+func checkCopyPartSourceEncryption(src ObjectInfo, h http.Header) error {
+	if _, encrypted := crypto.IsEncrypted(src.UserDefined); !encrypted && crypto.SSECopy.IsRequested(h) {
+		return errInvalidEncryptionParameters
+	}
+	if crypto.SSEC.IsEncrypted(src.UserDefined) && !crypto.SSECopy.IsRequested(h) {
+		return crypto.ErrInvalidCustomerAlgorithm
+	}
+	return nil
+}
+
 // GetDecryptedRange - To decrypt the range (off, length) of the
 // decrypted object stream, we need to read the range (encOff,
 // encLength) of the encrypted object stream to decrypt it, and
@@ -20,12 +20,16 @@ package cmd
 import (
 	"bytes"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio/internal/config/api"
 	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/sio"
 )
@@ -134,6 +138,70 @@ func TestDecryptObjectInfo(t *testing.T) {
 	}
 }
 
+var checkCopyPartSourceEncryptionTests = []struct {
+	metadata map[string]string
+	header   http.Header
+	expErr   error
+}{
+	{ // 0 - unencrypted source, no copy source key
+		metadata: map[string]string{},
+		header:   http.Header{},
+	},
+	{ // 1 - unencrypted source, copy source key
+		metadata: map[string]string{},
+		header: http.Header{
+			xhttp.AmzServerSideEncryptionCopyCustomerAlgorithm: []string{xhttp.AmzEncryptionAES},
+			xhttp.AmzServerSideEncryptionCopyCustomerKey:       []string{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ="},
+			xhttp.AmzServerSideEncryptionCopyCustomerKeyMD5:    []string{"7PpPLAK26ONlVUGOWlusfg=="},
+		},
+		expErr: errInvalidEncryptionParameters,
+	},
+	{ // 2 - SSE-C source, only the destination key
+		metadata: map[string]string{crypto.MetaSealedKeySSEC: "EAAfAAAAAAD7v1hQq3PFRUHsItalxmrJqrOq6FwnbXNarxOOpb8jTWONPPKyM3Gfjkjyj6NCf+aB/VpHCLCTBA==", crypto.MetaAlgorithm: crypto.InsecureSealAlgorithm},
+		header: http.Header{
+			xhttp.AmzServerSideEncryptionCustomerAlgorithm: []string{xhttp.AmzEncryptionAES},
+			xhttp.AmzServerSideEncryptionCustomerKey:       []string{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ="},
+			xhttp.AmzServerSideEncryptionCustomerKeyMD5:    []string{"7PpPLAK26ONlVUGOWlusfg=="},
+		},
+		expErr: crypto.ErrInvalidCustomerAlgorithm,
+	},
+	{ // 3 - SSE-C source, copy source key
+		metadata: map[string]string{crypto.MetaSealedKeySSEC: "EAAfAAAAAAD7v1hQq3PFRUHsItalxmrJqrOq6FwnbXNarxOOpb8jTWONPPKyM3Gfjkjyj6NCf+aB/VpHCLCTBA==", crypto.MetaAlgorithm: crypto.InsecureSealAlgorithm},
+		header: http.Header{
+			xhttp.AmzServerSideEncryptionCopyCustomerAlgorithm: []string{xhttp.AmzEncryptionAES},
+			xhttp.AmzServerSideEncryptionCopyCustomerKey:       []string{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ="},
+			xhttp.AmzServerSideEncryptionCopyCustomerKeyMD5:    []string{"7PpPLAK26ONlVUGOWlusfg=="},
+		},
+	},
+}
+
+func TestCheckCopyPartSourceEncryption(t *testing.T) {
+	for i, test := range checkCopyPartSourceEncryptionTests {
+		if err := checkCopyPartSourceEncryption(ObjectInfo{UserDefined: test.metadata}, test.header); err != test.expErr {
+			t.Errorf("Test %d: got error %v, want %v", i, err, test.expErr)
+		}
+	}
+}
+
+var decryptPartETagTests = []struct {
+	kind crypto.Type
+	etag string
+	want string
+}{
+	{kind: crypto.S3, etag: "20000f00f27834c9a2654927546df57f9e998187496394d4ee80f3d9978f85f3c7d81f72600cdbe03d80dc5a13d69354", want: "8ad3fe6b84bf38489e95c701c84355b6"},
+	{kind: crypto.SSEC, etag: "20000f00f27834c9a2654927546df57f9e998187496394d4ee80f3d9978f85f3c7d81f72600cdbe03d80dc5a13d69354", want: "c7d81f72600cdbe03d80dc5a13d69354"},
+	{kind: crypto.SSEC, etag: "916516b396f0f4d4f2a0e7177557bec4-1", want: "916516b396f0f4d4f2a0e7177557bec4-1"},
+	{kind: crypto.S3KMS, etag: "8ad3fe6b84bf38489e95c701c84355b6", want: "8ad3fe6b84bf38489e95c701c84355b6"},
+}
+
+func TestDecryptPartETag(t *testing.T) {
+	for i, test := range decryptPartETagTests {
+		if etag := decryptPartETag(test.kind, crypto.ObjectKey{}, test.etag); etag != test.want {
+			t.Errorf("Test %d: got ETag %s, want %s", i, etag, test.want)
+		}
+	}
+}
+
 var decryptETagTests = []struct {
 	ObjectKey  crypto.ObjectKey
 	ObjectInfo ObjectInfo
@@ -206,6 +274,58 @@ func TestDecryptETag(t *testing.T) {
 	}
 }
 
+func TestClientETag(t *testing.T) {
+	setETagMode := func(mode string) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.etagMode = mode
+		globalAPIConfig.mu.Unlock()
+	}
+	defer setETagMode(globalAPIConfig.getETagMode())
+
+	const sealedETag = "20000f00f27834c9a2654927546df57f9e998187496394d4ee80f3d9978f85f3c7d81f72600cdbe03d80dc5a13d69354"
+	data := []byte("hello, world")
+	wantETag := getMD5Hash(data)
+
+	testCases := []struct {
+		mode     string
+		metadata map[string]string
+		stored   bool
+	}{
+		{mode: api.ETagModeAWS, metadata: map[string]string{crypto.MetaSealedKeyS3: "sealed"}, stored: true},
+		{mode: api.ETagModeDefault, metadata: map[string]string{crypto.MetaSealedKeyS3: "sealed"}},
+		{mode: api.ETagModeAWS, metadata: map[string]string{crypto.MetaSealedKeyKMS: "sealed"}},
+		{mode: api.ETagModeAWS, metadata: map[string]string{}},
+		// A stale client ETag must be removed.
+		{mode: api.ETagModeDefault, metadata: map[string]string{crypto.MetaSealedKeyS3: "sealed", clientETagKey: "stale"}},
+	}
+	for i, test := range testCases {
+		setETagMode(test.mode)
+		hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.Copy(ioutil.Discard, hr); err != nil {
+			t.Fatal(err)
+		}
+		setClientETag(test.metadata, NewPutObjReader(hr))
+
+		etag, ok := test.metadata[clientETagKey]
+		if ok != test.stored {
+			t.Fatalf("Test %d: client ETag stored: got %v, want %v", i, ok, test.stored)
+		}
+		if !ok {
+			continue
+		}
+		if etag != wantETag {
+			t.Fatalf("Test %d: client ETag: got %s, want %s", i, etag, wantETag)
+		}
+		objInfo := ObjectInfo{ETag: sealedETag, UserDefined: test.metadata}
+		if etag = getDecryptedETag(http.Header{}, objInfo, false); etag != wantETag {
+			t.Fatalf("Test %d: decrypted ETag: got %s, want %s", i, etag, wantETag)
+		}
+	}
+}
+
 // Tests for issue reproduced when getting the right encrypted
 // offset of the object.
 func TestGetDecryptedRange_Issue50(t *testing.T) {
@@ -200,6 +200,9 @@ type PoolEndpoints struct {
 	SetCount     int
 	DrivesPerSet int
 	Endpoints    Endpoints
+	// CmdLine is the command line argument the pool was created
+	// from, it identifies the pool across restarts.
+	CmdLine string
 }
 
 // EndpointServerPools - list of list of endpoints
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// globalBlockCache caches erasure blocks decoded by GET requests, it is
+// disabled unless sized by the api block_cache_size config.
+var globalBlockCache = newBlockCache(0)
+
+// blockCache is a LRU cache of decoded erasure blocks, capped in bytes.
+type blockCache struct {
+	// capacity in bytes, 0 disables the cache.
+	size int64
+
+	hits, misses uint64
+
+	mu      sync.Mutex
+	used    int64
+	lru     *list.List // of *blockCacheEntry, most recently used first.
+	entries map[string]*list.Element
+}
+
+type blockCacheEntry struct {
+	key  string
+	data []byte
+}
+
+func newBlockCache(size int64) *blockCache {
+	return &blockCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// blockCacheKey returns the key of a block of a part of an object version,
+// prefix is unique to the version and changes with any rewrite of its data.
+func blockCacheKey(prefix string, partNumber int, block int64) string {
+	return prefix + SlashSeparator + strconv.Itoa(partNumber) + SlashSeparator + strconv.FormatInt(block, 10)
+}
+
+func (c *blockCache) enabled() bool {
+	return c != nil && atomic.LoadInt64(&c.size) > 0
+}
+
+// get returns the cached block, which must not be modified.
+func (c *blockCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return e.Value.(*blockCacheEntry).data, true
+}
+
+// put caches a block, which must not be modified afterwards.
+func (c *blockCache) put(key string, data []byte) {
+	size := atomic.LoadInt64(&c.size)
+	if int64(len(data)) > size {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&blockCacheEntry{key: key, data: data})
+	c.used += int64(len(data))
+	c.evict(size)
+}
+
+// evict removes the least recently used blocks until at most size bytes
+// are used, c.mu must be held.
+func (c *blockCache) evict(size int64) {
+	for c.used > size {
+		e := c.lru.Back()
+		if e == nil {
+			return
+		}
+		entry := c.lru.Remove(e).(*blockCacheEntry)
+		delete(c.entries, entry.key)
+		c.used -= int64(len(entry.data))
+	}
+}
+
+// resize changes the capacity of the cache, 0 disables and empties it.
+func (c *blockCache) resize(size int64) {
+	if size < 0 {
+		size = 0
+	}
+	atomic.StoreInt64(&c.size, size)
+	c.mu.Lock()
+	c.evict(size)
+	c.mu.Unlock()
+}
+
+type blockCacheStats struct {
+	Hits, Misses uint64
+	Used, Size   int64
+	Blocks       int
+}
+
+func (c *blockCache) stats() blockCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return blockCacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+		Used:   c.used,
+		Size:   atomic.LoadInt64(&c.size),
+		Blocks: c.lru.Len(),
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBlockCache(t *testing.T) {
+	c := newBlockCache(0)
+	if c.enabled() {
+		t.Fatal("expected a disabled cache")
+	}
+	c.put("a", []byte("a"))
+	if _, ok := c.get("a"); ok {
+		t.Fatal("expected a disabled cache to cache nothing")
+	}
+
+	c.resize(10)
+	c.put("a", []byte("aaaa"))
+	c.put("b", []byte("bbbb"))
+	c.put("large", bytes.Repeat([]byte("l"), 11))
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	// b is the least recently used.
+	c.put("c", []byte("cccc"))
+	if _, ok := c.get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Fatalf("expected %s to be cached", key)
+		}
+	}
+	if _, ok := c.get("large"); ok {
+		t.Fatal("expected a block larger than the cache not to be cached")
+	}
+
+	st := c.stats()
+	if st.Hits != 3 || st.Misses != 3 || st.Used != 8 || st.Blocks != 2 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	c.resize(0)
+	if st = c.stats(); st.Used != 0 || st.Blocks != 0 {
+		t.Fatalf("expected an empty cache once disabled, got %+v", st)
+	}
+}
+
+func TestGetObjectBlockCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer globalBlockCache.resize(0)
+	globalBlockCache.resize(64 << 20)
+
+	disks, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(disks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const bucket, object = "bucket", "object"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Spans several erasure blocks.
+	data := bytes.Repeat([]byte("0123456789"), (5*blockSizeV2/2)/10)
+	if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(rs *HTTPRangeSpec) []byte {
+		t.Helper()
+		gr, err := objLayer.GetObjectNInfo(ctx, bucket, object, rs, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		got, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	if got := get(nil); !bytes.Equal(got, data) {
+		t.Fatal("unexpected data")
+	}
+	if st := globalBlockCache.stats(); st.Blocks != 3 || st.Used != int64(len(data)) {
+		t.Fatalf("expected all blocks to be cached, got %+v", st)
+	}
+
+	// Cached blocks are served without reading the drives.
+	for _, disk := range disks {
+		parts, err := filepath.Glob(filepath.Join(disk, bucket, object, "*", "part.1"))
+		if err != nil || len(parts) != 1 {
+			t.Fatalf("expected a part on %s, got %v: %v", disk, parts, err)
+		}
+		if err = os.Remove(parts[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := get(nil); !bytes.Equal(got, data) {
+		t.Fatal("unexpected cached data")
+	}
+	rs := &HTTPRangeSpec{Start: blockSizeV2 - 10, End: 2*blockSizeV2 + 10}
+	if got := get(rs); !bytes.Equal(got, data[blockSizeV2-10:2*blockSizeV2+11]) {
+		t.Fatal("unexpected cached range")
+	}
+}
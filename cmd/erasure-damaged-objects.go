@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DamagedObjectDisk is the state of a damaged object on a single disk.
+type DamagedObjectDisk struct {
+	Endpoint string `json:"endpoint"`
+	// Error reading the object metadata from this disk.
+	Error string `json:"error,omitempty"`
+	// ModTime of the version found on this disk, disks with a
+	// different ModTime than the others hold an outdated version.
+	ModTime time.Time `json:"modTime"`
+}
+
+// DamagedObject is an object whose latest version has lost read quorum
+// and cannot be recovered by healing.
+type DamagedObject struct {
+	Bucket string              `json:"bucket"`
+	Object string              `json:"object"`
+	Pool   int                 `json:"pool"`
+	Set    int                 `json:"set"`
+	Disks  []DamagedObjectDisk `json:"disks"`
+}
+
+// listDamagedObjects sends all objects in bucket under prefix which have
+// lost read quorum to results. results is closed when done.
+func (z *erasureServerPools) listDamagedObjects(ctx context.Context, bucket, prefix string, results chan<- DamagedObject) error {
+	defer close(results)
+
+	for _, pool := range z.getServerPools() {
+		for _, set := range pool.sets {
+			if err := set.listDamagedObjects(ctx, bucket, prefix, results); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listDamagedObjects lists the objects of this erasure set in bucket under
+// prefix, sending those which have lost read quorum to results.
+func (er erasureObjects) listDamagedObjects(ctx context.Context, bucket, prefix string, results chan<- DamagedObject) error {
+	listingDisks, _ := er.getOnlineDisksWithHealing()
+	if len(listingDisks) == 0 {
+		return errErasureReadQuorum
+	}
+	disks := er.getDisks()
+	endpoints := er.getEndpoints()
+
+	// Objects are checked in batches, reading their metadata with a
+	// single call per disk.
+	pending := make([]string, 0, readVersionsBatchSize)
+	checkPending := func() {
+		if len(pending) == 0 {
+			return
+		}
+		metaArrs, errs := readAllFileInfos(ctx, disks, bucket, pending, nil, false)
+		for i, object := range pending {
+			if _, err := getLatestFileInfo(ctx, metaArrs[i], errs[i]); !errors.Is(err, errErasureReadQuorum) {
+				continue
+			}
+			damaged := DamagedObject{
+				Bucket: bucket,
+				Object: object,
+				Pool:   er.poolIndex,
+				Set:    er.setIndex,
+				Disks:  make([]DamagedObjectDisk, len(disks)),
+			}
+			for d := range disks {
+				damaged.Disks[d].Endpoint = endpoints[d].String()
+				if errs[i][d] != nil {
+					damaged.Disks[d].Error = errs[i][d].Error()
+					continue
+				}
+				damaged.Disks[d].ModTime = metaArrs[i][d].ModTime
+			}
+			select {
+			case results <- damaged:
+			case <-ctx.Done():
+				return
+			}
+		}
+		pending = pending[:0]
+	}
+	checkEntry := func(entry metaCacheEntry) {
+		if entry.isDir() || !strings.HasPrefix(entry.name, prefix) {
+			return
+		}
+		pending = append(pending, entry.name)
+		if len(pending) == readVersionsBatchSize {
+			checkPending()
+		}
+	}
+
+	baseDir := baseDirFromPrefix(prefix)
+	err := listPathRaw(ctx, listPathRawOptions{
+		disks:        listingDisks,
+		bucket:       bucket,
+		path:         baseDir,
+		filterPrefix: strings.Trim(strings.TrimPrefix(prefix, baseDir), slashSeparator),
+		recursive:    true,
+		minDisks:     1,
+		agreed:       checkEntry,
+		partial:      resolvePartialEntries(bucket, checkEntry),
+	})
+	if err != nil {
+		return err
+	}
+	checkPending()
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestListDamagedObjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	objects := []string{"dir/damaged", "dir/healthy", "other/damaged"}
+	// More objects than fit in two batches of metadata reads.
+	for i := 0; i < 2*readVersionsBatchSize+1; i++ {
+		objects = append(objects, fmt.Sprintf("dir/healthy-%d", i))
+	}
+	for _, object := range objects {
+		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	z := obj.(*erasureServerPools)
+	for _, object := range []string{"dir/damaged", "other/damaged"} {
+		er := z.getServerPools()[0].getHashedSet(object)
+		// Remove more disks than the parity allows.
+		for _, disk := range er.getDisks()[:er.defaultParityCount+1] {
+			if err = removeAll(pathJoin(disk.String(), bucket, object)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	results := make(chan DamagedObject, 10)
+	if err = z.listDamagedObjects(ctx, bucket, "dir/", results); err != nil {
+		t.Fatal(err)
+	}
+	var damaged []DamagedObject
+	for d := range results {
+		damaged = append(damaged, d)
+	}
+	if len(damaged) != 1 || damaged[0].Object != "dir/damaged" {
+		t.Fatalf("expected only dir/damaged to be reported, got %v", damaged)
+	}
+	var missing int
+	for _, disk := range damaged[0].Disks {
+		if disk.Error != "" {
+			missing++
+		}
+	}
+	er := z.getServerPools()[0].getHashedSet("dir/damaged")
+	if missing != er.defaultParityCount+1 {
+		t.Fatalf("expected %d disks with errors, got %d", er.defaultParityCount+1, missing)
+	}
+}
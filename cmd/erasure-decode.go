@@ -204,6 +204,21 @@ func (p *parallelReader) Read(dst [][]byte) ([][]byte, error) {
 // Decode reads from readers, reconstructs data if needed and writes the data to the writer.
 // A set of preferred drives can be supplied. In that case they will be used and the data reconstructed.
 func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64, prefer []bool) (written int64, derr error) {
+	return e.decode(ctx, writer, readers, offset, length, totalLength, prefer, nil)
+}
+
+// DecodeCached is like Decode for a part of an object version, its blocks
+// are cached in globalBlockCache with keys made of cacheKey and partNumber.
+// Leading cached blocks are served without reading, all blocks following
+// the first one which is not cached are read, as bitrot readers only read
+// sequentially.
+func (e Erasure) DecodeCached(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64, prefer []bool, cacheKey string, partNumber int) (written int64, derr error) {
+	return e.decode(ctx, writer, readers, offset, length, totalLength, prefer, func(block int64) string {
+		return blockCacheKey(cacheKey, partNumber, block)
+	})
+}
+
+func (e Erasure) decode(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64, prefer []bool, cacheKey func(block int64) string) (written int64, derr error) {
 	if offset < 0 || length < 0 {
 		logger.LogIf(ctx, errInvalidArgument)
 		return -1, errInvalidArgument
@@ -217,10 +232,7 @@ func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.Read
 		return 0, nil
 	}
 
-	reader := newParallelReader(readers, e, offset, totalLength)
-	if len(prefer) == len(readers) {
-		reader.preferReaders(prefer)
-	}
+	var reader *parallelReader
 
 	startBlock := offset / e.blockSize
 	endBlock := (offset + length) / e.blockSize
@@ -247,6 +259,27 @@ func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.Read
 			break
 		}
 
+		if cacheKey != nil && reader == nil {
+			if data, ok := globalBlockCache.get(cacheKey(block)); ok && int64(len(data)) >= blockOffset+blockLength {
+				n, err := writer.Write(data[blockOffset : blockOffset+blockLength])
+				if err != nil {
+					if err != io.ErrClosedPipe && err != io.EOF {
+						logger.LogIf(ctx, err)
+					}
+					return -1, err
+				}
+				bytesWritten += int64(n)
+				continue
+			}
+		}
+
+		if reader == nil {
+			reader = newParallelReader(readers, e, block*e.blockSize, totalLength)
+			if len(prefer) == len(readers) {
+				reader.preferReaders(prefer)
+			}
+		}
+
 		var err error
 		bufs, err = reader.Read(bufs)
 		if len(bufs) > 0 {
@@ -267,6 +300,10 @@ func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.Read
 			return -1, err
 		}
 
+		if cacheKey != nil {
+			globalBlockCache.put(cacheKey(block), e.blockData(bufs, block, totalLength))
+		}
+
 		n, err := writeDataBlocks(ctx, writer, bufs, e.dataBlocks, blockOffset, blockLength)
 		if err != nil {
 			return -1, err
@@ -283,6 +320,22 @@ func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.Read
 	return bytesWritten, derr
 }
 
+// blockData returns a copy of the data of a decoded block.
+func (e Erasure) blockData(bufs [][]byte, block, totalLength int64) []byte {
+	size := totalLength - block*e.blockSize
+	if size > e.blockSize {
+		size = e.blockSize
+	}
+	data := make([]byte, 0, size)
+	for _, buf := range bufs[:e.dataBlocks] {
+		if int64(len(data)+len(buf)) >= size {
+			return append(data, buf[:size-int64(len(data))]...)
+		}
+		data = append(data, buf...)
+	}
+	return data
+}
+
 // Heal reads from readers, reconstruct shards and writes the data to the writers.
 func (e Erasure) Heal(ctx context.Context, writers []io.Writer, readers []io.ReaderAt, totalLength int64) (derr error) {
 	if len(writers) != e.parityBlocks+e.dataBlocks {
@@ -27,3 +27,7 @@ var errErasureWriteQuorum = errors.New("Write failed. Insufficient number of dis
 
 // errNoHealRequired - returned when healing is attempted on a previously healed disks.
 var errNoHealRequired = errors.New("No healing is required")
+
+// errCopyDataUnsupported - returned when the data of a source object
+// cannot be copied as is to another object of the same erasure set.
+var errCopyDataUnsupported = errors.New("Object data cannot be copied without rewriting it")
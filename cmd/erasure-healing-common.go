@@ -174,19 +174,38 @@ func getLatestFileInfo(ctx context.Context, partsMetadata []FileInfo, errs []err
 	return latestFileInfo, nil
 }
 
-// disksWithAllParts - This function needs to be called with
-// []StorageAPI returned by listOnlineDisks. Returns,
-//
-// - disks which have all parts specified in the latest xl.meta.
-//
-// - slice of errors about the state of data files on disk - can have
-//   a not-found error or a hash-mismatch error.
-func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetadata []FileInfo,
-	errs []error, latestMeta FileInfo,
-	bucket, object string, scanMode madmin.HealScanMode) ([]StorageAPI, []error) {
+// readQuorumMargin returns the number of additional disks that can be
+// lost before the latest version in partsMetadata drops below read
+// quorum, a margin of '0' means the object is readable but only one
+// disk away from becoming unreadable. The latest version is chosen
+// with the same modTime logic used by getLatestFileInfo.
+func readQuorumMargin(ctx context.Context, partsMetadata []FileInfo, errs []error, defaultParityCount int) (int, error) {
+	readQuorum, _, err := objectQuorumFromMeta(ctx, partsMetadata, errs, defaultParityCount)
+	if err != nil {
+		return 0, err
+	}
 
-	availableDisks := make([]StorageAPI, len(onlineDisks))
-	dataErrs := make([]error, len(onlineDisks))
+	modTimes := listObjectModtimes(partsMetadata, errs)
+	modTime := commonTime(modTimes)
+
+	var count int
+	for index, t := range modTimes {
+		if partsMetadata[index].IsValid() && t.Equal(modTime) {
+			count++
+		}
+	}
+
+	if count < readQuorum {
+		return 0, errErasureReadQuorum
+	}
+	return count - readQuorum, nil
+}
+
+// isErasureDistributionReliable returns false if the erasure index of
+// more than half of partsMetadata does not match its position in the
+// erasure distribution, in which case erasure.Distribution can't be
+// trusted.
+func isErasureDistributionReliable(partsMetadata []FileInfo, diskCount int) bool {
 	inconsistent := 0
 	for i, meta := range partsMetadata {
 		if !meta.IsValid() {
@@ -195,7 +214,7 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 			continue
 		}
 		if !meta.Deleted {
-			if len(meta.Erasure.Distribution) != len(onlineDisks) {
+			if len(meta.Erasure.Distribution) != diskCount {
 				// Erasure distribution seems to have lesser
 				// number of items than number of online disks.
 				inconsistent++
@@ -208,12 +227,30 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 		}
 	}
 
-	erasureDistributionReliable := true
-	if inconsistent > len(partsMetadata)/2 {
-		// If there are too many inconsistent files, then we can't trust erasure.Distribution (most likely
-		// because of bugs found in CopyObject/PutObjectTags) https://github.com/minio/minio/pull/10772
-		erasureDistributionReliable = false
-	}
+	// If there are too many inconsistent files, then we can't trust erasure.Distribution (most likely
+	// because of bugs found in CopyObject/PutObjectTags) https://github.com/minio/minio/pull/10772
+	return inconsistent <= len(partsMetadata)/2
+}
+
+// disksWithAllParts - This function needs to be called with
+// []StorageAPI returned by listOnlineDisks. Returns,
+//
+// - disks which have all parts specified in the latest xl.meta.
+//
+// - slice of errors about the state of data files on disk - can have
+//   a not-found error or a hash-mismatch error.
+//
+// - slice of errors about the state of each part on disk, in the order
+//   of the parts of the latest xl.meta. It is nil for a disk whose parts
+//   were not checked one by one e.g. when its xl.meta is outdated.
+func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetadata []FileInfo,
+	errs []error, latestMeta FileInfo,
+	bucket, object string, scanMode madmin.HealScanMode) ([]StorageAPI, []error, [][]error) {
+
+	availableDisks := make([]StorageAPI, len(onlineDisks))
+	dataErrs := make([]error, len(onlineDisks))
+	partsErrs := make([][]error, len(onlineDisks))
+	erasureDistributionReliable := isErasureDistributionReliable(partsMetadata, len(onlineDisks))
 
 	for i, onlineDisk := range onlineDisks {
 		if errs[i] != nil {
@@ -275,17 +312,28 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 		}
 
 		meta.DataDir = latestMeta.DataDir
+		var resp *CheckPartsResp
 		switch scanMode {
 		case madmin.HealDeepScan:
 			// disk has a valid xl.meta but may not have all the
 			// parts. This is considered an outdated disk, since
 			// it needs healing too.
 			if !meta.Deleted && !meta.IsRemote() {
-				dataErrs[i] = onlineDisk.VerifyFile(ctx, bucket, object, meta)
+				resp, dataErrs[i] = onlineDisk.VerifyFile(ctx, bucket, object, meta)
 			}
 		case madmin.HealNormalScan:
 			if !meta.Deleted && !meta.IsRemote() {
-				dataErrs[i] = onlineDisk.CheckParts(ctx, bucket, object, meta)
+				resp, dataErrs[i] = onlineDisk.CheckParts(ctx, bucket, object, meta)
+			}
+		}
+
+		if dataErrs[i] == nil && resp != nil {
+			partsErrs[i] = resp.partErrs()
+			for _, err := range partsErrs[i] {
+				if err != nil {
+					dataErrs[i] = err
+					break
+				}
 			}
 		}
 
@@ -298,5 +346,5 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 		}
 	}
 
-	return availableDisks, dataErrs
+	return availableDisks, dataErrs, partsErrs
 }
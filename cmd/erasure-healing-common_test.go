@@ -184,7 +184,7 @@ func TestListOnlineDisks(t *testing.T) {
 	object := "object"
 	data := bytes.Repeat([]byte("a"), smallFileThreshold*16)
 	z := obj.(*erasureServerPools)
-	erasureDisks := z.serverPools[0].sets[0].getDisks()
+	erasureDisks := z.getServerPools()[0].sets[0].getDisks()
 	for i, test := range testCases {
 		test := test
 		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
@@ -251,7 +251,7 @@ func TestListOnlineDisks(t *testing.T) {
 				t.Fatalf("Expected modTime to be equal to %v but was found to be %v",
 					test.expectedTime, modTime)
 			}
-			availableDisks, newErrs := disksWithAllParts(ctx, onlineDisks, partsMetadata, test.errs, fi, bucket, object, madmin.HealDeepScan)
+			availableDisks, newErrs, _ := disksWithAllParts(ctx, onlineDisks, partsMetadata, test.errs, fi, bucket, object, madmin.HealDeepScan)
 			test.errs = newErrs
 
 			if test._tamperBackend != noTamper {
@@ -355,7 +355,7 @@ func TestListOnlineDisksSmallObjects(t *testing.T) {
 	object := "object"
 	data := bytes.Repeat([]byte("a"), smallFileThreshold/2)
 	z := obj.(*erasureServerPools)
-	erasureDisks := z.serverPools[0].sets[0].getDisks()
+	erasureDisks := z.getServerPools()[0].sets[0].getDisks()
 	for i, test := range testCases {
 		test := test
 		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
@@ -433,7 +433,7 @@ func TestListOnlineDisksSmallObjects(t *testing.T) {
 					test.expectedTime, modTime)
 			}
 
-			availableDisks, newErrs := disksWithAllParts(ctx, onlineDisks, partsMetadata, test.errs, fi, bucket, object, madmin.HealDeepScan)
+			availableDisks, newErrs, _ := disksWithAllParts(ctx, onlineDisks, partsMetadata, test.errs, fi, bucket, object, madmin.HealDeepScan)
 			test.errs = newErrs
 
 			if test._tamperBackend != noTamper {
@@ -462,7 +462,7 @@ func TestDisksWithAllParts(t *testing.T) {
 	partCount := 3
 	data := bytes.Repeat([]byte("a"), 6*1024*1024*partCount)
 	z := obj.(*erasureServerPools)
-	s := z.serverPools[0].sets[0]
+	s := z.getServerPools()[0].sets[0]
 	erasureDisks := s.getDisks()
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
 	if err != nil {
@@ -494,7 +494,7 @@ func TestDisksWithAllParts(t *testing.T) {
 
 	erasureDisks, _ = listOnlineDisks(erasureDisks, partsMetadata, errs)
 
-	filteredDisks, errs := disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
+	filteredDisks, errs, _ := disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
 
 	if len(filteredDisks) != len(erasureDisks) {
 		t.Errorf("Unexpected number of disks: %d", len(filteredDisks))
@@ -515,7 +515,7 @@ func TestDisksWithAllParts(t *testing.T) {
 	partsMetadata[0].ModTime = partsMetadata[0].ModTime.Add(-1 * time.Hour)
 
 	errs = make([]error, len(erasureDisks))
-	filteredDisks, _ = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
+	filteredDisks, _, _ = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
 
 	if len(filteredDisks) != len(erasureDisks) {
 		t.Errorf("Unexpected number of disks: %d", len(filteredDisks))
@@ -535,7 +535,7 @@ func TestDisksWithAllParts(t *testing.T) {
 	partsMetadata[1].DataDir = "foo-random"
 
 	errs = make([]error, len(erasureDisks))
-	filteredDisks, _ = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
+	filteredDisks, _, _ = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
 
 	if len(filteredDisks) != len(erasureDisks) {
 		t.Errorf("Unexpected number of disks: %d", len(filteredDisks))
@@ -571,7 +571,7 @@ func TestDisksWithAllParts(t *testing.T) {
 	}
 
 	errs = make([]error, len(erasureDisks))
-	filteredDisks, errs = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
+	filteredDisks, errs, _ = disksWithAllParts(ctx, erasureDisks, partsMetadata, errs, fi, bucket, object, madmin.HealDeepScan)
 
 	if len(filteredDisks) != len(erasureDisks) {
 		t.Errorf("Unexpected number of disks: %d", len(filteredDisks))
@@ -596,3 +596,48 @@ func TestDisksWithAllParts(t *testing.T) {
 		}
 	}
 }
+
+func TestReadQuorumMargin(t *testing.T) {
+	const dataBlocks, parityBlocks = 4, 4
+	modTime := time.Unix(0, 3).UTC()
+
+	// newParts returns metadata for 8 disks, of which the first
+	// 'latest' disks hold the latest version and the rest are offline.
+	newParts := func(latest int) ([]FileInfo, []error) {
+		partsMetadata := make([]FileInfo, dataBlocks+parityBlocks)
+		errs := make([]error, dataBlocks+parityBlocks)
+		for i := range partsMetadata {
+			if i >= latest {
+				errs[i] = errDiskNotFound
+				continue
+			}
+			fi := newFileInfo("object", dataBlocks, parityBlocks)
+			fi.Erasure.Index = i + 1
+			fi.ModTime = modTime
+			partsMetadata[i] = fi
+		}
+		return partsMetadata, errs
+	}
+
+	testCases := []struct {
+		latest         int
+		expectedMargin int
+		expectedErr    error
+	}{
+		{latest: 8, expectedMargin: 4},
+		{latest: 5, expectedMargin: 1},
+		{latest: 4, expectedMargin: 0},
+		{latest: 3, expectedErr: errErasureReadQuorum},
+	}
+
+	for i, testCase := range testCases {
+		partsMetadata, errs := newParts(testCase.latest)
+		margin, err := readQuorumMargin(context.Background(), partsMetadata, errs, parityBlocks)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if margin != testCase.expectedMargin {
+			t.Errorf("Test %d: expected margin %d, got %d", i+1, testCase.expectedMargin, margin)
+		}
+	}
+}
@@ -253,10 +253,62 @@ func shouldHealObjectOnDisk(erErr, dataErr error, meta FileInfo, latestMeta File
 	return false
 }
 
+// shouldHealPartsOnDisk returns whether only the missing or corrupt parts
+// of an object need to be healed on a disk, instead of the whole object.
+// The disk must hold the latest xl.meta at its place in the erasure
+// distribution, which the healed parts leave unchanged.
+func shouldHealPartsOnDisk(erErr error, partErrs []error, meta FileInfo, latestMeta FileInfo, diskIndex int) bool {
+	if erErr != nil || len(partErrs) != len(latestMeta.Parts) || len(meta.Parts) != len(latestMeta.Parts) {
+		return false
+	}
+	if latestMeta.Deleted || latestMeta.IsRemote() || latestMeta.InlineData() || latestMeta.XLV1 {
+		return false
+	}
+	if shouldHealObjectOnDisk(erErr, nil, meta, latestMeta) {
+		return false
+	}
+	distribution := latestMeta.Erasure.Distribution
+	if meta.DataDir != latestMeta.DataDir || diskIndex >= len(distribution) ||
+		meta.Erasure.Index != distribution[diskIndex] {
+		return false
+	}
+	for i, part := range latestMeta.Parts {
+		if meta.Parts[i].Number != part.Number || meta.Parts[i].Size != part.Size {
+			return false
+		}
+		// Healed parts are written with the streaming bitrot algorithm,
+		// whose checksums are not kept in xl.meta.
+		if partErrs[i] != nil && meta.Erasure.GetChecksumInfo(part.Number).Algorithm != DefaultBitrotAlgorithm {
+			return false
+		}
+	}
+	return true
+}
+
+// shufflePartsErrs - shuffles the parts errors of each disk as
+// per the erasure distribution.
+func shufflePartsErrs(partsErrs [][]error, distribution []int) [][]error {
+	if distribution == nil {
+		return partsErrs
+	}
+	shuffled := make([][]error, len(partsErrs))
+	for index := range partsErrs {
+		blockIndex := distribution[index]
+		shuffled[blockIndex-1] = partsErrs[index]
+	}
+	return shuffled
+}
+
 // Heals an object by re-writing corrupt/missing erasure blocks.
 func (er erasureObjects) healObject(ctx context.Context, bucket string, object string, versionID string, opts madmin.HealOpts) (result madmin.HealResultItem, err error) {
+	// Number of outdated disks the object was written to.
+	var disksHealed int
 	if !opts.DryRun {
 		defer NSUpdated(bucket, object)
+		defer func() {
+			// ctx is canceled once the object lock is released.
+			sendObjectHealEvent(GlobalContext, bucket, object, versionID, result.ObjectSize, disksHealed, err)
+		}()
 	}
 
 	dryRun := opts.DryRun
@@ -312,13 +364,21 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 	// used here for reconstruction. This is done to ensure that
 	// we do not skip drives that have inconsistent metadata to be
 	// skipped from purging when they are stale.
-	availableDisks, dataErrs := disksWithAllParts(ctx, onlineDisks, partsMetadata,
+	//
+	// disksWithAllParts invalidates the metadata of the disks failing
+	// the checks, keep the original to heal only their bad parts.
+	metaArr := make([]FileInfo, len(partsMetadata))
+	copy(metaArr, partsMetadata)
+	availableDisks, dataErrs, partsErrs := disksWithAllParts(ctx, onlineDisks, partsMetadata,
 		errs, latestMeta, bucket, object, scanMode)
 
 	// Loop to find number of disks with valid data, per-drive
 	// data state and a list of outdated disks on which data needs
 	// to be healed.
 	outDatedDisks := make([]StorageAPI, len(storageDisks))
+	// Parts errors of the outdated disks on which only the
+	// missing or corrupt parts are healed.
+	badParts := make([][]error, len(storageDisks))
 	numAvailableDisks := 0
 	disksToHealCount := 0
 	for i, v := range availableDisks {
@@ -348,6 +408,9 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 		if shouldHealObjectOnDisk(errs[i], dataErrs[i], partsMetadata[i], latestMeta) {
 			outDatedDisks[i] = storageDisks[i]
 			disksToHealCount++
+			if shouldHealPartsOnDisk(errs[i], partsErrs[i], metaArr[i], latestMeta, i) {
+				badParts[i] = partsErrs[i]
+			}
 			result.Before.Drives = append(result.Before.Drives, madmin.HealDriveInfo{
 				UUID:     "",
 				Endpoint: storageEndpoints[i].String(),
@@ -399,6 +462,19 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 		return result, nil
 	}
 
+	// Bytes written to each outdated disk, in erasure distribution order.
+	healedBytes := make([]int64, len(outDatedDisks))
+	if globalHealEvents.NumSubscribers() > 0 {
+		defer func() {
+			var bytesReconstructed int64
+			for _, n := range healedBytes {
+				bytesReconstructed += n
+			}
+			publishHealEvent(bucket, object, versionID, disksHealed, bytesReconstructed,
+				storageEndpoints, dataErrs, err)
+		}()
+	}
+
 	cleanFileInfo := func(fi FileInfo) FileInfo {
 		// Returns a copy of the 'fi' with checksums and parts nil'ed.
 		nfi := fi
@@ -419,6 +495,10 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 			continue
 		}
 		copyPartsMetadata[i] = partsMetadata[i]
+		if badParts[i] != nil {
+			// The intact parts are read from the disk.
+			copyPartsMetadata[i] = metaArr[i]
+		}
 		partsMetadata[i] = cleanFileInfo(latestMeta)
 	}
 
@@ -440,6 +520,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 	// Reorder so that we have data disks first and parity disks next.
 	latestDisks := shuffleDisks(availableDisks, latestMeta.Erasure.Distribution)
 	outDatedDisks = shuffleDisks(outDatedDisks, latestMeta.Erasure.Distribution)
+	badParts = shufflePartsErrs(badParts, latestMeta.Erasure.Distribution)
 	partsMetadata = shufflePartsMetadata(partsMetadata, latestMeta.Erasure.Distribution)
 	copyPartsMetadata = shufflePartsMetadata(copyPartsMetadata, latestMeta.Erasure.Distribution)
 
@@ -457,14 +538,35 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 		}
 
 		erasureInfo := latestMeta.Erasure
-		for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
+
+		healCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// outDatedDisks that had write errors should not be
+		// written to for remaining parts.
+		var failedMu sync.Mutex
+		failedDisks := make([]bool, len(outDatedDisks))
+
+		// needsPart returns whether a part is healed on an outdated
+		// disk, only the bad parts are healed on some disks.
+		needsPart := func(i, partIndex int) bool {
+			return badParts[i] == nil || badParts[i][partIndex] != nil
+		}
+
+		// healPart heals a single part and returns the writers of
+		// the healed part by outdated disk, a nil writer indicates
+		// a write error.
+		healPart := func(partIndex int) ([]io.Writer, error) {
 			partSize := latestMeta.Parts[partIndex].Size
-			partActualSize := latestMeta.Parts[partIndex].ActualSize
 			partNumber := latestMeta.Parts[partIndex].Number
 			tillOffset := erasure.ShardFileOffset(0, partSize, partSize)
 			readers := make([]io.ReaderAt, len(latestDisks))
 			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
 			for i, disk := range latestDisks {
+				if disk == OfflineDisk && badParts[i] != nil && badParts[i][partIndex] == nil {
+					// The part is intact on a disk missing other parts.
+					disk = outDatedDisks[i]
+				}
 				if disk == OfflineDisk {
 					continue
 				}
@@ -474,10 +576,13 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 					checksumInfo.Hash, erasure.ShardSize())
 			}
 			writers := make([]io.Writer, len(outDatedDisks))
+			var toHeal int
+			failedMu.Lock()
 			for i, disk := range outDatedDisks {
-				if disk == OfflineDisk {
+				if disk == OfflineDisk || failedDisks[i] || !needsPart(i, partIndex) {
 					continue
 				}
+				toHeal++
 				partPath := pathJoin(tmpID, dstDataDir, fmt.Sprintf("part.%d", partNumber))
 				if len(inlineBuffers) > 0 {
 					inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, erasure.ShardFileSize(latestMeta.Size)+32))
@@ -487,17 +592,84 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 						tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
 				}
 			}
-			err = erasure.Heal(ctx, writers, readers, partSize)
+			failedMu.Unlock()
+			if toHeal == 0 {
+				// The part is intact on all outdated disks.
+				return writers, nil
+			}
+			err := erasure.Heal(healCtx, writers, readers, partSize)
 			closeBitrotReaders(readers)
 			closeBitrotWriters(writers)
 			if err != nil {
+				return nil, err
+			}
+
+			failedMu.Lock()
+			for i, disk := range outDatedDisks {
+				if disk != OfflineDisk && writers[i] == nil && needsPart(i, partIndex) {
+					failedDisks[i] = true
+				}
+			}
+			failedMu.Unlock()
+			return writers, nil
+		}
+
+		// Inline data is healed into a single buffer per disk,
+		// only heal parts of larger objects in parallel.
+		workers := globalHealConfig.PartsConcurrency()
+		if workers > len(latestMeta.Parts) {
+			workers = len(latestMeta.Parts)
+		}
+		if workers < 1 || len(inlineBuffers) > 0 {
+			workers = 1
+		}
+
+		partsWriters := make([][]io.Writer, len(latestMeta.Parts))
+		partsErrs := make([]error, len(latestMeta.Parts))
+
+		partIndexCh := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for partIndex := range partIndexCh {
+					partsWriters[partIndex], partsErrs[partIndex] = healPart(partIndex)
+					if partsErrs[partIndex] != nil {
+						// Stop healing remaining parts.
+						cancel()
+					}
+				}
+			}()
+		}
+	dispatch:
+		for partIndex := range latestMeta.Parts {
+			select {
+			case partIndexCh <- partIndex:
+			case <-healCtx.Done():
+				for ; partIndex < len(latestMeta.Parts); partIndex++ {
+					partsErrs[partIndex] = healCtx.Err()
+				}
+				break dispatch
+			}
+		}
+		close(partIndexCh)
+		wg.Wait()
+
+		for partIndex, writers := range partsWriters {
+			if err = partsErrs[partIndex]; err != nil {
 				return result, toObjectErr(err, bucket, object)
 			}
 
+			partSize := latestMeta.Parts[partIndex].Size
+			partActualSize := latestMeta.Parts[partIndex].ActualSize
+			partNumber := latestMeta.Parts[partIndex].Number
+			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
+
 			// outDatedDisks that had write errors should not be
 			// written to for remaining parts, so we nil it out.
 			for i, disk := range outDatedDisks {
-				if disk == OfflineDisk {
+				if disk == OfflineDisk || !needsPart(i, partIndex) {
 					continue
 				}
 
@@ -509,6 +681,11 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 					continue
 				}
 
+				healedBytes[i] += erasure.ShardFileSize(partSize)
+				if badParts[i] != nil {
+					// The xl.meta on the disk is kept as is.
+					continue
+				}
 				partsMetadata[i].DataDir = dstDataDir
 				partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
 				partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
@@ -527,9 +704,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 			if disksToHealCount == 0 {
 				return result, fmt.Errorf("all disks had write errors, unable to heal")
 			}
-
 		}
-
 	}
 
 	defer er.deleteObject(context.Background(), minioMetaTmpBucket, tmpID, len(storageDisks)/2+1)
@@ -540,14 +715,31 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 			continue
 		}
 
-		// record the index of the updated disks
-		partsMetadata[i].Erasure.Index = i + 1
+		if badParts[i] != nil {
+			// Replace only the healed parts, the xl.meta on the
+			// disk already describes them.
+			for partIndex, perr := range badParts[i] {
+				if perr == nil {
+					continue
+				}
+				partPath := fmt.Sprintf("part.%d", latestMeta.Parts[partIndex].Number)
+				if err = disk.RenameFile(ctx, minioMetaTmpBucket, pathJoin(tmpID, dstDataDir, partPath),
+					bucket, pathJoin(object, dstDataDir, partPath)); err != nil {
+					logger.LogIf(ctx, err)
+					return result, toObjectErr(err, bucket, object)
+				}
+			}
+		} else {
+			// record the index of the updated disks
+			partsMetadata[i].Erasure.Index = i + 1
 
-		// Attempt a rename now from healed data to final location.
-		if err = disk.RenameData(ctx, minioMetaTmpBucket, tmpID, partsMetadata[i], bucket, object); err != nil {
-			logger.LogIf(ctx, err)
-			return result, toObjectErr(err, bucket, object)
+			// Attempt a rename now from healed data to final location.
+			if err = disk.RenameData(ctx, minioMetaTmpBucket, tmpID, partsMetadata[i], bucket, object); err != nil {
+				logger.LogIf(ctx, err)
+				return result, toObjectErr(err, bucket, object)
+			}
 		}
+		disksHealed++
 
 		// Remove any remaining parts from outdated disks from before transition.
 		if partsMetadata[i].IsRemote() {
@@ -887,6 +1079,13 @@ func isObjectDangling(metaArr []FileInfo, errs []error, dataErrs []error) (valid
 
 // HealObject - heal the given object, automatically deletes the object if stale/corrupted if `remove` is true.
 func (er erasureObjects) HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (hr madmin.HealResultItem, err error) {
+	return er.healObjectVersion(ctx, bucket, object, versionID, opts, true)
+}
+
+// healObjectVersion heals the given object version as HealObject does,
+// quickCheck skips the unlocked check that the object is present on some
+// disk, for callers which already checked it for many objects at once.
+func (er erasureObjects) healObjectVersion(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts, quickCheck bool) (hr madmin.HealResultItem, err error) {
 	defer func() {
 		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 			err = nil
@@ -917,17 +1116,19 @@ func (er erasureObjects) HealObject(ctx context.Context, bucket, object, version
 		versionID = nullVersionID
 	}
 
-	// Perform quick read without lock.
-	// This allows to quickly check if all is ok or all are missing.
-	_, errs := readAllFileInfo(healCtx, storageDisks, bucket, object, versionID, false)
-	if isAllNotFound(errs) {
-		err = toObjectErr(errFileNotFound, bucket, object)
-		if versionID != "" {
-			err = toObjectErr(errFileVersionNotFound, bucket, object, versionID)
+	if quickCheck {
+		// Perform quick read without lock.
+		// This allows to quickly check if all is ok or all are missing.
+		_, errs := readAllFileInfo(healCtx, storageDisks, bucket, object, versionID, false)
+		if isAllNotFound(errs) {
+			err = toObjectErr(errFileNotFound, bucket, object)
+			if versionID != "" {
+				err = toObjectErr(errFileVersionNotFound, bucket, object, versionID)
+			}
+			// Nothing to do, file is already gone.
+			return er.defaultHealResult(FileInfo{}, storageDisks, storageEndpoints,
+				errs, bucket, object, versionID), err
 		}
-		// Nothing to do, file is already gone.
-		return er.defaultHealResult(FileInfo{}, storageDisks, storageEndpoints,
-			errs, bucket, object, versionID), err
 	}
 
 	// Heal the object.
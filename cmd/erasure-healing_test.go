@@ -22,15 +22,18 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"io"
 	"os"
 	"path"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config/heal"
 )
 
 // Tests both object and bucket healing.
@@ -46,7 +49,7 @@ func TestHealing(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	er := z.serverPools[0].sets[0]
+	er := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -177,7 +180,7 @@ func TestHealingDanglingObject(t *testing.T) {
 		t.Fatalf("Failed to make a bucket - %v", err)
 	}
 
-	disks = objLayer.(*erasureServerPools).serverPools[0].erasureDisks[0]
+	disks = objLayer.(*erasureServerPools).getServerPools()[0].erasureDisks[0]
 	orgDisks := append([]StorageAPI{}, disks...)
 
 	// Enable versioning.
@@ -191,9 +194,9 @@ func TestHealingDanglingObject(t *testing.T) {
 	}
 
 	setDisks := func(newDisks ...StorageAPI) {
-		objLayer.(*erasureServerPools).serverPools[0].erasureDisksMu.Lock()
+		objLayer.(*erasureServerPools).getServerPools()[0].erasureDisksMu.Lock()
 		copy(disks, newDisks)
-		objLayer.(*erasureServerPools).serverPools[0].erasureDisksMu.Unlock()
+		objLayer.(*erasureServerPools).getServerPools()[0].erasureDisksMu.Unlock()
 	}
 	// Remove 4 disks.
 	setDisks(nil, nil, nil, nil)
@@ -216,7 +219,7 @@ func TestHealingDanglingObject(t *testing.T) {
 		t.Fatalf("Expected versions 1, got %d", fileInfoPreHeal.NumVersions)
 	}
 
-	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true},
+	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true}, HealTimeWindow{},
 		func(bucket, object, vid string) error {
 			_, err := objLayer.HealObject(ctx, bucket, object, vid, madmin.HealOpts{Remove: true})
 			return err
@@ -263,7 +266,7 @@ func TestHealingDanglingObject(t *testing.T) {
 		t.Fatalf("Expected versions 1, got %d", fileInfoPreHeal.NumVersions)
 	}
 
-	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true},
+	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true}, HealTimeWindow{},
 		func(bucket, object, vid string) error {
 			_, err := objLayer.HealObject(ctx, bucket, object, vid, madmin.HealOpts{Remove: true})
 			return err
@@ -310,7 +313,7 @@ func TestHealingDanglingObject(t *testing.T) {
 		t.Fatalf("Expected versions 3, got %d", fileInfoPreHeal.NumVersions)
 	}
 
-	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true},
+	if err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{Remove: true}, HealTimeWindow{},
 		func(bucket, object, vid string) error {
 			_, err := objLayer.HealObject(ctx, bucket, object, vid, madmin.HealOpts{Remove: true})
 			return err
@@ -385,7 +388,7 @@ func TestHealObjectCorrupted(t *testing.T) {
 
 	// Test 1: Remove the object backend files from the first disk.
 	z := objLayer.(*erasureServerPools)
-	er := z.serverPools[0].sets[0]
+	er := z.getServerPools()[0].sets[0]
 	erasureDisks := er.getDisks()
 	firstDisk := erasureDisks[0]
 	err = firstDisk.Delete(context.Background(), bucket, pathJoin(object, xlStorageFormatFile), false)
@@ -530,7 +533,7 @@ func TestHealObjectErasure(t *testing.T) {
 
 	// Remove the object backend files from the first disk.
 	z := obj.(*erasureServerPools)
-	er := z.serverPools[0].sets[0]
+	er := z.getServerPools()[0].sets[0]
 	firstDisk := er.getDisks()[0]
 
 	_, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, ObjectOptions{})
@@ -553,7 +556,7 @@ func TestHealObjectErasure(t *testing.T) {
 	}
 
 	erasureDisks := er.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	er.getDisks = func() []StorageAPI {
 		// Nil more than half the disks, to remove write quorum.
 		for i := 0; i <= len(erasureDisks)/2; i++ {
@@ -561,7 +564,7 @@ func TestHealObjectErasure(t *testing.T) {
 		}
 		return erasureDisks
 	}
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 
 	// Try healing now, expect to receive errDiskNotFound.
 	_, err = obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealDeepScan})
@@ -607,7 +610,7 @@ func TestHealEmptyDirectoryErasure(t *testing.T) {
 
 	// Remove the object backend files from the first disk.
 	z := obj.(*erasureServerPools)
-	er := z.serverPools[0].sets[0]
+	er := z.getServerPools()[0].sets[0]
 	firstDisk := er.getDisks()[0]
 	err = firstDisk.DeleteVol(context.Background(), pathJoin(bucket, encodeDirObject(object)), true)
 	if err != nil {
@@ -711,7 +714,7 @@ func TestHealLastDataShard(t *testing.T) {
 			actualSha256 := actualH.Sum(nil)
 
 			z := obj.(*erasureServerPools)
-			er := z.serverPools[0].getHashedSet(object)
+			er := z.getServerPools()[0].getHashedSet(object)
 
 			disks := er.getDisks()
 			distribution := hashOrder(pathJoin(bucket, object), nDisks)
@@ -774,3 +777,332 @@ func TestHealLastDataShard(t *testing.T) {
 		})
 	}
 }
+
+// Tests healing of multipart objects with parts healed in parallel.
+func TestHealObjectPartsParallel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	healCfg := globalHealConfig
+	globalHealConfig.Update(heal.Config{PartWorkers: 3})
+	defer globalHealConfig.Update(healCfg)
+
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create a multipart upload - %v", err)
+	}
+	var uploadedParts []CompletePart
+	content := sha256.New()
+	for partID := 1; partID <= 7; partID++ {
+		data := make([]byte, 5*humanize.MiByte)
+		if _, err = rand.Read(data); err != nil {
+			t.Fatal(err)
+		}
+		content.Write(data)
+		pInfo, err := obj.PutObjectPart(ctx, bucket, object, uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Failed to upload a part - %v", err)
+		}
+		uploadedParts = append(uploadedParts, CompletePart{PartNumber: pInfo.PartNumber, ETag: pInfo.ETag})
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, ObjectOptions{}); err != nil {
+		t.Fatalf("Failed to complete multipart upload - %v", err)
+	}
+
+	z := obj.(*erasureServerPools)
+	er := z.getServerPools()[0].getHashedSet(object)
+	shuffledDisks := shuffleDisks(er.getDisks(), hashOrder(pathJoin(bucket, object), nDisks))
+
+	// Remove a data and a parity shard.
+	for _, disk := range []StorageAPI{shuffledDisks[0], shuffledDisks[nDisks-1]} {
+		if err = removeAll(pathJoin(disk.String(), bucket, object)); err != nil {
+			t.Fatalf("Failed to delete a file - %v", err)
+		}
+	}
+
+	result, err := obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, drive := range result.After.Drives {
+		if drive.State != madmin.DriveStateOk {
+			t.Fatalf("Expected all drives to be healed, got %v", result.After.Drives)
+		}
+	}
+
+	// Remove more shards so that reads depend on the healed ones.
+	for _, disk := range shuffledDisks[1:3] {
+		if err = removeAll(pathJoin(disk.String(), bucket, object)); err != nil {
+			t.Fatalf("Failed to delete a file - %v", err)
+		}
+	}
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, noLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	healedH := sha256.New()
+	if _, err = io.Copy(healedH, gr); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content.Sum(nil), healedH.Sum(nil)) {
+		t.Fatal("object healed wrong")
+	}
+}
+
+func TestHealObjectsTimeWindow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nDisks := 4
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	data := []byte("hello")
+	for _, object := range []string{"a", "b/c"} {
+		_, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := UTCNow()
+
+	testCases := []struct {
+		window   HealTimeWindow
+		expected int
+	}{
+		{HealTimeWindow{}, 2},
+		{HealTimeWindow{After: now.Add(-time.Hour)}, 2},
+		{HealTimeWindow{After: now.Add(time.Hour)}, 0},
+		{HealTimeWindow{Before: now.Add(-time.Hour)}, 0},
+		{HealTimeWindow{After: now.Add(-time.Hour), Before: now.Add(time.Hour)}, 2},
+	}
+
+	for i, testCase := range testCases {
+		var mu sync.Mutex
+		var healed int
+		err = objLayer.HealObjects(ctx, bucket, "", madmin.HealOpts{}, testCase.window, func(bucket, object, versionID string) error {
+			mu.Lock()
+			healed++
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if healed != testCase.expected {
+			t.Errorf("Test %d: expected %d objects to be healed, got %d", i+1, testCase.expected, healed)
+		}
+	}
+}
+
+// Tests healing of only the missing and corrupt parts of an object.
+func TestHealObjectBadParts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nDisks := 8
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create a multipart upload - %v", err)
+	}
+	var uploadedParts []CompletePart
+	content := sha256.New()
+	for partID := 1; partID <= 3; partID++ {
+		data := make([]byte, 5*humanize.MiByte)
+		if _, err = rand.Read(data); err != nil {
+			t.Fatal(err)
+		}
+		content.Write(data)
+		pInfo, err := obj.PutObjectPart(ctx, bucket, object, uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Failed to upload a part - %v", err)
+		}
+		uploadedParts = append(uploadedParts, CompletePart{PartNumber: pInfo.PartNumber, ETag: pInfo.ETag})
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, ObjectOptions{}); err != nil {
+		t.Fatalf("Failed to complete multipart upload - %v", err)
+	}
+
+	z := obj.(*erasureServerPools)
+	er := z.getServerPools()[0].getHashedSet(object)
+	shuffledDisks := shuffleDisks(er.getDisks(), hashOrder(pathJoin(bucket, object), nDisks))
+
+	fi, err := shuffledDisks[0].ReadVersion(ctx, bucket, object, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partPath := func(disk StorageAPI, partNumber int) string {
+		return pathJoin(disk.String(), bucket, object, fi.DataDir, fmt.Sprintf("part.%d", partNumber))
+	}
+
+	// Remove a part of a data shard and corrupt a part of a parity shard.
+	if err = os.Remove(partPath(shuffledDisks[0], 2)); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Truncate(partPath(shuffledDisks[nDisks-1], 3), 1024); err != nil {
+		t.Fatal(err)
+	}
+	intactParts := map[string]os.FileInfo{}
+	for _, p := range []string{partPath(shuffledDisks[0], 1), partPath(shuffledDisks[0], 3), partPath(shuffledDisks[nDisks-1], 1)} {
+		st, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		intactParts[p] = st
+	}
+
+	result, err := obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, drive := range result.After.Drives {
+		if drive.State != madmin.DriveStateOk {
+			t.Fatalf("Expected all drives to be healed, got %v", result.After.Drives)
+		}
+	}
+
+	// The intact parts must not have been rewritten.
+	for p, st := range intactParts {
+		nst, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !os.SameFile(st, nst) {
+			t.Errorf("Expected %s to be kept as is", p)
+		}
+	}
+
+	// Remove more shards so that reads depend on the healed ones.
+	for _, disk := range shuffledDisks[1:3] {
+		if err = removeAll(pathJoin(disk.String(), bucket, object)); err != nil {
+			t.Fatalf("Failed to delete a file - %v", err)
+		}
+	}
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, noLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	healedH := sha256.New()
+	if _, err = io.Copy(healedH, gr); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content.Sum(nil), healedH.Sum(nil)) {
+		t.Fatal("object healed wrong")
+	}
+}
+
+// Tests healing a fresh drive, checking the objects in several batches.
+func TestHealErasureSetBatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetGlobalHealState()
+	defer resetGlobalHealState()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	globalBackgroundHealState.Lock()
+	globalBackgroundHealState.healSeqMap[minioReservedBucket] = newBgHealSequence()
+	globalBackgroundHealState.Unlock()
+	defer func() {
+		// The sequence has no heal routine, later tests must not queue to it.
+		globalBackgroundHealState.Lock()
+		delete(globalBackgroundHealState.healSeqMap, minioReservedBucket)
+		globalBackgroundHealState.Unlock()
+	}()
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var objects []string
+	data := bytes.Repeat([]byte("a"), 1024)
+	for i := 0; i < 2*readVersionsBatchSize+1; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, object)
+	}
+
+	z := obj.(*erasureServerPools)
+	er := z.getServerPools()[0].sets[0]
+	disk := er.getDisks()[0]
+
+	// Remove the bucket and all its objects from the first drive.
+	if err = os.RemoveAll(path.Join(disk.String(), bucket)); err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := disk.ReadVersions(ctx, bucket, objects[:1], nil, false); errs[0] == nil {
+		t.Fatal("expected the objects to be removed from the first drive")
+	}
+
+	tracker := newHealingTracker(disk)
+	if err = er.healErasureSet(ctx, []string{bucket}, tracker); err != nil {
+		t.Fatal(err)
+	}
+	if tracker.ItemsHealed != uint64(len(objects)) || tracker.ItemsFailed != 0 {
+		t.Fatalf("expected %d objects healed, got %d healed and %d failed", len(objects), tracker.ItemsHealed, tracker.ItemsFailed)
+	}
+
+	_, errs := disk.ReadVersions(ctx, bucket, objects, nil, false)
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("expected %s to be healed, got %v", objects[i], err)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/sync/errgroup"
+)
+
+var (
+	// error returned when an inline migration is started while another
+	// one is running on this node.
+	errInlineMigrationRunning = AdminError{
+		Code:       "XMinioAdminInlineMigrationRunning",
+		Message:    "An inline migration is already in progress",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when cancelling or querying without a migration.
+	errInlineMigrationNotStarted = AdminError{
+		Code:       "XMinioAdminInlineMigrationNotStarted",
+		Message:    "No inline migration was started on this node",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// shouldInlineShard returns true if an erasure shard of the given size is
+// to be stored inline in xl.meta. Versioned objects are only inlined when
+// smaller than 1/8th of the threshold, to keep xl.meta small when it
+// holds many versions.
+func shouldInlineShard(shardFileSize int64, versioned bool, threshold int64) bool {
+	if !versioned && shardFileSize < threshold {
+		return true
+	}
+	return shardFileSize < threshold/8
+}
+
+// InlineMigrationStatus is the progress of the current or last migration
+// of objects in or out of xl.meta started on this node.
+type InlineMigrationStatus struct {
+	Node      string    `json:"node"`
+	Bucket    string    `json:"bucket,omitempty"` // empty when migrating all buckets
+	Threshold int64     `json:"threshold"`
+	StartedAt time.Time `json:"startedAt"`
+	// CompletedAt is zero while the migration is running.
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	Cancelled   bool      `json:"cancelled,omitempty"`
+
+	Scanned   uint64 `json:"scanned"`
+	Inlined   uint64 `json:"inlined"`
+	Uninlined uint64 `json:"uninlined"`
+	// Skipped counts the versions which need to be healed first.
+	Skipped uint64 `json:"skipped"`
+	Failed  uint64 `json:"failed"`
+}
+
+type inlineMigration struct {
+	mu      sync.Mutex
+	status  *InlineMigrationStatus
+	running bool
+	cancel  context.CancelFunc
+}
+
+var globalInlineMigration = &inlineMigration{}
+
+func (m *inlineMigration) update(fn func(st *InlineMigrationStatus)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(m.status)
+}
+
+// getStatus returns a copy of the progress of the current or last migration.
+func (m *inlineMigration) getStatus() (InlineMigrationStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.status == nil {
+		return InlineMigrationStatus{}, errInlineMigrationNotStarted
+	}
+	return *m.status, nil
+}
+
+// stop cancels the running migration, the versions being rewritten are
+// completed.
+func (m *inlineMigration) stop() (InlineMigrationStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.running {
+		return InlineMigrationStatus{}, errInlineMigrationNotStarted
+	}
+	m.cancel()
+	m.status.Cancelled = true
+	return *m.status, nil
+}
+
+// startInlineMigration starts moving the data of the objects of bucket,
+// or of all buckets when empty, in or out of xl.meta to match the
+// current inline threshold.
+func (z *erasureServerPools) startInlineMigration(ctx context.Context, bucket string) (InlineMigrationStatus, error) {
+	var buckets []string
+	if bucket != "" {
+		if _, err := z.GetBucketInfo(ctx, bucket); err != nil {
+			return InlineMigrationStatus{}, err
+		}
+		buckets = append(buckets, bucket)
+	} else {
+		bis, err := z.ListBuckets(ctx)
+		if err != nil {
+			return InlineMigrationStatus{}, err
+		}
+		for _, bi := range bis {
+			buckets = append(buckets, bi.Name)
+		}
+	}
+
+	m := globalInlineMigration
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.running {
+		return InlineMigrationStatus{}, errInlineMigrationRunning
+	}
+
+	threshold := globalAPIConfig.getInlineThreshold()
+	m.status = &InlineMigrationStatus{
+		Node:      globalLocalNodeName,
+		Bucket:    bucket,
+		Threshold: threshold,
+		StartedAt: UTCNow(),
+	}
+	m.running = true
+
+	var runCtx context.Context
+	runCtx, m.cancel = context.WithCancel(GlobalContext)
+	go func() {
+		defer m.cancel()
+		for _, bucket := range buckets {
+			if err := z.migrateInlineBucket(runCtx, bucket, threshold); err != nil && runCtx.Err() == nil {
+				logger.LogIf(runCtx, err)
+			}
+		}
+		m.mu.Lock()
+		m.status.CompletedAt = UTCNow()
+		m.running = false
+		m.mu.Unlock()
+	}()
+	return *m.status, nil
+}
+
+// migrateInlineBucket lists the versions of all objects in bucket, set by
+// set, and rewrites the ones whose inline state does not match threshold.
+func (z *erasureServerPools) migrateInlineBucket(ctx context.Context, bucket string, threshold int64) error {
+	var wg sync.WaitGroup
+	for _, pool := range z.getServerPools() {
+		for _, set := range pool.sets {
+			set := set
+			disks, _ := set.getOnlineDisksWithHealing()
+			if len(disks) == 0 {
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				migrateEntry := func(entry metaCacheEntry) {
+					if entry.isDir() {
+						return
+					}
+					fivs, err := entry.fileInfoVersions(bucket)
+					if err != nil {
+						return
+					}
+					for _, version := range fivs.Versions {
+						if ctx.Err() != nil {
+							return
+						}
+						globalInlineMigration.update(func(st *InlineMigrationStatus) { st.Scanned++ })
+						if !needsInlineMigration(version, threshold) {
+							continue
+						}
+						inlined, migrated, err := set.migrateInlineVersion(ctx, bucket, version.Name, version.VersionID, threshold)
+						globalInlineMigration.update(func(st *InlineMigrationStatus) {
+							switch {
+							case err != nil:
+								st.Failed++
+							case !migrated:
+								st.Skipped++
+							case inlined:
+								st.Inlined++
+							default:
+								st.Uninlined++
+							}
+						})
+						if err != nil && ctx.Err() == nil {
+							logger.LogIf(ctx, err)
+						}
+					}
+				}
+
+				err := listPathRaw(ctx, listPathRawOptions{
+					disks:     disks,
+					bucket:    bucket,
+					recursive: true,
+					minDisks:  1,
+					agreed:    migrateEntry,
+					partial:   resolvePartialEntries(bucket, migrateEntry),
+				})
+				if err != nil && ctx.Err() == nil {
+					logger.LogIf(ctx, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// needsInlineMigration returns true if the data of the version is to be
+// moved in or out of xl.meta with the given threshold. Only single part
+// versions with data on the drives are considered.
+func needsInlineMigration(fi FileInfo, threshold int64) bool {
+	if fi.Deleted || fi.IsRemote() || fi.Size == 0 || len(fi.Parts) != 1 {
+		return false
+	}
+	versioned := fi.VersionID != "" && fi.VersionID != nullVersionID
+	return shouldInlineShard(fi.Erasure.ShardFileSize(fi.Size), versioned, threshold) != fi.InlineData()
+}
+
+// migrateInlineVersion moves the data of an object version in or out of
+// xl.meta to match threshold. Every drive holds its own shard, inline data
+// and part files share the same streaming bitrot format, so the shards
+// are moved as is without decoding. The version keeps its modtime and
+// metadata, only its data dir changes. Versions which are not consistent
+// on all drives are left for healing.
+func (er erasureObjects) migrateInlineVersion(ctx context.Context, bucket, object, versionID string, threshold int64) (inlined, migrated bool, err error) {
+	lk := er.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return false, false, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	disks := er.getDisks()
+	metaArr, errs := readAllFileInfo(ctx, disks, bucket, object, versionID, true)
+	for _, err := range errs {
+		if err != nil {
+			// Removed in the meantime or needs healing first.
+			return false, false, nil
+		}
+	}
+
+	// ReadVersion marks the version as inline when its data is
+	// really held in xl.meta.
+	fi := metaArr[0]
+	for _, meta := range metaArr[1:] {
+		if !meta.ModTime.Equal(fi.ModTime) || meta.DataDir != fi.DataDir || meta.InlineData() != fi.InlineData() {
+			return false, false, nil
+		}
+	}
+	if !needsInlineMigration(fi, threshold) || fi.Parts[0].Number != 1 {
+		return false, false, nil
+	}
+	if fi.Erasure.GetChecksumInfo(1).Algorithm != DefaultBitrotAlgorithm {
+		// Whole file bitrot protected parts have no inline form.
+		return false, false, nil
+	}
+	inline := !fi.InlineData()
+
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, metaArr, errs, er.defaultParityCount)
+	if err != nil {
+		return false, false, err
+	}
+
+	partName := "part.1"
+	tmpID := mustGetUUID()
+	dataDir := mustGetUUID()
+	defer er.deleteAll(context.Background(), minioMetaTmpBucket, tmpID)
+
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			disk := disks[index]
+			if disk == nil {
+				return errDiskNotFound
+			}
+			nfi := metaArr[index]
+			nfi.DataDir = dataDir
+			if inline {
+				buf, err := disk.ReadAll(ctx, bucket, pathJoin(object, fi.DataDir, partName))
+				if err != nil {
+					return err
+				}
+				nfi.Data = buf
+				nfi.SetInlineData()
+			} else {
+				if len(nfi.Data) == 0 {
+					return errFileCorrupt
+				}
+				err := disk.CreateFile(ctx, minioMetaTmpBucket, pathJoin(tmpID, dataDir, partName),
+					int64(len(nfi.Data)), bytes.NewReader(nfi.Data))
+				if err != nil {
+					return err
+				}
+				nfi.Data = nil
+				delete(nfi.Metadata, ReservedMetadataPrefixLower+"inline-data")
+			}
+			return disk.RenameData(ctx, minioMetaTmpBucket, tmpID, nfi, bucket, object)
+		}, index)
+	}
+	errs = g.Wait()
+
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return false, false, toObjectErr(err, bucket, object)
+	}
+	for _, err := range errs {
+		if err != nil {
+			// Let the drives which failed catch up.
+			er.addPartial(bucket, object, fi.VersionID, fi.Size)
+			break
+		}
+	}
+	return inline, true, nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShouldInlineShard(t *testing.T) {
+	testCases := []struct {
+		size      int64
+		versioned bool
+		threshold int64
+		inline    bool
+	}{
+		{size: 0, threshold: smallFileThreshold, inline: true},
+		{size: smallFileThreshold - 1, threshold: smallFileThreshold, inline: true},
+		{size: smallFileThreshold, threshold: smallFileThreshold, inline: false},
+		{size: smallFileThreshold - 1, versioned: true, threshold: smallFileThreshold, inline: false},
+		{size: smallFileThreshold/8 - 1, versioned: true, threshold: smallFileThreshold, inline: true},
+		{size: 512 << 10, threshold: 1 << 20, inline: true},
+		{size: 1 << 10, threshold: 1, inline: false},
+	}
+	for i, tc := range testCases {
+		if got := shouldInlineShard(tc.size, tc.versioned, tc.threshold); got != tc.inline {
+			t.Errorf("case %d: expected %v, got %v", i+1, tc.inline, got)
+		}
+	}
+}
+
+func TestMigrateInlineVersion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(disks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+
+	const bucket = "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 64<<10)
+	objects := []struct {
+		name string
+		opts ObjectOptions
+	}{
+		{name: "null-version"},
+		{name: "versioned", opts: ObjectOptions{Versioned: true, VersionID: mustGetUUID()}},
+	}
+
+	// readMeta returns the metadata of the object on all drives, after
+	// checking that they agree on the inline state.
+	readMeta := func(object, versionID string, inline bool) []FileInfo {
+		t.Helper()
+		set := z.getServerPools()[0].getHashedSet(object)
+		metaArr, errs := readAllFileInfo(ctx, set.getDisks(), bucket, object, versionID, true)
+		for i := range metaArr {
+			if errs[i] != nil {
+				t.Fatal(errs[i])
+			}
+			if metaArr[i].InlineData() != inline {
+				t.Fatalf("%s: expected inline %v, got %v", object, inline, metaArr[i].InlineData())
+			}
+		}
+		return metaArr
+	}
+	checkData := func(object, versionID string) {
+		t.Helper()
+		gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%s: content mismatch after migration", object)
+		}
+	}
+	// dataDirExists returns true if the data dir exists on any drive.
+	dataDirExists := func(object, dataDir string) bool {
+		for _, disk := range disks {
+			if _, err := os.Stat(filepath.Join(disk, bucket, object, dataDir)); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, obj := range objects {
+		_, err = z.PutObject(ctx, bucket, obj.name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), obj.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		set := z.getServerPools()[0].getHashedSet(obj.name)
+		versionID := obj.opts.VersionID
+		inline := !obj.opts.Versioned
+
+		// Nothing to do with the threshold the object was written with.
+		if _, migrated, err := set.migrateInlineVersion(ctx, bucket, obj.name, versionID, smallFileThreshold); err != nil || migrated {
+			t.Fatalf("%s: expected no migration, got migrated=%v err=%v", obj.name, migrated, err)
+		}
+
+		// Move the data out of xl.meta, or into it for the versioned
+		// object which was too large to be inlined.
+		threshold := int64(1)
+		if !inline {
+			threshold = 1 << 20
+		}
+		before := readMeta(obj.name, versionID, inline)
+		inlined, migrated, err := set.migrateInlineVersion(ctx, bucket, obj.name, versionID, threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !migrated || inlined == inline {
+			t.Fatalf("%s: expected migration to inline %v, got migrated=%v inlined=%v", obj.name, !inline, migrated, inlined)
+		}
+		after := readMeta(obj.name, versionID, !inline)
+		if !after[0].ModTime.Equal(before[0].ModTime) || after[0].VersionID != before[0].VersionID {
+			t.Fatalf("%s: version changed by migration", obj.name)
+		}
+		if !inline && dataDirExists(obj.name, before[0].DataDir) {
+			t.Fatalf("%s: previous data dir was not removed", obj.name)
+		}
+		if inline && !dataDirExists(obj.name, after[0].DataDir) {
+			t.Fatalf("%s: data dir was not created", obj.name)
+		}
+		checkData(obj.name, versionID)
+
+		// And back again with the default threshold.
+		if !inline {
+			threshold = smallFileThreshold
+		} else {
+			threshold = 1 << 20
+		}
+		if _, migrated, err = set.migrateInlineVersion(ctx, bucket, obj.name, versionID, threshold); err != nil || !migrated {
+			t.Fatalf("%s: expected migration back, got migrated=%v err=%v", obj.name, migrated, err)
+		}
+		readMeta(obj.name, versionID, inline)
+		if inline && dataDirExists(obj.name, after[0].DataDir) {
+			t.Fatalf("%s: data dir was not removed", obj.name)
+		}
+		checkData(obj.name, versionID)
+	}
+}
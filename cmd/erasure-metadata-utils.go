@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"hash/crc32"
+	"sync"
 
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
@@ -154,6 +155,54 @@ func readAllFileInfo(ctx context.Context, disks []StorageAPI, bucket, object, ve
 	return metadataArray, g.Wait()
 }
 
+// readVersionsBatchSize is the number of objects whose `xl.meta` are
+// read with a single call to each disk by readAllFileInfos.
+const readVersionsBatchSize = 100
+
+// Reads a version of all objects in `xl.meta` of all disks, with one call
+// per disk, the one in versionIDs when not nil, the latest otherwise.
+// metaArrs and errs are indexed by object, then by disk.
+// It serves scans checking listed objects on all disks, such as healing,
+// plain listings already get `xl.meta` along with the walked entries.
+func readAllFileInfos(ctx context.Context, disks []StorageAPI, bucket string, objects, versionIDs []string, readData bool) (metaArrs [][]FileInfo, errs [][]error) {
+	metaArrs = make([][]FileInfo, len(objects))
+	errs = make([][]error, len(objects))
+	for i := range objects {
+		metaArrs[i] = make([]FileInfo, len(disks))
+		errs[i] = make([]error, len(disks))
+	}
+
+	var wg sync.WaitGroup
+	for index := range disks {
+		if disks[index] == nil {
+			for i := range objects {
+				errs[i][index] = errDiskNotFound
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			fis, derrs := disks[index].ReadVersions(ctx, bucket, objects, versionIDs, readData)
+			for i := range objects {
+				metaArrs[i][index], errs[i][index] = fis[i], derrs[i]
+				if derrs[i] != nil && !IsErr(derrs[i], []error{
+					errFileNotFound,
+					errVolumeNotFound,
+					errFileVersionNotFound,
+					errDiskNotFound,
+				}...) {
+					logger.LogOnceIf(ctx, fmt.Errorf("Drive %s, path (%s/%s) returned an error (%w)",
+						disks[index], bucket, objects[i], derrs[i]),
+						disks[index].String())
+				}
+			}
+		}(index)
+	}
+	wg.Wait()
+	return metaArrs, errs
+}
+
 // shuffleDisksAndPartsMetadataByIndex this function should be always used by GetObjectNInfo()
 // and CompleteMultipartUpload code path, it is not meant to be used with PutObject,
 // NewMultipartUpload metadata shuffling.
@@ -164,7 +164,7 @@ func TestShuffleDisks(t *testing.T) {
 
 // Test shuffleDisks which returns shuffled slice of disks for their actual distribution.
 func testShuffleDisks(t *testing.T, z *erasureServerPools) {
-	disks := z.serverPools[0].GetDisks(0)()
+	disks := z.getServerPools()[0].GetDisks(0)()
 	distribution := []int{16, 14, 12, 10, 8, 6, 4, 2, 1, 3, 5, 7, 9, 11, 13, 15}
 	shuffledDisks := shuffleDisks(disks, distribution)
 	// From the "distribution" above you can notice that:
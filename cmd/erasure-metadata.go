@@ -396,6 +396,11 @@ func objectQuorumFromMeta(ctx context.Context, partsMetaData []FileInfo, errs []
 	}
 
 	parityBlocks := globalStorageClass.GetParityForSC(latestFileInfo.Metadata[xhttp.AmzStorageClass])
+	if isCustomStorageClass(latestFileInfo.Metadata[xhttp.AmzStorageClass]) {
+		// The storage classes of a bucket may change, use the
+		// parity the object was written with.
+		parityBlocks = latestFileInfo.Erasure.ParityBlocks
+	}
 	if parityBlocks <= 0 {
 		parityBlocks = defaultParityCount
 	}
@@ -36,6 +36,15 @@ import (
 	"github.com/minio/pkg/mimedb"
 )
 
+const (
+	// multipartUploadObjectKey records the bucket and object of a multipart upload
+	// in its metadata, the upload directory is only named after their hash.
+	multipartUploadObjectKey = ReservedMetadataPrefix + "upload-object"
+	// multipartUploadInitiatedKey records when a multipart upload was initiated,
+	// its modtime is updated by each uploaded part.
+	multipartUploadInitiatedKey = ReservedMetadataPrefix + "upload-initiated"
+)
+
 func (er erasureObjects) getUploadIDDir(bucket, object, uploadID string) string {
 	return pathJoin(er.getMultipartSHADir(bucket, object), uploadID)
 }
@@ -143,6 +152,43 @@ func (er erasureObjects) deleteAll(ctx context.Context, bucket, prefix string) {
 	wg.Wait()
 }
 
+// abortIncompleteMultipartUpload aborts the multipart upload described by fi if
+// it is due per the AbortIncompleteMultipartUpload lifecycle rules of its bucket,
+// returns true if the upload was aborted.
+func (er erasureObjects) abortIncompleteMultipartUpload(ctx context.Context, fi FileInfo, uploadID string, now time.Time) bool {
+	bucketObject, ok := fi.Metadata[multipartUploadObjectKey]
+	if !ok {
+		// Uploads initiated by older servers are only
+		// removed by the stale uploads expiry.
+		return false
+	}
+	initiated, err := time.Parse(time.RFC3339Nano, fi.Metadata[multipartUploadInitiatedKey])
+	if err != nil {
+		return false
+	}
+	bucket, object := path2BucketObject(bucketObject)
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil || !lc.HasAbortIncompleteMultipartUpload() {
+		return false
+	}
+	if _, due := lc.PredictAbortMultipartTime(object, initiated); due.IsZero() || now.Before(due) {
+		return false
+	}
+
+	var size int64
+	for _, part := range fi.Parts {
+		size += part.Size
+	}
+	if err = er.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{}); err != nil {
+		// The upload may have been completed or aborted meanwhile,
+		// or by another drive of the set listing it too.
+		return false
+	}
+	globalLifecycleMultipartStats.aborted(size)
+	auditLogLifecycle(ctx, ObjectInfo{Bucket: bucket, Name: object}, ILMAbortMultipart)
+	return true
+}
+
 // Remove the old multipart uploads on the given disk.
 func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration) {
 	now := time.Now()
@@ -156,6 +202,10 @@ func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 				return nil
 			}
 			wait := er.deletedCleanupSleeper.Timer(ctx)
+			if er.abortIncompleteMultipartUpload(ctx, fi, strings.TrimSuffix(uploadIDDir, SlashSeparator), now) {
+				wait()
+				return nil
+			}
 			if now.Sub(fi.ModTime) > expiry {
 				er.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
 			}
@@ -284,7 +334,7 @@ func (er erasureObjects) ListMultipartUploads(ctx context.Context, bucket, objec
 // operation(s) on the object.
 func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string, object string, opts ObjectOptions) (string, error) {
 	onlineDisks := er.getDisks()
-	parityDrives := globalStorageClass.GetParityForSC(opts.UserDefined[xhttp.AmzStorageClass])
+	parityDrives := getParityForSC(bucket, opts.UserDefined[xhttp.AmzStorageClass])
 	if parityDrives <= 0 {
 		parityDrives = er.defaultParityCount
 	}
@@ -354,6 +404,8 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 
 	uploadID := mustGetUUID()
 	uploadIDPath := er.getUploadIDDir(bucket, object, uploadID)
+	opts.UserDefined[multipartUploadObjectKey] = pathJoin(bucket, object)
+	opts.UserDefined[multipartUploadInitiatedKey] = modTime.Format(time.RFC3339Nano)
 
 	// Write updated `xl.meta` to all disks.
 	if _, err := writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum); err != nil {
@@ -468,6 +520,14 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		return pi, err
 	}
 
+	// Reject a part which makes the upload impossible to complete
+	// before writing it.
+	if globalAPIConfig.isMultipartPartsValidated() {
+		if err = checkPartSize(fi.Parts, partID, data.ActualSize()); err != nil {
+			return pi, err
+		}
+	}
+
 	onlineDisks = shuffleDisks(onlineDisks, fi.Erasure.Distribution)
 
 	// Need a unique name for the part being written in minioMetaBucket to
@@ -596,6 +656,12 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 			Algorithm:  DefaultBitrotAlgorithm,
 			Hash:       bitrotWriterSum(writers[i]),
 		})
+		if opts.WantChecksum != nil {
+			if partsMetadata[i].Metadata == nil {
+				partsMetadata[i].Metadata = make(map[string]string)
+			}
+			partsMetadata[i].Metadata[multipartPartChecksumKey(partID)] = opts.WantChecksum.Encoded
+		}
 	}
 
 	// Writes update `xl.meta` format for each disk.
@@ -615,6 +681,29 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	}, nil
 }
 
+// checkPartSize validates the size of a part against the parts already
+// uploaded, all the parts but the last one must be at least the minimum
+// part size. A small part followed by another part can not be the last
+// part, it is rejected at upload instead of when the upload completes.
+func checkPartSize(parts []ObjectPartInfo, partID int, size int64) error {
+	for _, part := range parts {
+		switch {
+		case part.Number > partID && size >= 0 && !isMinAllowedPartSize(size):
+			return PartTooSmall{
+				PartNumber: partID,
+				PartSize:   size,
+			}
+		case part.Number < partID && !isMinAllowedPartSize(part.ActualSize):
+			return PartTooSmall{
+				PartNumber: part.Number,
+				PartSize:   part.ActualSize,
+				PartETag:   part.ETag,
+			}
+		}
+	}
+	return nil
+}
+
 // GetMultipartInfo returns multipart metadata uploaded during newMultipartUpload, used
 // by callers to verify object states
 // - encrypted
@@ -809,12 +898,6 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		return oi, err
 	}
 
-	// Calculate full object size.
-	var objectSize int64
-
-	// Calculate consolidated actual size.
-	var objectActualSize int64
-
 	// Order online disks in accordance with distribution order.
 	// Order parts metadata in accordance with distribution order.
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadataByIndex(onlineDisks, partsMetadata, fi)
@@ -822,55 +905,23 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Save current erasure metadata for validation.
 	var currentFI = fi
 
-	// Allocate parts similar to incoming slice.
-	fi.Parts = make([]ObjectPartInfo, len(parts))
-
-	// Validate each part and then commit to disk.
-	for i, part := range parts {
-		partIdx := objectPartIndex(currentFI.Parts, part.PartNumber)
-		// All parts should have same part number.
-		if partIdx == -1 {
-			invp := InvalidPart{
-				PartNumber: part.PartNumber,
-				GotETag:    part.ETag,
-			}
-			return oi, invp
-		}
-
-		// ensure that part ETag is canonicalized to strip off extraneous quotes
-		part.ETag = canonicalizeETag(part.ETag)
-		if currentFI.Parts[partIdx].ETag != part.ETag {
-			invp := InvalidPart{
-				PartNumber: part.PartNumber,
-				ExpETag:    currentFI.Parts[partIdx].ETag,
-				GotETag:    part.ETag,
-			}
-			return oi, invp
-		}
-
-		// All parts except the last part has to be atleast 5MB.
-		if (i < len(parts)-1) && !isMinAllowedPartSize(currentFI.Parts[partIdx].ActualSize) {
-			return oi, PartTooSmall{
-				PartNumber: part.PartNumber,
-				PartSize:   currentFI.Parts[partIdx].ActualSize,
-				PartETag:   part.ETag,
-			}
-		}
-
-		// Save for total object size.
-		objectSize += currentFI.Parts[partIdx].Size
-
-		// Save the consolidated actual size.
-		objectActualSize += currentFI.Parts[partIdx].ActualSize
+	// Validate the parts, the parts are validated concurrently in batches.
+	fi.Parts, err = validateCompleteParts(rctx, currentFI.Parts, parts)
+	if err != nil {
+		return oi, err
+	}
 
-		// Add incoming parts.
-		fi.Parts[i] = ObjectPartInfo{
-			Number:     part.PartNumber,
-			Size:       currentFI.Parts[partIdx].Size,
-			ActualSize: currentFI.Parts[partIdx].ActualSize,
-		}
+	// Calculate full object size and consolidated actual size.
+	var objectSize, objectActualSize int64
+	for _, part := range fi.Parts {
+		objectSize += part.Size
+		objectActualSize += part.ActualSize
 	}
 
+	// Drives which missed the upload of a part are not part of the
+	// object, they are healed once the upload completes.
+	verifyCompletePartsOnDisks(rctx, onlineDisks, partsMetadata, fi.Parts)
+
 	// Save the final object size and modtime.
 	fi.Size = objectSize
 	fi.ModTime = opts.MTime
@@ -887,6 +938,19 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The object no longer needs to be found from its upload.
+	delete(fi.Metadata, multipartUploadObjectKey)
+	delete(fi.Metadata, multipartUploadInitiatedKey)
+
+	// Save the composite checksum of the parts, if requested.
+	checksum, err := completeMultipartChecksum(fi.Metadata, parts)
+	if err != nil {
+		return oi, err
+	}
+	if checksum != nil {
+		fi.Metadata[objectChecksumKey] = checksum.String()
+	}
+
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
@@ -907,6 +971,10 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	if err = er.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
 	if err != nil {
@@ -914,17 +982,28 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	}
 
 	// Remove parts that weren't present in CompleteMultipartUpload request.
-	for _, curpart := range currentFI.Parts {
-		if objectPartIndex(fi.Parts, curpart.Number) == -1 {
+	completed := make(map[int]struct{}, len(fi.Parts))
+	for _, part := range fi.Parts {
+		completed[part.Number] = struct{}{}
+	}
+	g := errgroup.WithNErrs(len(currentFI.Parts)).WithConcurrency(completeMultipartConcurrency)
+	for index, curpart := range currentFI.Parts {
+		if _, ok := completed[curpart.Number]; ok {
+			continue
+		}
+		partNumber := curpart.Number
+		g.Go(func() error {
 			// Delete the missing part files. e.g,
 			// Request 1: NewMultipart
 			// Request 2: PutObjectPart 1
 			// Request 3: PutObjectPart 2
 			// Request 4: CompleteMultipartUpload --part 2
 			// N.B. 1st part is not present. This part should be removed from the storage.
-			er.removeObjectPart(bucket, object, uploadID, fi.DataDir, curpart.Number)
-		}
+			er.removeObjectPart(bucket, object, uploadID, fi.DataDir, partNumber)
+			return nil
+		}, index)
 	}
+	g.Wait()
 
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
@@ -957,6 +1036,131 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	return fi.ToObjectInfo(bucket, object), nil
 }
 
+// completeMultipartConcurrency is the number of batches of parts validated
+// concurrently, and of unused parts removed concurrently, when completing
+// a multipart upload.
+const completeMultipartConcurrency = 16
+
+// completeMultipartBatchSize is the number of parts validated by a goroutine.
+const completeMultipartBatchSize = 500
+
+// validateCompleteParts validates the parts of a CompleteMultipartUpload
+// request against the uploaded parts and returns the parts of the object,
+// the error of the first invalid part in the request is returned.
+func validateCompleteParts(ctx context.Context, uploaded []ObjectPartInfo, parts []CompletePart) ([]ObjectPartInfo, error) {
+	uploadedIdx := make(map[int]int, len(uploaded))
+	for i, part := range uploaded {
+		uploadedIdx[part.Number] = i
+	}
+
+	objectParts := make([]ObjectPartInfo, len(parts))
+	validate := func(i int) error {
+		part := parts[i]
+		partIdx, ok := uploadedIdx[part.PartNumber]
+		// All parts should have same part number.
+		if !ok {
+			return InvalidPart{
+				PartNumber: part.PartNumber,
+				GotETag:    part.ETag,
+			}
+		}
+
+		// ensure that part ETag is canonicalized to strip off extraneous quotes
+		etag := canonicalizeETag(part.ETag)
+		if uploaded[partIdx].ETag != etag {
+			return InvalidPart{
+				PartNumber: part.PartNumber,
+				ExpETag:    uploaded[partIdx].ETag,
+				GotETag:    etag,
+			}
+		}
+
+		// All parts except the last part has to be atleast 5MB.
+		if (i < len(parts)-1) && !isMinAllowedPartSize(uploaded[partIdx].ActualSize) {
+			return PartTooSmall{
+				PartNumber: part.PartNumber,
+				PartSize:   uploaded[partIdx].ActualSize,
+				PartETag:   etag,
+			}
+		}
+
+		// Add incoming parts.
+		objectParts[i] = ObjectPartInfo{
+			Number:     part.PartNumber,
+			Size:       uploaded[partIdx].Size,
+			ActualSize: uploaded[partIdx].ActualSize,
+		}
+		return nil
+	}
+
+	batches := (len(parts) + completeMultipartBatchSize - 1) / completeMultipartBatchSize
+	g := errgroup.WithNErrs(batches).WithConcurrency(completeMultipartConcurrency)
+	for b := 0; b < batches; b++ {
+		start := b * completeMultipartBatchSize
+		end := start + completeMultipartBatchSize
+		if end > len(parts) {
+			end = len(parts)
+		}
+		g.Go(func() error {
+			for i := start; i < end; i++ {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := validate(i); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, b)
+	}
+	// Batches are in the order of the parts, the first error
+	// is the one of the first invalid part.
+	for _, err := range g.Wait() {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objectParts, nil
+}
+
+// verifyCompletePartsOnDisks verifies concurrently on every drive that the
+// metadata of the upload has all the parts of the object, with the bitrot
+// checksum of the part on the drive. A drive which missed a part, e.g. as
+// it was offline during its upload, is removed from onlineDisks.
+func verifyCompletePartsOnDisks(ctx context.Context, onlineDisks []StorageAPI, partsMetadata []FileInfo, parts []ObjectPartInfo) {
+	var wg sync.WaitGroup
+	for index := range onlineDisks {
+		if onlineDisks[index] == nil || !partsMetadata[index].IsValid() {
+			continue
+		}
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			meta := partsMetadata[index]
+			diskParts := make(map[int]ObjectPartInfo, len(meta.Parts))
+			for _, part := range meta.Parts {
+				diskParts[part.Number] = part
+			}
+			checksums := make(map[int]struct{}, len(meta.Erasure.Checksums))
+			for _, sum := range meta.Erasure.Checksums {
+				checksums[sum.PartNumber] = struct{}{}
+			}
+			for _, part := range parts {
+				diskPart, ok := diskParts[part.Number]
+				if !ok || diskPart.Size != part.Size {
+					onlineDisks[index] = nil
+					return
+				}
+				if _, ok = checksums[part.Number]; !ok {
+					onlineDisks[index] = nil
+					return
+				}
+			}
+		}(index)
+	}
+	wg.Wait()
+}
+
 // AbortMultipartUpload - aborts an ongoing multipart operation
 // signified by the input uploadID. This is an atomic operation
 // doesn't require clients to initiate multiple such requests.
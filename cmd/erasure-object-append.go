@@ -0,0 +1,342 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+)
+
+// appendObject appends the data to the latest version of an object, the
+// appended data is erasure coded into a new part of the object which
+// shares the data dir of the previous version, so the existing data is
+// neither read nor rewritten. Objects with inlined data are small and are
+// rewritten as a whole. The result is a new version in a versioned bucket,
+// otherwise the null version is replaced.
+func (er erasureObjects) appendObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	data := r.Reader
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, toObjectErr(errInvalidArgument)
+	}
+
+	// The size of the object is validated against the offset before
+	// the data is written, hold the lock for the entire append.
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+
+	fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, true)
+	if err != nil {
+		err = toObjectErr(err, bucket, object)
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return ObjectInfo{}, err
+		}
+	}
+	if err != nil || fi.Deleted {
+		// Appending to a missing object creates it.
+		if opts.AppendOffset != 0 {
+			return ObjectInfo{}, InvalidArgument{
+				Bucket: bucket,
+				Object: object,
+				Err:    fmt.Errorf("object does not exist, write offset must be 0 not %d", opts.AppendOffset),
+			}
+		}
+		if opts.CheckPrecondFn != nil && opts.CheckPrecondFn(ObjectInfo{}) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+		opts.Append = false
+		opts.CheckPrecondFn = nil
+		opts.NoLock = true
+		return er.putObject(ctx, bucket, object, r, opts)
+	}
+
+	oi := fi.ToObjectInfo(bucket, object)
+	if opts.CheckPrecondFn != nil && opts.CheckPrecondFn(oi) {
+		return ObjectInfo{}, PreConditionFailed{}
+	}
+	if opts.AppendOffset != fi.Size {
+		return ObjectInfo{}, InvalidArgument{
+			Bucket: bucket,
+			Object: object,
+			Err:    fmt.Errorf("write offset %d does not match the object size %d", opts.AppendOffset, fi.Size),
+		}
+	}
+	if _, encrypted := crypto.IsEncrypted(fi.Metadata); encrypted {
+		return ObjectInfo{}, NotImplemented{Message: "Appending to an encrypted object is not supported"}
+	}
+	if oi.IsCompressed() || fi.IsRemote() || fi.XLV1 {
+		return ObjectInfo{}, NotImplemented{Message: "Appending to a compressed, transitioned or legacy object is not supported"}
+	}
+	if isMaxObjectSize(fi.Size + data.Size()) {
+		return ObjectInfo{}, ObjectTooLarge{Bucket: bucket, Object: object}
+	}
+
+	// The metadata of the object is kept, the metadata sent with the
+	// append is applied on top of it. The checksum and the replication
+	// state of the previous content are no longer valid for the whole
+	// object, the replication state is set again by the request.
+	metadata := cloneMSS(fi.Metadata)
+	delete(metadata, objectChecksumKey)
+	for k := range metadata {
+		switch {
+		case k == ReservedMetadataPrefixLower+ReplicationStatus,
+			k == ReservedMetadataPrefixLower+ReplicationTimestamp,
+			k == ReservedMetadataPrefixLower+ReplicaStatus,
+			k == ReservedMetadataPrefixLower+ReplicaTimestamp,
+			k == ReservedMetadataPrefixLower+ReplicaOrigin,
+			strings.HasPrefix(k, ReservedMetadataPrefixLower+ReplicationReset):
+			delete(metadata, k)
+		}
+	}
+	for k, v := range opts.UserDefined {
+		metadata[k] = v
+	}
+
+	// A null version replacing a version with an ID must not share its
+	// data dir, the previous null version is removed along with its data.
+	if fi.InlineData() || (!opts.Versioned && fi.VersionID != "") {
+		return er.rewriteAppendObject(ctx, bucket, object, r, fi, metaArr, onlineDisks, metadata, opts)
+	}
+
+	partID := len(fi.Parts) + 1
+	if partID > globalMaxPartID {
+		return ObjectInfo{}, NotImplemented{Message: fmt.Sprintf("Object has reached the maximum of %d appends", globalMaxPartID)}
+	}
+
+	writeQuorum := fi.Erasure.DataBlocks
+	if fi.Erasure.DataBlocks == fi.Erasure.ParityBlocks {
+		writeQuorum++
+	}
+
+	onlineDisks, metaArr = shuffleDisksAndPartsMetadataByIndex(onlineDisks, metaArr, fi)
+
+	partSuffix := fmt.Sprintf("part.%d", partID)
+	tmpPart := mustGetUUID()
+	tmpPartPath := pathJoin(tmpPart, partSuffix)
+
+	// Delete the temporary part, if the append succeeds there is nothing to delete.
+	var online int
+	defer func() {
+		if online != len(onlineDisks) {
+			er.deleteObject(context.Background(), minioMetaTmpBucket, tmpPart, writeQuorum)
+		}
+	}()
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Fetch buffer for I/O, returns from the pool if not allocates a new one and returns.
+	var buffer []byte
+	switch size := data.Size(); {
+	case size == 0:
+		buffer = make([]byte, 1) // Allocate atleast a byte to reach EOF
+	case size == -1:
+		if size := data.ActualSize(); size > 0 && size < fi.Erasure.BlockSize {
+			buffer = make([]byte, data.ActualSize()+256, data.ActualSize()*2+512)
+		} else {
+			buffer = er.bp.Get()
+			defer er.bp.Put(buffer)
+		}
+	case size >= fi.Erasure.BlockSize:
+		buffer = er.bp.Get()
+		defer er.bp.Put(buffer)
+	case size < fi.Erasure.BlockSize:
+		// No need to allocate fully fi.Erasure.BlockSize buffer if the incoming data is smaller.
+		buffer = make([]byte, size, 2*size+int64(fi.Erasure.ParityBlocks+fi.Erasure.DataBlocks-1))
+	}
+
+	if len(buffer) > int(fi.Erasure.BlockSize) {
+		buffer = buffer[:fi.Erasure.BlockSize]
+	}
+	writers := make([]io.Writer, len(onlineDisks))
+	for i, disk := range onlineDisks {
+		if disk == nil || !metaArr[i].IsValid() {
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+
+	n, err := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
+	closeBitrotWriters(writers)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if n < data.Size() {
+		return ObjectInfo{}, IncompleteBody{Bucket: bucket, Object: object}
+	}
+
+	for i := range writers {
+		if writers[i] == nil {
+			onlineDisks[i] = nil
+		}
+	}
+
+	// Rename the appended part next to the existing parts of the object.
+	partPath := pathJoin(object, fi.DataDir, partSuffix)
+	onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tmpPartPath, bucket, partPath, false, writeQuorum, nil)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, partPath)
+	}
+
+	// The object ETag is computed like the ETag of a multipart
+	// object, a single part object has its ETag as the part ETag.
+	parts := make([]ObjectPartInfo, len(fi.Parts), len(fi.Parts)+1)
+	copy(parts, fi.Parts)
+	if len(parts) == 1 && parts[0].ETag == "" {
+		parts[0].ETag = fi.Metadata["etag"]
+	}
+	parts = append(parts, ObjectPartInfo{
+		Number:     partID,
+		ETag:       r.MD5CurrentHexString(),
+		Size:       n,
+		ActualSize: data.ActualSize(),
+	})
+	completeParts := make([]CompletePart, len(parts))
+	for i, part := range parts {
+		completeParts[i] = CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+	metadata["etag"] = getCompleteMultipartMD5(completeParts)
+
+	versionID := fi.VersionID
+	if opts.Versioned {
+		versionID = opts.VersionID
+		if versionID == "" {
+			versionID = mustGetUUID()
+		}
+	}
+	modTime := opts.MTime
+	if opts.MTime.IsZero() {
+		modTime = UTCNow()
+	}
+
+	for i, disk := range onlineDisks {
+		if disk == OfflineDisk {
+			continue
+		}
+		metaArr[i].VersionID = versionID
+		metaArr[i].ModTime = modTime
+		metaArr[i].Size = fi.Size + n
+		metaArr[i].Metadata = metadata
+		metaArr[i].Parts = parts
+		checksums := make([]ChecksumInfo, len(metaArr[i].Erasure.Checksums), len(metaArr[i].Erasure.Checksums)+1)
+		copy(checksums, metaArr[i].Erasure.Checksums)
+		metaArr[i].Erasure.Checksums = checksums
+		metaArr[i].Erasure.AddChecksumInfo(ChecksumInfo{
+			PartNumber: partID,
+			Algorithm:  DefaultBitrotAlgorithm,
+			Hash:       bitrotWriterSum(writers[i]),
+		})
+	}
+
+	disks, err := writeUniqueFileInfo(ctx, onlineDisks, bucket, object, metaArr, writeQuorum)
+	if err != nil {
+		// Remove the appended part, the previous version does not reference it.
+		for _, disk := range onlineDisks {
+			if disk != nil {
+				disk.Delete(context.Background(), bucket, partPath, false)
+			}
+		}
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	onlineDisks = disks
+
+	fi.VersionID = versionID
+	fi.ModTime = modTime
+	fi.Size += n
+	fi.Metadata = metadata
+	fi.Parts = parts
+
+	// Whether a disk was initially or becomes offline
+	// during this append, send it to the MRF list.
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, fi.VersionID, fi.Size)
+		break
+	}
+
+	fi.ReplicationState = opts.PutReplicationState()
+	online = countOnlineDisks(onlineDisks)
+
+	// we are adding a new version to this object under the namespace lock, so this is the latest version.
+	fi.IsLatest = true
+
+	return fi.ToObjectInfo(bucket, object), nil
+}
+
+// rewriteAppendObject rewrites an object along with the appended data,
+// used when the data of the object is inlined in `xl.meta` and too small
+// to be worth a part of its own, or when the data dir can not be shared.
+// The previous content is read into memory before it is written again,
+// only objects up to the minimum part size are rewritten.
+func (er erasureObjects) rewriteAppendObject(ctx context.Context, bucket, object string, r *PutObjReader, fi FileInfo, metaArr []FileInfo, onlineDisks []StorageAPI, metadata map[string]string, opts ObjectOptions) (ObjectInfo, error) {
+	if fi.Size > globalMinPartSize {
+		return ObjectInfo{}, NotImplemented{Message: fmt.Sprintf("Appending to this object rewrites it, which is only supported up to %d bytes", int64(globalMinPartSize))}
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(fi.Size))
+	if err := er.getObjectWithFileInfo(ctx, bucket, object, 0, fi.Size, &buf, fi, metaArr, onlineDisks); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	size, actualSize := int64(-1), int64(-1)
+	if r.Reader.Size() >= 0 {
+		size = fi.Size + r.Reader.Size()
+	}
+	if r.Reader.ActualSize() >= 0 {
+		actualSize = fi.Size + r.Reader.ActualSize()
+	}
+	hashReader, err := hash.NewReader(io.MultiReader(&buf, r), size, "", "", actualSize)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// The ETag of the whole object is computed while it is written,
+	// the rewritten object is inlined again only if it is still small.
+	delete(metadata, "etag")
+	delete(metadata, ReservedMetadataPrefixLower+"inline-data")
+
+	opts.UserDefined = metadata
+	opts.Append = false
+	opts.CheckPrecondFn = nil
+	opts.NoLock = true
+	return er.putObject(ctx, bucket, object, NewPutObjReader(hashReader), opts)
+}
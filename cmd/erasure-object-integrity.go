@@ -0,0 +1,260 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// PartIntegrity is the state of an object part on a disk.
+type PartIntegrity struct {
+	Number int `json:"number"`
+	// ShardSize is the expected size of the part file on the disk.
+	ShardSize int64  `json:"shardSize"`
+	Algorithm string `json:"algorithm,omitempty"`
+	// Checksum is the hex encoded whole file checksum, empty for
+	// streaming bitrot algorithms which interleave the checksums with
+	// the shard data.
+	Checksum string `json:"checksum,omitempty"`
+	Present  bool   `json:"present"`
+	// Verified is true if the shard passed bitrot verification.
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DiskIntegrity is the state of an object version on a disk.
+type DiskIntegrity struct {
+	Endpoint string `json:"endpoint"`
+	// State is one of the madmin.DriveState values.
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+
+	ModTime      time.Time `json:"modTime,omitempty"`
+	DataDir      string    `json:"dataDir,omitempty"`
+	Inline       bool      `json:"inline,omitempty"`
+	ErasureIndex int       `json:"erasureIndex,omitempty"`
+	Distribution []int     `json:"distribution,omitempty"`
+	// DistributionConsistent is false if the erasure index does not
+	// match the position of the disk in the erasure distribution.
+	DistributionConsistent bool `json:"distributionConsistent"`
+
+	Parts []PartIntegrity `json:"parts,omitempty"`
+}
+
+// ObjectIntegrity is the integrity report of an object version.
+type ObjectIntegrity struct {
+	Bucket       string    `json:"bucket"`
+	Object       string    `json:"object"`
+	VersionID    string    `json:"versionId,omitempty"`
+	Pool         int       `json:"pool"`
+	Set          int       `json:"set"`
+	ModTime      time.Time `json:"modTime"`
+	Size         int64     `json:"size"`
+	DeleteMarker bool      `json:"deleteMarker,omitempty"`
+	Remote       bool      `json:"remote,omitempty"`
+	DataBlocks   int       `json:"dataBlocks"`
+	ParityBlocks int       `json:"parityBlocks"`
+	// DistributionReliable is false if too many disks disagree on the
+	// erasure distribution for it to be trusted while healing.
+	DistributionReliable bool `json:"distributionReliable"`
+	// HealthyDisks is the number of disks holding the latest metadata
+	// and all the parts.
+	HealthyDisks int             `json:"healthyDisks"`
+	Disks        []DiskIntegrity `json:"disks"`
+}
+
+// objectIntegrity returns the per disk state of an object version.
+func (z *erasureServerPools) objectIntegrity(ctx context.Context, bucket, object, versionID string) (ObjectIntegrity, error) {
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return ObjectIntegrity{}, err
+	}
+
+	idx, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		return ObjectIntegrity{}, err
+	}
+	sets := z.getServerPools()[idx]
+	setIdx := sets.getHashedSetIndex(object)
+	report, err := sets.sets[setIdx].objectIntegrity(ctx, bucket, object, versionID)
+	report.Pool = idx
+	report.Set = setIdx
+	return report, err
+}
+
+func (er erasureObjects) objectIntegrity(ctx context.Context, bucket, object, versionID string) (report ObjectIntegrity, err error) {
+	storageDisks := er.getDisks()
+	storageEndpoints := er.getEndpoints()
+
+	report = ObjectIntegrity{
+		Bucket:       bucket,
+		Object:       object,
+		VersionID:    versionID,
+		ParityBlocks: er.defaultParityCount,
+		DataBlocks:   len(storageDisks) - er.defaultParityCount,
+	}
+
+	lk := er.NewNSLock(bucket, object)
+	lkctx, err := lk.GetRLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return report, err
+	}
+	ctx = lkctx.Context()
+	defer lk.RUnlock(lkctx.Cancel)
+
+	partsMetadata, errs := readAllFileInfo(ctx, storageDisks, bucket, object, versionID, true)
+	if _, err = getLatestFileInfo(ctx, partsMetadata, errs); err != nil {
+		return report, toObjectErr(err, bucket, object, versionID)
+	}
+
+	onlineDisks, modTime := listOnlineDisks(storageDisks, partsMetadata, errs)
+	latestMeta, err := pickValidFileInfo(ctx, partsMetadata, modTime, report.DataBlocks)
+	if err != nil {
+		return report, toObjectErr(err, bucket, object, versionID)
+	}
+	report.ModTime = latestMeta.ModTime
+	report.Size = latestMeta.Size
+	report.DeleteMarker = latestMeta.Deleted
+	report.Remote = latestMeta.IsRemote()
+	if latestMeta.Erasure.DataBlocks > 0 {
+		report.DataBlocks = latestMeta.Erasure.DataBlocks
+		report.ParityBlocks = latestMeta.Erasure.ParityBlocks
+	}
+	report.DistributionReliable = isErasureDistributionReliable(partsMetadata, len(storageDisks))
+
+	// disksWithAllParts invalidates the metadata of the disks failing
+	// the checks, keep the original for the report.
+	metas := make([]FileInfo, len(partsMetadata))
+	copy(metas, partsMetadata)
+	availableDisks, dataErrs, _ := disksWithAllParts(ctx, onlineDisks, metas,
+		errs, latestMeta, bucket, object, madmin.HealNormalScan)
+
+	report.Disks = make([]DiskIntegrity, len(storageDisks))
+	for i, meta := range partsMetadata {
+		d := DiskIntegrity{
+			Endpoint: storageEndpoints[i].String(),
+		}
+		switch {
+		case availableDisks[i] != nil:
+			d.State = madmin.DriveStateOk
+			report.HealthyDisks++
+		case errs[i] == errDiskNotFound, dataErrs[i] == errDiskNotFound:
+			d.State = madmin.DriveStateOffline
+		case errs[i] == errFileNotFound, errs[i] == errFileVersionNotFound, errs[i] == errVolumeNotFound:
+			fallthrough
+		case dataErrs[i] == errFileNotFound, dataErrs[i] == errFileVersionNotFound, dataErrs[i] == errVolumeNotFound:
+			d.State = madmin.DriveStateMissing
+		default:
+			d.State = madmin.DriveStateCorrupt
+		}
+		if errs[i] != nil {
+			d.Error = errs[i].Error()
+		} else if dataErrs[i] != nil {
+			d.Error = dataErrs[i].Error()
+		}
+
+		if errs[i] != nil || !meta.IsValid() {
+			report.Disks[i] = d
+			continue
+		}
+
+		d.ModTime = meta.ModTime
+		d.DataDir = meta.DataDir
+		d.Inline = meta.InlineData()
+		d.ErasureIndex = meta.Erasure.Index
+		d.Distribution = meta.Erasure.Distribution
+		d.DistributionConsistent = meta.Deleted ||
+			(len(meta.Erasure.Distribution) == len(storageDisks) && meta.Erasure.Distribution[i] == meta.Erasure.Index)
+
+		if !meta.Deleted {
+			d.Parts = partsIntegrity(ctx, storageDisks[i], bucket, object, meta, latestMeta)
+		}
+		if d.State == madmin.DriveStateOk && !latestMeta.IsRemote() {
+			// disksWithAllParts only checked the presence of
+			// the parts, account for the bitrot verification.
+			for _, p := range d.Parts {
+				if !p.Verified {
+					d.State = madmin.DriveStateCorrupt
+					d.Error = p.Error
+					report.HealthyDisks--
+					break
+				}
+			}
+		}
+		report.Disks[i] = d
+	}
+	return report, nil
+}
+
+// partsIntegrity returns the state of the parts of latestMeta on disk,
+// meta being the metadata read from disk.
+func partsIntegrity(ctx context.Context, disk StorageAPI, bucket, object string, meta, latestMeta FileInfo) []PartIntegrity {
+	parts := make([]PartIntegrity, 0, len(latestMeta.Parts))
+	outdated := !meta.ModTime.Equal(latestMeta.ModTime) || meta.DataDir != latestMeta.DataDir
+	for j, part := range latestMeta.Parts {
+		checksumInfo := meta.Erasure.GetChecksumInfo(part.Number)
+		p := PartIntegrity{
+			Number:    part.Number,
+			ShardSize: meta.Erasure.ShardFileSize(part.Size),
+			Algorithm: checksumInfo.Algorithm.String(),
+			Checksum:  hex.EncodeToString(checksumInfo.Hash),
+		}
+
+		var err error
+		switch {
+		case disk == nil || disk == OfflineDisk:
+			err = errDiskNotFound
+		case outdated:
+			// The parts on this disk belong to another version of
+			// the object, they can't be verified against latestMeta.
+			err = errFileCorrupt
+		case latestMeta.IsRemote():
+			// Transitioned objects hold no data locally.
+			parts = append(parts, p)
+			continue
+		case len(meta.Data) > 0 || meta.Size == 0:
+			if j > 0 {
+				err = errFileNotFound
+				break
+			}
+			err = bitrotVerify(bytes.NewReader(meta.Data),
+				int64(len(meta.Data)),
+				meta.Erasure.ShardFileSize(meta.Size),
+				checksumInfo.Algorithm,
+				checksumInfo.Hash, meta.Erasure.ShardSize())
+		default:
+			pfi := meta
+			pfi.Parts = []ObjectPartInfo{part}
+			var resp *CheckPartsResp
+			if resp, err = disk.VerifyFile(ctx, bucket, object, pfi); err == nil && len(resp.Results) > 0 {
+				err = resp.partErrs()[0]
+			}
+		}
+		p.Present = err == nil || err == errFileCorrupt
+		p.Verified = err == nil
+		if err != nil {
+			p.Error = err.Error()
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
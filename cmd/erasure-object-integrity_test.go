@@ -0,0 +1,128 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestObjectIntegrity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := getRandomBucketName()
+	object := getRandomObjectName()
+	data := bytes.Repeat([]byte("a"), 5*1024*1024)
+	var opts ObjectOptions
+
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	uploadID, err := objLayer.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		t.Fatalf("Failed to create a multipart upload - %v", err)
+	}
+	var uploadedParts []CompletePart
+	for _, partID := range []int{1, 2} {
+		pInfo, err1 := objLayer.PutObjectPart(ctx, bucket, object, uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err1 != nil {
+			t.Fatalf("Failed to upload a part - %v", err1)
+		}
+		uploadedParts = append(uploadedParts, CompletePart{
+			PartNumber: pInfo.PartNumber,
+			ETag:       pInfo.ETag,
+		})
+	}
+	if _, err = objLayer.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, ObjectOptions{}); err != nil {
+		t.Fatalf("Failed to complete multipart upload - %v", err)
+	}
+
+	z := objLayer.(*erasureServerPools)
+	er := z.getServerPools()[0].sets[0]
+	erasureDisks := er.getDisks()
+	fileInfos, errs := readAllFileInfo(ctx, erasureDisks, bucket, object, "", false)
+	fi, err := getLatestFileInfo(ctx, fileInfos, errs)
+	if err != nil {
+		t.Fatalf("Failed to getLatestFileInfo - %v", err)
+	}
+
+	// Disk 0: part.1 is missing.
+	if err = erasureDisks[0].Delete(ctx, bucket, pathJoin(object, fi.DataDir, "part.1"), false); err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+	// Disk 1: part.2 is corrupted.
+	part2 := pathJoin(object, fi.DataDir, "part.2")
+	buf, err := erasureDisks[1].ReadAll(ctx, bucket, part2)
+	if err != nil {
+		t.Fatalf("Failed to read a file - %v", err)
+	}
+	buf[len(buf)-1]++
+	if err = erasureDisks[1].WriteAll(ctx, bucket, part2, buf); err != nil {
+		t.Fatalf("Failed to write a file - %v", err)
+	}
+	// Disk 2: xl.meta is missing.
+	if err = erasureDisks[2].Delete(ctx, bucket, pathJoin(object, xlStorageFormatFile), false); err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+
+	report, err := z.objectIntegrity(ctx, bucket, object, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Size != 2*int64(len(data)) || !report.DistributionReliable || len(report.Disks) != nDisks {
+		t.Fatalf("unexpected report %#v", report)
+	}
+	if report.HealthyDisks != nDisks-3 {
+		t.Fatalf("expected %d healthy disks, got %d", nDisks-3, report.HealthyDisks)
+	}
+
+	d := report.Disks[0]
+	if d.State != madmin.DriveStateMissing || len(d.Parts) != 2 || d.Parts[0].Present || !d.Parts[1].Verified {
+		t.Fatalf("unexpected disk 0 state %#v", d)
+	}
+	d = report.Disks[1]
+	if d.State != madmin.DriveStateCorrupt || len(d.Parts) != 2 || !d.Parts[0].Verified || !d.Parts[1].Present || d.Parts[1].Verified {
+		t.Fatalf("unexpected disk 1 state %#v", d)
+	}
+	d = report.Disks[2]
+	if d.State != madmin.DriveStateMissing || len(d.Parts) != 0 {
+		t.Fatalf("unexpected disk 2 state %#v", d)
+	}
+	for i, d := range report.Disks[3:] {
+		if d.State != madmin.DriveStateOk || !d.DistributionConsistent || len(d.Parts) != 2 || !d.Parts[0].Verified || !d.Parts[1].Verified {
+			t.Fatalf("unexpected disk %d state %#v", i+3, d)
+		}
+	}
+}
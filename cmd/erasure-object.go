@@ -57,6 +57,36 @@ func countOnlineDisks(onlineDisks []StorageAPI) (online int) {
 	return online
 }
 
+// copyObjectInPlace returns true if copying an object onto itself only
+// changes its metadata, which is then updated in `xl.meta` without
+// reading and rewriting the data of the object. In a versioned bucket the
+// copy adds a version referencing the data of the source version.
+func copyObjectInPlace(srcInfo *ObjectInfo, srcOpts, dstOpts ObjectOptions) bool {
+	if !srcInfo.metadataOnly {
+		return false
+	}
+	// Version ID is set for the destination and source == destination version ID.
+	// perform an in-place update.
+	if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
+		return true
+	}
+	// Destination is not versioned and source version ID is empty
+	// perform an in-place update.
+	if !dstOpts.Versioned && srcOpts.VersionID == "" {
+		return true
+	}
+	// Destination is versioned, the copy is a new version, either of
+	// another version or of the latest version. We don't create an entire
+	// copy of the content, instead we add a reference, we disallow legacy
+	// objects to be self referenced in this manner so make sure that we
+	// actually create a new dataDir for legacy objects.
+	if dstOpts.Versioned && (srcOpts.VersionID != dstOpts.VersionID || srcOpts.VersionID == "") && !srcInfo.Legacy {
+		srcInfo.versionOnly = true
+		return true
+	}
+	return false
+}
+
 // CopyObject - copy object source object to destination object.
 // if source object and destination object are same we only
 // update metadata.
@@ -141,6 +171,147 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 	return fi.ToObjectInfo(srcBucket, srcObject), nil
 }
 
+// copyObjectData copies the source object to another object of the same
+// erasure set without decoding its data, every drive copies its own shards
+// of the source version to the destination. It serves copies storing the
+// data of the source as is, the new version gets the metadata of srcInfo.
+// errCopyDataUnsupported is returned when the data has to be rewritten.
+func (er erasureObjects) copyObjectData(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, err error) {
+	if !dstOpts.NoLock {
+		lk := er.NewNSLock(dstBucket, dstObject)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return oi, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+
+	// Read metadata associated with the object from all disks.
+	storageDisks := er.getDisks()
+	metaArr, errs := readAllFileInfo(ctx, storageDisks, srcBucket, srcObject, srcOpts.VersionID, true)
+
+	// get Quorum for this object
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, metaArr, errs, er.defaultParityCount)
+	if err != nil {
+		return oi, toObjectErr(err, srcBucket, srcObject)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(storageDisks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	fi, err := pickValidFileInfo(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return oi, toObjectErr(err, srcBucket, srcObject)
+	}
+	if fi.Deleted {
+		if srcOpts.VersionID == "" {
+			return oi, toObjectErr(errFileNotFound, srcBucket, srcObject)
+		}
+		return fi.ToObjectInfo(srcBucket, srcObject), toObjectErr(errMethodNotAllowed, srcBucket, srcObject)
+	}
+
+	// Transitioned and legacy versions have no shards to copy, the
+	// destination must also get the same parity and inline layout as
+	// a regular upload of the data would.
+	if fi.IsRemote() || fi.XLV1 {
+		return oi, errCopyDataUnsupported
+	}
+	parityDrives := getParityForSC(dstBucket, srcInfo.UserDefined[xhttp.AmzStorageClass])
+	if parityDrives <= 0 {
+		parityDrives = er.defaultParityCount
+	}
+	if fi.Erasure.ParityBlocks != parityDrives {
+		return oi, errCopyDataUnsupported
+	}
+	if fi.InlineData() && !shouldInlineShard(fi.Erasure.ShardFileSize(fi.Size), dstOpts.Versioned, globalAPIConfig.getInlineThreshold()) {
+		return oi, errCopyDataUnsupported
+	}
+
+	filterOnlineDisksInplace(fi, metaArr, onlineDisks)
+
+	versionID := dstOpts.VersionID
+	if dstOpts.Versioned && versionID == "" {
+		versionID = mustGetUUID()
+	}
+	modTime = dstOpts.MTime
+	if modTime.IsZero() {
+		modTime = UTCNow()
+	}
+	metadata := cloneMSS(srcInfo.UserDefined)
+	metadata["etag"] = srcInfo.ETag
+	if fi.InlineData() {
+		metadata[ReservedMetadataPrefixLower+"inline-data"] = "true"
+	}
+
+	tmpID := mustGetUUID()
+	dataDir := mustGetUUID()
+	defer er.deleteAll(context.Background(), minioMetaTmpBucket, tmpID)
+
+	if !fi.InlineData() {
+		// Every drive copies the shards of all parts as is, inline
+		// data is copied along with the metadata.
+		g := errgroup.WithNErrs(len(onlineDisks))
+		for index := range onlineDisks {
+			index := index
+			g.Go(func() error {
+				disk := onlineDisks[index]
+				if disk == nil {
+					return errDiskNotFound
+				}
+				erasure := metaArr[index].Erasure
+				for _, part := range metaArr[index].Parts {
+					partName := fmt.Sprintf("part.%d", part.Number)
+					size := bitrotShardFileSize(erasure.ShardFileSize(part.Size), erasure.ShardSize(), erasure.GetChecksumInfo(part.Number).Algorithm)
+					rc, err := disk.ReadFileStream(ctx, srcBucket, pathJoin(srcObject, fi.DataDir, partName), 0, size)
+					if err != nil {
+						return err
+					}
+					err = disk.CreateFile(ctx, minioMetaTmpBucket, pathJoin(tmpID, dataDir, partName), size, rc)
+					rc.Close()
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}, index)
+		}
+		errs = g.Wait()
+		if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+			return oi, toObjectErr(err, dstBucket, dstObject)
+		}
+		onlineDisks = evalDisks(onlineDisks, errs)
+	}
+
+	for index := range metaArr {
+		metaArr[index].DataDir = dataDir
+		metaArr[index].VersionID = versionID
+		metaArr[index].ModTime = modTime
+		metaArr[index].Metadata = metadata
+	}
+
+	// Rename the copied shards to their final location.
+	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaTmpBucket, tmpID, metaArr, dstBucket, dstObject, writeQuorum); err != nil {
+		return oi, toObjectErr(err, dstBucket, dstObject)
+	}
+
+	for _, disk := range onlineDisks {
+		if disk == nil || !disk.IsOnline() {
+			// Let the drives which missed the copy catch up.
+			er.addPartial(dstBucket, dstObject, versionID, fi.Size)
+			break
+		}
+	}
+
+	fi.DataDir = dataDir
+	fi.VersionID = versionID
+	fi.ModTime = modTime
+	fi.Metadata = metadata
+	fi.IsLatest = true
+	return fi.ToObjectInfo(dstBucket, dstObject), nil
+}
+
 // GetObjectNInfo - returns object info and an object
 // Read(Closer). When err != nil, the returned reader is always nil.
 func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
@@ -206,6 +377,24 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 	}
 	unlockOnDefer = false
 
+	decode := func(w io.Writer, off, length int64) error {
+		return er.getObjectWithFileInfo(ctx, bucket, object, off, length, w, fi, metaArr, onlineDisks)
+	}
+	if zr := newZeroCopyReader(ctx, bucket, object, fi, objInfo, metaArr, onlineDisks, off, length, decode); zr != nil {
+		return fn(zr, h, func() { zr.Close() }, nsUnlocker)
+	}
+
+	if shouldCoalesceRead(fi, off, length) {
+		// Share a single decode with concurrent reads of the same version,
+		// it outlives the request which started it and is only canceled
+		// once all readers are closed.
+		rd := globalReadCoalescer.Read(objectDataKey(bucket, object, fi), fi.Size, func(w io.Writer) error {
+			ctx := logger.SetReqInfo(GlobalContext, logger.GetReqInfo(ctx))
+			return er.getObjectWithFileInfo(ctx, bucket, object, 0, fi.Size, w, fi, metaArr, onlineDisks)
+		}, nsUnlocker)
+		return fn(rd, h, func() { rd.Close() })
+	}
+
 	pr, pw := xioutil.WaitPipe()
 	go func() {
 		pw.CloseWithError(er.getObjectWithFileInfo(ctx, bucket, object, off, length, pw, fi, metaArr, onlineDisks))
@@ -267,6 +456,12 @@ func (er erasureObjects) getObjectWithFileInfo(ctx context.Context, bucket, obje
 	// dataDir that has stale FileInfo{} to ensure that we fail appropriately
 	// during reads and expect the same dataDir everywhere.
 	dataDir := fi.DataDir
+
+	// Inline data is already in memory.
+	var cacheKey string
+	if globalBlockCache.enabled() && len(fi.Data) == 0 {
+		cacheKey = objectDataKey(bucket, object, fi)
+	}
 	for ; partIndex <= lastPartIndex; partIndex++ {
 		if length == totalBytesRead {
 			break
@@ -302,8 +497,14 @@ func (er erasureObjects) getObjectWithFileInfo(ctx context.Context, bucket, obje
 			// Prefer local disks
 			prefer[index] = disk.Hostname() == ""
 		}
+		avoidSuspectDrives(onlineDisks, readers, prefer, erasure.dataBlocks)
 
-		written, err := erasure.Decode(ctx, writer, readers, partOffset, partLength, partSize, prefer)
+		var written int64
+		if cacheKey != "" {
+			written, err = erasure.DecodeCached(ctx, writer, readers, partOffset, partLength, partSize, prefer, cacheKey, partNumber)
+		} else {
+			written, err = erasure.Decode(ctx, writer, readers, partOffset, partLength, partSize, prefer)
+		}
 		// Note: we should not be defer'ing the following closeBitrotReaders() call as
 		// we are inside a for loop i.e if we use defer, we would accumulate a lot of open files by the time
 		// we return from this function.
@@ -446,6 +647,41 @@ func (er erasureObjects) getObjectFileInfo(ctx context.Context, bucket, object s
 	return fi, metaArr, onlineDisks, nil
 }
 
+// getObjectFileInfos reads a version of many objects, the one in
+// versionIDs when not nil, the latest otherwise, reading their metadata
+// in batches with a single call per disk, delete markers are returned
+// without error.
+func (er erasureObjects) getObjectFileInfos(ctx context.Context, bucket string, objects, versionIDs []string) ([]FileInfo, []error) {
+	fis := make([]FileInfo, len(objects))
+	errs := make([]error, len(objects))
+	disks := er.getDisks()
+	for start := 0; start < len(objects); start += readVersionsBatchSize {
+		end := start + readVersionsBatchSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+		var batchVersionIDs []string
+		if versionIDs != nil {
+			batchVersionIDs = versionIDs[start:end]
+		}
+		metaArrs, diskErrs := readAllFileInfos(ctx, disks, bucket, objects[start:end], batchVersionIDs, false)
+		for i, metaArr := range metaArrs {
+			readQuorum, _, err := objectQuorumFromMeta(ctx, metaArr, diskErrs[i], er.defaultParityCount)
+			if err == nil {
+				err = reduceReadQuorumErrs(ctx, diskErrs[i], objectOpIgnoredErrs, readQuorum)
+			}
+			if err == nil {
+				_, modTime := listOnlineDisks(disks, metaArr, diskErrs[i])
+				fis[start+i], err = pickValidFileInfo(ctx, metaArr, modTime, readQuorum)
+			}
+			if err != nil {
+				errs[start+i] = toObjectErr(err, bucket, objects[start+i])
+			}
+		}
+	}
+	return fis, errs
+}
+
 // getObjectInfo - wrapper for reading object metadata and constructs ObjectInfo.
 func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, opts, false)
@@ -453,7 +689,14 @@ func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object strin
 		return objInfo, toObjectErr(err, bucket, object)
 
 	}
-	objInfo = fi.ToObjectInfo(bucket, object)
+	return fileInfoToObjectInfo(fi, bucket, object, opts)
+}
+
+// fileInfoToObjectInfo - constructs the ObjectInfo of the version read
+// for opts, delete markers and versions pending purge are returned with
+// an error.
+func fileInfoToObjectInfo(fi FileInfo, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	objInfo := fi.ToObjectInfo(bucket, object)
 	if opts.VersionID != "" && !fi.VersionPurgeStatus().Empty() {
 		// Make sure to return object info to provide extra information.
 		return objInfo, toObjectErr(errMethodNotAllowed, bucket, object)
@@ -745,13 +988,42 @@ func (er erasureObjects) putMetacacheObject(ctx context.Context, key string, r *
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (er erasureObjects) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Append {
+		return er.appendObject(ctx, bucket, object, data, opts)
+	}
 	return er.putObject(ctx, bucket, object, data, opts)
 }
 
+// checkPutPrecondition evaluates the preconditions of a conditional
+// PutObject, CopyObject or CompleteMultipartUpload against the latest version of the object, a missing object
+// or a delete marker is passed as an empty ObjectInfo.
+func (er erasureObjects) checkPutPrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // putObject wrapper for erasureObjects PutObject
 func (er erasureObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	data := r.Reader
 
+	// Fail early before writing the data, the preconditions
+	// are evaluated again under the namespace lock.
+	if err = er.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	// No metadata is set, allocate a new one.
 	if opts.UserDefined == nil {
 		opts.UserDefined = make(map[string]string)
@@ -762,7 +1034,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	parityDrives := len(storageDisks) / 2
 	if !opts.MaxParity {
 		// Get parity and data drive count based on storage class metadata
-		parityDrives = globalStorageClass.GetParityForSC(opts.UserDefined[xhttp.AmzStorageClass])
+		parityDrives = getParityForSC(bucket, opts.UserDefined[xhttp.AmzStorageClass])
 		if parityDrives <= 0 {
 			parityDrives = er.defaultParityCount
 		}
@@ -885,18 +1157,15 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	shardFileSize := erasure.ShardFileSize(data.Size())
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
+	inlineThreshold := globalAPIConfig.getInlineThreshold()
 	if shardFileSize >= 0 {
-		if !opts.Versioned && shardFileSize < smallFileThreshold {
-			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-		} else if shardFileSize < smallFileThreshold/8 {
+		if shouldInlineShard(shardFileSize, opts.Versioned, inlineThreshold) {
 			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	} else {
 		// If compressed, use actual size to determine.
 		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 {
-			if !opts.Versioned && sz < smallFileThreshold {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			} else if sz < smallFileThreshold/8 {
+			if shouldInlineShard(sz, opts.Versioned, inlineThreshold) {
 				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 			}
 		}
@@ -940,6 +1209,10 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	if err = er.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -960,6 +1233,12 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	if opts.UserDefined["etag"] == "" {
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
+	setClientETag(opts.UserDefined, r)
+
+	// A trailing checksum is only known, and verified, once all data was read.
+	if opts.WantChecksum != nil && opts.WantChecksum.Trailing {
+		opts.UserDefined[objectChecksumKey] = opts.WantChecksum.String()
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -1103,10 +1382,16 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 			Idx: i,
 		}
 		vr.SetTierFreeVersionID(mustGetUUID())
+		versioned, suspended := opts.Versioned, opts.VersionSuspended
+		if versioned && opts.PrefixEnabledFn != nil && !opts.PrefixEnabledFn(objects[i].ObjectName) {
+			// Objects under the excluded prefixes of a versioned
+			// bucket behave as in a versioning suspended bucket.
+			versioned, suspended = false, true
+		}
 		// VersionID is not set means delete is not specific about
 		// any version, look for if the bucket is versioned or not.
 		if objects[i].VersionID == "" {
-			if opts.Versioned || opts.VersionSuspended {
+			if versioned || suspended {
 				// Bucket is versioned and no version was explicitly
 				// mentioned for deletes, create a delete marker instead.
 				vr.ModTime = UTCNow()
@@ -1114,7 +1399,7 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 				// Versioning suspended means that we add a `null` version
 				// delete marker, if not add a new version for this delete
 				// marker.
-				if opts.Versioned {
+				if versioned {
 					vr.VersionID = mustGetUUID()
 				}
 			}
@@ -1712,9 +1997,14 @@ func (er erasureObjects) updateRestoreMetadata(ctx context.Context, bucket, obje
 	oi := objInfo.Clone()
 	oi.metadataOnly = true // Perform only metadata updates.
 
-	if rerr == nil {
+	delete(oi.UserDefined, ReservedMetadataPrefixLower+RestoreRehydrating)
+	switch {
+	case rerr == nil:
 		oi.UserDefined[xhttp.AmzRestore] = completedRestoreObj(opts.Transition.RestoreExpiry).String()
-	} else { // allow retry in the case of failure to restore
+	case errors.Is(rerr, errRestoreRehydrating):
+		// restore resumes once the remote object is rehydrated
+		oi.UserDefined[ReservedMetadataPrefixLower+RestoreRehydrating] = "true"
+	default: // allow retry in the case of failure to restore
 		delete(oi.UserDefined, xhttp.AmzRestore)
 	}
 	if _, err := er.CopyObject(ctx, bucket, object, bucket, object, oi, ObjectOptions{
@@ -1743,7 +2033,21 @@ func (er erasureObjects) restoreTransitionedObject(ctx context.Context, bucket s
 	}
 
 	oi = actualfi.ToObjectInfo(bucket, object)
+
+	// objects in an offline archive tier must be rehydrated before they can be read
+	rehydrated, err := rehydrateTransitionedObject(ctx, oi)
+	if err != nil {
+		return setRestoreHeaderFn(oi, err)
+	}
+	if !rehydrated {
+		if isRestoreRehydrating(oi) {
+			return errRestoreRehydrating
+		}
+		return setRestoreHeaderFn(oi, errRestoreRehydrating)
+	}
+
 	ropts := putRestoreOpts(bucket, object, opts.Transition.RestoreRequest, oi)
+	delete(ropts.UserDefined, ReservedMetadataPrefixLower+RestoreRehydrating)
 	if len(oi.Parts) == 1 {
 		var rs *HTTPRangeSpec
 		gr, err := getTransitionedObjectReader(ctx, bucket, object, rs, http.Header{}, oi, opts)
@@ -26,9 +26,13 @@ import (
 	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/storageclass"
 )
 
@@ -72,6 +76,150 @@ func TestRepeatPutObjectPart(t *testing.T) {
 	}
 }
 
+func TestPutObjectPartValidatePartSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.multipartMinPartSize = 0
+		globalAPIConfig.multipartValidateParts = false
+		globalAPIConfig.mu.Unlock()
+	}()
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.multipartMinPartSize = 1024
+	globalAPIConfig.multipartValidateParts = true
+	globalAPIConfig.mu.Unlock()
+
+	objLayer, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objLayer.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	if err = objLayer.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := objLayer.NewMultipartUpload(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	putPart := func(partID int, size int) error {
+		data := bytes.Repeat([]byte("a"), size)
+		_, err := objLayer.PutObjectPart(ctx, "bucket", "object", uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", ""), ObjectOptions{})
+		return err
+	}
+
+	// Parts of the configured minimum size, below the default of 5MiB.
+	if err = putPart(1, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if err = putPart(3, 100); err != nil {
+		t.Fatal(err)
+	}
+	// A small part followed by part 3 can not be the last part.
+	if err = putPart(2, 100); !errors.As(err, &PartTooSmall{}) {
+		t.Fatalf("Expected part 2 to be too small, got %v", err)
+	}
+	// Part 3 is small and can only be the last part.
+	if err = putPart(4, 1024); !errors.As(err, &PartTooSmall{}) {
+		t.Fatalf("Expected part 3 to be too small, got %v", err)
+	}
+	if err = putPart(2, 2048); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := []CompletePart{{PartNumber: 1}, {PartNumber: 2}, {PartNumber: 3}}
+	lpi, err := objLayer.ListObjectParts(ctx, "bucket", "object", uploadID, 0, 10, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, part := range lpi.Parts {
+		parts[i].ETag = part.ETag
+	}
+	oi, err := objLayer.CompleteMultipartUpload(ctx, "bucket", "object", uploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != 1024+2048+100 {
+		t.Fatalf("Expected size %d, got %d", 1024+2048+100, oi.Size)
+	}
+}
+
+func TestValidateCompleteParts(t *testing.T) {
+	const numParts = 2*completeMultipartBatchSize + 10
+	uploaded := make([]ObjectPartInfo, numParts)
+	complete := make([]CompletePart, numParts)
+	for i := range uploaded {
+		uploaded[i] = ObjectPartInfo{Number: i + 1, ETag: strconv.Itoa(i), Size: globalMinPartSize + 1, ActualSize: globalMinPartSize}
+		complete[i] = CompletePart{PartNumber: i + 1, ETag: `"` + strconv.Itoa(i) + `"`}
+	}
+	// The last part may be small.
+	uploaded[numParts-1].ActualSize = 1
+
+	parts, err := validateCompleteParts(context.Background(), uploaded, complete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, part := range parts {
+		if part.Number != i+1 || part.Size != uploaded[i].Size || part.ActualSize != uploaded[i].ActualSize {
+			t.Fatalf("Unexpected part %d: %+v", i, part)
+		}
+	}
+
+	// The error of the first invalid part is returned.
+	invalid := append([]CompletePart{}, complete...)
+	invalid[completeMultipartBatchSize+3].ETag = "bad"
+	invalid[2*completeMultipartBatchSize+1].PartNumber = numParts + 1
+	_, err = validateCompleteParts(context.Background(), uploaded, invalid)
+	var invp InvalidPart
+	if !errors.As(err, &invp) || invp.PartNumber != completeMultipartBatchSize+4 {
+		t.Fatalf("Expected part %d to be invalid, got %v", completeMultipartBatchSize+4, err)
+	}
+
+	// Only the last part may be small.
+	_, err = validateCompleteParts(context.Background(), uploaded, complete[numParts-2:numParts-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploaded[1].ActualSize = 1
+	if _, err = validateCompleteParts(context.Background(), uploaded, complete); !errors.As(err, &PartTooSmall{}) {
+		t.Fatalf("Expected part 2 to be too small, got %v", err)
+	}
+}
+
+func TestVerifyCompletePartsOnDisks(t *testing.T) {
+	parts := []ObjectPartInfo{{Number: 1, Size: 10}, {Number: 2, Size: 5}}
+	newMeta := func(parts []ObjectPartInfo, checksums ...int) FileInfo {
+		fi := newFileInfo("bucket/object", 2, 2)
+		fi.ModTime = UTCNow()
+		fi.Parts = parts
+		for _, n := range checksums {
+			fi.Erasure.AddChecksumInfo(ChecksumInfo{PartNumber: n, Algorithm: DefaultBitrotAlgorithm})
+		}
+		return fi
+	}
+	disks := []StorageAPI{&xlStorage{}, &xlStorage{}, &xlStorage{}, &xlStorage{}}
+	metas := []FileInfo{
+		newMeta(parts, 1, 2),
+		// Missed the upload of part 1.
+		newMeta(parts, 2),
+		// Missed the upload of part 2.
+		newMeta(parts[:1], 1),
+		newMeta(parts, 1, 2),
+	}
+	for i := range metas {
+		metas[i].Erasure.Index = i + 1
+	}
+	verifyCompletePartsOnDisks(context.Background(), disks, metas, parts)
+	for i, want := range []bool{true, false, false, true} {
+		if (disks[i] != nil) != want {
+			t.Fatalf("Disk %d: expected online %v", i, want)
+		}
+	}
+}
+
 func TestErasureDeleteObjectBasic(t *testing.T) {
 	testCases := []struct {
 		bucket      string
@@ -142,7 +290,7 @@ func TestErasureDeleteObjectsErasureSet(t *testing.T) {
 			defer os.RemoveAll(dir)
 		}
 		z := obj.(*erasureServerPools)
-		xl := z.serverPools[0].sets[0]
+		xl := z.getServerPools()[0].sets[0]
 		objs = append(objs, xl)
 	}
 
@@ -201,6 +349,470 @@ func TestErasureDeleteObjectsErasureSet(t *testing.T) {
 	}
 }
 
+func TestErasureDeleteObjectsExcludedPrefix(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	prefixEnabled := func(prefix string) bool {
+		return !strings.HasPrefix(prefix, "tmp/")
+	}
+	objects := []ObjectToDelete{{ObjectName: "data/obj"}, {ObjectName: "tmp/obj"}}
+	for _, object := range objects {
+		_, err = obj.PutObject(ctx, bucket, object.ObjectName, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{
+			Versioned:        prefixEnabled(object.ObjectName),
+			VersionSuspended: !prefixEnabled(object.ObjectName),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dobjs, errs := obj.DeleteObjects(ctx, bucket, objects, ObjectOptions{
+		Versioned:       true,
+		PrefixEnabledFn: prefixEnabled,
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			t.Fatalf("Failed to remove object `%v` with the error: `%v`", objects[i].ObjectName, errs[i])
+		}
+	}
+	for _, dobj := range dobjs {
+		if !dobj.DeleteMarker {
+			t.Fatalf("Expected a delete marker for %s", dobj.ObjectName)
+		}
+		// Excluded objects get a `null` delete marker, which replaces
+		// the `null` version instead of adding a version.
+		if excluded := !prefixEnabled(dobj.ObjectName); excluded != (dobj.DeleteMarkerVersionID == "") {
+			t.Fatalf("Unexpected delete marker version %q for %s", dobj.DeleteMarkerVersionID, dobj.ObjectName)
+		}
+	}
+
+	result, err := obj.ListObjectVersions(ctx, bucket, "tmp/", "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || !result.Objects[0].DeleteMarker {
+		t.Fatalf("Expected only a delete marker under an excluded prefix, got %d versions", len(result.Objects))
+	}
+}
+
+func TestErasureCopyObjectVersionedInPlace(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A metadata only copy of the latest version onto itself adds a
+	// version without reading the data, there is no reader to read from.
+	srcInfo := oi
+	srcInfo.metadataOnly = true
+	srcInfo.UserDefined = cloneMSS(oi.UserDefined)
+	srcInfo.UserDefined["x-amz-meta-key"] = "value"
+	copied, err := obj.CopyObject(ctx, bucket, object, bucket, object, srcInfo, ObjectOptions{}, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied.VersionID == "" || copied.VersionID == oi.VersionID {
+		t.Fatalf("Expected a new version, got %q", copied.VersionID)
+	}
+
+	for _, versionID := range []string{oi.VersionID, copied.VersionID} {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Version %s: unexpected content", versionID)
+		}
+		if _, ok := gr.ObjInfo.UserDefined["x-amz-meta-key"]; ok != (versionID == copied.VersionID) {
+			t.Fatalf("Version %s: unexpected metadata %v", versionID, gr.ObjInfo.UserDefined)
+		}
+	}
+}
+
+func TestErasurePutObjectPrecondition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only create the object if it does not exist.
+	ifNoneMatch := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool {
+		return oi.Name != ""
+	}}
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ifNoneMatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), 4, "", ""), ifNoneMatch)
+	if !isErrPreconditionFailed(err) {
+		t.Fatalf("Expected precondition failed, got %v", err)
+	}
+
+	// Only overwrite the version which was read.
+	ifMatch := func(etag string) ObjectOptions {
+		return ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool {
+			return oi.ETag != etag
+		}}
+	}
+	updated, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), 4, "", ""), ifMatch(oi.ETag))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("ijkl")), 4, "", ""), ifMatch(oi.ETag))
+	if !isErrPreconditionFailed(err) {
+		t.Fatalf("Expected precondition failed, got %v", err)
+	}
+	got, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ETag != updated.ETag {
+		t.Fatalf("Expected the object to be left unchanged, got ETag %s", got.ETag)
+	}
+}
+
+func TestErasureAppendObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	readObject := func(bucket, object string, opts ObjectOptions) []byte {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		b, err := io.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	appendObject := func(bucket, object string, data []byte, offset int64, versioned bool) (ObjectInfo, error) {
+		return obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+			Versioned:    versioned,
+			Append:       true,
+			AppendOffset: offset,
+		})
+	}
+
+	testCases := []struct {
+		bucket    string
+		versioned bool
+		size      int
+	}{
+		// Inlined data is rewritten.
+		{bucket: "inline", size: 1024},
+		{bucket: "versioned-inline", versioned: true, size: 1024},
+		// Data is appended as new parts.
+		{bucket: "parts", size: 2 * humanize.MiByte},
+		{bucket: "versioned", versioned: true, size: 1 * humanize.MiByte},
+	}
+	er := obj.(*erasureServerPools).getServerPools()[0].sets[0]
+	for _, tc := range testCases {
+		t.Run(tc.bucket, func(t *testing.T) {
+			if err := obj.MakeBucketWithLocation(ctx, tc.bucket, BucketOptions{VersioningEnabled: tc.versioned}); err != nil {
+				t.Fatal(err)
+			}
+			first := bytes.Repeat([]byte("a"), tc.size)
+			second := bytes.Repeat([]byte("b"), tc.size)
+			third := bytes.Repeat([]byte("c"), 10)
+
+			if _, err := appendObject(tc.bucket, "object", first, 1, tc.versioned); err == nil {
+				t.Fatal("Expected appending to a missing object at a non zero offset to fail")
+			}
+			oi1, err := appendObject(tc.bucket, "object", first, 0, tc.versioned)
+			if err != nil {
+				t.Fatal(err)
+			}
+			oi2, err := appendObject(tc.bucket, "object", second, oi1.Size, tc.versioned)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = appendObject(tc.bucket, "object", third, oi1.Size, tc.versioned); err == nil {
+				t.Fatal("Expected appending at a stale offset to fail")
+			}
+			oi3, err := appendObject(tc.bucket, "object", third, oi2.Size, tc.versioned)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := append(append(append([]byte{}, first...), second...), third...)
+			if oi3.Size != int64(len(want)) {
+				t.Fatalf("Expected size %d, got %d", len(want), oi3.Size)
+			}
+			if got := readObject(tc.bucket, "object", ObjectOptions{}); !bytes.Equal(got, want) {
+				t.Fatalf("Expected the appended content of %d bytes, got %d bytes", len(want), len(got))
+			}
+			if oi3.ETag == oi2.ETag || oi2.ETag == oi1.ETag {
+				t.Fatal("Expected the ETag to change with every append")
+			}
+			fi, _, _, err := er.getObjectFileInfo(ctx, tc.bucket, "object", ObjectOptions{}, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			inline, wantParts := tc.size < humanize.MiByte, 3
+			if inline {
+				wantParts = 1
+			}
+			if fi.InlineData() != inline || len(fi.Parts) != wantParts {
+				t.Fatalf("Expected inlined data %t with %d parts, got %t with %d parts", inline, wantParts, fi.InlineData(), len(fi.Parts))
+			}
+
+			if !tc.versioned {
+				return
+			}
+			if oi3.VersionID == oi2.VersionID || oi2.VersionID == oi1.VersionID {
+				t.Fatal("Expected every append to create a new version")
+			}
+			// Previous versions share the data and remain readable.
+			if got := readObject(tc.bucket, "object", ObjectOptions{VersionID: oi1.VersionID}); !bytes.Equal(got, first) {
+				t.Fatalf("Expected the first version of %d bytes, got %d bytes", len(first), len(got))
+			}
+			// Deleting a version keeps the data shared with the other versions.
+			if _, err = obj.DeleteObject(ctx, tc.bucket, "object", ObjectOptions{VersionID: oi1.VersionID, Versioned: true}); err != nil {
+				t.Fatal(err)
+			}
+			if got := readObject(tc.bucket, "object", ObjectOptions{VersionID: oi3.VersionID}); !bytes.Equal(got, want) {
+				t.Fatalf("Expected the latest version of %d bytes, got %d bytes", len(want), len(got))
+			}
+			if _, err = obj.DeleteObject(ctx, tc.bucket, "object", ObjectOptions{VersionID: oi3.VersionID, Versioned: true}); err != nil {
+				t.Fatal(err)
+			}
+			want = append(append([]byte{}, first...), second...)
+			if got := readObject(tc.bucket, "object", ObjectOptions{VersionID: oi2.VersionID}); !bytes.Equal(got, want) {
+				t.Fatalf("Expected the second version of %d bytes, got %d bytes", len(want), len(got))
+			}
+		})
+	}
+}
+
+func TestErasureAppendObjectRewrite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	readObject := func(object string, opts ObjectOptions) []byte {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		b, err := io.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	putObject := func(object string, data []byte, opts ObjectOptions) (ObjectInfo, error) {
+		return obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+	}
+
+	// The replication state of the object appended to is not kept.
+	first := bytes.Repeat([]byte("a"), 1*humanize.MiByte)
+	oi1, err := putObject("replicated", first, ObjectOptions{Versioned: true, UserDefined: map[string]string{
+		ReservedMetadataPrefixLower + ReplicationStatus:    "arn:minio:replication::id:bucket=COMPLETED;",
+		ReservedMetadataPrefixLower + ReplicationTimestamp: UTCNow().Format(time.RFC3339Nano),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi1.ReplicationStatus != replication.Completed {
+		t.Fatalf("Expected replication status %s, got %s", replication.Completed, oi1.ReplicationStatus)
+	}
+	oi2, err := putObject("replicated", []byte("b"), ObjectOptions{Versioned: true, Append: true, AppendOffset: oi1.Size})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi2, err = obj.GetObjectInfo(ctx, bucket, "replicated", ObjectOptions{VersionID: oi2.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi2.ReplicationStatus != "" {
+		t.Fatalf("Expected no replication status, got %s", oi2.ReplicationStatus)
+	}
+
+	// A null version appended to a version with an ID does not share
+	// its data and is rewritten.
+	suspended := ObjectOptions{VersionSuspended: true, Append: true}
+	oi1, err = putObject("suspended", first, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	suspended.AppendOffset = oi1.Size
+	oi2, err = putObject("suspended", []byte("b"), suspended)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi2.VersionID != "" {
+		t.Fatalf("Expected a null version, got %s", oi2.VersionID)
+	}
+	if got, want := readObject("suspended", ObjectOptions{}), append(append([]byte{}, first...), 'b'); !bytes.Equal(got, want) {
+		t.Fatalf("Expected the appended content of %d bytes, got %d bytes", len(want), len(got))
+	}
+	if got := readObject("suspended", ObjectOptions{VersionID: oi1.VersionID}); !bytes.Equal(got, first) {
+		t.Fatalf("Expected the previous version of %d bytes, got %d bytes", len(first), len(got))
+	}
+
+	// Objects larger than the minimum part size are not rewritten.
+	large := bytes.Repeat([]byte("a"), globalMinPartSize+1)
+	if oi1, err = putObject("large", large, ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+	suspended.AppendOffset = oi1.Size
+	if _, err = putObject("large", []byte("b"), suspended); !errors.As(err, &NotImplemented{}) {
+		t.Fatalf("Expected NotImplemented, got %v", err)
+	}
+}
+
+func TestErasureCopyObjectReuseData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := newTwoPoolsObjectLayer(ctx, t)
+	defer z.Shutdown(context.Background())
+
+	const bucket = "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	putObject := func(poolIdx int, object string, data []byte) {
+		_, err := z.getServerPools()[poolIdx].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// copyObject copies src to dst the way a copy keeping the stored
+	// data does, r is only read when the data is rewritten.
+	copyObject := func(src, dst string, r io.Reader) ObjectInfo {
+		srcInfo, err := z.GetObjectInfo(ctx, bucket, src, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcInfo.UserDefined = cloneMSS(srcInfo.UserDefined)
+		srcInfo.UserDefined["x-amz-meta-key"] = "value"
+		srcInfo.reuseData = true
+		srcInfo.PutObjReader = mustGetPutObjReader(t, r, srcInfo.Size, "", "")
+		oi, err := z.CopyObject(ctx, bucket, src, bucket, dst, srcInfo, ObjectOptions{}, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	checkObject := func(poolIdx int, object string, data []byte) {
+		gr, err := z.getServerPools()[poolIdx].GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%s: unexpected content", object)
+		}
+		if gr.ObjInfo.UserDefined["x-amz-meta-key"] != "value" {
+			t.Fatalf("%s: unexpected metadata %v", object, gr.ObjInfo.UserDefined)
+		}
+		if _, err = z.getServerPools()[1-poolIdx].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object only in pool %d, got %v", object, poolIdx, err)
+		}
+	}
+
+	large := bytes.Repeat([]byte("a"), 1<<20)
+	small := []byte("small")
+	putObject(1, "large", large)
+	putObject(1, "small", small)
+	putObject(0, "existing", []byte("existing"))
+
+	// New destinations are placed in the pool of the source and get a
+	// copy of its shards, the data is not read.
+	unread := iotest.ErrReader(errors.New("data must not be read"))
+	for _, testCase := range []struct {
+		src, dst string
+		data     []byte
+	}{
+		{"large", "large-copy", large},
+		{"small", "small-copy", small},
+	} {
+		oi := copyObject(testCase.src, testCase.dst, unread)
+		if oi.Size != int64(len(testCase.data)) {
+			t.Fatalf("%s: expected size %d, got %d", testCase.dst, len(testCase.data), oi.Size)
+		}
+		if _, err := z.DeleteObject(ctx, bucket, testCase.src, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		checkObject(1, testCase.dst, testCase.data)
+	}
+
+	// An existing destination in another pool is rewritten in place.
+	putObject(1, "large", large)
+	copyObject("large", "existing", bytes.NewReader(large))
+	checkObject(0, "existing", large)
+}
+
 func TestErasureDeleteObjectDiskNotFound(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -215,7 +827,7 @@ func TestErasureDeleteObjectDiskNotFound(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -233,7 +845,7 @@ func TestErasureDeleteObjectDiskNotFound(t *testing.T) {
 	}
 
 	erasureDisks := xl.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		for i := range erasureDisks[:6] {
 			erasureDisks[i] = newNaughtyDisk(erasureDisks[i], nil, errFaultyDisk)
@@ -241,7 +853,7 @@ func TestErasureDeleteObjectDiskNotFound(t *testing.T) {
 		return erasureDisks
 	}
 
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	if !errors.Is(err, errErasureWriteQuorum) {
 		t.Fatal(err)
@@ -255,14 +867,14 @@ func TestErasureDeleteObjectDiskNotFound(t *testing.T) {
 
 	// Remove one more disk to 'lose' quorum, by taking 2 more drives offline.
 	erasureDisks = xl.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		erasureDisks[7] = nil
 		erasureDisks[8] = nil
 		return erasureDisks
 	}
 
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	// since majority of disks are not available, metaquorum is not achieved and hence errErasureWriteQuorum error
 	if !errors.Is(err, errErasureWriteQuorum) {
@@ -284,7 +896,7 @@ func TestErasureDeleteObjectDiskNotFoundErasure4(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -314,7 +926,7 @@ func TestErasureDeleteObjectDiskNotFoundErasure4(t *testing.T) {
 
 	// Remove disks to 'lose' quorum for object, by setting 5 to nil.
 	erasureDisks := xl.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		for i := range erasureDisks[:5] {
 			erasureDisks[i] = newNaughtyDisk(erasureDisks[i], nil, errFaultyDisk)
@@ -322,7 +934,7 @@ func TestErasureDeleteObjectDiskNotFoundErasure4(t *testing.T) {
 		return erasureDisks
 	}
 
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	// since majority of disks are not available, metaquorum is not achieved and hence errErasureWriteQuorum error
 	if !errors.Is(err, errErasureWriteQuorum) {
@@ -344,7 +956,7 @@ func TestErasureDeleteObjectDiskNotFoundErr(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -363,7 +975,7 @@ func TestErasureDeleteObjectDiskNotFoundErr(t *testing.T) {
 	// for a 16 disk setup, EC is 4, but will be upgraded up to 8.
 	// Remove 4 disks.
 	erasureDisks := xl.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		for i := range erasureDisks[:4] {
 			erasureDisks[i] = newNaughtyDisk(erasureDisks[i], nil, errFaultyDisk)
@@ -371,7 +983,7 @@ func TestErasureDeleteObjectDiskNotFoundErr(t *testing.T) {
 		return erasureDisks
 	}
 
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	if err != nil {
 		t.Fatal(err)
@@ -385,7 +997,7 @@ func TestErasureDeleteObjectDiskNotFoundErr(t *testing.T) {
 
 	// Object was uploaded with 4 known bad drives, so we should still be able to lose 3 drives and still write to the object.
 	erasureDisks = xl.getDisks()
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		erasureDisks[7] = nil
 		erasureDisks[8] = nil
@@ -393,7 +1005,7 @@ func TestErasureDeleteObjectDiskNotFoundErr(t *testing.T) {
 		return erasureDisks
 	}
 
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	// since majority of disks are available, metaquorum achieved.
 	if err != nil {
@@ -415,7 +1027,7 @@ func TestGetObjectNoQuorum(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -485,11 +1097,11 @@ func TestGetObjectNoQuorum(t *testing.T) {
 				erasureDisks[i] = newNaughtyDisk(erasureDisks[i], diskErrors, errFaultyDisk)
 			}
 		}
-		z.serverPools[0].erasureDisksMu.Lock()
+		z.getServerPools()[0].erasureDisksMu.Lock()
 		xl.getDisks = func() []StorageAPI {
 			return erasureDisks
 		}
-		z.serverPools[0].erasureDisksMu.Unlock()
+		z.getServerPools()[0].erasureDisksMu.Unlock()
 		// Fetch object from store.
 		gr, err := xl.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, opts)
 		if err != nil {
@@ -522,7 +1134,7 @@ func TestHeadObjectNoQuorum(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -569,11 +1181,11 @@ func TestHeadObjectNoQuorum(t *testing.T) {
 		erasureDisks[i] = nil
 	}
 
-	z.serverPools[0].erasureDisksMu.Lock()
+	z.getServerPools()[0].erasureDisksMu.Lock()
 	xl.getDisks = func() []StorageAPI {
 		return erasureDisks
 	}
-	z.serverPools[0].erasureDisksMu.Unlock()
+	z.getServerPools()[0].erasureDisksMu.Unlock()
 
 	// Fetch object from store.
 	_, err = xl.GetObjectInfo(ctx, bucket, object, opts)
@@ -597,7 +1209,7 @@ func TestPutObjectNoQuorum(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -632,11 +1244,11 @@ func TestPutObjectNoQuorum(t *testing.T) {
 				erasureDisks[i] = newNaughtyDisk(erasureDisks[i], diskErrors, errFaultyDisk)
 			}
 		}
-		z.serverPools[0].erasureDisksMu.Lock()
+		z.getServerPools()[0].erasureDisksMu.Lock()
 		xl.getDisks = func() []StorageAPI {
 			return erasureDisks
 		}
-		z.serverPools[0].erasureDisksMu.Unlock()
+		z.getServerPools()[0].erasureDisksMu.Unlock()
 		// Upload new content to same object "object"
 		_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(bytes.Repeat([]byte{byte(f)}, smallFileThreshold*16)), smallFileThreshold*16, "", ""), opts)
 		if !errors.Is(err, errErasureWriteQuorum) {
@@ -660,7 +1272,7 @@ func TestPutObjectNoQuorumSmall(t *testing.T) {
 	defer removeRoots(fsDirs)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 
 	// Create "bucket"
 	err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{})
@@ -696,11 +1308,11 @@ func TestPutObjectNoQuorumSmall(t *testing.T) {
 					erasureDisks[i] = newNaughtyDisk(erasureDisks[i], diskErrors, errFaultyDisk)
 				}
 			}
-			z.serverPools[0].erasureDisksMu.Lock()
+			z.getServerPools()[0].erasureDisksMu.Lock()
 			xl.getDisks = func() []StorageAPI {
 				return erasureDisks
 			}
-			z.serverPools[0].erasureDisksMu.Unlock()
+			z.getServerPools()[0].erasureDisksMu.Unlock()
 			// Upload new content to same object "object"
 			_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(bytes.Repeat([]byte{byte(f)}, smallFileThreshold/2)), smallFileThreshold/2, "", ""), opts)
 			if !errors.Is(err, errErasureWriteQuorum) {
@@ -794,7 +1406,7 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 	data := bytes.Repeat([]byte("a"), 6*1024*1024*partCount)
 
 	z := obj.(*erasureServerPools)
-	xl := z.serverPools[0].sets[0]
+	xl := z.getServerPools()[0].sets[0]
 	erasureDisks := xl.getDisks()
 
 	ctx, cancel := context.WithCancel(GlobalContext)
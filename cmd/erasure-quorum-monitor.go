@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	quorumMonitorInterval   = 15 * time.Minute // Time between two sampling cycles.
+	quorumMonitorSampleSize = 256              // Maximum objects sampled per erasure set per cycle.
+	quorumMonitorSelectProb = 16               // Probability of an object being sampled; one in n.
+
+	quorumMonitorObjName = bucketMetaPrefix + SlashSeparator + ".quorum-monitor.json"
+)
+
+var quorumMonitorLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// SetQuorumStatus is the read quorum health of the objects
+// sampled from a single erasure set.
+type SetQuorumStatus struct {
+	Pool int `json:"pool"`
+	Set  int `json:"set"`
+
+	// Sampled is the number of objects inspected in this set.
+	Sampled uint64 `json:"sampled"`
+	// AtRisk is the number of objects which are readable but
+	// are one disk away from losing read quorum.
+	AtRisk uint64 `json:"atRisk"`
+	// Unreadable is the number of objects which have already
+	// lost read quorum.
+	Unreadable uint64 `json:"unreadable"`
+}
+
+// QuorumMonitorStatus is the result of the last quorum monitor cycle.
+type QuorumMonitorStatus struct {
+	LastUpdate time.Time         `json:"lastUpdate"`
+	Sets       []SetQuorumStatus `json:"sets"`
+}
+
+// initQuorumMonitor will start the quorum monitor in the background.
+func initQuorumMonitor(ctx context.Context, objAPI ObjectLayer) {
+	go runQuorumMonitor(ctx, objAPI)
+}
+
+// runQuorumMonitor periodically samples objects on every erasure set
+// and records how many of them are close to losing read quorum.
+// There should only ever be one quorum monitor running per cluster.
+func runQuorumMonitor(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+
+	// Make sure only 1 quorum monitor is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runQuorumMonitor.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, quorumMonitorLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(quorumMonitorInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	monitorTimer := time.NewTimer(quorumMonitorInterval)
+	defer monitorTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-monitorTimer.C:
+			status := z.sampleQuorumStatus(ctx, r)
+			if err := saveQuorumMonitorStatus(ctx, objAPI, status); err != nil {
+				logger.LogIf(ctx, err)
+			}
+			monitorTimer.Reset(quorumMonitorInterval)
+		}
+	}
+}
+
+// sampleQuorumStatus samples objects from all erasure sets in all pools.
+func (z *erasureServerPools) sampleQuorumStatus(ctx context.Context, r *rand.Rand) QuorumMonitorStatus {
+	status := QuorumMonitorStatus{}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return status
+	}
+
+	for _, pool := range z.getServerPools() {
+		for _, set := range pool.sets {
+			st := set.sampleQuorumStatus(ctx, buckets, quorumMonitorSampleSize, r)
+			status.Sets = append(status.Sets, st)
+		}
+	}
+	status.LastUpdate = UTCNow()
+	return status
+}
+
+// sampleQuorumStatus walks the buckets of this erasure set, sampling
+// up to sampleSize objects and classifying them by how close their
+// latest version is to losing read quorum.
+func (er erasureObjects) sampleQuorumStatus(ctx context.Context, buckets []BucketInfo, sampleSize int, r *rand.Rand) SetQuorumStatus {
+	st := SetQuorumStatus{
+		Pool: er.poolIndex,
+		Set:  er.setIndex,
+	}
+
+	listingDisks, _ := er.getOnlineDisksWithHealing()
+	if len(listingDisks) == 0 {
+		return st
+	}
+	disks := er.getDisks()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Visit buckets in a random order, so that sampling does
+	// not always favor the buckets sorted first.
+	r.Shuffle(len(buckets), func(i, j int) {
+		buckets[i], buckets[j] = buckets[j], buckets[i]
+	})
+
+	for _, bucket := range buckets {
+		bucket := bucket.Name
+		// Sampled objects are checked in batches, reading their
+		// metadata with a single call per disk.
+		pending := make([]string, 0, readVersionsBatchSize)
+		checkPending := func() {
+			if len(pending) == 0 {
+				return
+			}
+			metaArrs, errs := readAllFileInfos(ctx, disks, bucket, pending, nil, false)
+			for i := range pending {
+				margin, err := readQuorumMargin(ctx, metaArrs[i], errs[i], er.defaultParityCount)
+				switch {
+				case errors.Is(err, errErasureReadQuorum):
+					st.Unreadable++
+				case err != nil:
+					// Object was most likely removed while sampling.
+					continue
+				case margin == 0:
+					st.AtRisk++
+				}
+				st.Sampled++
+			}
+			pending = pending[:0]
+		}
+		checkEntry := func(entry metaCacheEntry) {
+			if entry.isDir() || st.Sampled+uint64(len(pending)) >= uint64(sampleSize) {
+				return
+			}
+			if r.Intn(quorumMonitorSelectProb) != 0 {
+				return
+			}
+			pending = append(pending, entry.name)
+			if len(pending) == readVersionsBatchSize || st.Sampled+uint64(len(pending)) >= uint64(sampleSize) {
+				checkPending()
+				if st.Sampled >= uint64(sampleSize) {
+					cancel()
+				}
+			}
+		}
+
+		err := listPathRaw(ctx, listPathRawOptions{
+			disks:     listingDisks,
+			bucket:    bucket,
+			recursive: true,
+			minDisks:  1,
+			agreed:    checkEntry,
+			partial:   resolvePartialEntries(bucket, checkEntry),
+		})
+		checkPending()
+		if ctx.Err() != nil {
+			break
+		}
+		logger.LogIf(ctx, err)
+	}
+	return st
+}
+
+func saveQuorumMonitorStatus(ctx context.Context, objAPI ObjectLayer, status QuorumMonitorStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, quorumMonitorObjName, data)
+}
+
+func loadQuorumMonitorStatus(ctx context.Context, objAPI ObjectLayer) (QuorumMonitorStatus, error) {
+	var status QuorumMonitorStatus
+	data, err := readConfig(ctx, objAPI, quorumMonitorObjName)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return status, nil
+		}
+		return status, err
+	}
+	if err = json.Unmarshal(data, &status); err != nil {
+		return status, err
+	}
+	return status, nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Concurrent GETs of the whole of the same object version are coalesced,
+// the first one decodes the object and the others joining it before it
+// streams any byte are served from the decoded output, instead of each
+// one reading the shards from the drives. The decoded output is only
+// buffered once a second reader joins.
+var globalReadCoalescer = newReadCoalescer()
+
+// readCoalesceMaxSize is the size of the largest object coalesced, the
+// decoded object is held in memory until all of its readers are done.
+var readCoalesceMaxSize int64 = 16 << 20
+
+// readCoalesceMaxBuffered is the total size of the objects held in
+// memory at once for their readers, reads joining beyond that decode
+// the object on their own.
+var readCoalesceMaxBuffered int64 = 256 << 20
+
+var errCoalescedReadAbandoned = errors.New("coalesced read abandoned by all its readers")
+
+type readCoalescer struct {
+	mu    sync.Mutex
+	reads map[string]*coalescedRead
+	// bytes reserved by the shared reads, only changed with mu held.
+	buffered int64
+}
+
+func newReadCoalescer() *readCoalescer {
+	return &readCoalescer{reads: make(map[string]*coalescedRead)}
+}
+
+// objectDataKey returns a key unique to the data of the object version,
+// which changes with any rewrite of its data.
+func objectDataKey(bucket, object string, fi FileInfo) string {
+	return pathJoin(bucket, object) + SlashSeparator + fi.VersionID + SlashSeparator + fi.DataDir + SlashSeparator + strconv.FormatInt(fi.ModTime.UnixNano(), 10)
+}
+
+// shouldCoalesceRead returns true if a read of length bytes at offset of
+// the object is coalesced with concurrent reads.
+func shouldCoalesceRead(fi FileInfo, offset, length int64) bool {
+	// Inline data is already in memory.
+	return offset == 0 && length == fi.Size && fi.Size > 0 &&
+		fi.Size <= readCoalesceMaxSize && len(fi.Data) == 0
+}
+
+// Read returns a reader of size bytes identified by key. The first caller
+// for a key fills the bytes in the background, its cleanup runs once fill
+// returns. Callers with the same key joining before any byte is filled
+// share these bytes, their cleanup runs when their reader is closed. fill
+// is canceled once all readers are closed.
+func (c *readCoalescer) Read(key string, size int64, fill func(w io.Writer) error, cleanup func()) io.ReadCloser {
+	c.mu.Lock()
+	if r, ok := c.reads[key]; ok && c.join(r) {
+		c.mu.Unlock()
+		return &coalescedReader{read: r, cleanup: cleanup}
+	}
+	r := &coalescedRead{
+		c:    c,
+		key:  key,
+		size: size,
+		refs: 1,
+	}
+	r.cond = sync.NewCond(&r.mu)
+	c.reads[key] = r
+	c.mu.Unlock()
+
+	go func() {
+		err := fill(r)
+		// Later callers start a new read.
+		c.remove(r)
+		r.mu.Lock()
+		r.done, r.err = true, err
+		r.cond.Broadcast()
+		r.mu.Unlock()
+		if cleanup != nil {
+			cleanup()
+		}
+	}()
+	return &coalescedReader{read: r}
+}
+
+// join adds a reader to r if it has not streamed any byte yet, r starts
+// buffering its bytes if this is its second reader and the bytes fit in
+// the memory left. Must be called with c.mu held.
+func (c *readCoalescer) join(r *coalescedRead) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.streaming || r.refs == 0 {
+		return false
+	}
+	if !r.shared {
+		if c.buffered+r.size > readCoalesceMaxBuffered {
+			return false
+		}
+		c.buffered += r.size
+		r.shared = true
+		r.buf = make([]byte, 0, r.size)
+	}
+	r.refs++
+	return true
+}
+
+func (c *readCoalescer) remove(r *coalescedRead) {
+	c.mu.Lock()
+	if c.reads[r.key] == r {
+		delete(c.reads, r.key)
+	}
+	c.mu.Unlock()
+}
+
+func (c *readCoalescer) release(r *coalescedRead) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r.mu.Lock()
+	r.refs--
+	closed := r.refs == 0
+	if closed {
+		if r.shared {
+			c.buffered -= r.size
+			r.buf = nil
+		}
+		// Unblock a fill waiting for its bytes to be read.
+		r.cond.Broadcast()
+	}
+	r.mu.Unlock()
+
+	if closed && c.reads[r.key] == r {
+		delete(c.reads, r.key)
+	}
+}
+
+// coalescedRead holds the bytes of a read shared by its readers, or
+// hands them over to its only reader.
+type coalescedRead struct {
+	c    *readCoalescer
+	key  string
+	size int64
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// number of open readers.
+	refs int
+	// set once a second reader joined, all bytes are kept in buf.
+	shared bool
+	buf    []byte
+	// set once bytes are streamed to the only reader, pending
+	// holds the bytes of the last write not read yet.
+	streaming bool
+	pending   []byte
+	done      bool
+	err       error
+}
+
+func (r *coalescedRead) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	if r.refs == 0 {
+		r.mu.Unlock()
+		return 0, errCoalescedReadAbandoned
+	}
+	if r.shared {
+		r.buf = append(r.buf, p...)
+		r.cond.Broadcast()
+		r.mu.Unlock()
+		return len(p), nil
+	}
+	if !r.streaming {
+		r.streaming = true
+		// No one can join anymore, later callers start a new read.
+		r.mu.Unlock()
+		r.c.remove(r)
+		r.mu.Lock()
+	}
+	r.pending = p
+	r.cond.Broadcast()
+	for len(r.pending) > 0 && r.refs > 0 {
+		r.cond.Wait()
+	}
+	n := len(p) - len(r.pending)
+	r.pending = nil
+	r.mu.Unlock()
+	if n < len(p) {
+		return n, errCoalescedReadAbandoned
+	}
+	return n, nil
+}
+
+type coalescedReader struct {
+	read    *coalescedRead
+	offset  int
+	once    sync.Once
+	cleanup func()
+}
+
+func (cr *coalescedReader) Read(p []byte) (int, error) {
+	r := cr.read
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		switch {
+		case len(r.pending) > 0:
+			n := copy(p, r.pending)
+			r.pending = r.pending[n:]
+			if len(r.pending) == 0 {
+				r.cond.Broadcast()
+			}
+			return n, nil
+		case cr.offset < len(r.buf):
+			n := copy(p, r.buf[cr.offset:])
+			cr.offset += n
+			return n, nil
+		case r.done:
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		r.cond.Wait()
+	}
+}
+
+func (cr *coalescedReader) Close() error {
+	cr.once.Do(func() {
+		cr.read.c.release(cr.read)
+		if cr.cleanup != nil {
+			cr.cleanup()
+		}
+	})
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestReadCoalescer(t *testing.T) {
+	c := newReadCoalescer()
+	data := bytes.Repeat([]byte("coalesced"), 1000)
+
+	var fills, cleanups int
+	var mu sync.Mutex
+	start := make(chan struct{})
+	fill := func(w io.Writer) error {
+		mu.Lock()
+		fills++
+		mu.Unlock()
+		<-start
+		for i := 0; i < len(data); i += 100 {
+			if _, err := w.Write(data[i : i+100]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cleanup := func() {
+		mu.Lock()
+		cleanups++
+		mu.Unlock()
+	}
+
+	readers := make([]io.ReadCloser, 10)
+	for i := range readers {
+		readers[i] = c.Read("bucket/object", int64(len(data)), fill, cleanup)
+	}
+	close(start)
+
+	var wg sync.WaitGroup
+	for i, rd := range readers {
+		wg.Add(1)
+		go func(i int, rd io.ReadCloser) {
+			defer wg.Done()
+			defer rd.Close()
+			got, err := ioutil.ReadAll(rd)
+			if err != nil {
+				t.Errorf("reader %d: %v", i, err)
+				return
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("reader %d: unexpected data of %d bytes", i, len(got))
+			}
+		}(i, rd)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	inflight := len(c.reads)
+	c.mu.Unlock()
+	mu.Lock()
+	if fills != 1 {
+		t.Errorf("expected a single fill, got %d", fills)
+	}
+	// The cleanup of the first reader runs once the fill returns,
+	// possibly after the others are closed.
+	if cleanups < len(readers)-1 {
+		t.Errorf("expected at least %d cleanups, got %d", len(readers)-1, cleanups)
+	}
+	mu.Unlock()
+	if inflight != 0 {
+		t.Errorf("expected no reads in flight, got %d", inflight)
+	}
+	if c.buffered != 0 {
+		t.Errorf("expected no buffered bytes left, got %d", c.buffered)
+	}
+
+	// Reads of an abandoned fill are not shared.
+	abandoned := make(chan error, 1)
+	rd := c.Read("bucket/object", int64(len(data)), func(w io.Writer) error {
+		<-start
+		_, err := w.Write(data)
+		abandoned <- err
+		return err
+	}, nil)
+	rd.Close()
+	if err := <-abandoned; err != errCoalescedReadAbandoned {
+		t.Errorf("expected %v, got %v", errCoalescedReadAbandoned, err)
+	}
+}
+
+func TestReadCoalescerStreaming(t *testing.T) {
+	c := newReadCoalescer()
+	data := bytes.Repeat([]byte("streamed"), 1000)
+
+	fills := make(chan struct{}, 2)
+	resume := make(chan struct{})
+	fill := func(w io.Writer) error {
+		fills <- struct{}{}
+		if _, err := w.Write(data[:100]); err != nil {
+			return err
+		}
+		<-resume
+		_, err := w.Write(data[100:])
+		return err
+	}
+
+	// A single reader is handed the bytes without buffering them.
+	rd := c.Read("bucket/object", int64(len(data)), fill, nil)
+	defer rd.Close()
+	buf := make([]byte, 100)
+	if _, err := io.ReadFull(rd, buf); err != nil {
+		t.Fatal(err)
+	}
+	if r := rd.(*coalescedReader).read; r.shared || r.buf != nil {
+		t.Fatal("expected the bytes of a single reader not to be buffered")
+	}
+
+	// A reader coming after the bytes are streamed reads on its own.
+	other := c.Read("bucket/object", int64(len(data)), fill, nil)
+	defer other.Close()
+	close(resume)
+	for _, r := range []io.Reader{io.MultiReader(bytes.NewReader(buf), rd), other} {
+		got, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("unexpected data of %d bytes", len(got))
+		}
+	}
+	if len(fills) != 2 {
+		t.Fatalf("expected 2 fills, got %d", len(fills))
+	}
+	if c.buffered != 0 {
+		t.Fatalf("expected no buffered bytes, got %d", c.buffered)
+	}
+}
+
+func TestReadCoalescerMaxBuffered(t *testing.T) {
+	defer func(max int64) { readCoalesceMaxBuffered = max }(readCoalesceMaxBuffered)
+	readCoalesceMaxBuffered = 1000
+
+	c := newReadCoalescer()
+	data := bytes.Repeat([]byte("a"), 1000)
+	start := make(chan struct{})
+	var mu sync.Mutex
+	fills := make(map[string]int)
+	fillFn := func(key string) func(w io.Writer) error {
+		return func(w io.Writer) error {
+			mu.Lock()
+			fills[key]++
+			mu.Unlock()
+			<-start
+			_, err := w.Write(data)
+			return err
+		}
+	}
+
+	var readers []io.ReadCloser
+	for _, key := range []string{"bucket/first", "bucket/first", "bucket/first", "bucket/second", "bucket/second"} {
+		readers = append(readers, c.Read(key, int64(len(data)), fillFn(key), nil))
+	}
+	if c.buffered != 1000 {
+		t.Fatalf("expected 1000 buffered bytes, got %d", c.buffered)
+	}
+	close(start)
+	for _, rd := range readers {
+		got, err := ioutil.ReadAll(rd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("unexpected data of %d bytes", len(got))
+		}
+		rd.Close()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The second object does not fit in the memory left.
+	if fills["bucket/first"] != 1 || fills["bucket/second"] != 2 {
+		t.Fatalf("unexpected fills %v", fills)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.buffered != 0 {
+		t.Fatalf("expected no buffered bytes left, got %d", c.buffered)
+	}
+}
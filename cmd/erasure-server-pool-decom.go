@@ -0,0 +1,771 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	decommissionMetaName = "decommission.json"
+
+	// Time between two checks for a decommission operation to run or resume.
+	decommissionCheckInterval = time.Minute
+	// Time between two saves of the decommission progress.
+	decommissionSaveInterval = 30 * time.Second
+)
+
+var (
+	decommissionLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// error returned when a decommission is started while another one is running.
+	errDecommissionAlreadyRunning = AdminError{
+		Code:       "XMinioAdminDecommissionAlreadyRunning",
+		Message:    "A decommission operation is already in progress, or its pool was not removed yet",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when canceling without a running decommission.
+	errDecommissionNotStarted = AdminError{
+		Code:       "XMinioAdminDecommissionNotStarted",
+		Message:    "No decommission operation is in progress for this pool",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when the pool to decommission is unknown.
+	errDecommissionInvalidPool = AdminError{
+		Code:       "XMinioAdminDecommissionInvalidPool",
+		Message:    "The pool argument does not match any server pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when decommission is requested on a single pool setup.
+	errDecommissionSinglePool = AdminError{
+		Code:       "XMinioAdminDecommissionSinglePool",
+		Message:    "Decommission requires more than one server pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the other pools can't hold the objects of the pool.
+	errDecommissionNoSpace = AdminError{
+		Code:       "XMinioAdminDecommissionNoSpace",
+		Message:    "The remaining pools do not have enough free space to hold the objects of the pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a decommission is started during a rebalance.
+	errDecommissionRebalanceRunning = AdminError{
+		Code:       "XMinioAdminDecommissionRebalanceRunning",
+		Message:    "A rebalance operation is in progress, pause it first",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when a rebalance is started during a decommission.
+	errRebalanceDecommissionRunning = AdminError{
+		Code:       "XMinioAdminRebalanceDecommissionRunning",
+		Message:    "A pool is being decommissioned",
+		StatusCode: http.StatusConflict,
+	}
+)
+
+// DecommissionBucketProgress is the progress of moving the objects of a
+// bucket out of the pool being decommissioned.
+type DecommissionBucketProgress struct {
+	Name     string `json:"name"`
+	Objects  uint64 `json:"objects"`
+	Versions uint64 `json:"versions"`
+	Bytes    uint64 `json:"bytes"`
+	Failed   uint64 `json:"failed"`
+	Done     bool   `json:"done"`
+}
+
+// decommissionMeta is the persisted state of a pool decommission.
+type decommissionMeta struct {
+	ID string `json:"id"`
+	// CmdLine identifies the pool, Pool is its index at the time the
+	// decommission was started.
+	CmdLine     string    `json:"cmdLine"`
+	Pool        int       `json:"pool"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	Canceled    bool      `json:"canceled,omitempty"`
+	// Failed is set on completion if some objects were not moved, the
+	// decommission must be started again before removing the pool.
+	Failed bool `json:"failed,omitempty"`
+
+	Bucket  string                       `json:"bucket,omitempty"` // bucket currently being drained
+	Buckets []DecommissionBucketProgress `json:"buckets"`
+}
+
+func (m decommissionMeta) complete() bool {
+	return !m.CompletedAt.IsZero()
+}
+
+// removable returns true once all objects were moved out of the pool,
+// which can then be removed from the server command line.
+func (m decommissionMeta) removable() bool {
+	return m.complete() && !m.Failed && !m.Canceled
+}
+
+func loadDecommissionMeta(ctx context.Context, objAPI ObjectLayer) (*decommissionMeta, error) {
+	data, err := readConfig(ctx, objAPI, decommissionMetaName)
+	if err != nil {
+		return nil, err
+	}
+	m := &decommissionMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func saveDecommissionMeta(ctx context.Context, objAPI ObjectLayer, m *decommissionMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, decommissionMetaName, data)
+}
+
+// poolIndex returns the index of the pool created from cmdLine, -1 if
+// no such pool exists.
+func (z *erasureServerPools) poolIndex(cmdLine string) int {
+	for i, c := range z.getPoolCmdLines() {
+		if c == cmdLine {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookupPool returns the index of the pool identified by arg, either
+// its command line or its index.
+func (z *erasureServerPools) lookupPool(arg string) (int, error) {
+	if idx := z.poolIndex(arg); idx >= 0 {
+		return idx, nil
+	}
+	idx, err := strconv.Atoi(arg)
+	if err != nil || idx < 0 || idx >= len(z.getServerPools()) {
+		return -1, errDecommissionInvalidPool
+	}
+	return idx, nil
+}
+
+// reloadPoolMeta marks the pool being decommissioned and the pending
+// pools as suspended, so that no new objects are written to them. Pending
+// pools which are part of the pool layout are committed.
+func (z *erasureServerPools) reloadPoolMeta(ctx context.Context) error {
+	m, err := loadDecommissionMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+	layout, err := loadPoolLayoutMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+
+	// Pools are not added while the suspended pools are computed.
+	z.poolsMu.Lock()
+	defer z.poolsMu.Unlock()
+	z.commitPendingPools(layout)
+
+	suspended := make([]bool, len(z.getServerPools()))
+	for cmdLine := range z.pendingPools {
+		if idx := z.poolIndex(cmdLine); idx >= 0 {
+			suspended[idx] = true
+		}
+	}
+	if m != nil && !m.Canceled {
+		if idx := z.poolIndex(m.CmdLine); idx >= 0 {
+			suspended[idx] = true
+		} else if !m.removable() {
+			logger.LogIf(ctx, fmt.Errorf("pool '%s' was removed before its decommission completed, its objects are no longer available", m.CmdLine))
+		}
+	}
+
+	z.suspendedMu.Lock()
+	z.suspended = suspended
+	z.suspendedMu.Unlock()
+	return nil
+}
+
+// decommissionActive returns true if a pool of this setup is being
+// decommissioned, or was but is not removed yet.
+func (z *erasureServerPools) decommissionActive(ctx context.Context) (bool, error) {
+	m, err := loadDecommissionMeta(ctx, z)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !m.Canceled && z.poolIndex(m.CmdLine) >= 0, nil
+}
+
+// hasSpaceForPool returns true if the free space of the other pools
+// can hold what the pool idx uses, both in raw disk capacity.
+func (z *erasureServerPools) hasSpaceForPool(ctx context.Context, idx int) bool {
+	var used, available uint64
+	for i, pool := range z.getServerPools() {
+		if i != idx && z.IsSuspended(i) {
+			continue
+		}
+		info, _ := pool.StorageInfo(ctx)
+		for _, disk := range info.Disks {
+			if i == idx {
+				used += disk.UsedSpace
+			} else {
+				available += disk.AvailableSpace
+			}
+		}
+	}
+	return available >= used
+}
+
+// startDecommission initializes the decommission of the pool idx, the
+// pool is suspended right away on all nodes.
+func (z *erasureServerPools) startDecommission(ctx context.Context, idx int) (*decommissionMeta, error) {
+	if z.SinglePool() {
+		return nil, errDecommissionSinglePool
+	}
+	if idx < 0 || idx >= len(z.getServerPools()) {
+		return nil, errDecommissionInvalidPool
+	}
+
+	m, err := loadDecommissionMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	// A decommission which failed to move some objects can be started
+	// again, any other one must be canceled or its pool removed first.
+	if m != nil && !m.Canceled && z.poolIndex(m.CmdLine) >= 0 &&
+		(m.CmdLine != z.getPoolCmdLines()[idx] || !m.complete() || !m.Failed) {
+		return nil, errDecommissionAlreadyRunning
+	}
+
+	rm, err := loadRebalanceMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	if rm != nil && !rm.complete() && !rm.Paused {
+		return nil, errDecommissionRebalanceRunning
+	}
+
+	if !z.hasSpaceForPool(ctx, idx) {
+		return nil, errDecommissionNoSpace
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m = &decommissionMeta{
+		ID:        mustGetUUID(),
+		CmdLine:   z.getPoolCmdLines()[idx],
+		Pool:      idx,
+		StartedAt: UTCNow(),
+	}
+	for _, bucket := range buckets {
+		m.Buckets = append(m.Buckets, DecommissionBucketProgress{Name: bucket.Name})
+	}
+	// Server configuration and bucket metadata are moved last.
+	for _, prefix := range []string{minioConfigPrefix, bucketMetaPrefix} {
+		m.Buckets = append(m.Buckets, DecommissionBucketProgress{Name: pathJoin(minioMetaBucket, prefix)})
+	}
+	if err = saveDecommissionMeta(ctx, z, m); err != nil {
+		return nil, err
+	}
+	return m, z.reloadPoolMeta(ctx)
+}
+
+// cancelDecommission stops the decommission of the pool idx, the pool
+// accepts new objects again. Objects already moved are not moved back.
+func (z *erasureServerPools) cancelDecommission(ctx context.Context, idx int) (*decommissionMeta, error) {
+	m, err := loadDecommissionMeta(ctx, z)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errDecommissionNotStarted
+		}
+		return nil, err
+	}
+	cmdLines := z.getPoolCmdLines()
+	if m.Canceled || idx < 0 || idx >= len(cmdLines) || m.CmdLine != cmdLines[idx] {
+		return nil, errDecommissionNotStarted
+	}
+	m.Canceled = true
+	if err = saveDecommissionMeta(ctx, z, m); err != nil {
+		return nil, err
+	}
+	return m, z.reloadPoolMeta(ctx)
+}
+
+// initDecommission suspends the pool being decommissioned and starts the
+// decommission worker in the background.
+func initDecommission(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	logger.LogIf(ctx, z.reloadPoolMeta(ctx))
+	if z.SinglePool() {
+		return
+	}
+	go z.runDecommission(ctx)
+}
+
+// runDecommission waits for a decommission operation to be started, or
+// resumed after a restart, and moves all objects out of the pool.
+// There should only ever be one decommission worker running per cluster.
+func (z *erasureServerPools) runDecommission(ctx context.Context) {
+	// Make sure only 1 decommission worker is running on the cluster.
+	locker := z.NewNSLock(minioMetaBucket, "runDecommission.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, decommissionLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(decommissionCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	checkTimer := time.NewTimer(decommissionCheckInterval)
+	defer checkTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkTimer.C:
+			m, err := loadDecommissionMeta(ctx, z)
+			if err == nil && !m.complete() && !m.Canceled {
+				if idx := z.poolIndex(m.CmdLine); idx >= 0 {
+					ds := &decommissionState{meta: m}
+					if err = z.decommission(ctx, ds, idx); err != nil && !errors.Is(err, errDecommissionCanceled) {
+						logger.LogIf(ctx, err)
+					}
+				}
+			} else if err != nil && !errors.Is(err, errConfigNotFound) {
+				logger.LogIf(ctx, err)
+			}
+			checkTimer.Reset(decommissionCheckInterval)
+		}
+	}
+}
+
+var errDecommissionCanceled = errors.New("decommission canceled")
+
+// decommissionState is the in-memory state of a running decommission.
+type decommissionState struct {
+	mu       sync.Mutex
+	meta     *decommissionMeta
+	lastSave time.Time
+}
+
+// sync persists the in-memory progress, and picks up cancel requests
+// written to the backend by the admin API on any node.
+func (ds *decommissionState) sync(ctx context.Context, z *erasureServerPools, force bool) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if !force && time.Since(ds.lastSave) < decommissionSaveInterval {
+		return nil
+	}
+
+	if stored, err := loadDecommissionMeta(ctx, z); err == nil {
+		if stored.ID != ds.meta.ID || stored.Canceled {
+			// Canceled or replaced by a new decommission, give up
+			// without overwriting the stored state.
+			return errDecommissionCanceled
+		}
+	}
+
+	ds.lastSave = time.Now()
+	return saveDecommissionMeta(ctx, z, ds.meta)
+}
+
+func (ds *decommissionState) update(bucket int, fn func(b *DecommissionBucketProgress)) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	fn(&ds.meta.Buckets[bucket])
+}
+
+// decommission moves all objects out of the pool idx, one bucket at a
+// time. On completion the pool stays suspended until it is removed.
+func (z *erasureServerPools) decommission(ctx context.Context, ds *decommissionState, idx int) error {
+	ds.mu.Lock()
+	buckets := make([]DecommissionBucketProgress, len(ds.meta.Buckets))
+	copy(buckets, ds.meta.Buckets)
+	ds.mu.Unlock()
+
+	for i, bucket := range buckets {
+		if bucket.Done {
+			continue
+		}
+		ds.mu.Lock()
+		ds.meta.Bucket = bucket.Name
+		ds.mu.Unlock()
+		if err := z.decommissionBucket(ctx, ds, idx, i); err != nil {
+			ds.sync(ctx, z, true)
+			return err
+		}
+		ds.update(i, func(b *DecommissionBucketProgress) {
+			b.Done = true
+		})
+		if err := ds.sync(ctx, z, true); err != nil {
+			return err
+		}
+	}
+
+	ds.mu.Lock()
+	ds.meta.Bucket = ""
+	ds.meta.CompletedAt = UTCNow()
+	for _, b := range ds.meta.Buckets {
+		if b.Failed > 0 {
+			ds.meta.Failed = true
+		}
+	}
+	ds.mu.Unlock()
+	if err := ds.sync(ctx, z, true); err != nil {
+		return err
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// decommissionSkipped returns true for the objects of the system bucket
+// which belong to a pool and must not be moved, i.e the listing and data
+// usage caches of its erasure sets.
+func decommissionSkipped(bucket, object string) bool {
+	if bucket != minioMetaBucket {
+		return false
+	}
+	return strings.HasPrefix(path.Base(object), dataUsageCacheName) ||
+		strings.Contains(object, SlashSeparator+metacachePrefix+SlashSeparator) ||
+		strings.HasPrefix(object, consistencyProbePrefix+SlashSeparator)
+}
+
+// decommissionBucket walks all erasure sets of the pool idx and moves the
+// objects of the bucket found there to the other pools.
+func (z *erasureServerPools) decommissionBucket(ctx context.Context, ds *decommissionState, idx, bucketIdx int) error {
+	ds.mu.Lock()
+	bucket, prefix := ds.meta.Buckets[bucketIdx].Name, ""
+	ds.mu.Unlock()
+	if strings.HasPrefix(bucket, minioMetaBucket+SlashSeparator) {
+		bucket, prefix = minioMetaBucket, strings.TrimPrefix(bucket, minioMetaBucket+SlashSeparator)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		stopErr error
+	)
+	stop := func(err error) {
+		errOnce.Do(func() {
+			stopErr = err
+			cancel()
+		})
+	}
+
+	for _, set := range z.getServerPools()[idx].sets {
+		set := set
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			moveEntry := func(entry metaCacheEntry) {
+				if entry.isDir() || decommissionSkipped(bucket, entry.name) {
+					return
+				}
+				if err := ds.sync(ctx, z, false); err != nil {
+					stop(err)
+					return
+				}
+				fivs, err := entry.fileInfoVersions(bucket)
+				if err == nil {
+					var bytes int64
+					bytes, err = z.decommissionObject(ctx, set, bucket, fivs)
+					if err == nil {
+						ds.update(bucketIdx, func(b *DecommissionBucketProgress) {
+							b.Objects++
+							b.Versions += uint64(len(fivs.Versions))
+							b.Bytes += uint64(bytes)
+						})
+						return
+					}
+				}
+				ds.update(bucketIdx, func(b *DecommissionBucketProgress) {
+					b.Failed++
+				})
+				if ctx.Err() == nil {
+					logger.LogIf(ctx, fmt.Errorf("unable to decommission %s/%s: %w", bucket, entry.name, err))
+				}
+			}
+
+			err := listPathRaw(ctx, listPathRawOptions{
+				disks:     disks,
+				bucket:    bucket,
+				path:      prefix,
+				recursive: true,
+				minDisks:  1,
+				agreed:    moveEntry,
+				partial:   resolvePartialEntries(bucket, moveEntry),
+			})
+			if err != nil && ctx.Err() == nil && !errors.Is(err, errVolumeNotFound) {
+				stop(err)
+			}
+		}()
+	}
+	wg.Wait()
+	return stopErr
+}
+
+// decommissionObject moves all versions of an object from the erasure set
+// 'set' to the other pools, oldest first, and removes the object from
+// the set once every version was moved. Versions are read back through
+// the erasure decoder, reconstructing missing shards just like healing.
+func (z *erasureServerPools) decommissionObject(ctx context.Context, set *erasureObjects, bucket string, fivs FileInfoVersions) (bytes int64, err error) {
+	object := fivs.Name
+	lk := z.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return 0, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	// The listing is not done under the lock, make sure the object
+	// did not change in the meantime.
+	latest, changed, err := listedVersionsChanged(ctx, set, bucket, fivs)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			// Object was removed in the meantime.
+			return 0, nil
+		}
+		return 0, err
+	}
+	if changed {
+		return 0, fmt.Errorf("object changed while being listed")
+	}
+
+	// Versions of the object written after the pool was suspended already
+	// live on another pool, move the older ones there too.
+	dst, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{NoLock: true, SkipDecommissioned: true})
+	if err != nil && !isErrObjectNotFound(err) {
+		return 0, err
+	}
+	if dst < 0 {
+		if dst = z.getAvailablePoolIdx(ctx, bucket, object, latest.Size); dst < 0 {
+			return 0, toObjectErr(errDiskFull)
+		}
+	}
+	return moveObjectVersions(ctx, set, z.getServerPools()[dst], bucket, fivs)
+}
+
+// listedVersionsChanged reads the latest version of the object listed as
+// fivs from the erasure set and reports whether its versions changed since
+// they were listed. The caller must hold the object lock.
+func listedVersionsChanged(ctx context.Context, set *erasureObjects, bucket string, fivs FileInfoVersions) (latest FileInfo, changed bool, err error) {
+	object := fivs.Name
+	metas, errs := readAllFileInfo(ctx, set.getDisks(), bucket, object, "", false)
+	latest, err = getLatestFileInfo(ctx, metas, errs)
+	if err != nil {
+		if errors.Is(err, errFileNotFound) || errors.Is(err, errFileVersionNotFound) {
+			return latest, false, ObjectNotFound{Bucket: bucket, Object: object}
+		}
+		return latest, false, toObjectErr(err, bucket, object)
+	}
+	changed = len(fivs.Versions) == 0 || latest.NumVersions != len(fivs.Versions) ||
+		latest.VersionID != fivs.Versions[0].VersionID || !latest.ModTime.Equal(fivs.Versions[0].ModTime)
+	return latest, changed, nil
+}
+
+// moveObjectVersions copies all versions of an object from the erasure set
+// 'set' to dstPool, oldest first so they keep their order, then removes the
+// object from 'set'. The number of bytes of data copied is returned. The
+// caller must hold the object lock.
+func moveObjectVersions(ctx context.Context, set *erasureObjects, dstPool *erasureSets, bucket string, fivs FileInfoVersions) (bytes int64, err error) {
+	object := fivs.Name
+	dstSet := dstPool.getHashedSet(object)
+
+	for i := len(fivs.Versions) - 1; i >= 0; i-- {
+		version := fivs.Versions[i]
+		if hasVersionSince(ctx, dstSet, bucket, object, version) {
+			// Moved by an interrupted run or overwritten since.
+			continue
+		}
+		switch {
+		case version.Deleted:
+			err = dstSet.deleteObjectVersion(ctx, bucket, object, len(dstSet.getDisks())/2+1, version, true)
+		case version.IsRemote():
+			err = dstSet.putRemoteVersion(ctx, bucket, object, version)
+		default:
+			err = decommissionVersion(ctx, set, dstPool, bucket, object, version)
+			bytes += version.Size
+		}
+		if err != nil {
+			return 0, toObjectErr(err, bucket, object, version.VersionID)
+		}
+	}
+
+	// All versions now live on the destination pool.
+	if err = set.deleteObject(ctx, bucket, object, len(set.getDisks())/2+1); err != nil {
+		return 0, err
+	}
+	NSUpdated(bucket, object)
+	return bytes, nil
+}
+
+// hasVersionSince returns true if the erasure set holds the version of the
+// object, written at the same time or later.
+func hasVersionSince(ctx context.Context, set *erasureObjects, bucket, object string, version FileInfo) bool {
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	metas, errs := readAllFileInfo(ctx, set.getDisks(), bucket, object, versionID, false)
+	fi, err := getLatestFileInfo(ctx, metas, errs)
+	return err == nil && !fi.ModTime.Before(version.ModTime)
+}
+
+// decommissionVersion copies a version holding data from the erasure set
+// 'set' to the pool dstPool. Multipart versions are copied part by part
+// to keep their part boundaries, which encryption and compression depend
+// on, as well as their ETag.
+func decommissionVersion(ctx context.Context, set *erasureObjects, dstPool *erasureSets, bucket, object string, version FileInfo) error {
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	fi, metaArr, onlineDisks, err := set.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID, NoLock: true}, true)
+	if err != nil {
+		return err
+	}
+
+	// readRange streams the stored bytes of the version in the range.
+	readRange := func(offset, length int64) io.ReadCloser {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(set.getObjectWithFileInfo(ctx, bucket, object, offset, length, pw, fi, metaArr, onlineDisks))
+		}()
+		return pr
+	}
+
+	opts := ObjectOptions{
+		VersionID:   fi.VersionID,
+		Versioned:   fi.VersionID != "",
+		MTime:       fi.ModTime,
+		UserDefined: cloneMSS(fi.Metadata),
+		NoLock:      true,
+	}
+
+	if len(fi.Parts) <= 1 {
+		actualSize, err := fi.ToObjectInfo(bucket, object).GetActualSize()
+		if err != nil {
+			return err
+		}
+		rd := readRange(0, fi.Size)
+		defer rd.Close()
+		hr, err := hash.NewReader(rd, fi.Size, "", "", actualSize)
+		if err != nil {
+			return err
+		}
+		_, err = dstPool.PutObject(ctx, bucket, object, NewPutObjReader(hr), opts)
+		return err
+	}
+
+	uploadID, err := dstPool.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			dstPool.AbortMultipartUpload(context.Background(), bucket, object, uploadID, ObjectOptions{NoLock: true})
+		}
+	}()
+
+	parts := make([]CompletePart, 0, len(fi.Parts))
+	var offset int64
+	for _, part := range fi.Parts {
+		rd := readRange(offset, part.Size)
+		var hr *hash.Reader
+		hr, err = hash.NewReader(rd, part.Size, "", "", part.ActualSize)
+		if err != nil {
+			rd.Close()
+			return err
+		}
+		var pi PartInfo
+		pi, err = dstPool.PutObjectPart(ctx, bucket, object, uploadID, part.Number, NewPutObjReader(hr), ObjectOptions{NoLock: true})
+		rd.Close()
+		if err != nil {
+			return err
+		}
+		parts = append(parts, CompletePart{PartNumber: part.Number, ETag: pi.ETag})
+		offset += part.Size
+	}
+
+	_, err = dstPool.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{
+		MTime:       fi.ModTime,
+		UserDefined: map[string]string{"etag": fi.Metadata["etag"]},
+		NoLock:      true,
+	})
+	return err
+}
+
+// putRemoteVersion writes a version whose data lives on a remote tier to
+// the erasure set, only its metadata is stored locally.
+func (er erasureObjects) putRemoteVersion(ctx context.Context, bucket, object string, fi FileInfo) error {
+	disks := er.getDisks()
+	parityDrives := er.defaultParityCount
+	dataDrives := len(disks) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum++
+	}
+
+	fi.Erasure = newFileInfo(pathJoin(bucket, object), dataDrives, parityDrives).Erasure
+	fi.Data = nil
+	metaArr := make([]FileInfo, len(disks))
+	for i := range metaArr {
+		metaArr[i] = fi
+	}
+	shuffledDisks, shuffledMeta := shuffleDisksAndPartsMetadata(disks, metaArr, FileInfo{Erasure: fi.Erasure})
+	_, err := writeUniqueFileInfo(ctx, shuffledDisks, bucket, object, shuffledMeta, writeQuorum)
+	return toObjectErr(err, bucket, object)
+}
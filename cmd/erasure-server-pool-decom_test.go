@@ -0,0 +1,174 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/minio/minio/internal/config/storageclass"
+)
+
+func TestDecommissionPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Objects use the default parity of the 4 disks sets.
+	defer func(sc storageclass.Config) { globalStorageClass = sc }(globalStorageClass)
+	globalStorageClass = storageclass.Config{}
+
+	pool0, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(pool0)
+	pool1, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(pool1)
+
+	endpoints := append(mustGetPoolEndpoints(pool0...), mustGetPoolEndpoints(pool1...)...)
+	objLayer, _, err := initObjectLayer(ctx, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+
+	if _, err = z.cancelDecommission(ctx, 0); !errors.Is(err, errDecommissionNotStarted) {
+		t.Fatalf("expected %v, got %v", errDecommissionNotStarted, err)
+	}
+	if _, err = z.lookupPool("2"); !errors.Is(err, errDecommissionInvalidPool) {
+		t.Fatalf("expected %v, got %v", errDecommissionInvalidPool, err)
+	}
+	if idx, err := z.lookupPool(z.getPoolCmdLines()[1]); err != nil || idx != 1 {
+		t.Fatalf("expected pool 1, got %d, %v", idx, err)
+	}
+
+	const bucket = "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	pool := z.getServerPools()[0]
+	put := func(object string, data []byte) ObjectInfo {
+		oi, err := pool.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+
+	// Two versions hidden by a delete marker.
+	v1 := put("versioned", []byte("version 1"))
+	v2 := put("versioned", []byte("version 2"))
+	if _, err = pool.DeleteObject(ctx, bucket, "versioned", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A multipart object.
+	uploadID, err := pool.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	partData := [][]byte{bytes.Repeat([]byte("a"), 5<<20), bytes.Repeat([]byte("b"), 1<<20)}
+	var parts []CompletePart
+	for i, data := range partData {
+		pi, err := pool.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: i + 1, ETag: pi.ETag})
+	}
+	mp, err := pool.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := z.startDecommission(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) || z.IsSuspended(1) {
+		t.Fatal("expected only pool 0 to be suspended")
+	}
+	if _, err = z.startDecommission(ctx, 1); !errors.Is(err, errDecommissionAlreadyRunning) {
+		t.Fatalf("expected %v, got %v", errDecommissionAlreadyRunning, err)
+	}
+	if _, err = z.startRebalance(ctx); !errors.Is(err, errRebalanceDecommissionRunning) {
+		t.Fatalf("expected %v, got %v", errRebalanceDecommissionRunning, err)
+	}
+	// New versions of objects held by the suspended pool go elsewhere.
+	if idx, err := z.getPoolIdx(ctx, bucket, "versioned", 1); err != nil || idx != 1 {
+		t.Fatalf("expected pool 1, got %d, %v", idx, err)
+	}
+
+	if err = z.decommission(ctx, &decommissionState{meta: m}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if m, err = loadDecommissionMeta(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if !m.removable() {
+		t.Fatalf("expected decommission to be complete, got %#v", m)
+	}
+	if b := m.Buckets[0]; b.Name != bucket || !b.Done || b.Objects != 2 || b.Versions != 4 || b.Failed != 0 {
+		t.Fatalf("unexpected bucket progress %#v", b)
+	}
+
+	for _, object := range []string{"versioned", "multipart"} {
+		if _, err = pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: nullVersionID}); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			t.Fatalf("expected %s to be removed from pool 0, got %v", object, err)
+		}
+	}
+
+	read := func(object, versionID string) (ObjectInfo, []byte) {
+		gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		data, err := ioutil.ReadAll(gr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gr.ObjInfo, data
+	}
+	if _, err = z.getServerPools()[1].GetObjectInfo(ctx, bucket, "versioned", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected the delete marker to be the latest version, got %v", err)
+	}
+	for _, v := range []ObjectInfo{v1, v2} {
+		oi, data := read("versioned", v.VersionID)
+		if oi.ETag != v.ETag || !oi.ModTime.Equal(v.ModTime) || int64(len(data)) != v.Size {
+			t.Fatalf("version %s not preserved, got %#v", v.VersionID, oi)
+		}
+	}
+	oi, data := read("multipart", "")
+	if oi.ETag != mp.ETag || oi.VersionID != mp.VersionID || !bytes.Equal(data, append(partData[0], partData[1]...)) {
+		t.Fatalf("multipart object not preserved, got %#v", oi)
+	}
+
+	if _, err = z.cancelDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if z.IsSuspended(0) {
+		t.Fatal("expected pool 0 to accept new objects again")
+	}
+}
@@ -0,0 +1,484 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config/storageclass"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/ellipses"
+)
+
+const (
+	poolLayoutMetaName = "pools.json"
+
+	// Time to wait for the drives of an added pool to be online and
+	// formatted.
+	addPoolFormatTimeout = time.Minute
+)
+
+var (
+	// error returned when the pool argument is not an ellipses pattern.
+	errPoolAddInvalidArg = AdminError{
+		Code:       "XMinioAdminPoolAddInvalidArg",
+		Message:    "The pool must be given as a single ellipses pattern, just like on the server command line",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the drives of an added pool are on this server.
+	errPoolAddLocalDrives = AdminError{
+		Code:       "XMinioAdminPoolAddLocalDrives",
+		Message:    "The drives of a pool added to running servers must be on new servers",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a pool is added while another one is pending.
+	errPoolAddPending = AdminError{
+		Code:       "XMinioAdminPoolAddPending",
+		Message:    "Another pool is being added, retry once it is added",
+		StatusCode: http.StatusConflict,
+	}
+)
+
+// globalAddedPools holds the pools added to the running server, or loaded
+// from the pool layout at startup, they are reported to the bootstrap check
+// of the servers of these pools.
+var (
+	globalAddedPoolsMu sync.RWMutex
+	globalAddedPools   EndpointServerPools
+)
+
+// currentEndpoints returns the endpoints of the server command line along
+// with the pools added since the server started.
+func currentEndpoints() EndpointServerPools {
+	globalAddedPoolsMu.RLock()
+	defer globalAddedPoolsMu.RUnlock()
+	if len(globalAddedPools) == 0 {
+		return globalEndpoints
+	}
+	endpoints := make(EndpointServerPools, 0, len(globalEndpoints)+len(globalAddedPools))
+	endpoints = append(endpoints, globalEndpoints...)
+	return append(endpoints, globalAddedPools...)
+}
+
+// poolLayoutMeta is the persisted list of the pools added to the running
+// servers, they are loaded at startup by servers which do not have them on
+// their command line.
+type poolLayoutMeta struct {
+	Pools []addedPoolMeta `json:"pools"`
+}
+
+// addedPoolMeta is a pool of the pool layout.
+type addedPoolMeta struct {
+	CmdLine      string    `json:"cmdLine"`
+	SetCount     int       `json:"setCount"`
+	DrivesPerSet int       `json:"drivesPerSet"`
+	AddedAt      time.Time `json:"addedAt"`
+}
+
+func (m poolLayoutMeta) contains(cmdLine string) bool {
+	for _, p := range m.Pools {
+		if p.CmdLine == cmdLine {
+			return true
+		}
+	}
+	return false
+}
+
+func loadPoolLayoutMeta(ctx context.Context, objAPI ObjectLayer) (*poolLayoutMeta, error) {
+	data, err := readConfig(ctx, objAPI, poolLayoutMetaName)
+	if err != nil {
+		return nil, err
+	}
+	m := &poolLayoutMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func savePoolLayoutMeta(ctx context.Context, objAPI ObjectLayer, m *poolLayoutMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, poolLayoutMetaName, data)
+}
+
+// pendingPool is a pool added to the running server which is not part of
+// the pool layout yet, it stays suspended until then.
+type pendingPool struct {
+	ep     PoolEndpoints
+	sets   *erasureSets
+	cancel context.CancelFunc
+}
+
+// newPoolEndpoints returns the endpoints of the pool given by arg, which
+// is an ellipses pattern like on the server command line.
+func newPoolEndpoints(arg string) (PoolEndpoints, error) {
+	if !ellipses.HasEllipses(arg) {
+		return PoolEndpoints{}, errPoolAddInvalidArg
+	}
+	setArgs, err := GetAllSets(arg)
+	if err != nil {
+		return PoolEndpoints{}, err
+	}
+	// The servers of the new pool are remote, this server already
+	// has local endpoints in the existing pools.
+	endpoints, setupType, err := CreateEndpoints(globalMinioAddr, true, setArgs...)
+	if err != nil {
+		return PoolEndpoints{}, err
+	}
+	if (setupType == DistErasureSetupType) != globalIsDistErasure {
+		return PoolEndpoints{}, errPoolAddInvalidArg
+	}
+	if globalIsDistErasure && endpoints.atleastOneEndpointLocal() {
+		return PoolEndpoints{}, errPoolAddLocalDrives
+	}
+	return PoolEndpoints{
+		SetCount:     len(setArgs),
+		DrivesPerSet: len(setArgs[0]),
+		Endpoints:    endpoints,
+		CmdLine:      arg,
+	}, nil
+}
+
+// expand adds the pool ep to all running servers and returns its index.
+// The pool is added suspended on this server, which formats its drives,
+// then on all peers. It is committed to the pool layout, which lifts the
+// suspension everywhere, only once all peers added it, otherwise it is
+// removed again.
+func (z *erasureServerPools) expand(ctx context.Context, ep PoolEndpoints) (int, error) {
+	idx, err := z.addPool(ctx, ep, true, true)
+	if err != nil {
+		return -1, err
+	}
+
+	if globalNotificationSys != nil {
+		var failed []string
+		for _, nerr := range globalNotificationSys.AddPool(ctx, ep.CmdLine) {
+			if nerr.Err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", nerr.Host.String(), nerr.Err))
+			}
+		}
+		if len(failed) > 0 {
+			z.rollbackPool(ctx, ep.CmdLine)
+			return -1, AdminError{
+				Code:       "XMinioAdminPoolAddFailed",
+				Message:    fmt.Sprintf("The pool was not added, some servers failed to add it: %s", strings.Join(failed, ", ")),
+				StatusCode: http.StatusServiceUnavailable,
+			}
+		}
+	}
+
+	if err = z.commitPool(ctx, ep); err != nil {
+		z.rollbackPool(ctx, ep.CmdLine)
+		return -1, err
+	}
+	return idx, nil
+}
+
+// commitPool adds the pool ep to the pool layout and lifts its suspension
+// on all servers. Servers which miss the reload keep the pool suspended
+// until their next reload of the pool metadata or restart.
+func (z *erasureServerPools) commitPool(ctx context.Context, ep PoolEndpoints) error {
+	m, err := loadPoolLayoutMeta(ctx, z)
+	if err != nil {
+		if !errors.Is(err, errConfigNotFound) {
+			return err
+		}
+		m = &poolLayoutMeta{}
+	}
+	if !m.contains(ep.CmdLine) {
+		m.Pools = append(m.Pools, addedPoolMeta{
+			CmdLine:      ep.CmdLine,
+			SetCount:     ep.SetCount,
+			DrivesPerSet: ep.DrivesPerSet,
+			AddedAt:      UTCNow(),
+		})
+		if err = savePoolLayoutMeta(ctx, z, m); err != nil {
+			return err
+		}
+	}
+
+	// The pool is part of the layout from now on, it is not rolled back.
+	logger.LogIf(ctx, z.reloadPoolMeta(ctx))
+	if globalNotificationSys != nil {
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+// rollbackPool removes the pending pool cmdLine from all servers.
+func (z *erasureServerPools) rollbackPool(ctx context.Context, cmdLine string) {
+	if globalNotificationSys != nil {
+		globalNotificationSys.RemovePendingPool(ctx, cmdLine)
+	}
+	z.removePendingPool(ctx, cmdLine)
+}
+
+// addPool adds the pool ep to the running server and returns its index.
+// The drives of the pool are formatted if firstDisk is set, otherwise they
+// are expected to be formatted by another server. A pending pool is kept
+// suspended until it is committed to the pool layout by reloadPoolMeta(),
+// only one pool can be pending at a time. Adding a pool which was already
+// added returns its index.
+func (z *erasureServerPools) addPool(ctx context.Context, ep PoolEndpoints, firstDisk, pending bool) (int, error) {
+	z.poolsMu.Lock()
+	defer z.poolsMu.Unlock()
+
+	if idx := z.poolIndex(ep.CmdLine); idx >= 0 {
+		return idx, nil
+	}
+	if len(z.pendingPools) > 0 {
+		return -1, errPoolAddPending
+	}
+	existing := currentEndpoints()
+	if err := existing.Add(ep); err != nil {
+		return -1, AdminError{
+			Code:       "XMinioAdminPoolAddInvalidArg",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	current := z.pools.Load().(*serverPoolsSnapshot)
+	first := current.serverPools[0]
+	parityDrives := first.defaultParityCount
+	if err := storageclass.ValidateParity(parityDrives, ep.DrivesPerSet); err != nil {
+		return -1, AdminError{
+			Code:       "XMinioAdminPoolAddInvalidArg",
+			Message:    fmt.Sprintf("All serverPools should have same parity ratio - expected %d: %v", parityDrives, err),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	idx := len(current.serverPools)
+	storageDisks, format, err := waitForPoolFormat(ctx, firstDisk, ep, idx+1,
+		first.format.ID, first.format.Erasure.DistributionAlgo)
+	if err != nil {
+		return -1, err
+	}
+	if format.ID != first.format.ID {
+		closeStorageDisks(storageDisks)
+		return -1, fmt.Errorf("All serverPools should have same deployment ID expected %s, got %s", first.format.ID, format.ID)
+	}
+
+	// The sets outlive the request adding them, they are stopped if the
+	// pool is not added in the end.
+	setsCtx, cancel := context.WithCancel(GlobalContext)
+	sets, err := newErasureSets(setsCtx, ep.Endpoints, storageDisks, format, parityDrives, idx)
+	if err != nil {
+		cancel()
+		closeStorageDisks(storageDisks)
+		return -1, err
+	}
+	abort := func() {
+		cancel()
+		logger.LogIf(ctx, sets.Shutdown(ctx))
+	}
+
+	// Objects are placed on the pool as soon as it is not suspended, it
+	// must hold the existing buckets by then.
+	buckets, err := first.ListBuckets(ctx)
+	if err != nil {
+		abort()
+		return -1, err
+	}
+	for _, bucket := range buckets {
+		if err = sets.MakeBucketWithLocation(ctx, bucket.Name, BucketOptions{}); err != nil {
+			if _, ok := err.(BucketExists); !ok {
+				abort()
+				return -1, err
+			}
+		}
+	}
+
+	// Everything indexed by pool is extended before the pool itself
+	// becomes visible, a pool missing from suspended is not suspended.
+	z.suspendedMu.Lock()
+	if len(z.suspended) == idx {
+		z.suspended = append(z.suspended, pending)
+	}
+	z.suspendedMu.Unlock()
+
+	// Readers hold on to the previous snapshot, build a new one.
+	next := &serverPoolsSnapshot{
+		serverPools: make([]*erasureSets, 0, idx+1),
+		cmdLines:    make([]string, 0, idx+1),
+	}
+	next.serverPools = append(append(next.serverPools, current.serverPools...), sets)
+	next.cmdLines = append(append(next.cmdLines, current.cmdLines...), ep.CmdLine)
+	z.pools.Store(next)
+
+	if pending {
+		if z.pendingPools == nil {
+			z.pendingPools = make(map[string]pendingPool)
+		}
+		z.pendingPools[ep.CmdLine] = pendingPool{ep: ep, sets: sets, cancel: cancel}
+		return idx, nil
+	}
+
+	globalAddedPoolsMu.Lock()
+	globalAddedPools = append(globalAddedPools, ep)
+	globalAddedPoolsMu.Unlock()
+
+	logger.Info("Added %s pool, %v set(s), %v drives per set.", ep.CmdLine, ep.SetCount, ep.DrivesPerSet)
+	return idx, nil
+}
+
+// commitPendingPools lifts the suspension of the pending pools which are
+// part of the pool layout m, z.poolsMu must be held.
+func (z *erasureServerPools) commitPendingPools(m *poolLayoutMeta) {
+	for cmdLine, p := range z.pendingPools {
+		if m == nil || !m.contains(cmdLine) {
+			continue
+		}
+		delete(z.pendingPools, cmdLine)
+
+		globalAddedPoolsMu.Lock()
+		globalAddedPools = append(globalAddedPools, p.ep)
+		globalAddedPoolsMu.Unlock()
+
+		logger.Info("Added %s pool, %v set(s), %v drives per set.", p.ep.CmdLine, p.ep.SetCount, p.ep.DrivesPerSet)
+
+		if z.poolIndex(cmdLine) == 1 {
+			// The workers moving objects between pools are only
+			// started on multi pool setups.
+			go z.runRebalance(GlobalContext)
+			go z.runDecommission(GlobalContext)
+		}
+	}
+}
+
+// removePendingPool removes the pool cmdLine if it is still pending, no
+// new objects were placed on it since it is suspended.
+func (z *erasureServerPools) removePendingPool(ctx context.Context, cmdLine string) {
+	z.poolsMu.Lock()
+	defer z.poolsMu.Unlock()
+
+	p, ok := z.pendingPools[cmdLine]
+	if !ok {
+		return
+	}
+	// Only one pool is pending at a time, it is the last one.
+	current := z.pools.Load().(*serverPoolsSnapshot)
+	idx := len(current.cmdLines) - 1
+	if current.cmdLines[idx] != cmdLine {
+		return
+	}
+	delete(z.pendingPools, cmdLine)
+
+	z.pools.Store(&serverPoolsSnapshot{
+		serverPools: current.serverPools[:idx:idx],
+		cmdLines:    current.cmdLines[:idx:idx],
+	})
+	z.suspendedMu.Lock()
+	if len(z.suspended) > idx {
+		z.suspended = z.suspended[:idx]
+	}
+	z.suspendedMu.Unlock()
+
+	p.cancel()
+	logger.LogIf(ctx, p.sets.Shutdown(ctx))
+	logger.Info("Removed %s pool which could not be added to all servers.", cmdLine)
+}
+
+// initAddedPools adds the pools of the pool layout which are not on the
+// server command line. Pools which were decommissioned are dropped from
+// the layout, they are no longer needed.
+func initAddedPools(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	m, err := loadPoolLayoutMeta(ctx, z)
+	if err != nil {
+		if !errors.Is(err, errConfigNotFound) {
+			logger.LogIf(ctx, fmt.Errorf("Unable to load the pool layout: %w", err))
+		}
+		return
+	}
+	dm, err := loadDecommissionMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	pools := m.Pools[:0:0]
+	for _, p := range m.Pools {
+		if dm != nil && dm.CmdLine == p.CmdLine && dm.removable() {
+			continue
+		}
+		pools = append(pools, p)
+		if z.poolIndex(p.CmdLine) >= 0 {
+			continue
+		}
+		ep, err := newPoolEndpoints(p.CmdLine)
+		if err == nil && (ep.SetCount != p.SetCount || ep.DrivesPerSet != p.DrivesPerSet) {
+			err = fmt.Errorf("expected %d set(s) of %d drives, got %d set(s) of %d drives",
+				p.SetCount, p.DrivesPerSet, ep.SetCount, ep.DrivesPerSet)
+		}
+		if err == nil {
+			_, err = z.addPool(ctx, ep, false, false)
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to add the pool %s of the pool layout: %w", p.CmdLine, err))
+		}
+	}
+	if len(pools) != len(m.Pools) {
+		m.Pools = pools
+		logger.LogIf(ctx, savePoolLayoutMeta(ctx, z, m))
+	}
+}
+
+// waitForPoolFormat connects to the drives of the pool ep and loads their
+// format, formatting them if firstDisk is set. It gives up after
+// addPoolFormatTimeout, unlike waitForFormatErasure used at startup.
+func waitForPoolFormat(ctx context.Context, firstDisk bool, ep PoolEndpoints, poolCount int, deploymentID, distributionAlgo string) ([]StorageAPI, *formatErasureV3, error) {
+	ctx, cancel := context.WithTimeout(ctx, addPoolFormatTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	var tries int
+	for {
+		storageDisks, format, err := connectLoadInitFormats(tries, firstDisk, ep.Endpoints, poolCount,
+			ep.SetCount, ep.DrivesPerSet, deploymentID, distributionAlgo)
+		switch err {
+		case nil:
+			return storageDisks, format, nil
+		case errNotFirstDisk, errFirstDiskWait, errErasureReadQuorum, errErasureWriteQuorum:
+			// Drives are not all online or not formatted yet.
+		default:
+			return nil, nil, err
+		}
+		tries++
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("drives of the pool %s are not ready: %w", ep.CmdLine, err)
+		case <-ticker.C:
+		}
+	}
+}
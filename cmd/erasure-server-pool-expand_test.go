@@ -0,0 +1,305 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pools [][]string
+	for i := 0; i < 3; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(disks)
+		pools = append(pools, disks)
+	}
+
+	endpoints := append(mustGetPoolEndpoints(pools[0]...), mustGetPoolEndpoints(pools[1]...)...)
+	objLayer, _, err := initObjectLayer(ctx, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+	defer func() {
+		globalAddedPools = nil
+	}()
+
+	if _, err = newPoolEndpoints(pools[2][0]); !errors.Is(err, errPoolAddInvalidArg) {
+		t.Fatalf("expected %v, got %v", errPoolAddInvalidArg, err)
+	}
+
+	const bucket, object = "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ep := mustGetPoolEndpoints(pools[2]...)[0]
+	ep.CmdLine = "pool2"
+	idx, err := z.addPool(ctx, ep, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx != 2 || len(z.getServerPools()) != 3 || z.poolIndex("pool2") != 2 || !z.IsSuspended(2) {
+		t.Fatalf("expected pending pool to be added at index 2, got %d", idx)
+	}
+	if n := len(currentEndpoints()); n != len(globalEndpoints) {
+		t.Fatalf("expected the pending pool not in the current endpoints, got %d pools", n)
+	}
+
+	// Adding the pool again is a no-op, adding another one while it is
+	// pending is not.
+	if idx, err = z.addPool(ctx, ep, false, true); err != nil || idx != 2 {
+		t.Fatalf("expected pool 2, got %d, %v", idx, err)
+	}
+	ep.CmdLine = "pool3"
+	if _, err = z.addPool(ctx, ep, true, true); !errors.Is(err, errPoolAddPending) {
+		t.Fatalf("expected %v, got %v", errPoolAddPending, err)
+	}
+
+	ep.CmdLine = "pool2"
+	if err = z.commitPool(ctx, ep); err != nil {
+		t.Fatal(err)
+	}
+	if z.IsSuspended(2) || len(z.pendingPools) != 0 {
+		t.Fatal("expected the committed pool not to be suspended")
+	}
+	if n := len(currentEndpoints()); n != len(globalEndpoints)+1 {
+		t.Fatalf("expected the added pool in the current endpoints, got %d pools", n)
+	}
+	m, err := loadPoolLayoutMeta(ctx, z)
+	if err != nil || !m.contains("pool2") {
+		t.Fatalf("expected the pool in the pool layout, got %v, %v", m, err)
+	}
+
+	// Reusing the drives of the pool is rejected.
+	ep.CmdLine = "pool3"
+	if _, err = z.addPool(ctx, ep, true, true); err == nil {
+		t.Fatal("expected duplicate drives to be rejected")
+	}
+
+	// Existing buckets are created on the added pool.
+	data := []byte("hello")
+	if _, err = z.getServerPools()[2].GetBucketInfo(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.getServerPools()[2].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("object content mismatch")
+	}
+}
+
+// TestAddPoolConcurrentTraffic adds a pool while objects are written, read
+// and listed, run it with -race.
+func TestAddPoolConcurrentTraffic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pools [][]string
+	for i := 0; i < 3; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(disks)
+		pools = append(pools, disks)
+	}
+
+	endpoints := append(mustGetPoolEndpoints(pools[0]...), mustGetPoolEndpoints(pools[1]...)...)
+	objLayer, _, err := initObjectLayer(ctx, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+	defer func() {
+		globalAddedPools = nil
+	}()
+
+	const bucket = "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		puts int64
+		done = make(chan struct{})
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				object := fmt.Sprintf("worker-%d/object-%d", w, i)
+				data := []byte(object)
+				if _, err := z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+					fail(fmt.Errorf("put %s: %w", object, err))
+					return
+				}
+				atomic.AddInt64(&puts, 1)
+				gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+				if err != nil {
+					fail(fmt.Errorf("get %s: %w", object, err))
+					return
+				}
+				got, err := ioutil.ReadAll(gr)
+				gr.Close()
+				if err != nil || !bytes.Equal(got, data) {
+					fail(fmt.Errorf("get %s: content mismatch %v", object, err))
+					return
+				}
+				if _, err = z.ListObjects(ctx, bucket, fmt.Sprintf("worker-%d/", w), "", "", 10); err != nil {
+					fail(fmt.Errorf("list: %w", err))
+					return
+				}
+				z.getServerPoolsAvailableSpace(ctx, bucket, object, int64(len(data)))
+			}
+		}(w)
+	}
+
+	ep := mustGetPoolEndpoints(pools[2]...)[0]
+	ep.CmdLine = "pool2"
+	if _, err = z.expand(ctx, ep); err != nil {
+		t.Fatal(err)
+	}
+	// Keep the traffic going on the three pools for a while.
+	added := atomic.LoadInt64(&puts)
+	for atomic.LoadInt64(&puts) < added+40 {
+		mu.Lock()
+		failed := len(errs) > 0
+		mu.Unlock()
+		if failed {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(done)
+	wg.Wait()
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if n := len(z.getServerPools()); n != 3 {
+		t.Fatalf("expected 3 pools, got %d", n)
+	}
+}
+
+func TestAddPoolRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pools [][]string
+	for i := 0; i < 3; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(disks)
+		pools = append(pools, disks)
+	}
+
+	endpoints := append(mustGetPoolEndpoints(pools[0]...), mustGetPoolEndpoints(pools[1]...)...)
+	objLayer, _, err := initObjectLayer(ctx, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+	defer func() {
+		globalAddedPools = nil
+	}()
+
+	const bucket, object = "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ep := mustGetPoolEndpoints(pools[2]...)[0]
+	ep.CmdLine = "pool2"
+	if _, err = z.addPool(ctx, ep, true, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// New objects are not placed on the pending pool.
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("%s-%d", object, i)
+		data := []byte("hello")
+		if _, err = z.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if idx, err := z.getPoolIdxExisting(ctx, bucket, name); err != nil || idx == 2 {
+			t.Fatalf("expected %s not on the pending pool, got pool %d, %v", name, idx, err)
+		}
+	}
+
+	// A reload of the pool metadata keeps the pool pending.
+	if err = z.reloadPoolMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(2) {
+		t.Fatal("expected the pending pool to stay suspended")
+	}
+
+	z.rollbackPool(ctx, "pool2")
+	if len(z.getServerPools()) != 2 || z.poolIndex("pool2") >= 0 || len(z.pendingPools) != 0 {
+		t.Fatalf("expected the pending pool to be removed, got %d pools", len(z.getServerPools()))
+	}
+	if _, err = loadPoolLayoutMeta(ctx, z); !errors.Is(err, errConfigNotFound) {
+		t.Fatalf("expected no pool layout, got %v", err)
+	}
+
+	// The pool can be added again once rolled back.
+	if idx, err := z.addPool(ctx, ep, false, true); err != nil || idx != 2 {
+		t.Fatalf("expected pool 2, got %d, %v", idx, err)
+	}
+}
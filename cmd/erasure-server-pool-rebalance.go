@@ -0,0 +1,554 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	rebalanceMetaName = "rebalance.json"
+
+	// Time between two checks for a rebalance operation to run or resume.
+	rebalanceCheckInterval = time.Minute
+	// Time between two saves of the rebalance progress.
+	rebalanceSaveInterval = 30 * time.Second
+)
+
+var (
+	rebalanceLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// error returned when a rebalance is started while another one is running.
+	errRebalanceAlreadyRunning = AdminError{
+		Code:       "XMinioAdminRebalanceAlreadyRunning",
+		Message:    "A rebalance operation is already in progress",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when pausing or resuming without a running rebalance.
+	errRebalanceNotStarted = AdminError{
+		Code:       "XMinioAdminRebalanceNotStarted",
+		Message:    "No rebalance operation is in progress",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when rebalance is requested on a single pool setup.
+	errRebalanceSinglePool = AdminError{
+		Code:       "XMinioAdminRebalanceSinglePool",
+		Message:    "Rebalance requires more than one server pool",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// RebalancePoolProgress is the progress of moving objects out of a pool.
+type RebalancePoolProgress struct {
+	Pool int `json:"pool"`
+
+	// Participating is true when objects are being moved out of
+	// this pool, i.e its usage was above the cluster average.
+	Participating bool    `json:"participating"`
+	InitUsedRatio float64 `json:"initUsedRatio"`
+	UsedRatio     float64 `json:"usedRatio"`
+
+	Bucket      string   `json:"bucket,omitempty"` // bucket currently being rebalanced
+	BucketsDone []string `json:"bucketsDone,omitempty"`
+
+	Objects uint64 `json:"objects"`
+	Bytes   uint64 `json:"bytes"`
+	Skipped uint64 `json:"skipped"`
+	Failed  uint64 `json:"failed"`
+
+	Complete bool `json:"complete"`
+}
+
+// rebalanceMeta is the persisted state of a rebalance operation.
+type rebalanceMeta struct {
+	ID          string                  `json:"id"`
+	StartedAt   time.Time               `json:"startedAt"`
+	CompletedAt time.Time               `json:"completedAt,omitempty"`
+	TargetRatio float64                 `json:"targetRatio"` // cluster wide used ratio to converge to
+	Paused      bool                    `json:"paused"`
+	Pools       []RebalancePoolProgress `json:"pools"`
+}
+
+func (m rebalanceMeta) complete() bool {
+	return !m.CompletedAt.IsZero()
+}
+
+func loadRebalanceMeta(ctx context.Context, objAPI ObjectLayer) (*rebalanceMeta, error) {
+	data, err := readConfig(ctx, objAPI, rebalanceMetaName)
+	if err != nil {
+		return nil, err
+	}
+	m := &rebalanceMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func saveRebalanceMeta(ctx context.Context, objAPI ObjectLayer, m *rebalanceMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, rebalanceMetaName, data)
+}
+
+// poolUsedRatios returns the fraction of used capacity of each pool.
+func (z *erasureServerPools) poolUsedRatios(ctx context.Context) []float64 {
+	pools := z.getServerPools()
+	ratios := make([]float64, len(pools))
+	for i, pool := range pools {
+		info, _ := pool.StorageInfo(ctx)
+		var total, used uint64
+		for _, disk := range info.Disks {
+			total += disk.TotalSpace
+			used += disk.UsedSpace
+		}
+		if total > 0 {
+			ratios[i] = float64(used) / float64(total)
+		}
+	}
+	return ratios
+}
+
+// startRebalance initializes a new rebalance operation, pools with usage
+// above the cluster average are marked as participating.
+func (z *erasureServerPools) startRebalance(ctx context.Context) (*rebalanceMeta, error) {
+	if z.SinglePool() {
+		return nil, errRebalanceSinglePool
+	}
+
+	m, err := loadRebalanceMeta(ctx, z)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	if m != nil && !m.complete() {
+		return nil, errRebalanceAlreadyRunning
+	}
+	if active, err := z.decommissionActive(ctx); err != nil {
+		return nil, err
+	} else if active {
+		return nil, errRebalanceDecommissionRunning
+	}
+
+	ratios := z.poolUsedRatios(ctx)
+	var target float64
+	for _, ratio := range ratios {
+		target += ratio
+	}
+	target /= float64(len(ratios))
+
+	m = &rebalanceMeta{
+		ID:          mustGetUUID(),
+		StartedAt:   UTCNow(),
+		TargetRatio: target,
+		Pools:       make([]RebalancePoolProgress, len(ratios)),
+	}
+	for i, ratio := range ratios {
+		m.Pools[i] = RebalancePoolProgress{
+			Pool:          i,
+			Participating: ratio > target,
+			InitUsedRatio: ratio,
+			UsedRatio:     ratio,
+			Complete:      ratio <= target,
+		}
+	}
+	if err = saveRebalanceMeta(ctx, z, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// setRebalancePaused pauses or resumes the current rebalance operation.
+func (z *erasureServerPools) setRebalancePaused(ctx context.Context, paused bool) (*rebalanceMeta, error) {
+	m, err := loadRebalanceMeta(ctx, z)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errRebalanceNotStarted
+		}
+		return nil, err
+	}
+	if m.complete() {
+		return nil, errRebalanceNotStarted
+	}
+	if !paused {
+		if active, err := z.decommissionActive(ctx); err != nil {
+			return nil, err
+		} else if active {
+			return nil, errRebalanceDecommissionRunning
+		}
+	}
+	m.Paused = paused
+	if err = saveRebalanceMeta(ctx, z, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// initRebalance will start the rebalance worker in the background.
+func initRebalance(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok || z.SinglePool() {
+		return
+	}
+	go z.runRebalance(ctx)
+}
+
+// runRebalance waits for a rebalance operation to be started, or resumed
+// and moves objects out of participating pools until they are balanced.
+// There should only ever be one rebalance worker running per cluster.
+func (z *erasureServerPools) runRebalance(ctx context.Context) {
+	// Make sure only 1 rebalance worker is running on the cluster.
+	locker := z.NewNSLock(minioMetaBucket, "runRebalance.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, rebalanceLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(rebalanceCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	checkTimer := time.NewTimer(rebalanceCheckInterval)
+	defer checkTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkTimer.C:
+			m, err := loadRebalanceMeta(ctx, z)
+			if err == nil && !m.complete() && !m.Paused {
+				rs := &rebalanceState{meta: m}
+				if err = z.rebalance(ctx, rs); err != nil && !errors.Is(err, errRebalancePaused) {
+					logger.LogIf(ctx, err)
+				}
+			} else if err != nil && !errors.Is(err, errConfigNotFound) {
+				logger.LogIf(ctx, err)
+			}
+			checkTimer.Reset(rebalanceCheckInterval)
+		}
+	}
+}
+
+var errRebalancePaused = errors.New("rebalance paused")
+
+// rebalanceState is the in-memory state of a running rebalance.
+type rebalanceState struct {
+	mu       sync.Mutex
+	meta     *rebalanceMeta
+	lastSave time.Time
+}
+
+// sync persists the in-memory progress, and picks up pause requests
+// written to the backend by the admin API on any node.
+func (rs *rebalanceState) sync(ctx context.Context, z *erasureServerPools, force bool) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if !force && time.Since(rs.lastSave) < rebalanceSaveInterval {
+		if rs.meta.Paused {
+			return errRebalancePaused
+		}
+		return nil
+	}
+
+	if stored, err := loadRebalanceMeta(ctx, z); err == nil {
+		if stored.ID != rs.meta.ID {
+			// A new rebalance replaced this one, give up.
+			return errRebalancePaused
+		}
+		rs.meta.Paused = stored.Paused
+	}
+
+	ratios := z.poolUsedRatios(ctx)
+	for i := len(rs.meta.Pools); i < len(ratios); i++ {
+		// Pools added since the rebalance started only receive objects.
+		rs.meta.Pools = append(rs.meta.Pools, RebalancePoolProgress{
+			Pool:          i,
+			InitUsedRatio: ratios[i],
+			Complete:      true,
+		})
+	}
+	for i := range rs.meta.Pools {
+		if i >= len(ratios) {
+			break
+		}
+		rs.meta.Pools[i].UsedRatio = ratios[i]
+		if rs.meta.Pools[i].Participating && ratios[i] <= rs.meta.TargetRatio {
+			rs.meta.Pools[i].Complete = true
+		}
+	}
+
+	rs.lastSave = time.Now()
+	if err := saveRebalanceMeta(ctx, z, rs.meta); err != nil {
+		return err
+	}
+	if rs.meta.Paused {
+		return errRebalancePaused
+	}
+	return nil
+}
+
+func (rs *rebalanceState) update(pool int, fn func(p *RebalancePoolProgress)) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if pool < len(rs.meta.Pools) {
+		fn(&rs.meta.Pools[pool])
+	}
+}
+
+func (rs *rebalanceState) poolComplete(pool int) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	// Pools added since the rebalance started do not participate.
+	return pool >= len(rs.meta.Pools) || rs.meta.Pools[pool].Complete
+}
+
+func (rs *rebalanceState) bucketDone(pool int, bucket string) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for _, b := range rs.meta.Pools[pool].BucketsDone {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// rebalance moves objects out of all participating pools, one bucket
+// at a time, until each pool reaches the target used ratio.
+func (z *erasureServerPools) rebalance(ctx context.Context, rs *rebalanceState) error {
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	for idx := range z.getServerPools() {
+		if rs.poolComplete(idx) {
+			continue
+		}
+		for _, bucket := range buckets {
+			if rs.bucketDone(idx, bucket.Name) {
+				continue
+			}
+			rs.update(idx, func(p *RebalancePoolProgress) {
+				p.Bucket = bucket.Name
+			})
+			if err = z.rebalanceBucket(ctx, rs, idx, bucket.Name); err != nil {
+				rs.sync(ctx, z, true)
+				return err
+			}
+			rs.update(idx, func(p *RebalancePoolProgress) {
+				p.Bucket = ""
+				p.BucketsDone = append(p.BucketsDone, bucket.Name)
+			})
+			if err = rs.sync(ctx, z, true); err != nil {
+				return err
+			}
+			if rs.poolComplete(idx) {
+				break
+			}
+		}
+		rs.update(idx, func(p *RebalancePoolProgress) {
+			p.Complete = true
+		})
+	}
+
+	rs.mu.Lock()
+	rs.meta.CompletedAt = UTCNow()
+	rs.mu.Unlock()
+	return rs.sync(ctx, z, true)
+}
+
+// rebalanceBucket walks all erasure sets of a pool and moves the
+// objects of bucket found there to other pools.
+func (z *erasureServerPools) rebalanceBucket(ctx context.Context, rs *rebalanceState, idx int, bucket string) error {
+	pool := z.getServerPools()[idx]
+
+	rs.mu.Lock()
+	participating := make([]bool, len(rs.meta.Pools))
+	for i, p := range rs.meta.Pools {
+		participating[i] = p.Participating
+	}
+	rs.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		stopErr error
+	)
+	stop := func(err error) {
+		errOnce.Do(func() {
+			stopErr = err
+			cancel()
+		})
+	}
+
+	for _, set := range pool.sets {
+		set := set
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			moveEntry := func(entry metaCacheEntry) {
+				if entry.isDir() {
+					return
+				}
+				if err := rs.sync(ctx, z, false); err != nil {
+					stop(err)
+					return
+				}
+				if rs.poolComplete(idx) {
+					stop(nil)
+					return
+				}
+				fivs, err := entry.fileInfoVersions(bucket)
+				var (
+					moved bool
+					size  int64
+				)
+				if err == nil {
+					moved, size, err = z.rebalanceObject(ctx, set, participating, bucket, fivs)
+				}
+				rs.update(idx, func(p *RebalancePoolProgress) {
+					switch {
+					case err != nil:
+						p.Failed++
+					case !moved:
+						p.Skipped++
+					default:
+						p.Objects++
+						p.Bytes += uint64(size)
+					}
+				})
+				if err != nil && ctx.Err() == nil {
+					logger.LogIf(ctx, err)
+				}
+			}
+
+			err := listPathRaw(ctx, listPathRawOptions{
+				disks:     disks,
+				bucket:    bucket,
+				recursive: true,
+				minDisks:  1,
+				agreed:    moveEntry,
+				partial:   resolvePartialEntries(bucket, moveEntry),
+			})
+			if err != nil && ctx.Err() == nil {
+				stop(err)
+			}
+		}()
+	}
+	wg.Wait()
+	return stopErr
+}
+
+// rebalanceObject moves all versions of an object listed as fivs from the
+// erasure set 'set' to a pool not participating in the rebalance, oldest
+// first. The object data is read back through the erasure decoder,
+// reconstructing missing shards just like healing. Objects which changed
+// since they were listed are skipped and reported as such, they are left
+// for the next rebalance run.
+func (z *erasureServerPools) rebalanceObject(ctx context.Context, set *erasureObjects, participating []bool, bucket string, fivs FileInfoVersions) (moved bool, size int64, err error) {
+	object := fivs.Name
+	// S3 writes lock through the pools, take the same lock to keep them out
+	// until the object is removed from the source pool.
+	lk := z.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return false, 0, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	// The listing is not done under the lock, make sure the object
+	// did not change in the meantime.
+	_, changed, err := listedVersionsChanged(ctx, set, bucket, fivs)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			// Object was removed in the meantime.
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+	if changed {
+		return false, 0, nil
+	}
+
+	for _, version := range fivs.Versions {
+		if !version.Deleted && !version.IsRemote() {
+			size += version.Size
+		}
+	}
+	dst := z.rebalanceTargetPool(ctx, participating, bucket, object, size)
+	if dst < 0 {
+		return false, 0, nil
+	}
+
+	size, err = moveObjectVersions(ctx, set, z.getServerPools()[dst], bucket, fivs)
+	if err != nil {
+		return false, 0, err
+	}
+	return true, size, nil
+}
+
+// rebalanceTargetPool returns the pool to move an object of the given size
+// to, pools are weighted by their available space. Participating pools
+// are never chosen as destination. -1 is returned if none has space.
+func (z *erasureServerPools) rebalanceTargetPool(ctx context.Context, participating []bool, bucket, object string, size int64) int {
+	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
+	for i := range serverPools {
+		if i < len(participating) && participating[i] {
+			serverPools[i].Available = 0
+		}
+	}
+	total := serverPools.TotalAvailable()
+	if total == 0 {
+		return -1
+	}
+	choose := rand.Uint64() % total
+	atTotal := uint64(0)
+	for _, pool := range serverPools {
+		atTotal += pool.Available
+		if atTotal > choose && pool.Available > 0 {
+			return pool.Index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+// listObjectVersions returns the versions of an object in an erasure set
+// the way a listing does.
+func listObjectVersions(t *testing.T, set *erasureObjects, bucket, object string) FileInfoVersions {
+	t.Helper()
+	buf, err := set.getDisks()[0].ReadAll(context.Background(), bucket, pathJoin(object, xlStorageFormatFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fivs, err := getFileInfoVersions(buf, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fivs
+}
+
+// newTwoPoolsObjectLayer returns an object layer made of two pools of four
+// disks each.
+func newTwoPoolsObjectLayer(ctx context.Context, t *testing.T) *erasureServerPools {
+	t.Helper()
+	pool0, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeRoots(pool0) })
+	pool1, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { removeRoots(pool1) })
+
+	endpoints := append(mustGetPoolEndpoints(pool0...), mustGetPoolEndpoints(pool1...)...)
+	objLayer, _, err := initObjectLayer(ctx, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return objLayer.(*erasureServerPools)
+}
+
+func TestRebalanceObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := newTwoPoolsObjectLayer(ctx, t)
+	var err error
+
+	if _, err = z.setRebalancePaused(ctx, true); !errors.Is(err, errRebalanceNotStarted) {
+		t.Fatalf("expected %v, got %v", errRebalanceNotStarted, err)
+	}
+
+	const bucket, object = "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	_, err = z.getServerPools()[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := z.getServerPools()[0].getHashedSet(object)
+	moved, size, err := z.rebalanceObject(ctx, set, []bool{true, false}, bucket, listObjectVersions(t, set, bucket, object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !moved || size != int64(len(data)) {
+		t.Fatalf("expected object of size %d to be moved, got moved=%v size=%d", len(data), moved, size)
+	}
+
+	if _, err = z.getServerPools()[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected object to be removed from pool 0, got %v", err)
+	}
+
+	gr, err := z.getServerPools()[1].GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("moved object content mismatch")
+	}
+
+	if _, err = z.startRebalance(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.startRebalance(ctx); !errors.Is(err, errRebalanceAlreadyRunning) {
+		t.Fatalf("expected %v, got %v", errRebalanceAlreadyRunning, err)
+	}
+	m, err := z.setRebalancePaused(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Paused {
+		t.Fatal("expected rebalance to be paused")
+	}
+}
+
+func TestRebalanceObjectConcurrentOverwrite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := newTwoPoolsObjectLayer(ctx, t)
+
+	const bucket, object = "bucket", "object"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1<<10)
+	_, err := z.getServerPools()[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := z.getServerPools()[0].getHashedSet(object)
+	listed := listObjectVersions(t, set, bucket, object)
+
+	// Overwritten through S3 between the listing and the move.
+	overwrite := bytes.Repeat([]byte("b"), 1<<10)
+	_, err = z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(overwrite), int64(len(overwrite)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved, _, err := z.rebalanceObject(ctx, set, []bool{true, false}, bucket, listed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved {
+		t.Fatal("expected the overwritten object to be skipped")
+	}
+
+	gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, overwrite) {
+		t.Fatal("overwritten object content was lost")
+	}
+}
+
+func TestRebalanceVersionedObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := newTwoPoolsObjectLayer(ctx, t)
+
+	const bucket, object = "bucket", "object"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var versions []ObjectInfo
+	for _, c := range []byte("abc") {
+		data := bytes.Repeat([]byte{c}, 1<<10)
+		oi, err := z.getServerPools()[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, oi)
+	}
+	marker, err := z.getServerPools()[0].DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := z.getServerPools()[0].getHashedSet(object)
+	moved, size, err := z.rebalanceObject(ctx, set, []bool{true, false}, bucket, listObjectVersions(t, set, bucket, object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !moved || size != 3<<10 {
+		t.Fatalf("expected 3 versions to be moved, got moved=%v size=%d", moved, size)
+	}
+
+	if _, err = z.getServerPools()[0].getHashedSet(object).getDisks()[0].ReadAll(ctx, bucket, pathJoin(object, xlStorageFormatFile)); err == nil {
+		t.Fatal("expected object to be removed from pool 0")
+	}
+
+	fivs := listObjectVersions(t, z.getServerPools()[1].getHashedSet(object), bucket, object)
+	if len(fivs.Versions) != 4 {
+		t.Fatalf("expected 4 versions on pool 1, got %d", len(fivs.Versions))
+	}
+	if !fivs.Versions[0].Deleted || fivs.Versions[0].VersionID != marker.VersionID {
+		t.Fatalf("expected the delete marker to remain the latest version, got %+v", fivs.Versions[0])
+	}
+	for i, oi := range versions {
+		// Versions are listed newest first.
+		fi := fivs.Versions[len(versions)-i]
+		if fi.VersionID != oi.VersionID || !fi.ModTime.Equal(oi.ModTime) {
+			t.Fatalf("version %d: expected %s, got %s", i, oi.VersionID, fi.VersionID)
+		}
+		gr, err := z.getServerPools()[1].GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: oi.VersionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, bytes.Repeat([]byte{"abc"[i]}, 1<<10)) {
+			t.Fatalf("version %d content mismatch", i)
+		}
+	}
+}
@@ -27,7 +27,9 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/madmin-go"
@@ -42,14 +44,68 @@ import (
 type erasureServerPools struct {
 	GatewayUnsupported
 
-	serverPools []*erasureSets
+	// poolsMu serializes the addition of pools while running. The pools
+	// are published as an immutable *serverPoolsSnapshot which is only
+	// ever replaced by a larger one, or a smaller one when a pending pool
+	// is rolled back. Readers load it once per operation through
+	// getServerPools() and never see it change under their feet.
+	poolsMu sync.Mutex
+	pools   atomic.Value
+
+	// pendingPools are the pools added while running which are not part
+	// of the pool layout yet, guarded by poolsMu.
+	pendingPools map[string]pendingPool
+
+	// suspended is set for the pools being decommissioned, or pending,
+	// no new objects are written to them.
+	suspendedMu sync.RWMutex
+	suspended   []bool
 
 	// Shut down async operations
 	shutdown context.CancelFunc
 }
 
+// serverPoolsSnapshot is the immutable list of the pools of the setup.
+type serverPoolsSnapshot struct {
+	serverPools []*erasureSets
+	// cmdLines identifies each pool across restarts.
+	cmdLines []string
+}
+
+// getServerPools returns the current pools, pools added later are not
+// part of the returned slice.
+func (z *erasureServerPools) getServerPools() []*erasureSets {
+	return z.pools.Load().(*serverPoolsSnapshot).serverPools
+}
+
+// getPoolCmdLines returns the command lines of the current pools, in
+// the order of getServerPools().
+func (z *erasureServerPools) getPoolCmdLines() []string {
+	return z.pools.Load().(*serverPoolsSnapshot).cmdLines
+}
+
 func (z *erasureServerPools) SinglePool() bool {
-	return len(z.serverPools) == 1
+	return len(z.getServerPools()) == 1
+}
+
+// IsSuspended returns true if the pool idx is being decommissioned, or
+// is pending.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.suspendedMu.RLock()
+	defer z.suspendedMu.RUnlock()
+	return idx >= 0 && idx < len(z.suspended) && z.suspended[idx]
+}
+
+// anySuspended returns true if a pool is being decommissioned.
+func (z *erasureServerPools) anySuspended() bool {
+	z.suspendedMu.RLock()
+	defer z.suspendedMu.RUnlock()
+	for _, suspended := range z.suspended {
+		if suspended {
+			return true
+		}
+	}
+	return false
 }
 
 // Initialize new pool of erasure sets.
@@ -62,7 +118,11 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 
 		formats      = make([]*formatErasureV3, len(endpointServerPools))
 		storageDisks = make([][]StorageAPI, len(endpointServerPools))
-		z            = &erasureServerPools{serverPools: make([]*erasureSets, len(endpointServerPools))}
+		serverPools  = make([]*erasureSets, len(endpointServerPools))
+		poolCmdLines = make([]string, len(endpointServerPools))
+		z            = &erasureServerPools{
+			suspended: make([]bool, len(endpointServerPools)),
+		}
 	)
 
 	var localDrives []string
@@ -108,18 +168,23 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 			return nil, fmt.Errorf("All serverPools should have same deployment ID expected %s, got %s", deploymentID, formats[i].ID)
 		}
 
-		z.serverPools[i], err = newErasureSets(ctx, ep.Endpoints, storageDisks[i], formats[i], commonParityDrives, i)
+		serverPools[i], err = newErasureSets(ctx, ep.Endpoints, storageDisks[i], formats[i], commonParityDrives, i)
 		if err != nil {
 			return nil, err
 		}
+		poolCmdLines[i] = ep.CmdLine
+		if poolCmdLines[i] == "" {
+			poolCmdLines[i] = strings.Join(ep.Endpoints.GetAllStrings(), " ")
+		}
 	}
+	z.pools.Store(&serverPoolsSnapshot{serverPools: serverPools, cmdLines: poolCmdLines})
 	ctx, z.shutdown = context.WithCancel(ctx)
 	go intDataUpdateTracker.start(ctx, localDrives...)
 	return z, nil
 }
 
 func (z *erasureServerPools) NewNSLock(bucket string, objects ...string) RWLocker {
-	return z.serverPools[0].NewNSLock(bucket, objects...)
+	return z.getServerPools()[0].NewNSLock(bucket, objects...)
 }
 
 // GetDisksID will return disks by their ID.
@@ -129,7 +194,7 @@ func (z *erasureServerPools) GetDisksID(ids ...string) []StorageAPI {
 		idMap[id] = struct{}{}
 	}
 	res := make([]StorageAPI, 0, len(idMap))
-	for _, s := range z.serverPools {
+	for _, s := range z.getServerPools() {
 		s.erasureDisksMu.RLock()
 		defer s.erasureDisksMu.RUnlock()
 		for _, disks := range s.erasureDisks {
@@ -153,7 +218,7 @@ func (z *erasureServerPools) GetDisksID(ids ...string) []StorageAPI {
 // For now only direct file paths are supported.
 func (z *erasureServerPools) GetRawData(ctx context.Context, volume, file string, fn func(r io.Reader, host string, disk string, filename string, info StatInfo) error) error {
 	found := 0
-	for _, s := range z.serverPools {
+	for _, s := range z.getServerPools() {
 		for _, disks := range s.erasureDisks {
 			for i, disk := range disks {
 				if disk == OfflineDisk {
@@ -194,9 +259,10 @@ func (z *erasureServerPools) GetRawData(ctx context.Context, volume, file string
 }
 
 func (z *erasureServerPools) SetDriveCounts() []int {
-	setDriveCounts := make([]int, len(z.serverPools))
-	for i := range z.serverPools {
-		setDriveCounts[i] = z.serverPools[i].SetDriveCount()
+	pools := z.getServerPools()
+	setDriveCounts := make([]int, len(pools))
+	for i := range pools {
+		setDriveCounts[i] = pools[i].SetDriveCount()
 	}
 	return setDriveCounts
 }
@@ -243,15 +309,16 @@ func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, ob
 // If there is not enough space the pool will return 0 bytes available.
 // Negative sizes are seen as 0 bytes.
 func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, bucket, object string, size int64) serverPoolsAvailableSpace {
-	var serverPools = make(serverPoolsAvailableSpace, len(z.serverPools))
+	pools := z.getServerPools()
+	var serverPools = make(serverPoolsAvailableSpace, len(pools))
 
-	storageInfos := make([][]*DiskInfo, len(z.serverPools))
-	g := errgroup.WithNErrs(len(z.serverPools))
-	for index := range z.serverPools {
+	storageInfos := make([][]*DiskInfo, len(pools))
+	g := errgroup.WithNErrs(len(pools))
+	for index := range pools {
 		index := index
 		g.Go(func() error {
 			// Get the set where it would be placed.
-			storageInfos[index] = getDiskInfos(ctx, z.serverPools[index].getHashedSet(object).getDisks())
+			storageInfos[index] = getDiskInfos(ctx, pools[index].getHashedSet(object).getDisks())
 			return nil
 		}, index)
 	}
@@ -261,6 +328,10 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, b
 
 	for i, zinfo := range storageInfos {
 		var available uint64
+		if z.IsSuspended(i) {
+			serverPools[i] = poolAvailableSpace{Index: i}
+			continue
+		}
 		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(zinfo, size) {
 			serverPools[i] = poolAvailableSpace{Index: i}
 			continue
@@ -287,14 +358,15 @@ type poolObjInfo struct {
 }
 
 func (z *erasureServerPools) getPoolIdxExistingWithOpts(ctx context.Context, bucket, object string, opts ObjectOptions) (idx int, err error) {
+	pools := z.getServerPools()
 	if z.SinglePool() {
 		return 0, nil
 	}
 
-	poolObjInfos := make([]poolObjInfo, len(z.serverPools))
+	poolObjInfos := make([]poolObjInfo, len(pools))
 
 	var wg sync.WaitGroup
-	for i, pool := range z.serverPools {
+	for i, pool := range pools {
 		wg.Add(1)
 		go func(i int, pool *erasureSets) {
 			defer wg.Done()
@@ -302,6 +374,11 @@ func (z *erasureServerPools) getPoolIdxExistingWithOpts(ctx context.Context, buc
 			pinfo := poolObjInfo{
 				PoolIndex: i,
 			}
+			if opts.SkipDecommissioned && z.IsSuspended(i) {
+				pinfo.Err = toObjectErr(errFileNotFound, bucket, object)
+				poolObjInfos[i] = pinfo
+				return
+			}
 			pinfo.ObjInfo, pinfo.Err = pool.GetObjectInfo(ctx, bucket, object, opts)
 			poolObjInfos[i] = pinfo
 		}(i, pool)
@@ -339,6 +416,116 @@ func (z *erasureServerPools) getPoolIdxExistingWithOpts(ctx context.Context, buc
 	return -1, toObjectErr(errFileNotFound, bucket, object)
 }
 
+// getPoolsObjectFileInfos reads a version of many objects on all pools,
+// the one in versionIDs when not nil, the latest otherwise, with a single
+// call per disk of each pool for each batch of objects. The results are
+// indexed by pool, then by object.
+func (z *erasureServerPools) getPoolsObjectFileInfos(ctx context.Context, bucket string, objects, versionIDs []string) ([][]FileInfo, [][]error) {
+	pools := z.getServerPools()
+	poolFis := make([][]FileInfo, len(pools))
+	poolErrs := make([][]error, len(pools))
+	var wg sync.WaitGroup
+	for i, pool := range pools {
+		wg.Add(1)
+		go func(i int, pool *erasureSets) {
+			defer wg.Done()
+			poolFis[i], poolErrs[i] = pool.getObjectFileInfos(ctx, bucket, objects, versionIDs)
+		}(i, pool)
+	}
+	wg.Wait()
+	return poolFis, poolErrs
+}
+
+// getPoolIdxsExistingNoLock returns the pool index of each of the objects,
+// as getPoolIdxExistingNoLock does, reading the metadata of the objects
+// with a single call per disk of each pool for each batch of objects.
+// Objects not found, or which could not be looked up, are at index -1
+// and their error is set in errs.
+func (z *erasureServerPools) getPoolIdxsExistingNoLock(ctx context.Context, bucket string, objects []ObjectToDelete, errs []error) []int {
+	names := make([]string, len(objects))
+	for i := range objects {
+		names[i] = objects[i].ObjectName
+	}
+	poolFis, poolErrs := z.getPoolsObjectFileInfos(ctx, bucket, names, nil)
+
+	idxs := make([]int, len(objects))
+	for j := range objects {
+		idxs[j] = -1
+		// Serve the latest object, a defensive choice to handle any
+		// duplicate content that may have been created.
+		var modTime time.Time
+		var err error
+		for i := range poolFis {
+			if perr := poolErrs[i][j]; perr != nil {
+				if !isErrObjectNotFound(perr) && err == nil {
+					err = perr
+				}
+				continue
+			}
+			if idxs[j] < 0 || poolFis[i][j].ModTime.After(modTime) {
+				idxs[j], modTime = i, poolFis[i][j].ModTime
+			}
+		}
+		switch {
+		case idxs[j] >= 0:
+		case err != nil:
+			errs[j] = err
+		default:
+			errs[j] = toObjectErr(errFileNotFound, bucket, objects[j].ObjectName)
+		}
+	}
+	return idxs
+}
+
+// getObjectInfos returns the ObjectInfo of many objects, as GetObjectInfo
+// does without locking the objects, the version of each object is the
+// one in its options. The metadata of the objects is read with a single
+// call per disk of each pool for each batch of objects.
+func (z *erasureServerPools) getObjectInfos(ctx context.Context, bucket string, objects []string, opts []ObjectOptions) ([]ObjectInfo, []error) {
+	objInfos := make([]ObjectInfo, len(objects))
+	errs := make([]error, len(objects))
+	names := make([]string, len(objects))
+	versionIDs := make([]string, len(objects))
+	for i, object := range objects {
+		errs[i] = checkGetObjArgs(ctx, bucket, object)
+		names[i] = encodeDirObject(object)
+		versionIDs[i] = opts[i].VersionID
+	}
+	poolFis, poolErrs := z.getPoolsObjectFileInfos(ctx, bucket, names, versionIDs)
+
+	for j, object := range objects {
+		if errs[j] != nil {
+			continue
+		}
+		// Serve the latest object, the lowest pool on a tie, as
+		// getLatestObjectInfoWithIdx does.
+		idx := -1
+		var err error
+		for i := range poolFis {
+			if perr := poolErrs[i][j]; perr != nil {
+				if !isErrObjectNotFound(perr) && !isErrVersionNotFound(perr) && err == nil {
+					err = perr
+				}
+				continue
+			}
+			if idx < 0 || poolFis[i][j].ModTime.After(poolFis[idx][j].ModTime) {
+				idx = i
+			}
+		}
+		switch {
+		case idx >= 0:
+			objInfos[j], errs[j] = fileInfoToObjectInfo(poolFis[idx][j], bucket, names[j], opts[j])
+		case err != nil:
+			errs[j] = err
+		case opts[j].VersionID != "":
+			errs[j] = VersionNotFound{Bucket: bucket, Object: object, VersionID: opts[j].VersionID}
+		default:
+			errs[j] = ObjectNotFound{Bucket: bucket, Object: object}
+		}
+	}
+	return objInfos, errs
+}
+
 func (z *erasureServerPools) getPoolIdxExistingNoLock(ctx context.Context, bucket, object string) (idx int, err error) {
 	return z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{NoLock: true})
 }
@@ -358,7 +545,9 @@ func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, objec
 		return idx, err
 	}
 
-	if isErrObjectNotFound(err) {
+	// New versions of objects held by a pool being decommissioned
+	// are written to the other pools.
+	if isErrObjectNotFound(err) || z.IsSuspended(idx) {
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
 			return -1, toObjectErr(errDiskFull)
@@ -376,7 +565,9 @@ func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object stri
 		return idx, err
 	}
 
-	if isErrObjectNotFound(err) {
+	// New versions of objects held by a pool being decommissioned
+	// are written to the other pools.
+	if isErrObjectNotFound(err) || z.IsSuspended(idx) {
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
 			return -1, toObjectErr(errDiskFull)
@@ -387,14 +578,15 @@ func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object stri
 }
 
 func (z *erasureServerPools) Shutdown(ctx context.Context) error {
+	pools := z.getServerPools()
 	defer z.shutdown()
 
-	g := errgroup.WithNErrs(len(z.serverPools))
+	g := errgroup.WithNErrs(len(pools))
 
-	for index := range z.serverPools {
+	for index := range pools {
 		index := index
 		g.Go(func() error {
-			return z.serverPools[index].Shutdown(ctx)
+			return pools[index].Shutdown(ctx)
 		}, index)
 	}
 
@@ -412,7 +604,7 @@ func (z *erasureServerPools) BackendInfo() (b madmin.BackendInfo) {
 
 	scParity := globalStorageClass.GetParityForSC(storageclass.STANDARD)
 	if scParity <= 0 {
-		scParity = z.serverPools[0].defaultParityCount
+		scParity = z.getServerPools()[0].defaultParityCount
 	}
 	rrSCParity := globalStorageClass.GetParityForSC(storageclass.RRS)
 
@@ -428,15 +620,16 @@ func (z *erasureServerPools) BackendInfo() (b madmin.BackendInfo) {
 }
 
 func (z *erasureServerPools) LocalStorageInfo(ctx context.Context) (StorageInfo, []error) {
+	pools := z.getServerPools()
 	var storageInfo StorageInfo
 
-	storageInfos := make([]StorageInfo, len(z.serverPools))
-	storageInfosErrs := make([][]error, len(z.serverPools))
-	g := errgroup.WithNErrs(len(z.serverPools))
-	for index := range z.serverPools {
+	storageInfos := make([]StorageInfo, len(pools))
+	storageInfosErrs := make([][]error, len(pools))
+	g := errgroup.WithNErrs(len(pools))
+	for index := range pools {
 		index := index
 		g.Go(func() error {
-			storageInfos[index], storageInfosErrs[index] = z.serverPools[index].LocalStorageInfo(ctx)
+			storageInfos[index], storageInfosErrs[index] = pools[index].LocalStorageInfo(ctx)
 			return nil
 		}, index)
 	}
@@ -450,22 +643,23 @@ func (z *erasureServerPools) LocalStorageInfo(ctx context.Context) (StorageInfo,
 	}
 
 	var errs []error
-	for i := range z.serverPools {
+	for i := range pools {
 		errs = append(errs, storageInfosErrs[i]...)
 	}
 	return storageInfo, errs
 }
 
 func (z *erasureServerPools) StorageInfo(ctx context.Context) (StorageInfo, []error) {
+	pools := z.getServerPools()
 	var storageInfo StorageInfo
 
-	storageInfos := make([]StorageInfo, len(z.serverPools))
-	storageInfosErrs := make([][]error, len(z.serverPools))
-	g := errgroup.WithNErrs(len(z.serverPools))
-	for index := range z.serverPools {
+	storageInfos := make([]StorageInfo, len(pools))
+	storageInfosErrs := make([][]error, len(pools))
+	g := errgroup.WithNErrs(len(pools))
+	for index := range pools {
 		index := index
 		g.Go(func() error {
-			storageInfos[index], storageInfosErrs[index] = z.serverPools[index].StorageInfo(ctx)
+			storageInfos[index], storageInfosErrs[index] = pools[index].StorageInfo(ctx)
 			return nil
 		}, index)
 	}
@@ -479,7 +673,7 @@ func (z *erasureServerPools) StorageInfo(ctx context.Context) (StorageInfo, []er
 	}
 
 	var errs []error
-	for i := range z.serverPools {
+	for i := range pools {
 		errs = append(errs, storageInfosErrs[i]...)
 	}
 	return storageInfo, errs
@@ -513,8 +707,9 @@ func (z *erasureServerPools) NSScanner(ctx context.Context, bf *bloomFilter, upd
 	})
 
 	// Collect for each set in serverPools.
-	for _, z := range z.serverPools {
+	for _, z := range z.getServerPools() {
 		for _, erObj := range z.sets {
+			globalScannerControl.addBuckets(len(allBuckets))
 			wg.Add(1)
 			results = append(results, dataUsageCache{})
 			go func(i int, erObj *erasureObjects) {
@@ -609,7 +804,8 @@ func (z *erasureServerPools) NSScanner(ctx context.Context, bf *bloomFilter, upd
 // even if one of the sets fail to create buckets, we proceed all the successful
 // operations.
 func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket string, opts BucketOptions) error {
-	g := errgroup.WithNErrs(len(z.serverPools))
+	pools := z.getServerPools()
+	g := errgroup.WithNErrs(len(pools))
 
 	// Lock the bucket name before creating.
 	lk := z.NewNSLock(minioMetaTmpBucket, bucket+".lck")
@@ -621,10 +817,10 @@ func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket
 	defer lk.Unlock(lkctx.Cancel)
 
 	// Create buckets in parallel across all sets.
-	for index := range z.serverPools {
+	for index := range pools {
 		index := index
 		g.Go(func() error {
-			return z.serverPools[index].MakeBucketWithLocation(ctx, bucket, opts)
+			return pools[index].MakeBucketWithLocation(ctx, bucket, opts)
 		}, index)
 	}
 
@@ -670,7 +866,7 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		return z.serverPools[0].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		return z.getServerPools()[0].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
 	}
 
 	var unlockOnDefer bool
@@ -728,21 +924,22 @@ func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object
 	}
 
 	lockType = noLock // do not take locks at lower levels for GetObjectNInfo()
-	return z.serverPools[zIdx].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+	return z.getServerPools()[zIdx].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
 }
 
 // getLatestObjectInfoWithIdx returns the objectInfo of the latest object from multiple pools (this function
 // is present in-case there were duplicate writes to both pools, this function also returns the
 // additional index where the latest object exists, that is used to start the GetObject stream.
 func (z *erasureServerPools) getLatestObjectInfoWithIdx(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, int, error) {
+	pools := z.getServerPools()
 	object = encodeDirObject(object)
 	results := make([]struct {
 		zIdx int
 		oi   ObjectInfo
 		err  error
-	}, len(z.serverPools))
+	}, len(pools))
 	var wg sync.WaitGroup
-	for i, pool := range z.serverPools {
+	for i, pool := range pools {
 		wg.Add(1)
 		go func(i int, pool *erasureSets) {
 			defer wg.Done()
@@ -792,7 +989,7 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		return z.serverPools[0].GetObjectInfo(ctx, bucket, object, opts)
+		return z.getServerPools()[0].GetObjectInfo(ctx, bucket, object, opts)
 	}
 
 	if !opts.NoLock {
@@ -814,18 +1011,22 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 
 // PutObject - writes an object to least used erasure pool.
 func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	pools := z.getServerPools()
 	// Validate put object input args.
 	if err := checkPutObjectArgs(ctx, bucket, object, z); err != nil {
 		return ObjectInfo{}, err
 	}
 
+	// Recorded on return, after the object is visible to listings.
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()), data.Size()) {
+		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, pools[0].getHashedSet(object).getDisks()), data.Size()) {
 			return ObjectInfo{}, toObjectErr(errDiskFull)
 		}
-		return z.serverPools[0].PutObject(ctx, bucket, object, data, opts)
+		return pools[0].PutObject(ctx, bucket, object, data, opts)
 	}
 	if !opts.NoLock {
 		ns := z.NewNSLock(bucket, object)
@@ -844,11 +1045,11 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 	}
 
 	// Overwrite the object at the right pool
-	return z.serverPools[idx].PutObject(ctx, bucket, object, data, opts)
+	return z.getServerPools()[idx].PutObject(ctx, bucket, object, data, opts)
 }
 
 func (z *erasureServerPools) deletePrefix(ctx context.Context, bucket string, prefix string) error {
-	for _, zone := range z.serverPools {
+	for _, zone := range z.getServerPools() {
 		_, err := zone.DeleteObject(ctx, bucket, prefix, ObjectOptions{DeletePrefix: true})
 		if err != nil {
 			return err
@@ -864,12 +1065,15 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 
 	if opts.DeletePrefix {
 		err := z.deletePrefix(ctx, bucket, object)
+		localMetacacheWrites.writtenAll(bucket)
 		return ObjectInfo{}, err
 	}
 
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].DeleteObject(ctx, bucket, object, opts)
+		return z.getServerPools()[0].DeleteObject(ctx, bucket, object, opts)
 	}
 
 	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
@@ -877,13 +1081,20 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 		return objInfo, err
 	}
 
-	return z.serverPools[idx].DeleteObject(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].DeleteObject(ctx, bucket, object, opts)
 }
 
 func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error) {
 	derrs := make([]error, len(objects))
 	dobjects := make([]DeletedObject, len(objects))
 	objSets := set.NewStringSet()
+	defer func() {
+		for _, obj := range dobjects {
+			if obj.ObjectName != "" {
+				localMetacacheWrites.written(bucket, obj.ObjectName)
+			}
+		}
+	}()
 	for i := range derrs {
 		objects[i].ObjectName = encodeDirObject(objects[i].ObjectName)
 
@@ -904,42 +1115,31 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 	defer multiDeleteLock.Unlock(lkctx.Cancel)
 
 	if z.SinglePool() {
-		deleteObjects, dErrs := z.serverPools[0].DeleteObjects(ctx, bucket, objects, opts)
+		deleteObjects, dErrs := z.getServerPools()[0].DeleteObjects(ctx, bucket, objects, opts)
 		for i := range deleteObjects {
 			deleteObjects[i].ObjectName = decodeDirObject(deleteObjects[i].ObjectName)
 		}
 		return deleteObjects, dErrs
 	}
 
-	// Fetch location of up to 10 objects concurrently.
+	// Fetch location of all objects with batched reads on all pools.
 	poolObjIdxMap := map[int][]ObjectToDelete{}
 	origIndexMap := map[int][]int{}
-
-	var mu sync.Mutex
-	eg := errgroup.WithNErrs(len(objects)).WithConcurrency(10)
-	for j, obj := range objects {
-		j := j
-		obj := obj
-		eg.Go(func() error {
-			idx, err := z.getPoolIdxExistingNoLock(ctx, bucket, obj.ObjectName)
-			if err != nil {
-				derrs[j] = err
-				return nil
-			}
-			mu.Lock()
-			poolObjIdxMap[idx] = append(poolObjIdxMap[idx], obj)
-			origIndexMap[idx] = append(origIndexMap[idx], j)
-			mu.Unlock()
-			return nil
-		}, j)
+	for j, idx := range z.getPoolIdxsExistingNoLock(ctx, bucket, objects, derrs) {
+		if idx < 0 {
+			continue
+		}
+		poolObjIdxMap[idx] = append(poolObjIdxMap[idx], objects[j])
+		origIndexMap[idx] = append(origIndexMap[idx], j)
 	}
 
-	eg.Wait() // wait to check all the pools.
+	var mu sync.Mutex
 
 	// Delete concurrently in all server pools.
 	var wg sync.WaitGroup
-	wg.Add(len(z.serverPools))
-	for idx, pool := range z.serverPools {
+	serverPools := z.getServerPools()
+	wg.Add(len(serverPools))
+	for idx, pool := range serverPools {
 		go func(idx int, pool *erasureSets) {
 			defer wg.Done()
 			objs := poolObjIdxMap[idx]
@@ -964,6 +1164,8 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 }
 
 func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	defer localMetacacheWrites.written(dstBucket, dstObject)
+
 	srcObject = encodeDirObject(srcObject)
 	dstObject = encodeDirObject(dstObject)
 
@@ -980,30 +1182,56 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		dstOpts.NoLock = true
 	}
 
+	// The preconditions are evaluated against the latest version
+	// of the destination in all pools, under the destination lock.
+	if dstOpts.CheckPrecondFn != nil {
+		oi, _, err := z.getLatestObjectInfoWithIdx(ctx, dstBucket, dstObject, ObjectOptions{NoLock: true})
+		if err != nil {
+			if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				return objInfo, err
+			}
+			oi = ObjectInfo{}
+		}
+		if dstOpts.CheckPrecondFn(oi) {
+			return objInfo, PreConditionFailed{}
+		}
+		dstOpts.CheckPrecondFn = nil
+	}
+
 	poolIdx, err := z.getPoolIdxNoLock(ctx, dstBucket, dstObject, srcInfo.Size)
 	if err != nil {
 		return objInfo, err
 	}
 
 	if cpSrcDstSame && srcInfo.metadataOnly {
-		// Version ID is set for the destination and source == destination version ID.
-		if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+		// Metadata is updated in place, also on a pool being
+		// decommissioned.
+		if z.anySuspended() {
+			if idx, err := z.getPoolIdxExistingNoLock(ctx, dstBucket, dstObject); err == nil && z.IsSuspended(idx) {
+				poolIdx = idx
+			}
+		}
+		if copyObjectInPlace(&srcInfo, srcOpts, dstOpts) {
+			return z.getServerPools()[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
-		// Destination is not versioned and source version ID is empty
-		// perform an in-place update.
-		if !dstOpts.Versioned && srcOpts.VersionID == "" {
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+	}
+
+	if !cpSrcDstSame && srcInfo.reuseData {
+		// The data of the source is stored as is, a new destination
+		// is placed in the pool of the source when it has room, so
+		// that the pool can copy the data without rewriting it.
+		srcIdx, err := z.getPoolIdxExistingWithOpts(ctx, srcBucket, srcObject, ObjectOptions{
+			VersionID: srcOpts.VersionID,
+			NoLock:    true,
+		})
+		if err == nil && srcIdx != poolIdx && !z.IsSuspended(srcIdx) {
+			if _, err = z.getPoolIdxExistingNoLock(ctx, dstBucket, dstObject); isErrObjectNotFound(err) &&
+				z.getServerPoolsAvailableSpace(ctx, dstBucket, dstObject, srcInfo.Size)[srcIdx].Available > 0 {
+				poolIdx = srcIdx
+			}
 		}
-		// Destination is versioned, source is not destination version,
-		// as a special case look for if the source object is not legacy
-		// from older format, for older format we will rewrite them as
-		// newer using PutObject() - this is an optimization to save space
-		if dstOpts.Versioned && srcOpts.VersionID != dstOpts.VersionID && !srcInfo.Legacy {
-			// CopyObject optimization where we don't create an entire copy
-			// of the content, instead we add a reference.
-			srcInfo.versionOnly = true
-			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+		if srcIdx == poolIdx {
+			return z.getServerPools()[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
 	}
 
@@ -1016,7 +1244,7 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		NoLock:               true,
 	}
 
-	return z.serverPools[poolIdx].PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
+	return z.getServerPools()[poolIdx].PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
 }
 
 func (z *erasureServerPools) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (ListObjectsV2Info, error) {
@@ -1068,7 +1296,7 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 		o.parseMarker()
 		merged.forwardPast(o.Marker)
 	}
-	objects := merged.fileInfoVersions(bucket, prefix, delimiter, versionMarker)
+	objects := merged.fileInfoVersions(bucket, prefix, delimiter, opts.Marker, versionMarker)
 	loi.IsTruncated = err == nil && len(objects) > 0
 	if maxKeys > 0 && len(objects) > maxKeys {
 		objects = objects[:maxKeys]
@@ -1084,7 +1312,14 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 	if loi.IsTruncated {
 		last := objects[len(objects)-1]
 		loi.NextMarker = opts.encodeMarker(last.Name)
-		loi.NextVersionIDMarker = last.VersionID
+		if !last.IsDir {
+			// The page may end in the middle of the versions of an
+			// object, before versions older than a null version.
+			loi.NextVersionIDMarker = last.VersionID
+			if loi.NextVersionIDMarker == "" {
+				loi.NextVersionIDMarker = nullVersionID
+			}
+		}
 	}
 	return loi, nil
 }
@@ -1162,12 +1397,13 @@ func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, ma
 }
 
 func (z *erasureServerPools) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (ListMultipartsInfo, error) {
+	pools := z.getServerPools()
 	if err := checkListMultipartArgs(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, z); err != nil {
 		return ListMultipartsInfo{}, err
 	}
 
 	if z.SinglePool() {
-		return z.serverPools[0].ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+		return pools[0].ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
 	}
 
 	var poolResult = ListMultipartsInfo{}
@@ -1175,7 +1411,7 @@ func (z *erasureServerPools) ListMultipartUploads(ctx context.Context, bucket, p
 	poolResult.KeyMarker = keyMarker
 	poolResult.Prefix = prefix
 	poolResult.Delimiter = delimiter
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		result, err := pool.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker,
 			delimiter, maxUploads)
 		if err != nil {
@@ -1193,13 +1429,13 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 	}
 
 	if z.SinglePool() {
-		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()), -1) {
+		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, z.getServerPools()[0].getHashedSet(object).getDisks()), -1) {
 			return "", toObjectErr(errDiskFull)
 		}
-		return z.serverPools[0].NewMultipartUpload(ctx, bucket, object, opts)
+		return z.getServerPools()[0].NewMultipartUpload(ctx, bucket, object, opts)
 	}
 
-	for idx, pool := range z.serverPools {
+	for _, pool := range z.getServerPools() {
 		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
 		if err != nil {
 			return "", err
@@ -1208,7 +1444,7 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 		// create the new multipart in the same pool, this will avoid
 		// creating two multiparts uploads in two different pools
 		if len(result.Uploads) != 0 {
-			return z.serverPools[idx].NewMultipartUpload(ctx, bucket, object, opts)
+			return pool.NewMultipartUpload(ctx, bucket, object, opts)
 		}
 	}
 
@@ -1219,7 +1455,7 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 		return "", err
 	}
 
-	return z.serverPools[idx].NewMultipartUpload(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].NewMultipartUpload(ctx, bucket, object, opts)
 }
 
 // Copies a part of an object from source hashedSet to destination hashedSet.
@@ -1234,15 +1470,16 @@ func (z *erasureServerPools) CopyObjectPart(ctx context.Context, srcBucket, srcO
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
 func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (PartInfo, error) {
+	pools := z.getServerPools()
 	if err := checkPutObjectPartArgs(ctx, bucket, object, z); err != nil {
 		return PartInfo{}, err
 	}
 
 	if z.SinglePool() {
-		return z.serverPools[0].PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+		return pools[0].PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 	}
 
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
 			return pool.PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
@@ -1264,14 +1501,15 @@ func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object,
 }
 
 func (z *erasureServerPools) GetMultipartInfo(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (MultipartInfo, error) {
+	pools := z.getServerPools()
 	if err := checkListPartsArgs(ctx, bucket, object, z); err != nil {
 		return MultipartInfo{}, err
 	}
 
 	if z.SinglePool() {
-		return z.serverPools[0].GetMultipartInfo(ctx, bucket, object, uploadID, opts)
+		return pools[0].GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 	}
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		mi, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
 			return mi, nil
@@ -1294,14 +1532,15 @@ func (z *erasureServerPools) GetMultipartInfo(ctx context.Context, bucket, objec
 
 // ListObjectParts - lists all uploaded parts to an object in hashedSet.
 func (z *erasureServerPools) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (ListPartsInfo, error) {
+	pools := z.getServerPools()
 	if err := checkListPartsArgs(ctx, bucket, object, z); err != nil {
 		return ListPartsInfo{}, err
 	}
 
 	if z.SinglePool() {
-		return z.serverPools[0].ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+		return pools[0].ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
 	}
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
 			return pool.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
@@ -1321,15 +1560,16 @@ func (z *erasureServerPools) ListObjectParts(ctx context.Context, bucket, object
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
 func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) error {
+	pools := z.getServerPools()
 	if err := checkAbortMultipartArgs(ctx, bucket, object, z); err != nil {
 		return err
 	}
 
 	if z.SinglePool() {
-		return z.serverPools[0].AbortMultipartUpload(ctx, bucket, object, uploadID, opts)
+		return pools[0].AbortMultipartUpload(ctx, bucket, object, uploadID, opts)
 	}
 
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
 			return pool.AbortMultipartUpload(ctx, bucket, object, uploadID, opts)
@@ -1350,15 +1590,18 @@ func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, o
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
 func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	pools := z.getServerPools()
 	if err = checkCompleteMultipartArgs(ctx, bucket, object, z); err != nil {
 		return objInfo, err
 	}
 
+	defer localMetacacheWrites.written(bucket, object)
+
 	if z.SinglePool() {
-		return z.serverPools[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+		return pools[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 		if err == nil {
 			return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
@@ -1374,8 +1617,9 @@ func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket
 
 // GetBucketInfo - returns bucket info from one of the erasure coded serverPools.
 func (z *erasureServerPools) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
+	pools := z.getServerPools()
 	if z.SinglePool() {
-		bucketInfo, err = z.serverPools[0].GetBucketInfo(ctx, bucket)
+		bucketInfo, err = pools[0].GetBucketInfo(ctx, bucket)
 		if err != nil {
 			return bucketInfo, err
 		}
@@ -1385,7 +1629,7 @@ func (z *erasureServerPools) GetBucketInfo(ctx context.Context, bucket string) (
 		}
 		return bucketInfo, nil
 	}
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		bucketInfo, err = pool.GetBucketInfo(ctx, bucket)
 		if err != nil {
 			if isErrBucketNotFound(err) {
@@ -1432,13 +1676,14 @@ func (z *erasureServerPools) IsTaggingSupported() bool {
 // even if one of the serverPools fail to delete buckets, we proceed to
 // undo a successful operation.
 func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, opts DeleteBucketOptions) error {
-	g := errgroup.WithNErrs(len(z.serverPools))
+	pools := z.getServerPools()
+	g := errgroup.WithNErrs(len(pools))
 
 	// Delete buckets in parallel across all serverPools.
-	for index := range z.serverPools {
+	for index := range pools {
 		index := index
 		g.Go(func() error {
-			return z.serverPools[index].DeleteBucket(ctx, bucket, opts)
+			return pools[index].DeleteBucket(ctx, bucket, opts)
 		}, index)
 	}
 
@@ -1449,7 +1694,7 @@ func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, op
 	for _, err := range errs {
 		if err != nil {
 			if !z.SinglePool() && !opts.NoRecreate {
-				undoDeleteBucketServerPools(context.Background(), bucket, z.serverPools, errs)
+				undoDeleteBucketServerPools(context.Background(), bucket, pools, errs)
 			}
 			return err
 		}
@@ -1468,7 +1713,7 @@ func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, op
 // data is not distributed across sets. Errors are logged but individual
 // disk failures are not returned.
 func (z *erasureServerPools) renameAll(ctx context.Context, bucket, prefix string) {
-	for _, servers := range z.serverPools {
+	for _, servers := range z.getServerPools() {
 		for _, set := range servers.sets {
 			set.renameAll(ctx, bucket, prefix)
 		}
@@ -1497,10 +1742,11 @@ func undoDeleteBucketServerPools(ctx context.Context, bucket string, serverPools
 // sort here just for simplification. As per design it is assumed
 // that all buckets are present on all serverPools.
 func (z *erasureServerPools) ListBuckets(ctx context.Context) (buckets []BucketInfo, err error) {
+	pools := z.getServerPools()
 	if z.SinglePool() {
-		buckets, err = z.serverPools[0].ListBuckets(ctx)
+		buckets, err = pools[0].ListBuckets(ctx)
 	} else {
-		for _, pool := range z.serverPools {
+		for _, pool := range pools {
 			buckets, err = pool.ListBuckets(ctx)
 			if err != nil {
 				logger.LogIf(ctx, err)
@@ -1522,6 +1768,7 @@ func (z *erasureServerPools) ListBuckets(ctx context.Context) (buckets []BucketI
 }
 
 func (z *erasureServerPools) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	pools := z.getServerPools()
 	// Acquire lock on format.json
 	formatLock := z.NewNSLock(minioMetaBucket, formatConfigFile)
 	lkctx, err := formatLock.GetLock(ctx, globalOperationTimeout)
@@ -1537,7 +1784,7 @@ func (z *erasureServerPools) HealFormat(ctx context.Context, dryRun bool) (madmi
 	}
 
 	var countNoHeal int
-	for _, pool := range z.serverPools {
+	for _, pool := range pools {
 		result, err := pool.HealFormat(ctx, dryRun)
 		if err != nil && !errors.Is(err, errNoHealRequired) {
 			logger.LogIf(ctx, err)
@@ -1555,7 +1802,7 @@ func (z *erasureServerPools) HealFormat(ctx context.Context, dryRun bool) (madmi
 	}
 
 	// No heal returned by all serverPools, return errNoHealRequired
-	if countNoHeal == len(z.serverPools) {
+	if countNoHeal == len(pools) {
 		return r, errNoHealRequired
 	}
 
@@ -1571,7 +1818,7 @@ func (z *erasureServerPools) HealBucket(ctx context.Context, bucket string, opts
 	// Attempt heal on the bucket metadata, ignore any failures
 	defer z.HealObject(ctx, minioMetaBucket, pathJoin(bucketConfigPrefix, bucket, bucketMetadataFile), "", opts)
 
-	for _, pool := range z.serverPools {
+	for _, pool := range z.getServerPools() {
 		result, err := pool.HealBucket(ctx, bucket, opts)
 		if err != nil {
 			switch err.(type) {
@@ -1606,7 +1853,7 @@ func (z *erasureServerPools) Walk(ctx context.Context, bucket, prefix string, re
 		defer cancel()
 		defer close(results)
 
-		for _, erasureSet := range z.serverPools {
+		for _, erasureSet := range z.getServerPools() {
 			var wg sync.WaitGroup
 			for _, set := range erasureSet.sets {
 				set := set
@@ -1686,14 +1933,33 @@ func (z *erasureServerPools) Walk(ctx context.Context, bucket, prefix string, re
 // HealObjectFn closure function heals the object.
 type HealObjectFn func(bucket, object, versionID string) error
 
-func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObject HealObjectFn) error {
+// HealTimeWindow restricts healing to the object versions modified
+// within it, e.g. to heal the objects written while a drive was offline
+// without rescanning older data. A zero bound leaves its side open.
+type HealTimeWindow struct {
+	After  time.Time
+	Before time.Time
+}
+
+// contains returns whether modTime falls within the window.
+func (w HealTimeWindow) contains(modTime time.Time) bool {
+	if !w.After.IsZero() && modTime.Before(w.After) {
+		return false
+	}
+	if !w.Before.IsZero() && !modTime.Before(w.Before) {
+		return false
+	}
+	return true
+}
+
+func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, window HealTimeWindow, healObject HealObjectFn) error {
 	errCh := make(chan error)
 	ctx, cancel := context.WithCancel(ctx)
 	go func() {
 		defer close(errCh)
 		defer cancel()
 
-		for _, erasureSet := range z.serverPools {
+		for _, erasureSet := range z.getServerPools() {
 			var wg sync.WaitGroup
 			for _, set := range erasureSet.sets {
 				set := set
@@ -1740,6 +2006,9 @@ func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix str
 						}
 
 						for _, version := range fivs.Versions {
+							if !window.contains(version.ModTime) {
+								continue
+							}
 							if err := healObject(bucket, version.Name, version.VersionID); err != nil {
 								cancel()
 								errCh <- err
@@ -1799,7 +2068,7 @@ func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix str
 func (z *erasureServerPools) HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
 	object = encodeDirObject(object)
 
-	for _, pool := range z.serverPools {
+	for _, pool := range z.getServerPools() {
 		result, err := pool.HealObject(ctx, bucket, object, versionID, opts)
 		result.Object = decodeDirObject(result.Object)
 		if err != nil {
@@ -1827,8 +2096,9 @@ func (z *erasureServerPools) GetMetrics(ctx context.Context) (*BackendMetrics, e
 }
 
 func (z *erasureServerPools) getPoolAndSet(id string) (poolIdx, setIdx, diskIdx int, err error) {
-	for poolIdx := range z.serverPools {
-		format := z.serverPools[poolIdx].format
+	pools := z.getServerPools()
+	for poolIdx := range pools {
+		format := pools[poolIdx].format
 		for setIdx, set := range format.Erasure.Sets {
 			for i, diskID := range set {
 				if diskID == id {
@@ -1857,9 +2127,10 @@ type HealthResult struct {
 
 // ReadHealth returns if the cluster can serve read requests
 func (z *erasureServerPools) ReadHealth(ctx context.Context) bool {
-	erasureSetUpCount := make([][]int, len(z.serverPools))
-	for i := range z.serverPools {
-		erasureSetUpCount[i] = make([]int, len(z.serverPools[i].sets))
+	pools := z.getServerPools()
+	erasureSetUpCount := make([][]int, len(pools))
+	for i := range pools {
+		erasureSetUpCount[i] = make([]int, len(pools[i].sets))
 	}
 
 	diskIDs := globalNotificationSys.GetLocalDiskIDs(ctx)
@@ -1894,9 +2165,10 @@ func (z *erasureServerPools) ReadHealth(ctx context.Context) bool {
 // can be used to query scenarios if health may be lost
 // if this node is taken down by an external orchestrator.
 func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) HealthResult {
-	erasureSetUpCount := make([][]int, len(z.serverPools))
-	for i := range z.serverPools {
-		erasureSetUpCount[i] = make([]int, len(z.serverPools[i].sets))
+	pools := z.getServerPools()
+	erasureSetUpCount := make([][]int, len(pools))
+	for i := range pools {
+		erasureSetUpCount[i] = make([]int, len(pools[i].sets))
 	}
 
 	diskIDs := globalNotificationSys.GetLocalDiskIDs(ctx)
@@ -1977,9 +2249,11 @@ func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) Hea
 
 // PutObjectMetadata - replace or add tags to an existing object
 func (z *erasureServerPools) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].PutObjectMetadata(ctx, bucket, object, opts)
+		return z.getServerPools()[0].PutObjectMetadata(ctx, bucket, object, opts)
 	}
 
 	// We don't know the size here set 1GiB atleast.
@@ -1988,14 +2262,16 @@ func (z *erasureServerPools) PutObjectMetadata(ctx context.Context, bucket, obje
 		return ObjectInfo{}, err
 	}
 
-	return z.serverPools[idx].PutObjectMetadata(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].PutObjectMetadata(ctx, bucket, object, opts)
 }
 
 // PutObjectTags - replace or add tags to an existing object
 func (z *erasureServerPools) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) (ObjectInfo, error) {
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].PutObjectTags(ctx, bucket, object, tags, opts)
+		return z.getServerPools()[0].PutObjectTags(ctx, bucket, object, tags, opts)
 	}
 
 	// We don't know the size here set 1GiB atleast.
@@ -2004,14 +2280,16 @@ func (z *erasureServerPools) PutObjectTags(ctx context.Context, bucket, object s
 		return ObjectInfo{}, err
 	}
 
-	return z.serverPools[idx].PutObjectTags(ctx, bucket, object, tags, opts)
+	return z.getServerPools()[idx].PutObjectTags(ctx, bucket, object, tags, opts)
 }
 
 // DeleteObjectTags - delete object tags from an existing object
 func (z *erasureServerPools) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].DeleteObjectTags(ctx, bucket, object, opts)
+		return z.getServerPools()[0].DeleteObjectTags(ctx, bucket, object, opts)
 	}
 
 	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
@@ -2019,14 +2297,14 @@ func (z *erasureServerPools) DeleteObjectTags(ctx context.Context, bucket, objec
 		return ObjectInfo{}, err
 	}
 
-	return z.serverPools[idx].DeleteObjectTags(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].DeleteObjectTags(ctx, bucket, object, opts)
 }
 
 // GetObjectTags - get object tags from an existing object
 func (z *erasureServerPools) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tags.Tags, error) {
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].GetObjectTags(ctx, bucket, object, opts)
+		return z.getServerPools()[0].GetObjectTags(ctx, bucket, object, opts)
 	}
 
 	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
@@ -2034,14 +2312,16 @@ func (z *erasureServerPools) GetObjectTags(ctx context.Context, bucket, object s
 		return nil, err
 	}
 
-	return z.serverPools[idx].GetObjectTags(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].GetObjectTags(ctx, bucket, object, opts)
 }
 
 // TransitionObject - transition object content to target tier.
 func (z *erasureServerPools) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].TransitionObject(ctx, bucket, object, opts)
+		return z.getServerPools()[0].TransitionObject(ctx, bucket, object, opts)
 	}
 
 	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
@@ -2049,14 +2329,16 @@ func (z *erasureServerPools) TransitionObject(ctx context.Context, bucket, objec
 		return err
 	}
 
-	return z.serverPools[idx].TransitionObject(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].TransitionObject(ctx, bucket, object, opts)
 }
 
 // RestoreTransitionedObject - restore transitioned object content locally on this cluster.
 func (z *erasureServerPools) RestoreTransitionedObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	defer localMetacacheWrites.written(bucket, object)
+
 	object = encodeDirObject(object)
 	if z.SinglePool() {
-		return z.serverPools[0].RestoreTransitionedObject(ctx, bucket, object, opts)
+		return z.getServerPools()[0].RestoreTransitionedObject(ctx, bucket, object, opts)
 	}
 
 	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
@@ -2064,5 +2346,5 @@ func (z *erasureServerPools) RestoreTransitionedObject(ctx context.Context, buck
 		return err
 	}
 
-	return z.serverPools[idx].RestoreTransitionedObject(ctx, bucket, object, opts)
+	return z.getServerPools()[idx].RestoreTransitionedObject(ctx, bucket, object, opts)
 }
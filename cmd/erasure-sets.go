@@ -972,15 +972,26 @@ func (s *erasureSets) DeleteObjects(ctx context.Context, bucket string, objects
 		objSetMap[index] = append(objSetMap[index], delObj{setIndex: index, origIndex: i, object: object})
 	}
 
-	// Invoke bulk delete on objects per set and save
-	// the result of the delete operation
+	// Invoke bulk delete on objects of all sets concurrently
+	// and save the result of the delete operation
+	var wg sync.WaitGroup
+	for _, objsGroup := range objSetMap {
+		wg.Add(1)
+		go func(objsGroup []delObj) {
+			defer wg.Done()
+			dobjects, errs := s.sets[objsGroup[0].setIndex].DeleteObjects(ctx, bucket, toNames(objsGroup), opts)
+			for i, obj := range objsGroup {
+				delErrs[obj.origIndex] = errs[i]
+				delObjects[obj.origIndex] = dobjects[i]
+			}
+		}(objsGroup)
+	}
+	wg.Wait()
+
 	for _, objsGroup := range objSetMap {
-		set := s.getHashedSet(objsGroup[0].object.ObjectName)
-		dobjects, errs := set.DeleteObjects(ctx, bucket, toNames(objsGroup), opts)
-		for i, obj := range objsGroup {
-			delErrs[obj.origIndex] = errs[i]
-			delObjects[obj.origIndex] = dobjects[i]
-			if errs[i] == nil {
+		set := s.sets[objsGroup[0].setIndex]
+		for _, obj := range objsGroup {
+			if delErrs[obj.origIndex] == nil {
 				auditObjectErasureSet(ctx, obj.object.ObjectName, set)
 			}
 		}
@@ -989,6 +1000,48 @@ func (s *erasureSets) DeleteObjects(ctx context.Context, bucket string, objects
 	return delObjects, delErrs
 }
 
+// getObjectFileInfos reads a version of many objects, the one in
+// versionIDs when not nil, the latest otherwise, the objects of all sets
+// are read concurrently, with a single call per disk for each batch of
+// objects.
+func (s *erasureSets) getObjectFileInfos(ctx context.Context, bucket string, objects, versionIDs []string) ([]FileInfo, []error) {
+	fis := make([]FileInfo, len(objects))
+	errs := make([]error, len(objects))
+
+	// Group objects by set index, keeping their original index.
+	objSetMap := make(map[int][]int)
+	for i, object := range objects {
+		index := s.getHashedSetIndex(object)
+		objSetMap[index] = append(objSetMap[index], i)
+	}
+
+	var wg sync.WaitGroup
+	for setIndex, origIndexes := range objSetMap {
+		wg.Add(1)
+		go func(setIndex int, origIndexes []int) {
+			defer wg.Done()
+			names := make([]string, len(origIndexes))
+			var setVersionIDs []string
+			if versionIDs != nil {
+				setVersionIDs = make([]string, len(origIndexes))
+			}
+			for i, origIndex := range origIndexes {
+				names[i] = objects[origIndex]
+				if versionIDs != nil {
+					setVersionIDs[i] = versionIDs[origIndex]
+				}
+			}
+			setFis, setErrs := s.sets[setIndex].getObjectFileInfos(ctx, bucket, names, setVersionIDs)
+			for i, origIndex := range origIndexes {
+				fis[origIndex], errs[origIndex] = setFis[i], setErrs[i]
+			}
+		}(setIndex, origIndexes)
+	}
+	wg.Wait()
+
+	return fis, errs
+}
+
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
 	srcSet := s.getHashedSet(srcObject)
@@ -998,24 +1051,16 @@ func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstB
 
 	cpSrcDstSame := srcSet == dstSet
 	// Check if this request is only metadata update.
-	if cpSrcDstSame && srcInfo.metadataOnly {
-		// Version ID is set for the destination and source == destination version ID.
-		// perform an in-place update.
-		if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
-			return srcSet.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
-		}
-		// Destination is not versioned and source version ID is empty
-		// perform an in-place update.
-		if !dstOpts.Versioned && srcOpts.VersionID == "" {
-			return srcSet.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
-		}
-		// CopyObject optimization where we don't create an entire copy
-		// of the content, instead we add a reference, we disallow legacy
-		// objects to be self referenced in this manner so make sure
-		// that we actually create a new dataDir for legacy objects.
-		if dstOpts.Versioned && srcOpts.VersionID != dstOpts.VersionID && !srcInfo.Legacy {
-			srcInfo.versionOnly = true
-			return srcSet.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+	if cpSrcDstSame && copyObjectInPlace(&srcInfo, srcOpts, dstOpts) {
+		return srcSet.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+	}
+
+	// The data of the source is stored as is, copy its shards within
+	// the erasure set instead of encoding it again.
+	if cpSrcDstSame && srcInfo.reuseData {
+		objInfo, err = srcSet.copyObjectData(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
+		if !errors.Is(err, errCopyDataUnsupported) {
+			return objInfo, err
 		}
 	}
 
@@ -1025,6 +1070,7 @@ func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstB
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
 		MTime:                dstOpts.MTime,
+		NoLock:               dstOpts.NoLock,
 	}
 
 	return dstSet.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
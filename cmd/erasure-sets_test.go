@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -231,3 +233,118 @@ func TestHashedLayer(t *testing.T) {
 		}
 	}
 }
+
+func TestErasureSetsGetObjectFileInfos(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasureSets32(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// More objects than fit in a single batch, spread over both sets.
+	var objects []ObjectToDelete
+	for i := 0; i < 2*readVersionsBatchSize+10; i++ {
+		objects = append(objects, ObjectToDelete{ObjectName: fmt.Sprintf("dir/obj-%d", i)})
+	}
+	for _, object := range objects[:len(objects)-10] {
+		if _, err = obj.PutObject(ctx, bucket, object.ObjectName, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The latest version of an object being a delete marker still locates it.
+	if _, err = obj.DeleteObject(ctx, bucket, objects[0].ObjectName, ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	z := obj.(*erasureServerPools)
+	errs := make([]error, len(objects))
+	idxs := z.getPoolIdxsExistingNoLock(ctx, bucket, objects, errs)
+	for i, object := range objects {
+		if i < len(objects)-10 {
+			if idxs[i] != 0 || errs[i] != nil {
+				t.Fatalf("%s: expected pool 0, got %d (%v)", object.ObjectName, idxs[i], errs[i])
+			}
+			continue
+		}
+		if idxs[i] != -1 || !isErrObjectNotFound(errs[i]) {
+			t.Fatalf("%s: expected object not found, got %d (%v)", object.ObjectName, idxs[i], errs[i])
+		}
+	}
+
+	names := make([]string, len(objects))
+	for i := range objects {
+		names[i] = objects[i].ObjectName
+	}
+	fis, _ := z.getServerPools()[0].getObjectFileInfos(ctx, bucket, names, nil)
+	if !fis[0].Deleted || fis[1].Deleted || fis[1].Size != 4 {
+		t.Fatalf("unexpected latest versions %v %v", fis[0], fis[1])
+	}
+}
+
+func TestErasureServerPoolsGetObjectInfos(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasureSets32(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object string) ObjectInfo {
+		oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	v1 := put("obj")
+	put("obj")
+	put("dir/")
+	put("deleted")
+	dm, err := obj.DeleteObject(ctx, bucket, "deleted", ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The batched lookup returns the same as a lookup of each object.
+	objects := []string{"obj", "obj", "dir/", "deleted", "deleted", "missing", "missing"}
+	opts := []ObjectOptions{
+		{},
+		{VersionID: v1.VersionID},
+		{},
+		{},
+		{VersionID: dm.VersionID},
+		{},
+		{VersionID: mustGetUUID()},
+	}
+	z := obj.(*erasureServerPools)
+	objInfos, errs := z.getObjectInfos(ctx, bucket, objects, opts)
+	for i, object := range objects {
+		oi, err := z.GetObjectInfo(ctx, bucket, object, opts[i])
+		if fmt.Sprintf("%T", err) != fmt.Sprintf("%T", errs[i]) {
+			t.Fatalf("case %d: expected error %v, got %v", i+1, err, errs[i])
+		}
+		if objInfos[i].Name != oi.Name || objInfos[i].VersionID != oi.VersionID || objInfos[i].DeleteMarker != oi.DeleteMarker {
+			t.Fatalf("case %d: expected %s/%s, got %s/%s", i+1, oi.Name, oi.VersionID, objInfos[i].Name, objInfos[i].VersionID)
+		}
+	}
+	if objInfos[1].VersionID != v1.VersionID {
+		t.Fatalf("expected version %s, got %s", v1.VersionID, objInfos[1].VersionID)
+	}
+}
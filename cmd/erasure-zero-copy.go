@@ -0,0 +1,320 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/logger"
+)
+
+// zeroCopyMaxParts is the largest number of parts a zero copy read spans,
+// the shard files of all of them are kept open for the whole read.
+const zeroCopyMaxParts = 16
+
+// zeroCopyReader reads an object straight from the files of its data
+// shards on local drives, without erasure decoding. The bitrot hash of
+// each block is verified before its data is sent, a shard which turns out
+// to be corrupt or unreadable hands the rest of the read over to decode,
+// which reconstructs it from parity.
+type zeroCopyReader struct {
+	ctx     context.Context
+	fi      FileInfo
+	erasure Erasure
+	// files of the data shards of the parts in range, by part and by
+	// shard index, opened before the read is chosen.
+	files     [][]*os.File
+	partIndex int
+	// decode writes the object range through the erasure decoder.
+	decode func(w io.Writer, offset, length int64) error
+
+	offset, length int64
+
+	once sync.Once
+	pr   *io.PipeReader
+}
+
+// localXLStorage returns the local drive behind disk, nil if it is remote.
+func localXLStorage(disk StorageAPI) *xlStorage {
+	if d, ok := disk.(*xlStorageDiskIDCheck); ok {
+		disk = d.storage
+	}
+	s, _ := disk.(*xlStorage)
+	return s
+}
+
+// newZeroCopyReader returns a reader of length bytes at offset of the
+// object, nil if the object is not stored as is or if any of the data
+// shards of the range is not a complete file on an online local drive.
+// decode is used for the part of the range which can't be read as is.
+func newZeroCopyReader(ctx context.Context, bucket, object string, fi FileInfo, oi ObjectInfo, metaArr []FileInfo, onlineDisks []StorageAPI, offset, length int64, decode func(w io.Writer, offset, length int64) error) *zeroCopyReader {
+	if !globalAPIConfig.isZeroCopyGetEnabled() {
+		return nil
+	}
+	// Inline data is already in memory.
+	if len(fi.Data) > 0 || fi.Size == 0 || offset < 0 || length <= 0 || offset+length > fi.Size {
+		return nil
+	}
+	if _, encrypted := crypto.IsEncrypted(oi.UserDefined); encrypted || oi.IsCompressed() {
+		return nil
+	}
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return nil
+	}
+	firstPart, _, err := fi.ObjectToPartOffset(ctx, offset)
+	if err != nil {
+		return nil
+	}
+	lastPart, _, err := fi.ObjectToPartOffset(ctx, offset+length-1)
+	if err != nil || lastPart-firstPart+1 > zeroCopyMaxParts {
+		return nil
+	}
+
+	disks := make([]*xlStorage, fi.Erasure.DataBlocks)
+	for i, disk := range onlineDisks {
+		if disk == nil || !disk.IsOnline() || !metaArr[i].IsValid() ||
+			metaArr[i].DataDir != fi.DataDir || !metaArr[i].ModTime.Equal(fi.ModTime) {
+			continue
+		}
+		shard := metaArr[i].Erasure.Index - 1
+		if shard < 0 || shard >= len(disks) {
+			continue
+		}
+		if s := localXLStorage(disk); s != nil {
+			disks[shard] = s
+		}
+	}
+	for _, disk := range disks {
+		if disk == nil {
+			return nil
+		}
+	}
+
+	z := &zeroCopyReader{
+		ctx:       ctx,
+		fi:        fi,
+		erasure:   erasure,
+		partIndex: firstPart,
+		decode:    decode,
+		offset:    offset,
+		length:    length,
+	}
+	for _, part := range fi.Parts[firstPart : lastPart+1] {
+		files, err := openZeroCopyShards(disks, bucket, object, fi, erasure, part)
+		z.files = append(z.files, files)
+		if err != nil {
+			// Missing or truncated, leave it to decode.
+			z.closeFiles()
+			return nil
+		}
+	}
+	return z
+}
+
+// openZeroCopyShards opens the files of the data shards of a part and
+// checks their size, they must all be complete and use streaming bitrot.
+func openZeroCopyShards(disks []*xlStorage, bucket, object string, fi FileInfo, erasure Erasure, part ObjectPartInfo) ([]*os.File, error) {
+	files := make([]*os.File, len(disks))
+	algo := fi.Erasure.GetChecksumInfo(part.Number).Algorithm
+	if algo != HighwayHash256S {
+		return files, errBitrotHashAlgoInvalid
+	}
+	size := bitrotShardFileSize(erasure.ShardFileSize(part.Size), erasure.ShardSize(), algo)
+	partPath := pathJoin(object, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
+	for i, disk := range disks {
+		f, err := disk.openDataFile(bucket, partPath)
+		if err != nil {
+			return files, err
+		}
+		files[i] = f
+		st, err := f.Stat()
+		if err != nil {
+			return files, err
+		}
+		if st.Size() != size {
+			return files, errFileCorrupt
+		}
+	}
+	return files, nil
+}
+
+// WriteTo writes the data to w.
+func (z *zeroCopyReader) WriteTo(w io.Writer) (written int64, err error) {
+	defer z.closeFiles()
+
+	_, partOffset, err := z.fi.ObjectToPartOffset(z.ctx, z.offset)
+	if err != nil {
+		return 0, err
+	}
+	remaining := z.length
+	for i := 0; remaining > 0 && i < len(z.files); i++ {
+		part := z.fi.Parts[z.partIndex+i]
+		length := part.Size - partOffset
+		if length > remaining {
+			length = remaining
+		}
+		n, err := z.writePart(w, part, z.files[i], partOffset, length)
+		written += n
+		remaining -= n
+		if err != nil {
+			var werr zeroCopyWriteErr
+			if errors.As(err, &werr) {
+				return written, werr.err
+			}
+			// Unreadable or corrupt shard, decode the rest of the range
+			// from all the shards.
+			logger.LogIf(z.ctx, fmt.Errorf("zero copy read of %s failed, decoding the rest: %w", z.fi.Name, err))
+			cw := &countWriter{Writer: w}
+			err = z.decode(cw, z.offset+written, remaining)
+			return written + cw.n, err
+		}
+		partOffset = 0
+	}
+	if remaining > 0 {
+		return written, errLessData
+	}
+	return written, nil
+}
+
+// zeroCopyWriteErr is an error writing to the destination of a read, as
+// opposed to reading the shards.
+type zeroCopyWriteErr struct {
+	err error
+}
+
+func (e zeroCopyWriteErr) Error() string {
+	return e.err.Error()
+}
+
+// countWriter counts the bytes written to Writer.
+type countWriter struct {
+	io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.Writer.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writePart writes length bytes at offset of a part from the files of its
+// data shards, a block at a time once its bitrot hashes are verified.
+func (z *zeroCopyReader) writePart(w io.Writer, part ObjectPartInfo, files []*os.File, offset, length int64) (written int64, err error) {
+	h := HighwayHash256S.New()
+	hashSize := int64(h.Size())
+	blockSize := z.erasure.blockSize
+	shardSize := z.erasure.ShardSize()
+	bufs := make([][]byte, len(files))
+	for length > 0 {
+		block := offset / blockSize
+		blockOffset := offset % blockSize
+		blockLength := part.Size - block*blockSize
+		if blockLength > blockSize {
+			blockLength = blockSize
+		}
+		n := blockLength - blockOffset
+		if n > length {
+			n = length
+		}
+
+		// The block is split evenly over the data shards, each prefixed
+		// with its hash.
+		blockShardSize := ceilFrac(blockLength, int64(z.erasure.dataBlocks))
+		shardOffset := block * (hashSize + shardSize)
+		first, last := blockOffset/blockShardSize, (blockOffset+n-1)/blockShardSize
+		for shard := first; shard <= last; shard++ {
+			if bufs[shard] == nil {
+				bufs[shard] = make([]byte, hashSize+shardSize)
+			}
+			buf := bufs[shard][:hashSize+blockShardSize]
+			if _, err = files[shard].ReadAt(buf, shardOffset); err != nil {
+				return written, err
+			}
+			h.Reset()
+			h.Write(buf[hashSize:])
+			if !bytes.Equal(h.Sum(nil), buf[:hashSize]) {
+				return written, errFileCorrupt
+			}
+		}
+
+		for off := blockOffset; off < blockOffset+n; {
+			shard := off / blockShardSize
+			start := off % blockShardSize
+			m := blockShardSize - start
+			if m > blockOffset+n-off {
+				m = blockOffset + n - off
+			}
+			c, err := w.Write(bufs[shard][hashSize+start : hashSize+start+m])
+			written += int64(c)
+			if err != nil {
+				return written, zeroCopyWriteErr{err}
+			}
+			off += m
+		}
+		offset += n
+		length -= n
+	}
+	return written, nil
+}
+
+// Read reads the data through a pipe.
+func (z *zeroCopyReader) Read(p []byte) (int, error) {
+	z.once.Do(func() {
+		pr, pw := io.Pipe()
+		z.pr = pr
+		go func() {
+			_, err := z.WriteTo(pw)
+			pw.CloseWithError(err)
+		}()
+	})
+	return z.pr.Read(p)
+}
+
+// Close stops any read in progress.
+func (z *zeroCopyReader) Close() error {
+	started := true
+	z.once.Do(func() { started = false })
+	if z.pr != nil {
+		z.pr.Close()
+	}
+	if !started {
+		z.closeFiles()
+	}
+	return nil
+}
+
+// closeFiles closes the shard files, once.
+func (z *zeroCopyReader) closeFiles() {
+	for _, files := range z.files {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+			}
+		}
+	}
+	z.files = nil
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetObjectZeroCopy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	setZeroCopy := func(enabled bool) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.zeroCopyGet = enabled
+		globalAPIConfig.mu.Unlock()
+	}
+	defer setZeroCopy(false)
+	setZeroCopy(true)
+
+	disks, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(disks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const bucket = "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Spans several erasure blocks, the last one split unevenly.
+	data := make([]byte, 5*blockSizeV2/2+7)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	if _, err = objLayer.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A multipart object, with parts of 5MiB and the rest of data.
+	multipart := append(bytes.Repeat([]byte("m"), 5<<20), data...)
+	uploadID, err := objLayer.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i, part := range [][]byte{multipart[:5<<20], multipart[5<<20:]} {
+		pi, err := objLayer.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(part), int64(len(part)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	if _, err = objLayer.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object string
+		data   []byte
+		rs     *HTTPRangeSpec
+	}{
+		{object: "object", data: data},
+		{object: "object", data: data, rs: &HTTPRangeSpec{Start: blockSizeV2 - 3, End: 2*blockSizeV2 + 5}},
+		{object: "object", data: data, rs: &HTTPRangeSpec{Start: int64(len(data)) - 10, End: int64(len(data)) - 1}},
+		{object: "multipart", data: multipart},
+		{object: "multipart", data: multipart, rs: &HTTPRangeSpec{Start: 5<<20 - 100, End: 5<<20 + 100}},
+	}
+	for i, tc := range testCases {
+		want := tc.data
+		if tc.rs != nil {
+			want = tc.data[tc.rs.Start : tc.rs.End+1]
+		}
+		for _, writeTo := range []bool{false, true} {
+			gr, err := objLayer.GetObjectNInfo(ctx, bucket, tc.object, tc.rs, nil, readLock, ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := gr.Reader.(*zeroCopyReader); !ok {
+				t.Fatalf("case %d: expected a zero copy read, got %T", i+1, gr.Reader)
+			}
+			var got []byte
+			if writeTo {
+				// Written with WriteTo, as by the GET handler.
+				rec := httptest.NewRecorder()
+				_, err = gr.WriteTo(rec)
+				got = rec.Body.Bytes()
+			} else {
+				got, err = ioutil.ReadAll(gr)
+			}
+			gr.Close()
+			if err != nil {
+				t.Fatalf("case %d: %v", i+1, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("case %d: unexpected data of %d bytes, expected %d bytes", i+1, len(got), len(want))
+			}
+		}
+	}
+
+	// Not used once disabled.
+	setZeroCopy(false)
+	gr, err := objLayer.GetObjectNInfo(ctx, bucket, "object", nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	if _, ok := gr.Reader.(*zeroCopyReader); ok {
+		t.Fatal("unexpected zero copy read")
+	}
+}
+
+func TestGetObjectZeroCopyFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.zeroCopyGet = true
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.zeroCopyGet = false
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	disks, err := getRandomDisks(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(disks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const bucket = "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 5*blockSizeV2/2+7)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	// shardFile returns the path of the file of the first data shard of
+	// the object.
+	shardFile := func(object string) string {
+		for _, disk := range objLayer.(*erasureServerPools).getServerPools()[0].sets[0].getDisks() {
+			fi, err := disk.ReadVersion(ctx, bucket, object, "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Erasure.Index == 1 {
+				return pathJoin(disk.String(), bucket, object, fi.DataDir, "part.1")
+			}
+		}
+		t.Fatal("no first data shard")
+		return ""
+	}
+
+	testCases := []struct {
+		object   string
+		damage   func(path string) error
+		zeroCopy bool
+	}{
+		// Detected once the read started, the rest is decoded.
+		{object: "corrupt", damage: func(path string) error {
+			f, err := os.OpenFile(path, os.O_RDWR, 0)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			// In the data of the second block.
+			_, err = f.WriteAt([]byte("corrupt"), blockSizeV2/2+100)
+			return err
+		}, zeroCopy: true},
+		// Detected before the read is chosen.
+		{object: "missing", damage: os.Remove},
+		{object: "truncated", damage: func(path string) error {
+			return os.Truncate(path, 100)
+		}},
+	}
+	for _, tc := range testCases {
+		if _, err = objLayer.PutObject(ctx, bucket, tc.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err = tc.damage(shardFile(tc.object)); err != nil {
+			t.Fatal(err)
+		}
+		for _, writeTo := range []bool{false, true} {
+			gr, err := objLayer.GetObjectNInfo(ctx, bucket, tc.object, nil, nil, readLock, ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := gr.Reader.(*zeroCopyReader); ok != tc.zeroCopy {
+				t.Fatalf("%s: expected zero copy %v, got %T", tc.object, tc.zeroCopy, gr.Reader)
+			}
+			var got []byte
+			if writeTo {
+				rec := httptest.NewRecorder()
+				_, err = gr.WriteTo(rec)
+				got = rec.Body.Bytes()
+			} else {
+				got, err = ioutil.ReadAll(gr)
+			}
+			gr.Close()
+			if err != nil {
+				t.Fatalf("%s: %v", tc.object, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("%s: unexpected data", tc.object)
+			}
+		}
+	}
+}
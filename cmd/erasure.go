@@ -240,7 +240,15 @@ func getStorageInfo(disks []StorageAPI, endpoints []Endpoint) (StorageInfo, []er
 func (er erasureObjects) StorageInfo(ctx context.Context) (StorageInfo, []error) {
 	disks := er.getDisks()
 	endpoints := er.getEndpoints()
-	return getStorageInfo(disks, endpoints)
+	storageInfo, errs := getStorageInfo(disks, endpoints)
+
+	// Offline disks cannot report their location, all
+	// disks belong to this erasure set nevertheless.
+	for i := range storageInfo.Disks {
+		storageInfo.Disks[i].PoolIndex = er.poolIndex
+		storageInfo.Disks[i].SetIndex = er.setIndex
+	}
+	return storageInfo, errs
 }
 
 // LocalStorageInfo - returns underlying local storage statistics.
@@ -456,6 +464,8 @@ func (er erasureObjects) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 					return
 				default:
 				}
+				globalScannerControl.waitWhilePaused(ctx)
+				globalScannerControl.bucketStarted(bucket.Name)
 
 				// Load cache for bucket
 				cacheName := pathJoin(bucket.Name, dataUsageCacheName)
@@ -466,6 +476,7 @@ func (er erasureObjects) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 				}
 				cache.Info.BloomFilter = bloom
 				cache.Info.SkipHealing = healing
+				cache.Info.FullScan = globalScannerControl.isFullScan(bucket.Name)
 				cache.Disks = allDiskIDs
 				cache.Info.NextCycle = wantCycle
 				if cache.Info.Name != bucket.Name {
@@ -498,6 +509,8 @@ func (er erasureObjects) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 				var err error
 				cache, err = disk.NSScanner(ctx, cache, updates)
 				cache.Info.BloomFilter = nil
+				cache.Info.FullScan = false
+				globalScannerControl.bucketFinished(bucket.Name)
 				if err != nil {
 					if !cache.Info.LastUpdate.IsZero() && cache.Info.LastUpdate.After(before) {
 						logger.LogIf(ctx, cache.save(ctx, er, cacheName))
@@ -564,6 +564,12 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(err, bucket, object)
 	}
 
+	// Fail early before appending the parts, the preconditions
+	// are evaluated again under the namespace lock.
+	if err = fs.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	// ensure that part ETag is canonicalized to strip off extraneous quotes
 	for i := range parts {
 		parts[i].ETag = canonicalizeETag(parts[i].ETag)
@@ -708,6 +714,10 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	ctx = lkctx.Context()
 	defer destLock.Unlock(lkctx.Cancel)
 
+	if err = fs.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
 	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Write(fsMetaPath)
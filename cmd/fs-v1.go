@@ -618,7 +618,9 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 	defer NSUpdated(dstBucket, dstObject)
 
-	if !cpSrcDstSame {
+	// The source is read without a lock when it is the destination,
+	// a conditional copy locks it to evaluate the preconditions.
+	if !cpSrcDstSame || dstOpts.CheckPrecondFn != nil {
 		objectDWLock := fs.NewNSLock(dstBucket, dstObject)
 		lkctx, err := objectDWLock.GetLock(ctx, globalOperationTimeout)
 		if err != nil {
@@ -628,6 +630,10 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		defer objectDWLock.Unlock(lkctx.Cancel)
 	}
 
+	if err = fs.checkPutPrecondition(ctx, dstBucket, dstObject, dstOpts); err != nil {
+		return oi, err
+	}
+
 	if _, err := fs.statBucketDir(ctx, srcBucket); err != nil {
 		return oi, toObjectErr(err, srcBucket)
 	}
@@ -986,7 +992,7 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 // Additionally writes `fs.json` which carries the necessary metadata
 // for future object operations.
 func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	if opts.Versioned {
+	if opts.Versioned || opts.Append {
 		return objInfo, NotImplemented{}
 	}
 
@@ -1006,9 +1012,33 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	if err = fs.checkPutPrecondition(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
+// checkPutPrecondition evaluates the preconditions of a conditional
+// PutObject, CopyObject or CompleteMultipartUpload against the object,
+// a missing object is passed as an empty ObjectInfo.
+func (fs *FSObjects) checkPutPrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // putObject - wrapper for PutObject
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
@@ -1092,6 +1122,12 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	setClientETag(fsMeta.Meta, r)
+
+	// A trailing checksum is only known, and verified, once all data was read.
+	if opts.WantChecksum != nil && opts.WantChecksum.Trailing {
+		fsMeta.Meta[objectChecksumKey] = opts.WantChecksum.String()
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -1365,7 +1401,7 @@ func (fs *FSObjects) Walk(ctx context.Context, bucket, prefix string, results ch
 }
 
 // HealObjects - no-op for fs. Valid only for Erasure.
-func (fs *FSObjects) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn HealObjectFn) (e error) {
+func (fs *FSObjects) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, window HealTimeWindow, fn HealObjectFn) (e error) {
 	logger.LogIf(ctx, NotImplemented{})
 	return NotImplemented{}
 }
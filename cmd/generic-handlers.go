@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"path"
@@ -268,6 +269,30 @@ func parseAmzDateHeader(req *http.Request) (time.Time, APIErrorCode) {
 }
 
 // setHttpStatsHandler sets a http Stats handler to gather HTTP statistics
+type contextKeyType string
+
+// requestReceivedTimeKey is the context key of the time a request was
+// received at.
+const requestReceivedTimeKey = contextKeyType("request-received-time")
+
+// setRequestReceivedTimeHandler records the time the request was received
+// at, S3 request latencies are measured from it.
+func setRequestReceivedTimeHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestReceivedTimeKey, time.Now().UTC())
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// getRequestReceivedTime returns the time the request was received at,
+// or def if it was not recorded.
+func getRequestReceivedTime(r *http.Request, def time.Time) time.Time {
+	if t, ok := r.Context().Value(requestReceivedTimeKey).(time.Time); ok {
+		return t
+	}
+	return def
+}
+
 func setHTTPStatsHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Meters s3 connection stats.
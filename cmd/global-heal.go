@@ -214,25 +214,9 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			disks = disks[:3]
 		}
 
-		healEntry := func(entry metaCacheEntry) {
-			if entry.isDir() {
-				return
-			}
-			// We might land at .metacache, .trash, .multipart
-			// no need to heal them skip, only when bucket
-			// is '.minio.sys'
-			if bucket == minioMetaBucket {
-				if wildcard.Match("buckets/*/.metacache/*", entry.name) {
-					return
-				}
-				if wildcard.Match("tmp/.trash/*", entry.name) {
-					return
-				}
-				if wildcard.Match("multipart/*", entry.name) {
-					return
-				}
-			}
-
+		// healEntry heals all versions of entry, gone is set when the
+		// object was removed from all disks since it was listed.
+		healEntry := func(entry metaCacheEntry, gone bool) {
 			fivs, err := entry.fileInfoVersions(bucket)
 			if err != nil {
 				err := bgSeq.queueHealTask(healSource{
@@ -251,11 +235,15 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			}
 
 			for _, version := range fivs.Versions {
-				if _, err := er.HealObject(ctx, bucket, version.Name,
-					version.VersionID, madmin.HealOpts{
-						ScanMode: scanMode,
-						Remove:   healDeleteDangling,
-					}); err != nil {
+				var err error
+				if !gone {
+					_, err = er.healObjectVersion(ctx, bucket, version.Name,
+						version.VersionID, madmin.HealOpts{
+							ScanMode: scanMode,
+							Remove:   healDeleteDangling,
+						}, false)
+				}
+				if err != nil {
 					// If not deleted, assume they failed.
 					tracker.ItemsFailed++
 					tracker.BytesFailed += uint64(version.Size)
@@ -275,6 +263,48 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			waitForLowHTTPReq()
 		}
 
+		// Objects are healed in batches, checking which of them are
+		// still present with a single metadata read per disk for the
+		// whole batch instead of one read per disk for each version.
+		pending := make([]metaCacheEntry, 0, readVersionsBatchSize)
+		healPending := func() {
+			if len(pending) == 0 {
+				return
+			}
+			names := make([]string, len(pending))
+			for i := range pending {
+				names[i] = pending[i].name
+			}
+			_, errs := readAllFileInfos(ctx, er.getDisks(), bucket, names, nil, false)
+			for i := range pending {
+				healEntry(pending[i], isAllNotFound(errs[i]))
+			}
+			pending = pending[:0]
+		}
+		queueEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+			// We might land at .metacache, .trash, .multipart
+			// no need to heal them skip, only when bucket
+			// is '.minio.sys'
+			if bucket == minioMetaBucket {
+				if wildcard.Match("buckets/*/.metacache/*", entry.name) {
+					return
+				}
+				if wildcard.Match("tmp/.trash/*", entry.name) {
+					return
+				}
+				if wildcard.Match("multipart/*", entry.name) {
+					return
+				}
+			}
+			pending = append(pending, entry)
+			if len(pending) == readVersionsBatchSize {
+				healPending()
+			}
+		}
+
 		// How to resolve partial results.
 		resolver := metadataResolutionParams{
 			dirQuorum: 1,
@@ -289,7 +319,7 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			forwardTo:      forwardTo,
 			minDisks:       1,
 			reportNotFound: false,
-			agreed:         healEntry,
+			agreed:         queueEntry,
 			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
 				entry, ok := entries.resolve(&resolver)
 				if !ok {
@@ -297,10 +327,13 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 					// proceed to heal nonetheless.
 					entry, _ = entries.firstFound()
 				}
-				healEntry(*entry)
+				queueEntry(*entry)
 			},
 			finished: nil,
 		})
+		if err == nil && ctx.Err() == nil {
+			healPending()
+		}
 
 		if err != nil {
 			// Set this such that when we return this function
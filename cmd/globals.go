@@ -185,6 +185,11 @@ var (
 	globalLifecycleSys       *LifecycleSys
 	globalBucketSSEConfigSys *BucketSSEConfigSys
 	globalBucketTargetSys    *BucketTargetSys
+
+	// globalObjectLambda transforms objects read with GET
+	// through the configured lambda webhooks.
+	globalObjectLambda = NewObjectLambdaSys()
+
 	// globalAPIConfig controls S3 API requests throttling,
 	// healthcheck readiness deadlines and cors settings.
 	globalAPIConfig = apiConfig{listQuorum: 3}
@@ -194,6 +199,9 @@ var (
 	globalOpenIDConfig openid.Config
 	globalSTSTLSConfig xtls.Config
 
+	// All OpenID providers, globalOpenIDConfig is the default one.
+	globalOpenIDProviders openid.Providers
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -210,6 +218,10 @@ var (
 	// and Storage/OS calls info to registered listeners.
 	globalTrace = pubsub.New()
 
+	// global heal events system to send per object heal
+	// progress to registered listeners.
+	globalHealEvents = pubsub.New()
+
 	// global Listen system to send S3 API events to registered listeners
 	globalHTTPListen = pubsub.New()
 
@@ -233,6 +245,9 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Buffered access logs of the buckets with logging enabled
+	globalBucketAccessLogs = newBucketAccessLogs()
+
 	// Time when the server is started
 	globalBootTime = UTCNow()
 
@@ -248,6 +263,7 @@ var (
 
 	globalBucketObjectLockSys *BucketObjectLockSys
 	globalBucketQuotaSys      *BucketQuotaSys
+	globalBucketQoSSys        *BucketQoSSys
 	globalBucketVersioningSys *BucketVersioningSys
 
 	// Disk cache drives
@@ -314,6 +330,10 @@ var (
 
 	globalInternodeTransport http.RoundTripper
 
+	// Port of the internode gRPC server, empty if
+	// internode calls are made over HTTP.
+	globalInternodeGRPCPort string
+
 	globalProxyTransport http.RoundTripper
 
 	globalDNSCache = &dnscache.Resolver{
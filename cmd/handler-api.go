@@ -28,6 +28,7 @@ import (
 	"github.com/shirou/gopsutil/v3/mem"
 
 	"github.com/minio/minio/internal/config/api"
+	xhttp "github.com/minio/minio/internal/http"
 	xioutil "github.com/minio/minio/internal/ioutil"
 	"github.com/minio/minio/internal/logger"
 )
@@ -49,6 +50,18 @@ type apiConfig struct {
 	staleUploadsExpiry          time.Duration
 	staleUploadsCleanupInterval time.Duration
 	deleteCleanupInterval       time.Duration
+	etagMode                    string
+	blockPublicAccess           bool
+	inlineThreshold             int64
+	zeroCopyGet                 bool
+	odirect                     api.ODirectConfig
+	admission                   admissionLimits
+	replicationMRFSize          int
+	replicationMRFBackoff       api.ReplicationBackoffConfig
+	readiness                   readinessCriteria
+	multipartMinPartSize        int64
+	multipartMaxParts           int
+	multipartValidateParts      bool
 }
 
 const cgroupLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
@@ -151,6 +164,127 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	t.staleUploadsExpiry = cfg.StaleUploadsExpiry
 	t.staleUploadsCleanupInterval = cfg.StaleUploadsCleanupInterval
 	t.deleteCleanupInterval = cfg.DeleteCleanupInterval
+	t.etagMode = cfg.ETagMode
+	t.blockPublicAccess = cfg.BlockPublicAccess
+	t.inlineThreshold = cfg.InlineThreshold
+	globalBlockCache.resize(cfg.BlockCacheSize)
+	t.zeroCopyGet = cfg.ZeroCopyGet
+	t.odirect = cfg.ODirect
+
+	t.admission = admissionLimits{
+		inflightSize: cfg.AdmissionInflightSize,
+		minSize:      cfg.AdmissionMinSize,
+		deadline:     cfg.AdmissionDeadline,
+	}
+	if cfg.AdmissionHeapPercent > 0 {
+		t.admission.heapSize = availableMemory() / 100 * uint64(cfg.AdmissionHeapPercent)
+	}
+	t.replicationMRFSize = cfg.ReplicationMRFSize
+	t.replicationMRFBackoff = cfg.ReplicationMRFBackoff
+	t.readiness = readinessCriteria{
+		minOnlineDrives:       cfg.ReadyMinOnlineDrives,
+		maxHealBacklog:        cfg.ReadyMaxHealBacklog,
+		maxReplicationBacklog: cfg.ReadyMaxReplicationBacklog,
+	}
+	t.multipartMinPartSize = cfg.MultipartMinPartSize
+	t.multipartMaxParts = cfg.MultipartMaxParts
+	t.multipartValidateParts = cfg.MultipartValidateParts
+}
+
+func (t *apiConfig) getETagMode() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.etagMode
+}
+
+func (t *apiConfig) isPublicAccessBlocked() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.blockPublicAccess
+}
+
+func (t *apiConfig) isZeroCopyGetEnabled() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.zeroCopyGet
+}
+
+func (t *apiConfig) getAdmissionLimits() admissionLimits {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.admission
+}
+
+func (t *apiConfig) getReplicationMRFSize() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.replicationMRFSize
+}
+
+func (t *apiConfig) getReplicationMRFBackoff(arn string) api.ReplicationBackoff {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.replicationMRFBackoff.Get(arn)
+}
+
+func (t *apiConfig) getReadinessCriteria() readinessCriteria {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.readiness
+}
+
+func (t *apiConfig) getODirectMode(drivePath string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.odirect.GetMode(drivePath)
+}
+
+func (t *apiConfig) getInlineThreshold() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.inlineThreshold <= 0 {
+		return smallFileThreshold
+	}
+
+	return t.inlineThreshold
+}
+
+func (t *apiConfig) getMultipartMinPartSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.multipartMinPartSize <= 0 {
+		return globalMinPartSize
+	}
+
+	return t.multipartMinPartSize
+}
+
+func (t *apiConfig) getMultipartMaxParts() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.multipartMaxParts <= 0 {
+		return globalMaxPartID
+	}
+
+	return t.multipartMaxParts
+}
+
+func (t *apiConfig) isMultipartPartsValidated() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.multipartValidateParts
 }
 
 func (t *apiConfig) getListQuorum() int {
@@ -227,6 +361,14 @@ func (t *apiConfig) getRequestsPool() (chan struct{}, time.Duration) {
 // maxClients throttles the S3 API calls
 func maxClients(f http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if globalNodeDrain.isDraining() {
+			// Ask the client to retry, the load balancer sends it to
+			// another node once the readiness probe of this one fails.
+			w.Header().Set(xhttp.RetryAfter, strconv.Itoa(nodeDrainRetryAfter))
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSlowDown), r.URL)
+			return
+		}
+
 		if val := globalServiceFreeze.Load(); val != nil {
 			if unlock, ok := val.(chan struct{}); ok && unlock != nil {
 				// Wait until unfrozen.
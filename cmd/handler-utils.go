@@ -29,13 +29,16 @@ import (
 	"net/http"
 	"net/textproto"
 	"net/url"
+	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/http/stats"
 	"github.com/minio/minio/internal/logger"
 )
 
@@ -355,8 +358,9 @@ func extractPostPolicyFormValues(ctx context.Context, form *multipart.Form) (fil
 
 // Log headers and body.
 func httpTraceAll(f http.HandlerFunc) http.HandlerFunc {
+	name := getOpName(runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
 	return func(w http.ResponseWriter, r *http.Request) {
-		if globalTrace.NumSubscribers() == 0 {
+		if globalTrace.NumSubscribers() == 0 || !globalTraceSampler.sample(name) {
 			f.ServeHTTP(w, r)
 			return
 		}
@@ -367,8 +371,9 @@ func httpTraceAll(f http.HandlerFunc) http.HandlerFunc {
 
 // Log only the headers.
 func httpTraceHdrs(f http.HandlerFunc) http.HandlerFunc {
+	name := getOpName(runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name())
 	return func(w http.ResponseWriter, r *http.Request) {
-		if globalTrace.NumSubscribers() == 0 {
+		if globalTrace.NumSubscribers() == 0 || !globalTraceSampler.sample(name) {
 			f.ServeHTTP(w, r)
 			return
 		}
@@ -383,10 +388,28 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 		defer globalHTTPStats.currentS3Requests.Dec(api)
 
 		statsWriter := logger.NewResponseWriter(w)
+		loggingConfig := getBucketLoggingConfig(r)
+		// Error bodies hold the S3 error codes of the bucket error
+		// metrics and of the access log.
+		statsWriter.LogErrBody = true
+		var statsReader *stats.IncomingTrafficMeter
+		if r.Body != nil {
+			statsReader = &stats.IncomingTrafficMeter{ReadCloser: r.Body}
+			r.Body = statsReader
+		}
 
 		f.ServeHTTP(statsWriter, r)
 
-		globalHTTPStats.updateStats(api, r, statsWriter)
+		var bytesRead int64
+		if statsReader != nil {
+			bytesRead = statsReader.BytesRead()
+		}
+		globalHTTPStats.updateStats(api, r, statsWriter, bytesRead)
+		globalUsageAccounting.recordRequest(r, api, bytesRead, int64(statsWriter.Size()))
+		globalBucketErrorStats.recordResponse(api, r, statsWriter)
+		if loggingConfig != nil {
+			logBucketAccess(loggingConfig, r, statsWriter)
+		}
 	}
 }
 
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/minio/minio/internal/event"
+)
+
+// Healing specific APIName
+const (
+	HealObjectAPI       = "HealObject"
+	ObjectQuorumLostAPI = "ObjectQuorumLost"
+	HealDriveAPI        = "HealDrive"
+)
+
+const (
+	// HealObjectTrail - audit trail for objects repaired or found unreadable by healing
+	HealObjectTrail = "heal:object"
+	// HealDriveTrail - audit trail for the healing of fresh drives
+	HealDriveTrail = "heal:drive"
+)
+
+// sendObjectHealEvent notifies the subscribers of a bucket and the audit
+// targets that healing repaired an object version, or found it unreadable
+// because it lost read quorum.
+func sendObjectHealEvent(ctx context.Context, bucket, object, versionID string, size int64, disksHealed int, err error) {
+	if isMinioMetaBucketName(bucket) {
+		return
+	}
+
+	var readQuorum InsufficientReadQuorum
+	var eventName event.Name
+	var apiName string
+	switch {
+	case err == nil && disksHealed > 0:
+		eventName, apiName = event.ObjectHealed, HealObjectAPI
+	case errors.As(err, &readQuorum):
+		eventName, apiName = event.ObjectQuorumLost, ObjectQuorumLostAPI
+	default:
+		return
+	}
+
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Bucket:    bucket,
+			Name:      object,
+			VersionID: versionID,
+			Size:      size,
+		},
+		ReqParams: map[string]string{
+			"disksHealed": strconv.Itoa(disksHealed),
+		},
+		Host: "Internal: [Healing]",
+	})
+	auditLogInternal(ctx, bucket, object, AuditLogOptions{
+		Trigger:   HealObjectTrail,
+		APIName:   apiName,
+		Status:    eventName.String(),
+		VersionID: versionID,
+	})
+}
+
+// sendDriveHealEvent notifies the subscribers of all buckets and the audit
+// targets that the healing of a fresh drive started or completed.
+func sendDriveHealEvent(ctx context.Context, eventName event.Name, tracker *healingTracker) {
+	args := eventArgs{
+		EventName: eventName,
+		ReqParams: map[string]string{
+			"endpoint":       tracker.Endpoint,
+			"pool":           strconv.Itoa(tracker.PoolIndex),
+			"set":            strconv.Itoa(tracker.SetIndex),
+			"disk":           strconv.Itoa(tracker.DiskIndex),
+			"itemsHealed":    strconv.FormatUint(tracker.ItemsHealed, 10),
+			"itemsFailed":    strconv.FormatUint(tracker.ItemsFailed, 10),
+			"bytesDone":      strconv.FormatUint(tracker.BytesDone, 10),
+			"bytesFailed":    strconv.FormatUint(tracker.BytesFailed, 10),
+			"objectsTotal":   strconv.FormatUint(tracker.ObjectsTotalCount, 10),
+			"bytesTotal":     strconv.FormatUint(tracker.ObjectsTotalSize, 10),
+			"healingStarted": tracker.Started.Format(iso8601TimeFormat),
+		},
+		Host: "Internal: [Healing]",
+	}
+
+	// globalNotificationSys is not initialized in gateway mode.
+	if globalNotificationSys != nil {
+		if globalHTTPListen.NumSubscribers() > 0 {
+			globalHTTPListen.Publish(args.ToEvent(false))
+		}
+		globalNotificationSys.SendToAll(args)
+	}
+	auditLogInternal(ctx, "", "", AuditLogOptions{
+		Trigger: HealDriveTrail,
+		APIName: HealDriveAPI,
+		Status:  eventName.String(),
+	})
+}
@@ -19,14 +19,19 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	xhttp "github.com/minio/minio/internal/http"
 )
 
-const unavailable = "offline"
+const (
+	unavailable = "offline"
+	degraded    = "degraded"
+)
 
 func shouldProxy() bool {
 	return newObjectLayerFn() == nil
@@ -104,9 +109,133 @@ func ClusterReadCheckHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
 
-// ReadinessCheckHandler Checks if the process is up. Always returns success.
+// ConsistencyCheckHandler returns the results of the last consistency
+// probes of the erasure sets by this server, it fails if any of them failed.
+func ConsistencyCheckHandler(w http.ResponseWriter, r *http.Request) {
+	if globalIsGateway {
+		writeResponse(w, http.StatusOK, nil, mimeNone)
+		return
+	}
+
+	if shouldProxy() {
+		w.Header().Set(xhttp.MinIOServerStatus, unavailable)
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+
+	status := globalConsistencyProbes.status()
+	statusCode := http.StatusOK
+	if !status.Healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+	if r.Method == http.MethodHead {
+		writeResponse(w, statusCode, nil, mimeNone)
+		return
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, nil, mimeNone)
+		return
+	}
+	writeResponse(w, statusCode, data, mimeJSON)
+}
+
+// readinessCriteria - the server is not ready when any of the enabled
+// criteria is not met, a zero value disables a criterion.
+type readinessCriteria struct {
+	// Minimum online drives of each erasure set.
+	minOnlineDrives int
+	// Maximum number of objects waiting to be healed.
+	maxHealBacklog int
+	// Maximum number of objects waiting to be replicated.
+	maxReplicationBacklog int
+}
+
+// readinessState - the state of the server checked by the readiness
+// criteria.
+type readinessState struct {
+	// Online drives of each erasure set, by pool.
+	onlineDrives       [][]int
+	healBacklog        int
+	replicationBacklog int
+}
+
+// getReadinessState returns the state of the server as seen locally.
+func getReadinessState(objAPI ObjectLayer) (s readinessState) {
+	if z, ok := objAPI.(*erasureServerPools); ok {
+		pools := z.getServerPools()
+		s.onlineDrives = make([][]int, len(pools))
+		for i, pool := range pools {
+			s.onlineDrives[i] = make([]int, len(pool.sets))
+			for j, set := range pool.sets {
+				for _, disk := range set.getDisks() {
+					if disk != nil && disk.IsOnline() {
+						s.onlineDrives[i][j]++
+					}
+				}
+			}
+		}
+	}
+	s.healBacklog = globalMRFState.backlog()
+	s.replicationBacklog = globalReplicationPool.backlog()
+	return s
+}
+
+// check returns the reasons the server is not ready, none if it is.
+func (c readinessCriteria) check(s readinessState) (reasons []string) {
+	if c.minOnlineDrives > 0 {
+		for pool := range s.onlineDrives {
+			for set, online := range s.onlineDrives[pool] {
+				if online < c.minOnlineDrives {
+					reasons = append(reasons, fmt.Sprintf("pool %d set %d has %d online drives, expected at least %d",
+						pool, set, online, c.minOnlineDrives))
+				}
+			}
+		}
+	}
+	if c.maxHealBacklog > 0 && s.healBacklog > c.maxHealBacklog {
+		reasons = append(reasons, fmt.Sprintf("%d objects are waiting to be healed, expected at most %d",
+			s.healBacklog, c.maxHealBacklog))
+	}
+	if c.maxReplicationBacklog > 0 && s.replicationBacklog > c.maxReplicationBacklog {
+		reasons = append(reasons, fmt.Sprintf("%d objects are waiting to be replicated, expected at most %d",
+			s.replicationBacklog, c.maxReplicationBacklog))
+	}
+	return reasons
+}
+
+// ReadinessCheckHandler checks if the process is up and meets the
+// configured readiness criteria, a degraded server is reported as not
+// ready so that load balancers may drain it.
 func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
-	LivenessCheckHandler(w, r)
+	objLayer := newObjectLayerFn()
+	if globalIsGateway || objLayer == nil {
+		LivenessCheckHandler(w, r)
+		return
+	}
+
+	reasons := globalAPIConfig.getReadinessCriteria().check(getReadinessState(objLayer))
+	if reason := globalNodeDrain.status().readinessReason(); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if len(reasons) == 0 {
+		LivenessCheckHandler(w, r)
+		return
+	}
+
+	w.Header().Set(xhttp.MinIOServerStatus, degraded)
+	if r.Method == http.MethodHead {
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+	data, err := json.Marshal(struct {
+		Reasons []string `json:"reasons"`
+	}{reasons})
+	if err != nil {
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+	writeResponse(w, http.StatusServiceUnavailable, data, mimeJSON)
 }
 
 // LivenessCheckHandler - Checks if the process is up. Always returns success.
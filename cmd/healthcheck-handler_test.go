@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestReadinessCriteria(t *testing.T) {
+	state := readinessState{
+		onlineDrives:       [][]int{{4, 3}, {4}},
+		healBacklog:        100,
+		replicationBacklog: 5000,
+	}
+
+	testCases := []struct {
+		criteria readinessCriteria
+		reasons  int
+	}{
+		// All criteria disabled.
+		{readinessCriteria{}, 0},
+		{readinessCriteria{minOnlineDrives: 3, maxHealBacklog: 100, maxReplicationBacklog: 5000}, 0},
+		{readinessCriteria{minOnlineDrives: 4}, 1},
+		{readinessCriteria{minOnlineDrives: 5}, 3},
+		{readinessCriteria{maxHealBacklog: 99}, 1},
+		{readinessCriteria{minOnlineDrives: 4, maxHealBacklog: 10, maxReplicationBacklog: 1000}, 3},
+	}
+	for i, testCase := range testCases {
+		if reasons := testCase.criteria.check(state); len(reasons) != testCase.reasons {
+			t.Errorf("Case %d: expected %d reasons, got %v", i+1, testCase.reasons, reasons)
+		}
+	}
+}
@@ -29,6 +29,7 @@ const (
 	healthCheckReadinessPath   = "/ready"
 	healthCheckClusterPath     = "/cluster"
 	healthCheckClusterReadPath = "/cluster/read"
+	healthCheckConsistencyPath = "/consistency"
 	healthCheckPathPrefix      = minioReservedBucketPath + healthCheckPath
 )
 
@@ -44,6 +45,10 @@ func registerHealthCheckRouter(router *mux.Router) {
 	healthRouter.Methods(http.MethodGet).Path(healthCheckClusterReadPath).HandlerFunc(httpTraceAll(ClusterReadCheckHandler))
 	healthRouter.Methods(http.MethodHead).Path(healthCheckClusterReadPath).HandlerFunc(httpTraceAll(ClusterReadCheckHandler))
 
+	// Consistency probes handler
+	healthRouter.Methods(http.MethodGet).Path(healthCheckConsistencyPath).HandlerFunc(httpTraceAll(ConsistencyCheckHandler))
+	healthRouter.Methods(http.MethodHead).Path(healthCheckConsistencyPath).HandlerFunc(httpTraceAll(ConsistencyCheckHandler))
+
 	// Liveness handler
 	healthRouter.Methods(http.MethodGet).Path(healthCheckLivenessPath).HandlerFunc(httpTraceAll(LivenessCheckHandler))
 	healthRouter.Methods(http.MethodHead).Path(healthCheckLivenessPath).HandlerFunc(httpTraceAll(LivenessCheckHandler))
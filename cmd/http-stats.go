@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/minio/minio/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -177,7 +178,7 @@ func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
 }
 
 // Update statistics from http request and response data
-func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.ResponseWriter) {
+func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.ResponseWriter, bytesRead int64) {
 	// A successful request has a 2xx response code or < 4xx response
 	successReq := w.StatusCode >= 200 && w.StatusCode < 400
 
@@ -199,6 +200,19 @@ func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.Response
 
 	// Increment the prometheus http request response histogram with appropriate label
 	httpRequestsDuration.With(prometheus.Labels{"api": api}).Observe(w.TimeToFirstByte.Seconds())
+
+	// Latencies are measured from the receipt of the request, before
+	// it waited for authentication, throttling or admission.
+	received := getRequestReceivedTime(r, w.StartTime)
+	if successReq && w.TimeToFirstByte > 0 {
+		firstByte := w.StartTime.Add(w.TimeToFirstByte).Sub(received)
+		httpRequestsFirstByte.With(prometheus.Labels{"api": api}).Observe(firstByte.Seconds())
+	}
+	httpRequestsLatency.With(prometheus.Labels{"api": api}).Observe(time.Since(received).Seconds())
+
+	// Record the size of the request and response bodies
+	httpRequestsSize.With(prometheus.Labels{"api": api}).Observe(float64(bytesRead))
+	httpResponsesSize.With(prometheus.Labels{"api": api}).Observe(float64(w.Size()))
 }
 
 // Prepare new HTTPStats structure
@@ -142,6 +142,13 @@ func (ids *iamDummyStore) loadMappedPolicies(ctx context.Context, userType IAMUs
 	return nil
 }
 
+func (ids *iamDummyStore) loadPermissionsBoundaries(ctx context.Context, m map[string]MappedPolicy) error {
+	for k, v := range ids.iamUserBoundaryMap {
+		m[k] = v
+	}
+	return nil
+}
+
 func (ids *iamDummyStore) saveIAMConfig(ctx context.Context, item interface{}, path string, opts ...options) error {
 	return nil
 }
@@ -473,6 +473,28 @@ func (ies *IAMEtcdStore) loadMappedPolicies(ctx context.Context, userType IAMUse
 
 }
 
+func (ies *IAMEtcdStore) loadPermissionsBoundaries(ctx context.Context, m map[string]MappedPolicy) error {
+	cctx, cancel := context.WithTimeout(ctx, defaultContextTimeout)
+	defer cancel()
+
+	r, err := ies.client.Get(cctx, iamConfigPolicyDBBoundariesPrefix, etcd.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range r.Kvs {
+		var mp MappedPolicy
+		if err = getIAMConfig(&mp, kv.Value, string(kv.Key)); err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return err
+		}
+		m[extractPathPrefixAndSuffix(string(kv.Key), iamConfigPolicyDBBoundariesPrefix, ".json")] = mp
+	}
+	return nil
+}
+
 func (ies *IAMEtcdStore) savePolicyDoc(ctx context.Context, policyName string, p iampolicy.Policy) error {
 	return ies.saveIAMConfig(ctx, &p, getPolicyDocPath(policyName))
 }
@@ -0,0 +1,549 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/auth"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// IAM export archive layout, one JSON file per kind of IAM entry.
+const (
+	iamExportFormatVersion1 = 1
+
+	iamExportFormatFile          = "format.json"
+	iamExportPoliciesFile        = "policies.json"
+	iamExportUsersFile           = "users.json"
+	iamExportGroupsFile          = "groups.json"
+	iamExportServiceAccountsFile = "svcaccts.json"
+	iamExportUserMappingsFile    = "user_mappings.json"
+	iamExportGroupMappingsFile   = "group_mappings.json"
+	iamExportSTSMappingsFile     = "stsuser_mappings.json"
+	iamExportBoundariesFile      = "boundaries.json"
+)
+
+// Kinds of IAM entries reported by an IAM import.
+const (
+	iamEntryPolicy         = "policies"
+	iamEntryUser           = "users"
+	iamEntryGroup          = "groups"
+	iamEntryServiceAccount = "serviceAccounts"
+	iamEntryUserMapping    = "userMappings"
+	iamEntryGroupMapping   = "groupMappings"
+	iamEntrySTSMapping     = "stsUserMappings"
+	iamEntryBoundary       = "boundaries"
+)
+
+// Resolutions of conflicts between imported and existing IAM entries.
+const (
+	// Keep the existing entry.
+	iamImportSkip = "skip"
+	// Replace the existing entry with the imported one.
+	iamImportOverwrite = "overwrite"
+	// Import nothing if any entry conflicts.
+	iamImportFail = "fail"
+)
+
+// errIAMImportConflict - imported IAM entries conflict with existing ones.
+var errIAMImportConflict = errors.New("Imported IAM entries conflict with existing entries")
+
+// iamExportUser - a user of the internal identity provider.
+type iamExportUser struct {
+	SecretKey string               `json:"secretKey"`
+	Status    madmin.AccountStatus `json:"status"`
+}
+
+// iamExportServiceAccount - a service account, its claims are re-signed
+// with the root credentials of the importing cluster.
+type iamExportServiceAccount struct {
+	SecretKey  string `json:"secretKey"`
+	ParentUser string `json:"parentUser,omitempty"`
+	// RootParent is set for service accounts of the root user, they are
+	// imported as service accounts of the root user of the importing
+	// cluster.
+	RootParent bool                   `json:"rootParent,omitempty"`
+	Groups     []string               `json:"groups,omitempty"`
+	Status     string                 `json:"status"`
+	Claims     map[string]interface{} `json:"claims,omitempty"`
+}
+
+// iamExport - all long-term IAM state, temporary credentials are left out.
+type iamExport struct {
+	Policies        map[string]iampolicy.Policy
+	Users           map[string]iamExportUser
+	Groups          map[string]GroupInfo
+	ServiceAccounts map[string]iamExportServiceAccount
+	UserMappings    map[string]MappedPolicy
+	GroupMappings   map[string]MappedPolicy
+	STSMappings     map[string]MappedPolicy
+	Boundaries      map[string]MappedPolicy
+}
+
+func (e *iamExport) files() map[string]interface{} {
+	return map[string]interface{}{
+		iamExportPoliciesFile:        &e.Policies,
+		iamExportUsersFile:           &e.Users,
+		iamExportGroupsFile:          &e.Groups,
+		iamExportServiceAccountsFile: &e.ServiceAccounts,
+		iamExportUserMappingsFile:    &e.UserMappings,
+		iamExportGroupMappingsFile:   &e.GroupMappings,
+		iamExportSTSMappingsFile:     &e.STSMappings,
+		iamExportBoundariesFile:      &e.Boundaries,
+	}
+}
+
+// writeIAMExportArchive writes the IAM state as a zip archive to w.
+func writeIAMExportArchive(w io.Writer, e iamExport) error {
+	zw := zip.NewWriter(w)
+	files := e.files()
+	files[iamExportFormatFile] = iamFormat{Version: iamExportFormatVersion1}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if err = json.NewEncoder(fw).Encode(files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// readIAMExportArchive reads the IAM state from a zip archive written by
+// writeIAMExportArchive.
+func readIAMExportArchive(data []byte) (e iamExport, err error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return e, err
+	}
+
+	var format iamFormat
+	files := e.files()
+	files[iamExportFormatFile] = &format
+	for _, f := range zr.File {
+		v, ok := files[f.Name]
+		if !ok {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return e, err
+		}
+		err = json.NewDecoder(rc).Decode(v)
+		rc.Close()
+		if err != nil {
+			return e, fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	if format.Version != iamExportFormatVersion1 {
+		return e, fmt.Errorf("unsupported IAM export format version %d", format.Version)
+	}
+	return e, nil
+}
+
+// exportIAM returns a copy of the long-term IAM state in the cache.
+func (store *IAMStoreSys) exportIAM() (iamExport, error) {
+	cache := store.rlock()
+	defer store.runlock()
+
+	e := iamExport{
+		Policies:        make(map[string]iampolicy.Policy),
+		Users:           make(map[string]iamExportUser),
+		Groups:          make(map[string]GroupInfo),
+		ServiceAccounts: make(map[string]iamExportServiceAccount),
+		UserMappings:    make(map[string]MappedPolicy),
+		GroupMappings:   make(map[string]MappedPolicy),
+		STSMappings:     make(map[string]MappedPolicy),
+		Boundaries:      make(map[string]MappedPolicy),
+	}
+
+	defaultPolicies := make(map[string]iampolicy.Policy, len(iampolicy.DefaultPolicies))
+	for _, p := range iampolicy.DefaultPolicies {
+		defaultPolicies[p.Name] = p.Definition
+	}
+	for name, p := range cache.iamPolicyDocsMap {
+		// Canned policies exist on every cluster, unless modified.
+		if dp, ok := defaultPolicies[name]; ok && samePolicy(p, dp) {
+			continue
+		}
+		e.Policies[name] = p
+	}
+
+	for name, cred := range cache.iamUsersMap {
+		switch {
+		case cred.IsTemp():
+		case cred.IsServiceAccount():
+			claims, err := auth.ExtractClaims(cred.SessionToken, globalActiveCred.SecretKey)
+			if err != nil {
+				return e, err
+			}
+			sa := iamExportServiceAccount{
+				SecretKey:  cred.SecretKey,
+				ParentUser: cred.ParentUser,
+				Groups:     cred.Groups,
+				Status:     cred.Status,
+				Claims:     claims.Map(),
+			}
+			delete(sa.Claims, "accessKey")
+			if cred.ParentUser == globalActiveCred.AccessKey {
+				sa.ParentUser = ""
+				sa.RootParent = true
+				delete(sa.Claims, parentClaim)
+			}
+			e.ServiceAccounts[name] = sa
+		default:
+			status := madmin.AccountEnabled
+			if cred.Status == auth.AccountOff {
+				status = madmin.AccountDisabled
+			}
+			e.Users[name] = iamExportUser{SecretKey: cred.SecretKey, Status: status}
+		}
+	}
+
+	for name, gi := range cache.iamGroupsMap {
+		gi.Members = set.CreateStringSet(gi.Members...).ToSlice()
+		e.Groups[name] = gi
+	}
+
+	for name, mp := range cache.iamUserPolicyMap {
+		if _, ok := e.Users[name]; ok {
+			e.UserMappings[name] = mp
+			continue
+		}
+		// Mappings of LDAP users, mappings of temporary credentials
+		// expire with the credentials.
+		if _, ok := cache.iamUsersMap[name]; !ok && store.getUsersSysType() == LDAPUsersSysType {
+			e.STSMappings[name] = mp
+		}
+	}
+	for name, mp := range cache.iamGroupPolicyMap {
+		e.GroupMappings[name] = mp
+	}
+	for name, mp := range cache.iamUserBoundaryMap {
+		if cred, ok := cache.iamUsersMap[name]; ok && cred.IsTemp() {
+			continue
+		}
+		e.Boundaries[name] = mp
+	}
+	return e, nil
+}
+
+// samePolicy returns true if a and b have the same statements, in the
+// same order.
+func samePolicy(a, b iampolicy.Policy) bool {
+	if a.ID != b.ID || a.Version != b.Version || len(a.Statements) != len(b.Statements) {
+		return false
+	}
+	for i := range a.Statements {
+		if !a.Statements[i].Equals(b.Statements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameJSON returns true if a and b have the same JSON encoding.
+func sameJSON(a, b interface{}) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
+
+// ExportIAM - returns all long-term IAM state: policies, users, groups,
+// service accounts, policy mappings and permissions boundaries.
+func (sys *IAMSys) ExportIAM(ctx context.Context) (iamExport, error) {
+	if !sys.Initialized() {
+		return iamExport{}, errServerNotInitialized
+	}
+
+	<-sys.configLoaded
+
+	return sys.store.exportIAM()
+}
+
+// iamImportError - an IAM entry that could not be imported.
+type iamImportError struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// iamImportResult - the outcome of an IAM import, entry names by kind.
+// Entries identical to the existing ones are not reported.
+type iamImportResult struct {
+	Added       map[string][]string `json:"added,omitempty"`
+	Overwritten map[string][]string `json:"overwritten,omitempty"`
+	Skipped     map[string][]string `json:"skipped,omitempty"`
+	Conflicts   map[string][]string `json:"conflicts,omitempty"`
+	Failed      []iamImportError    `json:"failed,omitempty"`
+}
+
+type iamImporter struct {
+	onConflict string
+	dryRun     bool
+	result     iamImportResult
+}
+
+func addIAMEntry(m *map[string][]string, kind, name string) {
+	if *m == nil {
+		*m = make(map[string][]string)
+	}
+	(*m)[kind] = append((*m)[kind], name)
+}
+
+// importEntry imports an entry with fn, unless it is the same as the
+// existing entry or conflicts with it and conflicts are not overwritten.
+func (im *iamImporter) importEntry(kind, name string, exists, same bool, fn func() error) {
+	if exists {
+		if same {
+			return
+		}
+		addIAMEntry(&im.result.Conflicts, kind, name)
+		if im.onConflict != iamImportOverwrite {
+			if !im.dryRun {
+				addIAMEntry(&im.result.Skipped, kind, name)
+			}
+			return
+		}
+	}
+	if im.dryRun {
+		return
+	}
+	if err := fn(); err != nil {
+		im.result.Failed = append(im.result.Failed, iamImportError{Kind: kind, Name: name, Error: err.Error()})
+		return
+	}
+	if exists {
+		addIAMEntry(&im.result.Overwritten, kind, name)
+	} else {
+		addIAMEntry(&im.result.Added, kind, name)
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]iampolicy.Policy:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]iamExportUser:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]GroupInfo:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]iamExportServiceAccount:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]MappedPolicy:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ImportIAM - imports IAM state exported by ExportIAM, onConflict decides
+// how entries that differ from existing ones are handled. Dependencies are
+// imported first: policies, users and groups before their mappings, users
+// before the service accounts.
+func (sys *IAMSys) ImportIAM(ctx context.Context, e iamExport, onConflict string, dryRun bool) (iamImportResult, error) {
+	if !sys.Initialized() {
+		return iamImportResult{}, errServerNotInitialized
+	}
+
+	<-sys.configLoaded
+
+	switch onConflict {
+	case iamImportSkip, iamImportOverwrite, iamImportFail:
+	default:
+		return iamImportResult{}, errInvalidArgument
+	}
+
+	if onConflict == iamImportFail && !dryRun {
+		// Find the conflicts before importing anything.
+		result, err := sys.ImportIAM(ctx, e, onConflict, true)
+		if err != nil {
+			return result, err
+		}
+		if len(result.Conflicts) > 0 {
+			return result, errIAMImportConflict
+		}
+	}
+
+	existing, err := sys.store.exportIAM()
+	if err != nil {
+		return iamImportResult{}, err
+	}
+
+	im := &iamImporter{onConflict: onConflict, dryRun: dryRun}
+
+	for _, name := range sortedKeys(e.Policies) {
+		p := e.Policies[name]
+		cp, ok := existing.Policies[name]
+		if !ok {
+			// Unmodified canned policies are not exported.
+			if dp, err := sys.store.GetPolicy(name); err == nil {
+				cp, ok = dp, true
+			}
+		}
+		im.importEntry(iamEntryPolicy, name, ok, samePolicy(p, cp), func() error {
+			return sys.SetPolicy(ctx, name, p)
+		})
+	}
+
+	for _, name := range sortedKeys(e.Users) {
+		u := e.Users[name]
+		cu, ok := existing.Users[name]
+		im.importEntry(iamEntryUser, name, ok, sameJSON(u, cu), func() error {
+			return sys.CreateUser(ctx, name, madmin.UserInfo{SecretKey: u.SecretKey, Status: u.Status})
+		})
+	}
+
+	for _, name := range sortedKeys(e.Groups) {
+		gi := e.Groups[name]
+		cgi, ok := existing.Groups[name]
+		im.importEntry(iamEntryGroup, name, ok, sameJSON(gi, cgi), func() error {
+			return sys.importGroup(ctx, name, gi, cgi.Members)
+		})
+	}
+
+	for _, name := range sortedKeys(e.UserMappings) {
+		mp := e.UserMappings[name]
+		cmp, ok := existing.UserMappings[name]
+		im.importEntry(iamEntryUserMapping, name, ok, sameJSON(mp, cmp), func() error {
+			return sys.PolicyDBSet(ctx, name, mp.Policies, false)
+		})
+	}
+
+	for _, name := range sortedKeys(e.GroupMappings) {
+		mp := e.GroupMappings[name]
+		cmp, ok := existing.GroupMappings[name]
+		im.importEntry(iamEntryGroupMapping, name, ok, sameJSON(mp, cmp), func() error {
+			return sys.PolicyDBSet(ctx, name, mp.Policies, true)
+		})
+	}
+
+	for _, name := range sortedKeys(e.STSMappings) {
+		mp := e.STSMappings[name]
+		cmp, ok := existing.STSMappings[name]
+		im.importEntry(iamEntrySTSMapping, name, ok, sameJSON(mp, cmp), func() error {
+			return sys.PolicyDBSet(ctx, name, mp.Policies, false)
+		})
+	}
+
+	for _, name := range sortedKeys(e.ServiceAccounts) {
+		sa := e.ServiceAccounts[name]
+		csa, ok := existing.ServiceAccounts[name]
+		im.importEntry(iamEntryServiceAccount, name, ok, sameJSON(sa, csa), func() error {
+			return sys.importServiceAccount(ctx, name, sa, ok)
+		})
+	}
+
+	// Boundaries last, they may be attached to service accounts.
+	for _, name := range sortedKeys(e.Boundaries) {
+		mp := e.Boundaries[name]
+		cmp, ok := existing.Boundaries[name]
+		im.importEntry(iamEntryBoundary, name, ok, sameJSON(mp, cmp), func() error {
+			return sys.SetPermissionsBoundary(ctx, name, mp.Policies)
+		})
+	}
+
+	return im.result, nil
+}
+
+// importGroup sets the members and the status of a group, members not in
+// the imported group are removed.
+func (sys *IAMSys) importGroup(ctx context.Context, group string, gi GroupInfo, members []string) error {
+	if err := sys.AddUsersToGroup(ctx, group, gi.Members); err != nil {
+		return err
+	}
+	removed := set.CreateStringSet(members...).Difference(set.CreateStringSet(gi.Members...))
+	if !removed.IsEmpty() {
+		if err := sys.RemoveUsersFromGroup(ctx, group, removed.ToSlice()); err != nil {
+			return err
+		}
+	}
+	return sys.SetGroupStatus(ctx, group, gi.Status != statusDisabled)
+}
+
+// importServiceAccount creates a service account with its exported
+// credentials and claims, replacing the existing service account.
+func (sys *IAMSys) importServiceAccount(ctx context.Context, accessKey string, sa iamExportServiceAccount, exists bool) error {
+	parentUser := sa.ParentUser
+	if sa.RootParent {
+		parentUser = globalActiveCred.AccessKey
+	}
+	if parentUser == "" {
+		return errInvalidArgument
+	}
+
+	claims := make(map[string]interface{}, len(sa.Claims)+1)
+	for k, v := range sa.Claims {
+		claims[k] = v
+	}
+	claims[parentClaim] = parentUser
+
+	cred, err := auth.CreateNewCredentialsWithMetadata(accessKey, sa.SecretKey, claims, globalActiveCred.SecretKey)
+	if err != nil {
+		return err
+	}
+	cred.ParentUser = parentUser
+	cred.Groups = sa.Groups
+	cred.Status = sa.Status
+
+	if exists {
+		if err = sys.DeleteServiceAccount(ctx, accessKey); err != nil {
+			return err
+		}
+	}
+	if err = sys.store.AddServiceAccount(ctx, cred); err != nil {
+		return err
+	}
+
+	sys.notifyForServiceAccount(ctx, accessKey)
+	return nil
+}
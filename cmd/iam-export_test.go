@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func TestIAMExportImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	policy, err := iampolicy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::testbucket/*"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy(ctx, "testpolicy", *policy); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.CreateUser(ctx, "testuser1", madmin.UserInfo{SecretKey: "testsecret1", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup(ctx, "testgroup", []string{"testuser1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet(ctx, "testgroup", "testpolicy", true); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet(ctx, "testuser1", "readonly", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.NewServiceAccount(ctx, "testuser1", nil, newServiceAccountOpts{accessKey: "testsvcacct1", secretKey: "testsvcsecret1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.NewServiceAccount(ctx, globalActiveCred.AccessKey, nil, newServiceAccountOpts{accessKey: "testsvcacct2", secretKey: "testsvcsecret2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = writeIAMExportArchive(&buf, exported); err != nil {
+		t.Fatal(err)
+	}
+	e, err := readIAMExportArchive(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Policies) != 1 || !samePolicy(e.Policies["testpolicy"], *policy) {
+		t.Fatalf("Expected only testpolicy to be exported, got %v", e.Policies)
+	}
+	e.Policies = exported.Policies
+	if !sameJSON(e, exported) {
+		t.Fatal("Expected the archive to hold the exported IAM state")
+	}
+	if _, ok := e.Policies["readonly"]; ok {
+		t.Fatal("Expected unmodified canned policies to not be exported")
+	}
+	if sa := e.ServiceAccounts["testsvcacct2"]; !sa.RootParent || sa.ParentUser != "" {
+		t.Fatalf("Expected a root service account, got %#v", sa)
+	}
+
+	// Importing the same state changes nothing.
+	result, err := globalIAMSys.ImportIAM(ctx, e, iamImportFail, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added)+len(result.Overwritten)+len(result.Skipped)+len(result.Conflicts)+len(result.Failed) != 0 {
+		t.Fatalf("Expected no changes, got %#v", result)
+	}
+
+	if err = globalIAMSys.SetUserSecretKey(ctx, "testuser1", "changedsecret1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.DeleteServiceAccount(ctx, "testsvcacct1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Conflicts abort the import.
+	result, err = globalIAMSys.ImportIAM(ctx, e, iamImportFail, false)
+	if !errors.Is(err, errIAMImportConflict) {
+		t.Fatalf("Expected %v, got %v", errIAMImportConflict, err)
+	}
+	if len(result.Conflicts[iamEntryUser]) != 1 || len(result.Added) != 0 {
+		t.Fatalf("Unexpected import result %#v", result)
+	}
+	if _, ok := globalIAMSys.store.GetUser("testsvcacct1"); ok {
+		t.Fatal("Expected nothing to be imported")
+	}
+
+	result, err = globalIAMSys.ImportIAM(ctx, e, iamImportSkip, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Skipped[iamEntryUser]) != 1 || len(result.Added[iamEntryServiceAccount]) != 1 || len(result.Failed) != 0 {
+		t.Fatalf("Unexpected import result %#v", result)
+	}
+	if cred, _ := globalIAMSys.store.GetUser("testuser1"); cred.SecretKey != "changedsecret1" {
+		t.Fatal("Expected the existing user to be kept")
+	}
+	sa, _, err := globalIAMSys.GetServiceAccount(ctx, "testsvcacct1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa.ParentUser != "testuser1" {
+		t.Fatalf("Expected the service account of testuser1, got %#v", sa)
+	}
+
+	result, err = globalIAMSys.ImportIAM(ctx, e, iamImportOverwrite, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Overwritten[iamEntryUser]) != 1 || len(result.Failed) != 0 {
+		t.Fatalf("Unexpected import result %#v", result)
+	}
+	if cred, _ := globalIAMSys.store.GetUser("testuser1"); cred.SecretKey != "testsecret1" {
+		t.Fatal("Expected the user to be overwritten")
+	}
+
+	if _, err = globalIAMSys.ImportIAM(ctx, e, "merge", false); !errors.Is(err, errInvalidArgument) {
+		t.Fatalf("Expected %v, got %v", errInvalidArgument, err)
+	}
+}
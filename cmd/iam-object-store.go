@@ -385,6 +385,25 @@ func (iamOS *IAMObjectStore) loadMappedPolicies(ctx context.Context, userType IA
 	return nil
 }
 
+func (iamOS *IAMObjectStore) loadPermissionsBoundaries(ctx context.Context, m map[string]MappedPolicy) error {
+	for item := range listIAMConfigItems(ctx, iamOS.objAPI, iamConfigPolicyDBBoundariesPrefix) {
+		if item.Err != nil {
+			return item.Err
+		}
+
+		name := strings.TrimSuffix(item.Item, ".json")
+		var mp MappedPolicy
+		if err := iamOS.loadIAMConfig(ctx, &mp, getPermissionsBoundaryPath(name)); err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return err
+		}
+		m[name] = mp
+	}
+	return nil
+}
+
 func (iamOS *IAMObjectStore) savePolicyDoc(ctx context.Context, policyName string, p iampolicy.Policy) error {
 	return iamOS.saveIAMConfig(ctx, &p, getPolicyDocPath(policyName))
 }
@@ -58,6 +58,7 @@ const (
 	iamConfigPolicyDBSTSUsersPrefix        = iamConfigPolicyDBPrefix + "sts-users/"
 	iamConfigPolicyDBServiceAccountsPrefix = iamConfigPolicyDBPrefix + "service-accounts/"
 	iamConfigPolicyDBGroupsPrefix          = iamConfigPolicyDBPrefix + "groups/"
+	iamConfigPolicyDBBoundariesPrefix      = iamConfigPolicyDBPrefix + "boundaries/"
 
 	// IAM identity file which captures identity credentials.
 	iamIdentityFile = "identity.json"
@@ -121,6 +122,10 @@ func getMappedPolicyPath(name string, userType IAMUserType, isGroup bool) string
 	}
 }
 
+func getPermissionsBoundaryPath(name string) string {
+	return pathJoin(iamConfigPolicyDBBoundariesPrefix, name+".json")
+}
+
 // UserIdentity represents a user's secret key and their status
 type UserIdentity struct {
 	Version     int              `json:"version"`
@@ -193,6 +198,9 @@ type iamCache struct {
 	iamUserPolicyMap map[string]MappedPolicy
 	// map of group names to policy names
 	iamGroupPolicyMap map[string]MappedPolicy
+	// map of usernames, service accounts and role ARNs to the
+	// policy names of their permissions boundary
+	iamUserBoundaryMap map[string]MappedPolicy
 }
 
 func newIamCache() *iamCache {
@@ -203,6 +211,7 @@ func newIamCache() *iamCache {
 		iamUserGroupMemberships: map[string]set.StringSet{},
 		iamUserPolicyMap:        map[string]MappedPolicy{},
 		iamGroupPolicyMap:       map[string]MappedPolicy{},
+		iamUserBoundaryMap:      map[string]MappedPolicy{},
 	}
 }
 
@@ -344,6 +353,8 @@ type IAMStorageAPI interface {
 	loadMappedPolicy(ctx context.Context, name string, userType IAMUserType, isGroup bool, m map[string]MappedPolicy) error
 	loadMappedPolicies(ctx context.Context, userType IAMUserType, isGroup bool, m map[string]MappedPolicy) error
 
+	loadPermissionsBoundaries(ctx context.Context, m map[string]MappedPolicy) error
+
 	saveIAMConfig(ctx context.Context, item interface{}, path string, opts ...options) error
 	loadIAMConfig(ctx context.Context, item interface{}, path string) error
 	deleteIAMConfig(ctx context.Context, path string) error
@@ -423,6 +434,11 @@ func (store *IAMStoreSys) LoadIAMCache(ctx context.Context) error {
 		return err
 	}
 
+	// load permissions boundaries
+	if err := store.loadPermissionsBoundaries(ctx, newCache.iamUserBoundaryMap); err != nil {
+		return err
+	}
+
 	newCache.buildUserGroupMemberships()
 
 	cache.iamGroupPolicyMap = newCache.iamGroupPolicyMap
@@ -430,6 +446,7 @@ func (store *IAMStoreSys) LoadIAMCache(ctx context.Context) error {
 	cache.iamPolicyDocsMap = newCache.iamPolicyDocsMap
 	cache.iamUserGroupMemberships = newCache.iamUserGroupMemberships
 	cache.iamUserPolicyMap = newCache.iamUserPolicyMap
+	cache.iamUserBoundaryMap = newCache.iamUserBoundaryMap
 	cache.iamUsersMap = newCache.iamUsersMap
 
 	return nil
@@ -902,11 +919,18 @@ func (store *IAMStoreSys) DeletePolicy(ctx context.Context, policy string) error
 			groups = append(groups, g)
 		}
 	}
-	if len(users) != 0 || len(groups) != 0 {
+	boundaries := []string{}
+	for u, mp := range cache.iamUserBoundaryMap {
+		if mp.policySet().Contains(policy) {
+			boundaries = append(boundaries, u)
+		}
+	}
+	if len(users) != 0 || len(groups) != 0 || len(boundaries) != 0 {
 		// error out when a policy could not be deleted as it was in use.
-		loggedErr := fmt.Errorf("policy could not be deleted as it is use (users=%s; groups=%s)",
+		loggedErr := fmt.Errorf("policy could not be deleted as it is use (users=%s; groups=%s; boundaries=%s)",
 			fmt.Sprintf("[%s]", strings.Join(users, ",")),
 			fmt.Sprintf("[%s]", strings.Join(groups, ",")),
+			fmt.Sprintf("[%s]", strings.Join(boundaries, ",")),
 		)
 		logger.LogIf(GlobalContext, loggedErr)
 		return errPolicyInUse
@@ -1180,6 +1204,103 @@ func (store *IAMStoreSys) PolicyMappingNotificationHandler(ctx context.Context,
 	return err
 }
 
+// SetPermissionsBoundary - sets the permissions boundary of a user, service
+// account or role ARN, an empty policy removes it.
+func (store *IAMStoreSys) SetPermissionsBoundary(ctx context.Context, name, policy string) error {
+	if name == "" {
+		return errInvalidArgument
+	}
+
+	cache := store.lock()
+	defer store.unlock()
+
+	if policy == "" {
+		err := store.deleteIAMConfig(ctx, getPermissionsBoundaryPath(name))
+		if err != nil && err != errConfigNotFound {
+			return err
+		}
+		delete(cache.iamUserBoundaryMap, name)
+		return nil
+	}
+
+	mp := newMappedPolicy(policy)
+	for _, p := range mp.toSlice() {
+		if _, found := cache.iamPolicyDocsMap[p]; !found {
+			logger.LogIf(GlobalContext, fmt.Errorf("%w: (%s)", errNoSuchPolicy, p))
+			return errNoSuchPolicy
+		}
+	}
+	if err := store.saveIAMConfig(ctx, mp, getPermissionsBoundaryPath(name)); err != nil {
+		return err
+	}
+	cache.iamUserBoundaryMap[name] = mp
+	return nil
+}
+
+// GetPermissionsBoundary - returns the policies of the permissions boundary
+// of a user, service account or role ARN.
+func (store *IAMStoreSys) GetPermissionsBoundary(name string) (MappedPolicy, bool) {
+	cache := store.rlock()
+	defer store.runlock()
+
+	mp, ok := cache.iamUserBoundaryMap[name]
+	return mp, ok
+}
+
+// PermissionsBoundaryNotificationHandler - handles updating a permissions
+// boundary from storage.
+func (store *IAMStoreSys) PermissionsBoundaryNotificationHandler(ctx context.Context, name string) error {
+	if name == "" {
+		return errInvalidArgument
+	}
+
+	cache := store.lock()
+	defer store.unlock()
+
+	var mp MappedPolicy
+	err := store.loadIAMConfig(ctx, &mp, getPermissionsBoundaryPath(name))
+	if err == errConfigNotFound || (err == nil && len(mp.toSlice()) == 0) {
+		// The permissions boundary was removed.
+		delete(cache.iamUserBoundaryMap, name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cache.iamUserBoundaryMap[name] = mp
+	return nil
+}
+
+// IsAllowedByPermissionsBoundaries - checks that the permissions boundaries
+// set on any of names allow the request. Names without a permissions boundary
+// are ignored, a boundary referring to a missing policy denies the request.
+func (store *IAMStoreSys) IsAllowedByPermissionsBoundaries(args iampolicy.Args, names ...string) bool {
+	cache := store.rlock()
+	defer store.runlock()
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		mp, ok := cache.iamUserBoundaryMap[name]
+		if !ok {
+			continue
+		}
+		var boundary iampolicy.Policy
+		for _, policy := range mp.toSlice() {
+			p, found := cache.iamPolicyDocsMap[policy]
+			if !found {
+				return false
+			}
+			boundary = boundary.Merge(p)
+		}
+		if !boundary.IsAllowed(args) {
+			return false
+		}
+	}
+	return true
+}
+
 // UserNotificationHandler - handles updating a user/STS account/service account
 // from storage.
 func (store *IAMStoreSys) UserNotificationHandler(ctx context.Context, accessKey string, userType IAMUserType) error {
@@ -1214,6 +1335,7 @@ func (store *IAMStoreSys) UserNotificationHandler(ctx context.Context, accessKey
 			for _, u := range cache.iamUsersMap {
 				if u.IsServiceAccount() && u.ParentUser == accessKey {
 					delete(cache.iamUsersMap, u.AccessKey)
+					delete(cache.iamUserBoundaryMap, u.AccessKey)
 				}
 				if u.IsTemp() && u.ParentUser == accessKey {
 					delete(cache.iamUsersMap, u.AccessKey)
@@ -1221,8 +1343,9 @@ func (store *IAMStoreSys) UserNotificationHandler(ctx context.Context, accessKey
 			}
 		}
 
-		// 3. Delete any mapped policy
+		// 3. Delete any mapped policy and permissions boundary
 		delete(cache.iamUserPolicyMap, accessKey)
+		delete(cache.iamUserBoundaryMap, accessKey)
 		return nil
 	}
 	if err != nil {
@@ -1285,7 +1408,9 @@ func (store *IAMStoreSys) DeleteUser(ctx context.Context, accessKey string, user
 		for _, u := range cache.iamUsersMap {
 			if u.IsServiceAccount() && u.ParentUser == accessKey {
 				_ = store.deleteUserIdentity(ctx, u.AccessKey, svcUser)
+				_ = store.deleteIAMConfig(ctx, getPermissionsBoundaryPath(u.AccessKey))
 				delete(cache.iamUsersMap, u.AccessKey)
+				delete(cache.iamUserBoundaryMap, u.AccessKey)
 			}
 			// Delete any associated STS users.
 			if u.IsTemp() && u.ParentUser == accessKey {
@@ -1299,6 +1424,10 @@ func (store *IAMStoreSys) DeleteUser(ctx context.Context, accessKey string, user
 	store.deleteMappedPolicy(ctx, accessKey, userType, false)
 	delete(cache.iamUserPolicyMap, accessKey)
 
+	// Same for the permissions boundary
+	store.deleteIAMConfig(ctx, getPermissionsBoundaryPath(accessKey))
+	delete(cache.iamUserBoundaryMap, accessKey)
+
 	err := store.deleteUserIdentity(ctx, accessKey, userType)
 	if err == errNoSuchUser {
 		// ignore if user is already deleted.
@@ -1502,27 +1631,49 @@ func (store *IAMStoreSys) UpdateServiceAccount(ctx context.Context, accessKey st
 		return errors.New("unknown account status value")
 	}
 
-	if opts.sessionPolicy != nil {
-		m, err := getClaimsFromToken(cr.SessionToken)
+	if opts.sessionPolicy != nil || opts.expiration != nil || opts.sourceIPs != nil {
+		claims, err := auth.ExtractClaims(cr.SessionToken, globalActiveCred.SecretKey)
 		if err != nil {
 			return fmt.Errorf("unable to get svc acc claims: %v", err)
 		}
+		m := claims.Map()
 
-		err = opts.sessionPolicy.Validate()
-		if err != nil {
-			return err
+		if opts.sessionPolicy != nil {
+			err = opts.sessionPolicy.Validate()
+			if err != nil {
+				return err
+			}
+			policyBuf, err := json.Marshal(opts.sessionPolicy)
+			if err != nil {
+				return err
+			}
+			if len(policyBuf) > 16*humanize.KiByte {
+				return fmt.Errorf("Session policy should not exceed 16 KiB characters")
+			}
+
+			// Overwrite session policy claims.
+			m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString(policyBuf)
+			m[iamPolicyClaimNameSA()] = "embedded-policy"
 		}
-		policyBuf, err := json.Marshal(opts.sessionPolicy)
+
+		// Keep the restrictions not being updated.
+		expiration, networks, err := getSvcAccRestrictions(m)
 		if err != nil {
 			return err
 		}
-		if len(policyBuf) > 16*humanize.KiByte {
-			return fmt.Errorf("Session policy should not exceed 16 KiB characters")
+		if opts.expiration != nil {
+			expiration = *opts.expiration
+		}
+		sourceIPs := opts.sourceIPs
+		if sourceIPs == nil {
+			for _, network := range networks {
+				sourceIPs = append(sourceIPs, network.String())
+			}
+		}
+		if err = setSvcAccRestrictions(m, expiration, sourceIPs); err != nil {
+			return err
 		}
 
-		// Overwrite session policy claims.
-		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString(policyBuf)
-		m[iamPolicyClaimNameSA()] = "embedded-policy"
 		cr.SessionToken, err = auth.JWTSignWithAccessKey(accessKey, m, globalActiveCred.SecretKey)
 		if err != nil {
 			return err
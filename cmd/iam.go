@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"path"
 	"sort"
 	"strings"
@@ -123,7 +124,15 @@ func (sys *IAMSys) LoadPolicyMapping(ctx context.Context, objAPI ObjectLayer, us
 		userType = stsUser
 	}
 
-	return sys.store.PolicyMappingNotificationHandler(ctx, userOrGroup, isGroup, userType)
+	if err := sys.store.PolicyMappingNotificationHandler(ctx, userOrGroup, isGroup, userType); err != nil {
+		return err
+	}
+	if isGroup {
+		return nil
+	}
+
+	// Permissions boundaries are mapped to users as well.
+	return sys.store.PermissionsBoundaryNotificationHandler(ctx, userOrGroup)
 }
 
 // LoadUser - reloads a specific user from backend disks or etcd.
@@ -288,7 +297,7 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 
 	// Set up polling for expired accounts and credentials purging.
 	switch {
-	case globalOpenIDConfig.ProviderEnabled():
+	case globalOpenIDProviders.ProviderEnabled():
 		go func() {
 			ticker := time.NewTicker(sys.iamRefreshInterval)
 			defer ticker.Stop()
@@ -320,18 +329,28 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 	// Start watching changes to storage.
 	go sys.watch(ctx)
 
-	// Load RoleARN
-	if roleARN, rolePolicy, enabled := globalOpenIDConfig.GetRoleInfo(); enabled {
+	// Load RoleARNs of all OpenID providers.
+	rolesMap := make(map[arn.ARN]string)
+	for _, cfg := range globalOpenIDProviders.List() {
+		roleARN, rolePolicy, enabled := cfg.GetRoleInfo()
+		if !enabled {
+			continue
+		}
 		numPolicies := len(strings.Split(rolePolicy, ","))
 		validPolicies, _ := sys.store.FilterPolicies(rolePolicy, "")
 		numValidPolicies := len(strings.Split(validPolicies, ","))
 		if numPolicies != numValidPolicies {
-			logger.LogIf(ctx, fmt.Errorf("Some specified role policies (%s) were not defined - role based policies will not be enabled.", rolePolicy))
-			return
+			logger.LogIf(ctx, fmt.Errorf("Some specified role policies (%s) of the OpenID provider '%s' were not defined - role based policies will not be enabled.", rolePolicy, cfg.Target))
+			continue
 		}
-		sys.rolesMap = map[arn.ARN]string{
-			roleARN: rolePolicy,
+		if _, ok := rolesMap[roleARN]; ok {
+			logger.LogIf(ctx, fmt.Errorf("The OpenID provider '%s' has the same role ARN %s as another provider - role based policies will not be enabled.", cfg.Target, roleARN))
+			continue
 		}
+		rolesMap[roleARN] = rolePolicy
+	}
+	if len(rolesMap) > 0 {
+		sys.rolesMap = rolesMap
 	}
 
 	sys.printIAMRoles()
@@ -397,6 +416,7 @@ func (sys *IAMSys) loadWatchedEvent(ctx context.Context, event iamWatchEvent) (e
 	policyDBUsersPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBUsersPrefix)
 	policyDBSTSUsersPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBSTSUsersPrefix)
 	policyDBGroupsPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBGroupsPrefix)
+	policyDBBoundariesPrefix := strings.HasPrefix(event.keyPath, iamConfigPolicyDBBoundariesPrefix)
 
 	ctx, cancel := context.WithTimeout(ctx, defaultContextTimeout)
 	defer cancel()
@@ -429,6 +449,10 @@ func (sys *IAMSys) loadWatchedEvent(ctx context.Context, event iamWatchEvent) (e
 		policyMapFile := strings.TrimPrefix(event.keyPath, iamConfigPolicyDBGroupsPrefix)
 		user := strings.TrimSuffix(policyMapFile, ".json")
 		err = sys.store.PolicyMappingNotificationHandler(ctx, user, true, regUser)
+	case policyDBBoundariesPrefix:
+		boundaryFile := strings.TrimPrefix(event.keyPath, iamConfigPolicyDBBoundariesPrefix)
+		name := strings.TrimSuffix(boundaryFile, ".json")
+		err = sys.store.PermissionsBoundaryNotificationHandler(ctx, name)
 	}
 	return err
 }
@@ -682,9 +706,95 @@ type newServiceAccountOpts struct {
 	accessKey     string
 	secretKey     string
 
+	// Optional time after which the service account is rejected and
+	// networks it may only be used from.
+	expiration time.Time
+	sourceIPs  []string
+
 	claims map[string]interface{}
 }
 
+// JWT claims restricting when and from where a service account may be
+// used, kept in its signed session token.
+const (
+	svcAccExpirationClaim = "svcAccExpiration"
+	svcAccSourceIPsClaim  = "svcAccSourceIPs"
+)
+
+// setSvcAccRestrictions sets the expiration and source IP claims of a
+// service account, a zero expiration or no source IPs remove the
+// restriction.
+func setSvcAccRestrictions(m map[string]interface{}, expiration time.Time, sourceIPs []string) error {
+	if expiration.IsZero() {
+		delete(m, svcAccExpirationClaim)
+	} else {
+		if !expiration.After(UTCNow()) {
+			return errInvalidArgument
+		}
+		m[svcAccExpirationClaim] = expiration.Unix()
+	}
+
+	if len(sourceIPs) == 0 {
+		delete(m, svcAccSourceIPsClaim)
+		return nil
+	}
+	networks := make([]string, 0, len(sourceIPs))
+	for _, sourceIP := range sourceIPs {
+		network, err := parseSvcAccSourceIP(sourceIP)
+		if err != nil {
+			return err
+		}
+		networks = append(networks, network.String())
+	}
+	m[svcAccSourceIPsClaim] = strings.Join(networks, ",")
+	return nil
+}
+
+// getSvcAccRestrictions returns the expiration and the source networks
+// of a service account from its claims.
+func getSvcAccRestrictions(claims map[string]interface{}) (expiration time.Time, networks []*net.IPNet, err error) {
+	if v, ok := claims[svcAccExpirationClaim]; ok {
+		expAt, err := auth.ExpToInt64(v)
+		if err != nil {
+			return expiration, nil, err
+		}
+		expiration = time.Unix(expAt, 0).UTC()
+	}
+
+	if v, ok := claims[svcAccSourceIPsClaim].(string); ok && v != "" {
+		for _, sourceIP := range strings.Split(v, ",") {
+			network, err := parseSvcAccSourceIP(sourceIP)
+			if err != nil {
+				return expiration, nil, err
+			}
+			networks = append(networks, network)
+		}
+	}
+	return expiration, networks, nil
+}
+
+// parseSvcAccSourceIP parses a network in CIDR notation, a single IP
+// address is a network of one host.
+func parseSvcAccSourceIP(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, errInvalidArgument
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errInvalidArgument
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(8*net.IPv4len, 8*net.IPv4len)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(8*net.IPv6len, 8*net.IPv6len)}, nil
+}
+
 // NewServiceAccount - create a new service account
 func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, groups []string, opts newServiceAccountOpts) (auth.Credentials, error) {
 	if !sys.Initialized() {
@@ -726,6 +836,10 @@ func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, gro
 		m[iamPolicyClaimNameSA()] = "inherited-policy"
 	}
 
+	if err := setSvcAccRestrictions(m, opts.expiration, opts.sourceIPs); err != nil {
+		return auth.Credentials{}, err
+	}
+
 	// Add all the necessary claims for the service accounts.
 	for k, v := range opts.claims {
 		_, ok := m[k]
@@ -764,6 +878,11 @@ type updateServiceAccountOpts struct {
 	sessionPolicy *iampolicy.Policy
 	secretKey     string
 	status        string
+
+	// Replace the expiration when set, a zero time removes it.
+	expiration *time.Time
+	// Replace the source IPs when not nil, an empty list removes them.
+	sourceIPs []string
 }
 
 // UpdateServiceAccount - edit a service account
@@ -858,6 +977,25 @@ func (sys *IAMSys) GetClaimsForSvcAcc(ctx context.Context, accessKey string) (ma
 	return jwtClaims.Map(), nil
 }
 
+// GetServiceAccountRestrictions - returns the expiration and the source
+// networks a service account is restricted to.
+func (sys *IAMSys) GetServiceAccountRestrictions(ctx context.Context, accessKey string) (time.Time, []*net.IPNet, error) {
+	if !sys.Initialized() {
+		return time.Time{}, nil, errServerNotInitialized
+	}
+
+	sa, ok := sys.store.GetUser(accessKey)
+	if !ok || !sa.IsServiceAccount() {
+		return time.Time{}, nil, errNoSuchServiceAccount
+	}
+
+	claims, err := auth.ExtractClaims(sa.SessionToken, globalActiveCred.SecretKey)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return getSvcAccRestrictions(claims.Map())
+}
+
 // DeleteServiceAccount - delete a service account
 func (sys *IAMSys) DeleteServiceAccount(ctx context.Context, accessKey string) error {
 	if !sys.Initialized() {
@@ -927,11 +1065,11 @@ func (sys *IAMSys) purgeExpiredCredentialsForExternalSSO(ctx context.Context) {
 	parentUsers := sys.store.GetAllParentUsers()
 	var expiredUsers []string
 	for _, parentUser := range parentUsers {
-		userid, err := parseOpenIDParentUser(parentUser)
+		userid, issuer, err := parseOpenIDParentUser(parentUser)
 		if err == errSkipFile {
 			continue
 		}
-		u, err := globalOpenIDConfig.LookupUser(userid)
+		u, err := globalOpenIDProviders.ForIssuer(issuer).LookupUser(userid)
 		if err != nil {
 			logger.LogIf(GlobalContext, err)
 			continue
@@ -1176,7 +1314,7 @@ func (sys *IAMSys) PolicyDBSet(ctx context.Context, name, policy string, isGroup
 
 	err := sys.store.PolicyDBSet(ctx, name, policy, userType, isGroup)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	// Notify all other MinIO peers to reload policy
@@ -1192,6 +1330,66 @@ func (sys *IAMSys) PolicyDBSet(ctx context.Context, name, policy string, isGroup
 	return nil
 }
 
+// SetPermissionsBoundary - attaches a permissions boundary to a user, service
+// account or role ARN, an empty policy removes it. Requests are only allowed
+// when permitted by both the identity policies and the permissions boundary.
+func (sys *IAMSys) SetPermissionsBoundary(ctx context.Context, name, policy string) error {
+	if !sys.Initialized() {
+		return errServerNotInitialized
+	}
+
+	if name == globalActiveCred.AccessKey {
+		return errIAMActionNotAllowed
+	}
+
+	// Role ARNs accept a permissions boundary as well, which applies to
+	// all credentials obtained by assuming the role.
+	if roleArn, err := arn.Parse(name); err != nil || sys.rolesMap[roleArn] == "" {
+		cred, ok := sys.store.GetUser(name)
+		switch {
+		case ok && cred.IsTemp():
+			return errIAMActionNotAllowed
+		case !ok && sys.usersSysType == MinIOUsersSysType:
+			return errNoSuchUser
+		}
+	}
+
+	if err := sys.store.SetPermissionsBoundary(ctx, name, policy); err != nil {
+		return err
+	}
+
+	// Notify all other MinIO peers to reload the permissions boundary
+	if !sys.HasWatcher() {
+		for _, nerr := range sys.notificationSys.LoadPolicyMapping(name, false) {
+			if nerr.Err != nil {
+				logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+				logger.LogIf(ctx, nerr.Err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetPermissionsBoundary - returns the policies of the permissions boundary
+// attached to a user, service account or role ARN.
+func (sys *IAMSys) GetPermissionsBoundary(name string) (string, error) {
+	if !sys.Initialized() {
+		return "", errServerNotInitialized
+	}
+
+	mp, ok := sys.store.GetPermissionsBoundary(name)
+	if !ok {
+		return "", errNoSuchPolicy
+	}
+	return strings.Join(mp.toSlice(), ","), nil
+}
+
+// isAllowedByPermissionsBoundary - checks the permissions boundaries of the
+// account, of its parent user and of the role it assumed, if any.
+func (sys *IAMSys) isAllowedByPermissionsBoundary(args iampolicy.Args, parentUser string) bool {
+	return sys.store.IsAllowedByPermissionsBoundaries(args, args.AccountName, parentUser, args.GetRoleArn())
+}
+
 // PolicyDBGet - gets policy set on a user or group. If a list of groups is
 // given, policies associated with them are included as well.
 func (sys *IAMSys) PolicyDBGet(name string, isGroup bool, groups ...string) ([]string, error) {
@@ -1246,7 +1444,7 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 		} else {
 			// If there is no roleArn claim, check the OpenID
 			// provider's policy claim.
-			policySet, _ := iampolicy.GetPoliciesFromClaims(args.Claims, iamPolicyClaimNameOpenID())
+			policySet, _ := iampolicy.GetPoliciesFromClaims(args.Claims, iamPolicyClaimNameOpenIDFor(args.Claims))
 			svcPolicies = policySet.ToSlice()
 		}
 		if len(svcPolicies) == 0 {
@@ -1375,7 +1573,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args, parentUser string) bool {
 	} else {
 		// If roleArn is not used, we fall back to using policy claim
 		// from JWT.
-		policySet, ok := args.GetPolicies(iamPolicyClaimNameOpenID())
+		policySet, ok := args.GetPolicies(iamPolicyClaimNameOpenIDFor(args.Claims))
 		if !ok {
 			// When claims are set, it should have a policy claim field.
 			return false
@@ -1409,7 +1607,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args, parentUser string) bool {
 			_, err := sys.store.GetPolicy(pname)
 			if err == errNoSuchPolicy {
 				// all policies presented in the claim should exist
-				logger.LogIf(GlobalContext, fmt.Errorf("expected policy (%s) missing from the JWT claim %s, rejecting the request", pname, iamPolicyClaimNameOpenID()))
+				logger.LogIf(GlobalContext, fmt.Errorf("expected policy (%s) missing from the JWT claim %s, rejecting the request", pname, iamPolicyClaimNameOpenIDFor(args.Claims)))
 				return false
 			}
 		}
@@ -1496,7 +1694,7 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return false
 	}
 	if ok {
-		return sys.IsAllowedSTS(args, parentUser)
+		return sys.IsAllowedSTS(args, parentUser) && sys.isAllowedByPermissionsBoundary(args, parentUser)
 	}
 
 	// If the credential is for a service account, perform related check
@@ -1505,7 +1703,7 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return false
 	}
 	if ok {
-		return sys.IsAllowedServiceAccount(args, parentUser)
+		return sys.IsAllowedServiceAccount(args, parentUser) && sys.isAllowedByPermissionsBoundary(args, parentUser)
 	}
 
 	// Continue with the assumption of a regular user
@@ -1520,7 +1718,7 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	}
 
 	// Policies were found, evaluate all of them.
-	return sys.GetCombinedPolicy(policies...).IsAllowed(args)
+	return sys.GetCombinedPolicy(policies...).IsAllowed(args) && sys.isAllowedByPermissionsBoundary(args, "")
 }
 
 // EnableLDAPSys - enable ldap system users type.
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/fips"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/rest"
+	"github.com/minio/pkg/certs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Values accepted by MINIO_INTERNODE_TRANSPORT.
+const (
+	internodeTransportHTTP = "http"
+	internodeTransportGRPC = "grpc"
+
+	defaultInternodeGRPCPort = "9100"
+)
+
+// Keepalive pings detect dead connections between calls, the server
+// must permit the client interval or it closes the connection.
+const (
+	internodeGRPCKeepaliveTime    = 15 * time.Second
+	internodeGRPCKeepaliveTimeout = 10 * time.Second
+)
+
+var (
+	internodeGRPCConnsMu sync.Mutex
+	internodeGRPCConns   = map[string]*grpc.ClientConn{}
+)
+
+// newInternodeRESTClient returns a REST client for internode calls to
+// serverURL, all clients of the same host share one gRPC connection
+// when MINIO_INTERNODE_TRANSPORT is set to grpc.
+func newInternodeRESTClient(serverURL *url.URL) *rest.Client {
+	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	if globalInternodeGRPCPort != "" {
+		restClient.GRPCConn = internodeGRPCConn(serverURL.Host)
+	}
+	return restClient
+}
+
+// internodeGRPCConn returns the gRPC connection to the internode gRPC
+// server of host, the connection is established lazily on first use.
+func internodeGRPCConn(host string) *grpc.ClientConn {
+	internodeGRPCConnsMu.Lock()
+	defer internodeGRPCConnsMu.Unlock()
+
+	if conn, ok := internodeGRPCConns[host]; ok {
+		return conn
+	}
+
+	target := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		target = net.JoinHostPort(h, globalInternodeGRPCPort)
+	}
+
+	dial := xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(rest.DefaultTimeout))
+	opts := append(rest.GRPCDialOptions(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                internodeGRPCKeepaliveTime,
+			Timeout:             internodeGRPCKeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dial(ctx, "tcp", addr)
+		}),
+	)
+	if globalIsTLS {
+		opts = append(opts, grpc.WithTransportCredentials(newInternodeGRPCCredentials(&tls.Config{
+			CipherSuites:     fips.CipherSuitesTLS(),
+			CurvePreferences: fips.EllipticCurvesTLS(),
+		})))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	// Dial does not block, it only fails on invalid options.
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		logger.LogIf(GlobalContext, err)
+		return nil
+	}
+	internodeGRPCConns[host] = conn
+	return conn
+}
+
+// startInternodeGRPCServer serves the internode REST APIs of handler over
+// gRPC until GlobalContext is canceled.
+func startInternodeGRPCServer(handler http.Handler, getCert certs.GetCertificateFunc) error {
+	l, err := net.Listen("tcp", net.JoinHostPort(globalMinioHost, globalInternodeGRPCPort))
+	if err != nil {
+		return err
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             internodeGRPCKeepaliveTime / 2,
+			PermitWithoutStream: true,
+		}),
+	}
+	if tlsConfig := newTLSConfig(getCert); tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	srv := rest.NewGRPCServer(handler, []string{
+		storageRESTPrefix,
+		peerRESTPrefix,
+		lockRESTPrefix,
+		bootstrapRESTPrefix,
+	}, opts...)
+	go func() {
+		<-GlobalContext.Done()
+		srv.Stop()
+	}()
+	return srv.Serve(l)
+}
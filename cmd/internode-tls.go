@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/certs"
+	"github.com/rjeczalik/notify"
+	"google.golang.org/grpc/credentials"
+)
+
+// Certificates are usually replaced one file after another,
+// changes are picked up once no file changed for this long.
+const internodeCertsReloadDelay = time.Second
+
+// internodeRootCAs holds the root CAs internode TLS connections are
+// verified against. Unlike globalRootCAs it is replaced when the
+// certificates on disk change, see watchInternodeRootCAs.
+var internodeRootCAs atomic.Value
+
+// getInternodeRootCAs returns the current internode root CAs.
+func getInternodeRootCAs() *x509.CertPool {
+	if rootCAs, ok := internodeRootCAs.Load().(*x509.CertPool); ok {
+		return rootCAs
+	}
+	return globalRootCAs
+}
+
+// loadInternodeRootCAs reads the root CAs the same way they are read on
+// startup, the CAs directory plus the public certificate of this server.
+func loadInternodeRootCAs() (*x509.CertPool, error) {
+	rootCAs, err := certs.GetRootCAs(globalCertsCADir.Get())
+	if err != nil {
+		return nil, err
+	}
+	if isFile(getPublicCertFile()) {
+		publicCerts, err := config.ParsePublicCertFile(getPublicCertFile())
+		if err != nil {
+			return nil, err
+		}
+		for _, publicCrt := range publicCerts {
+			rootCAs.AddCert(publicCrt)
+		}
+	}
+	return rootCAs, nil
+}
+
+// reloadInternodeRootCAs replaces the internode root CAs and closes idle
+// internode connections, so that the following calls handshake again.
+// The current root CAs are kept if the new ones cannot be read.
+func reloadInternodeRootCAs(ctx context.Context) {
+	rootCAs, err := loadInternodeRootCAs()
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to reload root CAs, keeping the current ones: %w", err))
+		return
+	}
+	internodeRootCAs.Store(rootCAs)
+
+	if tr, ok := globalInternodeTransport.(interface{ CloseIdleConnections() }); ok {
+		tr.CloseIdleConnections()
+	}
+	logger.Info("Reloaded internode root CAs from %s", globalCertsDir.Get())
+}
+
+// watchInternodeRootCAs reloads the internode root CAs whenever a file in
+// the certs directory changes or on SIGHUP, until ctx is canceled. The
+// server certificates themselves are reloaded by globalTLSCerts.
+func watchInternodeRootCAs(ctx context.Context) {
+	events := make(chan notify.EventInfo, 1)
+	if err := notify.Watch(filepath.Join(globalCertsDir.Get(), "..."), events, notify.All); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to watch %s for certificate changes: %w", globalCertsDir.Get(), err))
+		return
+	}
+	defer notify.Stop(events)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-events:
+			reload = time.After(internodeCertsReloadDelay)
+		case <-sigs:
+			reloadInternodeRootCAs(ctx)
+		case <-reload:
+			reload = nil
+			reloadInternodeRootCAs(ctx)
+		}
+	}
+}
+
+// internodeTLSConfig returns a copy of tlsConfig for a new internode
+// connection to addr, verifying the server against the current root CAs.
+func internodeTLSConfig(tlsConfig *tls.Config, addr string) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.RootCAs = getInternodeRootCAs()
+	if tlsConfig.ServerName == "" && addr != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		tlsConfig.ServerName = host
+	}
+	return tlsConfig
+}
+
+// newInternodeDialTLSContext returns a dialer for internode HTTP transports
+// which handshakes every connection with internodeTLSConfig.
+func newInternodeDialTLSContext(tlsConfig *tls.Config, dial xhttp.DialContext, handshakeTimeout time.Duration) xhttp.DialContext {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+		defer cancel()
+		tlsConn := tls.Client(conn, internodeTLSConfig(tlsConfig, addr))
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
+
+// internodeGRPCCredentials is the gRPC counterpart of
+// newInternodeDialTLSContext.
+type internodeGRPCCredentials struct {
+	credentials.TransportCredentials
+	tlsConfig *tls.Config
+}
+
+func newInternodeGRPCCredentials(tlsConfig *tls.Config) credentials.TransportCredentials {
+	return internodeGRPCCredentials{
+		TransportCredentials: credentials.NewTLS(tlsConfig),
+		tlsConfig:            tlsConfig,
+	}
+}
+
+func (c internodeGRPCCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(internodeTLSConfig(c.tlsConfig, authority)).ClientHandshake(ctx, authority, conn)
+}
+
+func (c internodeGRPCCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestInternodeRootCAsReload(t *testing.T) {
+	certsDir, err := ioutil.TempDir("", "minio-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(certsDir)
+	caDir := filepath.Join(certsDir, certsCADir)
+	if err = os.Mkdir(caDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	prevCertsDir, prevCertsCADir, prevTransport := globalCertsDir, globalCertsCADir, globalInternodeTransport
+	prevRootCAs := getInternodeRootCAs()
+	defer func() {
+		globalCertsDir, globalCertsCADir, globalInternodeTransport = prevCertsDir, prevCertsCADir, prevTransport
+		if prevRootCAs != nil {
+			internodeRootCAs.Store(prevRootCAs)
+		}
+	}()
+	globalCertsDir = &ConfigDir{path: certsDir}
+	globalCertsCADir = &ConfigDir{path: caDir}
+
+	oldCA, oldCAKey := newTestCertificate(t, "old CA", nil, nil, true)
+	newCA, newCAKey := newTestCertificate(t, "new CA", nil, nil, true)
+	writeCA := func(name string, ca *x509.Certificate) {
+		pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+		if err := ioutil.WriteFile(filepath.Join(caDir, name), pemBytes, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	serverCert := func(ca *x509.Certificate, caKey *ecdsa.PrivateKey) *tls.Certificate {
+		cert, key := newTestCertificate(t, "127.0.0.1", ca, caKey, false)
+		return &tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key}
+	}
+
+	var current atomic.Value
+	current.Store(serverCert(oldCA, oldCAKey))
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Listener = tls.NewListener(server.Listener, &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return current.Load().(*tls.Certificate), nil
+		},
+	})
+	server.Start()
+	defer server.Close()
+	serverURL := "https://" + server.Listener.Addr().String()
+
+	globalInternodeTransport = newInternodeHTTPTransport(&tls.Config{}, time.Second)()
+	get := func() error {
+		req, err := http.NewRequest(http.MethodGet, serverURL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := globalInternodeTransport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	writeCA("old.crt", oldCA)
+	reloadInternodeRootCAs(context.Background())
+	if err = get(); err != nil {
+		t.Fatalf("expected the server to be trusted: %v", err)
+	}
+
+	// The server rotates to a certificate of a CA which is not trusted yet,
+	// connections established before the rotation are closed on reload.
+	current.Store(serverCert(newCA, newCAKey))
+	reloadInternodeRootCAs(context.Background())
+	if err = get(); err == nil {
+		t.Fatal("expected the rotated server certificate to be rejected")
+	}
+
+	writeCA("new.crt", newCA)
+	reloadInternodeRootCAs(context.Background())
+	if err = get(); err != nil {
+		t.Fatalf("expected the rotated server certificate to be trusted: %v", err)
+	}
+
+	// Unreadable CAs keep the current ones.
+	if err = ioutil.WriteFile(filepath.Join(caDir, "broken.crt"), []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reloadInternodeRootCAs(context.Background())
+	if err = get(); err != nil {
+		t.Fatalf("expected the current root CAs to be kept: %v", err)
+	}
+}
+
+func TestWatchInternodeRootCAs(t *testing.T) {
+	certsDir, err := ioutil.TempDir("", "minio-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(certsDir)
+	caDir := filepath.Join(certsDir, certsCADir)
+	if err = os.Mkdir(caDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	prevCertsDir, prevCertsCADir := globalCertsDir, globalCertsCADir
+	prevRootCAs := getInternodeRootCAs()
+	defer func() {
+		globalCertsDir, globalCertsCADir = prevCertsDir, prevCertsCADir
+		if prevRootCAs != nil {
+			internodeRootCAs.Store(prevRootCAs)
+		}
+	}()
+	globalCertsDir = &ConfigDir{path: certsDir}
+	globalCertsCADir = &ConfigDir{path: caDir}
+
+	initial := x509.NewCertPool()
+	internodeRootCAs.Store(initial)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchInternodeRootCAs(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	// Give the watcher time to register.
+	time.Sleep(100 * time.Millisecond)
+
+	ca, _ := newTestCertificate(t, "CA", nil, nil, true)
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	if err = ioutil.WriteFile(filepath.Join(caDir, "ca.crt"), pemBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(10 * internodeCertsReloadDelay)
+	for getInternodeRootCAs() == initial {
+		if time.Now().After(deadline) {
+			t.Fatal("root CAs were not reloaded after the CAs directory changed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if _, err = ca.Verify(x509.VerifyOptions{Roots: getInternodeRootCAs()}); err != nil {
+		t.Fatalf("expected the new CA to be trusted: %v", err)
+	}
+}
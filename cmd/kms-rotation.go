@@ -0,0 +1,411 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/logger"
+	"golang.org/x/time/rate"
+)
+
+const (
+	kmsRotationMetaName = "kms-rotation.json"
+
+	// Number of objects re-wrapped per second unless specified.
+	kmsRotationDefaultRate = 100
+
+	// Time between two checks for a key rotation to run or resume.
+	kmsRotationCheckInterval = time.Minute
+	// Time between two saves of the key rotation progress.
+	kmsRotationSaveInterval = 30 * time.Second
+
+	// Object metadata holding the ID of the last key rotation which
+	// re-wrapped the object key, objects already re-wrapped are
+	// skipped when a rotation is resumed.
+	kmsRotationIDKey = ReservedMetadataPrefixLower + "kms-rotation-id"
+)
+
+var (
+	kmsRotationLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// error returned when a key rotation is started while another one is running.
+	errKMSRotationAlreadyRunning = AdminError{
+		Code:       "XMinioAdminKMSRotationAlreadyRunning",
+		Message:    "A KMS key rotation is already in progress for this bucket",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when canceling without a running key rotation.
+	errKMSRotationNotStarted = AdminError{
+		Code:       "XMinioAdminKMSRotationNotStarted",
+		Message:    "No KMS key rotation is in progress for this bucket",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when the bucket has no SSE-KMS default encryption.
+	errKMSRotationNoBucketKey = AdminError{
+		Code:       "XMinioAdminKMSRotationNoBucketKey",
+		Message:    "KMS key rotation requires SSE-KMS default encryption on the bucket",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the object layer cannot update object metadata.
+	errKMSRotationNotSupported = AdminError{
+		Code:       "XMinioAdminKMSRotationNotSupported",
+		Message:    "KMS key rotation is only supported in erasure mode",
+		StatusCode: http.StatusNotImplemented,
+	}
+)
+
+// kmsRotationMeta is the persisted state of the key rotation of a bucket.
+type kmsRotationMeta struct {
+	ID          string    `json:"id"`
+	Bucket      string    `json:"bucket"`
+	KeyID       string    `json:"keyId"` // bucket default key at start
+	Rate        int       `json:"rate"`  // objects re-wrapped per second
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	Canceled    bool      `json:"canceled"`
+
+	Scanned   uint64 `json:"scanned"`
+	Rotated   uint64 `json:"rotated"`
+	Skipped   uint64 `json:"skipped"`
+	Failed    uint64 `json:"failed"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+func (m kmsRotationMeta) complete() bool {
+	return m.Canceled || !m.CompletedAt.IsZero()
+}
+
+func kmsRotationMetaPath(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, kmsRotationMetaName)
+}
+
+func loadKMSRotationMeta(ctx context.Context, objAPI ObjectLayer, bucket string) (*kmsRotationMeta, error) {
+	data, err := readConfig(ctx, objAPI, kmsRotationMetaPath(bucket))
+	if err != nil {
+		return nil, err
+	}
+	m := &kmsRotationMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func saveKMSRotationMeta(ctx context.Context, objAPI ObjectLayer, m *kmsRotationMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, kmsRotationMetaPath(m.Bucket), data)
+}
+
+// startKMSRotation initializes a new key rotation of bucket, which
+// re-wraps at most rateLimit object keys per second.
+func startKMSRotation(ctx context.Context, objAPI ObjectLayer, bucket string, rateLimit int) (*kmsRotationMeta, error) {
+	if _, ok := objAPI.(*erasureServerPools); !ok {
+		return nil, errKMSRotationNotSupported
+	}
+	if GlobalKMS == nil {
+		return nil, errKMSNotConfigured
+	}
+	if rateLimit < 0 {
+		return nil, errInvalidArgument
+	}
+	if rateLimit == 0 {
+		rateLimit = kmsRotationDefaultRate
+	}
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, err
+	}
+	cfg, err := globalBucketSSEConfigSys.Get(bucket)
+	if err != nil || cfg.Algo() != sse.AWSKms {
+		return nil, errKMSRotationNoBucketKey
+	}
+
+	m, err := loadKMSRotationMeta(ctx, objAPI, bucket)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	if m != nil && !m.complete() {
+		return nil, errKMSRotationAlreadyRunning
+	}
+
+	m = &kmsRotationMeta{
+		ID:        mustGetUUID(),
+		Bucket:    bucket,
+		KeyID:     cfg.KeyID(),
+		Rate:      rateLimit,
+		StartedAt: UTCNow(),
+	}
+	if err = saveKMSRotationMeta(ctx, objAPI, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// cancelKMSRotation cancels the key rotation in progress for bucket,
+// objects already re-wrapped keep their new key.
+func cancelKMSRotation(ctx context.Context, objAPI ObjectLayer, bucket string) (*kmsRotationMeta, error) {
+	m, err := loadKMSRotationMeta(ctx, objAPI, bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errKMSRotationNotStarted
+		}
+		return nil, err
+	}
+	if m.complete() {
+		return nil, errKMSRotationNotStarted
+	}
+	m.Canceled = true
+	if err = saveKMSRotationMeta(ctx, objAPI, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// initKMSRotation will start the key rotation worker in the background.
+func initKMSRotation(ctx context.Context, objAPI ObjectLayer) {
+	if _, ok := objAPI.(*erasureServerPools); !ok {
+		return
+	}
+	go runKMSRotation(ctx, objAPI)
+}
+
+// runKMSRotation waits for key rotations to be started and re-wraps
+// the object keys of one bucket at a time. There should only ever be
+// one key rotation worker running per cluster.
+func runKMSRotation(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 key rotation worker is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runKMSRotation.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, kmsRotationLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(kmsRotationCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	checkTimer := time.NewTimer(kmsRotationCheckInterval)
+	defer checkTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkTimer.C:
+			buckets, err := objAPI.ListBuckets(ctx)
+			if err != nil {
+				logger.LogIf(ctx, err)
+			}
+			for _, bucket := range buckets {
+				m, err := loadKMSRotationMeta(ctx, objAPI, bucket.Name)
+				if err != nil {
+					if !errors.Is(err, errConfigNotFound) {
+						logger.LogIf(ctx, err)
+					}
+					continue
+				}
+				if m.complete() {
+					continue
+				}
+				rs := &kmsRotationState{meta: m}
+				if err = rotateBucketKMSKeys(ctx, objAPI, rs); err != nil && !errors.Is(err, errKMSRotationCanceled) {
+					logger.LogIf(ctx, err)
+				}
+			}
+			checkTimer.Reset(kmsRotationCheckInterval)
+		}
+	}
+}
+
+var errKMSRotationCanceled = errors.New("kms key rotation canceled")
+
+// kmsRotationState is the in-memory state of a running key rotation.
+type kmsRotationState struct {
+	mu       sync.Mutex
+	meta     *kmsRotationMeta
+	lastSave time.Time
+}
+
+// sync persists the in-memory progress, and picks up cancel requests
+// written to the backend by the admin API on any node.
+func (rs *kmsRotationState) sync(ctx context.Context, objAPI ObjectLayer, force bool) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if !force && time.Since(rs.lastSave) < kmsRotationSaveInterval {
+		if rs.meta.Canceled {
+			return errKMSRotationCanceled
+		}
+		return nil
+	}
+
+	if stored, err := loadKMSRotationMeta(ctx, objAPI, rs.meta.Bucket); err == nil {
+		if stored.ID != rs.meta.ID {
+			// A new key rotation replaced this one, give up.
+			return errKMSRotationCanceled
+		}
+		rs.meta.Canceled = stored.Canceled
+	}
+
+	rs.lastSave = time.Now()
+	if err := saveKMSRotationMeta(ctx, objAPI, rs.meta); err != nil {
+		return err
+	}
+	if rs.meta.Canceled {
+		return errKMSRotationCanceled
+	}
+	return nil
+}
+
+func (rs *kmsRotationState) update(fn func(m *kmsRotationMeta)) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	fn(rs.meta)
+}
+
+// rotateBucketKMSKeys walks all object versions of the bucket and
+// re-wraps their SSE-KMS object keys, at most meta.Rate per second.
+func rotateBucketKMSKeys(ctx context.Context, objAPI ObjectLayer, rs *kmsRotationState) error {
+	rs.mu.Lock()
+	bucket, jobID, rateLimit := rs.meta.Bucket, rs.meta.ID, rs.meta.Rate
+	rs.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan ObjectInfo, 100)
+	if err := objAPI.Walk(ctx, bucket, "", results, ObjectOptions{WalkVersions: true}); err != nil {
+		return err
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(rateLimit), 1)
+	var stopErr error
+	for oi := range results {
+		// Keep draining the walker once stopped, it does
+		// not give up sending on context cancelation.
+		if stopErr != nil {
+			continue
+		}
+		if stopErr = rs.sync(ctx, objAPI, false); stopErr != nil {
+			cancel()
+			continue
+		}
+		rs.update(func(m *kmsRotationMeta) {
+			m.Scanned++
+		})
+		if !kmsRotationNeeded(oi, jobID) {
+			rs.update(func(m *kmsRotationMeta) {
+				m.Skipped++
+			})
+			continue
+		}
+		if stopErr = limiter.Wait(ctx); stopErr != nil {
+			cancel()
+			continue
+		}
+		rotated, err := rotateObjectKMSKey(ctx, objAPI, bucket, oi, jobID)
+		rs.update(func(m *kmsRotationMeta) {
+			switch {
+			case err != nil:
+				m.Failed++
+				m.LastError = err.Error()
+			case !rotated:
+				m.Skipped++
+			default:
+				m.Rotated++
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.LogIf(ctx, err)
+		}
+	}
+	if stopErr != nil {
+		rs.sync(ctx, objAPI, true)
+		return stopErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rs.update(func(m *kmsRotationMeta) {
+		m.CompletedAt = UTCNow()
+	})
+	return rs.sync(ctx, objAPI, true)
+}
+
+// kmsRotationNeeded returns whether the object key of oi is sealed by
+// the KMS and was not re-wrapped by the key rotation jobID yet.
+func kmsRotationNeeded(oi ObjectInfo, jobID string) bool {
+	if oi.DeleteMarker {
+		return false
+	}
+	if kind, _ := crypto.IsEncrypted(oi.UserDefined); kind != crypto.S3KMS {
+		return false
+	}
+	return oi.UserDefined[kmsRotationIDKey] != jobID
+}
+
+var errKMSRotationObjectChanged = errors.New("object changed during kms key rotation")
+
+// rotateObjectKMSKey re-wraps the object key of the object version oi
+// with the latest version of its KMS master key. The object data and
+// modification time are left untouched, rotated is false when the
+// object was removed or overwritten in the meantime.
+func rotateObjectKMSKey(ctx context.Context, objAPI ObjectLayer, bucket string, oi ObjectInfo, jobID string) (rotated bool, err error) {
+	_, err = objAPI.PutObjectMetadata(ctx, bucket, oi.Name, ObjectOptions{
+		MTime:     oi.ModTime,
+		VersionID: oi.VersionID,
+		EvalMetadataFn: func(cur ObjectInfo) error {
+			if !cur.ModTime.Equal(oi.ModTime) || !kmsRotationNeeded(cur, jobID) {
+				return errKMSRotationObjectChanged
+			}
+			keyID, _, _, _, err := crypto.S3KMS.ParseMetadata(cur.UserDefined)
+			if err != nil {
+				return err
+			}
+			if err = rotateKey(nil, keyID, nil, bucket, oi.Name, cur.UserDefined, nil); err != nil {
+				return err
+			}
+			cur.UserDefined[kmsRotationIDKey] = jobID
+			return nil
+		},
+	})
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, errKMSRotationObjectChanged), isErrObjectNotFound(err), isErrVersionNotFound(err), isErrMethodNotAllowed(err):
+		return false, nil
+	}
+	return false, err
+}
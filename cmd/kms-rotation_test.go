@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/kms"
+)
+
+func TestRotateBucketKMSKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	oldKMS := GlobalKMS
+	defer func() { GlobalKMS = oldKMS }()
+	GlobalKMS, err = kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const bucket = "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An SSE-KMS object, the data is left unencrypted since only
+	// the metadata is touched by the key rotation.
+	metadata := make(map[string]string)
+	objectKey, err := newEncryptMetadata(crypto.S3KMS, "my-minio-key", nil, bucket, "kms", metadata, kms.Context{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("data")
+	if _, err = objLayer.PutObject(ctx, bucket, "kms", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.PutObject(ctx, bucket, "plain", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	before, err := objLayer.GetObjectInfo(ctx, bucket, "kms", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs := &kmsRotationState{meta: &kmsRotationMeta{
+		ID:     mustGetUUID(),
+		Bucket: bucket,
+		Rate:   kmsRotationDefaultRate,
+	}}
+	if err = rotateBucketKMSKeys(ctx, objLayer, rs); err != nil {
+		t.Fatal(err)
+	}
+	if m := rs.meta; !m.complete() || m.Scanned != 2 || m.Rotated != 1 || m.Skipped != 1 || m.Failed != 0 {
+		t.Fatalf("unexpected progress %+v", m)
+	}
+
+	after, err := objLayer.GetObjectInfo(ctx, bucket, "kms", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ModTime.Equal(before.ModTime) {
+		t.Fatalf("expected mod time %v, got %v", before.ModTime, after.ModTime)
+	}
+	if after.UserDefined[crypto.MetaSealedKeyKMS] == before.UserDefined[crypto.MetaSealedKeyKMS] {
+		t.Fatal("expected the object key to be sealed again")
+	}
+	if after.UserDefined[kmsRotationIDKey] != rs.meta.ID {
+		t.Fatalf("expected rotation id %s, got %s", rs.meta.ID, after.UserDefined[kmsRotationIDKey])
+	}
+	rotatedKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, after.UserDefined, bucket, "kms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotatedKey != objectKey {
+		t.Fatal("object key changed by the key rotation")
+	}
+
+	// Objects re-wrapped by the same rotation are skipped.
+	rotated, err := rotateObjectKMSKey(ctx, objLayer, bucket, after, rs.meta.ID)
+	if err != nil || rotated {
+		t.Fatalf("expected object to be skipped, got %v, %v", rotated, err)
+	}
+
+	// Canceling a finished rotation fails.
+	if err = saveKMSRotationMeta(ctx, objLayer, rs.meta); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cancelKMSRotation(ctx, objLayer, bucket); !errors.Is(err, errKMSRotationNotStarted) {
+		t.Fatalf("expected %v, got %v", errKMSRotationNotStarted, err)
+	}
+}
@@ -96,6 +96,10 @@ func (a *AccElem) avg() uint64 {
 	return 0
 }
 
+// latencyTotals holds the accumulated latencies of each object size
+// range in each of the 60 slots of a rolling window.
+type latencyTotals = [60][sizeLastElemMarker]AccElem
+
 // LastMinuteLatencies keeps track of last minute latencies.
 type LastMinuteLatencies struct {
 	Totals  [60][sizeLastElemMarker]AccElem
@@ -104,64 +108,140 @@ type LastMinuteLatencies struct {
 
 // Clone safely returns a copy for a LastMinuteLatencies structure
 func (l *LastMinuteLatencies) Clone() LastMinuteLatencies {
-	n := LastMinuteLatencies{}
-	n.LastSec = l.LastSec
-	for i := range l.Totals {
-		for j := range l.Totals[i] {
-			n.Totals[i][j] = AccElem{
-				Total: l.Totals[i][j].Total,
-				N:     l.Totals[i][j].N,
-			}
-		}
+	return LastMinuteLatencies{
+		Totals:  l.Totals,
+		LastSec: l.LastSec,
 	}
-	return n
 }
 
 // Merge safely merges two LastMinuteLatencies structures into one
 func (l LastMinuteLatencies) Merge(o LastMinuteLatencies) (merged LastMinuteLatencies) {
-	cl := l.Clone()
-	co := o.Clone()
+	merged.Totals, merged.LastSec = mergeLatencyTotals(l.Totals, l.LastSec, o.Totals, o.LastSec)
+	return merged
+}
 
-	if cl.LastSec > co.LastSec {
-		co.forwardTo(cl.LastSec)
-		merged.LastSec = cl.LastSec
-	} else {
-		cl.forwardTo(co.LastSec)
-		merged.LastSec = co.LastSec
+// Add latency t from object with the specified size.
+func (l *LastMinuteLatencies) Add(size int64, t time.Duration) {
+	addLatencyTotals(&l.Totals, &l.LastSec, time.Now().Unix(), size, t)
+}
+
+// GetAvg will return the average for each bucket from the last time minute.
+// The number of objects is also included.
+func (l *LastMinuteLatencies) GetAvg() [sizeLastElemMarker]AccElem {
+	l.forwardTo(time.Now().Unix())
+	return sumLatencyTotals(&l.Totals)
+}
+
+// forwardTo time t, clearing any entries in between.
+func (l *LastMinuteLatencies) forwardTo(t int64) {
+	forwardLatencyTotals(&l.Totals, &l.LastSec, t)
+}
+
+// RollingLatencies keeps track of the latencies of a rolling window made
+// of 60 slots of Slot seconds each, with one second slots it tracks the
+// same latencies as LastMinuteLatencies.
+type RollingLatencies struct {
+	Totals   [60][sizeLastElemMarker]AccElem
+	LastSlot int64
+	Slot     int64
+}
+
+// newRollingLatencies returns a window tracking the latencies of the last
+// d, rounded to a multiple of a minute.
+func newRollingLatencies(d time.Duration) RollingLatencies {
+	slot := int64(d / time.Minute)
+	if slot < 1 {
+		slot = 1
 	}
+	return RollingLatencies{Slot: slot}
+}
 
-	for i := range cl.Totals {
-		for j := range cl.Totals[i] {
-			merged.Totals[i][j] = AccElem{
-				Total: cl.Totals[i][j].Total + co.Totals[i][j].Total,
-				N:     cl.Totals[i][j].N + co.Totals[i][j].N,
-			}
-		}
+// Duration returns the duration covered by the window.
+func (w *RollingLatencies) Duration() time.Duration {
+	return time.Duration(w.Slot) * time.Minute
+}
+
+// Clone safely returns a copy for a RollingLatencies structure
+func (w *RollingLatencies) Clone() RollingLatencies {
+	return RollingLatencies{
+		Totals:   w.Totals,
+		LastSlot: w.LastSlot,
+		Slot:     w.Slot,
 	}
+}
+
+// Merge safely merges two windows of the same duration into one, w is
+// returned unchanged when o covers another duration.
+func (w RollingLatencies) Merge(o RollingLatencies) (merged RollingLatencies) {
+	if w.Slot != o.Slot {
+		return w.Clone()
+	}
+	merged.Slot = w.Slot
+	merged.Totals, merged.LastSlot = mergeLatencyTotals(w.Totals, w.LastSlot, o.Totals, o.LastSlot)
 	return merged
 }
 
 // Add latency t from object with the specified size.
-func (l *LastMinuteLatencies) Add(size int64, t time.Duration) {
+func (w *RollingLatencies) Add(size int64, t time.Duration) {
+	addLatencyTotals(&w.Totals, &w.LastSlot, w.now(), size, t)
+}
+
+// GetAvg will return the average for each bucket over the window.
+// The number of objects is also included.
+func (w *RollingLatencies) GetAvg() [sizeLastElemMarker]AccElem {
+	forwardLatencyTotals(&w.Totals, &w.LastSlot, w.now())
+	return sumLatencyTotals(&w.Totals)
+}
+
+// now returns the index of the current slot.
+func (w *RollingLatencies) now() int64 {
+	slot := w.Slot
+	if slot < 1 {
+		slot = 1
+	}
+	return time.Now().Unix() / slot
+}
+
+// addLatencyTotals adds latency t from object with the specified size
+// to the slot now of totals, last is the index of the last slot added to.
+func addLatencyTotals(totals *latencyTotals, last *int64, now int64, size int64, t time.Duration) {
 	tag := sizeToTag(size)
 
 	// Update...
-	sec := time.Now().Unix()
-	l.forwardTo(sec)
+	forwardLatencyTotals(totals, last, now)
 
-	winIdx := sec % 60
-	l.Totals[winIdx][tag].add(t)
+	winIdx := now % 60
+	totals[winIdx][tag].add(t)
 
-	l.LastSec = sec
+	*last = now
 }
 
-// GetAvg will return the average for each bucket from the last time minute.
-// The number of objects is also included.
-func (l *LastMinuteLatencies) GetAvg() [sizeLastElemMarker]AccElem {
+// mergeLatencyTotals merges the totals a and b whose last slots are
+// lastA and lastB.
+func mergeLatencyTotals(a latencyTotals, lastA int64, b latencyTotals, lastB int64) (merged latencyTotals, last int64) {
+	if lastA > lastB {
+		forwardLatencyTotals(&b, &lastB, lastA)
+		last = lastA
+	} else {
+		forwardLatencyTotals(&a, &lastA, lastB)
+		last = lastB
+	}
+
+	for i := range a {
+		for j := range a[i] {
+			merged[i][j] = AccElem{
+				Total: a[i][j].Total + b[i][j].Total,
+				N:     a[i][j].N + b[i][j].N,
+			}
+		}
+	}
+	return merged, last
+}
+
+// sumLatencyTotals returns the accumulated latencies of all slots.
+func sumLatencyTotals(totals *latencyTotals) [sizeLastElemMarker]AccElem {
 	var res [sizeLastElemMarker]AccElem
-	sec := time.Now().Unix()
-	l.forwardTo(sec)
-	for _, elems := range l.Totals[:] {
+	for _, elems := range totals[:] {
 		for j := range elems {
 			res[j].merge(elems[j])
 		}
@@ -169,19 +249,19 @@ func (l *LastMinuteLatencies) GetAvg() [sizeLastElemMarker]AccElem {
 	return res
 }
 
-// forwardTo time t, clearing any entries in between.
-func (l *LastMinuteLatencies) forwardTo(t int64) {
-	if l.LastSec >= t {
+// forwardLatencyTotals forwards totals to slot t, clearing any entries in between.
+func forwardLatencyTotals(totals *latencyTotals, last *int64, t int64) {
+	if *last >= t {
 		return
 	}
-	if t-l.LastSec >= 60 {
-		l.Totals = [60][sizeLastElemMarker]AccElem{}
+	if t-*last >= 60 {
+		*totals = latencyTotals{}
 		return
 	}
-	for l.LastSec != t {
+	for *last != t {
 		// Clear next element.
-		idx := (l.LastSec + 1) % 60
-		l.Totals[idx] = [sizeLastElemMarker]AccElem{}
-		l.LastSec++
+		idx := (*last + 1) % 60
+		totals[idx] = [sizeLastElemMarker]AccElem{}
+		*last++
 	}
 }
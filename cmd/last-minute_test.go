@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollingLatencies(t *testing.T) {
+	w := newRollingLatencies(5 * time.Minute)
+	if w.Slot != 5 || w.Duration() != 5*time.Minute {
+		t.Fatalf("unexpected window %d covering %v", w.Slot, w.Duration())
+	}
+	w.Add(100, 10*time.Millisecond)
+	w.Add(100, 30*time.Millisecond)
+	w.Add(2<<20, time.Second)
+
+	avg := w.GetAvg()
+	if avg[sizeLessThan1KiB].N != 2 || avg[sizeLessThan1KiB].avg() != uint64(20*time.Millisecond) {
+		t.Fatalf("unexpected average %+v", avg[sizeLessThan1KiB])
+	}
+	if avg[sizeLessThan10MiB].N != 1 {
+		t.Fatalf("unexpected average %+v", avg[sizeLessThan10MiB])
+	}
+
+	// Latencies older than the window are dropped.
+	old := w.Clone()
+	old.LastSlot -= 60
+	merged := w.Merge(old)
+	if avg := merged.GetAvg(); avg[sizeLessThan1KiB].N != 2 || avg[sizeLessThan10MiB].N != 1 {
+		t.Fatalf("unexpected merged averages %+v", avg)
+	}
+	merged = w.Merge(w.Clone())
+	if avg := merged.GetAvg(); avg[sizeLessThan1KiB].N != 4 || avg[sizeLessThan10MiB].N != 2 {
+		t.Fatalf("unexpected merged averages %+v", avg)
+	}
+
+	// Windows of different durations are not merged.
+	other := newRollingLatencies(time.Hour)
+	other.Add(100, time.Millisecond)
+	merged = w.Merge(other)
+	if avg := merged.GetAvg(); avg[sizeLessThan1KiB].N != 2 {
+		t.Fatalf("unexpected average after merging another window %+v", avg[sizeLessThan1KiB])
+	}
+}
+
+func TestReplicationLatencyWindows(t *testing.T) {
+	var rl ReplicationLatency
+	rl.update(100, 10*time.Millisecond)
+	rl.update(100, 30*time.Millisecond)
+	if len(rl.UploadWindows) != len(replicationLatencyWindows) {
+		t.Fatalf("expected %d windows, got %d", len(replicationLatencyWindows), len(rl.UploadWindows))
+	}
+
+	merged := rl.merge(rl.clone())
+	latencies := merged.getUploadLatencies()
+	for _, window := range []string{"1m", "5m", "1h"} {
+		if latencies[window][sizeTagToString(sizeLessThan1KiB)] != 20 {
+			t.Fatalf("unexpected latencies over %s: %v", window, latencies[window])
+		}
+	}
+
+	data, err := merged.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ReplicationLatency
+	if _, err = decoded.UnmarshalMsg(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.UploadWindows) != 2 || decoded.UploadWindows[1].Slot != 60 {
+		t.Fatalf("unexpected decoded windows %d", len(decoded.UploadWindows))
+	}
+	if avg := decoded.UploadWindows[1].GetAvg(); avg[sizeLessThan1KiB].N != 4 {
+		t.Fatalf("unexpected decoded average %+v", avg[sizeLessThan1KiB])
+	}
+
+	st := BucketReplicationStat{Latency: merged}
+	if data, err = st.MarshalMsg(nil); err != nil {
+		t.Fatal(err)
+	}
+	var decodedStat BucketReplicationStat
+	if _, err = decodedStat.UnmarshalMsg(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(decodedStat.Latency.UploadWindows) != 2 || decodedStat.Latency.UploadWindows[0].Slot != 5 {
+		t.Fatalf("unexpected decoded stat windows %+v", decodedStat.Latency.UploadWindows)
+	}
+}
@@ -125,6 +125,7 @@ func newApp(name string) *cli.App {
 	// Register all commands.
 	registerCommand(serverCmd)
 	registerCommand(gatewayCmd)
+	registerCommand(internodeVersionsCmd)
 
 	// Set up app.
 	cli.HelpFlag = cli.BoolFlag{
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"runtime/debug"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -72,6 +73,8 @@ func (b *bucketMetacache) debugf(format string, data ...interface{}) {
 
 // findCache will attempt to find a matching cache for the provided options.
 // If a cache with the same ID exists already it will be returned.
+// If a finished cache of the same path with no writes since it started
+// exists, it is shared with the listing.
 // If none can be found a new is created with the provided ID.
 func (b *bucketMetacache) findCache(o listPathOptions) metacache {
 	if b == nil {
@@ -87,23 +90,56 @@ func (b *bucketMetacache) findCache(o listPathOptions) metacache {
 
 	// Grab a write lock, since we create one if we cannot find one.
 	b.mu.Lock()
-	defer b.mu.Unlock()
 
 	// Check if exists already.
 	if c, ok := b.caches[o.ID]; ok {
 		c.lastHandout = time.Now()
 		b.caches[o.ID] = c
+		b.mu.Unlock()
 		b.debugf("returning existing %v", o.ID)
 		return c
 	}
 
 	if !o.Create {
+		b.mu.Unlock()
 		return metacache{
 			id:     o.ID,
 			bucket: o.Bucket,
 			status: scanStateNone,
 		}
 	}
+	shared := b.shareableCaches(o)
+	b.mu.Unlock()
+
+	// Checking for writes asks all nodes, do it without holding the lock.
+	for _, c := range shared {
+		ctx, cancel := context.WithTimeout(GlobalContext, 2*time.Second)
+		written := globalNotificationSys.MetacacheWrittenSince(ctx, c)
+		cancel()
+		if written {
+			// Invalidated, do not share it again.
+			// Listings already using it may continue.
+			b.mu.Lock()
+			b.unshareCache(c)
+			b.mu.Unlock()
+			b.debugf("cache %s invalidated by writes", c.id)
+			continue
+		}
+		b.mu.Lock()
+		c, ok := b.caches[c.id]
+		if ok {
+			c.lastHandout = time.Now()
+			b.caches[c.id] = c
+		}
+		b.mu.Unlock()
+		if ok {
+			b.debugf("sharing cache %s with %s", c.id, o.ID)
+			return c
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
 	// Create new and add.
 	best := o.newMetacache()
@@ -114,6 +150,41 @@ func (b *bucketMetacache) findCache(o listPathOptions) metacache {
 	return best
 }
 
+// shareableCaches returns the finished caches containing all entries
+// of the listing, newest first. Caller must hold the lock.
+func (b *bucketMetacache) shareableCaches(o listPathOptions) []metacache {
+	var shared []metacache
+	for _, id := range b.cachesRoot[o.BaseDir] {
+		c, ok := b.caches[id]
+		if !ok || c.status != scanStateSuccess || c.recursive != o.Recursive {
+			continue
+		}
+		if !strings.HasPrefix(o.FilterPrefix, c.filter) {
+			continue
+		}
+		if time.Since(c.started) > metacacheShareMaxAge || !c.worthKeeping() {
+			continue
+		}
+		shared = append(shared, c)
+	}
+	sort.Slice(shared, func(i, j int) bool {
+		return shared[i].started.After(shared[j].started)
+	})
+	return shared
+}
+
+// unshareCache removes the cache from the root index,
+// so it is no longer shared. Caller must hold the lock.
+func (b *bucketMetacache) unshareCache(c metacache) {
+	list := b.cachesRoot[c.root]
+	for i, id := range list {
+		if id == c.id {
+			b.cachesRoot[c.root] = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+}
+
 // cleanup removes redundant and outdated entries.
 func (b *bucketMetacache) cleanup() {
 	// Entries to remove.
@@ -229,14 +300,7 @@ func (b *bucketMetacache) deleteCache(id string) {
 	c, ok := b.caches[id]
 	if ok {
 		// Delete from root map.
-		list := b.cachesRoot[c.root]
-		for i, lid := range list {
-			if id == lid {
-				list = append(list[:i], list[i+1:]...)
-				break
-			}
-		}
-		b.cachesRoot[c.root] = list
+		b.unshareCache(c)
 		delete(b.caches, id)
 		b.updated = true
 	}
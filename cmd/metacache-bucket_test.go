@@ -20,6 +20,7 @@ package cmd
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func Benchmark_bucketMetacache_findCache(b *testing.B) {
@@ -66,3 +67,57 @@ func Benchmark_bucketMetacache_findCache(b *testing.B) {
 		})
 	}
 }
+
+func Test_bucketMetacache_findCacheShared(t *testing.T) {
+	defer func(bootTime time.Time, writes *metacacheWrites) {
+		globalBootTime, localMetacacheWrites = bootTime, writes
+	}(globalBootTime, localMetacacheWrites)
+	globalBootTime = UTCNow().Add(-time.Hour)
+	localMetacacheWrites = newMetacacheWrites()
+
+	bm := newBucketMetacache("bucket", false)
+	opts := func(id, filter string) listPathOptions {
+		return listPathOptions{
+			ID:           id,
+			Bucket:       "bucket",
+			BaseDir:      "prefix/",
+			FilterPrefix: filter,
+			Recursive:    true,
+			Create:       true,
+		}
+	}
+	bm.findCache(opts("a", ""))
+	// Still running, not shared.
+	if c := bm.findCache(opts("b", "")); c.id != "b" {
+		t.Fatalf("expected new cache, got %s", c.id)
+	}
+	if _, err := bm.updateCacheEntry(metacache{id: "a", bucket: "bucket", status: scanStateSuccess, pool: 1, set: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := bm.findCache(opts("c", "sub"))
+	if c.id != "a" || c.pool != 1 || c.set != 2 {
+		t.Fatalf("expected cache a shared at 1/2, got %s at %d/%d", c.id, c.pool, c.set)
+	}
+	nonRecursive := opts("d", "")
+	nonRecursive.Recursive = false
+	if c = bm.findCache(nonRecursive); c.id != "d" {
+		t.Fatalf("expected new cache, got %s", c.id)
+	}
+
+	// Writes outside of the listing do not invalidate it.
+	localMetacacheWrites.written("bucket", "other/object")
+	localMetacacheWrites.written("other", "prefix/sub/object")
+	if c = bm.findCache(opts("e", "")); c.id != "a" {
+		t.Fatalf("expected cache a shared, got %s", c.id)
+	}
+
+	localMetacacheWrites.written("bucket", "prefix/sub/object")
+	if c = bm.findCache(opts("f", "")); c.id != "f" {
+		t.Fatalf("expected new cache, got %s", c.id)
+	}
+	// Invalidated caches are still available to their listings.
+	if c = bm.findCache(listPathOptions{ID: "a", Bucket: "bucket"}); c.id != "a" || c.status != scanStateSuccess {
+		t.Fatalf("expected cache a, got %s with status %d", c.id, c.status)
+	}
+}
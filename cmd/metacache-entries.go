@@ -414,7 +414,7 @@ func (m metaCacheEntriesSorted) shallowClone() metaCacheEntriesSorted {
 
 // fileInfoVersions converts the metadata to FileInfoVersions where possible.
 // Metadata that cannot be decoded is skipped.
-func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, afterV string) (versions []ObjectInfo) {
+func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, marker, afterV string) (versions []ObjectInfo) {
 	versions = make([]ObjectInfo, 0, m.len())
 	prevPrefix := ""
 	for _, entry := range m.o {
@@ -443,12 +443,13 @@ func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, aft
 			}
 
 			fiVersions := fiv.Versions
-			if afterV != "" {
+			if afterV != "" && entry.name == marker {
+				// Versions are sorted newest first, resume after the
+				// marker version. If it is gone all versions are listed.
 				vidMarkerIdx := fiv.findVersionIndex(afterV)
 				if vidMarkerIdx >= 0 {
 					fiVersions = fiVersions[vidMarkerIdx+1:]
 				}
-				afterV = ""
 			}
 
 			for _, version := range fiVersions {
@@ -77,6 +77,7 @@ func (m *metacacheManager) initManager() {
 				}
 			}
 			m.mu.RUnlock()
+			localMetacacheWrites.cleanup()
 			m.mu.Lock()
 			for k, v := range m.trash {
 				if time.Since(v.lastUpdate) > metacacheMaxRunningAge {
@@ -57,6 +57,7 @@ func renameAllBucketMetacache(epPath string) error {
 // Other important fields are Limit, Marker.
 // List ID always derived from the Marker.
 func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (entries metaCacheEntriesSorted, err error) {
+	pools := z.getServerPools()
 	if err := checkListObjsArgs(ctx, o.Bucket, o.Prefix, o.Marker, z); err != nil {
 		return entries, err
 	}
@@ -97,6 +98,16 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 	o.parseMarker()
 	o.BaseDir = baseDirFromPrefix(o.Prefix)
 	o.Transient = o.Transient || isReservedOrInvalidBucket(o.Bucket, false)
+	if !o.Recursive && o.Limit > 0 {
+		// Listings with a delimiter only need the entries of a single
+		// directory, serve them directly from the directory entries
+		// forwarded to the marker instead of caching the whole
+		// directory, which resolves the metadata of every object in it.
+		o.ID = ""
+		o.Create = false
+		o.Transient = true
+		o.StopDiskAtLimit = true
+	}
 	o.SetFilter()
 	if o.Transient {
 		o.Create = false
@@ -142,6 +153,12 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 				o.Create = false
 				o.debugln("scan status", c.status, " - waiting a roundtrip to create")
 			} else {
+				if o.Create && c.id != o.ID {
+					// Another listing of the same path is shared,
+					// resume it from where it is stored.
+					o.Create = false
+					o.pool, o.set = c.pool, c.set
+				}
 				// Continue listing
 				o.ID = c.id
 				go func(meta metacache) {
@@ -174,9 +191,9 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 			}
 			entries.truncate(0)
 		} else {
-			if o.pool < len(z.serverPools) && o.set < len(z.serverPools[o.pool].sets) {
+			if o.pool < len(pools) && o.set < len(pools[o.pool].sets) {
 				o.debugln("Resuming", o)
-				entries, err = z.serverPools[o.pool].sets[o.set].streamMetadataParts(ctx, *o)
+				entries, err = pools[o.pool].sets[o.set].streamMetadataParts(ctx, *o)
 				entries.reuse = true // We read from stream and are not sharing results.
 				if err == nil {
 					return entries, nil
@@ -216,9 +233,47 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 	}
 
 	// Do listing in-place.
+	o.debugln("Raw List", o)
+	entries, stoppedAt, err := z.listRaw(ctx, *o)
+	for err == io.EOF && stoppedAt != "" && entries.len() <= o.Limit {
+		// The disks stopped at the limit before the page was full since
+		// entries were filtered out, continue after the last entry listed
+		// until the page is full or the disks have no more entries.
+		next := *o
+		next.Marker = stoppedAt
+		next.Limit = o.Limit - entries.len() + 1
+		var more metaCacheEntriesSorted
+		more, stoppedAt, err = z.listRaw(ctx, next)
+		for _, entry := range more.o {
+			if entry.name > next.Marker {
+				entries.o = append(entries.o, entry)
+			}
+		}
+	}
+	if err != nil && err != io.EOF {
+		return entries, err
+	}
+	entries.reuse = true
+	truncated := entries.len() > o.Limit || err == nil
+	entries.truncate(o.Limit)
+	if !o.Transient && truncated {
+		if o.ID == "" {
+			entries.listID = mustGetUUID()
+		} else {
+			entries.listID = o.ID
+		}
+	}
+	if !truncated {
+		return entries, io.EOF
+	}
+	return entries, nil
+}
+
+// listRaw lists the entries of o directly from the disks, if the disks
+// stopped at the limit the name of the last entry listed is returned.
+func (z *erasureServerPools) listRaw(ctx context.Context, o listPathOptions) (entries metaCacheEntriesSorted, stoppedAt string, err error) {
 	// Create output for our results.
 	// Create filter for results.
-	o.debugln("Raw List", o)
 	filterCh := make(chan metaCacheEntry, o.Limit)
 	listCtx, cancelList := context.WithCancel(ctx)
 	filteredResults := o.gatherResults(listCtx, filterCh)
@@ -228,31 +283,24 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 
 	go func(o listPathOptions) {
 		defer wg.Done()
-		o.Limit = 0
+		if o.StopDiskAtLimit {
+			o.limitReached = func(last string) {
+				stoppedAt = last
+			}
+		} else {
+			o.Limit = 0
+		}
 		listErr = z.listMerged(listCtx, o, filterCh)
 		o.debugln("listMerged returned with", listErr)
-	}(*o)
+	}(o)
 
 	entries, err = filteredResults()
 	cancelList()
 	wg.Wait()
 	if listErr != nil && !errors.Is(listErr, context.Canceled) {
-		return entries, listErr
-	}
-	entries.reuse = true
-	truncated := entries.len() > o.Limit || err == nil
-	entries.truncate(o.Limit)
-	if !o.Transient && truncated {
-		if o.ID == "" {
-			entries.listID = mustGetUUID()
-		} else {
-			entries.listID = o.ID
-		}
-	}
-	if !truncated {
-		return entries, io.EOF
+		return entries, "", listErr
 	}
-	return entries, nil
+	return entries, stoppedAt, err
 }
 
 // listMerged will list across all sets and return a merged results stream.
@@ -263,11 +311,52 @@ func (z *erasureServerPools) listMerged(ctx context.Context, o listPathOptions,
 	var errs []error
 	allAtEOF := true
 	var inputs []chan metaCacheEntry
+
+	// When the disks stop at the limit, the listing of a set is only
+	// complete up to the last entry it listed, merged entries after the
+	// first such entry of any set are dropped.
+	var stoppedAt string
+	limitReached := o.limitReached
+	if o.StopDiskAtLimit {
+		o.limitReached = func(last string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if stoppedAt == "" || last < stoppedAt {
+				stoppedAt = last
+			}
+		}
+		merged := make(chan metaCacheEntry, 100)
+		forwarded := make(chan struct{})
+		go func(results chan<- metaCacheEntry) {
+			defer close(forwarded)
+			defer close(results)
+			for entry := range merged {
+				mu.Lock()
+				drop := stoppedAt != "" && entry.name > stoppedAt
+				mu.Unlock()
+				if drop {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+				case results <- entry:
+				}
+			}
+		}(results)
+		defer func() {
+			<-forwarded
+			if stoppedAt != "" && limitReached != nil {
+				limitReached(stoppedAt)
+			}
+		}()
+		results = merged
+	}
+
 	mu.Lock()
 	// Ask all sets and merge entries.
 	listCtx, cancelList := context.WithCancel(ctx)
 	defer cancelList()
-	for _, pool := range z.serverPools {
+	for _, pool := range z.getServerPools() {
 		for _, set := range pool.sets {
 			wg.Add(1)
 			results := make(chan metaCacheEntry, 100)
@@ -355,8 +444,9 @@ func (z *erasureServerPools) listAndSave(ctx context.Context, o *listPathOptions
 		o.Transient = true
 		return entries, errDiskFull
 	}
-	o.set = z.serverPools[o.pool].getHashedSetIndex(o.ID)
-	saver := z.serverPools[o.pool].sets[o.set]
+	pool := z.getServerPools()[o.pool]
+	o.set = pool.getHashedSetIndex(o.ID)
+	saver := pool.sets[o.set]
 
 	// Disconnect from call above, but cancel on exit.
 	listCtx, cancel := context.WithCancel(GlobalContext)
@@ -91,6 +91,13 @@ type listPathOptions struct {
 	// A transient result will never be returned from the cache so knowing the list id is required.
 	Transient bool
 
+	// StopDiskAtLimit will stop listing on each disk when limit number of entries has been returned.
+	StopDiskAtLimit bool
+
+	// limitReached is called with the name of the last entry listed
+	// when the disks stopped at the limit, it is not sent to peers.
+	limitReached func(last string)
+
 	// pool and set of where the cache is located.
 	pool, set int
 }
@@ -105,6 +112,8 @@ func (o listPathOptions) newMetacache() metacache {
 		id:          o.ID,
 		bucket:      o.Bucket,
 		root:        o.BaseDir,
+		pool:        o.pool,
+		set:         o.set,
 		recursive:   o.Recursive,
 		status:      scanStateStarted,
 		error:       "",
@@ -562,6 +571,13 @@ func (er *erasureObjects) listPath(ctx context.Context, o listPathOptions, resul
 		bucket:    o.Bucket,
 	}
 
+	var limit int
+	if o.Limit > 0 && o.StopDiskAtLimit {
+		// Over-read by 4 + 1 for every 16 in limit to give some space for
+		// the resolver and to know if there are more results.
+		limit = o.Limit + 4 + (o.Limit / 16)
+	}
+
 	ctxDone := ctx.Done()
 	return listPathRaw(ctx, listPathRawOptions{
 		disks:         disks,
@@ -572,6 +588,8 @@ func (er *erasureObjects) listPath(ctx context.Context, o listPathOptions, resul
 		filterPrefix:  o.FilterPrefix,
 		minDisks:      listingQuorum,
 		forwardTo:     o.Marker,
+		perDiskLimit:  limit,
+		limitReached:  o.limitReached,
 		agreed: func(entry metaCacheEntry) {
 			select {
 			case <-ctxDone:
@@ -753,6 +771,10 @@ type listPathRawOptions struct {
 	// Forward to this prefix before returning results.
 	forwardTo string
 
+	// perDiskLimit will limit each disk to return n entries.
+	// If <= 0 all results will be returned until canceled.
+	perDiskLimit int
+
 	// Minimum number of good disks to continue.
 	// An error will be returned if this many disks returned an error.
 	minDisks       int
@@ -773,6 +795,33 @@ type listPathRawOptions struct {
 	// more than one disk returned an error.
 	// Will not be called if everything operates as expected.
 	finished func(errs []error)
+
+	// limitReached will be called with the name of the last entry
+	// when the listing stopped because a disk returned perDiskLimit
+	// entries, entries after it may not have been listed.
+	limitReached func(last string)
+}
+
+// resolvePartialEntries returns a listPathRawOptions.partial callback
+// which resolves disagreeing entries of bucket with a quorum of one and
+// passes the result to fn, falling back to the first entry found.
+func resolvePartialEntries(bucket string, fn func(entry metaCacheEntry)) func(entries metaCacheEntries, nAgreed int, errs []error) {
+	// How to resolve partial results.
+	resolver := metadataResolutionParams{
+		dirQuorum: 1,
+		objQuorum: 1,
+		bucket:    bucket,
+		strict:    false,
+	}
+	return func(entries metaCacheEntries, nAgreed int, errs []error) {
+		entry, ok := entries.resolve(&resolver)
+		if !ok {
+			entry, _ = entries.firstFound()
+		}
+		if entry != nil {
+			fn(*entry)
+		}
+	}
 }
 
 // listPathRaw will list a path on the provided drives.
@@ -822,6 +871,7 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 					ReportNotFound: opts.reportNotFound,
 					FilterPrefix:   opts.filterPrefix,
 					ForwardTo:      opts.forwardTo,
+					Limit:          opts.perDiskLimit,
 				}, w)
 			}
 
@@ -841,6 +891,7 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 						ReportNotFound: opts.reportNotFound,
 						FilterPrefix:   opts.filterPrefix,
 						ForwardTo:      opts.forwardTo,
+						Limit:          opts.perDiskLimit,
 					}, w)
 					if werr == nil {
 						break
@@ -862,10 +913,15 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 
 	topEntries := make(metaCacheEntries, len(readers))
 	errs := make([]error, len(readers))
+	// Number of entries read from each disk and the last entry returned,
+	// a disk that stopped at the limit may have more entries.
+	returned := make([]int, len(readers))
+	var last string
 	for {
 		// Get the top entry from each
 		var current metaCacheEntry
 		var atEOF, fnf, hasErr, agree int
+		var atLimit bool
 		for i := range topEntries {
 			topEntries[i] = metaCacheEntry{}
 		}
@@ -880,6 +936,9 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 			entry, err := r.peek()
 			switch err {
 			case io.EOF:
+				if opts.perDiskLimit > 0 && returned[i] >= opts.perDiskLimit {
+					atLimit = true
+				}
 				atEOF++
 				continue
 			case nil:
@@ -948,6 +1007,15 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 			return errors.New(strings.Join(combinedErr, ", "))
 		}
 
+		// Stop once a disk stopped at the limit, the other disks
+		// cannot be compared with it after this point.
+		if atLimit {
+			if opts.limitReached != nil {
+				opts.limitReached(last)
+			}
+			break
+		}
+
 		// Break if all at EOF or error.
 		if atEOF+hasErr == len(readers) {
 			if hasErr > 0 && opts.finished != nil {
@@ -958,10 +1026,12 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 		if fnf == len(readers) {
 			return errFileNotFound
 		}
+		last = current.name
 		if agree == len(readers) {
 			// Everybody agreed
-			for _, r := range readers {
+			for i, r := range readers {
 				r.skip(1)
+				returned[i]++
 			}
 			if opts.agreed != nil {
 				opts.agreed(current)
@@ -975,6 +1045,7 @@ func listPathRaw(ctx context.Context, opts listPathRawOptions) (err error) {
 		for i, r := range readers {
 			if topEntries[i].name != "" {
 				r.skip(1)
+				returned[i]++
 			}
 		}
 	}
@@ -54,6 +54,9 @@ type WalkDirOptions struct {
 
 	// ForwardTo will forward to the given object path.
 	ForwardTo string
+
+	// Limit the number of returned entries if > 0.
+	Limit int
 }
 
 // WalkDir will traverse a directory and return all entries found.
@@ -86,20 +89,30 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 	}
 	defer close(out)
 
+	var entriesReturned int
+	send := func(entry metaCacheEntry) {
+		out <- entry
+		entriesReturned++
+	}
+	// Stop walking once the limit of entries is returned.
+	limitReached := func() bool {
+		return opts.Limit > 0 && entriesReturned >= opts.Limit
+	}
+
 	// Fast exit track to check if we are listing an object with
 	// a trailing slash, this will avoid to list the object content.
 	if HasSuffix(opts.BaseDir, SlashSeparator) {
-		metadata, err := s.readMetadata(ctx, pathJoin(volumeDir,
+		metadata, err := s.readMetadataAll(ctx, pathJoin(volumeDir,
 			opts.BaseDir[:len(opts.BaseDir)-1]+globalDirSuffix,
 			xlStorageFormatFile))
 		if err == nil {
 			// if baseDir is already a directory object, consider it
 			// as part of the list call, this is a AWS S3 specific
 			// behavior.
-			out <- metaCacheEntry{
+			send(metaCacheEntry{
 				name:     opts.BaseDir,
 				metadata: metadata,
-			}
+			})
 		} else {
 			st, sterr := Lstat(pathJoin(volumeDir, opts.BaseDir, xlStorageFormatFile))
 			if sterr == nil && st.Mode().IsRegular() {
@@ -178,7 +191,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 			if HasSuffix(entry, xlStorageFormatFile) {
 				var meta metaCacheEntry
 				s.walkReadMu.Lock()
-				meta.metadata, err = s.readMetadata(ctx, pathJoin(volumeDir, current, entry))
+				meta.metadata, err = s.readMetadataAll(ctx, pathJoin(volumeDir, current, entry))
 				s.walkReadMu.Unlock()
 				if err != nil {
 					logger.LogIf(ctx, err)
@@ -188,7 +201,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 				meta.name = strings.TrimSuffix(meta.name, SlashSeparator)
 				meta.name = pathJoin(current, meta.name)
 				meta.name = decodeDirObject(meta.name)
-				out <- meta
+				send(meta)
 				return nil
 			}
 			// Check legacy.
@@ -204,7 +217,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 				meta.name = strings.TrimSuffix(entry, xlStorageFormatFileV1)
 				meta.name = strings.TrimSuffix(meta.name, SlashSeparator)
 				meta.name = pathJoin(current, meta.name)
-				out <- meta
+				send(meta)
 				return nil
 			}
 			// Skip all other files.
@@ -235,8 +248,11 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 
 			// If directory entry on stack before this, pop it now.
 			for len(dirStack) > 0 && dirStack[len(dirStack)-1] < meta.name {
+				if limitReached() {
+					return nil
+				}
 				pop := dirStack[len(dirStack)-1]
-				out <- metaCacheEntry{name: pop}
+				send(metaCacheEntry{name: pop})
 				if opts.Recursive {
 					// Scan folder we found. Should be in correct sort order where we are.
 					forward = ""
@@ -248,6 +264,10 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 				dirStack = dirStack[:len(dirStack)-1]
 			}
 
+			if limitReached() {
+				return nil
+			}
+
 			// All objects will be returned as directories, there has been no object check yet.
 			// Check it by attempting to read metadata.
 			_, isDirObj := dirObjects[entry]
@@ -256,7 +276,7 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 			}
 
 			s.walkReadMu.Lock()
-			meta.metadata, err = s.readMetadata(ctx, pathJoin(volumeDir, meta.name, xlStorageFormatFile))
+			meta.metadata, err = s.readMetadataAll(ctx, pathJoin(volumeDir, meta.name, xlStorageFormatFile))
 			s.walkReadMu.Unlock()
 			switch {
 			case err == nil:
@@ -264,12 +284,12 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 				if isDirObj {
 					meta.name = strings.TrimSuffix(meta.name, globalDirSuffixWithSlash) + slashSeparator
 				}
-				out <- meta
+				send(meta)
 			case osIsNotExist(err), isSysErrIsDir(err):
 				meta.metadata, err = xioutil.ReadFile(pathJoin(volumeDir, meta.name, xlStorageFormatFileV1))
 				if err == nil {
 					// It was an object
-					out <- meta
+					send(meta)
 					continue
 				}
 
@@ -289,8 +309,11 @@ func (s *xlStorage) WalkDir(ctx context.Context, opts WalkDirOptions, wr io.Writ
 
 		// If directory entry left on stack, pop it now.
 		for len(dirStack) > 0 {
+			if limitReached() {
+				return nil
+			}
 			pop := dirStack[len(dirStack)-1]
-			out <- metaCacheEntry{name: pop}
+			send(metaCacheEntry{name: pop})
 			if opts.Recursive {
 				// Scan folder we found. Should be in correct sort order where we are.
 				logger.LogIf(ctx, scanDir(pop))
@@ -328,6 +351,7 @@ func (client *storageRESTClient) WalkDir(ctx context.Context, opts WalkDirOption
 	values.Set(storageRESTReportNotFound, strconv.FormatBool(opts.ReportNotFound))
 	values.Set(storageRESTPrefixFilter, opts.FilterPrefix)
 	values.Set(storageRESTForwardFilter, opts.ForwardTo)
+	values.Set(storageRESTLimit, strconv.Itoa(opts.Limit))
 	respBody, err := client.call(ctx, storageRESTMethodWalkDir, values, nil, -1)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -360,6 +384,15 @@ func (s *storageRESTServer) WalkDirHandler(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	var limit int
+	if v := r.Form.Get(storageRESTLimit); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+	}
+
 	prefix := r.Form.Get(storageRESTPrefixFilter)
 	forward := r.Form.Get(storageRESTForwardFilter)
 	writer := streamHTTPResponse(w)
@@ -376,5 +409,6 @@ func (s *storageRESTServer) WalkDirHandler(w http.ResponseWriter, r *http.Reques
 		ReportNotFound: reportNotFound,
 		FilterPrefix:   prefix,
 		ForwardTo:      forward,
+		Limit:          limit,
 	}, writer))
 }
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// metacacheShareMaxAge is the maximum age of a finished listing
+	// handed out to other listings of the same path.
+	metacacheShareMaxAge = 5 * metacacheMaxClientWait
+
+	// metacacheWritesMaxDirs is the maximum number of directories
+	// tracked per bucket, above it the whole bucket is considered written.
+	metacacheWritesMaxDirs = 10000
+
+	// metacacheWritesClockSkew is the allowed clock difference between
+	// the node tracking a listing and the nodes reporting writes.
+	metacacheWritesClockSkew = 5 * time.Second
+)
+
+// localMetacacheWrites keeps track of the writes served by this node.
+var localMetacacheWrites = newMetacacheWrites()
+
+// metacacheWrites records the directories written on this node,
+// so finished listings can be checked for staleness before they
+// are shared with other listings of the same path.
+type metacacheWrites struct {
+	mu      sync.Mutex
+	buckets map[string]*bucketWrites
+}
+
+// bucketWrites are the writes of a single bucket.
+type bucketWrites struct {
+	// dirs is the time of the last write by directory.
+	dirs map[string]time.Time
+	// all is the time of the last write not tracked by directory.
+	all time.Time
+}
+
+func newMetacacheWrites() *metacacheWrites {
+	return &metacacheWrites{buckets: make(map[string]*bucketWrites)}
+}
+
+// bucket returns the writes of the bucket. Caller must hold the lock.
+func (w *metacacheWrites) bucket(bucket string) *bucketWrites {
+	b, ok := w.buckets[bucket]
+	if !ok {
+		b = &bucketWrites{dirs: make(map[string]time.Time)}
+		w.buckets[bucket] = b
+	}
+	return b
+}
+
+// written records a write of the object.
+func (w *metacacheWrites) written(bucket, object string) {
+	if isReservedOrInvalidBucket(bucket, false) {
+		// Listings of these buckets are never cached.
+		return
+	}
+	now := UTCNow()
+	dir := baseDirFromPrefix(object)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b := w.bucket(bucket)
+	if _, ok := b.dirs[dir]; !ok && len(b.dirs) >= metacacheWritesMaxDirs {
+		b.prune(now.Add(-2 * metacacheShareMaxAge))
+		if len(b.dirs) >= metacacheWritesMaxDirs {
+			// Too many directories, consider all of them written.
+			b.dirs = make(map[string]time.Time)
+			b.all = now
+			return
+		}
+	}
+	b.dirs[dir] = now
+}
+
+// writtenAll records a write of any object of the bucket.
+func (w *metacacheWrites) writtenAll(bucket string) {
+	if isReservedOrInvalidBucket(bucket, false) {
+		return
+	}
+	now := UTCNow()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bucket(bucket).all = now
+}
+
+// prune removes the directories written before the given time.
+func (b *bucketWrites) prune(before time.Time) {
+	for dir, t := range b.dirs {
+		if t.Before(before) {
+			delete(b.dirs, dir)
+		}
+	}
+}
+
+// writtenSince returns true if an object of a listing of the root and
+// filter may have been written on this node after the given time.
+func (w *metacacheWrites) writtenSince(bucket, root, filter string, since time.Time) bool {
+	since = since.Add(-metacacheWritesClockSkew)
+	if since.Before(globalBootTime) {
+		// Writes before the node started are unknown.
+		return true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b, ok := w.buckets[bucket]
+	if !ok {
+		return false
+	}
+	if b.all.After(since) {
+		return true
+	}
+	for dir, t := range b.dirs {
+		if !t.After(since) {
+			continue
+		}
+		// The filter has no slash, objects matching it are either
+		// directly in root or in directories matching it.
+		if dir == root || strings.HasPrefix(dir, root+filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanup removes the writes too old to invalidate a shared listing.
+func (w *metacacheWrites) cleanup() {
+	before := UTCNow().Add(-2 * metacacheShareMaxAge)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for bucket, b := range w.buckets {
+		b.prune(before)
+		if len(b.dirs) == 0 && b.all.Before(before) {
+			delete(w.buckets, bucket)
+		}
+	}
+}
+
+// MetacacheWrittenSince returns true if an object of the listing may have
+// been written on any node of the cluster since the listing started.
+// Unreachable nodes are assumed to have written.
+func (sys *NotificationSys) MetacacheWrittenSince(ctx context.Context, c metacache) bool {
+	if localMetacacheWrites.writtenSince(c.bucket, c.root, c.filter, c.started) {
+		return true
+	}
+	if sys == nil {
+		return false
+	}
+
+	written := make([]bool, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for i, client := range sys.peerClients {
+		if client == nil {
+			written[i] = true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, client *peerRESTClient) {
+			defer wg.Done()
+			ok, err := client.MetacacheWrittenSince(ctx, c.bucket, c.root, c.filter, c.started)
+			written[i] = ok || err != nil
+		}(i, client)
+	}
+	wg.Wait()
+	for _, ok := range written {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetacacheWritesWrittenSince(t *testing.T) {
+	defer func(bootTime time.Time) { globalBootTime = bootTime }(globalBootTime)
+	globalBootTime = UTCNow().Add(-time.Hour)
+
+	w := newMetacacheWrites()
+	since := UTCNow().Add(-time.Minute)
+	w.written("bucket", "a/b/object")
+	w.written("bucket", "object")
+	w.written(minioMetaBucket, "c/object")
+
+	testCases := []struct {
+		bucket, root, filter string
+		since                time.Time
+		written              bool
+	}{
+		{"bucket", "", "", since, true},
+		{"bucket", "a/", "", since, true},
+		{"bucket", "a/", "b", since, true},
+		{"bucket", "a/", "c", since, false},
+		{"bucket", "a/b/", "", since, true},
+		{"bucket", "a/b/c/", "", since, false},
+		{"bucket", "", "obj", since, true},
+		{"bucket", "d/", "", since, false},
+		{"bucket", "a/", "", UTCNow().Add(time.Minute), false},
+		{"other", "", "", since, false},
+		{minioMetaBucket, "c/", "", since, false},
+		// Writes before the node started are unknown.
+		{"other", "", "", globalBootTime.Add(-time.Minute), true},
+	}
+	for i, testCase := range testCases {
+		written := w.writtenSince(testCase.bucket, testCase.root, testCase.filter, testCase.since)
+		if written != testCase.written {
+			t.Errorf("Test %d: expected written %v, got %v", i+1, testCase.written, written)
+		}
+	}
+
+	w.writtenAll("other")
+	if !w.writtenSince("other", "d/", "", since) {
+		t.Error("expected bucket to be written")
+	}
+}
@@ -68,6 +68,8 @@ type metacache struct {
 	id           string     `msg:"id"`
 	error        string     `msg:"err"`
 	root         string     `msg:"root"`
+	pool         int        `msg:"p"`
+	set          int        `msg:"s"`
 	fileNotFound bool       `msg:"fnf"`
 	status       scanStatus `msg:"stat"`
 	recursive    bool       `msg:"rec"`
@@ -125,6 +127,8 @@ func (m *metacache) update(update metacache) {
 	}
 	if m.status == scanStateStarted && update.status == scanStateSuccess {
 		m.ended = UTCNow()
+		// The saver reports where the listing was stored.
+		m.pool, m.set = update.pool, update.set
 	}
 
 	if m.status == scanStateStarted && update.status != scanStateStarted {
@@ -78,6 +78,18 @@ func (z *metacache) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "root")
 				return
 			}
+		case "p":
+			z.pool, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "pool")
+				return
+			}
+		case "s":
+			z.set, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "set")
+				return
+			}
 		case "fnf":
 			z.fileNotFound, err = dc.ReadBool()
 			if err != nil {
@@ -119,9 +131,9 @@ func (z *metacache) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *metacache) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 13
+	// map header, size 15
 	// write "end"
-	err = en.Append(0x8d, 0xa3, 0x65, 0x6e, 0x64)
+	err = en.Append(0x8f, 0xa3, 0x65, 0x6e, 0x64)
 	if err != nil {
 		return
 	}
@@ -210,6 +222,26 @@ func (z *metacache) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "root")
 		return
 	}
+	// write "p"
+	err = en.Append(0xa1, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.pool)
+	if err != nil {
+		err = msgp.WrapError(err, "pool")
+		return
+	}
+	// write "s"
+	err = en.Append(0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.set)
+	if err != nil {
+		err = msgp.WrapError(err, "set")
+		return
+	}
 	// write "fnf"
 	err = en.Append(0xa3, 0x66, 0x6e, 0x66)
 	if err != nil {
@@ -256,9 +288,9 @@ func (z *metacache) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *metacache) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 13
+	// map header, size 15
 	// string "end"
-	o = append(o, 0x8d, 0xa3, 0x65, 0x6e, 0x64)
+	o = append(o, 0x8f, 0xa3, 0x65, 0x6e, 0x64)
 	o = msgp.AppendTime(o, z.ended)
 	// string "st"
 	o = append(o, 0xa2, 0x73, 0x74)
@@ -284,6 +316,12 @@ func (z *metacache) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "root"
 	o = append(o, 0xa4, 0x72, 0x6f, 0x6f, 0x74)
 	o = msgp.AppendString(o, z.root)
+	// string "p"
+	o = append(o, 0xa1, 0x70)
+	o = msgp.AppendInt(o, z.pool)
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o = msgp.AppendInt(o, z.set)
 	// string "fnf"
 	o = append(o, 0xa3, 0x66, 0x6e, 0x66)
 	o = msgp.AppendBool(o, z.fileNotFound)
@@ -371,6 +409,18 @@ func (z *metacache) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "root")
 				return
 			}
+		case "p":
+			z.pool, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "pool")
+				return
+			}
+		case "s":
+			z.set, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "set")
+				return
+			}
 		case "fnf":
 			z.fileNotFound, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
@@ -413,7 +463,7 @@ func (z *metacache) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *metacache) Msgsize() (s int) {
-	s = 1 + 4 + msgp.TimeSize + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 2 + msgp.TimeSize + 2 + msgp.StringPrefixSize + len(z.bucket) + 4 + msgp.StringPrefixSize + len(z.filter) + 3 + msgp.StringPrefixSize + len(z.id) + 4 + msgp.StringPrefixSize + len(z.error) + 5 + msgp.StringPrefixSize + len(z.root) + 4 + msgp.BoolSize + 5 + msgp.Uint8Size + 4 + msgp.BoolSize + 2 + msgp.Uint8Size
+	s = 1 + 4 + msgp.TimeSize + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 2 + msgp.TimeSize + 2 + msgp.StringPrefixSize + len(z.bucket) + 4 + msgp.StringPrefixSize + len(z.filter) + 3 + msgp.StringPrefixSize + len(z.id) + 4 + msgp.StringPrefixSize + len(z.error) + 5 + msgp.StringPrefixSize + len(z.root) + 2 + msgp.IntSize + 2 + msgp.IntSize + 4 + msgp.BoolSize + 5 + msgp.Uint8Size + 4 + msgp.BoolSize + 2 + msgp.Uint8Size
 	return
 }
 
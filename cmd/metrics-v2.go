@@ -22,11 +22,14 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -72,6 +75,12 @@ const (
 	usageSubsystem            MetricSubsystem = "usage"
 	ilmSubsystem              MetricSubsystem = "ilm"
 	scannerSubsystem          MetricSubsystem = "scanner"
+	quorumSubsystem           MetricSubsystem = "quorum"
+	qosSubsystem              MetricSubsystem = "qos"
+	notifyStoreSubsystem      MetricSubsystem = "notify_store"
+	erasureSetSubsystem       MetricSubsystem = "erasure_set"
+	probeSubsystem            MetricSubsystem = "probe"
+	blockCacheSubsystem       MetricSubsystem = "block_cache"
 )
 
 // MetricName are the individual names for the metric.
@@ -87,6 +96,7 @@ const (
 	inflightTotal  MetricName = "inflight_total"
 	invalidTotal   MetricName = "invalid_total"
 	limitTotal     MetricName = "limit_total"
+	memoryTotal    MetricName = "memory_total"
 	missedTotal    MetricName = "missed_total"
 	waitingTotal   MetricName = "waiting_total"
 	objectTotal    MetricName = "object_total"
@@ -100,6 +110,11 @@ const (
 	freeInodes     MetricName = "free_inodes"
 
 	failedCount     MetricName = "failed_count"
+	lockSyncFailed  MetricName = "lock_sync_failed_count"
+	deleteMarkers   MetricName = "delete_marker_count"
+	versionPurges   MetricName = "version_purge_count"
+	proxyCount      MetricName = "proxy_count"
+	driftCount      MetricName = "drift_count"
 	failedBytes     MetricName = "failed_bytes"
 	freeBytes       MetricName = "free_bytes"
 	readBytes       MetricName = "read_bytes"
@@ -110,16 +125,26 @@ const (
 	totalBytes      MetricName = "total_bytes"
 	usedBytes       MetricName = "used_bytes"
 	writeBytes      MetricName = "write_bytes"
+	inflightBytes   MetricName = "upload_inflight_bytes"
+	memWaitingTotal MetricName = "memory_waiting_total"
 	wcharBytes      MetricName = "wchar_bytes"
 
 	usagePercent MetricName = "update_percent"
 
+	latencyWindowMilliSec MetricName = "latency_window_ms"
+	lastReplicatedSeconds MetricName = "last_replicated_seconds"
+	oldestPendingSeconds  MetricName = "oldest_pending_seconds"
+
 	commitInfo  MetricName = "commit_info"
 	usageInfo   MetricName = "usage_info"
 	versionInfo MetricName = "version_info"
 
-	sizeDistribution = "size_distribution"
-	ttfbDistribution = "ttfb_seconds_distribution"
+	sizeDistribution         = "size_distribution"
+	ttfbDistribution         = "ttfb_seconds_distribution"
+	firstByteDistribution    = "first_byte_seconds_distribution"
+	latencyDistribution      = "latency_seconds_distribution"
+	requestSizeDistribution  = "request_size_distribution"
+	responseSizeDistribution = "response_size_distribution"
 
 	lastActivityTime = "last_activity_nano_seconds"
 	startTime        = "starttime_seconds"
@@ -127,9 +152,38 @@ const (
 	memory           = "resident_memory_bytes"
 	cpu              = "cpu_total_seconds"
 
-	expiryPendingTasks     MetricName = "expiry_pending_tasks"
-	transitionPendingTasks MetricName = "transition_pending_tasks"
-	transitionActiveTasks  MetricName = "transition_active_tasks"
+	expiryPendingTasks      MetricName = "expiry_pending_tasks"
+	transitionPendingTasks  MetricName = "transition_pending_tasks"
+	transitionActiveTasks   MetricName = "transition_active_tasks"
+	abortedMultipartUploads MetricName = "aborted_multipart_uploads"
+	abortedMultipartBytes   MetricName = "aborted_multipart_bytes"
+
+	tierTransitionedObjects      MetricName = "tier_transitioned_objects"
+	tierTransitionedBytes        MetricName = "tier_transitioned_bytes"
+	tierTransitionFailedObjects  MetricName = "tier_transition_failed_objects"
+	tierTransitionFailedBytes    MetricName = "tier_transition_failed_bytes"
+	tierTransitionPendingObjects MetricName = "tier_transition_pending_objects"
+	tierTransitionPendingBytes   MetricName = "tier_transition_pending_bytes"
+	tierTransitionErrorsQueued   MetricName = "tier_transition_errors_queued"
+
+	objectsSampled    MetricName = "objects_sampled"
+	objectsAtRisk     MetricName = "objects_at_risk"
+	objectsUnreadable MetricName = "objects_unreadable"
+
+	throttledRequestsTotal  MetricName = "throttled_requests_total"
+	clientErrorsTotal       MetricName = "4xx_errors_total"
+	serverErrorsTotal       MetricName = "5xx_errors_total"
+	errorCodesTotal         MetricName = "error_codes_total"
+	throttledBandwidthTotal MetricName = "throttled_bandwidth_total"
+
+	queuedEvents MetricName = "queued_events"
+
+	onlineDisks          MetricName = "online_disks"
+	healingDisks         MetricName = "healing_disks"
+	readQuorumAvailable  MetricName = "read_quorum_available"
+	writeQuorumAvailable MetricName = "write_quorum_available"
+
+	probeSuccess MetricName = "success"
 )
 
 const (
@@ -236,6 +290,9 @@ func GetGlobalGenerators() []MetricsGenerator {
 		getMinioHealingMetrics,
 		getNodeHealthMetrics,
 		getClusterStorageMetrics,
+		getClusterQuorumMetrics,
+		getClusterErasureSetMetrics,
+		getClusterProbeMetrics,
 	}
 	return g
 }
@@ -258,8 +315,15 @@ func GetGeneratorsForPeer() []MetricsGenerator {
 		getMinioVersionMetrics,
 		getNetworkMetrics,
 		getS3TTFBMetric,
+		getS3LatencyMetrics,
+		getS3SizeMetrics,
 		getILMNodeMetrics,
 		getScannerNodeMetrics,
+		getBucketQoSMetrics,
+		getBucketErrorMetrics,
+		getNotifyStoreMetrics,
+		getBlockCacheMetrics,
+		getLocalDriveODirectMetrics,
 	}
 	return g
 }
@@ -273,6 +337,8 @@ func GetSingleNodeGenerators() []MetricsGenerator {
 		getNetworkMetrics,
 		getMinioVersionMetrics,
 		getS3TTFBMetric,
+		getS3LatencyMetrics,
+		getS3SizeMetrics,
 	}
 	return g
 }
@@ -362,6 +428,116 @@ func getClusterDisksTotalMD() MetricDescription {
 	}
 }
 
+func getClusterQuorumObjectsSampledMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: quorumSubsystem,
+		Name:      objectsSampled,
+		Help:      "Objects sampled by the quorum monitor in the last cycle.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterQuorumObjectsAtRiskMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: quorumSubsystem,
+		Name:      objectsAtRisk,
+		Help:      "Sampled objects which are one disk away from losing read quorum.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterQuorumObjectsUnreadableMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: quorumSubsystem,
+		Name:      objectsUnreadable,
+		Help:      "Sampled objects which have lost read quorum.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterErasureSetOnlineDisksMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: erasureSetSubsystem,
+		Name:      onlineDisks,
+		Help:      "Disks online in the erasure set.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterErasureSetHealingDisksMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: erasureSetSubsystem,
+		Name:      healingDisks,
+		Help:      "Disks healing in the erasure set.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterErasureSetFreeBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: erasureSetSubsystem,
+		Name:      freeBytes,
+		Help:      "Free capacity of the online disks in the erasure set.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterErasureSetReadQuorumAvailableMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: erasureSetSubsystem,
+		Name:      readQuorumAvailable,
+		Help:      "1 if enough disks of the erasure set are online to read objects, 0 otherwise.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterErasureSetWriteQuorumAvailableMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: erasureSetSubsystem,
+		Name:      writeQuorumAvailable,
+		Help:      "1 if enough disks of the erasure set are online to write objects, 0 otherwise.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterProbeSuccessMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: probeSubsystem,
+		Name:      probeSuccess,
+		Help:      "1 if the last consistency probe of the erasure set succeeded, 0 otherwise.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterProbeLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: probeSubsystem,
+		Name:      latencyMilliSec,
+		Help:      "Latency of the steps of the last consistency probe of the erasure set.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getClusterProbeFailedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: probeSubsystem,
+		Name:      failedCount,
+		Help:      "Failed consistency probes of the erasure set since the server start.",
+		Type:      counterMetric,
+	}
+}
+
 func getClusterDisksFreeInodes() MetricDescription {
 	return MetricDescription{
 		Namespace: clusterMetricNamespace,
@@ -420,6 +596,16 @@ func getBucketRepLatencyMD() MetricDescription {
 	}
 }
 
+func getBucketRepLatencyWindowMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      latencyWindowMilliSec,
+		Help:      "Replication latency in milliseconds over each window.",
+		Type:      histogramMetric,
+	}
+}
+
 func getBucketRepFailedBytesMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -457,6 +643,69 @@ func getBucketRepFailedOperationsMD() MetricDescription {
 		Type:      gaugeMetric,
 	}
 }
+func getBucketRepLockSyncFailedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      lockSyncFailed,
+		Help:      "Total number of objects whose object lock metadata failed to sync",
+		Type:      gaugeMetric,
+	}
+}
+func getBucketRepDeleteMarkersMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      deleteMarkers,
+		Help:      "Total number of delete marker replications by status",
+		Type:      gaugeMetric,
+	}
+}
+func getBucketRepProxyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      proxyCount,
+		Help:      "Total number of reads proxied to the target because the object was not replicated locally yet, by status",
+		Type:      counterMetric,
+	}
+}
+func getBucketRepDriftMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      driftCount,
+		Help:      "Total number of object versions found out of sync with the target by the replication consistency check, by type",
+		Type:      counterMetric,
+	}
+}
+func getBucketRepLastReplicatedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      lastReplicatedSeconds,
+		Help:      "Time elapsed in seconds since the last successful replication to the target",
+		Type:      gaugeMetric,
+	}
+}
+func getBucketRepOldestPendingMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      oldestPendingSeconds,
+		Help:      "Time elapsed in seconds since the oldest replication pending to the target was queued, 0 when none is pending",
+		Type:      gaugeMetric,
+	}
+}
+func getBucketRepVersionPurgesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      versionPurges,
+		Help:      "Total number of permanent delete replications of object versions by status",
+		Type:      gaugeMetric,
+	}
+}
 func getBucketObjectDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -466,6 +715,60 @@ func getBucketObjectDistributionMD() MetricDescription {
 		Type:      histogramMetric,
 	}
 }
+func getBucketQoSThrottledRequestsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: qosSubsystem,
+		Name:      throttledRequestsTotal,
+		Help:      "Total number of requests rejected by the bucket request rate limits",
+		Type:      counterMetric,
+	}
+}
+func getBucketRequestsClientErrorsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      clientErrorsTotal,
+		Help:      "Total number of S3 requests on the bucket failed with a 4xx status code",
+		Type:      counterMetric,
+	}
+}
+func getBucketRequestsServerErrorsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      serverErrorsTotal,
+		Help:      "Total number of S3 requests on the bucket failed with a 5xx status code",
+		Type:      counterMetric,
+	}
+}
+func getBucketRequestsErrorCodesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      errorCodesTotal,
+		Help:      "Total number of S3 requests on the bucket failed with the most frequent S3 error codes",
+		Type:      counterMetric,
+	}
+}
+func getNotifyStoreQueuedEventsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifyStoreSubsystem,
+		Name:      queuedEvents,
+		Help:      "Number of undelivered events in the queue store of a notification target",
+		Type:      gaugeMetric,
+	}
+}
+func getBucketQoSThrottledBandwidthMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: qosSubsystem,
+		Name:      throttledBandwidthTotal,
+		Help:      "Total number of requests rejected by the bucket bandwidth limits",
+		Type:      counterMetric,
+	}
+}
 func getInternodeFailedRequests() MetricDescription {
 	return MetricDescription{
 		Namespace: interNodeMetricNamespace,
@@ -530,6 +833,33 @@ func getS3RequestsInQueueMD() MetricDescription {
 		Type:      gaugeMetric,
 	}
 }
+func getS3RequestsInMemoryQueueMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      memWaitingTotal,
+		Help:      "Number of large S3 requests waiting for memory pressure to drop",
+		Type:      gaugeMetric,
+	}
+}
+func getS3RequestsUploadInflightBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      inflightBytes,
+		Help:      "Total body bytes of large S3 uploads currently in flight",
+		Type:      gaugeMetric,
+	}
+}
+func getS3RejectedMemoryRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      memoryTotal,
+		Help:      "Total number S3 requests rejected with SlowDown under memory pressure.",
+		Type:      counterMetric,
+	}
+}
 func getS3RequestsTotalMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
@@ -738,6 +1068,44 @@ func getS3TTFBDistributionMD() MetricDescription {
 		Type:      gaugeMetric,
 	}
 }
+func getS3FirstByteDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: timeSubsystem,
+		Name:      firstByteDistribution,
+		Help:      "Distribution of the time from the receipt of requests to the first byte of their response body across API calls.",
+		Type:      gaugeMetric,
+	}
+}
+func getS3LatencyDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: timeSubsystem,
+		Name:      latencyDistribution,
+		Help:      "Distribution of the time from the receipt of requests to the end of their response across API calls.",
+		Type:      gaugeMetric,
+	}
+}
+func getS3RequestSizeDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      requestSizeDistribution,
+		Help:      "Distribution of the request body sizes across API calls.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getS3ResponseSizeDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: trafficSubsystem,
+		Name:      responseSizeDistribution,
+		Help:      "Distribution of the response body sizes across API calls.",
+		Type:      gaugeMetric,
+	}
+}
+
 func getMinioFDOpenMD() MetricDescription {
 	return MetricDescription{
 		Namespace: nodeMetricNamespace,
@@ -1021,6 +1389,76 @@ func getS3TTFBMetric() MetricsGroup {
 	}
 }
 
+// getS3LatencyMetrics reports the first byte and total latencies of the
+// S3 APIs, the time spent streaming the response to the client is their
+// difference.
+func getS3LatencyMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "s3LatencyMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			metrics = collectHistogramMetrics(httpRequestsFirstByte, getS3FirstByteDistributionMD(), "%.3f")
+			return append(metrics, collectHistogramMetrics(httpRequestsLatency, getS3LatencyDistributionMD(), "%.3f")...)
+		},
+	}
+}
+
+// getHistogramMetrics converts the buckets of a histogram per
+// API into metrics labeled with their upper bound in bytes.
+func getHistogramMetrics(hist *prometheus.HistogramVec, desc MetricDescription) []Metric {
+	return collectHistogramMetrics(hist, desc, "%.0f")
+}
+
+// collectHistogramMetrics converts the buckets of a histogram per API
+// into metrics labeled with their upper bound in the given format.
+func collectHistogramMetrics(hist *prometheus.HistogramVec, desc MetricDescription, leFormat string) (metrics []Metric) {
+	// Read prometheus metric on this channel
+	ch := make(chan prometheus.Metric)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// Read prometheus histogram data and convert it to internal metric data
+	go func() {
+		defer wg.Done()
+		for promMetric := range ch {
+			dtoMetric := &dto.Metric{}
+			if err := promMetric.Write(dtoMetric); err != nil {
+				logger.LogIf(GlobalContext, err)
+				continue
+			}
+			h := dtoMetric.GetHistogram()
+			for _, b := range h.Bucket {
+				labels := make(map[string]string)
+				for _, lp := range dtoMetric.GetLabel() {
+					labels[*lp.Name] = *lp.Value
+				}
+				labels["le"] = fmt.Sprintf(leFormat, *b.UpperBound)
+				metrics = append(metrics, Metric{
+					Description:    desc,
+					VariableLabels: labels,
+					Value:          float64(b.GetCumulativeCount()),
+				})
+			}
+		}
+	}()
+
+	hist.Collect(ch)
+	close(ch)
+	wg.Wait()
+	return
+}
+
+func getS3SizeMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "s3SizeMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			metrics = getHistogramMetrics(httpRequestsSize, getS3RequestSizeDistributionMD())
+			return append(metrics, getHistogramMetrics(httpResponsesSize, getS3ResponseSizeDistributionMD())...)
+		},
+	}
+}
+
 func getTransitionPendingTasksMD() MetricDescription {
 	return MetricDescription{
 		Namespace: nodeMetricNamespace,
@@ -1051,6 +1489,96 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getAbortedMultipartUploadsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      abortedMultipartUploads,
+		Help:      "Total number of incomplete multipart uploads aborted by ILM since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getAbortedMultipartBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      abortedMultipartBytes,
+		Help:      "Total bytes reclaimed by aborting incomplete multipart uploads since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionedObjectsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionedObjects,
+		Help:      "Total number of objects transitioned to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionedBytes,
+		Help:      "Total bytes transitioned to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionFailedObjectsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionFailedObjects,
+		Help:      "Total number of failed transitions to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionFailedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionFailedBytes,
+		Help:      "Total bytes of failed transitions to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierTransitionPendingObjectsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionPendingObjects,
+		Help:      "Number of objects waiting to be transitioned to the remote tier.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierTransitionPendingBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionPendingBytes,
+		Help:      "Bytes waiting to be transitioned to the remote tier.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierTransitionErrorsQueuedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      tierTransitionErrorsQueued,
+		Help:      "Number of failed transitions to the remote tier held in the error queue.",
+		Type:      gaugeMetric,
+	}
+}
+
 func getILMNodeMetrics() MetricsGroup {
 	return MetricsGroup{
 		id:         "ILMNodeMetrics",
@@ -1068,14 +1596,161 @@ func getILMNodeMetrics() MetricsGroup {
 			if globalExpiryState != nil {
 				expPendingTasks.Value = float64(globalExpiryState.PendingTasks())
 			}
+			var tierStatuses []TierTransitionStatus
 			if globalTransitionState != nil {
 				trPendingTasks.Value = float64(globalTransitionState.PendingTasks())
 				trActiveTasks.Value = float64(globalTransitionState.ActiveTasks())
+				tierStatuses = globalTransitionState.TierStatus()
 			}
-			return []Metric{
+			metrics := []Metric{
 				expPendingTasks,
 				trPendingTasks,
 				trActiveTasks,
+				{
+					Description: getAbortedMultipartUploadsMD(),
+					Value:       float64(atomic.LoadUint64(&globalLifecycleMultipartStats.abortedUploads)),
+				},
+				{
+					Description: getAbortedMultipartBytesMD(),
+					Value:       float64(atomic.LoadUint64(&globalLifecycleMultipartStats.abortedBytes)),
+				},
+			}
+			for _, st := range tierStatuses {
+				labels := map[string]string{"tier": st.Tier}
+				metrics = append(metrics,
+					Metric{Description: getTierTransitionedObjectsMD(), Value: float64(st.Transitioned), VariableLabels: labels},
+					Metric{Description: getTierTransitionedBytesMD(), Value: float64(st.TransitionedBytes), VariableLabels: labels},
+					Metric{Description: getTierTransitionFailedObjectsMD(), Value: float64(st.Failed), VariableLabels: labels},
+					Metric{Description: getTierTransitionFailedBytesMD(), Value: float64(st.FailedBytes), VariableLabels: labels},
+					Metric{Description: getTierTransitionPendingObjectsMD(), Value: float64(st.Pending), VariableLabels: labels},
+					Metric{Description: getTierTransitionPendingBytesMD(), Value: float64(st.PendingBytes), VariableLabels: labels},
+					Metric{Description: getTierTransitionErrorsQueuedMD(), Value: float64(len(st.Errors)), VariableLabels: labels},
+				)
+			}
+			return metrics
+		},
+	}
+}
+
+func getBucketQoSMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "BucketQoSMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			for bucket, s := range globalBucketQoSSys.stats() {
+				for t := qosRead; t < qosTypes; t++ {
+					metrics = append(metrics, Metric{
+						Description:    getBucketQoSThrottledRequestsMD(),
+						Value:          float64(s.ThrottledRequests[t]),
+						VariableLabels: map[string]string{"bucket": bucket, "type": t.String()},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketQoSThrottledBandwidthMD(),
+						Value:          float64(s.ThrottledBandwidth[t]),
+						VariableLabels: map[string]string{"bucket": bucket, "type": t.String()},
+					})
+				}
+			}
+			return
+		},
+	}
+}
+
+func getBucketErrorMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "BucketErrorMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			for bucket, apis := range globalBucketErrorStats.stats() {
+				for api, e := range apis {
+					metrics = append(metrics, Metric{
+						Description:    getBucketRequestsClientErrorsMD(),
+						Value:          float64(e.ClientErrors),
+						VariableLabels: map[string]string{"bucket": bucket, "api": api},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRequestsServerErrorsMD(),
+						Value:          float64(e.ServerErrors),
+						VariableLabels: map[string]string{"bucket": bucket, "api": api},
+					})
+					for _, code := range e.topCodes(bucketErrorTopCodes) {
+						metrics = append(metrics, Metric{
+							Description:    getBucketRequestsErrorCodesMD(),
+							Value:          float64(e.Codes[code]),
+							VariableLabels: map[string]string{"bucket": bucket, "api": api, "code": code},
+						})
+					}
+				}
+			}
+			return
+		},
+	}
+}
+
+func getNotifyStoreMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "NotifyStoreMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			for id, depth := range notifyStoreDepths() {
+				metrics = append(metrics, Metric{
+					Description:    getNotifyStoreQueuedEventsMD(),
+					Value:          float64(depth),
+					VariableLabels: map[string]string{"target_id": id.ID, "target_name": id.Name},
+				})
+			}
+			return
+		},
+	}
+}
+
+func getBlockCacheMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "BlockCacheMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) []Metric {
+			st := globalBlockCache.stats()
+			return []Metric{
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: blockCacheSubsystem,
+						Name:      "hits",
+						Help:      "Total number of erasure blocks served from the block cache.",
+						Type:      counterMetric,
+					},
+					Value: float64(st.Hits),
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: blockCacheSubsystem,
+						Name:      "misses",
+						Help:      "Total number of erasure blocks looked up in the block cache and read from drives.",
+						Type:      counterMetric,
+					},
+					Value: float64(st.Misses),
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: blockCacheSubsystem,
+						Name:      "used_bytes",
+						Help:      "Total size of the erasure blocks in the block cache.",
+						Type:      gaugeMetric,
+					},
+					Value: float64(st.Used),
+				},
+				{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: blockCacheSubsystem,
+						Name:      "capacity_bytes",
+						Help:      "Capacity of the block cache, 0 if disabled.",
+						Type:      gaugeMetric,
+					},
+					Value: float64(st.Size),
+				},
 			}
 		},
 	}
@@ -1339,7 +2014,7 @@ func getHTTPMetrics() MetricsGroup {
 		cachedRead: cachedRead,
 		read: func(ctx context.Context) (metrics []Metric) {
 			httpStats := globalHTTPStats.toServerHTTPStats()
-			metrics = make([]Metric, 0, 3+
+			metrics = make([]Metric, 0, 8+
 				len(httpStats.CurrentS3Requests.APIStats)+
 				len(httpStats.TotalS3Requests.APIStats)+
 				len(httpStats.TotalS3Errors.APIStats))
@@ -1363,6 +2038,18 @@ func getHTTPMetrics() MetricsGroup {
 				Description: getS3RequestsInQueueMD(),
 				Value:       float64(httpStats.S3RequestsInQueue),
 			})
+			metrics = append(metrics, Metric{
+				Description: getS3RequestsInMemoryQueueMD(),
+				Value:       float64(atomic.LoadInt64(&globalAdmission.waiting)),
+			})
+			metrics = append(metrics, Metric{
+				Description: getS3RequestsUploadInflightBytesMD(),
+				Value:       float64(atomic.LoadInt64(&globalAdmission.inflightBytes)),
+			})
+			metrics = append(metrics, Metric{
+				Description: getS3RejectedMemoryRequestsTotalMD(),
+				Value:       float64(atomic.LoadUint64(&globalAdmission.rejected)),
+			})
 			for api, value := range httpStats.CurrentS3Requests.APIStats {
 				metrics = append(metrics, Metric{
 					Description:    getS3RequestsInFlightMD(),
@@ -1493,12 +2180,85 @@ func getBucketUsageMetrics() MetricsGroup {
 							Value:          float64(stat.FailedCount),
 							VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
 						})
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepLockSyncFailedMD(),
+							Value:          float64(stat.LockSyncFailedCount),
+							VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+						})
+						for status, v := range map[string]int64{
+							"proxied": stat.ProxiedCount,
+							"failed":  stat.ProxyFailedCount,
+						} {
+							metrics = append(metrics, Metric{
+								Description:    getBucketRepProxyMD(),
+								Value:          float64(v),
+								VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn, "status": status},
+							})
+						}
+						for typ, v := range map[string]int64{
+							"missing": stat.DriftMissingCount,
+							"version": stat.DriftVersionMismatchCount,
+							"etag":    stat.DriftETagMismatchCount,
+							"tags":    stat.DriftTagMismatchCount,
+						} {
+							metrics = append(metrics, Metric{
+								Description:    getBucketRepDriftMD(),
+								Value:          float64(v),
+								VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn, "type": typ},
+							})
+						}
+						if !stat.LastReplicatedAt.IsZero() {
+							metrics = append(metrics, Metric{
+								Description:    getBucketRepLastReplicatedMD(),
+								Value:          time.Since(stat.LastReplicatedAt).Seconds(),
+								VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+							})
+						}
+						var oldestPending float64
+						if !stat.OldestPendingSince.IsZero() {
+							oldestPending = time.Since(stat.OldestPendingSince).Seconds()
+						}
+						metrics = append(metrics, Metric{
+							Description:    getBucketRepOldestPendingMD(),
+							Value:          oldestPending,
+							VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+						})
+						for status, v := range map[string]int64{
+							"replicated": stat.ReplicatedDeleteMarkers,
+							"pending":    stat.PendingDeleteMarkers,
+							"failed":     stat.FailedDeleteMarkers,
+						} {
+							metrics = append(metrics, Metric{
+								Description:    getBucketRepDeleteMarkersMD(),
+								Value:          float64(v),
+								VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn, "status": status},
+							})
+						}
+						for status, v := range map[string]int64{
+							"replicated": stat.ReplicatedVersionPurges,
+							"pending":    stat.PendingVersionPurges,
+							"failed":     stat.FailedVersionPurges,
+						} {
+							metrics = append(metrics, Metric{
+								Description:    getBucketRepVersionPurgesMD(),
+								Value:          float64(v),
+								VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn, "status": status},
+							})
+						}
 						metrics = append(metrics, Metric{
 							Description:          getBucketRepLatencyMD(),
 							HistogramBucketLabel: "range",
 							Histogram:            stat.Latency.getUploadLatency(),
 							VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
 						})
+						for window, histogram := range stat.Latency.getUploadLatencies() {
+							metrics = append(metrics, Metric{
+								Description:          getBucketRepLatencyWindowMD(),
+								HistogramBucketLabel: "range",
+								Histogram:            histogram,
+								VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn, "window": window},
+							})
+						}
 
 					}
 				}
@@ -1557,6 +2317,76 @@ func getLocalStorageMetrics() MetricsGroup {
 		},
 	}
 }
+
+func getLocalDriveODirectMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "localDriveODirectMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			z, ok := newObjectLayerFn().(*erasureServerPools)
+			if !ok {
+				return
+			}
+
+			for _, pool := range z.getServerPools() {
+				for _, set := range pool.sets {
+					for _, disk := range set.getDisks() {
+						s := localXLStorage(disk)
+						if s == nil {
+							continue
+						}
+						od := s.odirect()
+						odirect := 0.0
+						if od.enabled {
+							odirect = 1
+						}
+						metrics = append(metrics, Metric{
+							Description: MetricDescription{
+								Namespace: nodeMetricNamespace,
+								Subsystem: diskSubsystem,
+								Name:      "odirect",
+								Help:      "1 if writes to the disk use O_DIRECT, 0 otherwise. The mode label is the configured mode.",
+								Type:      gaugeMetric,
+							},
+							Value:          odirect,
+							VariableLabels: map[string]string{"disk": s.diskPath, "mode": od.mode},
+						})
+						metrics = append(metrics, Metric{
+							Description: MetricDescription{
+								Namespace: nodeMetricNamespace,
+								Subsystem: diskSubsystem,
+								Name:      "write_alignment_bytes",
+								Help:      "Alignment of O_DIRECT writes to the disk.",
+								Type:      gaugeMetric,
+							},
+							Value:          float64(od.align),
+							VariableLabels: map[string]string{"disk": s.diskPath},
+						})
+						if s.driveInfo != nil {
+							rotational := 0.0
+							if s.driveInfo.Rotational {
+								rotational = 1
+							}
+							metrics = append(metrics, Metric{
+								Description: MetricDescription{
+									Namespace: nodeMetricNamespace,
+									Subsystem: diskSubsystem,
+									Name:      "rotational",
+									Help:      "1 if the disk was detected as a spinning disk, 0 otherwise.",
+									Type:      gaugeMetric,
+								},
+								Value:          rotational,
+								VariableLabels: map[string]string{"disk": s.diskPath, "device": s.driveInfo.Name},
+							})
+						}
+					}
+				}
+			}
+			return
+		},
+	}
+}
+
 func getClusterStorageMetrics() MetricsGroup {
 	return MetricsGroup{
 		id:         "ClusterStorageMetrics",
@@ -1613,6 +2443,204 @@ func getClusterStorageMetrics() MetricsGroup {
 	}
 }
 
+func getClusterQuorumMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "ClusterQuorumMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			objLayer := newObjectLayerFn()
+			// Service not initialized yet
+			if objLayer == nil || !globalIsErasure {
+				return
+			}
+
+			status, err := loadQuorumMonitorStatus(ctx, objLayer)
+			if err != nil {
+				return
+			}
+
+			metrics = make([]Metric, 0, 3*len(status.Sets))
+			for _, st := range status.Sets {
+				labels := map[string]string{
+					"pool": strconv.Itoa(st.Pool),
+					"set":  strconv.Itoa(st.Set),
+				}
+				metrics = append(metrics, Metric{
+					Description:    getClusterQuorumObjectsSampledMD(),
+					Value:          float64(st.Sampled),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterQuorumObjectsAtRiskMD(),
+					Value:          float64(st.AtRisk),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterQuorumObjectsUnreadableMD(),
+					Value:          float64(st.Unreadable),
+					VariableLabels: labels,
+				})
+			}
+			return
+		},
+	}
+}
+
+// erasureSetHealth - health of a single erasure set.
+type erasureSetHealth struct {
+	Pool, Set    int
+	OnlineDisks  int
+	HealingDisks int
+	FreeBytes    uint64
+	ReadQuorum   int
+	WriteQuorum  int
+}
+
+// getErasureSetHealth groups the disks by erasure set, ordered
+// by pool and set index. Disks not part of a set are ignored.
+func getErasureSetHealth(disks []madmin.Disk, backend madmin.BackendInfo) []erasureSetHealth {
+	type setIndex struct{ pool, set int }
+	sets := make(map[setIndex]*erasureSetHealth)
+	for _, disk := range disks {
+		if disk.PoolIndex < 0 || disk.SetIndex < 0 {
+			continue
+		}
+		idx := setIndex{disk.PoolIndex, disk.SetIndex}
+		h, ok := sets[idx]
+		if !ok {
+			h = &erasureSetHealth{Pool: disk.PoolIndex, Set: disk.SetIndex}
+			if disk.PoolIndex < len(backend.StandardSCData) {
+				h.ReadQuorum = backend.StandardSCData[disk.PoolIndex]
+				h.WriteQuorum = h.ReadQuorum
+				if h.WriteQuorum == backend.StandardSCParity {
+					h.WriteQuorum++
+				}
+			}
+			sets[idx] = h
+		}
+		if disk.Healing {
+			h.HealingDisks++
+		}
+		if disk.State != madmin.DriveStateOk && disk.State != madmin.DriveStateUnformatted {
+			continue
+		}
+		h.OnlineDisks++
+		h.FreeBytes += disk.AvailableSpace
+	}
+
+	health := make([]erasureSetHealth, 0, len(sets))
+	for _, h := range sets {
+		health = append(health, *h)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		if health[i].Pool != health[j].Pool {
+			return health[i].Pool < health[j].Pool
+		}
+		return health[i].Set < health[j].Set
+	})
+	return health
+}
+
+func getClusterErasureSetMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "ClusterErasureSetMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			objLayer := newObjectLayerFn()
+			// Service not initialized yet
+			if objLayer == nil || !globalIsErasure {
+				return
+			}
+
+			storageInfo, _ := objLayer.StorageInfo(ctx)
+			health := getErasureSetHealth(storageInfo.Disks, storageInfo.Backend)
+
+			boolToFloat := func(b bool) float64 {
+				if b {
+					return 1
+				}
+				return 0
+			}
+
+			metrics = make([]Metric, 0, 5*len(health))
+			for _, h := range health {
+				labels := map[string]string{
+					"pool": strconv.Itoa(h.Pool),
+					"set":  strconv.Itoa(h.Set),
+				}
+				metrics = append(metrics, Metric{
+					Description:    getClusterErasureSetOnlineDisksMD(),
+					Value:          float64(h.OnlineDisks),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterErasureSetHealingDisksMD(),
+					Value:          float64(h.HealingDisks),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterErasureSetFreeBytesMD(),
+					Value:          float64(h.FreeBytes),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterErasureSetReadQuorumAvailableMD(),
+					Value:          boolToFloat(h.ReadQuorum > 0 && h.OnlineDisks >= h.ReadQuorum),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterErasureSetWriteQuorumAvailableMD(),
+					Value:          boolToFloat(h.WriteQuorum > 0 && h.OnlineDisks >= h.WriteQuorum),
+					VariableLabels: labels,
+				})
+			}
+			return
+		},
+	}
+}
+
+func getClusterProbeMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "ClusterProbeMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			status := globalConsistencyProbes.status()
+			for _, res := range status.Sets {
+				labels := map[string]string{
+					"pool": strconv.Itoa(res.Pool),
+					"set":  strconv.Itoa(res.Set),
+				}
+				var success float64
+				if res.OK {
+					success = 1
+				}
+				metrics = append(metrics, Metric{
+					Description:    getClusterProbeSuccessMD(),
+					Value:          success,
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description:    getClusterProbeFailedMD(),
+					Value:          float64(res.Failures),
+					VariableLabels: labels,
+				})
+				for step, latency := range res.Latencies {
+					metrics = append(metrics, Metric{
+						Description: getClusterProbeLatencyMD(),
+						Value:       float64(latency.Milliseconds()),
+						VariableLabels: map[string]string{
+							"pool": labels["pool"],
+							"set":  labels["set"],
+							"step": step,
+						},
+					})
+				}
+			}
+			return
+		},
+	}
+}
+
 type minioClusterCollector struct {
 	desc *prometheus.Desc
 }
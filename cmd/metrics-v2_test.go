@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestGetErasureSetHealth(t *testing.T) {
+	disk := func(pool, set int, state string, healing bool, free uint64) madmin.Disk {
+		return madmin.Disk{PoolIndex: pool, SetIndex: set, State: state, Healing: healing, AvailableSpace: free}
+	}
+	disks := []madmin.Disk{
+		disk(1, 0, madmin.DriveStateOk, false, 10),
+		disk(1, 0, madmin.DriveStateOk, false, 10),
+		disk(1, 0, madmin.DriveStateOffline, false, 10),
+		disk(1, 0, madmin.DriveStateOffline, false, 10),
+		disk(0, 1, madmin.DriveStateOk, false, 5),
+		disk(0, 1, madmin.DriveStateOk, true, 5),
+		disk(0, 1, madmin.DriveStateOk, false, 5),
+		disk(0, 1, madmin.DriveStateUnformatted, false, 5),
+		disk(-1, -1, madmin.DriveStateOffline, false, 0),
+	}
+	backend := madmin.BackendInfo{StandardSCData: []int{2, 2}, StandardSCParity: 2}
+
+	want := []erasureSetHealth{
+		{Pool: 0, Set: 1, OnlineDisks: 4, HealingDisks: 1, FreeBytes: 20, ReadQuorum: 2, WriteQuorum: 3},
+		{Pool: 1, Set: 0, OnlineDisks: 2, FreeBytes: 20, ReadQuorum: 2, WriteQuorum: 3},
+	}
+	if got := getErasureSetHealth(disks, backend); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestGetHistogramMetrics(t *testing.T) {
+	hist := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "test_size_bytes",
+			Buckets: []float64{1024, 1048576},
+		},
+		[]string{"api"},
+	)
+	hist.With(prometheus.Labels{"api": "PutObject"}).Observe(100)
+	hist.With(prometheus.Labels{"api": "PutObject"}).Observe(4096)
+
+	got := make(map[string]float64)
+	for _, m := range getHistogramMetrics(hist, getS3RequestSizeDistributionMD()) {
+		if m.VariableLabels["api"] != "PutObject" {
+			t.Fatalf("unexpected labels %v", m.VariableLabels)
+		}
+		got[m.VariableLabels["le"]] = m.Value
+	}
+	want := map[string]float64{"1024": 1, "1048576": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestS3LatencyMetrics(t *testing.T) {
+	const api = "testfirstbyte"
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	received := time.Now().UTC().Add(-3 * time.Second)
+	r = r.WithContext(context.WithValue(r.Context(), requestReceivedTimeKey, received))
+
+	// The request waited 2s before reaching the handler, which wrote the
+	// first byte of the body after 400ms more.
+	w := logger.NewResponseWriter(httptest.NewRecorder())
+	w.StartTime = received.Add(2 * time.Second)
+	w.TimeToFirstByte = 400 * time.Millisecond
+	newHTTPStats().updateStats(api, r, w, 0)
+
+	// Bodyless responses only have a total latency.
+	w = logger.NewResponseWriter(httptest.NewRecorder())
+	newHTTPStats().updateStats(api, httptest.NewRequest(http.MethodHead, "/bucket/object", nil), w, 0)
+
+	counts := func(hist *prometheus.HistogramVec) map[string]float64 {
+		got := make(map[string]float64)
+		for _, m := range collectHistogramMetrics(hist, getS3LatencyDistributionMD(), "%.3f") {
+			if m.VariableLabels["api"] == api {
+				got[m.VariableLabels["le"]] = m.Value
+			}
+		}
+		return got
+	}
+	firstByte := counts(httpRequestsFirstByte)
+	if firstByte["2.500"] != 1 || firstByte["1.000"] != 0 {
+		t.Errorf("Expected a first byte latency between 1s and 2.5s, got %v", firstByte)
+	}
+	latency := counts(httpRequestsLatency)
+	if latency["5.000"] != 2 || latency["2.500"] != 1 {
+		t.Errorf("Expected a total latency between 2.5s and 5s and a short one, got %v", latency)
+	}
+}
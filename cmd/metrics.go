@@ -23,12 +23,24 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Upper bounds of the request and response body size histograms,
+// from 1KiB up to 5GiB, the maximum size of a single PUT.
+var httpBodySizeBuckets = []float64{
+	humanize.KiByte, 64 * humanize.KiByte, 256 * humanize.KiByte,
+	humanize.MiByte, 4 * humanize.MiByte, 16 * humanize.MiByte, 64 * humanize.MiByte,
+	256 * humanize.MiByte, humanize.GiByte, 5 * humanize.GiByte,
+}
+
+// Upper bounds in seconds of the first byte and total latency histograms.
+var httpLatencyBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
 var (
 	httpRequestsDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -38,6 +50,38 @@ var (
 		},
 		[]string{"api"},
 	)
+	httpRequestsFirstByte = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_first_byte_seconds",
+			Help:    "Time from the receipt of requests to the first byte of their response body",
+			Buckets: httpLatencyBuckets,
+		},
+		[]string{"api"},
+	)
+	httpRequestsLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_latency_seconds",
+			Help:    "Time from the receipt of requests to the end of their response",
+			Buckets: httpLatencyBuckets,
+		},
+		[]string{"api"},
+	)
+	httpRequestsSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_request_size_bytes",
+			Help:    "Size of the request bodies received by current MinIO server instance",
+			Buckets: httpBodySizeBuckets,
+		},
+		[]string{"api"},
+	)
+	httpResponsesSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "s3_response_size_bytes",
+			Help:    "Size of the response bodies sent by current MinIO server instance",
+			Buckets: httpBodySizeBuckets,
+		},
+		[]string{"api"},
+	)
 	minioVersionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "minio",
@@ -116,6 +116,16 @@ func (m *mrfState) newSetReconnected(pool, set int) {
 	}
 }
 
+// backlog returns the number of partial operations waiting to be healed.
+func (m *mrfState) backlog() int {
+	if !m.initialized() {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.pendingOps) + len(m.opCh)
+}
+
 // Get current MRF stats of the last MRF activity
 func (m *mrfState) getCurrentMRFRoundInfo() madmin.MRFStatus {
 	m.mu.Lock()
@@ -211,9 +211,9 @@ func (d *naughtyDisk) RenameFile(ctx context.Context, srcVolume, srcPath, dstVol
 	return d.disk.RenameFile(ctx, srcVolume, srcPath, dstVolume, dstPath)
 }
 
-func (d *naughtyDisk) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
+func (d *naughtyDisk) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error) {
 	if err := d.calcError(); err != nil {
-		return err
+		return nil, err
 	}
 	return d.disk.CheckParts(ctx, volume, path, fi)
 }
@@ -264,6 +264,17 @@ func (d *naughtyDisk) ReadVersion(ctx context.Context, volume, path, versionID s
 	return d.disk.ReadVersion(ctx, volume, path, versionID, readData)
 }
 
+func (d *naughtyDisk) ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) (fis []FileInfo, errs []error) {
+	if err := d.calcError(); err != nil {
+		errs = make([]error, len(paths))
+		for i := range errs {
+			errs[i] = err
+		}
+		return make([]FileInfo, len(paths)), errs
+	}
+	return d.disk.ReadVersions(ctx, volume, paths, versionIDs, readData)
+}
+
 func (d *naughtyDisk) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
 	if err := d.calcError(); err != nil {
 		return err
@@ -278,9 +289,9 @@ func (d *naughtyDisk) ReadAll(ctx context.Context, volume string, path string) (
 	return d.disk.ReadAll(ctx, volume, path)
 }
 
-func (d *naughtyDisk) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
+func (d *naughtyDisk) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
 	if err := d.calcError(); err != nil {
-		return err
+		return nil, err
 	}
 	return d.disk.VerifyFile(ctx, volume, path, fi)
 }
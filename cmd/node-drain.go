@@ -0,0 +1,191 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+// States of a node being drained.
+const (
+	nodeDrainNone     = ""
+	nodeDrainDraining = "draining"
+	nodeDrainDrained  = "drained"
+)
+
+const (
+	// Seconds clients are asked to wait before retrying S3 requests
+	// rejected by a draining node.
+	nodeDrainRetryAfter = 30
+
+	// Interval at which a draining node checks for outstanding work.
+	nodeDrainCheckInterval = time.Second
+)
+
+// NodeDrainStatus - the drain state of a node, a drained node has no
+// outstanding work and can be shut down.
+type NodeDrainStatus struct {
+	Node               string    `json:"node"`
+	State              string    `json:"state,omitempty"`
+	Started            time.Time `json:"started,omitempty"`
+	Drained            time.Time `json:"drained,omitempty"`
+	InFlightRequests   int       `json:"inFlightRequests"`
+	HealBacklog        int       `json:"healBacklog"`
+	ReplicationBacklog int       `json:"replicationBacklog"`
+	Error              string    `json:"error,omitempty"`
+}
+
+// nodeDrain tracks the drain of this node.
+type nodeDrain struct {
+	// Set while the node rejects new S3 requests, read on every request.
+	draining int32
+
+	mu      sync.Mutex
+	state   string
+	started time.Time
+	drained time.Time
+	cancel  context.CancelFunc
+}
+
+var globalNodeDrain = &nodeDrain{}
+
+// error returned when the node to drain is not part of the cluster.
+var errNodeNotFound = errors.New("Specified node is not part of the cluster")
+
+// isDraining returns true if new S3 requests must be rejected.
+func (d *nodeDrain) isDraining() bool {
+	return atomic.LoadInt32(&d.draining) == 1
+}
+
+// start stops accepting new S3 requests and waits in the background
+// for the outstanding work to complete, starting a drain already in
+// progress is a no-op.
+func (d *nodeDrain) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.state != nodeDrainNone {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(GlobalContext)
+	d.state = nodeDrainDraining
+	d.started = UTCNow()
+	d.drained = time.Time{}
+	d.cancel = cancel
+	atomic.StoreInt32(&d.draining, 1)
+	go d.drain(ctx)
+}
+
+// stop accepts S3 requests again, whether the drain completed or not.
+func (d *nodeDrain) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+	d.state = nodeDrainNone
+	d.started = time.Time{}
+	d.drained = time.Time{}
+	atomic.StoreInt32(&d.draining, 0)
+}
+
+// drain waits for the in-flight S3 requests and the queued heal and
+// replication operations to complete, flushes the buffered state of
+// the node and then marks it drained.
+func (d *nodeDrain) drain(ctx context.Context) {
+	t := time.NewTimer(nodeDrainCheckInterval)
+	defer t.Stop()
+	for {
+		s := d.status()
+		if s.InFlightRequests == 0 && s.HealBacklog == 0 && s.ReplicationBacklog == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			t.Reset(nodeDrainCheckInterval)
+		}
+	}
+
+	flushNodeState(ctx)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if ctx.Err() != nil {
+		// Drain was canceled while flushing.
+		return
+	}
+	d.state = nodeDrainDrained
+	d.drained = UTCNow()
+}
+
+// status returns the drain state of this node.
+func (d *nodeDrain) status() NodeDrainStatus {
+	d.mu.Lock()
+	s := NodeDrainStatus{
+		Node:    globalLocalNodeName,
+		State:   d.state,
+		Started: d.started,
+		Drained: d.drained,
+	}
+	d.mu.Unlock()
+
+	for _, n := range globalHTTPStats.currentS3Requests.Load() {
+		s.InFlightRequests += n
+	}
+	s.HealBacklog = globalMRFState.backlog()
+	s.ReplicationBacklog = globalReplicationPool.queued()
+	return s
+}
+
+// readinessReason returns why a node being drained is not ready.
+func (s NodeDrainStatus) readinessReason() string {
+	switch s.State {
+	case nodeDrainDraining:
+		return "node is draining"
+	case nodeDrainDrained:
+		return "node is drained and ready for shutdown"
+	}
+	return ""
+}
+
+// flushNodeState saves the state this node buffers in memory, so that
+// nothing is lost when it is shut down.
+func flushNodeState(ctx context.Context) {
+	globalBucketAccessLogs.flush(ctx)
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+	logger.LogIf(ctx, globalUsageAccounting.save(ctx, objAPI))
+	if globalReplicationStats != nil {
+		logger.LogIf(ctx, globalReplicationStats.saveStats(ctx, objAPI))
+	}
+	if globalReplicationPool != nil && globalReplicationPool.mrf != nil {
+		logger.LogIf(ctx, globalReplicationPool.mrf.save(ctx, objAPI))
+	}
+}
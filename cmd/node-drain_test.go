@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestNodeDrain(t *testing.T) {
+	d := globalNodeDrain
+	defer d.stop()
+
+	handler := maxClients(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+		return rec
+	}
+
+	if rec := serve(); rec.Code != http.StatusOK {
+		t.Fatalf("Expected %d before drain, got %d", http.StatusOK, rec.Code)
+	}
+
+	// An in-flight request holds the drain.
+	globalHTTPStats.currentS3Requests.Inc("getobject")
+	d.start()
+	rec := serve()
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected %d while draining, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if rec.Header().Get(xhttp.RetryAfter) == "" {
+		t.Fatal("Expected Retry-After header while draining")
+	}
+	if s := d.status(); s.State != nodeDrainDraining || s.InFlightRequests != 1 || s.readinessReason() == "" {
+		t.Fatalf("Unexpected status while draining %#v", s)
+	}
+
+	globalHTTPStats.currentS3Requests.Dec("getobject")
+	deadline := time.Now().Add(10 * time.Second)
+	for d.status().State != nodeDrainDrained {
+		if time.Now().After(deadline) {
+			t.Fatalf("Node not drained, status %#v", d.status())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if serve().Code != http.StatusServiceUnavailable {
+		t.Fatal("Expected S3 requests to be rejected once drained")
+	}
+
+	d.stop()
+	if s := d.status(); s.State != nodeDrainNone || s.readinessReason() != "" {
+		t.Fatalf("Unexpected status after cancel %#v", s)
+	}
+	if rec := serve(); rec.Code != http.StatusOK {
+		t.Fatalf("Expected %d after cancel, got %d", http.StatusOK, rec.Code)
+	}
+}
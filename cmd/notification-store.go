@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/target"
+)
+
+var (
+	errNotifyTargetNotFound = errors.New("notification target not found")
+	errNotifyStoreNotFound  = errors.New("notification target has no queue store configured")
+)
+
+// NotifyStoreEvent - an undelivered event held in the queue store
+// of a notification target on a server.
+type NotifyStoreEvent struct {
+	Node string `json:"node"`
+	target.StoredEvent
+}
+
+// NotifyStoreResult - number of stored events replayed or purged
+// on a server.
+type NotifyStoreResult struct {
+	Node  string `json:"node"`
+	Count int    `json:"count"`
+	Error string `json:"error,omitempty"`
+}
+
+// notifyStoreFilterFromValues parses the stored event filter from
+// the from, to and max-events query values.
+func notifyStoreFilterFromValues(values url.Values) (filter target.StoreFilter, err error) {
+	if v := values.Get("from"); v != "" {
+		if filter.From, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return filter, err
+		}
+	}
+	if v := values.Get("to"); v != "" {
+		if filter.To, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return filter, err
+		}
+	}
+	if v := values.Get("max-events"); v != "" {
+		if filter.MaxEvents, err = strconv.Atoi(v); err != nil {
+			return filter, err
+		}
+		if filter.MaxEvents < 0 {
+			return filter, errInvalidArgument
+		}
+	}
+	return filter, nil
+}
+
+// notifyStoreFilterToValues encodes filter as query values.
+func notifyStoreFilterToValues(values url.Values, filter target.StoreFilter) {
+	if !filter.From.IsZero() {
+		values.Set("from", filter.From.Format(time.RFC3339Nano))
+	}
+	if !filter.To.IsZero() {
+		values.Set("to", filter.To.Format(time.RFC3339Nano))
+	}
+	if filter.MaxEvents > 0 {
+		values.Set("max-events", strconv.Itoa(filter.MaxEvents))
+	}
+}
+
+// getNotifyStoreTarget returns the notification target with targetID,
+// which must persist undelivered events in a queue store.
+func getNotifyStoreTarget(targetID string) (target.StoreTarget, error) {
+	for id, t := range globalNotificationSys.targetList.TargetMap() {
+		if id.String() != targetID {
+			continue
+		}
+		st, ok := t.(target.StoreTarget)
+		if !ok || st.Store() == nil {
+			return nil, errNotifyStoreNotFound
+		}
+		return st, nil
+	}
+	return nil, errNotifyTargetNotFound
+}
+
+// listNotifyStoreEvents lists the undelivered events of a
+// notification target held on this server.
+func listNotifyStoreEvents(targetID string, filter target.StoreFilter) ([]target.StoredEvent, error) {
+	t, err := getNotifyStoreTarget(targetID)
+	if err != nil {
+		return nil, err
+	}
+	return target.ListStoredEvents(t.Store(), filter)
+}
+
+// replayNotifyStoreEvents sends the undelivered events of a
+// notification target held on this server right away.
+func replayNotifyStoreEvents(targetID string, filter target.StoreFilter) (int, error) {
+	t, err := getNotifyStoreTarget(targetID)
+	if err != nil {
+		return 0, err
+	}
+	return target.ReplayStoredEvents(t, filter)
+}
+
+// purgeNotifyStoreEvents removes the undelivered events of a
+// notification target held on this server.
+func purgeNotifyStoreEvents(targetID string, filter target.StoreFilter) (int, error) {
+	t, err := getNotifyStoreTarget(targetID)
+	if err != nil {
+		return 0, err
+	}
+	return target.PurgeStoredEvents(t.Store(), filter)
+}
+
+// notifyStoreDepths returns the number of undelivered events held
+// on this server for all notification targets with a queue store.
+func notifyStoreDepths() map[event.TargetID]uint64 {
+	depths := make(map[event.TargetID]uint64)
+	if globalNotificationSys == nil {
+		return depths
+	}
+	for id, t := range globalNotificationSys.targetList.TargetMap() {
+		if st, ok := t.(target.StoreTarget); ok && st.Store() != nil {
+			depths[id] = st.Store().Len()
+		}
+	}
+	return depths
+}
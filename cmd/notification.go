@@ -36,9 +36,12 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	bucketBandwidth "github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/target"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
@@ -298,6 +301,35 @@ func (sys *NotificationSys) StartProfiling(profiler string) []NotificationPeerEr
 	return ng.Wait()
 }
 
+// DownloadDiagnostics writes the diagnostics snapshots of all nodes to a
+// zip archive, taken concurrently so that they describe the same moment.
+func (sys *NotificationSys) DownloadDiagnostics(ctx context.Context, writer io.Writer) {
+	zipWriter := zip.NewWriter(writer)
+	defer zipWriter.Close()
+
+	data := make([]map[string][]byte, len(sys.peerClients))
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			var err error
+			data[idx], err = client.Diagnostics(ctx)
+			return err
+		}, idx, *client.host)
+	}
+	localData, localErr := getDiagnosticsData()
+	writeDiagnosticsZip(ctx, zipWriter, globalLocalNodeName, localData, localErr)
+	for idx, nErr := range ng.Wait() {
+		if sys.peerClients[idx] == nil {
+			continue
+		}
+		writeDiagnosticsZip(ctx, zipWriter, nErr.Host.String(), data[idx], nErr.Err)
+	}
+}
+
 // DownloadProfilingData - download profiling data from all remote peers.
 func (sys *NotificationSys) DownloadProfilingData(ctx context.Context, writer io.Writer) bool {
 	profilingDataFound := false
@@ -558,6 +590,78 @@ func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName s
 	}
 }
 
+// ReloadPoolMeta - reloads the decommission state of the pools on all peers.
+func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadPoolMeta(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// AddPool - adds the pool given by its command line argument on all peers.
+func (sys *NotificationSys) AddPool(ctx context.Context, pool string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.AddPool(ctx, pool)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// RemovePendingPool - removes the pending pool given by its command line
+// argument on all peers.
+func (sys *NotificationSys) RemovePendingPool(ctx context.Context, pool string) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.RemovePendingPool(ctx, pool)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
+// ValidateConfig - validates the server config on all peers, without
+// applying it.
+func (sys *NotificationSys) ValidateConfig(ctx context.Context, cfg config.Config) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ValidateConfig(ctx, cfg)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // DeleteBucketMetadata - calls DeleteBucketMetadata call on all peers
 func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName string) {
 	globalReplicationStats.Delete(bucketName)
@@ -616,6 +720,34 @@ func (sys *NotificationSys) GetClusterBucketStats(ctx context.Context, bucketNam
 	return bucketStats
 }
 
+// GetClusterReplicationMRF - gets the queued failed replications of bucket on all nodes.
+func (sys *NotificationSys) GetClusterReplicationMRF(ctx context.Context, bucketName string) []ReplicationMRFStatus {
+	ng := WithNPeers(len(sys.peerClients))
+	mrfStatus := make([]ReplicationMRFStatus, len(sys.peerClients))
+	for index, client := range sys.peerClients {
+		index := index
+		client := client
+		ng.Go(ctx, func() error {
+			if client == nil {
+				return errPeerNotReachable
+			}
+			st, err := client.GetReplicationMRF(bucketName)
+			if err != nil {
+				return err
+			}
+			mrfStatus[index] = st
+			return nil
+		}, index, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+	return append(mrfStatus, globalReplicationPool.getMRFStatus(bucketName))
+}
+
 // LoadTransitionTierConfig notifies remote peers to load their remote tier
 // configs from config store.
 func (sys *NotificationSys) LoadTransitionTierConfig(ctx context.Context) {
@@ -770,8 +902,28 @@ func (sys *NotificationSys) RemoveAllRemoteTargets() {
 
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) {
+	object := args.filterObject()
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	targetIDSet := sys.bucketRulesMap[args.BucketName].MatchObject(args.EventName, object)
+	sys.RUnlock()
+
+	if len(targetIDSet) == 0 {
+		return
+	}
+
+	sys.targetList.Send(args.ToEvent(true), targetIDSet, sys.targetResCh)
+}
+
+// SendToAll - sends an event which concerns no particular bucket, e.g.
+// the healing of a drive, to the targets of all buckets subscribed to it.
+// Each target receives the event once.
+func (sys *NotificationSys) SendToAll(args eventArgs) {
+	object := args.filterObject()
+	targetIDSet := event.NewTargetIDSet()
+	sys.RLock()
+	for _, rulesMap := range sys.bucketRulesMap {
+		targetIDSet = targetIDSet.Union(rulesMap.MatchObject(args.EventName, object))
+	}
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
@@ -1379,6 +1531,21 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 	return newEvent
 }
 
+// filterObject - returns the object information the bucket notification
+// rules are matched against.
+func (args eventArgs) filterObject() event.FilterObject {
+	object := event.FilterObject{
+		Name:     args.Object.Name,
+		Metadata: event.NewFilterObjectMetadata(args.Object.UserDefined),
+	}
+	if args.Object.UserTags != "" {
+		if t, err := tags.ParseObjectTags(args.Object.UserTags); err == nil {
+			object.Tags = t.ToMap()
+		}
+	}
+	return object
+}
+
 func sendEvent(args eventArgs) {
 	args.Object.Size, _ = args.Object.GetActualSize()
 
@@ -1402,6 +1569,276 @@ func sendEvent(args eventArgs) {
 	globalNotificationSys.Send(args)
 }
 
+// ListNotifyStoreEvents - lists the undelivered events of a notification
+// target held on all nodes including self.
+func (sys *NotificationSys) ListNotifyStoreEvents(ctx context.Context, targetID string, filter target.StoreFilter) ([]NotifyStoreEvent, error) {
+	peerEvents := make([][]target.StoredEvent, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			peerEvents[index], err = sys.peerClients[index].ListNotifyStore(ctx, targetID, filter)
+			return err
+		}, index)
+	}
+
+	localEvents, err := listNotifyStoreEvents(targetID, filter)
+	if err != nil {
+		return nil, err
+	}
+	var events []NotifyStoreEvent
+	for _, e := range localEvents {
+		events = append(events, NotifyStoreEvent{Node: globalLocalNodeName, StoredEvent: e})
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, e := range peerEvents[index] {
+			events = append(events, NotifyStoreEvent{Node: sys.peerClients[index].host.String(), StoredEvent: e})
+		}
+	}
+	return events, nil
+}
+
+// ReplayNotifyStoreEvents - replays the undelivered events of a notification
+// target held on all nodes including self.
+func (sys *NotificationSys) ReplayNotifyStoreEvents(ctx context.Context, targetID string, filter target.StoreFilter) []NotifyStoreResult {
+	return sys.notifyStoreAll(ctx, targetID, filter, replayNotifyStoreEvents,
+		func(client *peerRESTClient) (NotifyStoreResult, error) {
+			return client.ReplayNotifyStore(ctx, targetID, filter)
+		})
+}
+
+// PurgeNotifyStoreEvents - removes the undelivered events of a notification
+// target held on all nodes including self.
+func (sys *NotificationSys) PurgeNotifyStoreEvents(ctx context.Context, targetID string, filter target.StoreFilter) []NotifyStoreResult {
+	return sys.notifyStoreAll(ctx, targetID, filter, purgeNotifyStoreEvents,
+		func(client *peerRESTClient) (NotifyStoreResult, error) {
+			return client.PurgeNotifyStore(ctx, targetID, filter)
+		})
+}
+
+func (sys *NotificationSys) notifyStoreAll(ctx context.Context, targetID string, filter target.StoreFilter,
+	local func(string, target.StoreFilter) (int, error), peer func(*peerRESTClient) (NotifyStoreResult, error)) []NotifyStoreResult {
+	results := make([]NotifyStoreResult, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index], err = peer(sys.peerClients[index])
+			return err
+		}, index)
+	}
+
+	localResult := NotifyStoreResult{Node: globalLocalNodeName}
+	count, err := local(targetID, filter)
+	localResult.Count = count
+	if err != nil {
+		localResult.Error = err.Error()
+	}
+
+	var all []NotifyStoreResult
+	for index, err := range g.Wait() {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		result := results[index]
+		result.Node = sys.peerClients[index].host.String()
+		if err != nil {
+			result.Error = err.Error()
+		}
+		all = append(all, result)
+	}
+	return append(all, localResult)
+}
+
+// ScannerControl - applies a scanner control operation on all peers.
+func (sys *NotificationSys) ScannerControl(ctx context.Context, op, bucket string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ScannerControl(ctx, op, bucket)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// ScannerStatus - returns the state of the data scanner of all nodes
+// including self.
+func (sys *NotificationSys) ScannerStatus(ctx context.Context) []ScannerStatus {
+	peerStatuses := make([]ScannerStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			peerStatuses[index], err = sys.peerClients[index].ScannerStatus(ctx)
+			return err
+		}, index)
+	}
+
+	statuses := []ScannerStatus{globalScannerControl.status()}
+	for index, err := range g.Wait() {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+			continue
+		}
+		statuses = append(statuses, peerStatuses[index])
+	}
+	return statuses
+}
+
+// UsageAccounting - returns the usage of the S3 APIs by access key
+// counted on all nodes including self.
+func (sys *NotificationSys) UsageAccounting(ctx context.Context) UsageAccountingSnapshot {
+	peerSnapshots := make([]UsageAccountingSnapshot, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			peerSnapshots[index], err = sys.peerClients[index].UsageAccounting(ctx)
+			return err
+		}, index)
+	}
+
+	s := globalUsageAccounting.snapshot()
+	for index, err := range g.Wait() {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+			continue
+		}
+		s.merge(peerSnapshots[index])
+	}
+	return s
+}
+
+// Drain - starts draining the node, or accepts S3 requests again on it
+// when cancel is true.
+func (sys *NotificationSys) Drain(ctx context.Context, node string, cancel bool) error {
+	if node == globalLocalNodeName {
+		if cancel {
+			globalNodeDrain.stop()
+		} else {
+			globalNodeDrain.start()
+		}
+		return nil
+	}
+	for _, client := range sys.peerClients {
+		if client != nil && client.host.String() == node {
+			return client.Drain(ctx, cancel)
+		}
+	}
+	return errNodeNotFound
+}
+
+// DrainStatus - returns the drain state of all nodes including self,
+// the state of an unreachable node holds the error.
+func (sys *NotificationSys) DrainStatus(ctx context.Context) []NodeDrainStatus {
+	statuses := make([]NodeDrainStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			statuses[index], err = sys.peerClients[index].DrainStatus(ctx)
+			return err
+		}, index)
+	}
+
+	out := []NodeDrainStatus{globalNodeDrain.status()}
+	for index, err := range g.Wait() {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		if err != nil {
+			host := sys.peerClients[index].host.String()
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host)
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, host)
+			statuses[index] = NodeDrainStatus{Node: host, Error: err.Error()}
+		}
+		out = append(out, statuses[index])
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Node < out[j].Node })
+	return out
+}
+
+// GetTierTransitionStatus - returns the transition statistics per remote
+// tier of all nodes including self.
+func (sys *NotificationSys) GetTierTransitionStatus(ctx context.Context) []TierTransitionStatus {
+	peerStatuses := make([][]TierTransitionStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			peerStatuses[index], err = sys.peerClients[index].TierTransitionStatus(ctx)
+			return err
+		}, index)
+	}
+
+	nodes := make(map[string][]TierTransitionStatus, len(sys.peerClients)+1)
+	if globalTransitionState != nil {
+		nodes[globalLocalNodeName] = globalTransitionState.TierStatus()
+	}
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+			continue
+		}
+		if sys.peerClients[index] != nil {
+			nodes[sys.peerClients[index].host.String()] = peerStatuses[index]
+		}
+	}
+	return mergeTierTransitionStatus(nodes)
+}
+
 // GetBandwidthReports - gets the bandwidth report from all nodes including self.
 func (sys *NotificationSys) GetBandwidthReports(ctx context.Context, buckets ...string) madmin.BucketBandwidthReport {
 	reports := make([]*madmin.BucketBandwidthReport, len(sys.peerClients))
@@ -160,6 +160,7 @@ type ObjectInfo struct {
 	metadataOnly bool
 	versionOnly  bool // adds a new version, only used by CopyObject
 	keyRotation  bool
+	reuseData    bool // the copy stores the data of the source as is, only used by CopyObject
 
 	// Date and time when the object was last accessed.
 	AccTime time.Time
@@ -209,6 +210,7 @@ func (o ObjectInfo) Clone() (cinfo ObjectInfo) {
 		metadataOnly:               o.metadataOnly,
 		versionOnly:                o.versionOnly,
 		keyRotation:                o.keyRotation,
+		reuseData:                  o.reuseData,
 		backendType:                o.backendType,
 		AccTime:                    o.AccTime,
 		Legacy:                     o.Legacy,
@@ -491,6 +493,21 @@ type CompletePart struct {
 
 	// Entity tag returned when the part was uploaded.
 	ETag string
+
+	// Additional checksums of the part, returned when the part was uploaded.
+	ChecksumCRC32C string
+	ChecksumSHA256 string
+}
+
+// checksum returns the additional checksum of the part of type t.
+func (p CompletePart) checksum(t hash.ChecksumType) string {
+	switch t {
+	case hash.ChecksumCRC32C:
+		return p.ChecksumCRC32C
+	case hash.ChecksumSHA256:
+		return p.ChecksumSHA256
+	}
+	return ""
 }
 
 // CompletedParts - is a collection satisfying sort.Interface.
@@ -372,6 +372,13 @@ func (e BucketTaggingNotFound) Error() string {
 	return "No bucket tags found for bucket: " + e.Bucket
 }
 
+// BucketPublicAccessBlockNotFound - no bucket public access block config found
+type BucketPublicAccessBlockNotFound GenericError
+
+func (e BucketPublicAccessBlockNotFound) Error() string {
+	return "No public access block configuration found for bucket: " + e.Bucket
+}
+
 // BucketObjectLockConfigNotFound - no bucket object lock config found
 type BucketObjectLockConfigNotFound GenericError
 
@@ -393,6 +400,20 @@ func (e BucketQuotaExceeded) Error() string {
 	return "Bucket quota exceeded for bucket: " + e.Bucket
 }
 
+// BucketVersionQuotaExceeded - bucket version count quota exceeded.
+type BucketVersionQuotaExceeded GenericError
+
+func (e BucketVersionQuotaExceeded) Error() string {
+	return "Bucket version count quota exceeded for bucket: " + e.Bucket
+}
+
+// BucketNoncurrentQuotaExceeded - bucket noncurrent versions size quota exceeded.
+type BucketNoncurrentQuotaExceeded GenericError
+
+func (e BucketNoncurrentQuotaExceeded) Error() string {
+	return "Bucket noncurrent versions quota exceeded for bucket: " + e.Bucket
+}
+
 // BucketReplicationConfigNotFound - no bucket replication config found
 type BucketReplicationConfigNotFound GenericError
 
@@ -611,7 +632,7 @@ type PartTooSmall struct {
 }
 
 func (e PartTooSmall) Error() string {
-	return fmt.Sprintf("Part size for %d should be at least 5MB", e.PartNumber)
+	return fmt.Sprintf("Part size for %d is smaller than the minimum allowed part size", e.PartNumber)
 }
 
 // PartTooBig returned if size of part is bigger than the allowed limit.
@@ -671,6 +692,14 @@ func isErrVersionNotFound(err error) bool {
 	return errors.As(err, &versionNotFound)
 }
 
+// isErrReplicationProxyable - Check if a read which failed with err may be
+// served by a replication target, because the object was not replicated to
+// this site yet or cannot be read here.
+func isErrReplicationProxyable(err error) bool {
+	var readQuorum InsufficientReadQuorum
+	return isErrObjectNotFound(err) || isErrVersionNotFound(err) || errors.As(err, &readQuorum)
+}
+
 // isErrSignatureDoesNotMatch - Check if error type is SignatureDoesNotMatch.
 func isErrSignatureDoesNotMatch(err error) bool {
 	var signatureDoesNotMatch SignatureDoesNotMatch
@@ -29,6 +29,7 @@ import (
 	"github.com/minio/pkg/bucket/policy"
 
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/hash"
 	xioutil "github.com/minio/minio/internal/ioutil"
 )
 
@@ -54,7 +55,7 @@ type ObjectOptions struct {
 	DeleteMarker      bool                // Is only set in DELETE operations for delete marker replication
 	UserDefined       map[string]string   // only set in case of POST/PUT operations
 	PartNumber        int                 // only useful in case of GetObject/HeadObject
-	CheckPrecondFn    CheckPreconditionFn // only set during GetObject/HeadObject/CopyObjectPart preconditional valuation
+	CheckPrecondFn    CheckPreconditionFn // only set during GetObject/HeadObject/CopyObjectPart/PutObject/CopyObject/CompleteMultipartUpload preconditional valuation
 	EvalMetadataFn    EvalMetadataFn      // only set for retention settings, meant to be used only when updating metadata in-place.
 	DeleteReplication ReplicationState    // Represents internal replication state needed for Delete replication
 	Transition        TransitionOptions
@@ -71,6 +72,22 @@ type ObjectOptions struct {
 
 	// Use the maximum parity (N/2), used when saving server configuration files
 	MaxParity bool
+
+	// Ignore the pools being decommissioned when looking up the pool of an object.
+	SkipDecommissioned bool
+
+	// Reports if versioning is enabled for an object of a versioned bucket,
+	// only set for DeleteObjects as the objects may be under excluded prefixes.
+	PrefixEnabledFn func(prefix string) bool
+
+	// Additional checksum sent by the client, set for PutObjectPart
+	// and for PutObject when the checksum is sent in the trailer.
+	WantChecksum *hash.Checksum
+
+	// Append the data of a PutObject to the latest version of the object,
+	// AppendOffset is the expected size of the object before the append.
+	Append       bool
+	AppendOffset int64
 }
 
 // ExpirationOptions represents object options for object expiration at objectLayer.
@@ -229,7 +246,7 @@ type ObjectLayer interface {
 	HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error)
 	HealBucket(ctx context.Context, bucket string, opts madmin.HealOpts) (madmin.HealResultItem, error)
 	HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (madmin.HealResultItem, error)
-	HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, fn HealObjectFn) error
+	HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, window HealTimeWindow, fn HealObjectFn) error
 
 	// Backend related metrics
 	GetMetrics(ctx context.Context) (*BackendMetrics, error)
@@ -23,11 +23,17 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
 )
 
 func TestListObjectsVersionedFolders(t *testing.T) {
@@ -1844,3 +1850,474 @@ func BenchmarkListObjects(b *testing.B) {
 		}
 	}
 }
+
+// Tests listings with a delimiter are served in pages from the directory
+// entries, each drive only walking the entries of the requested page.
+func TestListObjectsDelimiterPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("dir/obj%02d", i)
+		if i%4 == 0 {
+			object = fmt.Sprintf("dir/prefix%02d/obj", i)
+			want = append(want, fmt.Sprintf("dir/prefix%02d/", i))
+		} else {
+			want = append(want, object)
+		}
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("a")), 1, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sort.Strings(want)
+
+	var got []string
+	var marker string
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatal("listing did not finish")
+		}
+		result, err := obj.ListObjectsV2(ctx, bucket, "dir/", marker, SlashSeparator, 3, false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := len(result.Objects) + len(result.Prefixes); n > 3 {
+			t.Fatalf("expected at most 3 entries, got %d", n)
+		}
+		for _, o := range result.Objects {
+			got = append(got, o.Name)
+		}
+		got = append(got, result.Prefixes...)
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextContinuationToken
+	}
+	sort.Strings(got)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Each drive stops walking at the limit.
+	disk := obj.(*erasureServerPools).getServerPools()[0].sets[0].getDisks()[0]
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(disk.WalkDir(ctx, WalkDirOptions{Bucket: bucket, BaseDir: "dir/", Limit: 5}, w))
+	}()
+	names, err := newMetacacheReader(r).readNames(-1)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if len(names) != 5 {
+		t.Fatalf("expected 5 entries, got %v", names)
+	}
+}
+
+func TestListPathDelimiterDeleteMarkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	// Delete markers fill most of the entries read from each drive.
+	var want []string
+	for i := 0; i < 40; i++ {
+		object := fmt.Sprintf("dir/obj%02d", i)
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("a")), 1, "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+		if i%13 == 5 {
+			want = append(want, object)
+			continue
+		}
+		if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	z := obj.(*erasureServerPools)
+	var got []string
+	var marker string
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatal("listing did not finish")
+		}
+		o := listPathOptions{
+			Bucket:    bucket,
+			Prefix:    "dir/",
+			Separator: SlashSeparator,
+			Limit:     3,
+			Marker:    marker,
+			AskDisks:  globalAPIConfig.getListQuorum(),
+		}
+		entries, err := z.listPath(ctx, &o)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		entries.forwardPast(marker)
+		names := entries.entries().names()
+		got = append(got, names...)
+		if err == io.EOF {
+			break
+		}
+		if len(names) == 0 {
+			t.Fatal("expected a truncated page to have entries")
+		}
+		marker = names[len(names)-1]
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestListObjectsSharedCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(bootTime time.Time, writes *metacacheWrites) {
+		globalBootTime, localMetacacheWrites = bootTime, writes
+	}(globalBootTime, localMetacacheWrites)
+	globalBootTime = UTCNow().Add(-time.Hour)
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	put := func(object string) {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("a")), 1, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var want []string
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("dir/sub%d/obj%02d", i%3, i)
+		put(object)
+		want = append(want, object)
+	}
+	sort.Strings(want)
+	// Forget the writes, as if they were done long before listing.
+	localMetacacheWrites = newMetacacheWrites()
+
+	list := func() []string {
+		var got []string
+		var marker string
+		for pages := 0; ; pages++ {
+			if pages > 20 {
+				t.Fatal("listing did not finish")
+			}
+			result, err := obj.ListObjectsV2(ctx, bucket, "dir/", marker, "", 4, false, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, o := range result.Objects {
+				got = append(got, o.Name)
+			}
+			if !result.IsTruncated {
+				return got
+			}
+			marker = result.NextContinuationToken
+		}
+	}
+	finished := func() (n int) {
+		caches, _ := localMetacacheMgr.getBucket(ctx, bucket).cloneCaches()
+		for _, c := range caches {
+			if c.status == scanStateSuccess {
+				n++
+			}
+		}
+		return n
+	}
+	waitFinished := func(n int) {
+		deadline := time.Now().Add(10 * time.Second)
+		for finished() < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d finished listings, got %d", n, finished())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if got := list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	waitFinished(1)
+
+	// Another listing of the same prefix reuses the finished one.
+	if got := list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	caches, _ := localMetacacheMgr.getBucket(ctx, bucket).cloneCaches()
+	if len(caches) != 1 {
+		t.Fatalf("expected the listing to be shared, got %d listings", len(caches))
+	}
+
+	// A write under the prefix invalidates it.
+	put("dir/sub1/new")
+	want = append(want, "dir/sub1/new")
+	sort.Strings(want)
+	if got := list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	waitFinished(2)
+}
+
+// Wrapper for calling ListObjectVersions pagination tests for Erasure setups.
+func TestListObjectVersionsPagination(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectVersionsPagination)
+}
+
+// Lists the versions of a bucket page by page and checks that the pages
+// add up to the full listing, keys ascending and versions newest first.
+func testListObjectVersionsPagination(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	if instanceType == FSTestStr {
+		return
+	}
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket := "test-bucket-versions-pagination"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	// "a" has a null version under two versions, "b" a null version
+	// above another version.
+	puts := []struct {
+		name      string
+		versioned bool
+	}{
+		{"a", false}, {"a", true}, {"a", true},
+		{"b", true}, {"b", false},
+		{"c", true}, {"c", true}, {"c", true}, {"c", true},
+		{"dir/d", true}, {"dir/d", true},
+	}
+	for _, p := range puts {
+		_, err := obj.PutObject(ctx, bucket, p.name, mustGetPutObjReader(t, bytes.NewReader([]byte(p.name)), int64(len(p.name)), "", ""),
+			ObjectOptions{Versioned: p.versioned})
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		// Keep the modification times of the versions apart.
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	key := func(oi ObjectInfo) string {
+		vid := oi.VersionID
+		if vid == "" {
+			vid = nullVersionID
+		}
+		return oi.Name + "@" + vid
+	}
+
+	for _, delimiter := range []string{"", SlashSeparator} {
+		full, err := obj.ListObjectVersions(ctx, bucket, "", "", "", delimiter, 1000)
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		if full.IsTruncated {
+			t.Fatalf("%s : expected a complete listing", instanceType)
+		}
+		var want []string
+		for i, oi := range full.Objects {
+			want = append(want, key(oi))
+			if i == 0 {
+				continue
+			}
+			prev := full.Objects[i-1]
+			if prev.Name > oi.Name || (prev.Name == oi.Name && prev.ModTime.Before(oi.ModTime)) {
+				t.Fatalf("%s : %s listed before %s", instanceType, key(prev), key(oi))
+			}
+		}
+		want = append(want, full.Prefixes...)
+		wantVersions := len(puts)
+		if delimiter != "" {
+			wantVersions -= 2
+		}
+		if len(full.Objects) != wantVersions {
+			t.Fatalf("%s : expected %d versions, got %d", instanceType, wantVersions, len(full.Objects))
+		}
+
+		for _, maxKeys := range []int{1, 2, 3} {
+			var got []string
+			var marker, versionMarker string
+			for pages := 0; ; pages++ {
+				if pages > len(puts) {
+					t.Fatalf("%s : max-keys %d: pagination does not end", instanceType, maxKeys)
+				}
+				res, err := obj.ListObjectVersions(ctx, bucket, "", marker, versionMarker, delimiter, maxKeys)
+				if err != nil {
+					t.Fatalf("%s : %s", instanceType, err)
+				}
+				if len(res.Objects)+len(res.Prefixes) > maxKeys {
+					t.Fatalf("%s : max-keys %d: got %d entries", instanceType, maxKeys, len(res.Objects)+len(res.Prefixes))
+				}
+				for _, oi := range res.Objects {
+					got = append(got, key(oi))
+				}
+				got = append(got, res.Prefixes...)
+				if !res.IsTruncated {
+					break
+				}
+				marker, versionMarker = res.NextMarker, res.NextVersionIDMarker
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("%s : max-keys %d: expected %v, got %v", instanceType, maxKeys, want, got)
+			}
+		}
+	}
+}
+
+// memWarmBackend is a remote tier keeping transitioned objects in memory.
+type memWarmBackend struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memWarmBackend) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[object] = data
+	return "", nil
+}
+
+func (m *memWarmBackend) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[object]
+	if !ok {
+		return nil, errFileNotFound
+	}
+	data = data[opts.startOffset:]
+	if opts.length > 0 {
+		data = data[:opts.length]
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memWarmBackend) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, object)
+	return nil
+}
+
+func (m *memWarmBackend) InUse(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func TestListObjectsSharedCacheMetadataWrites(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(bootTime time.Time, writes *metacacheWrites, tiers *TierConfigMgr) {
+		globalBootTime, localMetacacheWrites, globalTierConfigMgr = bootTime, writes, tiers
+	}(globalBootTime, localMetacacheWrites, globalTierConfigMgr)
+	globalBootTime = UTCNow().Add(-time.Hour)
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierConfigMgr.drivercache["WARM"] = &memWarmBackend{objects: make(map[string][]byte)}
+
+	const bucket, object = "bucket", "dir/object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name  string
+		write func() error
+	}{
+		{"PutObjectTags", func() error {
+			_, err := obj.PutObjectTags(ctx, bucket, object, "key=value", ObjectOptions{})
+			return err
+		}},
+		{"DeleteObjectTags", func() error {
+			_, err := obj.DeleteObjectTags(ctx, bucket, object, ObjectOptions{})
+			return err
+		}},
+		{"PutObjectMetadata", func() error {
+			_, err := obj.PutObjectMetadata(ctx, bucket, object, ObjectOptions{
+				UserDefined: map[string]string{"x-amz-meta-key": "value"},
+			})
+			return err
+		}},
+		{"TransitionObject", func() error {
+			oi, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			return obj.TransitionObject(ctx, bucket, object, ObjectOptions{
+				Transition: TransitionOptions{Status: lifecycle.TransitionComplete, Tier: "WARM", ETag: oi.ETag},
+				MTime:      oi.ModTime,
+			})
+		}},
+		{"RestoreTransitionedObject", func() error {
+			return obj.RestoreTransitionedObject(ctx, bucket, object, ObjectOptions{
+				Transition: TransitionOptions{RestoreRequest: &RestoreObjectRequest{Days: 1}, RestoreExpiry: UTCNow().Add(24 * time.Hour)},
+			})
+		}},
+	}
+	for _, testCase := range testCases {
+		localMetacacheWrites = newMetacacheWrites()
+		since := UTCNow()
+		if err = testCase.write(); err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		// Listings of the object started before the write are stale.
+		if !localMetacacheWrites.writtenSince(bucket, "dir/", "", since) {
+			t.Fatalf("%s: expected listings of the object to be invalidated", testCase.name)
+		}
+		if localMetacacheWrites.writtenSince(bucket, "other/", "", since) {
+			t.Fatalf("%s: unexpected invalidation of other listings", testCase.name)
+		}
+	}
+
+	// The object was restored from the remote tier.
+	if oi, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !oi.RestoreOngoing && oi.RestoreExpires.IsZero() {
+		t.Fatalf("expected the object to be restored, got %+v", oi)
+	}
+}
@@ -20,12 +20,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/hash"
 )
 
@@ -1873,3 +1877,61 @@ func BenchmarkPutObjectPart50MbFS(b *testing.B) {
 func BenchmarkPutObjectPart50MbErasure(b *testing.B) {
 	benchmarkPutObjectPart(b, "Erasure", 50*humanize.MiByte)
 }
+
+// Tests the incomplete multipart uploads are aborted per the lifecycle rules of the bucket.
+func TestAbortIncompleteMultipartUploadLifecycle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lcXML := `<LifecycleConfiguration><Rule><ID>abort</ID><Status>Enabled</Status><Filter><Prefix>uploads/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`
+	meta := newBucketMetadata(bucket)
+	meta.LifecycleConfigXML = []byte(lcXML)
+	if meta.lifecycleConfig, err = lifecycle.ParseLifecycleConfig(strings.NewReader(lcXML)); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	initiated := time.Now().Add(-3 * 24 * time.Hour)
+	newUpload := func(object string) string {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{MTime: initiated})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := bytes.Repeat([]byte("a"), 1024)
+		if _, err = obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		return uploadID
+	}
+	abortedID := newUpload("uploads/object")
+	keptID := newUpload("object")
+
+	uploads := atomic.LoadUint64(&globalLifecycleMultipartStats.abortedUploads)
+	z := obj.(*erasureServerPools)
+	z.getServerPools()[0].sets[0].cleanupStaleUploads(ctx, 30*24*time.Hour)
+
+	if _, err = obj.ListObjectParts(ctx, bucket, "uploads/object", abortedID, 0, 10, ObjectOptions{}); !errors.As(err, &InvalidUploadID{}) {
+		t.Fatalf("expected upload to be aborted, got %v", err)
+	}
+	if _, err = obj.ListObjectParts(ctx, bucket, "object", keptID, 0, 10, ObjectOptions{}); err != nil {
+		t.Fatalf("expected upload to be kept, got %v", err)
+	}
+	if n := atomic.LoadUint64(&globalLifecycleMultipartStats.abortedUploads) - uploads; n != 1 {
+		t.Fatalf("expected 1 aborted upload, got %d", n)
+	}
+}
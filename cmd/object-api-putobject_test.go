@@ -445,6 +445,70 @@ func testObjectAPIMultipartPutObjectStaleFiles(obj ObjectLayer, instanceType str
 	}
 }
 
+func TestObjectAPIConditionalWrites(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectAPIConditionalWrites)
+}
+
+// Tests the preconditions of CopyObject and CompleteMultipartUpload.
+func testObjectAPIConditionalWrites(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "minio-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	src, err := obj.PutObject(ctx, bucket, "source", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	ifNoneMatch := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool {
+		return oi.Name != ""
+	}}
+	copyObject := func(dst string, dstOpts ObjectOptions) (ObjectInfo, error) {
+		srcInfo := src
+		srcInfo.PutObjReader = mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", "")
+		return obj.CopyObject(ctx, bucket, "source", bucket, dst, srcInfo, ObjectOptions{}, dstOpts)
+	}
+	completeUpload := func(object string, opts ObjectOptions) (ObjectInfo, error) {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		data := []byte("hello, world")
+		etag := getMD5Hash(data)
+		if _, err = obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), etag, ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		return obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{ETag: etag, PartNumber: 1}}, opts)
+	}
+
+	for name, write := range map[string]func(object string, opts ObjectOptions) (ObjectInfo, error){
+		"CopyObject":              copyObject,
+		"CompleteMultipartUpload": completeUpload,
+	} {
+		object := "object-" + name
+		oi, err := write(object, ifNoneMatch)
+		if err != nil {
+			t.Fatalf("%s: %s: %s", instanceType, name, err)
+		}
+		if _, err = write(object, ifNoneMatch); !isErrPreconditionFailed(err) {
+			t.Fatalf("%s: %s: expected precondition failed, got %v", instanceType, name, err)
+		}
+		ifMatch := ObjectOptions{CheckPrecondFn: func(got ObjectInfo) bool {
+			return got.ETag != oi.ETag
+		}}
+		if _, err = write(object, ifMatch); err != nil {
+			t.Fatalf("%s: %s: %s", instanceType, name, err)
+		}
+		if _, err = write("missing-"+name, ifMatch); !isErrPreconditionFailed(err) {
+			t.Fatalf("%s: %s: expected precondition failed, got %v", instanceType, name, err)
+		}
+		if _, err = obj.GetObjectInfo(ctx, bucket, "missing-"+name, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: %s: expected the object not to be written, got %v", instanceType, name, err)
+		}
+	}
+}
+
 // Benchmarks for ObjectLayer.PutObject().
 // The intent is to benchmark PutObject for various sizes ranging from few bytes to 100MB.
 // Also each of these Benchmarks are run both Erasure and FS backends.
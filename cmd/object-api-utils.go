@@ -37,8 +37,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/klauspost/readahead"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio/internal/bucket/compression"
 	"github.com/minio/minio/internal/config/compress"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/config/storageclass"
@@ -401,7 +403,7 @@ func (o ObjectInfo) IsCompressedOK() (bool, error) {
 		return false, nil
 	}
 	switch scheme {
-	case compressionAlgorithmV1, compressionAlgorithmV2:
+	case compressionAlgorithmV1, compressionAlgorithmV2, compressionAlgorithmZstd:
 		return true, nil
 	}
 	return true, fmt.Errorf("unknown compression scheme: %s", scheme)
@@ -451,6 +453,84 @@ func isCompressible(header http.Header, object string) bool {
 	return true
 }
 
+// objectCompression is how an object is compressed on upload.
+type objectCompression struct {
+	// algorithm is stored in the compression metadata of the object.
+	algorithm string
+	// dict is the zstd dictionary to compress with, if any.
+	dict []byte
+}
+
+// getObjectCompression returns how an object of size bytes is compressed on
+// upload, nil if it is stored uncompressed. The compression rules of the
+// bucket apply first, objects matched by no rule follow the server wide
+// compression config. A negative size is unknown, e.g. for multipart uploads.
+func getObjectCompression(header http.Header, bucket, object string, size int64) *objectCompression {
+	cfg, err := globalBucketMetadataSys.GetCompressionConfig(bucket)
+	if err != nil || cfg.IsEmpty() {
+		if !isCompressible(header, object) {
+			return nil
+		}
+		return &objectCompression{algorithm: compressionAlgorithmV2}
+	}
+
+	contentType := header.Get(xhttp.ContentType)
+	rule := cfg.Match(contentType, size)
+	if rule == nil {
+		if !isCompressible(header, object) {
+			return nil
+		}
+		return &objectCompression{algorithm: compressionAlgorithmV2}
+	}
+
+	globalCompressConfigMu.Lock()
+	allowEncrypted := globalCompressConfig.AllowEncrypted
+	globalCompressConfigMu.Unlock()
+
+	if _, ok := crypto.IsRequested(header); ok && !allowEncrypted {
+		return nil
+	}
+	// Already compressed objects are never compressed again.
+	if hasStringSuffixInSlice(object, standardExcludeCompressExtensions) || hasPattern(standardExcludeCompressContentTypes, contentType) {
+		return nil
+	}
+
+	switch rule.Algorithm {
+	case compression.S2:
+		return &objectCompression{algorithm: compressionAlgorithmV2}
+	case compression.Zstd:
+		c := &objectCompression{algorithm: compressionAlgorithmZstd}
+		if size >= 0 {
+			// Dictionaries are meant for small objects,
+			// only used for single part uploads.
+			c.dict = rule.Dictionary
+		}
+		return c
+	}
+	return nil
+}
+
+// getMultipartCompression returns how the parts of a multipart upload
+// are compressed, from the metadata set when the upload was initiated.
+func getMultipartCompression(metadata map[string]string) *objectCompression {
+	algorithm, ok := metadata[ReservedMetadataPrefix+"compression"]
+	if !ok {
+		return nil
+	}
+	if algorithm != compressionAlgorithmZstd {
+		algorithm = compressionAlgorithmV2
+	}
+	return &objectCompression{algorithm: algorithm}
+}
+
+// newReader returns the compressed data read from r, see newS2CompressReader.
+func (c *objectCompression) newReader(r io.Reader, on int64) io.ReadCloser {
+	if c.algorithm == compressionAlgorithmZstd {
+		return newZstdCompressReader(r, on, c.dict)
+	}
+	return newS2CompressReader(r, on)
+}
+
 // Eliminate the non-compressible objects.
 func excludeForCompression(header http.Header, object string, cfg compress.Config) bool {
 	objStr := object
@@ -545,6 +625,21 @@ func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (compressedOffset
 	return compressedOffset, offset - skipLength, firstPartIdx
 }
 
+// getCompressedEndOffset returns the offset in the compressed stream of
+// the end of the part holding the byte before the uncompressed offset
+// end, the size of the compressed stream if the object has no parts.
+func getCompressedEndOffset(objectInfo ObjectInfo, end int64) int64 {
+	var compressedEnd, cumulativeActualSize int64
+	for _, part := range objectInfo.Parts {
+		cumulativeActualSize += part.ActualSize
+		compressedEnd += part.Size
+		if cumulativeActualSize >= end {
+			return compressedEnd
+		}
+	}
+	return objectInfo.Size
+}
+
 // GetObjectReader is a type that wraps a reader with a lock to
 // provide a ReadCloser interface that unlocks on Close()
 type GetObjectReader struct {
@@ -555,6 +650,15 @@ type GetObjectReader struct {
 	once       sync.Once
 }
 
+// WriteTo writes the object to w, with the WriteTo of the underlying
+// reader if it has one, e.g. to send the object without copying it.
+func (g *GetObjectReader) WriteTo(w io.Writer) (int64, error) {
+	if wt, ok := g.Reader.(io.WriterTo); ok {
+		return wt.WriteTo(w)
+	}
+	return ioutil.Copy(w, struct{ io.Reader }{g.Reader})
+}
+
 // WithCleanupFuncs sets additional cleanup functions to be called when closing
 // the GetObjectReader.
 func (g *GetObjectReader) WithCleanupFuncs(fns ...func()) *GetObjectReader {
@@ -640,14 +744,17 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions) (
 			}
 
 			// In case of range based queries on multiparts, the offset and length are reduced.
+			start := off
 			off, decOff, firstPart = getCompressedOffsets(oi, off)
 
 			decLength = length
-			length = oi.Size - off
 			// For negative length we read everything.
 			if decLength < 0 {
 				decLength = actualSize - decOff
 			}
+			// Read up to the end of the part holding the end of the
+			// range, objects in remote tiers are fetched by range.
+			length = getCompressedEndOffset(oi, start+decLength) - off
 
 			// Reply back invalid range if the input offset and length fall out of range.
 			if decOff > actualSize || decOff+decLength > actualSize {
@@ -668,20 +775,17 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions) (
 				}
 				oi.Size = decLength
 			}
-			// Decompression reader.
-			s2Reader := s2.NewReader(inputReader)
-			// Apply the skipLen and limit on the decompressed stream.
-			if decOff > 0 {
-				if err = s2Reader.Skip(decOff); err != nil {
-					// Call the cleanup funcs
-					for i := len(cFns) - 1; i >= 0; i-- {
-						cFns[i]()
-					}
-					return nil, err
+			// Decompression reader, apply the skipLen on the decompressed stream.
+			compReader, err := newDecompressReader(inputReader, oi, decOff)
+			if err != nil {
+				// Call the cleanup funcs
+				for i := len(cFns) - 1; i >= 0; i-- {
+					cFns[i]()
 				}
+				return nil, err
 			}
-
-			decReader := io.LimitReader(s2Reader, decLength)
+			// Apply the limit on the decompressed stream.
+			decReader := io.LimitReader(compReader, decLength)
 			if decLength > compReadAheadSize {
 				rah, err := readahead.NewReaderSize(decReader, compReadAheadBuffers, compReadAheadBufSize)
 				if err == nil {
@@ -691,6 +795,10 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions) (
 					}}, cFns...)
 				}
 			}
+			// Release the decompressor once the read ahead is stopped.
+			cFns = append([]func(){func() {
+				compReader.Close()
+			}}, cFns...)
 			oi.Size = decLength
 
 			// Assemble the GetObjectReader
@@ -901,10 +1009,32 @@ func init() {
 // properly, because we do not wish to create an object even if
 // client closed the stream prematurely.
 func newS2CompressReader(r io.Reader, on int64) io.ReadCloser {
+	return newCompressReader(r, on, func(w io.Writer) (io.WriteCloser, error) {
+		return s2.NewWriter(w, compressOpts...), nil
+	})
+}
+
+// newZstdCompressReader is like newS2CompressReader, using zstd
+// with the optional dictionary dict.
+func newZstdCompressReader(r io.Reader, on int64, dict []byte) io.ReadCloser {
+	return newCompressReader(r, on, func(w io.Writer) (io.WriteCloser, error) {
+		opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+		if len(dict) > 0 {
+			opts = append(opts, zstd.WithEncoderDict(dict))
+		}
+		return zstd.NewWriter(w, opts...)
+	})
+}
+
+func newCompressReader(r io.Reader, on int64, newWriter func(io.Writer) (io.WriteCloser, error)) io.ReadCloser {
 	pr, pw := io.Pipe()
 	// Copy input to compressor
 	go func() {
-		comp := s2.NewWriter(pw, compressOpts...)
+		comp, err := newWriter(pw)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
 		cn, err := io.Copy(comp, r)
 		if err != nil {
 			comp.Close()
@@ -924,6 +1054,40 @@ func newS2CompressReader(r io.Reader, on int64) io.ReadCloser {
 	return pr
 }
 
+// newDecompressReader returns the decompressed data of the object read
+// from r, skipping the first skip bytes of the decompressed stream.
+func newDecompressReader(r io.Reader, oi ObjectInfo, skip int64) (io.ReadCloser, error) {
+	if oi.UserDefined[ReservedMetadataPrefix+"compression"] != compressionAlgorithmZstd {
+		s2Reader := s2.NewReader(r)
+		if skip > 0 {
+			if err := s2Reader.Skip(skip); err != nil {
+				return nil, err
+			}
+		}
+		return io.NopCloser(s2Reader), nil
+	}
+
+	opts := []zstd.DOption{zstd.WithDecoderConcurrency(1)}
+	// The object may have been compressed with any of the dictionaries
+	// of the bucket, the decoder picks the one referenced by the data.
+	if cfg, err := globalBucketMetadataSys.GetCompressionConfig(oi.Bucket); err == nil {
+		if dicts := cfg.Dictionaries(); len(dicts) > 0 {
+			opts = append(opts, zstd.WithDecoderDicts(dicts...))
+		}
+	}
+	dec, err := zstd.NewReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if skip > 0 {
+		if _, err = io.CopyN(io.Discard, dec, skip); err != nil {
+			dec.Close()
+			return nil, err
+		}
+	}
+	return dec.IOReadCloser(), nil
+}
+
 // compressSelfTest performs a self-test to ensure that compression
 // algorithms completes a roundtrip. If any algorithm
 // produces an incorrect checksum it fails with a hard error.
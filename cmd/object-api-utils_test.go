@@ -19,16 +19,20 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/klauspost/compress/s2"
+	"github.com/minio/minio/internal/bucket/compression"
 	"github.com/minio/minio/internal/config/compress"
 	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/pkg/trie"
 )
 
@@ -592,6 +596,38 @@ func TestGetCompressedOffsets(t *testing.T) {
 	}
 }
 
+func TestGetCompressedEndOffset(t *testing.T) {
+	objInfo := ObjectInfo{
+		Size: 39235668 + 19177372,
+		Parts: []ObjectPartInfo{
+			{
+				Size:       39235668,
+				ActualSize: 67108864,
+			},
+			{
+				Size:       19177372,
+				ActualSize: 32891137,
+			},
+		},
+	}
+	testCases := []struct {
+		objInfo ObjectInfo
+		end     int64
+		want    int64
+	}{
+		{objInfo: objInfo, end: 1, want: 39235668},
+		{objInfo: objInfo, end: 67108864, want: 39235668},
+		{objInfo: objInfo, end: 67108865, want: 39235668 + 19177372},
+		{objInfo: objInfo, end: 67108864 + 32891137, want: 39235668 + 19177372},
+		{objInfo: ObjectInfo{Size: 100}, end: 10, want: 100},
+	}
+	for i, test := range testCases {
+		if got := getCompressedEndOffset(test.objInfo, test.end); got != test.want {
+			t.Errorf("Test %d - expected end offset %d, got %d", i, test.want, got)
+		}
+	}
+}
+
 func TestS2CompressReader(t *testing.T) {
 	tests := []struct {
 		name string
@@ -647,3 +683,90 @@ func TestS2CompressReader(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectCompression(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	dict, err := os.ReadFile("../internal/bucket/compression/testdata/json.dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const bucket = "compressed"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata(bucket)
+	meta.compressionConfig = &compression.Config{
+		Rules: []compression.Rule{
+			{ContentTypes: []string{"application/json"}, MaxSize: 1 << 20, Algorithm: compression.Zstd, Dictionary: dict},
+			{ContentTypes: []string{"text/*"}, Algorithm: compression.S2},
+			{ContentTypes: []string{"application/octet-stream"}, Algorithm: compression.None},
+		},
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	testCases := []struct {
+		contentType string
+		size        int64
+		algorithm   string
+		dict        bool
+	}{
+		{"application/json", 100, compressionAlgorithmZstd, true},
+		{"application/json", -1, compressionAlgorithmZstd, false},
+		{"text/plain", 100, compressionAlgorithmV2, false},
+		{"application/octet-stream", 100, "", false},
+		{"application/zip", 100, "", false},
+	}
+	for i, testCase := range testCases {
+		header := http.Header{xhttp.ContentType: []string{testCase.contentType}}
+		c := getObjectCompression(header, bucket, "object", testCase.size)
+		var algorithm string
+		if c != nil {
+			algorithm = c.algorithm
+		}
+		if algorithm != testCase.algorithm {
+			t.Errorf("Test %d: expected algorithm %q, got %q", i+1, testCase.algorithm, algorithm)
+		}
+		if c != nil && (len(c.dict) > 0) != testCase.dict {
+			t.Errorf("Test %d: expected dictionary %v, got %v", i+1, testCase.dict, len(c.dict) > 0)
+		}
+	}
+
+	// Objects compressed with a dictionary decompress with
+	// the dictionaries of the bucket.
+	data := bytes.Repeat([]byte(`{"id":1,"name":"object","tags":["a","b"]}`), 100)
+	c := getObjectCompression(http.Header{xhttp.ContentType: []string{"application/json"}}, bucket, "object", int64(len(data)))
+	compressed, err := io.ReadAll(c.newReader(bytes.NewReader(data), int64(len(data))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi := ObjectInfo{
+		Bucket:      bucket,
+		UserDefined: map[string]string{ReservedMetadataPrefix + "compression": c.algorithm},
+	}
+	const skip = 50
+	r, err := newDecompressReader(bytes.NewReader(compressed), oi, skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data[skip:]) {
+		t.Fatalf("roundtrip failed, got %d bytes, want %d", len(got), len(data)-skip)
+	}
+}
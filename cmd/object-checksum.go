@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const (
+	// Additional checksum of the object content, stored
+	// as '<algorithm>:<base64 encoded checksum>'.
+	objectChecksumKey = ReservedMetadataPrefix + "checksum"
+
+	// Checksum algorithm requested when initiating a multipart upload.
+	multipartChecksumAlgorithmKey = ReservedMetadataPrefix + "checksum-algorithm"
+
+	// Prefix of the checksums of uploaded parts, followed by the part number.
+	multipartPartChecksumPrefix = ReservedMetadataPrefix + "checksum-part-"
+)
+
+// getObjectChecksum returns the additional checksum of the object, nil if none.
+func getObjectChecksum(metadata map[string]string) *hash.Checksum {
+	if v, ok := metadata[objectChecksumKey]; ok {
+		return hash.ParseChecksum(v)
+	}
+	return nil
+}
+
+// checkTrailingChecksum returns an error unless a trailing checksum
+// is announced exactly when the payload of r is followed by a trailer.
+func checkTrailingChecksum(r *http.Request, checksum *hash.Checksum) error {
+	payload := r.Header.Get(xhttp.AmzContentSha256)
+	trailer := payload == streamingContentSHA256Trailer || payload == unsignedPayloadTrailer
+	if trailer != (checksum != nil && checksum.Trailing) {
+		return hash.ErrInvalidChecksum
+	}
+	return nil
+}
+
+// getMultipartChecksumType returns the checksum algorithm of a multipart upload.
+func getMultipartChecksumType(metadata map[string]string) hash.ChecksumType {
+	return hash.NewChecksumType(metadata[multipartChecksumAlgorithmKey])
+}
+
+func multipartPartChecksumKey(partID int) string {
+	return multipartPartChecksumPrefix + strconv.Itoa(partID)
+}
+
+// completeMultipartChecksum validates the checksums of the parts sent
+// in a CompleteMultipartUpload request against the checksums stored while
+// uploading the parts and returns the composite checksum of the object.
+// The per part checksums are removed from metadata.
+func completeMultipartChecksum(metadata map[string]string, parts []CompletePart) (*hash.Checksum, error) {
+	defer func() {
+		for k := range metadata {
+			if strings.HasPrefix(k, multipartPartChecksumPrefix) {
+				delete(metadata, k)
+			}
+		}
+		delete(metadata, multipartChecksumAlgorithmKey)
+	}()
+
+	t := getMultipartChecksumType(metadata)
+	if !t.IsSet() {
+		return nil, nil
+	}
+	checksums := make([]*hash.Checksum, 0, len(parts))
+	for _, part := range parts {
+		stored := hash.NewChecksumString(t.String(), metadata[multipartPartChecksumKey(part.PartNumber)])
+		if stored == nil {
+			return nil, InvalidPart{PartNumber: part.PartNumber, GotETag: part.ETag}
+		}
+		if sent := part.checksum(t); sent != "" && sent != stored.Encoded {
+			return nil, InvalidPart{PartNumber: part.PartNumber, GotETag: part.ETag}
+		}
+		checksums = append(checksums, stored)
+	}
+	return hash.CompositeChecksum(t, checksums)
+}
+
+// setChecksumHeaders sets the additional checksum of the object as
+// response header, if the object has one.
+func setChecksumHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	if c := getObjectChecksum(objInfo.UserDefined); c != nil {
+		w.Header()[c.Type.Key()] = []string{c.Encoded}
+	}
+}
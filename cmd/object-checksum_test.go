@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/hash"
+)
+
+func TestCompleteMultipartChecksum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	const bucket, object = "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ObjectOptions{UserDefined: map[string]string{multipartChecksumAlgorithmKey: hash.ChecksumSHA256.String()}}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		parts     []CompletePart
+		checksums []*hash.Checksum
+	)
+	for i, data := range [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("b")} {
+		sum := sha256.Sum256(data)
+		checksum := hash.NewChecksumString("SHA256", base64.StdEncoding.EncodeToString(sum[:]))
+		pi, err := obj.PutObjectPart(ctx, bucket, object, uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{WantChecksum: checksum})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag, ChecksumSHA256: checksum.Encoded})
+		checksums = append(checksums, checksum)
+	}
+
+	wrongParts := append([]CompletePart{}, parts...)
+	wrongParts[1].ChecksumSHA256 = parts[0].ChecksumSHA256
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, wrongParts, ObjectOptions{}); !errors.As(err, &InvalidPart{}) {
+		t.Fatalf("expected invalid part error, got %v", err)
+	}
+
+	objInfo, err := obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := hash.CompositeChecksum(hash.ChecksumSHA256, checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := getObjectChecksum(objInfo.UserDefined)
+	if got == nil || got.String() != want.String() {
+		t.Fatalf("expected checksum %s, got %v", want, got)
+	}
+	for k := range objInfo.UserDefined {
+		if k == multipartChecksumAlgorithmKey || strings.HasPrefix(k, multipartPartChecksumPrefix) {
+			t.Errorf("unexpected multipart checksum metadata %s", k)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -221,6 +222,33 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Validates the preconditions of a conditional PutObject against the
+// latest version of the object, an empty objInfo when there is no object
+// or it is a delete marker. Returns true if the object must not be written.
+//   - If-Match: the object must exist, with the specified ETag unless `*`.
+//   - If-None-Match: the object must not exist when `*`, or its ETag must
+//     be different from the specified one.
+func checkPreconditionsPUT(r *http.Request, objInfo ObjectInfo) bool {
+	exists := objInfo.Name != "" && !objInfo.DeleteMarker
+	etag := objInfo.ETag
+	if _, encrypted := crypto.IsEncrypted(objInfo.UserDefined); exists && encrypted && !crypto.IsMultiPart(objInfo.UserDefined) {
+		etag = getDecryptedETag(r.Header, objInfo, false)
+	}
+
+	if ifMatchETagHeader := r.Header.Get(xhttp.IfMatch); ifMatchETagHeader != "" {
+		if !exists || (ifMatchETagHeader != "*" && !isETagEqual(etag, ifMatchETagHeader)) {
+			return true
+		}
+	}
+
+	if ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch); ifNoneMatchETagHeader != "" {
+		if exists && (ifNoneMatchETagHeader == "*" || isETagEqual(etag, ifNoneMatchETagHeader)) {
+			return true
+		}
+	}
+	return false
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -260,6 +288,10 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 		}
 	}
 
+	if !delete {
+		setChecksumHeaders(w, objInfo)
+	}
+
 	if objInfo.Bucket != "" && objInfo.Name != "" {
 		if lc, err := globalLifecycleSys.Get(objInfo.Bucket); err == nil && !delete {
 			lc.SetPredictionHeaders(w, objInfo.ToLifecycleOpts())
@@ -278,6 +310,9 @@ func deleteObjectVersions(ctx context.Context, o ObjectLayer, bucket string, toD
 			remaining = nil
 		}
 		deletedObjs, errs := o.DeleteObjects(ctx, bucket, toDel, ObjectOptions{
+			PrefixEnabledFn: func(prefix string) bool {
+				return globalBucketVersioningSys.PrefixEnabled(bucket, prefix)
+			},
 			Versioned:        versioned,
 			VersionSuspended: versionSuspended,
 		})
@@ -18,7 +18,10 @@
 package cmd
 
 import (
+	"net/http"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Tests - canonicalizeETag()
@@ -52,3 +55,42 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - checkPreconditionsPUT()
+func TestCheckPreconditionsPUT(t *testing.T) {
+	existing := ObjectInfo{Name: "object", ETag: "aa"}
+	deleted := ObjectInfo{Name: "object", DeleteMarker: true}
+	testCases := []struct {
+		ifMatch, ifNoneMatch string
+		objInfo              ObjectInfo
+		failed               bool
+	}{
+		{"", "", existing, false},
+		{"\"aa\"", "", existing, false},
+		{"bb", "", existing, true},
+		{"*", "", existing, false},
+		{"aa", "", ObjectInfo{}, true},
+		{"*", "", deleted, true},
+		{"", "*", existing, true},
+		{"", "*", ObjectInfo{}, false},
+		{"", "*", deleted, false},
+		{"", "aa", existing, true},
+		{"", "bb", existing, false},
+		{"aa", "bb", existing, false},
+	}
+	for i, test := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.ifMatch != "" {
+			r.Header.Set(xhttp.IfMatch, test.ifMatch)
+		}
+		if test.ifNoneMatch != "" {
+			r.Header.Set(xhttp.IfNoneMatch, test.ifNoneMatch)
+		}
+		if failed := checkPreconditionsPUT(r, test.objInfo); failed != test.failed {
+			t.Errorf("Test %d: expected precondition failed %v, got %v", i+1, test.failed, failed)
+		}
+	}
+}
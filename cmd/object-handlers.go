@@ -46,7 +46,7 @@ import (
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
-	"github.com/minio/minio/internal/config/storageclass"
+	"github.com/minio/minio/internal/config/lambda"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/etag"
 	"github.com/minio/minio/internal/event"
@@ -77,6 +77,8 @@ var supportedHeadGetReqParams = map[string]string{
 const (
 	compressionAlgorithmV1 = "golang/snappy/LZ77"
 	compressionAlgorithmV2 = "klauspost/compress/s2"
+	// Set by the compression rules of a bucket only.
+	compressionAlgorithmZstd = "klauspost/compress/zstd"
 
 	// When an upload exceeds encryptBufferThreshold ...
 	encryptBufferThreshold = 1 << 20
@@ -371,11 +373,15 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
+	// Objects transformed by a lambda webhook are read in full, the
+	// range is forwarded to the webhook along with the other headers.
+	lambdaTarget, transform := globalObjectLambda.Match(bucket, object)
+
 	// Get request range.
 	var rs *HTTPRangeSpec
 	var rangeErr error
 	rangeHeader := r.Header.Get(xhttp.Range)
-	if rangeHeader != "" {
+	if rangeHeader != "" && !transform {
 		// Both 'Range' and 'partNumber' cannot be specified at the same time
 		if opts.PartNumber > 0 {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRangePartNumber), r.URL)
@@ -413,12 +419,15 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 			reader *GetObjectReader
 			proxy  bool
 		)
-		proxytgts := getproxyTargets(ctx, bucket, object, opts)
-		if !proxytgts.Empty() {
-			// proxy to replication target if active-active replication is in place.
-			reader, proxy = proxyGetToReplicationTarget(ctx, bucket, object, rs, r.Header, opts, proxytgts)
-			if reader != nil && proxy {
-				gr = reader
+		// Proxy to replication target if active-active replication is in place
+		// and the object was not replicated here yet, but not if it was deleted.
+		if isErrReplicationProxyable(err) && (gr == nil || !gr.ObjInfo.DeleteMarker) {
+			proxytgts := getproxyTargets(ctx, bucket, object, opts)
+			if !proxytgts.Empty() {
+				reader, proxy = proxyGetToReplicationTarget(ctx, bucket, object, rs, r.Header, opts, proxytgts)
+				if reader != nil && proxy {
+					gr = reader
+				}
 			}
 		}
 		if reader == nil || !proxy {
@@ -491,6 +500,11 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	if transform {
+		api.getTransformedObject(ctx, lambdaTarget, w, r, objInfo, gr)
+		return
+	}
+
 	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -501,6 +515,11 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set the additional checksum of the full object, if requested.
+	if r.Header.Get(xhttp.AmzChecksumMode) == "ENABLED" && rs == nil && opts.PartNumber == 0 {
+		setChecksumHeaders(w, objInfo)
+	}
+
 	setHeadGetRespHeaders(w, r.Form)
 
 	statusCodeWritten := false
@@ -546,6 +565,41 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 	})
 }
 
+// getTransformedObject writes the object transformed by the lambda
+// webhook target to the client.
+func (api objectAPIHandlers) getTransformedObject(ctx context.Context, target lambda.Target, w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, gr io.Reader) {
+	resp, err := globalObjectLambda.Transform(ctx, target, r, objInfo, gr)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrLambdaInvalidResponse, err), r.URL)
+		return
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	setLambdaResponseHeaders(w, resp, objInfo)
+	setHeadGetRespHeaders(w, r.Form)
+	w.WriteHeader(resp.StatusCode)
+
+	// Write transformed object content to response body
+	if _, err = xioutil.Copy(w, resp.Body); err != nil {
+		if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+			logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+		}
+		return
+	}
+
+	// Notify object accessed via a GET request.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectAccessedGet,
+		BucketName:   objInfo.Bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
 // GetObjectHandler - GET Object
 // ----------
 // This implementation of the GET operation retrieves object. To use GET,
@@ -635,11 +689,14 @@ func (api objectAPIHandlers) headObjectHandler(ctx context.Context, objectAPI Ob
 			oi    ObjectInfo
 		)
 		// proxy HEAD to replication target if active-active replication configured on bucket
-		proxytgts := getproxyTargets(ctx, bucket, object, opts)
-		if !proxytgts.Empty() {
-			oi, proxy = proxyHeadToReplicationTarget(ctx, bucket, object, opts, proxytgts)
-			if proxy {
-				objInfo = oi
+		// and the object was not replicated here yet, but not if it was deleted.
+		if isErrReplicationProxyable(err) && !objInfo.DeleteMarker {
+			proxytgts := getproxyTargets(ctx, bucket, object, opts)
+			if !proxytgts.Empty() {
+				oi, proxy = proxyHeadToReplicationTarget(ctx, bucket, object, opts, proxytgts)
+				if proxy {
+					objInfo = oi
+				}
 			}
 		}
 		if !proxy {
@@ -755,9 +812,21 @@ func (api objectAPIHandlers) headObjectHandler(ctx context.Context, objectAPI Ob
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set the additional checksum of the full object, if requested.
+	if r.Header.Get(xhttp.AmzChecksumMode) == "ENABLED" && rs == nil && opts.PartNumber == 0 {
+		setChecksumHeaders(w, objInfo)
+	}
+
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.Form)
 
+	// Send the tags to sites proxying reads of objects not replicated to them yet.
+	if opts.ProxyRequest && objInfo.UserTags != "" {
+		if checkRequestAuthType(ctx, r, policy.GetObjectTaggingAction, bucket, object) == ErrNone {
+			w.Header().Set(xhttp.AmzObjectTagging, objInfo.UserTags)
+		}
+	}
+
 	// Successful response.
 	if rs != nil || opts.PartNumber > 0 {
 		w.WriteHeader(http.StatusPartialContent)
@@ -805,6 +874,93 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// GetObjectAttributesHandler - GET Object?attributes
+// ----------
+// This operation returns the object attributes requested in the
+// X-Amz-Object-Attributes header, without returning the object itself.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, ok := crypto.IsRequested(r.Header); !objectAPI.IsEncryptionSupported() && ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	attributes, s3Error := getObjectAttributesResources(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		if objInfo.VersionID != "" && objInfo.DeleteMarker {
+			w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+			w.Header()[xhttp.AmzDeleteMarker] = []string{strconv.FormatBool(objInfo.DeleteMarker)}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+			// Validate the SSE-C Key set in the header.
+			if _, err = crypto.SSEC.UnsealObjectKey(r.Header, objInfo.UserDefined, bucket, object); err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+		}
+	}
+
+	response, err := generateGetObjectAttributesResponse(objInfo, attributes)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -991,7 +1147,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// Validate storage class metadata if present
 	dstSc := r.Header.Get(xhttp.AmzStorageClass)
-	if dstSc != "" && !storageclass.IsValid(dstSc) {
+	if dstSc != "" && !isValidStorageClass(dstBucket, dstSc) {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 		return
 	}
@@ -1025,6 +1181,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if r.Header.Get(xhttp.IfMatch) != "" || r.Header.Get(xhttp.IfNoneMatch) != "" {
+		// Evaluated by the object layer under the namespace lock of the destination.
+		dstOpts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(r, oi)
+		}
+	}
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
@@ -1110,26 +1272,35 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if err := enforceBucketVersionQuota(ctx, dstBucket, dstObject, objectAPI.GetObjectInfo); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 	}
 
 	// Check if either the source is encrypted or the destination will be encrypted.
 	_, objectEncryption := crypto.IsRequested(r.Header)
 	objectEncryption = objectEncryption || crypto.IsSourceEncrypted(srcInfo.UserDefined)
 
+	srcCompressed := srcInfo.IsCompressed()
+
 	var compressMetadata map[string]string
 	// No need to compress for remote etcd calls
 	// Pass the decompressed stream to such calls.
-	isDstCompressed := objectAPI.IsCompressionSupported() &&
-		isCompressible(r.Header, dstObject) &&
-		!isRemoteCopyRequired(ctx, srcBucket, dstBucket, objectAPI) && !cpSrcDstSame && !objectEncryption
+	var dstCompression *objectCompression
+	if objectAPI.IsCompressionSupported() &&
+		!isRemoteCopyRequired(ctx, srcBucket, dstBucket, objectAPI) && !cpSrcDstSame && !objectEncryption {
+		dstCompression = getObjectCompression(r.Header, dstBucket, dstObject, actualSize)
+	}
+	isDstCompressed := dstCompression != nil
 	if isDstCompressed {
 		compressMetadata = make(map[string]string, 2)
 		// Preserving the compression metadata.
-		compressMetadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		compressMetadata[ReservedMetadataPrefix+"compression"] = dstCompression.algorithm
 		compressMetadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(actualSize, 10)
 
 		reader = etag.NewReader(reader, nil)
-		s2c := newS2CompressReader(reader, actualSize)
+		s2c := dstCompression.newReader(reader, actualSize)
 		defer s2c.Close()
 		reader = etag.Wrap(s2c, reader)
 		length = -1
@@ -1293,6 +1464,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		// The replaced tags get the default tags of the destination bucket.
+		tagsMeta := map[string]string{xhttp.AmzObjectTagging: objTags}
+		if err = applyBucketDefaultTags(r, dstBucket, tagsMeta); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		objTags = tagsMeta[xhttp.AmzObjectTagging]
 		if globalIsGateway {
 			srcInfo.UserDefined[xhttp.AmzTagDirective] = replaceDirective
 		}
@@ -1303,7 +1481,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		if dstOpts.ReplicationRequest {
 			srcTimestamp := dstOpts.ReplicationSourceTaggingTimestamp
 			if !srcTimestamp.IsZero() {
-				ondiskTimestamp, err := time.Parse(lastTaggingTimestamp, time.RFC3339Nano)
+				ondiskTimestamp, err := time.Parse(time.RFC3339Nano, lastTaggingTimestamp)
 				// update tagging metadata only if replica  timestamp is newer than what's on disk
 				if err != nil || (err == nil && ondiskTimestamp.Before(srcTimestamp)) {
 					srcInfo.UserDefined[ReservedMetadataPrefixLower+TaggingTimestamp] = srcTimestamp.Format(time.RFC3339Nano)
@@ -1317,6 +1495,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	}
 
+	// Lock metadata of the version before the copy, kept by replicas
+	// when changed more recently than on the replication source.
+	ondiskMeta := cloneMSS(srcInfo.UserDefined)
 	srcInfo.UserDefined = filterReplicationStatusMetadata(srcInfo.UserDefined)
 	srcInfo.UserDefined = objectlock.FilterObjectLockMetadata(srcInfo.UserDefined, true, true)
 	retPerms := isPutActionAllowed(ctx, getRequestAuthType(r), dstBucket, dstObject, r, iampolicy.PutObjectRetentionAction)
@@ -1328,40 +1509,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// apply default bucket configuration/governance headers for dest side.
 	retentionMode, retentionDate, legalHold, s3Err := checkPutObjectLockAllowed(ctx, r, dstBucket, dstObject, getObjectInfo, retPerms, holdPerms)
-	if s3Err == ErrNone && retentionMode.Valid() {
-		lastretentionTimestamp := srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp]
-		if dstOpts.ReplicationRequest {
-			srcTimestamp := dstOpts.ReplicationSourceRetentionTimestamp
-			if !srcTimestamp.IsZero() {
-				ondiskTimestamp, err := time.Parse(lastretentionTimestamp, time.RFC3339Nano)
-				// update retention metadata only if replica  timestamp is newer than what's on disk
-				if err != nil || (err == nil && ondiskTimestamp.Before(srcTimestamp)) {
-					srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
-					srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
-					srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = srcTimestamp.Format(time.RFC3339Nano)
-				}
-			}
-		} else {
+	if s3Err == ErrNone && dstOpts.ReplicationRequest {
+		srcInfo.UserDefined = applyReplicaLockMetadata(srcInfo.UserDefined, ondiskMeta, retentionMode, retentionDate, legalHold, dstOpts)
+	} else if s3Err == ErrNone {
+		if retentionMode.Valid() {
 			srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
 			srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
 			srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = UTCNow().Format(time.RFC3339Nano)
 		}
-	}
-
-	if s3Err == ErrNone && legalHold.Status.Valid() {
-		lastLegalHoldTimestamp := srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockLegalHoldTimestamp]
-		if dstOpts.ReplicationRequest {
-			srcTimestamp := dstOpts.ReplicationSourceLegalholdTimestamp
-			if !srcTimestamp.IsZero() {
-				ondiskTimestamp, err := time.Parse(lastLegalHoldTimestamp, time.RFC3339Nano)
-				// update legalhold metadata only if replica  timestamp is newer than what's on disk
-				if err != nil || (err == nil && ondiskTimestamp.Before(srcTimestamp)) {
-					srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
-					srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = srcTimestamp.Format(time.RFC3339Nano)
-				}
-			}
-		} else {
+		if legalHold.Status.Valid() {
 			srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
+			srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockLegalHoldTimestamp] = UTCNow().Format(time.RFC3339Nano)
 		}
 	}
 	if s3Err != ErrNone {
@@ -1396,6 +1554,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		srcInfo.metadataOnly = false
 	}
 
+	// The copy stores the data of the source as is when neither the
+	// compression, the encryption nor the storage class change, the
+	// object layer may then copy the stored data without rewriting it.
+	srcInfo.reuseData = !cpSrcDstSame && !chStorageClass && !objectEncryption &&
+		!srcCompressed && !isDstCompressed && !srcInfo.Legacy
+
 	// Check if x-amz-metadata-directive or x-amz-tagging-directive was not set to REPLACE and source,
 	// destination are same objects. Apply this restriction also when
 	// metadataOnly is true indicating that we are not overwriting the object.
@@ -1412,6 +1576,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	var objInfo ObjectInfo
 
 	if isRemoteCopyRequired(ctx, srcBucket, dstBucket, objectAPI) {
+		// The preconditions cannot be evaluated under the lock
+		// of a destination on a remote instance.
+		if dstOpts.CheckPrecondFn != nil {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+			return
+		}
 		var dstRecords []dns.SrvRecord
 		dstRecords, err = globalDNSConfig.Get(dstBucket)
 		if err != nil {
@@ -1483,7 +1653,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	encodedSuccessResponse := encodeResponse(response)
 
 	if dsc := mustReplicate(ctx, dstBucket, dstObject, getMustReplicateOptions(objInfo, replication.UnsetReplicationType, dstOpts)); dsc.ReplicateAny() {
-		scheduleReplication(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType)
+		if status := scheduleReplicationSync(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType); status != "" {
+			// The object is stored either way, report whether it is
+			// also committed to the synchronous targets.
+			w.Header().Set(xhttp.AmzBucketReplicationStatus, status.String())
+		}
 	}
 
 	setPutObjHeaders(w, objInfo, false)
@@ -1562,12 +1736,31 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !isValidStorageClass(bucket, sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
 	}
 
+	// Append extension, the data is appended to the latest
+	// version of the object at the offset sent by the client.
+	var (
+		appendObject bool
+		appendOffset int64
+	)
+	if offset, ok := r.Header[xhttp.AmzWriteOffsetBytes]; ok {
+		if !globalIsErasure {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+			return
+		}
+		appendOffset, err = strconv.ParseInt(offset[0], 10, 64)
+		if err != nil || appendOffset < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, fmt.Errorf("invalid %s header %q", xhttp.AmzWriteOffsetBytes, offset[0])), r.URL)
+			return
+		}
+		appendObject = true
+	}
+
 	clientETag, err := etag.FromContentMD5(r.Header)
 	if err != nil {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDigest), r.URL)
@@ -1577,7 +1770,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || rAuthType == authTypeStreamingUnsignedTrailer {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1606,6 +1799,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if appendObject && r.Header.Get(xhttp.ContentType) == "" {
+		// Keep the content type of the object being appended to.
+		delete(metadata, strings.ToLower(xhttp.ContentType))
+	}
 
 	if objTags := r.Header.Get(xhttp.AmzObjectTagging); objTags != "" {
 		if !objectAPI.IsTaggingSupported() {
@@ -1621,6 +1818,33 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		metadata[xhttp.AmzObjectTagging] = objTags
 	}
 
+	// An append keeps the tags of the object appended to, the default
+	// tags are only added when the append creates the object.
+	var appendTo *ObjectInfo
+	if appendObject {
+		if oi, err := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+			appendTo = &oi
+		}
+	}
+	if appendTo == nil {
+		if err = applyBucketDefaultTags(r, bucket, metadata); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	checksum, err := hash.NewChecksumFromHeader(r.Header)
+	if err == nil {
+		err = checkTrailingChecksum(r, checksum)
+	}
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if checksum != nil && !checksum.Trailing {
+		metadata[objectChecksumKey] = checksum.String()
+	}
+
 	var (
 		md5hex              = clientETag.String()
 		sha256hex           = ""
@@ -1638,7 +1862,14 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	switch rAuthType {
 	case authTypeStreamingSigned:
 		// Initialize stream signature verifier.
-		reader, s3Err = newSignV4ChunkedReader(r)
+		reader, s3Err = newSignV4ChunkedReader(r, checksum)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize the unsigned chunked reader, verifies the request signature.
+		reader, s3Err = newUnsignedV4ChunkedReader(r, checksum)
 		if s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 			return
@@ -1664,6 +1895,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if err := enforceBucketVersionQuota(ctx, bucket, object, objectAPI.GetObjectInfo); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 	if r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String() {
 		if s3Err = isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction); s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
@@ -1671,6 +1906,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 		metadata[ReservedMetadataPrefixLower+ReplicaStatus] = replication.Replica.String()
 		metadata[ReservedMetadataPrefixLower+ReplicaTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		if origin := getReplicaOrigin(ctx, r, bucket, object); origin != "" {
+			metadata[ReservedMetadataPrefixLower+ReplicaOrigin] = origin
+		}
 		defer globalReplicationStats.UpdateReplicaStat(bucket, size)
 	}
 
@@ -1680,11 +1918,19 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
 	})
+	if _, ok := crypto.IsRequested(r.Header); ok && appendObject {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrNotImplemented, errors.New("Appending to an encrypted object is not supported")), r.URL)
+		return
+	}
 
 	actualSize := size
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+	var objCompression *objectCompression
+	if objectAPI.IsCompressionSupported() && size > 0 && !appendObject {
+		objCompression = getObjectCompression(r.Header, bucket, object, size)
+	}
+	if objCompression != nil {
 		// Storing the compression metadata.
-		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"compression"] = objCompression.algorithm
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
 
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize)
@@ -1692,14 +1938,19 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if err = actualReader.AddChecksum(checksum); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 
 		// Set compression metrics.
-		s2c := newS2CompressReader(actualReader, actualSize)
+		s2c := objCompression.newReader(actualReader, actualSize)
 		defer s2c.Close()
 		reader = etag.Wrap(s2c, actualReader)
 		size = -1   // Since compressed size is un-predictable.
 		md5hex = "" // Do not try to verify the content.
 		sha256hex = ""
+		checksum = nil
 	}
 
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize)
@@ -1707,6 +1958,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if err = hashReader.AddChecksum(checksum); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader)
@@ -1718,8 +1973,21 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if r.Header.Get(xhttp.IfMatch) != "" || r.Header.Get(xhttp.IfNoneMatch) != "" {
+		// Evaluated by the object layer under the namespace lock of the object.
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(r, oi)
+		}
+	}
+	opts.Append = appendObject
+	opts.AppendOffset = appendOffset
+	if checksum != nil && checksum.Trailing {
+		// Only known once the trailer was read, recorded by the object layer.
+		opts.WantChecksum = checksum
+	}
 
-	if api.CacheAPI() != nil {
+	// The cache holds whole objects, appends go to the backend.
+	if api.CacheAPI() != nil && !appendObject {
 		putObject = api.CacheAPI().PutObject
 	}
 
@@ -1739,13 +2007,20 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	if s3Err == ErrNone && legalHold.Status.Valid() {
 		metadata[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
 	}
+	if s3Err == ErrNone && opts.ReplicationRequest && (retentionMode.Valid() || legalHold.Status.Valid()) {
+		// Record when the source last changed the lock metadata of the replica.
+		metadata = applyReplicaLockMetadata(metadata, nil, retentionMode, retentionDate, legalHold, opts)
+	}
 	if s3Err != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 		return
 	}
-	if dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(ObjectInfo{
-		UserDefined: metadata,
-	}, replication.ObjectReplicationType, opts)); dsc.ReplicateAny() {
+	replInfo := ObjectInfo{UserDefined: metadata}
+	if appendTo != nil && metadata[xhttp.AmzObjectTagging] == "" {
+		// The replication rules are evaluated with the tags kept.
+		replInfo.UserTags = appendTo.UserTags
+	}
+	if dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(replInfo, replication.ObjectReplicationType, opts)); dsc.ReplicateAny() {
 		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
 		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
 	}
@@ -1795,12 +2070,20 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	var conflict *replicaConflict
+	if opts.ReplicationRequest {
+		conflict = getReplicaConflict(ctx, objectAPI, r, bucket, object, opts.MTime)
+	}
+
 	// Create the object..
 	objInfo, err := putObject(ctx, bucket, object, pReader, opts)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if conflict != nil {
+		go conflict.resolve(GlobalContext, objectAPI, objInfo)
+	}
 
 	if r.Header.Get(xMinIOExtract) == "true" && strings.HasSuffix(object, archiveExt) {
 		opts := ObjectOptions{VersionID: objInfo.VersionID, MTime: objInfo.ModTime}
@@ -1833,10 +2116,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			}
 		}
 	}
-	if dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(ObjectInfo{
-		UserDefined: metadata,
-	}, replication.ObjectReplicationType, opts)); dsc.ReplicateAny() {
-		scheduleReplication(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType)
+	if dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(replInfo, replication.ObjectReplicationType, opts)); dsc.ReplicateAny() {
+		if status := scheduleReplicationSync(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType); status != "" {
+			// The object is stored either way, report whether it is
+			// also committed to the synchronous targets.
+			w.Header().Set(xhttp.AmzBucketReplicationStatus, status.String())
+		}
 	}
 
 	setPutObjHeaders(w, objInfo, false)
@@ -1912,7 +2197,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	// Validate storage class metadata if present
 	sc := r.Header.Get(xhttp.AmzStorageClass)
 	if sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !isValidStorageClass(bucket, sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
@@ -1927,7 +2212,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || rAuthType == authTypeStreamingUnsignedTrailer {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1969,7 +2254,14 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	switch rAuthType {
 	case authTypeStreamingSigned:
 		// Initialize stream signature verifier.
-		reader, s3Err = newSignV4ChunkedReader(r)
+		reader, s3Err = newSignV4ChunkedReader(r, nil)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize the unsigned chunked reader, verifies the request signature.
+		reader, s3Err = newUnsignedV4ChunkedReader(r, nil)
 		if s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 			return
@@ -2022,15 +2314,26 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	}
 
 	putObjectTar := func(reader io.Reader, info os.FileInfo, object string) error {
+		if err := enforceBucketVersionQuota(ctx, bucket, object, getObjectInfo); err != nil {
+			return err
+		}
+
 		size := info.Size()
 		metadata := map[string]string{
 			xhttp.AmzStorageClass: sc,
 		}
+		if err := applyBucketDefaultTags(r, bucket, metadata); err != nil {
+			return err
+		}
 
 		actualSize := size
-		if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+		var objCompression *objectCompression
+		if objectAPI.IsCompressionSupported() && size > 0 {
+			objCompression = getObjectCompression(r.Header, bucket, object, size)
+		}
+		if objCompression != nil {
 			// Storing the compression metadata.
-			metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+			metadata[ReservedMetadataPrefix+"compression"] = objCompression.algorithm
 			metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
 
 			actualReader, err := hash.NewReader(reader, size, "", "", actualSize)
@@ -2039,7 +2342,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 			}
 
 			// Set compression metrics.
-			s2c := newS2CompressReader(actualReader, actualSize)
+			s2c := objCompression.newReader(actualReader, actualSize)
 			defer s2c.Close()
 			reader = etag.Wrap(s2c, actualReader)
 			size = -1 // Since compressed size is un-predictable.
@@ -2216,7 +2519,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !isValidStorageClass(bucket, sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
@@ -2243,6 +2546,11 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if err = applyBucketDefaultTags(r, bucket, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	retPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectRetentionAction)
 	holdPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectLegalHoldAction)
 
@@ -2278,9 +2586,20 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(metadata)
 
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) {
-		// Storing the compression metadata.
-		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+	if objectAPI.IsCompressionSupported() {
+		if c := getObjectCompression(r.Header, bucket, object, -1); c != nil {
+			// Storing the compression metadata.
+			metadata[ReservedMetadataPrefix+"compression"] = c.algorithm
+		}
+	}
+
+	checksumType := hash.NewChecksumType(r.Header.Get(xhttp.AmzChecksumAlgorithm))
+	if checksumType == hash.ChecksumInvalid {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL)
+		return
+	}
+	if checksumType.IsSet() {
+		metadata[multipartChecksumAlgorithmKey] = checksumType.String()
 	}
 
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
@@ -2288,6 +2607,13 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if opts.ReplicationRequest && (retentionMode.Valid() || legalHold.Status.Valid()) {
+		// Record when the source last changed the lock metadata of the replica.
+		opts.UserDefined = applyReplicaLockMetadata(opts.UserDefined, nil, retentionMode, retentionDate, legalHold, opts)
+	}
+	if origin := getReplicaOrigin(ctx, r, bucket, object); origin != "" {
+		opts.UserDefined[ReservedMetadataPrefixLower+ReplicaOrigin] = origin
+	}
 	newMultipartUpload := objectAPI.NewMultipartUpload
 	if api.CacheAPI() != nil {
 		newMultipartUpload = api.CacheAPI().NewMultipartUpload
@@ -2299,6 +2625,17 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if checksumType.IsSet() {
+		w.Header().Set(xhttp.AmzChecksumAlgorithm, checksumType.String())
+	}
+
+	if lc, err := globalLifecycleSys.Get(bucket); err == nil {
+		if ruleID, abort := lc.PredictAbortMultipartTime(object, UTCNow()); !abort.IsZero() {
+			w.Header()[xhttp.AmzAbortDate] = []string{abort.Format(http.TimeFormat)}
+			w.Header()[xhttp.AmzAbortRuleID] = []string{ruleID}
+		}
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -2423,6 +2760,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 
 	checkCopyPartPrecondFn := func(o ObjectInfo) bool {
 		if objectAPI.IsEncryptionSupported() {
+			if err := checkCopyPartSourceEncryption(o, r.Header); err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return true
+			}
 			if _, err := DecryptObjectInfo(&o, r); err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 				return true
@@ -2529,10 +2870,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	// Read compression metadata preserved in the init multipart for the decision.
-	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
+	partCompression := getMultipartCompression(mi.UserDefined)
 	// Compress only if the compression is enabled during initial multipart.
-	if isCompressed {
-		s2c := newS2CompressReader(reader, actualPartSize)
+	if partCompression != nil {
+		s2c := partCompression.newReader(reader, actualPartSize)
 		defer s2c.Close()
 		reader = etag.Wrap(s2c, reader)
 		length = -1
@@ -2553,14 +2894,16 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	rawReader := srcInfo.Reader
 	pReader := NewPutObjReader(rawReader)
 
-	_, isEncrypted := crypto.IsEncrypted(mi.UserDefined)
+	kind, isEncrypted := crypto.IsEncrypted(mi.UserDefined)
 	var objectEncryptionKey crypto.ObjectKey
+	if objectAPI.IsEncryptionSupported() && !isEncrypted && crypto.SSEC.IsRequested(r.Header) {
+		// The part would be stored unencrypted, the customer key is
+		// only accepted by uploads initiated with SSE-C.
+		writeErrorResponse(ctx, w, toAPIError(ctx, errInvalidEncryptionParameters), r.URL)
+		return
+	}
 	if objectAPI.IsEncryptionSupported() && isEncrypted {
-		if !crypto.SSEC.IsRequested(r.Header) && crypto.SSEC.IsEncrypted(mi.UserDefined) {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrSSEMultipartEncrypted), r.URL)
-			return
-		}
-		if crypto.S3.IsEncrypted(mi.UserDefined) && crypto.SSEC.IsRequested(r.Header) {
+		if crypto.SSEC.IsEncrypted(mi.UserDefined) != crypto.SSEC.IsRequested(r.Header) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrSSEMultipartEncrypted), r.URL)
 			return
 		}
@@ -2620,7 +2963,8 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	if isEncrypted {
-		partInfo.ETag = tryDecryptETag(objectEncryptionKey[:], partInfo.ETag, crypto.SSEC.IsRequested(r.Header))
+		setPartEncryptionHeaders(w, r, kind)
+		partInfo.ETag = decryptPartETag(kind, objectEncryptionKey, partInfo.ETag)
 	}
 
 	response := generateCopyObjectPartResponse(partInfo.ETag, partInfo.LastModified)
@@ -2681,7 +3025,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	rAuthType := getRequestAuthType(r)
 	// For auth type streaming signature, we need to gather a different content length.
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || rAuthType == authTypeStreamingUnsignedTrailer {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -2720,6 +3064,15 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	checksum, err := hash.NewChecksumFromHeader(r.Header)
+	if err == nil {
+		err = checkTrailingChecksum(r, checksum)
+	}
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	var (
 		md5hex              = clientETag.String()
 		sha256hex           = ""
@@ -2734,7 +3087,14 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	switch rAuthType {
 	case authTypeStreamingSigned:
 		// Initialize stream signature verifier.
-		reader, s3Error = newSignV4ChunkedReader(r)
+		reader, s3Error = newSignV4ChunkedReader(r, checksum)
+		if s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize the unsigned chunked reader, verifies the request signature.
+		reader, s3Error = newUnsignedV4ChunkedReader(r, checksum)
 		if s3Error != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 			return
@@ -2778,23 +3138,39 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Parts of uploads initiated with a checksum algorithm
+	// must carry a checksum computed with that algorithm.
+	var wantChecksum *hash.Checksum
+	if t := getMultipartChecksumType(mi.UserDefined); t.IsSet() {
+		if checksum == nil || checksum.Type != t {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL)
+			return
+		}
+		wantChecksum = checksum
+	}
+
 	// Read compression metadata preserved in the init multipart for the decision.
-	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
+	partCompression := getMultipartCompression(mi.UserDefined)
 
-	if objectAPI.IsCompressionSupported() && isCompressed {
+	if objectAPI.IsCompressionSupported() && partCompression != nil {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize)
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if err = actualReader.AddChecksum(checksum); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 
 		// Set compression metrics.
-		s2c := newS2CompressReader(actualReader, actualSize)
+		s2c := partCompression.newReader(actualReader, actualSize)
 		defer s2c.Close()
 		reader = etag.Wrap(s2c, actualReader)
 		size = -1   // Since compressed size is un-predictable.
 		md5hex = "" // Do not try to verify the content.
 		sha256hex = ""
+		checksum = nil
 	}
 
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize)
@@ -2802,6 +3178,10 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if err = hashReader.AddChecksum(checksum); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader)
 
@@ -2871,6 +3251,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		putObjectPart = api.CacheAPI().PutObjectPart
 	}
 
+	opts.WantChecksum = wantChecksum
 	partInfo, err := putObjectPart(ctx, bucket, object, uploadID, partID, pReader, opts)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
@@ -2879,26 +3260,18 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	}
 
 	etag := partInfo.ETag
-	switch kind, encrypted := crypto.IsEncrypted(mi.UserDefined); {
-	case encrypted:
-		switch kind {
-		case crypto.S3:
-			w.Header().Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
-			etag = tryDecryptETag(objectEncryptionKey[:], etag, false)
-		case crypto.SSEC:
-			w.Header().Set(xhttp.AmzServerSideEncryptionCustomerAlgorithm, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerAlgorithm))
-			w.Header().Set(xhttp.AmzServerSideEncryptionCustomerKeyMD5, r.Header.Get(xhttp.AmzServerSideEncryptionCustomerKeyMD5))
-
-			if len(etag) >= 32 && strings.Count(etag, "-") != 1 {
-				etag = etag[len(etag)-32:]
-			}
-		}
+	if kind, encrypted := crypto.IsEncrypted(mi.UserDefined); encrypted {
+		setPartEncryptionHeaders(w, r, kind)
+		etag = decryptPartETag(kind, objectEncryptionKey, etag)
 	}
 
 	// We must not use the http.Header().Set method here because some (broken)
 	// clients expect the ETag header key to be literally "ETag" - not "Etag" (case-sensitive).
 	// Therefore, we have to set the ETag directly as map entry.
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	if wantChecksum != nil {
+		w.Header()[wantChecksum.Type.Key()] = []string{wantChecksum.Encoded}
+	}
 
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -3152,6 +3525,11 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	if err := enforceBucketVersionQuota(ctx, bucket, object, objectAPI.GetObjectInfo); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	var objectEncryptionKey []byte
 	var isEncrypted, ssec bool
 	if objectAPI.IsEncryptionSupported() {
@@ -3235,8 +3613,8 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		w.Write(encodedErrorResponse)
 	}
 
-	versioned := globalBucketVersioningSys.Enabled(bucket)
-	suspended := globalBucketVersioningSys.Suspended(bucket)
+	versioned := globalBucketVersioningSys.PrefixEnabled(bucket, object)
+	suspended := globalBucketVersioningSys.PrefixSuspended(bucket, object)
 	os := newObjSweeper(bucket, object).WithVersioning(versioned, suspended)
 	if !globalTierConfigMgr.Empty() {
 		// Get appropriate object info to identify the remote object to delete
@@ -3253,12 +3631,23 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if r.Header.Get(xhttp.IfMatch) != "" || r.Header.Get(xhttp.IfNoneMatch) != "" {
+		// Evaluated by the object layer under the namespace lock of the object.
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(r, oi)
+		}
+	}
 
 	// preserve ETag if set, or set from parts.
 	if _, ok := opts.UserDefined["etag"]; !ok {
 		opts.UserDefined["etag"] = s3MD5
 	}
 
+	var conflict *replicaConflict
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; ok {
+		conflict = getReplicaConflict(ctx, objectAPI, r, bucket, object, opts.MTime)
+	}
+
 	w = &whiteSpaceWriter{ResponseWriter: w, Flusher: w.(http.Flusher)}
 	completeDoneCh := sendWhiteSpace(w)
 	objInfo, err := completeMultiPartUpload(ctx, bucket, object, uploadID, completeParts, opts)
@@ -3278,6 +3667,14 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	// Generate complete multipart response.
 	response := generateCompleteMultpartUploadResponse(bucket, object, location, objInfo.ETag)
+	if c := getObjectChecksum(objInfo.UserDefined); c != nil {
+		switch c.Type {
+		case hash.ChecksumCRC32C:
+			response.ChecksumCRC32C = c.Encoded
+		case hash.ChecksumSHA256:
+			response.ChecksumSHA256 = c.Encoded
+		}
+	}
 	var encodedSuccessResponse []byte
 	if !headerWritten {
 		encodedSuccessResponse = encodeResponse(response)
@@ -3297,9 +3694,16 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		}
 	}
 
-	setPutObjHeaders(w, objInfo, false)
 	if dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(objInfo, replication.ObjectReplicationType, opts)); dsc.ReplicateAny() {
-		scheduleReplication(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType)
+		if status := scheduleReplicationSync(ctx, objInfo.Clone(), objectAPI, dsc, replication.ObjectReplicationType); status != "" {
+			// The object is stored either way, report whether it is
+			// also committed to the synchronous targets.
+			w.Header().Set(xhttp.AmzBucketReplicationStatus, status.String())
+		}
+	}
+	setPutObjHeaders(w, objInfo, false)
+	if conflict != nil {
+		go conflict.resolve(GlobalContext, objectAPI, objInfo)
 	}
 	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; ok {
 		actualSize, _ := objInfo.GetActualSize()
@@ -3864,7 +4268,16 @@ func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *h
 	}
 
 	// Get object tags
-	tags, err := objAPI.GetObjectTags(ctx, bucket, object, opts)
+	ot, err := objAPI.GetObjectTags(ctx, bucket, object, opts)
+	if err != nil && isErrReplicationProxyable(err) {
+		// proxy to replication target if active-active replication is in place
+		// and the object was not replicated here yet.
+		if proxytgts := getproxyTargets(ctx, bucket, object, opts); !proxytgts.Empty() {
+			if oi, proxy := proxyHeadToReplicationTarget(ctx, bucket, object, opts, proxytgts); proxy {
+				ot, err = tags.ParseObjectTags(oi.UserTags)
+			}
+		}
+	}
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -3874,7 +4287,7 @@ func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *h
 		w.Header()[xhttp.AmzVersionID] = []string{opts.VersionID}
 	}
 
-	writeSuccessResponseXML(w, encodeResponse(tags))
+	writeSuccessResponseXML(w, encodeResponse(ot))
 }
 
 // PutObjectTaggingHandler - PUT object tagging
@@ -4212,7 +4625,11 @@ func (api objectAPIHandlers) PostRestoreObjectHandler(w http.ResponseWriter, r *
 			VersionID: objInfo.VersionID,
 		}
 		if err := objectAPI.RestoreTransitionedObject(rctx, bucket, object, opts); err != nil {
-			logger.LogIf(ctx, err)
+			// Restores waiting on the remote tier to rehydrate the
+			// object are resumed by the scanner.
+			if !errors.Is(err, errRestoreRehydrating) {
+				logger.LogIf(ctx, err)
+			}
 			return
 		}
 
@@ -25,6 +25,7 @@ import (
 	"encoding/hex"
 	"encoding/xml"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"path"
 	"runtime"
@@ -37,9 +38,11 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
 	ioutilx "github.com/minio/minio/internal/ioutil"
 )
@@ -1290,6 +1293,82 @@ func testAPIPutObjectStreamSigV4Handler(obj ObjectLayer, instanceType, bucketNam
 	}
 }
 
+// Wrapper for calling PutObject API handler tests with trailing checksums for both Erasure multiple disks and FS single drive setup.
+func TestAPIPutObjectTrailingChecksumHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectTrailingChecksumHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectTrailingChecksumHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	data := bytes.Repeat([]byte("a"), 65*humanize.KiByte)
+	crc := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+	sum := base64.StdEncoding.EncodeToString([]byte{byte(crc >> 24), byte(crc >> 16), byte(crc >> 8), byte(crc)})
+	emptySum := base64.StdEncoding.EncodeToString([]byte{0, 0, 0, 0})
+
+	testCases := []struct {
+		data       []byte
+		trailer    string
+		signed     bool
+		tamper     bool // Change the trailer after signing.
+		expectCode int
+		expectErr  string
+	}{
+		// Test case - 1: signed chunks, matching checksum.
+		{data: data, trailer: "x-amz-checksum-crc32c:" + sum, signed: true, expectCode: http.StatusOK},
+		// Test case - 2: unsigned chunks, matching checksum.
+		{data: data, trailer: "x-amz-checksum-crc32c:" + sum, expectCode: http.StatusOK},
+		// Test case - 3: empty object, the trailer follows the final chunk.
+		{data: []byte{}, trailer: "x-amz-checksum-crc32c:" + emptySum, expectCode: http.StatusOK},
+		// Test case - 4: signed chunks, checksum mismatch.
+		{data: data, trailer: "x-amz-checksum-crc32c:" + emptySum, signed: true, expectCode: http.StatusBadRequest, expectErr: "BadDigest"},
+		// Test case - 5: unsigned chunks, checksum mismatch.
+		{data: data, trailer: "x-amz-checksum-crc32c:" + emptySum, expectCode: http.StatusBadRequest, expectErr: "BadDigest"},
+		// Test case - 6: unsupported trailer.
+		{data: data, trailer: "x-amz-checksum-crc32:" + sum, expectCode: http.StatusBadRequest, expectErr: "InvalidRequest"},
+		// Test case - 7: trailer does not match the trailer signature.
+		{data: data, trailer: "x-amz-checksum-crc32c:" + sum, signed: true, tamper: true, expectCode: http.StatusForbidden, expectErr: "SignatureDoesNotMatch"},
+	}
+	for i, testCase := range testCases {
+		objectName := fmt.Sprintf("test-object-%d", i+1)
+		req, err := newTestStreamingTrailerRequest(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			testCase.data, 16*humanize.KiByte, testCase.trailer, testCase.signed, credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		if testCase.tamper {
+			body, _ := ioutil.ReadAll(req.Body)
+			req.Body = ioutil.NopCloser(bytes.NewReader(bytes.Replace(body, []byte(sum), []byte(emptySum), 1)))
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectCode {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s", i+1, instanceType, testCase.expectCode, rec.Code, rec.Body.String())
+		}
+		if rec.Code != http.StatusOK {
+			if !strings.Contains(rec.Body.String(), "<Code>"+testCase.expectErr+"</Code>") {
+				t.Errorf("Test %d: %s: Expected the error code %s, got %s", i+1, instanceType, testCase.expectErr, rec.Body.String())
+			}
+			if _, err = obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{}); !isErrObjectNotFound(err) {
+				t.Errorf("Test %d: %s: Expected the object to not exist, got %v", i+1, instanceType, err)
+			}
+			continue
+		}
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the object info: <ERROR> %v", i+1, instanceType, err)
+		}
+		want := "CRC32C:" + strings.SplitN(testCase.trailer, ":", 2)[1]
+		if got := objInfo.UserDefined[objectChecksumKey]; got != want {
+			t.Errorf("Test %d: %s: Expected the object checksum %q, got %q", i+1, instanceType, want, got)
+		}
+		if objInfo.Size != int64(len(testCase.data)) {
+			t.Errorf("Test %d: %s: Expected the object size %d, got %d", i+1, instanceType, len(testCase.data), objInfo.Size)
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests for both Erasure multiple disks and FS single drive setup.
 func TestAPIPutObjectHandler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -1546,6 +1625,89 @@ func testAPIPutObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 
 }
 
+// Wrapper for calling PutObject API handler tests appending to objects.
+func TestAPIPutObjectAppendHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectAppendHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectAppendHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	isErasure := globalIsErasure
+	globalIsErasure = instanceType == ErasureTestStr
+	defer func() { globalIsErasure = isErasure }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	listenCh := make(chan interface{}, 10)
+	globalHTTPListen.Subscribe(listenCh, ctx.Done(), nil)
+
+	putObject := func(objectName string, data []byte, offset string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, map[string]string{
+				xhttp.AmzWriteOffsetBytes: offset,
+			})
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	setDefaultTags := func(tags map[string]string) {
+		meta := newBucketMetadata(bucketName)
+		meta.defaultTagsConfig = &BucketDefaultTags{Tags: tags}
+		globalBucketMetadataSys.Set(bucketName, meta)
+	}
+	setDefaultTags(map[string]string{"team": "logs"})
+	defer setDefaultTags(nil)
+
+	rec := putObject("object", []byte("aaaa"), "0")
+	if !globalIsErasure {
+		if rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be %d, but instead found %d", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be %d, but instead found %d", instanceType, http.StatusOK, rec.Code)
+	}
+	// The default tags are added to the object created, an append keeps the tags.
+	setDefaultTags(map[string]string{"team": "other"})
+	if rec = putObject("object", []byte("bbbb"), "4"); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be %d, but instead found %d", instanceType, http.StatusOK, rec.Code)
+	}
+	oi, err := obj.GetObjectInfo(context.Background(), bucketName, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.UserTags != "team=logs" {
+		t.Fatalf("%s: Expected the tags team=logs, got %q", instanceType, oi.UserTags)
+	}
+	if rec = putObject("object", []byte("cccc"), "4"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be %d, but instead found %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	// Appends notify the object created like any other PutObject.
+	for _, size := range []int64{4, 8} {
+		select {
+		case ev := <-listenCh:
+			e := ev.(event.Event)
+			if e.EventName != event.ObjectCreatedPut || e.S3.Object.Key != "object" || e.S3.Object.Size != size {
+				t.Fatalf("%s: Unexpected event %s of %s with size %d", instanceType, e.EventName, e.S3.Object.Key, e.S3.Object.Size)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: Expected an event for the object of size %d", instanceType, size)
+		}
+	}
+	select {
+	case ev := <-listenCh:
+		t.Fatalf("%s: Unexpected event for a failed append %v", instanceType, ev)
+	default:
+	}
+}
+
 // Tests sanity of attempting to copying each parts at offsets from an existing
 // file and create a new object. Also validates if the written is same as what we
 // expected.
@@ -0,0 +1,165 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/internal/config/lambda"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Request headers never forwarded to a lambda webhook.
+var lambdaExcludedHeaders = map[string]struct{}{
+	xhttp.Authorization:                                    {},
+	xhttp.AmzSecurityToken:                                 {},
+	xhttp.AmzServerSideEncryptionCustomerKey:               {},
+	xhttp.AmzServerSideEncryptionCopyCustomerKey:           {},
+	http.CanonicalHeaderKey(xhttp.ContentLength):           {},
+	http.CanonicalHeaderKey("Cookie"):                      {},
+	http.CanonicalHeaderKey(xhttp.AmzContentSha256):        {},
+	http.CanonicalHeaderKey(xhttp.AmzDecodedContentLength): {},
+}
+
+// Response headers of a lambda webhook returned to the client.
+var lambdaResponseHeaders = []string{
+	xhttp.ContentType,
+	xhttp.ContentLength,
+	xhttp.ContentRange,
+	xhttp.ContentEncoding,
+	xhttp.ContentLanguage,
+	xhttp.ContentDisposition,
+	xhttp.CacheControl,
+	xhttp.Expires,
+}
+
+// ObjectLambdaSys - transforms objects read with GET through the
+// configured lambda webhook targets.
+type ObjectLambdaSys struct {
+	sync.RWMutex
+	targets []lambda.Target
+	client  *http.Client
+}
+
+// NewObjectLambdaSys - creates a new object lambda system.
+func NewObjectLambdaSys() *ObjectLambdaSys {
+	return &ObjectLambdaSys{}
+}
+
+// Set replaces the lambda webhook targets.
+func (sys *ObjectLambdaSys) Set(targets map[string]lambda.Target) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.targets = sys.targets[:0]
+	for _, t := range targets {
+		sys.targets = append(sys.targets, t)
+	}
+	if sys.client == nil && len(sys.targets) > 0 {
+		sys.client = &http.Client{Transport: NewGatewayHTTPTransport()}
+	}
+}
+
+// Match returns the target transforming object in bucket, the one with
+// the longest prefix is chosen when several targets match.
+func (sys *ObjectLambdaSys) Match(bucket, object string) (target lambda.Target, ok bool) {
+	if sys == nil {
+		return target, false
+	}
+	sys.RLock()
+	defer sys.RUnlock()
+
+	for _, t := range sys.targets {
+		if !t.Matches(bucket, object) {
+			continue
+		}
+		if !ok || len(t.Prefix) > len(target.Prefix) ||
+			(len(t.Prefix) == len(target.Prefix) && t.Name < target.Name) {
+			target, ok = t, true
+		}
+	}
+	return target, ok
+}
+
+// Transform sends the object read from gr along with the client request
+// headers to the lambda webhook target and returns its response. The
+// caller must close the response body.
+func (sys *ObjectLambdaSys) Transform(ctx context.Context, target lambda.Target, r *http.Request, objInfo ObjectInfo, gr io.Reader) (*http.Response, error) {
+	sys.RLock()
+	client := sys.client
+	sys.RUnlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Endpoint.String(), gr)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		if _, ok := lambdaExcludedHeaders[http.CanonicalHeaderKey(k)]; ok {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(k), "x-minio-lambda-") {
+			continue
+		}
+		req.Header[k] = v
+	}
+	req.Header.Set(xhttp.MinIOLambdaBucket, objInfo.Bucket)
+	req.Header.Set(xhttp.MinIOLambdaObject, objInfo.Name)
+	if objInfo.VersionID != "" {
+		req.Header.Set(xhttp.MinIOLambdaVersionID, objInfo.VersionID)
+	}
+	if objInfo.ETag != "" {
+		req.Header.Set(xhttp.MinIOLambdaETag, "\""+objInfo.ETag+"\"")
+	}
+	if objInfo.ContentType != "" {
+		req.Header.Set(xhttp.MinIOLambdaContentType, objInfo.ContentType)
+	}
+	if target.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, target.AuthToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		xhttp.DrainBody(resp.Body)
+		return nil, fmt.Errorf("lambda webhook %s returned %s", target.Name, resp.Status)
+	}
+	return resp, nil
+}
+
+// setLambdaResponseHeaders sets the headers of the lambda webhook
+// response along with the object version on the client response.
+func setLambdaResponseHeaders(w http.ResponseWriter, resp *http.Response, objInfo ObjectInfo) {
+	for _, k := range lambdaResponseHeaders {
+		if v := resp.Header.Get(k); v != "" {
+			w.Header().Set(k, v)
+		}
+	}
+	if !objInfo.ModTime.IsZero() {
+		w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	}
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+}
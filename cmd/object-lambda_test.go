@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/lambda"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestObjectLambdaMatch(t *testing.T) {
+	scfg := config.Config{
+		config.LambdaWebhookSubSys: map[string]config.KVS{
+			"bucket": {
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: lambda.Endpoint, Value: "http://localhost:8080/bucket"},
+				config.KV{Key: lambda.Prefix, Value: "bucket"},
+			},
+			"photos": {
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: lambda.Endpoint, Value: "http://localhost:8080/photos"},
+				config.KV{Key: lambda.Prefix, Value: "bucket/photos/"},
+			},
+			"disabled": {
+				config.KV{Key: config.Enable, Value: config.EnableOff},
+				config.KV{Key: lambda.Endpoint, Value: "http://localhost:8080/disabled"},
+				config.KV{Key: lambda.Prefix, Value: "bucket/photos/private/"},
+			},
+		},
+	}
+	targets, err := lambda.LookupConfig(scfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sys := NewObjectLambdaSys()
+	sys.Set(targets)
+
+	testCases := []struct {
+		bucket, object string
+		target         string
+	}{
+		{"bucket", "object", "bucket"},
+		{"bucket", "photos/cat.png", "photos"},
+		{"bucket", "photos/private/cat.png", "photos"},
+		{"other", "photos/cat.png", ""},
+	}
+	for i, testCase := range testCases {
+		target, ok := sys.Match(testCase.bucket, testCase.object)
+		if ok != (testCase.target != "") || target.Name != testCase.target {
+			t.Errorf("Test %d: expected target %q, got %q", i+1, testCase.target, target.Name)
+		}
+	}
+
+	if _, err = lambda.LookupConfig(config.Config{
+		config.LambdaWebhookSubSys: map[string]config.KVS{
+			config.Default: {
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: lambda.Endpoint, Value: "http://localhost:8080"},
+				config.KV{Key: lambda.Prefix, Value: ""},
+			},
+		},
+	}); err == nil {
+		t.Fatal("expected an error for a target without prefix")
+	}
+}
+
+func TestObjectLambdaTransform(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xhttp.Authorization) != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get(xhttp.AmzServerSideEncryptionCustomerKey) != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get(xhttp.MinIOLambdaObject) != "object" || r.Header.Get("X-Custom") != "custom" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set(xhttp.ContentType, "text/plain")
+		w.Write(bytes.ToUpper(data))
+	}))
+	defer server.Close()
+
+	targets, err := lambda.LookupConfig(config.Config{
+		config.LambdaWebhookSubSys: map[string]config.KVS{
+			config.Default: {
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: lambda.Endpoint, Value: server.URL},
+				config.KV{Key: lambda.AuthToken, Value: "token"},
+				config.KV{Key: lambda.Prefix, Value: "bucket"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sys := NewObjectLambdaSys()
+	sys.Set(targets)
+	target, ok := sys.Match("bucket", "object")
+	if !ok {
+		t.Fatal("expected a matching target")
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	r.Header.Set("X-Custom", "custom")
+	r.Header.Set(xhttp.Authorization, "AWS4-HMAC-SHA256 Credential=...")
+	r.Header.Set(xhttp.AmzServerSideEncryptionCustomerKey, "secret")
+	objInfo := ObjectInfo{Bucket: "bucket", Name: "object"}
+
+	resp, err := sys.Transform(context.Background(), target, r, objInfo, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "HELLO" {
+		t.Fatalf("expected transformed object %q, got %q", "HELLO", string(data))
+	}
+
+	target.AuthToken = "invalid"
+	if _, err = sys.Transform(context.Background(), target, r, objInfo, strings.NewReader("hello")); err == nil {
+		t.Fatal("expected an error for a failed transformation")
+	}
+}
@@ -0,0 +1,495 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	xhash "github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	objectLockAuditMetaName = "object-lock-audit.json"
+
+	// Time between two checks for an audit to run.
+	objectLockAuditCheckInterval = time.Minute
+	// Time between two saves of the audit progress.
+	objectLockAuditSaveInterval = 30 * time.Second
+
+	objectLockAuditSchema = "Bucket, Key, VersionId, IsLatest, LastModifiedDate, ObjectLockMode, ObjectLockRetainUntilDate, ObjectLockLegalHoldStatus, MissingRetention"
+)
+
+var (
+	objectLockAuditLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+	// error returned when an audit is started while another one is running.
+	errObjectLockAuditAlreadyRunning = AdminError{
+		Code:       "XMinioAdminObjectLockAuditAlreadyRunning",
+		Message:    "An object lock audit is already in progress for this bucket",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when reading the status of a bucket never audited.
+	errObjectLockAuditNotStarted = AdminError{
+		Code:       "XMinioAdminObjectLockAuditNotStarted",
+		Message:    "No object lock audit was started for this bucket",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when auditing a bucket without object lock.
+	errObjectLockAuditNotLocked = AdminError{
+		Code:       "XMinioAdminObjectLockAuditNotLocked",
+		Message:    "Object lock is not enabled on this bucket",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// objectLockAuditMeta is the persisted state of the last object lock
+// audit of a bucket.
+type objectLockAuditMeta struct {
+	ID           string    `json:"id"`
+	Bucket       string    `json:"bucket"`
+	TargetBucket string    `json:"targetBucket"`
+	TargetPrefix string    `json:"targetPrefix,omitempty"`
+	StartedAt    time.Time `json:"startedAt"`
+	CompletedAt  time.Time `json:"completedAt,omitempty"`
+
+	Versions         uint64 `json:"versions"`
+	Compliance       uint64 `json:"compliance"`
+	Governance       uint64 `json:"governance"`
+	LegalHold        uint64 `json:"legalHold"`
+	MissingRetention uint64 `json:"missingRetention"`
+
+	// Key of the signed manifest of the report in the target bucket.
+	Manifest string `json:"manifest,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (m objectLockAuditMeta) complete() bool {
+	return !m.CompletedAt.IsZero()
+}
+
+// reportPrefix returns the prefix of the report files of the audit.
+func (m objectLockAuditMeta) reportPrefix() string {
+	return pathJoin(m.TargetPrefix, m.Bucket, "object-lock-audit-"+m.ID)
+}
+
+// objectLockAuditManifest describes a report, the report file is
+// covered by the signature of the manifest through its checksum.
+type objectLockAuditManifest struct {
+	SourceBucket      string    `json:"sourceBucket"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	// Bucket default retention, versions written without retention
+	// and still within it are reported as missing retention.
+	DefaultRetentionMode     string `json:"defaultRetentionMode,omitempty"`
+	DefaultRetentionValidity string `json:"defaultRetentionValidity,omitempty"`
+	FileSchema               string `json:"fileSchema"`
+	Key                      string `json:"key"`
+	Size                     int64  `json:"size"`
+	SHA256Checksum           string `json:"sha256Checksum"`
+
+	Versions         uint64 `json:"versions"`
+	Compliance       uint64 `json:"compliance"`
+	Governance       uint64 `json:"governance"`
+	LegalHold        uint64 `json:"legalHold"`
+	MissingRetention uint64 `json:"missingRetention"`
+}
+
+func objectLockAuditMetaPath(bucket string) string {
+	return pathJoin(bucketMetaPrefix, bucket, objectLockAuditMetaName)
+}
+
+func loadObjectLockAuditMeta(ctx context.Context, objAPI ObjectLayer, bucket string) (*objectLockAuditMeta, error) {
+	data, err := readConfig(ctx, objAPI, objectLockAuditMetaPath(bucket))
+	if err != nil {
+		return nil, err
+	}
+	m := &objectLockAuditMeta{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func saveObjectLockAuditMeta(ctx context.Context, objAPI ObjectLayer, m *objectLockAuditMeta) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, objectLockAuditMetaPath(m.Bucket), data)
+}
+
+// startObjectLockAudit initializes a new audit of bucket, whose report
+// is written under prefix in the target bucket.
+func startObjectLockAudit(ctx context.Context, objAPI ObjectLayer, bucket, target, prefix string) (*objectLockAuditMeta, error) {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, err
+	}
+	if _, err := objAPI.GetBucketInfo(ctx, target); err != nil {
+		return nil, err
+	}
+	ret, err := globalBucketObjectLockSys.Get(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.LockEnabled {
+		return nil, errObjectLockAuditNotLocked
+	}
+
+	m, err := loadObjectLockAuditMeta(ctx, objAPI, bucket)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+	if m != nil && !m.complete() {
+		return nil, errObjectLockAuditAlreadyRunning
+	}
+
+	m = &objectLockAuditMeta{
+		ID:           mustGetUUID(),
+		Bucket:       bucket,
+		TargetBucket: target,
+		TargetPrefix: prefix,
+		StartedAt:    UTCNow(),
+	}
+	if err = saveObjectLockAuditMeta(ctx, objAPI, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// initObjectLockAudit will start the audit worker in the background.
+func initObjectLockAudit(ctx context.Context, objAPI ObjectLayer) {
+	go runObjectLockAudit(ctx, objAPI)
+}
+
+// runObjectLockAudit waits for audits to be started and audits one
+// bucket at a time. There should only ever be one audit worker running
+// per cluster.
+func runObjectLockAudit(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only 1 audit worker is running on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "runObjectLockAudit.lock")
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		lkctx, err := locker.GetLock(ctx, objectLockAuditLeaderLockTimeout)
+		if err != nil {
+			time.Sleep(time.Duration(r.Float64() * float64(objectLockAuditCheckInterval)))
+			continue
+		}
+		ctx = lkctx.Context()
+		defer lkctx.Cancel()
+		break
+		// No unlock for "leader" lock.
+	}
+
+	checkTimer := time.NewTimer(objectLockAuditCheckInterval)
+	defer checkTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkTimer.C:
+			buckets, err := objAPI.ListBuckets(ctx)
+			if err != nil {
+				logger.LogIf(ctx, err)
+			}
+			for _, bucket := range buckets {
+				m, err := loadObjectLockAuditMeta(ctx, objAPI, bucket.Name)
+				if err != nil {
+					if !errors.Is(err, errConfigNotFound) {
+						logger.LogIf(ctx, err)
+					}
+					continue
+				}
+				if m.complete() {
+					continue
+				}
+				as := &objectLockAuditState{meta: m}
+				if err = auditBucketObjectLock(ctx, objAPI, as); err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					logger.LogIf(ctx, err)
+				}
+			}
+			checkTimer.Reset(objectLockAuditCheckInterval)
+		}
+	}
+}
+
+// objectLockAuditState is the in-memory state of a running audit.
+type objectLockAuditState struct {
+	mu       sync.Mutex
+	meta     *objectLockAuditMeta
+	lastSave time.Time
+}
+
+// sync persists the in-memory progress.
+func (as *objectLockAuditState) sync(ctx context.Context, objAPI ObjectLayer, force bool) error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if !force && time.Since(as.lastSave) < objectLockAuditSaveInterval {
+		return nil
+	}
+	as.lastSave = time.Now()
+	return saveObjectLockAuditMeta(ctx, objAPI, as.meta)
+}
+
+func (as *objectLockAuditState) update(fn func(m *objectLockAuditMeta)) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	fn(as.meta)
+}
+
+// auditBucketObjectLock walks all object versions of the bucket and
+// writes their retention and legal hold to a report in the target
+// bucket. An audit interrupted before completion starts over.
+func auditBucketObjectLock(ctx context.Context, objAPI ObjectLayer, as *objectLockAuditState) error {
+	m := *as.meta
+	as.update(func(m *objectLockAuditMeta) {
+		*m = objectLockAuditMeta{
+			ID:           m.ID,
+			Bucket:       m.Bucket,
+			TargetBucket: m.TargetBucket,
+			TargetPrefix: m.TargetPrefix,
+			StartedAt:    m.StartedAt,
+		}
+	})
+
+	manifest, err := writeObjectLockAuditReport(ctx, objAPI, as)
+	if err == nil {
+		err = putObjectLockAuditManifest(ctx, objAPI, m, manifest)
+	}
+	as.update(func(m *objectLockAuditMeta) {
+		if err != nil {
+			m.Error = err.Error()
+		} else {
+			m.Manifest = pathJoin(m.reportPrefix(), "manifest.json")
+		}
+		m.CompletedAt = UTCNow()
+	})
+	if ctx.Err() != nil {
+		// Interrupted, the next leader audits the bucket again.
+		return ctx.Err()
+	}
+	if serr := as.sync(ctx, objAPI, true); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// writeObjectLockAuditReport streams the rows of the report to the
+// report file and returns the manifest describing it.
+func writeObjectLockAuditReport(ctx context.Context, objAPI ObjectLayer, as *objectLockAuditState) (manifest objectLockAuditManifest, err error) {
+	as.mu.Lock()
+	m := *as.meta
+	as.mu.Unlock()
+
+	ret, err := globalBucketObjectLockSys.Get(m.Bucket)
+	if err != nil {
+		return manifest, err
+	}
+	manifest = objectLockAuditManifest{
+		SourceBucket:      m.Bucket,
+		CreationTimestamp: UTCNow(),
+		FileSchema:        objectLockAuditSchema,
+		Key:               pathJoin(m.reportPrefix(), "report.csv"),
+	}
+	if ret.Mode != "" {
+		manifest.DefaultRetentionMode = string(ret.Mode)
+		manifest.DefaultRetentionValidity = ret.Validity.String()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := putObjectLockAuditObject(ctx, objAPI, m.TargetBucket, manifest.Key, "text/csv", pr, -1)
+		pr.CloseWithError(err)
+		done <- err
+	}()
+
+	sha := sha256.New()
+	cw := &objectLockAuditCounter{w: io.MultiWriter(pw, sha)}
+	w := csv.NewWriter(cw)
+
+	results := make(chan ObjectInfo, 100)
+	if err = objAPI.Walk(ctx, m.Bucket, "", results, ObjectOptions{WalkVersions: true}); err != nil {
+		pw.CloseWithError(err)
+		<-done
+		return manifest, err
+	}
+	for oi := range results {
+		// Keep draining the walker once stopped, it does
+		// not give up sending on context cancelation.
+		if err != nil {
+			continue
+		}
+		if oi.DeleteMarker {
+			continue
+		}
+		row, mode, legalHold, missing := objectLockAuditRow(oi, ret)
+		if err = w.Write(row); err != nil {
+			cancel()
+			continue
+		}
+		as.update(func(m *objectLockAuditMeta) {
+			m.Versions++
+			switch mode {
+			case objectlock.RetCompliance:
+				m.Compliance++
+			case objectlock.RetGovernance:
+				m.Governance++
+			}
+			if legalHold {
+				m.LegalHold++
+			}
+			if missing {
+				m.MissingRetention++
+			}
+		})
+		if err = as.sync(ctx, objAPI, false); err != nil {
+			cancel()
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	pw.CloseWithError(err)
+	if perr := <-done; err == nil {
+		err = perr
+	}
+	if err != nil {
+		return manifest, err
+	}
+
+	as.mu.Lock()
+	manifest.Versions = as.meta.Versions
+	manifest.Compliance = as.meta.Compliance
+	manifest.Governance = as.meta.Governance
+	manifest.LegalHold = as.meta.LegalHold
+	manifest.MissingRetention = as.meta.MissingRetention
+	as.mu.Unlock()
+	manifest.Size = cw.n
+	manifest.SHA256Checksum = hex.EncodeToString(sha.Sum(nil))
+	return manifest, nil
+}
+
+// objectLockAuditRow returns the report row of the object version oi,
+// missing is true when ret is a bucket default retention which should
+// still protect the version but the version has no retention.
+func objectLockAuditRow(oi ObjectInfo, ret objectlock.Retention) (row []string, mode objectlock.RetMode, legalHold, missing bool) {
+	retention := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+	hold := objectlock.GetObjectLegalHoldMeta(oi.UserDefined)
+	mode = retention.Mode
+	legalHold = hold.Status == objectlock.LegalHoldOn
+	missing = ret.Mode != "" && !mode.Valid() && ret.Retain(oi.ModTime)
+
+	var retainUntil string
+	if !retention.RetainUntilDate.IsZero() {
+		retainUntil = retention.RetainUntilDate.UTC().Format(time.RFC3339)
+	}
+	versionID := oi.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	row = []string{
+		oi.Bucket,
+		url.QueryEscape(oi.Name),
+		versionID,
+		strconv.FormatBool(oi.IsLatest),
+		oi.ModTime.UTC().Format(time.RFC3339),
+		string(mode),
+		retainUntil,
+		string(hold.Status),
+		strconv.FormatBool(missing),
+	}
+	return row, mode, legalHold, missing
+}
+
+// objectLockAuditCounter counts the bytes written to the report file.
+type objectLockAuditCounter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *objectLockAuditCounter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// objectLockAuditMAC returns the MAC signing the reports, keyed with
+// the secret key of the root user so that it can verify them.
+func objectLockAuditMAC() hash.Hash {
+	return hmac.New(sha256.New, []byte(globalActiveCred.SecretKey))
+}
+
+// putObjectLockAuditManifest writes the manifest of the report and its
+// signature, the hex encoded HMAC-SHA256 of the manifest.
+func putObjectLockAuditManifest(ctx context.Context, objAPI ObjectLayer, m objectLockAuditMeta, manifest objectLockAuditManifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	prefix := m.reportPrefix()
+	if _, err = putObjectLockAuditObject(ctx, objAPI, m.TargetBucket, pathJoin(prefix, "manifest.json"), "application/json", bytes.NewReader(data), int64(len(data))); err != nil {
+		return err
+	}
+	mac := objectLockAuditMAC()
+	mac.Write(data)
+	sig := []byte(hex.EncodeToString(mac.Sum(nil)))
+	_, err = putObjectLockAuditObject(ctx, objAPI, m.TargetBucket, pathJoin(prefix, "manifest.sig"), "text/plain", bytes.NewReader(sig), int64(len(sig)))
+	return err
+}
+
+// putObjectLockAuditObject uploads a report file to the target bucket.
+func putObjectLockAuditObject(ctx context.Context, objAPI ObjectLayer, bucket, object, contentType string, r io.Reader, size int64) (ObjectInfo, error) {
+	hr, err := xhash.NewReader(r, size, "", "", size)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr), ObjectOptions{
+		UserDefined:      map[string]string{xhttp.ContentType: contentType},
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, object),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, object),
+	})
+}
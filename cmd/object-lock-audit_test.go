@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+)
+
+func TestObjectLockAuditRow(t *testing.T) {
+	now := UTCNow()
+	retainUntil := now.Add(24 * time.Hour).Format(time.RFC3339)
+	defaultRet := objectlock.Retention{
+		Mode:        objectlock.RetCompliance,
+		Validity:    7 * 24 * time.Hour,
+		LockEnabled: true,
+	}
+
+	testCases := []struct {
+		oi        ObjectInfo
+		ret       objectlock.Retention
+		mode      objectlock.RetMode
+		legalHold bool
+		missing   bool
+		row       string
+	}{
+		// Version locked in compliance mode.
+		{
+			oi: ObjectInfo{Bucket: "bucket", Name: "a b", VersionID: "v1", IsLatest: true, ModTime: now, UserDefined: map[string]string{
+				strings.ToLower(objectlock.AmzObjectLockMode):            string(objectlock.RetCompliance),
+				strings.ToLower(objectlock.AmzObjectLockRetainUntilDate): retainUntil,
+			}},
+			ret:  defaultRet,
+			mode: objectlock.RetCompliance,
+			row:  "bucket,a+b,v1,true," + now.Format(time.RFC3339) + ",COMPLIANCE," + retainUntil + ",,false",
+		},
+		// Version under legal hold only, still within the default retention.
+		{
+			oi: ObjectInfo{Bucket: "bucket", Name: "b", ModTime: now.Add(-time.Hour), UserDefined: map[string]string{
+				strings.ToLower(objectlock.AmzObjectLockLegalHold): string(objectlock.LegalHoldOn),
+			}},
+			ret:       defaultRet,
+			legalHold: true,
+			missing:   true,
+			row:       "bucket,b,null,false," + now.Add(-time.Hour).Format(time.RFC3339) + ",,,ON,true",
+		},
+		// Version older than the default retention.
+		{
+			oi:  ObjectInfo{Bucket: "bucket", Name: "c", VersionID: "v3", ModTime: now.Add(-8 * 24 * time.Hour)},
+			ret: defaultRet,
+			row: "bucket,c,v3,false," + now.Add(-8*24*time.Hour).Format(time.RFC3339) + ",,,,false",
+		},
+		// No default retention.
+		{
+			oi:  ObjectInfo{Bucket: "bucket", Name: "d", VersionID: "v4", ModTime: now},
+			ret: objectlock.Retention{LockEnabled: true},
+			row: "bucket,d,v4,false," + now.Format(time.RFC3339) + ",,,,false",
+		},
+	}
+	for i, testCase := range testCases {
+		row, mode, legalHold, missing := objectLockAuditRow(testCase.oi, testCase.ret)
+		if mode != testCase.mode || legalHold != testCase.legalHold || missing != testCase.missing {
+			t.Errorf("Case %d: expected %q %v %v, got %q %v %v", i+1, testCase.mode, testCase.legalHold, testCase.missing, mode, legalHold, missing)
+		}
+		if got := strings.Join(row, ","); got != testCase.row {
+			t.Errorf("Case %d: expected row %s, got %s", i+1, testCase.row, got)
+		}
+	}
+}
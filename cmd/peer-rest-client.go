@@ -34,7 +34,9 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/target"
 	"github.com/minio/minio/internal/http"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -488,6 +490,17 @@ func (client *peerRESTClient) DownloadProfileData() (data map[string][]byte, err
 	return data, err
 }
 
+// Diagnostics - returns the diagnostics snapshot of a remote node.
+func (client *peerRESTClient) Diagnostics(ctx context.Context) (data map[string][]byte, err error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodDiagnostics, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&data)
+	return data, err
+}
+
 // GetBucketStats - load bucket statistics
 func (client *peerRESTClient) GetBucketStats(bucket string) (BucketStats, error) {
 	values := make(url.Values)
@@ -502,6 +515,20 @@ func (client *peerRESTClient) GetBucketStats(bucket string) (BucketStats, error)
 	return bs, msgp.Decode(respBody, &bs)
 }
 
+// GetReplicationMRF - fetch the queued failed replications of bucket
+func (client *peerRESTClient) GetReplicationMRF(bucket string) (ReplicationMRFStatus, error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodGetReplicationMRF, values, nil, -1)
+	if err != nil {
+		return ReplicationMRFStatus{}, err
+	}
+
+	var s ReplicationMRFStatus
+	defer http.DrainBody(respBody)
+	return s, msgp.Decode(respBody, &s)
+}
+
 // LoadBucketMetadata - load bucket metadata
 func (client *peerRESTClient) LoadBucketMetadata(bucket string) error {
 	values := make(url.Values)
@@ -752,6 +779,24 @@ func (client *peerRESTClient) UpdateMetacacheListing(ctx context.Context, m meta
 
 }
 
+// MetacacheWrittenSince returns true if the peer wrote an object
+// of the listing of root and filter after the given time.
+func (client *peerRESTClient) MetacacheWrittenSince(ctx context.Context, bucket, root, filter string, since time.Time) (written bool, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	values.Set(peerRESTMetacacheRoot, root)
+	values.Set(peerRESTMetacacheFilter, filter)
+	values.Set(peerRESTMetacacheSince, since.Format(time.RFC3339Nano))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodMetacacheWrittenSince, values, nil, -1)
+	if err != nil {
+		return true, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&written)
+	return written, err
+}
+
 func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) error {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadTransitionTierConfig, nil, nil, 0)
 	if err != nil {
@@ -762,7 +807,7 @@ func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) erro
 	return nil
 }
 
-func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts traceOptions) {
 	values := make(url.Values)
 	values.Set(peerRESTTraceErr, strconv.FormatBool(traceOpts.OnlyErrors))
 	values.Set(peerRESTTraceS3, strconv.FormatBool(traceOpts.S3))
@@ -770,6 +815,8 @@ func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan st
 	values.Set(peerRESTTraceOS, strconv.FormatBool(traceOpts.OS))
 	values.Set(peerRESTTraceInternal, strconv.FormatBool(traceOpts.Internal))
 	values.Set(peerRESTTraceThreshold, traceOpts.Threshold.String())
+	values.Set(peerRESTTraceSample, strconv.FormatFloat(traceOpts.SampleRate, 'f', -1, 64))
+	values.Set(peerRESTTraceAPI, strings.Join(traceOpts.APIs, ","))
 
 	// To cancel the REST request in case doneCh gets closed.
 	ctx, cancel := context.WithCancel(GlobalContext)
@@ -863,7 +910,7 @@ func (client *peerRESTClient) Listen(listenCh chan interface{}, doneCh <-chan st
 }
 
 // Trace - send http trace request to peer nodes
-func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts traceOptions) {
 	go func() {
 		for {
 			client.doTrace(traceCh, doneCh, traceOpts)
@@ -878,6 +925,64 @@ func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan stru
 	}()
 }
 
+func (client *peerRESTClient) doHealEvents(healCh chan interface{}, doneCh <-chan struct{}, bucket string) {
+	// To cancel the REST request in case doneCh gets closed.
+	ctx, cancel := context.WithCancel(GlobalContext)
+
+	cancelCh := make(chan struct{})
+	defer close(cancelCh)
+	go func() {
+		select {
+		case <-doneCh:
+		case <-cancelCh:
+			// There was an error in the REST request.
+		}
+		cancel()
+	}()
+
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	respBody, err := client.callWithContext(ctx, peerRESTMethodHealEvents, values, nil, -1)
+	defer http.DrainBody(respBody)
+
+	if err != nil {
+		return
+	}
+
+	dec := gob.NewDecoder(respBody)
+	for {
+		var ev HealEvent
+		if err = dec.Decode(&ev); err != nil {
+			return
+		}
+		if len(ev.NodeName) > 0 {
+			select {
+			case healCh <- ev:
+			default:
+				// Do not block on slow receivers.
+			}
+		}
+	}
+}
+
+// HealEvents - sends request to peer node to get its heal events of
+// bucket, all its heal events if bucket is empty.
+func (client *peerRESTClient) HealEvents(healCh chan interface{}, doneCh <-chan struct{}, bucket string) {
+	go func() {
+		for {
+			client.doHealEvents(healCh, doneCh, bucket)
+			select {
+			case <-doneCh:
+				return
+			default:
+				// There was error in the REST request, retry after sometime as probably the peer is down.
+				time.Sleep(5 * time.Second)
+			}
+		}
+	}()
+}
+
 // ConsoleLog - sends request to peer nodes to get console logs
 func (client *peerRESTClient) ConsoleLog(logCh chan interface{}, doneCh <-chan struct{}) {
 	go func() {
@@ -958,9 +1063,9 @@ func newPeerRESTClient(peer *xnet.Host) *peerRESTClient {
 		Path:   peerRESTPath,
 	}
 
-	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient := newInternodeRESTClient(serverURL)
 	// Use a separate client to avoid recursive calls.
-	healthClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	healthClient := newInternodeRESTClient(serverURL)
 	healthClient.ExpectTimeouts = true
 	healthClient.NoMetrics = true
 
@@ -1050,3 +1155,182 @@ func (client *peerRESTClient) ReloadSiteReplicationConfig(ctx context.Context) e
 	defer http.DrainBody(respBody)
 	return nil
 }
+
+// ListNotifyStore - lists the undelivered events of a notification target on the peer.
+func (client *peerRESTClient) ListNotifyStore(ctx context.Context, targetID string, filter target.StoreFilter) ([]target.StoredEvent, error) {
+	values := make(url.Values)
+	values.Set(peerRESTNotifyTarget, targetID)
+	notifyStoreFilterToValues(values, filter)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodListNotifyStore, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var events []target.StoredEvent
+	err = gob.NewDecoder(respBody).Decode(&events)
+	return events, err
+}
+
+// ReplayNotifyStore - replays the undelivered events of a notification target on the peer.
+func (client *peerRESTClient) ReplayNotifyStore(ctx context.Context, targetID string, filter target.StoreFilter) (NotifyStoreResult, error) {
+	return client.notifyStore(ctx, peerRESTMethodReplayNotifyStore, targetID, filter)
+}
+
+// PurgeNotifyStore - removes the undelivered events of a notification target on the peer.
+func (client *peerRESTClient) PurgeNotifyStore(ctx context.Context, targetID string, filter target.StoreFilter) (NotifyStoreResult, error) {
+	return client.notifyStore(ctx, peerRESTMethodPurgeNotifyStore, targetID, filter)
+}
+
+func (client *peerRESTClient) notifyStore(ctx context.Context, method, targetID string, filter target.StoreFilter) (result NotifyStoreResult, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTNotifyTarget, targetID)
+	notifyStoreFilterToValues(values, filter)
+	respBody, err := client.callWithContext(ctx, method, values, nil, -1)
+	if err != nil {
+		return result, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&result)
+	return result, err
+}
+
+// ReplaceDrive - replaces a failed drive of the peer with the fresh drive mounted in its place.
+func (client *peerRESTClient) ReplaceDrive(ctx context.Context, endpoint string) (status DriveReplaceStatus, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTDrive, endpoint)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReplaceDrive, values, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
+// TierTransitionStatus - returns the transition statistics of the peer per remote tier.
+func (client *peerRESTClient) TierTransitionStatus(ctx context.Context) ([]TierTransitionStatus, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodTierTransitionStatus, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var statuses []TierTransitionStatus
+	err = gob.NewDecoder(respBody).Decode(&statuses)
+	return statuses, err
+}
+
+// ScannerControl - applies a scanner control operation on the peer.
+func (client *peerRESTClient) ScannerControl(ctx context.Context, op, bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTScannerOp, op)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodScannerControl, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadPoolMeta - reloads the decommission state of the pools on the peer.
+func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadPoolMeta, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// AddPool - adds the pool given by its command line argument on the peer.
+func (client *peerRESTClient) AddPool(ctx context.Context, pool string) error {
+	values := make(url.Values)
+	values.Set(peerRESTPool, pool)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodAddPool, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemovePendingPool - removes the pending pool given by its command line
+// argument on the peer.
+func (client *peerRESTClient) RemovePendingPool(ctx context.Context, pool string) error {
+	values := make(url.Values)
+	values.Set(peerRESTPool, pool)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodRemovePendingPool, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ValidateConfig - validates the server config on the peer, without applying it.
+func (client *peerRESTClient) ValidateConfig(ctx context.Context, cfg config.Config) error {
+	var reader bytes.Buffer
+	if err := gob.NewEncoder(&reader).Encode(cfg); err != nil {
+		return err
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodValidateConfig, nil, &reader, int64(reader.Len()))
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// UsageAccounting - returns the usage of the S3 APIs by access key
+// counted on the peer.
+func (client *peerRESTClient) UsageAccounting(ctx context.Context) (s UsageAccountingSnapshot, err error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodUsageAccounting, nil, nil, -1)
+	if err != nil {
+		return s, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&s)
+	return s, err
+}
+
+// Drain - starts draining the peer, or accepts S3 requests again on
+// it when cancel is true.
+func (client *peerRESTClient) Drain(ctx context.Context, cancel bool) error {
+	values := make(url.Values)
+	values.Set(peerRESTDrainCancel, strconv.FormatBool(cancel))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodDrain, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// DrainStatus - returns the drain state of the peer.
+func (client *peerRESTClient) DrainStatus(ctx context.Context) (status NodeDrainStatus, err error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodDrainStatus, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
+// ScannerStatus - returns the state of the data scanner on the peer.
+func (client *peerRESTClient) ScannerStatus(ctx context.Context) (status ScannerStatus, err error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodScannerStatus, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v17" // Add "storage-class" option for SpeedTest
+	peerRESTVersion       = "v26" // Add pools pending until committed
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -41,6 +41,7 @@ const (
 	peerRESTMethodDeleteBucketMetadata        = "/deletebucketmetadata"
 	peerRESTMethodLoadBucketMetadata          = "/loadbucketmetadata"
 	peerRESTMethodGetBucketStats              = "/getbucketstats"
+	peerRESTMethodGetReplicationMRF           = "/getreplicationmrf"
 	peerRESTMethodServerUpdate                = "/serverupdate"
 	peerRESTMethodSignalService               = "/signalservice"
 	peerRESTMethodBackgroundHealStatus        = "/backgroundhealstatus"
@@ -55,18 +56,38 @@ const (
 	peerRESTMethodLoadGroup                   = "/loadgroup"
 	peerRESTMethodStartProfiling              = "/startprofiling"
 	peerRESTMethodDownloadProfilingData       = "/downloadprofilingdata"
+	peerRESTMethodDiagnostics                 = "/diagnostics"
 	peerRESTMethodCycleBloom                  = "/cyclebloom"
 	peerRESTMethodTrace                       = "/trace"
 	peerRESTMethodListen                      = "/listen"
 	peerRESTMethodLog                         = "/log"
+	peerRESTMethodHealEvents                  = "/healevents"
 	peerRESTMethodGetLocalDiskIDs             = "/getlocaldiskids"
 	peerRESTMethodGetBandwidth                = "/bandwidth"
 	peerRESTMethodGetMetacacheListing         = "/getmetacache"
 	peerRESTMethodUpdateMetacacheListing      = "/updatemetacache"
+	peerRESTMethodMetacacheWrittenSince       = "/metacachewrittensince"
 	peerRESTMethodGetPeerMetrics              = "/peermetrics"
 	peerRESTMethodLoadTransitionTierConfig    = "/loadtransitiontierconfig"
 	peerRESTMethodSpeedtest                   = "/speedtest"
 	peerRESTMethodReloadSiteReplicationConfig = "/reloadsitereplicationconfig"
+	peerRESTMethodListNotifyStore             = "/listnotifystore"
+	peerRESTMethodReplayNotifyStore           = "/replaynotifystore"
+	peerRESTMethodPurgeNotifyStore            = "/purgenotifystore"
+	peerRESTMethodReplaceDrive                = "/replacedrive"
+	peerRESTMethodTierTransitionStatus        = "/tiertransitionstatus"
+	peerRESTMethodScannerControl              = "/scannercontrol"
+	peerRESTMethodScannerStatus               = "/scannerstatus"
+	peerRESTMethodReloadPoolMeta              = "/reloadpoolmeta"
+	peerRESTMethodAddPool                     = "/addpool"
+	peerRESTMethodRemovePendingPool           = "/removependingpool"
+	peerRESTMethodValidateConfig              = "/validateconfig"
+	peerRESTMethodUsageAccounting             = "/usageaccounting"
+	peerRESTMethodDrain                       = "/drain"
+	peerRESTMethodDrainStatus                 = "/drainstatus"
+
+	// Served without the version prefix, to be callable across releases.
+	peerRESTMethodInternodeVersions = "/internodeversions"
 )
 
 const (
@@ -86,10 +107,21 @@ const (
 	peerRESTTraceS3        = "s3"
 	peerRESTTraceOS        = "os"
 	peerRESTTraceThreshold = "threshold"
+	peerRESTTraceSample    = "sample"
+	peerRESTTraceAPI       = "api"
 	peerRESTSize           = "size"
 	peerRESTConcurrent     = "concurrent"
 	peerRESTDuration       = "duration"
 	peerRESTStorageClass   = "storage-class"
+	peerRESTNotifyTarget   = "target"
+	peerRESTDrive          = "drive"
+	peerRESTScannerOp      = "scanner-op"
+	peerRESTPool           = "pool"
+	peerRESTDrainCancel    = "cancel"
+
+	peerRESTMetacacheRoot   = "root"
+	peerRESTMetacacheFilter = "filter"
+	peerRESTMetacacheSince  = "since"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -36,7 +37,9 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
 	b "github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/event/target"
 	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -329,6 +332,22 @@ func (s *peerRESTServer) DownloadProfilingDataHandler(w http.ResponseWriter, r *
 	logger.LogIf(ctx, gob.NewEncoder(w).Encode(profileData))
 }
 
+// DiagnosticsHandler - returns the diagnostics snapshot of this node.
+func (s *peerRESTServer) DiagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "Diagnostics")
+	data, err := getDiagnosticsData()
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(data))
+}
+
 // ServerInfoHandler - returns Server Info
 func (s *peerRESTServer) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -587,6 +606,24 @@ func (s *peerRESTServer) GetBucketStatsHandler(w http.ResponseWriter, r *http.Re
 	logger.LogIf(r.Context(), msgp.Encode(w, &bs))
 }
 
+// GetReplicationMRFHandler - fetches the queued failed replications of a bucket.
+func (s *peerRESTServer) GetReplicationMRFHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucketName := vars[peerRESTBucket]
+	if bucketName == "" {
+		s.writeErrorResponse(w, errors.New("Bucket name is missing"))
+		return
+	}
+
+	st := globalReplicationPool.getMRFStatus(bucketName)
+	logger.LogIf(r.Context(), msgp.Encode(w, &st))
+}
+
 // LoadBucketMetadataHandler - reloads in memory bucket metadata
 func (s *peerRESTServer) LoadBucketMetadataHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -622,6 +659,8 @@ func (s *peerRESTServer) LoadBucketMetadataHandler(w http.ResponseWriter, r *htt
 	if meta.bucketTargetConfig != nil {
 		globalBucketTargetSys.UpdateAllTargets(bucketName, meta.bucketTargetConfig)
 	}
+
+	setReplicationBandwidthLimit(bucketName, meta)
 }
 
 // CycleServerBloomFilterHandler cycles bloom filter on server.
@@ -665,6 +704,24 @@ func (s *peerRESTServer) GetMetacacheListingHandler(w http.ResponseWriter, r *ht
 	logger.LogIf(ctx, msgp.Encode(w, &resp))
 }
 
+// MetacacheWrittenSinceHandler - returns true if an object of a listing
+// was written by this node after the given time.
+func (s *peerRESTServer) MetacacheWrittenSinceHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	since, err := time.Parse(time.RFC3339Nano, r.Form.Get(peerRESTMetacacheSince))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	written := localMetacacheWrites.writtenSince(r.Form.Get(peerRESTBucket),
+		r.Form.Get(peerRESTMetacacheRoot), r.Form.Get(peerRESTMetacacheFilter), since)
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(written))
+}
+
 func (s *peerRESTServer) UpdateMetacacheListingHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		s.writeErrorResponse(w, errors.New("Invalid request"))
@@ -718,10 +775,11 @@ func (s *peerRESTServer) PutBucketNotificationHandler(w http.ResponseWriter, r *
 
 // Return disk IDs of all the local disks.
 func getLocalDiskIDs(z *erasureServerPools) []string {
+	pools := z.getServerPools()
 	var ids []string
 
-	for poolIdx := range z.serverPools {
-		for _, set := range z.serverPools[poolIdx].sets {
+	for poolIdx := range pools {
+		for _, set := range pools[poolIdx].sets {
 			disks := set.getDisks()
 			for _, disk := range disks {
 				if disk == nil {
@@ -946,7 +1004,7 @@ func (s *peerRESTServer) ListenHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+func extractTraceOptsFromPeerRequest(r *http.Request) (opts traceOptions, err error) {
 	opts.S3 = r.Form.Get(peerRESTTraceS3) == "true"
 	opts.OS = r.Form.Get(peerRESTTraceOS) == "true"
 	opts.Storage = r.Form.Get(peerRESTTraceStorage) == "true"
@@ -960,7 +1018,9 @@ func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceO
 		}
 		opts.Threshold = d
 	}
-	return
+
+	opts.SampleRate, opts.APIs, err = parseTraceSampling(r.Form.Get(peerRESTTraceSample), r.Form.Get(peerRESTTraceAPI))
+	return opts, err
 }
 
 // TraceHandler sends http trace messages back to peer rest client
@@ -983,6 +1043,7 @@ func (s *peerRESTServer) TraceHandler(w http.ResponseWriter, r *http.Request) {
 	// Use buffered channel to take care of burst sends or slow w.Write()
 	ch := make(chan interface{}, 2000)
 
+	globalTraceSampler.register(&traceOpts, doneCh)
 	globalTrace.Subscribe(ch, doneCh, func(entry interface{}) bool {
 		return mustTrace(entry, traceOpts)
 	})
@@ -1007,6 +1068,42 @@ func (s *peerRESTServer) TraceHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HealEventsHandler sends heal events of this node to the remote peer.
+func (s *peerRESTServer) HealEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Heal events publisher uses nonblocking publish and hence does not wait for slow subscribers.
+	// Use buffered channel to take care of burst sends or slow w.Write()
+	ch := make(chan interface{}, 2000)
+
+	globalHealEvents.Subscribe(ch, doneCh, healEventFilter(r.Form.Get(peerRESTBucket)))
+
+	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+	defer keepAliveTicker.Stop()
+
+	enc := gob.NewEncoder(w)
+	for {
+		select {
+		case entry := <-ch:
+			if err := enc.Encode(entry); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-keepAliveTicker.C:
+			if err := enc.Encode(&HealEvent{}); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
 func (s *peerRESTServer) BackgroundHealStatusHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		s.writeErrorResponse(w, errors.New("invalid request"))
@@ -1306,6 +1403,254 @@ func (s *peerRESTServer) SpeedtestHandler(w http.ResponseWriter, r *http.Request
 	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(result))
 }
 
+// ListNotifyStoreHandler - lists the undelivered events of a notification target.
+func (s *peerRESTServer) ListNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	filter, err := notifyStoreFilterFromValues(r.Form)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	events, err := listNotifyStoreEvents(r.Form.Get(peerRESTNotifyTarget), filter)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(events))
+}
+
+// ReplayNotifyStoreHandler - replays the undelivered events of a notification target.
+func (s *peerRESTServer) ReplayNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	s.notifyStoreHandler(w, r, replayNotifyStoreEvents)
+}
+
+// PurgeNotifyStoreHandler - removes the undelivered events of a notification target.
+func (s *peerRESTServer) PurgeNotifyStoreHandler(w http.ResponseWriter, r *http.Request) {
+	s.notifyStoreHandler(w, r, purgeNotifyStoreEvents)
+}
+
+func (s *peerRESTServer) notifyStoreHandler(w http.ResponseWriter, r *http.Request, fn func(string, target.StoreFilter) (int, error)) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	filter, err := notifyStoreFilterFromValues(r.Form)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	var result NotifyStoreResult
+	result.Count, err = fn(r.Form.Get(peerRESTNotifyTarget), filter)
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(result))
+}
+
+// ReplaceDriveHandler - replaces a failed local drive with the fresh drive mounted in its place.
+func (s *peerRESTServer) ReplaceDriveHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	z, ok := newObjectLayerFn().(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	status, err := z.replaceDrive(r.Context(), r.Form.Get(peerRESTDrive))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(status))
+}
+
+// TierTransitionStatusHandler - returns the transition statistics of this node per remote tier.
+func (s *peerRESTServer) TierTransitionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	var statuses []TierTransitionStatus
+	if globalTransitionState != nil {
+		statuses = globalTransitionState.TierStatus()
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(statuses))
+}
+
+// ScannerControlHandler - applies a scanner control operation on this node.
+func (s *peerRESTServer) ScannerControlHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	if err := globalScannerControl.apply(r.Form.Get(peerRESTScannerOp), r.Form.Get(peerRESTBucket)); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// ScannerStatusHandler - returns the state of the data scanner on this node.
+func (s *peerRESTServer) ScannerStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalScannerControl.status()))
+}
+
+// UsageAccountingHandler - returns the usage of the S3 APIs by access
+// key counted on this node.
+func (s *peerRESTServer) UsageAccountingHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalUsageAccounting.snapshot()))
+}
+
+// DrainHandler - starts draining this node, or accepts S3 requests
+// again when cancel is set.
+func (s *peerRESTServer) DrainHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	cancel, err := strconv.ParseBool(r.Form.Get(peerRESTDrainCancel))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if cancel {
+		globalNodeDrain.stop()
+	} else {
+		globalNodeDrain.start()
+	}
+}
+
+// DrainStatusHandler - returns the drain state of this node.
+func (s *peerRESTServer) DrainStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalNodeDrain.status()))
+}
+
+// InternodeVersionsHandler - returns the internode API versions of
+// this node.
+func (s *peerRESTServer) InternodeVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), json.NewEncoder(w).Encode(localInternodeVersions()))
+}
+
+// ReloadPoolMetaHandler - reloads the decommission state of the pools.
+func (s *peerRESTServer) ReloadPoolMetaHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	z, ok := newObjectLayerFn().(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := z.reloadPoolMeta(r.Context()); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// AddPoolHandler - adds a pool formatted by another server.
+func (s *peerRESTServer) AddPoolHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	z, ok := newObjectLayerFn().(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	ep, err := newPoolEndpoints(r.Form.Get(peerRESTPool))
+	if err == nil {
+		_, err = z.addPool(r.Context(), ep, false, true)
+	}
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// RemovePendingPoolHandler - removes a pool which could not be added to
+// all servers.
+func (s *peerRESTServer) RemovePendingPoolHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	z, ok := newObjectLayerFn().(*erasureServerPools)
+	if !ok {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	z.removePendingPool(r.Context(), r.Form.Get(peerRESTPool))
+}
+
+// ValidateConfigHandler - validates the server config sent by the peer,
+// without applying it.
+func (s *peerRESTServer) ValidateConfigHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	if r.ContentLength < 0 || r.ContentLength > maxEConfigJSONSize {
+		s.writeErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	var cfg config.Config
+	if err := gob.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&cfg); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	if err := validateConfig(cfg); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
 // registerPeerRESTHandlers - register peer rest router.
 func registerPeerRESTHandlers(router *mux.Router) {
 	server := &peerRESTServer{}
@@ -1328,6 +1673,7 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDeleteBucketMetadata).HandlerFunc(httpTraceHdrs(server.DeleteBucketMetadataHandler)).Queries(restQueries(peerRESTBucket)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadBucketMetadata).HandlerFunc(httpTraceHdrs(server.LoadBucketMetadataHandler)).Queries(restQueries(peerRESTBucket)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetBucketStats).HandlerFunc(httpTraceHdrs(server.GetBucketStatsHandler)).Queries(restQueries(peerRESTBucket)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetReplicationMRF).HandlerFunc(httpTraceHdrs(server.GetReplicationMRFHandler)).Queries(restQueries(peerRESTBucket)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSignalService).HandlerFunc(httpTraceHdrs(server.SignalServiceHandler)).Queries(restQueries(peerRESTSignal)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodServerUpdate).HandlerFunc(httpTraceHdrs(server.ServerUpdateHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDeletePolicy).HandlerFunc(httpTraceAll(server.DeletePolicyHandler)).Queries(restQueries(peerRESTPolicy)...)
@@ -1341,16 +1687,34 @@ func registerPeerRESTHandlers(router *mux.Router) {
 
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodStartProfiling).HandlerFunc(httpTraceAll(server.StartProfilingHandler)).Queries(restQueries(peerRESTProfiler)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDownloadProfilingData).HandlerFunc(httpTraceHdrs(server.DownloadProfilingDataHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDiagnostics).HandlerFunc(httpTraceHdrs(server.DiagnosticsHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodTrace).HandlerFunc(server.TraceHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListen).HandlerFunc(httpTraceHdrs(server.ListenHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodBackgroundHealStatus).HandlerFunc(server.BackgroundHealStatusHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLog).HandlerFunc(server.ConsoleLogHandler)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodHealEvents).HandlerFunc(server.HealEventsHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLocalDiskIDs).HandlerFunc(httpTraceHdrs(server.GetLocalDiskIDs))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetBandwidth).HandlerFunc(httpTraceHdrs(server.GetBandwidth))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetMetacacheListing).HandlerFunc(httpTraceHdrs(server.GetMetacacheListingHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodUpdateMetacacheListing).HandlerFunc(httpTraceHdrs(server.UpdateMetacacheListingHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodMetacacheWrittenSince).HandlerFunc(httpTraceHdrs(server.MetacacheWrittenSinceHandler)).Queries(restQueries(peerRESTBucket, peerRESTMetacacheSince)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetPeerMetrics).HandlerFunc(httpTraceHdrs(server.GetPeerMetrics))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadTransitionTierConfig).HandlerFunc(httpTraceHdrs(server.LoadTransitionTierConfigHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSpeedtest).HandlerFunc(httpTraceHdrs(server.SpeedtestHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadSiteReplicationConfig).HandlerFunc(httpTraceHdrs(server.ReloadSiteReplicationConfigHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListNotifyStore).HandlerFunc(httpTraceHdrs(server.ListNotifyStoreHandler)).Queries(restQueries(peerRESTNotifyTarget)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReplayNotifyStore).HandlerFunc(httpTraceHdrs(server.ReplayNotifyStoreHandler)).Queries(restQueries(peerRESTNotifyTarget)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodPurgeNotifyStore).HandlerFunc(httpTraceHdrs(server.PurgeNotifyStoreHandler)).Queries(restQueries(peerRESTNotifyTarget)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReplaceDrive).HandlerFunc(httpTraceHdrs(server.ReplaceDriveHandler)).Queries(restQueries(peerRESTDrive)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodTierTransitionStatus).HandlerFunc(httpTraceHdrs(server.TierTransitionStatusHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodScannerControl).HandlerFunc(httpTraceHdrs(server.ScannerControlHandler)).Queries(restQueries(peerRESTScannerOp)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodScannerStatus).HandlerFunc(httpTraceHdrs(server.ScannerStatusHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodUsageAccounting).HandlerFunc(httpTraceHdrs(server.UsageAccountingHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDrain).HandlerFunc(httpTraceHdrs(server.DrainHandler)).Queries(restQueries(peerRESTDrainCancel)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDrainStatus).HandlerFunc(httpTraceHdrs(server.DrainStatusHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTMethodInternodeVersions).HandlerFunc(httpTraceHdrs(server.InternodeVersionsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadPoolMeta).HandlerFunc(httpTraceHdrs(server.ReloadPoolMetaHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodAddPool).HandlerFunc(httpTraceHdrs(server.AddPoolHandler)).Queries(restQueries(peerRESTPool)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodRemovePendingPool).HandlerFunc(httpTraceHdrs(server.RemovePendingPoolHandler)).Queries(restQueries(peerRESTPool)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodValidateConfig).HandlerFunc(httpTraceHdrs(server.ValidateConfigHandler))
 }
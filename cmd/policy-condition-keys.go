@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
+)
+
+// defaultRequestedRegion is the aws:RequestedRegion of requests to a
+// server without a region.
+const defaultRequestedRegion = "us-east-1"
+
+// existingObjectTagActions - actions for which the tags of the existing
+// object are the s3:ExistingObjectTag/<key> condition values.
+var existingObjectTagActions = set.CreateStringSet(
+	string(policy.GetObjectAction),
+	string(policy.GetObjectVersionAction),
+	string(policy.GetObjectTaggingAction),
+	string(policy.GetObjectVersionTaggingAction),
+	string(policy.PutObjectTaggingAction),
+	string(policy.PutObjectVersionTaggingAction),
+	string(policy.DeleteObjectTaggingAction),
+	string(policy.DeleteObjectVersionTaggingAction),
+	string(policy.GetObjectRetentionAction),
+	string(policy.PutObjectRetentionAction),
+	string(policy.GetObjectLegalHoldAction),
+	string(policy.PutObjectLegalHoldAction),
+)
+
+// getRequestedRegion returns the region in the credential scope of a
+// signature V4 request, the region of the server otherwise.
+func getRequestedRegion(r *http.Request) string {
+	credElement := "Credential=" + r.Form.Get(xhttp.AmzCredential)
+	if r.Form.Get(xhttp.AmzCredential) == "" {
+		v4Auth := strings.TrimPrefix(r.Header.Get(xhttp.Authorization), signV4Algorithm)
+		credElement = strings.TrimSpace(strings.Split(strings.TrimSpace(v4Auth), ",")[0])
+	}
+	if ch, s3Err := parseCredentialHeader(credElement, globalSite.Region, serviceS3); s3Err == ErrNone {
+		return ch.scope.region
+	}
+	if globalSite.Region != "" {
+		return globalSite.Region
+	}
+	return defaultRequestedRegion
+}
+
+// getRequestTagKeys returns the sorted keys of the object tags in the
+// request headers.
+func getRequestTagKeys(r *http.Request) []string {
+	tagging := r.Header.Get(xhttp.AmzObjectTagging)
+	if tagging == "" {
+		return nil
+	}
+	t, err := tags.ParseObjectTags(tagging)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(t.ToMap()))
+	for k := range t.ToMap() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// setRequestConditionValues sets the aws:RequestedRegion and aws:TagKeys
+// condition values. Values of these keys and of s3:ExistingObjectTag/<key>
+// copied from the request headers and query are dropped, conditions look
+// up the canonical header name first.
+func setRequestConditionValues(r *http.Request, args map[string][]string) {
+	for k := range args {
+		if strings.HasPrefix(k, condition.ExistingObjectTag.Name()+"/") {
+			delete(args, k)
+		}
+	}
+	delete(args, http.CanonicalHeaderKey(condition.AWSRequestedRegion.Name()))
+	delete(args, http.CanonicalHeaderKey(condition.AWSTagKeys.Name()))
+	args[condition.AWSRequestedRegion.Name()] = []string{getRequestedRegion(r)}
+	if keys := getRequestTagKeys(r); len(keys) > 0 {
+		args[condition.AWSTagKeys.Name()] = keys
+	} else {
+		delete(args, condition.AWSTagKeys.Name())
+	}
+}
+
+// setExistingObjectTagValues sets the tags of the existing object as
+// the s3:ExistingObjectTag/<key> condition values of object actions.
+func setExistingObjectTagValues(ctx context.Context, r *http.Request, args map[string][]string, action policy.Action, bucket, object string) {
+	if object == "" || !existingObjectTagActions.Contains(string(action)) {
+		return
+	}
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{
+		VersionID: r.Form.Get(xhttp.VersionID),
+	})
+	if err != nil || objInfo.UserTags == "" {
+		return
+	}
+	t, err := tags.ParseObjectTags(objInfo.UserTags)
+	if err != nil {
+		return
+	}
+	for k, v := range t.ToMap() {
+		args[condition.ExistingObjectTag.Name()+"/"+k] = []string{v}
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Kinds of policies whose statements are reported by a simulation.
+const (
+	simulatedBucketPolicy         = "bucket"
+	simulatedIdentityPolicy       = "identity"
+	simulatedSessionPolicy        = "session"
+	simulatedServiceAccountPolicy = "service-account"
+	simulatedBoundaryPolicy       = "boundary"
+)
+
+// policySimulationRequest - a request evaluated against the policies
+// without being sent, an empty access key simulates an anonymous
+// request.
+type policySimulationRequest struct {
+	AccessKey  string              `json:"accessKey,omitempty"`
+	Action     string              `json:"action"`
+	Bucket     string              `json:"bucket"`
+	Object     string              `json:"object,omitempty"`
+	Conditions map[string][]string `json:"conditions,omitempty"`
+}
+
+// policySimulationStatement - a statement which matched the simulated
+// request, whatever its effect.
+type policySimulationStatement struct {
+	Type      string      `json:"type"`
+	Policy    string      `json:"policy,omitempty"`
+	Statement interface{} `json:"statement"`
+}
+
+// policySimulationResult - the outcome of a simulated request.
+type policySimulationResult struct {
+	Allowed bool `json:"allowed"`
+	// Requests of the root user are allowed without any policy.
+	Owner             bool                        `json:"owner,omitempty"`
+	MatchedStatements []policySimulationStatement `json:"matchedStatements,omitempty"`
+}
+
+// validate checks the action is a bucket policy action for anonymous
+// requests, an IAM policy action otherwise.
+func (req policySimulationRequest) validate() error {
+	if req.Bucket == "" {
+		return errors.New("bucket must not be empty")
+	}
+	if req.AccessKey == "" {
+		if !policy.Action(req.Action).IsValid() {
+			return fmt.Errorf("invalid bucket policy action %s", req.Action)
+		}
+		return nil
+	}
+	if !iampolicy.Action(req.Action).IsValid() {
+		return fmt.Errorf("invalid action %s", req.Action)
+	}
+	return nil
+}
+
+// simulatePolicy evaluates the request with the live IAM and bucket
+// policy state and reports the statements matching it.
+func simulatePolicy(ctx context.Context, req policySimulationRequest) (res policySimulationResult, err error) {
+	if err = req.validate(); err != nil {
+		return res, err
+	}
+
+	conditions := map[string][]string{
+		"CurrentTime": {UTCNow().Format(time.RFC3339)},
+		"EpochTime":   {strconv.FormatInt(UTCNow().Unix(), 10)},
+	}
+
+	if req.AccessKey == "" {
+		conditions["principaltype"] = []string{"Anonymous"}
+		for k, v := range req.Conditions {
+			conditions[k] = v
+		}
+		args := policy.Args{
+			Action:          policy.Action(req.Action),
+			BucketName:      req.Bucket,
+			ObjectName:      req.Object,
+			ConditionValues: conditions,
+		}
+		res.Allowed = globalPolicySys.IsAllowed(args)
+		if p, err := globalPolicySys.Get(req.Bucket); err == nil {
+			for _, st := range p.Statements {
+				if st.IsAllowed(args) == (st.Effect == policy.Allow) {
+					res.MatchedStatements = append(res.MatchedStatements, policySimulationStatement{
+						Type:      simulatedBucketPolicy,
+						Statement: st,
+					})
+				}
+			}
+		}
+		return res, nil
+	}
+
+	cred, ok := globalIAMSys.GetUser(ctx, req.AccessKey)
+	owner := req.AccessKey == globalActiveCred.AccessKey
+	if owner {
+		cred, ok = globalActiveCred, true
+	}
+	if !ok {
+		return res, errNoSuchUser
+	}
+
+	var claims map[string]interface{}
+	if cred.SessionToken != "" {
+		if claims, err = getClaimsFromToken(cred.SessionToken); err != nil {
+			return res, err
+		}
+	}
+
+	principalType := "User"
+	switch {
+	case owner:
+		principalType = "Account"
+	case len(claims) > 0:
+		principalType = "AssumedRole"
+	}
+	conditions["principaltype"] = []string{principalType}
+	conditions["username"] = []string{req.AccessKey}
+	conditions["userid"] = []string{req.AccessKey}
+	for k, v := range claims {
+		if vStr, ok := v.(string); ok {
+			switch k {
+			case ldapUser:
+				conditions["user"] = []string{vStr}
+			case ldapUserN:
+				conditions["username"] = []string{vStr}
+			default:
+				conditions[k] = []string{vStr}
+			}
+		}
+	}
+	setPrincipalTagValues(conditions, claims)
+	for k, v := range req.Conditions {
+		conditions[k] = v
+	}
+
+	args := iampolicy.Args{
+		AccountName:     req.AccessKey,
+		Groups:          cred.Groups,
+		Action:          iampolicy.Action(req.Action),
+		BucketName:      req.Bucket,
+		ObjectName:      req.Object,
+		ConditionValues: conditions,
+		IsOwner:         owner,
+		Claims:          claims,
+	}
+	res.Allowed = globalIAMSys.IsAllowed(args)
+	res.Owner = owner
+	if owner {
+		return res, nil
+	}
+
+	match := func(kind, name string, p iampolicy.Policy) {
+		for _, st := range p.Statements {
+			if st.IsAllowed(args) == (st.Effect == policy.Allow) {
+				res.MatchedStatements = append(res.MatchedStatements, policySimulationStatement{
+					Type:      kind,
+					Policy:    name,
+					Statement: st,
+				})
+			}
+		}
+	}
+
+	for _, name := range simulatedPolicyNames(cred, claims) {
+		if p, err := globalIAMSys.InfoPolicy(name); err == nil {
+			match(simulatedIdentityPolicy, name, p)
+		}
+	}
+
+	if cred.IsServiceAccount() {
+		if _, p, err := globalIAMSys.GetServiceAccount(ctx, cred.AccessKey); err == nil && p != nil {
+			match(simulatedServiceAccountPolicy, "", *p)
+		}
+	} else if sp, ok := claims[iampolicy.SessionPolicyName].(string); ok {
+		if p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(sp))); err == nil {
+			match(simulatedSessionPolicy, "", *p)
+		}
+	}
+
+	for _, name := range []string{cred.AccessKey, cred.ParentUser, args.GetRoleArn()} {
+		if name == "" {
+			continue
+		}
+		boundary, err := globalIAMSys.GetPermissionsBoundary(name)
+		if err != nil {
+			continue
+		}
+		for _, policyName := range newMappedPolicy(boundary).toSlice() {
+			if p, err := globalIAMSys.InfoPolicy(policyName); err == nil {
+				match(simulatedBoundaryPolicy, policyName, p)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// simulatedPolicyNames returns the names of the policies attached to
+// the credentials, those of the parent user for service accounts and
+// temporary credentials.
+func simulatedPolicyNames(cred auth.Credentials, claims map[string]interface{}) []string {
+	user := cred.AccessKey
+	if cred.ParentUser != "" {
+		user = cred.ParentUser
+	}
+
+	if cred.IsTemp() && !cred.IsServiceAccount() && globalIAMSys.usersSysType != LDAPUsersSysType {
+		// OpenID credentials get their policies from the role or the
+		// policy claim of the token.
+		if roleArn, ok := claims[roleArnClaim].(string); ok && roleArn != "" {
+			rolePolicy, err := globalIAMSys.GetRolePolicy(roleArn)
+			if err != nil {
+				return nil
+			}
+			return newMappedPolicy(rolePolicy).toSlice()
+		}
+		policySet, _ := iampolicy.GetPoliciesFromClaims(claims, iamPolicyClaimNameOpenIDFor(claims))
+		return policySet.ToSlice()
+	}
+
+	names, err := globalIAMSys.PolicyDBGet(user, false, cred.Groups...)
+	if err != nil {
+		return nil
+	}
+	return names
+}
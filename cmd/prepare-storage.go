@@ -124,7 +124,7 @@ func isServerResolvable(endpoint Endpoint, timeout time.Duration) error {
 	var tlsConfig *tls.Config
 	if globalIsTLS {
 		tlsConfig = &tls.Config{
-			RootCAs: globalRootCAs,
+			RootCAs: getInternodeRootCAs(),
 		}
 	}
 
@@ -0,0 +1,486 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	xnet "github.com/minio/pkg/net"
+)
+
+// States of a rolling update and of its nodes.
+const (
+	rollingUpdatePending    = "pending"
+	rollingUpdateDraining   = "draining"
+	rollingUpdateUpdating   = "updating"
+	rollingUpdateRestarting = "restarting"
+	rollingUpdateVerifying  = "verifying"
+	rollingUpdateUpdated    = "updated"
+	rollingUpdateRunning    = "running"
+	rollingUpdateCompleted  = "completed"
+	rollingUpdateFailed     = "failed"
+)
+
+const (
+	rollingUpdateFile = "rolling-update.json"
+
+	// Time given to a node to drain before it is restarted anyway.
+	rollingUpdateDrainTimeout = 2 * time.Minute
+
+	// Time given to a restarted node to be back online with the
+	// cluster healthy before the rolling update fails.
+	rollingUpdateVerifyTimeout = 5 * time.Minute
+
+	// Interval at which a node is polled while waiting for it.
+	rollingUpdatePollInterval = 2 * time.Second
+)
+
+var (
+	// error returned when a rolling update is started while another one
+	// coordinated by the same node is running.
+	errRollingUpdateInProgress = AdminError{
+		Code:       "XMinioAdminRollingUpdateInProgress",
+		Message:    "A rolling update is already in progress",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when reading the progress of a rolling update
+	// before any was started.
+	errRollingUpdateNotStarted = AdminError{
+		Code:       "XMinioAdminRollingUpdateNotStarted",
+		Message:    "No rolling update was started",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// rollingUpdatePreconditionErr returns the error of a rolling update
+// refused because the cluster is not in a state to be updated.
+func rollingUpdatePreconditionErr(err error) AdminError {
+	return AdminError{
+		Code:       "XMinioAdminRollingUpdatePreconditionFailed",
+		Message:    err.Error(),
+		StatusCode: http.StatusPreconditionFailed,
+	}
+}
+
+// internodeVersions - the versions of the internode APIs of a server,
+// servers can only talk to each other when all of them match.
+type internodeVersions struct {
+	Version   string    `json:"version"`
+	Boot      time.Time `json:"boot"`
+	Peer      string    `json:"peer"`
+	Storage   string    `json:"storage"`
+	Lock      string    `json:"lock"`
+	Bootstrap string    `json:"bootstrap"`
+}
+
+// localInternodeVersions returns the internode API versions of this server.
+func localInternodeVersions() internodeVersions {
+	return internodeVersions{
+		Version:   Version,
+		Boot:      globalBootTime,
+		Peer:      peerRESTVersion,
+		Storage:   storageRESTVersion,
+		Lock:      lockRESTVersion,
+		Bootstrap: bootstrapRESTVersion,
+	}
+}
+
+// incompatible returns the internode APIs whose versions differ, none
+// if a server running v can join a cluster running o.
+func (v internodeVersions) incompatible(o internodeVersions) (apis []string) {
+	for _, api := range []struct {
+		name string
+		v, o string
+	}{
+		{"peer", v.Peer, o.Peer},
+		{"storage", v.Storage, o.Storage},
+		{"lock", v.Lock, o.Lock},
+		{"bootstrap", v.Bootstrap, o.Bootstrap},
+	} {
+		if api.v != api.o {
+			apis = append(apis, fmt.Sprintf("%s API %s, expected %s", api.name, api.v, api.o))
+		}
+	}
+	return apis
+}
+
+// internodeVersionsCmd prints the internode API versions of the binary,
+// the coordinator of a rolling update runs the binary of the new release
+// with it before updating any node.
+var internodeVersionsCmd = cli.Command{
+	Name:   "internode-versions",
+	Usage:  "print the internode API versions",
+	Hidden: true,
+	Action: func(ctx *cli.Context) error {
+		return json.NewEncoder(os.Stdout).Encode(localInternodeVersions())
+	},
+}
+
+// releaseInternodeVersions downloads the binary of the release to update
+// to, verifies its checksum and runs it to get its internode API versions.
+// Releases which do not report them cannot be rolled out.
+func releaseInternodeVersions(ctx context.Context, info serverUpdateInfo, mode string) (v internodeVersions, err error) {
+	var reader io.ReadCloser
+	if info.URL.Scheme == "https" || info.URL.Scheme == "http" {
+		reader, err = getUpdateReaderFromURL(info.URL, getUpdateTransport(30*time.Second), mode)
+	} else {
+		reader, err = getUpdateReaderFromFile(info.URL)
+	}
+	if err != nil {
+		return v, err
+	}
+	defer reader.Close()
+
+	f, err := ioutil.TempFile("", "minio-release-")
+	if err != nil {
+		return v, err
+	}
+	defer os.Remove(f.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), reader)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return v, err
+	}
+	if !bytes.Equal(h.Sum(nil), info.Sha256Sum) {
+		return v, fmt.Errorf("checksum mismatch for release %s", info.ReleaseInfo)
+	}
+	if err = os.Chmod(f.Name(), 0o700); err != nil {
+		return v, err
+	}
+
+	out, err := exec.CommandContext(ctx, f.Name(), internodeVersionsCmd.Name).Output()
+	if err != nil {
+		return v, fmt.Errorf("release %s does not report its internode API versions: %w", info.ReleaseInfo, err)
+	}
+	err = json.Unmarshal(out, &v)
+	return v, err
+}
+
+// getInternodeVersions returns the internode API versions of the server
+// at host. The call is not versioned itself, so that it succeeds with
+// servers running any release.
+func getInternodeVersions(ctx context.Context, host *xnet.Host) (v internodeVersions, err error) {
+	scheme := "http"
+	if globalIsTLS {
+		scheme = "https"
+	}
+	restClient := newInternodeRESTClient(&url.URL{
+		Scheme: scheme,
+		Host:   host.String(),
+		Path:   peerRESTPrefix,
+	})
+
+	respBody, err := restClient.Call(ctx, peerRESTMethodInternodeVersions, nil, nil, -1)
+	if err != nil {
+		return v, err
+	}
+	defer xhttp.DrainBody(respBody)
+	err = json.NewDecoder(respBody).Decode(&v)
+	return v, err
+}
+
+// RollingUpdateNode - the progress of the update of a node.
+type RollingUpdateNode struct {
+	Node     string    `json:"node"`
+	State    string    `json:"state"`
+	Version  string    `json:"version,omitempty"`
+	Started  time.Time `json:"started,omitempty"`
+	Finished time.Time `json:"finished,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// RollingUpdateStatus - the progress of a rolling update, which updates
+// one node at a time.
+type RollingUpdateStatus struct {
+	State          string              `json:"state"`
+	CurrentVersion string              `json:"currentVersion"`
+	UpdatedVersion string              `json:"updatedVersion"`
+	Started        time.Time           `json:"started"`
+	Finished       time.Time           `json:"finished,omitempty"`
+	Error          string              `json:"error,omitempty"`
+	Nodes          []RollingUpdateNode `json:"nodes"`
+}
+
+// rollingUpdate runs the rolling update coordinated by this node.
+type rollingUpdate struct {
+	mu      sync.Mutex
+	running bool
+	status  RollingUpdateStatus
+}
+
+var globalRollingUpdate = &rollingUpdate{}
+
+// rollingUpdatePath returns the path in the meta bucket where the
+// progress of the last rolling update is saved, so that it can be read
+// from any node, including once the coordinator restarted.
+func rollingUpdatePath() string {
+	return path.Join(bucketMetaPrefix, rollingUpdateFile)
+}
+
+// loadRollingUpdateStatus returns the progress of the last rolling update.
+func loadRollingUpdateStatus(ctx context.Context, objAPI ObjectLayer) (s RollingUpdateStatus, err error) {
+	data, err := readConfig(ctx, objAPI, rollingUpdatePath())
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// start checks that all nodes are online and run compatible versions,
+// that the cluster is healthy and that the release info can run along
+// with the current one, then updates the nodes to info in the
+// background, one at a time and this node last.
+func (ru *rollingUpdate) start(ctx context.Context, objAPI ObjectLayer, info serverUpdateInfo, mode string) (RollingUpdateStatus, error) {
+	ru.mu.Lock()
+	defer ru.mu.Unlock()
+	if ru.running {
+		return ru.status, errRollingUpdateInProgress
+	}
+
+	local := localInternodeVersions()
+	var peers []*peerRESTClient
+	for _, client := range globalNotificationSys.peerClients {
+		if client == nil {
+			continue
+		}
+		v, err := getInternodeVersions(ctx, client.host)
+		if err != nil {
+			return ru.status, rollingUpdatePreconditionErr(fmt.Errorf("node %s is not reachable: %w", client.host, err))
+		}
+		if apis := v.incompatible(local); len(apis) > 0 {
+			return ru.status, rollingUpdatePreconditionErr(fmt.Errorf("node %s runs %v", client.host, apis))
+		}
+		peers = append(peers, client)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].host.String() < peers[j].host.String()
+	})
+	if !objAPI.Health(ctx, HealthOptions{}).Healthy {
+		return ru.status, rollingUpdatePreconditionErr(errors.New("cluster is not healthy"))
+	}
+
+	// The cluster cannot run a mix of both releases, refuse releases
+	// which must be applied to all nodes at once before updating any.
+	release, err := releaseInternodeVersions(ctx, info, mode)
+	if err != nil {
+		return ru.status, rollingUpdatePreconditionErr(err)
+	}
+	if apis := release.incompatible(local); len(apis) > 0 {
+		return ru.status, rollingUpdatePreconditionErr(fmt.Errorf("release %s is incompatible with a rolling update, it runs %v", info.ReleaseInfo, apis))
+	}
+
+	s := RollingUpdateStatus{
+		State:          rollingUpdateRunning,
+		CurrentVersion: Version,
+		UpdatedVersion: info.Time.Format(minioReleaseTagTimeLayout),
+		Started:        UTCNow(),
+	}
+	for _, client := range peers {
+		s.Nodes = append(s.Nodes, RollingUpdateNode{Node: client.host.String(), State: rollingUpdatePending})
+	}
+	s.Nodes = append(s.Nodes, RollingUpdateNode{Node: globalLocalNodeName, State: rollingUpdatePending})
+	data, err := json.Marshal(s)
+	if err != nil {
+		return s, err
+	}
+	if err = saveConfig(ctx, objAPI, rollingUpdatePath(), data); err != nil {
+		return s, err
+	}
+
+	ru.status = s
+
+	ru.running = true
+	go ru.run(GlobalContext, objAPI, peers, info, mode)
+	return s, nil
+}
+
+// setNode updates the progress of the node at index i and saves it.
+func (ru *rollingUpdate) setNode(ctx context.Context, objAPI ObjectLayer, i int, state string, err error) {
+	ru.mu.Lock()
+	n := &ru.status.Nodes[i]
+	n.State = state
+	switch state {
+	case rollingUpdateDraining:
+		n.Started = UTCNow()
+	case rollingUpdateUpdated, rollingUpdateFailed:
+		n.Finished = UTCNow()
+	}
+	if err != nil {
+		n.Error = err.Error()
+		ru.status.State = rollingUpdateFailed
+		ru.status.Error = fmt.Sprintf("update of node %s failed, remaining nodes were not updated", n.Node)
+		ru.status.Finished = UTCNow()
+	} else if i == len(ru.status.Nodes)-1 && state == rollingUpdateUpdated {
+		ru.status.State = rollingUpdateCompleted
+		ru.status.Finished = UTCNow()
+	}
+	ru.mu.Unlock()
+
+	logger.LogIf(ctx, ru.save(ctx, objAPI))
+}
+
+// save persists the progress of the rolling update.
+func (ru *rollingUpdate) save(ctx context.Context, objAPI ObjectLayer) error {
+	ru.mu.Lock()
+	data, err := json.Marshal(ru.status)
+	ru.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, rollingUpdatePath(), data)
+}
+
+// run updates the peers one at a time, stopping at the first one which
+// fails, and then this node.
+func (ru *rollingUpdate) run(ctx context.Context, objAPI ObjectLayer, peers []*peerRESTClient, info serverUpdateInfo, mode string) {
+	defer func() {
+		ru.mu.Lock()
+		ru.running = false
+		ru.mu.Unlock()
+	}()
+
+	for i, client := range peers {
+		state, err := ru.updatePeer(ctx, objAPI, i, client, info)
+		if err != nil {
+			ru.setNode(ctx, objAPI, i, state, err)
+			logger.LogIf(ctx, fmt.Errorf("rolling update of %s failed: %w", client.host, err))
+			return
+		}
+		ru.setNode(ctx, objAPI, i, rollingUpdateUpdated, nil)
+	}
+
+	self := len(peers)
+	ru.setNode(ctx, objAPI, self, rollingUpdateDraining, nil)
+	globalNodeDrain.start()
+	waitForDrain(ctx, func(ctx context.Context) (NodeDrainStatus, error) {
+		return globalNodeDrain.status(), nil
+	})
+
+	ru.setNode(ctx, objAPI, self, rollingUpdateUpdating, nil)
+	if _, err := updateServer(info.URL, info.Sha256Sum, info.Time, info.ReleaseInfo, mode); err != nil {
+		globalNodeDrain.stop()
+		ru.setNode(ctx, objAPI, self, rollingUpdateFailed, err)
+		logger.LogIf(ctx, fmt.Errorf("rolling update of %s failed: %w", globalLocalNodeName, err))
+		return
+	}
+
+	// This node cannot verify itself once restarted, its peers were
+	// verified to run the new release with the same internode APIs.
+	ru.setNode(ctx, objAPI, self, rollingUpdateUpdated, nil)
+	globalServiceSignalCh <- serviceRestart
+}
+
+// updatePeer drains, updates and restarts the peer, then waits for it
+// to be back with compatible internode APIs and for the cluster to be
+// healthy. It returns the state in which the update failed.
+func (ru *rollingUpdate) updatePeer(ctx context.Context, objAPI ObjectLayer, i int, client *peerRESTClient, info serverUpdateInfo) (string, error) {
+	before, err := getInternodeVersions(ctx, client.host)
+	if err != nil {
+		return rollingUpdatePending, err
+	}
+
+	ru.setNode(ctx, objAPI, i, rollingUpdateDraining, nil)
+	if err = client.Drain(ctx, false); err != nil {
+		return rollingUpdateDraining, err
+	}
+	waitForDrain(ctx, client.DrainStatus)
+
+	ru.setNode(ctx, objAPI, i, rollingUpdateUpdating, nil)
+	if err = client.ServerUpdate(ctx, info.URL, info.Sha256Sum, info.Time, info.ReleaseInfo); err != nil {
+		logger.LogIf(ctx, client.Drain(ctx, true))
+		return rollingUpdateUpdating, err
+	}
+
+	ru.setNode(ctx, objAPI, i, rollingUpdateRestarting, nil)
+	if err = client.SignalService(serviceRestart); err != nil && !isNetworkError(err) {
+		return rollingUpdateRestarting, err
+	}
+
+	ru.setNode(ctx, objAPI, i, rollingUpdateVerifying, nil)
+	local := localInternodeVersions()
+	vctx, cancel := context.WithTimeout(ctx, rollingUpdateVerifyTimeout)
+	defer cancel()
+	t := time.NewTimer(rollingUpdatePollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-vctx.Done():
+			return rollingUpdateVerifying, fmt.Errorf("node did not come back healthy in %s", rollingUpdateVerifyTimeout)
+		case <-t.C:
+		}
+
+		after, err := getInternodeVersions(vctx, client.host)
+		if err == nil && after.Boot.After(before.Boot) {
+			if apis := after.incompatible(local); len(apis) > 0 {
+				// The release was checked before updating any node,
+				// the node runs another one.
+				return rollingUpdateVerifying, fmt.Errorf("release %s is incompatible with a rolling update, it runs %v", after.Version, apis)
+			}
+			ru.mu.Lock()
+			ru.status.Nodes[i].Version = after.Version
+			ru.mu.Unlock()
+			if objAPI.Health(vctx, HealthOptions{}).Healthy {
+				return rollingUpdateUpdated, nil
+			}
+		}
+		t.Reset(rollingUpdatePollInterval)
+	}
+}
+
+// waitForDrain waits for a node to be drained, at most for
+// rollingUpdateDrainTimeout after which the node is restarted anyway.
+func waitForDrain(ctx context.Context, status func(ctx context.Context) (NodeDrainStatus, error)) {
+	ctx, cancel := context.WithTimeout(ctx, rollingUpdateDrainTimeout)
+	defer cancel()
+	t := time.NewTimer(rollingUpdatePollInterval)
+	defer t.Stop()
+	for {
+		if s, err := status(ctx); err == nil && s.State == nodeDrainDrained {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			t.Reset(rollingUpdatePollInterval)
+		}
+	}
+}
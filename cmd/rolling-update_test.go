@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestInternodeVersionsIncompatible(t *testing.T) {
+	local := localInternodeVersions()
+
+	same := local
+	same.Version = "2021-11-05T09:16:26Z"
+	same.Boot = local.Boot.Add(time.Hour)
+	if apis := same.incompatible(local); len(apis) != 0 {
+		t.Fatalf("Expected a new release with the same internode APIs to be compatible, got %v", apis)
+	}
+
+	newer := local
+	newer.Peer = "v1000"
+	newer.Lock = "v1000"
+	if apis := newer.incompatible(local); len(apis) != 2 {
+		t.Fatalf("Expected the peer and lock APIs to be incompatible, got %v", apis)
+	}
+}
+
+func TestWaitForDrain(t *testing.T) {
+	var calls int
+	start := time.Now()
+	waitForDrain(context.Background(), func(ctx context.Context) (NodeDrainStatus, error) {
+		calls++
+		return NodeDrainStatus{State: nodeDrainDrained}, nil
+	})
+	if calls != 1 || time.Since(start) >= rollingUpdatePollInterval {
+		t.Fatalf("Expected a drained node to be returned immediately, %d calls", calls)
+	}
+
+	// A canceled wait returns without the node being drained.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	waitForDrain(ctx, func(ctx context.Context) (NodeDrainStatus, error) {
+		return NodeDrainStatus{State: nodeDrainDraining}, nil
+	})
+}
+
+func TestReleaseInternodeVersions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake release is a shell script")
+	}
+
+	release := func(script string) serverUpdateInfo {
+		binary := filepath.Join(t.TempDir(), "minio")
+		if err := ioutil.WriteFile(binary, []byte(script), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256([]byte(script))
+		return serverUpdateInfo{
+			URL:         &url.URL{Scheme: "file", Path: binary},
+			Sha256Sum:   sum[:],
+			ReleaseInfo: "minio.RELEASE.2021-11-05T09-16-26Z",
+		}
+	}
+	reporting := func(v internodeVersions) serverUpdateInfo {
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return release(fmt.Sprintf("#!/bin/sh\n[ \"$1\" = %s ] || exit 1\necho '%s'\n", internodeVersionsCmd.Name, out))
+	}
+
+	local := localInternodeVersions()
+	newer := local
+	newer.Version = "2021-11-05T09:16:26Z"
+	newer.Storage = "v1000"
+	v, err := releaseInternodeVersions(context.Background(), reporting(newer), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Version != newer.Version || len(v.incompatible(local)) != 1 {
+		t.Fatalf("Expected the storage API of release %s to be incompatible, got %v", v.Version, v.incompatible(local))
+	}
+
+	// The checksum of the release is verified before running it.
+	info := reporting(local)
+	info.Sha256Sum[0]++
+	if _, err = releaseInternodeVersions(context.Background(), info, ""); err == nil {
+		t.Fatal("Expected a release with a checksum mismatch to be refused")
+	}
+
+	// Releases which do not report their internode API versions are refused.
+	if _, err = releaseInternodeVersions(context.Background(), release("#!/bin/sh\nexit 1\n"), ""); err == nil {
+		t.Fatal("Expected a release which does not report its internode API versions to be refused")
+	}
+}
@@ -197,6 +197,9 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	// (non-)minio process is listening on IPv4 of given port.
 	// To avoid this error situation we check for port availability.
 	logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the server")
+	if globalInternodeGRPCPort != "" {
+		logger.FatalIf(checkPortAvailability(globalMinioHost, globalInternodeGRPCPort), "Unable to start the internode gRPC server")
+	}
 
 	globalIsErasure = (setupType == ErasureSetupType)
 	globalIsDistErasure = (setupType == DistErasureSetupType)
@@ -256,6 +259,9 @@ func newAllSubsystems() {
 	// Create new bucket quota subsystem
 	globalBucketQuotaSys = NewBucketQuotaSys()
 
+	// Create new bucket QoS subsystem
+	globalBucketQoSSys = NewBucketQoSSys()
+
 	// Create new bucket versioning subsystem
 	if globalBucketVersioningSys == nil {
 		globalBucketVersioningSys = NewBucketVersioningSys()
@@ -505,6 +511,16 @@ func serverMain(ctx *cli.Context) {
 
 	setHTTPServer(httpServer)
 
+	if globalIsDistErasure && globalIsTLS {
+		go watchInternodeRootCAs(GlobalContext)
+	}
+
+	if globalIsDistErasure && globalInternodeGRPCPort != "" {
+		go func() {
+			globalHTTPServerErrorCh <- startInternodeGRPCServer(handler, getCert)
+		}()
+	}
+
 	if globalIsDistErasure && globalEndpoints.FirstLocal() {
 		for {
 			// Additionally in distributed setup, validate the setup and configuration.
@@ -578,10 +594,23 @@ func serverMain(ctx *cli.Context) {
 	}
 
 	initDataScanner(GlobalContext, newObject)
+	initBucketAccessLogs(GlobalContext)
+	initUsageAccounting(GlobalContext, newObject)
 
 	if globalIsErasure { // to be done after config init
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
+		initQuorumMonitor(GlobalContext, newObject)
+		initAddedPools(GlobalContext, newObject)
+		initRebalance(GlobalContext, newObject)
+		initDecommission(GlobalContext, newObject)
+		initConsistencyProbes(GlobalContext, newObject)
+		initKMSRotation(GlobalContext, newObject)
+		initObjectLockAudit(GlobalContext, newObject)
+		initReplicationFsck(GlobalContext, newObject)
+		initDriveLatencyMonitor(GlobalContext, newObject)
+		initBucketInventory(GlobalContext, newObject)
+		initBatchJobs(GlobalContext, newObject)
 		globalTierJournal, err = initTierDeletionJournal(GlobalContext)
 		if err != nil {
 			logger.FatalIf(err, "Unable to initialize remote tier pending deletes journal")
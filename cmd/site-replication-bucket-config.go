@@ -0,0 +1,376 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Bucket metadata types replicated to peer clusters in addition to the
+// types known to madmin.
+const (
+	srBucketMetaTypeNotificationConfig = "notification-config"
+	srBucketMetaTypeLifecycleConfig    = "lifecycle-config"
+)
+
+// srBucketMeta is a bucket metadata change replicated to peer clusters,
+// it extends madmin.SRBucketMeta with the configs madmin does not know.
+type srBucketMeta struct {
+	madmin.SRBucketMeta
+
+	// Notification and lifecycle configs are sent as base64 encoded
+	// xml bytes, nil to delete the config.
+	NotificationConfig *string `json:"notificationConfig,omitempty"`
+	LifecycleConfig    *string `json:"lifecycleConfig,omitempty"`
+}
+
+// BucketConfigHook - called when a bucket notification or lifecycle
+// config changes and needs to be replicated to peer clusters.
+func (c *SiteReplicationSys) BucketConfigHook(ctx context.Context, item srBucketMeta) error {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	cErr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+		resp, err := c.peerAdminRequest(ctx, d, http.MethodPut, "/site-replication/peer/bucket-meta", nil, data)
+		if err == nil {
+			resp.Body.Close()
+		}
+		logger.LogIf(ctx, c.annotatePeerErr(p.Name, "SRInternalReplicateBucketMeta", err))
+		return err
+	})
+	return cErr.summaryErr
+}
+
+// PeerBucketNotificationConfigHandler - copies/deletes notification
+// config to local cluster.
+func (c *SiteReplicationSys) PeerBucketNotificationConfigHandler(ctx context.Context, bucket string, notificationConfig *string) error {
+	var configData []byte
+	if notificationConfig != nil {
+		data, err := base64.StdEncoding.DecodeString(*notificationConfig)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		// Validate the config against the targets of this cluster.
+		if _, err = event.ParseConfig(bytes.NewReader(data), globalSite.Region, globalNotificationSys.targetList); err != nil {
+			return wrapSRErr(err)
+		}
+		configData = data
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketNotificationConfig, configData); err != nil {
+		return wrapSRErr(err)
+	}
+	config, err := globalBucketMetadataSys.GetNotificationConfig(bucket)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	globalNotificationSys.AddRulesMap(bucket, config.ToRulesMap())
+	return nil
+}
+
+// PeerBucketLifecycleConfigHandler - copies/deletes lifecycle config to
+// local cluster.
+func (c *SiteReplicationSys) PeerBucketLifecycleConfigHandler(ctx context.Context, bucket string, lifecycleConfig *string) error {
+	if lifecycleConfig != nil {
+		configData, err := base64.StdEncoding.DecodeString(*lifecycleConfig)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader(configData))
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		if err = lc.Validate(); err != nil {
+			return wrapSRErr(err)
+		}
+		if err = globalBucketMetadataSys.Update(bucket, bucketLifecycleConfig, configData); err != nil {
+			return wrapSRErr(err)
+		}
+		return nil
+	}
+
+	// Delete the lifecycle config
+	if err := globalBucketMetadataSys.Update(bucket, bucketLifecycleConfig, nil); err != nil {
+		return wrapSRErr(err)
+	}
+	return nil
+}
+
+// syncBucketConfigsToPeers replicates the notification and lifecycle
+// configs of bucket to all peers.
+func (c *SiteReplicationSys) syncBucketConfigsToPeers(ctx context.Context, bucket string) SRError {
+	meta, err := globalBucketMetadataSys.GetConfig(bucket)
+	if err != nil {
+		return errSRBackendIssue(err)
+	}
+	if len(meta.NotificationConfigXML) > 0 {
+		cfgStr := base64.StdEncoding.EncodeToString(meta.NotificationConfigXML)
+		err = c.BucketConfigHook(ctx, srBucketMeta{
+			SRBucketMeta: madmin.SRBucketMeta{
+				Type:   srBucketMetaTypeNotificationConfig,
+				Bucket: bucket,
+			},
+			NotificationConfig: &cfgStr,
+		})
+		if err != nil {
+			return errSRBucketMetaError(err)
+		}
+	}
+	if len(meta.LifecycleConfigXML) > 0 {
+		cfgStr := base64.StdEncoding.EncodeToString(meta.LifecycleConfigXML)
+		err = c.BucketConfigHook(ctx, srBucketMeta{
+			SRBucketMeta: madmin.SRBucketMeta{
+				Type:   srBucketMetaTypeLifecycleConfig,
+				Bucket: bucket,
+			},
+			LifecycleConfig: &cfgStr,
+		})
+		if err != nil {
+			return errSRBucketMetaError(err)
+		}
+	}
+	return SRError{}
+}
+
+// peerAdminRequest sends an admin API request to a peer cluster, signed
+// with the site replicator credentials. NOTE: ensure to take at least a
+// read lock on SiteReplicationSys before calling this.
+func (c *SiteReplicationSys) peerAdminRequest(ctx context.Context, deploymentID, method, path string, query url.Values, body []byte) (*http.Response, error) {
+	creds, err := c.getPeerCreds()
+	if err != nil {
+		return nil, err
+	}
+	peer, ok := c.state.Peers[deploymentID]
+	if !ok {
+		return nil, errSRPeerNotFound
+	}
+	u, err := url.Parse(peer.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = adminPathPrefix + adminAPIVersionPrefix + path
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	req = signer.SignV4(*req, creds.AccessKey, creds.SecretKey, "", "")
+
+	client := &http.Client{Transport: newRemoteClusterHTTPTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var errResp madmin.ErrorResponse
+		data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 100<<10))
+		if err = json.Unmarshal(data, &errResp); err != nil || errResp.Code == "" {
+			return nil, fmt.Errorf("unexpected response from %s: %s", peer.Endpoint, resp.Status)
+		}
+		return nil, errResp
+	}
+	return resp, nil
+}
+
+// Bucket config types compared by the site replication status.
+var srBucketConfigTypes = []string{
+	madmin.SRBucketMetaTypePolicy,
+	madmin.SRBucketMetaTypeTags,
+	madmin.SRBucketMetaTypeObjectLockConfig,
+	madmin.SRBucketMetaTypeSSEConfig,
+	srBucketMetaTypeNotificationConfig,
+	srBucketMetaTypeLifecycleConfig,
+}
+
+// getBucketConfigChecksums returns the checksums of the replicated
+// configs of bucket, or of all buckets if bucket is empty. A config
+// which is not set has an empty checksum.
+func getBucketConfigChecksums(ctx context.Context, objAPI ObjectLayer, bucket string) (map[string]map[string]string, error) {
+	buckets := []string{bucket}
+	if bucket == "" {
+		bucketsInfo, err := objAPI.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		buckets = buckets[:0]
+		for _, bi := range bucketsInfo {
+			buckets = append(buckets, bi.Name)
+		}
+	} else if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, err
+	}
+
+	checksum := func(data []byte) string {
+		if len(data) == 0 {
+			return ""
+		}
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+	checksums := make(map[string]map[string]string, len(buckets))
+	for _, bucket := range buckets {
+		meta, err := globalBucketMetadataSys.GetConfig(bucket)
+		if err != nil {
+			return nil, err
+		}
+		checksums[bucket] = map[string]string{
+			madmin.SRBucketMetaTypePolicy:           checksum(meta.PolicyConfigJSON),
+			madmin.SRBucketMetaTypeTags:             checksum(meta.TaggingConfigXML),
+			madmin.SRBucketMetaTypeObjectLockConfig: checksum(meta.ObjectLockConfigXML),
+			madmin.SRBucketMetaTypeSSEConfig:        checksum(meta.EncryptionConfigXML),
+			srBucketMetaTypeNotificationConfig:      checksum(meta.NotificationConfigXML),
+			srBucketMetaTypeLifecycleConfig:         checksum(meta.LifecycleConfigXML),
+		}
+	}
+	return checksums, nil
+}
+
+// SRBucketConfigStatus is the status of a bucket config on all sites.
+type SRBucketConfigStatus struct {
+	// Checksums of the config by deployment ID, empty if the config is
+	// not set on a site. Sites which do not have the bucket are absent.
+	Checksums map[string]string `json:"checksums"`
+	InSync    bool              `json:"inSync"`
+}
+
+// SRStatusInfo is the site replication status returned by the
+// site replication status admin API.
+type SRStatusInfo struct {
+	Enabled bool `json:"enabled"`
+	// Sites maps the deployment IDs of the sites to their names.
+	Sites map[string]string `json:"sites,omitempty"`
+	// Buckets maps bucket names to the status of their configs.
+	Buckets map[string]map[string]SRBucketConfigStatus `json:"buckets,omitempty"`
+	// Errors maps the deployment IDs of the sites whose configs
+	// could not be read to the error.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// GetStatus compares the bucket configs of bucket, or of all buckets if
+// bucket is empty, across all sites.
+func (c *SiteReplicationSys) GetStatus(ctx context.Context, objAPI ObjectLayer, bucket string) (info SRStatusInfo, err error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return info, nil
+	}
+
+	info.Enabled = true
+	info.Sites = make(map[string]string, len(c.state.Peers))
+	sitesChecksums := make(map[string]map[string]map[string]string, len(c.state.Peers))
+	for d, p := range c.state.Peers {
+		info.Sites[d] = p.Name
+		if d == globalDeploymentID {
+			sitesChecksums[d], err = getBucketConfigChecksums(ctx, objAPI, bucket)
+			if err != nil {
+				return info, err
+			}
+			continue
+		}
+		query := url.Values{}
+		if bucket != "" {
+			query.Set("bucket", bucket)
+		}
+		checksums, err := c.getPeerBucketConfigChecksums(ctx, d, query)
+		if err != nil {
+			if info.Errors == nil {
+				info.Errors = make(map[string]string)
+			}
+			info.Errors[d] = err.Error()
+			continue
+		}
+		sitesChecksums[d] = checksums
+	}
+
+	info.Buckets = getBucketConfigStatuses(sitesChecksums)
+	return info, nil
+}
+
+// getBucketConfigStatuses compares the bucket config checksums of all
+// sites, by deployment ID. A config is in sync if all sites have the
+// bucket and the same checksum.
+func getBucketConfigStatuses(sitesChecksums map[string]map[string]map[string]string) map[string]map[string]SRBucketConfigStatus {
+	statuses := make(map[string]map[string]SRBucketConfigStatus)
+	for d, checksums := range sitesChecksums {
+		for bucket, bucketChecksums := range checksums {
+			if _, ok := statuses[bucket]; !ok {
+				statuses[bucket] = make(map[string]SRBucketConfigStatus, len(srBucketConfigTypes))
+				for _, t := range srBucketConfigTypes {
+					statuses[bucket][t] = SRBucketConfigStatus{Checksums: make(map[string]string)}
+				}
+			}
+			for _, t := range srBucketConfigTypes {
+				statuses[bucket][t].Checksums[d] = bucketChecksums[t]
+			}
+		}
+	}
+	for bucket, configs := range statuses {
+		for t, status := range configs {
+			sums := set.NewStringSet()
+			for _, sum := range status.Checksums {
+				sums.Add(sum)
+			}
+			status.InSync = len(status.Checksums) == len(sitesChecksums) && len(sums) == 1
+			statuses[bucket][t] = status
+		}
+	}
+	return statuses
+}
+
+// getPeerBucketConfigChecksums returns the bucket config checksums of a
+// peer cluster.
+func (c *SiteReplicationSys) getPeerBucketConfigChecksums(ctx context.Context, deploymentID string, query url.Values) (map[string]map[string]string, error) {
+	resp, err := c.peerAdminRequest(ctx, deploymentID, http.MethodGet, "/site-replication/peer/bucket-checksums", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var checksums map[string]map[string]string
+	if err = json.NewDecoder(resp.Body).Decode(&checksums); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
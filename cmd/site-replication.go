@@ -1389,6 +1389,11 @@ func (c *SiteReplicationSys) syncLocalToPeers(ctx context.Context) SRError {
 				return errSRBucketMetaError(err)
 			}
 		}
+
+		// Replicate existing bucket notification and lifecycle configs.
+		if serr := c.syncBucketConfigsToPeers(ctx, bucket); serr.Cause != nil {
+			return serr
+		}
 	}
 
 	{
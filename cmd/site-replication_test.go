@@ -64,3 +64,36 @@ func TestGetMissingSiteNames(t *testing.T) {
 		}
 	}
 }
+
+func TestGetBucketConfigStatuses(t *testing.T) {
+	checksums := func(lifecycle string) map[string]string {
+		return map[string]string{
+			madmin.SRBucketMetaTypePolicy:   "a1",
+			srBucketMetaTypeLifecycleConfig: lifecycle,
+		}
+	}
+	statuses := getBucketConfigStatuses(map[string]map[string]map[string]string{
+		"dep1": {"bucket1": checksums("b1"), "bucket2": checksums("")},
+		"dep2": {"bucket1": checksums("b2"), "bucket2": checksums("")},
+		"dep3": {"bucket1": checksums("b1")},
+	})
+	testCases := []struct {
+		bucket, config string
+		inSync         bool
+	}{
+		{"bucket1", madmin.SRBucketMetaTypePolicy, true},
+		{"bucket1", madmin.SRBucketMetaTypeTags, true},
+		{"bucket1", srBucketMetaTypeLifecycleConfig, false},
+		// bucket2 is missing on dep3.
+		{"bucket2", madmin.SRBucketMetaTypePolicy, false},
+	}
+	for i, tc := range testCases {
+		status := statuses[tc.bucket][tc.config]
+		if status.InSync != tc.inSync {
+			t.Errorf("Test %d: expected in sync %t, got %t (%v)", i+1, tc.inSync, status.InSync, status.Checksums)
+		}
+	}
+	if n := len(statuses["bucket2"][srBucketMetaTypeNotificationConfig].Checksums); n != 2 {
+		t.Errorf("expected checksums of 2 sites, got %d", n)
+	}
+}
@@ -49,6 +49,8 @@ type DiskInfo struct {
 type DiskMetrics struct {
 	APILatencies map[string]string `json:"apiLatencies,omitempty"`
 	APICalls     map[string]uint64 `json:"apiCalls,omitempty"`
+	// 99th percentile of the most recent read calls in nanoseconds.
+	ReadLatencyP99 int64 `json:"readLatencyP99,omitempty"`
 }
 
 // VolsInfo is a collection of volume(bucket) information
@@ -98,11 +100,14 @@ type FileInfoVersions struct {
 }
 
 // findVersionIndex will return the version index where the version
-// was found. Returns -1 if not found.
+// was found, "null" finds the null version. Returns -1 if not found.
 func (f *FileInfoVersions) findVersionIndex(v string) int {
 	if f == nil || v == "" {
 		return -1
 	}
+	if v == nullVersionID {
+		v = ""
+	}
 	for i, ver := range f.Versions {
 		if ver.VersionID == v {
 			return i
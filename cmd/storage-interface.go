@@ -61,6 +61,7 @@ type StorageAPI interface {
 	WriteMetadata(ctx context.Context, volume, path string, fi FileInfo) error
 	UpdateMetadata(ctx context.Context, volume, path string, fi FileInfo) error
 	ReadVersion(ctx context.Context, volume, path, versionID string, readData bool) (FileInfo, error)
+	ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) ([]FileInfo, []error)
 	RenameData(ctx context.Context, srcVolume, srcPath string, fi FileInfo, dstVolume, dstPath string) error
 
 	// File operations.
@@ -70,9 +71,9 @@ type StorageAPI interface {
 	CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) error
 	ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error)
 	RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) error
-	CheckParts(ctx context.Context, volume string, path string, fi FileInfo) error
+	CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error)
 	Delete(ctx context.Context, volume string, path string, recursive bool) (err error)
-	VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error
+	VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error)
 	StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error)
 
 	// Write all data, syncs the data to disk.
@@ -196,8 +197,8 @@ func (p *unrecognizedDisk) RenameData(ctx context.Context, srcVolume, srcPath st
 	return errDiskNotFound
 }
 
-func (p *unrecognizedDisk) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
-	return errDiskNotFound
+func (p *unrecognizedDisk) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error) {
+	return nil, errDiskNotFound
 }
 
 func (p *unrecognizedDisk) Delete(ctx context.Context, volume string, path string, recursive bool) (err error) {
@@ -215,8 +216,8 @@ func (p *unrecognizedDisk) DeleteVersions(ctx context.Context, volume string, ve
 	return errs
 }
 
-func (p *unrecognizedDisk) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
-	return errDiskNotFound
+func (p *unrecognizedDisk) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
+	return nil, errDiskNotFound
 }
 
 func (p *unrecognizedDisk) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
@@ -239,6 +240,14 @@ func (p *unrecognizedDisk) ReadVersion(ctx context.Context, volume, path, versio
 	return fi, errDiskNotFound
 }
 
+func (p *unrecognizedDisk) ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) (fis []FileInfo, errs []error) {
+	errs = make([]error, len(paths))
+	for i := range errs {
+		errs[i] = errDiskNotFound
+	}
+	return make([]FileInfo, len(paths)), errs
+}
+
 func (p *unrecognizedDisk) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
 	return nil, errDiskNotFound
 }
@@ -246,3 +255,52 @@ func (p *unrecognizedDisk) ReadAll(ctx context.Context, volume string, path stri
 func (p *unrecognizedDisk) StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error) {
 	return nil, errDiskNotFound
 }
+
+// Results of checking a part of an object on a disk.
+const (
+	checkPartUnknown int = iota
+	checkPartSuccess
+	checkPartVolumeNotFound
+	checkPartFileNotFound
+	checkPartFileCorrupt
+)
+
+// CheckPartsResp - the result of checking each part of an object on
+// a disk, in the order of the parts of the object.
+type CheckPartsResp struct {
+	Results []int
+}
+
+// partErrToCheckResult returns the result of a part check with err, ok
+// is false for errors not specific to the part e.g. a faulty disk.
+func partErrToCheckResult(err error) (result int, ok bool) {
+	switch err {
+	case nil:
+		return checkPartSuccess, true
+	case errVolumeNotFound:
+		return checkPartVolumeNotFound, true
+	case errFileNotFound:
+		return checkPartFileNotFound, true
+	case errFileCorrupt:
+		return checkPartFileCorrupt, true
+	}
+	return checkPartUnknown, false
+}
+
+// partErrs returns the error of each part checked, nil for the parts
+// found intact.
+func (c *CheckPartsResp) partErrs() []error {
+	errs := make([]error, len(c.Results))
+	for i, result := range c.Results {
+		switch result {
+		case checkPartSuccess:
+		case checkPartVolumeNotFound:
+			errs[i] = errVolumeNotFound
+		case checkPartFileNotFound:
+			errs[i] = errFileNotFound
+		default:
+			errs[i] = errFileCorrupt
+		}
+	}
+	return errs
+}
@@ -442,7 +442,7 @@ func (client *storageRESTClient) WriteAll(ctx context.Context, volume string, pa
 }
 
 // CheckParts - stat all file parts.
-func (client *storageRESTClient) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) error {
+func (client *storageRESTClient) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error) {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
 	values.Set(storageRESTFilePath, path)
@@ -450,12 +450,20 @@ func (client *storageRESTClient) CheckParts(ctx context.Context, volume string,
 	var reader bytes.Buffer
 	if err := msgp.Encode(&reader, &fi); err != nil {
 		logger.LogIf(context.Background(), err)
-		return err
+		return nil, err
 	}
 
 	respBody, err := client.call(ctx, storageRESTMethodCheckParts, values, &reader, -1)
 	defer xhttp.DrainBody(respBody)
-	return err
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &CheckPartsResp{}
+	if err = gob.NewDecoder(respBody).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // RenameData - rename source path to destination path atomically, metadata and data file.
@@ -512,6 +520,66 @@ func (client *storageRESTClient) ReadVersion(ctx context.Context, volume, path,
 	return fi, err
 }
 
+// ReadVersions - reads a version of a list of objects, the one in
+// versionIDs when not nil, the latest otherwise, in a single call.
+func (client *storageRESTClient) ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) (fis []FileInfo, errs []error) {
+	fis = make([]FileInfo, len(paths))
+	errs = make([]error, len(paths))
+	if len(paths) == 0 {
+		return fis, errs
+	}
+	setErr := func(err error) ([]FileInfo, []error) {
+		for i := range errs {
+			errs[i] = err
+		}
+		return fis, errs
+	}
+
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTTotalPaths, strconv.Itoa(len(paths)))
+	values.Set(storageRESTReadData, strconv.FormatBool(readData))
+
+	var buffer bytes.Buffer
+	encoder := msgp.NewWriter(&buffer)
+	for i, path := range paths {
+		var versionID string
+		if versionIDs != nil {
+			versionID = versionIDs[i]
+		}
+		encoder.WriteString(path)
+		encoder.WriteString(versionID)
+	}
+	logger.LogIf(ctx, encoder.Flush())
+
+	respBody, err := client.call(ctx, storageRESTMethodReadVersions, values, &buffer, -1)
+	defer xhttp.DrainBody(respBody)
+	if err != nil {
+		return setErr(err)
+	}
+
+	reader, err := waitForHTTPResponse(respBody)
+	if err != nil {
+		return setErr(toStorageErr(err))
+	}
+
+	dec := msgpNewReader(reader)
+	defer readMsgpReaderPool.Put(dec)
+	for i := range paths {
+		if err = fis[i].DecodeMsg(dec); err != nil {
+			return setErr(err)
+		}
+		errStr, err := dec.ReadString()
+		if err != nil {
+			return setErr(err)
+		}
+		if errStr != "" {
+			errs[i] = toStorageErr(StorageErr(errStr))
+		}
+	}
+	return fis, errs
+}
+
 // ReadAll - reads all contents of a file.
 func (client *storageRESTClient) ReadAll(ctx context.Context, volume string, path string) ([]byte, error) {
 	values := make(url.Values)
@@ -652,33 +720,36 @@ func (client *storageRESTClient) RenameFile(ctx context.Context, srcVolume, srcP
 	return err
 }
 
-func (client *storageRESTClient) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
+func (client *storageRESTClient) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
 	values := make(url.Values)
 	values.Set(storageRESTVolume, volume)
 	values.Set(storageRESTFilePath, path)
 
 	var reader bytes.Buffer
 	if err := msgp.Encode(&reader, &fi); err != nil {
-		return err
+		return nil, err
 	}
 
 	respBody, err := client.call(ctx, storageRESTMethodVerifyFile, values, &reader, -1)
 	defer xhttp.DrainBody(respBody)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	respReader, err := waitForHTTPResponse(respBody)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	verifyResp := &VerifyFileResp{}
 	if err = gob.NewDecoder(respReader).Decode(verifyResp); err != nil {
-		return err
+		return nil, err
 	}
 
-	return toStorageErr(verifyResp.Err)
+	if err = toStorageErr(verifyResp.Err); err != nil {
+		return nil, err
+	}
+	return verifyResp.Resp, nil
 }
 
 func (client *storageRESTClient) StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error) {
@@ -725,11 +796,11 @@ func newStorageRESTClient(endpoint Endpoint, healthcheck bool) *storageRESTClien
 		Path:   path.Join(storageRESTPrefix, endpoint.Path, storageRESTVersion),
 	}
 
-	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient := newInternodeRESTClient(serverURL)
 
 	if healthcheck {
 		// Use a separate client to avoid recursive calls.
-		healthClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+		healthClient := newInternodeRESTClient(serverURL)
 		healthClient.ExpectTimeouts = true
 		healthClient.NoMetrics = true
 		restClient.HealthCheckFn = func() bool {
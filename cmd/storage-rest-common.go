@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	storageRESTVersion       = "v42" // Added FreeVersions to FileInfoVersions
+	storageRESTVersion       = "v45" // Added ReadVersions
 	storageRESTVersionPrefix = SlashSeparator + storageRESTVersion
 	storageRESTPrefix        = minioReservedBucketPath + "/storage"
 )
@@ -40,6 +40,7 @@ const (
 	storageRESTMethodUpdateMetadata = "/updatemetadata"
 	storageRESTMethodDeleteVersion  = "/deleteversion"
 	storageRESTMethodReadVersion    = "/readversion"
+	storageRESTMethodReadVersions   = "/readversions"
 	storageRESTMethodRenameData     = "/renamedata"
 	storageRESTMethodCheckParts     = "/checkparts"
 	storageRESTMethodReadAll        = "/readall"
@@ -63,6 +64,7 @@ const (
 	storageRESTVersionID      = "version-id"
 	storageRESTReadData       = "read-data"
 	storageRESTTotalVersions  = "total-versions"
+	storageRESTTotalPaths     = "total-paths"
 	storageRESTSrcVolume      = "source-volume"
 	storageRESTSrcPath        = "source-path"
 	storageRESTDstVolume      = "destination-volume"
@@ -72,6 +74,7 @@ const (
 	storageRESTCount          = "count"
 	storageRESTPrefixFilter   = "prefix"
 	storageRESTForwardFilter  = "forward"
+	storageRESTLimit          = "limit"
 	storageRESTRecursive      = "recursive"
 	storageRESTReportNotFound = "report-notfound"
 	storageRESTBitrotAlgo     = "bitrot-algo"
@@ -389,6 +389,63 @@ func (s *storageRESTServer) ReadVersionHandler(w http.ResponseWriter, r *http.Re
 	logger.LogIf(r.Context(), msgp.Encode(w, &fi))
 }
 
+// ReadVersionsHandler reads a version of a list of objects, the FileInfo
+// and error of each object are streamed back in request order.
+func (s *storageRESTServer) ReadVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		return
+	}
+
+	vars := mux.Vars(r)
+	volume := vars[storageRESTVolume]
+	totalPaths, err := strconv.Atoi(vars[storageRESTTotalPaths])
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	readData, err := strconv.ParseBool(vars[storageRESTReadData])
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	paths := make([]string, totalPaths)
+	versionIDs := make([]string, totalPaths)
+	decoder := msgp.NewReader(r.Body)
+	for i := range paths {
+		if paths[i], err = decoder.ReadString(); err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+		if versionIDs[i], err = decoder.ReadString(); err != nil {
+			s.writeErrorResponse(w, err)
+			return
+		}
+	}
+
+	setEventStreamHeaders(w)
+	done := keepHTTPResponseAlive(w)
+	fis, errs := s.storage.ReadVersions(r.Context(), volume, paths, versionIDs, readData)
+	done(nil)
+
+	encoder := msgp.NewWriter(w)
+	for i := range paths {
+		var errStr string
+		if errs[i] != nil {
+			errStr = errs[i].Error()
+		}
+		if err = fis[i].EncodeMsg(encoder); err != nil {
+			logger.LogIf(r.Context(), err)
+			return
+		}
+		if err = encoder.WriteString(errStr); err != nil {
+			logger.LogIf(r.Context(), err)
+			return
+		}
+	}
+	logger.LogIf(r.Context(), encoder.Flush())
+}
+
 // WriteMetadata write new updated metadata.
 func (s *storageRESTServer) WriteMetadataHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -486,9 +543,12 @@ func (s *storageRESTServer) CheckPartsHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if err := s.storage.CheckParts(r.Context(), volume, filePath, fi); err != nil {
+	resp, err := s.storage.CheckParts(r.Context(), volume, filePath, fi)
+	if err != nil {
 		s.writeErrorResponse(w, err)
+		return
 	}
+	gob.NewEncoder(w).Encode(resp)
 }
 
 // ReadAllHandler - read all the contents of a file.
@@ -1057,7 +1117,8 @@ func waitForHTTPStream(respBody io.ReadCloser, w io.Writer) error {
 
 // VerifyFileResp - VerifyFile()'s response.
 type VerifyFileResp struct {
-	Err error
+	Err  error
+	Resp *CheckPartsResp
 }
 
 // VerifyFileHandler - Verify all part of file for bitrot errors.
@@ -1083,9 +1144,9 @@ func (s *storageRESTServer) VerifyFileHandler(w http.ResponseWriter, r *http.Req
 	setEventStreamHeaders(w)
 	encoder := gob.NewEncoder(w)
 	done := keepHTTPResponseAlive(w)
-	err := s.storage.VerifyFile(r.Context(), volume, filePath, fi)
+	resp, err := s.storage.VerifyFile(r.Context(), volume, filePath, fi)
 	done(nil)
-	vresp := &VerifyFileResp{}
+	vresp := &VerifyFileResp{Resp: resp}
 	if err != nil {
 		vresp.Err = StorageErr(err.Error())
 	}
@@ -1265,6 +1326,8 @@ func registerStorageRESTHandlers(router *mux.Router, endpointServerPools Endpoin
 				Queries(restQueries(storageRESTVolume, storageRESTFilePath, storageRESTForceDelMarker)...)
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodReadVersion).HandlerFunc(httpTraceHdrs(server.ReadVersionHandler)).
 				Queries(restQueries(storageRESTVolume, storageRESTFilePath, storageRESTVersionID, storageRESTReadData)...)
+			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodReadVersions).HandlerFunc(httpTraceHdrs(server.ReadVersionsHandler)).
+				Queries(restQueries(storageRESTVolume, storageRESTTotalPaths, storageRESTReadData)...)
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodRenameData).HandlerFunc(httpTraceHdrs(server.RenameDataHandler)).
 				Queries(restQueries(storageRESTSrcVolume, storageRESTSrcPath,
 					storageRESTDstVolume, storageRESTDstPath)...)
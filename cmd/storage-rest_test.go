@@ -20,13 +20,16 @@ package cmd
 import (
 	"context"
 	"io/ioutil"
+	"net"
 	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/rest"
 	xnet "github.com/minio/pkg/net"
+	"google.golang.org/grpc"
 )
 
 // Storage REST server, storageRESTReceiver and StorageRESTClient are
@@ -264,6 +267,88 @@ func testStorageAPIReadAll(t *testing.T, storage StorageAPI) {
 	}
 }
 
+func testStorageAPIReadVersions(t *testing.T, storage StorageAPI) {
+	ctx := context.Background()
+	if err := storage.MakeVol(ctx, "foo"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	objects := []string{"a", "b/c", "missing", "d"}
+	versionIDs := make([]string, len(objects))
+	for i, object := range objects {
+		versionIDs[i] = mustGetUUID()
+		if object == "missing" {
+			continue
+		}
+		fi := FileInfo{
+			Volume:    "foo",
+			Name:      object,
+			VersionID: versionIDs[i],
+			DataDir:   mustGetUUID(),
+			ModTime:   UTCNow(),
+			Size:      1,
+			Parts:     []ObjectPartInfo{{Number: 1, Size: 1, ActualSize: 1}},
+			Erasure: ErasureInfo{
+				Algorithm:    ReedSolomon.String(),
+				DataBlocks:   2,
+				ParityBlocks: 2,
+				BlockSize:    blockSizeV2,
+				Index:        1,
+				Distribution: []int{1, 2, 3, 4},
+			},
+		}
+		if err := storage.WriteMetadata(ctx, "foo", object, fi); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	fis, errs := storage.ReadVersions(ctx, "foo", objects, nil, false)
+	if len(fis) != len(objects) || len(errs) != len(objects) {
+		t.Fatalf("expected %d results, got %d/%d", len(objects), len(fis), len(errs))
+	}
+	for i, object := range objects {
+		if object == "missing" {
+			if errs[i] != errFileNotFound {
+				t.Fatalf("case %v: expected %v, got %v", i+1, errFileNotFound, errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("case %v: unexpected batch error %v", i+1, errs[i])
+		}
+		fi, err := storage.ReadVersion(ctx, "foo", object, "", false)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error %v", i+1, err)
+		}
+		if fis[i].Name != object || fis[i].VersionID != fi.VersionID || !fis[i].ModTime.Equal(fi.ModTime) {
+			t.Fatalf("case %v: expected %s/%s, got %s/%s", i+1, object, fi.VersionID, fis[i].Name, fis[i].VersionID)
+		}
+	}
+
+	// Versions are read by ID when given.
+	versionIDs[3] = mustGetUUID()
+	fis, errs = storage.ReadVersions(ctx, "foo", objects, versionIDs, false)
+	for i, object := range objects {
+		switch object {
+		case "missing", "d":
+			if errs[i] != errFileVersionNotFound {
+				t.Fatalf("case %v: expected %v, got %v", i+1, errFileVersionNotFound, errs[i])
+			}
+		default:
+			if errs[i] != nil || fis[i].VersionID != versionIDs[i] {
+				t.Fatalf("case %v: expected version %s, got %s (%v)", i+1, versionIDs[i], fis[i].VersionID, errs[i])
+			}
+		}
+	}
+
+	// Errors of the whole call are returned for every object.
+	_, errs = storage.ReadVersions(ctx, "nonexistent", objects, nil, false)
+	for i := range errs {
+		if errs[i] != errVolumeNotFound {
+			t.Fatalf("case %v: expected %v, got %v", i+1, errVolumeNotFound, errs[i])
+		}
+	}
+}
+
 func testStorageAPIReadFile(t *testing.T, storage StorageAPI) {
 	err := storage.MakeVol(context.Background(), "foo")
 	if err != nil {
@@ -514,6 +599,14 @@ func TestStorageRESTClientReadAll(t *testing.T) {
 	testStorageAPIReadAll(t, restClient)
 }
 
+func TestStorageRESTClientReadVersions(t *testing.T) {
+	httpServer, restClient, endpointPath := newStorageRESTHTTPServerClient(t)
+	defer httpServer.Close()
+	defer os.RemoveAll(endpointPath)
+
+	testStorageAPIReadVersions(t, restClient)
+}
+
 func TestStorageRESTClientReadFile(t *testing.T) {
 	httpServer, restClient, endpointPath := newStorageRESTHTTPServerClient(t)
 	defer httpServer.Close()
@@ -545,3 +638,38 @@ func TestStorageRESTClientRenameFile(t *testing.T) {
 
 	testStorageAPIRenameFile(t, restClient)
 }
+
+func TestStorageRESTClientGRPC(t *testing.T) {
+	testCases := map[string]func(*testing.T, StorageAPI){
+		"MakeVol":      testStorageAPIMakeVol,
+		"AppendFile":   testStorageAPIAppendFile,
+		"ReadAll":      testStorageAPIReadAll,
+		"ReadFile":     testStorageAPIReadFile,
+		"ReadVersions": testStorageAPIReadVersions,
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			httpServer, restClient, endpointPath := newStorageRESTHTTPServerClient(t)
+			defer httpServer.Close()
+			defer os.RemoveAll(endpointPath)
+
+			// Serve the same router over gRPC.
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			grpcServer := rest.NewGRPCServer(httpServer.Config.Handler, []string{storageRESTPrefix})
+			go grpcServer.Serve(l)
+			defer grpcServer.Stop()
+
+			conn, err := grpc.Dial(l.Addr().String(), append(rest.GRPCDialOptions(), grpc.WithInsecure())...)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			defer conn.Close()
+			restClient.restClient.GRPCConn = conn
+
+			testCase(t, restClient)
+		})
+	}
+}
@@ -32,15 +32,18 @@ import (
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/auth"
+	xhash "github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Streaming AWS Signature Version '4' constants.
 const (
-	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
-	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
-	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
-	streamingContentEncoding = "aws-chunked"
+	emptySHA256                   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	streamingContentSHA256        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingContentSHA256Trailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithm        = "AWS4-HMAC-SHA256-PAYLOAD"
+	signV4ChunkedAlgorithmTrailer = "AWS4-HMAC-SHA256-TRAILER"
+	streamingContentEncoding      = "aws-chunked"
 )
 
 // getChunkSignature - get chunk signature.
@@ -62,6 +65,21 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 	return newSignature
 }
 
+// getTrailerChunkSignature - get the signature of the trailer following the last chunk.
+func getTrailerChunkSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedTrailer string) string {
+	// Calculate string to sign.
+	stringToSign := signV4ChunkedAlgorithmTrailer + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		hashedTrailer
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	return getSignature(signingKey, stringToSign)
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
@@ -80,10 +98,11 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get(xhttp.AmzContentSha256)
 
-	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get(xhttp.AmzContentSha256) {
+	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD',
+	// or 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER' when followed by a trailer.
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
 		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
@@ -155,9 +174,12 @@ var errChunkTooBig = errors.New("chunk too big: choose chunk size <= 16MiB")
 // out of HTTP "chunked" format before returning it.
 // The s3ChunkedReader returns io.EOF when the final 0-length chunk is read.
 //
+// When the payload is followed by a trailer, the value of the trailing
+// checksum is set in trailer, if not nil, once the trailer was read.
+//
 // NewChunkedReader is not needed by normal applications. The http package
 // automatically decodes chunking when reading response bodies.
-func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
+func newSignV4ChunkedReader(req *http.Request, trailer *xhash.Checksum) (io.ReadCloser, APIErrorCode) {
 	cred, seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
 	if errCode != ErrNone {
 		return nil, errCode
@@ -171,6 +193,8 @@ func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 		region:            region,
 		chunkSHA256Writer: sha256.New(),
 		buffer:            make([]byte, 64*1024),
+		trailing:          req.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256Trailer,
+		trailer:           trailer,
 	}, ErrNone
 }
 
@@ -187,6 +211,9 @@ type s3ChunkedReader struct {
 	buffer            []byte
 	offset            int
 	err               error
+
+	trailing bool            // The final chunk is followed by a trailer.
+	trailer  *xhash.Checksum // Set from the trailer, if not nil.
 }
 
 func (cr *s3ChunkedReader) Close() (err error) {
@@ -206,12 +233,18 @@ func (cr *s3ChunkedReader) Close() (err error) {
 //
 // The last chunk is *always* 0-sized. So, we must only return io.EOF if we have encountered
 // a chunk with a chunk size = 0. However, this chunk still has a signature and we must
-// verify it.
+// verify it. With a trailer, the last chunk is followed by the trailer instead of "\r\n":
+//   <trailing header> + ":" + <value> + "\r\n" +
+//   "x-amz-trailer-signature:" + <signature-as-hex> + "\r\n" + "\r\n"
 const maxChunkSize = 16 << 20 // 16 MiB
 
 // Read - implements `io.Reader`, which transparently decodes
 // the incoming AWS Signature V4 streaming signature.
 func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
+	if cr.err != nil {
+		return 0, cr.err
+	}
+
 	// First, if there is any unread data, copy it to the client
 	// provided buffer.
 	if cr.offset > 0 {
@@ -317,22 +350,24 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 		cr.err = err
 		return n, cr.err
 	}
-	b, err = cr.reader.ReadByte()
-	if b != '\r' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
-	}
-	b, err = cr.reader.ReadByte()
-	if err == io.EOF {
-		err = io.ErrUnexpectedEOF
-	}
-	if err != nil {
-		cr.err = err
-		return n, cr.err
-	}
-	if b != '\n' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
+	if size != 0 || !cr.trailing {
+		b, err = cr.reader.ReadByte()
+		if b != '\r' {
+			cr.err = errMalformedEncoding
+			return n, cr.err
+		}
+		b, err = cr.reader.ReadByte()
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			cr.err = err
+			return n, cr.err
+		}
+		if b != '\n' {
+			cr.err = errMalformedEncoding
+			return n, cr.err
+		}
 	}
 
 	// Once we have read the entire chunk successfully, we verify
@@ -349,6 +384,12 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 	// If the chunk size is zero we return io.EOF. As specified by AWS,
 	// only the last chunk is zero-sized.
 	if size == 0 {
+		if cr.trailing {
+			if err = cr.readTrailer(); err != nil {
+				cr.err = err
+				return n, cr.err
+			}
+		}
 		cr.err = io.EOF
 		return n, cr.err
 	}
@@ -358,6 +399,72 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 	return n, err
 }
 
+// readTrailer reads the trailer following the last chunk and verifies its signature.
+func (cr *s3ChunkedReader) readTrailer() error {
+	trailer, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if len(trailer) == 0 {
+		return errMalformedEncoding
+	}
+	signature, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(signature, []byte(s3TrailerSignatureStr)) {
+		return errMalformedEncoding
+	}
+	if end, err := readTrailerLine(cr.reader); err != nil || len(end) != 0 {
+		if err == nil {
+			err = errMalformedEncoding
+		}
+		return err
+	}
+
+	// The trailer is signed with its trailing newline.
+	sum := sha256.Sum256(append(trailer, '\n'))
+	newSignature := getTrailerChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hex.EncodeToString(sum[:]))
+	if !compareSignatureV4(string(signature[len(s3TrailerSignatureStr):]), newSignature) {
+		return errSignatureMismatch
+	}
+	return setTrailingChecksum(cr.trailer, trailer)
+}
+
+// readTrailerLine reads a line terminated by "\r\n" from b
+// and returns it without the line ending.
+func readTrailerLine(b *bufio.Reader) ([]byte, error) {
+	buf, err := b.ReadSlice('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == bufio.ErrBufferFull {
+			err = errLineTooLong
+		}
+		return nil, err
+	}
+	if len(buf) >= maxLineLength {
+		return nil, errLineTooLong
+	}
+	if !bytes.HasSuffix(buf, []byte("\r\n")) {
+		return nil, errMalformedEncoding
+	}
+	return append([]byte(nil), buf[:len(buf)-2]...), nil
+}
+
+// setTrailingChecksum sets the value of the trailing checksum c,
+// if not nil, from the trailer line '<header>:<value>'.
+func setTrailingChecksum(c *xhash.Checksum, trailer []byte) error {
+	i := bytes.IndexByte(trailer, ':')
+	if i <= 0 {
+		return errMalformedEncoding
+	}
+	if c == nil {
+		return nil
+	}
+	return c.SetTrailer(string(trailer[:i]), string(trailer[i+1:]))
+}
+
 // readCRLF - check if reader only has '\r\n' CRLF character.
 // returns malformed encoding if it doesn't.
 func readCRLF(reader io.Reader) error {
@@ -412,6 +519,9 @@ func isASCIISpace(b byte) bool {
 // Constant s3 chunk encoding signature.
 const s3ChunkSignatureStr = ";chunk-signature="
 
+// Constant s3 chunk encoding trailer signature.
+const s3TrailerSignatureStr = "x-amz-trailer-signature:"
+
 // parses3ChunkExtension removes any s3 specific chunk-extension from buf.
 // For example,
 //     "10000;chunk-signature=..." => "10000", "chunk-signature=..."
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+
+	xhash "github.com/minio/minio/internal/hash"
+)
+
+// newUnsignedV4ChunkedReader returns a new s3UnsignedChunkedReader that translates
+// the unsigned data read from r out of "aws-chunked" format before returning it.
+// The request itself must be signed with AWS Signature Version '4', with the
+// 'STREAMING-UNSIGNED-PAYLOAD-TRAILER' payload.
+//
+// The value of the trailing checksum is set in trailer, if not nil,
+// once the trailer following the final 0-length chunk was read.
+func newUnsignedV4ChunkedReader(req *http.Request, trailer *xhash.Checksum) (io.ReadCloser, APIErrorCode) {
+	if errCode := reqSignatureV4Verify(req, globalSite.Region, serviceS3); errCode != ErrNone {
+		return nil, errCode
+	}
+	return &s3UnsignedChunkedReader{
+		reader:  bufio.NewReader(req.Body),
+		trailer: trailer,
+		buffer:  make([]byte, 0, 64*1024),
+	}, ErrNone
+}
+
+// Represents the overall state that is required for decoding
+// unsigned "aws-chunked" content followed by a trailer.
+type s3UnsignedChunkedReader struct {
+	reader  *bufio.Reader
+	trailer *xhash.Checksum
+	buffer  []byte
+	offset  int
+	err     error
+}
+
+func (cr *s3UnsignedChunkedReader) Close() (err error) {
+	return nil
+}
+
+// Read - implements `io.Reader`, a chunk has the following format:
+//
+//	<chunk-size-as-hex> + "\r\n" + <payload> + "\r\n"
+//
+// The last chunk is 0-sized and followed by the trailer:
+//
+//	"0" + "\r\n" + <trailing header> + ":" + <value> + "\r\n" + "\r\n"
+func (cr *s3UnsignedChunkedReader) Read(buf []byte) (n int, err error) {
+	// First, if there is any unread data, copy it to the client
+	// provided buffer.
+	if cr.offset < len(cr.buffer) {
+		n = copy(buf, cr.buffer[cr.offset:])
+		cr.offset += n
+		return n, nil
+	}
+	if cr.err != nil {
+		return 0, cr.err
+	}
+
+	line, err := readTrailerLine(cr.reader)
+	if err != nil {
+		cr.err = err
+		return 0, cr.err
+	}
+	if i := bytes.IndexByte(line, ';'); i >= 0 { // Ignore chunk extensions.
+		line = line[:i]
+	}
+	size, err := parseHexUint(line)
+	if err != nil || len(line) == 0 {
+		cr.err = errMalformedEncoding
+		return 0, cr.err
+	}
+	if size > maxChunkSize {
+		cr.err = errChunkTooBig
+		return 0, cr.err
+	}
+
+	// If the chunk size is zero we return io.EOF once the trailer was read.
+	if size == 0 {
+		if cr.err = cr.readTrailer(); cr.err == nil {
+			cr.err = io.EOF
+		}
+		return 0, cr.err
+	}
+
+	if uint64(cap(cr.buffer)) < size {
+		cr.buffer = make([]byte, size)
+	} else {
+		cr.buffer = cr.buffer[:size]
+	}
+	if _, err = io.ReadFull(cr.reader, cr.buffer); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		cr.err = err
+		return 0, cr.err
+	}
+	if err = readCRLF(cr.reader); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		cr.err = err
+		return 0, cr.err
+	}
+
+	cr.offset = copy(buf, cr.buffer)
+	return cr.offset, nil
+}
+
+// readTrailer reads the trailer following the last chunk.
+func (cr *s3UnsignedChunkedReader) readTrailer() error {
+	trailer, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if len(trailer) == 0 {
+		return errMalformedEncoding
+	}
+	end, err := readTrailerLine(cr.reader)
+	if err != nil {
+		return err
+	}
+	if len(end) != 0 {
+		return errMalformedEncoding
+	}
+	return setTrailingChecksum(cr.trailer, trailer)
+}
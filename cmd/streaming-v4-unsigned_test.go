@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/hash"
+)
+
+func TestS3UnsignedChunkedReader(t *testing.T) {
+	testCases := []struct {
+		stream   string
+		data     string
+		checksum string
+		err      error
+	}{
+		// Test case - 1: chunks followed by the trailer.
+		{
+			stream:   "3\r\nabc\r\n2\r\nde\r\n0\r\nx-amz-checksum-crc32c:ksgKMQ==\r\n\r\n",
+			data:     "abcde",
+			checksum: "ksgKMQ==",
+		},
+		// Test case - 2: chunk extensions are ignored, trailer header is case insensitive.
+		{
+			stream:   "5;ext=1\r\nabcde\r\n0\r\nX-Amz-Checksum-Crc32c: ksgKMQ==\r\n\r\n",
+			data:     "abcde",
+			checksum: "ksgKMQ==",
+		},
+		// Test case - 3: missing trailer.
+		{stream: "5\r\nabcde\r\n0\r\n\r\n", data: "abcde", err: errMalformedEncoding},
+		// Test case - 4: trailer of another checksum.
+		{stream: "5\r\nabcde\r\n0\r\nx-amz-checksum-sha256:ksgKMQ==\r\n\r\n", data: "abcde", err: hash.ErrInvalidChecksum},
+		// Test case - 5: missing final empty line.
+		{stream: "5\r\nabcde\r\n0\r\nx-amz-checksum-crc32c:ksgKMQ==\r\n", data: "abcde", err: io.ErrUnexpectedEOF},
+		// Test case - 6: chunk data not followed by CRLF.
+		{stream: "5\r\nabcdef\r\n0\r\n", err: errMalformedEncoding},
+		// Test case - 7: invalid chunk size.
+		{stream: "x\r\nabcde\r\n", err: errMalformedEncoding},
+		// Test case - 8: chunk too big.
+		{stream: "1000001\r\n", err: errChunkTooBig},
+	}
+	for i, testCase := range testCases {
+		checksum := &hash.Checksum{Type: hash.ChecksumCRC32C, Trailing: true}
+		cr := &s3UnsignedChunkedReader{
+			reader:  bufio.NewReader(strings.NewReader(testCase.stream)),
+			trailer: checksum,
+		}
+		data, err := ioutil.ReadAll(cr)
+		if err != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if string(data) != testCase.data {
+			t.Errorf("Test %d: expected data %q, got %q", i+1, testCase.data, data)
+		}
+		if checksum.Encoded != testCase.checksum {
+			t.Errorf("Test %d: expected checksum %q, got %q", i+1, testCase.checksum, checksum.Encoded)
+		}
+	}
+}
+
+func TestS3UnsignedChunkedReaderVerifyChecksum(t *testing.T) {
+	for i, sum := range []string{"xFDWlw==", "AAAAAA=="} {
+		checksum := &hash.Checksum{Type: hash.ChecksumCRC32C, Trailing: true}
+		cr := &s3UnsignedChunkedReader{
+			reader:  bufio.NewReader(strings.NewReader("5\r\nabcde\r\n0\r\nx-amz-checksum-crc32c:" + sum + "\r\n\r\n")),
+			trailer: checksum,
+		}
+		r, err := hash.NewReader(cr, 5, "", "", 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(checksum); err != nil {
+			t.Fatal(err)
+		}
+		_, err = ioutil.ReadAll(r)
+		if _, ok := err.(hash.ChecksumMismatch); ok != (i == 1) {
+			t.Errorf("Test %d: expected checksum mismatch %v, got %v", i+1, i == 1, err)
+		}
+	}
+}
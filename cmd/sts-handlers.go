@@ -79,14 +79,14 @@ const (
 	roleArnClaim = "roleArn"
 )
 
-func parseOpenIDParentUser(parentUser string) (userID string, err error) {
+func parseOpenIDParentUser(parentUser string) (userID, issuer string, err error) {
 	if strings.HasPrefix(parentUser, "openid:") {
 		tokens := strings.SplitN(strings.TrimPrefix(parentUser, "openid:"), ":", 2)
 		if len(tokens) == 2 {
-			return tokens[0], nil
+			return tokens[0], tokens[1], nil
 		}
 	}
-	return "", errSkipFile
+	return "", "", errSkipFile
 }
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -156,14 +156,16 @@ func checkAssumeRoleAuth(ctx context.Context, r *http.Request) (user auth.Creden
 			return user, false, STSErrorCode(s3Err)
 		}
 
-		// Temporary credentials or Service accounts cannot generate further temporary credentials.
-		if user.IsTemp() || user.IsServiceAccount() {
+		// Service accounts cannot generate temporary credentials,
+		// temporary credentials may chain a new session.
+		if user.IsServiceAccount() {
 			return user, true, ErrSTSAccessDenied
 		}
 	}
 
-	// Session tokens are not allowed in STS AssumeRole requests.
-	if getSessionToken(r) != "" {
+	// Session tokens are only allowed in STS AssumeRole requests
+	// chaining a session.
+	if getSessionToken(r) != "" && !user.IsTemp() {
 		return user, true, ErrSTSAccessDenied
 	}
 
@@ -244,22 +246,69 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	m := map[string]interface{}{
-		expClaim: UTCNow().Add(duration).Unix(),
-	}
-
-	policies, err := globalIAMSys.PolicyDBGet(user.AccessKey, false)
+	// Session tags passed by the caller.
+	tags, err := sessionTagsFromForm(r.Form)
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
 		return
 	}
 
-	policyName := strings.Join(policies, ",")
+	m := map[string]interface{}{
+		expClaim: UTCNow().Add(duration).Unix(),
+	}
+
+	var (
+		policyName string
+		parentUser = user.AccessKey
+		groups     []string
+	)
+	if user.IsTemp() {
+		// A chained session keeps the identity, the session policy
+		// and the transitive session tags of the calling session and
+		// cannot outlive it.
+		claims, err := auth.ExtractClaims(user.SessionToken, globalActiveCred.SecretKey)
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied, err)
+			return
+		}
+		parentClaims := claims.Map()
+		if _, ok := parentClaims[iampolicy.SessionPolicyName]; ok && len(sessionPolicyStr) > 0 {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
+				errors.New("Session policy of the calling session cannot be replaced"))
+			return
+		}
+		for k, v := range parentClaims {
+			switch k {
+			case expClaim, sessionTagsClaim, transitiveTagKeysClaim:
+				continue
+			}
+			m[k] = v
+		}
+		tags, err = sessionTagsFromClaims(parentClaims).transitive().merge(tags)
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+			return
+		}
+		m[expClaim] = chainedSessionExpiry(parentClaims, UTCNow().Add(duration).Unix())
+		policyName, _ = parentClaims[iamPolicyClaimNameOpenIDFor(parentClaims)].(string)
+		parentUser = user.ParentUser
+		groups = user.Groups
+	} else {
+		policies, err := globalIAMSys.PolicyDBGet(user.AccessKey, false)
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+			return
+		}
 
-	// This policy is the policy associated with the user
-	// requesting for temporary credentials. The temporary
-	// credentials will inherit the same policy requirements.
-	m[iamPolicyClaimNameOpenID()] = policyName
+		policyName = strings.Join(policies, ",")
+
+		// This policy is the policy associated with the user
+		// requesting for temporary credentials. The temporary
+		// credentials will inherit the same policy requirements.
+		m[iamPolicyClaimNameOpenID()] = policyName
+	}
+
+	tags.setClaims(m)
 
 	if len(sessionPolicyStr) > 0 {
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
@@ -274,7 +323,8 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 
 	// Set the parent of the temporary access key, this is useful
 	// in obtaining service accounts by this cred.
-	cred.ParentUser = user.AccessKey
+	cred.ParentUser = parentUser
+	cred.Groups = groups
 
 	// Set the newly generated credentials.
 	if err = globalIAMSys.SetTempUser(ctx, cred.AccessKey, cred, policyName); err != nil {
@@ -357,6 +407,14 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// The token was validated by the OpenID provider of its issuer,
+	// the client ID and the policy claims are provider specific.
+	var issFromToken string
+	if v, ok := m[issClaim]; ok {
+		issFromToken, _ = v.(string)
+	}
+	provider := globalOpenIDProviders.ForIssuer(issFromToken)
+
 	// REQUIRED. Audience(s) that this ID Token is intended for.
 	// It MUST contain the OAuth 2.0 client_id of the Relying Party
 	// as an audience value. It MAY also contain identifiers for
@@ -370,7 +428,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 			errors.New("STS JWT Token has `aud` claim invalid, `aud` must match configured OpenID Client ID"))
 		return
 	}
-	if !audValues.Contains(globalOpenIDConfig.ClientID) {
+	if !audValues.Contains(provider.ClientID) {
 		// if audience claims is missing, look for "azp" claims.
 		// OPTIONAL. Authorized party - the party to which the ID
 		// Token was issued. If present, it MUST contain the OAuth
@@ -386,17 +444,29 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 				errors.New("STS JWT Token has `aud` claim invalid, `aud` must match configured OpenID Client ID"))
 			return
 		}
-		if !azpValues.Contains(globalOpenIDConfig.ClientID) {
+		if !azpValues.Contains(provider.ClientID) {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
 				errors.New("STS JWT Token has `azp` claim invalid, `azp` must match configured OpenID Client ID"))
 			return
 		}
 	}
 
+	// Session tags are set by the identity provider in the token.
+	tags, err := sessionTagsFromWebIdentity(m)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+	delete(m, webIdentityTagsClaim)
+	tags.setClaims(m)
+
 	var policyName string
-	if globalIAMSys.HasRolePolicy() {
+	if providerArn, _, ok := provider.GetRoleInfo(); ok && globalIAMSys.HasRolePolicy() {
 		roleArn := r.Form.Get(stsRoleArn)
 		_, err := globalIAMSys.GetRolePolicy(roleArn)
+		if err == nil && roleArn != providerArn.String() {
+			err = fmt.Errorf("RoleARN %s is not defined for the issuer %s", roleArn, issFromToken)
+		}
 		if err != nil {
 			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
 				fmt.Errorf("Error processing %s parameter: %v", stsRoleArn, err))
@@ -410,7 +480,8 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		// JWT. This is a MinIO STS API specific value, this value
 		// should be set and configured on your identity provider as
 		// part of JWT custom claims.
-		policySet, ok := iampolicy.GetPoliciesFromClaims(m, iamPolicyClaimNameOpenID())
+		claimName := provider.ClaimPrefix + provider.ClaimName
+		policySet, ok := iampolicy.GetPoliciesFromClaims(m, claimName)
 		policies := strings.Join(policySet.ToSlice(), ",")
 		if ok {
 			policyName = globalIAMSys.CurrentPolicies(policies)
@@ -419,7 +490,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		if globalPolicyOPA == nil {
 			if !ok {
 				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
-					fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", iamPolicyClaimNameOpenID()))
+					fmt.Errorf("%s claim missing from the JWT token, credentials will not be generated", claimName))
 				return
 			} else if policyName == "" {
 				writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue,
@@ -427,7 +498,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 				return
 			}
 		}
-		m[iamPolicyClaimNameOpenID()] = policyName
+		m[claimName] = policyName
 	}
 
 	sessionPolicyStr := r.Form.Get(stsPolicy)
@@ -478,11 +549,6 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	var issFromToken string
-	if v, ok := m[issClaim]; ok {
-		issFromToken, _ = v.(string)
-	}
-
 	cred.ParentUser = "openid:" + subFromToken + ":" + issFromToken
 
 	// Set the newly generated credentials.
@@ -592,7 +658,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		}
 	}
 
-	ldapUserDN, groupDistNames, err := globalLDAPConfig.Bind(ldapUsername, ldapPassword)
+	ldapUserDN, groupDistNames, ldapAttributes, err := globalLDAPConfig.Bind(ldapUsername, ldapPassword)
 	if err != nil {
 		err = fmt.Errorf("LDAP server error: %w", err)
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
@@ -614,11 +680,19 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		return
 	}
 
+	// Session tags are set from the attributes of the LDAP user.
+	tags, err := sessionTagsFromLDAP(ldapAttributes)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
 	m := map[string]interface{}{
 		expClaim:  UTCNow().Add(expiryDur).Unix(),
 		ldapUser:  ldapUserDN,
 		ldapUserN: ldapUsername,
 	}
+	tags.setClaims(m)
 
 	if len(sessionPolicyStr) > 0 {
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
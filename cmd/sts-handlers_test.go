@@ -18,8 +18,11 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -31,12 +34,17 @@ import (
 	"github.com/minio/madmin-go"
 	minio "github.com/minio/minio-go/v7"
 	cr "github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 	"golang.org/x/oauth2"
 )
 
 func runAllIAMSTSTests(suite *TestSuiteIAM, c *check) {
 	suite.SetUpSuite(c)
 	suite.TestSTS(c)
+	suite.TestSTSChaining(c)
+	suite.TestSTSSessionTags(c)
 	suite.TearDownSuite(c)
 }
 
@@ -154,6 +162,269 @@ func (s *TestSuiteIAM) TestSTS(c *check) {
 	}
 }
 
+func (s *TestSuiteIAM) TestSTSChaining(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket creat error: %v", err)
+	}
+
+	// Create policy, user and associate policy
+	policy := "mychainedpolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:PutObject",
+    "s3:GetObject",
+    "s3:ListBucket"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/*"
+   ]
+  }
+ ]
+}`, bucket))
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	assumeRole := cr.STSAssumeRole{
+		Client:      s.TestSuiteCommon.client,
+		STSEndpoint: s.endPoint,
+		Options: cr.STSAssumeRoleOptions{
+			AccessKey: accessKey,
+			SecretKey: secretKey,
+			Location:  "",
+		},
+	}
+
+	value, err := assumeRole.Retrieve()
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+
+	// Chain a session with the temporary credentials.
+	v := url.Values{}
+	v.Set("Action", "AssumeRole")
+	v.Set("Version", stsAPIVersion)
+	v.Set("DurationSeconds", "43200")
+	body := v.Encode()
+	req, err := http.NewRequest(http.MethodPost, s.endPoint+"/", strings.NewReader(body))
+	if err != nil {
+		c.Fatalf("Unable to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(xhttp.AmzContentSha256, getSHA256Hash([]byte(body)))
+	req.Header.Set(xhttp.AmzSecurityToken, value.SessionToken)
+	req = signer.SignV4STS(*req, value.AccessKeyID, value.SecretAccessKey, "")
+
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("err calling chained assumeRole: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		c.Fatalf("unexpected chained assumeRole status: %s %s", resp.Status, b)
+	}
+	var chained AssumeRoleResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&chained); err != nil {
+		c.Fatalf("Unable to decode chained assumeRole response: %v", err)
+	}
+
+	// The chained session cannot outlive the calling session, which
+	// expires in an hour.
+	chainedCred := chained.Result.Credentials
+	if chainedCred.Expiration.After(time.Now().Add(time.Hour)) {
+		c.Fatalf("chained session expires at %s after the calling session", chainedCred.Expiration)
+	}
+
+	minioClient, err := minio.New(s.endpoint, &minio.Options{
+		Creds:     cr.NewStaticV4(chainedCred.AccessKey, chainedCred.SecretKey, chainedCred.SessionToken),
+		Secure:    s.secure,
+		Transport: s.TestSuiteCommon.client.Transport,
+	})
+	if err != nil {
+		c.Fatalf("Error initializing client: %v", err)
+	}
+
+	// Validate that the client from chained sts creds keeps the
+	// permissions of the user.
+	c.mustListObjects(ctx, minioClient, bucket)
+
+	err = minioClient.RemoveObject(ctx, bucket, "someobject", minio.RemoveObjectOptions{})
+	if err.Error() != "Access Denied." {
+		c.Fatalf("unexpected non-access-denied err: %v", err)
+	}
+}
+
+// assumeRole calls AssumeRole with the form values signed by the
+// credentials and returns the temporary credentials.
+func (s *TestSuiteIAM) assumeRole(c *check, cred auth.Credentials, v url.Values) (auth.Credentials, error) {
+	v.Set("Action", "AssumeRole")
+	v.Set("Version", stsAPIVersion)
+	body := v.Encode()
+	req, err := http.NewRequest(http.MethodPost, s.endPoint+"/", strings.NewReader(body))
+	if err != nil {
+		c.Fatalf("Unable to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(xhttp.AmzContentSha256, getSHA256Hash([]byte(body)))
+	if cred.SessionToken != "" {
+		req.Header.Set(xhttp.AmzSecurityToken, cred.SessionToken)
+	}
+	req = signer.SignV4STS(*req, cred.AccessKey, cred.SecretKey, "")
+
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return auth.Credentials{}, fmt.Errorf("%s %s", resp.Status, b)
+	}
+	var result AssumeRoleResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		c.Fatalf("Unable to decode assumeRole response: %v", err)
+	}
+	return result.Result.Credentials, nil
+}
+
+func (s *TestSuiteIAM) TestSTSSessionTags(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket creat error: %v", err)
+	}
+
+	// Allow the objects of the bucket to sessions tagged with the
+	// storage team, except for the prefix of the admins.
+	policy := "mytaggedpolicy"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:PutObject",
+    "s3:GetObject"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/*"
+   ],
+   "Condition": {"StringEquals": {"aws:PrincipalTag/team": ["storage"]}}
+  },
+  {
+   "Effect": "Deny",
+   "Action": [
+    "s3:PutObject"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/admin/*"
+   ],
+   "Condition": {"StringNotEquals": {"aws:PrincipalTag/role": ["admin"]}}
+  }
+ ]
+}`, bucket, bucket))
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+	userCred := auth.Credentials{AccessKey: accessKey, SecretKey: secretKey}
+
+	testCases := []struct {
+		tags     map[string]string
+		object   string
+		accessOK bool
+	}{
+		{nil, "someobject", false},
+		{map[string]string{"team": "other"}, "someobject", false},
+		{map[string]string{"team": "storage"}, "someobject", true},
+		{map[string]string{"team": "storage"}, "admin/someobject", false},
+		{map[string]string{"team": "storage", "role": "admin"}, "admin/someobject", true},
+	}
+	for i, testCase := range testCases {
+		v := url.Values{}
+		n := 0
+		for k, tv := range testCase.tags {
+			n++
+			v.Set(fmt.Sprintf("Tags.member.%d.Key", n), k)
+			v.Set(fmt.Sprintf("Tags.member.%d.Value", n), tv)
+		}
+		cred, err := s.assumeRole(c, userCred, v)
+		if err != nil {
+			c.Fatalf("Test %d: err calling assumeRole: %v", i+1, err)
+		}
+
+		minioClient, err := minio.New(s.endpoint, &minio.Options{
+			Creds:     cr.NewStaticV4(cred.AccessKey, cred.SecretKey, cred.SessionToken),
+			Secure:    s.secure,
+			Transport: s.TestSuiteCommon.client.Transport,
+		})
+		if err != nil {
+			c.Fatalf("Error initializing client: %v", err)
+		}
+
+		_, err = minioClient.PutObject(ctx, bucket, testCase.object, bytes.NewReader([]byte("a")), 1, minio.PutObjectOptions{})
+		if testCase.accessOK && err != nil {
+			c.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !testCase.accessOK && (err == nil || err.Error() != "Access Denied.") {
+			c.Fatalf("Test %d: unexpected non-access-denied err: %v", i+1, err)
+		}
+	}
+
+	// The transitive tags of a session cannot be replaced by a
+	// chained session.
+	v := url.Values{}
+	v.Set("Tags.member.1.Key", "team")
+	v.Set("Tags.member.1.Value", "storage")
+	v.Set("TransitiveTagKeys.member.1", "team")
+	cred, err := s.assumeRole(c, userCred, v)
+	if err != nil {
+		c.Fatalf("err calling assumeRole: %v", err)
+	}
+	if _, err = s.assumeRole(c, cred, url.Values{
+		"Tags.member.1.Key":   []string{"Team"},
+		"Tags.member.1.Value": []string{"other"},
+	}); err == nil {
+		c.Fatalf("expected the transitive session tag to not be replaced")
+	}
+}
+
 func (s *TestSuiteIAM) GetLDAPServer(c *check) string {
 	return os.Getenv(EnvTestLDAPServer)
 }
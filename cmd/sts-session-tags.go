@@ -0,0 +1,278 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+const (
+	// JWT claim holding the session tags of temporary credentials, a
+	// map of tag keys to values.
+	sessionTagsClaim = "sessionTags"
+
+	// JWT claim holding the keys of the session tags passed on to
+	// credentials obtained with AssumeRole by temporary credentials.
+	transitiveTagKeysClaim = "transitiveTagKeys"
+
+	// Claim of web identity tokens holding session tags, see
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_session-tags.html#id_session-tags_adding-assume-role-idp
+	webIdentityTagsClaim     = "https://aws.amazon.com/tags"
+	webIdentityPrincipalTags = "principal_tags"
+	webIdentityTransitiveTag = "transitive_tag_keys"
+
+	// Prefix of the condition values holding session tags, set as
+	// the aws:PrincipalTag/<key> condition key.
+	principalTagPrefix = "PrincipalTag/"
+
+	// Form parameters of the session tags of AssumeRole requests, see
+	// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+	stsTagKeyFmt           = "Tags.member.%d.Key"
+	stsTagValueFmt         = "Tags.member.%d.Value"
+	stsTransitiveTagKeyFmt = "TransitiveTagKeys.member.%d"
+
+	maxSessionTags        = 50
+	maxSessionTagKeyLen   = 128
+	maxSessionTagValueLen = 256
+)
+
+// sessionTags - tags of a session and the keys of those passed on to
+// chained sessions.
+type sessionTags struct {
+	tags           map[string]string
+	transitiveKeys []string
+}
+
+// isEmpty returns whether no session tag is set.
+func (t sessionTags) isEmpty() bool {
+	return len(t.tags) == 0
+}
+
+// validate checks the number and the size of the session tags, that
+// keys are unique ignoring case and that all transitive keys are set.
+func (t sessionTags) validate() error {
+	if len(t.tags) > maxSessionTags {
+		return fmt.Errorf("Session tags cannot exceed %d tags", maxSessionTags)
+	}
+	keys := make(map[string]struct{}, len(t.tags))
+	for k, v := range t.tags {
+		if k == "" || len(k) > maxSessionTagKeyLen {
+			return fmt.Errorf("Session tag key '%s' must be between 1 and %d characters", k, maxSessionTagKeyLen)
+		}
+		if len(v) > maxSessionTagValueLen {
+			return fmt.Errorf("Session tag value of '%s' cannot exceed %d characters", k, maxSessionTagValueLen)
+		}
+		if _, ok := keys[strings.ToLower(k)]; ok {
+			return fmt.Errorf("Session tag key '%s' is duplicated", k)
+		}
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+	for _, k := range t.transitiveKeys {
+		if _, ok := t.tags[k]; !ok {
+			return fmt.Errorf("Transitive tag key '%s' is not a session tag", k)
+		}
+	}
+	return nil
+}
+
+// transitive returns the session tags passed on to chained sessions.
+func (t sessionTags) transitive() sessionTags {
+	if len(t.transitiveKeys) == 0 {
+		return sessionTags{}
+	}
+	tags := make(map[string]string, len(t.transitiveKeys))
+	for _, k := range t.transitiveKeys {
+		if v, ok := t.tags[k]; ok {
+			tags[k] = v
+		}
+	}
+	return sessionTags{tags: tags, transitiveKeys: t.transitiveKeys}
+}
+
+// setClaims sets the session tags claims of temporary credentials.
+func (t sessionTags) setClaims(m map[string]interface{}) {
+	delete(m, sessionTagsClaim)
+	delete(m, transitiveTagKeysClaim)
+	if t.isEmpty() {
+		return
+	}
+	m[sessionTagsClaim] = t.tags
+	if len(t.transitiveKeys) > 0 {
+		sort.Strings(t.transitiveKeys)
+		m[transitiveTagKeysClaim] = t.transitiveKeys
+	}
+}
+
+// sessionTagsFromLDAP returns the session tags of the attributes of
+// an LDAP user, all of which are passed on to chained sessions.
+func sessionTagsFromLDAP(attributes map[string]string) (t sessionTags, err error) {
+	if len(attributes) == 0 {
+		return t, nil
+	}
+	t.tags = attributes
+	for k := range attributes {
+		t.transitiveKeys = append(t.transitiveKeys, k)
+	}
+	return t, t.validate()
+}
+
+// sessionTagsFromWebIdentity parses the session tags of the claims of
+// a web identity token, the first value of each principal tag is used.
+func sessionTagsFromWebIdentity(claims map[string]interface{}) (t sessionTags, err error) {
+	v, ok := claims[webIdentityTagsClaim]
+	if !ok {
+		return t, nil
+	}
+	tagsClaim, ok := v.(map[string]interface{})
+	if !ok {
+		return t, fmt.Errorf("Invalid %s claim", webIdentityTagsClaim)
+	}
+	if principalTags, ok := tagsClaim[webIdentityPrincipalTags].(map[string]interface{}); ok {
+		t.tags = make(map[string]string, len(principalTags))
+		for k, v := range principalTags {
+			switch tv := v.(type) {
+			case string:
+				t.tags[k] = tv
+			case []interface{}:
+				if len(tv) > 0 {
+					t.tags[k] = fmt.Sprint(tv[0])
+				}
+			default:
+				return t, fmt.Errorf("Invalid value of session tag '%s'", k)
+			}
+		}
+	}
+	if transitiveKeys, ok := tagsClaim[webIdentityTransitiveTag].([]interface{}); ok {
+		for _, k := range transitiveKeys {
+			t.transitiveKeys = append(t.transitiveKeys, fmt.Sprint(k))
+		}
+	}
+	return t, t.validate()
+}
+
+// sessionTagsFromForm parses the session tags passed as parameters of
+// an AssumeRole request.
+func sessionTagsFromForm(form url.Values) (t sessionTags, err error) {
+	for i := 1; ; i++ {
+		k, ok := form[fmt.Sprintf(stsTagKeyFmt, i)]
+		if !ok {
+			break
+		}
+		if len(t.tags) == maxSessionTags {
+			return t, fmt.Errorf("Session tags cannot exceed %d tags", maxSessionTags)
+		}
+		if t.tags == nil {
+			t.tags = make(map[string]string)
+		}
+		t.tags[k[0]] = form.Get(fmt.Sprintf(stsTagValueFmt, i))
+	}
+	for i := 1; ; i++ {
+		k, ok := form[fmt.Sprintf(stsTransitiveTagKeyFmt, i)]
+		if !ok {
+			break
+		}
+		t.transitiveKeys = append(t.transitiveKeys, k[0])
+	}
+	return t, t.validate()
+}
+
+// merge adds the session tags of the request to the transitive session
+// tags of the calling session, which cannot be replaced.
+func (t sessionTags) merge(req sessionTags) (sessionTags, error) {
+	if t.isEmpty() {
+		return req, nil
+	}
+	merged := sessionTags{
+		tags:           make(map[string]string, len(t.tags)+len(req.tags)),
+		transitiveKeys: append([]string{}, t.transitiveKeys...),
+	}
+	for k, v := range t.tags {
+		merged.tags[k] = v
+	}
+	for k, v := range req.tags {
+		for pk := range t.tags {
+			if strings.EqualFold(k, pk) {
+				return merged, fmt.Errorf("Transitive session tag '%s' of the calling session cannot be replaced", pk)
+			}
+		}
+		merged.tags[k] = v
+	}
+	for _, k := range req.transitiveKeys {
+		if _, ok := t.tags[k]; !ok {
+			merged.transitiveKeys = append(merged.transitiveKeys, k)
+		}
+	}
+	return merged, merged.validate()
+}
+
+// sessionTagsFromClaims returns the session tags of temporary
+// credentials.
+func sessionTagsFromClaims(claims map[string]interface{}) (t sessionTags) {
+	switch tags := claims[sessionTagsClaim].(type) {
+	case map[string]interface{}:
+		t.tags = make(map[string]string, len(tags))
+		for k, v := range tags {
+			if s, ok := v.(string); ok {
+				t.tags[k] = s
+			}
+		}
+	case map[string]string:
+		t.tags = tags
+	}
+	switch keys := claims[transitiveTagKeysClaim].(type) {
+	case []interface{}:
+		for _, k := range keys {
+			if s, ok := k.(string); ok {
+				t.transitiveKeys = append(t.transitiveKeys, s)
+			}
+		}
+	case []string:
+		t.transitiveKeys = keys
+	}
+	return t
+}
+
+// setPrincipalTagValues sets the session tags of the claims as the
+// aws:PrincipalTag/<key> condition values, removing any value of this
+// form not set by the claims.
+func setPrincipalTagValues(args map[string][]string, claims map[string]interface{}) {
+	for k := range args {
+		if strings.HasPrefix(strings.ToLower(k), strings.ToLower(principalTagPrefix)) {
+			delete(args, k)
+		}
+	}
+	for k, v := range sessionTagsFromClaims(claims).tags {
+		args[principalTagPrefix+k] = []string{v}
+	}
+}
+
+// chainedSessionExpiry returns the expiry of credentials obtained by
+// temporary credentials, which cannot outlive the calling session.
+func chainedSessionExpiry(claims map[string]interface{}, expiry int64) int64 {
+	if v, ok := claims[expClaim]; ok {
+		if parentExpiry, err := auth.ExpToInt64(v); err == nil && parentExpiry < expiry {
+			return parentExpiry
+		}
+	}
+	return expiry
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSessionTagsFromWebIdentity(t *testing.T) {
+	var claims map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+  "sub": "johndoe",
+  "https://aws.amazon.com/tags": {
+    "principal_tags": {
+      "Project": ["Automation"],
+      "CostCenter": ["987654", "123456"],
+      "Team": "Storage"
+    },
+    "transitive_tag_keys": ["Project"]
+  }
+}`), &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := sessionTagsFromWebIdentity(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"Project": "Automation", "CostCenter": "987654", "Team": "Storage"}
+	if !reflect.DeepEqual(tags.tags, expected) {
+		t.Fatalf("expected %v, got %v", expected, tags.tags)
+	}
+
+	// Session tags are kept in the claims of the credentials and only
+	// the transitive ones are passed on to chained sessions.
+	m := make(map[string]interface{})
+	tags.setClaims(m)
+	buf, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = make(map[string]interface{})
+	if err = json.Unmarshal(buf, &m); err != nil {
+		t.Fatal(err)
+	}
+	chained := sessionTagsFromClaims(m).transitive()
+	if !reflect.DeepEqual(chained.tags, map[string]string{"Project": "Automation"}) {
+		t.Fatalf("unexpected transitive tags %v", chained.tags)
+	}
+	if !reflect.DeepEqual(chained.transitiveKeys, []string{"Project"}) {
+		t.Fatalf("unexpected transitive keys %v", chained.transitiveKeys)
+	}
+
+	testCases := []string{
+		`{"https://aws.amazon.com/tags": "Project"}`,
+		`{"https://aws.amazon.com/tags": {"principal_tags": {"Project": 1}}}`,
+		`{"https://aws.amazon.com/tags": {"principal_tags": {"Project": "a", "project": "b"}}}`,
+		`{"https://aws.amazon.com/tags": {"principal_tags": {"Project": "a"}, "transitive_tag_keys": ["Team"]}}`,
+	}
+	for i, testCase := range testCases {
+		var claims map[string]interface{}
+		if err := json.Unmarshal([]byte(testCase), &claims); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sessionTagsFromWebIdentity(claims); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestSessionTagsFromForm(t *testing.T) {
+	form := url.Values{
+		"Tags.member.1.Key":          []string{"Team"},
+		"Tags.member.1.Value":        []string{"Storage"},
+		"Tags.member.2.Key":          []string{"Project"},
+		"Tags.member.2.Value":        []string{"Automation"},
+		"TransitiveTagKeys.member.1": []string{"Project"},
+	}
+	tags, err := sessionTagsFromForm(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"Team": "Storage", "Project": "Automation"}
+	if !reflect.DeepEqual(tags.tags, expected) {
+		t.Fatalf("expected %v, got %v", expected, tags.tags)
+	}
+
+	// Transitive tags of the calling session are kept and cannot be
+	// replaced by the tags of a chained session.
+	merged, err := tags.transitive().merge(sessionTags{tags: map[string]string{"Team": "Compute"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = map[string]string{"Team": "Compute", "Project": "Automation"}
+	if !reflect.DeepEqual(merged.tags, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged.tags)
+	}
+	if _, err = tags.transitive().merge(sessionTags{tags: map[string]string{"project": "Other"}}); err == nil {
+		t.Fatal("expected the transitive tag to not be replaced")
+	}
+
+	// Transitive keys must be keys of the tags.
+	form.Set("TransitiveTagKeys.member.2", "CostCenter")
+	if _, err = sessionTagsFromForm(form); err == nil {
+		t.Fatal("expected an error for a transitive key without a tag")
+	}
+}
+
+func TestSetPrincipalTagValues(t *testing.T) {
+	claims := make(map[string]interface{})
+	sessionTags{tags: map[string]string{"Team": "Storage"}}.setClaims(claims)
+
+	// Values not set by the session tags of the credentials are
+	// removed, so that they cannot be sent by clients.
+	args := map[string][]string{
+		"PrincipalTag/Team":    {"Finance"},
+		"principaltag/Project": {"Automation"},
+		"username":             {"johndoe"},
+	}
+	setPrincipalTagValues(args, claims)
+	expected := map[string][]string{
+		"PrincipalTag/Team": {"Storage"},
+		"username":          {"johndoe"},
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestChainedSessionExpiry(t *testing.T) {
+	claims := map[string]interface{}{expClaim: float64(1000)}
+	if expiry := chainedSessionExpiry(claims, 2000); expiry != 1000 {
+		t.Errorf("expected 1000, got %d", expiry)
+	}
+	if expiry := chainedSessionExpiry(claims, 500); expiry != 500 {
+		t.Errorf("expected 500, got %d", expiry)
+	}
+}
@@ -825,6 +825,62 @@ func newTestStreamingSignedRequest(method, urlStr string, contentLength, chunkSi
 	return req, err
 }
 
+// Returns new HTTP request object with an "aws-chunked" payload followed by
+// the trailer, the chunks and the trailer are signed with streaming signature
+// v4 if signed is set, otherwise only the request is signed.
+func newTestStreamingTrailerRequest(method, urlStr string, data []byte, chunkSize int, trailer string, signed bool, accessKey, secretKey string) (*http.Request, error) {
+	req, err := http.NewRequest(method, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	payload := unsignedPayloadTrailer
+	if signed {
+		payload = streamingContentSHA256Trailer
+	}
+	req.Header.Set("x-amz-content-sha256", payload)
+	req.Header.Set("content-encoding", "aws-chunked")
+	req.Header.Set("x-amz-decoded-content-length", strconv.Itoa(len(data)))
+	req.Header.Set("x-amz-trailer", strings.SplitN(trailer, ":", 2)[0])
+
+	currTime := UTCNow()
+	signature, err := signStreamingRequest(req, accessKey, secretKey, currTime)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := auth.Credentials{AccessKey: accessKey, SecretKey: secretKey}
+	var stream bytes.Buffer
+	for {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+		chunk := data[:n]
+		data = data[n:]
+		if signed {
+			signature = getChunkSignature(cred, signature, globalSite.Region, currTime, getSHA256Hash(chunk))
+			fmt.Fprintf(&stream, "%x;chunk-signature=%s\r\n", n, signature)
+		} else {
+			fmt.Fprintf(&stream, "%x\r\n", n)
+		}
+		if n == 0 {
+			break
+		}
+		stream.Write(chunk)
+		stream.WriteString("\r\n")
+	}
+	stream.WriteString(trailer + "\r\n")
+	if signed {
+		signature = getTrailerChunkSignature(cred, signature, globalSite.Region, currTime, getSHA256Hash([]byte(trailer+"\n")))
+		stream.WriteString(s3TrailerSignatureStr + signature + "\r\n")
+	}
+	stream.WriteString("\r\n")
+
+	req.ContentLength = int64(stream.Len())
+	req.Body = ioutil.NopCloser(&stream)
+	return req, nil
+}
+
 // preSignV4 presign the request, in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
 func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires int64) error {
@@ -1344,6 +1400,13 @@ func getBucketLifecycleURL(endPoint, bucketName string) (ret string) {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL For set/get versioning of the bucket.
+func getBucketVersioningURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("versioning", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for listing objects in the bucket with V1 legacy API.
 func getListObjectsV1URL(endPoint, bucketName, prefix, maxKeys, encodingType string) string {
 	queryValue := url.Values{}
@@ -1490,7 +1553,7 @@ func initObjectLayer(ctx context.Context, endpointServerPools EndpointServerPool
 	var formattedDisks []StorageAPI
 	// Should use the object layer tests for validating cache.
 	if z, ok := objLayer.(*erasureServerPools); ok {
-		formattedDisks = z.serverPools[0].GetDisks(0)()
+		formattedDisks = z.getServerPools()[0].GetDisks(0)()
 	}
 
 	// Success.
@@ -1938,6 +2001,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "DeleteObject":
 			// Register Delete Object handler.
 			bucket.Methods(http.MethodDelete).Path("/{object:.+}").HandlerFunc(api.DeleteObjectHandler)
+		case "PutObjectExtract":
+			// Register PutObject handler extracting archives.
+			bucket.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp("X-Amz-Meta-Snowball-Auto-Extract", "true").HandlerFunc(api.PutObjectExtractHandler)
 		case "CopyObject":
 			// Register Copy Object  handler.
 			bucket.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectHandler)
@@ -1950,6 +2016,18 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "GetBucketPolicy":
 			// Register Get Bucket policy HTTP Handler.
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketPolicyHandler).Queries("policy", "")
+		case "PutBucketLogging":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLoggingHandler).Queries("logging", "")
+		case "GetBucketLogging":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLoggingHandler).Queries("logging", "")
+		case "PutPublicAccessBlock":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutPublicAccessBlockHandler).Queries("publicAccessBlock", "")
+		case "GetPublicAccessBlock":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetPublicAccessBlockHandler).Queries("publicAccessBlock", "")
+		case "DeletePublicAccessBlock":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeletePublicAccessBlockHandler).Queries("publicAccessBlock", "")
+		case "PutBucketVersioning":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketVersioningHandler).Queries("versioning", "")
 		case "GetBucketLifecycle":
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLifecycleHandler).Queries("lifecycle", "")
 		case "PutBucketLifecycle":
@@ -2297,7 +2375,7 @@ func uploadTestObject(t *testing.T, apiRouter http.Handler, creds auth.Credentia
 				if etag == "" {
 					t.Fatalf("Unexpected empty etag")
 				}
-				cp = append(cp, CompletePart{partID, etag[1 : len(etag)-1]})
+				cp = append(cp, CompletePart{PartNumber: partID, ETag: etag[1 : len(etag)-1]})
 			} else {
 				t.Fatalf("Missing etag header")
 			}
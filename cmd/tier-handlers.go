@@ -234,3 +234,29 @@ func (api adminAPIHandlers) TierStatsHandler(w http.ResponseWriter, r *http.Requ
 	}
 	writeSuccessResponseJSON(w, data)
 }
+
+// TierStatusHandler returns the transition statistics and the queue of
+// failed transitions of each remote tier across the cluster.
+func (api adminAPIHandlers) TierStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalNotificationSys.GetTierTransitionStatus(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// transitionErrorQueueSize is the maximum number of failed transitions
+	// held in the error queue, the oldest are dropped first.
+	transitionErrorQueueSize = 1000
+
+	// transitionMaxRetries is the number of times a failed transition is
+	// retried before it is left to the next scanner cycle.
+	transitionMaxRetries = 3
+
+	// transitionRetryBackoff is the delay before the first retry of a
+	// failed transition, doubled for every following retry.
+	transitionRetryBackoff = time.Minute
+)
+
+// TransitionError is a failed transition held in the error queue.
+type TransitionError struct {
+	Node      string    `json:"node,omitempty"`
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	Tier      string    `json:"tier"`
+	Error     string    `json:"error"`
+	Time      time.Time `json:"time"`
+	Retries   int       `json:"retries"`
+	// NextRetry is zero once all retries are exhausted.
+	NextRetry time.Time `json:"nextRetry,omitempty"`
+}
+
+// TierTransitionStatus holds the transition statistics of a remote tier.
+type TierTransitionStatus struct {
+	Tier              string            `json:"tier"`
+	Transitioned      uint64            `json:"transitioned"`
+	TransitionedBytes uint64            `json:"transitionedBytes"`
+	Failed            uint64            `json:"failed"`
+	FailedBytes       uint64            `json:"failedBytes"`
+	Pending           int64             `json:"pending"`
+	PendingBytes      int64             `json:"pendingBytes"`
+	Errors            []TransitionError `json:"errors,omitempty"`
+}
+
+func (s *TierTransitionStatus) merge(o TierTransitionStatus) {
+	s.Transitioned += o.Transitioned
+	s.TransitionedBytes += o.TransitionedBytes
+	s.Failed += o.Failed
+	s.FailedBytes += o.FailedBytes
+	s.Pending += o.Pending
+	s.PendingBytes += o.PendingBytes
+	s.Errors = append(s.Errors, o.Errors...)
+}
+
+// transitionFailure is an entry of the error queue.
+type transitionFailure struct {
+	TransitionError
+	oi ObjectInfo
+	// retrying is set while the failed transition is queued again.
+	retrying bool
+}
+
+// transitionStats tracks the transitions of this node per remote tier.
+type transitionStats struct {
+	mu    sync.Mutex
+	tiers map[string]*TierTransitionStatus
+	// failures is the error queue, oldest first.
+	failures []*transitionFailure
+}
+
+func newTransitionStats() *transitionStats {
+	return &transitionStats{
+		tiers: make(map[string]*TierTransitionStatus),
+	}
+}
+
+func (s *transitionStats) tier(name string) *TierTransitionStatus {
+	ts, ok := s.tiers[name]
+	if !ok {
+		ts = &TierTransitionStatus{Tier: name}
+		s.tiers[name] = ts
+	}
+	return ts
+}
+
+// queued records a transition of oi to tier waiting for a worker.
+func (s *transitionStats) queued(tier string, oi ObjectInfo) {
+	if tier == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := s.tier(tier)
+	ts.Pending++
+	ts.PendingBytes += oi.Size
+}
+
+// done records the outcome of the transition of oi to tier.
+func (s *transitionStats) done(tier string, oi ObjectInfo, err error) {
+	if tier == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := s.tier(tier)
+	ts.Pending--
+	ts.PendingBytes -= oi.Size
+
+	idx := -1
+	for i, f := range s.failures {
+		if f.Bucket == oi.Bucket && f.Object == oi.Name && f.VersionID == oi.VersionID {
+			idx = i
+			break
+		}
+	}
+	if err == nil {
+		ts.Transitioned++
+		ts.TransitionedBytes += uint64(oi.Size)
+		if idx >= 0 {
+			s.failures = append(s.failures[:idx], s.failures[idx+1:]...)
+		}
+		return
+	}
+
+	ts.Failed++
+	ts.FailedBytes += uint64(oi.Size)
+	f := &transitionFailure{
+		TransitionError: TransitionError{
+			Bucket:    oi.Bucket,
+			Object:    oi.Name,
+			VersionID: oi.VersionID,
+		},
+	}
+	if idx >= 0 {
+		f = s.failures[idx]
+		f.Retries++
+		s.failures = append(s.failures[:idx], s.failures[idx+1:]...)
+	}
+	now := UTCNow()
+	f.oi = oi
+	f.retrying = false
+	f.Tier = tier
+	f.Error = err.Error()
+	f.Time = now
+	f.NextRetry = time.Time{}
+	if f.Retries < transitionMaxRetries {
+		f.NextRetry = now.Add(transitionRetryBackoff << uint(f.Retries))
+	}
+	if len(s.failures) >= transitionErrorQueueSize {
+		s.failures = s.failures[1:]
+	}
+	s.failures = append(s.failures, f)
+}
+
+// dueRetries returns the failed transitions due for a retry at now.
+func (s *transitionStats) dueRetries(now time.Time) []ObjectInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var objs []ObjectInfo
+	for _, f := range s.failures {
+		if f.retrying || f.NextRetry.IsZero() || f.NextRetry.After(now) {
+			continue
+		}
+		f.retrying = true
+		objs = append(objs, f.oi)
+	}
+	return objs
+}
+
+// retryLater marks the retry of oi as not queued, it is attempted again
+// in the next round.
+func (s *transitionStats) retryLater(oi ObjectInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.failures {
+		if f.Bucket == oi.Bucket && f.Object == oi.Name && f.VersionID == oi.VersionID {
+			f.retrying = false
+			return
+		}
+	}
+}
+
+// retry queues the failed transitions again as they fall due.
+func (s *transitionStats) retry(ctx context.Context, t *transitionState) {
+	ticker := time.NewTicker(transitionRetryBackoff / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, oi := range s.dueRetries(UTCNow()) {
+				if !t.queueTransitionTask(oi) {
+					s.retryLater(oi)
+				}
+			}
+		}
+	}
+}
+
+// status returns the transition statistics of this node sorted by tier.
+func (s *transitionStats) status() []TierTransitionStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]TierTransitionStatus, 0, len(s.tiers))
+	idx := make(map[string]int, len(s.tiers))
+	for _, ts := range s.tiers {
+		st := *ts
+		st.Errors = nil
+		statuses = append(statuses, st)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Tier < statuses[j].Tier
+	})
+	for i, st := range statuses {
+		idx[st.Tier] = i
+	}
+	for _, f := range s.failures {
+		i, ok := idx[f.Tier]
+		if !ok {
+			continue
+		}
+		statuses[i].Errors = append(statuses[i].Errors, f.TransitionError)
+	}
+	return statuses
+}
+
+// mergeTierTransitionStatus merges the transition statistics reported by
+// nodes into one entry per tier, sorted by tier.
+func mergeTierTransitionStatus(nodes map[string][]TierTransitionStatus) []TierTransitionStatus {
+	merged := make(map[string]*TierTransitionStatus)
+	for node, statuses := range nodes {
+		for _, st := range statuses {
+			for i := range st.Errors {
+				st.Errors[i].Node = node
+			}
+			m, ok := merged[st.Tier]
+			if !ok {
+				m = &TierTransitionStatus{Tier: st.Tier}
+				merged[st.Tier] = m
+			}
+			m.merge(st)
+		}
+	}
+	statuses := make([]TierTransitionStatus, 0, len(merged))
+	for _, m := range merged {
+		sort.Slice(m.Errors, func(i, j int) bool {
+			return m.Errors[i].Time.Before(m.Errors[j].Time)
+		})
+		statuses = append(statuses, *m)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Tier < statuses[j].Tier
+	})
+	return statuses
+}
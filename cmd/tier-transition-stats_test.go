@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTransitionStats(t *testing.T) {
+	s := newTransitionStats()
+	obj1 := ObjectInfo{Bucket: "bucket", Name: "obj1", VersionID: "v1", Size: 100}
+	obj2 := ObjectInfo{Bucket: "bucket", Name: "obj2", Size: 10}
+
+	s.queued("WARM-1", obj1)
+	s.queued("WARM-1", obj2)
+	s.queued("", obj2)
+	st := s.status()
+	if len(st) != 1 || st[0].Pending != 2 || st[0].PendingBytes != 110 {
+		t.Fatalf("unexpected pending status %#v", st)
+	}
+
+	s.done("WARM-1", obj1, errors.New("remote tier offline"))
+	s.done("WARM-1", obj2, nil)
+	st = s.status()
+	if st[0].Pending != 0 || st[0].Transitioned != 1 || st[0].TransitionedBytes != 10 || st[0].Failed != 1 || st[0].FailedBytes != 100 {
+		t.Fatalf("unexpected status %#v", st)
+	}
+	if len(st[0].Errors) != 1 || st[0].Errors[0].Object != "obj1" || st[0].Errors[0].Retries != 0 {
+		t.Fatalf("unexpected errors %#v", st[0].Errors)
+	}
+
+	// Not due yet
+	if objs := s.dueRetries(UTCNow()); len(objs) != 0 {
+		t.Fatalf("expected no retries, got %d", len(objs))
+	}
+	objs := s.dueRetries(UTCNow().Add(transitionRetryBackoff + time.Second))
+	if len(objs) != 1 || objs[0].Name != "obj1" {
+		t.Fatalf("expected obj1 to be retried, got %#v", objs)
+	}
+	// Already being retried
+	if objs := s.dueRetries(UTCNow().Add(transitionRetryBackoff + time.Second)); len(objs) != 0 {
+		t.Fatalf("expected no retries, got %d", len(objs))
+	}
+
+	// Retries are exhausted after transitionMaxRetries failures.
+	for i := 0; i < transitionMaxRetries; i++ {
+		s.queued("WARM-1", obj1)
+		s.done("WARM-1", obj1, errors.New("remote tier offline"))
+	}
+	st = s.status()
+	if len(st[0].Errors) != 1 || st[0].Errors[0].Retries != transitionMaxRetries || !st[0].Errors[0].NextRetry.IsZero() {
+		t.Fatalf("unexpected errors %#v", st[0].Errors)
+	}
+
+	// A successful transition removes the object from the error queue.
+	s.queued("WARM-1", obj1)
+	s.done("WARM-1", obj1, nil)
+	if st = s.status(); len(st[0].Errors) != 0 {
+		t.Fatalf("expected empty error queue, got %#v", st[0].Errors)
+	}
+
+	merged := mergeTierTransitionStatus(map[string][]TierTransitionStatus{
+		"node1": {{Tier: "WARM-2", Transitioned: 1}, {Tier: "WARM-1", Failed: 1, Errors: []TransitionError{{Object: "obj"}}}},
+		"node2": {{Tier: "WARM-1", Transitioned: 2, Failed: 1}},
+	})
+	if len(merged) != 2 || merged[0].Tier != "WARM-1" || merged[0].Transitioned != 2 || merged[0].Failed != 2 {
+		t.Fatalf("unexpected merged status %#v", merged)
+	}
+	if len(merged[0].Errors) != 1 || merged[0].Errors[0].Node != "node1" {
+		t.Fatalf("unexpected merged errors %#v", merged[0].Errors)
+	}
+}
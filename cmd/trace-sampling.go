@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/minio/madmin-go"
+)
+
+// traceOptions - options of a trace subscriber, extends
+// madmin.ServiceTraceOpts with per request sampling controls.
+type traceOptions struct {
+	madmin.ServiceTraceOpts
+
+	// Fraction of the HTTP requests traced, 1 traces every request.
+	SampleRate float64
+	// Only trace these APIs, either qualified like "s3.GetObject"
+	// or just "GetObject". Empty traces all APIs.
+	APIs []string
+}
+
+// tracesHTTP returns true if HTTP requests are traced at all.
+func (o traceOptions) tracesHTTP() bool {
+	return (o.S3 || o.Internal) && o.SampleRate > 0
+}
+
+// matchAPI returns true if the API name as returned
+// by getOpName is to be traced.
+func (o traceOptions) matchAPI(name string) bool {
+	if len(o.APIs) == 0 {
+		return true
+	}
+	short := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		short = name[i+1:]
+	}
+	for _, api := range o.APIs {
+		if api == name || api == short {
+			return true
+		}
+	}
+	return false
+}
+
+// traceSampler keeps track of the options of all local trace
+// subscribers, the HTTP tracing middleware consults it to skip
+// recording requests no subscriber is going to look at.
+type traceSampler struct {
+	mu   sync.RWMutex
+	subs map[*traceOptions]struct{}
+}
+
+var globalTraceSampler = &traceSampler{subs: make(map[*traceOptions]struct{})}
+
+// register adds the options of a trace subscriber until doneCh is closed.
+func (s *traceSampler) register(opts *traceOptions, doneCh <-chan struct{}) {
+	s.mu.Lock()
+	s.subs[opts] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-doneCh
+		s.mu.Lock()
+		delete(s.subs, opts)
+		s.mu.Unlock()
+	}()
+}
+
+// sampleRate returns the highest sample rate among the subscribers
+// tracing the API, 0 if none of them does.
+func (s *traceSampler) sampleRate(name string) (rate float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for opts := range s.subs {
+		if opts.tracesHTTP() && opts.SampleRate > rate && opts.matchAPI(name) {
+			rate = opts.SampleRate
+		}
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	return rate
+}
+
+// sample decides whether a request to the API is to be traced.
+func (s *traceSampler) sample(name string) bool {
+	rate := s.sampleRate(name)
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// sampled decides whether a subscriber with the sample rate keeps an HTTP
+// trace of the API. The middleware already traced the request with the
+// highest sample rate of all subscribers, so the trace is kept with the
+// ratio of both to trace the expected fraction of requests in total.
+func (s *traceSampler) sampled(name string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	traced := s.sampleRate(name)
+	if traced <= rate {
+		return true
+	}
+	return rand.Float64() < rate/traced
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestParseTraceSampling(t *testing.T) {
+	testCases := []struct {
+		sample, apis string
+		rate         float64
+		names        []string
+		shouldFail   bool
+	}{
+		{"", "", 1, nil, false},
+		{"0.25", "", 0.25, nil, false},
+		{"1", "s3.GetObject, PutObject,", 1, []string{"s3.GetObject", "PutObject"}, false},
+		{"0", "", 0, nil, true},
+		{"1.5", "", 0, nil, true},
+		{"abc", "", 0, nil, true},
+	}
+	for i, testCase := range testCases {
+		rate, names, err := parseTraceSampling(testCase.sample, testCase.apis)
+		if testCase.shouldFail {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if rate != testCase.rate || !reflect.DeepEqual(names, testCase.names) {
+			t.Errorf("Test %d: expected %v %v, got %v %v", i+1, testCase.rate, testCase.names, rate, names)
+		}
+	}
+}
+
+func TestTraceSampler(t *testing.T) {
+	sampler := &traceSampler{subs: make(map[*traceOptions]struct{})}
+	if sampler.sample("s3.GetObject") {
+		t.Fatal("expected no request to be traced without subscribers")
+	}
+
+	doneCh := make(chan struct{})
+	all := traceOptions{ServiceTraceOpts: madmin.ServiceTraceOpts{S3: true}, SampleRate: 0.1}
+	sampler.register(&all, doneCh)
+	gets := traceOptions{ServiceTraceOpts: madmin.ServiceTraceOpts{S3: true}, SampleRate: 0.5, APIs: []string{"GetObject"}}
+	sampler.register(&gets, doneCh)
+	storage := traceOptions{ServiceTraceOpts: madmin.ServiceTraceOpts{Storage: true}, SampleRate: 1}
+	sampler.register(&storage, doneCh)
+
+	if rate := sampler.sampleRate("s3.GetObject"); rate != 0.5 {
+		t.Errorf("expected sample rate 0.5, got %v", rate)
+	}
+	if rate := sampler.sampleRate("s3.PutObject"); rate != 0.1 {
+		t.Errorf("expected sample rate 0.1, got %v", rate)
+	}
+
+	var traced int
+	for i := 0; i < 10000; i++ {
+		if sampler.sample("s3.PutObject") {
+			traced++
+		}
+	}
+	if traced < 700 || traced > 1300 {
+		t.Errorf("expected about 1000 of 10000 requests traced, got %d", traced)
+	}
+
+	close(doneCh)
+}
+
+func TestMustTraceAPIs(t *testing.T) {
+	opts := traceOptions{ServiceTraceOpts: madmin.ServiceTraceOpts{S3: true}, SampleRate: 1, APIs: []string{"s3.GetObject"}}
+	entry := func(name string, status int) madmin.TraceInfo {
+		return madmin.TraceInfo{
+			TraceType: madmin.TraceHTTP,
+			FuncName:  name,
+			ReqInfo:   madmin.TraceRequestInfo{Path: "/bucket/object"},
+			RespInfo:  madmin.TraceResponseInfo{StatusCode: status},
+		}
+	}
+	if !mustTrace(entry("s3.GetObject", http.StatusOK), opts) {
+		t.Error("expected s3.GetObject to be traced")
+	}
+	if mustTrace(entry("s3.PutObject", http.StatusOK), opts) {
+		t.Error("expected s3.PutObject not to be traced")
+	}
+	opts.OnlyErrors = true
+	if mustTrace(entry("s3.GetObject", http.StatusOK), opts) {
+		t.Error("expected successful s3.GetObject not to be traced")
+	}
+	if !mustTrace(entry("s3.GetObject", http.StatusNotFound), opts) {
+		t.Error("expected failed s3.GetObject to be traced")
+	}
+}
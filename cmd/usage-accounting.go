@@ -0,0 +1,305 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	usageAccountingDir          = ".usage-accounting"
+	usageAccountingSaveInterval = 5 * time.Minute
+)
+
+// APIUsage - number of requests and bytes transferred for an S3 API.
+type APIUsage struct {
+	Requests    uint64 `json:"requests"`
+	InputBytes  uint64 `json:"rxBytes"`
+	OutputBytes uint64 `json:"txBytes"`
+}
+
+func (u *APIUsage) add(o APIUsage) {
+	u.Requests += o.Requests
+	u.InputBytes += o.InputBytes
+	u.OutputBytes += o.OutputBytes
+}
+
+// AccessKeyUsage - usage of the S3 APIs by an access key, User is
+// the parent user of service accounts and temporary credentials.
+type AccessKeyUsage struct {
+	User string              `json:"user,omitempty"`
+	APIs map[string]APIUsage `json:"apis"`
+}
+
+// UsageAccountingSnapshot - usage of the S3 APIs by access key since
+// the accounting started.
+type UsageAccountingSnapshot struct {
+	Since      time.Time                 `json:"since"`
+	AccessKeys map[string]AccessKeyUsage `json:"accessKeys"`
+}
+
+// merge adds the usage of another snapshot to s.
+func (s *UsageAccountingSnapshot) merge(o UsageAccountingSnapshot) {
+	if s.Since.IsZero() || (!o.Since.IsZero() && o.Since.Before(s.Since)) {
+		s.Since = o.Since
+	}
+	if s.AccessKeys == nil {
+		s.AccessKeys = make(map[string]AccessKeyUsage, len(o.AccessKeys))
+	}
+	for accessKey, ou := range o.AccessKeys {
+		u, ok := s.AccessKeys[accessKey]
+		if !ok {
+			u = AccessKeyUsage{User: ou.User, APIs: make(map[string]APIUsage, len(ou.APIs))}
+		}
+		for api, oa := range ou.APIs {
+			a := u.APIs[api]
+			a.add(oa)
+			u.APIs[api] = a
+		}
+		s.AccessKeys[accessKey] = u
+	}
+}
+
+// UsageAccountingReport - cluster-wide usage of the S3 APIs by access
+// key, and by user including their service accounts and temporary
+// credentials.
+type UsageAccountingReport struct {
+	UsageAccountingSnapshot
+	Users map[string]map[string]APIUsage `json:"users"`
+}
+
+// newUsageAccountingReport totals the usage of the snapshot by user.
+func newUsageAccountingReport(s UsageAccountingSnapshot) UsageAccountingReport {
+	r := UsageAccountingReport{
+		UsageAccountingSnapshot: s,
+		Users:                   make(map[string]map[string]APIUsage),
+	}
+	for accessKey, u := range s.AccessKeys {
+		user := u.User
+		if user == "" {
+			user = accessKey
+		}
+		apis, ok := r.Users[user]
+		if !ok {
+			apis = make(map[string]APIUsage, len(u.APIs))
+			r.Users[user] = apis
+		}
+		for api, ua := range u.APIs {
+			a := apis[api]
+			a.add(ua)
+			apis[api] = a
+		}
+	}
+	return r
+}
+
+// apiUsageCounters - counters of an API updated without locking.
+type apiUsageCounters struct {
+	requests    uint64
+	inputBytes  uint64
+	outputBytes uint64
+}
+
+type accessKeyUsageCounters struct {
+	user string
+	apis map[string]*apiUsageCounters
+}
+
+// usageAccounting - counts the requests and bytes transferred by access
+// key and S3 API on this node.
+type usageAccounting struct {
+	mu    sync.RWMutex
+	since time.Time
+	keys  map[string]*accessKeyUsageCounters
+}
+
+func newUsageAccounting() *usageAccounting {
+	return &usageAccounting{
+		since: UTCNow(),
+		keys:  make(map[string]*accessKeyUsageCounters),
+	}
+}
+
+var globalUsageAccounting = newUsageAccounting()
+
+// counters returns the counters of the API for the access key,
+// creating them if needed.
+func (u *usageAccounting) counters(accessKey, user, api string) *apiUsageCounters {
+	u.mu.RLock()
+	k, ok := u.keys[accessKey]
+	if ok {
+		if c, ok := k.apis[api]; ok {
+			u.mu.RUnlock()
+			return c
+		}
+	}
+	u.mu.RUnlock()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	k, ok = u.keys[accessKey]
+	if !ok {
+		k = &accessKeyUsageCounters{user: user, apis: make(map[string]*apiUsageCounters)}
+		u.keys[accessKey] = k
+	}
+	c, ok := k.apis[api]
+	if !ok {
+		c = &apiUsageCounters{}
+		k.apis[api] = c
+	}
+	return c
+}
+
+// record counts a request of the API by the access key, anonymous
+// requests are not accounted.
+func (u *usageAccounting) record(accessKey, user, api string, inputBytes, outputBytes int64) {
+	if accessKey == "" {
+		return
+	}
+	if user == accessKey {
+		user = ""
+	}
+	c := u.counters(accessKey, user, api)
+	atomic.AddUint64(&c.requests, 1)
+	if inputBytes > 0 {
+		atomic.AddUint64(&c.inputBytes, uint64(inputBytes))
+	}
+	if outputBytes > 0 {
+		atomic.AddUint64(&c.outputBytes, uint64(outputBytes))
+	}
+}
+
+// recordRequest counts a request of the API with the credentials in its
+// signature.
+func (u *usageAccounting) recordRequest(r *http.Request, api string, inputBytes, outputBytes int64) {
+	cred := getReqAccessCred(r, globalSite.Region)
+	user := cred.AccessKey
+	if cred.ParentUser != "" {
+		user = cred.ParentUser
+	}
+	u.record(cred.AccessKey, user, api, inputBytes, outputBytes)
+}
+
+// snapshot returns a copy of the usage counted on this node.
+func (u *usageAccounting) snapshot() UsageAccountingSnapshot {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	s := UsageAccountingSnapshot{
+		Since:      u.since,
+		AccessKeys: make(map[string]AccessKeyUsage, len(u.keys)),
+	}
+	for accessKey, k := range u.keys {
+		ku := AccessKeyUsage{User: k.user, APIs: make(map[string]APIUsage, len(k.apis))}
+		for api, c := range k.apis {
+			ku.APIs[api] = APIUsage{
+				Requests:    atomic.LoadUint64(&c.requests),
+				InputBytes:  atomic.LoadUint64(&c.inputBytes),
+				OutputBytes: atomic.LoadUint64(&c.outputBytes),
+			}
+		}
+		s.AccessKeys[accessKey] = ku
+	}
+	return s
+}
+
+// restore adds the usage of a snapshot saved before a restart to the
+// usage counted since.
+func (u *usageAccounting) restore(s UsageAccountingSnapshot) {
+	u.mu.Lock()
+	if !s.Since.IsZero() && s.Since.Before(u.since) {
+		u.since = s.Since
+	}
+	u.mu.Unlock()
+
+	for accessKey, ku := range s.AccessKeys {
+		for api, a := range ku.APIs {
+			c := u.counters(accessKey, ku.User, api)
+			atomic.AddUint64(&c.requests, a.Requests)
+			atomic.AddUint64(&c.inputBytes, a.InputBytes)
+			atomic.AddUint64(&c.outputBytes, a.OutputBytes)
+		}
+	}
+}
+
+// usageAccountingPath returns the path in the meta bucket where this
+// node persists its usage accounting.
+func usageAccountingPath() string {
+	return path.Join(bucketMetaPrefix, usageAccountingDir, fmt.Sprintf("%x.json", xxhash.Sum64String(globalLocalNodeName)))
+}
+
+// save persists the usage counted on this node.
+func (u *usageAccounting) save(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := json.Marshal(u.snapshot())
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, usageAccountingPath(), data)
+}
+
+// load restores the usage persisted by this node before it was
+// restarted.
+func (u *usageAccounting) load(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := readConfig(ctx, objAPI, usageAccountingPath())
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var s UsageAccountingSnapshot
+	if err = json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	u.restore(s)
+	return nil
+}
+
+// persist loads the usage saved before the last restart and then
+// periodically saves the usage until ctx is canceled.
+func (u *usageAccounting) persist(ctx context.Context, objAPI ObjectLayer) {
+	logger.LogIf(ctx, u.load(ctx, objAPI))
+
+	sTimer := time.NewTimer(usageAccountingSaveInterval)
+	defer sTimer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sTimer.C:
+			logger.LogIf(ctx, u.save(ctx, objAPI))
+			sTimer.Reset(usageAccountingSaveInterval)
+		}
+	}
+}
+
+func initUsageAccounting(ctx context.Context, objAPI ObjectLayer) {
+	go globalUsageAccounting.persist(ctx, objAPI)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUsageAccounting(t *testing.T) {
+	u := newUsageAccounting()
+	u.record("alice", "alice", "GetObject", 0, 100)
+	u.record("alice", "alice", "GetObject", 0, 50)
+	u.record("svc1", "alice", "PutObject", 1024, 0)
+	// Anonymous requests are not accounted.
+	u.record("", "", "GetObject", 0, 10)
+
+	s := u.snapshot()
+	expected := map[string]AccessKeyUsage{
+		"alice": {APIs: map[string]APIUsage{"GetObject": {Requests: 2, OutputBytes: 150}}},
+		"svc1":  {User: "alice", APIs: map[string]APIUsage{"PutObject": {Requests: 1, InputBytes: 1024}}},
+	}
+	if !reflect.DeepEqual(s.AccessKeys, expected) {
+		t.Fatalf("Expected %v, got %v", expected, s.AccessKeys)
+	}
+
+	// Usage of another node is added to the usage of this node.
+	since := s.Since.Add(-time.Hour)
+	s.merge(UsageAccountingSnapshot{
+		Since: since,
+		AccessKeys: map[string]AccessKeyUsage{
+			"alice": {APIs: map[string]APIUsage{"GetObject": {Requests: 1, OutputBytes: 10}}},
+			"bob":   {APIs: map[string]APIUsage{"ListObjectsV2": {Requests: 3}}},
+		},
+	})
+	if !s.Since.Equal(since) {
+		t.Errorf("Expected the earliest start of the accounting, got %v", s.Since)
+	}
+	if a := s.AccessKeys["alice"].APIs["GetObject"]; a.Requests != 3 || a.OutputBytes != 160 {
+		t.Errorf("Unexpected merged usage %v", a)
+	}
+
+	// Service accounts are accounted to their parent user.
+	report := newUsageAccountingReport(s)
+	expectedUsers := map[string]map[string]APIUsage{
+		"alice": {
+			"GetObject": {Requests: 3, OutputBytes: 160},
+			"PutObject": {Requests: 1, InputBytes: 1024},
+		},
+		"bob": {"ListObjectsV2": {Requests: 3}},
+	}
+	if !reflect.DeepEqual(report.Users, expectedUsers) {
+		t.Errorf("Expected %v, got %v", expectedUsers, report.Users)
+	}
+
+	// Usage saved before a restart is added to the usage counted since.
+	restarted := newUsageAccounting()
+	restarted.record("alice", "alice", "GetObject", 0, 1)
+	restarted.restore(u.snapshot())
+	if a := restarted.snapshot().AccessKeys["alice"].APIs["GetObject"]; a.Requests != 3 || a.OutputBytes != 151 {
+		t.Errorf("Unexpected restored usage %v", a)
+	}
+}
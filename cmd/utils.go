@@ -198,14 +198,16 @@ func isMaxAllowedPartSize(size int64) bool {
 	return size > globalMaxPartSize
 }
 
-// Check if part size is more than or equal to minimum allowed size.
+// Check if part size is more than or equal to minimum allowed size,
+// as configured by the operator.
 func isMinAllowedPartSize(size int64) bool {
-	return size >= globalMinPartSize
+	return size >= globalAPIConfig.getMultipartMinPartSize()
 }
 
-// isMaxPartNumber - Check if part ID is greater than the maximum allowed ID.
+// isMaxPartNumber - Check if part ID is greater than the maximum allowed ID,
+// as configured by the operator.
 func isMaxPartID(partID int) bool {
-	return partID > globalMaxPartID
+	return partID > globalAPIConfig.getMultipartMaxParts()
 }
 
 func contains(slice interface{}, elem interface{}) bool {
@@ -485,6 +487,11 @@ func newInternodeHTTPTransport(tlsConfig *tls.Config, dialTimeout time.Duration)
 		// in raw stream.
 		DisableCompression: true,
 	}
+	if tlsConfig != nil {
+		// Verify against the current root CAs, they
+		// are reloaded when the files on disk change.
+		tr.DialTLSContext = newInternodeDialTLSContext(tlsConfig, tr.DialContext, tr.TLSHandshakeTimeout)
+	}
 
 	// https://github.com/golang/go/issues/23559
 	// https://github.com/golang/go/issues/42534
@@ -853,6 +860,14 @@ func iamPolicyClaimNameOpenID() string {
 	return globalOpenIDConfig.ClaimPrefix + globalOpenIDConfig.ClaimName
 }
 
+// iamPolicyClaimNameOpenIDFor returns the policy claim name of the
+// OpenID provider which issued the given claims.
+func iamPolicyClaimNameOpenIDFor(claims map[string]interface{}) string {
+	issuer, _ := claims[issClaim].(string)
+	cfg := globalOpenIDProviders.ForIssuer(issuer)
+	return cfg.ClaimPrefix + cfg.ClaimName
+}
+
 func iamPolicyClaimNameSA() string {
 	return "sa-policy"
 }
@@ -27,12 +27,14 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/Azure/azure-pipeline-go/pipeline"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/minio/madmin-go"
 )
 
 type warmBackendAzure struct {
 	serviceURL   azblob.ServiceURL
+	pipeline     pipeline.Pipeline
 	Bucket       string
 	Prefix       string
 	StorageClass string
@@ -45,6 +47,14 @@ func (az *warmBackendAzure) getDest(object string) string {
 	}
 	return destObj
 }
+
+// getRehydratedDest returns the name of the online copy an archived object
+// is rehydrated to, the archived blob itself is never moved out of the
+// archive tier.
+func (az *warmBackendAzure) getRehydratedDest(object string) string {
+	return az.getDest(object) + ".rehydrated"
+}
+
 func (az *warmBackendAzure) tier() azblob.AccessTierType {
 	for _, t := range azblob.PossibleAccessTierTypeValues() {
 		if strings.EqualFold(az.StorageClass, string(t)) {
@@ -59,16 +69,16 @@ func (az *warmBackendAzure) tier() azblob.AccessTierType {
 
 func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
 	blobURL := az.serviceURL.NewContainerURL(az.Bucket).NewBlockBlobURL(az.getDest(object))
-	// set tier if specified -
+	res, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{})
+	if err != nil {
+		return "", azureToObjectError(err, az.Bucket, object)
+	}
+	// set tier if specified, the blob must exist to change its tier.
 	if az.StorageClass != "" {
 		if _, err := blobURL.SetTier(ctx, az.tier(), azblob.LeaseAccessConditions{}); err != nil {
 			return "", azureToObjectError(err, az.Bucket, object)
 		}
 	}
-	res, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{})
-	if err != nil {
-		return "", azureToObjectError(err, az.Bucket, object)
-	}
 	return remoteVersionID(res.Version()), nil
 }
 
@@ -76,10 +86,24 @@ func (az *warmBackendAzure) Get(ctx context.Context, object string, rv remoteVer
 	if opts.startOffset < 0 {
 		return nil, InvalidRange{}
 	}
-	blobURL := az.serviceURL.NewContainerURL(az.Bucket).NewBlobURL(az.getDest(object))
-	blob, err := blobURL.Download(ctx, opts.startOffset, opts.length, azblob.BlobAccessConditions{}, false)
-	if err != nil {
-		return nil, azureToObjectError(err, az.Bucket, object)
+	containerURL := az.serviceURL.NewContainerURL(az.Bucket)
+	var blob *azblob.DownloadResponse
+	// archived objects are read from their rehydrated copy, if any.
+	if az.Archived() {
+		blobURL := containerURL.NewBlobURL(az.getRehydratedDest(object))
+		blob, err = blobURL.Download(ctx, opts.startOffset, opts.length, azblob.BlobAccessConditions{}, false)
+		if err != nil {
+			if err = azureToObjectError(err, az.Bucket, object); !isErrObjectNotFound(err) {
+				return nil, err
+			}
+		}
+	}
+	if blob == nil {
+		blobURL := containerURL.NewBlobURL(az.getDest(object))
+		blob, err = blobURL.Download(ctx, opts.startOffset, opts.length, azblob.BlobAccessConditions{}, false)
+		if err != nil {
+			return nil, azureToObjectError(err, az.Bucket, object)
+		}
 	}
 
 	rc := blob.Body(azblob.RetryReaderOptions{})
@@ -87,11 +111,89 @@ func (az *warmBackendAzure) Get(ctx context.Context, object string, rv remoteVer
 }
 
 func (az *warmBackendAzure) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	if az.Archived() {
+		if err := az.RemoveRehydrated(ctx, object, rv); err != nil {
+			return err
+		}
+	}
 	blob := az.serviceURL.NewContainerURL(az.Bucket).NewBlobURL(az.getDest(object))
 	_, err := blob.Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
 	return azureToObjectError(err, az.Bucket, object)
 }
 
+// Archived returns true if objects are transitioned to the Azure archive
+// tier, which is offline.
+func (az *warmBackendAzure) Archived() bool {
+	return az.tier() == azblob.AccessTierArchive
+}
+
+// Rehydrate requests an object in the archive tier to be rehydrated by
+// copying it to the cool tier. Rehydration may take several hours, it
+// returns true once the object can be read. The archived blob is left in the
+// archive tier, the rehydrated copy is kept until the restored object expires.
+func (az *warmBackendAzure) Rehydrate(ctx context.Context, object string, rv remoteVersionID) (bool, error) {
+	containerURL := az.serviceURL.NewContainerURL(az.Bucket)
+	blobURL := containerURL.NewBlobURL(az.getDest(object))
+	props, err := blobURL.GetProperties(ctx, azblob.BlobAccessConditions{})
+	if err != nil {
+		return false, azureToObjectError(err, az.Bucket, object)
+	}
+	if !strings.EqualFold(props.AccessTier(), string(azblob.AccessTierArchive)) {
+		return true, nil
+	}
+
+	copyURL := containerURL.NewBlobURL(az.getRehydratedDest(object))
+	cprops, err := copyURL.GetProperties(ctx, azblob.BlobAccessConditions{})
+	if err != nil {
+		if err = azureToObjectError(err, az.Bucket, object); !isErrObjectNotFound(err) {
+			return false, err
+		}
+		// StartCopyFromURL doesn't take the tier of the copy, which must be
+		// set by the copy request itself to rehydrate an archived blob.
+		copyURL = copyURL.WithPipeline(azureRehydratePipeline{
+			Pipeline: az.pipeline,
+			tier:     azblob.AccessTierCool,
+		})
+		_, err = copyURL.StartCopyFromURL(ctx, blobURL.URL(), nil, azblob.ModifiedAccessConditions{}, azblob.BlobAccessConditions{})
+		return false, azureToObjectError(err, az.Bucket, object)
+	}
+	switch cprops.CopyStatus() {
+	case azblob.CopyStatusSuccess:
+		return true, nil
+	case azblob.CopyStatusPending:
+		return false, nil
+	}
+	// the copy failed or was aborted, remove it to retry on the next restore.
+	if _, err = copyURL.Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{}); err != nil {
+		return false, azureToObjectError(err, az.Bucket, object)
+	}
+	return false, fmt.Errorf("rehydrating %s failed: %s %s", object, cprops.CopyStatus(), cprops.CopyStatusDescription())
+}
+
+// RemoveRehydrated removes the cool tier copy of an archived object made by
+// Rehydrate, if any.
+func (az *warmBackendAzure) RemoveRehydrated(ctx context.Context, object string, rv remoteVersionID) error {
+	copyURL := az.serviceURL.NewContainerURL(az.Bucket).NewBlobURL(az.getRehydratedDest(object))
+	_, err := copyURL.Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	if err = azureToObjectError(err, az.Bucket, object); err != nil && !isErrObjectNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// azureRehydratePipeline sets the access tier and rehydrate priority of the
+// requests it sends.
+type azureRehydratePipeline struct {
+	pipeline.Pipeline
+	tier azblob.AccessTierType
+}
+
+func (p azureRehydratePipeline) Do(ctx context.Context, methodFactory pipeline.Factory, request pipeline.Request) (pipeline.Response, error) {
+	request.Header.Set("x-ms-access-tier", string(p.tier))
+	request.Header.Set("x-ms-rehydrate-priority", string(azblob.RehydratePriorityStandard))
+	return p.Pipeline.Do(ctx, methodFactory, request)
+}
+
 func (az *warmBackendAzure) InUse(ctx context.Context) (bool, error) {
 	containerURL := az.serviceURL.NewContainerURL(az.Bucket)
 	resp, err := containerURL.ListBlobsHierarchySegment(ctx, azblob.Marker{}, "/", azblob.ListBlobsSegmentOptions{
@@ -123,6 +225,7 @@ func newWarmBackendAzure(conf madmin.TierAzure) (*warmBackendAzure, error) {
 	serviceURL := azblob.NewServiceURL(*u, p)
 	return &warmBackendAzure{
 		serviceURL:   serviceURL,
+		pipeline:     p,
 		Bucket:       conf.Bucket,
 		Prefix:       strings.TrimSuffix(conf.Prefix, slashSeparator),
 		StorageClass: conf.StorageClass,
@@ -0,0 +1,268 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+type azureTestBlob struct {
+	tier       string
+	copyStatus string
+	data       string
+}
+
+// azureTestServer mocks the Azure blob requests of the warm backend.
+type azureTestServer struct {
+	sync.Mutex
+	blobs   map[string]*azureTestBlob
+	setTier []string
+	copies  []http.Header
+}
+
+func (s *azureTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	blob, ok := s.blobs[name]
+	notFound := func() {
+		w.Header().Set("x-ms-error-code", "BlobNotFound")
+		w.WriteHeader(http.StatusNotFound)
+	}
+	switch {
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "tier":
+		s.setTier = append(s.setTier, name)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut && r.Header.Get("x-ms-copy-source") != "":
+		s.copies = append(s.copies, r.Header.Clone())
+		u, err := url.Parse(r.Header.Get("x-ms-copy-source"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		src, ok := s.blobs[strings.TrimPrefix(u.Path, "/")]
+		if !ok {
+			notFound()
+			return
+		}
+		s.blobs[name] = &azureTestBlob{
+			tier:       r.Header.Get("x-ms-access-tier"),
+			copyStatus: string(azblob.CopyStatusPending),
+			data:       src.data,
+		}
+		w.Header().Set("x-ms-copy-status", string(azblob.CopyStatusPending))
+		w.WriteHeader(http.StatusAccepted)
+	case !ok:
+		notFound()
+	case r.Method == http.MethodHead:
+		w.Header().Set("x-ms-access-tier", blob.tier)
+		if blob.copyStatus != "" {
+			w.Header().Set("x-ms-copy-status", blob.copyStatus)
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet:
+		if blob.tier == string(azblob.AccessTierArchive) || blob.copyStatus == string(azblob.CopyStatusPending) {
+			w.Header().Set("x-ms-error-code", "BlobArchived")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(blob.data))
+	case r.Method == http.MethodDelete:
+		delete(s.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func newTestWarmBackendAzure(t *testing.T, s *azureTestServer) *warmBackendAzure {
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := azblob.NewPipeline(azblob.NewAnonymousCredential(), azblob.PipelineOptions{})
+	return &warmBackendAzure{
+		serviceURL:   azblob.NewServiceURL(*u, p),
+		pipeline:     p,
+		Bucket:       "bucket",
+		Prefix:       "prefix",
+		StorageClass: string(azblob.AccessTierArchive),
+	}
+}
+
+func TestWarmBackendAzureRehydrate(t *testing.T) {
+	ctx := context.Background()
+	s := &azureTestServer{
+		blobs: map[string]*azureTestBlob{
+			"bucket/prefix/object": {tier: string(azblob.AccessTierArchive), data: "archived"},
+		},
+	}
+	az := newTestWarmBackendAzure(t, s)
+
+	// the first rehydrate starts a copy to the cool tier.
+	rehydrated, err := az.Rehydrate(ctx, "object", "")
+	if err != nil || rehydrated {
+		t.Fatalf("expected rehydration to start, got %v, %v", rehydrated, err)
+	}
+	if len(s.copies) != 1 {
+		t.Fatalf("expected 1 copy request, got %d", len(s.copies))
+	}
+	h := s.copies[0]
+	if h.Get("x-ms-access-tier") != string(azblob.AccessTierCool) {
+		t.Errorf("expected copy to the cool tier, got %q", h.Get("x-ms-access-tier"))
+	}
+	if h.Get("x-ms-rehydrate-priority") != string(azblob.RehydratePriorityStandard) {
+		t.Errorf("expected standard rehydrate priority, got %q", h.Get("x-ms-rehydrate-priority"))
+	}
+	if !strings.HasSuffix(h.Get("x-ms-copy-source"), "/bucket/prefix/object") {
+		t.Errorf("unexpected copy source %q", h.Get("x-ms-copy-source"))
+	}
+
+	// a pending copy is neither restarted nor readable.
+	rehydrated, err = az.Rehydrate(ctx, "object", "")
+	if err != nil || rehydrated {
+		t.Fatalf("expected rehydration to be pending, got %v, %v", rehydrated, err)
+	}
+	if len(s.copies) != 1 {
+		t.Fatalf("expected the pending copy not to be restarted, got %d copies", len(s.copies))
+	}
+
+	s.Lock()
+	s.blobs["bucket/prefix/object.rehydrated"].copyStatus = string(azblob.CopyStatusSuccess)
+	s.Unlock()
+	rehydrated, err = az.Rehydrate(ctx, "object", "")
+	if err != nil || !rehydrated {
+		t.Fatalf("expected object to be rehydrated, got %v, %v", rehydrated, err)
+	}
+
+	r, err := az.Get(ctx, "object", "", WarmBackendGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "archived" {
+		t.Fatalf("expected the rehydrated copy to be read, got %q, %v", data, err)
+	}
+
+	// the archived blob must stay in the archive tier.
+	if len(s.setTier) != 0 {
+		t.Errorf("expected no tier changes, got %v", s.setTier)
+	}
+	if tier := s.blobs["bucket/prefix/object"].tier; tier != string(azblob.AccessTierArchive) {
+		t.Errorf("expected archived blob to stay in the archive tier, got %q", tier)
+	}
+
+	// removing the object removes its rehydrated copy too.
+	if err = az.Remove(ctx, "object", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.blobs) != 0 {
+		t.Errorf("expected all blobs to be removed, got %d", len(s.blobs))
+	}
+}
+
+func TestWarmBackendAzureRehydrateFailedCopy(t *testing.T) {
+	ctx := context.Background()
+	s := &azureTestServer{
+		blobs: map[string]*azureTestBlob{
+			"bucket/prefix/object":            {tier: string(azblob.AccessTierArchive)},
+			"bucket/prefix/object.rehydrated": {tier: string(azblob.AccessTierCool), copyStatus: string(azblob.CopyStatusFailed)},
+		},
+	}
+	az := newTestWarmBackendAzure(t, s)
+
+	if _, err := az.Rehydrate(ctx, "object", ""); err == nil {
+		t.Fatal("expected failed rehydration to be reported")
+	}
+	if _, ok := s.blobs["bucket/prefix/object.rehydrated"]; ok {
+		t.Fatal("expected failed copy to be removed")
+	}
+	// the next rehydrate starts a new copy.
+	if rehydrated, err := az.Rehydrate(ctx, "object", ""); err != nil || rehydrated {
+		t.Fatalf("expected rehydration to restart, got %v, %v", rehydrated, err)
+	}
+	if len(s.copies) != 1 {
+		t.Fatalf("expected 1 copy request, got %d", len(s.copies))
+	}
+}
+
+func TestWarmBackendAzureRehydrateOnline(t *testing.T) {
+	ctx := context.Background()
+	s := &azureTestServer{
+		blobs: map[string]*azureTestBlob{
+			"bucket/prefix/object": {tier: string(azblob.AccessTierCool), data: "online"},
+		},
+	}
+	az := newTestWarmBackendAzure(t, s)
+
+	if rehydrated, err := az.Rehydrate(ctx, "object", ""); err != nil || !rehydrated {
+		t.Fatalf("expected online object to be readable, got %v, %v", rehydrated, err)
+	}
+	if len(s.copies) != 0 || len(s.setTier) != 0 {
+		t.Fatalf("expected no copies or tier changes, got %d, %v", len(s.copies), s.setTier)
+	}
+	// without a rehydrated copy the blob itself is read.
+	r, err := az.Get(ctx, "object", "", WarmBackendGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "online" {
+		t.Fatalf("expected the blob to be read, got %q, %v", data, err)
+	}
+}
+
+func TestWarmBackendAzureRemoveRehydrated(t *testing.T) {
+	ctx := context.Background()
+	s := &azureTestServer{
+		blobs: map[string]*azureTestBlob{
+			"bucket/prefix/object":            {tier: string(azblob.AccessTierArchive)},
+			"bucket/prefix/object.rehydrated": {tier: string(azblob.AccessTierCool), copyStatus: string(azblob.CopyStatusSuccess)},
+		},
+	}
+	az := newTestWarmBackendAzure(t, s)
+
+	if err := az.RemoveRehydrated(ctx, "object", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.blobs["bucket/prefix/object.rehydrated"]; ok {
+		t.Fatal("expected the rehydrated copy to be removed")
+	}
+	if _, ok := s.blobs["bucket/prefix/object"]; !ok {
+		t.Fatal("expected the archived blob to be kept")
+	}
+	// there is nothing to remove anymore.
+	if err := az.RemoveRehydrated(ctx, "object", ""); err != nil {
+		t.Fatal(err)
+	}
+}
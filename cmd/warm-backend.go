@@ -42,6 +42,20 @@ type WarmBackend interface {
 	InUse(ctx context.Context) (bool, error)
 }
 
+// warmBackendArchive is implemented by remote tier backends which may store
+// transitioned objects in an offline archive tier. Such objects must be
+// rehydrated to an online tier before they can be read.
+type warmBackendArchive interface {
+	// Archived returns true if objects are transitioned to an offline tier.
+	Archived() bool
+	// Rehydrate requests object to be rehydrated to an online tier, it
+	// returns true once object can be read.
+	Rehydrate(ctx context.Context, object string, rv remoteVersionID) (bool, error)
+	// RemoveRehydrated removes the online copy object was rehydrated to,
+	// once it is not restored anymore.
+	RemoveRehydrated(ctx context.Context, object string, rv remoteVersionID) error
+}
+
 const probeObject = "probeobject"
 
 // checkWarmBackend checks if tier config credentials have sufficient privileges
@@ -60,21 +74,24 @@ func checkWarmBackend(ctx context.Context, w WarmBackend) error {
 		}
 	}
 
-	_, err = w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
-	if err != nil {
-		switch err.(type) {
-		case BackendDown:
-			return err
-		}
-		switch {
-		case isErrBucketNotFound(err):
-			return errTierBucketNotFound
-		case isErrSignatureDoesNotMatch(err):
-			return errTierInvalidCredentials
-		default:
-			return tierPermErr{
-				Op:  tierGet,
-				Err: err,
+	// Objects in an offline archive tier can't be read back right away.
+	if a, ok := w.(warmBackendArchive); !ok || !a.Archived() {
+		_, err = w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
+		if err != nil {
+			switch err.(type) {
+			case BackendDown:
+				return err
+			}
+			switch {
+			case isErrBucketNotFound(err):
+				return errTierBucketNotFound
+			case isErrSignatureDoesNotMatch(err):
+				return errTierInvalidCredentials
+			default:
+				return tierPermErr{
+					Op:  tierGet,
+					Err: err,
+				}
 			}
 		}
 	}
@@ -58,6 +58,7 @@ const (
 	storageMetricReadVersion
 	storageMetricReadAll
 	storageMetricStatInfoFile
+	storageMetricReadVersions
 
 	// .... add more
 
@@ -70,10 +71,11 @@ type xlStorageDiskIDCheck struct {
 	// do not re-order them, if you add new fields
 	// please use `fieldalignment ./...` to check
 	// if your changes are not causing any problems.
-	storage      StorageAPI
-	apiLatencies [storageMetricLast]ewma.MovingAverage
-	diskID       string
-	apiCalls     [storageMetricLast]uint64
+	storage       StorageAPI
+	readLatencies *latencyWindow
+	apiLatencies  [storageMetricLast]ewma.MovingAverage
+	diskID        string
+	apiCalls      [storageMetricLast]uint64
 }
 
 func (p *xlStorageDiskIDCheck) getMetrics() DiskMetrics {
@@ -87,6 +89,7 @@ func (p *xlStorageDiskIDCheck) getMetrics() DiskMetrics {
 	for i := range p.apiCalls {
 		diskMetric.APICalls[storageMetric(i).String()] = atomic.LoadUint64(&p.apiCalls[i])
 	}
+	diskMetric.ReadLatencyP99 = int64(p.readLatencies.percentile(0.99))
 	return diskMetric
 }
 
@@ -116,7 +119,8 @@ func (e *lockedSimpleEWMA) Value() float64 {
 
 func newXLStorageDiskIDCheck(storage *xlStorage) *xlStorageDiskIDCheck {
 	xl := xlStorageDiskIDCheck{
-		storage: storage,
+		storage:       storage,
+		readLatencies: &latencyWindow{},
 	}
 	for i := range xl.apiLatencies[:] {
 		xl.apiLatencies[i] = &lockedSimpleEWMA{
@@ -391,15 +395,15 @@ func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPat
 	return p.storage.RenameData(ctx, srcVolume, srcPath, fi, dstVolume, dstPath)
 }
 
-func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
+func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error) {
 	defer p.updateStorageMetrics(storageMetricCheckParts, volume, path)()
 
 	if contextCanceled(ctx) {
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
-	if err = p.checkDiskStale(); err != nil {
-		return err
+	if err := p.checkDiskStale(); err != nil {
+		return nil, err
 	}
 
 	return p.storage.CheckParts(ctx, volume, path, fi)
@@ -449,15 +453,15 @@ func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string
 	return p.storage.DeleteVersions(ctx, volume, versions)
 }
 
-func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
+func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
 	defer p.updateStorageMetrics(storageMetricVerifyFile, volume, path)()
 
 	if contextCanceled(ctx) {
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
 	if err := p.checkDiskStale(); err != nil {
-		return err
+		return nil, err
 	}
 
 	return p.storage.VerifyFile(ctx, volume, path, fi)
@@ -533,6 +537,35 @@ func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, ve
 	return p.storage.ReadVersion(ctx, volume, path, versionID, readData)
 }
 
+// ReadVersions reads a version of a list of objects.
+func (p *xlStorageDiskIDCheck) ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) (fis []FileInfo, errs []error) {
+	// Merely for tracing storage
+	path := ""
+	if len(paths) > 0 {
+		path = paths[0]
+	}
+
+	defer p.updateStorageMetrics(storageMetricReadVersions, volume, path)()
+
+	if contextCanceled(ctx) {
+		return p.readVersionsErr(paths, ctx.Err())
+	}
+
+	if err := p.checkDiskStale(); err != nil {
+		return p.readVersionsErr(paths, err)
+	}
+
+	return p.storage.ReadVersions(ctx, volume, paths, versionIDs, readData)
+}
+
+func (p *xlStorageDiskIDCheck) readVersionsErr(paths []string, err error) ([]FileInfo, []error) {
+	errs := make([]error, len(paths))
+	for i := range errs {
+		errs[i] = err
+	}
+	return make([]FileInfo, len(paths)), errs
+}
+
 func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
 	defer p.updateStorageMetrics(storageMetricReadAll, volume, path)()
 
@@ -584,6 +617,11 @@ func (p *xlStorageDiskIDCheck) updateStorageMetrics(s storageMetric, paths ...st
 		atomic.AddUint64(&p.apiCalls[s], 1)
 		p.apiLatencies[s].Add(float64(duration))
 
+		switch s {
+		case storageMetricReadFile, storageMetricReadFileStream, storageMetricReadAll, storageMetricReadVersion:
+			p.readLatencies.add(duration)
+		}
+
 		if trace {
 			globalTrace.Publish(storageTrace(s, startTime, duration, strings.Join(paths, " ")))
 		}
@@ -819,6 +819,12 @@ type xlMetaV2 struct {
 
 	// metadata version.
 	metaV uint8
+
+	// segments the oldest versions are spilled into.
+	segments xlMetaSegments
+	// segments merged back into versions, to be removed once
+	// the metadata is written.
+	mergedSegments xlMetaSegments
 }
 
 // LoadOrConvert will load the metadata in the buffer.
@@ -866,6 +872,10 @@ func (x *xlMetaV2) loadIndexed(buf xlMetaBuf, data xlMetaInlineData) error {
 		x.data.repair()
 		logger.Info("xlMetaV2.loadIndexed: data validation failed: %v. %d entries after repair", err, x.data.entries())
 	}
+	x.mergedSegments = xlMetaSegments{}
+	if x.segments, err = decodeXLSegments(buf, versions); err != nil {
+		return err
+	}
 
 	return decodeVersions(buf, versions, func(i int, hdr, meta []byte) error {
 		ver := &x.versions[i]
@@ -1058,6 +1068,7 @@ func (x *xlMetaV2) AppendTo(dst []byte) ([]byte, error) {
 		// Add full meta
 		dst = msgp.AppendBytes(dst, ver.meta)
 	}
+	dst = x.segments.appendTo(dst)
 
 	// Update size...
 	binary.BigEndian.PutUint32(dst[dataOffset-4:dataOffset], uint32(len(dst)-dataOffset))
@@ -1627,7 +1638,7 @@ func (x xlMetaV2) ToFileInfo(volume, path, versionID string) (fi FileInfo, err e
 
 		return FileInfo{}, errFileVersionNotFound
 	}
-	fi.NumVersions = nonFreeVersions
+	fi.NumVersions = nonFreeVersions + x.segments.Versions
 	return fi, err
 }
 
@@ -1863,7 +1874,7 @@ func (x xlMetaBuf) ToFileInfo(volume, path, versionID string) (fi FileInfo, err
 
 		return FileInfo{}, errFileVersionNotFound
 	}
-	fi.NumVersions = nonFreeVersions
+	fi.NumVersions = nonFreeVersions + x.segments().Versions
 	return fi, err
 }
 
@@ -0,0 +1,521 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pathutil "path"
+	"strconv"
+
+	"github.com/google/uuid"
+	xioutil "github.com/minio/minio/internal/ioutil"
+	"github.com/minio/minio/internal/logger"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Keys with many versions spill their oldest versions out of xl.meta into
+// secondary metadata segments, the files xl.meta.<n> next to it. xl.meta
+// always keeps the latest version, reading it or any recent version never
+// touches the segments. Segments are only loaded to read, update or delete
+// the versions they hold, and to list all versions of the key.
+var (
+	// Spill once xl.meta holds more versions than this.
+	xlMetaSpillVersions = 1000
+
+	// Number of most recent versions kept in xl.meta after a spill.
+	xlMetaKeepVersions = 100
+)
+
+// xlMetaSegments describes the segments of xl.meta, stored after its
+// versions. Older versions of the metadata format ignore it and only
+// see the versions left in xl.meta.
+type xlMetaSegments struct {
+	// Segments are numbered from First to Last, higher numbers were
+	// spilled later and hold more recent versions.
+	First, Last int
+
+	// Versions is the number of versions in all segments.
+	Versions int
+
+	// Newest is the modtime of the most recent version in all segments,
+	// xl.meta always holds a version at least as recent.
+	Newest int64
+}
+
+func (s xlMetaSegments) empty() bool {
+	return s.Last == 0
+}
+
+func (s xlMetaSegments) appendTo(dst []byte) []byte {
+	if s.empty() {
+		return dst
+	}
+	dst = msgp.AppendArrayHeader(dst, 4)
+	dst = msgp.AppendInt(dst, s.First)
+	dst = msgp.AppendInt(dst, s.Last)
+	dst = msgp.AppendInt(dst, s.Versions)
+	return msgp.AppendInt64(dst, s.Newest)
+}
+
+// decodeXLSegments decodes the segments following the versions in buf.
+func decodeXLSegments(buf []byte, versions int) (s xlMetaSegments, err error) {
+	for i := 0; i < 2*versions; i++ {
+		if buf, err = msgp.Skip(buf); err != nil {
+			return s, err
+		}
+	}
+	if len(buf) == 0 {
+		return s, nil
+	}
+	sz, buf, err := msgp.ReadArrayHeaderBytes(buf)
+	if err != nil {
+		return s, err
+	}
+	if sz < 4 {
+		return s, fmt.Errorf("decodeXLSegments: unexpected size %d", sz)
+	}
+	if s.First, buf, err = msgp.ReadIntBytes(buf); err != nil {
+		return s, err
+	}
+	if s.Last, buf, err = msgp.ReadIntBytes(buf); err != nil {
+		return s, err
+	}
+	if s.Versions, buf, err = msgp.ReadIntBytes(buf); err != nil {
+		return s, err
+	}
+	s.Newest, _, err = msgp.ReadInt64Bytes(buf)
+	return s, err
+}
+
+// segments returns the segments of the metadata, if any.
+func (x xlMetaBuf) segments() xlMetaSegments {
+	versions, _, _, buf, err := decodeXLHeaders(x)
+	if err != nil {
+		return xlMetaSegments{}
+	}
+	s, _ := decodeXLSegments(buf, versions)
+	return s
+}
+
+func xlMetaSegmentFile(n int) string {
+	return xlStorageFormatFile + "." + strconv.Itoa(n)
+}
+
+// inlineDataKey returns the key of the inline data of a version.
+func inlineDataKey(versionID [16]byte) string {
+	if versionID == [16]byte{} {
+		return nullVersionID
+	}
+	return uuid.UUID(versionID).String()
+}
+
+// inlineDataEntries returns all keys and values of the inline data.
+func inlineDataEntries(x xlMetaInlineData) (keys, vals [][]byte) {
+	if len(x) == 0 || !x.versionOK() {
+		return nil, nil
+	}
+	sz, buf, err := msgp.ReadMapHeaderBytes(x.afterVersion())
+	if err != nil {
+		return nil, nil
+	}
+	for i := uint32(0); i < sz; i++ {
+		var key, val []byte
+		if key, buf, err = msgp.ReadMapKeyZC(buf); err != nil {
+			break
+		}
+		if val, buf, err = msgp.ReadBytesZC(buf); err != nil {
+			break
+		}
+		keys = append(keys, key)
+		vals = append(vals, val)
+	}
+	return keys, vals
+}
+
+// needsSegments returns true if the version may be held by a segment.
+// The null version is never spilled.
+func (x *xlMetaV2) needsSegments(versionID string) bool {
+	if x.segments.empty() || versionID == "" || versionID == nullVersionID {
+		return false
+	}
+	uv, err := uuid.Parse(versionID)
+	if err != nil {
+		return false
+	}
+	_, _, err = x.findVersion(uv)
+	return err != nil
+}
+
+// latestModTime returns the modtime of the latest version, free versions
+// are ignored. false is returned when there is none.
+func (x *xlMetaV2) latestModTime() (int64, bool) {
+	for _, ver := range x.versions {
+		if !ver.header.FreeVersion() {
+			return ver.header.ModTime, true
+		}
+	}
+	return 0, false
+}
+
+// mergeSegments merges the versions of the segments of xl.meta in metaDir
+// back into x. The merged segments are removed by removeMergedSegments
+// once x is written. Missing or corrupt segments are skipped, the
+// versions they held are restored by healing.
+func (s *xlStorage) mergeSegments(ctx context.Context, metaDir string, x *xlMetaV2) error {
+	if x.segments.empty() {
+		return nil
+	}
+	keys, vals := inlineDataEntries(x.data)
+	for n := x.segments.Last; n >= x.segments.First; n-- {
+		segPath := pathJoin(metaDir, xlMetaSegmentFile(n))
+		buf, err := xioutil.ReadFile(segPath)
+		if err != nil {
+			if osIsNotExist(err) {
+				logger.LogIf(ctx, fmt.Errorf("xl.meta segment %s is missing", segPath))
+				continue
+			}
+			return osErrToFileErr(err)
+		}
+		var seg xlMetaV2
+		if err = seg.Load(buf); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("xl.meta segment %s is corrupt: %w", segPath, err))
+			continue
+		}
+		segKeys, segVals := inlineDataEntries(seg.data)
+		keys = append(keys, segKeys...)
+		vals = append(vals, segVals...)
+		for _, ver := range seg.versions {
+			if _, _, err = x.findVersion(ver.header.VersionID); err == nil {
+				// Left over by an interrupted spill.
+				continue
+			}
+			x.versions = append(x.versions, ver)
+		}
+	}
+	x.data.serialize(-1, keys, vals)
+	x.sortByModTime()
+
+	if x.mergedSegments.empty() {
+		x.mergedSegments = x.segments
+	} else {
+		x.mergedSegments.Last = x.segments.Last
+	}
+	x.segments = xlMetaSegments{}
+	return nil
+}
+
+// spillSegments prepares x to be written to xl.meta of path. When x holds
+// too many versions the oldest ones are spilled into a new segment, when
+// its latest version is older than the segments, e.g. after the most
+// recent versions were deleted, they are merged back.
+func (s *xlStorage) spillSegments(ctx context.Context, volume, path string, x *xlMetaV2) error {
+	volumeDir, err := s.getVolDir(volume)
+	if err != nil {
+		return err
+	}
+	if !x.segments.empty() {
+		if latest, ok := x.latestModTime(); !ok || latest < x.segments.Newest {
+			if err = s.mergeSegments(ctx, pathJoin(volumeDir, path), x); err != nil {
+				return err
+			}
+		}
+	}
+
+	var versions int
+	for _, ver := range x.versions {
+		if !ver.header.FreeVersion() {
+			versions++
+		}
+	}
+	if versions <= xlMetaSpillVersions {
+		return nil
+	}
+
+	// Keep the most recent versions, the null version and the free
+	// versions, the latter are looked up by the scanner in xl.meta.
+	kept := make([]xlMetaV2ShallowVersion, 0, xlMetaKeepVersions+1)
+	var spilled []xlMetaV2ShallowVersion
+	spilledKeys := make(map[string]struct{})
+	for _, ver := range x.versions {
+		if ver.header.FreeVersion() || ver.header.VersionID == [16]byte{} || len(kept) < xlMetaKeepVersions {
+			kept = append(kept, ver)
+			continue
+		}
+		spilled = append(spilled, ver)
+		spilledKeys[inlineDataKey(ver.header.VersionID)] = struct{}{}
+	}
+	if len(spilled) == 0 {
+		return nil
+	}
+
+	var keptKeys, keptVals, segKeys, segVals [][]byte
+	keys, vals := inlineDataEntries(x.data)
+	for i, key := range keys {
+		if _, ok := spilledKeys[string(key)]; ok {
+			segKeys = append(segKeys, key)
+			segVals = append(segVals, vals[i])
+		} else {
+			keptKeys = append(keptKeys, key)
+			keptVals = append(keptVals, vals[i])
+		}
+	}
+
+	next := x.segments.Last
+	if x.mergedSegments.Last > next {
+		next = x.mergedSegments.Last
+	}
+	next++
+	// Segments are written before xl.meta refers to them, an interrupted
+	// spill leaves an unreferenced segment which the next spill replaces.
+	seg := xlMetaV2{versions: spilled, metaV: x.metaV}
+	seg.data.serialize(-1, segKeys, segVals)
+	buf, err := seg.AppendTo(metaDataPoolGet())
+	defer metaDataPoolPut(buf)
+	if err != nil {
+		return err
+	}
+	if err = s.writeAll(ctx, volume, pathJoin(path, xlMetaSegmentFile(next)), buf, true); err != nil {
+		return err
+	}
+
+	if x.segments.empty() {
+		x.segments.First = next
+	}
+	x.segments.Last = next
+	x.segments.Versions += len(spilled)
+	if spilled[0].header.ModTime > x.segments.Newest {
+		x.segments.Newest = spilled[0].header.ModTime
+	}
+	x.versions = kept
+	x.data.serialize(-1, keptKeys, keptVals)
+	return nil
+}
+
+// xlMetaSegment is a segment loaded to update one of its versions.
+type xlMetaSegment struct {
+	meta xlMetaV2
+	// number of versions when loaded.
+	versions int
+}
+
+// segmentTarget returns the metadata holding versionID, which is x unless
+// the version was spilled into a segment. Segments holding a version are
+// added to loaded, to be written back by writeSegments.
+func (s *xlStorage) segmentTarget(metaDir string, x *xlMetaV2, versionID string, loaded map[int]*xlMetaSegment) (*xlMetaV2, error) {
+	if !x.needsSegments(versionID) {
+		return x, nil
+	}
+	uv, err := uuid.Parse(versionID)
+	if err != nil {
+		return x, nil
+	}
+	for n := x.segments.Last; n >= x.segments.First; n-- {
+		seg, ok := loaded[n]
+		if !ok {
+			buf, err := xioutil.ReadFile(pathJoin(metaDir, xlMetaSegmentFile(n)))
+			if err != nil {
+				if osIsNotExist(err) {
+					// Restored by healing.
+					continue
+				}
+				return nil, osErrToFileErr(err)
+			}
+			seg = &xlMetaSegment{}
+			if err = seg.meta.Load(buf); err != nil {
+				continue
+			}
+			seg.versions = len(seg.meta.versions)
+		}
+		if _, _, err = seg.meta.findVersion(uv); err == nil {
+			loaded[n] = seg
+			return &seg.meta, nil
+		}
+	}
+	// Not found, new versions are added to xl.meta.
+	return x, nil
+}
+
+// writeSegments writes back the segments loaded by segmentTarget and
+// updates the segments of x, which must be written after them. Segments
+// left without versions are removed.
+func (s *xlStorage) writeSegments(ctx context.Context, volume, path string, x *xlMetaV2, loaded map[int]*xlMetaSegment) error {
+	if len(loaded) == 0 {
+		return nil
+	}
+	volumeDir, err := s.getVolDir(volume)
+	if err != nil {
+		return err
+	}
+	for n, seg := range loaded {
+		x.segments.Versions += len(seg.meta.versions) - seg.versions
+		if len(seg.meta.versions) == 0 {
+			err = s.moveToTrash(pathJoin(volumeDir, path, xlMetaSegmentFile(n)), false)
+			if err != nil && err != errFileNotFound {
+				return err
+			}
+			continue
+		}
+		buf, err := seg.meta.AppendTo(nil)
+		if err != nil {
+			return err
+		}
+		if err = s.writeAll(ctx, volume, pathJoin(path, xlMetaSegmentFile(n)), buf, true); err != nil {
+			return err
+		}
+		if latest, ok := seg.meta.latestModTime(); ok && latest > x.segments.Newest {
+			x.segments.Newest = latest
+		}
+	}
+	if x.segments.Versions <= 0 {
+		// Segments holding no versions are left to be removed.
+		x.mergedSegments = x.segments
+		x.segments = xlMetaSegments{}
+	}
+	return nil
+}
+
+// usesDataDir returns true if a version of x other than versionID uses
+// dataDir.
+func (x *xlMetaV2) usesDataDir(versionID, dataDir [16]byte) bool {
+	var decoded xlMetaDataDirDecoder
+	for _, version := range x.versions {
+		if version.header.Type != ObjectType || !version.header.UsesDataDir() || version.header.VersionID == versionID {
+			continue
+		}
+		if _, err := decoded.UnmarshalMsg(version.meta); err == nil && decoded.ObjectV2 != nil && decoded.ObjectV2.DataDir == dataDir {
+			return true
+		}
+	}
+	return false
+}
+
+// dataDirInUse returns true if a version other than versionID, in
+// xl.meta or in the segments of x, uses dataDir. Versions sharing a data
+// dir may be spread over xl.meta and its segments, loaded segments are
+// checked as updated in memory.
+func (s *xlStorage) dataDirInUse(metaDir string, x *xlMetaV2, loaded map[int]*xlMetaSegment, versionID, dataDir string) bool {
+	if x.segments.empty() && x.mergedSegments.empty() {
+		return false
+	}
+	dd, err := uuid.Parse(dataDir)
+	if err != nil {
+		return false
+	}
+	var uv uuid.UUID
+	if versionID != "" && versionID != nullVersionID {
+		if uv, err = uuid.Parse(versionID); err != nil {
+			return true
+		}
+	}
+	if x.usesDataDir(uv, dd) {
+		return true
+	}
+	for n := x.segments.First; n <= x.segments.Last && !x.segments.empty(); n++ {
+		if seg, ok := loaded[n]; ok {
+			if seg.meta.usesDataDir(uv, dd) {
+				return true
+			}
+			continue
+		}
+		buf, err := xioutil.ReadFile(pathJoin(metaDir, xlMetaSegmentFile(n)))
+		if err != nil {
+			if osIsNotExist(err) {
+				continue
+			}
+			// Keep the data when unsure.
+			return true
+		}
+		var seg xlMetaV2
+		if err = seg.Load(buf); err != nil || seg.usesDataDir(uv, dd) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeMergedSegments removes the segments merged into x, after x was
+// written to xl.meta of path.
+func (s *xlStorage) removeMergedSegments(volumeDir, path string, x *xlMetaV2) {
+	if x.mergedSegments.empty() {
+		return
+	}
+	for n := x.mergedSegments.First; n <= x.mergedSegments.Last; n++ {
+		if err := s.moveToTrash(pathJoin(volumeDir, path, xlMetaSegmentFile(n)), false); err != nil && !osIsNotExist(err) {
+			logger.LogIf(GlobalContext, err)
+		}
+	}
+	x.mergedSegments = xlMetaSegments{}
+}
+
+// readSegmentVersion reads a version which is not in xl.meta, buf, from
+// the segments of xl.meta.
+func (s *xlStorage) readSegmentVersion(volume, volumeDir, path string, buf []byte, versionID string, readData bool) (FileInfo, error) {
+	meta, _ := isIndexedMetaV2(buf)
+	if meta == nil {
+		return FileInfo{}, errFileVersionNotFound
+	}
+	segs := meta.segments()
+	for n := segs.Last; n >= segs.First && !segs.empty(); n-- {
+		sbuf, err := xioutil.ReadFile(pathJoin(volumeDir, path, xlMetaSegmentFile(n)))
+		if err != nil {
+			// Missing segments are restored by healing.
+			continue
+		}
+		fi, err := getFileInfo(sbuf, volume, path, versionID, readData)
+		if errors.Is(err, errFileVersionNotFound) {
+			continue
+		}
+		if err != nil {
+			return fi, err
+		}
+		// xl.meta always holds a more recent version.
+		fi.IsLatest = false
+		if latest, err := meta.ToFileInfo(volume, path, ""); err == nil {
+			fi.NumVersions = latest.NumVersions
+		}
+		return fi, nil
+	}
+	return FileInfo{}, errFileVersionNotFound
+}
+
+// readMetadataAll reads xl.meta at itemPath like readMetadata, with the
+// versions of its segments merged back, for callers needing all versions.
+func (s *xlStorage) readMetadataAll(ctx context.Context, itemPath string) ([]byte, error) {
+	buf, err := s.readMetadata(ctx, itemPath)
+	if err != nil {
+		return nil, err
+	}
+	meta, _ := isIndexedMetaV2(buf)
+	if meta == nil || meta.segments().empty() {
+		return buf, nil
+	}
+	defer metaDataPoolPut(buf)
+
+	var x xlMetaV2
+	if err = x.Load(buf); err != nil {
+		return nil, err
+	}
+	if err = s.mergeSegments(ctx, pathutil.Dir(itemPath), &x); err != nil {
+		return nil, err
+	}
+	// Like readMetadata, without inline data.
+	x.data = nil
+	return x.AppendTo(metaDataPoolGet())
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestXLStorageMetaSegments(t *testing.T) {
+	defer func(spill, keep int) {
+		xlMetaSpillVersions, xlMetaKeepVersions = spill, keep
+	}(xlMetaSpillVersions, xlMetaKeepVersions)
+	xlMetaSpillVersions, xlMetaKeepVersions = 10, 4
+
+	diskPath := t.TempDir()
+	storage, err := newLocalXLStorage(diskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	const volume, object = "bucket", "object"
+	if err = storage.MakeVol(ctx, volume); err != nil {
+		t.Fatal(err)
+	}
+
+	const total = 30
+	sharedDataDir := mustGetUUID()
+	versions := make([]string, total)
+	modTime := time.Now().Add(-time.Hour)
+	for i := range versions {
+		versions[i] = mustGetUUID()
+		fi := FileInfo{
+			Volume:    volume,
+			Name:      object,
+			VersionID: versions[i],
+			DataDir:   mustGetUUID(),
+			ModTime:   modTime.Add(time.Duration(i) * time.Second),
+			Size:      1,
+			Parts:     []ObjectPartInfo{{Number: 1, Size: 1, ActualSize: 1}},
+			Data:      []byte(fmt.Sprint(i)),
+			Erasure: ErasureInfo{
+				Algorithm:    ReedSolomon.String(),
+				DataBlocks:   2,
+				ParityBlocks: 2,
+				BlockSize:    blockSizeV2,
+				Index:        1,
+				Distribution: []int{1, 2, 3, 4},
+			},
+		}
+		if i == 0 || i == total-1 {
+			// The oldest and latest versions share their data dir.
+			fi.DataDir = sharedDataDir
+			fi.Data = nil
+		} else {
+			fi.SetInlineData()
+		}
+		if err = storage.WriteMetadata(ctx, volume, object, fi); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = os.MkdirAll(filepath.Join(diskPath, volume, object, sharedDataDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(filepath.Join(diskPath, volume, object, xlMetaSegmentFile(1))); err != nil {
+		t.Fatal("expected a segment to be spilled:", err)
+	}
+	buf, err := storage.ReadAll(ctx, volume, pathJoin(object, xlStorageFormatFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var xlMeta xlMetaV2
+	if err = xlMeta.Load(buf); err != nil {
+		t.Fatal(err)
+	}
+	if len(xlMeta.versions) > xlMetaSpillVersions || xlMeta.segments.empty() {
+		t.Fatalf("expected at most %d versions in xl.meta with segments, got %d", xlMetaSpillVersions, len(xlMeta.versions))
+	}
+
+	for i, versionID := range versions {
+		shared := i == 0 || i == total-1
+		fi, err := storage.ReadVersion(ctx, volume, object, versionID, !shared)
+		if err != nil {
+			t.Fatalf("version %d: %v", i, err)
+		}
+		if fi.NumVersions != total {
+			t.Errorf("version %d: expected %d versions, got %d", i, total, fi.NumVersions)
+		}
+		if fi.IsLatest != (i == total-1) {
+			t.Errorf("version %d: unexpected latest %v", i, fi.IsLatest)
+		}
+		if !shared && !bytes.Equal(fi.Data, []byte(fmt.Sprint(i))) {
+			t.Errorf("version %d: unexpected data %q", i, fi.Data)
+		}
+	}
+
+	countAll := func() int {
+		t.Helper()
+		buf, err := storage.readMetadataAll(ctx, filepath.Join(diskPath, volume, object, xlStorageFormatFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var all xlMetaV2
+		if err = all.Load(buf); err != nil {
+			t.Fatal(err)
+		}
+		return len(all.versions)
+	}
+	if n := countAll(); n != total {
+		t.Fatalf("expected %d versions listed, got %d", total, n)
+	}
+
+	// Deleting the latest version keeps the data dir still used by the
+	// oldest version, which was spilled.
+	if err = storage.DeleteVersion(ctx, volume, object, FileInfo{Volume: volume, Name: object, VersionID: versions[total-1]}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(diskPath, volume, object, sharedDataDir)); err != nil {
+		t.Fatal("shared data dir was removed:", err)
+	}
+	if err = storage.DeleteVersion(ctx, volume, object, FileInfo{Volume: volume, Name: object, VersionID: versions[0]}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(diskPath, volume, object, sharedDataDir)); !os.IsNotExist(err) {
+		t.Fatal("expected the shared data dir to be removed:", err)
+	}
+
+	// Deleting and updating spilled versions.
+	if err = storage.DeleteVersions(ctx, volume, []FileInfoVersions{{
+		Name: object,
+		Versions: []FileInfo{
+			{Volume: volume, Name: object, VersionID: versions[1]},
+			{Volume: volume, Name: object, VersionID: versions[2]},
+		},
+	}})[0]; err != nil {
+		t.Fatal(err)
+	}
+	fi, err := storage.ReadVersion(ctx, volume, object, versions[3], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi.Metadata = map[string]string{"x-amz-meta-updated": "true"}
+	if err = storage.UpdateMetadata(ctx, volume, object, fi); err != nil {
+		t.Fatal(err)
+	}
+	for i, versionID := range versions[:3] {
+		if _, err = storage.ReadVersion(ctx, volume, object, versionID, false); err != errFileVersionNotFound {
+			t.Errorf("version %d: expected %v, got %v", i, errFileVersionNotFound, err)
+		}
+	}
+	fi, err = storage.ReadVersion(ctx, volume, object, versions[3], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Metadata["x-amz-meta-updated"] != "true" || fi.NumVersions != total-4 {
+		t.Errorf("unexpected updated version %v with %d versions", fi.Metadata, fi.NumVersions)
+	}
+	if n := countAll(); n != total-4 {
+		t.Fatalf("expected %d versions listed, got %d", total-4, n)
+	}
+
+	// A lost segment only loses the versions it held, until healed.
+	if err = os.Remove(filepath.Join(diskPath, volume, object, xlMetaSegmentFile(1))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = storage.ReadVersion(ctx, volume, object, versions[total-2], false); err != nil {
+		t.Fatal(err)
+	}
+	if n := countAll(); n == 0 || n >= total-4 {
+		t.Fatalf("unexpected %d versions listed", n)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"github.com/minio/minio/internal/config/api"
+	"github.com/minio/minio/internal/disk"
+	xioutil "github.com/minio/minio/internal/ioutil"
+)
+
+// odirectSettings is how a drive writes files larger than
+// smallFileThreshold.
+type odirectSettings struct {
+	// mode is the configured O_DIRECT mode of the drive.
+	mode string
+	// enabled reports whether writes use O_DIRECT.
+	enabled bool
+	// align is the size that O_DIRECT writes are aligned to,
+	// the unaligned remainder is written through the page cache.
+	align int
+	// reallyLargeBuffer writes with 4MiB buffers regardless of
+	// the size of the file.
+	reallyLargeBuffer bool
+}
+
+// odirect returns the write settings of the drive, derived from its
+// configured O_DIRECT mode and the characteristics detected when the
+// drive was initialized.
+func (s *xlStorage) odirect() odirectSettings {
+	o := odirectSettings{
+		mode:    globalAPIConfig.getODirectMode(s.diskPath),
+		enabled: true,
+		align:   xioutil.DirectioAlignSize,
+	}
+	switch o.mode {
+	case api.ODirectOff:
+		o.enabled = false
+	case api.ODirectAuto:
+		if s.driveInfo == nil {
+			// Unknown drive, keep the defaults.
+			break
+		}
+		o.enabled, o.align = odirectAlignment(*s.driveInfo)
+		// Fewer, larger writes amortize the seeks of spinning disks.
+		o.reallyLargeBuffer = s.driveInfo.Rotational
+	}
+	return o
+}
+
+// odirectAlignment returns whether O_DIRECT can be used on a drive and
+// the alignment of its writes. Our buffers are 4KiB aligned, drives
+// with larger logical blocks are written through the page cache.
+func odirectAlignment(info disk.DriveInfo) (bool, int) {
+	lbs := info.LogicalBlockSize
+	switch {
+	case lbs == 0 || lbs&(lbs-1) != 0:
+		return true, xioutil.DirectioAlignSize
+	case lbs > xioutil.DirectioAlignSize:
+		return false, xioutil.DirectioAlignSize
+	case lbs < 512:
+		return true, 512
+	}
+	return true, int(lbs)
+}
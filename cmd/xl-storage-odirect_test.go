@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/minio/minio/internal/config/api"
+	"github.com/minio/minio/internal/disk"
+)
+
+func TestODirectAlignment(t *testing.T) {
+	testCases := []struct {
+		logicalBlockSize uint64
+		enabled          bool
+		align            int
+	}{
+		{0, true, 4096},
+		{100, true, 4096},
+		{256, true, 512},
+		{512, true, 512},
+		{4096, true, 4096},
+		{8192, false, 4096},
+	}
+	for _, tc := range testCases {
+		enabled, align := odirectAlignment(disk.DriveInfo{LogicalBlockSize: tc.logicalBlockSize})
+		if enabled != tc.enabled || align != tc.align {
+			t.Errorf("logical block size %d: expected %v/%d, got %v/%d", tc.logicalBlockSize, tc.enabled, tc.align, enabled, align)
+		}
+	}
+}
+
+func TestXLStorageCreateFileODirect(t *testing.T) {
+	setODirect := func(o api.ODirectConfig) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.odirect = o
+		globalAPIConfig.mu.Unlock()
+	}
+	defer setODirect(api.ODirectConfig{})
+
+	xl, path, err := newXLStorageTestSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeAll(path)
+	s := xl.storage.(*xlStorage)
+
+	if err = xl.MakeVol(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, o := range []api.ODirectConfig{
+		{Mode: api.ODirectAuto},
+		{Mode: api.ODirectOn},
+		{Mode: api.ODirectOn, Drives: map[string]string{s.diskPath: api.ODirectOff}},
+		{Mode: api.ODirectOff},
+	} {
+		setODirect(o)
+		od := s.odirect()
+		if od.mode != o.GetMode(s.diskPath) {
+			t.Fatalf("expected mode %s, got %s", o.GetMode(s.diskPath), od.mode)
+		}
+		if od.mode == api.ODirectOff && od.enabled {
+			t.Fatal("expected O_DIRECT to be disabled")
+		}
+		for _, size := range []int{smallFileThreshold + 2, 1<<20 + 513, 5<<20 + 4096 + 7} {
+			data := make([]byte, size)
+			rand.Read(data)
+			name := fmt.Sprintf("%d-%s-%d", i, od.mode, size)
+			if err = xl.CreateFile(context.Background(), "bucket", name, int64(size), bytes.NewReader(data)); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			got, err := xl.ReadAll(context.Background(), "bucket", name)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("%s: content mismatch", name)
+			}
+			// More data than announced is never written.
+			if err = xl.CreateFile(context.Background(), "bucket", name+"-more", int64(size-1), bytes.NewReader(data)); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if err = xl.CreateFile(context.Background(), "bucket", name+"-less", int64(size+1), bytes.NewReader(data)); err != errLessData {
+				t.Fatalf("%s: expected %v, got %v", name, errLessData, err)
+			}
+		}
+	}
+}
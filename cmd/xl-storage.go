@@ -97,6 +97,9 @@ type xlStorage struct {
 
 	diskID string
 
+	// driveInfo of the device holding diskPath, nil if unknown.
+	driveInfo *disk.DriveInfo
+
 	// Indexes, will be -1 until assigned a set.
 	poolIndex, setIndex, diskIndex int
 
@@ -258,6 +261,9 @@ func newXLStorage(ep Endpoint) (*xlStorage, error) {
 		setIndex:   -1,
 		diskIndex:  -1,
 	}
+	if info, err := disk.GetDriveInfo(path); err == nil {
+		p.driveInfo = &info
+	}
 
 	// Create all necessary bucket folders if possible.
 	if err = p.MakeVolBulk(context.TODO(), minioMetaBucket, minioMetaTmpBucket, minioMetaMultipartBucket, dataUsageBucket, minioMetaSpeedTestBucket); err != nil {
@@ -453,7 +459,7 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			return sizeSummary{}, errSkipFile
 		}
 
-		buf, err := s.readMetadata(ctx, item.Path)
+		buf, err := s.readMetadataAll(ctx, item.Path)
 		if err != nil {
 			if intDataUpdateTracker.debug {
 				console.Debugf(color.Green("scannerBucket:")+" object path missing: %v: %w\n", item.Path, err)
@@ -491,6 +497,9 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			sz := item.applyActions(ctx, objAPI, oi, &sizeS)
 			if !oi.DeleteMarker && sz == oi.Size {
 				sizeS.versions++
+				if !oi.IsLatest {
+					sizeS.noncurrentSize += sz
+				}
 			}
 			sizeS.totalSize += sz
 
@@ -878,13 +887,15 @@ func (s *xlStorage) deleteVersions(ctx context.Context, volume, path string, fis
 		return err
 	}
 
-	var (
-		dataDir     string
-		lastVersion bool
-	)
-
+	var dataDir string
+	segments := make(map[int]*xlMetaSegment)
 	for _, fi := range fis {
-		dataDir, lastVersion, err = xlMeta.DeleteVersion(fi)
+		// The version may have been spilled into a segment.
+		target, err := s.segmentTarget(pathJoin(volumeDir, path), &xlMeta, fi.VersionID, segments)
+		if err != nil {
+			return err
+		}
+		dataDir, _, err = target.DeleteVersion(fi)
 		if err != nil {
 			return err
 		}
@@ -896,7 +907,7 @@ func (s *xlStorage) deleteVersions(ctx context.Context, volume, path string, fis
 			// PR #11758 used DataDir, preserve it
 			// for users who might have used master
 			// branch
-			if !xlMeta.data.remove(versionID, dataDir) {
+			if !target.data.remove(versionID, dataDir) && !s.dataDirInUse(pathJoin(volumeDir, path), &xlMeta, segments, versionID, dataDir) {
 				filePath := pathJoin(volumeDir, path, dataDir)
 				if err = checkPathLength(filePath); err != nil {
 					return err
@@ -910,14 +921,26 @@ func (s *xlStorage) deleteVersions(ctx context.Context, volume, path string, fis
 		}
 	}
 
-	if !lastVersion {
+	if err = s.writeSegments(ctx, volume, path, &xlMeta, segments); err != nil {
+		return err
+	}
+
+	if err = s.spillSegments(ctx, volume, path, &xlMeta); err != nil {
+		return err
+	}
+
+	if len(xlMeta.versions) > 0 {
 		buf, err = xlMeta.AppendTo(metaDataPoolGet())
 		defer metaDataPoolPut(buf)
 		if err != nil {
 			return err
 		}
 
-		return s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf)
+		if err = s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf); err != nil {
+			return err
+		}
+		s.removeMergedSegments(volumeDir, path, &xlMeta)
+		return nil
 	}
 
 	// Move xl.meta to trash
@@ -1005,7 +1028,14 @@ func (s *xlStorage) DeleteVersion(ctx context.Context, volume, path string, fi F
 		return err
 	}
 
-	dataDir, lastVersion, err := xlMeta.DeleteVersion(fi)
+	// The version may have been spilled into a segment.
+	segments := make(map[int]*xlMetaSegment)
+	target, err := s.segmentTarget(pathJoin(volumeDir, path), &xlMeta, fi.VersionID, segments)
+	if err != nil {
+		return err
+	}
+
+	dataDir, _, err := target.DeleteVersion(fi)
 	if err != nil {
 		return err
 	}
@@ -1017,7 +1047,7 @@ func (s *xlStorage) DeleteVersion(ctx context.Context, volume, path string, fi F
 		// PR #11758 used DataDir, preserve it
 		// for users who might have used master
 		// branch
-		if !xlMeta.data.remove(versionID, dataDir) {
+		if !target.data.remove(versionID, dataDir) && !s.dataDirInUse(pathJoin(volumeDir, path), &xlMeta, segments, versionID, dataDir) {
 			filePath := pathJoin(volumeDir, path, dataDir)
 			if err = checkPathLength(filePath); err != nil {
 				return err
@@ -1030,14 +1060,26 @@ func (s *xlStorage) DeleteVersion(ctx context.Context, volume, path string, fi F
 		}
 	}
 
-	if !lastVersion {
+	if err = s.writeSegments(ctx, volume, path, &xlMeta, segments); err != nil {
+		return err
+	}
+
+	if err = s.spillSegments(ctx, volume, path, &xlMeta); err != nil {
+		return err
+	}
+
+	if len(xlMeta.versions) > 0 {
 		buf, err = xlMeta.AppendTo(metaDataPoolGet())
 		defer metaDataPoolPut(buf)
 		if err != nil {
 			return err
 		}
 
-		return s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf)
+		if err = s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf); err != nil {
+			return err
+		}
+		s.removeMergedSegments(volumeDir, path, &xlMeta)
+		return nil
 	}
 
 	// Move xl.meta to trash
@@ -1080,7 +1122,25 @@ func (s *xlStorage) UpdateMetadata(ctx context.Context, volume, path string, fi
 		return err
 	}
 
-	if err = xlMeta.UpdateObjectVersion(fi); err != nil {
+	volumeDir, err := s.getVolDir(volume)
+	if err != nil {
+		return err
+	}
+	segments := make(map[int]*xlMetaSegment)
+	target, err := s.segmentTarget(pathJoin(volumeDir, path), &xlMeta, fi.VersionID, segments)
+	if err != nil {
+		return err
+	}
+
+	if err = target.UpdateObjectVersion(fi); err != nil {
+		return err
+	}
+
+	if err = s.writeSegments(ctx, volume, path, &xlMeta, segments); err != nil {
+		return err
+	}
+
+	if err = s.spillSegments(ctx, volume, path, &xlMeta); err != nil {
 		return err
 	}
 
@@ -1090,7 +1150,11 @@ func (s *xlStorage) UpdateMetadata(ctx context.Context, volume, path string, fi
 	}
 	defer metaDataPoolPut(wbuf)
 
-	return s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), wbuf)
+	if err = s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), wbuf); err != nil {
+		return err
+	}
+	s.removeMergedSegments(volumeDir, path, &xlMeta)
+	return nil
 }
 
 // WriteMetadata - writes FileInfo metadata for path at `xl.meta`
@@ -1142,11 +1206,29 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 			xlMeta = xlMetaV2{}
 		}
 
-		if err = xlMeta.AddVersion(fi); err != nil {
+		volumeDir, err := s.getVolDir(volume)
+		if err != nil {
+			return err
+		}
+		segments := make(map[int]*xlMetaSegment)
+		target, err := s.segmentTarget(pathJoin(volumeDir, path), &xlMeta, fi.VersionID, segments)
+		if err != nil {
+			return err
+		}
+
+		if err = target.AddVersion(fi); err != nil {
 			logger.LogIf(ctx, err)
 			return err
 		}
 
+		if err = s.writeSegments(ctx, volume, path, &xlMeta, segments); err != nil {
+			return err
+		}
+
+		if err = s.spillSegments(ctx, volume, path, &xlMeta); err != nil {
+			return err
+		}
+
 		buf, err = xlMeta.AppendTo(metaDataPoolGet())
 		defer metaDataPoolPut(buf)
 		if err != nil {
@@ -1155,7 +1237,17 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 		}
 	}
 
-	return s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf)
+	if err = s.WriteAll(ctx, volume, pathJoin(path, xlStorageFormatFile), buf); err != nil {
+		return err
+	}
+	if !xlMeta.mergedSegments.empty() {
+		volumeDir, err := s.getVolDir(volume)
+		if err != nil {
+			return err
+		}
+		s.removeMergedSegments(volumeDir, path, &xlMeta)
+	}
+	return nil
 }
 
 func (s *xlStorage) renameLegacyMetadata(volumeDir, path string) (err error) {
@@ -1256,6 +1348,10 @@ func (s *xlStorage) ReadVersion(ctx context.Context, volume, path, versionID str
 	}
 
 	fi, err = getFileInfo(buf, volume, path, versionID, readData)
+	if err == errFileVersionNotFound && versionID != "" {
+		// The version may have been spilled into a segment.
+		fi, err = s.readSegmentVersion(volume, volumeDir, path, buf, versionID, readData)
+	}
 	if err != nil {
 		return fi, err
 	}
@@ -1374,6 +1470,26 @@ func (s *xlStorage) readAllData(volumeDir string, filePath string) (buf []byte,
 	return buf, osErrToFileErr(err)
 }
 
+// ReadVersions - reads a version of all objects at paths in volume, the
+// one in versionIDs when not nil, the latest otherwise, errs holds the
+// error of each object.
+func (s *xlStorage) ReadVersions(ctx context.Context, volume string, paths, versionIDs []string, readData bool) (fis []FileInfo, errs []error) {
+	fis = make([]FileInfo, len(paths))
+	errs = make([]error, len(paths))
+	for i, path := range paths {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		var versionID string
+		if versionIDs != nil {
+			versionID = versionIDs[i]
+		}
+		fis[i], errs[i] = s.ReadVersion(ctx, volume, path, versionID, readData)
+	}
+	return fis, errs
+}
+
 // ReadAll reads from r until an error or EOF and returns the data it read.
 // A successful call returns err == nil, not err == EOF. Because ReadAll is
 // defined to read from src until EOF, it does not treat an EOF from Read
@@ -1554,6 +1670,24 @@ func (s *xlStorage) openFileNoSync(filePath string, mode int) (f *os.File, err e
 	return w, nil
 }
 
+// openDataFile opens a data file for reading through the page cache,
+// e.g. to send it without copying it.
+func (s *xlStorage) openDataFile(volume, path string) (*os.File, error) {
+	volumeDir, err := s.getVolDir(volume)
+	if err != nil {
+		return nil, err
+	}
+	filePath := pathJoin(volumeDir, path)
+	if err = checkPathLength(filePath); err != nil {
+		return nil, err
+	}
+	f, err := OpenFile(filePath, readMode, 0)
+	if err != nil {
+		return nil, osErrToFileErr(err)
+	}
+	return f, nil
+}
+
 // ReadFileStream - Returns the read stream of the file.
 func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
 	if offset < 0 {
@@ -1719,7 +1853,13 @@ func (s *xlStorage) CreateFile(ctx context.Context, volume, path string, fileSiz
 		return osErrToFileErr(err)
 	}
 
-	w, err := OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	od := s.odirect()
+	var w *os.File
+	if od.enabled {
+		w, err = OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	} else {
+		w, err = OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	}
 	if err != nil {
 		return osErrToFileErr(err)
 	}
@@ -1730,7 +1870,7 @@ func (s *xlStorage) CreateFile(ctx context.Context, volume, path string, fileSiz
 	}()
 
 	var bufp *[]byte
-	if fileSize > 0 && fileSize >= reallyLargeFileThreshold {
+	if od.reallyLargeBuffer || fileSize > 0 && fileSize >= reallyLargeFileThreshold {
 		// use a larger 4MiB buffer for really large streams.
 		bufp = xioutil.ODirectPoolXLarge.Get().(*[]byte)
 		defer xioutil.ODirectPoolXLarge.Put(bufp)
@@ -1739,7 +1879,15 @@ func (s *xlStorage) CreateFile(ctx context.Context, volume, path string, fileSiz
 		defer xioutil.ODirectPoolLarge.Put(bufp)
 	}
 
-	written, err := xioutil.CopyAligned(w, r, *bufp, fileSize)
+	var written int64
+	if od.enabled {
+		written, err = xioutil.CopyAlignedSize(w, r, *bufp, fileSize, od.align)
+	} else {
+		if fileSize >= 0 {
+			r = io.LimitReader(r, fileSize)
+		}
+		written, err = io.CopyBuffer(struct{ io.Writer }{w}, r, *bufp)
+	}
 	if err != nil {
 		return err
 	}
@@ -1838,39 +1986,54 @@ func (s *xlStorage) AppendFile(ctx context.Context, volume string, path string,
 }
 
 // CheckParts check if path has necessary parts available.
-func (s *xlStorage) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) error {
+func (s *xlStorage) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (*CheckPartsResp, error) {
 	volumeDir, err := s.getVolDir(volume)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Stat a volume entry.
 	if err = Access(volumeDir); err != nil {
 		if osIsNotExist(err) {
-			return errVolumeNotFound
+			return nil, errVolumeNotFound
 		}
-		return err
+		return nil, err
 	}
 
-	for _, part := range fi.Parts {
+	resp := &CheckPartsResp{
+		Results: make([]int, len(fi.Parts)),
+	}
+	for i, part := range fi.Parts {
 		partPath := pathJoin(path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
 		filePath := pathJoin(volumeDir, partPath)
 		if err = checkPathLength(filePath); err != nil {
-			return err
-		}
-		st, err := Lstat(filePath)
-		if err != nil {
-			return osErrToFileErr(err)
-		}
-		if st.Mode().IsDir() {
-			return errFileNotFound
+			return nil, err
 		}
-		// Check if shard is truncated.
-		if st.Size() < fi.Erasure.ShardFileSize(part.Size) {
-			return errFileCorrupt
+		err = s.checkPart(filePath, fi.Erasure.ShardFileSize(part.Size))
+		result, ok := partErrToCheckResult(err)
+		if !ok {
+			return nil, err
 		}
+		resp.Results[i] = result
 	}
 
+	return resp, nil
+}
+
+// checkPart checks that the shard of a part is present and
+// not truncated.
+func (s *xlStorage) checkPart(filePath string, shardFileSize int64) error {
+	st, err := Lstat(filePath)
+	if err != nil {
+		return osErrToFileErr(err)
+	}
+	if st.Mode().IsDir() {
+		return errFileNotFound
+	}
+	// Check if shard is truncated.
+	if st.Size() < shardFileSize {
+		return errFileCorrupt
+	}
 	return nil
 }
 
@@ -2138,12 +2301,25 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath string, f
 		}
 	}
 
+	// Healing or replicating a version spilled into a segment updates
+	// that segment, the null version is never spilled.
+	segments := make(map[int]*xlMetaSegment)
+	target, err := s.segmentTarget(pathJoin(dstVolumeDir, dstPath), &xlMeta, fi.VersionID, segments)
+	if err != nil {
+		if legacyPreserved {
+			// Any failed rename calls un-roll previous transaction.
+			s.deleteFile(dstVolumeDir, legacyDataPath, true)
+		}
+		return err
+	}
+
 	var oldDstDataPath string
 	if fi.VersionID == "" {
 		// return the latest "null" versionId info
 		ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, nullVersionID)
 		if err == nil && !ofi.Deleted {
-			if xlMeta.SharedDataDirCountStr(nullVersionID, ofi.DataDir) == 0 {
+			if xlMeta.SharedDataDirCountStr(nullVersionID, ofi.DataDir) == 0 &&
+				!s.dataDirInUse(pathJoin(dstVolumeDir, dstPath), &xlMeta, segments, nullVersionID, ofi.DataDir) {
 				// Purge the destination path as we are not preserving anything
 				// versioned object was not requested.
 				oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
@@ -2161,13 +2337,48 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath string, f
 		// the 'null' version. We add a free-version to track its tiered
 		// content for asynchronous deletion.
 		xlMeta.AddFreeVersion(fi)
+	} else if fi.DataDir != "" {
+		// An existing version rewritten with a new data dir, e.g. when its
+		// data is moved in or out of xl.meta, purge its previous data dir.
+		ofi, err := target.ToFileInfo(dstVolume, dstPath, fi.VersionID)
+		if err == nil && !ofi.Deleted && ofi.DataDir != "" && ofi.DataDir != fi.DataDir &&
+			target.SharedDataDirCountStr(fi.VersionID, ofi.DataDir) == 0 &&
+			!s.dataDirInUse(pathJoin(dstVolumeDir, dstPath), &xlMeta, segments, fi.VersionID, ofi.DataDir) {
+			oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
+		}
+	}
+
+	if len(fi.Data) == 0 && fi.Size > 0 {
+		// The data of the version is moved into its data dir below,
+		// drop any inline data it had.
+		versionID := fi.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		target.data.remove(versionID)
 	}
 
 	// indicates if RenameData() is called by healing.
 	// healing doesn't preserve the dataDir as 'legacy'
 	healing := fi.XLV1 && fi.DataDir != legacyDataDir
 
-	if err = xlMeta.AddVersion(fi); err != nil {
+	if err = target.AddVersion(fi); err != nil {
+		if legacyPreserved {
+			// Any failed rename calls un-roll previous transaction.
+			s.deleteFile(dstVolumeDir, legacyDataPath, true)
+		}
+		return err
+	}
+
+	if err = s.writeSegments(ctx, dstVolume, dstPath, &xlMeta, segments); err != nil {
+		if legacyPreserved {
+			// Any failed rename calls un-roll previous transaction.
+			s.deleteFile(dstVolumeDir, legacyDataPath, true)
+		}
+		return err
+	}
+
+	if err = s.spillSegments(ctx, dstVolume, dstPath, &xlMeta); err != nil {
 		if legacyPreserved {
 			// Any failed rename calls un-roll previous transaction.
 			s.deleteFile(dstVolumeDir, legacyDataPath, true)
@@ -2251,6 +2462,8 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath string, f
 		}
 	}
 
+	s.removeMergedSegments(dstVolumeDir, dstPath, &xlMeta)
+
 	// srcFilePath is always in minioMetaTmpBucket, an attempt to
 	// remove the temporary folder is enough since at this point
 	// ideally all transaction should be complete.
@@ -2356,45 +2569,45 @@ func (s *xlStorage) bitrotVerify(partPath string, partSize int64, algo BitrotAlg
 	return bitrotVerify(file, fi.Size(), partSize, algo, sum, shardSize)
 }
 
-func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (err error) {
+func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (*CheckPartsResp, error) {
 	volumeDir, err := s.getVolDir(volume)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Stat a volume entry.
 	if err = Access(volumeDir); err != nil {
 		if osIsNotExist(err) {
-			return errVolumeNotFound
+			return nil, errVolumeNotFound
 		} else if isSysErrIO(err) {
-			return errFaultyDisk
+			return nil, errFaultyDisk
 		} else if osIsPermission(err) {
-			return errVolumeAccessDenied
+			return nil, errVolumeAccessDenied
 		}
-		return err
+		return nil, err
 	}
 
+	resp := &CheckPartsResp{
+		Results: make([]int, len(fi.Parts)),
+	}
 	erasure := fi.Erasure
-	for _, part := range fi.Parts {
+	for i, part := range fi.Parts {
 		checksumInfo := erasure.GetChecksumInfo(part.Number)
 		partPath := pathJoin(volumeDir, path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
-		if err := s.bitrotVerify(partPath,
+		err := s.bitrotVerify(partPath,
 			erasure.ShardFileSize(part.Size),
 			checksumInfo.Algorithm,
-			checksumInfo.Hash, erasure.ShardSize()); err != nil {
-			if !IsErr(err, []error{
-				errFileNotFound,
-				errVolumeNotFound,
-				errFileCorrupt,
-			}...) {
-				logger.GetReqInfo(ctx).AppendTags("disk", s.String())
-				logger.LogIf(ctx, err)
-			}
-			return err
+			checksumInfo.Hash, erasure.ShardSize())
+		result, ok := partErrToCheckResult(err)
+		if !ok {
+			logger.GetReqInfo(ctx).AppendTags("disk", s.String())
+			logger.LogIf(ctx, err)
+			return nil, err
 		}
+		resp.Results[i] = result
 	}
 
-	return nil
+	return resp, nil
 }
 
 func (s *xlStorage) StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error) {
@@ -0,0 +1,60 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bandwidth
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const sysClassNet = "/sys/class/net"
+
+// probeLinkCapacity returns the speed of the fastest network interface
+// which is up in bytes per second, zero if it is unknown.
+func probeLinkCapacity() int64 {
+	ifaces, err := ioutil.ReadDir(sysClassNet)
+	if err != nil {
+		return 0
+	}
+	var capacity int64
+	for _, iface := range ifaces {
+		dir := filepath.Join(sysClassNet, iface.Name())
+		state, err := ioutil.ReadFile(filepath.Join(dir, "operstate"))
+		if err != nil || strings.TrimSpace(string(state)) != "up" {
+			continue
+		}
+		speed, err := ioutil.ReadFile(filepath.Join(dir, "speed"))
+		if err != nil {
+			continue
+		}
+		// speed is in Mbit/s, -1 if unknown.
+		mbits, err := strconv.ParseInt(strings.TrimSpace(string(speed)), 10, 64)
+		if err != nil || mbits <= 0 {
+			continue
+		}
+		if c := mbits * 1000 * 1000 / 8; c > capacity {
+			capacity = c
+		}
+	}
+	return capacity
+}
@@ -31,10 +31,28 @@ type throttle struct {
 	NodeBandwidthPerSec int64
 }
 
+// Limit is a bucket level replication bandwidth limit, either an
+// absolute number of bytes per second for the whole cluster or a
+// percentage of the link capacity of each node. The link capacity is
+// probed from the network interfaces of each node unless configured.
+type Limit struct {
+	BytesPerSec  int64   `json:"bytesPerSec,omitempty"`
+	Percent      float64 `json:"percent,omitempty"`
+	LinkCapacity int64   `json:"linkCapacity,omitempty"` // Bytes per second per node
+}
+
+// IsEmpty returns true if no limit is set.
+func (l Limit) IsEmpty() bool {
+	return l.BytesPerSec == 0 && l.Percent == 0
+}
+
 // Monitor holds the state of the global bucket monitor
 type Monitor struct {
-	tlock                 sync.RWMutex // mutex for bucketThrottle
+	tlock                 sync.RWMutex // mutex for bucketThrottle, targetLimits and bucketLimits
 	bucketThrottle        map[string]*throttle
+	targetLimits          map[string]int64              // Limits configured on the remote targets of buckets
+	bucketLimits          map[string]Limit              // Bucket level limits, capped by the target limits
+	linkCapacity          int64                         // Link capacity of this node in bytes per second
 	mlock                 sync.RWMutex                  // mutex for activeBuckets map
 	activeBuckets         map[string]*bucketMeasurement // Buckets with objects in flight
 	bucketMovingAvgTicker *time.Ticker                  // Ticker for calculating moving averages
@@ -47,6 +65,9 @@ func NewMonitor(ctx context.Context, numNodes uint64) *Monitor {
 	m := &Monitor{
 		activeBuckets:         make(map[string]*bucketMeasurement),
 		bucketThrottle:        make(map[string]*throttle),
+		targetLimits:          make(map[string]int64),
+		bucketLimits:          make(map[string]Limit),
+		linkCapacity:          probeLinkCapacity(),
 		bucketMovingAvgTicker: time.NewTicker(2 * time.Second),
 		ctx:                   ctx,
 		NodeCount:             numNodes,
@@ -56,8 +77,8 @@ func NewMonitor(ctx context.Context, numNodes uint64) *Monitor {
 }
 
 func (m *Monitor) updateMeasurement(bucket string, bytes uint64) {
-	m.mlock.Lock()
-	defer m.mlock.Unlock()
+	m.mlock.RLock()
+	defer m.mlock.RUnlock()
 	if m, ok := m.activeBuckets[bucket]; ok {
 		m.incrementBytes(bytes)
 	}
@@ -150,6 +171,8 @@ func (m *Monitor) track(bucket string) {
 func (m *Monitor) DeleteBucket(bucket string) {
 	m.tlock.Lock()
 	delete(m.bucketThrottle, bucket)
+	delete(m.targetLimits, bucket)
+	delete(m.bucketLimits, bucket)
 	m.tlock.Unlock()
 	m.mlock.Lock()
 	delete(m.activeBuckets, bucket)
@@ -163,21 +186,94 @@ func (m *Monitor) throttle(bucket string) *throttle {
 	return m.bucketThrottle[bucket]
 }
 
-// SetBandwidthLimit sets the bandwidth limit for a bucket
+// SetBandwidthLimit sets the bandwidth limit configured on the remote
+// targets of a bucket, a zero limit removes it.
 func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
 	m.tlock.Lock()
 	defer m.tlock.Unlock()
-	bw := limit / int64(m.NodeCount)
+	if limit <= 0 {
+		delete(m.targetLimits, bucket)
+	} else {
+		m.targetLimits[bucket] = limit
+	}
+	m.updateThrottle(bucket)
+}
+
+// SetBucketLimit sets the bucket level bandwidth limit, the limits of
+// the remote targets remain a hard cap. An empty limit removes it.
+func (m *Monitor) SetBucketLimit(bucket string, limit Limit) {
+	m.tlock.Lock()
+	defer m.tlock.Unlock()
+	if limit.IsEmpty() {
+		delete(m.bucketLimits, bucket)
+	} else {
+		m.bucketLimits[bucket] = limit
+	}
+	m.updateThrottle(bucket)
+}
+
+// nodeLinkCapacity returns the link capacity a percentage limit applies
+// to on this node, zero if it is unknown. Must be called with tlock held.
+func (m *Monitor) nodeLinkCapacity(limit Limit) int64 {
+	if limit.LinkCapacity > 0 {
+		return limit.LinkCapacity
+	}
+	return m.linkCapacity
+}
+
+// nodeBandwidthLimit returns the bandwidth limit of bucket on this node,
+// zero if it is not throttled. The limit configured on the remote targets
+// caps the bucket level limit. Must be called with tlock held.
+func (m *Monitor) nodeBandwidthLimit(bucket string) int64 {
+	targetLimit := m.targetLimits[bucket] / int64(m.NodeCount)
+	limit, ok := m.bucketLimits[bucket]
+	if !ok {
+		return targetLimit
+	}
+	var bw int64
+	if limit.Percent > 0 {
+		// Only the target limit applies while the link capacity is unknown.
+		bw = int64(float64(m.nodeLinkCapacity(limit)) * limit.Percent / 100)
+	} else {
+		bw = limit.BytesPerSec / int64(m.NodeCount)
+	}
+	if bw <= 0 || (targetLimit > 0 && targetLimit < bw) {
+		return targetLimit
+	}
+	return bw
+}
+
+// updateThrottle applies the current bandwidth limit of bucket, readers
+// already in flight pick up the new limit. Must be called with tlock held.
+func (m *Monitor) updateThrottle(bucket string) {
+	bw := m.nodeBandwidthLimit(bucket)
+	if bw <= 0 {
+		delete(m.bucketThrottle, bucket)
+		return
+	}
 	t, ok := m.bucketThrottle[bucket]
 	if !ok {
-		t = &throttle{
+		m.bucketThrottle[bucket] = &throttle{
+			Limiter:             rate.NewLimiter(rate.Limit(bw), int(bw)),
 			NodeBandwidthPerSec: bw,
 		}
+		return
+	}
+	if t.NodeBandwidthPerSec == bw {
+		return
 	}
 	t.NodeBandwidthPerSec = bw
-	newlimit := rate.Every(time.Second / time.Duration(t.NodeBandwidthPerSec))
-	t.Limiter = rate.NewLimiter(newlimit, int(t.NodeBandwidthPerSec))
-	m.bucketThrottle[bucket] = t
+	t.SetLimit(rate.Limit(bw))
+	t.SetBurst(int(bw))
+}
+
+// BucketLimit returns the bucket level bandwidth limit of bucket and the
+// link capacity its percentage applies to on this node.
+func (m *Monitor) BucketLimit(bucket string) (Limit, int64) {
+	m.tlock.RLock()
+	defer m.tlock.RUnlock()
+	limit := m.bucketLimits[bucket]
+	return limit, m.nodeLinkCapacity(limit)
 }
 
 // IsThrottled returns true if a bucket has bandwidth throttling enabled.
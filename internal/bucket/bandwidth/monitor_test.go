@@ -18,6 +18,7 @@
 package bandwidth
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 
 const (
 	oneMiB uint64 = 1024 * 1024
+	oneMB  int64  = 1000 * 1000
 )
 
 func TestMonitor_GetReport(t *testing.T) {
@@ -110,3 +112,102 @@ func TestMonitor_GetReport(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_SetBucketLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewMonitor(ctx, 2)
+
+	nodeLimit := func() int64 {
+		m.tlock.RLock()
+		defer m.tlock.RUnlock()
+		if t := m.bucketThrottle["bucket"]; t != nil {
+			if int64(t.Limit()) != t.NodeBandwidthPerSec || t.Burst() != int(t.NodeBandwidthPerSec) {
+				return -1
+			}
+			return t.NodeBandwidthPerSec
+		}
+		return 0
+	}
+
+	// Link capacity not probed.
+	m.tlock.Lock()
+	m.linkCapacity = 0
+	m.tlock.Unlock()
+
+	m.SetBandwidthLimit("bucket", 200*oneMB)
+	if got := nodeLimit(); got != 100*oneMB {
+		t.Fatalf("target limit: got %d, want %d", got, 100*oneMB)
+	}
+	thr := m.throttle("bucket")
+
+	m.SetBucketLimit("bucket", Limit{BytesPerSec: 100 * oneMB})
+	if got := nodeLimit(); got != 50*oneMB {
+		t.Fatalf("bucket limit: got %d, want %d", got, 50*oneMB)
+	}
+	if m.throttle("bucket") != thr {
+		t.Fatal("expected throttle to be updated in place")
+	}
+
+	// The target limit is a hard cap.
+	m.SetBucketLimit("bucket", Limit{BytesPerSec: 400 * oneMB})
+	if got := nodeLimit(); got != 100*oneMB {
+		t.Fatalf("capped bucket limit: got %d, want %d", got, 100*oneMB)
+	}
+
+	// Only the target limit applies while the link capacity is unknown.
+	m.SetBucketLimit("bucket", Limit{Percent: 50})
+	if got := nodeLimit(); got != 100*oneMB {
+		t.Fatalf("percent limit without capacity: got %d, want %d", got, 100*oneMB)
+	}
+
+	// Percentage of the probed link capacity.
+	m.tlock.Lock()
+	m.linkCapacity = 300 * oneMB
+	m.tlock.Unlock()
+	m.SetBucketLimit("bucket", Limit{Percent: 25})
+	if got := nodeLimit(); got != 75*oneMB {
+		t.Fatalf("percent limit: got %d, want %d", got, 75*oneMB)
+	}
+
+	// A configured link capacity takes precedence over the probed one.
+	m.SetBucketLimit("bucket", Limit{Percent: 25, LinkCapacity: 100 * oneMB})
+	if got := nodeLimit(); got != 25*oneMB {
+		t.Fatalf("percent of configured capacity: got %d, want %d", got, 25*oneMB)
+	}
+	if _, capacity := m.BucketLimit("bucket"); capacity != 100*oneMB {
+		t.Fatalf("link capacity: got %d, want %d", capacity, 100*oneMB)
+	}
+
+	// Measured bandwidth doesn't change a percentage limit.
+	m.track("bucket")
+	m.updateMeasurement("bucket", uint64(1000*oneMB))
+	m.updateMovingAvg()
+	if got := nodeLimit(); got != 25*oneMB {
+		t.Fatalf("percent limit after measurement: got %d, want %d", got, 25*oneMB)
+	}
+
+	// Percentage capped by the target limit.
+	m.SetBucketLimit("bucket", Limit{Percent: 50})
+	if got := nodeLimit(); got != 100*oneMB {
+		t.Fatalf("capped percent limit: got %d, want %d", got, 100*oneMB)
+	}
+
+	// Without a target limit the bucket limit applies alone.
+	m.SetBandwidthLimit("bucket", 0)
+	if got := nodeLimit(); got != 150*oneMB {
+		t.Fatalf("percent limit without target limit: got %d, want %d", got, 150*oneMB)
+	}
+
+	// Removing the bucket limit restores the target limit.
+	m.SetBandwidthLimit("bucket", 200*oneMB)
+	m.SetBucketLimit("bucket", Limit{})
+	if got := nodeLimit(); got != 100*oneMB {
+		t.Fatalf("restored target limit: got %d, want %d", got, 100*oneMB)
+	}
+
+	m.SetBandwidthLimit("bucket", 0)
+	if m.IsThrottled("bucket") {
+		t.Fatal("expected bucket to not be throttled")
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package compression
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/pkg/wildcard"
+)
+
+// Algorithm is the compression algorithm applied by a rule.
+type Algorithm string
+
+// Supported compression algorithms.
+const (
+	// None disables compression of the matching objects.
+	None Algorithm = "none"
+	// S2 is the default compression, fast and suited to any object size.
+	S2 Algorithm = "s2"
+	// Zstd compresses better than S2 at a higher CPU cost, it can use
+	// a dictionary to compress small objects sharing a structure.
+	Zstd Algorithm = "zstd"
+)
+
+// Rule selects the compression of the objects of a bucket by
+// content-type and size.
+type Rule struct {
+	// ContentTypes are the wildcard patterns of the content-types
+	// the rule applies to, e.g. "application/json" or "text/*".
+	ContentTypes []string `json:"contentTypes"`
+	// MinSize is the size in bytes below which objects are not
+	// matched by the rule.
+	MinSize int64 `json:"minSize,omitempty"`
+	// MaxSize is the size in bytes above which objects are not
+	// matched by the rule, 0 for no limit.
+	MaxSize   int64     `json:"maxSize,omitempty"`
+	Algorithm Algorithm `json:"algorithm"`
+	// Dictionary is a zstd dictionary, as trained with `zstd --train`.
+	// It is only used for objects uploaded in a single request.
+	Dictionary []byte `json:"dictionary,omitempty"`
+}
+
+// Matches returns true if the rule applies to an object of the content-type
+// and size, a negative size is unknown and matches any size limit.
+func (r Rule) Matches(contentType string, size int64) bool {
+	if size >= 0 && (size < r.MinSize || (r.MaxSize > 0 && size > r.MaxSize)) {
+		return false
+	}
+	contentType = strings.ToLower(contentType)
+	for _, pattern := range r.ContentTypes {
+		if wildcard.MatchSimple(strings.ToLower(pattern), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate - validates the rule.
+func (r Rule) Validate() error {
+	if len(r.ContentTypes) == 0 {
+		return errors.New("compression rule must have at least one content-type")
+	}
+	for _, pattern := range r.ContentTypes {
+		if pattern == "" {
+			return errors.New("compression rule content-type cannot be empty")
+		}
+	}
+	if r.MinSize < 0 || r.MaxSize < 0 {
+		return errors.New("compression rule sizes cannot be negative")
+	}
+	if r.MaxSize > 0 && r.MaxSize < r.MinSize {
+		return errors.New("compression rule maxSize cannot be smaller than minSize")
+	}
+	switch r.Algorithm {
+	case None, S2:
+		if len(r.Dictionary) > 0 {
+			return fmt.Errorf("compression algorithm %s does not support dictionaries", r.Algorithm)
+		}
+	case Zstd:
+		if len(r.Dictionary) > 0 {
+			if err := validateDictionary(r.Dictionary); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported compression algorithm %q", r.Algorithm)
+	}
+	return nil
+}
+
+// Config is the compression configuration of a bucket, the first
+// rule matching an object applies. Objects matched by no rule are
+// compressed according to the server wide configuration.
+type Config struct {
+	Rules []Rule `json:"rules"`
+	// RetiredDictionaries are the dictionaries of the rules which
+	// were removed, kept to read the objects compressed with them.
+	RetiredDictionaries [][]byte `json:"retiredDictionaries,omitempty"`
+}
+
+// IsEmpty returns true if the config has no rules.
+func (c Config) IsEmpty() bool {
+	return len(c.Rules) == 0
+}
+
+// Match returns the first rule applying to an object of
+// the content-type and size, nil if none applies.
+func (c Config) Match(contentType string, size int64) *Rule {
+	for i := range c.Rules {
+		if c.Rules[i].Matches(contentType, size) {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Dictionaries returns all the dictionaries which objects of
+// the bucket may have been compressed with.
+func (c Config) Dictionaries() [][]byte {
+	dicts := append([][]byte{}, c.RetiredDictionaries...)
+	for _, r := range c.Rules {
+		if len(r.Dictionary) > 0 {
+			dicts = append(dicts, r.Dictionary)
+		}
+	}
+	return dicts
+}
+
+// RetainDictionaries keeps the dictionaries of prev which are no longer
+// used by the rules of c, objects compressed with them stay readable.
+func (c *Config) RetainDictionaries(prev *Config) {
+	if prev == nil {
+		return
+	}
+	for _, dict := range prev.Dictionaries() {
+		var found bool
+		for _, d := range c.Dictionaries() {
+			if bytes.Equal(d, dict) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.RetiredDictionaries = append(c.RetiredDictionaries, dict)
+		}
+	}
+}
+
+// Validate - validates the compression configuration.
+func (c Config) Validate() error {
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, dict := range c.RetiredDictionaries {
+		if err := validateDictionary(dict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := json.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// validateDictionary returns an error if dict is not a zstd dictionary.
+func validateDictionary(dict []byte) error {
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderDict(dict))
+	if err != nil {
+		return fmt.Errorf("invalid zstd dictionary: %w", err)
+	}
+	return enc.Close()
+}
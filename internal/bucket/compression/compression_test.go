@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package compression
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	dict, err := os.ReadFile("testdata/json.dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encDict := base64.StdEncoding.EncodeToString(dict)
+
+	testCases := []struct {
+		config     string
+		shouldFail bool
+	}{
+		{`{"rules":[]}`, false},
+		{`{"rules":[{"contentTypes":["text/*"],"algorithm":"s2"}]}`, false},
+		{`{"rules":[{"contentTypes":["application/json"],"maxSize":4096,"algorithm":"zstd","dictionary":"` + encDict + `"}]}`, false},
+		{`{"rules":[{"contentTypes":["image/*"],"algorithm":"none"}]}`, false},
+		{`{"rules":[{"contentTypes":[],"algorithm":"s2"}]}`, true},
+		{`{"rules":[{"contentTypes":["text/*"],"algorithm":"gzip"}]}`, true},
+		{`{"rules":[{"contentTypes":["text/*"],"minSize":-1,"algorithm":"s2"}]}`, true},
+		{`{"rules":[{"contentTypes":["text/*"],"minSize":10,"maxSize":5,"algorithm":"s2"}]}`, true},
+		{`{"rules":[{"contentTypes":["text/*"],"algorithm":"s2","dictionary":"` + encDict + `"}]}`, true},
+		{`{"rules":[{"contentTypes":["text/*"],"algorithm":"zstd","dictionary":"aGVsbG8="}]}`, true},
+		{`{"rules":`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.shouldFail && err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+		if !testCase.shouldFail && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	cfg := Config{
+		Rules: []Rule{
+			{ContentTypes: []string{"application/json"}, MaxSize: 4096, Algorithm: Zstd},
+			{ContentTypes: []string{"text/*", "application/json"}, MinSize: 1024, Algorithm: S2},
+			{ContentTypes: []string{"image/*"}, Algorithm: None},
+		},
+	}
+	testCases := []struct {
+		contentType string
+		size        int64
+		algorithm   Algorithm
+	}{
+		{"application/json", 100, Zstd},
+		{"Application/JSON", 4096, Zstd},
+		{"application/json", 8192, S2},
+		{"application/json", -1, Zstd},
+		{"text/plain", 100, ""},
+		{"text/plain", 2048, S2},
+		{"image/png", 1 << 20, None},
+		{"video/mp4", 1 << 20, ""},
+	}
+	for i, testCase := range testCases {
+		var algorithm Algorithm
+		if rule := cfg.Match(testCase.contentType, testCase.size); rule != nil {
+			algorithm = rule.Algorithm
+		}
+		if algorithm != testCase.algorithm {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.algorithm, algorithm)
+		}
+	}
+}
+
+func TestRetainDictionaries(t *testing.T) {
+	dict, err := os.ReadFile("testdata/json.dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := &Config{
+		Rules: []Rule{{ContentTypes: []string{"application/json"}, Algorithm: Zstd, Dictionary: dict}},
+	}
+
+	// The dictionary is still in use, nothing to retire.
+	cfg := &Config{
+		Rules: []Rule{{ContentTypes: []string{"*/json"}, Algorithm: Zstd, Dictionary: dict}},
+	}
+	cfg.RetainDictionaries(prev)
+	if len(cfg.RetiredDictionaries) != 0 || len(cfg.Dictionaries()) != 1 {
+		t.Fatalf("expected dictionary not to be retired, got %d", len(cfg.RetiredDictionaries))
+	}
+
+	// The rule is removed, the dictionary is retired once.
+	cfg = &Config{}
+	cfg.RetainDictionaries(prev)
+	cfg.RetainDictionaries(prev)
+	if len(cfg.RetiredDictionaries) != 1 || len(cfg.Dictionaries()) != 1 {
+		t.Fatalf("expected dictionary to be retired, got %d", len(cfg.RetiredDictionaries))
+	}
+}
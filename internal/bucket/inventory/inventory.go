@@ -0,0 +1,320 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format of the inventory data files.
+type Format string
+
+// Supported inventory formats, ORC is not supported.
+const (
+	CSV     Format = "CSV"
+	Parquet Format = "Parquet"
+	ORC     Format = "ORC"
+)
+
+// Frequency of the inventory reports.
+type Frequency string
+
+// Supported inventory frequencies.
+const (
+	Daily  Frequency = "Daily"
+	Weekly Frequency = "Weekly"
+)
+
+// Interval returns the time between two reports.
+func (f Frequency) Interval() time.Duration {
+	if f == Weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Versions of the objects listed in the inventory reports.
+const (
+	AllVersions     = "All"
+	CurrentVersions = "Current"
+)
+
+// Optional fields of the inventory reports, in the order of the report columns.
+const (
+	FieldSize                      = "Size"
+	FieldLastModifiedDate          = "LastModifiedDate"
+	FieldStorageClass              = "StorageClass"
+	FieldETag                      = "ETag"
+	FieldIsMultipartUploaded       = "IsMultipartUploaded"
+	FieldReplicationStatus         = "ReplicationStatus"
+	FieldEncryptionStatus          = "EncryptionStatus"
+	FieldObjectLockRetainUntilDate = "ObjectLockRetainUntilDate"
+	FieldObjectLockMode            = "ObjectLockMode"
+	FieldObjectLockLegalHoldStatus = "ObjectLockLegalHoldStatus"
+)
+
+var supportedFields = []string{
+	FieldSize,
+	FieldLastModifiedDate,
+	FieldStorageClass,
+	FieldETag,
+	FieldIsMultipartUploaded,
+	FieldReplicationStatus,
+	FieldEncryptionStatus,
+	FieldObjectLockRetainUntilDate,
+	FieldObjectLockMode,
+	FieldObjectLockLegalHoldStatus,
+}
+
+const (
+	// maxConfigs is the maximum number of inventory configurations of a bucket.
+	maxConfigs = 1000
+
+	// bucketARNPrefix is the prefix of the destination bucket ARN.
+	bucketARNPrefix = "arn:aws:s3:::"
+)
+
+// Filter selects the objects listed in the reports.
+type Filter struct {
+	Prefix string `xml:"Prefix,omitempty"`
+}
+
+// SSEKMS requests the report files to be encrypted with a KMS key.
+type SSEKMS struct {
+	KeyID string `xml:"KeyId"`
+}
+
+// Encryption of the report files.
+type Encryption struct {
+	SSES3  *struct{} `xml:"SSE-S3,omitempty"`
+	SSEKMS *SSEKMS   `xml:"SSE-KMS,omitempty"`
+}
+
+// BucketDestination is the bucket the reports are written to.
+type BucketDestination struct {
+	AccountID  string      `xml:"AccountId,omitempty"`
+	Bucket     string      `xml:"Bucket"`
+	Format     Format      `xml:"Format"`
+	Prefix     string      `xml:"Prefix,omitempty"`
+	Encryption *Encryption `xml:"Encryption,omitempty"`
+}
+
+// Destination of the reports.
+type Destination struct {
+	S3BucketDestination BucketDestination `xml:"S3BucketDestination"`
+}
+
+// Schedule of the reports.
+type Schedule struct {
+	Frequency Frequency `xml:"Frequency"`
+}
+
+// Config - an S3 inventory configuration.
+type Config struct {
+	XMLNS                  string      `xml:"xmlns,attr,omitempty"`
+	XMLName                xml.Name    `xml:"InventoryConfiguration"`
+	ID                     string      `xml:"Id"`
+	IsEnabled              bool        `xml:"IsEnabled"`
+	Filter                 *Filter     `xml:"Filter,omitempty"`
+	Destination            Destination `xml:"Destination"`
+	Schedule               Schedule    `xml:"Schedule"`
+	IncludedObjectVersions string      `xml:"IncludedObjectVersions"`
+	OptionalFields         []string    `xml:"OptionalFields>Field,omitempty"`
+}
+
+// Validate - validates the inventory configuration.
+func (c Config) Validate() error {
+	if c.ID == "" || len(c.ID) > 64 {
+		return Errorf("inventory configuration ID must be between 1 and 64 characters")
+	}
+	dst := c.Destination.S3BucketDestination
+	if !strings.HasPrefix(dst.Bucket, bucketARNPrefix) || dst.Bucket == bucketARNPrefix {
+		return Errorf("invalid destination bucket ARN %q", dst.Bucket)
+	}
+	switch dst.Format {
+	case CSV, Parquet:
+	case ORC:
+		return Errorf("inventory format %s is not supported", dst.Format)
+	default:
+		return Errorf("invalid inventory format %q", dst.Format)
+	}
+	if e := dst.Encryption; e != nil {
+		if (e.SSES3 == nil) == (e.SSEKMS == nil) {
+			return Errorf("inventory encryption must be one of SSE-S3 or SSE-KMS")
+		}
+		if e.SSEKMS != nil && e.SSEKMS.KeyID == "" {
+			return Errorf("inventory SSE-KMS encryption requires a key ID")
+		}
+	}
+	switch c.Schedule.Frequency {
+	case Daily, Weekly:
+	default:
+		return Errorf("invalid inventory frequency %q", c.Schedule.Frequency)
+	}
+	switch c.IncludedObjectVersions {
+	case AllVersions, CurrentVersions:
+	default:
+		return Errorf("invalid inventory included object versions %q", c.IncludedObjectVersions)
+	}
+	seen := make(map[string]bool, len(c.OptionalFields))
+	for _, field := range c.OptionalFields {
+		if !isSupportedField(field) {
+			return Errorf("inventory field %q is not supported", field)
+		}
+		if seen[field] {
+			return Errorf("duplicate inventory field %q", field)
+		}
+		seen[field] = true
+	}
+	return nil
+}
+
+func isSupportedField(field string) bool {
+	for _, f := range supportedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// DestinationBucket returns the name of the destination bucket.
+func (c Config) DestinationBucket() string {
+	return strings.TrimPrefix(c.Destination.S3BucketDestination.Bucket, bucketARNPrefix)
+}
+
+// Prefix returns the prefix of the objects listed in the reports.
+func (c Config) Prefix() string {
+	if c.Filter == nil {
+		return ""
+	}
+	return c.Filter.Prefix
+}
+
+// IncludesAllVersions returns true if the reports list all versions.
+func (c Config) IncludesAllVersions() bool {
+	return c.IncludedObjectVersions == AllVersions
+}
+
+// Fields returns the optional fields of the reports,
+// in the order of the report columns.
+func (c Config) Fields() []string {
+	var fields []string
+	for _, f := range supportedFields {
+		for _, field := range c.OptionalFields {
+			if f == field {
+				fields = append(fields, f)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// Columns returns the columns of the reports: the bucket, the key, the
+// version fields when all versions are listed and the optional fields.
+func (c Config) Columns() []Column {
+	columns := []Column{{Name: "Bucket"}, {Name: "Key"}}
+	if c.IncludesAllVersions() {
+		columns = append(columns,
+			Column{Name: "VersionId"},
+			Column{Name: "IsLatest", Type: BoolColumn},
+			Column{Name: "IsDeleteMarker", Type: BoolColumn})
+	}
+	for _, field := range c.Fields() {
+		column := Column{Name: field}
+		switch field {
+		case FieldSize:
+			column.Type = Int64Column
+		case FieldIsMultipartUploaded:
+			column.Type = BoolColumn
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// ParseConfig - parses data in given reader to an inventory configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Configs - all the inventory configurations of a bucket.
+type Configs struct {
+	XMLName xml.Name `xml:"InventoryConfigurations"`
+	Configs []Config `xml:"InventoryConfiguration"`
+}
+
+// Get returns the configuration with the ID, nil if not found.
+func (c *Configs) Get(id string) *Config {
+	for i := range c.Configs {
+		if c.Configs[i].ID == id {
+			return &c.Configs[i]
+		}
+	}
+	return nil
+}
+
+// Set adds the configuration or replaces the one with the same ID.
+func (c *Configs) Set(cfg Config) error {
+	if existing := c.Get(cfg.ID); existing != nil {
+		*existing = cfg
+		return nil
+	}
+	if len(c.Configs) >= maxConfigs {
+		return Errorf("a bucket cannot have more than %d inventory configurations", maxConfigs)
+	}
+	c.Configs = append(c.Configs, cfg)
+	return nil
+}
+
+// Delete removes the configuration with the ID,
+// returns false if not found.
+func (c *Configs) Delete(id string) bool {
+	for i := range c.Configs {
+		if c.Configs[i].ID == id {
+			c.Configs = append(c.Configs[:i], c.Configs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConfigs - parses data in given reader to inventory configurations.
+func ParseConfigs(reader io.Reader) (*Configs, error) {
+	var c Configs
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	for _, cfg := range c.Configs {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &c, nil
+}
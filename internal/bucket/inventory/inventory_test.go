@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func inventoryXML(id, bucket, format, frequency, versions, extra string) string {
+	return fmt.Sprintf(`<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Id>%s</Id><IsEnabled>true</IsEnabled>
+<Destination><S3BucketDestination><Bucket>%s</Bucket><Format>%s</Format><Prefix>reports</Prefix>%s</S3BucketDestination></Destination>
+<Schedule><Frequency>%s</Frequency></Schedule>
+<IncludedObjectVersions>%s</IncludedObjectVersions>
+<OptionalFields><Field>ETag</Field><Field>Size</Field></OptionalFields>
+</InventoryConfiguration>`, id, bucket, format, extra, frequency, versions)
+}
+
+func TestParseConfig(t *testing.T) {
+	const dst = "arn:aws:s3:::reports"
+	testCases := []struct {
+		config     string
+		shouldFail bool
+	}{
+		{inventoryXML("report1", dst, "CSV", "Daily", "All", ""), false},
+		{inventoryXML("report1", dst, "Parquet", "Weekly", "Current", ""), false},
+		{inventoryXML("report1", dst, "CSV", "Daily", "All", "<Encryption><SSE-S3/></Encryption>"), false},
+		{inventoryXML("report1", dst, "CSV", "Daily", "All", "<Encryption><SSE-KMS><KeyId>key</KeyId></SSE-KMS></Encryption>"), false},
+		{inventoryXML("report1", dst, "CSV", "Daily", "All", "<Encryption><SSE-KMS></SSE-KMS></Encryption>"), true},
+		{inventoryXML("report1", dst, "CSV", "Daily", "All", "<Encryption></Encryption>"), true},
+		{inventoryXML("", dst, "CSV", "Daily", "All", ""), true},
+		{inventoryXML("report1", "reports", "CSV", "Daily", "All", ""), true},
+		{inventoryXML("report1", "arn:aws:s3:::", "CSV", "Daily", "All", ""), true},
+		{inventoryXML("report1", dst, "ORC", "Daily", "All", ""), true},
+		{inventoryXML("report1", dst, "CSV", "Hourly", "All", ""), true},
+		{inventoryXML("report1", dst, "CSV", "Daily", "Some", ""), true},
+		{strings.Replace(inventoryXML("report1", dst, "CSV", "Daily", "All", ""), "ETag", "BucketKeyStatus", 1), true},
+		{strings.Replace(inventoryXML("report1", dst, "CSV", "Daily", "All", ""), "ETag", "Size", 1), true},
+	}
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.shouldFail && err == nil {
+			t.Errorf("Test %d: expected to fail", i+1)
+		}
+		if !testCase.shouldFail && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	cfg, err := ParseConfig(strings.NewReader(inventoryXML("report1", "arn:aws:s3:::reports", "CSV", "Daily", "All", "<Encryption><SSE-S3/></Encryption>")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DestinationBucket() != "reports" {
+		t.Fatalf("unexpected destination bucket %s", cfg.DestinationBucket())
+	}
+	if fields := strings.Join(cfg.Fields(), ","); fields != "Size,ETag" {
+		t.Fatalf("unexpected fields %s", fields)
+	}
+
+	var configs Configs
+	if err = configs.Set(*cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.ID = "report2"
+	if err = configs.Set(*cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.IsEnabled = false
+	if err = configs.Set(*cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(configs.Configs) != 2 || configs.Get("report2").IsEnabled {
+		t.Fatalf("unexpected configurations %+v", configs.Configs)
+	}
+
+	data, err := xml.Marshal(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseConfigs(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Configs) != 2 || parsed.Get("report1").Destination.S3BucketDestination.Encryption.SSES3 == nil {
+		t.Fatalf("unexpected configurations %s", data)
+	}
+	if !parsed.Delete("report1") || parsed.Delete("report1") || parsed.Get("report1") != nil {
+		t.Fatal("expected report1 to be deleted once")
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/klauspost/compress/snappy"
+	"github.com/minio/parquet-go/gen-go/parquet"
+)
+
+// parquetMagic starts and ends every parquet file.
+const parquetMagic = "PAR1"
+
+// parquetRowGroupSize is the number of rows buffered per row group.
+const parquetRowGroupSize = 10000
+
+// ColumnType is the type of a report column.
+type ColumnType int
+
+// Supported column types.
+const (
+	StringColumn ColumnType = iota
+	Int64Column
+	BoolColumn
+)
+
+// Column of a report.
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// parquetColumn buffers the values of a column of the current row group.
+type parquetColumn struct {
+	Column
+	data  bytes.Buffer
+	bools []bool
+}
+
+// ParquetWriter writes report rows as a parquet file, all
+// columns are required, plain encoded and snappy compressed.
+type ParquetWriter struct {
+	w         io.Writer
+	columns   []*parquetColumn
+	offset    int64
+	rows      int64
+	rowGroups []*parquet.RowGroup
+	numRows   int64
+}
+
+// NewParquetWriter starts a parquet file with the columns.
+func NewParquetWriter(w io.Writer, columns []Column) (*ParquetWriter, error) {
+	if _, err := io.WriteString(w, parquetMagic); err != nil {
+		return nil, err
+	}
+	pw := &ParquetWriter{w: w, offset: int64(len(parquetMagic))}
+	for _, c := range columns {
+		pw.columns = append(pw.columns, &parquetColumn{Column: c})
+	}
+	return pw, nil
+}
+
+// Write adds a row, values must match the column types:
+// string, int64 or bool.
+func (pw *ParquetWriter) Write(values []interface{}) error {
+	if len(values) != len(pw.columns) {
+		return fmt.Errorf("parquet: expected %d values, got %d", len(pw.columns), len(values))
+	}
+	for i, c := range pw.columns {
+		var ok bool
+		switch c.Type {
+		case StringColumn:
+			_, ok = values[i].(string)
+		case Int64Column:
+			_, ok = values[i].(int64)
+		case BoolColumn:
+			_, ok = values[i].(bool)
+		}
+		if !ok {
+			return fmt.Errorf("parquet: unexpected value %T for column %s", values[i], c.Name)
+		}
+	}
+	for i, c := range pw.columns {
+		switch v := values[i].(type) {
+		case string:
+			var length [4]byte
+			binary.LittleEndian.PutUint32(length[:], uint32(len(v)))
+			c.data.Write(length[:])
+			c.data.WriteString(v)
+		case int64:
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], uint64(v))
+			c.data.Write(b[:])
+		case bool:
+			c.bools = append(c.bools, v)
+		}
+	}
+	pw.rows++
+	if pw.rows >= parquetRowGroupSize {
+		return pw.flush()
+	}
+	return nil
+}
+
+func serializeThrift(msg thrift.TStruct) ([]byte, error) {
+	ts := thrift.NewTSerializer()
+	ts.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(ts.Transport)
+	return ts.Write(context.Background(), msg)
+}
+
+// flush writes the buffered rows as a row group.
+func (pw *ParquetWriter) flush() error {
+	if pw.rows == 0 {
+		return nil
+	}
+	rowGroup := &parquet.RowGroup{NumRows: pw.rows}
+	for _, c := range pw.columns {
+		data := c.data.Bytes()
+		if c.Type == BoolColumn {
+			// Booleans are bit packed, least significant bit first.
+			data = make([]byte, (len(c.bools)+7)/8)
+			for i, v := range c.bools {
+				if v {
+					data[i/8] |= 1 << uint(i%8)
+				}
+			}
+		}
+		compressed := snappy.Encode(nil, data)
+		header, err := serializeThrift(&parquet.PageHeader{
+			Type:                 parquet.PageType_DATA_PAGE,
+			UncompressedPageSize: int32(len(data)),
+			CompressedPageSize:   int32(len(compressed)),
+			DataPageHeader: &parquet.DataPageHeader{
+				NumValues:               int32(pw.rows),
+				Encoding:                parquet.Encoding_PLAIN,
+				DefinitionLevelEncoding: parquet.Encoding_RLE,
+				RepetitionLevelEncoding: parquet.Encoding_RLE,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = pw.w.Write(header); err != nil {
+			return err
+		}
+		if _, err = pw.w.Write(compressed); err != nil {
+			return err
+		}
+		chunk := &parquet.ColumnChunk{
+			FileOffset: pw.offset,
+			MetaData: &parquet.ColumnMetaData{
+				Type:                  c.parquetType(),
+				Encodings:             []parquet.Encoding{parquet.Encoding_PLAIN, parquet.Encoding_RLE},
+				PathInSchema:          []string{c.Name},
+				Codec:                 parquet.CompressionCodec_SNAPPY,
+				NumValues:             pw.rows,
+				TotalUncompressedSize: int64(len(header) + len(data)),
+				TotalCompressedSize:   int64(len(header) + len(compressed)),
+				DataPageOffset:        pw.offset,
+			},
+		}
+		pw.offset += int64(len(header) + len(compressed))
+		rowGroup.TotalByteSize += chunk.MetaData.TotalUncompressedSize
+		rowGroup.Columns = append(rowGroup.Columns, chunk)
+
+		c.data.Reset()
+		c.bools = c.bools[:0]
+	}
+	pw.rowGroups = append(pw.rowGroups, rowGroup)
+	pw.numRows += pw.rows
+	pw.rows = 0
+	return nil
+}
+
+func (c *parquetColumn) parquetType() parquet.Type {
+	switch c.Type {
+	case Int64Column:
+		return parquet.Type_INT64
+	case BoolColumn:
+		return parquet.Type_BOOLEAN
+	}
+	return parquet.Type_BYTE_ARRAY
+}
+
+// Close writes the buffered rows and the file footer.
+// It does not close the underlying writer.
+func (pw *ParquetWriter) Close() error {
+	if err := pw.flush(); err != nil {
+		return err
+	}
+	numChildren := int32(len(pw.columns))
+	schema := []*parquet.SchemaElement{{Name: "schema", NumChildren: &numChildren}}
+	for _, c := range pw.columns {
+		element := &parquet.SchemaElement{
+			Type:           parquet.TypePtr(c.parquetType()),
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED),
+			Name:           c.Name,
+		}
+		if c.Type == StringColumn {
+			element.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+		}
+		schema = append(schema, element)
+	}
+	createdBy := "MinIO inventory"
+	footer, err := serializeThrift(&parquet.FileMetaData{
+		Version:   1,
+		Schema:    schema,
+		NumRows:   pw.numRows,
+		RowGroups: pw.rowGroups,
+		CreatedBy: &createdBy,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = pw.w.Write(footer); err != nil {
+		return err
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if _, err = pw.w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err = io.WriteString(pw.w, parquetMagic)
+	return err
+}
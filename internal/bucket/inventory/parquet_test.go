@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	parquetgo "github.com/minio/parquet-go"
+)
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	pw, err := NewParquetWriter(&buf, []Column{
+		{Name: "Key", Type: StringColumn},
+		{Name: "Size", Type: Int64Column},
+		{Name: "IsLatest", Type: BoolColumn},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = pw.Write([]interface{}{"key", "size", true}); err == nil {
+		t.Fatal("expected mismatching value to fail")
+	}
+	const rows = parquetRowGroupSize + 10
+	for i := 0; i < rows; i++ {
+		if err = pw.Write([]interface{}{fmt.Sprintf("key-%d", i), int64(i), i%3 == 0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = pw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	r, err := parquetgo.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset += int64(len(data))
+		}
+		if length < 0 {
+			length = int64(len(data)) - offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, set.CreateStringSet("Key", "Size", "IsLatest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			if i != rows {
+				t.Fatalf("expected %d rows, got %d", rows, i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, _ := record.Get("Key")
+		size, _ := record.Get("Size")
+		isLatest, _ := record.Get("IsLatest")
+		if string(key.Value.([]byte)) != fmt.Sprintf("key-%d", i) || size.Value != int64(i) || isLatest.Value != (i%3 == 0) {
+			t.Fatalf("row %d: unexpected values %q %v %v", i, key.Value, size.Value, isLatest.Value)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inventory
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/klauspost/compress/gzip"
+)
+
+// Writer writes the rows of a report data file.
+type Writer interface {
+	Write(values []interface{}) error
+	Close() error
+}
+
+// NewWriter returns a writer of report rows in the format.
+// Closing it does not close w.
+func NewWriter(w io.Writer, format Format, columns []Column) (Writer, error) {
+	switch format {
+	case CSV:
+		gz := gzip.NewWriter(w)
+		return &csvWriter{gz: gz, w: csv.NewWriter(gz), columns: len(columns)}, nil
+	case Parquet:
+		return NewParquetWriter(w, columns)
+	}
+	return nil, Errorf("inventory format %s is not supported", format)
+}
+
+// FileExtension returns the extension of the data files of the format.
+func (f Format) FileExtension() string {
+	if f == Parquet {
+		return ".parquet"
+	}
+	return ".csv.gz"
+}
+
+// csvWriter writes gzip compressed CSV rows with no header,
+// as S3 inventory does.
+type csvWriter struct {
+	gz      *gzip.Writer
+	w       *csv.Writer
+	columns int
+	record  []string
+}
+
+func (c *csvWriter) Write(values []interface{}) error {
+	if len(values) != c.columns {
+		return fmt.Errorf("csv: expected %d values, got %d", c.columns, len(values))
+	}
+	c.record = c.record[:0]
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			c.record = append(c.record, v)
+		case int64:
+			c.record = append(c.record, strconv.FormatInt(v, 10))
+		case bool:
+			c.record = append(c.record, strconv.FormatBool(v))
+		default:
+			return fmt.Errorf("csv: unsupported value %T", v)
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return err
+	}
+	return c.gz.Close()
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+var (
+	errLifecycleInvalidDaysAfterInitiation = Errorf("DaysAfterInitiation must be a positive integer in AbortIncompleteMultipartUpload")
+	errLifecycleAbortMultipartWithTags     = Errorf("AbortIncompleteMultipartUpload cannot be specified with Tags")
+)
+
+// AbortIncompleteMultipartUpload - an action for lifecycle configuration rule,
+// aborts the multipart uploads which are not completed after a number of days.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name       `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation ExpirationDays `xml:"DaysAfterInitiation,omitempty"`
+	set                 bool
+}
+
+// MarshalXML if DaysAfterInitiation is set to non-zero value
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsNull() {
+		return nil
+	}
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	return e.EncodeElement(abortIncompleteMultipartUploadWrapper(a), start)
+}
+
+// UnmarshalXML decodes AbortIncompleteMultipartUpload
+func (a *AbortIncompleteMultipartUpload) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	var val abortIncompleteMultipartUploadWrapper
+	err := d.DecodeElement(&val, &startElement)
+	if err != nil {
+		return err
+	}
+	*a = AbortIncompleteMultipartUpload(val)
+	a.set = true
+	return nil
+}
+
+// IsNull returns true if DaysAfterInitiation is not set
+func (a AbortIncompleteMultipartUpload) IsNull() bool {
+	return a.DaysAfterInitiation == ExpirationDays(0)
+}
+
+// Validate returns an error with wrong value
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if !a.set {
+		return nil
+	}
+	if a.IsNull() {
+		return errLifecycleInvalidDaysAfterInitiation
+	}
+	return nil
+}
+
+// NextDue returns the time at which a multipart upload initiated at
+// initiated is aborted, returns false if the action is not set.
+func (a AbortIncompleteMultipartUpload) NextDue(initiated time.Time) (time.Time, bool) {
+	if a.IsNull() {
+		return time.Time{}, false
+	}
+	return ExpectedExpiryTime(initiated, int(a.DaysAfterInitiation)), true
+}
@@ -25,27 +25,49 @@ var errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
 
 // And - a tag to combine a prefix and multiple tags for lifecycle configuration rule.
 type And struct {
-	XMLName xml.Name `xml:"And"`
-	Prefix  Prefix   `xml:"Prefix,omitempty"`
-	Tags    []Tag    `xml:"Tag,omitempty"`
+	XMLName               xml.Name `xml:"And"`
+	Prefix                Prefix   `xml:"Prefix,omitempty"`
+	Tags                  []Tag    `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64    `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64    `xml:"ObjectSizeLessThan,omitempty"`
 }
 
 // isEmpty returns true if Tags field is null
 func (a And) isEmpty() bool {
-	return len(a.Tags) == 0 && !a.Prefix.set
+	return len(a.Tags) == 0 && !a.Prefix.set && a.ObjectSizeGreaterThan == 0 && a.ObjectSizeLessThan == 0
 }
 
 // Validate - validates the And field
 func (a And) Validate() error {
 	emptyPrefix := !a.Prefix.set
 	emptyTags := len(a.Tags) == 0
+	emptySize := a.ObjectSizeGreaterThan == 0 && a.ObjectSizeLessThan == 0
 
-	if emptyPrefix && emptyTags {
+	if emptyPrefix && emptyTags && emptySize {
 		return nil
 	}
 
-	if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
-		return errXMLNotWellFormed
+	if emptySize {
+		if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
+			return errXMLNotWellFormed
+		}
+	} else {
+		// And combines at least two predicates.
+		n := len(a.Tags)
+		for _, sz := range []int64{a.ObjectSizeGreaterThan, a.ObjectSizeLessThan} {
+			if sz != 0 {
+				n++
+			}
+		}
+		if !emptyPrefix {
+			n++
+		}
+		if n < 2 {
+			return errXMLNotWellFormed
+		}
+		if err := validateObjectSize(a.ObjectSizeGreaterThan, a.ObjectSizeLessThan); err != nil {
+			return err
+		}
 	}
 
 	if a.ContainsDuplicateTag() {
@@ -23,7 +23,8 @@ import (
 )
 
 var (
-	errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidFilter     = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidObjectSize = Errorf("ObjectSizeGreaterThan and ObjectSizeLessThan must be non-negative, and ObjectSizeGreaterThan must be less than ObjectSizeLessThan")
 )
 
 // Filter - a filter for a lifecycle configuration Rule.
@@ -38,12 +39,17 @@ type Filter struct {
 
 	Tag    Tag
 	tagSet bool
+
+	ObjectSizeGreaterThan int64
+	ObjectSizeLessThan    int64
+
 	// Caching tags, only once
 	cachedTags []string
 }
 
 // MarshalXML - produces the xml representation of the Filter struct
-// only one of Prefix, And and Tag should be present in the output.
+// only one of Prefix, And, Tag and object size limits should be present
+// in the output.
 func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -58,8 +64,19 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
 		}
+	case f.ObjectSizeGreaterThan > 0 || f.ObjectSizeLessThan > 0:
+		if f.ObjectSizeGreaterThan > 0 {
+			if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+				return err
+			}
+		}
+		if f.ObjectSizeLessThan > 0 {
+			if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+				return err
+			}
+		}
 	default:
-		// Always print Prefix field when both And & Tag are empty
+		// Always print Prefix field when And, Tag and object size limits are empty
 		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 			return err
 		}
@@ -104,6 +121,14 @@ func (f *Filter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error
 				}
 				f.Tag = tag
 				f.tagSet = true
+			case "ObjectSizeGreaterThan":
+				if err = d.DecodeElement(&f.ObjectSizeGreaterThan, &se); err != nil {
+					return err
+				}
+			case "ObjectSizeLessThan":
+				if err = d.DecodeElement(&f.ObjectSizeLessThan, &se); err != nil {
+					return err
+				}
 			default:
 				return errUnknownXMLTag
 			}
@@ -122,7 +147,17 @@ func (f Filter) Validate() error {
 	if f.IsEmpty() {
 		return errXMLNotWellFormed
 	}
-	// A Filter must have exactly one of Prefix, Tag, or And specified.
+	// A Filter must have exactly one of Prefix, Tag, or And specified,
+	// object size limits may only be combined with each other.
+	hasSize := f.ObjectSizeGreaterThan != 0 || f.ObjectSizeLessThan != 0
+	if hasSize {
+		if f.Prefix.set || !f.Tag.IsEmpty() || !f.And.isEmpty() {
+			return errInvalidFilter
+		}
+		if err := validateObjectSize(f.ObjectSizeGreaterThan, f.ObjectSizeLessThan); err != nil {
+			return err
+		}
+	}
 	if !f.And.isEmpty() {
 		if f.Prefix.set {
 			return errInvalidFilter
@@ -150,6 +185,33 @@ func (f Filter) Validate() error {
 	return nil
 }
 
+// BySize returns true if sz satisfies the object size limits of the
+// Filter, it returns true if there are no size limits.
+func (f Filter) BySize(sz int64) bool {
+	gt, lt := f.ObjectSizeGreaterThan, f.ObjectSizeLessThan
+	if !f.And.isEmpty() {
+		gt, lt = f.And.ObjectSizeGreaterThan, f.And.ObjectSizeLessThan
+	}
+	if gt > 0 && sz <= gt {
+		return false
+	}
+	if lt > 0 && sz >= lt {
+		return false
+	}
+	return true
+}
+
+// validateObjectSize validates the object size limits of a Filter or And.
+func validateObjectSize(gt, lt int64) error {
+	if gt < 0 || lt < 0 {
+		return errInvalidObjectSize
+	}
+	if gt > 0 && lt > 0 && gt >= lt {
+		return errInvalidObjectSize
+	}
+	return nil
+}
+
 // TestTags tests if the object tags satisfy the Filter tags requirement,
 // it returns true if there is no tags in the underlying Filter.
 func (f Filter) TestTags(tags []string) bool {
@@ -109,6 +109,53 @@ func TestUnsupportedFilters(t *testing.T) {
 						</Filter>`,
 			expectedErr: errInvalidFilter,
 		},
+		{ // Filter with object size limits
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>2048</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with object size limits and Prefix
+			inputXML: ` <Filter>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+						</Filter>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Filter with ObjectSizeGreaterThan larger than ObjectSizeLessThan
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>2048</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
+		{ // Filter with And, Prefix and ObjectSizeGreaterThan
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							</And>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And and only ObjectSizeLessThan
+			inputXML: ` <Filter>
+							<And>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: errXMLNotWellFormed,
+		},
+		{ // Filter with And and negative ObjectSizeGreaterThan
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>-1</ObjectSizeGreaterThan>
+							</And>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -124,3 +171,51 @@ func TestUnsupportedFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterBySize(t *testing.T) {
+	testCases := []struct {
+		inputXML string
+		size     int64
+		want     bool
+	}{
+		{
+			inputXML: `<Filter><Prefix>key-prefix</Prefix></Filter>`,
+			size:     0,
+			want:     true,
+		},
+		{
+			inputXML: `<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`,
+			size:     1024,
+			want:     false,
+		},
+		{
+			inputXML: `<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`,
+			size:     1025,
+			want:     true,
+		},
+		{
+			inputXML: `<Filter><ObjectSizeLessThan>1024</ObjectSizeLessThan></Filter>`,
+			size:     1024,
+			want:     false,
+		},
+		{
+			inputXML: `<Filter><And><Prefix>key-prefix</Prefix><ObjectSizeGreaterThan>10</ObjectSizeGreaterThan><ObjectSizeLessThan>20</ObjectSizeLessThan></And></Filter>`,
+			size:     15,
+			want:     true,
+		},
+		{
+			inputXML: `<Filter><And><Prefix>key-prefix</Prefix><ObjectSizeGreaterThan>10</ObjectSizeGreaterThan><ObjectSizeLessThan>20</ObjectSizeLessThan></And></Filter>`,
+			size:     20,
+			want:     false,
+		},
+	}
+	for i, tc := range testCases {
+		var filter Filter
+		if err := xml.Unmarshal([]byte(tc.inputXML), &filter); err != nil {
+			t.Fatalf("%d: Expected no error but got %v", i+1, err)
+		}
+		if got := filter.BySize(tc.size); got != tc.want {
+			t.Fatalf("%d: Expected %v but got %v", i+1, tc.want, got)
+		}
+	}
+}
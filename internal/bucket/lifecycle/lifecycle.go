@@ -34,6 +34,8 @@ var (
 	errLifecycleDuplicateID                 = Errorf("Lifecycle configuration has rule with the same ID. Rule ID must be unique.")
 	errXMLNotWellFormed                     = Errorf("The XML you provided was not well-formed or did not validate against our published schema")
 	errLifecycleInvalidNoncurrentExpiration = Errorf("Exactly one of NoncurrentDays (positive integer) or MaxNoncurrentVersions should be specified in a NoncurrentExpiration rule.")
+
+	errLifecycleInvalidNewerNoncurrentVersions = Errorf("NewerNoncurrentVersions must be a positive integer")
 )
 
 const (
@@ -141,6 +143,9 @@ func (lc Lifecycle) HasActiveRules(prefix string, recursive bool) bool {
 		if rule.NoncurrentVersionExpiration.NoncurrentDays > 0 {
 			return true
 		}
+		if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
+			return true
+		}
 		if rule.NoncurrentVersionExpiration.MaxNoncurrentVersions > 0 {
 			return true
 		}
@@ -222,6 +227,9 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 		if !strings.HasPrefix(obj.Name, rule.GetPrefix()) {
 			continue
 		}
+		if !obj.DeleteMarker && !rule.Filter.BySize(obj.Size) {
+			continue
+		}
 		// Indicates whether MinIO will remove a delete marker with no
 		// noncurrent versions. If set to true, the delete marker will
 		// be expired; if set to false the policy takes no action. This
@@ -238,6 +246,10 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 			rules = append(rules, rule)
 			continue
 		}
+		if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
+			rules = append(rules, rule)
+			continue
+		}
 		if rule.NoncurrentVersionExpiration.MaxNoncurrentVersions > 0 {
 			rules = append(rules, rule)
 			continue
@@ -266,6 +278,7 @@ type ObjectOpts struct {
 	Name             string
 	UserTags         string
 	ModTime          time.Time
+	Size             int64
 	VersionID        string
 	IsLatest         bool
 	DeleteMarker     bool
@@ -310,7 +323,9 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			}
 		}
 
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() {
+		// Rules limited by NewerNoncurrentVersions depend on the newer
+		// versions of obj, see ComputeNewerNoncurrentAction.
+		if !rule.NoncurrentVersionExpiration.IsDaysNull() && rule.NoncurrentVersionExpiration.NewerNoncurrentVersions == 0 {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() {
 				// Non current versions should be deleted if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
@@ -320,7 +335,7 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			}
 		}
 
-		if !rule.NoncurrentVersionTransition.IsNull() {
+		if !rule.NoncurrentVersionTransition.IsNull() && rule.NoncurrentVersionTransition.NewerNoncurrentVersions == 0 {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() && !obj.DeleteMarker && obj.TransitionStatus != TransitionComplete {
 				// Non current versions should be transitioned if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
@@ -371,6 +386,54 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 	return action
 }
 
+// ComputeNewerNoncurrentAction returns the action to perform on the
+// noncurrent version obj, with newer noncurrent versions newer than it, by
+// evaluating the lifecycle rules limited by NewerNoncurrentVersions. These
+// rules are not evaluated by ComputeAction since they depend on the other
+// versions of the object.
+func (lc Lifecycle) ComputeNewerNoncurrentAction(obj ObjectOpts, newer int) Action {
+	if obj.ModTime.IsZero() || obj.VersionID == "" || obj.IsLatest || obj.SuccessorModTime.IsZero() {
+		return NoneAction
+	}
+	action := NoneAction
+	now := time.Now().UTC()
+	for _, rule := range lc.FilterActionableRules(obj) {
+		if exp := rule.NoncurrentVersionExpiration; exp.NewerNoncurrentVersions > 0 && newer >= exp.NewerNoncurrentVersions {
+			if exp.IsDaysNull() || now.After(ExpectedExpiryTime(obj.SuccessorModTime, int(exp.NoncurrentDays))) {
+				return DeleteVersionAction
+			}
+		}
+		if tr := rule.NoncurrentVersionTransition; tr.NewerNoncurrentVersions > 0 && newer >= tr.NewerNoncurrentVersions {
+			if obj.DeleteMarker || obj.TransitionStatus == TransitionComplete || tr.StorageClass == "" {
+				continue
+			}
+			// NoncurrentDays == 0 indicates immediate tiering.
+			due := obj.SuccessorModTime
+			if tr.NoncurrentDays > 0 {
+				due = ExpectedExpiryTime(obj.SuccessorModTime, int(tr.NoncurrentDays))
+			}
+			if now.After(due) {
+				action = TransitionVersionAction
+			}
+		}
+	}
+	return action
+}
+
+// HasNewerNoncurrentVersions returns true if there exists a rule with
+// NewerNoncurrentVersions limit set.
+func (lc Lifecycle) HasNewerNoncurrentVersions() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 || rule.NoncurrentVersionTransition.NewerNoncurrentVersions > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // ExpectedExpiryTime calculates the expiry, transition or restore date/time based on a object modtime.
 // The expected transition or restore time is always a midnight time following the the object
 // modification time plus the number of transition/restore days.
@@ -395,7 +458,7 @@ func (lc Lifecycle) PredictExpiryTime(obj ObjectOpts) (string, time.Time) {
 	// Iterate over all actionable rules and find the earliest
 	// expiration date and its associated rule ID.
 	for _, rule := range lc.FilterActionableRules(obj) {
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() && !obj.IsLatest && obj.VersionID != "" {
+		if !rule.NoncurrentVersionExpiration.IsDaysNull() && rule.NoncurrentVersionExpiration.NewerNoncurrentVersions == 0 && !obj.IsLatest && obj.VersionID != "" {
 			return rule.ID, ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))
 		}
 
@@ -449,6 +512,43 @@ func (lc Lifecycle) PredictTransitionTime(obj ObjectOpts) (string, time.Time) {
 	return finalTransitionRuleID, finalTransitionDate
 }
 
+// PredictAbortMultipartTime returns the time at which a multipart upload of
+// object initiated at initiated is aborted, after evaluating the current
+// lifecycle document.
+func (lc Lifecycle) PredictAbortMultipartTime(object string, initiated time.Time) (string, time.Time) {
+	var finalAbortDate time.Time
+	var finalAbortRuleID string
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if !strings.HasPrefix(object, rule.GetPrefix()) {
+			continue
+		}
+		if due, ok := rule.AbortIncompleteMultipartUpload.NextDue(initiated); ok {
+			if finalAbortDate.IsZero() || finalAbortDate.After(due) {
+				finalAbortRuleID = rule.ID
+				finalAbortDate = due
+			}
+		}
+	}
+	return finalAbortRuleID, finalAbortDate
+}
+
+// HasAbortIncompleteMultipartUpload returns true if there exists an
+// enabled rule with the AbortIncompleteMultipartUpload action.
+func (lc Lifecycle) HasAbortIncompleteMultipartUpload() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if !rule.AbortIncompleteMultipartUpload.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
 // SetPredictionHeaders sets time to expiry and transition headers on w for a
 // given obj.
 func (lc Lifecycle) SetPredictionHeaders(w http.ResponseWriter, obj ObjectOpts) {
@@ -118,6 +118,24 @@ func TestParseAndValidateLifecycleConfig(t *testing.T) {
 			expectedParsingErr:    nil,
 			expectedValidationErr: nil,
 		},
+		// Lifecycle with abort incomplete multipart upload
+		{
+			inputConfig:           `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Prefix>uploads/</Prefix></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: nil,
+		},
+		// Lifecycle with abort incomplete multipart upload without days
+		{
+			inputConfig:           `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>rule</ID><Status>Enabled</Status><Filter></Filter><AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errLifecycleInvalidDaysAfterInitiation,
+		},
+		// Lifecycle with abort incomplete multipart upload and a tag filter
+		{
+			inputConfig:           `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>rule</ID><Status>Enabled</Status><Filter><Tag><Key>key1</Key><Value>val1</Value></Tag></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			expectedParsingErr:    nil,
+			expectedValidationErr: errLifecycleAbortMultipartWithTags,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -647,3 +665,125 @@ func TestNoncurrentVersionsLimit(t *testing.T) {
 		t.Fatalf("Expected max noncurrent versions limit to be 1 but got %d", lim)
 	}
 }
+
+func TestComputeNewerNoncurrentAction(t *testing.T) {
+	now := time.Now().UTC()
+	testCases := []struct {
+		inputConfig      string
+		newer            int
+		size             int64
+		successorModTime time.Time
+		expectedAction   Action
+	}{
+		// Fewer newer noncurrent versions than retained
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newer:            1,
+			successorModTime: now.Add(-time.Hour),
+			expectedAction:   NoneAction,
+		},
+		// Enough newer noncurrent versions, no NoncurrentDays
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newer:            2,
+			successorModTime: now.Add(-time.Hour),
+			expectedAction:   DeleteVersionAction,
+		},
+		// Enough newer noncurrent versions, too early per NoncurrentDays
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newer:            3,
+			successorModTime: now.Add(-2 * 24 * time.Hour),
+			expectedAction:   NoneAction,
+		},
+		// Enough newer noncurrent versions, past NoncurrentDays
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newer:            3,
+			successorModTime: now.Add(-10 * 24 * time.Hour),
+			expectedAction:   DeleteVersionAction,
+		},
+		// Version smaller than ObjectSizeGreaterThan
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NewerNoncurrentVersions>1</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newer:            1,
+			size:             512,
+			successorModTime: now.Add(-time.Hour),
+			expectedAction:   NoneAction,
+		},
+		// Transition once enough newer noncurrent versions exist
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionTransition><NoncurrentDays>0</NoncurrentDays><NewerNoncurrentVersions>1</NewerNoncurrentVersions><StorageClass>WARM-1</StorageClass></NoncurrentVersionTransition></Rule></LifecycleConfiguration>`,
+			newer:            1,
+			successorModTime: now.Add(-time.Hour),
+			expectedAction:   TransitionVersionAction,
+		},
+	}
+	for i, tc := range testCases {
+		lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+		if err != nil {
+			t.Fatalf("%d: Got unexpected error: %v", i+1, err)
+		}
+		if err = lc.Validate(); err != nil {
+			t.Fatalf("%d: Got unexpected validation error: %v", i+1, err)
+		}
+		obj := ObjectOpts{
+			Name:             "foodir/fooobject",
+			ModTime:          tc.successorModTime.Add(-time.Hour),
+			Size:             tc.size,
+			VersionID:        "v1",
+			SuccessorModTime: tc.successorModTime,
+		}
+		if action := lc.ComputeNewerNoncurrentAction(obj, tc.newer); action != tc.expectedAction {
+			t.Fatalf("%d: Expected action: `%v`, got: `%v`", i+1, tc.expectedAction, action)
+		}
+		// ComputeAction leaves these rules to ComputeNewerNoncurrentAction.
+		if action := lc.ComputeAction(obj); action != NoneAction {
+			t.Fatalf("%d: Expected ComputeAction to return `%v`, got: `%v`", i+1, NoneAction, action)
+		}
+	}
+}
+
+func TestPredictAbortMultipartTime(t *testing.T) {
+	lc := Lifecycle{
+		Rules: []Rule{
+			{
+				ID:                             "rule-1",
+				Status:                         Enabled,
+				Filter:                         Filter{Prefix: Prefix{string: "uploads/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 7, set: true},
+			},
+			{
+				ID:                             "rule-2",
+				Status:                         Enabled,
+				Filter:                         Filter{Prefix: Prefix{string: "uploads/tmp/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+			{
+				ID:                             "rule-3",
+				Status:                         Disabled,
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+		},
+	}
+	if !lc.HasAbortIncompleteMultipartUpload() {
+		t.Fatal("expected lifecycle to abort incomplete multipart uploads")
+	}
+
+	initiated := time.Date(2021, time.May, 21, 13, 42, 50, 0, time.UTC)
+	testCases := []struct {
+		object string
+		ruleID string
+		due    time.Time
+	}{
+		{"uploads/object", "rule-1", time.Date(2021, time.May, 29, 0, 0, 0, 0, time.UTC)},
+		{"uploads/tmp/object", "rule-2", time.Date(2021, time.May, 23, 0, 0, 0, 0, time.UTC)},
+		{"object", "", time.Time{}},
+	}
+	for i, tc := range testCases {
+		ruleID, due := lc.PredictAbortMultipartTime(tc.object, initiated)
+		if ruleID != tc.ruleID || !due.Equal(tc.due) {
+			t.Errorf("Test %d: expected (%s, %v), got (%s, %v)", i+1, tc.ruleID, tc.due, ruleID, due)
+		}
+	}
+}
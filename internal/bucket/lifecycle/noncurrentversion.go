@@ -24,10 +24,11 @@ import (
 
 // NoncurrentVersionExpiration - an action for lifecycle configuration rule.
 type NoncurrentVersionExpiration struct {
-	XMLName               xml.Name       `xml:"NoncurrentVersionExpiration"`
-	NoncurrentDays        ExpirationDays `xml:"NoncurrentDays,omitempty"`
-	MaxNoncurrentVersions int            `xml:"MaxNoncurrentVersions,omitempty"`
-	set                   bool
+	XMLName                 xml.Name       `xml:"NoncurrentVersionExpiration"`
+	NoncurrentDays          ExpirationDays `xml:"NoncurrentDays,omitempty"`
+	NewerNoncurrentVersions int            `xml:"NewerNoncurrentVersions,omitempty"`
+	MaxNoncurrentVersions   int            `xml:"MaxNoncurrentVersions,omitempty"`
+	set                     bool
 }
 
 // MarshalXML if non-current days not set to non zero value
@@ -54,7 +55,7 @@ func (n *NoncurrentVersionExpiration) UnmarshalXML(d *xml.Decoder, startElement
 
 // IsNull returns if both NoncurrentDays and NoncurrentVersions are empty
 func (n NoncurrentVersionExpiration) IsNull() bool {
-	return n.IsDaysNull() && n.MaxNoncurrentVersions == 0
+	return n.IsDaysNull() && n.NewerNoncurrentVersions == 0 && n.MaxNoncurrentVersions == 0
 }
 
 // IsDaysNull returns true if days field is null
@@ -69,14 +70,17 @@ func (n NoncurrentVersionExpiration) Validate() error {
 	}
 	val := int(n.NoncurrentDays)
 	switch {
-	case val == 0 && n.MaxNoncurrentVersions == 0:
-		// both fields can't be zero
+	case val == 0 && n.NewerNoncurrentVersions == 0 && n.MaxNoncurrentVersions == 0:
+		// all fields can't be zero
 		return errXMLNotWellFormed
 
-	case val > 0 && n.MaxNoncurrentVersions > 0:
-		// both tags can't be non-zero simultaneously
+	case val > 0 && n.MaxNoncurrentVersions > 0, n.NewerNoncurrentVersions > 0 && n.MaxNoncurrentVersions > 0:
+		// MaxNoncurrentVersions can't be combined with other tags
 		return errLifecycleInvalidNoncurrentExpiration
 
+	case n.NewerNoncurrentVersions < 0:
+		return errLifecycleInvalidNewerNoncurrentVersions
+
 	case val < 0, n.MaxNoncurrentVersions < 0:
 		// negative values are not supported
 	}
@@ -85,9 +89,10 @@ func (n NoncurrentVersionExpiration) Validate() error {
 
 // NoncurrentVersionTransition - an action for lifecycle configuration rule.
 type NoncurrentVersionTransition struct {
-	NoncurrentDays TransitionDays `xml:"NoncurrentDays"`
-	StorageClass   string         `xml:"StorageClass"`
-	set            bool
+	NoncurrentDays          TransitionDays `xml:"NoncurrentDays"`
+	NewerNoncurrentVersions int            `xml:"NewerNoncurrentVersions,omitempty"`
+	StorageClass            string         `xml:"StorageClass"`
+	set                     bool
 }
 
 // MarshalXML is extended to leave out
@@ -127,13 +132,18 @@ func (n NoncurrentVersionTransition) Validate() error {
 	if n.StorageClass == "" {
 		return errXMLNotWellFormed
 	}
+	if n.NewerNoncurrentVersions < 0 {
+		return errLifecycleInvalidNewerNoncurrentVersions
+	}
 	return nil
 }
 
 // NextDue returns upcoming NoncurrentVersionTransition date for obj if
-// applicable, returns false otherwise.
+// applicable, returns false otherwise. Transitions limited by
+// NewerNoncurrentVersions also depend on the newer versions of obj, see
+// ComputeNewerNoncurrentAction.
 func (n NoncurrentVersionTransition) NextDue(obj ObjectOpts) (time.Time, bool) {
-	if obj.IsLatest || n.StorageClass == "" {
+	if obj.IsLatest || n.StorageClass == "" || n.NewerNoncurrentVersions > 0 {
 		return time.Time{}, false
 	}
 	// Days == 0 indicates immediate tiering, i.e object is eligible for tiering since it became noncurrent.
@@ -35,16 +35,16 @@ const (
 
 // Rule - a rule for lifecycle configuration.
 type Rule struct {
-	XMLName    xml.Name   `xml:"Rule"`
-	ID         string     `xml:"ID,omitempty"`
-	Status     Status     `xml:"Status"`
-	Filter     Filter     `xml:"Filter,omitempty"`
-	Prefix     Prefix     `xml:"Prefix,omitempty"`
-	Expiration Expiration `xml:"Expiration,omitempty"`
-	Transition Transition `xml:"Transition,omitempty"`
-	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
-	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
-	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+	XMLName                        xml.Name                       `xml:"Rule"`
+	ID                             string                         `xml:"ID,omitempty"`
+	Status                         Status                         `xml:"Status"`
+	Filter                         Filter                         `xml:"Filter,omitempty"`
+	Prefix                         Prefix                         `xml:"Prefix,omitempty"`
+	Expiration                     Expiration                     `xml:"Expiration,omitempty"`
+	Transition                     Transition                     `xml:"Transition,omitempty"`
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition    NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
 var (
@@ -120,6 +120,17 @@ func (r Rule) validateNoncurrentTransition() error {
 	return r.NoncurrentVersionTransition.Validate()
 }
 
+func (r Rule) validateAbortIncompleteMultipartUpload() error {
+	if err := r.AbortIncompleteMultipartUpload.Validate(); err != nil {
+		return err
+	}
+	// Multipart uploads have no tags, the action only applies by prefix.
+	if r.AbortIncompleteMultipartUpload.set && r.Tags() != "" {
+		return errLifecycleAbortMultipartWithTags
+	}
+	return nil
+}
+
 // GetPrefix - a rule can either have prefix under <rule></rule>, <filter></filter>
 // or under <filter><and></and></filter>. This method returns the prefix from the
 // location where it is available.
@@ -179,7 +190,10 @@ func (r Rule) Validate() error {
 	if err := r.validateNoncurrentTransition(); err != nil {
 		return err
 	}
-	if !r.Expiration.set && !r.Transition.set && !r.NoncurrentVersionExpiration.set && !r.NoncurrentVersionTransition.set {
+	if err := r.validateAbortIncompleteMultipartUpload(); err != nil {
+		return err
+	}
+	if !r.Expiration.set && !r.Transition.set && !r.NoncurrentVersionExpiration.set && !r.NoncurrentVersionTransition.set && !r.AbortIncompleteMultipartUpload.set {
 		return errXMLNotWellFormed
 	}
 	return nil
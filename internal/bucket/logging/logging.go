@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// Sources of the date used in partitioned log object keys.
+const (
+	EventTime    = "EventTime"
+	DeliveryTime = "DeliveryTime"
+)
+
+// PartitionedPrefix - log objects are written under
+// <prefix><account>/<region>/<bucket>/YYYY/MM/DD/.
+type PartitionedPrefix struct {
+	PartitionDateSource string `xml:"PartitionDateSource,omitempty"`
+}
+
+// TargetObjectKeyFormat - layout of the log object keys.
+type TargetObjectKeyFormat struct {
+	PartitionedPrefix *PartitionedPrefix `xml:"PartitionedPrefix,omitempty"`
+	SimplePrefix      *struct{}          `xml:"SimplePrefix,omitempty"`
+}
+
+// LoggingEnabled - where the access logs of a bucket are delivered.
+type LoggingEnabled struct {
+	TargetBucket          string                 `xml:"TargetBucket"`
+	TargetPrefix          string                 `xml:"TargetPrefix"`
+	TargetObjectKeyFormat *TargetObjectKeyFormat `xml:"TargetObjectKeyFormat,omitempty"`
+}
+
+// Config - the S3 BucketLoggingStatus of a bucket, logging is disabled
+// when LoggingEnabled is not set.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled returns true if access logs must be delivered.
+func (c *Config) Enabled() bool {
+	return c != nil && c.LoggingEnabled != nil
+}
+
+// TargetBucket returns the bucket the access logs are delivered to.
+func (c *Config) TargetBucket() string {
+	if !c.Enabled() {
+		return ""
+	}
+	return c.LoggingEnabled.TargetBucket
+}
+
+// Partitioned returns true if the log objects use the partitioned
+// key layout.
+func (c *Config) Partitioned() bool {
+	return c.Enabled() && c.LoggingEnabled.TargetObjectKeyFormat != nil &&
+		c.LoggingEnabled.TargetObjectKeyFormat.PartitionedPrefix != nil
+}
+
+// PartitionByEventTime returns true if the date of the partitioned
+// keys is the time of the logged requests, instead of the delivery time.
+func (c *Config) PartitionByEventTime() bool {
+	return c.Partitioned() &&
+		c.LoggingEnabled.TargetObjectKeyFormat.PartitionedPrefix.PartitionDateSource == EventTime
+}
+
+// Validate - validates the bucket logging configuration.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.LoggingEnabled.TargetBucket == "" {
+		return Errorf("TargetBucket must be specified")
+	}
+	if f := c.LoggingEnabled.TargetObjectKeyFormat; f != nil {
+		if f.PartitionedPrefix != nil && f.SimplePrefix != nil {
+			return Errorf("only one of PartitionedPrefix and SimplePrefix may be specified")
+		}
+		if p := f.PartitionedPrefix; p != nil {
+			switch p.PartitionDateSource {
+			case "", EventTime, DeliveryTime:
+			default:
+				return Errorf("unknown PartitionDateSource %q", p.PartitionDateSource)
+			}
+		}
+	}
+	return nil
+}
+
+// ObjectKey returns the key of a log object delivered at deliveryTime.
+// eventTime is the time of the logged requests, it is only used by
+// partitioned keys dated by EventTime.
+//
+// Simple keys are <prefix>YYYY-MM-DD-hh-mm-ss-<unique>, partitioned keys
+// are <prefix><account>/<region>/<bucket>/YYYY/MM/DD/YYYY-MM-DD-hh-mm-ss-<unique>.
+func (c *Config) ObjectKey(accountID, region, bucket string, eventTime, deliveryTime time.Time, unique string) string {
+	deliveryTime = deliveryTime.UTC()
+	name := deliveryTime.Format("2006-01-02-15-04-05") + "-" + unique
+	if !c.Partitioned() {
+		return c.LoggingEnabled.TargetPrefix + name
+	}
+	partitionTime := deliveryTime
+	if c.PartitionByEventTime() {
+		partitionTime = eventTime.UTC()
+	}
+	return c.LoggingEnabled.TargetPrefix + strings.Join([]string{
+		accountID, region, bucket, partitionTime.Format("2006/01/02"), name,
+	}, "/")
+}
+
+// ParseConfig - parses data in given reader to BucketLoggingStatus.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
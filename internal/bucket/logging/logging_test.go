@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loggingXML(enabled string) string {
+	return fmt.Sprintf(`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/">%s</BucketLoggingStatus>`, enabled)
+}
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		config     string
+		enabled    bool
+		shouldFail bool
+	}{
+		{loggingXML(""), false, false},
+		{loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled>"), true, false},
+		{loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix></TargetPrefix><TargetObjectKeyFormat><SimplePrefix/></TargetObjectKeyFormat></LoggingEnabled>"), true, false},
+		{loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix></TargetPrefix><TargetObjectKeyFormat><PartitionedPrefix><PartitionDateSource>EventTime</PartitionDateSource></PartitionedPrefix></TargetObjectKeyFormat></LoggingEnabled>"), true, false},
+		{loggingXML("<LoggingEnabled><TargetBucket></TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled>"), false, true},
+		{loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix></TargetPrefix><TargetObjectKeyFormat><SimplePrefix/><PartitionedPrefix/></TargetObjectKeyFormat></LoggingEnabled>"), false, true},
+		{loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix></TargetPrefix><TargetObjectKeyFormat><PartitionedPrefix><PartitionDateSource>Hourly</PartitionDateSource></PartitionedPrefix></TargetObjectKeyFormat></LoggingEnabled>"), false, true},
+		{"<BucketLoggingStatus>", false, true},
+	}
+	for i, testCase := range testCases {
+		c, err := ParseConfig(strings.NewReader(testCase.config))
+		if testCase.shouldFail && err == nil {
+			t.Errorf("Test %d: expected failure but passed", i+1)
+		}
+		if !testCase.shouldFail {
+			if err != nil {
+				t.Errorf("Test %d: expected success but failed with %v", i+1, err)
+				continue
+			}
+			if c.Enabled() != testCase.enabled {
+				t.Errorf("Test %d: expected enabled %t, got %t", i+1, testCase.enabled, c.Enabled())
+			}
+		}
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	eventTime := time.Date(2023, 10, 31, 23, 59, 59, 0, time.UTC)
+	deliveryTime := time.Date(2023, 11, 1, 0, 5, 10, 0, time.UTC)
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{"", "access/2023-11-01-00-05-10-ABCDEF"},
+		{"<TargetObjectKeyFormat><SimplePrefix/></TargetObjectKeyFormat>", "access/2023-11-01-00-05-10-ABCDEF"},
+		{"<TargetObjectKeyFormat><PartitionedPrefix/></TargetObjectKeyFormat>", "access/account/us-east-1/src/2023/11/01/2023-11-01-00-05-10-ABCDEF"},
+		{"<TargetObjectKeyFormat><PartitionedPrefix><PartitionDateSource>DeliveryTime</PartitionDateSource></PartitionedPrefix></TargetObjectKeyFormat>", "access/account/us-east-1/src/2023/11/01/2023-11-01-00-05-10-ABCDEF"},
+		{"<TargetObjectKeyFormat><PartitionedPrefix><PartitionDateSource>EventTime</PartitionDateSource></PartitionedPrefix></TargetObjectKeyFormat>", "access/account/us-east-1/src/2023/10/31/2023-11-01-00-05-10-ABCDEF"},
+	}
+	for i, testCase := range testCases {
+		c, err := ParseConfig(strings.NewReader(loggingXML("<LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix>" + testCase.format + "</LoggingEnabled>")))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if key := c.ObjectKey("account", "us-east-1", "src", eventTime, deliveryTime, "ABCDEF"); key != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, key)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record - a request in the S3 server access log format.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/LogFormat.html
+type Record struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// Authentication types of the logged requests.
+const (
+	AuthHeader  = "AuthHeader"
+	QueryString = "QueryString"
+)
+
+// field returns s or "-" when s is empty.
+func field(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// quoted returns s between double quotes, or "-" when s is empty.
+func quoted(s string) string {
+	return `"` + strings.ReplaceAll(field(s), `"`, `\"`) + `"`
+}
+
+func intField(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// escapeKey URL encodes the object key the way S3 logs it, keeping
+// the path separators.
+func escapeKey(key string) string {
+	return strings.ReplaceAll(url.PathEscape(key), "%2F", "/")
+}
+
+// String returns the record as one line of the access log, without the
+// trailing new line.
+func (r Record) String() string {
+	var key string
+	if r.Key != "" {
+		key = escapeKey(r.Key)
+	}
+	fields := []string{
+		field(r.BucketOwner),
+		field(r.Bucket),
+		"[" + r.Time.UTC().Format("02/Jan/2006:15:04:05 -0700") + "]",
+		field(r.RemoteIP),
+		field(r.Requester),
+		field(r.RequestID),
+		field(r.Operation),
+		field(key),
+		quoted(r.RequestURI),
+		intField(int64(r.HTTPStatus)),
+		field(r.ErrorCode),
+		intField(r.BytesSent),
+		intField(r.ObjectSize),
+		strconv.FormatInt(r.TotalTime.Milliseconds(), 10),
+		strconv.FormatInt(r.TurnAroundTime.Milliseconds(), 10),
+		quoted(r.Referer),
+		quoted(r.UserAgent),
+		field(r.VersionID),
+		field(r.HostID),
+		field(r.SignatureVersion),
+		field(r.CipherSuite),
+		field(r.AuthType),
+		field(r.HostHeader),
+		field(r.TLSVersion),
+		"-", // Access point ARN
+		"-", // aclRequired
+	}
+	return strings.Join(fields, " ")
+}
+
+// subResources maps the sub-resource query parameters to the resource
+// names of the logged operations, in order of precedence.
+var subResources = []struct {
+	query, resource string
+}{
+	{"acl", "ACL"},
+	{"tagging", "TAGGING"},
+	{"versioning", "VERSIONING"},
+	{"lifecycle", "LIFECYCLE"},
+	{"policy", "BUCKETPOLICY"},
+	{"policyStatus", "BUCKETPOLICYSTATUS"},
+	{"location", "LOCATION"},
+	{"cors", "CORS"},
+	{"encryption", "ENCRYPTION"},
+	{"replication", "REPLICATION"},
+	{"notification", "NOTIFICATION"},
+	{"logging", "LOGGING_STATUS"},
+	{"object-lock", "OBJECT_LOCK_CONFIGURATION"},
+	{"retention", "OBJECT_LOCK_RETENTION"},
+	{"legal-hold", "OBJECT_LOCK_LEGALHOLD"},
+	{"website", "WEBSITE"},
+	{"accelerate", "ACCELERATE"},
+	{"requestPayment", "REQUEST_PAYMENT"},
+	{"inventory", "INVENTORY_CONFIG"},
+	{"publicAccessBlock", "PUBLIC_ACCESS_BLOCK"},
+	{"versions", "BUCKETVERSIONS"},
+	{"uploads", "UPLOADS"},
+	{"delete", "MULTI_OBJECT_DELETE"},
+	{"restore", "RESTORE"},
+	{"select", "SELECT"},
+}
+
+// Operation returns the logged operation of a request, e.g.
+// REST.GET.OBJECT, REST.PUT.PART or REST.COPY.OBJECT.
+func Operation(method string, query url.Values, object string, copySource bool) string {
+	resource := "BUCKET"
+	if object != "" {
+		resource = "OBJECT"
+	}
+	for _, s := range subResources {
+		if _, ok := query[s.query]; ok {
+			resource = s.resource
+			break
+		}
+	}
+	if _, ok := query["uploadId"]; ok {
+		resource = "UPLOAD"
+		if _, ok = query["partNumber"]; ok && method == "PUT" {
+			resource = "PART"
+		}
+	}
+	if copySource && method == "PUT" && (resource == "OBJECT" || resource == "PART") {
+		method = "COPY"
+	}
+	return "REST." + method + "." + resource
+}
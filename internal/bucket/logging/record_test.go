@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRecordString(t *testing.T) {
+	r := Record{
+		BucketOwner:      "owner",
+		Bucket:           "photos",
+		Time:             time.Date(2019, 2, 6, 0, 0, 38, 0, time.UTC),
+		RemoteIP:         "192.0.2.3",
+		Requester:        "minioadmin",
+		RequestID:        "3E57427F3EXAMPLE",
+		Operation:        "REST.GET.OBJECT",
+		Key:              "2019/puppy dog.jpg",
+		RequestURI:       "GET /photos/2019/puppy%20dog.jpg HTTP/1.1",
+		HTTPStatus:       200,
+		BytesSent:        113,
+		ObjectSize:       113,
+		TotalTime:        7 * time.Millisecond,
+		TurnAroundTime:   3 * time.Millisecond,
+		UserAgent:        `curl/7.15.1 "test"`,
+		SignatureVersion: "SigV4",
+		AuthType:         AuthHeader,
+		HostHeader:       "localhost:9000",
+	}
+	expected := `owner photos [06/Feb/2019:00:00:38 +0000] 192.0.2.3 minioadmin 3E57427F3EXAMPLE REST.GET.OBJECT 2019/puppy%20dog.jpg "GET /photos/2019/puppy%20dog.jpg HTTP/1.1" 200 - 113 113 7 3 "-" "curl/7.15.1 \"test\"" - - SigV4 - AuthHeader localhost:9000 - - -`
+	if s := r.String(); s != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	testCases := []struct {
+		method     string
+		query      string
+		object     string
+		copySource bool
+		expected   string
+	}{
+		{"GET", "", "obj", false, "REST.GET.OBJECT"},
+		{"HEAD", "", "obj", false, "REST.HEAD.OBJECT"},
+		{"GET", "list-type=2", "", false, "REST.GET.BUCKET"},
+		{"PUT", "", "obj", true, "REST.COPY.OBJECT"},
+		{"PUT", "partNumber=1&uploadId=id", "obj", false, "REST.PUT.PART"},
+		{"PUT", "partNumber=1&uploadId=id", "obj", true, "REST.COPY.PART"},
+		{"POST", "uploads", "obj", false, "REST.POST.UPLOADS"},
+		{"POST", "uploadId=id", "obj", false, "REST.POST.UPLOAD"},
+		{"GET", "acl", "obj", false, "REST.GET.ACL"},
+		{"PUT", "tagging", "", false, "REST.PUT.TAGGING"},
+		{"POST", "delete", "", false, "REST.POST.MULTI_OBJECT_DELETE"},
+		{"GET", "versioning", "", false, "REST.GET.VERSIONING"},
+	}
+	for i, testCase := range testCases {
+		query, err := url.ParseQuery(testCase.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op := Operation(testCase.method, query, testCase.object, testCase.copySource); op != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, op)
+		}
+	}
+}
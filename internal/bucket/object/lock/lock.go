@@ -420,7 +420,9 @@ func ParseObjectLockRetentionHeaders(h http.Header) (rmode RetMode, r RetentionD
 	}
 
 	if retDate.Before(t) {
-		return rmode, r, ErrPastObjectLockRetainDate
+		// The date is still returned, replicas inherit
+		// expired retention from their source.
+		return rmode, RetentionDate{retDate}, ErrPastObjectLockRetainDate
 	}
 
 	return rmode, RetentionDate{retDate}, nil
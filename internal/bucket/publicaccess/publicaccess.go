@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package publicaccess
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Config - public access block configuration of a bucket.
+//
+// BlockPublicAcls and IgnorePublicAcls are accepted for compatibility,
+// public ACLs are never granted.
+type Config struct {
+	XMLNS                 string   `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name `xml:"PublicAccessBlockConfiguration"`
+	BlockPublicAcls       bool     `xml:"BlockPublicAcls"`
+	IgnorePublicAcls      bool     `xml:"IgnorePublicAcls"`
+	BlockPublicPolicy     bool     `xml:"BlockPublicPolicy"`
+	RestrictPublicBuckets bool     `xml:"RestrictPublicBuckets"`
+}
+
+// BlocksPublicPolicy - returns true if bucket policies granting public
+// access must be rejected.
+func (c *Config) BlocksPublicPolicy() bool {
+	return c != nil && c.BlockPublicPolicy
+}
+
+// RestrictsPublicAccess - returns true if anonymous requests must be
+// denied, regardless of the bucket policy.
+func (c *Config) RestrictsPublicAccess() bool {
+	return c != nil && c.RestrictPublicBuckets
+}
+
+// ParseConfig - parses data in given reader to PublicAccessBlockConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package publicaccess
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input          string
+		blockPolicy    bool
+		restrictPublic bool
+		shouldFail     bool
+	}{
+		{
+			input: `<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <BlockPublicAcls>true</BlockPublicAcls>
+  <IgnorePublicAcls>true</IgnorePublicAcls>
+  <BlockPublicPolicy>true</BlockPublicPolicy>
+  <RestrictPublicBuckets>true</RestrictPublicBuckets>
+</PublicAccessBlockConfiguration>`,
+			blockPolicy:    true,
+			restrictPublic: true,
+		},
+		{
+			input:       `<PublicAccessBlockConfiguration><BlockPublicPolicy>true</BlockPublicPolicy></PublicAccessBlockConfiguration>`,
+			blockPolicy: true,
+		},
+		{
+			input: `<PublicAccessBlockConfiguration></PublicAccessBlockConfiguration>`,
+		},
+		{
+			input:      `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`,
+			shouldFail: true,
+		},
+		{
+			input:      `<PublicAccessBlockConfiguration><BlockPublicPolicy>yes</BlockPublicPolicy></PublicAccessBlockConfiguration>`,
+			shouldFail: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		c, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.shouldFail {
+			if err == nil {
+				t.Fatalf("Test %d: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if c.BlocksPublicPolicy() != tc.blockPolicy {
+			t.Fatalf("Test %d: expected BlocksPublicPolicy %v", i+1, tc.blockPolicy)
+		}
+		if c.RestrictsPublicAccess() != tc.restrictPublic {
+			t.Fatalf("Test %d: expected RestrictsPublicAccess %v", i+1, tc.restrictPublic)
+		}
+
+		// The marshaled configuration must parse to the same values.
+		data, err := xml.Marshal(c)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		c2, err := ParseConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if *c2 != *c {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, c, c2)
+		}
+	}
+
+	var c *Config
+	if c.BlocksPublicPolicy() || c.RestrictsPublicAccess() {
+		t.Fatal("a missing configuration must not block anything")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ConflictPolicy decides which of two versions of an object, written
+// concurrently on two sites replicating to each other, becomes the
+// latest version on both sites.
+type ConflictPolicy string
+
+// Supported conflict policies.
+const (
+	// LastWriterWins keeps the version with the newest modification time.
+	LastWriterWins ConflictPolicy = "last-writer-wins"
+	// SourcePriority keeps the version written on the site with the
+	// highest priority.
+	SourcePriority ConflictPolicy = "source-priority"
+	// LargestObjectWins keeps the largest version.
+	LargestObjectWins ConflictPolicy = "largest-object-wins"
+)
+
+// ConflictConfig is the conflict resolution configuration of a bucket,
+// it must be the same on all sites replicating the bucket.
+type ConflictConfig struct {
+	Policy ConflictPolicy `json:"policy"`
+	// Sites are the deployment IDs of the sites by decreasing priority,
+	// sites not listed have the lowest priority.
+	Sites []string `json:"sites,omitempty"`
+}
+
+// ConflictVersion is a version of an object in a conflict.
+type ConflictVersion struct {
+	VersionID string
+	ModTime   time.Time
+	Size      int64
+	// Site is the deployment ID of the site the version was written on.
+	Site string
+}
+
+// IsEmpty returns true if no conflict policy is configured.
+func (c ConflictConfig) IsEmpty() bool {
+	return c.Policy == ""
+}
+
+// Validate - validates the conflict resolution configuration.
+func (c ConflictConfig) Validate() error {
+	switch c.Policy {
+	case LastWriterWins, LargestObjectWins:
+		if len(c.Sites) > 0 {
+			return fmt.Errorf("sites are only supported by the %s conflict policy", SourcePriority)
+		}
+	case SourcePriority:
+		if len(c.Sites) == 0 {
+			return fmt.Errorf("%s conflict policy requires sites", SourcePriority)
+		}
+		seen := make(map[string]struct{}, len(c.Sites))
+		for _, site := range c.Sites {
+			if site == "" {
+				return errors.New("conflict policy site cannot be empty")
+			}
+			if _, ok := seen[site]; ok {
+				return fmt.Errorf("duplicate conflict policy site %s", site)
+			}
+			seen[site] = struct{}{}
+		}
+	default:
+		return fmt.Errorf("unsupported conflict policy %q", c.Policy)
+	}
+	return nil
+}
+
+// rank returns the priority of a site, lower is higher.
+func (c ConflictConfig) rank(site string) int {
+	for i, s := range c.Sites {
+		if s == site {
+			return i
+		}
+	}
+	return len(c.Sites)
+}
+
+// Wins returns true if version a wins over version b. The result does
+// not depend on the site evaluating it, so that all sites agree.
+func (c ConflictConfig) Wins(a, b ConflictVersion) bool {
+	switch c.Policy {
+	case SourcePriority:
+		if ra, rb := c.rank(a.Site), c.rank(b.Site); ra != rb {
+			return ra < rb
+		}
+	case LargestObjectWins:
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+	}
+	if !a.ModTime.Equal(b.ModTime) {
+		return a.ModTime.After(b.ModTime)
+	}
+	return a.VersionID > b.VersionID
+}
+
+// ParseConflictConfig - parses data in given reader to ConflictConfig.
+func ParseConflictConfig(reader io.Reader) (*ConflictConfig, error) {
+	var c ConflictConfig
+	if err := json.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
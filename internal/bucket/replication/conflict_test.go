@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConflictConfig(t *testing.T) {
+	testCases := []struct {
+		config    string
+		expectErr bool
+	}{
+		{config: `{"policy":"last-writer-wins"}`},
+		{config: `{"policy":"largest-object-wins"}`},
+		{config: `{"policy":"source-priority","sites":["site-a","site-b"]}`},
+		{config: `{"policy":"source-priority"}`, expectErr: true},
+		{config: `{"policy":"source-priority","sites":["site-a","site-a"]}`, expectErr: true},
+		{config: `{"policy":"source-priority","sites":[""]}`, expectErr: true},
+		{config: `{"policy":"last-writer-wins","sites":["site-a"]}`, expectErr: true},
+		{config: `{"policy":"first-writer-wins"}`, expectErr: true},
+		{config: `{}`, expectErr: true},
+	}
+	for i, tc := range testCases {
+		_, err := ParseConflictConfig(strings.NewReader(tc.config))
+		if (err != nil) != tc.expectErr {
+			t.Errorf("case %d: expected error %t, got %v", i+1, tc.expectErr, err)
+		}
+	}
+}
+
+func TestConflictConfigWins(t *testing.T) {
+	now := time.Now()
+	older := ConflictVersion{VersionID: "a", ModTime: now, Size: 10, Site: "site-b"}
+	newer := ConflictVersion{VersionID: "b", ModTime: now.Add(time.Second), Size: 5, Site: "site-a"}
+	unknown := ConflictVersion{VersionID: "c", ModTime: now.Add(time.Hour), Size: 5, Site: "site-c"}
+	same := ConflictVersion{VersionID: "d", ModTime: now, Size: 10, Site: "site-a"}
+
+	testCases := []struct {
+		config ConflictConfig
+		a, b   ConflictVersion
+		wins   bool
+	}{
+		{config: ConflictConfig{Policy: LastWriterWins}, a: newer, b: older, wins: true},
+		{config: ConflictConfig{Policy: LastWriterWins}, a: older, b: newer, wins: false},
+		// Same modification time, the greater version ID wins.
+		{config: ConflictConfig{Policy: LastWriterWins}, a: same, b: older, wins: true},
+		{config: ConflictConfig{Policy: LargestObjectWins}, a: older, b: newer, wins: true},
+		// Same size, the newest version wins.
+		{config: ConflictConfig{Policy: LargestObjectWins}, a: newer, b: unknown, wins: false},
+		{config: ConflictConfig{Policy: SourcePriority, Sites: []string{"site-b", "site-a"}}, a: older, b: newer, wins: true},
+		{config: ConflictConfig{Policy: SourcePriority, Sites: []string{"site-a", "site-b"}}, a: older, b: newer, wins: false},
+		// Sites not listed have the lowest priority.
+		{config: ConflictConfig{Policy: SourcePriority, Sites: []string{"site-a", "site-b"}}, a: unknown, b: older, wins: false},
+	}
+	for i, tc := range testCases {
+		if wins := tc.config.Wins(tc.a, tc.b); wins != tc.wins {
+			t.Errorf("case %d: expected %t, got %t", i+1, tc.wins, wins)
+		}
+		if wins := tc.config.Wins(tc.b, tc.a); wins == tc.wins {
+			t.Errorf("case %d: expected the reverse comparison to be %t", i+1, !tc.wins)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ProxyConfig is the proxying configuration of a bucket. When enabled,
+// reads of objects which have not been replicated to the local site yet
+// are proxied to the replication targets which have them.
+type ProxyConfig struct {
+	// Disabled turns proxying off for all targets of the bucket, it is
+	// on by default and can also be turned off per target.
+	Disabled bool `json:"disabled"`
+}
+
+// Enabled returns true if reads may be proxied to replication targets.
+func (c *ProxyConfig) Enabled() bool {
+	return c == nil || !c.Disabled
+}
+
+// ParseProxyConfig - parses data in given reader to ProxyConfig.
+func ParseProxyConfig(reader io.Reader) (*ProxyConfig, error) {
+	var c ProxyConfig
+	if err := json.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
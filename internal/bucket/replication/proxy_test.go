@@ -0,0 +1,51 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProxyConfig(t *testing.T) {
+	testCases := []struct {
+		data    string
+		enabled bool
+		success bool
+	}{
+		{`{}`, true, true},
+		{`{"disabled": false}`, true, true},
+		{`{"disabled": true}`, false, true},
+		{`{"disabled": "yes"}`, false, false},
+		{`disabled`, false, false},
+	}
+	for i, testCase := range testCases {
+		c, err := ParseProxyConfig(strings.NewReader(testCase.data))
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && c.Enabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, c.Enabled())
+		}
+	}
+
+	var c *ProxyConfig
+	if !c.Enabled() {
+		t.Fatal("expected proxying to be enabled without a configuration")
+	}
+}
@@ -216,6 +216,15 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 	return false
 }
 
+// SyncAcknowledgment returns the sync acknowledgment setting of the rule
+// replicating the object to obj.TargetArn.
+func (c Config) SyncAcknowledgment(obj ObjectOpts) SyncAcknowledgment {
+	if rules := c.FilterActionableRules(obj); len(rules) > 0 {
+		return rules[0].SyncAcknowledgment
+	}
+	return SyncAcknowledgment{}
+}
+
 // HasActiveRules - returns whether replication policy has active rules
 // Optionally a prefix can be supplied.
 // If recursive is specified the function will also return true if any level below the
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestParseAndValidateReplicationConfig(t *testing.T) {
@@ -398,3 +399,67 @@ func TestFilterActionableRules(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncAcknowledgment(t *testing.T) {
+	const rules = `<Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix></Filter><Priority>2</Priority><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket></Destination><SyncAcknowledgment><Status>Enabled</Status><TimeoutSeconds>5</TimeoutSeconds></SyncAcknowledgment></Rule>` +
+		`<Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix></Filter><Priority>1</Priority><Destination><Bucket>arn:minio:replication:xxx::destinationbucket2</Bucket></Destination></Rule>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role></Role>` + rules + `</ReplicationConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate("bucket", false); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		opts    ObjectOpts
+		enabled bool
+		timeout time.Duration
+	}{
+		{ObjectOpts{Name: "prefix/object", TargetArn: "arn:minio:replication:xxx::destinationbucket"}, true, 5 * time.Second},
+		// The rule of the other target does not acknowledge writes.
+		{ObjectOpts{Name: "prefix/object", TargetArn: "arn:minio:replication:xxx::destinationbucket2"}, false, 0},
+		// No rule applies to the object.
+		{ObjectOpts{Name: "other/object", TargetArn: "arn:minio:replication:xxx::destinationbucket"}, false, 0},
+	}
+	for i, tc := range testCases {
+		sack := cfg.SyncAcknowledgment(tc.opts)
+		if sack.Enabled() != tc.enabled || sack.Timeout() != tc.timeout {
+			t.Fatalf("Test %d: expected enabled %v with timeout %v, got %+v", i+1, tc.enabled, tc.timeout, sack)
+		}
+	}
+
+	for _, tc := range []struct {
+		policy   AckPolicy
+		total    int
+		required int
+	}{
+		{"", 3, 3},
+		{AckAll, 3, 3},
+		{AckQuorum, 3, 2},
+		{AckQuorum, 4, 3},
+		{AckMin, 3, 1},
+	} {
+		sack := SyncAcknowledgment{Status: Enabled, Policy: tc.policy}
+		if required := sack.Required(tc.total); required != tc.required {
+			t.Fatalf("policy %q: expected %d of %d destinations, got %d", tc.policy, tc.required, tc.total, required)
+		}
+	}
+
+	for _, invalid := range []struct {
+		sack string
+		err  error
+	}{
+		{`<SyncAcknowledgment><Status>string</Status></SyncAcknowledgment>`, errInvalidSyncAcknowledgmentStatus},
+		{`<SyncAcknowledgment><Status>Enabled</Status><TimeoutSeconds>-1</TimeoutSeconds></SyncAcknowledgment>`, errInvalidSyncAcknowledgmentTimeout},
+		{`<SyncAcknowledgment><Status>Enabled</Status><Policy>Any</Policy></SyncAcknowledgment>`, errInvalidSyncAcknowledgmentPolicy},
+	} {
+		cfg, err := ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Role></Role><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>prefix</Prefix></Filter><Destination><Bucket>arn:minio:replication:xxx::destinationbucket</Bucket></Destination>` + invalid.sack + `</Rule></ReplicationConfiguration>`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cfg.Validate("bucket", false); err != invalid.err {
+			t.Fatalf("expected %v, got %v", invalid.err, err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package replication
 import (
 	"bytes"
 	"encoding/xml"
+	"time"
 )
 
 // Status represents Enabled/Disabled status
@@ -127,6 +128,92 @@ func (e *ExistingObjectReplication) UnmarshalXML(dec *xml.Decoder, start xml.Sta
 	return nil
 }
 
+// AckPolicy - how many of the synchronous destinations acknowledging a
+// write must commit the object before the write returns.
+type AckPolicy string
+
+// Supported acknowledgment policies
+const (
+	AckAll    AckPolicy = "All"    // all the destinations, the default
+	AckQuorum AckPolicy = "Quorum" // a majority of the destinations
+	AckMin    AckPolicy = "Min"    // at least one destination
+)
+
+// SyncAcknowledgment - whether writes wait until the object is committed to
+// the destination of the rule when it is a synchronous target - this is a
+// MinIO only extension.
+type SyncAcknowledgment struct {
+	Status Status `xml:"Status"` // should be set to "Disabled" by default
+	// Seconds writes wait for the destination, 0 waits without limit
+	TimeoutSeconds int `xml:"TimeoutSeconds,omitempty"`
+	// How many destinations must commit the object, "All" by default
+	Policy AckPolicy `xml:"Policy,omitempty"`
+}
+
+// IsEmpty returns true if SyncAcknowledgment is not set
+func (s SyncAcknowledgment) IsEmpty() bool {
+	return len(s.Status) == 0
+}
+
+// Enabled returns true if writes wait for the destination to commit the object.
+func (s SyncAcknowledgment) Enabled() bool {
+	return s.Status == Enabled
+}
+
+// Timeout returns how long writes wait for the destination, 0 if
+// they wait without limit.
+func (s SyncAcknowledgment) Timeout() time.Duration {
+	return time.Duration(s.TimeoutSeconds) * time.Second
+}
+
+// Required returns how many of total destinations acknowledging a write
+// must commit the object according to the policy.
+func (s SyncAcknowledgment) Required(total int) int {
+	switch s.Policy {
+	case AckMin:
+		return 1
+	case AckQuorum:
+		return total/2 + 1
+	default:
+		return total
+	}
+}
+
+// Validate validates the status, the timeout and the policy.
+func (s SyncAcknowledgment) Validate() error {
+	if s.IsEmpty() {
+		return nil
+	}
+	if s.Status != Disabled && s.Status != Enabled {
+		return errInvalidSyncAcknowledgmentStatus
+	}
+	if s.TimeoutSeconds < 0 {
+		return errInvalidSyncAcknowledgmentTimeout
+	}
+	switch s.Policy {
+	case "", AckAll, AckQuorum, AckMin:
+	default:
+		return errInvalidSyncAcknowledgmentPolicy
+	}
+	return nil
+}
+
+// UnmarshalXML - decodes XML data. Default to Disabled unless specified
+func (s *SyncAcknowledgment) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) (err error) {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type syncAcknowledgment SyncAcknowledgment
+	sack := syncAcknowledgment{}
+
+	if err := dec.DecodeElement(&sack, &start); err != nil {
+		return err
+	}
+	if len(sack.Status) == 0 {
+		sack.Status = Disabled
+	}
+	*s = SyncAcknowledgment(sack)
+	return nil
+}
+
 // Rule - a rule for replication configuration.
 type Rule struct {
 	XMLName                 xml.Name                `xml:"Rule" json:"Rule"`
@@ -140,6 +227,8 @@ type Rule struct {
 	SourceSelectionCriteria   SourceSelectionCriteria   `xml:"SourceSelectionCriteria" json:"SourceSelectionCriteria"`
 	Filter                    Filter                    `xml:"Filter" json:"Filter"`
 	ExistingObjectReplication ExistingObjectReplication `xml:"ExistingObjectReplication,omitempty" json:"ExistingObjectReplication,omitempty"`
+	// MinIO extension to acknowledge writes once replicated synchronously
+	SyncAcknowledgment SyncAcknowledgment `xml:"SyncAcknowledgment,omitempty" json:"SyncAcknowledgment,omitempty"`
 }
 
 var (
@@ -153,6 +242,9 @@ var (
 	errDeleteReplicationMissing               = Errorf("Delete replication must be specified")
 	errInvalidDeleteReplicationStatus         = Errorf("Delete replication is either enable|disable")
 	errInvalidExistingObjectReplicationStatus = Errorf("Existing object replication status is invalid")
+	errInvalidSyncAcknowledgmentStatus        = Errorf("Sync acknowledgment status is invalid")
+	errInvalidSyncAcknowledgmentTimeout       = Errorf("Sync acknowledgment timeout must not be negative")
+	errInvalidSyncAcknowledgmentPolicy        = Errorf("Sync acknowledgment policy must be All, Quorum or Min")
 )
 
 // validateID - checks if ID is valid or not.
@@ -239,6 +331,9 @@ func (r Rule) Validate(bucket string, sameTarget bool) error {
 	if r.Destination.Bucket == bucket && sameTarget {
 		return errDestinationSourceIdentical
 	}
+	if err := r.SyncAcknowledgment.Validate(); err != nil {
+		return err
+	}
 	return r.ExistingObjectReplication.Validate()
 }
 
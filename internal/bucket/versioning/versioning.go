@@ -20,6 +20,9 @@ package versioning
 import (
 	"encoding/xml"
 	"io"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
 )
 
 // State - enabled/disabled/suspended states
@@ -33,12 +36,26 @@ const (
 	Suspended State = "Suspended"
 )
 
+// Maximum number of prefixes excluded from versioning.
+const maxExcludedPrefixes = 10
+
+// ExcludedPrefix - a prefix, which may hold wildcards, whose objects
+// are not versioned although versioning is enabled on the bucket.
+type ExcludedPrefix struct {
+	Prefix string
+}
+
 // Versioning - Configuration for bucket versioning.
 type Versioning struct {
 	XMLNS   string   `xml:"xmlns,attr,omitempty"`
 	XMLName xml.Name `xml:"VersioningConfiguration"`
 	// MFADelete State    `xml:"MFADelete,omitempty"` // not supported yet.
 	Status State `xml:"Status,omitempty"`
+	// MinIO extension - objects under these prefixes are not versioned.
+	ExcludedPrefixes []ExcludedPrefix `xml:",omitempty"`
+	// MinIO extension - folder objects, whose names end with a slash,
+	// are not versioned.
+	ExcludeFolders bool `xml:",omitempty"`
 }
 
 // Validate - validates the versioning configuration
@@ -50,7 +67,20 @@ func (v Versioning) Validate() error {
 	// 	return Errorf("unsupported MFADelete state %s", v.MFADelete)
 	// }
 	switch v.Status {
-	case Enabled, Suspended:
+	case Enabled:
+		// Versioning can be enabled while excluding specific prefixes.
+		if len(v.ExcludedPrefixes) > maxExcludedPrefixes {
+			return Errorf("too many excluded prefixes, at most %d are supported", maxExcludedPrefixes)
+		}
+		for _, p := range v.ExcludedPrefixes {
+			if p.Prefix == "" {
+				return Errorf("excluded prefix must not be empty")
+			}
+		}
+	case Suspended:
+		if len(v.ExcludedPrefixes) > 0 || v.ExcludeFolders {
+			return Errorf("excluded prefixes and folders are only supported when versioning is enabled")
+		}
 	default:
 		return Errorf("unsupported Versioning status %s", v.Status)
 	}
@@ -67,6 +97,36 @@ func (v Versioning) Suspended() bool {
 	return v.Status == Suspended
 }
 
+// excluded returns true if versioning is enabled on the bucket but not
+// for the object.
+func (v Versioning) excluded(object string) bool {
+	if v.Status != Enabled || object == "" {
+		return false
+	}
+	if v.ExcludeFolders && strings.HasSuffix(object, "/") {
+		return true
+	}
+	for _, p := range v.ExcludedPrefixes {
+		if wildcard.MatchSimple(p.Prefix+"*", object) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrefixEnabled - returns true if versioning is enabled for the object,
+// an empty object name checks the bucket.
+func (v Versioning) PrefixEnabled(object string) bool {
+	return v.Enabled() && !v.excluded(object)
+}
+
+// PrefixSuspended - returns true if versioning is suspended for the
+// object, either on the whole bucket or because the object is excluded
+// from versioning, an empty object name checks the bucket.
+func (v Versioning) PrefixSuspended(object string) bool {
+	return v.Suspended() || v.excluded(object)
+}
+
 // ParseConfig - parses data in given reader to VersioningConfiguration.
 func ParseConfig(reader io.Reader) (*Versioning, error) {
 	var v Versioning
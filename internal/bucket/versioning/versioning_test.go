@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package versioning
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		shouldErr bool
+	}{
+		{`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`, false},
+		{`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`, false},
+		{`<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`, true},
+		{`<VersioningConfiguration><Status>Enabled</Status><ExcludedPrefixes><Prefix>spark/_temporary/</Prefix></ExcludedPrefixes><ExcludeFolders>true</ExcludeFolders></VersioningConfiguration>`, false},
+		{`<VersioningConfiguration><Status>Enabled</Status><ExcludedPrefixes><Prefix></Prefix></ExcludedPrefixes></VersioningConfiguration>`, true},
+		{`<VersioningConfiguration><Status>Suspended</Status><ExcludedPrefixes><Prefix>tmp/</Prefix></ExcludedPrefixes></VersioningConfiguration>`, true},
+		{`<VersioningConfiguration><Status>Suspended</Status><ExcludeFolders>true</ExcludeFolders></VersioningConfiguration>`, true},
+		{`<VersioningConfiguration><Status>Enabled</Status>` + strings.Repeat(`<ExcludedPrefixes><Prefix>tmp/</Prefix></ExcludedPrefixes>`, 11) + `</VersioningConfiguration>`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.input))
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Case %d: expected error %v, got %v", i+1, testCase.shouldErr, err)
+		}
+	}
+}
+
+func TestPrefixEnabled(t *testing.T) {
+	v, err := ParseConfig(strings.NewReader(`<VersioningConfiguration><Status>Enabled</Status><ExcludedPrefixes><Prefix>spark/_temporary/</Prefix></ExcludedPrefixes><ExcludedPrefixes><Prefix>*/_staging/</Prefix></ExcludedPrefixes><ExcludeFolders>true</ExcludeFolders></VersioningConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		object  string
+		enabled bool
+	}{
+		{"", true},
+		{"data/object", true},
+		{"data/", false},
+		{"spark/_temporary/0/part-0000", false},
+		{"spark/output/part-0000", true},
+		{"job/_staging/part-0000", false},
+		{"job/staging/part-0000", true},
+	}
+	for _, testCase := range testCases {
+		if enabled := v.PrefixEnabled(testCase.object); enabled != testCase.enabled {
+			t.Errorf("%s: expected enabled %v, got %v", testCase.object, testCase.enabled, enabled)
+		}
+		if suspended := v.PrefixSuspended(testCase.object); suspended == testCase.enabled {
+			t.Errorf("%s: expected suspended %v, got %v", testCase.object, !testCase.enabled, suspended)
+		}
+	}
+
+	suspended := Versioning{Status: Suspended}
+	if suspended.PrefixEnabled("data/object") || !suspended.PrefixSuspended("data/object") {
+		t.Error("expected versioning suspended for all objects of a suspended bucket")
+	}
+}
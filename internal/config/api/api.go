@@ -20,11 +20,13 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
 )
@@ -43,6 +45,24 @@ const (
 	apiStaleUploadsCleanupInterval = "stale_uploads_cleanup_interval"
 	apiStaleUploadsExpiry          = "stale_uploads_expiry"
 	apiDeleteCleanupInterval       = "delete_cleanup_interval"
+	apiETagMode                    = "etag_mode"
+	apiBlockPublicAccess           = "block_public_access"
+	apiInlineThreshold             = "inline_threshold"
+	apiBlockCacheSize              = "block_cache_size"
+	apiZeroCopyGet                 = "zero_copy_get"
+	apiODirect                     = "odirect"
+	apiAdmissionHeapPercent        = "admission_heap_percent"
+	apiAdmissionInflightSize       = "admission_inflight_size"
+	apiAdmissionMinSize            = "admission_min_size"
+	apiAdmissionDeadline           = "admission_deadline"
+	apiReplicationMRFSize          = "replication_mrf_size"
+	apiReplicationMRFBackoff       = "replication_mrf_backoff"
+	apiReadyMinOnlineDrives        = "ready_min_online_drives"
+	apiReadyMaxHealBacklog         = "ready_max_heal_backlog"
+	apiReadyMaxReplicationBacklog  = "ready_max_replication_backlog"
+	apiMultipartMinPartSize        = "multipart_min_part_size"
+	apiMultipartMaxParts           = "multipart_max_parts"
+	apiMultipartValidateParts      = "multipart_validate_parts"
 
 	EnvAPIRequestsMax              = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline         = "MINIO_API_REQUESTS_DEADLINE"
@@ -59,6 +79,205 @@ const (
 	EnvAPIStaleUploadsExpiry          = "MINIO_API_STALE_UPLOADS_EXPIRY"
 	EnvAPIDeleteCleanupInterval       = "MINIO_API_DELETE_CLEANUP_INTERVAL"
 	EnvDeleteCleanupInterval          = "MINIO_DELETE_CLEANUP_INTERVAL"
+	EnvAPIETagMode                    = "MINIO_API_ETAG_MODE"
+	EnvAPIBlockPublicAccess           = "MINIO_API_BLOCK_PUBLIC_ACCESS"
+	EnvAPIInlineThreshold             = "MINIO_API_INLINE_THRESHOLD"
+	EnvAPIBlockCacheSize              = "MINIO_API_BLOCK_CACHE_SIZE"
+	EnvAPIZeroCopyGet                 = "MINIO_API_ZERO_COPY_GET"
+	EnvAPIODirect                     = "MINIO_API_ODIRECT"
+	EnvAPIAdmissionHeapPercent        = "MINIO_API_ADMISSION_HEAP_PERCENT"
+	EnvAPIAdmissionInflightSize       = "MINIO_API_ADMISSION_INFLIGHT_SIZE"
+	EnvAPIAdmissionMinSize            = "MINIO_API_ADMISSION_MIN_SIZE"
+	EnvAPIAdmissionDeadline           = "MINIO_API_ADMISSION_DEADLINE"
+	EnvAPIReplicationMRFSize          = "MINIO_API_REPLICATION_MRF_SIZE"
+	EnvAPIReplicationMRFBackoff       = "MINIO_API_REPLICATION_MRF_BACKOFF"
+	EnvAPIReadyMinOnlineDrives        = "MINIO_API_READY_MIN_ONLINE_DRIVES"
+	EnvAPIReadyMaxHealBacklog         = "MINIO_API_READY_MAX_HEAL_BACKLOG"
+	EnvAPIReadyMaxReplicationBacklog  = "MINIO_API_READY_MAX_REPLICATION_BACKLOG"
+	EnvAPIMultipartMinPartSize        = "MINIO_API_MULTIPART_MIN_PART_SIZE"
+	EnvAPIMultipartMaxParts           = "MINIO_API_MULTIPART_MAX_PARTS"
+	EnvAPIMultipartValidateParts      = "MINIO_API_MULTIPART_VALIDATE_PARTS"
+)
+
+// O_DIRECT modes
+const (
+	// ODirectAuto uses O_DIRECT with the alignment and buffer size
+	// tuned to the detected characteristics of each drive.
+	ODirectAuto = "auto"
+	// ODirectOn always uses O_DIRECT with 4KiB alignment.
+	ODirectOn = "on"
+	// ODirectOff never uses O_DIRECT, writes go through the page cache
+	// and are flushed with fdatasync before the file is closed.
+	ODirectOff = "off"
+)
+
+// ODirectConfig is the O_DIRECT mode of all drives with per drive
+// overrides, keyed by drive path.
+type ODirectConfig struct {
+	Mode   string            `json:"mode"`
+	Drives map[string]string `json:"drives,omitempty"`
+}
+
+// GetMode returns the O_DIRECT mode of the drive at drivePath.
+func (o ODirectConfig) GetMode(drivePath string) string {
+	if mode, ok := o.Drives[drivePath]; ok {
+		return mode
+	}
+	if o.Mode == "" {
+		return ODirectAuto
+	}
+	return o.Mode
+}
+
+// parseODirect parses a default O_DIRECT mode followed by optional
+// comma separated per drive overrides, e.g. "auto,/mnt/disk3=off".
+func parseODirect(v string) (o ODirectConfig, err error) {
+	validMode := func(mode string) bool {
+		switch mode {
+		case ODirectAuto, ODirectOn, ODirectOff:
+			return true
+		}
+		return false
+	}
+	o.Mode = ODirectAuto
+	for n, field := range strings.Split(v, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		i := strings.Index(field, "=")
+		if i < 0 {
+			if n != 0 || !validMode(field) {
+				return o, fmt.Errorf("invalid value for odirect %q, expected \"auto\", \"on\" or \"off\" optionally followed by <drive>=<mode> overrides", v)
+			}
+			o.Mode = field
+			continue
+		}
+		drivePath, mode := strings.TrimSpace(field[:i]), strings.TrimSpace(field[i+1:])
+		if drivePath == "" || !validMode(mode) {
+			return o, fmt.Errorf("invalid odirect override %q, expected <drive>=auto|on|off", field)
+		}
+		if o.Drives == nil {
+			o.Drives = make(map[string]string)
+		}
+		o.Drives[drivePath] = mode
+	}
+	return o, nil
+}
+
+// ReplicationBackoff is the retry policy of failed replications to a
+// target, the delay between attempts doubles from Initial up to Max.
+type ReplicationBackoff struct {
+	Initial  time.Duration `json:"initial"`
+	Max      time.Duration `json:"max"`
+	Attempts int           `json:"attempts"`
+}
+
+// Delay returns the delay before the retry attempt, starting at 1.
+func (b ReplicationBackoff) Delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// ReplicationBackoffConfig is the retry policy of failed replications
+// with per target overrides, keyed by target ARN.
+type ReplicationBackoffConfig struct {
+	Default ReplicationBackoff            `json:"default"`
+	Targets map[string]ReplicationBackoff `json:"targets,omitempty"`
+}
+
+// Get returns the retry policy of the target arn.
+func (c ReplicationBackoffConfig) Get(arn string) ReplicationBackoff {
+	if b, ok := c.Targets[arn]; ok {
+		return b
+	}
+	return c.Default
+}
+
+// parseReplicationBackoffPolicy parses a retry policy "<initial>/<max>/<attempts>".
+func parseReplicationBackoffPolicy(v string) (b ReplicationBackoff, err error) {
+	fields := strings.Split(v, "/")
+	if len(fields) != 3 {
+		return b, fmt.Errorf("invalid replication retry policy %q, expected <initial>/<max>/<attempts>", v)
+	}
+	if b.Initial, err = time.ParseDuration(fields[0]); err != nil {
+		return b, err
+	}
+	if b.Max, err = time.ParseDuration(fields[1]); err != nil {
+		return b, err
+	}
+	if b.Attempts, err = strconv.Atoi(fields[2]); err != nil {
+		return b, err
+	}
+	if b.Initial <= 0 || b.Max < b.Initial || b.Attempts < 0 {
+		return b, fmt.Errorf("invalid replication retry policy %q, expected 0 < initial <= max and attempts >= 0", v)
+	}
+	return b, nil
+}
+
+// parseReplicationBackoff parses a default retry policy followed by optional
+// comma separated per target overrides, e.g. "10s/1h/10,<arn>=1m/6h/20".
+func parseReplicationBackoff(v string) (c ReplicationBackoffConfig, err error) {
+	for n, field := range strings.Split(v, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		// ARNs contain no '=', the policy follows the last one.
+		i := strings.LastIndex(field, "=")
+		if i < 0 {
+			if n != 0 {
+				return c, fmt.Errorf("invalid value for replication mrf backoff %q, the default policy must come first", v)
+			}
+			if c.Default, err = parseReplicationBackoffPolicy(field); err != nil {
+				return c, err
+			}
+			continue
+		}
+		arn := strings.TrimSpace(field[:i])
+		if arn == "" {
+			return c, fmt.Errorf("invalid replication mrf backoff override %q, expected <arn>=<initial>/<max>/<attempts>", field)
+		}
+		b, err := parseReplicationBackoffPolicy(strings.TrimSpace(field[i+1:]))
+		if err != nil {
+			return c, err
+		}
+		if c.Targets == nil {
+			c.Targets = make(map[string]ReplicationBackoff)
+		}
+		c.Targets[arn] = b
+	}
+	if c.Default == (ReplicationBackoff{}) {
+		return c, fmt.Errorf("invalid value for replication mrf backoff %q, expected a default policy <initial>/<max>/<attempts>", v)
+	}
+	return c, nil
+}
+
+// ETag modes
+const (
+	// ETagModeDefault keeps the ETag of objects encrypted with SSE-S3
+	// sealed, it is decrypted on every request.
+	ETagModeDefault = "default"
+	// ETagModeAWS stores the content MD5 of objects encrypted with
+	// SSE-S3 next to the sealed ETag, and returns it like AWS S3 does.
+	ETagModeAWS = "aws"
+)
+
+// MaxInlineThreshold is the largest allowed inline threshold, all the
+// inlined data of an object is held in memory when its xl.meta is read.
+const MaxInlineThreshold = 1 << 20
+
+// Limits of the multipart part size and number of parts, part numbers
+// are limited to 10000 by S3 and parts to 5GiB.
+const (
+	MaxMultipartMinPartSize = 5 << 30
+	MaxMultipartParts       = 10000
 )
 
 // Deprecated key and ENVs
@@ -118,23 +337,113 @@ var (
 			Key:   apiDeleteCleanupInterval,
 			Value: "5m",
 		},
+		config.KV{
+			Key:   apiETagMode,
+			Value: ETagModeDefault,
+		},
+		config.KV{
+			Key:   apiBlockPublicAccess,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiInlineThreshold,
+			Value: "128KiB",
+		},
+		config.KV{
+			Key:   apiBlockCacheSize,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiZeroCopyGet,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiODirect,
+			Value: ODirectAuto,
+		},
+		config.KV{
+			Key:   apiAdmissionHeapPercent,
+			Value: "90",
+		},
+		config.KV{
+			Key:   apiAdmissionInflightSize,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiAdmissionMinSize,
+			Value: "1MiB",
+		},
+		config.KV{
+			Key:   apiAdmissionDeadline,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   apiReplicationMRFSize,
+			Value: "100000",
+		},
+		config.KV{
+			Key:   apiReplicationMRFBackoff,
+			Value: "10s/1h/10",
+		},
+		config.KV{
+			Key:   apiReadyMinOnlineDrives,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiReadyMaxHealBacklog,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiReadyMaxReplicationBacklog,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiMultipartMinPartSize,
+			Value: "5MiB",
+		},
+		config.KV{
+			Key:   apiMultipartMaxParts,
+			Value: "10000",
+		},
+		config.KV{
+			Key:   apiMultipartValidateParts,
+			Value: config.EnableOff,
+		},
 	}
 )
 
 // Config storage class configuration
 type Config struct {
-	RequestsMax                 int           `json:"requests_max"`
-	RequestsDeadline            time.Duration `json:"requests_deadline"`
-	ClusterDeadline             time.Duration `json:"cluster_deadline"`
-	CorsAllowOrigin             []string      `json:"cors_allow_origin"`
-	RemoteTransportDeadline     time.Duration `json:"remote_transport_deadline"`
-	ListQuorum                  string        `json:"list_quorum"`
-	ReplicationWorkers          int           `json:"replication_workers"`
-	ReplicationFailedWorkers    int           `json:"replication_failed_workers"`
-	TransitionWorkers           int           `json:"transition_workers"`
-	StaleUploadsCleanupInterval time.Duration `json:"stale_uploads_cleanup_interval"`
-	StaleUploadsExpiry          time.Duration `json:"stale_uploads_expiry"`
-	DeleteCleanupInterval       time.Duration `json:"delete_cleanup_interval"`
+	RequestsMax                 int                      `json:"requests_max"`
+	RequestsDeadline            time.Duration            `json:"requests_deadline"`
+	ClusterDeadline             time.Duration            `json:"cluster_deadline"`
+	CorsAllowOrigin             []string                 `json:"cors_allow_origin"`
+	RemoteTransportDeadline     time.Duration            `json:"remote_transport_deadline"`
+	ListQuorum                  string                   `json:"list_quorum"`
+	ReplicationWorkers          int                      `json:"replication_workers"`
+	ReplicationFailedWorkers    int                      `json:"replication_failed_workers"`
+	TransitionWorkers           int                      `json:"transition_workers"`
+	StaleUploadsCleanupInterval time.Duration            `json:"stale_uploads_cleanup_interval"`
+	StaleUploadsExpiry          time.Duration            `json:"stale_uploads_expiry"`
+	DeleteCleanupInterval       time.Duration            `json:"delete_cleanup_interval"`
+	ETagMode                    string                   `json:"etag_mode"`
+	BlockPublicAccess           bool                     `json:"block_public_access"`
+	InlineThreshold             int64                    `json:"inline_threshold"`
+	BlockCacheSize              int64                    `json:"block_cache_size"`
+	ZeroCopyGet                 bool                     `json:"zero_copy_get"`
+	ODirect                     ODirectConfig            `json:"odirect"`
+	AdmissionHeapPercent        int                      `json:"admission_heap_percent"`
+	AdmissionInflightSize       int64                    `json:"admission_inflight_size"`
+	AdmissionMinSize            int64                    `json:"admission_min_size"`
+	AdmissionDeadline           time.Duration            `json:"admission_deadline"`
+	ReplicationMRFSize          int                      `json:"replication_mrf_size"`
+	ReplicationMRFBackoff       ReplicationBackoffConfig `json:"replication_mrf_backoff"`
+	ReadyMinOnlineDrives        int                      `json:"ready_min_online_drives"`
+	ReadyMaxHealBacklog         int                      `json:"ready_max_heal_backlog"`
+	ReadyMaxReplicationBacklog  int                      `json:"ready_max_replication_backlog"`
+	MultipartMinPartSize        int64                    `json:"multipart_min_part_size"`
+	MultipartMaxParts           int                      `json:"multipart_max_parts"`
+	MultipartValidateParts      bool                     `json:"multipart_validate_parts"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -254,6 +563,115 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	etagMode := env.Get(EnvAPIETagMode, kvs.Get(apiETagMode))
+	switch etagMode {
+	case "":
+		etagMode = ETagModeDefault
+	case ETagModeDefault, ETagModeAWS:
+	default:
+		return cfg, errors.New("invalid value for etag mode, expected \"default\" or \"aws\"")
+	}
+
+	blockPublicAccess, err := config.ParseBool(env.Get(EnvAPIBlockPublicAccess, kvs.Get(apiBlockPublicAccess)))
+	if err != nil {
+		return cfg, err
+	}
+
+	inlineThreshold, err := humanize.ParseBytes(env.Get(EnvAPIInlineThreshold, kvs.Get(apiInlineThreshold)))
+	if err != nil {
+		return cfg, err
+	}
+	if inlineThreshold == 0 || inlineThreshold > MaxInlineThreshold {
+		return cfg, errors.New("invalid value for inline threshold, expected a size between 1B and 1MiB")
+	}
+
+	blockCacheSize, err := humanize.ParseBytes(env.Get(EnvAPIBlockCacheSize, kvs.Get(apiBlockCacheSize)))
+	if err != nil {
+		return cfg, err
+	}
+
+	zeroCopyGet, err := config.ParseBool(env.Get(EnvAPIZeroCopyGet, kvs.Get(apiZeroCopyGet)))
+	if err != nil {
+		return cfg, err
+	}
+
+	odirect, err := parseODirect(env.Get(EnvAPIODirect, kvs.Get(apiODirect)))
+	if err != nil {
+		return cfg, err
+	}
+
+	admissionHeapPercent, err := strconv.Atoi(env.Get(EnvAPIAdmissionHeapPercent, kvs.Get(apiAdmissionHeapPercent)))
+	if err != nil {
+		return cfg, err
+	}
+	if admissionHeapPercent < 0 || admissionHeapPercent > 100 {
+		return cfg, errors.New("invalid value for admission heap percent, expected a percentage between 0 and 100")
+	}
+
+	admissionInflightSize, err := humanize.ParseBytes(env.Get(EnvAPIAdmissionInflightSize, kvs.Get(apiAdmissionInflightSize)))
+	if err != nil {
+		return cfg, err
+	}
+
+	admissionMinSize, err := humanize.ParseBytes(env.Get(EnvAPIAdmissionMinSize, kvs.Get(apiAdmissionMinSize)))
+	if err != nil {
+		return cfg, err
+	}
+
+	admissionDeadline, err := time.ParseDuration(env.Get(EnvAPIAdmissionDeadline, kvs.Get(apiAdmissionDeadline)))
+	if err != nil {
+		return cfg, err
+	}
+
+	replicationMRFSize, err := strconv.Atoi(env.Get(EnvAPIReplicationMRFSize, kvs.Get(apiReplicationMRFSize)))
+	if err != nil {
+		return cfg, err
+	}
+	if replicationMRFSize < 0 {
+		return cfg, errors.New("invalid value for replication mrf size, expected a positive number of entries or 0 to disable retries")
+	}
+
+	replicationMRFBackoff, err := parseReplicationBackoff(env.Get(EnvAPIReplicationMRFBackoff, kvs.Get(apiReplicationMRFBackoff)))
+	if err != nil {
+		return cfg, err
+	}
+
+	// The readiness criteria are disabled when 0.
+	readyLimits := make([]int, 3)
+	for i, k := range []struct{ env, key string }{
+		{EnvAPIReadyMinOnlineDrives, apiReadyMinOnlineDrives},
+		{EnvAPIReadyMaxHealBacklog, apiReadyMaxHealBacklog},
+		{EnvAPIReadyMaxReplicationBacklog, apiReadyMaxReplicationBacklog},
+	} {
+		if readyLimits[i], err = strconv.Atoi(env.Get(k.env, kvs.Get(k.key))); err != nil {
+			return cfg, err
+		}
+		if readyLimits[i] < 0 {
+			return cfg, fmt.Errorf("invalid value for %s, expected a positive number or 0 to disable it", k.key)
+		}
+	}
+
+	multipartMinPartSize, err := humanize.ParseBytes(env.Get(EnvAPIMultipartMinPartSize, kvs.Get(apiMultipartMinPartSize)))
+	if err != nil {
+		return cfg, err
+	}
+	if multipartMinPartSize == 0 || multipartMinPartSize > MaxMultipartMinPartSize {
+		return cfg, errors.New("invalid value for multipart min part size, expected a size between 1B and 5GiB")
+	}
+
+	multipartMaxParts, err := strconv.Atoi(env.Get(EnvAPIMultipartMaxParts, kvs.Get(apiMultipartMaxParts)))
+	if err != nil {
+		return cfg, err
+	}
+	if multipartMaxParts <= 0 || multipartMaxParts > MaxMultipartParts {
+		return cfg, errors.New("invalid value for multipart max parts, expected a number between 1 and 10000")
+	}
+
+	multipartValidateParts, err := config.ParseBool(env.Get(EnvAPIMultipartValidateParts, kvs.Get(apiMultipartValidateParts)))
+	if err != nil {
+		return cfg, err
+	}
+
 	return Config{
 		RequestsMax:                 requestsMax,
 		RequestsDeadline:            requestsDeadline,
@@ -267,5 +685,23 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		StaleUploadsCleanupInterval: staleUploadsCleanupInterval,
 		StaleUploadsExpiry:          staleUploadsExpiry,
 		DeleteCleanupInterval:       deleteCleanupInterval,
+		ETagMode:                    etagMode,
+		BlockPublicAccess:           blockPublicAccess,
+		InlineThreshold:             int64(inlineThreshold),
+		BlockCacheSize:              int64(blockCacheSize),
+		ZeroCopyGet:                 zeroCopyGet,
+		ODirect:                     odirect,
+		AdmissionHeapPercent:        admissionHeapPercent,
+		AdmissionInflightSize:       int64(admissionInflightSize),
+		AdmissionMinSize:            int64(admissionMinSize),
+		AdmissionDeadline:           admissionDeadline,
+		ReplicationMRFSize:          replicationMRFSize,
+		ReplicationMRFBackoff:       replicationMRFBackoff,
+		ReadyMinOnlineDrives:        readyLimits[0],
+		ReadyMaxHealBacklog:         readyLimits[1],
+		ReadyMaxReplicationBacklog:  readyLimits[2],
+		MultipartMinPartSize:        int64(multipartMinPartSize),
+		MultipartMaxParts:           multipartMaxParts,
+		MultipartValidateParts:      multipartValidateParts,
 	}, nil
 }
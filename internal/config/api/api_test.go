@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseODirect(t *testing.T) {
+	testCases := []struct {
+		str      string
+		expected ODirectConfig
+		success  bool
+	}{
+		// invalid input
+		{"yes", ODirectConfig{}, false},
+		{"/mnt/disk1=yes", ODirectConfig{}, false},
+		{"=off", ODirectConfig{}, false},
+		{"/mnt/disk1=off,on", ODirectConfig{}, false},
+
+		// valid input
+		{"", ODirectConfig{Mode: ODirectAuto}, true},
+		{"off", ODirectConfig{Mode: ODirectOff}, true},
+		{"/mnt/disk1=off", ODirectConfig{Mode: ODirectAuto, Drives: map[string]string{"/mnt/disk1": ODirectOff}}, true},
+		{"on, /mnt/disk1=off ,/mnt/disk2=auto", ODirectConfig{Mode: ODirectOn, Drives: map[string]string{"/mnt/disk1": ODirectOff, "/mnt/disk2": ODirectAuto}}, true},
+	}
+	for i, tc := range testCases {
+		o, err := parseODirect(tc.str)
+		if tc.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+		if tc.success && !reflect.DeepEqual(o, tc.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expected, o)
+		}
+	}
+
+	o := ODirectConfig{Mode: ODirectOn, Drives: map[string]string{"/mnt/disk1": ODirectOff}}
+	if mode := o.GetMode("/mnt/disk1"); mode != ODirectOff {
+		t.Fatalf("expected %s, got %s", ODirectOff, mode)
+	}
+	if mode := o.GetMode("/mnt/disk2"); mode != ODirectOn {
+		t.Fatalf("expected %s, got %s", ODirectOn, mode)
+	}
+}
+
+func TestParseReplicationBackoff(t *testing.T) {
+	arn := "arn:minio:replication::c5be6b16-769d-432a-9ef1-4567081f3566:dest"
+	def := ReplicationBackoff{Initial: 10 * time.Second, Max: time.Hour, Attempts: 10}
+	testCases := []struct {
+		str      string
+		expected ReplicationBackoffConfig
+		success  bool
+	}{
+		// invalid input
+		{"", ReplicationBackoffConfig{}, false},
+		{"10s/1h", ReplicationBackoffConfig{}, false},
+		{"1h/10s/10", ReplicationBackoffConfig{}, false},
+		{"10s/1h/x", ReplicationBackoffConfig{}, false},
+		{arn + "=1m/6h/20", ReplicationBackoffConfig{}, false},
+		{"10s/1h/10,1m/6h/20", ReplicationBackoffConfig{}, false},
+		{"10s/1h/10,=1m/6h/20", ReplicationBackoffConfig{}, false},
+
+		// valid input
+		{"10s/1h/10", ReplicationBackoffConfig{Default: def}, true},
+		{"10s/1h/10, " + arn + "=1m/6h/20", ReplicationBackoffConfig{
+			Default: def,
+			Targets: map[string]ReplicationBackoff{arn: {Initial: time.Minute, Max: 6 * time.Hour, Attempts: 20}},
+		}, true},
+	}
+	for i, tc := range testCases {
+		c, err := parseReplicationBackoff(tc.str)
+		if tc.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+		if tc.success && !reflect.DeepEqual(c, tc.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expected, c)
+		}
+	}
+
+	c := ReplicationBackoffConfig{Default: def, Targets: map[string]ReplicationBackoff{arn: {Initial: time.Minute, Max: 6 * time.Hour, Attempts: 20}}}
+	if b := c.Get("arn:other"); b != def {
+		t.Fatalf("expected %v, got %v", def, b)
+	}
+	for attempt, expected := range []time.Duration{time.Minute, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute} {
+		if attempt == 0 {
+			continue
+		}
+		if d := c.Get(arn).Delay(attempt); d != expected {
+			t.Fatalf("attempt %d: expected %v, got %v", attempt, expected, d)
+		}
+	}
+	if d := def.Delay(20); d != time.Hour {
+		t.Fatalf("expected delay capped at %v, got %v", time.Hour, d)
+	}
+}
@@ -94,5 +94,113 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiETagMode,
+			Description: `set to "aws" to return the content MD5 as ETag of single part SSE-S3 objects uploaded from now on, defaults to "default"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiBlockPublicAccess,
+			Description: `set to "on" to deny anonymous requests and reject public bucket policies on all buckets, defaults to "off"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiInlineThreshold,
+			Description: `objects with an erasure shard smaller than this size are stored inline in xl.meta, 1/8th of it for versioned objects, max "1MiB", defaults to "128KiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiBlockCacheSize,
+			Description: `size of the in-memory cache of decoded erasure blocks served by GET requests, e.g. "512MiB", defaults to "0" which disables it`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiZeroCopyGet,
+			Description: `set to "on" to send unencrypted and uncompressed objects straight from the data shards on local drives, without erasure decoding, defaults to "off"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiODirect,
+			Description: `O_DIRECT mode of drive writes "auto", "on" or "off", optionally followed by per drive overrides e.g. "auto,/mnt/disk3=off", defaults to "auto"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiAdmissionHeapPercent,
+			Description: `reject large uploads and GET requests with SlowDown while the heap is above this percentage of the available memory, "0" disables it, defaults to "90"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiAdmissionInflightSize,
+			Description: `reject large uploads with SlowDown while the bodies of the uploads in flight add up to more than this size e.g. "4GiB", defaults to "0" which disables it`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiAdmissionMinSize,
+			Description: `uploads smaller than this size are never rejected under memory pressure, defaults to "1MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiAdmissionDeadline,
+			Description: `how long large requests wait for memory under pressure before they are rejected, defaults to "1s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiReplicationMRFSize,
+			Description: `maximum number of failed replications queued for retry on each server, "0" disables retries, defaults to "100000"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiReplicationMRFBackoff,
+			Description: `retry policy of failed replications "<initial>/<max>/<attempts>", optionally followed by per target overrides e.g. "10s/1h/10,<arn>=1m/6h/20", defaults to "10s/1h/10"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiReadyMinOnlineDrives,
+			Description: `the server is not ready when an erasure set has fewer online drives, "0" disables the check, defaults to "0"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiReadyMaxHealBacklog,
+			Description: `the server is not ready when more objects are waiting to be healed, "0" disables the check, defaults to "0"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiReadyMaxReplicationBacklog,
+			Description: `the server is not ready when more objects are waiting to be replicated, "0" disables the check, defaults to "0"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiMultipartMinPartSize,
+			Description: `minimum size of all the parts of a multipart upload but the last one, max "5GiB", defaults to "5MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiMultipartMaxParts,
+			Description: `maximum part number of a multipart upload, max "10000", defaults to "10000"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiMultipartValidateParts,
+			Description: `set to "on" to reject parts smaller than the minimum part size when they are uploaded instead of when the upload completes, defaults to "off"`,
+			Optional:    true,
+			Type:        "string",
+		},
 	}
 )
@@ -81,10 +81,12 @@ const (
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
 	AuditKafkaSubSys     = "audit_kafka"
+	AuditFileSubSys      = "audit_file"
 	HealSubSys           = "heal"
 	ScannerSubSys        = "scanner"
 	CrawlerSubSys        = "crawler"
 	SubnetSubSys         = "subnet"
+	LambdaWebhookSubSys  = "lambda_webhook"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -99,7 +101,9 @@ const (
 	NotifyESSubSys       = "notify_elasticsearch"
 	NotifyAMQPSubSys     = "notify_amqp"
 	NotifyPostgresSubSys = "notify_postgres"
+	NotifyPulsarSubSys   = "notify_pulsar"
 	NotifyRedisSubSys    = "notify_redis"
+	NotifySQSSubSys      = "notify_sqs"
 	NotifyWebhookSubSys  = "notify_webhook"
 
 	// Add new constants here if you add new fields to config.
@@ -118,6 +122,7 @@ var SubSystems = set.CreateStringSet(
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
 	AuditKafkaSubSys,
+	AuditFileSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
@@ -132,9 +137,12 @@ var SubSystems = set.CreateStringSet(
 	NotifyNATSSubSys,
 	NotifyNSQSubSys,
 	NotifyPostgresSubSys,
+	NotifyPulsarSubSys,
 	NotifyRedisSubSys,
+	NotifySQSSubSys,
 	NotifyWebhookSubSys,
 	SubnetSubSys,
+	LambdaWebhookSubSys,
 )
 
 // SubSystemsDynamic - all sub-systems that have dynamic config.
@@ -158,7 +166,6 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	CompressionSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
-	IdentityOpenIDSubSys,
 	IdentityTLSSubSys,
 	HealSubSys,
 	ScannerSubSys,
@@ -396,6 +403,10 @@ func (c Config) ReadConfig(r io.Reader) (dynOnly bool, err error) {
 	return dynOnly, nil
 }
 
+// RedactedValue - replaces the value of sensitive keys in redacted
+// configs.
+const RedactedValue = "*redacted*"
+
 // RedactSensitiveInfo - removes sensitive information
 // like urls and credentials from the configuration
 func (c Config) RedactSensitiveInfo() Config {
@@ -407,7 +418,7 @@ func (c Config) RedactSensitiveInfo() Config {
 				for name, kvs := range configVals {
 					for i := range kvs {
 						if kvs[i].Key == helpKV.Key && len(kvs[i].Value) > 0 {
-							kvs[i].Value = "*redacted*"
+							kvs[i].Value = RedactedValue
 						}
 					}
 					configVals[name] = kvs
@@ -47,6 +47,9 @@ const (
 
 	EnvUpdate = "MINIO_UPDATE"
 
+	EnvInternodeTransport = "MINIO_INTERNODE_TRANSPORT"
+	EnvInternodeGRPCPort  = "MINIO_INTERNODE_GRPC_PORT"
+
 	EnvKMSSecretKey  = "MINIO_KMS_SECRET_KEY"
 	EnvKESEndpoint   = "MINIO_KMS_KES_ENDPOINT"
 	EnvKESKeyName    = "MINIO_KMS_KES_KEY_NAME"
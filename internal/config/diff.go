@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// Diff - a key with different values in two configs.
+type Diff struct {
+	SubSys string `json:"subSys"`
+	Target string `json:"target,omitempty"`
+	Key    string `json:"key"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// Diff - returns the keys with different values in c and to, sorted
+// by sub-system, target and key. Values of sensitive keys are redacted.
+func (c Config) Diff(to Config) []Diff {
+	var diffs []Diff
+	subSystems := set.NewStringSet()
+	for subSys := range c {
+		subSystems.Add(subSys)
+	}
+	for subSys := range to {
+		subSystems.Add(subSys)
+	}
+	for _, subSys := range subSystems.ToSlice() {
+		targets := set.NewStringSet()
+		for tgt := range c[subSys] {
+			targets.Add(tgt)
+		}
+		for tgt := range to[subSys] {
+			targets.Add(tgt)
+		}
+		for _, tgt := range targets.ToSlice() {
+			fromKVS, toKVS := c[subSys][tgt], to[subSys][tgt]
+			keys := set.CreateStringSet(fromKVS.Keys()...).Union(set.CreateStringSet(toKVS.Keys()...)).ToSlice()
+			for _, key := range keys {
+				fromV, toV := fromKVS.Get(key), toKVS.Get(key)
+				if fromV == toV {
+					continue
+				}
+				if hkv, ok := HelpSubSysMap[subSys].Lookup(key); ok && hkv.Sensitive {
+					fromV, toV = redact(fromV), redact(toV)
+				}
+				diff := Diff{SubSys: subSys, Key: key, From: fromV, To: toV}
+				if tgt != Default {
+					diff.Target = tgt
+				}
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+	return diffs
+}
+
+func redact(v string) string {
+	if v == "" {
+		return v
+	}
+	return RedactedValue
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigDiff(t *testing.T) {
+	helpSubSysMap := HelpSubSysMap
+	defer func() { HelpSubSysMap = helpSubSysMap }()
+	HelpSubSysMap = map[string]HelpKVS{
+		"test": {
+			{Key: "endpoint"},
+			{Key: "secret", Sensitive: true},
+		},
+	}
+
+	from := Config{
+		"test": {
+			Default: KVS{{Key: "endpoint", Value: "http://a"}, {Key: "secret", Value: "s1"}},
+			"old":   KVS{{Key: "endpoint", Value: "http://old"}},
+		},
+		"other": {Default: KVS{{Key: "key", Value: "v"}}},
+	}
+	to := Config{
+		"test": {
+			Default: KVS{{Key: "endpoint", Value: "http://b"}, {Key: "secret", Value: "s2"}},
+			"new":   KVS{{Key: "secret", Value: "s3"}},
+		},
+		"other": {Default: KVS{{Key: "key", Value: "v"}}},
+	}
+
+	expected := []Diff{
+		{SubSys: "test", Key: "endpoint", From: "http://a", To: "http://b"},
+		{SubSys: "test", Key: "secret", From: RedactedValue, To: RedactedValue},
+		{SubSys: "test", Target: "new", Key: "secret", To: RedactedValue},
+		{SubSys: "test", Target: "old", Key: "endpoint", From: "http://old"},
+	}
+	if diffs := from.Diff(to); !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("Expected %#v, got %#v", expected, diffs)
+	}
+
+	if diffs := from.Diff(from); len(diffs) != 0 {
+		t.Fatalf("Expected no differences, got %#v", diffs)
+	}
+}
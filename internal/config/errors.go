@@ -31,6 +31,12 @@ var (
 		"Can only accept `on` and `off` values. To enable O_SYNC for fs backend, set this value to `on`",
 	)
 
+	ErrInvalidInternodeTransport = newErrFn(
+		"Invalid internode transport value",
+		"Please check the passed value",
+		"Can only accept `http` and `grpc` values, all servers must use the same transport",
+	)
+
 	ErrOverlappingDomainValue = newErrFn(
 		"Overlapping domain values",
 		"Please check the passed value",
@@ -30,13 +30,19 @@ import (
 
 // Compression environment variables
 const (
-	Bitrot  = "bitrotscan"
-	Sleep   = "max_sleep"
-	IOCount = "max_io"
-
-	EnvBitrot  = "MINIO_HEAL_BITROTSCAN"
-	EnvSleep   = "MINIO_HEAL_MAX_SLEEP"
-	EnvIOCount = "MINIO_HEAL_MAX_IO"
+	Bitrot           = "bitrotscan"
+	Sleep            = "max_sleep"
+	IOCount          = "max_io"
+	PartsConcurrency = "parts_concurrency"
+	LatencyFactor    = "drive_latency_factor"
+	LatencyWindow    = "drive_latency_window"
+
+	EnvBitrot           = "MINIO_HEAL_BITROTSCAN"
+	EnvSleep            = "MINIO_HEAL_MAX_SLEEP"
+	EnvIOCount          = "MINIO_HEAL_MAX_IO"
+	EnvPartsConcurrency = "MINIO_HEAL_PARTS_CONCURRENCY"
+	EnvLatencyFactor    = "MINIO_HEAL_DRIVE_LATENCY_FACTOR"
+	EnvLatencyWindow    = "MINIO_HEAL_DRIVE_LATENCY_WINDOW"
 )
 
 var configMutex sync.RWMutex
@@ -48,6 +54,12 @@ type Config struct {
 	// maximum sleep duration between objects to slow down heal operation.
 	Sleep   time.Duration `json:"sleep"`
 	IOCount int           `json:"iocount"`
+	// number of parts of an object healed in parallel.
+	PartWorkers int `json:"partWorkers"`
+	// a drive whose read latency exceeds LatencyFactor times the
+	// median of its erasure set for LatencyWindow is marked suspect.
+	LatencyFactor float64       `json:"latencyFactor"`
+	LatencyWindow time.Duration `json:"latencyWindow"`
 }
 
 // ScanMode returns configured scan mode
@@ -60,6 +72,21 @@ func (opts Config) ScanMode() madmin.HealScanMode {
 	return madmin.HealNormalScan
 }
 
+// PartsConcurrency returns the number of parts of an object to heal in parallel.
+func (opts Config) PartsConcurrency() int {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.PartWorkers
+}
+
+// DriveLatency returns the factor and the time window after which a
+// slow drive is marked suspect, a factor of 0 disables it.
+func (opts Config) DriveLatency() (factor float64, window time.Duration) {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.LatencyFactor, opts.LatencyWindow
+}
+
 // Wait waits for IOCount to go down or max sleep to elapse before returning.
 // usually used in healing paths to wait for specified amount of time to
 // throttle healing.
@@ -103,6 +130,9 @@ func (opts *Config) Update(nopts Config) {
 	opts.Bitrot = nopts.Bitrot
 	opts.IOCount = nopts.IOCount
 	opts.Sleep = nopts.Sleep
+	opts.PartWorkers = nopts.PartWorkers
+	opts.LatencyFactor = nopts.LatencyFactor
+	opts.LatencyWindow = nopts.LatencyWindow
 }
 
 var (
@@ -120,6 +150,18 @@ var (
 			Key:   IOCount,
 			Value: "100",
 		},
+		config.KV{
+			Key:   PartsConcurrency,
+			Value: "4",
+		},
+		config.KV{
+			Key:   LatencyFactor,
+			Value: "5",
+		},
+		config.KV{
+			Key:   LatencyWindow,
+			Value: "5m",
+		},
 	}
 
 	// Help provides help for config values
@@ -142,6 +184,24 @@ var (
 			Optional:    true,
 			Type:        "int",
 		},
+		config.HelpKV{
+			Key:         PartsConcurrency,
+			Description: `number of parts of a multipart object healed in parallel. eg. 8`,
+			Optional:    true,
+			Type:        "int",
+		},
+		config.HelpKV{
+			Key:         LatencyFactor,
+			Description: `mark drives suspect whose read latency exceeds the erasure set median by this factor, 0 disables it. eg. 5`,
+			Optional:    true,
+			Type:        "float",
+		},
+		config.HelpKV{
+			Key:         LatencyWindow,
+			Description: `time a drive must stay slow before it is marked suspect, or fast before it is cleared. eg. 5m`,
+			Optional:    true,
+			Type:        "duration",
+		},
 	}
 )
 
@@ -162,5 +222,23 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, fmt.Errorf("'heal:max_io' value invalid: %w", err)
 	}
+	cfg.PartWorkers, err = strconv.Atoi(env.Get(EnvPartsConcurrency, kvs.GetWithDefault(PartsConcurrency, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:parts_concurrency' value invalid: %w", err)
+	}
+	if cfg.PartWorkers <= 0 {
+		return cfg, fmt.Errorf("'heal:parts_concurrency' value invalid: must be greater than 0")
+	}
+	cfg.LatencyFactor, err = strconv.ParseFloat(env.Get(EnvLatencyFactor, kvs.GetWithDefault(LatencyFactor, DefaultKVS)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:drive_latency_factor' value invalid: %w", err)
+	}
+	if cfg.LatencyFactor != 0 && cfg.LatencyFactor <= 1 {
+		return cfg, fmt.Errorf("'heal:drive_latency_factor' value invalid: must be 0 or greater than 1")
+	}
+	cfg.LatencyWindow, err = time.ParseDuration(env.Get(EnvLatencyWindow, kvs.GetWithDefault(LatencyWindow, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:drive_latency_window' value invalid: %w", err)
+	}
 	return cfg, nil
 }
@@ -59,41 +59,56 @@ type Config struct {
 	GroupSearchBaseDistNames []string `json:"-"`
 	GroupSearchFilter        string   `json:"groupSearchFilter"`
 
+	// Levels of nested groups resolved, 0 for the direct groups only
+	GroupSearchNestedDepth int `json:"groupSearchNestedDepth"`
+
 	// Lookup bind LDAP service account
 	LookupBindDN       string `json:"lookupBindDN"`
 	LookupBindPassword string `json:"lookupBindPassword"`
 
+	// User attributes set as session tags of STS credentials
+	SessionTagAttributes []string `json:"sessionTagAttributes"`
+
 	stsExpiryDuration time.Duration // contains converted value
 	tlsSkipVerify     bool          // allows skipping TLS verification
 	serverInsecure    bool          // allows plain text connection to LDAP server
 	serverStartTLS    bool          // allows using StartTLS connection to LDAP server
 	rootCAs           *x509.CertPool
+	groupCache        *groupCache // caches group lookups, nil if disabled
 }
 
 // LDAP keys and envs.
 const (
-	ServerAddr         = "server_addr"
-	LookupBindDN       = "lookup_bind_dn"
-	LookupBindPassword = "lookup_bind_password"
-	UserDNSearchBaseDN = "user_dn_search_base_dn"
-	UserDNSearchFilter = "user_dn_search_filter"
-	GroupSearchFilter  = "group_search_filter"
-	GroupSearchBaseDN  = "group_search_base_dn"
-	TLSSkipVerify      = "tls_skip_verify"
-	ServerInsecure     = "server_insecure"
-	ServerStartTLS     = "server_starttls"
-
-	EnvServerAddr         = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
-	EnvTLSSkipVerify      = "MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY"
-	EnvServerInsecure     = "MINIO_IDENTITY_LDAP_SERVER_INSECURE"
-	EnvServerStartTLS     = "MINIO_IDENTITY_LDAP_SERVER_STARTTLS"
-	EnvUsernameFormat     = "MINIO_IDENTITY_LDAP_USERNAME_FORMAT"
-	EnvUserDNSearchBaseDN = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_BASE_DN"
-	EnvUserDNSearchFilter = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_FILTER"
-	EnvGroupSearchFilter  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER"
-	EnvGroupSearchBaseDN  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
-	EnvLookupBindDN       = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
-	EnvLookupBindPassword = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
+	ServerAddr             = "server_addr"
+	LookupBindDN           = "lookup_bind_dn"
+	LookupBindPassword     = "lookup_bind_password"
+	UserDNSearchBaseDN     = "user_dn_search_base_dn"
+	UserDNSearchFilter     = "user_dn_search_filter"
+	GroupSearchFilter      = "group_search_filter"
+	GroupSearchBaseDN      = "group_search_base_dn"
+	GroupSearchNestedDepth = "group_search_nested_depth"
+	GroupCacheTTL          = "group_cache_ttl"
+	TLSSkipVerify          = "tls_skip_verify"
+	ServerInsecure         = "server_insecure"
+	ServerStartTLS         = "server_starttls"
+
+	SessionTagAttributes = "session_tag_attributes"
+
+	EnvServerAddr             = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
+	EnvTLSSkipVerify          = "MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY"
+	EnvServerInsecure         = "MINIO_IDENTITY_LDAP_SERVER_INSECURE"
+	EnvServerStartTLS         = "MINIO_IDENTITY_LDAP_SERVER_STARTTLS"
+	EnvUsernameFormat         = "MINIO_IDENTITY_LDAP_USERNAME_FORMAT"
+	EnvUserDNSearchBaseDN     = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_BASE_DN"
+	EnvUserDNSearchFilter     = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_FILTER"
+	EnvGroupSearchFilter      = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER"
+	EnvGroupSearchBaseDN      = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
+	EnvGroupSearchNestedDepth = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_NESTED_DEPTH"
+	EnvGroupCacheTTL          = "MINIO_IDENTITY_LDAP_GROUP_CACHE_TTL"
+	EnvLookupBindDN           = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
+	EnvLookupBindPassword     = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
+
+	EnvSessionTagAttributes = "MINIO_IDENTITY_LDAP_SESSION_TAG_ATTRIBUTES"
 )
 
 var removedKeys = []string{
@@ -127,6 +142,14 @@ var (
 			Key:   GroupSearchBaseDN,
 			Value: "",
 		},
+		config.KV{
+			Key:   GroupSearchNestedDepth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   GroupCacheTTL,
+			Value: "0s",
+		},
 		config.KV{
 			Key:   TLSSkipVerify,
 			Value: config.EnableOff,
@@ -147,6 +170,10 @@ var (
 			Key:   LookupBindPassword,
 			Value: "",
 		},
+		config.KV{
+			Key:   SessionTagAttributes,
+			Value: "",
+		},
 	}
 )
 
@@ -208,30 +235,50 @@ func (l *Config) lookupUserDN(conn *ldap.Conn, username string) (string, error)
 	return searchResult.Entries[0].DN, nil
 }
 
+// lookupUserAttributes returns the session tag attributes of the user
+// entry with the first value of each attribute. conn is assumed to be
+// using the lookup bind service account.
+func (l *Config) lookupUserAttributes(conn *ldap.Conn, userDN string) (map[string]string, error) {
+	if len(l.SessionTagAttributes) == 0 {
+		return nil, nil
+	}
+	searchRequest := ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		l.SessionTagAttributes,
+		nil,
+	)
+
+	searchResult, err := conn.Search(searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	if len(searchResult.Entries) != 1 {
+		return nil, fmt.Errorf("User entry for %s not found", userDN)
+	}
+	attributes := make(map[string]string, len(l.SessionTagAttributes))
+	for _, attribute := range l.SessionTagAttributes {
+		if v := searchResult.Entries[0].GetAttributeValue(attribute); v != "" {
+			attributes[attribute] = v
+		}
+	}
+	return attributes, nil
+}
+
 func (l *Config) searchForUserGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
 	// User groups lookup.
-	var groups []string
-	if l.GroupSearchFilter != "" {
-		for _, groupSearchBase := range l.GroupSearchBaseDistNames {
-			filter := strings.ReplaceAll(l.GroupSearchFilter, "%s", ldap.EscapeFilter(username))
-			filter = strings.ReplaceAll(filter, "%d", ldap.EscapeFilter(bindDN))
-			searchRequest := ldap.NewSearchRequest(
-				groupSearchBase,
-				ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-				filter,
-				nil,
-				nil,
-			)
-
-			var newGroups []string
-			newGroups, err := getGroups(conn, searchRequest)
-			if err != nil {
-				errRet := fmt.Errorf("Error finding groups of %s: %w", bindDN, err)
-				return nil, errRet
-			}
+	if l.GroupSearchFilter == "" {
+		return nil, nil
+	}
 
-			groups = append(groups, newGroups...)
-		}
+	lookup := func(memberDN, name string) ([]string, error) {
+		return l.lookupGroups(conn, memberDN, name)
+	}
+	groups, err := resolveGroups(bindDN, username, l.GroupSearchNestedDepth, lookup)
+	if err != nil {
+		errRet := fmt.Errorf("Error finding groups of %s: %w", bindDN, err)
+		return nil, errRet
 	}
 
 	return groups, nil
@@ -266,46 +313,53 @@ func (l *Config) LookupUserDN(username string) (string, []string, error) {
 }
 
 // Bind - binds to ldap, searches LDAP and returns the distinguished name of the
-// user and the list of groups.
-func (l *Config) Bind(username, password string) (string, []string, error) {
+// user, the list of groups and the user attributes set as session tags.
+func (l *Config) Bind(username, password string) (string, []string, map[string]string, error) {
 	conn, err := l.Connect()
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 	defer conn.Close()
 
 	var bindDN string
 	// Bind to the lookup user account
 	if err = l.lookupBind(conn); err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 
 	// Lookup user DN
 	bindDN, err = l.lookupUserDN(conn, username)
 	if err != nil {
 		errRet := fmt.Errorf("Unable to find user DN: %w", err)
-		return "", nil, errRet
+		return "", nil, nil, errRet
 	}
 
 	// Authenticate the user credentials.
 	err = conn.Bind(bindDN, password)
 	if err != nil {
 		errRet := fmt.Errorf("LDAP auth failed for DN %s: %w", bindDN, err)
-		return "", nil, errRet
+		return "", nil, nil, errRet
 	}
 
 	// Bind to the lookup user account again to perform group search.
 	if err = l.lookupBind(conn); err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 
 	// User groups lookup.
 	groups, err := l.searchForUserGroups(conn, username, bindDN)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 
-	return bindDN, groups, nil
+	// User attributes lookup.
+	attributes, err := l.lookupUserAttributes(conn, bindDN)
+	if err != nil {
+		errRet := fmt.Errorf("Error finding attributes of %s: %w", bindDN, err)
+		return "", nil, nil, errRet
+	}
+
+	return bindDN, groups, attributes, nil
 }
 
 // Connect connect to ldap server.
@@ -536,5 +590,27 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 		l.GroupSearchBaseDistNames = strings.Split(l.GroupSearchBaseDistName, dnDelimiter)
 	}
 
+	if v := env.Get(EnvGroupSearchNestedDepth, kvs.Get(GroupSearchNestedDepth)); v != "" {
+		l.GroupSearchNestedDepth, err = strconv.Atoi(v)
+		if err != nil || l.GroupSearchNestedDepth < 0 || l.GroupSearchNestedDepth > maxGroupSearchNestedDepth {
+			return l, fmt.Errorf("Invalid nested group search depth %s, must be between 0 and %d", v, maxGroupSearchNestedDepth)
+		}
+	}
+	if v := env.Get(EnvGroupCacheTTL, kvs.Get(GroupCacheTTL)); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return l, fmt.Errorf("Invalid group cache TTL %s", v)
+		}
+		l.groupCache = newGroupCache(ttl)
+	}
+
+	if v := env.Get(EnvSessionTagAttributes, kvs.Get(SessionTagAttributes)); v != "" {
+		for _, attribute := range strings.Split(v, config.ValueSeparator) {
+			if attribute = strings.TrimSpace(attribute); attribute != "" {
+				l.SessionTagAttributes = append(l.SessionTagAttributes, attribute)
+			}
+		}
+	}
+
 	return l, nil
 }
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// maxGroupSearchNestedDepth is the maximum number of levels of nested
+// groups resolved for a user.
+const maxGroupSearchNestedDepth = 10
+
+// groupLookupFn returns the DNs of the groups the entry with the given
+// DN and name is a direct member of.
+type groupLookupFn func(memberDN, name string) ([]string, error)
+
+// resolveGroups returns the groups of the member and, up to depth
+// levels, the groups these groups are members of. Each group is
+// looked up once, so membership cycles end the resolution.
+func resolveGroups(memberDN, name string, depth int, lookup groupLookupFn) ([]string, error) {
+	groups, err := lookup(memberDN, name)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := set.CreateStringSet(groups...)
+	level := groups
+	for i := 0; i < depth && len(level) > 0; i++ {
+		var next []string
+		for _, groupDN := range level {
+			parents, err := lookup(groupDN, groupName(groupDN))
+			if err != nil {
+				return nil, err
+			}
+			for _, parent := range parents {
+				if seen.Contains(parent) {
+					continue
+				}
+				seen.Add(parent)
+				groups = append(groups, parent)
+				next = append(next, parent)
+			}
+		}
+		level = next
+	}
+	return groups, nil
+}
+
+// groupName returns the value of the first RDN of a group DN, it is
+// substituted for %s in the group search filter of nested groups.
+func groupName(groupDN string) string {
+	dn, err := ldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return strings.SplitN(groupDN, ",", 2)[0]
+	}
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+type groupCacheEntry struct {
+	groups []string
+	expiry time.Time
+}
+
+// groupCache holds the direct groups of user and group DNs for a
+// time to live, a nil groupCache caches nothing.
+type groupCache struct {
+	sync.Mutex
+	ttl       time.Duration
+	entries   map[string]groupCacheEntry
+	lastPurge time.Time
+}
+
+func newGroupCache(ttl time.Duration) *groupCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &groupCache{
+		ttl:       ttl,
+		entries:   make(map[string]groupCacheEntry),
+		lastPurge: time.Now(),
+	}
+}
+
+func (c *groupCache) get(memberDN string) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	e, ok := c.entries[memberDN]
+	if !ok || time.Now().After(e.expiry) {
+		return nil, false
+	}
+	return e.groups, true
+}
+
+func (c *groupCache) set(memberDN string, groups []string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	now := time.Now()
+	// Drop expired entries once per time to live.
+	if now.Sub(c.lastPurge) > c.ttl {
+		for dn, e := range c.entries {
+			if now.After(e.expiry) {
+				delete(c.entries, dn)
+			}
+		}
+		c.lastPurge = now
+	}
+	c.entries[memberDN] = groupCacheEntry{
+		groups: groups,
+		expiry: now.Add(c.ttl),
+	}
+}
+
+// lookupGroups returns the direct groups of the member from the cache
+// or the group search bases.
+func (l *Config) lookupGroups(conn *ldap.Conn, memberDN, name string) ([]string, error) {
+	if groups, ok := l.groupCache.get(memberDN); ok {
+		return groups, nil
+	}
+
+	var groups []string
+	for _, groupSearchBase := range l.GroupSearchBaseDistNames {
+		filter := strings.ReplaceAll(l.GroupSearchFilter, "%s", ldap.EscapeFilter(name))
+		filter = strings.ReplaceAll(filter, "%d", ldap.EscapeFilter(memberDN))
+		searchRequest := ldap.NewSearchRequest(
+			groupSearchBase,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			nil,
+			nil,
+		)
+
+		newGroups, err := getGroups(conn, searchRequest)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, newGroups...)
+	}
+
+	l.groupCache.set(memberDN, groups)
+	return groups, nil
+}
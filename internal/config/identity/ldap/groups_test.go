@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResolveGroups(t *testing.T) {
+	memberships := map[string][]string{
+		"uid=alice,ou=people,dc=min,dc=io": {"cn=dev,ou=groups,dc=min,dc=io"},
+		"cn=dev,ou=groups,dc=min,dc=io":    {"cn=eng,ou=groups,dc=min,dc=io"},
+		"cn=eng,ou=groups,dc=min,dc=io":    {"cn=staff,ou=groups,dc=min,dc=io"},
+		// Membership cycle.
+		"cn=staff,ou=groups,dc=min,dc=io": {"cn=dev,ou=groups,dc=min,dc=io"},
+	}
+	var names []string
+	lookup := func(memberDN, name string) ([]string, error) {
+		names = append(names, name)
+		return memberships[memberDN], nil
+	}
+
+	testCases := []struct {
+		depth  int
+		groups []string
+	}{
+		{0, []string{"cn=dev,ou=groups,dc=min,dc=io"}},
+		{1, []string{"cn=dev,ou=groups,dc=min,dc=io", "cn=eng,ou=groups,dc=min,dc=io"}},
+		{5, []string{"cn=dev,ou=groups,dc=min,dc=io", "cn=eng,ou=groups,dc=min,dc=io", "cn=staff,ou=groups,dc=min,dc=io"}},
+	}
+	for _, testCase := range testCases {
+		groups, err := resolveGroups("uid=alice,ou=people,dc=min,dc=io", "alice", testCase.depth, lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(groups, testCase.groups) {
+			t.Errorf("Depth %d: expected groups %v, got %v", testCase.depth, testCase.groups, groups)
+		}
+	}
+
+	// Nested groups are looked up with the value of their first RDN.
+	if !reflect.DeepEqual(names[len(names)-3:], []string{"dev", "eng", "staff"}) {
+		t.Errorf("Unexpected names of nested group lookups %v", names)
+	}
+}
+
+func TestGroupCache(t *testing.T) {
+	if c := newGroupCache(0); c != nil {
+		t.Fatal("Expected no cache without a time to live")
+	}
+	var disabled *groupCache
+	disabled.set("cn=dev", []string{"cn=eng"})
+	if _, ok := disabled.get("cn=dev"); ok {
+		t.Fatal("Expected a disabled cache to cache nothing")
+	}
+
+	c := newGroupCache(time.Minute)
+	c.set("cn=dev", []string{"cn=eng"})
+	if groups, ok := c.get("cn=dev"); !ok || !reflect.DeepEqual(groups, []string{"cn=eng"}) {
+		t.Fatalf("Expected cached groups, got %v", groups)
+	}
+
+	c.entries["cn=dev"] = groupCacheEntry{groups: []string{"cn=eng"}, expiry: time.Now().Add(-time.Second)}
+	if _, ok := c.get("cn=dev"); ok {
+		t.Fatal("Expected expired groups to not be returned")
+	}
+}
@@ -66,6 +66,18 @@ var (
 			Optional:    true,
 			Type:        "list",
 		},
+		config.HelpKV{
+			Key:         GroupSearchNestedDepth,
+			Description: `levels of nested groups resolved with the group search filter, defaults to "0" (direct groups only)`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         GroupCacheTTL,
+			Description: `duration for which group lookups are cached e.g. "5m", defaults to "0s" (no caching)`,
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         TLSSkipVerify,
 			Description: `trust server TLS without verification, defaults to "off" (verify)`,
@@ -84,6 +96,12 @@ var (
 			Optional:    true,
 			Type:        "on|off",
 		},
+		config.HelpKV{
+			Key:         SessionTagAttributes,
+			Description: `"," separated list of user attributes set as session tags of STS credentials e.g. "department,title"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
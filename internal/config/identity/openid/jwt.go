@@ -47,6 +47,10 @@ import (
 type Config struct {
 	*sync.RWMutex
 
+	// Target is the name of the provider, config.Default for the
+	// default provider.
+	Target string `json:"target,omitempty"`
+
 	Enabled bool `json:"enabled"`
 	JWKS    struct {
 		URL *xnet.URL `json:"url"`
@@ -153,15 +157,15 @@ const (
 // InitializeProvider initializes if any additional vendor specific
 // information was provided, initialization will return an error
 // initial login fails.
-func (r Config) InitializeProvider(kvs config.KVS) error {
-	vendor := env.Get(EnvIdentityOpenIDVendor, kvs.Get(Vendor))
+func (r Config) InitializeProvider(target string, kvs config.KVS) error {
+	vendor := env.Get(targetEnv(EnvIdentityOpenIDVendor, target), kvs.Get(Vendor))
 	if vendor == "" {
 		return nil
 	}
 	switch vendor {
 	case keyCloakVendor:
-		adminURL := env.Get(EnvIdentityOpenIDKeyCloakAdminURL, kvs.Get(KeyCloakAdminURL))
-		realm := env.Get(EnvIdentityOpenIDKeyCloakRealm, kvs.Get(KeyCloakRealm))
+		adminURL := env.Get(targetEnv(EnvIdentityOpenIDKeyCloakAdminURL, target), kvs.Get(KeyCloakAdminURL))
+		realm := env.Get(targetEnv(EnvIdentityOpenIDKeyCloakRealm, target), kvs.Get(KeyCloakRealm))
 		return r.InitializeKeycloakProvider(adminURL, realm)
 	default:
 		return fmt.Errorf("Unsupport vendor %s", keyCloakVendor)
@@ -502,6 +506,19 @@ func Enabled(kvs config.KVS) bool {
 
 // LookupConfig lookup jwks from config, override with any ENVs.
 func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser), serverRegion string) (c Config, err error) {
+	return lookupConfig(config.Default, kvs, transport, closeRespFn, serverRegion)
+}
+
+// targetEnv returns the name of the environment variable overriding
+// a key of the given provider, named providers use a suffix.
+func targetEnv(name, target string) string {
+	if target == config.Default {
+		return name
+	}
+	return name + config.Default + target
+}
+
+func lookupConfig(target string, kvs config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser), serverRegion string) (c Config, err error) {
 	// remove this since we have removed this already.
 	kvs.Delete(JwksURL)
 
@@ -511,20 +528,21 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 
 	c = Config{
 		RWMutex:            &sync.RWMutex{},
-		ClaimName:          env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
-		ClaimUserinfo:      env.Get(EnvIdentityOpenIDClaimUserInfo, kvs.Get(ClaimUserinfo)) == config.EnableOn,
-		ClaimPrefix:        env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
-		RedirectURI:        env.Get(EnvIdentityOpenIDRedirectURI, kvs.Get(RedirectURI)),
-		RedirectURIDynamic: env.Get(EnvIdentityOpenIDRedirectURIDynamic, kvs.Get(RedirectURIDynamic)) == config.EnableOn,
+		Target:             target,
+		ClaimName:          env.Get(targetEnv(EnvIdentityOpenIDClaimName, target), kvs.Get(ClaimName)),
+		ClaimUserinfo:      env.Get(targetEnv(EnvIdentityOpenIDClaimUserInfo, target), kvs.Get(ClaimUserinfo)) == config.EnableOn,
+		ClaimPrefix:        env.Get(targetEnv(EnvIdentityOpenIDClaimPrefix, target), kvs.Get(ClaimPrefix)),
+		RedirectURI:        env.Get(targetEnv(EnvIdentityOpenIDRedirectURI, target), kvs.Get(RedirectURI)),
+		RedirectURIDynamic: env.Get(targetEnv(EnvIdentityOpenIDRedirectURIDynamic, target), kvs.Get(RedirectURIDynamic)) == config.EnableOn,
 		publicKeys:         make(map[string]crypto.PublicKey),
-		ClientID:           env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
-		ClientSecret:       env.Get(EnvIdentityOpenIDClientSecret, kvs.Get(ClientSecret)),
-		RolePolicy:         env.Get(EnvIdentityOpenIDRolePolicy, kvs.Get(RolePolicy)),
+		ClientID:           env.Get(targetEnv(EnvIdentityOpenIDClientID, target), kvs.Get(ClientID)),
+		ClientSecret:       env.Get(targetEnv(EnvIdentityOpenIDClientSecret, target), kvs.Get(ClientSecret)),
+		RolePolicy:         env.Get(targetEnv(EnvIdentityOpenIDRolePolicy, target), kvs.Get(RolePolicy)),
 		transport:          transport,
 		closeRespFn:        closeRespFn,
 	}
 
-	configURL := env.Get(EnvIdentityOpenIDURL, kvs.Get(ConfigURL))
+	configURL := env.Get(targetEnv(EnvIdentityOpenIDURL, target), kvs.Get(ConfigURL))
 	var configURLDomain string
 	if configURL != "" {
 		c.URL, err = xnet.ParseHTTPURL(configURL)
@@ -542,7 +560,7 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		return c, errors.New("please specify config_url to enable fetching claims from UserInfo endpoint")
 	}
 
-	if scopeList := env.Get(EnvIdentityOpenIDScopes, kvs.Get(Scopes)); scopeList != "" {
+	if scopeList := env.Get(targetEnv(EnvIdentityOpenIDScopes, target), kvs.Get(Scopes)); scopeList != "" {
 		var scopes []string
 		for _, scope := range strings.Split(scopeList, ",") {
 			scope = strings.TrimSpace(scope)
@@ -613,7 +631,7 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 		return c, err
 	}
 
-	if err = c.InitializeProvider(kvs); err != nil {
+	if err = c.InitializeProvider(target, kvs); err != nil {
 		return c, err
 	}
 
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/internal/config"
+)
+
+// Providers - holds all configured OpenID providers. Tokens are
+// routed to the provider whose issuer matches the `iss` claim,
+// tokens of other issuers are handled by the default provider.
+type Providers struct {
+	Default Config
+
+	// named providers indexed by the issuer of their tokens.
+	issuers map[string]*Config
+}
+
+// LookupConfigs looks up the default and all named OpenID providers
+// from the config, override with any ENVs. Named providers are
+// enabled by their config_url and must have distinct issuers. A
+// provider which fails to be looked up is left out, the first such
+// error is returned along with the remaining providers.
+func LookupConfigs(kvsMap map[string]config.KVS, transport *http.Transport, closeRespFn func(io.ReadCloser), serverRegion string) (p Providers, err error) {
+	p.issuers = make(map[string]*Config)
+
+	cfgKVS := config.Merge(kvsMap, EnvIdentityOpenIDURL, DefaultKVS)
+	targets := make([]string, 0, len(cfgKVS))
+	for target := range cfgKVS {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		cfg, lerr := lookupConfig(target, cfgKVS[target], transport, closeRespFn, serverRegion)
+		if lerr == nil && target != config.Default && cfg.Enabled {
+			lerr = p.addIssuer(&cfg)
+		}
+		if lerr != nil {
+			if err == nil {
+				err = fmt.Errorf("%s: %w", config.IdentityOpenIDSubSys+config.SubSystemSeparator+target, lerr)
+			}
+			continue
+		}
+		if target == config.Default {
+			p.Default = cfg
+		}
+	}
+
+	if p.Default.Enabled {
+		if other, ok := p.issuers[p.Default.DiscoveryDoc.Issuer]; ok {
+			delete(p.issuers, p.Default.DiscoveryDoc.Issuer)
+			if err == nil {
+				err = config.Errorf("OpenID providers '%s' and '%s' have the same issuer %s",
+					config.Default, other.Target, p.Default.DiscoveryDoc.Issuer)
+			}
+		}
+	}
+
+	return p, err
+}
+
+func (p *Providers) addIssuer(cfg *Config) error {
+	issuer := cfg.DiscoveryDoc.Issuer
+	if issuer == "" {
+		return config.Errorf("the discovery document of the OpenID provider '%s' has no issuer", cfg.Target)
+	}
+	if other, ok := p.issuers[issuer]; ok {
+		return config.Errorf("OpenID providers '%s' and '%s' have the same issuer %s", other.Target, cfg.Target, issuer)
+	}
+	p.issuers[issuer] = cfg
+	return nil
+}
+
+// Enabled returns true if any OpenID provider is enabled.
+func (p Providers) Enabled() bool {
+	return p.Default.Enabled || len(p.issuers) > 0
+}
+
+// ProviderEnabled returns true if any vendor specific provider is
+// enabled for any of the OpenID providers.
+func (p Providers) ProviderEnabled() bool {
+	for _, cfg := range p.List() {
+		if cfg.ProviderEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns all enabled OpenID providers, the default first and
+// the named providers sorted by their name.
+func (p Providers) List() []*Config {
+	var cfgs []*Config
+	for _, cfg := range p.issuers {
+		cfgs = append(cfgs, cfg)
+	}
+	sort.Slice(cfgs, func(i, j int) bool {
+		return cfgs[i].Target < cfgs[j].Target
+	})
+	if p.Default.Enabled {
+		cfgs = append([]*Config{&p.Default}, cfgs...)
+	}
+	return cfgs
+}
+
+// ForIssuer returns the OpenID provider of the given issuer, the
+// default provider if no named provider has this issuer.
+func (p Providers) ForIssuer(issuer string) *Config {
+	if cfg, ok := p.issuers[issuer]; ok {
+		return cfg
+	}
+	return &p.Default
+}
+
+// ForToken returns the OpenID provider which issued the given token,
+// the token is not verified.
+func (p Providers) ForToken(token string) (*Config, error) {
+	var claims jwtgo.MapClaims
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, &claims); err != nil {
+		return nil, err
+	}
+	issuer, _ := claims["iss"].(string)
+	cfg := p.ForIssuer(issuer)
+	if !cfg.Enabled {
+		return nil, fmt.Errorf("no OpenID provider is configured for the issuer %s", issuer)
+	}
+	return cfg, nil
+}
+
+// Validate - validates the id_token with the provider of its issuer.
+func (p Providers) Validate(token, accessToken, dsecs string) (map[string]interface{}, error) {
+	cfg, err := p.ForToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Validate(token, accessToken, dsecs)
+}
+
+// ID returns the provider name and authentication type.
+func (Providers) ID() ID {
+	return "jwt"
+}
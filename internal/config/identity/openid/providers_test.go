@@ -0,0 +1,151 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jwtg "github.com/golang-jwt/jwt/v4"
+	"github.com/minio/minio/internal/config"
+)
+
+const testJWKS = `{"keys":
+  [
+    {"kty":"RSA",
+     "n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+     "e":"AQAB",
+     "alg":"RS256",
+     "kid":"2011-04-29"}
+  ]
+}`
+
+// newTestIssuer serves the discovery document and the keys of an
+// OpenID provider for each issuer under /<issuer>/.
+func newTestIssuer() *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/jwks"):
+			io.WriteString(w, testJWKS)
+		default:
+			json.NewEncoder(w).Encode(DiscoveryDoc{
+				Issuer:  "https://" + issuer,
+				JwksURI: srv.URL + "/" + issuer + "/jwks",
+			})
+		}
+	}))
+	return srv
+}
+
+func testProviderKVS(url, clientID, claimName string) config.KVS {
+	kvs := config.KVS{}
+	for _, kv := range DefaultKVS {
+		kvs.Set(kv.Key, kv.Value)
+	}
+	kvs.Set(ConfigURL, url)
+	kvs.Set(ClientID, clientID)
+	kvs.Set(ClaimName, claimName)
+	return kvs
+}
+
+func testToken(t *testing.T, issuer string) string {
+	token, err := jwtg.NewWithClaims(jwtg.SigningMethodHS256, jwtg.MapClaims{
+		"iss": issuer,
+		"sub": "user",
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestLookupConfigs(t *testing.T) {
+	srv := newTestIssuer()
+	defer srv.Close()
+
+	transport := http.DefaultTransport.(*http.Transport)
+	closeRespFn := func(rc io.ReadCloser) { rc.Close() }
+
+	kvsMap := map[string]config.KVS{
+		config.Default: testProviderKVS(srv.URL+"/default/.well-known/openid-configuration", "default-client", "policy"),
+		"partner":      testProviderKVS(srv.URL+"/partner/.well-known/openid-configuration", "partner-client", "partner_policy"),
+		"disabled":     testProviderKVS("", "", "policy"),
+	}
+	p, err := LookupConfigs(kvsMap, transport, closeRespFn, "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Enabled() {
+		t.Fatal("Expected the OpenID providers to be enabled")
+	}
+
+	cfgs := p.List()
+	if len(cfgs) != 2 || cfgs[0].Target != config.Default || cfgs[1].Target != "partner" {
+		t.Fatalf("Unexpected providers %v", cfgs)
+	}
+
+	testCases := []struct {
+		issuer   string
+		clientID string
+	}{
+		{"https://partner", "partner-client"},
+		{"https://default", "default-client"},
+		// Tokens of unknown issuers are handled by the default provider.
+		{"https://unknown", "default-client"},
+	}
+	for _, testCase := range testCases {
+		cfg, err := p.ForToken(testToken(t, testCase.issuer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.ClientID != testCase.clientID {
+			t.Errorf("Expected client ID %s for issuer %s, got %s", testCase.clientID, testCase.issuer, cfg.ClientID)
+		}
+		if cfg = p.ForIssuer(testCase.issuer); cfg.ClientID != testCase.clientID {
+			t.Errorf("Expected client ID %s for issuer %s, got %s", testCase.clientID, testCase.issuer, cfg.ClientID)
+		}
+	}
+	if claimName := p.ForIssuer("https://partner").ClaimName; claimName != "partner_policy" {
+		t.Errorf("Expected the claim name of the partner provider, got %s", claimName)
+	}
+
+	// Without a default provider tokens of unknown issuers are rejected.
+	delete(kvsMap, config.Default)
+	p, err = LookupConfigs(kvsMap, transport, closeRespFn, "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.ForToken(testToken(t, "https://unknown")); err == nil {
+		t.Fatal("Expected a token of an unknown issuer to be rejected")
+	}
+	if _, err = p.ForToken(testToken(t, "https://partner")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Providers of the same issuer are ambiguous.
+	kvsMap["other"] = testProviderKVS(srv.URL+"/partner/.well-known/openid-configuration", "other-client", "policy")
+	if _, err = LookupConfigs(kvsMap, transport, closeRespFn, "us-east-1"); err == nil {
+		t.Fatal("Expected providers with the same issuer to be rejected")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lambda
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Lambda webhook constants
+const (
+	Endpoint  = "endpoint"
+	AuthToken = "auth_token"
+	Prefix    = "prefix"
+
+	EnvLambdaWebhookEnable    = "MINIO_LAMBDA_WEBHOOK_ENABLE"
+	EnvLambdaWebhookEndpoint  = "MINIO_LAMBDA_WEBHOOK_ENDPOINT"
+	EnvLambdaWebhookAuthToken = "MINIO_LAMBDA_WEBHOOK_AUTH_TOKEN"
+	EnvLambdaWebhookPrefix    = "MINIO_LAMBDA_WEBHOOK_PREFIX"
+)
+
+// DefaultKVS - default config for lambda webhook targets
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   Prefix,
+			Value: "",
+		},
+	}
+)
+
+var errMissingPrefix = errors.New("lambda webhook: prefix must be of the form 'bucket' or 'bucket/prefix'")
+
+// Target - a webhook transforming the objects read with GET
+// under a bucket prefix.
+type Target struct {
+	Name      string    `json:"name"`
+	Endpoint  *xnet.URL `json:"endpoint"`
+	AuthToken string    `json:"-"`
+	Bucket    string    `json:"bucket"`
+	Prefix    string    `json:"prefix"`
+}
+
+// Matches returns whether object in bucket is transformed by
+// the target.
+func (t Target) Matches(bucket, object string) bool {
+	return t.Bucket == bucket && strings.HasPrefix(object, t.Prefix)
+}
+
+func newTarget(name, endpoint, authToken, prefix string) (Target, error) {
+	u, err := xnet.ParseHTTPURL(endpoint)
+	if err != nil {
+		return Target{}, err
+	}
+	bucket, objPrefix := prefix, ""
+	if i := strings.Index(prefix, "/"); i >= 0 {
+		bucket, objPrefix = prefix[:i], prefix[i+1:]
+	}
+	if bucket == "" {
+		return Target{}, errMissingPrefix
+	}
+	return Target{
+		Name:      name,
+		Endpoint:  u,
+		AuthToken: authToken,
+		Bucket:    bucket,
+		Prefix:    objPrefix,
+	}, nil
+}
+
+// LookupConfig - lookup lambda webhook targets, override with ENVs if set.
+func LookupConfig(scfg config.Config) (map[string]Target, error) {
+	targets := make(map[string]Target)
+
+	for _, k := range env.List(EnvLambdaWebhookEndpoint) {
+		target := strings.TrimPrefix(k, EnvLambdaWebhookEndpoint+config.Default)
+		if target == EnvLambdaWebhookEndpoint {
+			target = config.Default
+		}
+		suffix := ""
+		if target != config.Default {
+			suffix = config.Default + target
+		}
+		enable, err := config.ParseBool(env.Get(EnvLambdaWebhookEnable+suffix, ""))
+		if err != nil || !enable {
+			continue
+		}
+		t, err := newTarget(target, env.Get(EnvLambdaWebhookEndpoint+suffix, ""),
+			env.Get(EnvLambdaWebhookAuthToken+suffix, ""),
+			env.Get(EnvLambdaWebhookPrefix+suffix, ""))
+		if err != nil {
+			return nil, err
+		}
+		targets[target] = t
+	}
+
+	for starget, kv := range scfg[config.LambdaWebhookSubSys] {
+		if _, ok := targets[starget]; ok {
+			// Ignore this target since another target with the
+			// same name is already loaded from the environment.
+			continue
+		}
+		subSysTarget := config.LambdaWebhookSubSys
+		if starget != config.Default {
+			subSysTarget = config.LambdaWebhookSubSys + config.SubSystemSeparator + starget
+		}
+		if err := config.CheckValidKeys(subSysTarget, kv, DefaultKVS); err != nil {
+			return nil, err
+		}
+		enabled, err := config.ParseBool(kv.Get(config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+		t, err := newTarget(starget, kv.Get(Endpoint), kv.Get(AuthToken), kv.Get(Prefix))
+		if err != nil {
+			return nil, err
+		}
+		targets[starget] = t
+	}
+
+	return targets, nil
+}
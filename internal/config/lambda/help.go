@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lambda
+
+import "github.com/minio/minio/internal/config"
+
+// Help template for lambda webhook targets.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `HTTP(s) endpoint transforming objects e.g. "http://localhost:8080/transform"`,
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         Prefix,
+			Description: `bucket and optional object prefix of the objects to transform e.g. "mybucket/photos/"`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: `opaque string or JWT authorization token`,
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
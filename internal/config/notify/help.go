@@ -76,6 +76,77 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         target.WebhookHMACSecret,
+			Description: "secret to sign requests with HMAC-SHA256 in the 'X-Minio-Signature' header",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.WebhookHMACTolerance,
+			Description: "validity of a signed request, advertised in the 'X-Minio-Signature-Expires' header, defaults to '5m'",
+			Optional:    true,
+			Type:        "duration",
+		},
+	}
+
+	HelpSQS = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.SQSQueueURL,
+			Description: "SQS queue URL e.g. 'https://sqs.us-east-1.amazonaws.com/123456789012/events.fifo'",
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.SQSRegion,
+			Description: "SQS queue region e.g. 'us-east-1'",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSAccessKey,
+			Description: "access key used to sign requests to SQS",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.SQSSecretKey,
+			Description: "secret key used to sign requests to SQS",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.SQSSessionToken,
+			Description: "session token for temporary credentials",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.SQSMessageGroupDepth,
+			Description: "number of object key path components used to group FIFO messages, defaults to '0' i.e per object ordering",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
 	}
 
 	HelpAMQP = config.HelpKVS{
@@ -194,7 +265,7 @@ var (
 		},
 		config.HelpKV{
 			Key:         target.KafkaSASLMechanism,
-			Description: "sasl authentication mechanism, default 'plain'",
+			Description: "sasl authentication mechanism, one of 'plain', 'sha256', 'sha512' or 'oauthbearer', default 'plain'",
 			Optional:    true,
 			Type:        "string",
 		},
@@ -254,6 +325,58 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         target.KafkaSASLOAuthTokenURL,
+			Description: "OAuth2 token endpoint for SASL/OAUTHBEARER authentication",
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLOAuthClientID,
+			Description: "OAuth2 client ID for SASL/OAUTHBEARER authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLOAuthClientSecret,
+			Description: "OAuth2 client secret for SASL/OAUTHBEARER authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.KafkaSASLOAuthScopes,
+			Description: "comma separated list of OAuth2 scopes to request for SASL/OAUTHBEARER authentication",
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         target.KafkaEncoding,
+			Description: "message encoding, 'json' or 'avro', default 'json'",
+			Optional:    true,
+			Type:        "json|avro",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSchemaRegistryURL,
+			Description: "Confluent schema registry endpoint the Avro event schema is registered with",
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSchemaRegistryUsername,
+			Description: "username for schema registry basic authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.KafkaSchemaRegistryPassword,
+			Description: "password for schema registry basic authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -573,6 +696,71 @@ var (
 		},
 	}
 
+	HelpPulsar = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.PulsarURL,
+			Description: "Pulsar web service URL e.g. 'http://localhost:8080'",
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTopic,
+			Description: "Pulsar topic e.g. 'persistent://public/default/minio'",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PulsarTopicPerBucket,
+			Description: "set to 'on' to publish events of each bucket to the topic '<topic>-<bucket>'",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PulsarAuthToken,
+			Description: "JWT token for Pulsar token authentication",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarTLSSkipVerify,
+			Description: `trust server TLS without verification, defaults to "on" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientTLSCert,
+			Description: "path to client certificate for Pulsar TLS authentication",
+			Optional:    true,
+			Type:        "path",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarClientTLSKey,
+			Description: "path to client key for Pulsar TLS authentication",
+			Optional:    true,
+			Type:        "path",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PulsarQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
 	HelpES = config.HelpKVS{
 		config.HelpKV{
 			Key:         target.ElasticURL,
@@ -616,6 +804,43 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         target.ElasticAPIKey,
+			Description: "base64 encoded Elasticsearch API key, instead of basic-auth",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.ElasticCACert,
+			Description: "path to the CA certificate of the Elasticsearch HTTP layer e.g. 'http_ca.crt' of ES 8.x",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.ElasticBatchSize,
+			Description: "number of events sent in a single bulk request, defaults to '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.ElasticFlushInterval,
+			Description: "maximum time events wait for a batch to fill up, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.ElasticIndexRotation,
+			Description: "rotate the index 'daily' or by 'size' in access format",
+			Optional:    true,
+			Type:        "daily|size",
+		},
+		config.HelpKV{
+			Key:         target.ElasticIndexMaxSize,
+			Description: "size at which the index is rotated with 'size' rotation e.g. '50GiB'",
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -27,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/event/target"
@@ -153,11 +154,21 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		return nil, err
 	}
 
+	pulsarTargets, err := GetNotifyPulsar(cfg[config.NotifyPulsarSubSys])
+	if err != nil {
+		return nil, err
+	}
+
 	redisTargets, err := GetNotifyRedis(cfg[config.NotifyRedisSubSys])
 	if err != nil {
 		return nil, err
 	}
 
+	sqsTargets, err := GetNotifySQS(cfg[config.NotifySQSSubSys], transport)
+	if err != nil {
+		return nil, err
+	}
+
 	webhookTargets, err := GetNotifyWebhook(cfg[config.NotifyWebhookSubSys], transport)
 	if err != nil {
 		return nil, err
@@ -209,6 +220,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 			continue
 		}
 		args.TLS.RootCAs = transport.TLSClientConfig.RootCAs
+		args.Transport = transport
 		newTarget, err := target.NewKafkaTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
 		if err != nil {
 			targetsOffline = true
@@ -326,6 +338,27 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range pulsarTargets {
+		if !args.Enable {
+			continue
+		}
+		args.TLS.RootCAs = transport.TLSClientConfig.RootCAs
+		newTarget, err := target.NewPulsarTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	for id, args := range redisTargets {
 		if !args.Enable {
 			continue
@@ -346,6 +379,26 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range sqsTargets {
+		if !args.Enable {
+			continue
+		}
+		newTarget, err := target.NewSQSTarget(ctx, id, args, logger.LogOnceIf, transport, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	for id, args := range webhookTargets {
 		if !args.Enable {
 			continue
@@ -383,7 +436,9 @@ var (
 		config.NotifyNATSSubSys:     DefaultNATSKVS,
 		config.NotifyNSQSubSys:      DefaultNSQKVS,
 		config.NotifyPostgresSubSys: DefaultPostgresKVS,
+		config.NotifyPulsarSubSys:   DefaultPulsarKVS,
 		config.NotifyRedisSubSys:    DefaultRedisKVS,
+		config.NotifySQSSubSys:      DefaultSQSKVS,
 		config.NotifyWebhookSubSys:  DefaultWebhookKVS,
 		config.NotifyESSubSys:       DefaultESKVS,
 	}
@@ -473,6 +528,38 @@ var (
 			Key:   target.KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.KafkaSASLOAuthTokenURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLOAuthClientID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLOAuthClientSecret,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSASLOAuthScopes,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaEncoding,
+			Value: target.KafkaEncodingJSON,
+		},
+		config.KV{
+			Key:   target.KafkaSchemaRegistryURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSchemaRegistryUsername,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaSchemaRegistryPassword,
+			Value: "",
+		},
 	}
 )
 
@@ -601,6 +688,60 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 		kafkaArgs.SASL.Password = env.Get(saslPasswordEnv, kv.Get(target.KafkaSASLPassword))
 		kafkaArgs.SASL.Mechanism = env.Get(saslMechanismEnv, kv.Get(target.KafkaSASLMechanism))
 
+		saslOAuthTokenURLEnv := target.EnvKafkaSASLOAuthTokenURL
+		if k != config.Default {
+			saslOAuthTokenURLEnv = saslOAuthTokenURLEnv + config.Default + k
+		}
+		saslOAuthClientIDEnv := target.EnvKafkaSASLOAuthClientID
+		if k != config.Default {
+			saslOAuthClientIDEnv = saslOAuthClientIDEnv + config.Default + k
+		}
+		saslOAuthClientSecretEnv := target.EnvKafkaSASLOAuthClientSecret
+		if k != config.Default {
+			saslOAuthClientSecretEnv = saslOAuthClientSecretEnv + config.Default + k
+		}
+		saslOAuthScopesEnv := target.EnvKafkaSASLOAuthScopes
+		if k != config.Default {
+			saslOAuthScopesEnv = saslOAuthScopesEnv + config.Default + k
+		}
+		if tokenURL := env.Get(saslOAuthTokenURLEnv, kv.Get(target.KafkaSASLOAuthTokenURL)); tokenURL != "" {
+			kafkaArgs.SASL.OAuth.TokenURL, err = xnet.ParseHTTPURL(tokenURL)
+			if err != nil {
+				return nil, err
+			}
+		}
+		kafkaArgs.SASL.OAuth.ClientID = env.Get(saslOAuthClientIDEnv, kv.Get(target.KafkaSASLOAuthClientID))
+		kafkaArgs.SASL.OAuth.ClientSecret = env.Get(saslOAuthClientSecretEnv, kv.Get(target.KafkaSASLOAuthClientSecret))
+		if scopes := env.Get(saslOAuthScopesEnv, kv.Get(target.KafkaSASLOAuthScopes)); scopes != "" {
+			kafkaArgs.SASL.OAuth.Scopes = strings.Split(scopes, config.ValueSeparator)
+		}
+
+		encodingEnv := target.EnvKafkaEncoding
+		if k != config.Default {
+			encodingEnv = encodingEnv + config.Default + k
+		}
+		schemaRegistryURLEnv := target.EnvKafkaSchemaRegistryURL
+		if k != config.Default {
+			schemaRegistryURLEnv = schemaRegistryURLEnv + config.Default + k
+		}
+		schemaRegistryUsernameEnv := target.EnvKafkaSchemaRegistryUsername
+		if k != config.Default {
+			schemaRegistryUsernameEnv = schemaRegistryUsernameEnv + config.Default + k
+		}
+		schemaRegistryPasswordEnv := target.EnvKafkaSchemaRegistryPassword
+		if k != config.Default {
+			schemaRegistryPasswordEnv = schemaRegistryPasswordEnv + config.Default + k
+		}
+		kafkaArgs.Encoding = env.Get(encodingEnv, kv.Get(target.KafkaEncoding))
+		if registryURL := env.Get(schemaRegistryURLEnv, kv.Get(target.KafkaSchemaRegistryURL)); registryURL != "" {
+			kafkaArgs.SchemaRegistry.URL, err = xnet.ParseHTTPURL(registryURL)
+			if err != nil {
+				return nil, err
+			}
+		}
+		kafkaArgs.SchemaRegistry.Username = env.Get(schemaRegistryUsernameEnv, kv.Get(target.KafkaSchemaRegistryUsername))
+		kafkaArgs.SchemaRegistry.Password = env.Get(schemaRegistryPasswordEnv, kv.Get(target.KafkaSchemaRegistryPassword))
+
 		if err = kafkaArgs.Validate(); err != nil {
 			return nil, err
 		}
@@ -1450,6 +1591,14 @@ var (
 			Key:   target.WebhookClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.WebhookHMACSecret,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.WebhookHMACTolerance,
+			Value: target.DefaultWebhookHMACTolerance.String(),
+		},
 	}
 )
 
@@ -1513,6 +1662,21 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 			ClientCert: env.Get(clientCertEnv, kv.Get(target.WebhookClientCert)),
 			ClientKey:  env.Get(clientKeyEnv, kv.Get(target.WebhookClientKey)),
 		}
+
+		hmacSecretEnv := target.EnvWebhookHMACSecret
+		if k != config.Default {
+			hmacSecretEnv = hmacSecretEnv + config.Default + k
+		}
+		hmacToleranceEnv := target.EnvWebhookHMACTolerance
+		if k != config.Default {
+			hmacToleranceEnv = hmacToleranceEnv + config.Default + k
+		}
+		webhookArgs.HMAC.Secret = env.Get(hmacSecretEnv, kv.Get(target.WebhookHMACSecret))
+		webhookArgs.HMAC.Tolerance, err = time.ParseDuration(env.Get(hmacToleranceEnv,
+			kv.Get(target.WebhookHMACTolerance)))
+		if err != nil {
+			return nil, err
+		}
 		if err = webhookArgs.Validate(); err != nil {
 			return nil, err
 		}
@@ -1521,6 +1685,259 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 	return webhookTargets, nil
 }
 
+// DefaultPulsarKVS - default KV for Pulsar config
+var (
+	DefaultPulsarKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTopicPerBucket,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarAuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PulsarClientTLSCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarClientTLSKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PulsarQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.PulsarQueueDir,
+			Value: "",
+		},
+	}
+)
+
+// GetNotifyPulsar - returns a map of registered notification 'pulsar' targets
+func GetNotifyPulsar(pulsarKVS map[string]config.KVS) (map[string]target.PulsarArgs, error) {
+	pulsarTargets := make(map[string]target.PulsarArgs)
+	for k, kv := range config.Merge(pulsarKVS, target.EnvPulsarEnable, DefaultPulsarKVS) {
+		enableEnv := target.EnvPulsarEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+		urlEnv := target.EnvPulsarURL
+		if k != config.Default {
+			urlEnv = urlEnv + config.Default + k
+		}
+		url, err := xnet.ParseHTTPURL(env.Get(urlEnv, kv.Get(target.PulsarURL)))
+		if err != nil {
+			return nil, err
+		}
+		topicEnv := target.EnvPulsarTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+		topicPerBucketEnv := target.EnvPulsarTopicPerBucket
+		if k != config.Default {
+			topicPerBucketEnv = topicPerBucketEnv + config.Default + k
+		}
+		topicPerBucket, err := config.ParseBool(env.Get(topicPerBucketEnv, kv.Get(target.PulsarTopicPerBucket)))
+		if err != nil {
+			return nil, err
+		}
+		authTokenEnv := target.EnvPulsarAuthToken
+		if k != config.Default {
+			authTokenEnv = authTokenEnv + config.Default + k
+		}
+		tlsSkipVerifyEnv := target.EnvPulsarTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+		clientTLSCertEnv := target.EnvPulsarClientTLSCert
+		if k != config.Default {
+			clientTLSCertEnv = clientTLSCertEnv + config.Default + k
+		}
+		clientTLSKeyEnv := target.EnvPulsarClientTLSKey
+		if k != config.Default {
+			clientTLSKeyEnv = clientTLSKeyEnv + config.Default + k
+		}
+		queueDirEnv := target.EnvPulsarQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+		queueLimitEnv := target.EnvPulsarQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.PulsarQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		pulsarArgs := target.PulsarArgs{
+			Enable:         enabled,
+			URL:            *url,
+			Topic:          env.Get(topicEnv, kv.Get(target.PulsarTopic)),
+			TopicPerBucket: topicPerBucket,
+			AuthToken:      env.Get(authTokenEnv, kv.Get(target.PulsarAuthToken)),
+			QueueDir:       env.Get(queueDirEnv, kv.Get(target.PulsarQueueDir)),
+			QueueLimit:     queueLimit,
+		}
+		pulsarArgs.TLS.SkipVerify = env.Get(tlsSkipVerifyEnv, kv.Get(target.PulsarTLSSkipVerify)) == config.EnableOn
+		pulsarArgs.TLS.ClientTLSCert = env.Get(clientTLSCertEnv, kv.Get(target.PulsarClientTLSCert))
+		pulsarArgs.TLS.ClientTLSKey = env.Get(clientTLSKeyEnv, kv.Get(target.PulsarClientTLSKey))
+
+		if err = pulsarArgs.Validate(); err != nil {
+			return nil, err
+		}
+		pulsarTargets[k] = pulsarArgs
+	}
+	return pulsarTargets, nil
+}
+
+// DefaultSQSKVS - default KV for SQS config
+var (
+	DefaultSQSKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.SQSQueueURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSRegion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSAccessKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSecretKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSessionToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSMessageGroupDepth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.SQSQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.SQSQueueDir,
+			Value: "",
+		},
+	}
+)
+
+// GetNotifySQS - returns a map of registered notification 'sqs' targets
+func GetNotifySQS(sqsKVS map[string]config.KVS, transport *http.Transport) (map[string]target.SQSArgs, error) {
+	sqsTargets := make(map[string]target.SQSArgs)
+	for k, kv := range config.Merge(sqsKVS, target.EnvSQSEnable, DefaultSQSKVS) {
+		enableEnv := target.EnvSQSEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+		urlEnv := target.EnvSQSQueueURL
+		if k != config.Default {
+			urlEnv = urlEnv + config.Default + k
+		}
+		url, err := xnet.ParseHTTPURL(env.Get(urlEnv, kv.Get(target.SQSQueueURL)))
+		if err != nil {
+			return nil, err
+		}
+		groupDepthEnv := target.EnvSQSMessageGroupDepth
+		if k != config.Default {
+			groupDepthEnv = groupDepthEnv + config.Default + k
+		}
+		groupDepth, err := strconv.Atoi(env.Get(groupDepthEnv, kv.Get(target.SQSMessageGroupDepth)))
+		if err != nil {
+			return nil, err
+		}
+		queueLimitEnv := target.EnvSQSQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.SQSQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		regionEnv := target.EnvSQSRegion
+		if k != config.Default {
+			regionEnv = regionEnv + config.Default + k
+		}
+		accessKeyEnv := target.EnvSQSAccessKey
+		if k != config.Default {
+			accessKeyEnv = accessKeyEnv + config.Default + k
+		}
+		secretKeyEnv := target.EnvSQSSecretKey
+		if k != config.Default {
+			secretKeyEnv = secretKeyEnv + config.Default + k
+		}
+		sessionTokenEnv := target.EnvSQSSessionToken
+		if k != config.Default {
+			sessionTokenEnv = sessionTokenEnv + config.Default + k
+		}
+		queueDirEnv := target.EnvSQSQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		sqsArgs := target.SQSArgs{
+			Enable:            enabled,
+			QueueURL:          *url,
+			Region:            env.Get(regionEnv, kv.Get(target.SQSRegion)),
+			AccessKey:         env.Get(accessKeyEnv, kv.Get(target.SQSAccessKey)),
+			SecretKey:         env.Get(secretKeyEnv, kv.Get(target.SQSSecretKey)),
+			SessionToken:      env.Get(sessionTokenEnv, kv.Get(target.SQSSessionToken)),
+			Transport:         transport,
+			MessageGroupDepth: groupDepth,
+			QueueDir:          env.Get(queueDirEnv, kv.Get(target.SQSQueueDir)),
+			QueueLimit:        queueLimit,
+		}
+		if err = sqsArgs.Validate(); err != nil {
+			return nil, err
+		}
+		sqsTargets[k] = sqsArgs
+	}
+	return sqsTargets, nil
+}
+
 // DefaultESKVS - default KV config for Elasticsearch target
 var (
 	DefaultESKVS = config.KVS{
@@ -1556,6 +1973,30 @@ var (
 			Key:   target.ElasticPassword,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.ElasticAPIKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticCACert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticBatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   target.ElasticFlushInterval,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   target.ElasticIndexRotation,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticIndexMaxSize,
+			Value: "",
+		},
 	}
 )
 
@@ -1631,6 +2072,52 @@ func GetNotifyES(esKVS map[string]config.KVS, transport *http.Transport) (map[st
 			Username:   env.Get(usernameEnv, kv.Get(target.ElasticUsername)),
 			Password:   env.Get(passwordEnv, kv.Get(target.ElasticPassword)),
 		}
+
+		apiKeyEnv := target.EnvElasticAPIKey
+		if k != config.Default {
+			apiKeyEnv = apiKeyEnv + config.Default + k
+		}
+		caCertEnv := target.EnvElasticCACert
+		if k != config.Default {
+			caCertEnv = caCertEnv + config.Default + k
+		}
+		esArgs.APIKey = env.Get(apiKeyEnv, kv.Get(target.ElasticAPIKey))
+		esArgs.CACert = env.Get(caCertEnv, kv.Get(target.ElasticCACert))
+
+		batchSizeEnv := target.EnvElasticBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+		esArgs.BatchSize, err = strconv.Atoi(env.Get(batchSizeEnv, kv.Get(target.ElasticBatchSize)))
+		if err != nil {
+			return nil, err
+		}
+
+		flushIntervalEnv := target.EnvElasticFlushInterval
+		if k != config.Default {
+			flushIntervalEnv = flushIntervalEnv + config.Default + k
+		}
+		esArgs.FlushInterval, err = time.ParseDuration(env.Get(flushIntervalEnv, kv.Get(target.ElasticFlushInterval)))
+		if err != nil {
+			return nil, err
+		}
+
+		indexRotationEnv := target.EnvElasticIndexRotation
+		if k != config.Default {
+			indexRotationEnv = indexRotationEnv + config.Default + k
+		}
+		esArgs.IndexRotation = env.Get(indexRotationEnv, kv.Get(target.ElasticIndexRotation))
+
+		indexMaxSizeEnv := target.EnvElasticIndexMaxSize
+		if k != config.Default {
+			indexMaxSizeEnv = indexMaxSizeEnv + config.Default + k
+		}
+		if maxSize := env.Get(indexMaxSizeEnv, kv.Get(target.ElasticIndexMaxSize)); maxSize != "" {
+			esArgs.IndexMaxSize, err = humanize.ParseBytes(maxSize)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if err = esArgs.Validate(); err != nil {
 			return nil, err
 		}
@@ -18,6 +18,7 @@
 package scanner
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -31,11 +32,14 @@ const (
 	MaxWait = "max_wait"
 	Cycle   = "cycle"
 
+	PrefixDepth = "prefix_depth"
+
 	EnvDelay         = "MINIO_SCANNER_DELAY"
 	EnvCycle         = "MINIO_SCANNER_CYCLE"
 	EnvDelayLegacy   = "MINIO_CRAWLER_DELAY"
 	EnvMaxWait       = "MINIO_SCANNER_MAX_WAIT"
 	EnvMaxWaitLegacy = "MINIO_CRAWLER_MAX_WAIT"
+	EnvPrefixDepth   = "MINIO_SCANNER_PREFIX_DEPTH"
 )
 
 // Config represents the heal settings.
@@ -46,6 +50,9 @@ type Config struct {
 	MaxWait time.Duration
 	// Cycle is the time.Duration between each scanner cycles
 	Cycle time.Duration
+	// PrefixDepth is the number of prefix levels below a bucket
+	// for which usage is always kept separately.
+	PrefixDepth int
 }
 
 var (
@@ -63,6 +70,10 @@ var (
 			Key:   Cycle,
 			Value: "1m",
 		},
+		config.KV{
+			Key:   PrefixDepth,
+			Value: "0",
+		},
 	}
 
 	// Help provides help for config values
@@ -85,6 +96,12 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         PrefixDepth,
+			Description: `number of prefix levels below a bucket to always keep usage for, defaults to '0'`,
+			Optional:    true,
+			Type:        "number",
+		},
 	}
 )
 
@@ -114,5 +131,13 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, err
 	}
+
+	cfg.PrefixDepth, err = strconv.Atoi(env.Get(EnvPrefixDepth, kvs.GetWithDefault(PrefixDepth, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scanner:prefix_depth' value invalid: %w", err)
+	}
+	if cfg.PrefixDepth < 0 {
+		return cfg, fmt.Errorf("'scanner:prefix_depth' value invalid: must not be negative")
+	}
 	return cfg, nil
 }
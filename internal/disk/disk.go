@@ -17,6 +17,8 @@
 
 package disk
 
+import "errors"
+
 // Info stat fs struct is container which holds following values
 // Total - total size of the volume / disk
 // Free - free size of the volume / disk
@@ -31,3 +33,17 @@ type Info struct {
 	Ffree  uint64
 	FSType string
 }
+
+// DriveInfo holds the characteristics of the block device of a drive.
+type DriveInfo struct {
+	// Name of the block device, e.g. nvme0n1 or sda.
+	Name       string
+	Rotational bool
+	// Smallest and optimal units of I/O of the device.
+	LogicalBlockSize  uint64
+	PhysicalBlockSize uint64
+}
+
+// ErrDriveInfoUnsupported is returned when the characteristics of a drive
+// cannot be detected, e.g. on a filesystem without block device.
+var ErrDriveInfoUnsupported = errors.New("drive info not supported")
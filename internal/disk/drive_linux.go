@@ -0,0 +1,79 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package disk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetDriveInfo returns the characteristics of the block device holding
+// path, read from sysfs.
+func GetDriveInfo(path string) (info DriveInfo, err error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return info, err
+	}
+	stat, ok := st.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info, ErrDriveInfoUnsupported
+	}
+	//nolint:unconvert
+	dev := uint64(stat.Dev)
+	devPath, err := filepath.EvalSymlinks(fmt.Sprintf("/sys/dev/block/%d:%d", unix.Major(dev), unix.Minor(dev)))
+	if err != nil {
+		// Not a block device, e.g. tmpfs or a network filesystem.
+		return info, ErrDriveInfoUnsupported
+	}
+	queuePath := filepath.Join(devPath, "queue")
+	if _, err = os.Stat(queuePath); err != nil {
+		// Partitions share the queue of their device.
+		queuePath = filepath.Join(filepath.Dir(devPath), "queue")
+	}
+
+	readUint := func(name string) (uint64, error) {
+		buf, err := ioutil.ReadFile(filepath.Join(queuePath, name))
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(strings.TrimSpace(string(buf)), 10, 64)
+	}
+	rotational, err := readUint("rotational")
+	if err != nil {
+		return info, err
+	}
+	if info.LogicalBlockSize, err = readUint("logical_block_size"); err != nil {
+		return info, err
+	}
+	if info.PhysicalBlockSize, err = readUint("physical_block_size"); err != nil {
+		return info, err
+	}
+	info.Name = filepath.Base(devPath)
+	info.Rotational = rotational == 1
+	return info, nil
+}
@@ -137,20 +137,89 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
-// S3Key - represents elements inside <S3Key>...</S3Key>
+// FilterKeyValue - represents elements inside <Tag>...</Tag> and
+// <Metadata>...</Metadata> of a filter. Value is a wildcard pattern.
+type FilterKeyValue struct {
+	Key   string `xml:"Key" json:"Key"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// S3Key - represents elements inside <Filter>...</Filter>. Tag and
+// Metadata filters are MinIO extensions, an object matches when all
+// of them match.
 type S3Key struct {
-	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+	RuleList FilterRuleList   `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+	Tags     []FilterKeyValue `xml:"Tag,omitempty" json:"Tag,omitempty"`
+	Metadata []FilterKeyValue `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
 }
 
 // MarshalXML implements a custom marshaller to support `omitempty` feature.
 func (s3Key S3Key) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if s3Key.RuleList.isEmpty() {
+	if s3Key.RuleList.isEmpty() && len(s3Key.Tags) == 0 && len(s3Key.Metadata) == 0 {
 		return nil
 	}
 	type s3KeyWrapper S3Key
 	return e.EncodeElement(s3KeyWrapper(s3Key), start)
 }
 
+// UnmarshalXML - decodes XML data.
+func (s3Key *S3Key) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type s3KeyWrapper S3Key
+	key := s3KeyWrapper{}
+	if err := d.DecodeElement(&key, &start); err != nil {
+		return err
+	}
+
+	if err := validateFilterKeyValues("Tag", key.Tags, false); err != nil {
+		return err
+	}
+	if err := validateFilterKeyValues("Metadata", key.Metadata, true); err != nil {
+		return err
+	}
+
+	*s3Key = S3Key(key)
+	return nil
+}
+
+// validateFilterKeyValues - checks that keys are set and unique, metadata
+// keys are compared case-insensitively.
+func validateFilterKeyValues(filterName string, kvs []FilterKeyValue, foldCase bool) error {
+	keys := set.NewStringSet()
+	for _, kv := range kvs {
+		key := kv.Key
+		if foldCase {
+			key = normalizeMetadataKey(key)
+		}
+		if kv.Key == "" || keys.Contains(key) {
+			return &ErrInvalidFilterKey{FilterName: filterName, Key: kv.Key}
+		}
+		keys.Add(key)
+		if len(kv.Value) > 256 || !utf8.ValidString(kv.Value) {
+			return &ErrInvalidFilterValue{kv.Value}
+		}
+	}
+	return nil
+}
+
+// RuleFilter - returns the tag and metadata conditions of the filter.
+func (s3Key S3Key) RuleFilter() RuleFilter {
+	var filter RuleFilter
+	if len(s3Key.Tags) > 0 {
+		filter.Tags = make(map[string]string, len(s3Key.Tags))
+		for _, tag := range s3Key.Tags {
+			filter.Tags[tag.Key] = tag.Value
+		}
+	}
+	if len(s3Key.Metadata) > 0 {
+		filter.Metadata = make(map[string]string, len(s3Key.Metadata))
+		for _, meta := range s3Key.Metadata {
+			filter.Metadata[normalizeMetadataKey(meta.Key)] = meta.Value
+		}
+	}
+	return filter
+}
+
 // common - represents common elements inside <QueueConfiguration>, <CloudFunctionConfiguration>
 // and <TopicConfiguration>
 type common struct {
@@ -220,7 +289,7 @@ func (q *Queue) SetRegion(region string) {
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
 	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	return NewFilteredRulesMap(q.Events, pattern, q.Filter.RuleFilter(), q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
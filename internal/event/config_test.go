@@ -959,3 +959,36 @@ func TestParseConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestS3KeyUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		data           []byte
+		expectedResult RuleFilter
+		expectErr      bool
+	}{
+		{[]byte(`<Filter></Filter>`), RuleFilter{}, false},
+		{[]byte(`<Filter><Tag><Key>project</Key><Value>alpha</Value></Tag><Metadata><Key>Owner</Key><Value>*</Value></Metadata></Filter>`),
+			RuleFilter{Tags: map[string]string{"project": "alpha"}, Metadata: map[string]string{"x-amz-meta-owner": "*"}}, false},
+		{[]byte(`<Filter><Metadata><Key>X-Amz-Meta-Owner</Key><Value>bob</Value></Metadata></Filter>`),
+			RuleFilter{Metadata: map[string]string{"x-amz-meta-owner": "bob"}}, false},
+		{[]byte(`<Filter><Tag><Key></Key><Value>alpha</Value></Tag></Filter>`), RuleFilter{}, true},
+		{[]byte(`<Filter><Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>a</Key><Value>2</Value></Tag></Filter>`), RuleFilter{}, true},
+		{[]byte(`<Filter><Metadata><Key>owner</Key><Value>1</Value></Metadata><Metadata><Key>X-Amz-Meta-Owner</Key><Value>2</Value></Metadata></Filter>`), RuleFilter{}, true},
+	}
+
+	for i, testCase := range testCases {
+		var s3Key S3Key
+		err := xml.Unmarshal(testCase.data, &s3Key)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if result := s3Key.RuleFilter(); !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("test %v: data: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
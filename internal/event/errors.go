@@ -33,6 +33,8 @@ func IsEventError(err error) bool {
 		return true
 	case ErrInvalidFilterValue, *ErrInvalidFilterValue:
 		return true
+	case ErrInvalidFilterKey, *ErrInvalidFilterKey:
+		return true
 	case ErrDuplicateEventName, *ErrDuplicateEventName:
 		return true
 	case ErrUnsupportedConfiguration, *ErrUnsupportedConfiguration:
@@ -84,6 +86,19 @@ func (err ErrInvalidFilterValue) Error() string {
 	return fmt.Sprintf("invalid filter value '%v'", err.FilterValue)
 }
 
+// ErrInvalidFilterKey - empty or duplicate tag or metadata filter key error.
+type ErrInvalidFilterKey struct {
+	FilterName string
+	Key        string
+}
+
+func (err ErrInvalidFilterKey) Error() string {
+	if err.Key == "" {
+		return fmt.Sprintf("empty key in %v filter", err.FilterName)
+	}
+	return fmt.Sprintf("more than one %v filter for key '%v'", err.FilterName, err.Key)
+}
+
 // ErrDuplicateEventName - duplicate event name error.
 type ErrDuplicateEventName struct {
 	EventName Name
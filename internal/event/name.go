@@ -25,7 +25,7 @@ import (
 // Name - event type enum.
 // Refer http://docs.aws.amazon.com/AmazonS3/latest/dev/NotificationHowTo.html#notification-how-to-event-types-and-destinations
 // for most basic values we have since extend this and its not really much applicable other than a reference point.
-// "s3:Replication:OperationCompletedReplication" is a MinIO extension.
+// "s3:Replication:OperationCompletedReplication" and "s3:Heal:*" are MinIO extensions.
 type Name int
 
 // Values of event Name
@@ -55,12 +55,18 @@ const (
 	ObjectReplicationMissedThreshold
 	ObjectReplicationReplicatedAfterThreshold
 	ObjectReplicationNotTracked
+	ObjectReplicationConflict
 	ObjectRestorePostInitiated
 	ObjectRestorePostCompleted
 	ObjectRestorePostAll
 	ObjectTransitionAll
 	ObjectTransitionFailed
 	ObjectTransitionComplete
+	HealAll
+	ObjectHealed
+	ObjectQuorumLost
+	DriveHealStarted
+	DriveHealCompleted
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -94,6 +100,7 @@ func (name Name) Expand() []Name {
 			ObjectReplicationNotTracked,
 			ObjectReplicationMissedThreshold,
 			ObjectReplicationReplicatedAfterThreshold,
+			ObjectReplicationConflict,
 		}
 	case ObjectRestorePostAll:
 		return []Name{
@@ -105,6 +112,13 @@ func (name Name) Expand() []Name {
 			ObjectTransitionFailed,
 			ObjectTransitionComplete,
 		}
+	case HealAll:
+		return []Name{
+			ObjectHealed,
+			ObjectQuorumLost,
+			DriveHealStarted,
+			DriveHealCompleted,
+		}
 	default:
 		return []Name{name}
 	}
@@ -159,6 +173,8 @@ func (name Name) String() string {
 		return "s3:Replication:OperationCompletedReplication"
 	case ObjectReplicationNotTracked:
 		return "s3:Replication:OperationNotTracked"
+	case ObjectReplicationConflict:
+		return "s3:Replication:OperationConflict"
 	case ObjectReplicationMissedThreshold:
 		return "s3:Replication:OperationMissedThreshold"
 	case ObjectReplicationReplicatedAfterThreshold:
@@ -173,6 +189,16 @@ func (name Name) String() string {
 		return "s3:ObjectTransition:Failed"
 	case ObjectTransitionComplete:
 		return "s3:ObjectTransition:Complete"
+	case HealAll:
+		return "s3:Heal:*"
+	case ObjectHealed:
+		return "s3:Heal:ObjectHealed"
+	case ObjectQuorumLost:
+		return "s3:Heal:ObjectQuorumLost"
+	case DriveHealStarted:
+		return "s3:Heal:DriveHealStarted"
+	case DriveHealCompleted:
+		return "s3:Heal:DriveHealCompleted"
 	}
 
 	return ""
@@ -273,6 +299,8 @@ func ParseName(s string) (Name, error) {
 		return ObjectReplicationReplicatedAfterThreshold, nil
 	case "s3:Replication:OperationNotTracked":
 		return ObjectReplicationNotTracked, nil
+	case "s3:Replication:OperationConflict":
+		return ObjectReplicationConflict, nil
 	case "s3:ObjectRestore:*":
 		return ObjectRestorePostAll, nil
 	case "s3:ObjectRestore:Post":
@@ -285,6 +313,16 @@ func ParseName(s string) (Name, error) {
 		return ObjectTransitionComplete, nil
 	case "s3:ObjectTransition:*":
 		return ObjectTransitionAll, nil
+	case "s3:Heal:*":
+		return HealAll, nil
+	case "s3:Heal:ObjectHealed":
+		return ObjectHealed, nil
+	case "s3:Heal:ObjectQuorumLost":
+		return ObjectQuorumLost, nil
+	case "s3:Heal:DriveHealStarted":
+		return DriveHealStarted, nil
+	case "s3:Heal:DriveHealCompleted":
+		return DriveHealCompleted, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
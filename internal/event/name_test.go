@@ -36,6 +36,7 @@ func TestNameExpand(t *testing.T) {
 			ObjectCreatedPutRetention, ObjectCreatedPutLegalHold, ObjectCreatedPutTagging, ObjectCreatedDeleteTagging}},
 		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
+		{HealAll, []Name{ObjectHealed, ObjectQuorumLost, DriveHealStarted, DriveHealCompleted}},
 	}
 
 	for i, testCase := range testCases {
@@ -70,6 +71,11 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
 		{ObjectAccessedGetRetention, "s3:ObjectAccessed:GetRetention"},
 		{ObjectAccessedGetLegalHold, "s3:ObjectAccessed:GetLegalHold"},
+		{HealAll, "s3:Heal:*"},
+		{ObjectHealed, "s3:Heal:ObjectHealed"},
+		{ObjectQuorumLost, "s3:Heal:ObjectQuorumLost"},
+		{DriveHealStarted, "s3:Heal:DriveHealStarted"},
+		{DriveHealCompleted, "s3:Heal:DriveHealCompleted"},
 
 		{blankName, ""},
 	}
@@ -181,6 +187,7 @@ func TestNameUnmarshalJSON(t *testing.T) {
 	}{
 		{[]byte(`"s3:ObjectAccessed:*"`), ObjectAccessedAll, false},
 		{[]byte(`"s3:ObjectRemoved:Delete"`), ObjectRemovedDelete, false},
+		{[]byte(`"s3:Heal:DriveHealCompleted"`), DriveHealCompleted, false},
 		{[]byte(`""`), blankName, true},
 	}
 
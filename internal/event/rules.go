@@ -18,6 +18,7 @@
 package event
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/minio/pkg/wildcard"
@@ -46,6 +47,95 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
+// RuleFilter - conditions on the tags and user metadata of an object, in
+// addition to the object name pattern of a rule. Values are wildcard
+// patterns, metadata keys are lower case 'x-amz-meta-' prefixed.
+type RuleFilter struct {
+	Tags     map[string]string `json:"t,omitempty"`
+	Metadata map[string]string `json:"m,omitempty"`
+}
+
+func (filter RuleFilter) isEmpty() bool {
+	return len(filter.Tags) == 0 && len(filter.Metadata) == 0
+}
+
+// ruleKeySeparator - starts and ends the conditions encoded in front of
+// the pattern of a filtered rule. It never occurs in encoded JSON.
+const ruleKeySeparator = "\x00"
+
+// ruleKey - returns the key of a rule with given pattern and filter. The
+// conditions are part of the key so that rules with the same pattern but
+// different conditions are kept apart when merging and removing rules.
+func (filter RuleFilter) ruleKey(pattern string) string {
+	if filter.isEmpty() {
+		return pattern
+	}
+	// Map keys are sorted, the encoding is canonical.
+	conditions, _ := json.Marshal(filter)
+	return ruleKeySeparator + string(conditions) + ruleKeySeparator + pattern
+}
+
+// parseRuleKey - returns the pattern and filter of a rule key.
+func parseRuleKey(key string) (pattern string, filter RuleFilter) {
+	if !strings.HasPrefix(key, ruleKeySeparator) {
+		return key, filter
+	}
+	key = key[len(ruleKeySeparator):]
+	i := strings.Index(key, ruleKeySeparator)
+	if i < 0 {
+		return key, filter
+	}
+	json.Unmarshal([]byte(key[:i]), &filter)
+	return key[i+len(ruleKeySeparator):], filter
+}
+
+// normalizeMetadataKey - returns the lower case, 'x-amz-meta-' prefixed
+// form of a user metadata key.
+func normalizeMetadataKey(key string) string {
+	key = strings.ToLower(key)
+	if !strings.HasPrefix(key, userMetadataPrefix) {
+		key = userMetadataPrefix + key
+	}
+	return key
+}
+
+const userMetadataPrefix = "x-amz-meta-"
+
+// FilterObject - object information rules are matched against.
+type FilterObject struct {
+	Name string
+	Tags map[string]string
+	// User metadata, keys as returned by NewFilterObjectMetadata.
+	Metadata map[string]string
+}
+
+// NewFilterObjectMetadata - returns the user metadata entries of an
+// object's metadata, keyed as expected by FilterObject.
+func NewFilterObjectMetadata(metadata map[string]string) map[string]string {
+	userMetadata := make(map[string]string)
+	for k, v := range metadata {
+		if strings.HasPrefix(strings.ToLower(k), userMetadataPrefix) {
+			userMetadata[strings.ToLower(k)] = v
+		}
+	}
+	return userMetadata
+}
+
+func matchConditions(conditions, values map[string]string) bool {
+	for k, pattern := range conditions {
+		v, ok := values[k]
+		if !ok || !wildcard.MatchSimple(pattern, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// match - returns true if the object satisfies all conditions.
+func (filter RuleFilter) match(object FilterObject) bool {
+	return matchConditions(filter.Tags, object.Tags) && matchConditions(filter.Metadata, object.Metadata)
+}
+
 // Rules - event rules
 type Rules map[string]TargetIDSet
 
@@ -56,20 +146,28 @@ func (rules Rules) Add(pattern string, targetID TargetID) {
 
 // MatchSimple - returns true one of the matching object name in rules.
 func (rules Rules) MatchSimple(objectName string) bool {
-	for pattern := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for key := range rules {
+		pattern, filter := parseRuleKey(key)
+		if wildcard.MatchSimple(pattern, objectName) && filter.match(FilterObject{Name: objectName}) {
 			return true
 		}
 	}
 	return false
 }
 
-// Match - returns TargetIDSet matching object name in rules.
+// Match - returns TargetIDSet matching object name in rules. Rules with
+// tag or metadata conditions do not match, use MatchObject.
 func (rules Rules) Match(objectName string) TargetIDSet {
+	return rules.MatchObject(FilterObject{Name: objectName})
+}
+
+// MatchObject - returns TargetIDSet matching object in rules.
+func (rules Rules) MatchObject(object FilterObject) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for key, targetIDSet := range rules {
+		pattern, filter := parseRuleKey(key)
+		if wildcard.MatchSimple(pattern, object.Name) && filter.match(object) {
 			targetIDs = targetIDs.Union(targetIDSet)
 		}
 	}
@@ -21,6 +21,7 @@ package event
 type RulesMap map[Name]Rules
 
 // add - adds event names, prefixes, suffixes and target ID to rules map.
+// The pattern may carry tag and metadata conditions, see RuleFilter.
 func (rulesMap RulesMap) add(eventNames []Name, pattern string, targetID TargetID) {
 	rules := make(Rules)
 	rules.Add(pattern, targetID)
@@ -71,6 +72,11 @@ func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
 	return rulesMap[eventName].Match(objectName)
 }
 
+// MatchObject - returns TargetIDSet matching object and event name in rules map.
+func (rulesMap RulesMap) MatchObject(eventName Name, object FilterObject) TargetIDSet {
+	return rulesMap[eventName].MatchObject(object)
+}
+
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
@@ -82,3 +88,16 @@ func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap
 	rulesMap.add(eventNames, pattern, targetID)
 	return rulesMap
 }
+
+// NewFilteredRulesMap - creates new rules map with given values, matching
+// only objects satisfying the tag and metadata conditions of filter.
+func NewFilteredRulesMap(eventNames []Name, pattern string, filter RuleFilter, targetID TargetID) RulesMap {
+	// If pattern is empty, add '*' wildcard to match all.
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	rulesMap := make(RulesMap)
+	rulesMap.add(eventNames, filter.ruleKey(pattern), targetID)
+	return rulesMap
+}
@@ -183,3 +183,50 @@ func TestNewRulesMap(t *testing.T) {
 		}
 	}
 }
+
+func TestRulesMapMatchObject(t *testing.T) {
+	rulesMap := NewRulesMap([]Name{ObjectCreatedAll}, "*", TargetID{"1", "webhook"})
+	rulesMap.Add(NewFilteredRulesMap([]Name{ObjectCreatedAll}, "images/*", RuleFilter{
+		Tags: map[string]string{"project": "alpha"},
+	}, TargetID{"2", "amqp"}))
+	rulesMap.Add(NewFilteredRulesMap([]Name{ObjectCreatedAll}, "images/*", RuleFilter{
+		Metadata: map[string]string{"x-amz-meta-owner": "b*"},
+	}, TargetID{"3", "kafka"}))
+
+	testCases := []struct {
+		object         FilterObject
+		expectedResult TargetIDSet
+	}{
+		{FilterObject{Name: "images/photo.jpg"}, NewTargetIDSet(TargetID{"1", "webhook"})},
+		{FilterObject{Name: "images/photo.jpg", Tags: map[string]string{"project": "alpha"}},
+			NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"2", "amqp"})},
+		{FilterObject{Name: "images/photo.jpg", Tags: map[string]string{"project": "beta"}},
+			NewTargetIDSet(TargetID{"1", "webhook"})},
+		{FilterObject{Name: "docs/alpha.txt", Tags: map[string]string{"project": "alpha"}},
+			NewTargetIDSet(TargetID{"1", "webhook"})},
+		{FilterObject{Name: "images/photo.jpg", Metadata: NewFilterObjectMetadata(map[string]string{"X-Amz-Meta-Owner": "bob"})},
+			NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"3", "kafka"})},
+	}
+
+	for i, testCase := range testCases {
+		result := rulesMap.MatchObject(ObjectCreatedPut, testCase.object)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Rules with the same pattern but other conditions are removed separately.
+	rulesMap.Remove(NewFilteredRulesMap([]Name{ObjectCreatedAll}, "images/*", RuleFilter{
+		Tags: map[string]string{"project": "alpha"},
+	}, TargetID{"2", "amqp"}))
+	result := rulesMap.MatchObject(ObjectCreatedPut, FilterObject{
+		Name:     "images/photo.jpg",
+		Tags:     map[string]string{"project": "alpha"},
+		Metadata: map[string]string{"x-amz-meta-owner": "bob"},
+	})
+	expectedResult := NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"3", "kafka"})
+	if !reflect.DeepEqual(result, expectedResult) {
+		t.Fatalf("result: expected: %v, got: %v", expectedResult, result)
+	}
+}
@@ -140,6 +140,11 @@ func (target *AMQPTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *AMQPTarget) Store() Store {
+	return target.store
+}
+
 func (target *AMQPTarget) channel() (*amqp.Channel, chan amqp.Confirmation, error) {
 	var err error
 	var conn *amqp.Connection
@@ -31,6 +31,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	elasticsearch7 "github.com/elastic/go-elasticsearch/v7"
@@ -42,22 +43,45 @@ import (
 
 // Elastic constants
 const (
-	ElasticFormat     = "format"
-	ElasticURL        = "url"
-	ElasticIndex      = "index"
-	ElasticQueueDir   = "queue_dir"
-	ElasticQueueLimit = "queue_limit"
-	ElasticUsername   = "username"
-	ElasticPassword   = "password"
-
-	EnvElasticEnable     = "MINIO_NOTIFY_ELASTICSEARCH_ENABLE"
-	EnvElasticFormat     = "MINIO_NOTIFY_ELASTICSEARCH_FORMAT"
-	EnvElasticURL        = "MINIO_NOTIFY_ELASTICSEARCH_URL"
-	EnvElasticIndex      = "MINIO_NOTIFY_ELASTICSEARCH_INDEX"
-	EnvElasticQueueDir   = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_DIR"
-	EnvElasticQueueLimit = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_LIMIT"
-	EnvElasticUsername   = "MINIO_NOTIFY_ELASTICSEARCH_USERNAME"
-	EnvElasticPassword   = "MINIO_NOTIFY_ELASTICSEARCH_PASSWORD"
+	ElasticFormat        = "format"
+	ElasticURL           = "url"
+	ElasticIndex         = "index"
+	ElasticQueueDir      = "queue_dir"
+	ElasticQueueLimit    = "queue_limit"
+	ElasticUsername      = "username"
+	ElasticPassword      = "password"
+	ElasticAPIKey        = "api_key"
+	ElasticCACert        = "ca_cert"
+	ElasticBatchSize     = "batch_size"
+	ElasticFlushInterval = "flush_interval"
+	ElasticIndexRotation = "index_rotation"
+	ElasticIndexMaxSize  = "index_max_size"
+
+	EnvElasticEnable        = "MINIO_NOTIFY_ELASTICSEARCH_ENABLE"
+	EnvElasticFormat        = "MINIO_NOTIFY_ELASTICSEARCH_FORMAT"
+	EnvElasticURL           = "MINIO_NOTIFY_ELASTICSEARCH_URL"
+	EnvElasticIndex         = "MINIO_NOTIFY_ELASTICSEARCH_INDEX"
+	EnvElasticQueueDir      = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_DIR"
+	EnvElasticQueueLimit    = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_LIMIT"
+	EnvElasticUsername      = "MINIO_NOTIFY_ELASTICSEARCH_USERNAME"
+	EnvElasticPassword      = "MINIO_NOTIFY_ELASTICSEARCH_PASSWORD"
+	EnvElasticAPIKey        = "MINIO_NOTIFY_ELASTICSEARCH_API_KEY"
+	EnvElasticCACert        = "MINIO_NOTIFY_ELASTICSEARCH_CA_CERT"
+	EnvElasticBatchSize     = "MINIO_NOTIFY_ELASTICSEARCH_BATCH_SIZE"
+	EnvElasticFlushInterval = "MINIO_NOTIFY_ELASTICSEARCH_FLUSH_INTERVAL"
+	EnvElasticIndexRotation = "MINIO_NOTIFY_ELASTICSEARCH_INDEX_ROTATION"
+	EnvElasticIndexMaxSize  = "MINIO_NOTIFY_ELASTICSEARCH_INDEX_MAX_SIZE"
+)
+
+// Elasticsearch index rotation patterns
+const (
+	// ESIndexRotationDaily - events are written to '<index>-YYYY.MM.DD'
+	// by the UTC date of the event.
+	ESIndexRotationDaily = "daily"
+	// ESIndexRotationSize - events are written to '<index>-NNNNNN', the
+	// sequence number is incremented once the index grows past the
+	// configured maximum size.
+	ESIndexRotationSize = "size"
 )
 
 // ESSupportStatus is a typed string representing the support status for
@@ -100,6 +124,17 @@ func getESVersionSupportStatus(version string) (res ESSupportStatus, err error)
 // magic HH-256 key as HH-256 hash of the first 100 decimals of π as utf-8 string with a zero key.
 var magicHighwayHash256Key = []byte("\x4b\xe7\x34\xfa\x8e\x23\x8a\xcd\x26\x3e\x83\xe6\xbb\x96\x85\x52\x04\x0f\x93\x5d\xa3\x9f\x44\x14\x97\xe0\x9d\x13\x22\xde\x36\xa0")
 
+// esBulkOp - a single index or delete operation of a bulk request.
+type esBulkOp struct {
+	delete bool
+	index  string
+	id     string
+	doc    []byte
+
+	// key of the event in the queue store, empty without a store.
+	storeKey string
+}
+
 // Interface for elasticsearch client objects
 type esClient interface {
 	isAtleastV7() bool
@@ -107,23 +142,29 @@ type esClient interface {
 	ping(context.Context, ElasticsearchArgs) (bool, error)
 	stop()
 
-	entryExists(context.Context, string, string) (bool, error)
-	removeEntry(context.Context, string, string) error
-	updateEntry(context.Context, string, string, event.Event) error
-	addEntry(context.Context, string, event.Event) error
+	// latestIndex - returns the size rotated index with the highest
+	// sequence number and its size, -1 if there is none.
+	latestIndex(context.Context, string) (int, uint64, error)
+	bulk(context.Context, []esBulkOp) error
 }
 
 // ElasticsearchArgs - Elasticsearch target arguments.
 type ElasticsearchArgs struct {
-	Enable     bool            `json:"enable"`
-	Format     string          `json:"format"`
-	URL        xnet.URL        `json:"url"`
-	Index      string          `json:"index"`
-	QueueDir   string          `json:"queueDir"`
-	QueueLimit uint64          `json:"queueLimit"`
-	Transport  *http.Transport `json:"-"`
-	Username   string          `json:"username"`
-	Password   string          `json:"password"`
+	Enable        bool            `json:"enable"`
+	Format        string          `json:"format"`
+	URL           xnet.URL        `json:"url"`
+	Index         string          `json:"index"`
+	QueueDir      string          `json:"queueDir"`
+	QueueLimit    uint64          `json:"queueLimit"`
+	Transport     *http.Transport `json:"-"`
+	Username      string          `json:"username"`
+	Password      string          `json:"password"`
+	APIKey        string          `json:"apiKey"`
+	CACert        string          `json:"caCert"`
+	BatchSize     int             `json:"batchSize"`
+	FlushInterval time.Duration   `json:"flushInterval"`
+	IndexRotation string          `json:"indexRotation"`
+	IndexMaxSize  uint64          `json:"indexMaxSize"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -147,6 +188,34 @@ func (a ElasticsearchArgs) Validate() error {
 	if (a.Username == "" && a.Password != "") || (a.Username != "" && a.Password == "") {
 		return errors.New("username and password should be set in pairs")
 	}
+	if a.APIKey != "" && a.Username != "" {
+		return errors.New("api key and username/password are mutually exclusive")
+	}
+	if a.CACert != "" && !filepath.IsAbs(a.CACert) {
+		return errors.New("ca cert path should be absolute")
+	}
+
+	if a.BatchSize < 0 {
+		return errors.New("batch size cannot be negative")
+	}
+	if a.BatchSize > 1 && a.FlushInterval <= 0 {
+		return errors.New("flush interval must be positive when batching")
+	}
+
+	switch a.IndexRotation {
+	case "":
+	case ESIndexRotationDaily, ESIndexRotationSize:
+		// Documents are updated and deleted in place in namespace format,
+		// which requires all of them to live in the same index.
+		if a.Format != event.AccessFormat {
+			return errors.New("index rotation requires access format")
+		}
+		if a.IndexRotation == ESIndexRotationSize && a.IndexMaxSize == 0 {
+			return errors.New("index max size is required for size based rotation")
+		}
+	default:
+		return fmt.Errorf("unknown index rotation '%s', expected '%s' or '%s'", a.IndexRotation, ESIndexRotationDaily, ESIndexRotationSize)
+	}
 
 	return nil
 }
@@ -158,6 +227,20 @@ type ElasticsearchTarget struct {
 	client     esClient
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+
+	// Operations waiting for the next bulk request, with the store keys
+	// of the queued events among them.
+	batchMu     sync.Mutex
+	batch       []esBulkOp
+	batchedKeys map[string]struct{}
+	// Serializes bulk requests so that operations on the same document
+	// are applied in order.
+	flushMu sync.Mutex
+
+	// Size rotated index currently written to.
+	rotationMu    sync.Mutex
+	rotationSeq   int
+	rotationBytes uint64
 }
 
 // ID - returns target ID.
@@ -170,6 +253,11 @@ func (target *ElasticsearchTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *ElasticsearchTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *ElasticsearchTarget) IsActive() (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -197,22 +285,87 @@ func (target *ElasticsearchTarget) Save(eventData event.Event) error {
 		return err
 	}
 
-	err = target.send(eventData)
+	err = target.send(eventData, "")
 	if xnet.IsNetworkOrHostDown(err, false) {
 		return errNotConnected
 	}
 	return err
 }
 
-// send - sends the event to the target.
-func (target *ElasticsearchTarget) send(eventData event.Event) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// send - adds the event to the batch, and sends the batch once full.
+func (target *ElasticsearchTarget) send(eventData event.Event, storeKey string) error {
+	op, err := target.bulkOp(eventData)
+	if err != nil {
+		return err
+	}
+	op.storeKey = storeKey
+
+	target.batchMu.Lock()
+	if storeKey != "" {
+		// Queued events are listed again until deleted from the store.
+		if _, ok := target.batchedKeys[storeKey]; ok {
+			target.batchMu.Unlock()
+			return nil
+		}
+		target.batchedKeys[storeKey] = struct{}{}
+	}
+	target.batch = append(target.batch, op)
+	full := len(target.batch) >= target.args.BatchSize
+	target.batchMu.Unlock()
+
+	if !full {
+		return nil
+	}
+	return target.flush()
+}
+
+// flush - sends the batched operations in a single bulk request. Queued
+// events are deleted from the store once sent, on failure they are
+// replayed from the store, others are dropped.
+func (target *ElasticsearchTarget) flush() error {
+	target.flushMu.Lock()
+	defer target.flushMu.Unlock()
+
+	target.batchMu.Lock()
+	ops := target.batch
+	target.batch = nil
+	target.batchedKeys = make(map[string]struct{})
+	target.batchMu.Unlock()
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if err := target.client.bulk(ctx, ops); err != nil {
+		return err
+	}
+
+	if target.store != nil {
+		for _, op := range ops {
+			if err := target.store.Del(op.storeKey); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bulkOp - returns the bulk operation recording the event.
+func (target *ElasticsearchTarget) bulkOp(eventData event.Event) (op esBulkOp, err error) {
+	doc, err := json.Marshal(map[string]interface{}{
+		"Records": []event.Event{eventData},
+	})
+	if err != nil {
+		return op, err
+	}
+
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
-			return err
+			return op, err
 		}
 
 		// Calculate a hash of the key for the id of the ES document.
@@ -231,18 +384,36 @@ func (target *ElasticsearchTarget) send(eventData event.Event) error {
 		}
 
 		if eventData.EventName == event.ObjectRemovedDelete {
-			err = target.client.removeEntry(ctx, target.args.Index, keyHash)
-		} else {
-			err = target.client.updateEntry(ctx, target.args.Index, keyHash, eventData)
+			return esBulkOp{delete: true, index: target.args.Index, id: keyHash}, nil
 		}
-		return err
+		return esBulkOp{index: target.args.Index, id: keyHash, doc: doc}, nil
 	}
 
-	if target.args.Format == event.AccessFormat {
-		return target.client.addEntry(ctx, target.args.Index, eventData)
-	}
+	return esBulkOp{index: target.indexName(eventData, uint64(len(doc))), doc: doc}, nil
+}
 
-	return nil
+// indexName - returns the index an event of docSize bytes is written to.
+func (target *ElasticsearchTarget) indexName(eventData event.Event, docSize uint64) string {
+	switch target.args.IndexRotation {
+	case ESIndexRotationDaily:
+		t, err := time.Parse(event.AMZTimeFormat, eventData.EventTime)
+		if err != nil {
+			t = time.Now()
+		}
+		return target.args.Index + "-" + t.UTC().Format("2006.01.02")
+	case ESIndexRotationSize:
+		target.rotationMu.Lock()
+		defer target.rotationMu.Unlock()
+		// The size is approximated by the bytes of the documents sent,
+		// which does not account for the compression done by ES.
+		if target.rotationBytes > 0 && target.rotationBytes+docSize > target.args.IndexMaxSize {
+			target.rotationSeq++
+			target.rotationBytes = 0
+		}
+		target.rotationBytes += docSize
+		return fmt.Sprintf("%s-%06d", target.args.Index, target.rotationSeq)
+	}
+	return target.args.Index
 }
 
 // Send - reads an event from store and sends it to Elasticsearch.
@@ -265,26 +436,44 @@ func (target *ElasticsearchTarget) Send(eventKey string) error {
 		return eErr
 	}
 
-	if err := target.send(eventData); err != nil {
+	if err := target.send(eventData, eventKey); err != nil {
 		if xnet.IsNetworkOrHostDown(err, false) {
 			return errNotConnected
 		}
 		return err
 	}
 
-	// Delete the event from store.
-	return target.store.Del(eventKey)
+	// The event is deleted from the store once its batch is sent.
+	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - sends the pending batch and stops the client.
 func (target *ElasticsearchTarget) Close() error {
 	if target.client != nil {
+		err := target.flush()
 		// Stops the background processes that the client is running.
 		target.client.stop()
+		return err
 	}
 	return nil
 }
 
+// flushPeriodically - sends partial batches every flush interval.
+func (target *ElasticsearchTarget) flushPeriodically(doneCh <-chan struct{}) {
+	ticker := time.NewTicker(target.args.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := target.flush(); err != nil {
+				target.loggerOnce(context.Background(), err, target.ID())
+			}
+		case <-doneCh:
+			return
+		}
+	}
+}
+
 func (target *ElasticsearchTarget) checkAndInitClient(ctx context.Context) error {
 	if target.client != nil {
 		return nil
@@ -309,23 +498,43 @@ func (target *ElasticsearchTarget) checkAndInitClient(ctx context.Context) error
 		return errors.New("there is no currently deprecated version of ES in MinIO")
 
 	case ESSSupported:
-		target.client = clientV7
-
 	default:
 		// ESSUnsupported case
 		return fmt.Errorf("Elasticsearch version '%s' is not supported! Please use at least version 7.x.", version)
 	}
 
-	target.client.createIndex(target.args)
+	switch target.args.IndexRotation {
+	case ESIndexRotationSize:
+		// Resume writing to the latest index.
+		seq, size, err := clientV7.latestIndex(ctx, target.args.Index)
+		if err != nil {
+			return err
+		}
+		target.rotationMu.Lock()
+		if seq >= 0 {
+			target.rotationSeq, target.rotationBytes = seq, size
+		}
+		target.rotationMu.Unlock()
+	case ESIndexRotationDaily:
+		// Rotated indices are auto-created by the bulk requests.
+	default:
+		clientV7.createIndex(target.args)
+	}
+
+	target.client = clientV7
 	return nil
 }
 
 // NewElasticsearchTarget - creates new Elasticsearch target.
 func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*ElasticsearchTarget, error) {
+	if args.BatchSize < 1 {
+		args.BatchSize = 1
+	}
 	target := &ElasticsearchTarget{
-		id:         event.TargetID{ID: id, Name: "elasticsearch"},
-		args:       args,
-		loggerOnce: loggerOnce,
+		id:          event.TargetID{ID: id, Name: "elasticsearch"},
+		args:        args,
+		loggerOnce:  loggerOnce,
+		batchedKeys: make(map[string]struct{}),
 	}
 
 	if args.QueueDir != "" {
@@ -348,6 +557,10 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 		}
 	}
 
+	if !test && args.BatchSize > 1 {
+		go target.flushPeriodically(doneCh)
+	}
+
 	if target.store != nil && !test {
 		// Replays the events from the store.
 		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
@@ -376,6 +589,16 @@ func newClientV7(args ElasticsearchArgs) (*esClientV7, error) {
 		elasticConfig.Username = args.Username
 		elasticConfig.Password = args.Password
 	}
+	// ES 8.x enables security by default, clusters are commonly
+	// accessed with API keys and a self-signed HTTP CA.
+	elasticConfig.APIKey = args.APIKey
+	if args.CACert != "" {
+		caCert, err := ioutil.ReadFile(args.CACert)
+		if err != nil {
+			return nil, err
+		}
+		elasticConfig.CACert = caCert
+	}
 	// Create a client
 	client, err := elasticsearch7.NewClient(elasticConfig)
 	if err != nil {
@@ -395,6 +618,12 @@ func (c *esClientV7) getServerSupportStatus(ctx context.Context) (ESSupportStatu
 
 	defer resp.Body.Close()
 
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Security is enabled by default since ES 8.x.
+		return ESSUnknown, "", fmt.Errorf("Elasticsearch denied access with '%s', check the credentials or API key", resp.Status())
+	}
+
 	m := make(map[string]interface{})
 	err = json.NewDecoder(resp.Body).Decode(&m)
 	if err != nil {
@@ -468,95 +697,137 @@ func (c *esClientV7) ping(ctx context.Context, _ ElasticsearchArgs) (bool, error
 	return !resp.IsError(), nil
 }
 
-func (c *esClientV7) entryExists(ctx context.Context, index string, key string) (bool, error) {
-	res, err := c.Exists(
-		index,
-		key,
-		c.Exists.WithContext(ctx),
+func (c *esClientV7) latestIndex(ctx context.Context, prefix string) (int, uint64, error) {
+	res, err := c.Indices.ResolveIndex([]string{prefix + "-*"}, c.Indices.ResolveIndex.WithContext(ctx))
+	if err != nil {
+		return -1, 0, errNotConnected
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return -1, 0, fmt.Errorf("Resolve index err: %s", res.String())
+	}
+
+	var v struct {
+		Indices []struct {
+			Name string `json:"name"`
+		} `json:"indices"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return -1, 0, fmt.Errorf("Error parsing response body: %v", err)
+	}
+
+	latest := -1
+	for _, index := range v.Indices {
+		seq, err := strconv.Atoi(strings.TrimPrefix(index.Name, prefix+"-"))
+		if err == nil && seq > latest {
+			latest = seq
+		}
+	}
+	if latest < 0 {
+		return latest, 0, nil
+	}
+
+	name := fmt.Sprintf("%s-%06d", prefix, latest)
+	stats, err := c.Indices.Stats(
+		c.Indices.Stats.WithIndex(name),
+		c.Indices.Stats.WithMetric("store"),
+		c.Indices.Stats.WithContext(ctx),
 	)
 	if err != nil {
-		return false, err
+		return -1, 0, errNotConnected
 	}
-	io.Copy(ioutil.Discard, res.Body)
-	res.Body.Close()
-	return !res.IsError(), nil
+	defer stats.Body.Close()
+	if stats.IsError() {
+		return -1, 0, fmt.Errorf("Index stats err: %s", stats.String())
+	}
+
+	var s struct {
+		All struct {
+			Primaries struct {
+				Store struct {
+					SizeInBytes uint64 `json:"size_in_bytes"`
+				} `json:"store"`
+			} `json:"primaries"`
+		} `json:"_all"`
+	}
+	if err := json.NewDecoder(stats.Body).Decode(&s); err != nil {
+		return -1, 0, fmt.Errorf("Error parsing response body: %v", err)
+	}
+	return latest, s.All.Primaries.Store.SizeInBytes, nil
 }
 
-func (c *esClientV7) removeEntry(ctx context.Context, index string, key string) error {
-	exists, err := c.entryExists(ctx, index, key)
-	if err == nil && exists {
-		res, err := c.Delete(
-			index,
-			key,
-			c.Delete.WithContext(ctx),
-		)
+// encodeBulkBody - returns the NDJSON body of a bulk request.
+func encodeBulkBody(ops []esBulkOp) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, op := range ops {
+		type meta struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id,omitempty"`
+		}
+		action := map[string]meta{}
+		if op.delete {
+			action["delete"] = meta{Index: op.index, ID: op.id}
+		} else {
+			action["index"] = meta{Index: op.index, ID: op.id}
+		}
+		line, err := json.Marshal(action)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		defer res.Body.Close()
-		if res.IsError() {
-			err := fmt.Errorf("Delete err: %s", res.String())
-			return err
+		buf.Write(line)
+		buf.WriteByte('\n')
+		if !op.delete {
+			buf.Write(op.doc)
+			buf.WriteByte('\n')
 		}
-		io.Copy(ioutil.Discard, res.Body)
-		return nil
 	}
-	return err
+	return buf.Bytes(), nil
 }
 
-func (c *esClientV7) updateEntry(ctx context.Context, index string, key string, eventData event.Event) error {
-	doc := map[string]interface{}{
-		"Records": []event.Event{eventData},
-	}
-	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
-	err := enc.Encode(doc)
-	if err != nil {
-		return err
+// bulkError - returns the first failed item of a bulk response, deleting
+// a document which does not exist is not a failure.
+func bulkError(body io.Reader) error {
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return fmt.Errorf("Error parsing response body: %v", err)
 	}
-	res, err := c.Index(
-		index,
-		&buf,
-		c.Index.WithDocumentID(key),
-		c.Index.WithContext(ctx),
-	)
-	if err != nil {
-		return err
+	if !resp.Errors {
+		return nil
 	}
-	defer res.Body.Close()
-	if res.IsError() {
-		err := fmt.Errorf("Update err: %s", res.String())
-		return err
+	for _, item := range resp.Items {
+		for action, result := range item {
+			if result.Status < 300 || action == "delete" && result.Status == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("Bulk %s err: %d %s", action, result.Status, string(result.Error))
+		}
 	}
-	io.Copy(ioutil.Discard, res.Body)
 	return nil
 }
 
-func (c *esClientV7) addEntry(ctx context.Context, index string, eventData event.Event) error {
-	doc := map[string]interface{}{
-		"Records": []event.Event{eventData},
-	}
-	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
-	err := enc.Encode(doc)
+func (c *esClientV7) bulk(ctx context.Context, ops []esBulkOp) error {
+	body, err := encodeBulkBody(ops)
 	if err != nil {
 		return err
 	}
-	res, err := c.Index(
-		index,
-		&buf,
-		c.Index.WithContext(ctx),
+	res, err := c.Bulk(
+		bytes.NewReader(body),
+		c.Bulk.WithContext(ctx),
 	)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 	if res.IsError() {
-		err := fmt.Errorf("Add err: %s", res.String())
-		return err
+		return fmt.Errorf("Bulk err: %s", res.String())
 	}
-	io.Copy(ioutil.Discard, res.Body)
-	return nil
+	return bulkError(res.Body)
 }
 
 func (c *esClientV7) stop() {
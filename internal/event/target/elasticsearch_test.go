@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestElasticsearchArgsValidate(t *testing.T) {
+	u, err := xnet.ParseHTTPURL("http://localhost:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := func(format, rotation string, maxSize uint64, batchSize int) ElasticsearchArgs {
+		return ElasticsearchArgs{
+			Enable:        true,
+			URL:           *u,
+			Index:         "minio",
+			Format:        format,
+			BatchSize:     batchSize,
+			FlushInterval: time.Second,
+			IndexRotation: rotation,
+			IndexMaxSize:  maxSize,
+		}
+	}
+
+	testCases := []struct {
+		args    ElasticsearchArgs
+		wantErr bool
+	}{
+		{args(event.NamespaceFormat, "", 0, 1), false},
+		{args(event.AccessFormat, ESIndexRotationDaily, 0, 100), false},
+		{args(event.AccessFormat, ESIndexRotationSize, 1<<30, 100), false},
+		{args(event.AccessFormat, ESIndexRotationSize, 0, 100), true},
+		{args(event.NamespaceFormat, ESIndexRotationDaily, 0, 1), true},
+		{args(event.AccessFormat, "weekly", 0, 1), true},
+		{args(event.AccessFormat, "", 0, -1), true},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
+
+func TestElasticsearchIndexName(t *testing.T) {
+	e := event.Event{EventTime: "2021-11-30T23:59:59.000Z"}
+
+	target := &ElasticsearchTarget{args: ElasticsearchArgs{Index: "minio", IndexRotation: ESIndexRotationDaily}}
+	if name := target.indexName(e, 10); name != "minio-2021.11.30" {
+		t.Errorf("expected daily index 'minio-2021.11.30', got '%s'", name)
+	}
+
+	target = &ElasticsearchTarget{args: ElasticsearchArgs{Index: "minio", IndexRotation: ESIndexRotationSize, IndexMaxSize: 25}}
+	expected := []string{"minio-000000", "minio-000000", "minio-000001", "minio-000001", "minio-000002"}
+	for i, name := range expected {
+		if got := target.indexName(e, 10); got != name {
+			t.Errorf("document %d: expected index '%s', got '%s'", i+1, name, got)
+		}
+	}
+}
+
+func TestElasticsearchBulk(t *testing.T) {
+	body, err := encodeBulkBody([]esBulkOp{
+		{index: "minio", id: "a", doc: []byte(`{"Records":[]}`)},
+		{delete: true, index: "minio", id: "b"},
+		{index: "minio-2021.11.30", doc: []byte(`{}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"index":{"_index":"minio","_id":"a"}}
+{"Records":[]}
+{"delete":{"_index":"minio","_id":"b"}}
+{"index":{"_index":"minio-2021.11.30"}}
+{}
+`
+	if string(body) != expected {
+		t.Fatalf("expected bulk body %q, got %q", expected, string(body))
+	}
+
+	testCases := []struct {
+		response string
+		wantErr  bool
+	}{
+		{`{"errors":false,"items":[{"index":{"status":201}}]}`, false},
+		{`{"errors":true,"items":[{"index":{"status":201}},{"delete":{"status":404}}]}`, false},
+		{`{"errors":true,"items":[{"index":{"status":429,"error":{"type":"es_rejected_execution_exception"}}}]}`, true},
+		{`not json`, true},
+	}
+	for i, testCase := range testCases {
+		if err := bulkError(strings.NewReader(testCase.response)); (err != nil) != testCase.wantErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
@@ -23,7 +23,9 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -52,6 +54,16 @@ const (
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
 
+	KafkaSASLOAuthTokenURL     = "sasl_oauth_token_url"
+	KafkaSASLOAuthClientID     = "sasl_oauth_client_id"
+	KafkaSASLOAuthClientSecret = "sasl_oauth_client_secret"
+	KafkaSASLOAuthScopes       = "sasl_oauth_scopes"
+
+	KafkaEncoding               = "encoding"
+	KafkaSchemaRegistryURL      = "schema_registry_url"
+	KafkaSchemaRegistryUsername = "schema_registry_username"
+	KafkaSchemaRegistryPassword = "schema_registry_password"
+
 	EnvKafkaEnable        = "MINIO_NOTIFY_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_NOTIFY_KAFKA_BROKERS"
 	EnvKafkaTopic         = "MINIO_NOTIFY_KAFKA_TOPIC"
@@ -67,6 +79,16 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_NOTIFY_KAFKA_VERSION"
+
+	EnvKafkaSASLOAuthTokenURL     = "MINIO_NOTIFY_KAFKA_SASL_OAUTH_TOKEN_URL"
+	EnvKafkaSASLOAuthClientID     = "MINIO_NOTIFY_KAFKA_SASL_OAUTH_CLIENT_ID"
+	EnvKafkaSASLOAuthClientSecret = "MINIO_NOTIFY_KAFKA_SASL_OAUTH_CLIENT_SECRET"
+	EnvKafkaSASLOAuthScopes       = "MINIO_NOTIFY_KAFKA_SASL_OAUTH_SCOPES"
+
+	EnvKafkaEncoding               = "MINIO_NOTIFY_KAFKA_ENCODING"
+	EnvKafkaSchemaRegistryURL      = "MINIO_NOTIFY_KAFKA_SCHEMA_REGISTRY_URL"
+	EnvKafkaSchemaRegistryUsername = "MINIO_NOTIFY_KAFKA_SCHEMA_REGISTRY_USERNAME"
+	EnvKafkaSchemaRegistryPassword = "MINIO_NOTIFY_KAFKA_SCHEMA_REGISTRY_PASSWORD"
 )
 
 // KafkaArgs - Kafka target arguments.
@@ -90,7 +112,20 @@ type KafkaArgs struct {
 		User      string `json:"username"`
 		Password  string `json:"password"`
 		Mechanism string `json:"mechanism"`
+		OAuth     struct {
+			TokenURL     *xnet.URL `json:"tokenURL"`
+			ClientID     string    `json:"clientID"`
+			ClientSecret string    `json:"clientSecret"`
+			Scopes       []string  `json:"scopes"`
+		} `json:"oauth"`
 	} `json:"sasl"`
+	Encoding       string `json:"encoding"`
+	SchemaRegistry struct {
+		URL      *xnet.URL `json:"url"`
+		Username string    `json:"username"`
+		Password string    `json:"password"`
+	} `json:"schemaRegistry"`
+	Transport *http.Transport `json:"-"`
 }
 
 // Validate KafkaArgs fields
@@ -116,6 +151,26 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
+	if k.SASL.Enable && k.SASL.Mechanism == "oauthbearer" {
+		if k.SASL.OAuth.TokenURL == nil {
+			return errors.New("sasl oauth token URL is required for the oauthbearer mechanism")
+		}
+		if k.SASL.OAuth.ClientID == "" {
+			return errors.New("sasl oauth client ID is required for the oauthbearer mechanism")
+		}
+	}
+	switch k.Encoding {
+	case "", KafkaEncodingJSON:
+	case KafkaEncodingAvro:
+		if k.SchemaRegistry.URL == nil {
+			return errors.New("schema registry URL is required for avro encoding")
+		}
+		if k.Topic == "" {
+			return errors.New("topic is required for avro encoding")
+		}
+	default:
+		return fmt.Errorf("unknown encoding '%s', expected '%s' or '%s'", k.Encoding, KafkaEncodingJSON, KafkaEncodingAvro)
+	}
 	return nil
 }
 
@@ -125,6 +180,7 @@ type KafkaTarget struct {
 	args       KafkaArgs
 	producer   sarama.SyncProducer
 	config     *sarama.Config
+	registry   *kafkaSchemaRegistry
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
 }
@@ -139,6 +195,11 @@ func (target *KafkaTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *KafkaTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *KafkaTarget) IsActive() (bool, error) {
 	if !target.args.pingBrokers() {
@@ -170,9 +231,19 @@ func (target *KafkaTarget) send(eventData event.Event) error {
 	}
 	key := eventData.S3.Bucket.Name + "/" + objectName
 
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
-	if err != nil {
-		return err
+	log := event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}}
+	var data []byte
+	if target.registry != nil {
+		schemaID, err := target.registry.SchemaID(context.Background())
+		if err != nil {
+			return err
+		}
+		data = encodeAvroLog(schemaID, log)
+	} else {
+		data, err = json.Marshal(log)
+		if err != nil {
+			return err
+		}
 	}
 
 	msg := sarama.ProducerMessage{
@@ -273,6 +344,10 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 	config.Net.SASL.User = args.SASL.User
 	config.Net.SASL.Password = args.SASL.Password
 	initScramClient(args, config) // initializes configured scram client.
+	if args.SASL.Mechanism == "oauthbearer" {
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(sarama.SASLTypeOAuth)
+		config.Net.SASL.TokenProvider = newKafkaOAuthTokenProvider(args)
+	}
 	config.Net.SASL.Enable = args.SASL.Enable
 
 	tlsConfig, err := saramatls.NewConfig(args.TLS.ClientTLSCert, args.TLS.ClientTLSKey)
@@ -294,6 +369,11 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 
 	target.config = config
 
+	if args.Encoding == KafkaEncodingAvro {
+		target.registry = newKafkaSchemaRegistry((*url.URL)(args.SchemaRegistry.URL), args.SchemaRegistry.Username,
+			args.SchemaRegistry.Password, args.Topic, args.Transport)
+	}
+
 	brokers := []string{}
 	for _, broker := range args.Brokers {
 		brokers = append(brokers, broker.String())
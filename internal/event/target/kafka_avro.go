@@ -0,0 +1,250 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Kafka message encodings
+const (
+	KafkaEncodingJSON = "json"
+	KafkaEncodingAvro = "avro"
+)
+
+// kafkaAvroSchema - Avro schema of the event.Log sent to Kafka, registered
+// with the schema registry under the "<topic>-value" subject.
+const kafkaAvroSchema = `{
+  "type": "record",
+  "name": "Log",
+  "namespace": "io.minio.event",
+  "fields": [
+    {"name": "EventName", "type": "string"},
+    {"name": "Key", "type": "string"},
+    {"name": "Records", "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Event",
+      "fields": [
+        {"name": "eventVersion", "type": "string"},
+        {"name": "eventSource", "type": "string"},
+        {"name": "awsRegion", "type": "string"},
+        {"name": "eventTime", "type": "string"},
+        {"name": "eventName", "type": "string"},
+        {"name": "userIdentity", "type": {"type": "record", "name": "Identity", "fields": [
+          {"name": "principalId", "type": "string"}
+        ]}},
+        {"name": "requestParameters", "type": {"type": "map", "values": "string"}},
+        {"name": "responseElements", "type": {"type": "map", "values": "string"}},
+        {"name": "s3", "type": {"type": "record", "name": "Metadata", "fields": [
+          {"name": "s3SchemaVersion", "type": "string"},
+          {"name": "configurationId", "type": "string"},
+          {"name": "bucket", "type": {"type": "record", "name": "Bucket", "fields": [
+            {"name": "name", "type": "string"},
+            {"name": "ownerIdentity", "type": "Identity"},
+            {"name": "arn", "type": "string"}
+          ]}},
+          {"name": "object", "type": {"type": "record", "name": "Object", "fields": [
+            {"name": "key", "type": "string"},
+            {"name": "size", "type": "long"},
+            {"name": "eTag", "type": "string"},
+            {"name": "contentType", "type": "string"},
+            {"name": "userMetadata", "type": {"type": "map", "values": "string"}},
+            {"name": "versionId", "type": "string"},
+            {"name": "sequencer", "type": "string"}
+          ]}}
+        ]}},
+        {"name": "source", "type": {"type": "record", "name": "Source", "fields": [
+          {"name": "host", "type": "string"},
+          {"name": "port", "type": "string"},
+          {"name": "userAgent", "type": "string"}
+        ]}}
+      ]
+    }}}
+  ]
+}`
+
+// avroWriter - writes values in the Avro binary encoding.
+type avroWriter struct {
+	bytes.Buffer
+}
+
+func (w *avroWriter) writeLong(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	// binary.PutVarint uses the same zig-zag encoding as Avro.
+	n := binary.PutVarint(buf[:], v)
+	w.Write(buf[:n])
+}
+
+func (w *avroWriter) writeString(s string) {
+	w.writeLong(int64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *avroWriter) writeMap(m map[string]string) {
+	if len(m) > 0 {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.writeLong(int64(len(keys)))
+		for _, k := range keys {
+			w.writeString(k)
+			w.writeString(m[k])
+		}
+	}
+	// A zero length block terminates the map.
+	w.writeLong(0)
+}
+
+func (w *avroWriter) writeEvent(e event.Event) {
+	w.writeString(e.EventVersion)
+	w.writeString(e.EventSource)
+	w.writeString(e.AwsRegion)
+	w.writeString(e.EventTime)
+	w.writeString(e.EventName.String())
+	w.writeString(e.UserIdentity.PrincipalID)
+	w.writeMap(e.RequestParameters)
+	w.writeMap(e.ResponseElements)
+	w.writeString(e.S3.SchemaVersion)
+	w.writeString(e.S3.ConfigurationID)
+	w.writeString(e.S3.Bucket.Name)
+	w.writeString(e.S3.Bucket.OwnerIdentity.PrincipalID)
+	w.writeString(e.S3.Bucket.ARN)
+	w.writeString(e.S3.Object.Key)
+	w.writeLong(e.S3.Object.Size)
+	w.writeString(e.S3.Object.ETag)
+	w.writeString(e.S3.Object.ContentType)
+	w.writeMap(e.S3.Object.UserMetadata)
+	w.writeString(e.S3.Object.VersionID)
+	w.writeString(e.S3.Object.Sequencer)
+	w.writeString(e.Source.Host)
+	w.writeString(e.Source.Port)
+	w.writeString(e.Source.UserAgent)
+}
+
+// encodeAvroLog - encodes the log in the Confluent wire format, the Avro
+// binary encoding prefixed by a zero magic byte and the schema ID.
+func encodeAvroLog(schemaID uint32, log event.Log) []byte {
+	var w avroWriter
+	w.WriteByte(0)
+	var id [4]byte
+	binary.BigEndian.PutUint32(id[:], schemaID)
+	w.Write(id[:])
+
+	w.writeString(log.EventName.String())
+	w.writeString(log.Key)
+	if len(log.Records) > 0 {
+		w.writeLong(int64(len(log.Records)))
+		for _, e := range log.Records {
+			w.writeEvent(e)
+		}
+	}
+	w.writeLong(0)
+	return w.Bytes()
+}
+
+// kafkaSchemaRegistry - registers the event schema with a Confluent
+// compatible schema registry, once per target.
+type kafkaSchemaRegistry struct {
+	url      *url.URL
+	username string
+	password string
+	subject  string
+	client   *http.Client
+
+	mu       sync.Mutex
+	schemaID uint32
+}
+
+func newKafkaSchemaRegistry(u *url.URL, username, password, topic string, transport http.RoundTripper) *kafkaSchemaRegistry {
+	return &kafkaSchemaRegistry{
+		url:      u,
+		username: username,
+		password: password,
+		subject:  topic + "-value",
+		client:   &http.Client{Transport: transport},
+	}
+}
+
+// SchemaID - returns the ID of the registered event schema, registering it
+// on first use. Registration is idempotent, the registry returns the same
+// ID for an already registered schema.
+func (r *kafkaSchemaRegistry) SchemaID(ctx context.Context) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.schemaID != 0 {
+		return r.schemaID, nil
+	}
+
+	body, err := json.Marshal(struct {
+		Schema string `json:"schema"`
+	}{Schema: kafkaAvroSchema})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	u := *r.url
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/subjects/" + url.PathEscape(r.subject) + "/versions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(xhttp.ContentType, "application/vnd.schemaregistry.v1+json")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, errNotConnected
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("schema registry %s returned '%s': %s", r.url.Host, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		ID uint32 `json:"id"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+	r.schemaID = result.ID
+	return r.schemaID, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func TestAvroWriter(t *testing.T) {
+	testCases := []struct {
+		write    func(w *avroWriter)
+		expected []byte
+	}{
+		{func(w *avroWriter) { w.writeLong(0) }, []byte{0x00}},
+		{func(w *avroWriter) { w.writeLong(-1) }, []byte{0x01}},
+		{func(w *avroWriter) { w.writeLong(1) }, []byte{0x02}},
+		{func(w *avroWriter) { w.writeLong(64) }, []byte{0x80, 0x01}},
+		{func(w *avroWriter) { w.writeString("foo") }, []byte{0x06, 'f', 'o', 'o'}},
+		{func(w *avroWriter) { w.writeMap(nil) }, []byte{0x00}},
+		{func(w *avroWriter) { w.writeMap(map[string]string{"b": "2", "a": "1"}) },
+			[]byte{0x04, 0x02, 'a', 0x02, '1', 0x02, 'b', 0x02, '2', 0x00}},
+	}
+
+	for i, testCase := range testCases {
+		var w avroWriter
+		testCase.write(&w)
+		if !bytes.Equal(w.Bytes(), testCase.expected) {
+			t.Errorf("test %d: expected %x, got %x", i+1, testCase.expected, w.Bytes())
+		}
+	}
+}
+
+func TestEncodeAvroLog(t *testing.T) {
+	if !json.Valid([]byte(kafkaAvroSchema)) {
+		t.Fatal("event schema is not valid JSON")
+	}
+
+	data := encodeAvroLog(258, event.Log{EventName: event.ObjectCreatedPut, Key: "bucket/object"})
+	expected := []byte{0x00, 0x00, 0x00, 0x01, 0x02}
+	if !bytes.HasPrefix(data, expected) {
+		t.Fatalf("expected wire format header %x, got %x", expected, data[:5])
+	}
+
+	var w avroWriter
+	w.writeString(event.ObjectCreatedPut.String())
+	w.writeString("bucket/object")
+	w.writeLong(0)
+	if !bytes.Equal(data[5:], w.Bytes()) {
+		t.Fatalf("expected %x, got %x", w.Bytes(), data[5:])
+	}
+}
+
+func TestKafkaSchemaRegistry(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/subjects/bucketevents-value/versions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if user, pass, _ := r.BasicAuth(); user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := newKafkaSchemaRegistry(u, "user", "pass", "bucketevents", http.DefaultTransport)
+	for i := 0; i < 2; i++ {
+		id, err := registry.SchemaID(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 42 {
+			t.Fatalf("expected schema ID 42, got %d", id)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected the schema to be registered once, got %d requests", requests)
+	}
+
+	registry = newKafkaSchemaRegistry(u, "user", "wrong", "bucketevents", http.DefaultTransport)
+	if _, err = registry.SchemaID(context.Background()); err == nil {
+		t.Fatal("expected schema registration to fail")
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// kafkaOAuthTokenProvider - fetches SASL/OAUTHBEARER access tokens from
+// an OAuth2 token endpoint using the client credentials grant.
+type kafkaOAuthTokenProvider struct {
+	tokenSource oauth2.TokenSource
+}
+
+func newKafkaOAuthTokenProvider(args KafkaArgs) *kafkaOAuthTokenProvider {
+	cfg := clientcredentials.Config{
+		ClientID:     args.SASL.OAuth.ClientID,
+		ClientSecret: args.SASL.OAuth.ClientSecret,
+		TokenURL:     args.SASL.OAuth.TokenURL.String(),
+		Scopes:       args.SASL.OAuth.Scopes,
+	}
+	ctx := context.Background()
+	if args.Transport != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+			Transport: args.Transport,
+			Timeout:   10 * time.Second,
+		})
+	}
+	// The token source caches the token and refreshes it once expired.
+	return &kafkaOAuthTokenProvider{tokenSource: cfg.TokenSource(ctx)}
+}
+
+// Token - implements sarama.AccessTokenProvider.
+func (p *kafkaOAuthTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.AccessToken{Token: token.AccessToken}, nil
+}
@@ -124,6 +124,11 @@ func (target *MQTTTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *MQTTTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *MQTTTarget) IsActive() (bool, error) {
 	if !target.client.IsConnectionOpen() {
@@ -161,6 +161,11 @@ func (target *MySQLTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *MySQLTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *MySQLTarget) IsActive() (bool, error) {
 	if target.db == nil {
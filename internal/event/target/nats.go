@@ -214,6 +214,11 @@ func (target *NATSTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *NATSTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *NATSTarget) IsActive() (bool, error) {
 	var connErr error
@@ -0,0 +1,416 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Pulsar constants
+const (
+	PulsarURL            = "url"
+	PulsarTopic          = "topic"
+	PulsarTopicPerBucket = "topic_per_bucket"
+	PulsarAuthToken      = "auth_token"
+	PulsarTLSSkipVerify  = "tls_skip_verify"
+	PulsarClientTLSCert  = "client_tls_cert"
+	PulsarClientTLSKey   = "client_tls_key"
+	PulsarQueueDir       = "queue_dir"
+	PulsarQueueLimit     = "queue_limit"
+
+	EnvPulsarEnable         = "MINIO_NOTIFY_PULSAR_ENABLE"
+	EnvPulsarURL            = "MINIO_NOTIFY_PULSAR_URL"
+	EnvPulsarTopic          = "MINIO_NOTIFY_PULSAR_TOPIC"
+	EnvPulsarTopicPerBucket = "MINIO_NOTIFY_PULSAR_TOPIC_PER_BUCKET"
+	EnvPulsarAuthToken      = "MINIO_NOTIFY_PULSAR_AUTH_TOKEN"
+	EnvPulsarTLSSkipVerify  = "MINIO_NOTIFY_PULSAR_TLS_SKIP_VERIFY"
+	EnvPulsarClientTLSCert  = "MINIO_NOTIFY_PULSAR_CLIENT_TLS_CERT"
+	EnvPulsarClientTLSKey   = "MINIO_NOTIFY_PULSAR_CLIENT_TLS_KEY"
+	EnvPulsarQueueDir       = "MINIO_NOTIFY_PULSAR_QUEUE_DIR"
+	EnvPulsarQueueLimit     = "MINIO_NOTIFY_PULSAR_QUEUE_LIMIT"
+)
+
+const (
+	pulsarDefaultTenant    = "public"
+	pulsarDefaultNamespace = "default"
+
+	pulsarSendTimeout = 30 * time.Second
+)
+
+// PulsarArgs - Pulsar target arguments.
+type PulsarArgs struct {
+	Enable bool `json:"enable"`
+	// URL of the Pulsar web service, events are published
+	// through its WebSocket producer API.
+	URL   xnet.URL `json:"url"`
+	Topic string   `json:"topic"`
+	// TopicPerBucket publishes the events of each bucket
+	// to its own topic, named '<topic>-<bucket>'.
+	TopicPerBucket bool   `json:"topicPerBucket"`
+	AuthToken      string `json:"authToken"`
+	TLS            struct {
+		RootCAs       *x509.CertPool `json:"-"`
+		SkipVerify    bool           `json:"skipVerify"`
+		ClientTLSCert string         `json:"clientTLSCert"`
+		ClientTLSKey  string         `json:"clientTLSKey"`
+	} `json:"tls"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// Validate PulsarArgs fields
+func (p PulsarArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+	if p.URL.IsEmpty() {
+		return errors.New("empty url")
+	}
+	if p.Topic == "" {
+		return errors.New("empty topic")
+	}
+	if _, err := parsePulsarTopic(p.Topic); err != nil {
+		return err
+	}
+	if p.TLS.ClientTLSCert != "" && p.TLS.ClientTLSKey == "" || p.TLS.ClientTLSCert == "" && p.TLS.ClientTLSKey != "" {
+		return errors.New("cert and key must be specified as a pair")
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// parsePulsarTopic returns the WebSocket API path of a topic, both fully
+// qualified 'persistent://tenant/namespace/topic' and short topic names,
+// which live in the default tenant and namespace, are accepted.
+func parsePulsarTopic(topic string) (string, error) {
+	domain := "persistent"
+	if i := strings.Index(topic, "://"); i >= 0 {
+		domain, topic = topic[:i], topic[i+3:]
+		if domain != "persistent" && domain != "non-persistent" {
+			return "", fmt.Errorf("invalid topic domain %s", domain)
+		}
+	}
+	parts := strings.Split(topic, "/")
+	switch len(parts) {
+	case 1:
+		parts = []string{pulsarDefaultTenant, pulsarDefaultNamespace, parts[0]}
+	case 3:
+	default:
+		return "", fmt.Errorf("invalid topic name %s", topic)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("invalid topic name %s", topic)
+		}
+	}
+	return domain + "/" + strings.Join(parts, "/"), nil
+}
+
+// pulsarMessage is a message sent to the WebSocket producer API.
+type pulsarMessage struct {
+	Payload    string            `json:"payload"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Context    string            `json:"context,omitempty"`
+	Key        string            `json:"key,omitempty"`
+}
+
+// pulsarResponse is the acknowledgement of a sent message.
+type pulsarResponse struct {
+	Result    string `json:"result"`
+	MessageID string `json:"messageId"`
+	ErrorMsg  string `json:"errorMsg"`
+	Context   string `json:"context"`
+}
+
+// PulsarTarget - Pulsar target.
+type PulsarTarget struct {
+	id         event.TargetID
+	args       PulsarArgs
+	dialer     *websocket.Dialer
+	header     http.Header
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+
+	// producers holds one WebSocket producer connection per topic.
+	mu        sync.Mutex
+	producers map[string]*websocket.Conn
+}
+
+// ID - returns target ID.
+func (target *PulsarTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *PulsarTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// Store - returns the queue store of the target, nil if not configured.
+func (target *PulsarTarget) Store() Store {
+	return target.store
+}
+
+// IsActive - Return true if target is up and active
+func (target *PulsarTarget) IsActive() (bool, error) {
+	u := url.URL(target.args.URL)
+	host := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if target.args.URL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(host, port)
+	}
+	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
+	if err != nil {
+		return false, errNotConnected
+	}
+	conn.Close()
+	return true, nil
+}
+
+// Save - saves the events to the store which will be replayed when the Pulsar connection is active.
+func (target *PulsarTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	if _, err := target.IsActive(); err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
+// topic returns the topic events of a bucket are published to.
+func (target *PulsarTarget) topic(bucket string) string {
+	if target.args.TopicPerBucket {
+		return target.args.Topic + "-" + bucket
+	}
+	return target.args.Topic
+}
+
+// producer returns the producer connection of a topic, dialing it if needed.
+func (target *PulsarTarget) producer(topic string) (*websocket.Conn, error) {
+	if conn, ok := target.producers[topic]; ok {
+		return conn, nil
+	}
+
+	path, err := parsePulsarTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+	u := url.URL{
+		Scheme: "ws",
+		Host:   target.args.URL.Host,
+		Path:   "/ws/v2/producer/" + path,
+	}
+	if target.args.URL.Scheme == "https" {
+		u.Scheme = "wss"
+	}
+
+	conn, resp, err := target.dialer.Dial(u.String(), target.header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("unable to create producer for topic %s: %v", topic, resp.Status)
+		}
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return nil, errNotConnected
+		}
+		return nil, err
+	}
+	target.producers[topic] = conn
+	return conn, nil
+}
+
+// send - sends an event to Pulsar.
+func (target *PulsarTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	msg := pulsarMessage{
+		Payload: base64.StdEncoding.EncodeToString(data),
+		Properties: map[string]string{
+			"eventName": eventData.EventName.String(),
+			"bucket":    eventData.S3.Bucket.Name,
+		},
+		Context: eventData.S3.Object.Sequencer,
+		// Messages of the same object key are routed to the same
+		// partition, preserving their order.
+		Key: key,
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	topic := target.topic(eventData.S3.Bucket.Name)
+	conn, err := target.producer(topic)
+	if err != nil {
+		return err
+	}
+
+	err = target.publish(conn, msg)
+	if err != nil {
+		// Drop the connection, it is re-established on the next send.
+		conn.Close()
+		delete(target.producers, topic)
+	}
+	return err
+}
+
+// publish writes msg and waits for the broker acknowledgement.
+func (target *PulsarTarget) publish(conn *websocket.Conn, msg pulsarMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(pulsarSendTimeout))
+	if err := conn.WriteJSON(msg); err != nil {
+		return errNotConnected
+	}
+
+	conn.SetReadDeadline(time.Now().Add(pulsarSendTimeout))
+	var resp pulsarResponse
+	if err := conn.ReadJSON(&resp); err != nil {
+		return errNotConnected
+	}
+	if resp.Result != "ok" {
+		return fmt.Errorf("sending event failed with %s: %s", resp.Result, resp.ErrorMsg)
+	}
+	return nil
+}
+
+// Send - reads an event from store and sends it to Pulsar.
+func (target *PulsarTarget) Send(eventKey string) error {
+	if _, err := target.IsActive(); err != nil {
+		return err
+	}
+
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes all producer connections.
+func (target *PulsarTarget) Close() error {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	for topic, conn := range target.producers {
+		conn.Close()
+		delete(target.producers, topic)
+	}
+	return nil
+}
+
+// NewPulsarTarget - creates new Pulsar target.
+func NewPulsarTarget(id string, args PulsarArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*PulsarTarget, error) {
+	target := &PulsarTarget{
+		id:         event.TargetID{ID: id, Name: "pulsar"},
+		args:       args,
+		header:     http.Header{},
+		producers:  make(map[string]*websocket.Conn),
+		loggerOnce: loggerOnce,
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            args.TLS.RootCAs,
+		InsecureSkipVerify: args.TLS.SkipVerify,
+	}
+	if args.TLS.ClientTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(args.TLS.ClientTLSCert, args.TLS.ClientTLSKey)
+		if err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	target.dialer = &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:  tlsConfig,
+	}
+	if args.AuthToken != "" {
+		target.header.Set("Authorization", "Bearer "+args.AuthToken)
+	}
+
+	var store Store
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pulsar-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err := target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestParsePulsarTopic(t *testing.T) {
+	testCases := []struct {
+		topic    string
+		expected string
+		wantErr  bool
+	}{
+		{"minio", "persistent/public/default/minio", false},
+		{"persistent://tenant/ns/minio", "persistent/tenant/ns/minio", false},
+		{"non-persistent://tenant/ns/minio", "non-persistent/tenant/ns/minio", false},
+		{"kafka://tenant/ns/minio", "", true},
+		{"tenant/minio", "", true},
+		{"persistent://tenant//minio", "", true},
+	}
+	for i, testCase := range testCases {
+		got, err := parsePulsarTopic(testCase.topic)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestPulsarTargetSend(t *testing.T) {
+	type published struct {
+		path  string
+		auth  string
+		msg   pulsarMessage
+		event event.Log
+	}
+	publishedCh := make(chan published, 1)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg pulsarMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			p := published{path: r.URL.Path, auth: r.Header.Get("Authorization"), msg: msg}
+			data, _ := base64.StdEncoding.DecodeString(msg.Payload)
+			json.Unmarshal(data, &p.event)
+			publishedCh <- p
+			conn.WriteJSON(pulsarResponse{Result: "ok", MessageID: "CAAQAw==", Context: msg.Context})
+		}
+	}))
+	defer server.Close()
+
+	u, err := xnet.ParseHTTPURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := PulsarArgs{
+		Enable:         true,
+		URL:            *u,
+		Topic:          "persistent://tenant/ns/minio",
+		TopicPerBucket: true,
+		AuthToken:      "token",
+	}
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	target, err := NewPulsarTarget("1", args, nil, func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	var ev event.Event
+	ev.EventName = event.ObjectCreatedPut
+	ev.S3.Bucket.Name = "images"
+	ev.S3.Object.Key = "photos%2Fa.jpg"
+	if err = target.Save(ev); err != nil {
+		t.Fatal(err)
+	}
+
+	p := <-publishedCh
+	if p.path != "/ws/v2/producer/persistent/tenant/ns/minio-images" {
+		t.Errorf("unexpected producer path %s", p.path)
+	}
+	if p.auth != "Bearer token" {
+		t.Errorf("unexpected authorization header %s", p.auth)
+	}
+	if p.msg.Key != "images/photos/a.jpg" || p.event.Key != "images/photos/a.jpg" {
+		t.Errorf("unexpected message key %s, event key %s", p.msg.Key, p.event.Key)
+	}
+}
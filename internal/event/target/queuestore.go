@@ -170,6 +170,13 @@ func (store *QueueStore) del(key string) error {
 	return nil
 }
 
+// Len - returns the number of entries in the store.
+func (store *QueueStore) Len() uint64 {
+	store.RLock()
+	defer store.RUnlock()
+	return store.currentEntries
+}
+
 // List - lists all files from the directory.
 func (store *QueueStore) List() ([]string, error) {
 	store.RLock()
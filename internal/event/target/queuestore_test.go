@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 )
@@ -212,3 +213,67 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatalf("Expected List() to fail with os.ErrNotExist, %s", err)
 	}
 }
+
+// TestStoredEvents - tests for listing and purging stored events.
+func TestStoredEvents(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	start := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		e := testEvent
+		e.EventTime = start.Add(time.Duration(i) * time.Hour).Format(event.AMZTimeFormat)
+		if err := store.Put(e); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	if store.Len() != 6 {
+		t.Fatalf("Len() Expected: 6, got %d", store.Len())
+	}
+
+	events, err := ListStoredEvents(store, StoreFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 6 {
+		t.Fatalf("ListStoredEvents() Expected: 6, got %d", len(events))
+	}
+
+	events, err = ListStoredEvents(store, StoreFilter{MaxEvents: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ListStoredEvents() with MaxEvents Expected: 2, got %d", len(events))
+	}
+
+	filter := StoreFilter{From: start.Add(time.Hour), To: start.Add(3 * time.Hour)}
+	events, err = ListStoredEvents(store, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ListStoredEvents() with time range Expected: 2, got %d", len(events))
+	}
+
+	purged, err := PurgeStoredEvents(store, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 2 || store.Len() != 4 {
+		t.Fatalf("PurgeStoredEvents() Expected: 2 purged, 4 left, got %d purged, %d left", purged, store.Len())
+	}
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 4 {
+		t.Fatalf("List() Expected: 4, got %d", len(names))
+	}
+}
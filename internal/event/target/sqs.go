@@ -0,0 +1,426 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// SQS constants
+const (
+	SQSQueueURL          = "queue_url"
+	SQSRegion            = "region"
+	SQSAccessKey         = "access_key"
+	SQSSecretKey         = "secret_key"
+	SQSSessionToken      = "session_token"
+	SQSMessageGroupDepth = "message_group_depth"
+	SQSQueueDir          = "queue_dir"
+	SQSQueueLimit        = "queue_limit"
+
+	EnvSQSEnable            = "MINIO_NOTIFY_SQS_ENABLE"
+	EnvSQSQueueURL          = "MINIO_NOTIFY_SQS_QUEUE_URL"
+	EnvSQSRegion            = "MINIO_NOTIFY_SQS_REGION"
+	EnvSQSAccessKey         = "MINIO_NOTIFY_SQS_ACCESS_KEY"
+	EnvSQSSecretKey         = "MINIO_NOTIFY_SQS_SECRET_KEY"
+	EnvSQSSessionToken      = "MINIO_NOTIFY_SQS_SESSION_TOKEN"
+	EnvSQSMessageGroupDepth = "MINIO_NOTIFY_SQS_MESSAGE_GROUP_DEPTH"
+	EnvSQSQueueDir          = "MINIO_NOTIFY_SQS_QUEUE_DIR"
+	EnvSQSQueueLimit        = "MINIO_NOTIFY_SQS_QUEUE_LIMIT"
+)
+
+const (
+	sqsAPIVersion = "2012-11-05"
+	sqsService    = "sqs"
+
+	// Maximum length of MessageGroupId and MessageDeduplicationId.
+	sqsMaxIDLength = 128
+)
+
+// SQSArgs - SQS target arguments.
+type SQSArgs struct {
+	Enable       bool            `json:"enable"`
+	QueueURL     xnet.URL        `json:"queueURL"`
+	Region       string          `json:"region"`
+	AccessKey    string          `json:"accessKey"`
+	SecretKey    string          `json:"secretKey"`
+	SessionToken string          `json:"sessionToken"`
+	Transport    *http.Transport `json:"-"`
+
+	// MessageGroupDepth is the number of object key path components
+	// used along with the bucket name to derive the MessageGroupId of
+	// FIFO queues, '0' uses the full object key so that ordering is
+	// preserved per object.
+	MessageGroupDepth int `json:"messageGroupDepth"`
+
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// IsFIFO returns true if the configured queue is a FIFO queue,
+// FIFO queue names always carry the '.fifo' suffix.
+func (s SQSArgs) IsFIFO() bool {
+	return strings.HasSuffix(s.QueueURL.Path, ".fifo")
+}
+
+// Validate SQSArgs fields
+func (s SQSArgs) Validate() error {
+	if !s.Enable {
+		return nil
+	}
+	if s.QueueURL.IsEmpty() {
+		return errors.New("empty queue url")
+	}
+	if s.Region == "" {
+		return errors.New("empty region")
+	}
+	if s.AccessKey == "" && s.SecretKey != "" || s.AccessKey != "" && s.SecretKey == "" {
+		return errors.New("access key and secret key must be specified as a pair")
+	}
+	if s.MessageGroupDepth < 0 {
+		return errors.New("message group depth cannot be negative")
+	}
+	if s.QueueDir != "" {
+		if !filepath.IsAbs(s.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// SQSTarget - Amazon SQS target.
+type SQSTarget struct {
+	id         event.TargetID
+	args       SQSArgs
+	httpClient *http.Client
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *SQSTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *SQSTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// Store - returns the queue store of the target, nil if not configured.
+func (target *SQSTarget) Store() Store {
+	return target.store
+}
+
+// IsActive - Return true if target is up and active
+func (target *SQSTarget) IsActive() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	form := url.Values{}
+	form.Set("Action", "GetQueueAttributes")
+	form.Set("AttributeName.1", "QueueArn")
+
+	resp, err := target.do(ctx, form)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) || errors.Is(err, context.DeadlineExceeded) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	// No network failure i.e response from the target means its up
+	return true, nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when the SQS connection is active.
+func (target *SQSTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	err := target.send(eventData)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+	}
+	return err
+}
+
+// messageGroupID returns the MessageGroupId for an object, events of
+// objects sharing the same group are delivered in order by SQS.
+func (target *SQSTarget) messageGroupID(bucket, object string) string {
+	groupID := bucket + "/" + object
+	if depth := target.args.MessageGroupDepth; depth > 0 {
+		components := strings.SplitN(object, "/", depth+1)
+		if len(components) > depth {
+			components = components[:depth]
+		}
+		groupID = bucket + "/" + strings.Join(components, "/")
+	}
+	return sqsSafeID(groupID)
+}
+
+// messageDeduplicationID returns a MessageDeduplicationId unique to an
+// event, such that an event replayed from the queue store within the
+// SQS deduplication interval is only delivered once.
+func messageDeduplicationID(key string, eventData event.Event) string {
+	sum := sha256.Sum256([]byte(key + "\x00" + eventData.EventName.String() + "\x00" +
+		eventData.S3.Object.VersionID + "\x00" + eventData.S3.Object.Sequencer))
+	return hex.EncodeToString(sum[:])
+}
+
+// sqsSafeID returns id if it can be used as is as a MessageGroupId,
+// SQS only allows up to 128 printable ASCII characters, longer or
+// non ASCII identifiers are replaced by their SHA-256 sum.
+func sqsSafeID(id string) string {
+	valid := len(id) <= sqsMaxIDLength
+	for i := 0; valid && i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			valid = false
+		}
+	}
+	if valid {
+		return id
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// send - sends an event to the SQS queue.
+func (target *SQSTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("Action", "SendMessage")
+	form.Set("MessageBody", string(data))
+	if target.args.IsFIFO() {
+		form.Set("MessageGroupId", target.messageGroupID(eventData.S3.Bucket.Name, objectName))
+		form.Set("MessageDeduplicationId", messageDeduplicationID(key, eventData))
+	}
+
+	resp, err := target.do(context.Background(), form)
+	if err != nil {
+		target.Close()
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sending event failed with %v: %s", resp.Status, respBody)
+	}
+
+	return nil
+}
+
+// do sends a signed SQS query API request to the queue URL.
+func (target *SQSTarget) do(ctx context.Context, form url.Values) (*http.Response, error) {
+	form.Set("Version", sqsAPIVersion)
+	body := form.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.args.QueueURL.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if target.args.AccessKey != "" {
+		req = signSQSRequest(req, []byte(body), target.args.AccessKey, target.args.SecretKey,
+			target.args.SessionToken, target.args.Region, time.Now().UTC())
+	}
+	return target.httpClient.Do(req)
+}
+
+// signSQSRequest signs req with AWS signature version 4 for the SQS service.
+func signSQSRequest(req *http.Request, body []byte, accessKey, secretKey, sessionToken, region string, t time.Time) *http.Request {
+	return signV4(req, body, accessKey, secretKey, sessionToken, region, sqsService, t)
+}
+
+// signV4 signs req, whose payload is body, with AWS signature version 4
+// for the given service at time t, see
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html.
+// The host and all headers set on req are signed.
+func signV4(req *http.Request, body []byte, accessKey, secretKey, sessionToken, region, service string, t time.Time) *http.Request {
+	const (
+		algorithm  = "AWS4-HMAC-SHA256"
+		iso8601    = "20060102T150405Z"
+		yyyymmdd   = "20060102"
+		terminator = "aws4_request"
+	)
+
+	req.Header.Set("X-Amz-Date", t.Format(iso8601))
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if k == "authorization" || k == "user-agent" {
+			continue
+		}
+		headers[k] = strings.Join(strings.Fields(strings.Join(v, ",")), " ")
+	}
+	signedHeaders := make([]string, 0, len(headers))
+	for k := range headers {
+		signedHeaders = append(signedHeaders, k)
+	}
+	sort.Strings(signedHeaders)
+	var canonicalHeaders strings.Builder
+	for _, k := range signedHeaders {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{t.Format(yyyymmdd), region, service, terminator}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		algorithm,
+		t.Format(iso8601),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, v := range []string{t.Format(yyyymmdd), region, service, terminator} {
+		key = sumHMAC(key, []byte(v))
+	}
+	signature := hex.EncodeToString(sumHMAC(key, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", algorithm+" Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+", Signature="+signature)
+	return req
+}
+
+// sumHMAC returns the HMAC-SHA256 of data with key.
+func sumHMAC(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// Send - reads an event from store and sends it to SQS.
+func (target *SQSTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes idle connections to SQS.
+func (target *SQSTarget) Close() error {
+	target.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// NewSQSTarget - creates new SQS target.
+func NewSQSTarget(ctx context.Context, id string, args SQSArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), transport *http.Transport, test bool) (*SQSTarget, error) {
+	var store Store
+	target := &SQSTarget{
+		id:         event.TargetID{ID: id, Name: "sqs"},
+		args:       args,
+		httpClient: &http.Client{Transport: transport},
+		loggerOnce: loggerOnce,
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-sqs-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err := target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+	}
+
+	return target, nil
+}
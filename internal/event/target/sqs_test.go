@@ -0,0 +1,168 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestSQSMessageGroupID(t *testing.T) {
+	testCases := []struct {
+		depth    int
+		object   string
+		expected string
+	}{
+		{0, "photos/2021/a.jpg", "images/photos/2021/a.jpg"},
+		{1, "photos/2021/a.jpg", "images/photos"},
+		{2, "photos/2021/a.jpg", "images/photos/2021"},
+		{5, "photos/2021/a.jpg", "images/photos/2021/a.jpg"},
+		{1, "a.jpg", "images/a.jpg"},
+	}
+	for i, testCase := range testCases {
+		target := &SQSTarget{args: SQSArgs{MessageGroupDepth: testCase.depth}}
+		if got := target.messageGroupID("images", testCase.object); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+
+	target := &SQSTarget{}
+	for _, object := range []string{strings.Repeat("a", 200), "photos/ä.jpg", "my photo.jpg"} {
+		got := target.messageGroupID("images", object)
+		if len(got) != 64 {
+			t.Errorf("expected %s to be replaced by its checksum, got %s", object, got)
+		}
+	}
+}
+
+func TestSQSMessageDeduplicationID(t *testing.T) {
+	var ev1, ev2 event.Event
+	ev1.EventName = event.ObjectCreatedPut
+	ev1.S3.Object.Sequencer = "1562A792DAA53426"
+	ev2 = ev1
+
+	if messageDeduplicationID("images/a.jpg", ev1) != messageDeduplicationID("images/a.jpg", ev2) {
+		t.Fatal("expected the same event to have the same deduplication id")
+	}
+	ev2.S3.Object.Sequencer = "1562A792DAA53427"
+	if messageDeduplicationID("images/a.jpg", ev1) == messageDeduplicationID("images/a.jpg", ev2) {
+		t.Fatal("expected different events to have different deduplication ids")
+	}
+}
+
+func TestSQSArgsIsFIFO(t *testing.T) {
+	u, err := xnet.ParseHTTPURL("https://sqs.us-east-1.amazonaws.com/123456789012/events.fifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(SQSArgs{QueueURL: *u}).IsFIFO() {
+		t.Fatal("expected queue to be a FIFO queue")
+	}
+	u, err = xnet.ParseHTTPURL("https://sqs.us-east-1.amazonaws.com/123456789012/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (SQSArgs{QueueURL: *u}).IsFIFO() {
+		t.Fatal("expected queue to be a standard queue")
+	}
+}
+
+func TestSignSQSRequest(t *testing.T) {
+	const body = "Action=SendMessage&MessageBody=hello&Version=2012-11-05"
+	testCases := []struct {
+		sessionToken string
+		expected     string
+	}{
+		{"", "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/sqs/aws4_request, " +
+			"SignedHeaders=content-type;host;x-amz-date, " +
+			"Signature=25d981d8c30a3873b281d5e901263cde81525565eece7945e8f5dd6ed7493e23"},
+		{"session-token", "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/sqs/aws4_request, " +
+			"SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, " +
+			"Signature=dee9d1e583531c39379ef672dd16fadd4507bbdec456a576fccefc3ec96eb669"},
+	}
+	date := time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	for i, testCase := range testCases {
+		req, err := http.NewRequest(http.MethodPost, "https://sqs.us-east-1.amazonaws.com/123456789012/MyQueue", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		req = signSQSRequest(req, []byte(body), "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			testCase.sessionToken, "us-east-1", date)
+		if got := req.Header.Get("Authorization"); got != testCase.expected {
+			t.Errorf("test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+		if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+			t.Errorf("test %d: expected X-Amz-Date 20150830T123600Z, got %q", i+1, got)
+		}
+	}
+}
+
+// TestSignV4 checks the signer against the AWS signature version 4 test
+// suite, see https://docs.aws.amazon.com/general/latest/gr/signature-v4-test-suite.html.
+func TestSignV4(t *testing.T) {
+	const (
+		credential   = "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+		sessionToken = "AQoDYXdzEPT//////////wEXAMPLEtc764bNrC9SAPBSM22wDOk4x4HIZ8j4FZTwdQWLWsKWHGBuFqwAeMicRXmxfpSPfIeoIYRqTflfKD8YUuwthAx7mSEI/qkPpKPi/kMcGdQrmGdeehM4IC1NtBmUpp2wUE8phUZampKsburEDy0KPkyQDYwT7WZ0wq5VSXDvp75YU9HFvlRd8Tx6q6fE8YQcHNVXAkiY9q6d+xo0rKwT38xVqr7ZD0u0iPPkUL64lIZbqBAz+scqKmlzm8FDrypNC9Yjc8fPOLn9FX9KSYvKTr4rvx3iSIlTJabIQwj2ICCR/oLxBA=="
+	)
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		contentType  string
+		body         string
+		sessionToken string
+		expected     string
+	}{
+		{"get-vanilla", http.MethodGet, "https://example.amazonaws.com/", "", "", "",
+			credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{"get-vanilla-query-order-key-case", http.MethodGet, "https://example.amazonaws.com/?Param2=value2&Param1=value1", "", "", "",
+			credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"},
+		{"post-vanilla", http.MethodPost, "https://example.amazonaws.com/", "", "", "",
+			credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b"},
+		{"post-x-www-form-urlencoded", http.MethodPost, "https://example.amazonaws.com/", "application/x-www-form-urlencoded", "Param1=value1", "",
+			credential + "SignedHeaders=content-type;host;x-amz-date, " +
+				"Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a"},
+		{"post-sts-header-before", http.MethodPost, "https://example.amazonaws.com/", "", "", sessionToken,
+			credential + "SignedHeaders=host;x-amz-date;x-amz-security-token, " +
+				"Signature=85d96828115b5dc0cfc3bd16ad9e210dd772bbebba041836c64533a82be05ead"},
+	}
+	date := time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	for _, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, testCase.url, strings.NewReader(testCase.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testCase.contentType != "" {
+			req.Header.Set("Content-Type", testCase.contentType)
+		}
+		req = signV4(req, []byte(testCase.body), "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			testCase.sessionToken, "us-east-1", "service", date)
+		if got := req.Header.Get("Authorization"); got != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.name, testCase.expected, got)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"syscall"
 	"time"
@@ -42,9 +43,16 @@ type Store interface {
 	Get(key string) (event.Event, error)
 	List() ([]string, error)
 	Del(key string) error
+	Len() uint64
 	Open() error
 }
 
+// StoreTarget - a target persisting undelivered events in a store.
+type StoreTarget interface {
+	event.Target
+	Store() Store
+}
+
 // replayEvents - Reads the events from the store and replays.
 func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), id event.TargetID) <-chan string {
 	eventKeyCh := make(chan string)
@@ -142,3 +150,89 @@ func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan str
 		}
 	}
 }
+
+// StoredEvent - an undelivered event held in a store.
+type StoredEvent struct {
+	Key   string      `json:"key"`
+	Event event.Event `json:"event"`
+}
+
+// StoreFilter - selects stored events by their event time,
+// zero times and a zero MaxEvents are unbounded.
+type StoreFilter struct {
+	From      time.Time
+	To        time.Time
+	MaxEvents int
+}
+
+func (f StoreFilter) match(e event.Event) bool {
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+	t, err := time.Parse(event.AMZTimeFormat, e.EventTime)
+	if err != nil {
+		return false
+	}
+	return !t.Before(f.From) && (f.To.IsZero() || t.Before(f.To))
+}
+
+// ListStoredEvents - returns the events held in store selected
+// by filter, oldest first.
+func ListStoredEvents(store Store, filter StoreFilter) ([]StoredEvent, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	var events []StoredEvent
+	for _, name := range names {
+		if filter.MaxEvents > 0 && len(events) >= filter.MaxEvents {
+			break
+		}
+		key := strings.TrimSuffix(name, eventExt)
+		e, err := store.Get(key)
+		if err != nil {
+			// Delivered or removed meanwhile.
+			continue
+		}
+		if filter.match(e) {
+			events = append(events, StoredEvent{Key: key, Event: e})
+		}
+	}
+	return events, nil
+}
+
+// ReplayStoredEvents - sends the events held in the store of target
+// selected by filter right away, instead of waiting for the next retry.
+// Events sent successfully are removed from the store.
+func ReplayStoredEvents(target StoreTarget, filter StoreFilter) (replayed int, err error) {
+	events, err := ListStoredEvents(target.Store(), filter)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range events {
+		if err = target.Send(e.Key); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// PurgeStoredEvents - removes the events held in store selected by
+// filter, they are never delivered.
+func PurgeStoredEvents(store Store, filter StoreFilter) (purged int, err error) {
+	events, err := ListStoredEvents(store, filter)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range events {
+		if err = store.Del(e.Key); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
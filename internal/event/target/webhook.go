@@ -20,7 +20,10 @@ package target
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +33,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -48,6 +52,9 @@ const (
 	WebhookClientCert = "client_cert"
 	WebhookClientKey  = "client_key"
 
+	WebhookHMACSecret    = "hmac_secret"
+	WebhookHMACTolerance = "hmac_tolerance"
+
 	EnvWebhookEnable     = "MINIO_NOTIFY_WEBHOOK_ENABLE"
 	EnvWebhookEndpoint   = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
 	EnvWebhookAuthToken  = "MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN"
@@ -55,8 +62,26 @@ const (
 	EnvWebhookQueueLimit = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
 	EnvWebhookClientCert = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
 	EnvWebhookClientKey  = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+
+	EnvWebhookHMACSecret    = "MINIO_NOTIFY_WEBHOOK_HMAC_SECRET"
+	EnvWebhookHMACTolerance = "MINIO_NOTIFY_WEBHOOK_HMAC_TOLERANCE"
 )
 
+// Webhook request signing headers
+const (
+	// WebhookSignatureTimestamp - unix time at which the request was signed.
+	WebhookSignatureTimestamp = "X-Minio-Signature-Timestamp"
+	// WebhookSignatureExpires - unix time after which receivers must
+	// reject the request, the timestamp plus the configured tolerance.
+	WebhookSignatureExpires = "X-Minio-Signature-Expires"
+	// WebhookSignature - hex encoded HMAC-SHA256 of the timestamp, the
+	// expiry and the request body, separated by newlines.
+	WebhookSignature = "X-Minio-Signature"
+)
+
+// DefaultWebhookHMACTolerance - validity of a signed webhook request.
+const DefaultWebhookHMACTolerance = 5 * time.Minute
+
 // WebhookArgs - Webhook target arguments.
 type WebhookArgs struct {
 	Enable     bool            `json:"enable"`
@@ -67,6 +92,10 @@ type WebhookArgs struct {
 	QueueLimit uint64          `json:"queueLimit"`
 	ClientCert string          `json:"clientCert"`
 	ClientKey  string          `json:"clientKey"`
+	HMAC       struct {
+		Secret    string        `json:"secret"`
+		Tolerance time.Duration `json:"tolerance"`
+	} `json:"hmac"`
 }
 
 // Validate WebhookArgs fields
@@ -85,6 +114,59 @@ func (w WebhookArgs) Validate() error {
 	if w.ClientCert != "" && w.ClientKey == "" || w.ClientCert == "" && w.ClientKey != "" {
 		return errors.New("cert and key must be specified as a pair")
 	}
+	if w.HMAC.Secret != "" && w.HMAC.Tolerance <= 0 {
+		return errors.New("hmac tolerance must be positive")
+	}
+	return nil
+}
+
+// webhookSignature - returns the hex encoded HMAC-SHA256 of the signed
+// request fields.
+func webhookSignature(secret []byte, timestamp, expires string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(expires))
+	mac.Write([]byte{'\n'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signWebhookRequest - sets the signature headers of a webhook request
+// with the given body.
+func signWebhookRequest(req *http.Request, secret []byte, tolerance time.Duration, body []byte, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	expires := strconv.FormatInt(now.Add(tolerance).Unix(), 10)
+	req.Header.Set(WebhookSignatureTimestamp, timestamp)
+	req.Header.Set(WebhookSignatureExpires, expires)
+	req.Header.Set(WebhookSignature, webhookSignature(secret, timestamp, expires, body))
+}
+
+// VerifyWebhookSignature - verifies that a webhook request with the given
+// headers and body was signed with secret and has not expired, for use by
+// receivers written in Go.
+func VerifyWebhookSignature(secret []byte, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get(WebhookSignatureTimestamp)
+	expires := header.Get(WebhookSignatureExpires)
+	signature, err := hex.DecodeString(header.Get(WebhookSignature))
+	if err != nil || len(signature) == 0 {
+		return errors.New("webhook signature missing or malformed")
+	}
+	expected, _ := hex.DecodeString(webhookSignature(secret, timestamp, expires, body))
+	if !hmac.Equal(signature, expected) {
+		return errors.New("webhook signature mismatch")
+	}
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("webhook signature timestamp malformed")
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New("webhook signature expiry malformed")
+	}
+	if now.Unix() > expiresAt || now.Add(DefaultWebhookHMACTolerance).Unix() < signedAt {
+		return errors.New("webhook signature expired")
+	}
 	return nil
 }
 
@@ -107,6 +189,11 @@ func (target *WebhookTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Store - returns the queue store of the target, nil if not configured.
+func (target *WebhookTarget) Store() Store {
+	return target.store
+}
+
 // IsActive - Return true if target is up and active
 func (target *WebhookTarget) IsActive() (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -179,6 +266,10 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if target.args.HMAC.Secret != "" {
+		signWebhookRequest(req, []byte(target.args.HMAC.Secret), target.args.HMAC.Tolerance, data, time.Now())
+	}
+
 	resp, err := target.httpClient.Do(req)
 	if err != nil {
 		target.Close()
@@ -235,6 +326,9 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 	}
 
 	if target.args.ClientCert != "" && target.args.ClientKey != "" {
+		// The transport is shared by all targets, the client certificate
+		// must only be presented to this endpoint.
+		transport = transport.Clone()
 		manager, err := certs.NewManager(ctx, target.args.ClientCert, target.args.ClientKey, tls.LoadX509KeyPair)
 		if err != nil {
 			return target, err
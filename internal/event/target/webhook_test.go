@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"EventName":"s3:ObjectCreated:Put"}`)
+	signedAt := time.Unix(1600000000, 0)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:8080", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signWebhookRequest(req, secret, time.Minute, body, signedAt)
+
+	testCases := []struct {
+		secret  []byte
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{secret, body, signedAt, false},
+		{secret, body, signedAt.Add(time.Minute), false},
+		{secret, body, signedAt.Add(time.Minute + time.Second), true},
+		{secret, body, signedAt.Add(-time.Hour), true},
+		{[]byte("wrong"), body, signedAt, true},
+		{secret, []byte(`{}`), signedAt, true},
+	}
+
+	for i, testCase := range testCases {
+		err := VerifyWebhookSignature(testCase.secret, req.Header, testCase.body, testCase.now)
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+
+	req.Header.Set(WebhookSignatureExpires, "1700000000")
+	if err = VerifyWebhookSignature(secret, req.Header, body, signedAt); err == nil {
+		t.Error("expected a tampered expiry to be rejected")
+	}
+}
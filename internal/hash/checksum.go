@@ -0,0 +1,267 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// ErrInvalidChecksum is returned when an invalid checksum is provided in headers.
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+// ChecksumType is an additional object checksum algorithm,
+// computed over the content of an object or object part.
+type ChecksumType uint8
+
+const (
+	// ChecksumNone indicates no checksum.
+	ChecksumNone ChecksumType = iota
+	// ChecksumInvalid indicates an unsupported checksum algorithm.
+	ChecksumInvalid
+	// ChecksumCRC32C indicates a CRC32 checksum with the Castagnoli polynomial.
+	ChecksumCRC32C
+	// ChecksumSHA256 indicates a SHA-256 checksum.
+	ChecksumSHA256
+)
+
+// NewChecksumType returns the checksum type of the algorithm name alg,
+// as sent in the x-amz-checksum-algorithm header.
+func NewChecksumType(alg string) ChecksumType {
+	switch strings.ToUpper(alg) {
+	case "":
+		return ChecksumNone
+	case "CRC32C":
+		return ChecksumCRC32C
+	case "SHA256":
+		return ChecksumSHA256
+	}
+	return ChecksumInvalid
+}
+
+// String returns the algorithm name of the checksum type.
+func (c ChecksumType) String() string {
+	switch c {
+	case ChecksumCRC32C:
+		return "CRC32C"
+	case ChecksumSHA256:
+		return "SHA256"
+	case ChecksumNone:
+		return ""
+	}
+	return "invalid"
+}
+
+// Key returns the header carrying checksums of this type.
+func (c ChecksumType) Key() string {
+	switch c {
+	case ChecksumCRC32C:
+		return xhttp.AmzChecksumCRC32C
+	case ChecksumSHA256:
+		return xhttp.AmzChecksumSHA256
+	}
+	return ""
+}
+
+// IsSet returns true if c is a supported checksum type.
+func (c ChecksumType) IsSet() bool {
+	return c == ChecksumCRC32C || c == ChecksumSHA256
+}
+
+// Hasher returns a new hash.Hash computing checksums of this type.
+func (c ChecksumType) Hasher() hash.Hash {
+	switch c {
+	case ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// RawByteLen returns the length of raw checksums of this type.
+func (c ChecksumType) RawByteLen() int {
+	switch c {
+	case ChecksumCRC32C:
+		return crc32.Size
+	case ChecksumSHA256:
+		return sha256.Size
+	}
+	return 0
+}
+
+// Checksum is a checksum of an object or object part.
+//
+// The checksum of an object uploaded in multiple parts is a
+// composite checksum: the checksum of the concatenated raw part
+// checksums, its encoded form is suffixed with '-<number of parts>'.
+//
+// A trailing checksum is sent in the trailer of a chunked upload,
+// after the content, its value is set once the trailer was read.
+type Checksum struct {
+	Type     ChecksumType
+	Encoded  string
+	Raw      []byte
+	Trailing bool
+}
+
+// NewChecksumString returns a checksum of type alg from its base64
+// encoded value, nil is returned if the value is not valid.
+func NewChecksumString(alg, value string) *Checksum {
+	t := NewChecksumType(alg)
+	if !t.IsSet() {
+		return nil
+	}
+	c := &Checksum{Type: t, Encoded: value}
+	encoded := value
+	if i := strings.LastIndexByte(value, '-'); i > 0 {
+		// Composite checksum, ends with the number of parts.
+		if n, err := strconv.Atoi(value[i+1:]); err != nil || n <= 0 {
+			return nil
+		}
+		encoded = value[:i]
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != t.RawByteLen() {
+		return nil
+	}
+	c.Raw = raw
+	return c
+}
+
+// NewChecksumFromHeader returns the checksum sent in the x-amz-checksum-*
+// headers of h, it returns nil if no checksum was sent. At most one
+// checksum may be sent, either as header or, when named in the
+// x-amz-trailer header, as trailing checksum.
+func NewChecksumFromHeader(h http.Header) (*Checksum, error) {
+	var c *Checksum
+	if trailer := h.Get(xhttp.AmzTrailer); trailer != "" {
+		t := checksumTypeFromKey(trailer)
+		if !t.IsSet() {
+			return nil, ErrInvalidChecksum
+		}
+		c = &Checksum{Type: t, Trailing: true}
+	}
+	for _, t := range []ChecksumType{ChecksumCRC32C, ChecksumSHA256} {
+		value := h.Get(t.Key())
+		if value == "" {
+			continue
+		}
+		if c != nil {
+			return nil, ErrInvalidChecksum
+		}
+		if c = NewChecksumString(t.String(), value); c == nil || c.IsComposite() {
+			return nil, ErrInvalidChecksum
+		}
+	}
+	if alg := h.Get(xhttp.AmzSDKChecksumAlgorithm); alg != "" {
+		if c == nil || NewChecksumType(alg) != c.Type {
+			return nil, ErrInvalidChecksum
+		}
+	}
+	return c, nil
+}
+
+// checksumTypeFromKey returns the checksum type carried in the header key.
+func checksumTypeFromKey(key string) ChecksumType {
+	for _, t := range []ChecksumType{ChecksumCRC32C, ChecksumSHA256} {
+		if strings.EqualFold(strings.TrimSpace(key), t.Key()) {
+			return t
+		}
+	}
+	return ChecksumInvalid
+}
+
+// SetTrailer sets the value of a trailing checksum from
+// the trailer key and value read after the content.
+func (c *Checksum) SetTrailer(key, value string) error {
+	if !c.Trailing || checksumTypeFromKey(key) != c.Type {
+		return ErrInvalidChecksum
+	}
+	v := NewChecksumString(c.Type.String(), strings.TrimSpace(value))
+	if v == nil || v.IsComposite() {
+		return ErrInvalidChecksum
+	}
+	c.Encoded, c.Raw = v.Encoded, v.Raw
+	return nil
+}
+
+// ParseChecksum parses a checksum in the form returned by String.
+func ParseChecksum(s string) *Checksum {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil
+	}
+	return NewChecksumString(s[:i], s[i+1:])
+}
+
+// String returns the checksum as '<algorithm>:<encoded value>'.
+func (c Checksum) String() string {
+	return c.Type.String() + ":" + c.Encoded
+}
+
+// IsComposite returns true if c is the checksum of a multipart object.
+func (c Checksum) IsComposite() bool {
+	return strings.IndexByte(c.Encoded, '-') > 0
+}
+
+// Matches returns nil if the checksum matches the content.
+func (c Checksum) Matches(content []byte) error {
+	h := c.Type.Hasher()
+	if h == nil {
+		return ErrInvalidChecksum
+	}
+	h.Write(content)
+	if sum := h.Sum(nil); !bytes.Equal(sum, c.Raw) {
+		return ChecksumMismatch{
+			Want: c.Encoded,
+			Got:  base64.StdEncoding.EncodeToString(sum),
+		}
+	}
+	return nil
+}
+
+// CompositeChecksum returns the checksum of an object made of parts.
+// All parts must have a checksum of type t.
+func CompositeChecksum(t ChecksumType, parts []*Checksum) (*Checksum, error) {
+	h := t.Hasher()
+	if h == nil {
+		return nil, ErrInvalidChecksum
+	}
+	for _, part := range parts {
+		if part == nil || part.Type != t {
+			return nil, ErrInvalidChecksum
+		}
+		h.Write(part.Raw)
+	}
+	raw := h.Sum(nil)
+	return &Checksum{
+		Type:    t,
+		Encoded: base64.StdEncoding.EncodeToString(raw) + "-" + strconv.Itoa(len(parts)),
+		Raw:     raw,
+	}, nil
+}
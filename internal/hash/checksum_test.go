@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestNewChecksumFromHeader(t *testing.T) {
+	testCases := []struct {
+		header  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{header: map[string]string{}},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "ksgKMQ=="}, want: "CRC32C:ksgKMQ=="},
+		{header: map[string]string{"X-Amz-Checksum-Sha256": "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk="}, want: "SHA256:iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk="},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "ksgKMQ==", "X-Amz-Sdk-Checksum-Algorithm": "crc32c"}, want: "CRC32C:ksgKMQ=="},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "ksgKMQ==", "X-Amz-Sdk-Checksum-Algorithm": "SHA256"}, wantErr: true},
+		{header: map[string]string{"X-Amz-Sdk-Checksum-Algorithm": "CRC32C"}, wantErr: true},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "ksgKMQ==", "X-Amz-Checksum-Sha256": "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk="}, wantErr: true},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "not-base64"}, wantErr: true},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk="}, wantErr: true},
+		{header: map[string]string{"X-Amz-Checksum-Crc32c": "ksgKMQ==-2"}, wantErr: true},
+		{header: map[string]string{"X-Amz-Trailer": "x-amz-checksum-crc32c"}, want: "CRC32C:"},
+		{header: map[string]string{"X-Amz-Trailer": "x-amz-checksum-sha256", "X-Amz-Sdk-Checksum-Algorithm": "SHA256"}, want: "SHA256:"},
+		{header: map[string]string{"X-Amz-Trailer": "x-amz-checksum-crc32"}, wantErr: true},
+		{header: map[string]string{"X-Amz-Trailer": "x-amz-checksum-crc32c", "X-Amz-Checksum-Crc32c": "ksgKMQ=="}, wantErr: true},
+	}
+	for i, testCase := range testCases {
+		h := make(http.Header)
+		for k, v := range testCase.header {
+			h.Set(k, v)
+		}
+		c, err := NewChecksumFromHeader(h)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		var got string
+		if c != nil {
+			got = c.String()
+		}
+		if got != testCase.want {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestChecksumSetTrailer(t *testing.T) {
+	h := make(http.Header)
+	h.Set("X-Amz-Trailer", "x-amz-checksum-crc32c")
+	c, err := NewChecksumFromHeader(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Trailing {
+		t.Fatal("expected a trailing checksum")
+	}
+	if err = c.SetTrailer("x-amz-checksum-sha256", "ksgKMQ=="); err != ErrInvalidChecksum {
+		t.Fatalf("expected invalid checksum for another trailer, got %v", err)
+	}
+	if err = c.SetTrailer("x-amz-checksum-crc32c", "not-base64"); err != ErrInvalidChecksum {
+		t.Fatalf("expected invalid checksum for an invalid value, got %v", err)
+	}
+	if err = c.SetTrailer("X-Amz-Checksum-Crc32c", "ksgKMQ=="); err != nil {
+		t.Fatal(err)
+	}
+	if c.String() != "CRC32C:ksgKMQ==" || len(c.Raw) != 4 {
+		t.Fatalf("unexpected checksum %s", c)
+	}
+	if err = (&Checksum{Type: ChecksumCRC32C}).SetTrailer("x-amz-checksum-crc32c", "ksgKMQ=="); err != ErrInvalidChecksum {
+		t.Fatalf("expected invalid checksum for a checksum sent as header, got %v", err)
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	part1 := NewChecksumString("CRC32C", "ksgKMQ==")
+	part2 := NewChecksumString("CRC32C", "AAAAAA==")
+	c, err := CompositeChecksum(ChecksumCRC32C, []*Checksum{part1, part2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsComposite() {
+		t.Fatalf("expected composite checksum, got %s", c.Encoded)
+	}
+	parsed := ParseChecksum(c.String())
+	if parsed == nil || parsed.Encoded != c.Encoded || !bytes.Equal(parsed.Raw, c.Raw) {
+		t.Fatalf("failed to parse composite checksum %s", c)
+	}
+	if _, err = CompositeChecksum(ChecksumSHA256, []*Checksum{part1, part2}); err == nil {
+		t.Fatal("expected error for mismatching part checksum types")
+	}
+}
+
+func TestHashReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		checksum *Checksum
+		err      error
+	}{
+		{checksum: NewChecksumString("CRC32C", "ksgKMQ==")},
+		{checksum: NewChecksumString("SHA256", "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk=")},
+		{checksum: NewChecksumString("CRC32C", "AAAAAA=="), err: ChecksumMismatch{Want: "AAAAAA==", Got: "ksgKMQ=="}},
+	}
+	for i, testCase := range testCases {
+		r, err := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "", 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(testCase.checksum); err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.Copy(ioutil.Discard, r)
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if err = testCase.checksum.Matches([]byte("abcd")); (err == nil) != (testCase.err == nil) {
+			t.Errorf("Test %d: unexpected Matches result %v", i+1, err)
+		}
+	}
+}
@@ -37,6 +37,7 @@ import (
 // match the reference values.
 type Reader struct {
 	src       io.Reader
+	rawSrc    io.Reader
 	bytesRead int64
 
 	size       int64
@@ -46,6 +47,9 @@ type Reader struct {
 	contentSHA256 []byte
 
 	sha256 hash.Hash
+
+	contentHash   *Checksum
+	contentHasher hash.Hash
 }
 
 // NewReader returns a new Reader that wraps src and computes
@@ -110,6 +114,7 @@ func NewReader(src io.Reader, size int64, md5Hex, sha256Hex string, actualSize i
 		return r, nil
 	}
 
+	rawSrc := src
 	if size >= 0 {
 		r := io.LimitReader(src, size)
 		if _, ok := src.(etag.Tagger); !ok {
@@ -126,6 +131,7 @@ func NewReader(src io.Reader, size int64, md5Hex, sha256Hex string, actualSize i
 	}
 	return &Reader{
 		src:           src,
+		rawSrc:        rawSrc,
 		size:          size,
 		actualSize:    actualSize,
 		checksum:      etag.ETag(MD5),
@@ -140,6 +146,9 @@ func (r *Reader) Read(p []byte) (int, error) {
 	if r.sha256 != nil {
 		r.sha256.Write(p[:n])
 	}
+	if r.contentHasher != nil {
+		r.contentHasher.Write(p[:n])
+	}
 
 	if err == io.EOF { // Verify content SHA256, if set.
 		if r.sha256 != nil {
@@ -150,6 +159,19 @@ func (r *Reader) Read(p []byte) (int, error) {
 				}
 			}
 		}
+		if r.contentHasher != nil { // Verify additional content checksum, if set.
+			if r.contentHash.Trailing {
+				if err := r.readTrailer(); err != nil {
+					return n, err
+				}
+			}
+			if sum := r.contentHasher.Sum(nil); !bytes.Equal(r.contentHash.Raw, sum) {
+				return n, ChecksumMismatch{
+					Want: r.contentHash.Encoded,
+					Got:  base64.StdEncoding.EncodeToString(sum),
+				}
+			}
+		}
 	}
 	if err != nil && err != io.EOF {
 		if v, ok := err.(etag.VerifyError); ok {
@@ -162,6 +184,49 @@ func (r *Reader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// readTrailer reads the source past the end of the content, which
+// makes a chunked source read the trailer carrying a trailing checksum.
+// No content may follow the end of the content.
+func (r *Reader) readTrailer() error {
+	var b [1]byte
+	for {
+		n, err := r.rawSrc.Read(b[:])
+		if n > 0 {
+			return ErrSizeMismatch{Want: r.size, Got: r.bytesRead + int64(n)}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AddChecksum adds an additional checksum of the content
+// that is verified once all content has been read. The value
+// of a trailing checksum is read from the trailer of the source.
+func (r *Reader) AddChecksum(c *Checksum) error {
+	if c == nil {
+		return nil
+	}
+	if r.bytesRead > 0 {
+		return errors.New("hash: already read from hash reader")
+	}
+	r.contentHasher = c.Type.Hasher()
+	if r.contentHasher == nil {
+		return ErrInvalidChecksum
+	}
+	r.contentHash = c
+	return nil
+}
+
+// ContentChecksum returns the additional checksum of the
+// content set as reference value, or nil if not set.
+func (r *Reader) ContentChecksum() *Checksum {
+	return r.contentHash
+}
+
 // Size returns the absolute number of bytes the Reader
 // will return during reading. It returns -1 for unlimited
 // data.
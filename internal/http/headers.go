@@ -32,6 +32,7 @@ const (
 	Connection         = "Connection"
 	AcceptRanges       = "Accept-Ranges"
 	AmzBucketRegion    = "X-Amz-Bucket-Region"
+	AmzAccountID       = "X-Amz-Account-Id"
 	ServerInfo         = "Server"
 	RetryAfter         = "Retry-After"
 	Location           = "Location"
@@ -97,6 +98,10 @@ const (
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
+	// Multipart upload date/time of abort and the lifecycle rule aborting it
+	AmzAbortDate   = "x-amz-abort-date"
+	AmzAbortRuleID = "x-amz-abort-rule-id"
+
 	// Dummy putBucketACL
 	AmzACL = "x-amz-acl"
 
@@ -114,6 +119,22 @@ const (
 	AmzMetaUnencryptedContentLength = "X-Amz-Meta-X-Amz-Unencrypted-Content-Length"
 	AmzMetaUnencryptedContentMD5    = "X-Amz-Meta-X-Amz-Unencrypted-Content-Md5"
 
+	// Additional object checksum headers.
+	AmzChecksumAlgorithm    = "X-Amz-Checksum-Algorithm"
+	AmzSDKChecksumAlgorithm = "X-Amz-Sdk-Checksum-Algorithm"
+	AmzChecksumMode         = "X-Amz-Checksum-Mode"
+	AmzChecksumCRC32C       = "X-Amz-Checksum-Crc32c"
+	AmzChecksumSHA256       = "X-Amz-Checksum-Sha256"
+	AmzTrailer              = "X-Amz-Trailer"
+
+	// Append object extension, the size of the object before the append.
+	AmzWriteOffsetBytes = "X-Amz-Write-Offset-Bytes"
+
+	// GetObjectAttributes headers.
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
 	// AWS server-side encryption headers for SSE-S3, SSE-KMS and SSE-C.
 	AmzServerSideEncryption                      = "X-Amz-Server-Side-Encryption"
 	AmzServerSideEncryptionKmsID                 = AmzServerSideEncryption + "-Aws-Kms-Key-Id"
@@ -181,6 +202,13 @@ const (
 	MinIOSourceObjectLegalHoldTimestamp = "X-Minio-Source-Replication-LegalHold-Timestamp"
 	// predicted date/time of transition
 	MinIOTransition = "X-Minio-Transition"
+
+	// Headers describing the object sent to a lambda webhook for transformation
+	MinIOLambdaBucket      = "X-Minio-Lambda-Bucket"
+	MinIOLambdaObject      = "X-Minio-Lambda-Object"
+	MinIOLambdaVersionID   = "X-Minio-Lambda-Version-Id"
+	MinIOLambdaETag        = "X-Minio-Lambda-Etag"
+	MinIOLambdaContentType = "X-Minio-Lambda-Content-Type"
 )
 
 // Common http query params S3 API
@@ -55,6 +55,18 @@ func (w *OutgoingTrafficMeter) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+// ReadFrom calls the underlying ReadFrom if any, e.g. to send files
+// without copying them, and counts the output bytes.
+func (w *OutgoingTrafficMeter) ReadFrom(r io.Reader) (n int64, err error) {
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(struct{ io.Writer }{w.ResponseWriter}, r)
+	}
+	w.countBytes += n
+	return n, err
+}
+
 // Flush calls the underlying Flush.
 func (w *OutgoingTrafficMeter) Flush() {
 	w.ResponseWriter.(http.Flusher).Flush()
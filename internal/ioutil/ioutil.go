@@ -46,6 +46,16 @@ func (w *WriteOnCloser) Write(p []byte) (int, error) {
 	return w.Writer.Write(p)
 }
 
+// ReadFrom writes the data of r, with the ReadFrom of the underlying
+// writer if it has one, e.g. to send files without copying them.
+func (w *WriteOnCloser) ReadFrom(r io.Reader) (int64, error) {
+	w.hasWritten = true
+	if rf, ok := w.Writer.(io.ReaderFrom); ok {
+		return rf.ReadFrom(r)
+	}
+	return io.Copy(struct{ io.Writer }{w.Writer}, r)
+}
+
 // Close closes the WriteOnCloser. It behaves like io.Closer.
 func (w *WriteOnCloser) Close() error {
 	if !w.hasWritten {
@@ -258,6 +268,18 @@ const DirectioAlignSize = 4096
 // input writer *os.File not a generic io.Writer. Make sure to have
 // the file opened for writes with syscall.O_DIRECT flag.
 func CopyAligned(w *os.File, r io.Reader, alignedBuf []byte, totalSize int64) (int64, error) {
+	return CopyAlignedSize(w, r, alignedBuf, totalSize, DirectioAlignSize)
+}
+
+// CopyAlignedSize - is CopyAligned for drives with a DIRECT I/O
+// alignment of alignSize bytes, which must divide DirectioAlignSize.
+// Only the unaligned remainder of the last write is written without
+// DIRECT I/O.
+func CopyAlignedSize(w *os.File, r io.Reader, alignedBuf []byte, totalSize int64, alignSize int) (int64, error) {
+	if alignSize <= 0 || DirectioAlignSize%alignSize != 0 {
+		alignSize = DirectioAlignSize
+	}
+
 	// Writes remaining bytes in the buffer.
 	writeUnaligned := func(w *os.File, buf []byte) (remainingWritten int64, err error) {
 		// Disable O_DIRECT on fd's on unaligned buffer
@@ -286,15 +308,20 @@ func CopyAligned(w *os.File, r io.Reader, alignedBuf []byte, totalSize int64) (i
 			return written, err
 		}
 		buf = buf[:nr]
+		err = nil
 		var nw int64
-		if len(buf)%DirectioAlignSize == 0 {
+		// buf[:aligned] is aligned for directio write()
+		aligned := len(buf) - len(buf)%alignSize
+		if aligned > 0 {
 			var n int
-			// buf is aligned for directio write()
-			n, err = w.Write(buf)
+			n, err = w.Write(buf[:aligned])
 			nw = int64(n)
-		} else {
-			// buf is not aligned, hence use writeUnaligned()
-			nw, err = writeUnaligned(w, buf)
+		}
+		if err == nil && nw == int64(aligned) && aligned < len(buf) {
+			// the rest is not aligned, hence use writeUnaligned()
+			var n int64
+			n, err = writeUnaligned(w, buf[aligned:])
+			nw += n
 		}
 		if nw > 0 {
 			written += nw
@@ -25,6 +25,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/minio/minio/internal/disk"
 )
 
 type sleepWriter struct {
@@ -114,6 +116,47 @@ func TestAppendFile(t *testing.T) {
 	}
 }
 
+func TestCopyAlignedSize(t *testing.T) {
+	buf := disk.AlignedBlock(2 * DirectioAlignSize)
+	for _, align := range []int{512, DirectioAlignSize} {
+		for _, size := range []int{0, 100, 101, 512, 700, 701, DirectioAlignSize + 1, 3*DirectioAlignSize + 513} {
+			f, err := goioutil.TempFile("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := f.Name()
+			f.Close()
+			defer os.Remove(name)
+
+			w, err := disk.OpenFileDirectIO(name, os.O_WRONLY, 0666)
+			if err != nil {
+				t.Skip(err)
+			}
+			data := bytes.Repeat([]byte{'a'}, size)
+			totalSize := int64(size)
+			if size%2 == 1 {
+				// Streams of unknown size.
+				totalSize = -1
+			}
+			n, err := CopyAlignedSize(w, bytes.NewReader(data), buf, totalSize, align)
+			w.Close()
+			if err != nil {
+				t.Fatalf("align %d, size %d: %v", align, size, err)
+			}
+			if n != int64(size) {
+				t.Fatalf("align %d, size %d: expected %d bytes written, got %d", align, size, size, n)
+			}
+			b, err := goioutil.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b, data) {
+				t.Fatalf("align %d, size %d: content mismatch", align, size)
+			}
+		}
+	}
+}
+
 func TestSkipReader(t *testing.T) {
 	testCases := []struct {
 		src      io.Reader
@@ -44,6 +44,8 @@ type ResponseWriter struct {
 	StartTime       time.Time
 	// number of bytes written
 	bytesWritten int
+	// number of body bytes written
+	bodyBytesWritten int
 	// Internal recording buffer
 	headers bytes.Buffer
 	body    bytes.Buffer
@@ -69,6 +71,7 @@ func (lrw *ResponseWriter) Write(p []byte) (int, error) {
 	}
 	n, err := lrw.ResponseWriter.Write(p)
 	lrw.bytesWritten += n
+	lrw.bodyBytesWritten += n
 	if lrw.TimeToFirstByte == 0 {
 		lrw.TimeToFirstByte = time.Now().UTC().Sub(lrw.StartTime)
 	}
@@ -82,6 +85,25 @@ func (lrw *ResponseWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// ReadFrom calls the underlying ReadFrom if any, e.g. to send files
+// without copying them, unless the body is logged.
+func (lrw *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if !lrw.headersLogged {
+		lrw.WriteHeader(http.StatusOK)
+	}
+	rf, ok := lrw.ResponseWriter.(io.ReaderFrom)
+	if !ok || (lrw.LogErrBody && lrw.StatusCode >= http.StatusBadRequest) || lrw.LogAllBody {
+		return io.Copy(struct{ io.Writer }{lrw}, r)
+	}
+	if lrw.TimeToFirstByte == 0 {
+		lrw.TimeToFirstByte = time.Now().UTC().Sub(lrw.StartTime)
+	}
+	n, err := rf.ReadFrom(r)
+	lrw.bytesWritten += int(n)
+	lrw.bodyBytesWritten += int(n)
+	return n, err
+}
+
 // Write the headers into the given buffer
 func (lrw *ResponseWriter) writeHeaders(w io.Writer, statusCode int, headers http.Header) {
 	n, _ := fmt.Fprintf(w, "%d %s\n", statusCode, http.StatusText(statusCode))
@@ -126,6 +148,11 @@ func (lrw *ResponseWriter) Size() int {
 	return lrw.bytesWritten
 }
 
+// BodySize - returns the number of body bytes written, excluding headers
+func (lrw *ResponseWriter) BodySize() int {
+	return lrw.bodyBytesWritten
+}
+
 const contextAuditKey = contextKeyType("audit-entry")
 
 // SetAuditEntry sets Audit info in the context.
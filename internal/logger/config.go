@@ -25,7 +25,9 @@ import (
 	"github.com/minio/pkg/env"
 	xnet "github.com/minio/pkg/net"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger/target/file"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
 )
@@ -41,6 +43,14 @@ const (
 	AuthToken  = "auth_token"
 	ClientCert = "client_cert"
 	ClientKey  = "client_key"
+	BatchSize  = "batch_size"
+	Compress   = "compress"
+	QueueDir   = "queue_dir"
+	QueueSize  = "queue_size"
+
+	FilePath       = "path"
+	FileMaxSize    = "max_size"
+	FileMaxBackups = "max_backups"
 
 	KafkaBrokers       = "brokers"
 	KafkaTopic         = "topic"
@@ -64,6 +74,10 @@ const (
 	EnvAuditWebhookAuthToken  = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
 	EnvAuditWebhookClientCert = "MINIO_AUDIT_WEBHOOK_CLIENT_CERT"
 	EnvAuditWebhookClientKey  = "MINIO_AUDIT_WEBHOOK_CLIENT_KEY"
+	EnvAuditWebhookBatchSize  = "MINIO_AUDIT_WEBHOOK_BATCH_SIZE"
+	EnvAuditWebhookCompress   = "MINIO_AUDIT_WEBHOOK_COMPRESS"
+	EnvAuditWebhookQueueDir   = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueSize  = "MINIO_AUDIT_WEBHOOK_QUEUE_SIZE"
 
 	EnvKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
@@ -78,6 +92,14 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvKafkaBatchSize     = "MINIO_AUDIT_KAFKA_BATCH_SIZE"
+	EnvKafkaCompress      = "MINIO_AUDIT_KAFKA_COMPRESS"
+
+	EnvAuditFileEnable     = "MINIO_AUDIT_FILE_ENABLE"
+	EnvAuditFilePath       = "MINIO_AUDIT_FILE_PATH"
+	EnvAuditFileMaxSize    = "MINIO_AUDIT_FILE_MAX_SIZE"
+	EnvAuditFileMaxBackups = "MINIO_AUDIT_FILE_MAX_BACKUPS"
+	EnvAuditFileCompress   = "MINIO_AUDIT_FILE_COMPRESS"
 )
 
 // Default KVS for loggerHTTP and loggerAuditHTTP
@@ -118,6 +140,22 @@ var (
 			Key:   ClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   BatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   Compress,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: "100000",
+		},
 	}
 
 	DefaultAuditKafkaKVS = config.KVS{
@@ -173,6 +211,37 @@ var (
 			Key:   KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   BatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   Compress,
+			Value: config.EnableOff,
+		},
+	}
+
+	DefaultAuditFileKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   FilePath,
+			Value: "",
+		},
+		config.KV{
+			Key:   FileMaxSize,
+			Value: "100MiB",
+		},
+		config.KV{
+			Key:   FileMaxBackups,
+			Value: "10",
+		},
+		config.KV{
+			Key:   Compress,
+			Value: config.EnableOff,
+		},
 	}
 )
 
@@ -182,6 +251,7 @@ type Config struct {
 	HTTP         map[string]http.Config  `json:"http"`
 	AuditWebhook map[string]http.Config  `json:"audit"`
 	AuditKafka   map[string]kafka.Config `json:"audit_kafka"`
+	AuditFile    map[string]file.Config  `json:"audit_file"`
 }
 
 // NewConfig - initialize new logger config.
@@ -194,6 +264,7 @@ func NewConfig() Config {
 		HTTP:         make(map[string]http.Config),
 		AuditWebhook: make(map[string]http.Config),
 		AuditKafka:   make(map[string]kafka.Config),
+		AuditFile:    make(map[string]file.Config),
 	}
 
 	return cfg
@@ -313,11 +384,31 @@ func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, err
 			versionEnv = versionEnv + config.Default + k
 		}
 
+		batchSizeEnv := EnvKafkaBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+		batchSize, err := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(BatchSize)))
+		if err != nil || batchSize <= 0 {
+			return nil, config.Errorf("kafka 'batch_size' must be a positive number")
+		}
+
+		compressEnv := EnvKafkaCompress
+		if k != config.Default {
+			compressEnv = compressEnv + config.Default + k
+		}
+		compress, err := config.ParseBool(env.Get(compressEnv, kv.Get(Compress)))
+		if err != nil {
+			return nil, err
+		}
+
 		kafkaArgs := kafka.Config{
-			Enabled: enabled,
-			Brokers: brokers,
-			Topic:   env.Get(topicEnv, kv.Get(KafkaTopic)),
-			Version: env.Get(versionEnv, kv.Get(KafkaVersion)),
+			Enabled:   enabled,
+			Brokers:   brokers,
+			Topic:     env.Get(topicEnv, kv.Get(KafkaTopic)),
+			Version:   env.Get(versionEnv, kv.Get(KafkaVersion)),
+			BatchSize: batchSize,
+			Compress:  compress,
 		}
 
 		tlsEnableEnv := EnvKafkaTLS
@@ -373,6 +464,98 @@ func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, err
 	return kafkaTargets, nil
 }
 
+// GetAuditFile - returns a map of registered audit 'file' targets
+func GetAuditFile(fileKVS map[string]config.KVS) (map[string]file.Config, error) {
+	fileTargets := make(map[string]file.Config)
+	for k, kv := range config.Merge(fileKVS, EnvAuditFileEnable, DefaultAuditFileKVS) {
+		enableEnv := EnvAuditFileEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		pathEnv := EnvAuditFilePath
+		if k != config.Default {
+			pathEnv = pathEnv + config.Default + k
+		}
+		path := env.Get(pathEnv, kv.Get(FilePath))
+		if path == "" {
+			return nil, config.Errorf("file 'path' cannot be empty")
+		}
+
+		maxSizeEnv := EnvAuditFileMaxSize
+		if k != config.Default {
+			maxSizeEnv = maxSizeEnv + config.Default + k
+		}
+		maxSize, err := humanize.ParseBytes(env.Get(maxSizeEnv, kv.Get(FileMaxSize)))
+		if err != nil {
+			return nil, config.Errorf("file 'max_size' is invalid: %v", err)
+		}
+
+		maxBackupsEnv := EnvAuditFileMaxBackups
+		if k != config.Default {
+			maxBackupsEnv = maxBackupsEnv + config.Default + k
+		}
+		maxBackups, err := strconv.Atoi(env.Get(maxBackupsEnv, kv.Get(FileMaxBackups)))
+		if err != nil || maxBackups < 0 {
+			return nil, config.Errorf("file 'max_backups' must be a non-negative number")
+		}
+
+		compressEnv := EnvAuditFileCompress
+		if k != config.Default {
+			compressEnv = compressEnv + config.Default + k
+		}
+		compress, err := config.ParseBool(env.Get(compressEnv, kv.Get(Compress)))
+		if err != nil {
+			return nil, err
+		}
+
+		fileTargets[k] = file.Config{
+			Enabled:    true,
+			Name:       "audit_file:" + k,
+			Path:       path,
+			MaxSize:    int64(maxSize),
+			MaxBackups: maxBackups,
+			Compress:   compress,
+		}
+	}
+	return fileTargets, nil
+}
+
+// lookupWebhookQueueConfig parses the batching and queueing arguments
+// of an audit webhook target.
+func lookupWebhookQueueConfig(cfg *http.Config, batchSize, compress, queueDir, queueSize string) error {
+	// Configurations saved by older releases lack these keys.
+	if batchSize == "" {
+		batchSize = "1"
+	}
+	if compress == "" {
+		compress = config.EnableOff
+	}
+	if queueSize == "" {
+		queueSize = "100000"
+	}
+
+	var err error
+	if cfg.BatchSize, err = strconv.Atoi(batchSize); err != nil || cfg.BatchSize <= 0 {
+		return config.Errorf("webhook 'batch_size' must be a positive number")
+	}
+	if cfg.Compress, err = config.ParseBool(compress); err != nil {
+		return err
+	}
+	cfg.QueueDir = queueDir
+	if cfg.QueueSize, err = strconv.Atoi(queueSize); err != nil || cfg.QueueSize <= 0 {
+		return config.Errorf("webhook 'queue_size' must be a positive number")
+	}
+	return nil
+}
+
 // LookupConfig - lookup logger config, override with ENVs if set.
 func LookupConfig(scfg config.Config) (Config, error) {
 	// Lookup for legacy environment variables first
@@ -465,13 +648,35 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		cfg.AuditWebhook[target] = http.Config{
+		batchSizeEnv := EnvAuditWebhookBatchSize
+		if target != config.Default {
+			batchSizeEnv = EnvAuditWebhookBatchSize + config.Default + target
+		}
+		compressEnv := EnvAuditWebhookCompress
+		if target != config.Default {
+			compressEnv = EnvAuditWebhookCompress + config.Default + target
+		}
+		queueDirEnv := EnvAuditWebhookQueueDir
+		if target != config.Default {
+			queueDirEnv = EnvAuditWebhookQueueDir + config.Default + target
+		}
+		queueSizeEnv := EnvAuditWebhookQueueSize
+		if target != config.Default {
+			queueSizeEnv = EnvAuditWebhookQueueSize + config.Default + target
+		}
+		webhookCfg := http.Config{
 			Enabled:    true,
 			Endpoint:   env.Get(endpointEnv, ""),
 			AuthToken:  env.Get(authTokenEnv, ""),
 			ClientCert: env.Get(clientCertEnv, ""),
 			ClientKey:  env.Get(clientKeyEnv, ""),
 		}
+		err = lookupWebhookQueueConfig(&webhookCfg, env.Get(batchSizeEnv, ""), env.Get(compressEnv, ""),
+			env.Get(queueDirEnv, ""), env.Get(queueSizeEnv, ""))
+		if err != nil {
+			return cfg, err
+		}
+		cfg.AuditWebhook[target] = webhookCfg
 	}
 
 	for starget, kv := range scfg[config.LoggerWebhookSubSys] {
@@ -527,13 +732,18 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		cfg.AuditWebhook[starget] = http.Config{
+		webhookCfg := http.Config{
 			Enabled:    true,
 			Endpoint:   kv.Get(Endpoint),
 			AuthToken:  kv.Get(AuthToken),
 			ClientCert: kv.Get(ClientCert),
 			ClientKey:  kv.Get(ClientKey),
 		}
+		err = lookupWebhookQueueConfig(&webhookCfg, kv.Get(BatchSize), kv.Get(Compress), kv.Get(QueueDir), kv.Get(QueueSize))
+		if err != nil {
+			return cfg, err
+		}
+		cfg.AuditWebhook[starget] = webhookCfg
 	}
 
 	cfg.AuditKafka, err = GetAuditKafka(scfg[config.AuditKafkaSubSys])
@@ -541,5 +751,10 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		return cfg, err
 	}
 
+	cfg.AuditFile, err = GetAuditFile(scfg[config.AuditFileSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
@@ -73,6 +73,30 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         BatchSize,
+			Description: "number of audit log entries sent per request, defaults to '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Compress,
+			Description: "set to 'on' to gzip compress audit log requests",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: "local directory for audit log entries which could not be sent or buffered",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: "maximum number of audit log entries in 'queue_dir', defaults to '100000'",
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -157,6 +181,50 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         BatchSize,
+			Description: "number of audit log entries sent to the brokers at once, defaults to '1'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Compress,
+			Description: "set to 'on' to gzip compress audit log messages",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpFile = config.HelpKVS{
+		config.HelpKV{
+			Key:         FilePath,
+			Description: `path of the audit log file e.g. "/var/log/minio/audit.log"`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         FileMaxSize,
+			Description: "size at which the audit log file is rotated, defaults to '100MiB'",
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         FileMaxBackups,
+			Description: "number of rotated audit log files kept, '0' keeps all, defaults to '10'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Compress,
+			Description: "set to 'on' to gzip compress rotated audit log files",
+			Optional:    true,
+			Type:        "on|off",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
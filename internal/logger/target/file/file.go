@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layout of the timestamp appended to the name of rotated log files.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// Config - file target arguments.
+type Config struct {
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	// Size in bytes at which the log file is rotated, 0 never rotates.
+	MaxSize int64 `json:"maxSize"`
+	// Number of rotated log files kept, 0 keeps all of them.
+	MaxBackups int `json:"maxBackups"`
+	// Compress rotated log files with gzip.
+	Compress bool `json:"compress"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target appends the json format of log entries to a local file,
+// one entry per line. The file is rotated once it grows beyond
+// MaxSize, rotated files are named after the log file followed
+// by the time of rotation.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	file *os.File
+	size int64
+
+	config Config
+}
+
+// Endpoint returns the path of the log file.
+func (h *Target) Endpoint() string {
+	return h.config.Path
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+// Init opens the log file and starts writing log entries.
+func (h *Target) Init() error {
+	if h.config.Path == "" {
+		return errors.New("file target path cannot be empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(h.config.Path), 0o755); err != nil {
+		return err
+	}
+	if err := h.open(); err != nil {
+		return err
+	}
+
+	go h.startFileLogger()
+	return nil
+}
+
+func (h *Target) open() error {
+	f, err := os.OpenFile(h.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	h.file, h.size = f, fi.Size()
+	return nil
+}
+
+func (h *Target) startFileLogger() {
+	for entry := range h.logCh {
+		logJSON, err := json.Marshal(&entry)
+		if err != nil {
+			continue
+		}
+		if err = h.write(append(logJSON, '\n')); err != nil {
+			h.config.LogOnce(context.Background(), err, h.config.Path)
+		}
+	}
+}
+
+func (h *Target) write(line []byte) error {
+	if h.config.MaxSize > 0 && h.size > 0 && h.size+int64(len(line)) > h.config.MaxSize {
+		if err := h.rotate(); err != nil {
+			return err
+		}
+	}
+	if h.file == nil {
+		if err := h.open(); err != nil {
+			return err
+		}
+	}
+	n, err := h.file.Write(line)
+	h.size += int64(n)
+	return err
+}
+
+// rotate moves the log file aside and starts a new one.
+func (h *Target) rotate() error {
+	h.file.Close()
+	h.file = nil
+
+	rotated := h.config.Path + "." + time.Now().UTC().Format(rotatedTimeFormat)
+	if err := os.Rename(h.config.Path, rotated); err != nil {
+		return err
+	}
+	if err := h.open(); err != nil {
+		return err
+	}
+
+	go func() {
+		if h.config.Compress {
+			if err := compressFile(rotated); err != nil {
+				h.config.LogOnce(context.Background(), err, h.config.Path)
+			}
+		}
+		if err := h.removeBackups(); err != nil {
+			h.config.LogOnce(context.Background(), err, h.config.Path)
+		}
+	}()
+	return nil
+}
+
+// compressFile replaces name with its gzip compressed content.
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name + ".gz")
+		return err
+	}
+	return os.Remove(name)
+}
+
+// removeBackups removes the oldest rotated log files beyond MaxBackups.
+func (h *Target) removeBackups() error {
+	if h.config.MaxBackups <= 0 {
+		return nil
+	}
+	dir, base := filepath.Split(h.config.Path)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	var backups []string
+	for _, name := range names {
+		if strings.HasPrefix(name, base+".") {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= h.config.MaxBackups {
+		return nil
+	}
+	// The rotation time sorts in chronological order.
+	sort.Strings(backups)
+	for _, name := range backups[:len(backups)-h.config.MaxBackups] {
+		if err = os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// New initializes a new logger target which
+// appends logs to the specified file
+func New(config Config) *Target {
+	return &Target{
+		logCh:  make(chan interface{}, 10000),
+		config: config,
+	}
+}
+
+// Send log message 'e' to file target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
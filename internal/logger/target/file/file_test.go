@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTargetRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	h := New(Config{
+		Enabled:    true,
+		Path:       filepath.Join(dir, "audit.log"),
+		MaxSize:    20,
+		MaxBackups: 2,
+		LogOnce:    func(ctx context.Context, err error, id interface{}, errKind ...interface{}) { t.Error(err) },
+	})
+	if err = h.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each entry is 16 bytes long, every entry rotates the file.
+	for i := 0; i < 5; i++ {
+		if err = h.write([]byte(`{"entry":"abc"}` + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		// Rotated files are named after the time of rotation.
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var backups int
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), "audit.log.") {
+				backups++
+			}
+		}
+		if backups == 2 && len(entries) == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the log file and 2 backups, got %d files", len(entries))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	data, err := ioutil.ReadFile(h.config.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"entry":"abc"}`+"\n" {
+		t.Fatalf("unexpected log file content %q", data)
+	}
+}
@@ -19,19 +19,29 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	xhttp "github.com/minio/minio/internal/http"
 )
 
-// Timeout for the webhook http call
-const webhookCallTimeout = 5 * time.Second
+const (
+	// Timeout for the webhook http call
+	webhookCallTimeout = 5 * time.Second
+
+	// Maximum time log entries wait for a batch to fill up.
+	webhookBatchInterval = time.Second
+
+	// Number of log entries buffered in memory.
+	webhookLogBufferSize = 10000
+)
 
 // Config http logger target
 type Config struct {
@@ -42,6 +52,10 @@ type Config struct {
 	AuthToken  string            `json:"authToken"`
 	ClientCert string            `json:"clientCert"`
 	ClientKey  string            `json:"clientKey"`
+	BatchSize  int               `json:"batchSize"`
+	Compress   bool              `json:"compress"`
+	QueueDir   string            `json:"queueDir"`
+	QueueSize  int               `json:"queueSize"`
 	Transport  http.RoundTripper `json:"-"`
 
 	// Custom logger
@@ -50,13 +64,21 @@ type Config struct {
 
 // Target implements logger.Target and sends the json
 // format of a log entry to the configured http endpoint.
-// An internal buffer of logs is maintained but when the
-// buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// An internal buffer of logs is maintained, logs are sent
+// in batches of BatchSize entries, optionally gzip compressed.
+// When the buffer is full, new logs are spilled over to the
+// queue directory if one is configured, or just ignored and
+// an error is returned to the caller.
 type Target struct {
 	// Channel of log entries
 	logCh chan interface{}
 
+	// Log entries spilled over to disk, nil without queue directory.
+	store *queueStore
+
+	// Set to 1 while the endpoint accepts logs.
+	online int32
+
 	config Config
 }
 
@@ -108,6 +130,14 @@ func (h *Target) Init() error {
 			h.config.Endpoint, resp.Status)
 	}
 
+	if h.config.QueueDir != "" {
+		if h.store, err = newQueueStore(h.config.QueueDir, h.config.QueueSize); err != nil {
+			return err
+		}
+		go h.replayQueueStore()
+	}
+	atomic.StoreInt32(&h.online, 1)
+
 	go h.startHTTPLogger()
 	return nil
 }
@@ -121,59 +151,162 @@ func acceptedResponseStatusCode(code int) bool {
 
 func (h *Target) startHTTPLogger() {
 	// Create a routine which sends json logs received
-	// from an internal channel.
+	// from an internal channel in batches.
 	go func() {
-		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
+		batchSize := h.config.BatchSize
+		if batchSize <= 0 {
+			batchSize = 1
+		}
+		batch := make([][]byte, 0, batchSize)
+		ticker := time.NewTicker(webhookBatchInterval)
+		defer ticker.Stop()
+
+		flush := func() {
+			if len(batch) > 0 {
+				h.send(batch)
+				batch = make([][]byte, 0, batchSize)
 			}
+		}
+		for {
+			select {
+			case entry, ok := <-h.logCh:
+				if !ok {
+					flush()
+					return
+				}
+				logJSON, err := json.Marshal(&entry)
+				if err != nil {
+					continue
+				}
+				batch = append(batch, logJSON)
+				if len(batch) >= batchSize {
+					flush()
+				}
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+}
 
-			ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-				h.config.Endpoint, bytes.NewReader(logJSON))
-			if err != nil {
-				cancel()
-				continue
+// send posts a batch of log entries to the endpoint, failed batches
+// are spilled over to the queue directory if one is configured.
+func (h *Target) send(batch [][]byte) {
+	err := h.post(batch)
+	if err == nil {
+		atomic.StoreInt32(&h.online, 1)
+		return
+	}
+	atomic.StoreInt32(&h.online, 0)
+	h.config.LogOnce(context.Background(), err, h.config.Endpoint)
+	if h.store != nil {
+		for _, logJSON := range batch {
+			if err = h.store.put(logJSON); err != nil {
+				h.config.LogOnce(context.Background(), err, h.config.QueueDir)
+				return
 			}
-			req.Header.Set(xhttp.ContentType, "application/json")
+		}
+	}
+}
 
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.config.UserAgent)
+// post sends a single log entry as a JSON object, and several log
+// entries as newline delimited JSON.
+func (h *Target) post(batch [][]byte) error {
+	var buf bytes.Buffer
+	if h.config.Compress {
+		zw := gzip.NewWriter(&buf)
+		zw.Write(bytes.Join(batch, []byte{'\n'}))
+		zw.Close()
+	} else {
+		buf.Write(bytes.Join(batch, []byte{'\n'}))
+	}
 
-			if h.config.AuthToken != "" {
-				req.Header.Set("Authorization", h.config.AuthToken)
-			}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.Endpoint, &buf)
+	if err != nil {
+		return err
+	}
+	if len(batch) > 1 {
+		req.Header.Set(xhttp.ContentType, "application/x-ndjson")
+	} else {
+		req.Header.Set(xhttp.ContentType, "application/json")
+	}
+	if h.config.Compress {
+		req.Header.Set(xhttp.ContentEncoding, "gzip")
+	}
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.config.UserAgent)
+
+	if h.config.AuthToken != "" {
+		req.Header.Set("Authorization", h.config.AuthToken)
+	}
+
+	client := http.Client{Transport: h.config.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if !acceptedResponseStatusCode(resp.StatusCode) {
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status)
+		default:
+			return fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status)
+		}
+	}
+	return nil
+}
 
-			client := http.Client{Transport: h.config.Transport}
-			resp, err := client.Do(req)
-			cancel()
+// replayQueueStore moves log entries spilled over to disk back to
+// the internal buffer. While the endpoint is offline, the oldest log
+// entry is sent right away to detect when it is back online.
+func (h *Target) replayQueueStore() {
+	ticker := time.NewTicker(webhookBatchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		names, err := h.store.list()
+		if err != nil {
+			h.config.LogOnce(context.Background(), err, h.config.QueueDir)
+			continue
+		}
+		for _, name := range names {
+			logJSON, err := h.store.get(name)
 			if err != nil {
-				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
+				h.store.del(name)
 				continue
 			}
-
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
-
-			if !acceptedResponseStatusCode(resp.StatusCode) {
-				switch resp.StatusCode {
-				case http.StatusForbidden:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status), h.config.Endpoint)
-				default:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status), h.config.Endpoint)
+			if atomic.LoadInt32(&h.online) == 0 {
+				if err = h.post([][]byte{logJSON}); err != nil {
+					break
 				}
+				atomic.StoreInt32(&h.online, 1)
+				h.store.del(name)
+				continue
+			}
+			// Leave room for new log entries.
+			if len(h.logCh) >= cap(h.logCh)/2 {
+				break
 			}
+			h.logCh <- json.RawMessage(logJSON)
+			h.store.del(name)
 		}
-	}()
+	}
 }
 
 // New initializes a new logger target which
 // sends log over http to the specified endpoint
 func New(config Config) *Target {
 	h := &Target{
-		logCh:  make(chan interface{}, 10000),
+		logCh:  make(chan interface{}, webhookLogBufferSize),
 		config: config,
 	}
 
@@ -184,11 +317,19 @@ func New(config Config) *Target {
 func (h *Target) Send(entry interface{}, errKind string) error {
 	select {
 	case h.logCh <- entry:
+		return nil
 	default:
-		// log channel is full, do not wait and return
-		// an error immediately to the caller
-		return errors.New("log buffer full")
 	}
 
-	return nil
+	// log channel is full, do not wait and spill
+	// the entry over to disk, or return an error
+	// immediately to the caller
+	if h.store == nil {
+		return errors.New("log buffer full")
+	}
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	return h.store.put(logJSON)
 }
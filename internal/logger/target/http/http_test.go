@@ -0,0 +1,165 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testEndpoint records the log entries received by a webhook.
+type testEndpoint struct {
+	mu       sync.Mutex
+	requests [][]byte
+	failing  int32
+}
+
+func (e *testEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&e.failing) == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !bytes.Equal(data, []byte(`{}`)) {
+		e.mu.Lock()
+		e.requests = append(e.requests, data)
+		e.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (e *testEndpoint) waitRequests(t *testing.T, n int) [][]byte {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		e.mu.Lock()
+		requests := e.requests
+		e.mu.Unlock()
+		if len(requests) >= n {
+			return requests
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("expected %d requests", n)
+	return nil
+}
+
+func testLogOnce(ctx context.Context, err error, id interface{}, errKind ...interface{}) {}
+
+func TestTargetBatchCompress(t *testing.T) {
+	endpoint := &testEndpoint{}
+	srv := httptest.NewServer(endpoint)
+	defer srv.Close()
+
+	h := New(Config{
+		Enabled:   true,
+		Endpoint:  srv.URL,
+		BatchSize: 3,
+		Compress:  true,
+		Transport: http.DefaultTransport,
+		LogOnce:   testLogOnce,
+	})
+	if err := h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := h.Send(map[string]int{"entry": i}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	requests := endpoint.waitRequests(t, 1)
+	want := "{\"entry\":0}\n{\"entry\":1}\n{\"entry\":2}"
+	if string(requests[0]) != want {
+		t.Fatalf("expected batch %q, got %q", want, requests[0])
+	}
+}
+
+func TestTargetQueueStore(t *testing.T) {
+	endpoint := &testEndpoint{}
+	srv := httptest.NewServer(endpoint)
+	defer srv.Close()
+
+	queueDir, err := ioutil.TempDir("", "audit-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(queueDir)
+
+	h := New(Config{
+		Enabled:   true,
+		Endpoint:  srv.URL,
+		BatchSize: 1,
+		QueueDir:  queueDir,
+		Transport: http.DefaultTransport,
+		LogOnce:   testLogOnce,
+	})
+	if err = h.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries which cannot be delivered are spilled over to disk.
+	atomic.StoreInt32(&endpoint.failing, 1)
+	if err = h.Send(map[string]string{"entry": "queued"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		names, err := h.store.list()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(names) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected log entry in queue directory")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// And sent once the endpoint is back online.
+	atomic.StoreInt32(&endpoint.failing, 0)
+	requests := endpoint.waitRequests(t, 1)
+	if string(requests[0]) != `{"entry":"queued"}` {
+		t.Fatalf("unexpected log entry %q", requests[0])
+	}
+}
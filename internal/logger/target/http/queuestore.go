@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Default maximum number of log entries held in a queue directory.
+	defaultQueueSize = 100000
+
+	queueStoreExt = ".log"
+)
+
+var errQueueStoreFull = errors.New("log queue directory is full")
+
+// queueStore holds log entries on disk, one file per entry. The file
+// names sort in the order the entries were stored.
+type queueStore struct {
+	sync.Mutex
+	dir   string
+	limit int
+	count int
+	seq   uint64
+}
+
+// newQueueStore opens the queue directory dir, creating it if needed.
+func newQueueStore(dir string, limit int) (*queueStore, error) {
+	if limit <= 0 {
+		limit = defaultQueueSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &queueStore{dir: dir, limit: limit}
+	names, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	s.count = len(names)
+	return s, nil
+}
+
+// put stores the JSON encoded log entry.
+func (s *queueStore) put(logJSON []byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.count >= s.limit {
+		return errQueueStoreFull
+	}
+	s.seq++
+	name := fmt.Sprintf("%020d-%06d%s", time.Now().UnixNano(), s.seq%1000000, queueStoreExt)
+	if err := ioutil.WriteFile(filepath.Join(s.dir, name), logJSON, 0o644); err != nil {
+		return err
+	}
+	s.count++
+	return nil
+}
+
+// get returns the JSON encoded log entry stored as name.
+func (s *queueStore) get(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.dir, name))
+}
+
+// del removes the log entry stored as name.
+func (s *queueStore) del(name string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := os.Remove(filepath.Join(s.dir, name)); err == nil {
+		s.count--
+	}
+}
+
+// list returns the names of all stored log entries, oldest first.
+func (s *queueStore) list() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), queueStoreExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
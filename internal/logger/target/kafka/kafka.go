@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"time"
 
 	sarama "github.com/Shopify/sarama"
 	saramatls "github.com/Shopify/sarama/tools/tls"
@@ -32,6 +33,9 @@ import (
 	xnet "github.com/minio/pkg/net"
 )
 
+// Maximum time log entries wait for a batch to fill up.
+const kafkaBatchInterval = time.Second
+
 // Target - Kafka target.
 type Target struct {
 	// Channel of log entries
@@ -57,27 +61,50 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 
 func (h *Target) startKakfaLogger() {
 	// Create a routine which sends json logs received
-	// from an internal channel.
+	// from an internal channel in batches.
 	go func() {
-		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
-			}
+		batchSize := h.kconfig.BatchSize
+		if batchSize <= 0 {
+			batchSize = 1
+		}
+		batch := make([]*sarama.ProducerMessage, 0, batchSize)
+		ticker := time.NewTicker(kafkaBatchInterval)
+		defer ticker.Stop()
 
-			ae, ok := entry.(audit.Entry)
-			if ok {
-				msg := sarama.ProducerMessage{
-					Topic: h.kconfig.Topic,
-					Key:   sarama.StringEncoder(ae.RequestID),
-					Value: sarama.ByteEncoder(logJSON),
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			if err := h.producer.SendMessages(batch); err != nil {
+				h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
+			}
+			batch = make([]*sarama.ProducerMessage, 0, batchSize)
+		}
+		for {
+			select {
+			case entry, ok := <-h.logCh:
+				if !ok {
+					flush()
+					return
 				}
-
-				_, _, err = h.producer.SendMessage(&msg)
+				ae, ok := entry.(audit.Entry)
+				if !ok {
+					continue
+				}
+				logJSON, err := json.Marshal(&entry)
 				if err != nil {
-					h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
 					continue
 				}
+				batch = append(batch, &sarama.ProducerMessage{
+					Topic: h.kconfig.Topic,
+					Key:   sarama.StringEncoder(ae.RequestID),
+					Value: sarama.ByteEncoder(logJSON),
+				})
+				if len(batch) >= batchSize {
+					flush()
+				}
+			case <-ticker.C:
+				flush()
 			}
 		}
 	}()
@@ -89,7 +116,10 @@ type Config struct {
 	Brokers []xnet.Host `json:"brokers"`
 	Topic   string      `json:"topic"`
 	Version string      `json:"version"`
-	TLS     struct {
+	// Number of log entries sent to the brokers at once.
+	BatchSize int  `json:"batchSize"`
+	Compress  bool `json:"compress"`
+	TLS       struct {
 		Enable        bool               `json:"enable"`
 		RootCAs       *x509.CertPool     `json:"-"`
 		SkipVerify    bool               `json:"skipVerify"`
@@ -178,6 +208,9 @@ func (h *Target) Init() error {
 	sconfig.Producer.RequiredAcks = sarama.WaitForAll
 	sconfig.Producer.Retry.Max = 10
 	sconfig.Producer.Return.Successes = true
+	if h.kconfig.Compress {
+		sconfig.Producer.Compression = sarama.CompressionGZIP
+	}
 
 	h.config = sconfig
 
@@ -32,6 +32,7 @@ import (
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	xnet "github.com/minio/pkg/net"
+	"google.golang.org/grpc"
 )
 
 // DefaultTimeout - default REST timeout is 10 seconds.
@@ -102,6 +103,12 @@ type Client struct {
 	// Avoid metrics update if set to true
 	NoMetrics bool
 
+	// GRPCConn if set sends all calls over this connection instead of
+	// HTTP, the remote end must serve them with NewGRPCServer. The
+	// connection must be dialed with GRPCDialOptions and may be shared
+	// by all clients of the same host.
+	GRPCConn *grpc.ClientConn
+
 	httpClient   *http.Client
 	url          *url.URL
 	newAuthToken func(audience string) string
@@ -127,6 +134,9 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
+	if c.GRPCConn != nil {
+		return c.callGRPC(ctx, method, values, body, length)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String()+method+querySep+values.Encode(), body)
 	if err != nil {
 		return nil, &NetworkError{err}
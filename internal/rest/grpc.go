@@ -0,0 +1,482 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/tinylib/msgp/msgp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Internode calls over gRPC are tunneled through a single bi-directional
+// stream per call. Streams of all calls to a node share one HTTP/2
+// connection, so a slow call does not hold up the ones queued behind it,
+// and the caller's context deadline travels with the stream to the server.
+const (
+	grpcServiceName = "minio.internode.Transport"
+	grpcCallMethod  = "/" + grpcServiceName + "/Call"
+
+	// grpcChunkSize is the largest body slice sent in a single frame,
+	// well below the default 4MiB gRPC message limit.
+	grpcChunkSize = 1 << 20
+)
+
+var grpcStreamDesc = grpc.StreamDesc{
+	StreamName:    "Call",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// grpcFrame is a single message on an internode gRPC stream.
+// The first frame sent by the client carries the request path, query
+// and headers, the first frame sent by the server carries the response
+// status. All following frames carry body data, the last frame sent by
+// the server may carry the final status trailer.
+type grpcFrame struct {
+	Path    string
+	Query   string
+	Header  map[string]string
+	Length  int64
+	Status  int
+	Trailer string
+	Data    []byte
+}
+
+const grpcFrameFields = 7
+
+func (f *grpcFrame) marshal(b []byte) []byte {
+	b = msgp.AppendArrayHeader(b, grpcFrameFields)
+	b = msgp.AppendString(b, f.Path)
+	b = msgp.AppendString(b, f.Query)
+	b = msgp.AppendMapHeader(b, uint32(len(f.Header)))
+	for k, v := range f.Header {
+		b = msgp.AppendString(b, k)
+		b = msgp.AppendString(b, v)
+	}
+	b = msgp.AppendInt64(b, f.Length)
+	b = msgp.AppendInt(b, f.Status)
+	b = msgp.AppendString(b, f.Trailer)
+	return msgp.AppendBytes(b, f.Data)
+}
+
+func (f *grpcFrame) unmarshal(b []byte) (err error) {
+	var n uint32
+	if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+		return err
+	}
+	if n != grpcFrameFields {
+		return msgp.ArrayError{Wanted: grpcFrameFields, Got: n}
+	}
+	if f.Path, b, err = msgp.ReadStringBytes(b); err != nil {
+		return err
+	}
+	if f.Query, b, err = msgp.ReadStringBytes(b); err != nil {
+		return err
+	}
+	if n, b, err = msgp.ReadMapHeaderBytes(b); err != nil {
+		return err
+	}
+	if n > 0 {
+		f.Header = make(map[string]string, n)
+	}
+	for i := uint32(0); i < n; i++ {
+		var k, v string
+		if k, b, err = msgp.ReadStringBytes(b); err != nil {
+			return err
+		}
+		if v, b, err = msgp.ReadStringBytes(b); err != nil {
+			return err
+		}
+		f.Header[k] = v
+	}
+	if f.Length, b, err = msgp.ReadInt64Bytes(b); err != nil {
+		return err
+	}
+	if f.Status, b, err = msgp.ReadIntBytes(b); err != nil {
+		return err
+	}
+	if f.Trailer, b, err = msgp.ReadStringBytes(b); err != nil {
+		return err
+	}
+	f.Data, _, err = msgp.ReadBytesBytes(b, nil)
+	return err
+}
+
+// grpcCodec encodes grpcFrame messages, it replaces the
+// default protobuf codec on both ends of internode streams.
+type grpcCodec struct{}
+
+func (grpcCodec) Name() string { return "minio-internode" }
+
+func (grpcCodec) Marshal(v interface{}) ([]byte, error) {
+	f, ok := v.(*grpcFrame)
+	if !ok {
+		return nil, fmt.Errorf("rest: unexpected gRPC message type %T", v)
+	}
+	return f.marshal(nil), nil
+}
+
+func (grpcCodec) Unmarshal(data []byte, v interface{}) error {
+	f, ok := v.(*grpcFrame)
+	if !ok {
+		return fmt.Errorf("rest: unexpected gRPC message type %T", v)
+	}
+	return f.unmarshal(data)
+}
+
+// GRPCDialOptions returns the dial options which must be used for
+// connections assigned to Client.GRPCConn.
+func GRPCDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(grpcCodec{})),
+	}
+}
+
+// grpcError converts the status of a failed gRPC call back to
+// the context errors the callers of Client.Call check for.
+func grpcError(err error) error {
+	switch status.Code(err) {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// callGRPC is Call over c.GRPCConn.
+func (c *Client) callGRPC(ctx context.Context, method string, values url.Values, body io.Reader, length int64) (reply io.ReadCloser, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	stream, err := c.GRPCConn.NewStream(ctx, &grpcStreamDesc, grpcCallMethod)
+	if err != nil {
+		return nil, c.grpcNetworkError(ctx, err)
+	}
+
+	query := values.Encode()
+	req := &grpcFrame{
+		Path:  c.url.EscapedPath() + method,
+		Query: query,
+		Header: map[string]string{
+			"Authorization": "Bearer " + c.newAuthToken(query),
+			"X-Minio-Time":  time.Now().UTC().Format(time.RFC3339),
+		},
+		Length: -1,
+	}
+	switch v := body.(type) {
+	case nil:
+		req.Length = 0
+	case *bytes.Buffer:
+		// Same as http.NewRequest, handlers may
+		// rely on the content length being set.
+		req.Length = int64(v.Len())
+	case *bytes.Reader:
+		req.Length = int64(v.Len())
+	case *strings.Reader:
+		req.Length = int64(v.Len())
+	}
+	if length > 0 {
+		req.Length = length
+	}
+	if err = stream.SendMsg(req); err != nil {
+		return nil, c.grpcNetworkError(ctx, err)
+	}
+
+	if body == nil {
+		stream.CloseSend()
+	} else {
+		go func() {
+			buf := make([]byte, grpcChunkSize)
+			for {
+				n, rerr := body.Read(buf)
+				if n > 0 {
+					if err := stream.SendMsg(&grpcFrame{Data: buf[:n]}); err != nil {
+						return
+					}
+				}
+				if rerr == io.EOF {
+					stream.CloseSend()
+					return
+				}
+				if rerr != nil {
+					// Abort the call, the server must not
+					// mistake a failed upload for a short one.
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	var resp grpcFrame
+	if err = stream.RecvMsg(&resp); err != nil {
+		return nil, c.grpcNetworkError(ctx, err)
+	}
+
+	r := &grpcReader{stream: stream, cancel: cancel}
+	if resp.Status != http.StatusOK {
+		// Same as for HTTP, see the comment in Call.
+		if c.HealthCheckFn != nil && resp.Status == http.StatusPreconditionFailed {
+			logger.LogIf(ctx, fmt.Errorf("Marking %s temporary offline; caused by PreconditionFailed with disk ID mismatch", c.url.String()))
+			c.MarkOffline()
+		}
+		defer r.Close()
+		b, err := ioutil.ReadAll(io.LimitReader(r, c.MaxErrResponseSize))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > 0 {
+			return nil, errors.New(string(b))
+		}
+		return nil, fmt.Errorf("%d %s", resp.Status, http.StatusText(resp.Status))
+	}
+	return r, nil
+}
+
+// grpcNetworkError wraps an error which prevented a call from reaching the
+// remote handler and takes the client offline if the remote is unreachable.
+func (c *Client) grpcNetworkError(ctx context.Context, err error) error {
+	code := status.Code(err)
+	if code == codes.Unavailable || (code == codes.DeadlineExceeded && !c.ExpectTimeouts) {
+		if !c.NoMetrics {
+			atomic.AddUint64(&networkErrsCounter, 1)
+		}
+		if c.MarkOffline() {
+			logger.LogIf(ctx, fmt.Errorf("Marking %s temporary offline; caused by %w", c.url.String(), err))
+		}
+	}
+	return &NetworkError{grpcError(err)}
+}
+
+// grpcReader reads the response body frames of a call.
+type grpcReader struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	buf    []byte
+	err    error
+}
+
+func (r *grpcReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		var f grpcFrame
+		if err := r.stream.RecvMsg(&f); err != nil {
+			if err == io.EOF {
+				r.err = io.EOF
+			} else {
+				r.err = grpcError(err)
+			}
+			continue
+		}
+		if f.Trailer != "" && f.Trailer != "Success" {
+			r.err = errors.New(f.Trailer)
+		}
+		r.buf = f.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close aborts the call if the body was not read until EOF.
+func (r *grpcReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// NewGRPCServer returns a gRPC server for calls made by clients with
+// GRPCConn set. Every call is served by handler as if it had been made
+// over HTTP, calls to paths outside of the given prefixes are refused.
+func NewGRPCServer(handler http.Handler, prefixes []string, opts ...grpc.ServerOption) *grpc.Server {
+	h := &grpcHandler{handler: handler, prefixes: prefixes}
+	s := grpc.NewServer(append(opts, grpc.ForceServerCodec(grpcCodec{}))...)
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: grpcServiceName,
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    grpcStreamDesc.StreamName,
+			Handler:       h.serve,
+			ServerStreams: true,
+			ClientStreams: true,
+		}},
+	}, struct{}{})
+	return s
+}
+
+type grpcHandler struct {
+	handler  http.Handler
+	prefixes []string
+}
+
+func (h *grpcHandler) allowed(path string) bool {
+	for _, prefix := range h.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *grpcHandler) serve(_ interface{}, stream grpc.ServerStream) error {
+	var f grpcFrame
+	if err := stream.RecvMsg(&f); err != nil {
+		return err
+	}
+	u, err := url.Parse(f.Path + querySep + f.Query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := stream.Context()
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			var f grpcFrame
+			if err := stream.RecvMsg(&f); err != nil {
+				if err == io.EOF {
+					pw.Close()
+				} else {
+					pw.CloseWithError(grpcError(err))
+				}
+				return
+			}
+			if _, err := pw.Write(f.Data); err != nil {
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+
+	req := (&http.Request{
+		Method:        http.MethodPost,
+		URL:           u,
+		RequestURI:    u.RequestURI(),
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        make(http.Header, len(f.Header)),
+		Body:          pr,
+		ContentLength: f.Length,
+	}).WithContext(ctx)
+	for k, v := range f.Header {
+		req.Header.Set(k, v)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	w := &grpcResponseWriter{stream: stream, header: make(http.Header)}
+	if h.allowed(u.Path) {
+		h.handler.ServeHTTP(w, req)
+	} else {
+		http.NotFound(w, req)
+	}
+	return w.finish()
+}
+
+// grpcResponseWriter sends the response of a handler as frames.
+type grpcResponseWriter struct {
+	mu     sync.Mutex
+	stream grpc.ServerStream
+	header http.Header
+	status int
+	err    error
+}
+
+func (w *grpcResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *grpcResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(code)
+}
+
+func (w *grpcResponseWriter) writeHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	w.send(&grpcFrame{Status: code})
+}
+
+func (w *grpcResponseWriter) send(f *grpcFrame) {
+	if w.err == nil {
+		w.err = w.stream.SendMsg(f)
+	}
+}
+
+func (w *grpcResponseWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+	for len(p) > 0 && w.err == nil {
+		chunk := p
+		if len(chunk) > grpcChunkSize {
+			chunk = chunk[:grpcChunkSize]
+		}
+		w.send(&grpcFrame{Data: chunk})
+		if w.err == nil {
+			n += len(chunk)
+		}
+		p = p[len(chunk):]
+	}
+	return n, w.err
+}
+
+// Flush implements http.Flusher, frames are sent as they are written.
+func (w *grpcResponseWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+}
+
+// finish sends the status of handlers which did not write any
+// response and the trailer announced in the "Trailer" header.
+func (w *grpcResponseWriter) finish() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+	if trailer := w.header.Get("Trailer"); trailer != "" {
+		w.send(&grpcFrame{Trailer: w.header.Get(trailer)})
+	}
+	return w.err
+}
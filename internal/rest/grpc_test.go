@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func newTestGRPCClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewGRPCServer(handler, []string{"/minio/test/"})
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), append(GRPCDialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	u := &url.URL{Scheme: "http", Host: l.Addr().String(), Path: "/minio/test/v1"}
+	c := NewClient(u, nil, func(aud string) string { return "token-" + aud })
+	c.GRPCConn = conn
+	return c
+}
+
+func TestClientCallGRPC(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/minio/test/v1/echo", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token-"+r.URL.RawQuery {
+			http.Error(w, "unexpected token "+got, http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Minio-Time") == "" {
+			http.Error(w, "missing time", http.StatusBadRequest)
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.ContentLength != int64(len(b)) {
+			http.Error(w, "content length mismatch", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(r.URL.Query().Get("prefix")))
+		w.Write(b)
+	})
+	mux.HandleFunc("/minio/test/v1/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "file not found", http.StatusForbidden)
+	})
+	mux.HandleFunc("/minio/test/v1/trailer", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "FinalStatus")
+		w.Write([]byte("data"))
+		w.Header().Set("FinalStatus", "short read")
+	})
+	mux.HandleFunc("/minio/test/v1/deadline", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			http.Error(w, "no deadline", http.StatusBadRequest)
+			return
+		}
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/minio/other/v1/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not reachable"))
+	})
+	c := newTestGRPCClient(t, mux)
+
+	// Larger than a single frame.
+	body := bytes.Repeat([]byte("abcdefgh"), grpcChunkSize/4+3)
+	rc, err := c.Call(context.Background(), "/echo", url.Values{"prefix": []string{"x/"}}, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte("x/"), body...); !bytes.Equal(got, want) {
+		t.Fatalf("echo: got %d bytes, want %d", len(got), len(want))
+	}
+
+	if _, err = c.Call(context.Background(), "/error", nil, nil, -1); err == nil || err.Error() != "file not found\n" {
+		t.Fatalf("error: unexpected error %v", err)
+	}
+
+	rc, err = c.Call(context.Background(), "/trailer", nil, nil, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = ioutil.ReadAll(rc)
+	rc.Close()
+	if string(got) != "data" || err == nil || err.Error() != "short read" {
+		t.Fatalf("trailer: got %q, %v", got, err)
+	}
+
+	c.ExpectTimeouts = true
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err = c.Call(ctx, "/deadline", nil, nil, -1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("deadline: unexpected error %v", err)
+	}
+
+	c.url.Path = "/minio/other/v1"
+	if _, err = c.Call(context.Background(), "/echo", nil, nil, -1); err == nil || err.Error() != "404 page not found\n" {
+		t.Fatalf("prefix: unexpected error %v", err)
+	}
+	if !c.IsOnline() {
+		t.Fatal("client must stay online")
+	}
+}
+
+func TestClientCallGRPCOffline(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	conn, err := grpc.Dial(addr, append(GRPCDialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	c := NewClient(&url.URL{Scheme: "http", Host: addr, Path: "/minio/test/v1"}, nil, func(string) string { return "" })
+	c.GRPCConn = conn
+	c.HealthCheckFn = func() bool { return false }
+	c.HealthCheckInterval = time.Hour
+	defer c.Close()
+
+	_, err = c.Call(context.Background(), "/echo", nil, nil, -1)
+	var nerr *NetworkError
+	if !errors.As(err, &nerr) {
+		t.Fatalf("expected network error, got %v", err)
+	}
+	if c.IsOnline() {
+		t.Fatal("client must be marked offline")
+	}
+}
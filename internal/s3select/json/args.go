@@ -94,3 +94,28 @@ func (args *WriterArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) err
 	args.unmarshaled = true
 	return nil
 }
+
+// LinesWriterArgs - represents elements inside <OutputSerialization><JSONLines/> in request XML.
+// Records are written as JSON Lines, one JSON object per line.
+type LinesWriterArgs struct {
+	unmarshaled bool
+}
+
+// IsEmpty - returns whether writer args is empty or not.
+func (args *LinesWriterArgs) IsEmpty() bool {
+	return !args.unmarshaled
+}
+
+// UnmarshalXML - decodes XML data.
+func (args *LinesWriterArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type subLinesWriterArgs LinesWriterArgs
+	parsedArgs := subLinesWriterArgs{}
+	if err := d.DecodeElement(&parsedArgs, &start); err != nil {
+		return err
+	}
+
+	*args = LinesWriterArgs(parsedArgs)
+	args.unmarshaled = true
+	return nil
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/s3select/sql"
+	parquetgen "github.com/minio/parquet-go/gen-go/parquet"
+)
+
+// Length of the parquet file trailer, footer length followed by
+// the "PAR1" magic.
+const trailerLen = 8
+
+var errInvalidFooter = errors.New("parquet: invalid footer length")
+
+// footer is the file metadata of a parquet object. It is read once
+// before handing the object to the parquet reader, so that row groups
+// and columns not needed by a query are never fetched.
+type footer struct {
+	meta *parquetgen.FileMetaData
+
+	// Serialized metadata followed by the file trailer, served to
+	// the parquet reader in place of the end of the object.
+	tail []byte
+}
+
+// readFooter reads the file metadata of the object.
+func readFooter(getReaderFunc func(offset, length int64) (io.ReadCloser, error)) (*footer, error) {
+	trailer, err := readRange(getReaderFunc, -trailerLen, trailerLen)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(binary.LittleEndian.Uint32(trailer))
+	if size <= 0 {
+		return nil, errInvalidFooter
+	}
+	raw, err := readRange(getReaderFunc, -(trailerLen + size), size)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := parquetgen.NewFileMetaData()
+	protocol := thrift.NewTCompactProtocolFactory().GetProtocol(thrift.NewStreamTransportR(bytes.NewReader(raw)))
+	if err = meta.Read(context.Background(), protocol); err != nil {
+		return nil, err
+	}
+	return &footer{meta: meta, tail: append(raw, trailer...)}, nil
+}
+
+// readRange reads length bytes of the object at offset.
+func readRange(getReaderFunc func(offset, length int64) (io.ReadCloser, error), offset, length int64) ([]byte, error) {
+	rc, err := getReaderFunc(offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	buf := make([]byte, length)
+	if _, err = io.ReadFull(rc, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// getReaderFunc returns a reader function serving the end of the
+// object from the footer and everything else from the object.
+func (f *footer) getReaderFunc(getReaderFunc func(offset, length int64) (io.ReadCloser, error)) func(offset, length int64) (io.ReadCloser, error) {
+	return func(offset, length int64) (io.ReadCloser, error) {
+		if offset >= 0 {
+			return getReaderFunc(offset, length)
+		}
+		start := int64(len(f.tail)) + offset
+		if start < 0 || length < 0 || start+length > int64(len(f.tail)) {
+			return nil, errInvalidFooter
+		}
+		return ioutil.NopCloser(bytes.NewReader(f.tail[start : start+length])), nil
+	}
+}
+
+// pruneRowGroups drops the row groups whose column statistics show
+// that none of their rows can satisfy all compares.
+func (f *footer) pruneRowGroups(compares []sql.ColumnCompare) error {
+	if len(compares) == 0 {
+		return nil
+	}
+
+	rowGroups := make([]*parquetgen.RowGroup, 0, len(f.meta.RowGroups))
+	var numRows int64
+	for _, rowGroup := range f.meta.RowGroups {
+		if f.rowGroupMayMatch(rowGroup, compares) {
+			rowGroups = append(rowGroups, rowGroup)
+			numRows += rowGroup.GetNumRows()
+		}
+	}
+	if len(rowGroups) == len(f.meta.RowGroups) {
+		return nil
+	}
+	f.meta.RowGroups = rowGroups
+	f.meta.NumRows = numRows
+
+	buf := thrift.NewTMemoryBuffer()
+	protocol := thrift.NewTCompactProtocolFactory().GetProtocol(buf)
+	if err := f.meta.Write(context.Background(), protocol); err != nil {
+		return err
+	}
+	if err := protocol.Flush(context.Background()); err != nil {
+		return err
+	}
+	raw := buf.Bytes()
+	f.tail = make([]byte, len(raw)+trailerLen)
+	copy(f.tail, raw)
+	binary.LittleEndian.PutUint32(f.tail[len(raw):], uint32(len(raw)))
+	copy(f.tail[len(raw)+4:], "PAR1")
+	return nil
+}
+
+// columnNames returns the parquet columns holding the top level columns
+// referenced by stmt, nil when all columns must be read.
+func (f *footer) columnNames(stmt *sql.SelectStatement) set.StringSet {
+	columns, all := stmt.Columns()
+	if all || len(f.meta.RowGroups) == 0 {
+		return nil
+	}
+
+	names := set.NewStringSet()
+	var first string
+	for _, chunk := range f.meta.RowGroups[0].GetColumns() {
+		meta := chunk.GetMetaData()
+		if meta == nil || len(meta.GetPathInSchema()) == 0 {
+			return nil
+		}
+		path := meta.GetPathInSchema()
+		if first == "" && len(path) == 1 {
+			first = path[0]
+		}
+		for _, column := range columns {
+			if path[0] == column {
+				names.Add(strings.Join(path, "."))
+			}
+		}
+	}
+	if names.IsEmpty() {
+		// Queries such as `SELECT COUNT(*)` reference no column, read
+		// a single one to count the rows.
+		if first == "" {
+			return nil
+		}
+		names.Add(first)
+	}
+	return names
+}
+
+// rowGroupMayMatch returns false when the column statistics of the row
+// group show that none of its rows can satisfy all compares.
+func (f *footer) rowGroupMayMatch(rowGroup *parquetgen.RowGroup, compares []sql.ColumnCompare) bool {
+	for _, compare := range compares {
+		for _, chunk := range rowGroup.GetColumns() {
+			meta := chunk.GetMetaData()
+			if meta == nil || len(meta.GetPathInSchema()) != 1 || meta.PathInSchema[0] != compare.Column {
+				continue
+			}
+			min, max, ok := f.statsRange(meta)
+			if !ok {
+				break
+			}
+			cmpMin, ok1 := compareValues(min, compare.Value)
+			cmpMax, ok2 := compareValues(max, compare.Value)
+			if !ok1 || !ok2 {
+				break
+			}
+			if !rangeMayMatch(compare.Operator, cmpMin, cmpMax) {
+				return false
+			}
+			break
+		}
+	}
+	return true
+}
+
+// statsRange returns the minimum and maximum values of a column chunk,
+// ok is false when they are not known or do not order like the values
+// returned to S3 Select.
+func (f *footer) statsRange(meta *parquetgen.ColumnMetaData) (min, max *sql.Value, ok bool) {
+	stats := meta.GetStatistics()
+	if stats == nil {
+		return nil, nil, false
+	}
+
+	// Only plain and signed integer and UTF-8 columns are compared,
+	// dates, timestamps and decimals are formatted differently.
+	for _, se := range f.meta.GetSchema() {
+		if se.GetName() != meta.PathInSchema[0] || se.GetNumChildren() > 0 {
+			continue
+		}
+		if se.ConvertedType != nil {
+			switch se.GetConvertedType() {
+			case parquetgen.ConvertedType_UTF8, parquetgen.ConvertedType_INT_8, parquetgen.ConvertedType_INT_16,
+				parquetgen.ConvertedType_INT_32, parquetgen.ConvertedType_INT_64:
+			default:
+				return nil, nil, false
+			}
+		}
+		break
+	}
+
+	// The deprecated min and max use signed byte ordering, which
+	// only holds for numbers.
+	minRaw, maxRaw := stats.GetMinValue(), stats.GetMaxValue()
+	if meta.GetType() != parquetgen.Type_BYTE_ARRAY && (len(minRaw) == 0 || len(maxRaw) == 0) {
+		minRaw, maxRaw = stats.GetMin(), stats.GetMax()
+	}
+
+	decode := func(b []byte) *sql.Value {
+		switch meta.GetType() {
+		case parquetgen.Type_INT32:
+			if len(b) == 4 {
+				return sql.FromInt(int64(int32(binary.LittleEndian.Uint32(b))))
+			}
+		case parquetgen.Type_INT64:
+			if len(b) == 8 {
+				return sql.FromInt(int64(binary.LittleEndian.Uint64(b)))
+			}
+		case parquetgen.Type_FLOAT:
+			if len(b) == 4 {
+				if v := float64(math.Float32frombits(binary.LittleEndian.Uint32(b))); !math.IsNaN(v) {
+					return sql.FromFloat(v)
+				}
+			}
+		case parquetgen.Type_DOUBLE:
+			if len(b) == 8 {
+				if v := math.Float64frombits(binary.LittleEndian.Uint64(b)); !math.IsNaN(v) {
+					return sql.FromFloat(v)
+				}
+			}
+		case parquetgen.Type_BYTE_ARRAY:
+			if b != nil {
+				return sql.FromString(string(b))
+			}
+		}
+		return nil
+	}
+
+	if min, max = decode(minRaw), decode(maxRaw); min == nil || max == nil {
+		return nil, nil, false
+	}
+	return min, max, true
+}
+
+// compareValues compares two numbers or two strings, ok is false for
+// values of any other types.
+func compareValues(a, b *sql.Value) (cmp int, ok bool) {
+	if x, ok := a.ToInt(); ok {
+		if y, ok := b.ToInt(); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if x, ok := a.ToFloat(); ok {
+		if y, ok := b.ToFloat(); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if x, ok := a.ToString(); ok {
+		if y, ok := b.ToString(); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+	return 0, false
+}
+
+// rangeMayMatch returns whether a value between min and max may satisfy
+// `value op literal`, where cmpMin and cmpMax are the results of
+// comparing min and max with the literal.
+func rangeMayMatch(op string, cmpMin, cmpMax int) bool {
+	switch op {
+	case "=":
+		return cmpMin <= 0 && cmpMax >= 0
+	case "<":
+		return cmpMin < 0
+	case "<=":
+		return cmpMin <= 0
+	case ">":
+		return cmpMax > 0
+	case ">=":
+		return cmpMax >= 0
+	}
+	return true
+}
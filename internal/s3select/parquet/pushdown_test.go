@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	jsonfmt "github.com/minio/minio/internal/s3select/json"
+	"github.com/minio/minio/internal/s3select/sql"
+)
+
+func testdataReaderFunc() func(offset, length int64) (io.ReadCloser, error) {
+	return func(offset, length int64) (io.ReadCloser, error) {
+		file, err := os.Open("../testdata/testdata.parquet")
+		if err != nil {
+			return nil, err
+		}
+		fi, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if offset < 0 {
+			offset = fi.Size() + offset
+		}
+		if _, err = file.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return file, nil
+	}
+}
+
+func TestReaderPushdown(t *testing.T) {
+	testCases := []struct {
+		query   string
+		columns []string
+		records int
+	}{
+		{"SELECT * FROM S3Object", nil, 3},
+		{"SELECT one, two FROM S3Object", []string{"one", "two"}, 3},
+		{"SELECT s.two FROM S3Object s WHERE s.one > 0", []string{"one", "two"}, 3},
+		{"SELECT COUNT(*) FROM S3Object", []string{"one"}, 3},
+		{"SELECT two FROM S3Object WHERE one > 2.5", []string{"one", "two"}, 0},
+		{"SELECT two FROM S3Object WHERE 2.5 <= one", []string{"one", "two"}, 3},
+		{"SELECT two FROM S3Object WHERE one BETWEEN 3 AND 4", []string{"one", "two"}, 0},
+		{"SELECT one FROM S3Object WHERE two = 'zzz'", []string{"one", "two"}, 0},
+		{"SELECT one FROM S3Object WHERE two < 'bar'", []string{"one", "two"}, 0},
+		{"SELECT one FROM S3Object WHERE two = 'baz'", []string{"one", "two"}, 3},
+		{"SELECT one FROM S3Object WHERE two = 'zzz' OR one > 0", []string{"one", "two"}, 3},
+		{"SELECT one FROM S3Object WHERE two = 5", []string{"one", "two"}, 3},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			stmt, err := sql.ParseSelectStatement(testCase.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := NewReader(testdataReaderFunc(), &ReaderArgs{}, &stmt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			var records int
+			for {
+				rec, err := r.Read(nil)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				records++
+				var columns []string
+				for _, kv := range rec.(*jsonfmt.Record).KVS {
+					columns = append(columns, kv.Key)
+				}
+				if testCase.columns != nil && !reflect.DeepEqual(columns, testCase.columns) {
+					t.Errorf("expected columns %v, got %v", testCase.columns, columns)
+				}
+			}
+			if records != testCase.records {
+				t.Errorf("expected %d records, got %d", testCase.records, records)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/bcicen/jstream"
+	"github.com/minio/minio-go/v7/pkg/set"
 	jsonfmt "github.com/minio/minio/internal/s3select/json"
 	"github.com/minio/minio/internal/s3select/sql"
 	parquetgo "github.com/minio/parquet-go"
@@ -116,13 +117,32 @@ func (r *Reader) Close() error {
 }
 
 // NewReader - creates new Parquet reader using readerFunc callback.
-func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs) (r *Reader, err error) {
+// Only the columns referenced by stmt are read, and row groups whose
+// statistics show no row can pass its WHERE clause are skipped.
+func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs, stmt *sql.SelectStatement) (r *Reader, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("panic reading parquet header: %v", rec)
 		}
 	}()
-	reader, err := parquetgo.NewReader(getReaderFunc, nil)
+
+	var columnNames set.StringSet
+	if stmt != nil {
+		f, err := readFooter(getReaderFunc)
+		if err != nil {
+			if err != io.EOF {
+				return nil, errParquetParsingError(err)
+			}
+			return nil, err
+		}
+		if err = f.pruneRowGroups(stmt.ColumnCompares()); err != nil {
+			return nil, errParquetParsingError(err)
+		}
+		columnNames = f.columnNames(stmt)
+		getReaderFunc = f.getReaderFunc(getReaderFunc)
+	}
+
+	reader, err := parquetgo.NewReader(getReaderFunc, columnNames)
 	if err != nil {
 		if err != io.EOF {
 			return nil, errParquetParsingError(err)
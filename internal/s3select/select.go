@@ -51,9 +51,10 @@ type recordReader interface {
 }
 
 const (
-	csvFormat     = "csv"
-	jsonFormat    = "json"
-	parquetFormat = "parquet"
+	csvFormat       = "csv"
+	jsonFormat      = "json"
+	jsonLinesFormat = "jsonlines"
+	parquetFormat   = "parquet"
 )
 
 // CompressionType - represents value inside <CompressionType/> in request XML.
@@ -169,10 +170,11 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 
 // OutputSerialization - represents elements inside <OutputSerialization/> in request XML.
 type OutputSerialization struct {
-	CSVArgs     csv.WriterArgs  `xml:"CSV"`
-	JSONArgs    json.WriterArgs `xml:"JSON"`
-	unmarshaled bool
-	format      string
+	CSVArgs       csv.WriterArgs       `xml:"CSV"`
+	JSONArgs      json.WriterArgs      `xml:"JSON"`
+	JSONLinesArgs json.LinesWriterArgs `xml:"JSONLines"`
+	unmarshaled   bool
+	format        string
 }
 
 // IsEmpty - returns whether output serialization is empty or not.
@@ -198,8 +200,12 @@ func (output *OutputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartE
 		parsedOutput.format = jsonFormat
 		found++
 	}
+	if !parsedOutput.JSONLinesArgs.IsEmpty() {
+		parsedOutput.format = jsonLinesFormat
+		found++
+	}
 	if found != 1 {
-		return errObjectSerializationConflict(fmt.Errorf("either CSV or JSON should be present in OutputSerialization"))
+		return errObjectSerializationConflict(fmt.Errorf("one of CSV, JSON or JSONLines should be present in OutputSerialization"))
 	}
 
 	*output = OutputSerialization(parsedOutput)
@@ -282,7 +288,7 @@ func (s3Select *S3Select) outputRecord() sql.Record {
 	switch s3Select.Output.format {
 	case csvFormat:
 		return csv.NewRecord()
-	case jsonFormat:
+	case jsonFormat, jsonLinesFormat:
 		return json.NewRecord(sql.SelectFmtJSON)
 	}
 
@@ -348,7 +354,9 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 			return err
 		}
 
-		if strings.EqualFold(s3Select.Input.JSONArgs.ContentType, "lines") {
+		// With a LIMIT on the input the few records needed are read
+		// without the read-ahead of the parallel readers.
+		if strings.EqualFold(s3Select.Input.JSONArgs.ContentType, "lines") && s3Select.statement.InputLimit() < 0 {
 			if simdjson.SupportedCPU() {
 				s3Select.recordReader = simdj.NewReader(s3Select.progressReader, &s3Select.Input.JSONArgs)
 			} else {
@@ -365,7 +373,7 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 			return errors.New("parquet format parsing not enabled on server")
 		}
 		var err error
-		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs)
+		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs, s3Select.statement)
 		return err
 	}
 
@@ -414,6 +422,16 @@ func (s3Select *S3Select) marshal(buf *bytes.Buffer, record sql.Record) error {
 		}
 		buf.WriteString(s3Select.Output.JSONArgs.RecordDelimiter)
 
+		return nil
+	case jsonLinesFormat:
+		if err := record.WriteJSON(buf); err != nil {
+			return err
+		}
+		// Records are always on a single line.
+		if buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+
 		return nil
 	}
 
@@ -473,10 +491,19 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 		return true
 	}
 
+	// stopInput stops scanning the object once the LIMIT is reached,
+	// parallel readers may still be reading ahead.
+	stopInput := func() {
+		if s3Select.progressReader != nil {
+			s3Select.progressReader.Close()
+		}
+	}
+
 	var rec sql.Record
 OuterLoop:
 	for {
 		if s3Select.statement.LimitReached() {
+			stopInput()
 			if !sendRecord() {
 				break
 			}
@@ -549,6 +576,7 @@ OuterLoop:
 
 				outputQueue[len(outputQueue)-1] = outputRecord
 				if s3Select.statement.LimitReached() {
+					stopInput()
 					if !sendRecord() {
 						break
 					}
@@ -253,6 +253,34 @@ func TestJSONQueries(t *testing.T) {
 			query:      `SELECT * from s3object s WHERE title = 'Test Record'`,
 			wantResult: `{"id":0,"title":"Test Record","desc":"Some text","synonyms":["foo","bar","whatever"]}`,
 		},
+		{
+			name: "select-output-jsonlines-limit",
+			requestXML: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.id, s.title from s3object s LIMIT 2</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <JSON>
+            <Type>LINES</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <JSONLines/>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`),
+			wantResult: `{"id":0,"title":"Test Record"}
+{"id":1,"title":"Second Record"}`,
+		},
+		{
+			name:       "select-limit-where",
+			query:      `SELECT s.id from s3object s WHERE s.title = 'Second Record' LIMIT 2`,
+			wantResult: `{"id":1}
+{"id":2}`,
+		},
 		{
 			name: "select-output-field-as-csv",
 			requestXML: []byte(`<?xml version="1.0" encoding="UTF-8"?>
@@ -71,6 +71,19 @@ func (p *qProp) combine(q qProp) {
 	}
 }
 
+// addColumn records the top level column referenced by path.
+func (s *Select) addColumn(path *JSONPath) {
+	pathExpr := path.columnPath(s.From.As)
+	if len(pathExpr) == 0 || pathExpr[0].Key == nil {
+		s.allColumns = true
+		return
+	}
+	if s.columns == nil {
+		s.columns = make(map[string]struct{})
+	}
+	s.columns[pathExpr[0].Key.keyString()] = struct{}{}
+}
+
 func (e *SelectExpression) analyze(s *Select) (result qProp) {
 	if e.All {
 		return qProp{isRowFunc: true}
@@ -184,6 +197,7 @@ func (e *PrimaryTerm) analyze(s *Select) (result qProp) {
 				return
 			}
 		}
+		s.addColumn(e.JPathExpr)
 		result = qProp{isRowFunc: true}
 
 	case e.ListExpr != nil:
@@ -300,7 +314,7 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 		case e.Substring.From != nil:
 			result.combine(e.Substring.From.analyze(s))
 			if e.Substring.For != nil {
-				result.combine(e.Substring.Expr.analyze(s))
+				result.combine(e.Substring.For.analyze(s))
 			}
 		case e.Substring.Arg2 != nil:
 			result.combine(e.Substring.Arg2.analyze(s))
@@ -90,6 +90,12 @@ type Select struct {
 	From       *TableExpression  `parser:"\"FROM\" @@"`
 	Where      *Expression       `parser:"( \"WHERE\" @@ )?"`
 	Limit      *LitValue         `parser:"( \"LIMIT\" @@ )?"`
+
+	// Top level columns referenced by the statement, filled in by
+	// the query analysis. allColumns is set when a path does not
+	// name a column, such as `s.*` or `s[0]`.
+	columns    map[string]struct{}
+	allColumns bool
 }
 
 // SelectExpression represents the items requested in the select
@@ -20,6 +20,7 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bcicen/jstream"
@@ -332,6 +333,15 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 	return output, nil
 }
 
+// InputLimit - returns the number of input records after which the
+// statement produces no more output, -1 when any record may be needed.
+func (e *SelectStatement) InputLimit() int64 {
+	if e.limitValue < 0 || e.IsAggregated() || e.selectAST.Where != nil || e.selectAST.From.HasKeypath() {
+		return -1
+	}
+	return e.limitValue
+}
+
 // LimitReached - returns true if the number of records output has
 // reached the value of the `LIMIT` clause.
 func (e *SelectStatement) LimitReached() bool {
@@ -340,3 +350,106 @@ func (e *SelectStatement) LimitReached() bool {
 	}
 	return e.outputCount >= e.limitValue
 }
+
+// Columns returns the sorted top level columns referenced by the
+// statement, all is true when any column of a record may be used.
+func (e *SelectStatement) Columns() (columns []string, all bool) {
+	if e.selectAST.Expression.All || e.selectAST.allColumns || e.selectAST.From.HasKeypath() {
+		return nil, true
+	}
+	for column := range e.selectAST.columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns, false
+}
+
+// ColumnCompare is a comparison of a top level column against a
+// literal value, `Column Operator Value`.
+type ColumnCompare struct {
+	Column   string
+	Operator string
+	Value    *Value
+}
+
+// ColumnCompares returns the comparisons every record must satisfy
+// to pass the WHERE clause. Only comparisons in the top level
+// conjunction of the clause are returned, so a record satisfying
+// all of them may still be rejected by the clause.
+func (e *SelectStatement) ColumnCompares() (compares []ColumnCompare) {
+	where := e.selectAST.Where
+	if where == nil || len(where.And) != 1 || e.selectAST.From.HasKeypath() {
+		return nil
+	}
+	for _, cond := range where.And[0].Condition {
+		if cond.Operand == nil || cond.Operand.ConditionRHS == nil {
+			continue
+		}
+		left := cond.Operand.Operand.primaryTerm()
+		rhs := cond.Operand.ConditionRHS
+		switch {
+		case rhs.Compare != nil:
+			right := rhs.Compare.Operand.primaryTerm()
+			if c, ok := newColumnCompare(left, rhs.Compare.Operator, right, e.tableAlias); ok {
+				compares = append(compares, c)
+			} else if c, ok := newColumnCompare(right, flipCompareOperator(rhs.Compare.Operator), left, e.tableAlias); ok {
+				compares = append(compares, c)
+			}
+		case rhs.Between != nil && !rhs.Between.Not:
+			if c, ok := newColumnCompare(left, opGte, rhs.Between.Start.primaryTerm(), e.tableAlias); ok {
+				compares = append(compares, c)
+			}
+			if c, ok := newColumnCompare(left, opLte, rhs.Between.End.primaryTerm(), e.tableAlias); ok {
+				compares = append(compares, c)
+			}
+		}
+	}
+	return compares
+}
+
+// newColumnCompare returns the comparison of a column term against a
+// literal term, ok is false if the terms are of any other kind.
+func newColumnCompare(column *PrimaryTerm, op string, lit *PrimaryTerm, tableAlias string) (c ColumnCompare, ok bool) {
+	if column == nil || column.JPathExpr == nil || lit == nil || lit.Value == nil || lit.Value.Null {
+		return c, false
+	}
+	pathExpr := column.JPathExpr.columnPath(tableAlias)
+	if len(pathExpr) != 1 || pathExpr[0].Key == nil {
+		return c, false
+	}
+	switch op {
+	case opEq, opLt, opLte, opGt, opGte:
+	default:
+		return c, false
+	}
+	value, err := lit.Value.evalNode(nil)
+	if err != nil {
+		return c, false
+	}
+	return ColumnCompare{Column: pathExpr[0].Key.keyString(), Operator: op, Value: value}, true
+}
+
+// flipCompareOperator returns the operator comparing the operands of
+// op in reverse order.
+func flipCompareOperator(op string) string {
+	switch op {
+	case opLt:
+		return opGt
+	case opLte:
+		return opGte
+	case opGt:
+		return opLt
+	case opGte:
+		return opLte
+	}
+	return op
+}
+
+// primaryTerm returns the term of an operand made of a single primary
+// term, nil otherwise.
+func (e *Operand) primaryTerm() *PrimaryTerm {
+	if e == nil || len(e.Right) > 0 || len(e.Left.Right) > 0 {
+		return nil
+	}
+	return e.Left.Left.Primary
+}
@@ -58,6 +58,15 @@ func (e *JSONPath) StripTableAlias(tableAlias string) []*JSONPathElement {
 	return e.strippedPathExpr
 }
 
+// columnPath returns the path of e within a record, stripping the
+// table alias like query evaluation does.
+func (e *JSONPath) columnPath(tableAlias string) []*JSONPathElement {
+	if tableAlias == "" {
+		tableAlias = baseTableName
+	}
+	return e.StripTableAlias(tableAlias)
+}
+
 func (e *JSONPathElement) String() string {
 	switch {
 	case e.Key != nil:
@@ -0,0 +1,20 @@
+module github.com/minio/pkg
+
+go 1.16
+
+require (
+	github.com/cheggaaa/pb v1.0.29
+	github.com/fatih/color v1.12.0
+	github.com/fatih/structs v1.1.0
+	github.com/gorilla/mux v1.8.0
+	github.com/lestrrat-go/jwx v1.2.0
+	github.com/mattn/go-colorable v0.1.8
+	github.com/mattn/go-isatty v0.0.13
+	github.com/minio/madmin-go v1.0.12
+	github.com/minio/minio-go/v7 v7.0.11-0.20210302210017-6ae69c73ce78
+	github.com/montanaflynn/stats v0.6.6
+	github.com/rjeczalik/notify v0.9.2
+	go.etcd.io/etcd/client/v3 v3.5.0-beta.4
+	golang.org/x/sys v0.0.0-20210420072515-93ed5bcd2bfe
+	gopkg.in/yaml.v2 v2.4.0
+)
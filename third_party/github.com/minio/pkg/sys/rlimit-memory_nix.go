@@ -0,0 +1,40 @@
+//go:build linux || darwin || netbsd || solaris
+// +build linux darwin netbsd solaris
+
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import "syscall"
+
+// GetMaxMemoryLimit - returns the maximum size of the process's virtual memory (address space) in bytes.
+func GetMaxMemoryLimit() (curLimit, maxLimit uint64, err error) {
+	var rlimit syscall.Rlimit
+	if err = syscall.Getrlimit(syscall.RLIMIT_AS, &rlimit); err == nil {
+		curLimit = rlimit.Cur
+		maxLimit = rlimit.Max
+	}
+
+	return curLimit, maxLimit, err
+}
+
+// SetMaxMemoryLimit - sets the maximum size of the process's virtual memory (address space) in bytes.
+func SetMaxMemoryLimit(curLimit, maxLimit uint64) error {
+	rlimit := syscall.Rlimit{Cur: curLimit, Max: maxLimit}
+	return syscall.Setrlimit(syscall.RLIMIT_AS, &rlimit)
+}